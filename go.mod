@@ -3,9 +3,12 @@ module dekart
 go 1.14
 
 require (
+	cloud.google.com/go v0.64.0
 	cloud.google.com/go/bigquery v1.8.0
 	cloud.google.com/go/storage v1.10.0
+	github.com/DATA-DOG/go-sqlmock v1.5.0
 	github.com/desertbit/timer v0.0.0-20180107155436-c41aec40b27f // indirect
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang-migrate/migrate/v4 v4.14.1
 	github.com/golang/protobuf v1.4.3
 	github.com/google/uuid v1.1.2
@@ -14,9 +17,16 @@ require (
 	github.com/improbable-eng/grpc-web v0.13.0
 	github.com/lib/pq v1.9.0
 	github.com/mwitkow/go-conntrack v0.0.0-20190716064945-2f068394615f // indirect
+	github.com/prometheus/client_golang v1.7.1
 	github.com/rs/cors v1.7.0 // indirect
 	github.com/rs/zerolog v1.20.0
+	github.com/uber/h3-go/v3 v3.7.1
+	go.uber.org/goleak v1.1.10
+	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
+	golang.org/x/text v0.3.3
 	google.golang.org/api v0.30.0
+	google.golang.org/genproto v0.0.0-20201030142918-24207fddd1c3
 	google.golang.org/grpc v1.33.1
 	google.golang.org/protobuf v1.25.0
+	modernc.org/sqlite v1.10.8
 )
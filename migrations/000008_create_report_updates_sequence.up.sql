@@ -0,0 +1 @@
+CREATE SEQUENCE IF NOT EXISTS report_updates_sequence;
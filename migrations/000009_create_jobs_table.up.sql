@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS jobs (
+  id uuid NOT NULL,
+  query_id uuid NOT NULL,
+  replica text NOT NULL,
+  bigquery_job_id text,
+  bigquery_location text,
+  cancel_requested boolean default false,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX jobs_query_id_index ON jobs (query_id);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sessions (
+  id text NOT NULL,
+  email text NOT NULL,
+  groups text[],
+  revoked boolean default false,
+  expires_at timestamptz NOT NULL,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
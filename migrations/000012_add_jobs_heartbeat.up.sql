@@ -0,0 +1 @@
+ALTER TABLE jobs ADD COLUMN heartbeat_at timestamptz DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN rows_written bigint default 0;
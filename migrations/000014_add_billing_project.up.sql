@@ -0,0 +1,4 @@
+ALTER TABLE reports
+ADD COLUMN billing_project text;
+ALTER TABLE jobs
+ADD COLUMN bigquery_project text;
@@ -0,0 +1,2 @@
+ALTER TABLE reports
+ADD COLUMN default_dataset text;
@@ -0,0 +1,10 @@
+ALTER TABLE reports
+ADD COLUMN map_config_revision bigint default 0;
+CREATE TABLE IF NOT EXISTS map_configs (
+  report_id uuid NOT NULL,
+  revision bigint NOT NULL,
+  map_config text,
+  author_email text,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(report_id, revision)
+);
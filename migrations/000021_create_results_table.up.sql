@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS results (
+  id uuid NOT NULL,
+  object_name text NOT NULL,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
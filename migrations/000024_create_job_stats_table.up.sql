@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS job_stats (
+  id uuid NOT NULL,
+  query_id uuid NOT NULL,
+  report_id uuid NOT NULL,
+  user_email text NOT NULL,
+  job_status integer NOT NULL,
+  bytes_processed bigint DEFAULT 0,
+  bytes_billed bigint DEFAULT 0,
+  cache_hit boolean default false,
+  duration_ms bigint DEFAULT 0,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX job_stats_created_at_index ON job_stats (created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN result_table text;
@@ -0,0 +1,3 @@
+-- groups are stored as JSON array text, so encrypted values fit column;
+-- rows are encrypted by ReencryptColumns after DEKART_ENCRYPTION_KEYS is set
+ALTER TABLE sessions ALTER COLUMN "groups" TYPE text USING array_to_json("groups")::text;
@@ -0,0 +1,4 @@
+ALTER TABLE reports
+ADD COLUMN exploration_limit bigint default 0;
+ALTER TABLE queries
+ADD COLUMN exploration_limit bigint default 0;
@@ -0,0 +1,6 @@
+ALTER TABLE results
+ADD COLUMN query_id uuid;
+-- earlier results are known only while they are current result of query
+UPDATE results SET query_id = (
+  SELECT queries.id FROM queries WHERE queries.job_result_id = results.id OR queries.h3_result_id = results.id LIMIT 1
+);
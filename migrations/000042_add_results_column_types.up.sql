@@ -0,0 +1,2 @@
+ALTER TABLE results
+ADD COLUMN column_types text;
@@ -0,0 +1,13 @@
+ALTER TABLE reports
+ADD COLUMN parameters text;
+ALTER TABLE reports
+ADD COLUMN parameter_values text;
+ALTER TABLE queries
+ADD COLUMN parameter_bindings text;
+CREATE TABLE IF NOT EXISTS report_parameter_values (
+  report_id uuid NOT NULL,
+  user_email text NOT NULL,
+  parameter_values text NOT NULL,
+  updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(report_id, user_email)
+);
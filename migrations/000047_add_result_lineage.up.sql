@@ -0,0 +1,18 @@
+-- result_id is text, so rows are paged by ReencryptColumns like other encrypted tables
+CREATE TABLE IF NOT EXISTS result_lineage (
+  result_id text PRIMARY KEY,
+  report_id text NOT NULL,
+  query_id text NOT NULL,
+  job_id text NOT NULL,
+  bigquery_job_id text NOT NULL,
+  bigquery_project text NOT NULL,
+  bigquery_location text NOT NULL,
+  query_hash text NOT NULL,
+  query_text text,
+  parameter_values text,
+  user_email text NOT NULL,
+  dekart_version text NOT NULL,
+  job_started bigint NOT NULL,
+  job_finished bigint NOT NULL,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP
+);
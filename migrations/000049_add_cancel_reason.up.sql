@@ -0,0 +1,6 @@
+ALTER TABLE queries
+ADD COLUMN cancel_reason int default 0;
+ALTER TABLE job_stats
+ADD COLUMN cancel_reason int default 0;
+ALTER TABLE jobs
+ADD COLUMN cancel_reason int default 0;
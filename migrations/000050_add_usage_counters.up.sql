@@ -0,0 +1,8 @@
+-- bytes billed by month in UTC, empty user_email is total of instance; rows of users allow quotas per user
+CREATE TABLE IF NOT EXISTS usage_counters (
+  month text NOT NULL,
+  user_email text NOT NULL,
+  bytes_billed bigint NOT NULL DEFAULT 0,
+  updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(month, user_email)
+);
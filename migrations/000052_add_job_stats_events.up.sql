@@ -0,0 +1,4 @@
+ALTER TABLE job_stats
+ADD COLUMN events text;
+ALTER TABLE job_stats
+ADD COLUMN events_dropped int default 0;
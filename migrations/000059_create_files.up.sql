@@ -0,0 +1,12 @@
+-- files uploaded to reports, id is id of results row of stored object
+CREATE TABLE IF NOT EXISTS files (
+  id uuid NOT NULL,
+  report_id uuid NOT NULL,
+  name text NOT NULL,
+  file_type int NOT NULL,
+  size bigint NOT NULL,
+  author_email text NOT NULL,
+  created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX files_report_id_index ON files (report_id);
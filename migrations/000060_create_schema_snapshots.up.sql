@@ -0,0 +1,21 @@
+-- snapshots of INFORMATION_SCHEMA of source, project.dataset or project.region-xx; columns of source are
+-- replaced when refresh completes, failed refresh keeps them and records error
+CREATE TABLE IF NOT EXISTS schema_snapshots (
+  source text NOT NULL,
+  snapshot_at timestamptz,
+  columns_count int NOT NULL DEFAULT 0,
+  error text NOT NULL DEFAULT '',
+  failed_at timestamptz,
+  PRIMARY KEY(source)
+);
+CREATE TABLE IF NOT EXISTS schema_snapshot_columns (
+  source text NOT NULL,
+  table_name text NOT NULL,
+  position int NOT NULL,
+  column_name text NOT NULL,
+  data_type text NOT NULL,
+  mode text NOT NULL,
+  description text NOT NULL,
+  PRIMARY KEY(source, position)
+);
+CREATE INDEX schema_snapshot_columns_table_name_index ON schema_snapshot_columns (table_name);
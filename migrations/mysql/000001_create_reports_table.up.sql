@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS reports (
+  id char(36) NOT NULL,
+  map_config mediumtext,
+  title varchar(255),
+  archived boolean default false,
+  updated_at datetime DEFAULT CURRENT_TIMESTAMP,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS queries (
+  report_id char(36) NOT NULL,
+  id char(36) NOT NULL,
+  query_text mediumtext NOT NULL,
+  job_status int DEFAULT 0,
+  job_result_id char(36),
+  job_error text,
+  updated_at datetime DEFAULT CURRENT_TIMESTAMP,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id),
+  CONSTRAINT fk_report FOREIGN KEY(report_id) REFERENCES reports(id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE queries
+ADD COLUMN total_rows int default 0,
+ADD COLUMN bytes_processed int default 0;
@@ -0,0 +1,2 @@
+ALTER TABLE queries
+MODIFY COLUMN bytes_processed bigint default 0;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS jobs (
+  id char(36) NOT NULL,
+  query_id char(36) NOT NULL,
+  replica varchar(255) NOT NULL,
+  bigquery_job_id varchar(1024),
+  bigquery_location varchar(255),
+  cancel_requested boolean default false,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX jobs_query_id_index ON jobs (query_id);
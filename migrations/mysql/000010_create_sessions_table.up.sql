@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS sessions (
+  id varchar(255) NOT NULL,
+  email varchar(255) NOT NULL,
+  -- JSON array of groups
+  `groups` text,
+  revoked boolean default false,
+  expires_at datetime NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS instance_roles (
+  email_pattern varchar(255) NOT NULL,
+  role varchar(64) NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  updated_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(email_pattern)
+);
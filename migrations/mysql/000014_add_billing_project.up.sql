@@ -0,0 +1,4 @@
+ALTER TABLE reports
+ADD COLUMN billing_project varchar(255);
+ALTER TABLE jobs
+ADD COLUMN bigquery_project varchar(255);
@@ -0,0 +1,2 @@
+ALTER TABLE reports
+ADD COLUMN default_dataset varchar(1024);
@@ -0,0 +1,10 @@
+ALTER TABLE reports
+ADD COLUMN map_config_revision bigint default 0;
+CREATE TABLE IF NOT EXISTS map_configs (
+  report_id char(36) NOT NULL,
+  revision bigint NOT NULL,
+  map_config mediumtext,
+  author_email varchar(255),
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(report_id, revision)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE queries
+ADD COLUMN title varchar(255);
+UPDATE queries JOIN (
+  SELECT id, CONCAT('Query ', row_number() OVER (PARTITION BY report_id ORDER BY created_at, id)) AS title FROM queries
+) AS numbered ON queries.id = numbered.id
+SET queries.title = numbered.title;
+UPDATE reports SET title = 'Untitled' WHERE title IS NULL;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS results (
+  id char(36) NOT NULL,
+  object_name varchar(1024) NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
@@ -0,0 +1,11 @@
+ALTER TABLE results
+ADD COLUMN checked_at datetime,
+ADD COLUMN expired_at datetime;
+CREATE INDEX results_created_at_index ON results (created_at);
+-- results stored before object names were recorded are at bucket root
+INSERT IGNORE INTO results (id, object_name, created_at)
+SELECT job_result_id, CONCAT(job_result_id, '.csv'), CASE WHEN job_started IS NULL THEN updated_at ELSE job_started END
+FROM queries WHERE job_result_id IS NOT NULL;
+INSERT IGNORE INTO results (id, object_name, created_at)
+SELECT h3_result_id, CONCAT(h3_result_id, '.csv'), CASE WHEN job_started IS NULL THEN updated_at ELSE job_started END
+FROM queries WHERE h3_result_id IS NOT NULL;
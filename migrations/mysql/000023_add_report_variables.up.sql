@@ -0,0 +1,4 @@
+ALTER TABLE reports
+ADD COLUMN variables mediumtext;
+ALTER TABLE queries
+ADD COLUMN executed_query_text mediumtext;
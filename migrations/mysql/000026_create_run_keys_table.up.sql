@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS run_keys (
+  query_id char(36) NOT NULL,
+  idempotency_key varchar(128) NOT NULL,
+  job_id char(36) NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(query_id, idempotency_key)
+);
+CREATE INDEX run_keys_created_at_index ON run_keys (created_at);
@@ -0,0 +1,3 @@
+-- encrypted email is longer than varchar(255);
+-- rows are encrypted by ReencryptColumns after DEKART_ENCRYPTION_KEYS is set
+ALTER TABLE sessions MODIFY email text NOT NULL;
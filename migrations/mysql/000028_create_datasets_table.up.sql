@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS datasets (
+  id char(36) NOT NULL,
+  name varchar(64) NOT NULL,
+  description text NOT NULL,
+  query_text mediumtext NOT NULL,
+  author_email varchar(255) NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  updated_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE UNIQUE INDEX datasets_name_index ON datasets (name);
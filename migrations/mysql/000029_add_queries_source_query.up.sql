@@ -0,0 +1,3 @@
+ALTER TABLE queries
+ADD COLUMN source_query_id char(36),
+ADD COLUMN reused_from_query_id char(36);
@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN executed_default_dataset varchar(1024);
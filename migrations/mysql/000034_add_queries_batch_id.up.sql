@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN batch_id char(36);
@@ -0,0 +1,3 @@
+ALTER TABLE queries
+ADD COLUMN depends_on text,
+ADD COLUMN skipped_dependency_id char(36);
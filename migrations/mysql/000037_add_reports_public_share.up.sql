@@ -0,0 +1,2 @@
+ALTER TABLE reports
+ADD COLUMN public_share boolean default false;
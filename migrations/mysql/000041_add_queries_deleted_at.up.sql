@@ -0,0 +1,3 @@
+ALTER TABLE queries
+ADD COLUMN deleted_at datetime;
+CREATE INDEX queries_deleted_at_index ON queries (deleted_at);
@@ -0,0 +1,4 @@
+ALTER TABLE queries
+ADD COLUMN use_legacy_sql boolean default false;
+ALTER TABLE queries
+ADD COLUMN executed_use_legacy_sql boolean default false;
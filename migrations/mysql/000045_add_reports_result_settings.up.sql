@@ -0,0 +1,8 @@
+ALTER TABLE reports
+ADD COLUMN result_format int default 0;
+ALTER TABLE reports
+ADD COLUMN result_compression int default 0;
+ALTER TABLE reports
+ADD COLUMN result_delimiter varchar(8);
+ALTER TABLE results
+ADD COLUMN compression int default 0;
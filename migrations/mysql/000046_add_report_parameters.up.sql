@@ -0,0 +1,13 @@
+ALTER TABLE reports
+ADD COLUMN parameters mediumtext;
+ALTER TABLE reports
+ADD COLUMN parameter_values mediumtext;
+ALTER TABLE queries
+ADD COLUMN parameter_bindings text;
+CREATE TABLE IF NOT EXISTS report_parameter_values (
+  report_id char(36) NOT NULL,
+  user_email varchar(255) NOT NULL,
+  parameter_values mediumtext NOT NULL,
+  updated_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(report_id, user_email)
+);
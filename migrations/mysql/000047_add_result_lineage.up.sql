@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS result_lineage (
+  result_id char(36) PRIMARY KEY,
+  report_id char(36) NOT NULL,
+  query_id char(36) NOT NULL,
+  job_id varchar(255) NOT NULL,
+  bigquery_job_id varchar(1024) NOT NULL,
+  bigquery_project varchar(255) NOT NULL,
+  bigquery_location varchar(255) NOT NULL,
+  query_hash varchar(255) NOT NULL,
+  query_text mediumtext,
+  parameter_values mediumtext,
+  user_email varchar(255) NOT NULL,
+  dekart_version varchar(255) NOT NULL,
+  job_started bigint NOT NULL,
+  job_finished bigint NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS usage_counters (
+  month char(7) NOT NULL,
+  user_email varchar(255) NOT NULL,
+  bytes_billed bigint NOT NULL DEFAULT 0,
+  updated_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(month, user_email)
+);
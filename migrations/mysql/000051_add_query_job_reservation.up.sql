@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN job_reservation varchar(1024);
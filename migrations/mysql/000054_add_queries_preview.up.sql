@@ -0,0 +1,3 @@
+ALTER TABLE queries
+ADD COLUMN preview_result_id char(36),
+ADD COLUMN preview_rows bigint default 0;
@@ -0,0 +1,4 @@
+ALTER TABLE reports
+ADD COLUMN freshness_max_age bigint default 0;
+ALTER TABLE reports
+ADD COLUMN freshness_auto_rerun boolean default false;
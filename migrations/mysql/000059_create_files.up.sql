@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS files (
+  id char(36) NOT NULL,
+  report_id char(36) NOT NULL,
+  name varchar(255) NOT NULL,
+  file_type int NOT NULL,
+  size bigint NOT NULL,
+  author_email varchar(255) NOT NULL,
+  created_at datetime DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX files_report_id_index ON files (report_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS schema_snapshots (
+  source varchar(255) NOT NULL,
+  snapshot_at datetime,
+  columns_count int NOT NULL DEFAULT 0,
+  error text NOT NULL,
+  failed_at datetime,
+  PRIMARY KEY(source)
+);
+CREATE TABLE IF NOT EXISTS schema_snapshot_columns (
+  source varchar(255) NOT NULL,
+  table_name varchar(512) NOT NULL,
+  position int NOT NULL,
+  column_name text NOT NULL,
+  data_type text NOT NULL,
+  mode varchar(16) NOT NULL,
+  description text NOT NULL,
+  PRIMARY KEY(source, position)
+);
+CREATE INDEX schema_snapshot_columns_table_name_index ON schema_snapshot_columns (table_name);
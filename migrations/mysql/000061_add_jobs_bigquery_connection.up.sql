@@ -0,0 +1,3 @@
+-- connection of DEKART_BIGQUERY_CONNECTIONS job was created with, other replicas cancel job with its credentials
+ALTER TABLE jobs
+ADD COLUMN bigquery_connection varchar(255);
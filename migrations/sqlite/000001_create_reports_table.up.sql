@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS reports (
+  id text NOT NULL,
+  map_config text,
+  title text,
+  archived boolean default false,
+  updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
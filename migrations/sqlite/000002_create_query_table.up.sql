@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS queries (
+  report_id text NOT NULL,
+  id text NOT NULL,
+  query_text text NOT NULL,
+  job_status int DEFAULT 0,
+  job_result_id text,
+  job_error text,
+  updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id),
+  CONSTRAINT fk_report FOREIGN KEY(report_id) REFERENCES reports
+);
@@ -0,0 +1,4 @@
+ALTER TABLE queries
+ADD COLUMN total_rows int default 0;
+ALTER TABLE queries
+ADD COLUMN bytes_processed int default 0;
@@ -0,0 +1,2 @@
+-- SQLite integers are 64 bit, bytes_processed keeps int type
+SELECT 1;
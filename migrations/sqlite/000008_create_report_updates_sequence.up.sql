@@ -0,0 +1,2 @@
+-- report updates are delivered in process with SQLite, sequence is not used
+SELECT 1;
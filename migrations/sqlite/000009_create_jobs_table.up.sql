@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS jobs (
+  id text NOT NULL,
+  query_id text NOT NULL,
+  replica text NOT NULL,
+  bigquery_job_id text,
+  bigquery_location text,
+  cancel_requested boolean default false,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX jobs_query_id_index ON jobs (query_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS sessions (
+  id text NOT NULL,
+  email text NOT NULL,
+  -- JSON array of groups
+  groups text,
+  revoked boolean default false,
+  expires_at timestamp NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
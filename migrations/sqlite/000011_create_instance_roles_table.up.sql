@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS instance_roles (
+  email_pattern text NOT NULL,
+  role text NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(email_pattern)
+);
@@ -0,0 +1,7 @@
+-- SQLite does not allow CURRENT_TIMESTAMP default in ADD COLUMN
+ALTER TABLE jobs ADD COLUMN heartbeat_at timestamp;
+CREATE TRIGGER IF NOT EXISTS jobs_heartbeat_at AFTER INSERT ON jobs
+WHEN NEW.heartbeat_at IS NULL
+BEGIN
+  UPDATE jobs SET heartbeat_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
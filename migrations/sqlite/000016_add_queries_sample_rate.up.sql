@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN sample_rate double precision default 0;
@@ -0,0 +1,2 @@
+ALTER TABLE queries
+ADD COLUMN h3_result_id text;
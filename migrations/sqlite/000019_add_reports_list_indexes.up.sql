@@ -0,0 +1,3 @@
+CREATE INDEX reports_author_email_updated_at_index ON reports (author_email, updated_at DESC, id DESC);
+CREATE INDEX reports_updated_at_index ON reports (updated_at DESC, id DESC);
+CREATE INDEX queries_report_id_index ON queries (report_id);
@@ -0,0 +1,6 @@
+ALTER TABLE queries
+ADD COLUMN title text;
+UPDATE queries SET title = numbered.title FROM (
+  SELECT id, 'Query ' || row_number() OVER (PARTITION BY report_id ORDER BY created_at, id) AS title FROM queries
+) AS numbered WHERE queries.id = numbered.id;
+UPDATE reports SET title = 'Untitled' WHERE title IS NULL;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS results (
+  id text NOT NULL,
+  object_name text NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
@@ -0,0 +1,12 @@
+ALTER TABLE results
+ADD COLUMN checked_at timestamp;
+ALTER TABLE results
+ADD COLUMN expired_at timestamp;
+CREATE INDEX results_created_at_index ON results (created_at);
+-- results stored before object names were recorded are at bucket root
+INSERT OR IGNORE INTO results (id, object_name, created_at)
+SELECT job_result_id, job_result_id || '.csv', CASE WHEN job_started IS NULL THEN updated_at ELSE job_started END
+FROM queries WHERE job_result_id IS NOT NULL;
+INSERT OR IGNORE INTO results (id, object_name, created_at)
+SELECT h3_result_id, h3_result_id || '.csv', CASE WHEN job_started IS NULL THEN updated_at ELSE job_started END
+FROM queries WHERE h3_result_id IS NOT NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS run_keys (
+  query_id text NOT NULL,
+  idempotency_key text NOT NULL,
+  job_id text NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(query_id, idempotency_key)
+);
+CREATE INDEX run_keys_created_at_index ON run_keys (created_at);
@@ -0,0 +1,2 @@
+-- columns are text, rows are encrypted by ReencryptColumns after DEKART_ENCRYPTION_KEYS is set
+SELECT 1;
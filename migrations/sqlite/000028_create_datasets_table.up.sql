@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS datasets (
+  id text NOT NULL,
+  name text NOT NULL,
+  description text NOT NULL,
+  query_text text NOT NULL,
+  author_email text NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE UNIQUE INDEX datasets_name_index ON datasets (name);
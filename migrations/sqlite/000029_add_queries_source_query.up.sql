@@ -0,0 +1,4 @@
+ALTER TABLE queries
+ADD COLUMN source_query_id text;
+ALTER TABLE queries
+ADD COLUMN reused_from_query_id text;
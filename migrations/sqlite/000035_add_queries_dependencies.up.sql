@@ -0,0 +1,4 @@
+ALTER TABLE queries
+ADD COLUMN depends_on text;
+ALTER TABLE queries
+ADD COLUMN skipped_dependency_id text;
@@ -0,0 +1,2 @@
+ALTER TABLE results
+ADD COLUMN exact_total_rows bigint;
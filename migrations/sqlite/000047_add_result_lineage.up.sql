@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS result_lineage (
+  result_id text PRIMARY KEY,
+  report_id text NOT NULL,
+  query_id text NOT NULL,
+  job_id text NOT NULL,
+  bigquery_job_id text NOT NULL,
+  bigquery_project text NOT NULL,
+  bigquery_location text NOT NULL,
+  query_hash text NOT NULL,
+  query_text text,
+  parameter_values text,
+  user_email text NOT NULL,
+  dekart_version text NOT NULL,
+  job_started integer NOT NULL,
+  job_finished integer NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
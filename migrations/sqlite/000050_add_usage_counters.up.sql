@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS usage_counters (
+  month text NOT NULL,
+  user_email text NOT NULL,
+  bytes_billed integer NOT NULL DEFAULT 0,
+  updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(month, user_email)
+);
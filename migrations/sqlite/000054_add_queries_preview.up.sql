@@ -0,0 +1,4 @@
+ALTER TABLE queries
+ADD COLUMN preview_result_id text;
+ALTER TABLE queries
+ADD COLUMN preview_rows bigint default 0;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS files (
+  id text NOT NULL,
+  report_id text NOT NULL,
+  name text NOT NULL,
+  file_type integer NOT NULL,
+  size integer NOT NULL,
+  author_email text NOT NULL,
+  created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(id)
+);
+CREATE INDEX files_report_id_index ON files (report_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS schema_snapshots (
+  source text NOT NULL,
+  snapshot_at timestamp,
+  columns_count integer NOT NULL DEFAULT 0,
+  error text NOT NULL DEFAULT '',
+  failed_at timestamp,
+  PRIMARY KEY(source)
+);
+CREATE TABLE IF NOT EXISTS schema_snapshot_columns (
+  source text NOT NULL,
+  table_name text NOT NULL,
+  position integer NOT NULL,
+  column_name text NOT NULL,
+  data_type text NOT NULL,
+  mode text NOT NULL,
+  description text NOT NULL,
+  PRIMARY KEY(source, position)
+);
+CREATE INDEX schema_snapshot_columns_table_name_index ON schema_snapshot_columns (table_name);
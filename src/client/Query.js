@@ -2,6 +2,7 @@ import { useEffect, useState } from 'react'
 import AceEditor from 'react-ace'
 import { AutoSizer } from 'react-virtualized'
 import Button from 'antd/es/button'
+import Alert from 'antd/es/alert'
 import styles from './Query.module.css'
 import { useDispatch, useSelector } from 'react-redux'
 import { cancelQuery, runQuery, showDataTable } from './actions'
@@ -114,6 +115,29 @@ function QueryEditor ({ queryId, queryText, onChange, canWrite }) {
   )
 }
 
+function QueryWarnings ({ query }) {
+  const [dismissed, setDismissed] = useState([])
+  useEffect(() => setDismissed([]), [query.jobResultId])
+  const warnings = (query.warningsList || []).filter(w => !dismissed.includes(w.code))
+  if (!warnings.length) {
+    return null
+  }
+  return (
+    <div className={styles.warnings}>
+      {warnings.map(w => (
+        <Alert
+          key={w.code}
+          type='warning'
+          message={w.message}
+          showIcon
+          closable
+          onClose={() => setDismissed(dismissed.concat(w.code))}
+        />
+      ))}
+    </div>
+  )
+}
+
 function QueryStatus ({ children, query }) {
   let message, errorMessage, action, style
   let icon = null
@@ -158,6 +182,7 @@ function QueryStatus ({ children, query }) {
           {action ? <div className={styles.action}>{action}</div> : null}
         </div>
         {errorMessage ? <div className={styles.errorMessage}>{errorMessage}</div> : null}
+        <QueryWarnings query={query} />
       </div>
       {children ? <div className={styles.button}>{children}</div> : null}
 
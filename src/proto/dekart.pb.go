@@ -25,915 +25,968 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
-type GetEnvResponse_Variable_Type int32
+type Role int32
 
 const (
-	GetEnvResponse_Variable_TYPE_UNSPECIFIED           GetEnvResponse_Variable_Type = 0
-	GetEnvResponse_Variable_TYPE_MAPBOX_TOKEN          GetEnvResponse_Variable_Type = 1
-	GetEnvResponse_Variable_TYPE_UX_DATA_DOCUMENTATION GetEnvResponse_Variable_Type = 2
-	GetEnvResponse_Variable_TYPE_UX_HOMEPAGE           GetEnvResponse_Variable_Type = 3
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_VIEWER      Role = 1
+	Role_ROLE_EDITOR      Role = 2
+	Role_ROLE_ADMIN       Role = 3
 )
 
-// Enum value maps for GetEnvResponse_Variable_Type.
+// Enum value maps for Role.
 var (
-	GetEnvResponse_Variable_Type_name = map[int32]string{
-		0: "TYPE_UNSPECIFIED",
-		1: "TYPE_MAPBOX_TOKEN",
-		2: "TYPE_UX_DATA_DOCUMENTATION",
-		3: "TYPE_UX_HOMEPAGE",
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_VIEWER",
+		2: "ROLE_EDITOR",
+		3: "ROLE_ADMIN",
 	}
-	GetEnvResponse_Variable_Type_value = map[string]int32{
-		"TYPE_UNSPECIFIED":           0,
-		"TYPE_MAPBOX_TOKEN":          1,
-		"TYPE_UX_DATA_DOCUMENTATION": 2,
-		"TYPE_UX_HOMEPAGE":           3,
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_VIEWER":      1,
+		"ROLE_EDITOR":      2,
+		"ROLE_ADMIN":       3,
 	}
 )
 
-func (x GetEnvResponse_Variable_Type) Enum() *GetEnvResponse_Variable_Type {
-	p := new(GetEnvResponse_Variable_Type)
+func (x Role) Enum() *Role {
+	p := new(Role)
 	*p = x
 	return p
 }
 
-func (x GetEnvResponse_Variable_Type) String() string {
+func (x Role) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (GetEnvResponse_Variable_Type) Descriptor() protoreflect.EnumDescriptor {
+func (Role) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_dekart_proto_enumTypes[0].Descriptor()
 }
 
-func (GetEnvResponse_Variable_Type) Type() protoreflect.EnumType {
+func (Role) Type() protoreflect.EnumType {
 	return &file_proto_dekart_proto_enumTypes[0]
 }
 
-func (x GetEnvResponse_Variable_Type) Number() protoreflect.EnumNumber {
+func (x Role) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use GetEnvResponse_Variable_Type.Descriptor instead.
-func (GetEnvResponse_Variable_Type) EnumDescriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{2, 0, 0}
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{0}
 }
 
-type Query_JobStatus int32
+type FileType int32
 
 const (
-	Query_JOB_STATUS_UNSPECIFIED Query_JobStatus = 0
-	Query_JOB_STATUS_PENDING     Query_JobStatus = 1
-	Query_JOB_STATUS_RUNNING     Query_JobStatus = 2
-	Query_JOB_STATUS_DONE        Query_JobStatus = 3
+	FileType_FILE_TYPE_UNSPECIFIED FileType = 0
+	FileType_FILE_TYPE_CSV         FileType = 1
+	FileType_FILE_TYPE_GEOJSON     FileType = 2 // FeatureCollection or Feature
 )
 
-// Enum value maps for Query_JobStatus.
+// Enum value maps for FileType.
 var (
-	Query_JobStatus_name = map[int32]string{
-		0: "JOB_STATUS_UNSPECIFIED",
-		1: "JOB_STATUS_PENDING",
-		2: "JOB_STATUS_RUNNING",
-		3: "JOB_STATUS_DONE",
+	FileType_name = map[int32]string{
+		0: "FILE_TYPE_UNSPECIFIED",
+		1: "FILE_TYPE_CSV",
+		2: "FILE_TYPE_GEOJSON",
 	}
-	Query_JobStatus_value = map[string]int32{
-		"JOB_STATUS_UNSPECIFIED": 0,
-		"JOB_STATUS_PENDING":     1,
-		"JOB_STATUS_RUNNING":     2,
-		"JOB_STATUS_DONE":        3,
+	FileType_value = map[string]int32{
+		"FILE_TYPE_UNSPECIFIED": 0,
+		"FILE_TYPE_CSV":         1,
+		"FILE_TYPE_GEOJSON":     2,
 	}
 )
 
-func (x Query_JobStatus) Enum() *Query_JobStatus {
-	p := new(Query_JobStatus)
+func (x FileType) Enum() *FileType {
+	p := new(FileType)
 	*p = x
 	return p
 }
 
-func (x Query_JobStatus) String() string {
+func (x FileType) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (Query_JobStatus) Descriptor() protoreflect.EnumDescriptor {
+func (FileType) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_dekart_proto_enumTypes[1].Descriptor()
 }
 
-func (Query_JobStatus) Type() protoreflect.EnumType {
+func (FileType) Type() protoreflect.EnumType {
 	return &file_proto_dekart_proto_enumTypes[1]
 }
 
-func (x Query_JobStatus) Number() protoreflect.EnumNumber {
+func (x FileType) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use Query_JobStatus.Descriptor instead.
-func (Query_JobStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{8, 0}
+// Deprecated: Use FileType.Descriptor instead.
+func (FileType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{1}
 }
 
-type StreamOptions struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+// ResultFormat of result file
+type ResultFormat int32
 
-	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
-}
+const (
+	ResultFormat_RESULT_FORMAT_UNSPECIFIED ResultFormat = 0 // CSV
+	ResultFormat_RESULT_FORMAT_CSV         ResultFormat = 1
+	ResultFormat_RESULT_FORMAT_PARQUET     ResultFormat = 2 // GeoParquet when result has GEOGRAPHY columns, can't be rendered on map
+)
 
-func (x *StreamOptions) Reset() {
-	*x = StreamOptions{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for ResultFormat.
+var (
+	ResultFormat_name = map[int32]string{
+		0: "RESULT_FORMAT_UNSPECIFIED",
+		1: "RESULT_FORMAT_CSV",
+		2: "RESULT_FORMAT_PARQUET",
 	}
-}
+	ResultFormat_value = map[string]int32{
+		"RESULT_FORMAT_UNSPECIFIED": 0,
+		"RESULT_FORMAT_CSV":         1,
+		"RESULT_FORMAT_PARQUET":     2,
+	}
+)
 
-func (x *StreamOptions) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x ResultFormat) Enum() *ResultFormat {
+	p := new(ResultFormat)
+	*p = x
+	return p
 }
 
-func (*StreamOptions) ProtoMessage() {}
-
-func (x *StreamOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (x ResultFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-// Deprecated: Use StreamOptions.ProtoReflect.Descriptor instead.
-func (*StreamOptions) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{0}
+func (ResultFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[2].Descriptor()
 }
 
-func (x *StreamOptions) GetSequence() int64 {
-	if x != nil {
-		return x.Sequence
-	}
-	return 0
+func (ResultFormat) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[2]
 }
 
-type GetEnvRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x ResultFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *GetEnvRequest) Reset() {
-	*x = GetEnvRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+// Deprecated: Use ResultFormat.Descriptor instead.
+func (ResultFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetEnvRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
+// ResultCompression of CSV result file
+type ResultCompression int32
 
-func (*GetEnvRequest) ProtoMessage() {}
+const (
+	ResultCompression_RESULT_COMPRESSION_UNSPECIFIED ResultCompression = 0 // default of report or instance
+	ResultCompression_RESULT_COMPRESSION_NONE        ResultCompression = 1
+	ResultCompression_RESULT_COMPRESSION_GZIP        ResultCompression = 2 // stored with Content-Encoding gzip, served compressed to clients accepting gzip
+)
 
-func (x *GetEnvRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+// Enum value maps for ResultCompression.
+var (
+	ResultCompression_name = map[int32]string{
+		0: "RESULT_COMPRESSION_UNSPECIFIED",
+		1: "RESULT_COMPRESSION_NONE",
+		2: "RESULT_COMPRESSION_GZIP",
 	}
-	return mi.MessageOf(x)
+	ResultCompression_value = map[string]int32{
+		"RESULT_COMPRESSION_UNSPECIFIED": 0,
+		"RESULT_COMPRESSION_NONE":        1,
+		"RESULT_COMPRESSION_GZIP":        2,
+	}
+)
+
+func (x ResultCompression) Enum() *ResultCompression {
+	p := new(ResultCompression)
+	*p = x
+	return p
 }
 
-// Deprecated: Use GetEnvRequest.ProtoReflect.Descriptor instead.
-func (*GetEnvRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{1}
+func (x ResultCompression) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type GetEnvResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (ResultCompression) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[3].Descriptor()
+}
 
-	Variables []*GetEnvResponse_Variable `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
+func (ResultCompression) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[3]
 }
 
-func (x *GetEnvResponse) Reset() {
-	*x = GetEnvResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x ResultCompression) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *GetEnvResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use ResultCompression.Descriptor instead.
+func (ResultCompression) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{3}
 }
 
-func (*GetEnvResponse) ProtoMessage() {}
+// CancelReason of cancelled job, first cancellation wins when they race
+type CancelReason int32
 
-func (x *GetEnvResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	CancelReason_CANCEL_REASON_UNSPECIFIED    CancelReason = 0
+	CancelReason_CANCEL_REASON_USER_REQUEST   CancelReason = 1 // cancelled, removed or archived by author
+	CancelReason_CANCEL_REASON_ADMIN          CancelReason = 2 // cancelled by admin of instance
+	CancelReason_CANCEL_REASON_ORPHAN_TIMEOUT CancelReason = 3 // RunQueryAndWait timed out, nobody waits for result
+	CancelReason_CANCEL_REASON_SHUTDOWN       CancelReason = 4 // replica running job was shut down
+	CancelReason_CANCEL_REASON_SUPERSEDED     CancelReason = 5 // query was run again
+)
+
+// Enum value maps for CancelReason.
+var (
+	CancelReason_name = map[int32]string{
+		0: "CANCEL_REASON_UNSPECIFIED",
+		1: "CANCEL_REASON_USER_REQUEST",
+		2: "CANCEL_REASON_ADMIN",
+		3: "CANCEL_REASON_ORPHAN_TIMEOUT",
+		4: "CANCEL_REASON_SHUTDOWN",
+		5: "CANCEL_REASON_SUPERSEDED",
 	}
-	return mi.MessageOf(x)
-}
+	CancelReason_value = map[string]int32{
+		"CANCEL_REASON_UNSPECIFIED":    0,
+		"CANCEL_REASON_USER_REQUEST":   1,
+		"CANCEL_REASON_ADMIN":          2,
+		"CANCEL_REASON_ORPHAN_TIMEOUT": 3,
+		"CANCEL_REASON_SHUTDOWN":       4,
+		"CANCEL_REASON_SUPERSEDED":     5,
+	}
+)
 
-// Deprecated: Use GetEnvResponse.ProtoReflect.Descriptor instead.
-func (*GetEnvResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{2}
+func (x CancelReason) Enum() *CancelReason {
+	p := new(CancelReason)
+	*p = x
+	return p
 }
 
-func (x *GetEnvResponse) GetVariables() []*GetEnvResponse_Variable {
-	if x != nil {
-		return x.Variables
-	}
-	return nil
+func (x CancelReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type ArchiveReportRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (CancelReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[4].Descriptor()
+}
 
-	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
-	Archive  bool   `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+func (CancelReason) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[4]
 }
 
-func (x *ArchiveReportRequest) Reset() {
-	*x = ArchiveReportRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x CancelReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ArchiveReportRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use CancelReason.Descriptor instead.
+func (CancelReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{4}
 }
 
-func (*ArchiveReportRequest) ProtoMessage() {}
+type GetEnvResponse_Variable_Type int32
 
-func (x *ArchiveReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	GetEnvResponse_Variable_TYPE_UNSPECIFIED           GetEnvResponse_Variable_Type = 0
+	GetEnvResponse_Variable_TYPE_MAPBOX_TOKEN          GetEnvResponse_Variable_Type = 1
+	GetEnvResponse_Variable_TYPE_UX_DATA_DOCUMENTATION GetEnvResponse_Variable_Type = 2
+	GetEnvResponse_Variable_TYPE_UX_HOMEPAGE           GetEnvResponse_Variable_Type = 3
+	GetEnvResponse_Variable_TYPE_READ_ONLY             GetEnvResponse_Variable_Type = 4 // 1 when DEKART_READ_ONLY=1, reports can't be edited and queries can't be run
+)
+
+// Enum value maps for GetEnvResponse_Variable_Type.
+var (
+	GetEnvResponse_Variable_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_MAPBOX_TOKEN",
+		2: "TYPE_UX_DATA_DOCUMENTATION",
+		3: "TYPE_UX_HOMEPAGE",
+		4: "TYPE_READ_ONLY",
 	}
-	return mi.MessageOf(x)
-}
+	GetEnvResponse_Variable_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED":           0,
+		"TYPE_MAPBOX_TOKEN":          1,
+		"TYPE_UX_DATA_DOCUMENTATION": 2,
+		"TYPE_UX_HOMEPAGE":           3,
+		"TYPE_READ_ONLY":             4,
+	}
+)
 
-// Deprecated: Use ArchiveReportRequest.ProtoReflect.Descriptor instead.
-func (*ArchiveReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{3}
+func (x GetEnvResponse_Variable_Type) Enum() *GetEnvResponse_Variable_Type {
+	p := new(GetEnvResponse_Variable_Type)
+	*p = x
+	return p
 }
 
-func (x *ArchiveReportRequest) GetReportId() string {
-	if x != nil {
-		return x.ReportId
-	}
-	return ""
+func (x GetEnvResponse_Variable_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *ArchiveReportRequest) GetArchive() bool {
-	if x != nil {
-		return x.Archive
-	}
-	return false
+func (GetEnvResponse_Variable_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[5].Descriptor()
 }
 
-type ArchiveReportResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (GetEnvResponse_Variable_Type) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[5]
 }
 
-func (x *ArchiveReportResponse) Reset() {
-	*x = ArchiveReportResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x GetEnvResponse_Variable_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ArchiveReportResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use GetEnvResponse_Variable_Type.Descriptor instead.
+func (GetEnvResponse_Variable_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{2, 0, 0}
 }
 
-func (*ArchiveReportResponse) ProtoMessage() {}
+type GetUsageRequest_GroupBy int32
 
-func (x *ArchiveReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	GetUsageRequest_GROUP_BY_UNSPECIFIED GetUsageRequest_GroupBy = 0 // single total row
+	GetUsageRequest_GROUP_BY_USER        GetUsageRequest_GroupBy = 1
+	GetUsageRequest_GROUP_BY_REPORT      GetUsageRequest_GroupBy = 2
+	GetUsageRequest_GROUP_BY_DAY         GetUsageRequest_GroupBy = 3 // UTC day, YYYY-MM-DD
+)
+
+// Enum value maps for GetUsageRequest_GroupBy.
+var (
+	GetUsageRequest_GroupBy_name = map[int32]string{
+		0: "GROUP_BY_UNSPECIFIED",
+		1: "GROUP_BY_USER",
+		2: "GROUP_BY_REPORT",
+		3: "GROUP_BY_DAY",
 	}
-	return mi.MessageOf(x)
+	GetUsageRequest_GroupBy_value = map[string]int32{
+		"GROUP_BY_UNSPECIFIED": 0,
+		"GROUP_BY_USER":        1,
+		"GROUP_BY_REPORT":      2,
+		"GROUP_BY_DAY":         3,
+	}
+)
+
+func (x GetUsageRequest_GroupBy) Enum() *GetUsageRequest_GroupBy {
+	p := new(GetUsageRequest_GroupBy)
+	*p = x
+	return p
 }
 
-// Deprecated: Use ArchiveReportResponse.ProtoReflect.Descriptor instead.
-func (*ArchiveReportResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{4}
+func (x GetUsageRequest_GroupBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type ReportListRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (GetUsageRequest_GroupBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[6].Descriptor()
+}
 
-	StreamOptions *StreamOptions `protobuf:"bytes,1,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+func (GetUsageRequest_GroupBy) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[6]
 }
 
-func (x *ReportListRequest) Reset() {
-	*x = ReportListRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x GetUsageRequest_GroupBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ReportListRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use GetUsageRequest_GroupBy.Descriptor instead.
+func (GetUsageRequest_GroupBy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{26, 0}
 }
 
-func (*ReportListRequest) ProtoMessage() {}
+type ListReportsRequest_Filter int32
 
-func (x *ReportListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	ListReportsRequest_FILTER_ALL      ListReportsRequest_Filter = 0 // reports user can read, not archived
+	ListReportsRequest_FILTER_MINE     ListReportsRequest_Filter = 1
+	ListReportsRequest_FILTER_SHARED   ListReportsRequest_Filter = 2 // reports of other users
+	ListReportsRequest_FILTER_ARCHIVED ListReportsRequest_Filter = 3 // archived reports of user
+)
+
+// Enum value maps for ListReportsRequest_Filter.
+var (
+	ListReportsRequest_Filter_name = map[int32]string{
+		0: "FILTER_ALL",
+		1: "FILTER_MINE",
+		2: "FILTER_SHARED",
+		3: "FILTER_ARCHIVED",
 	}
-	return mi.MessageOf(x)
-}
+	ListReportsRequest_Filter_value = map[string]int32{
+		"FILTER_ALL":      0,
+		"FILTER_MINE":     1,
+		"FILTER_SHARED":   2,
+		"FILTER_ARCHIVED": 3,
+	}
+)
 
-// Deprecated: Use ReportListRequest.ProtoReflect.Descriptor instead.
-func (*ReportListRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{5}
+func (x ListReportsRequest_Filter) Enum() *ListReportsRequest_Filter {
+	p := new(ListReportsRequest_Filter)
+	*p = x
+	return p
 }
 
-func (x *ReportListRequest) GetStreamOptions() *StreamOptions {
-	if x != nil {
-		return x.StreamOptions
-	}
-	return nil
+func (x ListReportsRequest_Filter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type ReportListResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (ListReportsRequest_Filter) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[7].Descriptor()
+}
 
-	Reports       []*Report      `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
-	StreamOptions *StreamOptions `protobuf:"bytes,2,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+func (ListReportsRequest_Filter) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[7]
 }
 
-func (x *ReportListResponse) Reset() {
-	*x = ReportListResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x ListReportsRequest_Filter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ReportListResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use ListReportsRequest_Filter.Descriptor instead.
+func (ListReportsRequest_Filter) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{36, 0}
 }
 
-func (*ReportListResponse) ProtoMessage() {}
+type ListReportsRequest_Sort int32
 
-func (x *ReportListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	ListReportsRequest_SORT_UPDATED_AT ListReportsRequest_Sort = 0 // recently updated first
+	ListReportsRequest_SORT_TITLE      ListReportsRequest_Sort = 1
+)
+
+// Enum value maps for ListReportsRequest_Sort.
+var (
+	ListReportsRequest_Sort_name = map[int32]string{
+		0: "SORT_UPDATED_AT",
+		1: "SORT_TITLE",
 	}
-	return mi.MessageOf(x)
-}
+	ListReportsRequest_Sort_value = map[string]int32{
+		"SORT_UPDATED_AT": 0,
+		"SORT_TITLE":      1,
+	}
+)
 
-// Deprecated: Use ReportListResponse.ProtoReflect.Descriptor instead.
-func (*ReportListResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{6}
+func (x ListReportsRequest_Sort) Enum() *ListReportsRequest_Sort {
+	p := new(ListReportsRequest_Sort)
+	*p = x
+	return p
 }
 
-func (x *ReportListResponse) GetReports() []*Report {
-	if x != nil {
-		return x.Reports
-	}
-	return nil
+func (x ListReportsRequest_Sort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *ReportListResponse) GetStreamOptions() *StreamOptions {
-	if x != nil {
-		return x.StreamOptions
-	}
-	return nil
+func (ListReportsRequest_Sort) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[8].Descriptor()
 }
 
-type Report struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	MapConfig string `protobuf:"bytes,2,opt,name=map_config,json=mapConfig,proto3" json:"map_config,omitempty"`
-	Title     string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Archived  bool   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
-	CanWrite  bool   `protobuf:"varint,5,opt,name=can_write,json=canWrite,proto3" json:"can_write,omitempty"`
+func (ListReportsRequest_Sort) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[8]
 }
 
-func (x *Report) Reset() {
-	*x = Report{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x ListReportsRequest_Sort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Report) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use ListReportsRequest_Sort.Descriptor instead.
+func (ListReportsRequest_Sort) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{36, 1}
 }
 
-func (*Report) ProtoMessage() {}
+type ReportVariable_Type int32
 
-func (x *Report) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	ReportVariable_TYPE_UNSPECIFIED ReportVariable_Type = 0
+	ReportVariable_TYPE_STRING      ReportVariable_Type = 1 // quoted and escaped string literal
+	ReportVariable_TYPE_NUMBER      ReportVariable_Type = 2 // integer or decimal literal
+	ReportVariable_TYPE_IDENTIFIER  ReportVariable_Type = 3 // table, dataset or column name, only A-Z, a-z, 0-9, _ and .
+)
+
+// Enum value maps for ReportVariable_Type.
+var (
+	ReportVariable_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_STRING",
+		2: "TYPE_NUMBER",
+		3: "TYPE_IDENTIFIER",
 	}
-	return mi.MessageOf(x)
-}
+	ReportVariable_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_STRING":      1,
+		"TYPE_NUMBER":      2,
+		"TYPE_IDENTIFIER":  3,
+	}
+)
 
-// Deprecated: Use Report.ProtoReflect.Descriptor instead.
-func (*Report) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{7}
+func (x ReportVariable_Type) Enum() *ReportVariable_Type {
+	p := new(ReportVariable_Type)
+	*p = x
+	return p
 }
 
-func (x *Report) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+func (x ReportVariable_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Report) GetMapConfig() string {
-	if x != nil {
-		return x.MapConfig
-	}
-	return ""
+func (ReportVariable_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[9].Descriptor()
 }
 
-func (x *Report) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+func (ReportVariable_Type) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[9]
 }
 
-func (x *Report) GetArchived() bool {
-	if x != nil {
-		return x.Archived
-	}
-	return false
+func (x ReportVariable_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Report) GetCanWrite() bool {
-	if x != nil {
-		return x.CanWrite
-	}
-	return false
+// Deprecated: Use ReportVariable_Type.Descriptor instead.
+func (ReportVariable_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{39, 0}
 }
 
-type Query struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type ReportParameter_Type int32
 
-	Id             string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ReportId       string          `protobuf:"bytes,2,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
-	QueryText      string          `protobuf:"bytes,3,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`
-	JobStatus      Query_JobStatus `protobuf:"varint,4,opt,name=job_status,json=jobStatus,proto3,enum=Query_JobStatus" json:"job_status,omitempty"`
-	JobResultId    string          `protobuf:"bytes,5,opt,name=job_result_id,json=jobResultId,proto3" json:"job_result_id,omitempty"`
-	JobError       string          `protobuf:"bytes,6,opt,name=job_error,json=jobError,proto3" json:"job_error,omitempty"`
-	JobDuration    int64           `protobuf:"varint,7,opt,name=job_duration,json=jobDuration,proto3" json:"job_duration,omitempty"`
-	TotalRows      int32           `protobuf:"varint,8,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
-	BytesProcessed int64           `protobuf:"varint,9,opt,name=bytes_processed,json=bytesProcessed,proto3" json:"bytes_processed,omitempty"`
-	ResultSize     int64           `protobuf:"varint,10,opt,name=result_size,json=resultSize,proto3" json:"result_size,omitempty"`
-}
+const (
+	ReportParameter_TYPE_UNSPECIFIED ReportParameter_Type = 0
+	ReportParameter_TYPE_STRING      ReportParameter_Type = 1
+	ReportParameter_TYPE_INT64       ReportParameter_Type = 2
+	ReportParameter_TYPE_FLOAT64     ReportParameter_Type = 3
+	ReportParameter_TYPE_BOOL        ReportParameter_Type = 4 // true or false
+	ReportParameter_TYPE_DATE        ReportParameter_Type = 5 // YYYY-MM-DD
+	ReportParameter_TYPE_TIMESTAMP   ReportParameter_Type = 6 // RFC 3339
+)
 
-func (x *Query) Reset() {
-	*x = Query{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for ReportParameter_Type.
+var (
+	ReportParameter_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_STRING",
+		2: "TYPE_INT64",
+		3: "TYPE_FLOAT64",
+		4: "TYPE_BOOL",
+		5: "TYPE_DATE",
+		6: "TYPE_TIMESTAMP",
 	}
-}
+	ReportParameter_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_STRING":      1,
+		"TYPE_INT64":       2,
+		"TYPE_FLOAT64":     3,
+		"TYPE_BOOL":        4,
+		"TYPE_DATE":        5,
+		"TYPE_TIMESTAMP":   6,
+	}
+)
 
-func (x *Query) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x ReportParameter_Type) Enum() *ReportParameter_Type {
+	p := new(ReportParameter_Type)
+	*p = x
+	return p
 }
 
-func (*Query) ProtoMessage() {}
-
-func (x *Query) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (x ReportParameter_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-// Deprecated: Use Query.ProtoReflect.Descriptor instead.
-func (*Query) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{8}
+func (ReportParameter_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[10].Descriptor()
 }
 
-func (x *Query) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+func (ReportParameter_Type) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[10]
 }
 
-func (x *Query) GetReportId() string {
-	if x != nil {
-		return x.ReportId
-	}
-	return ""
+func (x ReportParameter_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Query) GetQueryText() string {
-	if x != nil {
-		return x.QueryText
-	}
-	return ""
+// Deprecated: Use ReportParameter_Type.Descriptor instead.
+func (ReportParameter_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{42, 0}
 }
 
-func (x *Query) GetJobStatus() Query_JobStatus {
-	if x != nil {
-		return x.JobStatus
-	}
-	return Query_JOB_STATUS_UNSPECIFIED
-}
+type Query_JobStatus int32
 
-func (x *Query) GetJobResultId() string {
-	if x != nil {
-		return x.JobResultId
-	}
-	return ""
-}
+const (
+	Query_JOB_STATUS_UNSPECIFIED     Query_JobStatus = 0
+	Query_JOB_STATUS_PENDING         Query_JobStatus = 1
+	Query_JOB_STATUS_RUNNING         Query_JobStatus = 2
+	Query_JOB_STATUS_DONE            Query_JobStatus = 3
+	Query_JOB_STATUS_SKIPPED         Query_JobStatus = 4 // skipped by RunAllQueries, skipped_dependency_id didn't finish with result
+	Query_JOB_STATUS_READING_RESULTS Query_JobStatus = 5 // BigQuery job is done, rows are read and written to result file
+	Query_JOB_STATUS_SAVING_RESULT   Query_JobStatus = 6 // last row is written, result file is being closed and stored
+)
 
-func (x *Query) GetJobError() string {
-	if x != nil {
-		return x.JobError
+// Enum value maps for Query_JobStatus.
+var (
+	Query_JobStatus_name = map[int32]string{
+		0: "JOB_STATUS_UNSPECIFIED",
+		1: "JOB_STATUS_PENDING",
+		2: "JOB_STATUS_RUNNING",
+		3: "JOB_STATUS_DONE",
+		4: "JOB_STATUS_SKIPPED",
+		5: "JOB_STATUS_READING_RESULTS",
+		6: "JOB_STATUS_SAVING_RESULT",
 	}
-	return ""
-}
-
-func (x *Query) GetJobDuration() int64 {
-	if x != nil {
-		return x.JobDuration
+	Query_JobStatus_value = map[string]int32{
+		"JOB_STATUS_UNSPECIFIED":     0,
+		"JOB_STATUS_PENDING":         1,
+		"JOB_STATUS_RUNNING":         2,
+		"JOB_STATUS_DONE":            3,
+		"JOB_STATUS_SKIPPED":         4,
+		"JOB_STATUS_READING_RESULTS": 5,
+		"JOB_STATUS_SAVING_RESULT":   6,
 	}
-	return 0
-}
+)
 
-func (x *Query) GetTotalRows() int32 {
-	if x != nil {
-		return x.TotalRows
-	}
-	return 0
+func (x Query_JobStatus) Enum() *Query_JobStatus {
+	p := new(Query_JobStatus)
+	*p = x
+	return p
 }
 
-func (x *Query) GetBytesProcessed() int64 {
-	if x != nil {
-		return x.BytesProcessed
-	}
-	return 0
+func (x Query_JobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Query) GetResultSize() int64 {
-	if x != nil {
-		return x.ResultSize
-	}
-	return 0
+func (Query_JobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[11].Descriptor()
 }
 
-type UpdateReportRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Report *Report `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+func (Query_JobStatus) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[11]
 }
 
-func (x *UpdateReportRequest) Reset() {
-	*x = UpdateReportRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x Query_JobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *UpdateReportRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use Query_JobStatus.Descriptor instead.
+func (Query_JobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{69, 0}
 }
 
-func (*UpdateReportRequest) ProtoMessage() {}
+type Query_ResultType int32
 
-func (x *UpdateReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	Query_RESULT_TYPE_UNSPECIFIED Query_ResultType = 0 // no result
+	Query_RESULT_TYPE_FILE        Query_ResultType = 1 // job_result_id file, rendered on map
+	Query_RESULT_TYPE_TABLE       Query_ResultType = 2 // result_table in BigQuery, can't be rendered on map
+)
+
+// Enum value maps for Query_ResultType.
+var (
+	Query_ResultType_name = map[int32]string{
+		0: "RESULT_TYPE_UNSPECIFIED",
+		1: "RESULT_TYPE_FILE",
+		2: "RESULT_TYPE_TABLE",
 	}
-	return mi.MessageOf(x)
+	Query_ResultType_value = map[string]int32{
+		"RESULT_TYPE_UNSPECIFIED": 0,
+		"RESULT_TYPE_FILE":        1,
+		"RESULT_TYPE_TABLE":       2,
+	}
+)
+
+func (x Query_ResultType) Enum() *Query_ResultType {
+	p := new(Query_ResultType)
+	*p = x
+	return p
 }
 
-// Deprecated: Use UpdateReportRequest.ProtoReflect.Descriptor instead.
-func (*UpdateReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{9}
+func (x Query_ResultType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *UpdateReportRequest) GetReport() *Report {
-	if x != nil {
-		return x.Report
-	}
-	return nil
+func (Query_ResultType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[12].Descriptor()
 }
 
-type UpdateReportResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (Query_ResultType) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[12]
 }
 
-func (x *UpdateReportResponse) Reset() {
-	*x = UpdateReportResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x Query_ResultType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *UpdateReportResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use Query_ResultType.Descriptor instead.
+func (Query_ResultType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{69, 1}
 }
 
-func (*UpdateReportResponse) ProtoMessage() {}
+type SerializationOptions_BoolStyle int32
 
-func (x *UpdateReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	SerializationOptions_BOOL_STYLE_UNSPECIFIED SerializationOptions_BoolStyle = 0 // true and false
+	SerializationOptions_BOOL_STYLE_NUMERIC     SerializationOptions_BoolStyle = 1 // 1 and 0
+	SerializationOptions_BOOL_STYLE_UPPER       SerializationOptions_BoolStyle = 2 // TRUE and FALSE
+)
+
+// Enum value maps for SerializationOptions_BoolStyle.
+var (
+	SerializationOptions_BoolStyle_name = map[int32]string{
+		0: "BOOL_STYLE_UNSPECIFIED",
+		1: "BOOL_STYLE_NUMERIC",
+		2: "BOOL_STYLE_UPPER",
 	}
-	return mi.MessageOf(x)
+	SerializationOptions_BoolStyle_value = map[string]int32{
+		"BOOL_STYLE_UNSPECIFIED": 0,
+		"BOOL_STYLE_NUMERIC":     1,
+		"BOOL_STYLE_UPPER":       2,
+	}
+)
+
+func (x SerializationOptions_BoolStyle) Enum() *SerializationOptions_BoolStyle {
+	p := new(SerializationOptions_BoolStyle)
+	*p = x
+	return p
 }
 
-// Deprecated: Use UpdateReportResponse.ProtoReflect.Descriptor instead.
-func (*UpdateReportResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{10}
+func (x SerializationOptions_BoolStyle) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type RunQueryRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (SerializationOptions_BoolStyle) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[13].Descriptor()
+}
 
-	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+func (SerializationOptions_BoolStyle) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[13]
 }
 
-func (x *RunQueryRequest) Reset() {
-	*x = RunQueryRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[11]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x SerializationOptions_BoolStyle) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *RunQueryRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use SerializationOptions_BoolStyle.Descriptor instead.
+func (SerializationOptions_BoolStyle) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{75, 0}
 }
 
-func (*RunQueryRequest) ProtoMessage() {}
+type SerializationOptions_BytesEncoding int32
 
-func (x *RunQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[11]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	SerializationOptions_BYTES_ENCODING_UNSPECIFIED SerializationOptions_BytesEncoding = 0 // standard base64 with padding
+	SerializationOptions_BYTES_ENCODING_BASE64      SerializationOptions_BytesEncoding = 1
+	SerializationOptions_BYTES_ENCODING_HEX         SerializationOptions_BytesEncoding = 2 // lowercase hex
+)
+
+// Enum value maps for SerializationOptions_BytesEncoding.
+var (
+	SerializationOptions_BytesEncoding_name = map[int32]string{
+		0: "BYTES_ENCODING_UNSPECIFIED",
+		1: "BYTES_ENCODING_BASE64",
+		2: "BYTES_ENCODING_HEX",
 	}
-	return mi.MessageOf(x)
+	SerializationOptions_BytesEncoding_value = map[string]int32{
+		"BYTES_ENCODING_UNSPECIFIED": 0,
+		"BYTES_ENCODING_BASE64":      1,
+		"BYTES_ENCODING_HEX":         2,
+	}
+)
+
+func (x SerializationOptions_BytesEncoding) Enum() *SerializationOptions_BytesEncoding {
+	p := new(SerializationOptions_BytesEncoding)
+	*p = x
+	return p
 }
 
-// Deprecated: Use RunQueryRequest.ProtoReflect.Descriptor instead.
-func (*RunQueryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{11}
+func (x SerializationOptions_BytesEncoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *RunQueryRequest) GetQueryId() string {
-	if x != nil {
-		return x.QueryId
-	}
-	return ""
+func (SerializationOptions_BytesEncoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[14].Descriptor()
 }
 
-type RunQueryResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (SerializationOptions_BytesEncoding) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[14]
 }
 
-func (x *RunQueryResponse) Reset() {
-	*x = RunQueryResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[12]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x SerializationOptions_BytesEncoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *RunQueryResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use SerializationOptions_BytesEncoding.Descriptor instead.
+func (SerializationOptions_BytesEncoding) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{75, 1}
 }
 
-func (*RunQueryResponse) ProtoMessage() {}
+type SerializationOptions_HeaderNaming int32
 
-func (x *RunQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[12]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	SerializationOptions_HEADER_NAMING_UNSPECIFIED SerializationOptions_HeaderNaming = 0 // original column names
+	SerializationOptions_HEADER_NAMING_SNAKE_CASE  SerializationOptions_HeaderNaming = 1 // lowercase letters and digits separated by underscores, like total_amount
+	SerializationOptions_HEADER_NAMING_STRICT      SerializationOptions_HeaderNaming = 2 // only A-Z, a-z, 0-9 and underscore, not starting with digit
+)
+
+// Enum value maps for SerializationOptions_HeaderNaming.
+var (
+	SerializationOptions_HeaderNaming_name = map[int32]string{
+		0: "HEADER_NAMING_UNSPECIFIED",
+		1: "HEADER_NAMING_SNAKE_CASE",
+		2: "HEADER_NAMING_STRICT",
 	}
-	return mi.MessageOf(x)
+	SerializationOptions_HeaderNaming_value = map[string]int32{
+		"HEADER_NAMING_UNSPECIFIED": 0,
+		"HEADER_NAMING_SNAKE_CASE":  1,
+		"HEADER_NAMING_STRICT":      2,
+	}
+)
+
+func (x SerializationOptions_HeaderNaming) Enum() *SerializationOptions_HeaderNaming {
+	p := new(SerializationOptions_HeaderNaming)
+	*p = x
+	return p
 }
 
-// Deprecated: Use RunQueryResponse.ProtoReflect.Descriptor instead.
-func (*RunQueryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{12}
+func (x SerializationOptions_HeaderNaming) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type RemoveQueryRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (SerializationOptions_HeaderNaming) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[15].Descriptor()
+}
 
-	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+func (SerializationOptions_HeaderNaming) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[15]
 }
 
-func (x *RemoveQueryRequest) Reset() {
-	*x = RemoveQueryRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x SerializationOptions_HeaderNaming) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *RemoveQueryRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use SerializationOptions_HeaderNaming.Descriptor instead.
+func (SerializationOptions_HeaderNaming) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{75, 2}
 }
 
-func (*RemoveQueryRequest) ProtoMessage() {}
+type DestinationTable_WriteDisposition int32
 
-func (x *RemoveQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[13]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	DestinationTable_WRITE_DISPOSITION_UNSPECIFIED DestinationTable_WriteDisposition = 0 // job fails when table has rows
+	DestinationTable_WRITE_DISPOSITION_TRUNCATE    DestinationTable_WriteDisposition = 1
+	DestinationTable_WRITE_DISPOSITION_APPEND      DestinationTable_WriteDisposition = 2
+)
+
+// Enum value maps for DestinationTable_WriteDisposition.
+var (
+	DestinationTable_WriteDisposition_name = map[int32]string{
+		0: "WRITE_DISPOSITION_UNSPECIFIED",
+		1: "WRITE_DISPOSITION_TRUNCATE",
+		2: "WRITE_DISPOSITION_APPEND",
 	}
-	return mi.MessageOf(x)
+	DestinationTable_WriteDisposition_value = map[string]int32{
+		"WRITE_DISPOSITION_UNSPECIFIED": 0,
+		"WRITE_DISPOSITION_TRUNCATE":    1,
+		"WRITE_DISPOSITION_APPEND":      2,
+	}
+)
+
+func (x DestinationTable_WriteDisposition) Enum() *DestinationTable_WriteDisposition {
+	p := new(DestinationTable_WriteDisposition)
+	*p = x
+	return p
 }
 
-// Deprecated: Use RemoveQueryRequest.ProtoReflect.Descriptor instead.
-func (*RemoveQueryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{13}
+func (x DestinationTable_WriteDisposition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *RemoveQueryRequest) GetQueryId() string {
-	if x != nil {
-		return x.QueryId
-	}
-	return ""
+func (DestinationTable_WriteDisposition) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[16].Descriptor()
 }
 
-type RemoveQueryResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (DestinationTable_WriteDisposition) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[16]
 }
 
-func (x *RemoveQueryResponse) Reset() {
-	*x = RemoveQueryResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[14]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x DestinationTable_WriteDisposition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *RemoveQueryResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use DestinationTable_WriteDisposition.Descriptor instead.
+func (DestinationTable_WriteDisposition) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{76, 0}
 }
 
-func (*RemoveQueryResponse) ProtoMessage() {}
+type H3Metric_Function int32
 
-func (x *RemoveQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[14]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	H3Metric_FUNCTION_UNSPECIFIED H3Metric_Function = 0
+	H3Metric_FUNCTION_SUM         H3Metric_Function = 1
+	H3Metric_FUNCTION_AVG         H3Metric_Function = 2
+)
+
+// Enum value maps for H3Metric_Function.
+var (
+	H3Metric_Function_name = map[int32]string{
+		0: "FUNCTION_UNSPECIFIED",
+		1: "FUNCTION_SUM",
+		2: "FUNCTION_AVG",
 	}
-	return mi.MessageOf(x)
+	H3Metric_Function_value = map[string]int32{
+		"FUNCTION_UNSPECIFIED": 0,
+		"FUNCTION_SUM":         1,
+		"FUNCTION_AVG":         2,
+	}
+)
+
+func (x H3Metric_Function) Enum() *H3Metric_Function {
+	p := new(H3Metric_Function)
+	*p = x
+	return p
 }
 
-// Deprecated: Use RemoveQueryResponse.ProtoReflect.Descriptor instead.
-func (*RemoveQueryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{14}
+func (x H3Metric_Function) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-type CancelQueryRequest struct {
+func (H3Metric_Function) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_dekart_proto_enumTypes[17].Descriptor()
+}
+
+func (H3Metric_Function) Type() protoreflect.EnumType {
+	return &file_proto_dekart_proto_enumTypes[17]
+}
+
+func (x H3Metric_Function) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use H3Metric_Function.Descriptor instead.
+func (H3Metric_Function) EnumDescriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{78, 0}
+}
+
+type StreamOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// request: client applies incremental updates; response: message contains only changed entities
+	Incremental     bool   `protobuf:"varint,2,opt,name=incremental,proto3" json:"incremental,omitempty"`
+	DeletedQueryId  string `protobuf:"bytes,3,opt,name=deleted_query_id,json=deletedQueryId,proto3" json:"deleted_query_id,omitempty"`    // response: query removed by change, restorable with RestoreQuery
+	RestoredQueryId string `protobuf:"bytes,4,opt,name=restored_query_id,json=restoredQueryId,proto3" json:"restored_query_id,omitempty"` // response: query restored by change
 }
 
-func (x *CancelQueryRequest) Reset() {
-	*x = CancelQueryRequest{}
+func (x *StreamOptions) Reset() {
+	*x = StreamOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[15]
+		mi := &file_proto_dekart_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelQueryRequest) String() string {
+func (x *StreamOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelQueryRequest) ProtoMessage() {}
+func (*StreamOptions) ProtoMessage() {}
 
-func (x *CancelQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[15]
+func (x *StreamOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -944,41 +997,62 @@ func (x *CancelQueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelQueryRequest.ProtoReflect.Descriptor instead.
-func (*CancelQueryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use StreamOptions.ProtoReflect.Descriptor instead.
+func (*StreamOptions) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *CancelQueryRequest) GetQueryId() string {
+func (x *StreamOptions) GetSequence() int64 {
 	if x != nil {
-		return x.QueryId
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StreamOptions) GetIncremental() bool {
+	if x != nil {
+		return x.Incremental
+	}
+	return false
+}
+
+func (x *StreamOptions) GetDeletedQueryId() string {
+	if x != nil {
+		return x.DeletedQueryId
 	}
 	return ""
 }
 
-type CancelQueryResponse struct {
+func (x *StreamOptions) GetRestoredQueryId() string {
+	if x != nil {
+		return x.RestoredQueryId
+	}
+	return ""
+}
+
+type GetEnvRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *CancelQueryResponse) Reset() {
-	*x = CancelQueryResponse{}
+func (x *GetEnvRequest) Reset() {
+	*x = GetEnvRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[16]
+		mi := &file_proto_dekart_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CancelQueryResponse) String() string {
+func (x *GetEnvRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelQueryResponse) ProtoMessage() {}
+func (*GetEnvRequest) ProtoMessage() {}
 
-func (x *CancelQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[16]
+func (x *GetEnvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,36 +1063,36 @@ func (x *CancelQueryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelQueryResponse.ProtoReflect.Descriptor instead.
-func (*CancelQueryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetEnvRequest.ProtoReflect.Descriptor instead.
+func (*GetEnvRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{1}
 }
 
-type UpdateQueryRequest struct {
+type GetEnvResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Variables []*GetEnvResponse_Variable `protobuf:"bytes,1,rep,name=variables,proto3" json:"variables,omitempty"`
 }
 
-func (x *UpdateQueryRequest) Reset() {
-	*x = UpdateQueryRequest{}
+func (x *GetEnvResponse) Reset() {
+	*x = GetEnvResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[17]
+		mi := &file_proto_dekart_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateQueryRequest) String() string {
+func (x *GetEnvResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateQueryRequest) ProtoMessage() {}
+func (*GetEnvResponse) ProtoMessage() {}
 
-func (x *UpdateQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[17]
+func (x *GetEnvResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1029,43 +1103,41 @@ func (x *UpdateQueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateQueryRequest.ProtoReflect.Descriptor instead.
-func (*UpdateQueryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetEnvResponse.ProtoReflect.Descriptor instead.
+func (*GetEnvResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *UpdateQueryRequest) GetQuery() *Query {
+func (x *GetEnvResponse) GetVariables() []*GetEnvResponse_Variable {
 	if x != nil {
-		return x.Query
+		return x.Variables
 	}
 	return nil
 }
 
-type UpdateQueryResponse struct {
+type GetCurrentUserRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 }
 
-func (x *UpdateQueryResponse) Reset() {
-	*x = UpdateQueryResponse{}
+func (x *GetCurrentUserRequest) Reset() {
+	*x = GetCurrentUserRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[18]
+		mi := &file_proto_dekart_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateQueryResponse) String() string {
+func (x *GetCurrentUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateQueryResponse) ProtoMessage() {}
+func (*GetCurrentUserRequest) ProtoMessage() {}
 
-func (x *UpdateQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[18]
+func (x *GetCurrentUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1076,43 +1148,38 @@ func (x *UpdateQueryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateQueryResponse.ProtoReflect.Descriptor instead.
-func (*UpdateQueryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{18}
-}
-
-func (x *UpdateQueryResponse) GetQuery() *Query {
-	if x != nil {
-		return x.Query
-	}
-	return nil
+// Deprecated: Use GetCurrentUserRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{3}
 }
 
-type CreateQueryRequest struct {
+type GetCurrentUserResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Email string      `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Role  Role        `protobuf:"varint,2,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
+	Quota *UsageQuota `protobuf:"bytes,3,opt,name=quota,proto3" json:"quota,omitempty"` // headroom_percent and reset_time only, set when monthly budget is configured
 }
 
-func (x *CreateQueryRequest) Reset() {
-	*x = CreateQueryRequest{}
+func (x *GetCurrentUserResponse) Reset() {
+	*x = GetCurrentUserResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[19]
+		mi := &file_proto_dekart_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateQueryRequest) String() string {
+func (x *GetCurrentUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateQueryRequest) ProtoMessage() {}
+func (*GetCurrentUserResponse) ProtoMessage() {}
 
-func (x *CreateQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[19]
+func (x *GetCurrentUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1123,43 +1190,58 @@ func (x *CreateQueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateQueryRequest.ProtoReflect.Descriptor instead.
-func (*CreateQueryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use GetCurrentUserResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateQueryRequest) GetQuery() *Query {
+func (x *GetCurrentUserResponse) GetEmail() string {
 	if x != nil {
-		return x.Query
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GetCurrentUserResponse) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *GetCurrentUserResponse) GetQuota() *UsageQuota {
+	if x != nil {
+		return x.Quota
 	}
 	return nil
 }
 
-type CreateQueryResponse struct {
+type RoleAssignment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	EmailPattern string `protobuf:"bytes,1,opt,name=email_pattern,json=emailPattern,proto3" json:"email_pattern,omitempty"`
+	Role         Role   `protobuf:"varint,2,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
 }
 
-func (x *CreateQueryResponse) Reset() {
-	*x = CreateQueryResponse{}
+func (x *RoleAssignment) Reset() {
+	*x = RoleAssignment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[20]
+		mi := &file_proto_dekart_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateQueryResponse) String() string {
+func (x *RoleAssignment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateQueryResponse) ProtoMessage() {}
+func (*RoleAssignment) ProtoMessage() {}
 
-func (x *CreateQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[20]
+func (x *RoleAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1170,44 +1252,48 @@ func (x *CreateQueryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateQueryResponse.ProtoReflect.Descriptor instead.
-func (*CreateQueryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use RoleAssignment.ProtoReflect.Descriptor instead.
+func (*RoleAssignment) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CreateQueryResponse) GetQuery() *Query {
+func (x *RoleAssignment) GetEmailPattern() string {
 	if x != nil {
-		return x.Query
+		return x.EmailPattern
 	}
-	return nil
+	return ""
 }
 
-type ReportStreamRequest struct {
+func (x *RoleAssignment) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type ListRoleAssignmentsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Report        *Report        `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
-	StreamOptions *StreamOptions `protobuf:"bytes,2,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
 }
 
-func (x *ReportStreamRequest) Reset() {
-	*x = ReportStreamRequest{}
+func (x *ListRoleAssignmentsRequest) Reset() {
+	*x = ListRoleAssignmentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[21]
+		mi := &file_proto_dekart_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReportStreamRequest) String() string {
+func (x *ListRoleAssignmentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportStreamRequest) ProtoMessage() {}
+func (*ListRoleAssignmentsRequest) ProtoMessage() {}
 
-func (x *ReportStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[21]
+func (x *ListRoleAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,52 +1304,36 @@ func (x *ReportStreamRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportStreamRequest.ProtoReflect.Descriptor instead.
-func (*ReportStreamRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *ReportStreamRequest) GetReport() *Report {
-	if x != nil {
-		return x.Report
-	}
-	return nil
-}
-
-func (x *ReportStreamRequest) GetStreamOptions() *StreamOptions {
-	if x != nil {
-		return x.StreamOptions
-	}
-	return nil
+// Deprecated: Use ListRoleAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{6}
 }
 
-type ReportStreamResponse struct {
+type ListRoleAssignmentsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Report        *Report        `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
-	Queries       []*Query       `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries,omitempty"`
-	StreamOptions *StreamOptions `protobuf:"bytes,3,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+	RoleAssignments []*RoleAssignment `protobuf:"bytes,1,rep,name=role_assignments,json=roleAssignments,proto3" json:"role_assignments,omitempty"`
 }
 
-func (x *ReportStreamResponse) Reset() {
-	*x = ReportStreamResponse{}
+func (x *ListRoleAssignmentsResponse) Reset() {
+	*x = ListRoleAssignmentsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[22]
+		mi := &file_proto_dekart_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ReportStreamResponse) String() string {
+func (x *ListRoleAssignmentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportStreamResponse) ProtoMessage() {}
+func (*ListRoleAssignmentsResponse) ProtoMessage() {}
 
-func (x *ReportStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[22]
+func (x *ListRoleAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1274,57 +1344,128 @@ func (x *ReportStreamResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportStreamResponse.ProtoReflect.Descriptor instead.
-func (*ReportStreamResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ListRoleAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ReportStreamResponse) GetReport() *Report {
+func (x *ListRoleAssignmentsResponse) GetRoleAssignments() []*RoleAssignment {
 	if x != nil {
-		return x.Report
+		return x.RoleAssignments
 	}
 	return nil
 }
 
-func (x *ReportStreamResponse) GetQueries() []*Query {
-	if x != nil {
-		return x.Queries
+type SetRoleAssignmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoleAssignment *RoleAssignment `protobuf:"bytes,1,opt,name=role_assignment,json=roleAssignment,proto3" json:"role_assignment,omitempty"`
+}
+
+func (x *SetRoleAssignmentRequest) Reset() {
+	*x = SetRoleAssignmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ReportStreamResponse) GetStreamOptions() *StreamOptions {
+func (x *SetRoleAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleAssignmentRequest) ProtoMessage() {}
+
+func (x *SetRoleAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*SetRoleAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetRoleAssignmentRequest) GetRoleAssignment() *RoleAssignment {
 	if x != nil {
-		return x.StreamOptions
+		return x.RoleAssignment
 	}
 	return nil
 }
 
-type ForkReportRequest struct {
+type SetRoleAssignmentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+func (x *SetRoleAssignmentResponse) Reset() {
+	*x = SetRoleAssignmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *ForkReportRequest) Reset() {
-	*x = ForkReportRequest{}
+func (x *SetRoleAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoleAssignmentResponse) ProtoMessage() {}
+
+func (x *SetRoleAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoleAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*SetRoleAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{9}
+}
+
+type RemoveRoleAssignmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmailPattern string `protobuf:"bytes,1,opt,name=email_pattern,json=emailPattern,proto3" json:"email_pattern,omitempty"`
+}
+
+func (x *RemoveRoleAssignmentRequest) Reset() {
+	*x = RemoveRoleAssignmentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[23]
+		mi := &file_proto_dekart_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ForkReportRequest) String() string {
+func (x *RemoveRoleAssignmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForkReportRequest) ProtoMessage() {}
+func (*RemoveRoleAssignmentRequest) ProtoMessage() {}
 
-func (x *ForkReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[23]
+func (x *RemoveRoleAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1335,43 +1476,81 @@ func (x *ForkReportRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForkReportRequest.ProtoReflect.Descriptor instead.
-func (*ForkReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RemoveRoleAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveRoleAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ForkReportRequest) GetReportId() string {
+func (x *RemoveRoleAssignmentRequest) GetEmailPattern() string {
 	if x != nil {
-		return x.ReportId
+		return x.EmailPattern
 	}
 	return ""
 }
 
-type ForkReportResponse struct {
+type RemoveRoleAssignmentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+func (x *RemoveRoleAssignmentResponse) Reset() {
+	*x = RemoveRoleAssignmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *ForkReportResponse) Reset() {
-	*x = ForkReportResponse{}
+func (x *RemoveRoleAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveRoleAssignmentResponse) ProtoMessage() {}
+
+func (x *RemoveRoleAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveRoleAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*RemoveRoleAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{11}
+}
+
+type GetResultLifecycleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OlderThanDays int32 `protobuf:"varint,1,opt,name=older_than_days,json=olderThanDays,proto3" json:"older_than_days,omitempty"` // age cutoff of results
+}
+
+func (x *GetResultLifecycleRequest) Reset() {
+	*x = GetResultLifecycleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[24]
+		mi := &file_proto_dekart_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ForkReportResponse) String() string {
+func (x *GetResultLifecycleRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ForkReportResponse) ProtoMessage() {}
+func (*GetResultLifecycleRequest) ProtoMessage() {}
 
-func (x *ForkReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[24]
+func (x *GetResultLifecycleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1382,41 +1561,46 @@ func (x *ForkReportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ForkReportResponse.ProtoReflect.Descriptor instead.
-func (*ForkReportResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use GetResultLifecycleRequest.ProtoReflect.Descriptor instead.
+func (*GetResultLifecycleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ForkReportResponse) GetReportId() string {
+func (x *GetResultLifecycleRequest) GetOlderThanDays() int32 {
 	if x != nil {
-		return x.ReportId
+		return x.OlderThanDays
 	}
-	return ""
+	return 0
 }
 
-type CreateReportRequest struct {
+type GetResultLifecycleResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	StoragePrefix        string `protobuf:"bytes,1,opt,name=storage_prefix,json=storagePrefix,proto3" json:"storage_prefix,omitempty"`                         // template of result object names, {reportID} and {date} placeholders
+	DateLayout           string `protobuf:"bytes,2,opt,name=date_layout,json=dateLayout,proto3" json:"date_layout,omitempty"`                                  // layout of {date}, YYYY/MM/DD in UTC
+	AffectedCount        int64  `protobuf:"varint,3,opt,name=affected_count,json=affectedCount,proto3" json:"affected_count,omitempty"`                        // results older than cutoff, not expired yet
+	AffectedCurrentCount int64  `protobuf:"varint,4,opt,name=affected_current_count,json=affectedCurrentCount,proto3" json:"affected_current_count,omitempty"` // affected results which are current results of queries
 }
 
-func (x *CreateReportRequest) Reset() {
-	*x = CreateReportRequest{}
+func (x *GetResultLifecycleResponse) Reset() {
+	*x = GetResultLifecycleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[25]
+		mi := &file_proto_dekart_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateReportRequest) String() string {
+func (x *GetResultLifecycleResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateReportRequest) ProtoMessage() {}
+func (*GetResultLifecycleResponse) ProtoMessage() {}
 
-func (x *CreateReportRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[25]
+func (x *GetResultLifecycleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1427,36 +1611,64 @@ func (x *CreateReportRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateReportRequest.ProtoReflect.Descriptor instead.
-func (*CreateReportRequest) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use GetResultLifecycleResponse.ProtoReflect.Descriptor instead.
+func (*GetResultLifecycleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{13}
 }
 
-type CreateReportResponse struct {
+func (x *GetResultLifecycleResponse) GetStoragePrefix() string {
+	if x != nil {
+		return x.StoragePrefix
+	}
+	return ""
+}
+
+func (x *GetResultLifecycleResponse) GetDateLayout() string {
+	if x != nil {
+		return x.DateLayout
+	}
+	return ""
+}
+
+func (x *GetResultLifecycleResponse) GetAffectedCount() int64 {
+	if x != nil {
+		return x.AffectedCount
+	}
+	return 0
+}
+
+func (x *GetResultLifecycleResponse) GetAffectedCurrentCount() int64 {
+	if x != nil {
+		return x.AffectedCurrentCount
+	}
+	return 0
+}
+
+type ReconcileResultsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Report *Report `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // number of results checked, 1000 by default; least recently checked first
 }
 
-func (x *CreateReportResponse) Reset() {
-	*x = CreateReportResponse{}
+func (x *ReconcileResultsRequest) Reset() {
+	*x = ReconcileResultsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[26]
+		mi := &file_proto_dekart_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateReportResponse) String() string {
+func (x *ReconcileResultsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateReportResponse) ProtoMessage() {}
+func (*ReconcileResultsRequest) ProtoMessage() {}
 
-func (x *CreateReportResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[26]
+func (x *ReconcileResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1467,364 +1679,12424 @@ func (x *CreateReportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateReportResponse.ProtoReflect.Descriptor instead.
-func (*CreateReportResponse) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ReconcileResultsRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileResultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CreateReportResponse) GetReport() *Report {
+func (x *ReconcileResultsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Report
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type GetEnvResponse_Variable struct {
+type ReconcileResultsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type  GetEnvResponse_Variable_Type `protobuf:"varint,1,opt,name=type,proto3,enum=GetEnvResponse_Variable_Type" json:"type,omitempty"`
-	Value string                       `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	CheckedCount int64 `protobuf:"varint,1,opt,name=checked_count,json=checkedCount,proto3" json:"checked_count,omitempty"`
+	ExpiredCount int64 `protobuf:"varint,2,opt,name=expired_count,json=expiredCount,proto3" json:"expired_count,omitempty"` // results with missing objects, marked expired
+	ErrorCount   int64 `protobuf:"varint,3,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`       // results not checked because of storage errors
 }
 
-func (x *GetEnvResponse_Variable) Reset() {
-	*x = GetEnvResponse_Variable{}
+func (x *ReconcileResultsResponse) Reset() {
+	*x = ReconcileResultsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_dekart_proto_msgTypes[27]
+		mi := &file_proto_dekart_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetEnvResponse_Variable) String() string {
+func (x *ReconcileResultsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetEnvResponse_Variable) ProtoMessage() {}
+func (*ReconcileResultsResponse) ProtoMessage() {}
 
-func (x *GetEnvResponse_Variable) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_dekart_proto_msgTypes[27]
+func (x *ReconcileResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileResultsResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileResultsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReconcileResultsResponse) GetCheckedCount() int64 {
+	if x != nil {
+		return x.CheckedCount
+	}
+	return 0
+}
+
+func (x *ReconcileResultsResponse) GetExpiredCount() int64 {
+	if x != nil {
+		return x.ExpiredCount
+	}
+	return 0
+}
+
+func (x *ReconcileResultsResponse) GetErrorCount() int64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+type ReencryptColumnsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReencryptColumnsRequest) Reset() {
+	*x = ReencryptColumnsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReencryptColumnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReencryptColumnsRequest) ProtoMessage() {}
+
+func (x *ReencryptColumnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetEnvResponse_Variable.ProtoReflect.Descriptor instead.
-func (*GetEnvResponse_Variable) Descriptor() ([]byte, []int) {
-	return file_proto_dekart_proto_rawDescGZIP(), []int{2, 0}
-}
-
-func (x *GetEnvResponse_Variable) GetType() GetEnvResponse_Variable_Type {
-	if x != nil {
-		return x.Type
-	}
-	return GetEnvResponse_Variable_TYPE_UNSPECIFIED
-}
-
-func (x *GetEnvResponse_Variable) GetValue() string {
-	if x != nil {
-		return x.Value
-	}
-	return ""
-}
-
-var File_proto_dekart_proto protoreflect.FileDescriptor
-
-var file_proto_dekart_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x6b, 0x61, 0x72, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2b, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x22, 0x89, 0x02, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e,
-	0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x1a, 0xbe, 0x01,
-	0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e,
-	0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x69, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x41, 0x50, 0x42, 0x4f, 0x58,
-	0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x55, 0x58, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e,
-	0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x55, 0x58, 0x5f, 0x48, 0x4f, 0x4d, 0x45, 0x50, 0x41, 0x47, 0x45, 0x10, 0x03, 0x22, 0x4d,
-	0x0a, 0x14, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x22, 0x17, 0x0a,
-	0x15, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x0e, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0x6e, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x0e, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a,
-	0x0a, 0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x6d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
-	0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1b,
-	0x0a, 0x09, 0x63, 0x61, 0x6e, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x22, 0xbf, 0x03, 0x0a, 0x05,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78,
-	0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x6a, 0x6f, 0x62, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4a, 0x6f,
-	0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x6f, 0x62, 0x5f, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x6f, 0x62, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
-	0x72, 0x6f, 0x77, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
-	0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x1f,
-	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x22,
-	0x6c, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x16,
-	0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4a, 0x4f, 0x42, 0x5f,
-	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01,
-	0x12, 0x16, 0x0a, 0x12, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52,
-	0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x4a, 0x4f, 0x42, 0x5f,
-	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x22, 0x36, 0x0a,
-	0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2c, 0x0a,
-	0x0f, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x22, 0x12, 0x0a, 0x10, 0x52,
-	0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x2f, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64,
-	0x22, 0x15, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2f, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
-	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x32, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x22, 0x33, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x32, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
-	0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x33, 0x0a, 0x13,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x22, 0x6d, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x35, 0x0a, 0x0e, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x22, 0x90, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x07, 0x71, 0x75,
-	0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x0e,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x30, 0x0a, 0x11, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x37, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x32, 0xd9, 0x05, 0x0a, 0x06, 0x44, 0x65, 0x6b,
-	0x61, 0x72, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70,
-	0x6f, 0x72, 0x74, 0x12, 0x14, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x12, 0x12, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x41, 0x72,
-	0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x15, 0x2e, 0x41, 0x72,
-	0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x14, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x12, 0x10, 0x2e, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65,
-	0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x43, 0x61,
-	0x6e, 0x63, 0x65, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x12, 0x13, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x2b, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x0e, 0x2e, 0x47, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x47, 0x65, 0x74, 0x45,
-	0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0f,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
-	0x14, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01,
-	0x12, 0x42, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73,
-	0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x52, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x30, 0x01, 0x42, 0x07, 0x5a, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_proto_dekart_proto_rawDescOnce sync.Once
-	file_proto_dekart_proto_rawDescData = file_proto_dekart_proto_rawDesc
-)
-
-func file_proto_dekart_proto_rawDescGZIP() []byte {
-	file_proto_dekart_proto_rawDescOnce.Do(func() {
-		file_proto_dekart_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dekart_proto_rawDescData)
-	})
-	return file_proto_dekart_proto_rawDescData
-}
-
-var file_proto_dekart_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_dekart_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
-var file_proto_dekart_proto_goTypes = []interface{}{
-	(GetEnvResponse_Variable_Type)(0), // 0: GetEnvResponse.Variable.Type
-	(Query_JobStatus)(0),              // 1: Query.JobStatus
-	(*StreamOptions)(nil),             // 2: StreamOptions
-	(*GetEnvRequest)(nil),             // 3: GetEnvRequest
-	(*GetEnvResponse)(nil),            // 4: GetEnvResponse
-	(*ArchiveReportRequest)(nil),      // 5: ArchiveReportRequest
-	(*ArchiveReportResponse)(nil),     // 6: ArchiveReportResponse
-	(*ReportListRequest)(nil),         // 7: ReportListRequest
-	(*ReportListResponse)(nil),        // 8: ReportListResponse
-	(*Report)(nil),                    // 9: Report
-	(*Query)(nil),                     // 10: Query
-	(*UpdateReportRequest)(nil),       // 11: UpdateReportRequest
-	(*UpdateReportResponse)(nil),      // 12: UpdateReportResponse
-	(*RunQueryRequest)(nil),           // 13: RunQueryRequest
-	(*RunQueryResponse)(nil),          // 14: RunQueryResponse
-	(*RemoveQueryRequest)(nil),        // 15: RemoveQueryRequest
-	(*RemoveQueryResponse)(nil),       // 16: RemoveQueryResponse
-	(*CancelQueryRequest)(nil),        // 17: CancelQueryRequest
-	(*CancelQueryResponse)(nil),       // 18: CancelQueryResponse
-	(*UpdateQueryRequest)(nil),        // 19: UpdateQueryRequest
-	(*UpdateQueryResponse)(nil),       // 20: UpdateQueryResponse
-	(*CreateQueryRequest)(nil),        // 21: CreateQueryRequest
-	(*CreateQueryResponse)(nil),       // 22: CreateQueryResponse
-	(*ReportStreamRequest)(nil),       // 23: ReportStreamRequest
-	(*ReportStreamResponse)(nil),      // 24: ReportStreamResponse
-	(*ForkReportRequest)(nil),         // 25: ForkReportRequest
-	(*ForkReportResponse)(nil),        // 26: ForkReportResponse
-	(*CreateReportRequest)(nil),       // 27: CreateReportRequest
-	(*CreateReportResponse)(nil),      // 28: CreateReportResponse
-	(*GetEnvResponse_Variable)(nil),   // 29: GetEnvResponse.Variable
-}
-var file_proto_dekart_proto_depIdxs = []int32{
-	29, // 0: GetEnvResponse.variables:type_name -> GetEnvResponse.Variable
-	2,  // 1: ReportListRequest.stream_options:type_name -> StreamOptions
-	9,  // 2: ReportListResponse.reports:type_name -> Report
-	2,  // 3: ReportListResponse.stream_options:type_name -> StreamOptions
-	1,  // 4: Query.job_status:type_name -> Query.JobStatus
-	9,  // 5: UpdateReportRequest.report:type_name -> Report
-	10, // 6: UpdateQueryRequest.query:type_name -> Query
-	10, // 7: UpdateQueryResponse.query:type_name -> Query
-	10, // 8: CreateQueryRequest.query:type_name -> Query
-	10, // 9: CreateQueryResponse.query:type_name -> Query
-	9,  // 10: ReportStreamRequest.report:type_name -> Report
-	2,  // 11: ReportStreamRequest.stream_options:type_name -> StreamOptions
-	9,  // 12: ReportStreamResponse.report:type_name -> Report
-	10, // 13: ReportStreamResponse.queries:type_name -> Query
-	2,  // 14: ReportStreamResponse.stream_options:type_name -> StreamOptions
-	9,  // 15: CreateReportResponse.report:type_name -> Report
-	0,  // 16: GetEnvResponse.Variable.type:type_name -> GetEnvResponse.Variable.Type
-	27, // 17: Dekart.CreateReport:input_type -> CreateReportRequest
-	25, // 18: Dekart.ForkReport:input_type -> ForkReportRequest
-	11, // 19: Dekart.UpdateReport:input_type -> UpdateReportRequest
-	5,  // 20: Dekart.ArchiveReport:input_type -> ArchiveReportRequest
-	21, // 21: Dekart.CreateQuery:input_type -> CreateQueryRequest
-	19, // 22: Dekart.UpdateQuery:input_type -> UpdateQueryRequest
-	13, // 23: Dekart.RunQuery:input_type -> RunQueryRequest
-	17, // 24: Dekart.CancelQuery:input_type -> CancelQueryRequest
-	15, // 25: Dekart.RemoveQuery:input_type -> RemoveQueryRequest
-	3,  // 26: Dekart.GetEnv:input_type -> GetEnvRequest
-	23, // 27: Dekart.GetReportStream:input_type -> ReportStreamRequest
-	7,  // 28: Dekart.GetReportListStream:input_type -> ReportListRequest
-	28, // 29: Dekart.CreateReport:output_type -> CreateReportResponse
-	26, // 30: Dekart.ForkReport:output_type -> ForkReportResponse
-	12, // 31: Dekart.UpdateReport:output_type -> UpdateReportResponse
-	6,  // 32: Dekart.ArchiveReport:output_type -> ArchiveReportResponse
-	22, // 33: Dekart.CreateQuery:output_type -> CreateQueryResponse
-	20, // 34: Dekart.UpdateQuery:output_type -> UpdateQueryResponse
-	14, // 35: Dekart.RunQuery:output_type -> RunQueryResponse
-	18, // 36: Dekart.CancelQuery:output_type -> CancelQueryResponse
-	16, // 37: Dekart.RemoveQuery:output_type -> RemoveQueryResponse
-	4,  // 38: Dekart.GetEnv:output_type -> GetEnvResponse
-	24, // 39: Dekart.GetReportStream:output_type -> ReportStreamResponse
-	8,  // 40: Dekart.GetReportListStream:output_type -> ReportListResponse
-	29, // [29:41] is the sub-list for method output_type
-	17, // [17:29] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
-}
-
-func init() { file_proto_dekart_proto_init() }
-func file_proto_dekart_proto_init() {
-	if File_proto_dekart_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_proto_dekart_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamOptions); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReencryptColumnsRequest.ProtoReflect.Descriptor instead.
+func (*ReencryptColumnsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{16}
+}
+
+// ReencryptColumnsResponse of encrypted columns; plaintext values and values of older keys are encrypted with primary key
+type ReencryptColumnsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PrimaryKeyId     string `protobuf:"bytes,1,opt,name=primary_key_id,json=primaryKeyId,proto3" json:"primary_key_id,omitempty"`
+	CheckedCount     int64  `protobuf:"varint,2,opt,name=checked_count,json=checkedCount,proto3" json:"checked_count,omitempty"`             // rows checked
+	ReencryptedCount int64  `protobuf:"varint,3,opt,name=reencrypted_count,json=reencryptedCount,proto3" json:"reencrypted_count,omitempty"` // rows with values encrypted with primary key
+	FailedCount      int64  `protobuf:"varint,4,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`                // rows with values which can't be decrypted, logged with key id
+}
+
+func (x *ReencryptColumnsResponse) Reset() {
+	*x = ReencryptColumnsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReencryptColumnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReencryptColumnsResponse) ProtoMessage() {}
+
+func (x *ReencryptColumnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReencryptColumnsResponse.ProtoReflect.Descriptor instead.
+func (*ReencryptColumnsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReencryptColumnsResponse) GetPrimaryKeyId() string {
+	if x != nil {
+		return x.PrimaryKeyId
+	}
+	return ""
+}
+
+func (x *ReencryptColumnsResponse) GetCheckedCount() int64 {
+	if x != nil {
+		return x.CheckedCount
+	}
+	return 0
+}
+
+func (x *ReencryptColumnsResponse) GetReencryptedCount() int64 {
+	if x != nil {
+		return x.ReencryptedCount
+	}
+	return 0
+}
+
+func (x *ReencryptColumnsResponse) GetFailedCount() int64 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+// LogSettings of replica, changes are not persisted and apply only to replica handling request
+type LogSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InfoSampleEvery  int32 `protobuf:"varint,1,opt,name=info_sample_every,json=infoSampleEvery,proto3" json:"info_sample_every,omitempty"`      // log 1 of N info messages, 0 and 1 log all; warnings and slow queries are always logged
+	SlowQueryWaitMs  int64 `protobuf:"varint,2,opt,name=slow_query_wait_ms,json=slowQueryWaitMs,proto3" json:"slow_query_wait_ms,omitempty"`    // log jobs waiting for BigQuery longer, 0 disables
+	SlowQueryTotalMs int64 `protobuf:"varint,3,opt,name=slow_query_total_ms,json=slowQueryTotalMs,proto3" json:"slow_query_total_ms,omitempty"` // log jobs running longer from creation to stored result, 0 disables
+}
+
+func (x *LogSettings) Reset() {
+	*x = LogSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSettings) ProtoMessage() {}
+
+func (x *LogSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSettings.ProtoReflect.Descriptor instead.
+func (*LogSettings) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LogSettings) GetInfoSampleEvery() int32 {
+	if x != nil {
+		return x.InfoSampleEvery
+	}
+	return 0
+}
+
+func (x *LogSettings) GetSlowQueryWaitMs() int64 {
+	if x != nil {
+		return x.SlowQueryWaitMs
+	}
+	return 0
+}
+
+func (x *LogSettings) GetSlowQueryTotalMs() int64 {
+	if x != nil {
+		return x.SlowQueryTotalMs
+	}
+	return 0
+}
+
+type GetLogSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLogSettingsRequest) Reset() {
+	*x = GetLogSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogSettingsRequest) ProtoMessage() {}
+
+func (x *GetLogSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{19}
+}
+
+type GetLogSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *LogSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *GetLogSettingsResponse) Reset() {
+	*x = GetLogSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogSettingsResponse) ProtoMessage() {}
+
+func (x *GetLogSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetLogSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetLogSettingsResponse) GetSettings() *LogSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateLogSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *LogSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"` // replaces all settings
+}
+
+func (x *UpdateLogSettingsRequest) Reset() {
+	*x = UpdateLogSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLogSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLogSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateLogSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLogSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLogSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateLogSettingsRequest) GetSettings() *LogSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateLogSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Settings *LogSettings `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+}
+
+func (x *UpdateLogSettingsResponse) Reset() {
+	*x = UpdateLogSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLogSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLogSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateLogSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLogSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLogSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateLogSettingsResponse) GetSettings() *LogSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type GetPermissionReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Refresh bool `protobuf:"varint,1,opt,name=refresh,proto3" json:"refresh,omitempty"` // test permissions again instead of returning report of startup
+}
+
+func (x *GetPermissionReportRequest) Reset() {
+	*x = GetPermissionReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPermissionReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionReportRequest) ProtoMessage() {}
+
+func (x *GetPermissionReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionReportRequest.ProtoReflect.Descriptor instead.
+func (*GetPermissionReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetPermissionReportRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+// PermissionCheck of service account, tested with testIamPermissions
+type PermissionCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceType string `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"` // project or bucket
+	Resource     string `protobuf:"bytes,2,opt,name=resource,proto3" json:"resource,omitempty"`
+	Permission   string `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`
+	Role         string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"` // predefined role granting permission
+	Granted      bool   `protobuf:"varint,5,opt,name=granted,proto3" json:"granted,omitempty"`
+	Error        string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"` // permissions of resource couldn't be tested
+}
+
+func (x *PermissionCheck) Reset() {
+	*x = PermissionCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PermissionCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PermissionCheck) ProtoMessage() {}
+
+func (x *PermissionCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PermissionCheck.ProtoReflect.Descriptor instead.
+func (*PermissionCheck) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PermissionCheck) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *PermissionCheck) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *PermissionCheck) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+func (x *PermissionCheck) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *PermissionCheck) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+func (x *PermissionCheck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetPermissionReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CheckedAt int64              `protobuf:"varint,1,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"` // unix seconds, 0 when permissions were not checked on replica
+	Checks    []*PermissionCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+}
+
+func (x *GetPermissionReportResponse) Reset() {
+	*x = GetPermissionReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPermissionReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPermissionReportResponse) ProtoMessage() {}
+
+func (x *GetPermissionReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPermissionReportResponse.ProtoReflect.Descriptor instead.
+func (*GetPermissionReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetPermissionReportResponse) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *GetPermissionReportResponse) GetChecks() []*PermissionCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type GetUsageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromTime int64                   `protobuf:"varint,1,opt,name=from_time,json=fromTime,proto3" json:"from_time,omitempty"` // unix seconds, inclusive; 30 days ago by default
+	ToTime   int64                   `protobuf:"varint,2,opt,name=to_time,json=toTime,proto3" json:"to_time,omitempty"`       // unix seconds, exclusive; now by default
+	GroupBy  GetUsageRequest_GroupBy `protobuf:"varint,3,opt,name=group_by,json=groupBy,proto3,enum=GetUsageRequest_GroupBy" json:"group_by,omitempty"`
+}
+
+func (x *GetUsageRequest) Reset() {
+	*x = GetUsageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageRequest) ProtoMessage() {}
+
+func (x *GetUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetUsageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetUsageRequest) GetFromTime() int64 {
+	if x != nil {
+		return x.FromTime
+	}
+	return 0
+}
+
+func (x *GetUsageRequest) GetToTime() int64 {
+	if x != nil {
+		return x.ToTime
+	}
+	return 0
+}
+
+func (x *GetUsageRequest) GetGroupBy() GetUsageRequest_GroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return GetUsageRequest_GROUP_BY_UNSPECIFIED
+}
+
+// Usage of BigQuery by jobs finished in time range
+type Usage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key            string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // user email, report id or day; empty for total
+	Jobs           int64  `protobuf:"varint,2,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	BytesProcessed int64  `protobuf:"varint,3,opt,name=bytes_processed,json=bytesProcessed,proto3" json:"bytes_processed,omitempty"`
+	BytesBilled    int64  `protobuf:"varint,4,opt,name=bytes_billed,json=bytesBilled,proto3" json:"bytes_billed,omitempty"`
+	CacheHits      int64  `protobuf:"varint,5,opt,name=cache_hits,json=cacheHits,proto3" json:"cache_hits,omitempty"`    // jobs answered from BigQuery cache
+	DurationMs     int64  `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // sum of job durations
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Usage) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Usage) GetJobs() int64 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
+}
+
+func (x *Usage) GetBytesProcessed() int64 {
+	if x != nil {
+		return x.BytesProcessed
+	}
+	return 0
+}
+
+func (x *Usage) GetBytesBilled() int64 {
+	if x != nil {
+		return x.BytesBilled
+	}
+	return 0
+}
+
+func (x *Usage) GetCacheHits() int64 {
+	if x != nil {
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *Usage) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// UsageQuota of monthly budget of bytes billed, DEKART_MONTHLY_BYTES_BILLED_BUDGET
+type UsageQuota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Month           string  `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM in UTC
+	BudgetBytes     int64   `protobuf:"varint,2,opt,name=budget_bytes,json=budgetBytes,proto3" json:"budget_bytes,omitempty"`
+	BytesBilled     int64   `protobuf:"varint,3,opt,name=bytes_billed,json=bytesBilled,proto3" json:"bytes_billed,omitempty"`              // billed by jobs finished in month
+	HeadroomPercent float64 `protobuf:"fixed64,4,opt,name=headroom_percent,json=headroomPercent,proto3" json:"headroom_percent,omitempty"` // part of budget left, 0 when budget is exhausted
+	ResetTime       int64   `protobuf:"varint,5,opt,name=reset_time,json=resetTime,proto3" json:"reset_time,omitempty"`                    // unix seconds of start of next month, when budget is available again
+}
+
+func (x *UsageQuota) Reset() {
+	*x = UsageQuota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageQuota) ProtoMessage() {}
+
+func (x *UsageQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageQuota.ProtoReflect.Descriptor instead.
+func (*UsageQuota) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UsageQuota) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *UsageQuota) GetBudgetBytes() int64 {
+	if x != nil {
+		return x.BudgetBytes
+	}
+	return 0
+}
+
+func (x *UsageQuota) GetBytesBilled() int64 {
+	if x != nil {
+		return x.BytesBilled
+	}
+	return 0
+}
+
+func (x *UsageQuota) GetHeadroomPercent() float64 {
+	if x != nil {
+		return x.HeadroomPercent
+	}
+	return 0
+}
+
+func (x *UsageQuota) GetResetTime() int64 {
+	if x != nil {
+		return x.ResetTime
+	}
+	return 0
+}
+
+type GetUsageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Usage []*Usage    `protobuf:"bytes,1,rep,name=usage,proto3" json:"usage,omitempty"` // ordered by bytes billed, largest first
+	Quota *UsageQuota `protobuf:"bytes,2,opt,name=quota,proto3" json:"quota,omitempty"` // current month, set when monthly budget is configured
+}
+
+func (x *GetUsageResponse) Reset() {
+	*x = GetUsageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageResponse) ProtoMessage() {}
+
+func (x *GetUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetUsageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetUsageResponse) GetUsage() []*Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+func (x *GetUsageResponse) GetQuota() *UsageQuota {
+	if x != nil {
+		return x.Quota
+	}
+	return nil
+}
+
+type ArchiveReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Archive  bool   `protobuf:"varint,2,opt,name=archive,proto3" json:"archive,omitempty"`
+}
+
+func (x *ArchiveReportRequest) Reset() {
+	*x = ArchiveReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArchiveReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveReportRequest) ProtoMessage() {}
+
+func (x *ArchiveReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveReportRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ArchiveReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ArchiveReportRequest) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+type ArchiveReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CancelledCount int32 `protobuf:"varint,1,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"` // jobs of archived report cancelled
+}
+
+func (x *ArchiveReportResponse) Reset() {
+	*x = ArchiveReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArchiveReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveReportResponse) ProtoMessage() {}
+
+func (x *ArchiveReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveReportResponse.ProtoReflect.Descriptor instead.
+func (*ArchiveReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ArchiveReportResponse) GetCancelledCount() int32 {
+	if x != nil {
+		return x.CancelledCount
+	}
+	return 0
+}
+
+// ShareReportRequest to serve results of report without login, only author can share report
+type ShareReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Public   bool   `protobuf:"varint,2,opt,name=public,proto3" json:"public,omitempty"` // false revokes share, public paths respond 403 within DEKART_PUBLIC_SHARE_CACHE_TTL
+}
+
+func (x *ShareReportRequest) Reset() {
+	*x = ShareReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareReportRequest) ProtoMessage() {}
+
+func (x *ShareReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareReportRequest.ProtoReflect.Descriptor instead.
+func (*ShareReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ShareReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ShareReportRequest) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+type ShareReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ShareReportResponse) Reset() {
+	*x = ShareReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShareReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareReportResponse) ProtoMessage() {}
+
+func (x *ShareReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareReportResponse.ProtoReflect.Descriptor instead.
+func (*ShareReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{33}
+}
+
+type ReportListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StreamOptions *StreamOptions `protobuf:"bytes,1,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+}
+
+func (x *ReportListRequest) Reset() {
+	*x = ReportListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportListRequest) ProtoMessage() {}
+
+func (x *ReportListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportListRequest.ProtoReflect.Descriptor instead.
+func (*ReportListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReportListRequest) GetStreamOptions() *StreamOptions {
+	if x != nil {
+		return x.StreamOptions
+	}
+	return nil
+}
+
+type ReportListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reports       []*Report      `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	StreamOptions *StreamOptions `protobuf:"bytes,2,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+}
+
+func (x *ReportListResponse) Reset() {
+	*x = ReportListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportListResponse) ProtoMessage() {}
+
+func (x *ReportListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportListResponse.ProtoReflect.Descriptor instead.
+func (*ReportListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReportListResponse) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ReportListResponse) GetStreamOptions() *StreamOptions {
+	if x != nil {
+		return x.StreamOptions
+	}
+	return nil
+}
+
+type ListReportsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Search    string                    `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"` // case insensitive match of title or query text
+	Filter    ListReportsRequest_Filter `protobuf:"varint,2,opt,name=filter,proto3,enum=ListReportsRequest_Filter" json:"filter,omitempty"`
+	Sort      ListReportsRequest_Sort   `protobuf:"varint,3,opt,name=sort,proto3,enum=ListReportsRequest_Sort" json:"sort,omitempty"`
+	PageSize  int32                     `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 50 default, max 200
+	PageToken string                    `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of previous page, same sort
+}
+
+func (x *ListReportsRequest) Reset() {
+	*x = ListReportsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsRequest) ProtoMessage() {}
+
+func (x *ListReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListReportsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListReportsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListReportsRequest) GetFilter() ListReportsRequest_Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return ListReportsRequest_FILTER_ALL
+}
+
+func (x *ListReportsRequest) GetSort() ListReportsRequest_Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return ListReportsRequest_SORT_UPDATED_AT
+}
+
+func (x *ListReportsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReportsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListReportsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reports             []*Report `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	NextPageToken       string    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`                    // empty on last page
+	TotalCount          int64     `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`                              // reports matching request
+	TotalCountEstimated bool      `protobuf:"varint,4,opt,name=total_count_estimated,json=totalCountEstimated,proto3" json:"total_count_estimated,omitempty"` // total_count is lower bound when too many reports match
+}
+
+func (x *ListReportsResponse) Reset() {
+	*x = ListReportsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReportsResponse) ProtoMessage() {}
+
+func (x *ListReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListReportsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListReportsResponse) GetReports() []*Report {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListReportsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListReportsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListReportsResponse) GetTotalCountEstimated() bool {
+	if x != nil {
+		return x.TotalCountEstimated
+	}
+	return false
+}
+
+type Report struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                      string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MapConfig               string             `protobuf:"bytes,2,opt,name=map_config,json=mapConfig,proto3" json:"map_config,omitempty"`
+	Title                   string             `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Archived                bool               `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	CanWrite                bool               `protobuf:"varint,5,opt,name=can_write,json=canWrite,proto3" json:"can_write,omitempty"`
+	BillingProject          string             `protobuf:"bytes,6,opt,name=billing_project,json=billingProject,proto3" json:"billing_project,omitempty"`                                                                                                                    // project billed for queries of report, empty for instance default
+	DefaultDataset          string             `protobuf:"bytes,7,opt,name=default_dataset,json=defaultDataset,proto3" json:"default_dataset,omitempty"`                                                                                                                    // dataset for unqualified table names, empty for instance default
+	EffectiveDefaultDataset string             `protobuf:"bytes,8,opt,name=effective_default_dataset,json=effectiveDefaultDataset,proto3" json:"effective_default_dataset,omitempty"`                                                                                       // project.dataset used for unqualified table names, read only
+	MapConfigRevision       int64              `protobuf:"varint,9,opt,name=map_config_revision,json=mapConfigRevision,proto3" json:"map_config_revision,omitempty"`                                                                                                        // revision of map_config, read only
+	AuthorEmail             string             `protobuf:"bytes,10,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`                                                                                                                            // read only
+	UpdatedAt               int64              `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                                                                                                                 // unix seconds, read only
+	Variables               []*ReportVariable  `protobuf:"bytes,12,rep,name=variables,proto3" json:"variables,omitempty"`                                                                                                                                                   // set with UpdateReportVariables, ignored by UpdateReport
+	ExplorationLimit        int64              `protobuf:"varint,13,opt,name=exploration_limit,json=explorationLimit,proto3" json:"exploration_limit,omitempty"`                                                                                                            // exploration mode wraps query in LIMIT of this many rows before run, 0 disables it
+	Public                  bool               `protobuf:"varint,14,opt,name=public,proto3" json:"public,omitempty"`                                                                                                                                                        // results are served under public_result_path without login, set with ShareReport
+	ResultSettings          *ResultSettings    `protobuf:"bytes,15,opt,name=result_settings,json=resultSettings,proto3" json:"result_settings,omitempty"`                                                                                                                   // defaults of runs of queries, set with UpdateReportSettings, ignored by UpdateReport
+	Parameters              []*ReportParameter `protobuf:"bytes,16,rep,name=parameters,proto3" json:"parameters,omitempty"`                                                                                                                                                 // controls of report, set with UpdateReportParameters, ignored by UpdateReport
+	ParameterValues         map[string]string  `protobuf:"bytes,17,rep,name=parameter_values,json=parameterValues,proto3" json:"parameter_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`                        // saved values of parameters by name, set with SetReportParameterValues
+	SessionParameterValues  map[string]string  `protobuf:"bytes,18,rep,name=session_parameter_values,json=sessionParameterValues,proto3" json:"session_parameter_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // values set by current user for viewing session, override saved values, read only
+	FreshnessPolicy         *FreshnessPolicy   `protobuf:"bytes,19,opt,name=freshness_policy,json=freshnessPolicy,proto3" json:"freshness_policy,omitempty"`                                                                                                                // set with UpdateReportFreshnessPolicy, ignored by UpdateReport
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Report) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Report) GetMapConfig() string {
+	if x != nil {
+		return x.MapConfig
+	}
+	return ""
+}
+
+func (x *Report) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Report) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Report) GetCanWrite() bool {
+	if x != nil {
+		return x.CanWrite
+	}
+	return false
+}
+
+func (x *Report) GetBillingProject() string {
+	if x != nil {
+		return x.BillingProject
+	}
+	return ""
+}
+
+func (x *Report) GetDefaultDataset() string {
+	if x != nil {
+		return x.DefaultDataset
+	}
+	return ""
+}
+
+func (x *Report) GetEffectiveDefaultDataset() string {
+	if x != nil {
+		return x.EffectiveDefaultDataset
+	}
+	return ""
+}
+
+func (x *Report) GetMapConfigRevision() int64 {
+	if x != nil {
+		return x.MapConfigRevision
+	}
+	return 0
+}
+
+func (x *Report) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *Report) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Report) GetVariables() []*ReportVariable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *Report) GetExplorationLimit() int64 {
+	if x != nil {
+		return x.ExplorationLimit
+	}
+	return 0
+}
+
+func (x *Report) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *Report) GetResultSettings() *ResultSettings {
+	if x != nil {
+		return x.ResultSettings
+	}
+	return nil
+}
+
+func (x *Report) GetParameters() []*ReportParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Report) GetParameterValues() map[string]string {
+	if x != nil {
+		return x.ParameterValues
+	}
+	return nil
+}
+
+func (x *Report) GetSessionParameterValues() map[string]string {
+	if x != nil {
+		return x.SessionParameterValues
+	}
+	return nil
+}
+
+func (x *Report) GetFreshnessPolicy() *FreshnessPolicy {
+	if x != nil {
+		return x.FreshnessPolicy
+	}
+	return nil
+}
+
+// ReportVariable substituted as {{name}} into query text of report before run
+type ReportVariable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // letters, digits and _, starts with letter or _
+	Type         ReportVariable_Type `protobuf:"varint,2,opt,name=type,proto3,enum=ReportVariable_Type" json:"type,omitempty"`
+	DefaultValue string              `protobuf:"bytes,3,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"` // used when value is not set on run
+}
+
+func (x *ReportVariable) Reset() {
+	*x = ReportVariable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportVariable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportVariable) ProtoMessage() {}
+
+func (x *ReportVariable) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportVariable.ProtoReflect.Descriptor instead.
+func (*ReportVariable) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReportVariable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReportVariable) GetType() ReportVariable_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ReportVariable_TYPE_UNSPECIFIED
+}
+
+func (x *ReportVariable) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+type UpdateReportVariablesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId  string            `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Variables []*ReportVariable `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty"` // replaces all variables of report
+}
+
+func (x *UpdateReportVariablesRequest) Reset() {
+	*x = UpdateReportVariablesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportVariablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportVariablesRequest) ProtoMessage() {}
+
+func (x *UpdateReportVariablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportVariablesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReportVariablesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UpdateReportVariablesRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateReportVariablesRequest) GetVariables() []*ReportVariable {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type UpdateReportVariablesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateReportVariablesResponse) Reset() {
+	*x = UpdateReportVariablesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportVariablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportVariablesResponse) ProtoMessage() {}
+
+func (x *UpdateReportVariablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportVariablesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReportVariablesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{41}
+}
+
+// ReportParameter is control of report, like dropdown or date picker; its value is passed as BigQuery named parameter
+// to queries bound to it with UpdateQueryParameterBindings. Value of run is value of viewing session, saved value or default value
+type ReportParameter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // letters, digits and _, starts with letter or _
+	Type          ReportParameter_Type `protobuf:"varint,2,opt,name=type,proto3,enum=ReportParameter_Type" json:"type,omitempty"`
+	AllowedValues []string             `protobuf:"bytes,3,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`   // values of dropdown, value must be one of them; any value of type when empty
+	ValuesQueryId string               `protobuf:"bytes,4,opt,name=values_query_id,json=valuesQueryId,proto3" json:"values_query_id,omitempty"` // query of report populating allowed values from first column of its result, can't be combined with allowed_values
+	DefaultValue  string               `protobuf:"bytes,5,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`      // used when value is not set
+}
+
+func (x *ReportParameter) Reset() {
+	*x = ReportParameter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportParameter) ProtoMessage() {}
+
+func (x *ReportParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportParameter.ProtoReflect.Descriptor instead.
+func (*ReportParameter) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReportParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReportParameter) GetType() ReportParameter_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ReportParameter_TYPE_UNSPECIFIED
+}
+
+func (x *ReportParameter) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+func (x *ReportParameter) GetValuesQueryId() string {
+	if x != nil {
+		return x.ValuesQueryId
+	}
+	return ""
+}
+
+func (x *ReportParameter) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+type UpdateReportParametersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId   string             `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Parameters []*ReportParameter `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty"` // replaces all parameters of report
+}
+
+func (x *UpdateReportParametersRequest) Reset() {
+	*x = UpdateReportParametersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportParametersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportParametersRequest) ProtoMessage() {}
+
+func (x *UpdateReportParametersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportParametersRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReportParametersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateReportParametersRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateReportParametersRequest) GetParameters() []*ReportParameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type UpdateReportParametersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateReportParametersResponse) Reset() {
+	*x = UpdateReportParametersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportParametersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportParametersResponse) ProtoMessage() {}
+
+func (x *UpdateReportParametersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportParametersResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReportParametersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{44}
+}
+
+type SetReportParameterValuesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string            `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Values   map[string]string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // replaces values of scope, parameters without value use saved or default value
+	Save     bool              `protobuf:"varint,3,opt,name=save,proto3" json:"save,omitempty"`                                                                                            // values are saved state of report, only author can save them; otherwise values of viewing session of current user
+}
+
+func (x *SetReportParameterValuesRequest) Reset() {
+	*x = SetReportParameterValuesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetReportParameterValuesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReportParameterValuesRequest) ProtoMessage() {}
+
+func (x *SetReportParameterValuesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReportParameterValuesRequest.ProtoReflect.Descriptor instead.
+func (*SetReportParameterValuesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SetReportParameterValuesRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *SetReportParameterValuesRequest) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SetReportParameterValuesRequest) GetSave() bool {
+	if x != nil {
+		return x.Save
+	}
+	return false
+}
+
+type SetReportParameterValuesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetReportParameterValuesResponse) Reset() {
+	*x = SetReportParameterValuesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetReportParameterValuesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReportParameterValuesResponse) ProtoMessage() {}
+
+func (x *SetReportParameterValuesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReportParameterValuesResponse.ProtoReflect.Descriptor instead.
+func (*SetReportParameterValuesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{46}
+}
+
+type GetReportParameterOptionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // parameter of report
+}
+
+func (x *GetReportParameterOptionsRequest) Reset() {
+	*x = GetReportParameterOptionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReportParameterOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportParameterOptionsRequest) ProtoMessage() {}
+
+func (x *GetReportParameterOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportParameterOptionsRequest.ProtoReflect.Descriptor instead.
+func (*GetReportParameterOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetReportParameterOptionsRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *GetReportParameterOptionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetReportParameterOptionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values    []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`        // allowed values, read from result of values query when it's set
+	Truncated bool     `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // values query result has more distinct values than returned
+}
+
+func (x *GetReportParameterOptionsResponse) Reset() {
+	*x = GetReportParameterOptionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReportParameterOptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportParameterOptionsResponse) ProtoMessage() {}
+
+func (x *GetReportParameterOptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportParameterOptionsResponse.ProtoReflect.Descriptor instead.
+func (*GetReportParameterOptionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetReportParameterOptionsResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *GetReportParameterOptionsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// ResultSettings of result files; unset fields of run request use settings of report, unset fields of report use instance defaults
+type ResultSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResultFormat      ResultFormat      `protobuf:"varint,1,opt,name=result_format,json=resultFormat,proto3,enum=ResultFormat" json:"result_format,omitempty"`
+	ResultCompression ResultCompression `protobuf:"varint,2,opt,name=result_compression,json=resultCompression,proto3,enum=ResultCompression" json:"result_compression,omitempty"` // only for CSV result
+	Delimiter         string            `protobuf:"bytes,3,opt,name=delimiter,proto3" json:"delimiter,omitempty"`                                                                  // one character separating values of CSV result
+}
+
+func (x *ResultSettings) Reset() {
+	*x = ResultSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultSettings) ProtoMessage() {}
+
+func (x *ResultSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultSettings.ProtoReflect.Descriptor instead.
+func (*ResultSettings) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ResultSettings) GetResultFormat() ResultFormat {
+	if x != nil {
+		return x.ResultFormat
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+func (x *ResultSettings) GetResultCompression() ResultCompression {
+	if x != nil {
+		return x.ResultCompression
+	}
+	return ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+}
+
+func (x *ResultSettings) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+type UpdateReportSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId       string          `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ResultSettings *ResultSettings `protobuf:"bytes,2,opt,name=result_settings,json=resultSettings,proto3" json:"result_settings,omitempty"` // replaces result settings of report
+}
+
+func (x *UpdateReportSettingsRequest) Reset() {
+	*x = UpdateReportSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateReportSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReportSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UpdateReportSettingsRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateReportSettingsRequest) GetResultSettings() *ResultSettings {
+	if x != nil {
+		return x.ResultSettings
+	}
+	return nil
+}
+
+type UpdateReportSettingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateReportSettingsResponse) Reset() {
+	*x = UpdateReportSettingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateReportSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReportSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{51}
+}
+
+// FreshnessPolicy of report results; result older than max_age_seconds is stale
+type FreshnessPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxAgeSeconds int64 `protobuf:"varint,1,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"` // 0 disables staleness
+	AutoRerun     bool  `protobuf:"varint,2,opt,name=auto_rerun,json=autoRerun,proto3" json:"auto_rerun,omitempty"`               // stale queries are run when author opens report, unless instance sets DEKART_AUTO_RERUN=0
+}
+
+func (x *FreshnessPolicy) Reset() {
+	*x = FreshnessPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FreshnessPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreshnessPolicy) ProtoMessage() {}
+
+func (x *FreshnessPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreshnessPolicy.ProtoReflect.Descriptor instead.
+func (*FreshnessPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *FreshnessPolicy) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+func (x *FreshnessPolicy) GetAutoRerun() bool {
+	if x != nil {
+		return x.AutoRerun
+	}
+	return false
+}
+
+type UpdateReportFreshnessPolicyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId        string           `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	FreshnessPolicy *FreshnessPolicy `protobuf:"bytes,2,opt,name=freshness_policy,json=freshnessPolicy,proto3" json:"freshness_policy,omitempty"` // replaces freshness policy of report, unset disables it
+}
+
+func (x *UpdateReportFreshnessPolicyRequest) Reset() {
+	*x = UpdateReportFreshnessPolicyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportFreshnessPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportFreshnessPolicyRequest) ProtoMessage() {}
+
+func (x *UpdateReportFreshnessPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportFreshnessPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReportFreshnessPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *UpdateReportFreshnessPolicyRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateReportFreshnessPolicyRequest) GetFreshnessPolicy() *FreshnessPolicy {
+	if x != nil {
+		return x.FreshnessPolicy
+	}
+	return nil
+}
+
+type UpdateReportFreshnessPolicyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateReportFreshnessPolicyResponse) Reset() {
+	*x = UpdateReportFreshnessPolicyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportFreshnessPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportFreshnessPolicyResponse) ProtoMessage() {}
+
+func (x *UpdateReportFreshnessPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportFreshnessPolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReportFreshnessPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{54}
+}
+
+// Dataset of catalog is shared query referenced as {{dataset:name}} in query text of reports,
+// reference is expanded into subquery before run; all users can read datasets, only author can edit them
+type Dataset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // letters, digits and _, starts with letter or _, unique in instance
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	QueryText   string `protobuf:"bytes,4,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`       // can use report variables, can't reference other datasets
+	AuthorEmail string `protobuf:"bytes,5,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"` // read only
+	UpdatedAt   int64  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`      // unix seconds, read only
+	CanWrite    bool   `protobuf:"varint,7,opt,name=can_write,json=canWrite,proto3" json:"can_write,omitempty"`         // read only
+}
+
+func (x *Dataset) Reset() {
+	*x = Dataset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dataset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dataset) ProtoMessage() {}
+
+func (x *Dataset) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dataset.ProtoReflect.Descriptor instead.
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *Dataset) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dataset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dataset) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Dataset) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
+	}
+	return ""
+}
+
+func (x *Dataset) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *Dataset) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Dataset) GetCanWrite() bool {
+	if x != nil {
+		return x.CanWrite
+	}
+	return false
+}
+
+type CreateDatasetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset *Dataset `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *CreateDatasetRequest) Reset() {
+	*x = CreateDatasetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDatasetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDatasetRequest) ProtoMessage() {}
+
+func (x *CreateDatasetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDatasetRequest.ProtoReflect.Descriptor instead.
+func (*CreateDatasetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CreateDatasetRequest) GetDataset() *Dataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return nil
+}
+
+type CreateDatasetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset *Dataset `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *CreateDatasetResponse) Reset() {
+	*x = CreateDatasetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDatasetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDatasetResponse) ProtoMessage() {}
+
+func (x *CreateDatasetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDatasetResponse.ProtoReflect.Descriptor instead.
+func (*CreateDatasetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CreateDatasetResponse) GetDataset() *Dataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return nil
+}
+
+type UpdateDatasetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset *Dataset `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"` // dataset of id is updated, renamed dataset is not found by old references
+}
+
+func (x *UpdateDatasetRequest) Reset() {
+	*x = UpdateDatasetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDatasetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDatasetRequest) ProtoMessage() {}
+
+func (x *UpdateDatasetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDatasetRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDatasetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *UpdateDatasetRequest) GetDataset() *Dataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return nil
+}
+
+type UpdateDatasetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dataset *Dataset `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *UpdateDatasetResponse) Reset() {
+	*x = UpdateDatasetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDatasetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDatasetResponse) ProtoMessage() {}
+
+func (x *UpdateDatasetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDatasetResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDatasetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *UpdateDatasetResponse) GetDataset() *Dataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return nil
+}
+
+type DeleteDatasetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteDatasetRequest) Reset() {
+	*x = DeleteDatasetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteDatasetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDatasetRequest) ProtoMessage() {}
+
+func (x *DeleteDatasetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDatasetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDatasetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DeleteDatasetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteDatasetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteDatasetResponse) Reset() {
+	*x = DeleteDatasetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteDatasetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDatasetResponse) ProtoMessage() {}
+
+func (x *DeleteDatasetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDatasetResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDatasetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{61}
+}
+
+type ListDatasetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"` // case insensitive substring of name or description
+}
+
+func (x *ListDatasetsRequest) Reset() {
+	*x = ListDatasetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDatasetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDatasetsRequest) ProtoMessage() {}
+
+func (x *ListDatasetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDatasetsRequest.ProtoReflect.Descriptor instead.
+func (*ListDatasetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListDatasetsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type ListDatasetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Datasets []*Dataset `protobuf:"bytes,1,rep,name=datasets,proto3" json:"datasets,omitempty"` // ordered by name
+}
+
+func (x *ListDatasetsResponse) Reset() {
+	*x = ListDatasetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDatasetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDatasetsResponse) ProtoMessage() {}
+
+func (x *ListDatasetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDatasetsResponse.ProtoReflect.Descriptor instead.
+func (*ListDatasetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListDatasetsResponse) GetDatasets() []*Dataset {
+	if x != nil {
+		return x.Datasets
+	}
+	return nil
+}
+
+// File uploaded to report, overlaid on map with query results; it's downloaded by id like query result
+type File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReportId    string   `protobuf:"bytes,2,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Name        string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	FileType    FileType `protobuf:"varint,4,opt,name=file_type,json=fileType,proto3,enum=FileType" json:"file_type,omitempty"`
+	Size        int64    `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"` // bytes
+	AuthorEmail string   `protobuf:"bytes,6,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	CreatedAt   int64    `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix seconds
+}
+
+func (x *File) Reset() {
+	*x = File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*File) ProtoMessage() {}
+
+func (x *File) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use File.ProtoReflect.Descriptor instead.
+func (*File) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *File) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *File) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *File) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *File) GetFileType() FileType {
+	if x != nil {
+		return x.FileType
+	}
+	return FileType_FILE_TYPE_UNSPECIFIED
+}
+
+func (x *File) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *File) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *File) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// UploadFileRequest is chunk of uploaded file; report_id and name are set in first chunk
+type UploadFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // type of file is detected by extension .csv, .geojson or .json
+	Data     []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *UploadFileRequest) Reset() {
+	*x = UploadFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileRequest) ProtoMessage() {}
+
+func (x *UploadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileRequest.ProtoReflect.Descriptor instead.
+func (*UploadFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UploadFileRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadFileRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	File *File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+}
+
+func (x *UploadFileResponse) Reset() {
+	*x = UploadFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFileResponse) ProtoMessage() {}
+
+func (x *UploadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFileResponse.ProtoReflect.Descriptor instead.
+func (*UploadFileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UploadFileResponse) GetFile() *File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type RemoveFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileId string `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+}
+
+func (x *RemoveFileRequest) Reset() {
+	*x = RemoveFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileRequest) ProtoMessage() {}
+
+func (x *RemoveFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RemoveFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type RemoveFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveFileResponse) Reset() {
+	*x = RemoveFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileResponse) ProtoMessage() {}
+
+func (x *RemoveFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{68}
+}
+
+type Query struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                   string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReportId             string                `protobuf:"bytes,2,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	QueryText            string                `protobuf:"bytes,3,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`
+	JobStatus            Query_JobStatus       `protobuf:"varint,4,opt,name=job_status,json=jobStatus,proto3,enum=Query_JobStatus" json:"job_status,omitempty"`
+	JobResultId          string                `protobuf:"bytes,5,opt,name=job_result_id,json=jobResultId,proto3" json:"job_result_id,omitempty"` // deprecated, id of result_meta; filled until clients read result_meta
+	JobError             string                `protobuf:"bytes,6,opt,name=job_error,json=jobError,proto3" json:"job_error,omitempty"`
+	JobDuration          int64                 `protobuf:"varint,7,opt,name=job_duration,json=jobDuration,proto3" json:"job_duration,omitempty"`
+	TotalRows            int32                 `protobuf:"varint,8,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	BytesProcessed       int64                 `protobuf:"varint,9,opt,name=bytes_processed,json=bytesProcessed,proto3" json:"bytes_processed,omitempty"`
+	ResultSize           int64                 `protobuf:"varint,10,opt,name=result_size,json=resultSize,proto3" json:"result_size,omitempty"`                       // deprecated, size of result_meta
+	RowsWritten          int64                 `protobuf:"varint,11,opt,name=rows_written,json=rowsWritten,proto3" json:"rows_written,omitempty"`                    // rows stored in result, equals total_rows when job is done
+	SampleRate           float64               `protobuf:"fixed64,12,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`                      // fraction of rows sampled into result, 0 when result is not sampled
+	H3ResultId           string                `protobuf:"bytes,13,opt,name=h3_result_id,json=h3ResultId,proto3" json:"h3_result_id,omitempty"`                      // result aggregated into H3 cells, empty when aggregation was not requested
+	Title                string                `protobuf:"bytes,14,opt,name=title,proto3" json:"title,omitempty"`                                                    // generated as "Query N" when empty on create
+	ExecutedQueryText    string                `protobuf:"bytes,15,opt,name=executed_query_text,json=executedQueryText,proto3" json:"executed_query_text,omitempty"` // query text of last job with datasets and variables expanded, sampling and exploration LIMIT applied, read only
+	ResultType           Query_ResultType      `protobuf:"varint,16,opt,name=result_type,json=resultType,proto3,enum=Query_ResultType" json:"result_type,omitempty"`
+	ResultTable          string                `protobuf:"bytes,17,opt,name=result_table,json=resultTable,proto3" json:"result_table,omitempty"`                                                                                                           // project.dataset.table of table result
+	ReusedFromQueryId    string                `protobuf:"bytes,18,opt,name=reused_from_query_id,json=reusedFromQueryId,proto3" json:"reused_from_query_id,omitempty"`                                                                                     // query of source report whose result was reused on first run of fork
+	ResultCreatedAt      int64                 `protobuf:"varint,19,opt,name=result_created_at,json=resultCreatedAt,proto3" json:"result_created_at,omitempty"`                                                                                            // unix seconds when job_result_id was stored, shows age of reused result
+	ResultFormat         ResultFormat          `protobuf:"varint,20,opt,name=result_format,json=resultFormat,proto3,enum=ResultFormat" json:"result_format,omitempty"`                                                                                     // format of job_result_id file; deprecated, format of result_meta
+	ExplorationLimit     int64                 `protobuf:"varint,21,opt,name=exploration_limit,json=explorationLimit,proto3" json:"exploration_limit,omitempty"`                                                                                           // LIMIT of exploration mode last job was wrapped in, 0 when query was run as written
+	ExecutedQueryHash    string                `protobuf:"bytes,22,opt,name=executed_query_hash,json=executedQueryHash,proto3" json:"executed_query_hash,omitempty"`                                                                                       // hash of executed_query_text, set as dekart-query-hash label of BigQuery job
+	Warnings             []*JobWarning         `protobuf:"bytes,23,rep,name=warnings,proto3" json:"warnings,omitempty"`                                                                                                                                    // conditions of last job which didn't fail it
+	BatchId              string                `protobuf:"bytes,24,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`                                                                                                                       // RunAllQueries batch which started last job, empty for job run by RunQuery
+	DependsOn            []string              `protobuf:"bytes,25,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`                                                                                                                 // queries of report RunAllQueries runs before this one, set with UpdateQueryDependencies
+	SkippedDependencyId  string                `protobuf:"bytes,26,opt,name=skipped_dependency_id,json=skippedDependencyId,proto3" json:"skipped_dependency_id,omitempty"`                                                                                 // dependency which failed, was cancelled or skipped, set with JOB_STATUS_SKIPPED
+	PublicResultPath     string                `protobuf:"bytes,27,opt,name=public_result_path,json=publicResultPath,proto3" json:"public_result_path,omitempty"`                                                                                          // /public/results/<job_result_id>.<ext> of public report, empty for private results
+	SerializationOptions *SerializationOptions `protobuf:"bytes,28,opt,name=serialization_options,json=serializationOptions,proto3" json:"serialization_options,omitempty"`                                                                                // options job_result_id was written with, unset for default serialization
+	PlanAvailable        bool                  `protobuf:"varint,29,opt,name=plan_available,json=planAvailable,proto3" json:"plan_available,omitempty"`                                                                                                    // GetJobPlan returns query plan of last job
+	UseLegacySql         bool                  `protobuf:"varint,30,opt,name=use_legacy_sql,json=useLegacySql,proto3" json:"use_legacy_sql,omitempty"`                                                                                                     // query is run in legacy SQL, standard SQL by default; named parameters and sampling need standard SQL
+	ExactTotalRows       int64                 `protobuf:"varint,31,opt,name=exact_total_rows,json=exactTotalRows,proto3" json:"exact_total_rows,omitempty"`                                                                                               // rows of query without sampling and exploration LIMIT, counted after job when DEKART_EXACT_COUNT_MAX_BYTES is set; -1 when not counted
+	ResultCompression    ResultCompression     `protobuf:"varint,32,opt,name=result_compression,json=resultCompression,proto3,enum=ResultCompression" json:"result_compression,omitempty"`                                                                 // compression job_result_id file was written with; deprecated, compression of result_meta
+	ParameterBindings    map[string]string     `protobuf:"bytes,33,rep,name=parameter_bindings,json=parameterBindings,proto3" json:"parameter_bindings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // named parameter @key of query text bound to report parameter, set with UpdateQueryParameterBindings
+	FormatResults        []*FormatResult       `protobuf:"bytes,34,rep,name=format_results,json=formatResults,proto3" json:"format_results,omitempty"`                                                                                                     // results of last job in additional formats of run, failed formats are warnings
+	CancelReason         CancelReason          `protobuf:"varint,35,opt,name=cancel_reason,json=cancelReason,proto3,enum=CancelReason" json:"cancel_reason,omitempty"`                                                                                     // reason of last job cancellation, unspecified when job was not cancelled
+	Reservation          string                `protobuf:"bytes,36,opt,name=reservation,proto3" json:"reservation,omitempty"`                                                                                                                              // reservation which ran last job as reported by BigQuery, project:location.reservation; empty when DEKART_BIGQUERY_RESERVATION or DEKART_BIGQUERY_CONNECTION_RESERVATIONS doesn't set reservation of job
+	PreviewResultId      string                `protobuf:"bytes,37,opt,name=preview_result_id,json=previewResultId,proto3" json:"preview_result_id,omitempty"`                                                                                             // partial CSV of first preview_rows rows while job reads result, /api/v1/job-results/<preview_result_id>.csv; empty when DEKART_PREVIEW_ROWS is not set and once job finished, job_result_id replaces it
+	PreviewRows          int64                 `protobuf:"varint,38,opt,name=preview_rows,json=previewRows,proto3" json:"preview_rows,omitempty"`                                                                                                          // rows in preview_result_id so far
+	JobErrorExplanation  *ErrorExplanation     `protobuf:"bytes,39,opt,name=job_error_explanation,json=jobErrorExplanation,proto3" json:"job_error_explanation,omitempty"`                                                                                 // explanation of job_error for users, job_error keeps original error; not set for unknown errors
+	ResultMeta           *ResultMeta           `protobuf:"bytes,40,opt,name=result_meta,json=resultMeta,proto3" json:"result_meta,omitempty"`                                                                                                              // result file of last job, unset without result file; replaces job_result_id, result_size, result_format and result_compression
+	Stale                bool                  `protobuf:"varint,41,opt,name=stale,proto3" json:"stale,omitempty"`                                                                                                                                         // result is older than max_age_seconds of freshness policy of report, computed when report is sent
+}
+
+func (x *Query) Reset() {
+	*x = Query{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Query) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Query) ProtoMessage() {}
+
+func (x *Query) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Query.ProtoReflect.Descriptor instead.
+func (*Query) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *Query) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Query) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *Query) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
+	}
+	return ""
+}
+
+func (x *Query) GetJobStatus() Query_JobStatus {
+	if x != nil {
+		return x.JobStatus
+	}
+	return Query_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *Query) GetJobResultId() string {
+	if x != nil {
+		return x.JobResultId
+	}
+	return ""
+}
+
+func (x *Query) GetJobError() string {
+	if x != nil {
+		return x.JobError
+	}
+	return ""
+}
+
+func (x *Query) GetJobDuration() int64 {
+	if x != nil {
+		return x.JobDuration
+	}
+	return 0
+}
+
+func (x *Query) GetTotalRows() int32 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *Query) GetBytesProcessed() int64 {
+	if x != nil {
+		return x.BytesProcessed
+	}
+	return 0
+}
+
+func (x *Query) GetResultSize() int64 {
+	if x != nil {
+		return x.ResultSize
+	}
+	return 0
+}
+
+func (x *Query) GetRowsWritten() int64 {
+	if x != nil {
+		return x.RowsWritten
+	}
+	return 0
+}
+
+func (x *Query) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *Query) GetH3ResultId() string {
+	if x != nil {
+		return x.H3ResultId
+	}
+	return ""
+}
+
+func (x *Query) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Query) GetExecutedQueryText() string {
+	if x != nil {
+		return x.ExecutedQueryText
+	}
+	return ""
+}
+
+func (x *Query) GetResultType() Query_ResultType {
+	if x != nil {
+		return x.ResultType
+	}
+	return Query_RESULT_TYPE_UNSPECIFIED
+}
+
+func (x *Query) GetResultTable() string {
+	if x != nil {
+		return x.ResultTable
+	}
+	return ""
+}
+
+func (x *Query) GetReusedFromQueryId() string {
+	if x != nil {
+		return x.ReusedFromQueryId
+	}
+	return ""
+}
+
+func (x *Query) GetResultCreatedAt() int64 {
+	if x != nil {
+		return x.ResultCreatedAt
+	}
+	return 0
+}
+
+func (x *Query) GetResultFormat() ResultFormat {
+	if x != nil {
+		return x.ResultFormat
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+func (x *Query) GetExplorationLimit() int64 {
+	if x != nil {
+		return x.ExplorationLimit
+	}
+	return 0
+}
+
+func (x *Query) GetExecutedQueryHash() string {
+	if x != nil {
+		return x.ExecutedQueryHash
+	}
+	return ""
+}
+
+func (x *Query) GetWarnings() []*JobWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *Query) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *Query) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Query) GetSkippedDependencyId() string {
+	if x != nil {
+		return x.SkippedDependencyId
+	}
+	return ""
+}
+
+func (x *Query) GetPublicResultPath() string {
+	if x != nil {
+		return x.PublicResultPath
+	}
+	return ""
+}
+
+func (x *Query) GetSerializationOptions() *SerializationOptions {
+	if x != nil {
+		return x.SerializationOptions
+	}
+	return nil
+}
+
+func (x *Query) GetPlanAvailable() bool {
+	if x != nil {
+		return x.PlanAvailable
+	}
+	return false
+}
+
+func (x *Query) GetUseLegacySql() bool {
+	if x != nil {
+		return x.UseLegacySql
+	}
+	return false
+}
+
+func (x *Query) GetExactTotalRows() int64 {
+	if x != nil {
+		return x.ExactTotalRows
+	}
+	return 0
+}
+
+func (x *Query) GetResultCompression() ResultCompression {
+	if x != nil {
+		return x.ResultCompression
+	}
+	return ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+}
+
+func (x *Query) GetParameterBindings() map[string]string {
+	if x != nil {
+		return x.ParameterBindings
+	}
+	return nil
+}
+
+func (x *Query) GetFormatResults() []*FormatResult {
+	if x != nil {
+		return x.FormatResults
+	}
+	return nil
+}
+
+func (x *Query) GetCancelReason() CancelReason {
+	if x != nil {
+		return x.CancelReason
+	}
+	return CancelReason_CANCEL_REASON_UNSPECIFIED
+}
+
+func (x *Query) GetReservation() string {
+	if x != nil {
+		return x.Reservation
+	}
+	return ""
+}
+
+func (x *Query) GetPreviewResultId() string {
+	if x != nil {
+		return x.PreviewResultId
+	}
+	return ""
+}
+
+func (x *Query) GetPreviewRows() int64 {
+	if x != nil {
+		return x.PreviewRows
+	}
+	return 0
+}
+
+func (x *Query) GetJobErrorExplanation() *ErrorExplanation {
+	if x != nil {
+		return x.JobErrorExplanation
+	}
+	return nil
+}
+
+func (x *Query) GetResultMeta() *ResultMeta {
+	if x != nil {
+		return x.ResultMeta
+	}
+	return nil
+}
+
+func (x *Query) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+// ResultMeta of result file of job; results stored before meta was recorded have meta built from fields of query,
+// without checksum and bucket
+type ResultMeta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // downloaded from /api/v1/job-results/<id>.<csv|parquet>
+	Format         ResultFormat      `protobuf:"varint,2,opt,name=format,proto3,enum=ResultFormat" json:"format,omitempty"`
+	Compression    ResultCompression `protobuf:"varint,3,opt,name=compression,proto3,enum=ResultCompression" json:"compression,omitempty"`
+	Size           int64             `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"` // bytes of stored result, compressed when result_compression is gzip
+	RowCount       int64             `protobuf:"varint,5,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	Checksum       string            `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"`                                    // base64 CRC32C of stored bytes, sent as ETag of download; empty when result was written by EXTRACT
+	CreatedAt      int64             `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                // unix seconds
+	Truncated      bool              `protobuf:"varint,8,opt,name=truncated,proto3" json:"truncated,omitempty"`                                 // rows are limited by exploration LIMIT
+	Sampled        bool              `protobuf:"varint,9,opt,name=sampled,proto3" json:"sampled,omitempty"`                                     // rows are sampled with sample_rate of query
+	StorageBackend string            `protobuf:"bytes,10,opt,name=storage_backend,json=storageBackend,proto3" json:"storage_backend,omitempty"` // gcs
+	Bucket         string            `protobuf:"bytes,11,opt,name=bucket,proto3" json:"bucket,omitempty"`                                       // bucket holding result, empty when not recorded
+	Parts          []*ResultPart     `protobuf:"bytes,12,rep,name=parts,proto3" json:"parts,omitempty"`                                         // results stored with this one
+	Ordered        bool              `protobuf:"varint,13,opt,name=ordered,proto3" json:"ordered,omitempty"`                                    // rows are stored in ORDER BY of query
+}
+
+func (x *ResultMeta) Reset() {
+	*x = ResultMeta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultMeta) ProtoMessage() {}
+
+func (x *ResultMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultMeta.ProtoReflect.Descriptor instead.
+func (*ResultMeta) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ResultMeta) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResultMeta) GetFormat() ResultFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+func (x *ResultMeta) GetCompression() ResultCompression {
+	if x != nil {
+		return x.Compression
+	}
+	return ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+}
+
+func (x *ResultMeta) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ResultMeta) GetRowCount() int64 {
+	if x != nil {
+		return x.RowCount
+	}
+	return 0
+}
+
+func (x *ResultMeta) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *ResultMeta) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ResultMeta) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ResultMeta) GetSampled() bool {
+	if x != nil {
+		return x.Sampled
+	}
+	return false
+}
+
+func (x *ResultMeta) GetStorageBackend() string {
+	if x != nil {
+		return x.StorageBackend
+	}
+	return ""
+}
+
+func (x *ResultMeta) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ResultMeta) GetParts() []*ResultPart {
+	if x != nil {
+		return x.Parts
+	}
+	return nil
+}
+
+func (x *ResultMeta) GetOrdered() bool {
+	if x != nil {
+		return x.Ordered
+	}
+	return false
+}
+
+// ResultPart stored with result file of job
+type ResultPart struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind   string       `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // format for additional format of run, h3 for H3 aggregation
+	Format ResultFormat `protobuf:"varint,3,opt,name=format,proto3,enum=ResultFormat" json:"format,omitempty"`
+}
+
+func (x *ResultPart) Reset() {
+	*x = ResultPart{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultPart) ProtoMessage() {}
+
+func (x *ResultPart) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultPart.ProtoReflect.Descriptor instead.
+func (*ResultPart) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ResultPart) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResultPart) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResultPart) GetFormat() ResultFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+// ErrorExplanation of common job error with suggested fix
+type ErrorExplanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // id of mapping which matched error, like table-not-found
+	Summary string `protobuf:"bytes,2,opt,name=summary,proto3" json:"summary,omitempty"`
+	Fix     string `protobuf:"bytes,3,opt,name=fix,proto3" json:"fix,omitempty"`
+	DocsUrl string `protobuf:"bytes,4,opt,name=docs_url,json=docsUrl,proto3" json:"docs_url,omitempty"` // documentation of error, optional
+}
+
+func (x *ErrorExplanation) Reset() {
+	*x = ErrorExplanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorExplanation) ProtoMessage() {}
+
+func (x *ErrorExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorExplanation.ProtoReflect.Descriptor instead.
+func (*ErrorExplanation) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ErrorExplanation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ErrorExplanation) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *ErrorExplanation) GetFix() string {
+	if x != nil {
+		return x.Fix
+	}
+	return ""
+}
+
+func (x *ErrorExplanation) GetDocsUrl() string {
+	if x != nil {
+		return x.DocsUrl
+	}
+	return ""
+}
+
+// FormatResult of job in additional format, written from same rows as job_result_id
+type FormatResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Format   ResultFormat `protobuf:"varint,1,opt,name=format,proto3,enum=ResultFormat" json:"format,omitempty"`
+	ResultId string       `protobuf:"bytes,2,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"` // downloaded from /api/v1/job-results/<result_id>.<csv|parquet>
+}
+
+func (x *FormatResult) Reset() {
+	*x = FormatResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FormatResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FormatResult) ProtoMessage() {}
+
+func (x *FormatResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FormatResult.ProtoReflect.Descriptor instead.
+func (*FormatResult) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *FormatResult) GetFormat() ResultFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+func (x *FormatResult) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+// JobWarning of condition user should know about, job still has result
+type JobWarning struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // STATISTICS_UNAVAILABLE, H3_ROWS_SKIPPED, H3_AGGREGATION_SKIPPED, CONTROL_CHARACTERS, EXACT_COUNT_UNAVAILABLE, FORMAT_RESULT_FAILED, UNKNOWN_COLUMN_TYPES or ORDER_NOT_PRESERVED
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *JobWarning) Reset() {
+	*x = JobWarning{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobWarning) ProtoMessage() {}
+
+func (x *JobWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobWarning.ProtoReflect.Descriptor instead.
+func (*JobWarning) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *JobWarning) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *JobWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// SerializationOptions of CSV result cells; result without options keeps default serialization with <nil> for NULL,
+// empty fields of set options use defaults below
+type SerializationOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delimiter            string                             `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`                  // one character separating values, comma when empty, tab writes TSV
+	NullToken            string                             `protobuf:"bytes,2,opt,name=null_token,json=nullToken,proto3" json:"null_token,omitempty"` // written for NULL values, empty cell when empty
+	BoolStyle            SerializationOptions_BoolStyle     `protobuf:"varint,3,opt,name=bool_style,json=boolStyle,proto3,enum=SerializationOptions_BoolStyle" json:"bool_style,omitempty"`
+	FloatPrecision       int32                              `protobuf:"varint,4,opt,name=float_precision,json=floatPrecision,proto3" json:"float_precision,omitempty"`                                      // FLOAT64 rounded to at most this many decimal digits with trailing zeros trimmed, 0 keeps all digits
+	TimestampFormat      string                             `protobuf:"bytes,5,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"`                                    // Go layout of TIMESTAMP values like 2006-01-02T15:04:05Z07:00, empty keeps default
+	NumericTrailingZeros bool                               `protobuf:"varint,6,opt,name=numeric_trailing_zeros,json=numericTrailingZeros,proto3" json:"numeric_trailing_zeros,omitempty"`                  // NUMERIC and BIGNUMERIC decimals keep trailing zeros up to scale of column, trimmed by default
+	BytesEncoding        SerializationOptions_BytesEncoding `protobuf:"varint,7,opt,name=bytes_encoding,json=bytesEncoding,proto3,enum=SerializationOptions_BytesEncoding" json:"bytes_encoding,omitempty"` // BYTES values, empty value is empty cell and NULL is null_token
+	HeaderNaming         SerializationOptions_HeaderNaming  `protobuf:"varint,8,opt,name=header_naming,json=headerNaming,proto3,enum=SerializationOptions_HeaderNaming" json:"header_naming,omitempty"`     // names of header; names equal ignoring case after renaming get suffixes _2, _3 in column order
+}
+
+func (x *SerializationOptions) Reset() {
+	*x = SerializationOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SerializationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerializationOptions) ProtoMessage() {}
+
+func (x *SerializationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerializationOptions.ProtoReflect.Descriptor instead.
+func (*SerializationOptions) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *SerializationOptions) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *SerializationOptions) GetNullToken() string {
+	if x != nil {
+		return x.NullToken
+	}
+	return ""
+}
+
+func (x *SerializationOptions) GetBoolStyle() SerializationOptions_BoolStyle {
+	if x != nil {
+		return x.BoolStyle
+	}
+	return SerializationOptions_BOOL_STYLE_UNSPECIFIED
+}
+
+func (x *SerializationOptions) GetFloatPrecision() int32 {
+	if x != nil {
+		return x.FloatPrecision
+	}
+	return 0
+}
+
+func (x *SerializationOptions) GetTimestampFormat() string {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return ""
+}
+
+func (x *SerializationOptions) GetNumericTrailingZeros() bool {
+	if x != nil {
+		return x.NumericTrailingZeros
+	}
+	return false
+}
+
+func (x *SerializationOptions) GetBytesEncoding() SerializationOptions_BytesEncoding {
+	if x != nil {
+		return x.BytesEncoding
+	}
+	return SerializationOptions_BYTES_ENCODING_UNSPECIFIED
+}
+
+func (x *SerializationOptions) GetHeaderNaming() SerializationOptions_HeaderNaming {
+	if x != nil {
+		return x.HeaderNaming
+	}
+	return SerializationOptions_HEADER_NAMING_UNSPECIFIED
+}
+
+// DestinationTable receives query result in BigQuery instead of result file
+type DestinationTable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table            string                            `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"` // project.dataset.table, or dataset.table in data project
+	WriteDisposition DestinationTable_WriteDisposition `protobuf:"varint,2,opt,name=write_disposition,json=writeDisposition,proto3,enum=DestinationTable_WriteDisposition" json:"write_disposition,omitempty"`
+}
+
+func (x *DestinationTable) Reset() {
+	*x = DestinationTable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DestinationTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestinationTable) ProtoMessage() {}
+
+func (x *DestinationTable) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestinationTable.ProtoReflect.Descriptor instead.
+func (*DestinationTable) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *DestinationTable) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *DestinationTable) GetWriteDisposition() DestinationTable_WriteDisposition {
+	if x != nil {
+		return x.WriteDisposition
+	}
+	return DestinationTable_WRITE_DISPOSITION_UNSPECIFIED
+}
+
+// H3Aggregation of result rows into H3 cells, stored as additional result
+type H3Aggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LatColumn       string      `protobuf:"bytes,1,opt,name=lat_column,json=latColumn,proto3" json:"lat_column,omitempty"`
+	LngColumn       string      `protobuf:"bytes,2,opt,name=lng_column,json=lngColumn,proto3" json:"lng_column,omitempty"`
+	GeographyColumn string      `protobuf:"bytes,3,opt,name=geography_column,json=geographyColumn,proto3" json:"geography_column,omitempty"` // column with GEOGRAPHY points, used instead of lat_column and lng_column
+	Resolution      int32       `protobuf:"varint,4,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	Metrics         []*H3Metric `protobuf:"bytes,5,rep,name=metrics,proto3" json:"metrics,omitempty"` // aggregations in addition to count of rows in cell
+}
+
+func (x *H3Aggregation) Reset() {
+	*x = H3Aggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *H3Aggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*H3Aggregation) ProtoMessage() {}
+
+func (x *H3Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use H3Aggregation.ProtoReflect.Descriptor instead.
+func (*H3Aggregation) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *H3Aggregation) GetLatColumn() string {
+	if x != nil {
+		return x.LatColumn
+	}
+	return ""
+}
+
+func (x *H3Aggregation) GetLngColumn() string {
+	if x != nil {
+		return x.LngColumn
+	}
+	return ""
+}
+
+func (x *H3Aggregation) GetGeographyColumn() string {
+	if x != nil {
+		return x.GeographyColumn
+	}
+	return ""
+}
+
+func (x *H3Aggregation) GetResolution() int32 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *H3Aggregation) GetMetrics() []*H3Metric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+type H3Metric struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Column   string            `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Function H3Metric_Function `protobuf:"varint,2,opt,name=function,proto3,enum=H3Metric_Function" json:"function,omitempty"`
+}
+
+func (x *H3Metric) Reset() {
+	*x = H3Metric{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *H3Metric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*H3Metric) ProtoMessage() {}
+
+func (x *H3Metric) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use H3Metric.ProtoReflect.Descriptor instead.
+func (*H3Metric) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *H3Metric) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *H3Metric) GetFunction() H3Metric_Function {
+	if x != nil {
+		return x.Function
+	}
+	return H3Metric_FUNCTION_UNSPECIFIED
+}
+
+type GetReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type GetReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Report  *Report  `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	Queries []*Query `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries,omitempty"`
+	Files   []*File  `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *GetReportResponse) Reset() {
+	*x = GetReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportResponse) ProtoMessage() {}
+
+func (x *GetReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportResponse.ProtoReflect.Descriptor instead.
+func (*GetReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *GetReportResponse) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetQueries() []*Query {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *GetReportResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type GetQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+}
+
+func (x *GetQueryRequest) Reset() {
+	*x = GetQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueryRequest) ProtoMessage() {}
+
+func (x *GetQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueryRequest.ProtoReflect.Descriptor instead.
+func (*GetQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetQueryRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+type GetQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *GetQueryResponse) Reset() {
+	*x = GetQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueryResponse) ProtoMessage() {}
+
+func (x *GetQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueryResponse.ProtoReflect.Descriptor instead.
+func (*GetQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetQueryResponse) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type GetQuerySchemaContextRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId     string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	QueryText    string `protobuf:"bytes,2,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`             // current text in editor, may be not saved
+	UseLegacySql bool   `protobuf:"varint,3,opt,name=use_legacy_sql,json=useLegacySql,proto3" json:"use_legacy_sql,omitempty"` // dialect of query_text, query_text is validated as it would run
+	Connection   string `protobuf:"bytes,4,opt,name=connection,proto3" json:"connection,omitempty"`                            // optional, tables outside of datasets allowed for connection are not shown
+}
+
+func (x *GetQuerySchemaContextRequest) Reset() {
+	*x = GetQuerySchemaContextRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuerySchemaContextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuerySchemaContextRequest) ProtoMessage() {}
+
+func (x *GetQuerySchemaContextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuerySchemaContextRequest.ProtoReflect.Descriptor instead.
+func (*GetQuerySchemaContextRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetQuerySchemaContextRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *GetQuerySchemaContextRequest) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
+	}
+	return ""
+}
+
+func (x *GetQuerySchemaContextRequest) GetUseLegacySql() bool {
+	if x != nil {
+		return x.UseLegacySql
+	}
+	return false
+}
+
+func (x *GetQuerySchemaContextRequest) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+type SchemaColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table       string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"` // project.dataset.table
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`   // nested fields are parent.child
+	Type        string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Mode        string `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"` // NULLABLE, REQUIRED or REPEATED
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *SchemaColumn) Reset() {
+	*x = SchemaColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchemaColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaColumn) ProtoMessage() {}
+
+func (x *SchemaColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaColumn.ProtoReflect.Descriptor instead.
+func (*SchemaColumn) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *SchemaColumn) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *SchemaColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SchemaColumn) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SchemaColumn) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SchemaColumn) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetQuerySchemaContextResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tables             []string        `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`                                                      // referenced tables with schema, in order of first reference
+	Columns            []*SchemaColumn `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`                                                    // columns of tables in table order
+	Warnings           []string        `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`                                                  // tables which are unknown, not accessible or can't be qualified
+	SnapshotAgeSeconds int64           `protobuf:"varint,4,opt,name=snapshot_age_seconds,json=snapshotAgeSeconds,proto3" json:"snapshot_age_seconds,omitempty"` // age of oldest schema snapshot columns were read from, 0 when all were read from table metadata
+}
+
+func (x *GetQuerySchemaContextResponse) Reset() {
+	*x = GetQuerySchemaContextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetQuerySchemaContextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuerySchemaContextResponse) ProtoMessage() {}
+
+func (x *GetQuerySchemaContextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuerySchemaContextResponse.ProtoReflect.Descriptor instead.
+func (*GetQuerySchemaContextResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetQuerySchemaContextResponse) GetTables() []string {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *GetQuerySchemaContextResponse) GetColumns() []*SchemaColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *GetQuerySchemaContextResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *GetQuerySchemaContextResponse) GetSnapshotAgeSeconds() int64 {
+	if x != nil {
+		return x.SnapshotAgeSeconds
+	}
+	return 0
+}
+
+// SchemaSnapshotSource of INFORMATION_SCHEMA snapshot, project.dataset or project.region-xx
+type SchemaSnapshotSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source       string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	SnapshotAt   int64  `protobuf:"varint,2,opt,name=snapshot_at,json=snapshotAt,proto3" json:"snapshot_at,omitempty"` // when last complete snapshot was stored, 0 before first one
+	AgeSeconds   int64  `protobuf:"varint,3,opt,name=age_seconds,json=ageSeconds,proto3" json:"age_seconds,omitempty"`
+	Stale        bool   `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"` // snapshot is older than max age, schema context reads table metadata instead
+	Error        string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`  // error of last refresh when it failed after snapshot_at, rows it read were discarded
+	FailedAt     int64  `protobuf:"varint,6,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	ColumnsCount int64  `protobuf:"varint,7,opt,name=columns_count,json=columnsCount,proto3" json:"columns_count,omitempty"`
+}
+
+func (x *SchemaSnapshotSource) Reset() {
+	*x = SchemaSnapshotSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchemaSnapshotSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaSnapshotSource) ProtoMessage() {}
+
+func (x *SchemaSnapshotSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaSnapshotSource.ProtoReflect.Descriptor instead.
+func (*SchemaSnapshotSource) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *SchemaSnapshotSource) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *SchemaSnapshotSource) GetSnapshotAt() int64 {
+	if x != nil {
+		return x.SnapshotAt
+	}
+	return 0
+}
+
+func (x *SchemaSnapshotSource) GetAgeSeconds() int64 {
+	if x != nil {
+		return x.AgeSeconds
+	}
+	return 0
+}
+
+func (x *SchemaSnapshotSource) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *SchemaSnapshotSource) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SchemaSnapshotSource) GetFailedAt() int64 {
+	if x != nil {
+		return x.FailedAt
+	}
+	return 0
+}
+
+func (x *SchemaSnapshotSource) GetColumnsCount() int64 {
+	if x != nil {
+		return x.ColumnsCount
+	}
+	return 0
+}
+
+type GetSchemaSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Connection  string `protobuf:"bytes,1,opt,name=connection,proto3" json:"connection,omitempty"`                      // optional, tables outside of datasets allowed for connection are not listed
+	TablePrefix string `protobuf:"bytes,2,opt,name=table_prefix,json=tablePrefix,proto3" json:"table_prefix,omitempty"` // optional, like project.dataset.
+	Table       string `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`                                // optional project.dataset.table, columns of it are returned
+}
+
+func (x *GetSchemaSnapshotRequest) Reset() {
+	*x = GetSchemaSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSchemaSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaSnapshotRequest) ProtoMessage() {}
+
+func (x *GetSchemaSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetSchemaSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetSchemaSnapshotRequest) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+func (x *GetSchemaSnapshotRequest) GetTablePrefix() string {
+	if x != nil {
+		return x.TablePrefix
+	}
+	return ""
+}
+
+func (x *GetSchemaSnapshotRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+type GetSchemaSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tables    []string                `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`        // tables of snapshot with table_prefix in name order
+	Truncated bool                    `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"` // more tables have table_prefix than are listed
+	Columns   []*SchemaColumn         `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`      // columns of table in order of snapshot
+	Sources   []*SchemaSnapshotSource `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *GetSchemaSnapshotResponse) Reset() {
+	*x = GetSchemaSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSchemaSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSchemaSnapshotResponse) ProtoMessage() {}
+
+func (x *GetSchemaSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSchemaSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*GetSchemaSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *GetSchemaSnapshotResponse) GetTables() []string {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *GetSchemaSnapshotResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *GetSchemaSnapshotResponse) GetColumns() []*SchemaColumn {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *GetSchemaSnapshotResponse) GetSources() []*SchemaSnapshotSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type RefreshSchemaSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // optional, all sources are refreshed when empty
+}
+
+func (x *RefreshSchemaSnapshotRequest) Reset() {
+	*x = RefreshSchemaSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshSchemaSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSchemaSnapshotRequest) ProtoMessage() {}
+
+func (x *RefreshSchemaSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSchemaSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSchemaSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *RefreshSchemaSnapshotRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type RefreshSchemaSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sources []*SchemaSnapshotSource `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"` // refreshed sources, failed refresh has error
+}
+
+func (x *RefreshSchemaSnapshotResponse) Reset() {
+	*x = RefreshSchemaSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefreshSchemaSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSchemaSnapshotResponse) ProtoMessage() {}
+
+func (x *RefreshSchemaSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSchemaSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*RefreshSchemaSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *RefreshSchemaSnapshotResponse) GetSources() []*SchemaSnapshotSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+type GetExecutedQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+}
+
+func (x *GetExecutedQueryRequest) Reset() {
+	*x = GetExecutedQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExecutedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutedQueryRequest) ProtoMessage() {}
+
+func (x *GetExecutedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutedQueryRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetExecutedQueryRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+type GetExecutedQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId           string  `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	ExecutedQueryText string  `protobuf:"bytes,2,opt,name=executed_query_text,json=executedQueryText,proto3" json:"executed_query_text,omitempty"`   // empty when query was never run
+	ExecutedQueryHash string  `protobuf:"bytes,3,opt,name=executed_query_hash,json=executedQueryHash,proto3" json:"executed_query_hash,omitempty"`   // hash of executed_query_text, set as dekart-query-hash label of BigQuery job
+	DefaultDataset    string  `protobuf:"bytes,4,opt,name=default_dataset,json=defaultDataset,proto3" json:"default_dataset,omitempty"`              // dataset of unqualified tables applied to job, empty when not set
+	SampleRate        float64 `protobuf:"fixed64,5,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`                        // fraction of rows sampled, 0 when not sampled
+	ExplorationLimit  int64   `protobuf:"varint,6,opt,name=exploration_limit,json=explorationLimit,proto3" json:"exploration_limit,omitempty"`       // LIMIT query was wrapped in, 0 when not wrapped
+	ReusedFromQueryId string  `protobuf:"bytes,7,opt,name=reused_from_query_id,json=reusedFromQueryId,proto3" json:"reused_from_query_id,omitempty"` // query whose result and executed text were reused
+	UseLegacySql      bool    `protobuf:"varint,8,opt,name=use_legacy_sql,json=useLegacySql,proto3" json:"use_legacy_sql,omitempty"`                 // executed_query_text was run in legacy SQL
+}
+
+func (x *GetExecutedQueryResponse) Reset() {
+	*x = GetExecutedQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExecutedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutedQueryResponse) ProtoMessage() {}
+
+func (x *GetExecutedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutedQueryResponse.ProtoReflect.Descriptor instead.
+func (*GetExecutedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GetExecutedQueryResponse) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *GetExecutedQueryResponse) GetExecutedQueryText() string {
+	if x != nil {
+		return x.ExecutedQueryText
+	}
+	return ""
+}
+
+func (x *GetExecutedQueryResponse) GetExecutedQueryHash() string {
+	if x != nil {
+		return x.ExecutedQueryHash
+	}
+	return ""
+}
+
+func (x *GetExecutedQueryResponse) GetDefaultDataset() string {
+	if x != nil {
+		return x.DefaultDataset
+	}
+	return ""
+}
+
+func (x *GetExecutedQueryResponse) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *GetExecutedQueryResponse) GetExplorationLimit() int64 {
+	if x != nil {
+		return x.ExplorationLimit
+	}
+	return 0
+}
+
+func (x *GetExecutedQueryResponse) GetReusedFromQueryId() string {
+	if x != nil {
+		return x.ReusedFromQueryId
+	}
+	return ""
+}
+
+func (x *GetExecutedQueryResponse) GetUseLegacySql() bool {
+	if x != nil {
+		return x.UseLegacySql
+	}
+	return false
+}
+
+type GetJobPlanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+}
+
+func (x *GetJobPlanRequest) Reset() {
+	*x = GetJobPlanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobPlanRequest) ProtoMessage() {}
+
+func (x *GetJobPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetJobPlanRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetJobPlanRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+// JobPlanStage of BigQuery query plan; ratios are relative to time of slowest shard of any stage
+type JobPlanStage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                        int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                      string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status                    string  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	InputStages               []int64 `protobuf:"varint,4,rep,packed,name=input_stages,json=inputStages,proto3" json:"input_stages,omitempty"`
+	RecordsRead               int64   `protobuf:"varint,5,opt,name=records_read,json=recordsRead,proto3" json:"records_read,omitempty"`
+	RecordsWritten            int64   `protobuf:"varint,6,opt,name=records_written,json=recordsWritten,proto3" json:"records_written,omitempty"`
+	WaitRatioAvg              float64 `protobuf:"fixed64,7,opt,name=wait_ratio_avg,json=waitRatioAvg,proto3" json:"wait_ratio_avg,omitempty"`
+	WaitRatioMax              float64 `protobuf:"fixed64,8,opt,name=wait_ratio_max,json=waitRatioMax,proto3" json:"wait_ratio_max,omitempty"`
+	ReadRatioAvg              float64 `protobuf:"fixed64,9,opt,name=read_ratio_avg,json=readRatioAvg,proto3" json:"read_ratio_avg,omitempty"`
+	ReadRatioMax              float64 `protobuf:"fixed64,10,opt,name=read_ratio_max,json=readRatioMax,proto3" json:"read_ratio_max,omitempty"`
+	ComputeRatioAvg           float64 `protobuf:"fixed64,11,opt,name=compute_ratio_avg,json=computeRatioAvg,proto3" json:"compute_ratio_avg,omitempty"`
+	ComputeRatioMax           float64 `protobuf:"fixed64,12,opt,name=compute_ratio_max,json=computeRatioMax,proto3" json:"compute_ratio_max,omitempty"`
+	WriteRatioAvg             float64 `protobuf:"fixed64,13,opt,name=write_ratio_avg,json=writeRatioAvg,proto3" json:"write_ratio_avg,omitempty"`
+	WriteRatioMax             float64 `protobuf:"fixed64,14,opt,name=write_ratio_max,json=writeRatioMax,proto3" json:"write_ratio_max,omitempty"`
+	ShuffleOutputBytes        int64   `protobuf:"varint,15,opt,name=shuffle_output_bytes,json=shuffleOutputBytes,proto3" json:"shuffle_output_bytes,omitempty"`
+	ShuffleOutputBytesSpilled int64   `protobuf:"varint,16,opt,name=shuffle_output_bytes_spilled,json=shuffleOutputBytesSpilled,proto3" json:"shuffle_output_bytes_spilled,omitempty"`
+	DurationMs                int64   `protobuf:"varint,17,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // from stage start to end, 0 when stage has not ended
+}
+
+func (x *JobPlanStage) Reset() {
+	*x = JobPlanStage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobPlanStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobPlanStage) ProtoMessage() {}
+
+func (x *JobPlanStage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobPlanStage.ProtoReflect.Descriptor instead.
+func (*JobPlanStage) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *JobPlanStage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobPlanStage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobPlanStage) GetInputStages() []int64 {
+	if x != nil {
+		return x.InputStages
+	}
+	return nil
+}
+
+func (x *JobPlanStage) GetRecordsRead() int64 {
+	if x != nil {
+		return x.RecordsRead
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetRecordsWritten() int64 {
+	if x != nil {
+		return x.RecordsWritten
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetWaitRatioAvg() float64 {
+	if x != nil {
+		return x.WaitRatioAvg
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetWaitRatioMax() float64 {
+	if x != nil {
+		return x.WaitRatioMax
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetReadRatioAvg() float64 {
+	if x != nil {
+		return x.ReadRatioAvg
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetReadRatioMax() float64 {
+	if x != nil {
+		return x.ReadRatioMax
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetComputeRatioAvg() float64 {
+	if x != nil {
+		return x.ComputeRatioAvg
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetComputeRatioMax() float64 {
+	if x != nil {
+		return x.ComputeRatioMax
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetWriteRatioAvg() float64 {
+	if x != nil {
+		return x.WriteRatioAvg
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetWriteRatioMax() float64 {
+	if x != nil {
+		return x.WriteRatioMax
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetShuffleOutputBytes() int64 {
+	if x != nil {
+		return x.ShuffleOutputBytes
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetShuffleOutputBytesSpilled() int64 {
+	if x != nil {
+		return x.ShuffleOutputBytesSpilled
+	}
+	return 0
+}
+
+func (x *JobPlanStage) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// GetJobPlanResponse with query plan summary of last job; NotFound when last job has no plan
+type GetJobPlanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId          string          `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	Stages           []*JobPlanStage `protobuf:"bytes,2,rep,name=stages,proto3" json:"stages,omitempty"`                                             // in plan order
+	TotalStages      int32           `protobuf:"varint,3,opt,name=total_stages,json=totalStages,proto3" json:"total_stages,omitempty"`               // stages of full plan, more than stages when plan was truncated
+	ReferencedTables []string        `protobuf:"bytes,4,rep,name=referenced_tables,json=referencedTables,proto3" json:"referenced_tables,omitempty"` // project.dataset.table read by job
+	Note             string          `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`                                                 // set when plan was truncated to slowest stages
+}
+
+func (x *GetJobPlanResponse) Reset() {
+	*x = GetJobPlanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobPlanResponse) ProtoMessage() {}
+
+func (x *GetJobPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobPlanResponse.ProtoReflect.Descriptor instead.
+func (*GetJobPlanResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetJobPlanResponse) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *GetJobPlanResponse) GetStages() []*JobPlanStage {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *GetJobPlanResponse) GetTotalStages() int32 {
+	if x != nil {
+		return x.TotalStages
+	}
+	return 0
+}
+
+func (x *GetJobPlanResponse) GetReferencedTables() []string {
+	if x != nil {
+		return x.ReferencedTables
+	}
+	return nil
+}
+
+func (x *GetJobPlanResponse) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type UpdateReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Report *Report `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (x *UpdateReportRequest) Reset() {
+	*x = UpdateReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[96]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportRequest) ProtoMessage() {}
+
+func (x *UpdateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[96]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *UpdateReportRequest) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type UpdateMapConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId  string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	MapConfig string `protobuf:"bytes,2,opt,name=map_config,json=mapConfig,proto3" json:"map_config,omitempty"`
+}
+
+func (x *UpdateMapConfigRequest) Reset() {
+	*x = UpdateMapConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[97]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateMapConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMapConfigRequest) ProtoMessage() {}
+
+func (x *UpdateMapConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[97]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMapConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMapConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *UpdateMapConfigRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateMapConfigRequest) GetMapConfig() string {
+	if x != nil {
+		return x.MapConfig
+	}
+	return ""
+}
+
+type UpdateMapConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"` // same as previous revision when map config is not changed
+}
+
+func (x *UpdateMapConfigResponse) Reset() {
+	*x = UpdateMapConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[98]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateMapConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMapConfigResponse) ProtoMessage() {}
+
+func (x *UpdateMapConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[98]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMapConfigResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMapConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *UpdateMapConfigResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type GetMapConfigHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Limit    int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // number of revisions, 10 by default, at most 100
+}
+
+func (x *GetMapConfigHistoryRequest) Reset() {
+	*x = GetMapConfigHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[99]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMapConfigHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMapConfigHistoryRequest) ProtoMessage() {}
+
+func (x *GetMapConfigHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[99]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMapConfigHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetMapConfigHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *GetMapConfigHistoryRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *GetMapConfigHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MapConfigRevision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision    int64  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	MapConfig   string `protobuf:"bytes,2,opt,name=map_config,json=mapConfig,proto3" json:"map_config,omitempty"`
+	AuthorEmail string `protobuf:"bytes,3,opt,name=author_email,json=authorEmail,proto3" json:"author_email,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix seconds
+}
+
+func (x *MapConfigRevision) Reset() {
+	*x = MapConfigRevision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[100]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MapConfigRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MapConfigRevision) ProtoMessage() {}
+
+func (x *MapConfigRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[100]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MapConfigRevision.ProtoReflect.Descriptor instead.
+func (*MapConfigRevision) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *MapConfigRevision) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *MapConfigRevision) GetMapConfig() string {
+	if x != nil {
+		return x.MapConfig
+	}
+	return ""
+}
+
+func (x *MapConfigRevision) GetAuthorEmail() string {
+	if x != nil {
+		return x.AuthorEmail
+	}
+	return ""
+}
+
+func (x *MapConfigRevision) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetMapConfigHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revisions []*MapConfigRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"` // newest first
+}
+
+func (x *GetMapConfigHistoryResponse) Reset() {
+	*x = GetMapConfigHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[101]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMapConfigHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMapConfigHistoryResponse) ProtoMessage() {}
+
+func (x *GetMapConfigHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[101]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMapConfigHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetMapConfigHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *GetMapConfigHistoryResponse) GetRevisions() []*MapConfigRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type UpdateReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Warning string `protobuf:"bytes,1,opt,name=warning,proto3" json:"warning,omitempty"` // report is saved, but settings may not work, e.g. default dataset not found
+}
+
+func (x *UpdateReportResponse) Reset() {
+	*x = UpdateReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[102]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReportResponse) ProtoMessage() {}
+
+func (x *UpdateReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[102]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReportResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *UpdateReportResponse) GetWarning() string {
+	if x != nil {
+		return x.Warning
+	}
+	return ""
+}
+
+type RunQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId              string                `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	SampleRate           float64               `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`                                                                   // run on fraction of rows for fast preview, 0 runs on all rows
+	H3Aggregation        *H3Aggregation        `protobuf:"bytes,3,opt,name=h3_aggregation,json=h3Aggregation,proto3" json:"h3_aggregation,omitempty"`                                                            // optional
+	Variables            map[string]string     `protobuf:"bytes,4,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // values of report variables by name, default values are used for others
+	DestinationTable     *DestinationTable     `protobuf:"bytes,5,opt,name=destination_table,json=destinationTable,proto3" json:"destination_table,omitempty"`                                                   // optional, can't be combined with h3_aggregation
+	IdempotencyKey       string                `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                                         // optional, retried request with same key within 5 minutes returns job of first request
+	ResultFormat         ResultFormat          `protobuf:"varint,7,opt,name=result_format,json=resultFormat,proto3,enum=ResultFormat" json:"result_format,omitempty"`                                            // format of result file, default of report when unspecified
+	SerializationOptions *SerializationOptions `protobuf:"bytes,8,opt,name=serialization_options,json=serializationOptions,proto3" json:"serialization_options,omitempty"`                                       // optional, only for CSV result
+	ResultCompression    ResultCompression     `protobuf:"varint,9,opt,name=result_compression,json=resultCompression,proto3,enum=ResultCompression" json:"result_compression,omitempty"`                        // only for CSV result, default of report when unspecified
+	Connection           string                `protobuf:"bytes,10,opt,name=connection,proto3" json:"connection,omitempty"`                                                                                      // optional, job is run with federated credentials of connection of DEKART_BIGQUERY_CONNECTIONS
+	AdditionalFormats    []ResultFormat        `protobuf:"varint,11,rep,packed,name=additional_formats,json=additionalFormats,proto3,enum=ResultFormat" json:"additional_formats,omitempty"`                     // result is also written in these formats in same pass, not with destination_table
+	AcknowledgedBytes    int64                 `protobuf:"varint,12,opt,name=acknowledged_bytes,json=acknowledgedBytes,proto3" json:"acknowledged_bytes,omitempty"`                                              // estimate user confirmed; run estimated above DEKART_CONFIRM_BYTES_THRESHOLD fails with NEEDS_CONFIRMATION unless estimate is at most this
+	AsOf                 int64                 `protobuf:"varint,13,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`                                                                                     // optional unix seconds, tables are read FOR SYSTEM_TIME AS OF @dekart_as_of; queries of views, wildcard tables or own time travel fail with TIME_TRAVEL_UNSUPPORTED
+}
+
+func (x *RunQueryRequest) Reset() {
+	*x = RunQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[103]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunQueryRequest) ProtoMessage() {}
+
+func (x *RunQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[103]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunQueryRequest.ProtoReflect.Descriptor instead.
+func (*RunQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *RunQueryRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *RunQueryRequest) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *RunQueryRequest) GetH3Aggregation() *H3Aggregation {
+	if x != nil {
+		return x.H3Aggregation
+	}
+	return nil
+}
+
+func (x *RunQueryRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *RunQueryRequest) GetDestinationTable() *DestinationTable {
+	if x != nil {
+		return x.DestinationTable
+	}
+	return nil
+}
+
+func (x *RunQueryRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RunQueryRequest) GetResultFormat() ResultFormat {
+	if x != nil {
+		return x.ResultFormat
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+func (x *RunQueryRequest) GetSerializationOptions() *SerializationOptions {
+	if x != nil {
+		return x.SerializationOptions
+	}
+	return nil
+}
+
+func (x *RunQueryRequest) GetResultCompression() ResultCompression {
+	if x != nil {
+		return x.ResultCompression
+	}
+	return ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+}
+
+func (x *RunQueryRequest) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+func (x *RunQueryRequest) GetAdditionalFormats() []ResultFormat {
+	if x != nil {
+		return x.AdditionalFormats
+	}
+	return nil
+}
+
+func (x *RunQueryRequest) GetAcknowledgedBytes() int64 {
+	if x != nil {
+		return x.AcknowledgedBytes
+	}
+	return 0
+}
+
+func (x *RunQueryRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+type RunQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId     string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Duplicate bool   `protobuf:"varint,2,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // request with same idempotency_key was already run, job_id is job of first request
+	Reused    bool   `protobuf:"varint,3,opt,name=reused,proto3" json:"reused,omitempty"`       // result of source query of forked report was reused, job_id is empty
+}
+
+func (x *RunQueryResponse) Reset() {
+	*x = RunQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[104]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunQueryResponse) ProtoMessage() {}
+
+func (x *RunQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[104]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunQueryResponse.ProtoReflect.Descriptor instead.
+func (*RunQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *RunQueryResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *RunQueryResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *RunQueryResponse) GetReused() bool {
+	if x != nil {
+		return x.Reused
+	}
+	return false
+}
+
+type RunAllQueriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId          string            `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Variables         map[string]string `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // values of report variables by name, default values are used for others
+	AcknowledgedBytes int64             `protobuf:"varint,3,opt,name=acknowledged_bytes,json=acknowledgedBytes,proto3" json:"acknowledged_bytes,omitempty"`                                               // estimate user confirmed like acknowledged_bytes of RunQueryRequest, applies to each query of batch
+}
+
+func (x *RunAllQueriesRequest) Reset() {
+	*x = RunAllQueriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunAllQueriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunAllQueriesRequest) ProtoMessage() {}
+
+func (x *RunAllQueriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunAllQueriesRequest.ProtoReflect.Descriptor instead.
+func (*RunAllQueriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *RunAllQueriesRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *RunAllQueriesRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *RunAllQueriesRequest) GetAcknowledgedBytes() int64 {
+	if x != nil {
+		return x.AcknowledgedBytes
+	}
+	return 0
+}
+
+// BatchRun of one query of RunAllQueries
+type BatchRun struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	JobId   string `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // empty when result was reused or query failed to start
+	Reused  bool   `protobuf:"varint,3,opt,name=reused,proto3" json:"reused,omitempty"`
+	Error   string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`      // query failed to start, its dependents are skipped and other queries of batch are run
+	Waiting bool   `protobuf:"varint,5,opt,name=waiting,proto3" json:"waiting,omitempty"` // query is started once its dependencies are done, job status is sent with report stream
+}
+
+func (x *BatchRun) Reset() {
+	*x = BatchRun{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRun) ProtoMessage() {}
+
+func (x *BatchRun) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRun.ProtoReflect.Descriptor instead.
+func (*BatchRun) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *BatchRun) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *BatchRun) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *BatchRun) GetReused() bool {
+	if x != nil {
+		return x.Reused
+	}
+	return false
+}
+
+func (x *BatchRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchRun) GetWaiting() bool {
+	if x != nil {
+		return x.Waiting
+	}
+	return false
+}
+
+type RunAllQueriesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchId string      `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Runs    []*BatchRun `protobuf:"bytes,2,rep,name=runs,proto3" json:"runs,omitempty"` // in order of queries in report
+}
+
+func (x *RunAllQueriesResponse) Reset() {
+	*x = RunAllQueriesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[107]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunAllQueriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunAllQueriesResponse) ProtoMessage() {}
+
+func (x *RunAllQueriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[107]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunAllQueriesResponse.ProtoReflect.Descriptor instead.
+func (*RunAllQueriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *RunAllQueriesResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *RunAllQueriesResponse) GetRuns() []*BatchRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type CancelBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	BatchId  string `protobuf:"bytes,2,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+}
+
+func (x *CancelBatchRequest) Reset() {
+	*x = CancelBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[108]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBatchRequest) ProtoMessage() {}
+
+func (x *CancelBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[108]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBatchRequest.ProtoReflect.Descriptor instead.
+func (*CancelBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *CancelBatchRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *CancelBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type CancelBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CancelledCount int32 `protobuf:"varint,1,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"`
+}
+
+func (x *CancelBatchResponse) Reset() {
+	*x = CancelBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[109]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBatchResponse) ProtoMessage() {}
+
+func (x *CancelBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[109]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBatchResponse.ProtoReflect.Descriptor instead.
+func (*CancelBatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *CancelBatchResponse) GetCancelledCount() int32 {
+	if x != nil {
+		return x.CancelledCount
+	}
+	return 0
+}
+
+type RunQueryAndWaitRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId              string                `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	QueryText            string                `protobuf:"bytes,2,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"` // raw SQL run instead of query of report, can't be combined with query_id
+	TimeoutSeconds       int64                 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	SampleRate           float64               `protobuf:"fixed64,4,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`                                                                   // run on fraction of rows for fast preview, 0 runs on all rows
+	H3Aggregation        *H3Aggregation        `protobuf:"bytes,5,opt,name=h3_aggregation,json=h3Aggregation,proto3" json:"h3_aggregation,omitempty"`                                                            // optional
+	Variables            map[string]string     `protobuf:"bytes,6,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // values of report variables by name, default values are used for others
+	DestinationTable     *DestinationTable     `protobuf:"bytes,7,opt,name=destination_table,json=destinationTable,proto3" json:"destination_table,omitempty"`                                                   // optional, can't be combined with h3_aggregation
+	ResultFormat         ResultFormat          `protobuf:"varint,8,opt,name=result_format,json=resultFormat,proto3,enum=ResultFormat" json:"result_format,omitempty"`                                            // format of result file, default of report when unspecified
+	SerializationOptions *SerializationOptions `protobuf:"bytes,9,opt,name=serialization_options,json=serializationOptions,proto3" json:"serialization_options,omitempty"`                                       // optional, only for CSV result
+	ResultCompression    ResultCompression     `protobuf:"varint,10,opt,name=result_compression,json=resultCompression,proto3,enum=ResultCompression" json:"result_compression,omitempty"`                       // only for CSV result, default of report when unspecified
+	Connection           string                `protobuf:"bytes,11,opt,name=connection,proto3" json:"connection,omitempty"`                                                                                      // optional, job is run with federated credentials of connection of DEKART_BIGQUERY_CONNECTIONS
+	AdditionalFormats    []ResultFormat        `protobuf:"varint,12,rep,packed,name=additional_formats,json=additionalFormats,proto3,enum=ResultFormat" json:"additional_formats,omitempty"`                     // result is also written in these formats in same pass, not with destination_table
+	IdempotencyKey       string                `protobuf:"bytes,13,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                                        // optional, retried request with same key within 5 minutes waits for job of first request; can't be combined with query_text
+	AsOf                 int64                 `protobuf:"varint,14,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`                                                                                     // optional unix seconds, tables are read FOR SYSTEM_TIME AS OF @dekart_as_of like as_of of RunQueryRequest
+	AcknowledgedBytes    int64                 `protobuf:"varint,15,opt,name=acknowledged_bytes,json=acknowledgedBytes,proto3" json:"acknowledged_bytes,omitempty"`                                              // estimate user confirmed like acknowledged_bytes of RunQueryRequest
+}
+
+func (x *RunQueryAndWaitRequest) Reset() {
+	*x = RunQueryAndWaitRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[110]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunQueryAndWaitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunQueryAndWaitRequest) ProtoMessage() {}
+
+func (x *RunQueryAndWaitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[110]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunQueryAndWaitRequest.ProtoReflect.Descriptor instead.
+func (*RunQueryAndWaitRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *RunQueryAndWaitRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitRequest) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitRequest) GetTimeoutSeconds() int64 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitRequest) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitRequest) GetH3Aggregation() *H3Aggregation {
+	if x != nil {
+		return x.H3Aggregation
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitRequest) GetDestinationTable() *DestinationTable {
+	if x != nil {
+		return x.DestinationTable
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitRequest) GetResultFormat() ResultFormat {
+	if x != nil {
+		return x.ResultFormat
+	}
+	return ResultFormat_RESULT_FORMAT_UNSPECIFIED
+}
+
+func (x *RunQueryAndWaitRequest) GetSerializationOptions() *SerializationOptions {
+	if x != nil {
+		return x.SerializationOptions
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitRequest) GetResultCompression() ResultCompression {
+	if x != nil {
+		return x.ResultCompression
+	}
+	return ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+}
+
+func (x *RunQueryAndWaitRequest) GetConnection() string {
+	if x != nil {
+		return x.Connection
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitRequest) GetAdditionalFormats() []ResultFormat {
+	if x != nil {
+		return x.AdditionalFormats
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitRequest) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitRequest) GetAcknowledgedBytes() int64 {
+	if x != nil {
+		return x.AcknowledgedBytes
+	}
+	return 0
+}
+
+type RunQueryAndWaitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keepalive         bool             `protobuf:"varint,1,opt,name=keepalive,proto3" json:"keepalive,omitempty"` // true for messages sent while job is still running
+	JobStatus         Query_JobStatus  `protobuf:"varint,2,opt,name=job_status,json=jobStatus,proto3,enum=Query_JobStatus" json:"job_status,omitempty"`
+	JobResultId       string           `protobuf:"bytes,3,opt,name=job_result_id,json=jobResultId,proto3" json:"job_result_id,omitempty"`
+	JobError          string           `protobuf:"bytes,4,opt,name=job_error,json=jobError,proto3" json:"job_error,omitempty"`
+	TotalRows         int64            `protobuf:"varint,5,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	BytesProcessed    int64            `protobuf:"varint,6,opt,name=bytes_processed,json=bytesProcessed,proto3" json:"bytes_processed,omitempty"`
+	ResultSize        int64            `protobuf:"varint,7,opt,name=result_size,json=resultSize,proto3" json:"result_size,omitempty"`
+	DownloadUrl       string           `protobuf:"bytes,8,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	RowsWritten       int64            `protobuf:"varint,9,opt,name=rows_written,json=rowsWritten,proto3" json:"rows_written,omitempty"`
+	SampleRate        float64          `protobuf:"fixed64,10,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // fraction of rows sampled into result, 0 when result is not sampled
+	H3ResultId        string           `protobuf:"bytes,11,opt,name=h3_result_id,json=h3ResultId,proto3" json:"h3_result_id,omitempty"`
+	H3DownloadUrl     string           `protobuf:"bytes,12,opt,name=h3_download_url,json=h3DownloadUrl,proto3" json:"h3_download_url,omitempty"`
+	ExecutedQueryText string           `protobuf:"bytes,13,opt,name=executed_query_text,json=executedQueryText,proto3" json:"executed_query_text,omitempty"` // query text run by job with datasets and variables expanded, sampling and exploration LIMIT applied
+	ResultType        Query_ResultType `protobuf:"varint,14,opt,name=result_type,json=resultType,proto3,enum=Query_ResultType" json:"result_type,omitempty"`
+	ResultTable       string           `protobuf:"bytes,15,opt,name=result_table,json=resultTable,proto3" json:"result_table,omitempty"`                     // project.dataset.table of table result
+	ExplorationLimit  int64            `protobuf:"varint,16,opt,name=exploration_limit,json=explorationLimit,proto3" json:"exploration_limit,omitempty"`     // LIMIT of exploration mode query was wrapped in, 0 when query was run as written
+	ExecutedQueryHash string           `protobuf:"bytes,17,opt,name=executed_query_hash,json=executedQueryHash,proto3" json:"executed_query_hash,omitempty"` // hash of executed_query_text, set as dekart-query-hash label of BigQuery job
+	Warnings          []*JobWarning    `protobuf:"bytes,18,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	FormatResults     []*FormatResult  `protobuf:"bytes,19,rep,name=format_results,json=formatResults,proto3" json:"format_results,omitempty"`                 // results in additional formats of request
+	CancelReason      CancelReason     `protobuf:"varint,20,opt,name=cancel_reason,json=cancelReason,proto3,enum=CancelReason" json:"cancel_reason,omitempty"` // set when job_status is unspecified because job was cancelled
+}
+
+func (x *RunQueryAndWaitResponse) Reset() {
+	*x = RunQueryAndWaitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[111]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunQueryAndWaitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunQueryAndWaitResponse) ProtoMessage() {}
+
+func (x *RunQueryAndWaitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[111]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunQueryAndWaitResponse.ProtoReflect.Descriptor instead.
+func (*RunQueryAndWaitResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *RunQueryAndWaitResponse) GetKeepalive() bool {
+	if x != nil {
+		return x.Keepalive
+	}
+	return false
+}
+
+func (x *RunQueryAndWaitResponse) GetJobStatus() Query_JobStatus {
+	if x != nil {
+		return x.JobStatus
+	}
+	return Query_JOB_STATUS_UNSPECIFIED
+}
+
+func (x *RunQueryAndWaitResponse) GetJobResultId() string {
+	if x != nil {
+		return x.JobResultId
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetJobError() string {
+	if x != nil {
+		return x.JobError
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitResponse) GetBytesProcessed() int64 {
+	if x != nil {
+		return x.BytesProcessed
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitResponse) GetResultSize() int64 {
+	if x != nil {
+		return x.ResultSize
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitResponse) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetRowsWritten() int64 {
+	if x != nil {
+		return x.RowsWritten
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitResponse) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitResponse) GetH3ResultId() string {
+	if x != nil {
+		return x.H3ResultId
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetH3DownloadUrl() string {
+	if x != nil {
+		return x.H3DownloadUrl
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetExecutedQueryText() string {
+	if x != nil {
+		return x.ExecutedQueryText
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetResultType() Query_ResultType {
+	if x != nil {
+		return x.ResultType
+	}
+	return Query_RESULT_TYPE_UNSPECIFIED
+}
+
+func (x *RunQueryAndWaitResponse) GetResultTable() string {
+	if x != nil {
+		return x.ResultTable
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetExplorationLimit() int64 {
+	if x != nil {
+		return x.ExplorationLimit
+	}
+	return 0
+}
+
+func (x *RunQueryAndWaitResponse) GetExecutedQueryHash() string {
+	if x != nil {
+		return x.ExecutedQueryHash
+	}
+	return ""
+}
+
+func (x *RunQueryAndWaitResponse) GetWarnings() []*JobWarning {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitResponse) GetFormatResults() []*FormatResult {
+	if x != nil {
+		return x.FormatResults
+	}
+	return nil
+}
+
+func (x *RunQueryAndWaitResponse) GetCancelReason() CancelReason {
+	if x != nil {
+		return x.CancelReason
+	}
+	return CancelReason_CANCEL_REASON_UNSPECIFIED
+}
+
+type ExportResultRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResultId       string `protobuf:"bytes,1,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`                    // job_result_id or h3_result_id of query
+	DestinationUri string `protobuf:"bytes,2,opt,name=destination_uri,json=destinationUri,proto3" json:"destination_uri,omitempty"`  // gs://bucket/object
+	Overwrite      bool   `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`                                 // replace existing object, export fails with ALREADY_EXISTS otherwise
+	IncludeLineage bool   `protobuf:"varint,4,opt,name=include_lineage,json=includeLineage,proto3" json:"include_lineage,omitempty"` // set lineage of result as dekart-lineage metadata of exported object
+}
+
+func (x *ExportResultRequest) Reset() {
+	*x = ExportResultRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[112]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResultRequest) ProtoMessage() {}
+
+func (x *ExportResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[112]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResultRequest.ProtoReflect.Descriptor instead.
+func (*ExportResultRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ExportResultRequest) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *ExportResultRequest) GetDestinationUri() string {
+	if x != nil {
+		return x.DestinationUri
+	}
+	return ""
+}
+
+func (x *ExportResultRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+func (x *ExportResultRequest) GetIncludeLineage() bool {
+	if x != nil {
+		return x.IncludeLineage
+	}
+	return false
+}
+
+// ExportResultResponse reports progress of copy, last message has done set
+type ExportResultResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Done           bool   `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`
+	BytesCopied    int64  `protobuf:"varint,2,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
+	TotalBytes     int64  `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	DestinationUri string `protobuf:"bytes,4,opt,name=destination_uri,json=destinationUri,proto3" json:"destination_uri,omitempty"`
+}
+
+func (x *ExportResultResponse) Reset() {
+	*x = ExportResultResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[113]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResultResponse) ProtoMessage() {}
+
+func (x *ExportResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[113]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResultResponse.ProtoReflect.Descriptor instead.
+func (*ExportResultResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ExportResultResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ExportResultResponse) GetBytesCopied() int64 {
+	if x != nil {
+		return x.BytesCopied
+	}
+	return 0
+}
+
+func (x *ExportResultResponse) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *ExportResultResponse) GetDestinationUri() string {
+	if x != nil {
+		return x.DestinationUri
+	}
+	return ""
+}
+
+// ResultLineage of completed result: job, query and values which produced it
+type ResultLineage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResultId         string            `protobuf:"bytes,1,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+	ReportId         string            `protobuf:"bytes,2,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	QueryId          string            `protobuf:"bytes,3,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	JobId            string            `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // dekart job
+	BigqueryJobId    string            `protobuf:"bytes,5,opt,name=bigquery_job_id,json=bigqueryJobId,proto3" json:"bigquery_job_id,omitempty"`
+	BigqueryProject  string            `protobuf:"bytes,6,opt,name=bigquery_project,json=bigqueryProject,proto3" json:"bigquery_project,omitempty"` // project job was billed to
+	BigqueryLocation string            `protobuf:"bytes,7,opt,name=bigquery_location,json=bigqueryLocation,proto3" json:"bigquery_location,omitempty"`
+	QueryHash        string            `protobuf:"bytes,8,opt,name=query_hash,json=queryHash,proto3" json:"query_hash,omitempty"`                                                                                                            // hash of executed query text
+	QueryText        string            `protobuf:"bytes,9,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`                                                                                                            // executed query text, empty unless DEKART_LINEAGE_QUERY_TEXT is set
+	ParameterValues  map[string]string `protobuf:"bytes,10,rep,name=parameter_values,json=parameterValues,proto3" json:"parameter_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // values of named parameters of query
+	UserEmail        string            `protobuf:"bytes,11,opt,name=user_email,json=userEmail,proto3" json:"user_email,omitempty"`                                                                                                           // user who ran query
+	DekartVersion    string            `protobuf:"bytes,12,opt,name=dekart_version,json=dekartVersion,proto3" json:"dekart_version,omitempty"`
+	JobStarted       int64             `protobuf:"varint,13,opt,name=job_started,json=jobStarted,proto3" json:"job_started,omitempty"`    // unix seconds
+	JobFinished      int64             `protobuf:"varint,14,opt,name=job_finished,json=jobFinished,proto3" json:"job_finished,omitempty"` // unix seconds
+}
+
+func (x *ResultLineage) Reset() {
+	*x = ResultLineage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[114]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultLineage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultLineage) ProtoMessage() {}
+
+func (x *ResultLineage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[114]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultLineage.ProtoReflect.Descriptor instead.
+func (*ResultLineage) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *ResultLineage) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetBigqueryJobId() string {
+	if x != nil {
+		return x.BigqueryJobId
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetBigqueryProject() string {
+	if x != nil {
+		return x.BigqueryProject
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetBigqueryLocation() string {
+	if x != nil {
+		return x.BigqueryLocation
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetQueryHash() string {
+	if x != nil {
+		return x.QueryHash
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetQueryText() string {
+	if x != nil {
+		return x.QueryText
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetParameterValues() map[string]string {
+	if x != nil {
+		return x.ParameterValues
+	}
+	return nil
+}
+
+func (x *ResultLineage) GetUserEmail() string {
+	if x != nil {
+		return x.UserEmail
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetDekartVersion() string {
+	if x != nil {
+		return x.DekartVersion
+	}
+	return ""
+}
+
+func (x *ResultLineage) GetJobStarted() int64 {
+	if x != nil {
+		return x.JobStarted
+	}
+	return 0
+}
+
+func (x *ResultLineage) GetJobFinished() int64 {
+	if x != nil {
+		return x.JobFinished
+	}
+	return 0
+}
+
+type GetResultLineageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResultId string `protobuf:"bytes,1,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+}
+
+func (x *GetResultLineageRequest) Reset() {
+	*x = GetResultLineageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[115]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResultLineageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultLineageRequest) ProtoMessage() {}
+
+func (x *GetResultLineageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[115]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultLineageRequest.ProtoReflect.Descriptor instead.
+func (*GetResultLineageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *GetResultLineageRequest) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+type GetResultLineageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lineage *ResultLineage `protobuf:"bytes,1,opt,name=lineage,proto3" json:"lineage,omitempty"`
+}
+
+func (x *GetResultLineageResponse) Reset() {
+	*x = GetResultLineageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[116]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResultLineageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultLineageResponse) ProtoMessage() {}
+
+func (x *GetResultLineageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[116]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultLineageResponse.ProtoReflect.Descriptor instead.
+func (*GetResultLineageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *GetResultLineageResponse) GetLineage() *ResultLineage {
+	if x != nil {
+		return x.Lineage
+	}
+	return nil
+}
+
+type GetResultFieldTypesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResultId string `protobuf:"bytes,1,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`
+}
+
+func (x *GetResultFieldTypesRequest) Reset() {
+	*x = GetResultFieldTypesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[117]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResultFieldTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultFieldTypesRequest) ProtoMessage() {}
+
+func (x *GetResultFieldTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[117]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultFieldTypesRequest.ProtoReflect.Descriptor instead.
+func (*GetResultFieldTypesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *GetResultFieldTypesRequest) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+// ResultFieldType of result column, mapped from BigQuery type so kepler.gl doesn't guess type from values
+type ResultFieldType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ColumnType   string `protobuf:"bytes,1,opt,name=column_type,json=columnType,proto3" json:"column_type,omitempty"`       // BigQuery type recorded with result, like FLOAT, NUMERIC(10,2), BYTES(BASE64) or ARRAY
+	FieldType    string `protobuf:"bytes,2,opt,name=field_type,json=fieldType,proto3" json:"field_type,omitempty"`          // kepler.gl field type: real, integer, boolean, timestamp, geojson or string
+	Name         string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                     // name of column in header when header naming renamed it, empty otherwise
+	OriginalName string `protobuf:"bytes,4,opt,name=original_name,json=originalName,proto3" json:"original_name,omitempty"` // name of column in query when header naming renamed it, shown as label
+	DatabaseType string `protobuf:"bytes,5,opt,name=database_type,json=databaseType,proto3" json:"database_type,omitempty"` // type reported by driver of SQL connection, like NUMERIC or TIMESTAMPTZ; empty for BigQuery result
+}
+
+func (x *ResultFieldType) Reset() {
+	*x = ResultFieldType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[118]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResultFieldType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResultFieldType) ProtoMessage() {}
+
+func (x *ResultFieldType) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[118]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResultFieldType.ProtoReflect.Descriptor instead.
+func (*ResultFieldType) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ResultFieldType) GetColumnType() string {
+	if x != nil {
+		return x.ColumnType
+	}
+	return ""
+}
+
+func (x *ResultFieldType) GetFieldType() string {
+	if x != nil {
+		return x.FieldType
+	}
+	return ""
+}
+
+func (x *ResultFieldType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResultFieldType) GetOriginalName() string {
+	if x != nil {
+		return x.OriginalName
+	}
+	return ""
+}
+
+func (x *ResultFieldType) GetDatabaseType() string {
+	if x != nil {
+		return x.DatabaseType
+	}
+	return ""
+}
+
+type GetResultFieldTypesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields     []*ResultFieldType `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`                           // in order of result columns
+	ResultMeta *ResultMeta        `protobuf:"bytes,2,opt,name=result_meta,json=resultMeta,proto3" json:"result_meta,omitempty"` // meta of result, unset for result stored before meta was recorded
+}
+
+func (x *GetResultFieldTypesResponse) Reset() {
+	*x = GetResultFieldTypesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[119]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResultFieldTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResultFieldTypesResponse) ProtoMessage() {}
+
+func (x *GetResultFieldTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[119]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResultFieldTypesResponse.ProtoReflect.Descriptor instead.
+func (*GetResultFieldTypesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *GetResultFieldTypesResponse) GetFields() []*ResultFieldType {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *GetResultFieldTypesResponse) GetResultMeta() *ResultMeta {
+	if x != nil {
+		return x.ResultMeta
+	}
+	return nil
+}
+
+type GetJobEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId string `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *GetJobEventsRequest) Reset() {
+	*x = GetJobEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[120]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobEventsRequest) ProtoMessage() {}
+
+func (x *GetJobEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[120]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetJobEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *GetJobEventsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// JobEvent of job timeline
+type JobEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time    int64  `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`      // unix milliseconds
+	Type    string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`       // STATE, RETRY, WARNING, FIRST_ROW, PROGRESS, UPLOAD_STARTED or UPLOAD_FINISHED
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // state, retry reason, warning code or percent of rows written
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[121]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[121]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *JobEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *JobEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JobEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetJobEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events        []*JobEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`                                     // in order they happened
+	EventsDropped int32       `protobuf:"varint,2,opt,name=events_dropped,json=eventsDropped,proto3" json:"events_dropped,omitempty"` // events replaced by later ones when log of job was full
+	Finished      bool        `protobuf:"varint,3,opt,name=finished,proto3" json:"finished,omitempty"`                                // events of finished job were persisted, running job can add events
+}
+
+func (x *GetJobEventsResponse) Reset() {
+	*x = GetJobEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[122]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobEventsResponse) ProtoMessage() {}
+
+func (x *GetJobEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[122]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetJobEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *GetJobEventsResponse) GetEvents() []*JobEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetJobEventsResponse) GetEventsDropped() int32 {
+	if x != nil {
+		return x.EventsDropped
+	}
+	return 0
+}
+
+func (x *GetJobEventsResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+// CompareResultsRequest of two CSV results of same query
+type CompareResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BaseResultId string `protobuf:"bytes,1,opt,name=base_result_id,json=baseResultId,proto3" json:"base_result_id,omitempty"` // earlier result
+	ResultId     string `protobuf:"bytes,2,opt,name=result_id,json=resultId,proto3" json:"result_id,omitempty"`               // later result, compared with base
+	KeyColumn    string `protobuf:"bytes,3,opt,name=key_column,json=keyColumn,proto3" json:"key_column,omitempty"`            // rows are joined by key column, full rows are matched without key
+	CreateDiff   bool   `protobuf:"varint,4,opt,name=create_diff,json=createDiff,proto3" json:"create_diff,omitempty"`        // store added, removed and changed rows as result with _change column
+}
+
+func (x *CompareResultsRequest) Reset() {
+	*x = CompareResultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[123]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResultsRequest) ProtoMessage() {}
+
+func (x *CompareResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[123]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResultsRequest.ProtoReflect.Descriptor instead.
+func (*CompareResultsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *CompareResultsRequest) GetBaseResultId() string {
+	if x != nil {
+		return x.BaseResultId
+	}
+	return ""
+}
+
+func (x *CompareResultsRequest) GetResultId() string {
+	if x != nil {
+		return x.ResultId
+	}
+	return ""
+}
+
+func (x *CompareResultsRequest) GetKeyColumn() string {
+	if x != nil {
+		return x.KeyColumn
+	}
+	return ""
+}
+
+func (x *CompareResultsRequest) GetCreateDiff() bool {
+	if x != nil {
+		return x.CreateDiff
+	}
+	return false
+}
+
+type ColumnChanges struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Column      string `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	ChangedRows int64  `protobuf:"varint,2,opt,name=changed_rows,json=changedRows,proto3" json:"changed_rows,omitempty"`
+}
+
+func (x *ColumnChanges) Reset() {
+	*x = ColumnChanges{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[124]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColumnChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnChanges) ProtoMessage() {}
+
+func (x *ColumnChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[124]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnChanges.ProtoReflect.Descriptor instead.
+func (*ColumnChanges) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *ColumnChanges) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *ColumnChanges) GetChangedRows() int64 {
+	if x != nil {
+		return x.ChangedRows
+	}
+	return 0
+}
+
+type CompareResultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RowsAdded      int64            `protobuf:"varint,1,opt,name=rows_added,json=rowsAdded,proto3" json:"rows_added,omitempty"`
+	RowsRemoved    int64            `protobuf:"varint,2,opt,name=rows_removed,json=rowsRemoved,proto3" json:"rows_removed,omitempty"`
+	RowsChanged    int64            `protobuf:"varint,3,opt,name=rows_changed,json=rowsChanged,proto3" json:"rows_changed,omitempty"` // rows of same key with changed values, always zero without key column
+	RowsUnchanged  int64            `protobuf:"varint,4,opt,name=rows_unchanged,json=rowsUnchanged,proto3" json:"rows_unchanged,omitempty"`
+	ColumnChanges  []*ColumnChanges `protobuf:"bytes,5,rep,name=column_changes,json=columnChanges,proto3" json:"column_changes,omitempty"` // columns of both results with changed values
+	AddedColumns   []string         `protobuf:"bytes,6,rep,name=added_columns,json=addedColumns,proto3" json:"added_columns,omitempty"`
+	RemovedColumns []string         `protobuf:"bytes,7,rep,name=removed_columns,json=removedColumns,proto3" json:"removed_columns,omitempty"`
+	DiffResultId   string           `protobuf:"bytes,8,opt,name=diff_result_id,json=diffResultId,proto3" json:"diff_result_id,omitempty"` // served like job_result_id, empty without create_diff
+}
+
+func (x *CompareResultsResponse) Reset() {
+	*x = CompareResultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[125]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompareResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResultsResponse) ProtoMessage() {}
+
+func (x *CompareResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[125]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResultsResponse.ProtoReflect.Descriptor instead.
+func (*CompareResultsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *CompareResultsResponse) GetRowsAdded() int64 {
+	if x != nil {
+		return x.RowsAdded
+	}
+	return 0
+}
+
+func (x *CompareResultsResponse) GetRowsRemoved() int64 {
+	if x != nil {
+		return x.RowsRemoved
+	}
+	return 0
+}
+
+func (x *CompareResultsResponse) GetRowsChanged() int64 {
+	if x != nil {
+		return x.RowsChanged
+	}
+	return 0
+}
+
+func (x *CompareResultsResponse) GetRowsUnchanged() int64 {
+	if x != nil {
+		return x.RowsUnchanged
+	}
+	return 0
+}
+
+func (x *CompareResultsResponse) GetColumnChanges() []*ColumnChanges {
+	if x != nil {
+		return x.ColumnChanges
+	}
+	return nil
+}
+
+func (x *CompareResultsResponse) GetAddedColumns() []string {
+	if x != nil {
+		return x.AddedColumns
+	}
+	return nil
+}
+
+func (x *CompareResultsResponse) GetRemovedColumns() []string {
+	if x != nil {
+		return x.RemovedColumns
+	}
+	return nil
+}
+
+func (x *CompareResultsResponse) GetDiffResultId() string {
+	if x != nil {
+		return x.DiffResultId
+	}
+	return ""
+}
+
+type RemoveQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+}
+
+func (x *RemoveQueryRequest) Reset() {
+	*x = RemoveQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[126]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveQueryRequest) ProtoMessage() {}
+
+func (x *RemoveQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[126]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveQueryRequest.ProtoReflect.Descriptor instead.
+func (*RemoveQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *RemoveQueryRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+// RemoveQueryResponse of query hidden from report, query with its results is deleted after restorable_until
+type RemoveQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestorableUntil int64 `protobuf:"varint,1,opt,name=restorable_until,json=restorableUntil,proto3" json:"restorable_until,omitempty"` // unix seconds
+}
+
+func (x *RemoveQueryResponse) Reset() {
+	*x = RemoveQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[127]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveQueryResponse) ProtoMessage() {}
+
+func (x *RemoveQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[127]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveQueryResponse.ProtoReflect.Descriptor instead.
+func (*RemoveQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *RemoveQueryResponse) GetRestorableUntil() int64 {
+	if x != nil {
+		return x.RestorableUntil
+	}
+	return 0
+}
+
+type RestoreQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+}
+
+func (x *RestoreQueryRequest) Reset() {
+	*x = RestoreQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[128]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreQueryRequest) ProtoMessage() {}
+
+func (x *RestoreQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[128]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreQueryRequest.ProtoReflect.Descriptor instead.
+func (*RestoreQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *RestoreQueryRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+type RestoreQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *RestoreQueryResponse) Reset() {
+	*x = RestoreQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[129]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreQueryResponse) ProtoMessage() {}
+
+func (x *RestoreQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[129]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreQueryResponse.ProtoReflect.Descriptor instead.
+func (*RestoreQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *RestoreQueryResponse) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type CancelQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+}
+
+func (x *CancelQueryRequest) Reset() {
+	*x = CancelQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[130]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueryRequest) ProtoMessage() {}
+
+func (x *CancelQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[130]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueryRequest.ProtoReflect.Descriptor instead.
+func (*CancelQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *CancelQueryRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+type CancelQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelQueryResponse) Reset() {
+	*x = CancelQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[131]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueryResponse) ProtoMessage() {}
+
+func (x *CancelQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[131]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueryResponse.ProtoReflect.Descriptor instead.
+func (*CancelQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{131}
+}
+
+// CancelReportRequest cancels jobs of all queries of report
+type CancelReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+}
+
+func (x *CancelReportRequest) Reset() {
+	*x = CancelReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[132]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReportRequest) ProtoMessage() {}
+
+func (x *CancelReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[132]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReportRequest.ProtoReflect.Descriptor instead.
+func (*CancelReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *CancelReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type CancelReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CancelledCount int32 `protobuf:"varint,1,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"` // jobs cancelled on all replicas
+}
+
+func (x *CancelReportResponse) Reset() {
+	*x = CancelReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[133]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReportResponse) ProtoMessage() {}
+
+func (x *CancelReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[133]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReportResponse.ProtoReflect.Descriptor instead.
+func (*CancelReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *CancelReportResponse) GetCancelledCount() int32 {
+	if x != nil {
+		return x.CancelledCount
+	}
+	return 0
+}
+
+type UpdateQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *UpdateQueryRequest) Reset() {
+	*x = UpdateQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[134]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryRequest) ProtoMessage() {}
+
+func (x *UpdateQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[134]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *UpdateQueryRequest) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type UpdateQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *UpdateQueryResponse) Reset() {
+	*x = UpdateQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[135]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryResponse) ProtoMessage() {}
+
+func (x *UpdateQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[135]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *UpdateQueryResponse) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type UpdateQueryDependenciesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId   string   `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	DependsOn []string `protobuf:"bytes,2,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"` // queries of same report, replaces dependencies; cycles are rejected
+}
+
+func (x *UpdateQueryDependenciesRequest) Reset() {
+	*x = UpdateQueryDependenciesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[136]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryDependenciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryDependenciesRequest) ProtoMessage() {}
+
+func (x *UpdateQueryDependenciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[136]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryDependenciesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQueryDependenciesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *UpdateQueryDependenciesRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *UpdateQueryDependenciesRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+type UpdateQueryDependenciesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *UpdateQueryDependenciesResponse) Reset() {
+	*x = UpdateQueryDependenciesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[137]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryDependenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryDependenciesResponse) ProtoMessage() {}
+
+func (x *UpdateQueryDependenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[137]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryDependenciesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateQueryDependenciesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{137}
+}
+
+func (x *UpdateQueryDependenciesResponse) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type UpdateQueryParameterBindingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId           string            `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	ParameterBindings map[string]string `protobuf:"bytes,2,rep,name=parameter_bindings,json=parameterBindings,proto3" json:"parameter_bindings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // named parameter of query text without @ to report parameter name, replaces bindings
+}
+
+func (x *UpdateQueryParameterBindingsRequest) Reset() {
+	*x = UpdateQueryParameterBindingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[138]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryParameterBindingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryParameterBindingsRequest) ProtoMessage() {}
+
+func (x *UpdateQueryParameterBindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[138]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryParameterBindingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQueryParameterBindingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *UpdateQueryParameterBindingsRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *UpdateQueryParameterBindingsRequest) GetParameterBindings() map[string]string {
+	if x != nil {
+		return x.ParameterBindings
+	}
+	return nil
+}
+
+type UpdateQueryParameterBindingsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateQueryParameterBindingsResponse) Reset() {
+	*x = UpdateQueryParameterBindingsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[139]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryParameterBindingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryParameterBindingsResponse) ProtoMessage() {}
+
+func (x *UpdateQueryParameterBindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[139]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryParameterBindingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateQueryParameterBindingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{139}
+}
+
+type UpdateQueryTitleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QueryId string `protobuf:"bytes,1,opt,name=query_id,json=queryId,proto3" json:"query_id,omitempty"`
+	Title   string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *UpdateQueryTitleRequest) Reset() {
+	*x = UpdateQueryTitleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[140]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryTitleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryTitleRequest) ProtoMessage() {}
+
+func (x *UpdateQueryTitleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[140]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryTitleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQueryTitleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *UpdateQueryTitleRequest) GetQueryId() string {
+	if x != nil {
+		return x.QueryId
+	}
+	return ""
+}
+
+func (x *UpdateQueryTitleRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type UpdateQueryTitleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *UpdateQueryTitleResponse) Reset() {
+	*x = UpdateQueryTitleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[141]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateQueryTitleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQueryTitleResponse) ProtoMessage() {}
+
+func (x *UpdateQueryTitleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[141]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQueryTitleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateQueryTitleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *UpdateQueryTitleResponse) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type CreateQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *CreateQueryRequest) Reset() {
+	*x = CreateQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[142]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQueryRequest) ProtoMessage() {}
+
+func (x *CreateQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[142]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQueryRequest.ProtoReflect.Descriptor instead.
+func (*CreateQueryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *CreateQueryRequest) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type CreateQueryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *CreateQueryResponse) Reset() {
+	*x = CreateQueryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[143]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateQueryResponse) ProtoMessage() {}
+
+func (x *CreateQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[143]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateQueryResponse.ProtoReflect.Descriptor instead.
+func (*CreateQueryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *CreateQueryResponse) GetQuery() *Query {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+type ReportStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Report        *Report        `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	StreamOptions *StreamOptions `protobuf:"bytes,2,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+	// JOB_STATUS_READING_RESULTS and JOB_STATUS_SAVING_RESULT are sent as is; clients which don't set it
+	// receive them as JOB_STATUS_RUNNING
+	FineGrainedJobStatus bool `protobuf:"varint,3,opt,name=fine_grained_job_status,json=fineGrainedJobStatus,proto3" json:"fine_grained_job_status,omitempty"`
+}
+
+func (x *ReportStreamRequest) Reset() {
+	*x = ReportStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[144]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportStreamRequest) ProtoMessage() {}
+
+func (x *ReportStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[144]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportStreamRequest.ProtoReflect.Descriptor instead.
+func (*ReportStreamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *ReportStreamRequest) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *ReportStreamRequest) GetStreamOptions() *StreamOptions {
+	if x != nil {
+		return x.StreamOptions
+	}
+	return nil
+}
+
+func (x *ReportStreamRequest) GetFineGrainedJobStatus() bool {
+	if x != nil {
+		return x.FineGrainedJobStatus
+	}
+	return false
+}
+
+type ReportStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Report        *Report        `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	Queries       []*Query       `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries,omitempty"`
+	StreamOptions *StreamOptions `protobuf:"bytes,3,opt,name=stream_options,json=streamOptions,proto3" json:"stream_options,omitempty"`
+	BatchProgress *BatchProgress `protobuf:"bytes,4,opt,name=batch_progress,json=batchProgress,proto3" json:"batch_progress,omitempty"` // last RunAllQueries batch of report, not set without batch
+	Files         []*File        `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty"`                                      // not set in incremental response of job status
+}
+
+func (x *ReportStreamResponse) Reset() {
+	*x = ReportStreamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[145]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportStreamResponse) ProtoMessage() {}
+
+func (x *ReportStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[145]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportStreamResponse.ProtoReflect.Descriptor instead.
+func (*ReportStreamResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{145}
+}
+
+func (x *ReportStreamResponse) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *ReportStreamResponse) GetQueries() []*Query {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *ReportStreamResponse) GetStreamOptions() *StreamOptions {
+	if x != nil {
+		return x.StreamOptions
+	}
+	return nil
+}
+
+func (x *ReportStreamResponse) GetBatchProgress() *BatchProgress {
+	if x != nil {
+		return x.BatchProgress
+	}
+	return nil
+}
+
+func (x *ReportStreamResponse) GetFiles() []*File {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// BatchProgress of queries started by RunAllQueries, query run again by RunQuery leaves batch
+type BatchProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BatchId  string `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Complete int32  `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"` // finished jobs, including failed and cancelled
+	Failed   int32  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Total    int32  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Skipped  int32  `protobuf:"varint,5,opt,name=skipped,proto3" json:"skipped,omitempty"` // complete queries skipped because dependency didn't finish
+}
+
+func (x *BatchProgress) Reset() {
+	*x = BatchProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[146]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchProgress) ProtoMessage() {}
+
+func (x *BatchProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[146]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchProgress.ProtoReflect.Descriptor instead.
+func (*BatchProgress) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{146}
+}
+
+func (x *BatchProgress) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *BatchProgress) GetComplete() int32 {
+	if x != nil {
+		return x.Complete
+	}
+	return 0
+}
+
+func (x *BatchProgress) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BatchProgress) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BatchProgress) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type ForkReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+}
+
+func (x *ForkReportRequest) Reset() {
+	*x = ForkReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[147]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForkReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForkReportRequest) ProtoMessage() {}
+
+func (x *ForkReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[147]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForkReportRequest.ProtoReflect.Descriptor instead.
+func (*ForkReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{147}
+}
+
+func (x *ForkReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type ForkReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId string `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+}
+
+func (x *ForkReportResponse) Reset() {
+	*x = ForkReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[148]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForkReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForkReportResponse) ProtoMessage() {}
+
+func (x *ForkReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[148]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForkReportResponse.ProtoReflect.Descriptor instead.
+func (*ForkReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{148}
+}
+
+func (x *ForkReportResponse) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type CreateReportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CreateReportRequest) Reset() {
+	*x = CreateReportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[149]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReportRequest) ProtoMessage() {}
+
+func (x *CreateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[149]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReportRequest.ProtoReflect.Descriptor instead.
+func (*CreateReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{149}
+}
+
+type CreateReportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Report *Report `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+}
+
+func (x *CreateReportResponse) Reset() {
+	*x = CreateReportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[150]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReportResponse) ProtoMessage() {}
+
+func (x *CreateReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[150]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReportResponse.ProtoReflect.Descriptor instead.
+func (*CreateReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{150}
+}
+
+func (x *CreateReportResponse) GetReport() *Report {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+type GetEnvResponse_Variable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  GetEnvResponse_Variable_Type `protobuf:"varint,1,opt,name=type,proto3,enum=GetEnvResponse_Variable_Type" json:"type,omitempty"`
+	Value string                       `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GetEnvResponse_Variable) Reset() {
+	*x = GetEnvResponse_Variable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_dekart_proto_msgTypes[151]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEnvResponse_Variable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEnvResponse_Variable) ProtoMessage() {}
+
+func (x *GetEnvResponse_Variable) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dekart_proto_msgTypes[151]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEnvResponse_Variable.ProtoReflect.Descriptor instead.
+func (*GetEnvResponse_Variable) Descriptor() ([]byte, []int) {
+	return file_proto_dekart_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *GetEnvResponse_Variable) GetType() GetEnvResponse_Variable_Type {
+	if x != nil {
+		return x.Type
+	}
+	return GetEnvResponse_Variable_TYPE_UNSPECIFIED
+}
+
+func (x *GetEnvResponse_Variable) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_proto_dekart_proto protoreflect.FileDescriptor
+
+var file_proto_dekart_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x64, 0x65, 0x6b, 0x61, 0x72, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa3, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x2a,
+	0x0a, 0x11, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x47, 0x65,
+	0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9d, 0x02, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36,
+	0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x1a, 0xd2, 0x01, 0x0a, 0x08, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x7d, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x4d, 0x41, 0x50, 0x42, 0x4f, 0x58, 0x5f, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x10,
+	0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x58, 0x5f, 0x44, 0x41, 0x54,
+	0x41, 0x5f, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x02, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x58, 0x5f, 0x48, 0x4f, 0x4d,
+	0x45, 0x50, 0x41, 0x47, 0x45, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x52, 0x45, 0x41, 0x44, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x04, 0x22, 0x17, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x6c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x05, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12,
+	0x21, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x52, 0x05, 0x71, 0x75, 0x6f,
+	0x74, 0x61, 0x22, 0x50, 0x0a, 0x0e, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x05, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x52, 0x04,
+	0x72, 0x6f, 0x6c, 0x65, 0x22, 0x1c, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x59, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3a, 0x0a, 0x10, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0f, 0x72, 0x6f,
+	0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x54, 0x0a,
+	0x18, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x0f, 0x72, 0x6f, 0x6c,
+	0x65, 0x5f, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0e, 0x72, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x22, 0x1b, 0x0a, 0x19, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x42, 0x0a, 0x1b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x0d, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x50, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x22, 0x1e, 0x0a, 0x1c, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x43, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x68, 0x61, 0x6e, 0x5f,
+	0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x54, 0x68, 0x61, 0x6e, 0x44, 0x61, 0x79, 0x73, 0x22, 0xc1, 0x01, 0x0a, 0x1a, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x61, 0x66, 0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x61, 0x66, 0x66, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x61, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x61, 0x66, 0x66, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x2f, 0x0a,
+	0x17, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x85,
+	0x01, 0x0a, 0x18, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0xb5, 0x01, 0x0a, 0x18, 0x52, 0x65, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x43,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24,
+	0x0a, 0x0e, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b,
+	0x65, 0x79, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x65,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x72, 0x65, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65,
+	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x0b, 0x4c, 0x6f,
+	0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x66,
+	0x6f, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x69, 0x6e, 0x66, 0x6f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x45, 0x76, 0x65, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x12, 0x73, 0x6c, 0x6f, 0x77, 0x5f, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x5f, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x73, 0x6c, 0x6f, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x57, 0x61, 0x69, 0x74,
+	0x4d, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x73, 0x6c, 0x6f, 0x77, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x10, 0x73, 0x6c, 0x6f, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d,
+	0x73, 0x22, 0x17, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x42, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x44,
+	0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x08, 0x73, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x4c,
+	0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x22, 0x45, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f,
+	0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x28, 0x0a, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x08, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x36, 0x0a, 0x1a, 0x47,
+	0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x22, 0xb6, 0x01, 0x0a, 0x0f, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x67, 0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x67,
+	0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x66, 0x0a, 0x1b,
+	0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x12, 0x28, 0x0a, 0x06, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x50, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x06, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x22, 0xdb, 0x01, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f,
+	0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x6f, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x33,
+	0x0a, 0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x42, 0x79, 0x22, 0x5d, 0x0a, 0x07, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x12, 0x18,
+	0x0a, 0x14, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x42, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x47, 0x52, 0x4f, 0x55,
+	0x50, 0x5f, 0x42, 0x59, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x47,
+	0x52, 0x4f, 0x55, 0x50, 0x5f, 0x42, 0x59, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x02,
+	0x12, 0x10, 0x0a, 0x0c, 0x47, 0x52, 0x4f, 0x55, 0x50, 0x5f, 0x42, 0x59, 0x5f, 0x44, 0x41, 0x59,
+	0x10, 0x03, 0x22, 0xb9, 0x01, 0x0a, 0x05, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6a, 0x6f,
+	0x62, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x5f, 0x62, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x42, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x22, 0xb2,
+	0x01, 0x0a, 0x0a, 0x55, 0x73, 0x61, 0x67, 0x65, 0x51, 0x75, 0x6f, 0x74, 0x61, 0x12, 0x14, 0x0a,
+	0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f,
+	0x6e, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x75, 0x64, 0x67, 0x65,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x62, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x42, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x68, 0x65, 0x61,
+	0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0f, 0x68, 0x65, 0x61, 0x64, 0x72, 0x6f, 0x6f, 0x6d, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65, 0x73, 0x65, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x22, 0x53, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x75, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x75, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x51, 0x75, 0x6f, 0x74,
+	0x61, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x22, 0x4d, 0x0a, 0x14, 0x41, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x22, 0x40, 0x0a, 0x15, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x49, 0x0a, 0x12, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4a, 0x0a, 0x11, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x35, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x6e, 0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73,
+	0x12, 0x35, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xca, 0x02, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x32, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x04, 0x73, 0x6f,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f,
+	0x72, 0x74, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x51, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x0e,
+	0x0a, 0x0a, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4d, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x41, 0x52, 0x43,
+	0x48, 0x49, 0x56, 0x45, 0x44, 0x10, 0x03, 0x22, 0x2b, 0x0a, 0x04, 0x53, 0x6f, 0x72, 0x74, 0x12,
+	0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x5f,
+	0x41, 0x54, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x54, 0x49, 0x54,
+	0x4c, 0x45, 0x10, 0x01, 0x22, 0xb5, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x07,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x22, 0xda, 0x07, 0x0a,
+	0x06, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x70, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x70,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x5f,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x61, 0x6e,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67,
+	0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x27,
+	0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x3a, 0x0a, 0x19, 0x65, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x65, 0x66, 0x66, 0x65,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x6d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2d, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x10, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x0f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x47, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x5d,
+	0x0a, 0x18, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x16, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x3b, 0x0a,
+	0x10, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0x42, 0x0a, 0x14, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x49,
+	0x0a, 0x1b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc8, 0x01, 0x0a, 0x0e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x53, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0f,
+	0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x10, 0x02, 0x12,
+	0x13, 0x0a, 0x0f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x46, 0x49,
+	0x45, 0x52, 0x10, 0x03, 0x22, 0x6a, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49,
+	0x64, 0x12, 0x2d, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x22, 0x1f, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0xc8, 0x02, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x36, 0x34, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x44, 0x41, 0x54, 0x45, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10, 0x06, 0x22, 0x6e, 0x0a, 0x1d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x0a, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x22, 0x20, 0x0a, 0x1e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xd3,
+	0x01, 0x0a, 0x1f, 0x53, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12,
+	0x44, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2c, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x76, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x61, 0x76, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x22, 0x0a, 0x20, 0x53, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x53, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x59, 0x0a,
+	0x21, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0xa5, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x32, 0x0a, 0x0d, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x41, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x11, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x22, 0x74, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x0f,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x1e, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x58, 0x0a, 0x0f, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e,
+	0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78,
+	0x5f, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x72, 0x75, 0x6e,
+	0x22, 0x7e, 0x0a, 0x22, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x10, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73,
+	0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0f, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x22, 0x25, 0x0a, 0x23, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xcd, 0x01, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x61,
+	0x6e, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x63,
+	0x61, 0x6e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x22, 0x3a, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x08, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x22, 0x3b, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x07,
+	0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x22, 0x3a, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x22, 0x3b, 0x0a, 0x15,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74,
+	0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x22, 0x26, 0x0a, 0x14, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2d, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22, 0x3c, 0x0a, 0x14, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x24, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x22, 0xc5, 0x01, 0x0a, 0x04, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x58, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2f, 0x0a, 0x12, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x19, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x2c, 0x0a, 0x11, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa4,
+	0x10, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x54, 0x65, 0x78, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x6a, 0x6f, 0x62, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6a, 0x6f,
+	0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x6f, 0x62,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x6f,
+	0x62, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6a, 0x6f,
+	0x62, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74,
+	0x65, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x77, 0x73, 0x57, 0x72,
+	0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x68, 0x33, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x33,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x2e,
+	0x0a, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x32,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x14, 0x72, 0x65, 0x75, 0x73, 0x65, 0x64, 0x5f,
+	0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x75, 0x73, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x32, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x10, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4a, 0x6f, 0x62, 0x57, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x19, 0x0a, 0x08,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x73, 0x5f, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x5f, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x44, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x4a, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x14,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x6e, 0x5f, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x6c,
+	0x61, 0x6e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x75,
+	0x73, 0x65, 0x5f, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x73, 0x71, 0x6c, 0x18, 0x1e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x53, 0x71,
+	0x6c, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x78, 0x61, 0x63, 0x74, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x65, 0x78, 0x61,
+	0x63, 0x74, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x41, 0x0a, 0x12, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x20, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x4c,
+	0x0a, 0x12, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x18, 0x21, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x34, 0x0a, 0x0e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x22,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x32, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x25, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f,
+	0x72, 0x6f, 0x77, 0x73, 0x18, 0x26, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x45, 0x0a, 0x15, 0x6a, 0x6f, 0x62, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x78,
+	0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x6a, 0x6f, 0x62, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x28, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x74, 0x61,
+	0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x29, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x6c, 0x65, 0x1a, 0x44, 0x0a, 0x16, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc2, 0x01, 0x0a, 0x09, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x16, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x4a, 0x4f,
+	0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47,
+	0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x4a, 0x4f, 0x42, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x4b, 0x49, 0x50, 0x50, 0x45, 0x44, 0x10, 0x04, 0x12,
+	0x1e, 0x0a, 0x1a, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45,
+	0x41, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x53, 0x10, 0x05, 0x12,
+	0x1c, 0x0a, 0x18, 0x4a, 0x4f, 0x42, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x41,
+	0x56, 0x49, 0x4e, 0x47, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x10, 0x06, 0x22, 0x56, 0x0a,
+	0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x52,
+	0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x45, 0x53, 0x55,
+	0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x15,
+	0x0a, 0x11, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x41,
+	0x42, 0x4c, 0x45, 0x10, 0x02, 0x22, 0x9b, 0x03, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x4d, 0x65, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x34, 0x0a, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x77, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x6f, 0x77, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x18,
+	0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x61,
+	0x72, 0x74, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x65, 0x64, 0x22, 0x57, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x69, 0x0a, 0x10,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x69,
+	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69, 0x78, 0x12, 0x19, 0x0a, 0x08,
+	0x64, 0x6f, 0x63, 0x73, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x64, 0x6f, 0x63, 0x73, 0x55, 0x72, 0x6c, 0x22, 0x52, 0x0a, 0x0c, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x0a, 0x4a,
+	0x6f, 0x62, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xd4, 0x05, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3e, 0x0a,
+	0x0a, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x79,
+	0x6c, 0x65, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x27, 0x0a,
+	0x0f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x50, 0x72, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x34, 0x0a, 0x16, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x5f, 0x74, 0x72, 0x61,
+	0x69, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x14, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x69,
+	0x6e, 0x67, 0x5a, 0x65, 0x72, 0x6f, 0x73, 0x12, 0x4a, 0x0a, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x23, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x47, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x6e, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x53, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x0c,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x22, 0x55, 0x0a, 0x09,
+	0x42, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x4f, 0x4f,
+	0x4c, 0x5f, 0x53, 0x54, 0x59, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x42, 0x4f, 0x4f, 0x4c, 0x5f, 0x53, 0x54,
+	0x59, 0x4c, 0x45, 0x5f, 0x4e, 0x55, 0x4d, 0x45, 0x52, 0x49, 0x43, 0x10, 0x01, 0x12, 0x14, 0x0a,
+	0x10, 0x42, 0x4f, 0x4f, 0x4c, 0x5f, 0x53, 0x54, 0x59, 0x4c, 0x45, 0x5f, 0x55, 0x50, 0x50, 0x45,
+	0x52, 0x10, 0x02, 0x22, 0x62, 0x0a, 0x0d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a, 0x1a, 0x42, 0x59, 0x54, 0x45, 0x53, 0x5f, 0x45, 0x4e,
+	0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x59, 0x54, 0x45, 0x53, 0x5f, 0x45, 0x4e,
+	0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x42, 0x41, 0x53, 0x45, 0x36, 0x34, 0x10, 0x01, 0x12,
+	0x16, 0x0a, 0x12, 0x42, 0x59, 0x54, 0x45, 0x53, 0x5f, 0x45, 0x4e, 0x43, 0x4f, 0x44, 0x49, 0x4e,
+	0x47, 0x5f, 0x48, 0x45, 0x58, 0x10, 0x02, 0x22, 0x65, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x4e, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x19, 0x48, 0x45, 0x41, 0x44, 0x45,
+	0x52, 0x5f, 0x4e, 0x41, 0x4d, 0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52,
+	0x5f, 0x4e, 0x41, 0x4d, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x4e, 0x41, 0x4b, 0x45, 0x5f, 0x43, 0x41,
+	0x53, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x5f, 0x4e,
+	0x41, 0x4d, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x02, 0x22, 0xee,
+	0x01, 0x0a, 0x10, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x4f, 0x0a, 0x11, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x44, 0x69, 0x73,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x77, 0x72, 0x69, 0x74, 0x65, 0x44,
+	0x69, 0x73, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x73, 0x0a, 0x10, 0x57, 0x72,
+	0x69, 0x74, 0x65, 0x44, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21,
+	0x0a, 0x1d, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x4f, 0x53, 0x49, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x4f,
+	0x53, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x55, 0x4e, 0x43, 0x41, 0x54, 0x45, 0x10,
+	0x01, 0x12, 0x1c, 0x0a, 0x18, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x4f,
+	0x53, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x22,
+	0xbd, 0x01, 0x0a, 0x0d, 0x48, 0x33, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x29, 0x0a, 0x10, 0x67, 0x65, 0x6f, 0x67, 0x72, 0x61, 0x70, 0x68, 0x79, 0x5f, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x67, 0x65, 0x6f, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x79, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
+	0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x07, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x48, 0x33,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22,
+	0x9c, 0x01, 0x0a, 0x08, 0x48, 0x33, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x2e, 0x0a, 0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x48, 0x33, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x2e, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x66, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x48, 0x0a, 0x08, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x14, 0x46, 0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x55,
+	0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c,
+	0x46, 0x55, 0x4e, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x56, 0x47, 0x10, 0x02, 0x22, 0x2f,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x22,
+	0x73, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07,
+	0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66,
+	0x69, 0x6c, 0x65, 0x73, 0x22, 0x2c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x49, 0x64, 0x22, 0x30, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x22, 0xa0, 0x01, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78,
+	0x74, 0x12, 0x24, 0x0a, 0x0e, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f,
+	0x73, 0x71, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x4c, 0x65,
+	0x67, 0x61, 0x63, 0x79, 0x53, 0x71, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x82, 0x01, 0x0a, 0x0c, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xae, 0x01, 0x0a,
+	0x1d, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x73,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xde, 0x01,
+	0x0a, 0x14, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x74, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x41, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x73,
+	0x0a, 0x18, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75,
+	0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73,
+	0x12, 0x2f, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x22, 0x36, 0x0a, 0x1c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x50, 0x0a, 0x1d, 0x52, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49,
+	0x64, 0x22, 0xe3, 0x02, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52,
+	0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x2f, 0x0a, 0x14, 0x72, 0x65, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x72, 0x65, 0x75, 0x73, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x51, 0x75, 0x65, 0x72, 0x79, 0x49,
+	0x64, 0x12, 0x24, 0x0a, 0x0e, 0x75, 0x73, 0x65, 0x5f, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f,
+	0x73, 0x71, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x4c, 0x65,
+	0x67, 0x61, 0x63, 0x79, 0x53, 0x71, 0x6c, 0x22, 0x2e, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x22, 0x8d, 0x05, 0x0a, 0x0c, 0x4a, 0x6f, 0x62, 0x50,
+	0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x61, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x57, 0x72, 0x69, 0x74,
+	0x74, 0x65, 0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x5f, 0x61, 0x76, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x77, 0x61, 0x69,
+	0x74, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x41, 0x76, 0x67, 0x12, 0x24, 0x0a, 0x0e, 0x77, 0x61, 0x69,
+	0x74, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0c, 0x77, 0x61, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x4d, 0x61, 0x78, 0x12,
+	0x24, 0x0a, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x5f, 0x61, 0x76,
+	0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x52, 0x61, 0x74,
+	0x69, 0x6f, 0x41, 0x76, 0x67, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x72,
+	0x65, 0x61, 0x64, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x4d, 0x61, 0x78, 0x12, 0x2a, 0x0a, 0x11, 0x63,
+	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x5f, 0x61, 0x76, 0x67,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x52,
+	0x61, 0x74, 0x69, 0x6f, 0x41, 0x76, 0x67, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f,
+	0x4d, 0x61, 0x78, 0x12, 0x26, 0x0a, 0x0f, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x5f, 0x61, 0x76, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x41, 0x76, 0x67, 0x12, 0x26, 0x0a, 0x0f, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f,
+	0x4d, 0x61, 0x78, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x5f, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x12, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x1c, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65,
+	0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x73, 0x70,
+	0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x19, 0x73, 0x68, 0x75,
+	0x66, 0x66, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x53,
+	0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x22, 0xba, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4a,
+	0x6f, 0x62, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x4a, 0x6f, 0x62, 0x50,
+	0x6c, 0x61, 0x6e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x64, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x6f, 0x74, 0x65, 0x22, 0x36, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x54, 0x0a, 0x16,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x22, 0x35, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4f, 0x0a, 0x1a, 0x47, 0x65, 0x74,
+	0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x90, 0x01, 0x0a, 0x11, 0x4d,
+	0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4f, 0x0a,
+	0x1b, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x09,
+	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x30,
+	0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67,
+	0x22, 0xcf, 0x05, 0x0a, 0x0f, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x12, 0x35, 0x0a, 0x0e, 0x68, 0x33, 0x5f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x48, 0x33, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x68, 0x33, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x52, 0x75, 0x6e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x11, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12,
+	0x32, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x4a, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x14, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x41, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x11, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0d,
+	0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x11, 0x61,
+	0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x73,
+	0x12, 0x2d, 0x0a, 0x12, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x61, 0x63,
+	0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x13, 0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x61, 0x73, 0x4f, 0x66, 0x1a, 0x3c, 0x0a, 0x0e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x5f, 0x0a, 0x10, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x75,
+	0x73, 0x65, 0x64, 0x22, 0xe4, 0x01, 0x0a, 0x14, 0x52, 0x75, 0x6e, 0x41, 0x6c, 0x6c, 0x51, 0x75,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x09, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x52,
+	0x75, 0x6e, 0x41, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x12, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x61, 0x63, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x1a, 0x3c, 0x0a, 0x0e,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x84, 0x01, 0x0a, 0x08, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x75, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x75,
+	0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x75, 0x73, 0x65,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x69, 0x74, 0x69,
+	0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x77, 0x61, 0x69, 0x74, 0x69, 0x6e,
+	0x67, 0x22, 0x51, 0x0a, 0x15, 0x52, 0x75, 0x6e, 0x41, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x04, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x75, 0x6e, 0x52, 0x04,
+	0x72, 0x75, 0x6e, 0x73, 0x22, 0x4c, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x49, 0x64, 0x22, 0x3e, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xa5, 0x06, 0x0a, 0x16, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41,
+	0x6e, 0x64, 0x57, 0x61, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x35, 0x0a, 0x0e, 0x68, 0x33, 0x5f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x48, 0x33, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x68, 0x33, 0x41, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x52, 0x75,
+	0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x6e, 0x64, 0x57, 0x61, 0x69, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x3e,
+	0x0a, 0x11, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x44, 0x65, 0x73, 0x74,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x10, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x32,
+	0x0a, 0x0d, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x12, 0x4a, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x14, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x41,
+	0x0a, 0x12, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x11,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x3c, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0d, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x11, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x73, 0x12,
+	0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x13, 0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f,
+	0x66, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x2d, 0x0a,
+	0x12, 0x61, 0x63, 0x6b, 0x6e, 0x6f, 0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x61, 0x63, 0x6b, 0x6e, 0x6f,
+	0x77, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x1a, 0x3c, 0x0a, 0x0e,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xba, 0x06, 0x0a, 0x17, 0x52,
+	0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x6e, 0x64, 0x57, 0x61, 0x69, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c,
+	0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61,
+	0x6c, 0x69, 0x76, 0x65, 0x12, 0x2f, 0x0a, 0x0a, 0x6a, 0x6f, 0x62, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x09, 0x6a, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6a, 0x6f,
+	0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6a, 0x6f, 0x62,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6a, 0x6f,
+	0x62, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x72, 0x6f, 0x77, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x55,
+	0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74,
+	0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x77, 0x73, 0x57, 0x72,
+	0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x68, 0x33, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x33,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x68, 0x33, 0x5f, 0x64,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x68, 0x33, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c,
+	0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x32, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x74,
+	0x61, 0x62, 0x6c, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x10, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64,
+	0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4a, 0x6f, 0x62, 0x57, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x34, 0x0a,
+	0x0e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d, 0x2e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xa2, 0x01, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x69, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x55, 0x72, 0x69, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x76, 0x65, 0x72, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6c,
+	0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x22, 0x97, 0x01, 0x0a,
+	0x14, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x5f, 0x63, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x43, 0x6f, 0x70, 0x69, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a,
+	0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x69,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x55, 0x72, 0x69, 0x22, 0xd7, 0x04, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x15, 0x0a,
+	0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a,
+	0x6f, 0x62, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62,
+	0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10,
+	0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x69, 0x67, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65,
+	0x78, 0x74, 0x12, 0x4e, 0x0a, 0x10, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x6b, 0x61, 0x72, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x6b, 0x61, 0x72,
+	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6a,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6a, 0x6f, 0x62,
+	0x5f, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6a, 0x6f, 0x62, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x1a, 0x42, 0x0a, 0x14,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x36, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x6e,
+	0x65, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x22, 0x44, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69,
+	0x6e, 0x65, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x22, 0x39,
+	0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x22, 0xaf, 0x01, 0x0a, 0x0f, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x61, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x75, 0x0a, 0x1b, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x6d,
+	0x65, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65,
+	0x74, 0x61, 0x22, 0x2c, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64,
+	0x22, 0x4c, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x7c,
+	0x0a, 0x14, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0d, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x22, 0x9a, 0x01, 0x0a,
+	0x15, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6b, 0x65, 0x79,
+	0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6b,
+	0x65, 0x79, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x5f, 0x64, 0x69, 0x66, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x69, 0x66, 0x66, 0x22, 0x4a, 0x0a, 0x0d, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x72, 0x6f,
+	0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x52, 0x6f, 0x77, 0x73, 0x22, 0xcf, 0x02, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x61, 0x64, 0x64, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x6f, 0x77, 0x73, 0x41, 0x64, 0x64, 0x65, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x77, 0x73, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x75, 0x6e,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72,
+	0x6f, 0x77, 0x73, 0x55, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x0e,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x52, 0x0d, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x64, 0x64, 0x65,
+	0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x73, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x69, 0x66, 0x66, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x66, 0x66, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x64, 0x22, 0x2f, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x22, 0x40, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x75, 0x6e,
+	0x74, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x62, 0x6c, 0x65, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x22, 0x30, 0x0a, 0x13, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x22, 0x34, 0x0a, 0x14,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x22, 0x2f, 0x0a, 0x12, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0x0a, 0x13, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x22, 0x3f,
+	0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x6c, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x32, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x22, 0x33, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x5a, 0x0a, 0x1e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73,
+	0x5f, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x70, 0x65, 0x6e,
+	0x64, 0x73, 0x4f, 0x6e, 0x22, 0x3f, 0x0a, 0x1f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0xf2, 0x01, 0x0a, 0x23, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42, 0x69,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x6a, 0x0a, 0x12, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x11, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x73, 0x1a, 0x44, 0x0a, 0x16, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x26, 0x0a, 0x24, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x4a, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x38,
+	0x0a, 0x18, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x74,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x32, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x33, 0x0a, 0x13,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x22, 0xa4, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x35, 0x0a, 0x0e, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x35, 0x0a, 0x17, 0x66, 0x69, 0x6e, 0x65, 0x5f, 0x67, 0x72, 0x61, 0x69, 0x6e, 0x65,
+	0x64, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x14, 0x66, 0x69, 0x6e, 0x65, 0x47, 0x72, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x4a,
+	0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe4, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x20, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0d, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x0e, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x1b, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x05, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22,
+	0x8e, 0x01, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x22, 0x30, 0x0a, 0x11, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x49, 0x64, 0x22, 0x31, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x37, 0x0a, 0x14,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2a, 0x4e, 0x0a, 0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a,
+	0x10, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x56, 0x49, 0x45, 0x57,
+	0x45, 0x52, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x45, 0x44, 0x49,
+	0x54, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x41, 0x44,
+	0x4d, 0x49, 0x4e, 0x10, 0x03, 0x2a, 0x4f, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x19, 0x0a, 0x15, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d,
+	0x46, 0x49, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x53, 0x56, 0x10, 0x01, 0x12,
+	0x15, 0x0a, 0x11, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x45, 0x4f,
+	0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x2a, 0x5f, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54,
+	0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f,
+	0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x53, 0x56, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15,
+	0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x50, 0x41,
+	0x52, 0x51, 0x55, 0x45, 0x54, 0x10, 0x02, 0x2a, 0x71, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x1e,
+	0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53, 0x49,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52,
+	0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x1b, 0x0a,
+	0x17, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x52, 0x45, 0x53, 0x53,
+	0x49, 0x4f, 0x4e, 0x5f, 0x47, 0x5a, 0x49, 0x50, 0x10, 0x02, 0x2a, 0xc2, 0x01, 0x0a, 0x0c, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x19, 0x43,
+	0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x53, 0x45, 0x52,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x41, 0x44, 0x4d, 0x49,
+	0x4e, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4f, 0x52, 0x50, 0x48, 0x41, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45,
+	0x4f, 0x55, 0x54, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f,
+	0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x10,
+	0x04, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x53, 0x55, 0x50, 0x45, 0x52, 0x53, 0x45, 0x44, 0x45, 0x44, 0x10, 0x05, 0x32,
+	0x9b, 0x21, 0x0a, 0x06, 0x44, 0x65, 0x6b, 0x61, 0x72, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x46, 0x6f, 0x72,
+	0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x46, 0x6f,
+	0x72, 0x6b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x14, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x40, 0x0a, 0x0d, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x15, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x41, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x13, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x46, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x17, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x12, 0x1d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x56,
+	0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x55, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1c, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6a, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73,
+	0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x61, 0x0a, 0x18, 0x53, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x20, 0x2e,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x21, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x1b, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x34,
+	0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x11, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x12, 0x13, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x13, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x1f,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x1c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x24, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x42, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x10,
+	0x2e, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x14, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x40, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x41, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x15, 0x2e, 0x52, 0x75, 0x6e, 0x41, 0x6c, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x52, 0x75, 0x6e, 0x41, 0x6c,
+	0x6c, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x13, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a,
+	0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e,
+	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x47, 0x65, 0x74,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x10, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x47, 0x65, 0x74,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x50, 0x6c,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x47, 0x65, 0x74, 0x4a,
+	0x6f, 0x62, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x48, 0x0a, 0x0f, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x6e, 0x64, 0x57,
+	0x61, 0x69, 0x74, 0x12, 0x17, 0x2e, 0x52, 0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x6e,
+	0x64, 0x57, 0x61, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x52,
+	0x75, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x41, 0x6e, 0x64, 0x57, 0x61, 0x69, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0c, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x2e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x43, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x49, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x6e,
+	0x65, 0x61, 0x67, 0x65, 0x12, 0x18, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70,
+	0x65, 0x73, 0x12, 0x1b, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x14, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x19, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x15, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x1d, 0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x40, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x12, 0x15, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x12, 0x15, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x12, 0x15, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61,
+	0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x12, 0x14, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01,
+	0x12, 0x37, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12,
+	0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x2b, 0x0a, 0x06, 0x47, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x12, 0x0e, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x13, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x1b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x4c, 0x0a, 0x11, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a,
+	0x14, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x6f, 0x6c, 0x65,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x1a, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x49, 0x0a, 0x10, 0x52, 0x65, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x73, 0x12, 0x18, 0x2e, 0x52, 0x65, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x52, 0x65, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0e, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x16, 0x2e,
+	0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4c, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x19, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f,
+	0x67, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x67, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52,
+	0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1b, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x31, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10,
+	0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x14, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x12, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x07, 0x5a,
+	0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_dekart_proto_rawDescOnce sync.Once
+	file_proto_dekart_proto_rawDescData = file_proto_dekart_proto_rawDesc
+)
+
+func file_proto_dekart_proto_rawDescGZIP() []byte {
+	file_proto_dekart_proto_rawDescOnce.Do(func() {
+		file_proto_dekart_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_dekart_proto_rawDescData)
+	})
+	return file_proto_dekart_proto_rawDescData
+}
+
+var file_proto_dekart_proto_enumTypes = make([]protoimpl.EnumInfo, 18)
+var file_proto_dekart_proto_msgTypes = make([]protoimpl.MessageInfo, 161)
+var file_proto_dekart_proto_goTypes = []interface{}{
+	(Role)(0),                                    // 0: Role
+	(FileType)(0),                                // 1: FileType
+	(ResultFormat)(0),                            // 2: ResultFormat
+	(ResultCompression)(0),                       // 3: ResultCompression
+	(CancelReason)(0),                            // 4: CancelReason
+	(GetEnvResponse_Variable_Type)(0),            // 5: GetEnvResponse.Variable.Type
+	(GetUsageRequest_GroupBy)(0),                 // 6: GetUsageRequest.GroupBy
+	(ListReportsRequest_Filter)(0),               // 7: ListReportsRequest.Filter
+	(ListReportsRequest_Sort)(0),                 // 8: ListReportsRequest.Sort
+	(ReportVariable_Type)(0),                     // 9: ReportVariable.Type
+	(ReportParameter_Type)(0),                    // 10: ReportParameter.Type
+	(Query_JobStatus)(0),                         // 11: Query.JobStatus
+	(Query_ResultType)(0),                        // 12: Query.ResultType
+	(SerializationOptions_BoolStyle)(0),          // 13: SerializationOptions.BoolStyle
+	(SerializationOptions_BytesEncoding)(0),      // 14: SerializationOptions.BytesEncoding
+	(SerializationOptions_HeaderNaming)(0),       // 15: SerializationOptions.HeaderNaming
+	(DestinationTable_WriteDisposition)(0),       // 16: DestinationTable.WriteDisposition
+	(H3Metric_Function)(0),                       // 17: H3Metric.Function
+	(*StreamOptions)(nil),                        // 18: StreamOptions
+	(*GetEnvRequest)(nil),                        // 19: GetEnvRequest
+	(*GetEnvResponse)(nil),                       // 20: GetEnvResponse
+	(*GetCurrentUserRequest)(nil),                // 21: GetCurrentUserRequest
+	(*GetCurrentUserResponse)(nil),               // 22: GetCurrentUserResponse
+	(*RoleAssignment)(nil),                       // 23: RoleAssignment
+	(*ListRoleAssignmentsRequest)(nil),           // 24: ListRoleAssignmentsRequest
+	(*ListRoleAssignmentsResponse)(nil),          // 25: ListRoleAssignmentsResponse
+	(*SetRoleAssignmentRequest)(nil),             // 26: SetRoleAssignmentRequest
+	(*SetRoleAssignmentResponse)(nil),            // 27: SetRoleAssignmentResponse
+	(*RemoveRoleAssignmentRequest)(nil),          // 28: RemoveRoleAssignmentRequest
+	(*RemoveRoleAssignmentResponse)(nil),         // 29: RemoveRoleAssignmentResponse
+	(*GetResultLifecycleRequest)(nil),            // 30: GetResultLifecycleRequest
+	(*GetResultLifecycleResponse)(nil),           // 31: GetResultLifecycleResponse
+	(*ReconcileResultsRequest)(nil),              // 32: ReconcileResultsRequest
+	(*ReconcileResultsResponse)(nil),             // 33: ReconcileResultsResponse
+	(*ReencryptColumnsRequest)(nil),              // 34: ReencryptColumnsRequest
+	(*ReencryptColumnsResponse)(nil),             // 35: ReencryptColumnsResponse
+	(*LogSettings)(nil),                          // 36: LogSettings
+	(*GetLogSettingsRequest)(nil),                // 37: GetLogSettingsRequest
+	(*GetLogSettingsResponse)(nil),               // 38: GetLogSettingsResponse
+	(*UpdateLogSettingsRequest)(nil),             // 39: UpdateLogSettingsRequest
+	(*UpdateLogSettingsResponse)(nil),            // 40: UpdateLogSettingsResponse
+	(*GetPermissionReportRequest)(nil),           // 41: GetPermissionReportRequest
+	(*PermissionCheck)(nil),                      // 42: PermissionCheck
+	(*GetPermissionReportResponse)(nil),          // 43: GetPermissionReportResponse
+	(*GetUsageRequest)(nil),                      // 44: GetUsageRequest
+	(*Usage)(nil),                                // 45: Usage
+	(*UsageQuota)(nil),                           // 46: UsageQuota
+	(*GetUsageResponse)(nil),                     // 47: GetUsageResponse
+	(*ArchiveReportRequest)(nil),                 // 48: ArchiveReportRequest
+	(*ArchiveReportResponse)(nil),                // 49: ArchiveReportResponse
+	(*ShareReportRequest)(nil),                   // 50: ShareReportRequest
+	(*ShareReportResponse)(nil),                  // 51: ShareReportResponse
+	(*ReportListRequest)(nil),                    // 52: ReportListRequest
+	(*ReportListResponse)(nil),                   // 53: ReportListResponse
+	(*ListReportsRequest)(nil),                   // 54: ListReportsRequest
+	(*ListReportsResponse)(nil),                  // 55: ListReportsResponse
+	(*Report)(nil),                               // 56: Report
+	(*ReportVariable)(nil),                       // 57: ReportVariable
+	(*UpdateReportVariablesRequest)(nil),         // 58: UpdateReportVariablesRequest
+	(*UpdateReportVariablesResponse)(nil),        // 59: UpdateReportVariablesResponse
+	(*ReportParameter)(nil),                      // 60: ReportParameter
+	(*UpdateReportParametersRequest)(nil),        // 61: UpdateReportParametersRequest
+	(*UpdateReportParametersResponse)(nil),       // 62: UpdateReportParametersResponse
+	(*SetReportParameterValuesRequest)(nil),      // 63: SetReportParameterValuesRequest
+	(*SetReportParameterValuesResponse)(nil),     // 64: SetReportParameterValuesResponse
+	(*GetReportParameterOptionsRequest)(nil),     // 65: GetReportParameterOptionsRequest
+	(*GetReportParameterOptionsResponse)(nil),    // 66: GetReportParameterOptionsResponse
+	(*ResultSettings)(nil),                       // 67: ResultSettings
+	(*UpdateReportSettingsRequest)(nil),          // 68: UpdateReportSettingsRequest
+	(*UpdateReportSettingsResponse)(nil),         // 69: UpdateReportSettingsResponse
+	(*FreshnessPolicy)(nil),                      // 70: FreshnessPolicy
+	(*UpdateReportFreshnessPolicyRequest)(nil),   // 71: UpdateReportFreshnessPolicyRequest
+	(*UpdateReportFreshnessPolicyResponse)(nil),  // 72: UpdateReportFreshnessPolicyResponse
+	(*Dataset)(nil),                              // 73: Dataset
+	(*CreateDatasetRequest)(nil),                 // 74: CreateDatasetRequest
+	(*CreateDatasetResponse)(nil),                // 75: CreateDatasetResponse
+	(*UpdateDatasetRequest)(nil),                 // 76: UpdateDatasetRequest
+	(*UpdateDatasetResponse)(nil),                // 77: UpdateDatasetResponse
+	(*DeleteDatasetRequest)(nil),                 // 78: DeleteDatasetRequest
+	(*DeleteDatasetResponse)(nil),                // 79: DeleteDatasetResponse
+	(*ListDatasetsRequest)(nil),                  // 80: ListDatasetsRequest
+	(*ListDatasetsResponse)(nil),                 // 81: ListDatasetsResponse
+	(*File)(nil),                                 // 82: File
+	(*UploadFileRequest)(nil),                    // 83: UploadFileRequest
+	(*UploadFileResponse)(nil),                   // 84: UploadFileResponse
+	(*RemoveFileRequest)(nil),                    // 85: RemoveFileRequest
+	(*RemoveFileResponse)(nil),                   // 86: RemoveFileResponse
+	(*Query)(nil),                                // 87: Query
+	(*ResultMeta)(nil),                           // 88: ResultMeta
+	(*ResultPart)(nil),                           // 89: ResultPart
+	(*ErrorExplanation)(nil),                     // 90: ErrorExplanation
+	(*FormatResult)(nil),                         // 91: FormatResult
+	(*JobWarning)(nil),                           // 92: JobWarning
+	(*SerializationOptions)(nil),                 // 93: SerializationOptions
+	(*DestinationTable)(nil),                     // 94: DestinationTable
+	(*H3Aggregation)(nil),                        // 95: H3Aggregation
+	(*H3Metric)(nil),                             // 96: H3Metric
+	(*GetReportRequest)(nil),                     // 97: GetReportRequest
+	(*GetReportResponse)(nil),                    // 98: GetReportResponse
+	(*GetQueryRequest)(nil),                      // 99: GetQueryRequest
+	(*GetQueryResponse)(nil),                     // 100: GetQueryResponse
+	(*GetQuerySchemaContextRequest)(nil),         // 101: GetQuerySchemaContextRequest
+	(*SchemaColumn)(nil),                         // 102: SchemaColumn
+	(*GetQuerySchemaContextResponse)(nil),        // 103: GetQuerySchemaContextResponse
+	(*SchemaSnapshotSource)(nil),                 // 104: SchemaSnapshotSource
+	(*GetSchemaSnapshotRequest)(nil),             // 105: GetSchemaSnapshotRequest
+	(*GetSchemaSnapshotResponse)(nil),            // 106: GetSchemaSnapshotResponse
+	(*RefreshSchemaSnapshotRequest)(nil),         // 107: RefreshSchemaSnapshotRequest
+	(*RefreshSchemaSnapshotResponse)(nil),        // 108: RefreshSchemaSnapshotResponse
+	(*GetExecutedQueryRequest)(nil),              // 109: GetExecutedQueryRequest
+	(*GetExecutedQueryResponse)(nil),             // 110: GetExecutedQueryResponse
+	(*GetJobPlanRequest)(nil),                    // 111: GetJobPlanRequest
+	(*JobPlanStage)(nil),                         // 112: JobPlanStage
+	(*GetJobPlanResponse)(nil),                   // 113: GetJobPlanResponse
+	(*UpdateReportRequest)(nil),                  // 114: UpdateReportRequest
+	(*UpdateMapConfigRequest)(nil),               // 115: UpdateMapConfigRequest
+	(*UpdateMapConfigResponse)(nil),              // 116: UpdateMapConfigResponse
+	(*GetMapConfigHistoryRequest)(nil),           // 117: GetMapConfigHistoryRequest
+	(*MapConfigRevision)(nil),                    // 118: MapConfigRevision
+	(*GetMapConfigHistoryResponse)(nil),          // 119: GetMapConfigHistoryResponse
+	(*UpdateReportResponse)(nil),                 // 120: UpdateReportResponse
+	(*RunQueryRequest)(nil),                      // 121: RunQueryRequest
+	(*RunQueryResponse)(nil),                     // 122: RunQueryResponse
+	(*RunAllQueriesRequest)(nil),                 // 123: RunAllQueriesRequest
+	(*BatchRun)(nil),                             // 124: BatchRun
+	(*RunAllQueriesResponse)(nil),                // 125: RunAllQueriesResponse
+	(*CancelBatchRequest)(nil),                   // 126: CancelBatchRequest
+	(*CancelBatchResponse)(nil),                  // 127: CancelBatchResponse
+	(*RunQueryAndWaitRequest)(nil),               // 128: RunQueryAndWaitRequest
+	(*RunQueryAndWaitResponse)(nil),              // 129: RunQueryAndWaitResponse
+	(*ExportResultRequest)(nil),                  // 130: ExportResultRequest
+	(*ExportResultResponse)(nil),                 // 131: ExportResultResponse
+	(*ResultLineage)(nil),                        // 132: ResultLineage
+	(*GetResultLineageRequest)(nil),              // 133: GetResultLineageRequest
+	(*GetResultLineageResponse)(nil),             // 134: GetResultLineageResponse
+	(*GetResultFieldTypesRequest)(nil),           // 135: GetResultFieldTypesRequest
+	(*ResultFieldType)(nil),                      // 136: ResultFieldType
+	(*GetResultFieldTypesResponse)(nil),          // 137: GetResultFieldTypesResponse
+	(*GetJobEventsRequest)(nil),                  // 138: GetJobEventsRequest
+	(*JobEvent)(nil),                             // 139: JobEvent
+	(*GetJobEventsResponse)(nil),                 // 140: GetJobEventsResponse
+	(*CompareResultsRequest)(nil),                // 141: CompareResultsRequest
+	(*ColumnChanges)(nil),                        // 142: ColumnChanges
+	(*CompareResultsResponse)(nil),               // 143: CompareResultsResponse
+	(*RemoveQueryRequest)(nil),                   // 144: RemoveQueryRequest
+	(*RemoveQueryResponse)(nil),                  // 145: RemoveQueryResponse
+	(*RestoreQueryRequest)(nil),                  // 146: RestoreQueryRequest
+	(*RestoreQueryResponse)(nil),                 // 147: RestoreQueryResponse
+	(*CancelQueryRequest)(nil),                   // 148: CancelQueryRequest
+	(*CancelQueryResponse)(nil),                  // 149: CancelQueryResponse
+	(*CancelReportRequest)(nil),                  // 150: CancelReportRequest
+	(*CancelReportResponse)(nil),                 // 151: CancelReportResponse
+	(*UpdateQueryRequest)(nil),                   // 152: UpdateQueryRequest
+	(*UpdateQueryResponse)(nil),                  // 153: UpdateQueryResponse
+	(*UpdateQueryDependenciesRequest)(nil),       // 154: UpdateQueryDependenciesRequest
+	(*UpdateQueryDependenciesResponse)(nil),      // 155: UpdateQueryDependenciesResponse
+	(*UpdateQueryParameterBindingsRequest)(nil),  // 156: UpdateQueryParameterBindingsRequest
+	(*UpdateQueryParameterBindingsResponse)(nil), // 157: UpdateQueryParameterBindingsResponse
+	(*UpdateQueryTitleRequest)(nil),              // 158: UpdateQueryTitleRequest
+	(*UpdateQueryTitleResponse)(nil),             // 159: UpdateQueryTitleResponse
+	(*CreateQueryRequest)(nil),                   // 160: CreateQueryRequest
+	(*CreateQueryResponse)(nil),                  // 161: CreateQueryResponse
+	(*ReportStreamRequest)(nil),                  // 162: ReportStreamRequest
+	(*ReportStreamResponse)(nil),                 // 163: ReportStreamResponse
+	(*BatchProgress)(nil),                        // 164: BatchProgress
+	(*ForkReportRequest)(nil),                    // 165: ForkReportRequest
+	(*ForkReportResponse)(nil),                   // 166: ForkReportResponse
+	(*CreateReportRequest)(nil),                  // 167: CreateReportRequest
+	(*CreateReportResponse)(nil),                 // 168: CreateReportResponse
+	(*GetEnvResponse_Variable)(nil),              // 169: GetEnvResponse.Variable
+	nil,                                          // 170: Report.ParameterValuesEntry
+	nil,                                          // 171: Report.SessionParameterValuesEntry
+	nil,                                          // 172: SetReportParameterValuesRequest.ValuesEntry
+	nil,                                          // 173: Query.ParameterBindingsEntry
+	nil,                                          // 174: RunQueryRequest.VariablesEntry
+	nil,                                          // 175: RunAllQueriesRequest.VariablesEntry
+	nil,                                          // 176: RunQueryAndWaitRequest.VariablesEntry
+	nil,                                          // 177: ResultLineage.ParameterValuesEntry
+	nil,                                          // 178: UpdateQueryParameterBindingsRequest.ParameterBindingsEntry
+}
+var file_proto_dekart_proto_depIdxs = []int32{
+	169, // 0: GetEnvResponse.variables:type_name -> GetEnvResponse.Variable
+	0,   // 1: GetCurrentUserResponse.role:type_name -> Role
+	46,  // 2: GetCurrentUserResponse.quota:type_name -> UsageQuota
+	0,   // 3: RoleAssignment.role:type_name -> Role
+	23,  // 4: ListRoleAssignmentsResponse.role_assignments:type_name -> RoleAssignment
+	23,  // 5: SetRoleAssignmentRequest.role_assignment:type_name -> RoleAssignment
+	36,  // 6: GetLogSettingsResponse.settings:type_name -> LogSettings
+	36,  // 7: UpdateLogSettingsRequest.settings:type_name -> LogSettings
+	36,  // 8: UpdateLogSettingsResponse.settings:type_name -> LogSettings
+	42,  // 9: GetPermissionReportResponse.checks:type_name -> PermissionCheck
+	6,   // 10: GetUsageRequest.group_by:type_name -> GetUsageRequest.GroupBy
+	45,  // 11: GetUsageResponse.usage:type_name -> Usage
+	46,  // 12: GetUsageResponse.quota:type_name -> UsageQuota
+	18,  // 13: ReportListRequest.stream_options:type_name -> StreamOptions
+	56,  // 14: ReportListResponse.reports:type_name -> Report
+	18,  // 15: ReportListResponse.stream_options:type_name -> StreamOptions
+	7,   // 16: ListReportsRequest.filter:type_name -> ListReportsRequest.Filter
+	8,   // 17: ListReportsRequest.sort:type_name -> ListReportsRequest.Sort
+	56,  // 18: ListReportsResponse.reports:type_name -> Report
+	57,  // 19: Report.variables:type_name -> ReportVariable
+	67,  // 20: Report.result_settings:type_name -> ResultSettings
+	60,  // 21: Report.parameters:type_name -> ReportParameter
+	170, // 22: Report.parameter_values:type_name -> Report.ParameterValuesEntry
+	171, // 23: Report.session_parameter_values:type_name -> Report.SessionParameterValuesEntry
+	70,  // 24: Report.freshness_policy:type_name -> FreshnessPolicy
+	9,   // 25: ReportVariable.type:type_name -> ReportVariable.Type
+	57,  // 26: UpdateReportVariablesRequest.variables:type_name -> ReportVariable
+	10,  // 27: ReportParameter.type:type_name -> ReportParameter.Type
+	60,  // 28: UpdateReportParametersRequest.parameters:type_name -> ReportParameter
+	172, // 29: SetReportParameterValuesRequest.values:type_name -> SetReportParameterValuesRequest.ValuesEntry
+	2,   // 30: ResultSettings.result_format:type_name -> ResultFormat
+	3,   // 31: ResultSettings.result_compression:type_name -> ResultCompression
+	67,  // 32: UpdateReportSettingsRequest.result_settings:type_name -> ResultSettings
+	70,  // 33: UpdateReportFreshnessPolicyRequest.freshness_policy:type_name -> FreshnessPolicy
+	73,  // 34: CreateDatasetRequest.dataset:type_name -> Dataset
+	73,  // 35: CreateDatasetResponse.dataset:type_name -> Dataset
+	73,  // 36: UpdateDatasetRequest.dataset:type_name -> Dataset
+	73,  // 37: UpdateDatasetResponse.dataset:type_name -> Dataset
+	73,  // 38: ListDatasetsResponse.datasets:type_name -> Dataset
+	1,   // 39: File.file_type:type_name -> FileType
+	82,  // 40: UploadFileResponse.file:type_name -> File
+	11,  // 41: Query.job_status:type_name -> Query.JobStatus
+	12,  // 42: Query.result_type:type_name -> Query.ResultType
+	2,   // 43: Query.result_format:type_name -> ResultFormat
+	92,  // 44: Query.warnings:type_name -> JobWarning
+	93,  // 45: Query.serialization_options:type_name -> SerializationOptions
+	3,   // 46: Query.result_compression:type_name -> ResultCompression
+	173, // 47: Query.parameter_bindings:type_name -> Query.ParameterBindingsEntry
+	91,  // 48: Query.format_results:type_name -> FormatResult
+	4,   // 49: Query.cancel_reason:type_name -> CancelReason
+	90,  // 50: Query.job_error_explanation:type_name -> ErrorExplanation
+	88,  // 51: Query.result_meta:type_name -> ResultMeta
+	2,   // 52: ResultMeta.format:type_name -> ResultFormat
+	3,   // 53: ResultMeta.compression:type_name -> ResultCompression
+	89,  // 54: ResultMeta.parts:type_name -> ResultPart
+	2,   // 55: ResultPart.format:type_name -> ResultFormat
+	2,   // 56: FormatResult.format:type_name -> ResultFormat
+	13,  // 57: SerializationOptions.bool_style:type_name -> SerializationOptions.BoolStyle
+	14,  // 58: SerializationOptions.bytes_encoding:type_name -> SerializationOptions.BytesEncoding
+	15,  // 59: SerializationOptions.header_naming:type_name -> SerializationOptions.HeaderNaming
+	16,  // 60: DestinationTable.write_disposition:type_name -> DestinationTable.WriteDisposition
+	96,  // 61: H3Aggregation.metrics:type_name -> H3Metric
+	17,  // 62: H3Metric.function:type_name -> H3Metric.Function
+	56,  // 63: GetReportResponse.report:type_name -> Report
+	87,  // 64: GetReportResponse.queries:type_name -> Query
+	82,  // 65: GetReportResponse.files:type_name -> File
+	87,  // 66: GetQueryResponse.query:type_name -> Query
+	102, // 67: GetQuerySchemaContextResponse.columns:type_name -> SchemaColumn
+	102, // 68: GetSchemaSnapshotResponse.columns:type_name -> SchemaColumn
+	104, // 69: GetSchemaSnapshotResponse.sources:type_name -> SchemaSnapshotSource
+	104, // 70: RefreshSchemaSnapshotResponse.sources:type_name -> SchemaSnapshotSource
+	112, // 71: GetJobPlanResponse.stages:type_name -> JobPlanStage
+	56,  // 72: UpdateReportRequest.report:type_name -> Report
+	118, // 73: GetMapConfigHistoryResponse.revisions:type_name -> MapConfigRevision
+	95,  // 74: RunQueryRequest.h3_aggregation:type_name -> H3Aggregation
+	174, // 75: RunQueryRequest.variables:type_name -> RunQueryRequest.VariablesEntry
+	94,  // 76: RunQueryRequest.destination_table:type_name -> DestinationTable
+	2,   // 77: RunQueryRequest.result_format:type_name -> ResultFormat
+	93,  // 78: RunQueryRequest.serialization_options:type_name -> SerializationOptions
+	3,   // 79: RunQueryRequest.result_compression:type_name -> ResultCompression
+	2,   // 80: RunQueryRequest.additional_formats:type_name -> ResultFormat
+	175, // 81: RunAllQueriesRequest.variables:type_name -> RunAllQueriesRequest.VariablesEntry
+	124, // 82: RunAllQueriesResponse.runs:type_name -> BatchRun
+	95,  // 83: RunQueryAndWaitRequest.h3_aggregation:type_name -> H3Aggregation
+	176, // 84: RunQueryAndWaitRequest.variables:type_name -> RunQueryAndWaitRequest.VariablesEntry
+	94,  // 85: RunQueryAndWaitRequest.destination_table:type_name -> DestinationTable
+	2,   // 86: RunQueryAndWaitRequest.result_format:type_name -> ResultFormat
+	93,  // 87: RunQueryAndWaitRequest.serialization_options:type_name -> SerializationOptions
+	3,   // 88: RunQueryAndWaitRequest.result_compression:type_name -> ResultCompression
+	2,   // 89: RunQueryAndWaitRequest.additional_formats:type_name -> ResultFormat
+	11,  // 90: RunQueryAndWaitResponse.job_status:type_name -> Query.JobStatus
+	12,  // 91: RunQueryAndWaitResponse.result_type:type_name -> Query.ResultType
+	92,  // 92: RunQueryAndWaitResponse.warnings:type_name -> JobWarning
+	91,  // 93: RunQueryAndWaitResponse.format_results:type_name -> FormatResult
+	4,   // 94: RunQueryAndWaitResponse.cancel_reason:type_name -> CancelReason
+	177, // 95: ResultLineage.parameter_values:type_name -> ResultLineage.ParameterValuesEntry
+	132, // 96: GetResultLineageResponse.lineage:type_name -> ResultLineage
+	136, // 97: GetResultFieldTypesResponse.fields:type_name -> ResultFieldType
+	88,  // 98: GetResultFieldTypesResponse.result_meta:type_name -> ResultMeta
+	139, // 99: GetJobEventsResponse.events:type_name -> JobEvent
+	142, // 100: CompareResultsResponse.column_changes:type_name -> ColumnChanges
+	87,  // 101: RestoreQueryResponse.query:type_name -> Query
+	87,  // 102: UpdateQueryRequest.query:type_name -> Query
+	87,  // 103: UpdateQueryResponse.query:type_name -> Query
+	87,  // 104: UpdateQueryDependenciesResponse.query:type_name -> Query
+	178, // 105: UpdateQueryParameterBindingsRequest.parameter_bindings:type_name -> UpdateQueryParameterBindingsRequest.ParameterBindingsEntry
+	87,  // 106: UpdateQueryTitleResponse.query:type_name -> Query
+	87,  // 107: CreateQueryRequest.query:type_name -> Query
+	87,  // 108: CreateQueryResponse.query:type_name -> Query
+	56,  // 109: ReportStreamRequest.report:type_name -> Report
+	18,  // 110: ReportStreamRequest.stream_options:type_name -> StreamOptions
+	56,  // 111: ReportStreamResponse.report:type_name -> Report
+	87,  // 112: ReportStreamResponse.queries:type_name -> Query
+	18,  // 113: ReportStreamResponse.stream_options:type_name -> StreamOptions
+	164, // 114: ReportStreamResponse.batch_progress:type_name -> BatchProgress
+	82,  // 115: ReportStreamResponse.files:type_name -> File
+	56,  // 116: CreateReportResponse.report:type_name -> Report
+	5,   // 117: GetEnvResponse.Variable.type:type_name -> GetEnvResponse.Variable.Type
+	167, // 118: Dekart.CreateReport:input_type -> CreateReportRequest
+	165, // 119: Dekart.ForkReport:input_type -> ForkReportRequest
+	114, // 120: Dekart.UpdateReport:input_type -> UpdateReportRequest
+	48,  // 121: Dekart.ArchiveReport:input_type -> ArchiveReportRequest
+	50,  // 122: Dekart.ShareReport:input_type -> ShareReportRequest
+	115, // 123: Dekart.UpdateMapConfig:input_type -> UpdateMapConfigRequest
+	58,  // 124: Dekart.UpdateReportVariables:input_type -> UpdateReportVariablesRequest
+	68,  // 125: Dekart.UpdateReportSettings:input_type -> UpdateReportSettingsRequest
+	71,  // 126: Dekart.UpdateReportFreshnessPolicy:input_type -> UpdateReportFreshnessPolicyRequest
+	61,  // 127: Dekart.UpdateReportParameters:input_type -> UpdateReportParametersRequest
+	63,  // 128: Dekart.SetReportParameterValues:input_type -> SetReportParameterValuesRequest
+	65,  // 129: Dekart.GetReportParameterOptions:input_type -> GetReportParameterOptionsRequest
+	117, // 130: Dekart.GetMapConfigHistory:input_type -> GetMapConfigHistoryRequest
+	97,  // 131: Dekart.GetReport:input_type -> GetReportRequest
+	54,  // 132: Dekart.ListReports:input_type -> ListReportsRequest
+	160, // 133: Dekart.CreateQuery:input_type -> CreateQueryRequest
+	152, // 134: Dekart.UpdateQuery:input_type -> UpdateQueryRequest
+	158, // 135: Dekart.UpdateQueryTitle:input_type -> UpdateQueryTitleRequest
+	154, // 136: Dekart.UpdateQueryDependencies:input_type -> UpdateQueryDependenciesRequest
+	156, // 137: Dekart.UpdateQueryParameterBindings:input_type -> UpdateQueryParameterBindingsRequest
+	121, // 138: Dekart.RunQuery:input_type -> RunQueryRequest
+	148, // 139: Dekart.CancelQuery:input_type -> CancelQueryRequest
+	150, // 140: Dekart.CancelReport:input_type -> CancelReportRequest
+	123, // 141: Dekart.RunAllQueries:input_type -> RunAllQueriesRequest
+	126, // 142: Dekart.CancelBatch:input_type -> CancelBatchRequest
+	144, // 143: Dekart.RemoveQuery:input_type -> RemoveQueryRequest
+	146, // 144: Dekart.RestoreQuery:input_type -> RestoreQueryRequest
+	99,  // 145: Dekart.GetQuery:input_type -> GetQueryRequest
+	109, // 146: Dekart.GetExecutedQuery:input_type -> GetExecutedQueryRequest
+	111, // 147: Dekart.GetJobPlan:input_type -> GetJobPlanRequest
+	128, // 148: Dekart.RunQueryAndWait:input_type -> RunQueryAndWaitRequest
+	130, // 149: Dekart.ExportResult:input_type -> ExportResultRequest
+	141, // 150: Dekart.CompareResults:input_type -> CompareResultsRequest
+	133, // 151: Dekart.GetResultLineage:input_type -> GetResultLineageRequest
+	135, // 152: Dekart.GetResultFieldTypes:input_type -> GetResultFieldTypesRequest
+	138, // 153: Dekart.GetJobEvents:input_type -> GetJobEventsRequest
+	101, // 154: Dekart.GetQuerySchemaContext:input_type -> GetQuerySchemaContextRequest
+	105, // 155: Dekart.GetSchemaSnapshot:input_type -> GetSchemaSnapshotRequest
+	107, // 156: Dekart.RefreshSchemaSnapshot:input_type -> RefreshSchemaSnapshotRequest
+	74,  // 157: Dekart.CreateDataset:input_type -> CreateDatasetRequest
+	76,  // 158: Dekart.UpdateDataset:input_type -> UpdateDatasetRequest
+	78,  // 159: Dekart.DeleteDataset:input_type -> DeleteDatasetRequest
+	80,  // 160: Dekart.ListDatasets:input_type -> ListDatasetsRequest
+	83,  // 161: Dekart.UploadFile:input_type -> UploadFileRequest
+	85,  // 162: Dekart.RemoveFile:input_type -> RemoveFileRequest
+	19,  // 163: Dekart.GetEnv:input_type -> GetEnvRequest
+	21,  // 164: Dekart.GetCurrentUser:input_type -> GetCurrentUserRequest
+	24,  // 165: Dekart.ListRoleAssignments:input_type -> ListRoleAssignmentsRequest
+	26,  // 166: Dekart.SetRoleAssignment:input_type -> SetRoleAssignmentRequest
+	28,  // 167: Dekart.RemoveRoleAssignment:input_type -> RemoveRoleAssignmentRequest
+	30,  // 168: Dekart.GetResultLifecycle:input_type -> GetResultLifecycleRequest
+	32,  // 169: Dekart.ReconcileResults:input_type -> ReconcileResultsRequest
+	34,  // 170: Dekart.ReencryptColumns:input_type -> ReencryptColumnsRequest
+	37,  // 171: Dekart.GetLogSettings:input_type -> GetLogSettingsRequest
+	39,  // 172: Dekart.UpdateLogSettings:input_type -> UpdateLogSettingsRequest
+	41,  // 173: Dekart.GetPermissionReport:input_type -> GetPermissionReportRequest
+	44,  // 174: Dekart.GetUsage:input_type -> GetUsageRequest
+	162, // 175: Dekart.GetReportStream:input_type -> ReportStreamRequest
+	52,  // 176: Dekart.GetReportListStream:input_type -> ReportListRequest
+	168, // 177: Dekart.CreateReport:output_type -> CreateReportResponse
+	166, // 178: Dekart.ForkReport:output_type -> ForkReportResponse
+	120, // 179: Dekart.UpdateReport:output_type -> UpdateReportResponse
+	49,  // 180: Dekart.ArchiveReport:output_type -> ArchiveReportResponse
+	51,  // 181: Dekart.ShareReport:output_type -> ShareReportResponse
+	116, // 182: Dekart.UpdateMapConfig:output_type -> UpdateMapConfigResponse
+	59,  // 183: Dekart.UpdateReportVariables:output_type -> UpdateReportVariablesResponse
+	69,  // 184: Dekart.UpdateReportSettings:output_type -> UpdateReportSettingsResponse
+	72,  // 185: Dekart.UpdateReportFreshnessPolicy:output_type -> UpdateReportFreshnessPolicyResponse
+	62,  // 186: Dekart.UpdateReportParameters:output_type -> UpdateReportParametersResponse
+	64,  // 187: Dekart.SetReportParameterValues:output_type -> SetReportParameterValuesResponse
+	66,  // 188: Dekart.GetReportParameterOptions:output_type -> GetReportParameterOptionsResponse
+	119, // 189: Dekart.GetMapConfigHistory:output_type -> GetMapConfigHistoryResponse
+	98,  // 190: Dekart.GetReport:output_type -> GetReportResponse
+	55,  // 191: Dekart.ListReports:output_type -> ListReportsResponse
+	161, // 192: Dekart.CreateQuery:output_type -> CreateQueryResponse
+	153, // 193: Dekart.UpdateQuery:output_type -> UpdateQueryResponse
+	159, // 194: Dekart.UpdateQueryTitle:output_type -> UpdateQueryTitleResponse
+	155, // 195: Dekart.UpdateQueryDependencies:output_type -> UpdateQueryDependenciesResponse
+	157, // 196: Dekart.UpdateQueryParameterBindings:output_type -> UpdateQueryParameterBindingsResponse
+	122, // 197: Dekart.RunQuery:output_type -> RunQueryResponse
+	149, // 198: Dekart.CancelQuery:output_type -> CancelQueryResponse
+	151, // 199: Dekart.CancelReport:output_type -> CancelReportResponse
+	125, // 200: Dekart.RunAllQueries:output_type -> RunAllQueriesResponse
+	127, // 201: Dekart.CancelBatch:output_type -> CancelBatchResponse
+	145, // 202: Dekart.RemoveQuery:output_type -> RemoveQueryResponse
+	147, // 203: Dekart.RestoreQuery:output_type -> RestoreQueryResponse
+	100, // 204: Dekart.GetQuery:output_type -> GetQueryResponse
+	110, // 205: Dekart.GetExecutedQuery:output_type -> GetExecutedQueryResponse
+	113, // 206: Dekart.GetJobPlan:output_type -> GetJobPlanResponse
+	129, // 207: Dekart.RunQueryAndWait:output_type -> RunQueryAndWaitResponse
+	131, // 208: Dekart.ExportResult:output_type -> ExportResultResponse
+	143, // 209: Dekart.CompareResults:output_type -> CompareResultsResponse
+	134, // 210: Dekart.GetResultLineage:output_type -> GetResultLineageResponse
+	137, // 211: Dekart.GetResultFieldTypes:output_type -> GetResultFieldTypesResponse
+	140, // 212: Dekart.GetJobEvents:output_type -> GetJobEventsResponse
+	103, // 213: Dekart.GetQuerySchemaContext:output_type -> GetQuerySchemaContextResponse
+	106, // 214: Dekart.GetSchemaSnapshot:output_type -> GetSchemaSnapshotResponse
+	108, // 215: Dekart.RefreshSchemaSnapshot:output_type -> RefreshSchemaSnapshotResponse
+	75,  // 216: Dekart.CreateDataset:output_type -> CreateDatasetResponse
+	77,  // 217: Dekart.UpdateDataset:output_type -> UpdateDatasetResponse
+	79,  // 218: Dekart.DeleteDataset:output_type -> DeleteDatasetResponse
+	81,  // 219: Dekart.ListDatasets:output_type -> ListDatasetsResponse
+	84,  // 220: Dekart.UploadFile:output_type -> UploadFileResponse
+	86,  // 221: Dekart.RemoveFile:output_type -> RemoveFileResponse
+	20,  // 222: Dekart.GetEnv:output_type -> GetEnvResponse
+	22,  // 223: Dekart.GetCurrentUser:output_type -> GetCurrentUserResponse
+	25,  // 224: Dekart.ListRoleAssignments:output_type -> ListRoleAssignmentsResponse
+	27,  // 225: Dekart.SetRoleAssignment:output_type -> SetRoleAssignmentResponse
+	29,  // 226: Dekart.RemoveRoleAssignment:output_type -> RemoveRoleAssignmentResponse
+	31,  // 227: Dekart.GetResultLifecycle:output_type -> GetResultLifecycleResponse
+	33,  // 228: Dekart.ReconcileResults:output_type -> ReconcileResultsResponse
+	35,  // 229: Dekart.ReencryptColumns:output_type -> ReencryptColumnsResponse
+	38,  // 230: Dekart.GetLogSettings:output_type -> GetLogSettingsResponse
+	40,  // 231: Dekart.UpdateLogSettings:output_type -> UpdateLogSettingsResponse
+	43,  // 232: Dekart.GetPermissionReport:output_type -> GetPermissionReportResponse
+	47,  // 233: Dekart.GetUsage:output_type -> GetUsageResponse
+	163, // 234: Dekart.GetReportStream:output_type -> ReportStreamResponse
+	53,  // 235: Dekart.GetReportListStream:output_type -> ReportListResponse
+	177, // [177:236] is the sub-list for method output_type
+	118, // [118:177] is the sub-list for method input_type
+	118, // [118:118] is the sub-list for extension type_name
+	118, // [118:118] is the sub-list for extension extendee
+	0,   // [0:118] is the sub-list for field type_name
+}
+
+func init() { file_proto_dekart_proto_init() }
+func file_proto_dekart_proto_init() {
+	if File_proto_dekart_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_dekart_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEnvRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEnvResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCurrentUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCurrentUserResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoleAssignment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoleAssignmentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoleAssignmentsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRoleAssignmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRoleAssignmentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveRoleAssignmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveRoleAssignmentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResultLifecycleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResultLifecycleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileResultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileResultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReencryptColumnsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReencryptColumnsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLogSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLogSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLogSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLogSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPermissionReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PermissionCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPermissionReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUsageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Usage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageQuota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetUsageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArchiveReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArchiveReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShareReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReportsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListReportsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Report); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportVariable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportVariablesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportVariablesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportParameter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportParametersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportParametersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetReportParameterValuesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetReportParameterValuesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReportParameterOptionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReportParameterOptionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportSettingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FreshnessPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportFreshnessPolicyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportFreshnessPolicyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dataset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDatasetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDatasetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDatasetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDatasetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteDatasetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteDatasetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDatasetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDatasetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveFileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Query); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultMeta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultPart); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorExplanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FormatResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobWarning); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SerializationOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestinationTable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*H3Aggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*H3Metric); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuerySchemaContextRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchemaColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetQuerySchemaContextResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchemaSnapshotSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSchemaSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSchemaSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshSchemaSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RefreshSchemaSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExecutedQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExecutedQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobPlanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobPlanStage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobPlanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateMapConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateMapConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMapConfigHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MapConfigRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMapConfigHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunAllQueriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchRun); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[107].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunAllQueriesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[108].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[109].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[110].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunQueryAndWaitRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[111].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunQueryAndWaitResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1835,8 +14107,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetEnvRequest); i {
+		file_proto_dekart_proto_msgTypes[112].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportResultRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1847,8 +14119,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetEnvResponse); i {
+		file_proto_dekart_proto_msgTypes[113].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportResultResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1859,8 +14131,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArchiveReportRequest); i {
+		file_proto_dekart_proto_msgTypes[114].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultLineage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1871,8 +14143,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArchiveReportResponse); i {
+		file_proto_dekart_proto_msgTypes[115].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResultLineageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1883,8 +14155,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportListRequest); i {
+		file_proto_dekart_proto_msgTypes[116].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResultLineageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1895,8 +14167,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportListResponse); i {
+		file_proto_dekart_proto_msgTypes[117].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResultFieldTypesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1907,8 +14179,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Report); i {
+		file_proto_dekart_proto_msgTypes[118].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultFieldType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1919,8 +14191,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Query); i {
+		file_proto_dekart_proto_msgTypes[119].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResultFieldTypesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1931,8 +14203,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateReportRequest); i {
+		file_proto_dekart_proto_msgTypes[120].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobEventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1943,8 +14215,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateReportResponse); i {
+		file_proto_dekart_proto_msgTypes[121].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1955,8 +14227,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunQueryRequest); i {
+		file_proto_dekart_proto_msgTypes[122].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetJobEventsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1967,8 +14239,8 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunQueryResponse); i {
+		file_proto_dekart_proto_msgTypes[123].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareResultsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1979,7 +14251,31 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[124].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColumnChanges); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[125].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompareResultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[126].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoveQueryRequest); i {
 			case 0:
 				return &v.state
@@ -1991,7 +14287,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[127].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoveQueryResponse); i {
 			case 0:
 				return &v.state
@@ -2003,7 +14299,31 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[128].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[129].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreQueryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[130].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CancelQueryRequest); i {
 			case 0:
 				return &v.state
@@ -2015,7 +14335,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[131].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CancelQueryResponse); i {
 			case 0:
 				return &v.state
@@ -2027,7 +14347,31 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[132].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelReportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[133].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelReportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[134].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateQueryRequest); i {
 			case 0:
 				return &v.state
@@ -2039,7 +14383,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[135].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateQueryResponse); i {
 			case 0:
 				return &v.state
@@ -2051,7 +14395,79 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[136].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateQueryDependenciesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[137].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateQueryDependenciesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[138].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateQueryParameterBindingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[139].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateQueryParameterBindingsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[140].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateQueryTitleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[141].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateQueryTitleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[142].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateQueryRequest); i {
 			case 0:
 				return &v.state
@@ -2063,7 +14479,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[143].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateQueryResponse); i {
 			case 0:
 				return &v.state
@@ -2075,7 +14491,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[144].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReportStreamRequest); i {
 			case 0:
 				return &v.state
@@ -2087,7 +14503,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[145].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReportStreamResponse); i {
 			case 0:
 				return &v.state
@@ -2099,7 +14515,19 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[146].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_dekart_proto_msgTypes[147].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ForkReportRequest); i {
 			case 0:
 				return &v.state
@@ -2111,7 +14539,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[148].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ForkReportResponse); i {
 			case 0:
 				return &v.state
@@ -2123,7 +14551,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[149].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateReportRequest); i {
 			case 0:
 				return &v.state
@@ -2135,7 +14563,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[150].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateReportResponse); i {
 			case 0:
 				return &v.state
@@ -2147,7 +14575,7 @@ func file_proto_dekart_proto_init() {
 				return nil
 			}
 		}
-		file_proto_dekart_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_dekart_proto_msgTypes[151].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetEnvResponse_Variable); i {
 			case 0:
 				return &v.state
@@ -2165,8 +14593,8 @@ func file_proto_dekart_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_dekart_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   28,
+			NumEnums:      18,
+			NumMessages:   161,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,12 +22,59 @@ type DekartClient interface {
 	ForkReport(ctx context.Context, in *ForkReportRequest, opts ...grpc.CallOption) (*ForkReportResponse, error)
 	UpdateReport(ctx context.Context, in *UpdateReportRequest, opts ...grpc.CallOption) (*UpdateReportResponse, error)
 	ArchiveReport(ctx context.Context, in *ArchiveReportRequest, opts ...grpc.CallOption) (*ArchiveReportResponse, error)
+	ShareReport(ctx context.Context, in *ShareReportRequest, opts ...grpc.CallOption) (*ShareReportResponse, error)
+	UpdateMapConfig(ctx context.Context, in *UpdateMapConfigRequest, opts ...grpc.CallOption) (*UpdateMapConfigResponse, error)
+	UpdateReportVariables(ctx context.Context, in *UpdateReportVariablesRequest, opts ...grpc.CallOption) (*UpdateReportVariablesResponse, error)
+	UpdateReportSettings(ctx context.Context, in *UpdateReportSettingsRequest, opts ...grpc.CallOption) (*UpdateReportSettingsResponse, error)
+	UpdateReportFreshnessPolicy(ctx context.Context, in *UpdateReportFreshnessPolicyRequest, opts ...grpc.CallOption) (*UpdateReportFreshnessPolicyResponse, error)
+	UpdateReportParameters(ctx context.Context, in *UpdateReportParametersRequest, opts ...grpc.CallOption) (*UpdateReportParametersResponse, error)
+	SetReportParameterValues(ctx context.Context, in *SetReportParameterValuesRequest, opts ...grpc.CallOption) (*SetReportParameterValuesResponse, error)
+	GetReportParameterOptions(ctx context.Context, in *GetReportParameterOptionsRequest, opts ...grpc.CallOption) (*GetReportParameterOptionsResponse, error)
+	GetMapConfigHistory(ctx context.Context, in *GetMapConfigHistoryRequest, opts ...grpc.CallOption) (*GetMapConfigHistoryResponse, error)
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error)
+	ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error)
 	CreateQuery(ctx context.Context, in *CreateQueryRequest, opts ...grpc.CallOption) (*CreateQueryResponse, error)
 	UpdateQuery(ctx context.Context, in *UpdateQueryRequest, opts ...grpc.CallOption) (*UpdateQueryResponse, error)
+	UpdateQueryTitle(ctx context.Context, in *UpdateQueryTitleRequest, opts ...grpc.CallOption) (*UpdateQueryTitleResponse, error)
+	UpdateQueryDependencies(ctx context.Context, in *UpdateQueryDependenciesRequest, opts ...grpc.CallOption) (*UpdateQueryDependenciesResponse, error)
+	UpdateQueryParameterBindings(ctx context.Context, in *UpdateQueryParameterBindingsRequest, opts ...grpc.CallOption) (*UpdateQueryParameterBindingsResponse, error)
 	RunQuery(ctx context.Context, in *RunQueryRequest, opts ...grpc.CallOption) (*RunQueryResponse, error)
 	CancelQuery(ctx context.Context, in *CancelQueryRequest, opts ...grpc.CallOption) (*CancelQueryResponse, error)
+	CancelReport(ctx context.Context, in *CancelReportRequest, opts ...grpc.CallOption) (*CancelReportResponse, error)
+	RunAllQueries(ctx context.Context, in *RunAllQueriesRequest, opts ...grpc.CallOption) (*RunAllQueriesResponse, error)
+	CancelBatch(ctx context.Context, in *CancelBatchRequest, opts ...grpc.CallOption) (*CancelBatchResponse, error)
 	RemoveQuery(ctx context.Context, in *RemoveQueryRequest, opts ...grpc.CallOption) (*RemoveQueryResponse, error)
+	RestoreQuery(ctx context.Context, in *RestoreQueryRequest, opts ...grpc.CallOption) (*RestoreQueryResponse, error)
+	GetQuery(ctx context.Context, in *GetQueryRequest, opts ...grpc.CallOption) (*GetQueryResponse, error)
+	GetExecutedQuery(ctx context.Context, in *GetExecutedQueryRequest, opts ...grpc.CallOption) (*GetExecutedQueryResponse, error)
+	GetJobPlan(ctx context.Context, in *GetJobPlanRequest, opts ...grpc.CallOption) (*GetJobPlanResponse, error)
+	RunQueryAndWait(ctx context.Context, in *RunQueryAndWaitRequest, opts ...grpc.CallOption) (Dekart_RunQueryAndWaitClient, error)
+	ExportResult(ctx context.Context, in *ExportResultRequest, opts ...grpc.CallOption) (Dekart_ExportResultClient, error)
+	CompareResults(ctx context.Context, in *CompareResultsRequest, opts ...grpc.CallOption) (*CompareResultsResponse, error)
+	GetResultLineage(ctx context.Context, in *GetResultLineageRequest, opts ...grpc.CallOption) (*GetResultLineageResponse, error)
+	GetResultFieldTypes(ctx context.Context, in *GetResultFieldTypesRequest, opts ...grpc.CallOption) (*GetResultFieldTypesResponse, error)
+	GetJobEvents(ctx context.Context, in *GetJobEventsRequest, opts ...grpc.CallOption) (*GetJobEventsResponse, error)
+	GetQuerySchemaContext(ctx context.Context, in *GetQuerySchemaContextRequest, opts ...grpc.CallOption) (*GetQuerySchemaContextResponse, error)
+	GetSchemaSnapshot(ctx context.Context, in *GetSchemaSnapshotRequest, opts ...grpc.CallOption) (*GetSchemaSnapshotResponse, error)
+	RefreshSchemaSnapshot(ctx context.Context, in *RefreshSchemaSnapshotRequest, opts ...grpc.CallOption) (*RefreshSchemaSnapshotResponse, error)
+	CreateDataset(ctx context.Context, in *CreateDatasetRequest, opts ...grpc.CallOption) (*CreateDatasetResponse, error)
+	UpdateDataset(ctx context.Context, in *UpdateDatasetRequest, opts ...grpc.CallOption) (*UpdateDatasetResponse, error)
+	DeleteDataset(ctx context.Context, in *DeleteDatasetRequest, opts ...grpc.CallOption) (*DeleteDatasetResponse, error)
+	ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error)
+	UploadFile(ctx context.Context, opts ...grpc.CallOption) (Dekart_UploadFileClient, error)
+	RemoveFile(ctx context.Context, in *RemoveFileRequest, opts ...grpc.CallOption) (*RemoveFileResponse, error)
 	GetEnv(ctx context.Context, in *GetEnvRequest, opts ...grpc.CallOption) (*GetEnvResponse, error)
+	GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...grpc.CallOption) (*GetCurrentUserResponse, error)
+	ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, opts ...grpc.CallOption) (*ListRoleAssignmentsResponse, error)
+	SetRoleAssignment(ctx context.Context, in *SetRoleAssignmentRequest, opts ...grpc.CallOption) (*SetRoleAssignmentResponse, error)
+	RemoveRoleAssignment(ctx context.Context, in *RemoveRoleAssignmentRequest, opts ...grpc.CallOption) (*RemoveRoleAssignmentResponse, error)
+	GetResultLifecycle(ctx context.Context, in *GetResultLifecycleRequest, opts ...grpc.CallOption) (*GetResultLifecycleResponse, error)
+	ReconcileResults(ctx context.Context, in *ReconcileResultsRequest, opts ...grpc.CallOption) (*ReconcileResultsResponse, error)
+	ReencryptColumns(ctx context.Context, in *ReencryptColumnsRequest, opts ...grpc.CallOption) (*ReencryptColumnsResponse, error)
+	GetLogSettings(ctx context.Context, in *GetLogSettingsRequest, opts ...grpc.CallOption) (*GetLogSettingsResponse, error)
+	UpdateLogSettings(ctx context.Context, in *UpdateLogSettingsRequest, opts ...grpc.CallOption) (*UpdateLogSettingsResponse, error)
+	GetPermissionReport(ctx context.Context, in *GetPermissionReportRequest, opts ...grpc.CallOption) (*GetPermissionReportResponse, error)
+	GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error)
 	GetReportStream(ctx context.Context, in *ReportStreamRequest, opts ...grpc.CallOption) (Dekart_GetReportStreamClient, error)
 	GetReportListStream(ctx context.Context, in *ReportListRequest, opts ...grpc.CallOption) (Dekart_GetReportListStreamClient, error)
 }
@@ -76,6 +123,105 @@ func (c *dekartClient) ArchiveReport(ctx context.Context, in *ArchiveReportReque
 	return out, nil
 }
 
+func (c *dekartClient) ShareReport(ctx context.Context, in *ShareReportRequest, opts ...grpc.CallOption) (*ShareReportResponse, error) {
+	out := new(ShareReportResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/ShareReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateMapConfig(ctx context.Context, in *UpdateMapConfigRequest, opts ...grpc.CallOption) (*UpdateMapConfigResponse, error) {
+	out := new(UpdateMapConfigResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateMapConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateReportVariables(ctx context.Context, in *UpdateReportVariablesRequest, opts ...grpc.CallOption) (*UpdateReportVariablesResponse, error) {
+	out := new(UpdateReportVariablesResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateReportVariables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateReportSettings(ctx context.Context, in *UpdateReportSettingsRequest, opts ...grpc.CallOption) (*UpdateReportSettingsResponse, error) {
+	out := new(UpdateReportSettingsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateReportSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateReportFreshnessPolicy(ctx context.Context, in *UpdateReportFreshnessPolicyRequest, opts ...grpc.CallOption) (*UpdateReportFreshnessPolicyResponse, error) {
+	out := new(UpdateReportFreshnessPolicyResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateReportFreshnessPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateReportParameters(ctx context.Context, in *UpdateReportParametersRequest, opts ...grpc.CallOption) (*UpdateReportParametersResponse, error) {
+	out := new(UpdateReportParametersResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateReportParameters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) SetReportParameterValues(ctx context.Context, in *SetReportParameterValuesRequest, opts ...grpc.CallOption) (*SetReportParameterValuesResponse, error) {
+	out := new(SetReportParameterValuesResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/SetReportParameterValues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetReportParameterOptions(ctx context.Context, in *GetReportParameterOptionsRequest, opts ...grpc.CallOption) (*GetReportParameterOptionsResponse, error) {
+	out := new(GetReportParameterOptionsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetReportParameterOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetMapConfigHistory(ctx context.Context, in *GetMapConfigHistoryRequest, opts ...grpc.CallOption) (*GetMapConfigHistoryResponse, error) {
+	out := new(GetMapConfigHistoryResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetMapConfigHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*GetReportResponse, error) {
+	out := new(GetReportResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) ListReports(ctx context.Context, in *ListReportsRequest, opts ...grpc.CallOption) (*ListReportsResponse, error) {
+	out := new(ListReportsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/ListReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dekartClient) CreateQuery(ctx context.Context, in *CreateQueryRequest, opts ...grpc.CallOption) (*CreateQueryResponse, error) {
 	out := new(CreateQueryResponse)
 	err := c.cc.Invoke(ctx, "/Dekart/CreateQuery", in, out, opts...)
@@ -94,6 +240,33 @@ func (c *dekartClient) UpdateQuery(ctx context.Context, in *UpdateQueryRequest,
 	return out, nil
 }
 
+func (c *dekartClient) UpdateQueryTitle(ctx context.Context, in *UpdateQueryTitleRequest, opts ...grpc.CallOption) (*UpdateQueryTitleResponse, error) {
+	out := new(UpdateQueryTitleResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateQueryTitle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateQueryDependencies(ctx context.Context, in *UpdateQueryDependenciesRequest, opts ...grpc.CallOption) (*UpdateQueryDependenciesResponse, error) {
+	out := new(UpdateQueryDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateQueryDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateQueryParameterBindings(ctx context.Context, in *UpdateQueryParameterBindingsRequest, opts ...grpc.CallOption) (*UpdateQueryParameterBindingsResponse, error) {
+	out := new(UpdateQueryParameterBindingsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateQueryParameterBindings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dekartClient) RunQuery(ctx context.Context, in *RunQueryRequest, opts ...grpc.CallOption) (*RunQueryResponse, error) {
 	out := new(RunQueryResponse)
 	err := c.cc.Invoke(ctx, "/Dekart/RunQuery", in, out, opts...)
@@ -112,6 +285,33 @@ func (c *dekartClient) CancelQuery(ctx context.Context, in *CancelQueryRequest,
 	return out, nil
 }
 
+func (c *dekartClient) CancelReport(ctx context.Context, in *CancelReportRequest, opts ...grpc.CallOption) (*CancelReportResponse, error) {
+	out := new(CancelReportResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/CancelReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) RunAllQueries(ctx context.Context, in *RunAllQueriesRequest, opts ...grpc.CallOption) (*RunAllQueriesResponse, error) {
+	out := new(RunAllQueriesResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/RunAllQueries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) CancelBatch(ctx context.Context, in *CancelBatchRequest, opts ...grpc.CallOption) (*CancelBatchResponse, error) {
+	out := new(CancelBatchResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/CancelBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dekartClient) RemoveQuery(ctx context.Context, in *RemoveQueryRequest, opts ...grpc.CallOption) (*RemoveQueryResponse, error) {
 	out := new(RemoveQueryResponse)
 	err := c.cc.Invoke(ctx, "/Dekart/RemoveQuery", in, out, opts...)
@@ -121,21 +321,48 @@ func (c *dekartClient) RemoveQuery(ctx context.Context, in *RemoveQueryRequest,
 	return out, nil
 }
 
-func (c *dekartClient) GetEnv(ctx context.Context, in *GetEnvRequest, opts ...grpc.CallOption) (*GetEnvResponse, error) {
-	out := new(GetEnvResponse)
-	err := c.cc.Invoke(ctx, "/Dekart/GetEnv", in, out, opts...)
+func (c *dekartClient) RestoreQuery(ctx context.Context, in *RestoreQueryRequest, opts ...grpc.CallOption) (*RestoreQueryResponse, error) {
+	out := new(RestoreQueryResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/RestoreQuery", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *dekartClient) GetReportStream(ctx context.Context, in *ReportStreamRequest, opts ...grpc.CallOption) (Dekart_GetReportStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[0], "/Dekart/GetReportStream", opts...)
+func (c *dekartClient) GetQuery(ctx context.Context, in *GetQueryRequest, opts ...grpc.CallOption) (*GetQueryResponse, error) {
+	out := new(GetQueryResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetQuery", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &dekartGetReportStreamClient{stream}
+	return out, nil
+}
+
+func (c *dekartClient) GetExecutedQuery(ctx context.Context, in *GetExecutedQueryRequest, opts ...grpc.CallOption) (*GetExecutedQueryResponse, error) {
+	out := new(GetExecutedQueryResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetExecutedQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetJobPlan(ctx context.Context, in *GetJobPlanRequest, opts ...grpc.CallOption) (*GetJobPlanResponse, error) {
+	out := new(GetJobPlanResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetJobPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) RunQueryAndWait(ctx context.Context, in *RunQueryAndWaitRequest, opts ...grpc.CallOption) (Dekart_RunQueryAndWaitClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[0], "/Dekart/RunQueryAndWait", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dekartRunQueryAndWaitClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -145,29 +372,29 @@ func (c *dekartClient) GetReportStream(ctx context.Context, in *ReportStreamRequ
 	return x, nil
 }
 
-type Dekart_GetReportStreamClient interface {
-	Recv() (*ReportStreamResponse, error)
+type Dekart_RunQueryAndWaitClient interface {
+	Recv() (*RunQueryAndWaitResponse, error)
 	grpc.ClientStream
 }
 
-type dekartGetReportStreamClient struct {
+type dekartRunQueryAndWaitClient struct {
 	grpc.ClientStream
 }
 
-func (x *dekartGetReportStreamClient) Recv() (*ReportStreamResponse, error) {
-	m := new(ReportStreamResponse)
+func (x *dekartRunQueryAndWaitClient) Recv() (*RunQueryAndWaitResponse, error) {
+	m := new(RunQueryAndWaitResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func (c *dekartClient) GetReportListStream(ctx context.Context, in *ReportListRequest, opts ...grpc.CallOption) (Dekart_GetReportListStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[1], "/Dekart/GetReportListStream", opts...)
+func (c *dekartClient) ExportResult(ctx context.Context, in *ExportResultRequest, opts ...grpc.CallOption) (Dekart_ExportResultClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[1], "/Dekart/ExportResult", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &dekartGetReportListStreamClient{stream}
+	x := &dekartExportResultClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -177,271 +404,1647 @@ func (c *dekartClient) GetReportListStream(ctx context.Context, in *ReportListRe
 	return x, nil
 }
 
-type Dekart_GetReportListStreamClient interface {
-	Recv() (*ReportListResponse, error)
+type Dekart_ExportResultClient interface {
+	Recv() (*ExportResultResponse, error)
 	grpc.ClientStream
 }
 
-type dekartGetReportListStreamClient struct {
+type dekartExportResultClient struct {
 	grpc.ClientStream
 }
 
-func (x *dekartGetReportListStreamClient) Recv() (*ReportListResponse, error) {
-	m := new(ReportListResponse)
+func (x *dekartExportResultClient) Recv() (*ExportResultResponse, error) {
+	m := new(ExportResultResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dekartClient) CompareResults(ctx context.Context, in *CompareResultsRequest, opts ...grpc.CallOption) (*CompareResultsResponse, error) {
+	out := new(CompareResultsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/CompareResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetResultLineage(ctx context.Context, in *GetResultLineageRequest, opts ...grpc.CallOption) (*GetResultLineageResponse, error) {
+	out := new(GetResultLineageResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetResultLineage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetResultFieldTypes(ctx context.Context, in *GetResultFieldTypesRequest, opts ...grpc.CallOption) (*GetResultFieldTypesResponse, error) {
+	out := new(GetResultFieldTypesResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetResultFieldTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetJobEvents(ctx context.Context, in *GetJobEventsRequest, opts ...grpc.CallOption) (*GetJobEventsResponse, error) {
+	out := new(GetJobEventsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetJobEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetQuerySchemaContext(ctx context.Context, in *GetQuerySchemaContextRequest, opts ...grpc.CallOption) (*GetQuerySchemaContextResponse, error) {
+	out := new(GetQuerySchemaContextResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetQuerySchemaContext", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetSchemaSnapshot(ctx context.Context, in *GetSchemaSnapshotRequest, opts ...grpc.CallOption) (*GetSchemaSnapshotResponse, error) {
+	out := new(GetSchemaSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetSchemaSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) RefreshSchemaSnapshot(ctx context.Context, in *RefreshSchemaSnapshotRequest, opts ...grpc.CallOption) (*RefreshSchemaSnapshotResponse, error) {
+	out := new(RefreshSchemaSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/RefreshSchemaSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) CreateDataset(ctx context.Context, in *CreateDatasetRequest, opts ...grpc.CallOption) (*CreateDatasetResponse, error) {
+	out := new(CreateDatasetResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/CreateDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateDataset(ctx context.Context, in *UpdateDatasetRequest, opts ...grpc.CallOption) (*UpdateDatasetResponse, error) {
+	out := new(UpdateDatasetResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) DeleteDataset(ctx context.Context, in *DeleteDatasetRequest, opts ...grpc.CallOption) (*DeleteDatasetResponse, error) {
+	out := new(DeleteDatasetResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/DeleteDataset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) ListDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (*ListDatasetsResponse, error) {
+	out := new(ListDatasetsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/ListDatasets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UploadFile(ctx context.Context, opts ...grpc.CallOption) (Dekart_UploadFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[2], "/Dekart/UploadFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dekartUploadFileClient{stream}
+	return x, nil
+}
+
+type Dekart_UploadFileClient interface {
+	Send(*UploadFileRequest) error
+	CloseAndRecv() (*UploadFileResponse, error)
+	grpc.ClientStream
+}
+
+type dekartUploadFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *dekartUploadFileClient) Send(m *UploadFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dekartUploadFileClient) CloseAndRecv() (*UploadFileResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadFileResponse)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return m, nil
+	return m, nil
+}
+
+func (c *dekartClient) RemoveFile(ctx context.Context, in *RemoveFileRequest, opts ...grpc.CallOption) (*RemoveFileResponse, error) {
+	out := new(RemoveFileResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/RemoveFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetEnv(ctx context.Context, in *GetEnvRequest, opts ...grpc.CallOption) (*GetEnvResponse, error) {
+	out := new(GetEnvResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetEnv", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetCurrentUser(ctx context.Context, in *GetCurrentUserRequest, opts ...grpc.CallOption) (*GetCurrentUserResponse, error) {
+	out := new(GetCurrentUserResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetCurrentUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) ListRoleAssignments(ctx context.Context, in *ListRoleAssignmentsRequest, opts ...grpc.CallOption) (*ListRoleAssignmentsResponse, error) {
+	out := new(ListRoleAssignmentsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/ListRoleAssignments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) SetRoleAssignment(ctx context.Context, in *SetRoleAssignmentRequest, opts ...grpc.CallOption) (*SetRoleAssignmentResponse, error) {
+	out := new(SetRoleAssignmentResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/SetRoleAssignment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) RemoveRoleAssignment(ctx context.Context, in *RemoveRoleAssignmentRequest, opts ...grpc.CallOption) (*RemoveRoleAssignmentResponse, error) {
+	out := new(RemoveRoleAssignmentResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/RemoveRoleAssignment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetResultLifecycle(ctx context.Context, in *GetResultLifecycleRequest, opts ...grpc.CallOption) (*GetResultLifecycleResponse, error) {
+	out := new(GetResultLifecycleResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetResultLifecycle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) ReconcileResults(ctx context.Context, in *ReconcileResultsRequest, opts ...grpc.CallOption) (*ReconcileResultsResponse, error) {
+	out := new(ReconcileResultsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/ReconcileResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) ReencryptColumns(ctx context.Context, in *ReencryptColumnsRequest, opts ...grpc.CallOption) (*ReencryptColumnsResponse, error) {
+	out := new(ReencryptColumnsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/ReencryptColumns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetLogSettings(ctx context.Context, in *GetLogSettingsRequest, opts ...grpc.CallOption) (*GetLogSettingsResponse, error) {
+	out := new(GetLogSettingsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetLogSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) UpdateLogSettings(ctx context.Context, in *UpdateLogSettingsRequest, opts ...grpc.CallOption) (*UpdateLogSettingsResponse, error) {
+	out := new(UpdateLogSettingsResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/UpdateLogSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetPermissionReport(ctx context.Context, in *GetPermissionReportRequest, opts ...grpc.CallOption) (*GetPermissionReportResponse, error) {
+	out := new(GetPermissionReportResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetPermissionReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetUsage(ctx context.Context, in *GetUsageRequest, opts ...grpc.CallOption) (*GetUsageResponse, error) {
+	out := new(GetUsageResponse)
+	err := c.cc.Invoke(ctx, "/Dekart/GetUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dekartClient) GetReportStream(ctx context.Context, in *ReportStreamRequest, opts ...grpc.CallOption) (Dekart_GetReportStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[3], "/Dekart/GetReportStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dekartGetReportStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dekart_GetReportStreamClient interface {
+	Recv() (*ReportStreamResponse, error)
+	grpc.ClientStream
+}
+
+type dekartGetReportStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *dekartGetReportStreamClient) Recv() (*ReportStreamResponse, error) {
+	m := new(ReportStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dekartClient) GetReportListStream(ctx context.Context, in *ReportListRequest, opts ...grpc.CallOption) (Dekart_GetReportListStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dekart_ServiceDesc.Streams[4], "/Dekart/GetReportListStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dekartGetReportListStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dekart_GetReportListStreamClient interface {
+	Recv() (*ReportListResponse, error)
+	grpc.ClientStream
+}
+
+type dekartGetReportListStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *dekartGetReportListStreamClient) Recv() (*ReportListResponse, error) {
+	m := new(ReportListResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DekartServer is the server API for Dekart service.
+// All implementations must embed UnimplementedDekartServer
+// for forward compatibility
+type DekartServer interface {
+	CreateReport(context.Context, *CreateReportRequest) (*CreateReportResponse, error)
+	ForkReport(context.Context, *ForkReportRequest) (*ForkReportResponse, error)
+	UpdateReport(context.Context, *UpdateReportRequest) (*UpdateReportResponse, error)
+	ArchiveReport(context.Context, *ArchiveReportRequest) (*ArchiveReportResponse, error)
+	ShareReport(context.Context, *ShareReportRequest) (*ShareReportResponse, error)
+	UpdateMapConfig(context.Context, *UpdateMapConfigRequest) (*UpdateMapConfigResponse, error)
+	UpdateReportVariables(context.Context, *UpdateReportVariablesRequest) (*UpdateReportVariablesResponse, error)
+	UpdateReportSettings(context.Context, *UpdateReportSettingsRequest) (*UpdateReportSettingsResponse, error)
+	UpdateReportFreshnessPolicy(context.Context, *UpdateReportFreshnessPolicyRequest) (*UpdateReportFreshnessPolicyResponse, error)
+	UpdateReportParameters(context.Context, *UpdateReportParametersRequest) (*UpdateReportParametersResponse, error)
+	SetReportParameterValues(context.Context, *SetReportParameterValuesRequest) (*SetReportParameterValuesResponse, error)
+	GetReportParameterOptions(context.Context, *GetReportParameterOptionsRequest) (*GetReportParameterOptionsResponse, error)
+	GetMapConfigHistory(context.Context, *GetMapConfigHistoryRequest) (*GetMapConfigHistoryResponse, error)
+	GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error)
+	ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error)
+	CreateQuery(context.Context, *CreateQueryRequest) (*CreateQueryResponse, error)
+	UpdateQuery(context.Context, *UpdateQueryRequest) (*UpdateQueryResponse, error)
+	UpdateQueryTitle(context.Context, *UpdateQueryTitleRequest) (*UpdateQueryTitleResponse, error)
+	UpdateQueryDependencies(context.Context, *UpdateQueryDependenciesRequest) (*UpdateQueryDependenciesResponse, error)
+	UpdateQueryParameterBindings(context.Context, *UpdateQueryParameterBindingsRequest) (*UpdateQueryParameterBindingsResponse, error)
+	RunQuery(context.Context, *RunQueryRequest) (*RunQueryResponse, error)
+	CancelQuery(context.Context, *CancelQueryRequest) (*CancelQueryResponse, error)
+	CancelReport(context.Context, *CancelReportRequest) (*CancelReportResponse, error)
+	RunAllQueries(context.Context, *RunAllQueriesRequest) (*RunAllQueriesResponse, error)
+	CancelBatch(context.Context, *CancelBatchRequest) (*CancelBatchResponse, error)
+	RemoveQuery(context.Context, *RemoveQueryRequest) (*RemoveQueryResponse, error)
+	RestoreQuery(context.Context, *RestoreQueryRequest) (*RestoreQueryResponse, error)
+	GetQuery(context.Context, *GetQueryRequest) (*GetQueryResponse, error)
+	GetExecutedQuery(context.Context, *GetExecutedQueryRequest) (*GetExecutedQueryResponse, error)
+	GetJobPlan(context.Context, *GetJobPlanRequest) (*GetJobPlanResponse, error)
+	RunQueryAndWait(*RunQueryAndWaitRequest, Dekart_RunQueryAndWaitServer) error
+	ExportResult(*ExportResultRequest, Dekart_ExportResultServer) error
+	CompareResults(context.Context, *CompareResultsRequest) (*CompareResultsResponse, error)
+	GetResultLineage(context.Context, *GetResultLineageRequest) (*GetResultLineageResponse, error)
+	GetResultFieldTypes(context.Context, *GetResultFieldTypesRequest) (*GetResultFieldTypesResponse, error)
+	GetJobEvents(context.Context, *GetJobEventsRequest) (*GetJobEventsResponse, error)
+	GetQuerySchemaContext(context.Context, *GetQuerySchemaContextRequest) (*GetQuerySchemaContextResponse, error)
+	GetSchemaSnapshot(context.Context, *GetSchemaSnapshotRequest) (*GetSchemaSnapshotResponse, error)
+	RefreshSchemaSnapshot(context.Context, *RefreshSchemaSnapshotRequest) (*RefreshSchemaSnapshotResponse, error)
+	CreateDataset(context.Context, *CreateDatasetRequest) (*CreateDatasetResponse, error)
+	UpdateDataset(context.Context, *UpdateDatasetRequest) (*UpdateDatasetResponse, error)
+	DeleteDataset(context.Context, *DeleteDatasetRequest) (*DeleteDatasetResponse, error)
+	ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error)
+	UploadFile(Dekart_UploadFileServer) error
+	RemoveFile(context.Context, *RemoveFileRequest) (*RemoveFileResponse, error)
+	GetEnv(context.Context, *GetEnvRequest) (*GetEnvResponse, error)
+	GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error)
+	ListRoleAssignments(context.Context, *ListRoleAssignmentsRequest) (*ListRoleAssignmentsResponse, error)
+	SetRoleAssignment(context.Context, *SetRoleAssignmentRequest) (*SetRoleAssignmentResponse, error)
+	RemoveRoleAssignment(context.Context, *RemoveRoleAssignmentRequest) (*RemoveRoleAssignmentResponse, error)
+	GetResultLifecycle(context.Context, *GetResultLifecycleRequest) (*GetResultLifecycleResponse, error)
+	ReconcileResults(context.Context, *ReconcileResultsRequest) (*ReconcileResultsResponse, error)
+	ReencryptColumns(context.Context, *ReencryptColumnsRequest) (*ReencryptColumnsResponse, error)
+	GetLogSettings(context.Context, *GetLogSettingsRequest) (*GetLogSettingsResponse, error)
+	UpdateLogSettings(context.Context, *UpdateLogSettingsRequest) (*UpdateLogSettingsResponse, error)
+	GetPermissionReport(context.Context, *GetPermissionReportRequest) (*GetPermissionReportResponse, error)
+	GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error)
+	GetReportStream(*ReportStreamRequest, Dekart_GetReportStreamServer) error
+	GetReportListStream(*ReportListRequest, Dekart_GetReportListStreamServer) error
+	mustEmbedUnimplementedDekartServer()
+}
+
+// UnimplementedDekartServer must be embedded to have forward compatible implementations.
+type UnimplementedDekartServer struct {
+}
+
+func (UnimplementedDekartServer) CreateReport(context.Context, *CreateReportRequest) (*CreateReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReport not implemented")
+}
+func (UnimplementedDekartServer) ForkReport(context.Context, *ForkReportRequest) (*ForkReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForkReport not implemented")
+}
+func (UnimplementedDekartServer) UpdateReport(context.Context, *UpdateReportRequest) (*UpdateReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReport not implemented")
+}
+func (UnimplementedDekartServer) ArchiveReport(context.Context, *ArchiveReportRequest) (*ArchiveReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveReport not implemented")
+}
+func (UnimplementedDekartServer) ShareReport(context.Context, *ShareReportRequest) (*ShareReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareReport not implemented")
+}
+func (UnimplementedDekartServer) UpdateMapConfig(context.Context, *UpdateMapConfigRequest) (*UpdateMapConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMapConfig not implemented")
+}
+func (UnimplementedDekartServer) UpdateReportVariables(context.Context, *UpdateReportVariablesRequest) (*UpdateReportVariablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReportVariables not implemented")
+}
+
+func (UnimplementedDekartServer) UpdateReportSettings(context.Context, *UpdateReportSettingsRequest) (*UpdateReportSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReportSettings not implemented")
+}
+
+func (UnimplementedDekartServer) UpdateReportFreshnessPolicy(context.Context, *UpdateReportFreshnessPolicyRequest) (*UpdateReportFreshnessPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReportFreshnessPolicy not implemented")
+}
+
+func (UnimplementedDekartServer) UpdateReportParameters(context.Context, *UpdateReportParametersRequest) (*UpdateReportParametersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateReportParameters not implemented")
+}
+
+func (UnimplementedDekartServer) SetReportParameterValues(context.Context, *SetReportParameterValuesRequest) (*SetReportParameterValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReportParameterValues not implemented")
+}
+
+func (UnimplementedDekartServer) GetReportParameterOptions(context.Context, *GetReportParameterOptionsRequest) (*GetReportParameterOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReportParameterOptions not implemented")
+}
+func (UnimplementedDekartServer) GetMapConfigHistory(context.Context, *GetMapConfigHistoryRequest) (*GetMapConfigHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMapConfigHistory not implemented")
+}
+func (UnimplementedDekartServer) GetReport(context.Context, *GetReportRequest) (*GetReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedDekartServer) ListReports(context.Context, *ListReportsRequest) (*ListReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedDekartServer) CreateQuery(context.Context, *CreateQueryRequest) (*CreateQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateQuery not implemented")
+}
+func (UnimplementedDekartServer) UpdateQuery(context.Context, *UpdateQueryRequest) (*UpdateQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuery not implemented")
+}
+func (UnimplementedDekartServer) UpdateQueryTitle(context.Context, *UpdateQueryTitleRequest) (*UpdateQueryTitleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQueryTitle not implemented")
+}
+func (UnimplementedDekartServer) UpdateQueryDependencies(context.Context, *UpdateQueryDependenciesRequest) (*UpdateQueryDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQueryDependencies not implemented")
+}
+
+func (UnimplementedDekartServer) UpdateQueryParameterBindings(context.Context, *UpdateQueryParameterBindingsRequest) (*UpdateQueryParameterBindingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateQueryParameterBindings not implemented")
+}
+func (UnimplementedDekartServer) RunQuery(context.Context, *RunQueryRequest) (*RunQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunQuery not implemented")
+}
+func (UnimplementedDekartServer) CancelQuery(context.Context, *CancelQueryRequest) (*CancelQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelQuery not implemented")
+}
+func (UnimplementedDekartServer) CancelReport(context.Context, *CancelReportRequest) (*CancelReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReport not implemented")
+}
+func (UnimplementedDekartServer) RunAllQueries(context.Context, *RunAllQueriesRequest) (*RunAllQueriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunAllQueries not implemented")
+}
+func (UnimplementedDekartServer) CancelBatch(context.Context, *CancelBatchRequest) (*CancelBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBatch not implemented")
+}
+func (UnimplementedDekartServer) RemoveQuery(context.Context, *RemoveQueryRequest) (*RemoveQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveQuery not implemented")
+}
+
+func (UnimplementedDekartServer) RestoreQuery(context.Context, *RestoreQueryRequest) (*RestoreQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreQuery not implemented")
+}
+func (UnimplementedDekartServer) GetQuery(context.Context, *GetQueryRequest) (*GetQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuery not implemented")
+}
+func (UnimplementedDekartServer) GetExecutedQuery(context.Context, *GetExecutedQueryRequest) (*GetExecutedQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExecutedQuery not implemented")
+}
+
+func (UnimplementedDekartServer) GetJobPlan(context.Context, *GetJobPlanRequest) (*GetJobPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobPlan not implemented")
+}
+func (UnimplementedDekartServer) RunQueryAndWait(*RunQueryAndWaitRequest, Dekart_RunQueryAndWaitServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunQueryAndWait not implemented")
+}
+func (UnimplementedDekartServer) ExportResult(*ExportResultRequest, Dekart_ExportResultServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportResult not implemented")
+}
+func (UnimplementedDekartServer) CompareResults(context.Context, *CompareResultsRequest) (*CompareResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareResults not implemented")
+}
+
+func (UnimplementedDekartServer) GetResultLineage(context.Context, *GetResultLineageRequest) (*GetResultLineageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultLineage not implemented")
+}
+
+func (UnimplementedDekartServer) GetResultFieldTypes(context.Context, *GetResultFieldTypesRequest) (*GetResultFieldTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultFieldTypes not implemented")
+}
+
+func (UnimplementedDekartServer) GetJobEvents(context.Context, *GetJobEventsRequest) (*GetJobEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobEvents not implemented")
+}
+func (UnimplementedDekartServer) GetQuerySchemaContext(context.Context, *GetQuerySchemaContextRequest) (*GetQuerySchemaContextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuerySchemaContext not implemented")
+}
+
+func (UnimplementedDekartServer) GetSchemaSnapshot(context.Context, *GetSchemaSnapshotRequest) (*GetSchemaSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchemaSnapshot not implemented")
+}
+
+func (UnimplementedDekartServer) RefreshSchemaSnapshot(context.Context, *RefreshSchemaSnapshotRequest) (*RefreshSchemaSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSchemaSnapshot not implemented")
+}
+func (UnimplementedDekartServer) CreateDataset(context.Context, *CreateDatasetRequest) (*CreateDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDataset not implemented")
+}
+func (UnimplementedDekartServer) UpdateDataset(context.Context, *UpdateDatasetRequest) (*UpdateDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDataset not implemented")
+}
+func (UnimplementedDekartServer) DeleteDataset(context.Context, *DeleteDatasetRequest) (*DeleteDatasetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDataset not implemented")
+}
+func (UnimplementedDekartServer) ListDatasets(context.Context, *ListDatasetsRequest) (*ListDatasetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDatasets not implemented")
+}
+
+func (UnimplementedDekartServer) UploadFile(Dekart_UploadFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedDekartServer) RemoveFile(context.Context, *RemoveFileRequest) (*RemoveFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFile not implemented")
+}
+func (UnimplementedDekartServer) GetEnv(context.Context, *GetEnvRequest) (*GetEnvResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEnv not implemented")
+}
+func (UnimplementedDekartServer) GetCurrentUser(context.Context, *GetCurrentUserRequest) (*GetCurrentUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentUser not implemented")
+}
+func (UnimplementedDekartServer) ListRoleAssignments(context.Context, *ListRoleAssignmentsRequest) (*ListRoleAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoleAssignments not implemented")
+}
+func (UnimplementedDekartServer) SetRoleAssignment(context.Context, *SetRoleAssignmentRequest) (*SetRoleAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoleAssignment not implemented")
+}
+func (UnimplementedDekartServer) RemoveRoleAssignment(context.Context, *RemoveRoleAssignmentRequest) (*RemoveRoleAssignmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveRoleAssignment not implemented")
+}
+func (UnimplementedDekartServer) GetResultLifecycle(context.Context, *GetResultLifecycleRequest) (*GetResultLifecycleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResultLifecycle not implemented")
+}
+func (UnimplementedDekartServer) ReconcileResults(context.Context, *ReconcileResultsRequest) (*ReconcileResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileResults not implemented")
+}
+func (UnimplementedDekartServer) ReencryptColumns(context.Context, *ReencryptColumnsRequest) (*ReencryptColumnsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReencryptColumns not implemented")
+}
+func (UnimplementedDekartServer) GetLogSettings(context.Context, *GetLogSettingsRequest) (*GetLogSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogSettings not implemented")
+}
+func (UnimplementedDekartServer) UpdateLogSettings(context.Context, *UpdateLogSettingsRequest) (*UpdateLogSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLogSettings not implemented")
+}
+func (UnimplementedDekartServer) GetPermissionReport(context.Context, *GetPermissionReportRequest) (*GetPermissionReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPermissionReport not implemented")
+}
+func (UnimplementedDekartServer) GetUsage(context.Context, *GetUsageRequest) (*GetUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsage not implemented")
+}
+func (UnimplementedDekartServer) GetReportStream(*ReportStreamRequest, Dekart_GetReportStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetReportStream not implemented")
+}
+func (UnimplementedDekartServer) GetReportListStream(*ReportListRequest, Dekart_GetReportListStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetReportListStream not implemented")
+}
+func (UnimplementedDekartServer) mustEmbedUnimplementedDekartServer() {}
+
+// UnsafeDekartServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DekartServer will
+// result in compilation errors.
+type UnsafeDekartServer interface {
+	mustEmbedUnimplementedDekartServer()
+}
+
+func RegisterDekartServer(s grpc.ServiceRegistrar, srv DekartServer) {
+	s.RegisterService(&Dekart_ServiceDesc, srv)
+}
+
+func _Dekart_CreateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CreateReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CreateReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CreateReport(ctx, req.(*CreateReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_ForkReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForkReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).ForkReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/ForkReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).ForkReport(ctx, req.(*ForkReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateReport(ctx, req.(*UpdateReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_ArchiveReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).ArchiveReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/ArchiveReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).ArchiveReport(ctx, req.(*ArchiveReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_ShareReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).ShareReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/ShareReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).ShareReport(ctx, req.(*ShareReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateMapConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMapConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateMapConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateMapConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateMapConfig(ctx, req.(*UpdateMapConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateReportVariables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReportVariablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateReportVariables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateReportVariables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateReportVariables(ctx, req.(*UpdateReportVariablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateReportSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReportSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateReportSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateReportSettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateReportSettings(ctx, req.(*UpdateReportSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateReportFreshnessPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReportFreshnessPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateReportFreshnessPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateReportFreshnessPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateReportFreshnessPolicy(ctx, req.(*UpdateReportFreshnessPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateReportParameters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateReportParametersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateReportParameters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateReportParameters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateReportParameters(ctx, req.(*UpdateReportParametersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_SetReportParameterValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReportParameterValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).SetReportParameterValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/SetReportParameterValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).SetReportParameterValues(ctx, req.(*SetReportParameterValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetReportParameterOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportParameterOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetReportParameterOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetReportParameterOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetReportParameterOptions(ctx, req.(*GetReportParameterOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetMapConfigHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMapConfigHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetMapConfigHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetMapConfigHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetMapConfigHistory(ctx, req.(*GetMapConfigHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).ListReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/ListReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).ListReports(ctx, req.(*ListReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_CreateQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CreateQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CreateQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CreateQuery(ctx, req.(*CreateQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateQuery(ctx, req.(*UpdateQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateQueryTitle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQueryTitleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateQueryTitle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateQueryTitle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateQueryTitle(ctx, req.(*UpdateQueryTitleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateQueryDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQueryDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateQueryDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateQueryDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateQueryDependencies(ctx, req.(*UpdateQueryDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateQueryParameterBindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateQueryParameterBindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateQueryParameterBindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateQueryParameterBindings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateQueryParameterBindings(ctx, req.(*UpdateQueryParameterBindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_RunQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).RunQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/RunQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).RunQuery(ctx, req.(*RunQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_CancelQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CancelQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CancelQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CancelQuery(ctx, req.(*CancelQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_CancelReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CancelReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CancelReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CancelReport(ctx, req.(*CancelReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_RunAllQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunAllQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).RunAllQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/RunAllQueries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).RunAllQueries(ctx, req.(*RunAllQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_CancelBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CancelBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CancelBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CancelBatch(ctx, req.(*CancelBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_RemoveQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).RemoveQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/RemoveQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).RemoveQuery(ctx, req.(*RemoveQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_RestoreQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).RestoreQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/RestoreQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).RestoreQuery(ctx, req.(*RestoreQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetQuery(ctx, req.(*GetQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetExecutedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetExecutedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetExecutedQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetExecutedQuery(ctx, req.(*GetExecutedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetJobPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetJobPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetJobPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetJobPlan(ctx, req.(*GetJobPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_RunQueryAndWait_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunQueryAndWaitRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DekartServer).RunQueryAndWait(m, &dekartRunQueryAndWaitServer{stream})
+}
+
+type Dekart_RunQueryAndWaitServer interface {
+	Send(*RunQueryAndWaitResponse) error
+	grpc.ServerStream
+}
+
+type dekartRunQueryAndWaitServer struct {
+	grpc.ServerStream
+}
+
+func (x *dekartRunQueryAndWaitServer) Send(m *RunQueryAndWaitResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Dekart_ExportResult_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportResultRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DekartServer).ExportResult(m, &dekartExportResultServer{stream})
+}
+
+type Dekart_ExportResultServer interface {
+	Send(*ExportResultResponse) error
+	grpc.ServerStream
+}
+
+type dekartExportResultServer struct {
+	grpc.ServerStream
+}
+
+func (x *dekartExportResultServer) Send(m *ExportResultResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Dekart_CompareResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CompareResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CompareResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CompareResults(ctx, req.(*CompareResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetResultLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultLineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetResultLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetResultLineage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetResultLineage(ctx, req.(*GetResultLineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetResultFieldTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultFieldTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetResultFieldTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetResultFieldTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetResultFieldTypes(ctx, req.(*GetResultFieldTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetJobEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetJobEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetJobEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetJobEvents(ctx, req.(*GetJobEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetQuerySchemaContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuerySchemaContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetQuerySchemaContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetQuerySchemaContext",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetQuerySchemaContext(ctx, req.(*GetQuerySchemaContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_GetSchemaSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetSchemaSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetSchemaSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetSchemaSnapshot(ctx, req.(*GetSchemaSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_RefreshSchemaSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSchemaSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).RefreshSchemaSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/RefreshSchemaSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).RefreshSchemaSnapshot(ctx, req.(*RefreshSchemaSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_CreateDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).CreateDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/CreateDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).CreateDataset(ctx, req.(*CreateDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_UpdateDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).UpdateDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/UpdateDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).UpdateDataset(ctx, req.(*UpdateDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_DeleteDataset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDatasetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).DeleteDataset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/DeleteDataset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).DeleteDataset(ctx, req.(*DeleteDatasetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dekart_ListDatasets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatasetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).ListDatasets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/ListDatasets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).ListDatasets(ctx, req.(*ListDatasetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// DekartServer is the server API for Dekart service.
-// All implementations must embed UnimplementedDekartServer
-// for forward compatibility
-type DekartServer interface {
-	CreateReport(context.Context, *CreateReportRequest) (*CreateReportResponse, error)
-	ForkReport(context.Context, *ForkReportRequest) (*ForkReportResponse, error)
-	UpdateReport(context.Context, *UpdateReportRequest) (*UpdateReportResponse, error)
-	ArchiveReport(context.Context, *ArchiveReportRequest) (*ArchiveReportResponse, error)
-	CreateQuery(context.Context, *CreateQueryRequest) (*CreateQueryResponse, error)
-	UpdateQuery(context.Context, *UpdateQueryRequest) (*UpdateQueryResponse, error)
-	RunQuery(context.Context, *RunQueryRequest) (*RunQueryResponse, error)
-	CancelQuery(context.Context, *CancelQueryRequest) (*CancelQueryResponse, error)
-	RemoveQuery(context.Context, *RemoveQueryRequest) (*RemoveQueryResponse, error)
-	GetEnv(context.Context, *GetEnvRequest) (*GetEnvResponse, error)
-	GetReportStream(*ReportStreamRequest, Dekart_GetReportStreamServer) error
-	GetReportListStream(*ReportListRequest, Dekart_GetReportListStreamServer) error
-	mustEmbedUnimplementedDekartServer()
+func _Dekart_UploadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DekartServer).UploadFile(&dekartUploadFileServer{stream})
 }
 
-// UnimplementedDekartServer must be embedded to have forward compatible implementations.
-type UnimplementedDekartServer struct {
+type Dekart_UploadFileServer interface {
+	SendAndClose(*UploadFileResponse) error
+	Recv() (*UploadFileRequest, error)
+	grpc.ServerStream
 }
 
-func (UnimplementedDekartServer) CreateReport(context.Context, *CreateReportRequest) (*CreateReportResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateReport not implemented")
-}
-func (UnimplementedDekartServer) ForkReport(context.Context, *ForkReportRequest) (*ForkReportResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ForkReport not implemented")
-}
-func (UnimplementedDekartServer) UpdateReport(context.Context, *UpdateReportRequest) (*UpdateReportResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateReport not implemented")
-}
-func (UnimplementedDekartServer) ArchiveReport(context.Context, *ArchiveReportRequest) (*ArchiveReportResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ArchiveReport not implemented")
-}
-func (UnimplementedDekartServer) CreateQuery(context.Context, *CreateQueryRequest) (*CreateQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateQuery not implemented")
-}
-func (UnimplementedDekartServer) UpdateQuery(context.Context, *UpdateQueryRequest) (*UpdateQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuery not implemented")
-}
-func (UnimplementedDekartServer) RunQuery(context.Context, *RunQueryRequest) (*RunQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RunQuery not implemented")
-}
-func (UnimplementedDekartServer) CancelQuery(context.Context, *CancelQueryRequest) (*CancelQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CancelQuery not implemented")
-}
-func (UnimplementedDekartServer) RemoveQuery(context.Context, *RemoveQueryRequest) (*RemoveQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveQuery not implemented")
+type dekartUploadFileServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedDekartServer) GetEnv(context.Context, *GetEnvRequest) (*GetEnvResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetEnv not implemented")
+
+func (x *dekartUploadFileServer) SendAndClose(m *UploadFileResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedDekartServer) GetReportStream(*ReportStreamRequest, Dekart_GetReportStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetReportStream not implemented")
+
+func (x *dekartUploadFileServer) Recv() (*UploadFileRequest, error) {
+	m := new(UploadFileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedDekartServer) GetReportListStream(*ReportListRequest, Dekart_GetReportListStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetReportListStream not implemented")
+
+func _Dekart_RemoveFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).RemoveFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/RemoveFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).RemoveFile(ctx, req.(*RemoveFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDekartServer) mustEmbedUnimplementedDekartServer() {}
 
-// UnsafeDekartServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DekartServer will
-// result in compilation errors.
-type UnsafeDekartServer interface {
-	mustEmbedUnimplementedDekartServer()
+func _Dekart_GetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetEnv(ctx, req.(*GetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDekartServer(s grpc.ServiceRegistrar, srv DekartServer) {
-	s.RegisterService(&Dekart_ServiceDesc, srv)
+func _Dekart_GetCurrentUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DekartServer).GetCurrentUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Dekart/GetCurrentUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DekartServer).GetCurrentUser(ctx, req.(*GetCurrentUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_CreateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateReportRequest)
+func _Dekart_ListRoleAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoleAssignmentsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).CreateReport(ctx, in)
+		return srv.(DekartServer).ListRoleAssignments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/CreateReport",
+		FullMethod: "/Dekart/ListRoleAssignments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).CreateReport(ctx, req.(*CreateReportRequest))
+		return srv.(DekartServer).ListRoleAssignments(ctx, req.(*ListRoleAssignmentsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_ForkReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForkReportRequest)
+func _Dekart_SetRoleAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleAssignmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).ForkReport(ctx, in)
+		return srv.(DekartServer).SetRoleAssignment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/ForkReport",
+		FullMethod: "/Dekart/SetRoleAssignment",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).ForkReport(ctx, req.(*ForkReportRequest))
+		return srv.(DekartServer).SetRoleAssignment(ctx, req.(*SetRoleAssignmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_UpdateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateReportRequest)
+func _Dekart_RemoveRoleAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveRoleAssignmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).UpdateReport(ctx, in)
+		return srv.(DekartServer).RemoveRoleAssignment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/UpdateReport",
+		FullMethod: "/Dekart/RemoveRoleAssignment",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).UpdateReport(ctx, req.(*UpdateReportRequest))
+		return srv.(DekartServer).RemoveRoleAssignment(ctx, req.(*RemoveRoleAssignmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_ArchiveReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ArchiveReportRequest)
+func _Dekart_GetResultLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultLifecycleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).ArchiveReport(ctx, in)
+		return srv.(DekartServer).GetResultLifecycle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/ArchiveReport",
+		FullMethod: "/Dekart/GetResultLifecycle",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).ArchiveReport(ctx, req.(*ArchiveReportRequest))
+		return srv.(DekartServer).GetResultLifecycle(ctx, req.(*GetResultLifecycleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_CreateQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateQueryRequest)
+func _Dekart_ReconcileResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileResultsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).CreateQuery(ctx, in)
+		return srv.(DekartServer).ReconcileResults(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/CreateQuery",
+		FullMethod: "/Dekart/ReconcileResults",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).CreateQuery(ctx, req.(*CreateQueryRequest))
+		return srv.(DekartServer).ReconcileResults(ctx, req.(*ReconcileResultsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_UpdateQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateQueryRequest)
+func _Dekart_ReencryptColumns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReencryptColumnsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).UpdateQuery(ctx, in)
+		return srv.(DekartServer).ReencryptColumns(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/UpdateQuery",
+		FullMethod: "/Dekart/ReencryptColumns",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).UpdateQuery(ctx, req.(*UpdateQueryRequest))
+		return srv.(DekartServer).ReencryptColumns(ctx, req.(*ReencryptColumnsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_RunQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RunQueryRequest)
+func _Dekart_GetLogSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogSettingsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).RunQuery(ctx, in)
+		return srv.(DekartServer).GetLogSettings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/RunQuery",
+		FullMethod: "/Dekart/GetLogSettings",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).RunQuery(ctx, req.(*RunQueryRequest))
+		return srv.(DekartServer).GetLogSettings(ctx, req.(*GetLogSettingsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_CancelQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CancelQueryRequest)
+func _Dekart_UpdateLogSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLogSettingsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).CancelQuery(ctx, in)
+		return srv.(DekartServer).UpdateLogSettings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/CancelQuery",
+		FullMethod: "/Dekart/UpdateLogSettings",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).CancelQuery(ctx, req.(*CancelQueryRequest))
+		return srv.(DekartServer).UpdateLogSettings(ctx, req.(*UpdateLogSettingsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_RemoveQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveQueryRequest)
+func _Dekart_GetPermissionReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPermissionReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).RemoveQuery(ctx, in)
+		return srv.(DekartServer).GetPermissionReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/RemoveQuery",
+		FullMethod: "/Dekart/GetPermissionReport",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).RemoveQuery(ctx, req.(*RemoveQueryRequest))
+		return srv.(DekartServer).GetPermissionReport(ctx, req.(*GetPermissionReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Dekart_GetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetEnvRequest)
+func _Dekart_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DekartServer).GetEnv(ctx, in)
+		return srv.(DekartServer).GetUsage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Dekart/GetEnv",
+		FullMethod: "/Dekart/GetUsage",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DekartServer).GetEnv(ctx, req.(*GetEnvRequest))
+		return srv.(DekartServer).GetUsage(ctx, req.(*GetUsageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -511,6 +2114,50 @@ var Dekart_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ArchiveReport",
 			Handler:    _Dekart_ArchiveReport_Handler,
 		},
+		{
+			MethodName: "ShareReport",
+			Handler:    _Dekart_ShareReport_Handler,
+		},
+		{
+			MethodName: "UpdateMapConfig",
+			Handler:    _Dekart_UpdateMapConfig_Handler,
+		},
+		{
+			MethodName: "UpdateReportVariables",
+			Handler:    _Dekart_UpdateReportVariables_Handler,
+		},
+		{
+			MethodName: "UpdateReportSettings",
+			Handler:    _Dekart_UpdateReportSettings_Handler,
+		},
+		{
+			MethodName: "UpdateReportFreshnessPolicy",
+			Handler:    _Dekart_UpdateReportFreshnessPolicy_Handler,
+		},
+		{
+			MethodName: "UpdateReportParameters",
+			Handler:    _Dekart_UpdateReportParameters_Handler,
+		},
+		{
+			MethodName: "SetReportParameterValues",
+			Handler:    _Dekart_SetReportParameterValues_Handler,
+		},
+		{
+			MethodName: "GetReportParameterOptions",
+			Handler:    _Dekart_GetReportParameterOptions_Handler,
+		},
+		{
+			MethodName: "GetMapConfigHistory",
+			Handler:    _Dekart_GetMapConfigHistory_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _Dekart_GetReport_Handler,
+		},
+		{
+			MethodName: "ListReports",
+			Handler:    _Dekart_ListReports_Handler,
+		},
 		{
 			MethodName: "CreateQuery",
 			Handler:    _Dekart_CreateQuery_Handler,
@@ -519,6 +2166,18 @@ var Dekart_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateQuery",
 			Handler:    _Dekart_UpdateQuery_Handler,
 		},
+		{
+			MethodName: "UpdateQueryTitle",
+			Handler:    _Dekart_UpdateQueryTitle_Handler,
+		},
+		{
+			MethodName: "UpdateQueryDependencies",
+			Handler:    _Dekart_UpdateQueryDependencies_Handler,
+		},
+		{
+			MethodName: "UpdateQueryParameterBindings",
+			Handler:    _Dekart_UpdateQueryParameterBindings_Handler,
+		},
 		{
 			MethodName: "RunQuery",
 			Handler:    _Dekart_RunQuery_Handler,
@@ -527,16 +2186,151 @@ var Dekart_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelQuery",
 			Handler:    _Dekart_CancelQuery_Handler,
 		},
+		{
+			MethodName: "CancelReport",
+			Handler:    _Dekart_CancelReport_Handler,
+		},
+		{
+			MethodName: "RunAllQueries",
+			Handler:    _Dekart_RunAllQueries_Handler,
+		},
+		{
+			MethodName: "CancelBatch",
+			Handler:    _Dekart_CancelBatch_Handler,
+		},
 		{
 			MethodName: "RemoveQuery",
 			Handler:    _Dekart_RemoveQuery_Handler,
 		},
+		{
+			MethodName: "RestoreQuery",
+			Handler:    _Dekart_RestoreQuery_Handler,
+		},
+		{
+			MethodName: "GetQuery",
+			Handler:    _Dekart_GetQuery_Handler,
+		},
+		{
+			MethodName: "GetExecutedQuery",
+			Handler:    _Dekart_GetExecutedQuery_Handler,
+		},
+		{
+			MethodName: "GetJobPlan",
+			Handler:    _Dekart_GetJobPlan_Handler,
+		},
+		{
+			MethodName: "CompareResults",
+			Handler:    _Dekart_CompareResults_Handler,
+		},
+		{
+			MethodName: "GetResultLineage",
+			Handler:    _Dekart_GetResultLineage_Handler,
+		},
+		{
+			MethodName: "GetResultFieldTypes",
+			Handler:    _Dekart_GetResultFieldTypes_Handler,
+		},
+		{
+			MethodName: "GetJobEvents",
+			Handler:    _Dekart_GetJobEvents_Handler,
+		},
+		{
+			MethodName: "GetQuerySchemaContext",
+			Handler:    _Dekart_GetQuerySchemaContext_Handler,
+		},
+		{
+			MethodName: "GetSchemaSnapshot",
+			Handler:    _Dekart_GetSchemaSnapshot_Handler,
+		},
+		{
+			MethodName: "RefreshSchemaSnapshot",
+			Handler:    _Dekart_RefreshSchemaSnapshot_Handler,
+		},
+		{
+			MethodName: "CreateDataset",
+			Handler:    _Dekart_CreateDataset_Handler,
+		},
+		{
+			MethodName: "UpdateDataset",
+			Handler:    _Dekart_UpdateDataset_Handler,
+		},
+		{
+			MethodName: "DeleteDataset",
+			Handler:    _Dekart_DeleteDataset_Handler,
+		},
+		{
+			MethodName: "ListDatasets",
+			Handler:    _Dekart_ListDatasets_Handler,
+		},
+		{
+			MethodName: "RemoveFile",
+			Handler:    _Dekart_RemoveFile_Handler,
+		},
 		{
 			MethodName: "GetEnv",
 			Handler:    _Dekart_GetEnv_Handler,
 		},
+		{
+			MethodName: "GetCurrentUser",
+			Handler:    _Dekart_GetCurrentUser_Handler,
+		},
+		{
+			MethodName: "ListRoleAssignments",
+			Handler:    _Dekart_ListRoleAssignments_Handler,
+		},
+		{
+			MethodName: "SetRoleAssignment",
+			Handler:    _Dekart_SetRoleAssignment_Handler,
+		},
+		{
+			MethodName: "RemoveRoleAssignment",
+			Handler:    _Dekart_RemoveRoleAssignment_Handler,
+		},
+		{
+			MethodName: "GetResultLifecycle",
+			Handler:    _Dekart_GetResultLifecycle_Handler,
+		},
+		{
+			MethodName: "ReconcileResults",
+			Handler:    _Dekart_ReconcileResults_Handler,
+		},
+		{
+			MethodName: "ReencryptColumns",
+			Handler:    _Dekart_ReencryptColumns_Handler,
+		},
+		{
+			MethodName: "GetLogSettings",
+			Handler:    _Dekart_GetLogSettings_Handler,
+		},
+		{
+			MethodName: "UpdateLogSettings",
+			Handler:    _Dekart_UpdateLogSettings_Handler,
+		},
+		{
+			MethodName: "GetPermissionReport",
+			Handler:    _Dekart_GetPermissionReport_Handler,
+		},
+		{
+			MethodName: "GetUsage",
+			Handler:    _Dekart_GetUsage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunQueryAndWait",
+			Handler:       _Dekart_RunQueryAndWait_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportResult",
+			Handler:       _Dekart_ExportResult_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadFile",
+			Handler:       _Dekart_UploadFile_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "GetReportStream",
 			Handler:       _Dekart_GetReportStream_Handler,
@@ -7,6 +7,15 @@ export class StreamOptions extends jspb.Message {
   getSequence(): number;
   setSequence(value: number): void;
 
+  getIncremental(): boolean;
+  setIncremental(value: boolean): void;
+
+  getDeletedQueryId(): string;
+  setDeletedQueryId(value: string): void;
+
+  getRestoredQueryId(): string;
+  setRestoredQueryId(value: string): void;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): StreamOptions.AsObject;
   static toObject(includeInstance: boolean, msg: StreamOptions): StreamOptions.AsObject;
@@ -20,6 +29,9 @@ export class StreamOptions extends jspb.Message {
 export namespace StreamOptions {
   export type AsObject = {
     sequence: number,
+    incremental: boolean,
+    deletedQueryId: string,
+    restoredQueryId: string,
   }
 }
 
@@ -88,274 +100,3900 @@ export namespace GetEnvResponse {
       TYPE_MAPBOX_TOKEN: 1;
       TYPE_UX_DATA_DOCUMENTATION: 2;
       TYPE_UX_HOMEPAGE: 3;
+      TYPE_READ_ONLY: 4;
     }
 
     export const Type: TypeMap;
   }
 }
 
-export class ArchiveReportRequest extends jspb.Message {
-  getReportId(): string;
-  setReportId(value: string): void;
+export class GetCurrentUserRequest extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetCurrentUserRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetCurrentUserRequest): GetCurrentUserRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetCurrentUserRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetCurrentUserRequest;
+  static deserializeBinaryFromReader(message: GetCurrentUserRequest, reader: jspb.BinaryReader): GetCurrentUserRequest;
+}
 
-  getArchive(): boolean;
-  setArchive(value: boolean): void;
+export namespace GetCurrentUserRequest {
+  export type AsObject = {
+  }
+}
+
+export class GetCurrentUserResponse extends jspb.Message {
+  getEmail(): string;
+  setEmail(value: string): void;
+
+  getRole(): RoleMap[keyof RoleMap];
+  setRole(value: RoleMap[keyof RoleMap]): void;
+
+  hasQuota(): boolean;
+  clearQuota(): void;
+  getQuota(): UsageQuota | undefined;
+  setQuota(value?: UsageQuota): void;
 
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): ArchiveReportRequest.AsObject;
-  static toObject(includeInstance: boolean, msg: ArchiveReportRequest): ArchiveReportRequest.AsObject;
+  toObject(includeInstance?: boolean): GetCurrentUserResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetCurrentUserResponse): GetCurrentUserResponse.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: ArchiveReportRequest, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): ArchiveReportRequest;
-  static deserializeBinaryFromReader(message: ArchiveReportRequest, reader: jspb.BinaryReader): ArchiveReportRequest;
+  static serializeBinaryToWriter(message: GetCurrentUserResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetCurrentUserResponse;
+  static deserializeBinaryFromReader(message: GetCurrentUserResponse, reader: jspb.BinaryReader): GetCurrentUserResponse;
 }
 
-export namespace ArchiveReportRequest {
+export namespace GetCurrentUserResponse {
   export type AsObject = {
-    reportId: string,
-    archive: boolean,
+    email: string,
+    role: RoleMap[keyof RoleMap],
+    quota?: UsageQuota.AsObject,
   }
 }
 
-export class ArchiveReportResponse extends jspb.Message {
+export class RoleAssignment extends jspb.Message {
+  getEmailPattern(): string;
+  setEmailPattern(value: string): void;
+
+  getRole(): RoleMap[keyof RoleMap];
+  setRole(value: RoleMap[keyof RoleMap]): void;
+
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): ArchiveReportResponse.AsObject;
-  static toObject(includeInstance: boolean, msg: ArchiveReportResponse): ArchiveReportResponse.AsObject;
+  toObject(includeInstance?: boolean): RoleAssignment.AsObject;
+  static toObject(includeInstance: boolean, msg: RoleAssignment): RoleAssignment.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: ArchiveReportResponse, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): ArchiveReportResponse;
-  static deserializeBinaryFromReader(message: ArchiveReportResponse, reader: jspb.BinaryReader): ArchiveReportResponse;
+  static serializeBinaryToWriter(message: RoleAssignment, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RoleAssignment;
+  static deserializeBinaryFromReader(message: RoleAssignment, reader: jspb.BinaryReader): RoleAssignment;
 }
 
-export namespace ArchiveReportResponse {
+export namespace RoleAssignment {
   export type AsObject = {
+    emailPattern: string,
+    role: RoleMap[keyof RoleMap],
   }
 }
 
-export class ReportListRequest extends jspb.Message {
-  hasStreamOptions(): boolean;
-  clearStreamOptions(): void;
-  getStreamOptions(): StreamOptions | undefined;
-  setStreamOptions(value?: StreamOptions): void;
+export class ListRoleAssignmentsRequest extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ListRoleAssignmentsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ListRoleAssignmentsRequest): ListRoleAssignmentsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ListRoleAssignmentsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ListRoleAssignmentsRequest;
+  static deserializeBinaryFromReader(message: ListRoleAssignmentsRequest, reader: jspb.BinaryReader): ListRoleAssignmentsRequest;
+}
+
+export namespace ListRoleAssignmentsRequest {
+  export type AsObject = {
+  }
+}
+
+export class ListRoleAssignmentsResponse extends jspb.Message {
+  clearRoleAssignmentsList(): void;
+  getRoleAssignmentsList(): Array<RoleAssignment>;
+  setRoleAssignmentsList(value: Array<RoleAssignment>): void;
+  addRoleAssignments(value?: RoleAssignment, index?: number): RoleAssignment;
 
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): ReportListRequest.AsObject;
-  static toObject(includeInstance: boolean, msg: ReportListRequest): ReportListRequest.AsObject;
+  toObject(includeInstance?: boolean): ListRoleAssignmentsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ListRoleAssignmentsResponse): ListRoleAssignmentsResponse.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: ReportListRequest, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): ReportListRequest;
-  static deserializeBinaryFromReader(message: ReportListRequest, reader: jspb.BinaryReader): ReportListRequest;
+  static serializeBinaryToWriter(message: ListRoleAssignmentsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ListRoleAssignmentsResponse;
+  static deserializeBinaryFromReader(message: ListRoleAssignmentsResponse, reader: jspb.BinaryReader): ListRoleAssignmentsResponse;
 }
 
-export namespace ReportListRequest {
+export namespace ListRoleAssignmentsResponse {
   export type AsObject = {
-    streamOptions?: StreamOptions.AsObject,
+    roleAssignmentsList: Array<RoleAssignment.AsObject>,
   }
 }
 
-export class ReportListResponse extends jspb.Message {
-  clearReportsList(): void;
-  getReportsList(): Array<Report>;
-  setReportsList(value: Array<Report>): void;
-  addReports(value?: Report, index?: number): Report;
+export class SetRoleAssignmentRequest extends jspb.Message {
+  hasRoleAssignment(): boolean;
+  clearRoleAssignment(): void;
+  getRoleAssignment(): RoleAssignment | undefined;
+  setRoleAssignment(value?: RoleAssignment): void;
 
-  hasStreamOptions(): boolean;
-  clearStreamOptions(): void;
-  getStreamOptions(): StreamOptions | undefined;
-  setStreamOptions(value?: StreamOptions): void;
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): SetRoleAssignmentRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: SetRoleAssignmentRequest): SetRoleAssignmentRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: SetRoleAssignmentRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SetRoleAssignmentRequest;
+  static deserializeBinaryFromReader(message: SetRoleAssignmentRequest, reader: jspb.BinaryReader): SetRoleAssignmentRequest;
+}
+
+export namespace SetRoleAssignmentRequest {
+  export type AsObject = {
+    roleAssignment?: RoleAssignment.AsObject,
+  }
+}
 
+export class SetRoleAssignmentResponse extends jspb.Message {
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): ReportListResponse.AsObject;
-  static toObject(includeInstance: boolean, msg: ReportListResponse): ReportListResponse.AsObject;
+  toObject(includeInstance?: boolean): SetRoleAssignmentResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: SetRoleAssignmentResponse): SetRoleAssignmentResponse.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: ReportListResponse, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): ReportListResponse;
-  static deserializeBinaryFromReader(message: ReportListResponse, reader: jspb.BinaryReader): ReportListResponse;
+  static serializeBinaryToWriter(message: SetRoleAssignmentResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SetRoleAssignmentResponse;
+  static deserializeBinaryFromReader(message: SetRoleAssignmentResponse, reader: jspb.BinaryReader): SetRoleAssignmentResponse;
 }
 
-export namespace ReportListResponse {
+export namespace SetRoleAssignmentResponse {
   export type AsObject = {
-    reportsList: Array<Report.AsObject>,
-    streamOptions?: StreamOptions.AsObject,
   }
 }
 
-export class Report extends jspb.Message {
-  getId(): string;
-  setId(value: string): void;
+export class RemoveRoleAssignmentRequest extends jspb.Message {
+  getEmailPattern(): string;
+  setEmailPattern(value: string): void;
 
-  getMapConfig(): string;
-  setMapConfig(value: string): void;
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RemoveRoleAssignmentRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RemoveRoleAssignmentRequest): RemoveRoleAssignmentRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RemoveRoleAssignmentRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RemoveRoleAssignmentRequest;
+  static deserializeBinaryFromReader(message: RemoveRoleAssignmentRequest, reader: jspb.BinaryReader): RemoveRoleAssignmentRequest;
+}
 
-  getTitle(): string;
-  setTitle(value: string): void;
+export namespace RemoveRoleAssignmentRequest {
+  export type AsObject = {
+    emailPattern: string,
+  }
+}
 
-  getArchived(): boolean;
-  setArchived(value: boolean): void;
+export class RemoveRoleAssignmentResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RemoveRoleAssignmentResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RemoveRoleAssignmentResponse): RemoveRoleAssignmentResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RemoveRoleAssignmentResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RemoveRoleAssignmentResponse;
+  static deserializeBinaryFromReader(message: RemoveRoleAssignmentResponse, reader: jspb.BinaryReader): RemoveRoleAssignmentResponse;
+}
 
-  getCanWrite(): boolean;
-  setCanWrite(value: boolean): void;
+export namespace RemoveRoleAssignmentResponse {
+  export type AsObject = {
+  }
+}
+
+export class GetResultLifecycleRequest extends jspb.Message {
+  getOlderThanDays(): number;
+  setOlderThanDays(value: number): void;
 
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): Report.AsObject;
-  static toObject(includeInstance: boolean, msg: Report): Report.AsObject;
+  toObject(includeInstance?: boolean): GetResultLifecycleRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetResultLifecycleRequest): GetResultLifecycleRequest.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: Report, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): Report;
-  static deserializeBinaryFromReader(message: Report, reader: jspb.BinaryReader): Report;
+  static serializeBinaryToWriter(message: GetResultLifecycleRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetResultLifecycleRequest;
+  static deserializeBinaryFromReader(message: GetResultLifecycleRequest, reader: jspb.BinaryReader): GetResultLifecycleRequest;
 }
 
-export namespace Report {
+export namespace GetResultLifecycleRequest {
   export type AsObject = {
-    id: string,
-    mapConfig: string,
-    title: string,
-    archived: boolean,
-    canWrite: boolean,
+    olderThanDays: number,
   }
 }
 
-export class Query extends jspb.Message {
-  getId(): string;
-  setId(value: string): void;
+export class GetResultLifecycleResponse extends jspb.Message {
+  getStoragePrefix(): string;
+  setStoragePrefix(value: string): void;
 
-  getReportId(): string;
-  setReportId(value: string): void;
+  getDateLayout(): string;
+  setDateLayout(value: string): void;
 
-  getQueryText(): string;
-  setQueryText(value: string): void;
+  getAffectedCount(): number;
+  setAffectedCount(value: number): void;
 
-  getJobStatus(): Query.JobStatusMap[keyof Query.JobStatusMap];
-  setJobStatus(value: Query.JobStatusMap[keyof Query.JobStatusMap]): void;
+  getAffectedCurrentCount(): number;
+  setAffectedCurrentCount(value: number): void;
 
-  getJobResultId(): string;
-  setJobResultId(value: string): void;
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetResultLifecycleResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetResultLifecycleResponse): GetResultLifecycleResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetResultLifecycleResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetResultLifecycleResponse;
+  static deserializeBinaryFromReader(message: GetResultLifecycleResponse, reader: jspb.BinaryReader): GetResultLifecycleResponse;
+}
 
-  getJobError(): string;
-  setJobError(value: string): void;
+export namespace GetResultLifecycleResponse {
+  export type AsObject = {
+    storagePrefix: string,
+    dateLayout: string,
+    affectedCount: number,
+    affectedCurrentCount: number,
+  }
+}
+
+export class ReconcileResultsRequest extends jspb.Message {
+  getLimit(): number;
+  setLimit(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReconcileResultsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ReconcileResultsRequest): ReconcileResultsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReconcileResultsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReconcileResultsRequest;
+  static deserializeBinaryFromReader(message: ReconcileResultsRequest, reader: jspb.BinaryReader): ReconcileResultsRequest;
+}
+
+export namespace ReconcileResultsRequest {
+  export type AsObject = {
+    limit: number,
+  }
+}
+
+export class ReconcileResultsResponse extends jspb.Message {
+  getCheckedCount(): number;
+  setCheckedCount(value: number): void;
+
+  getExpiredCount(): number;
+  setExpiredCount(value: number): void;
+
+  getErrorCount(): number;
+  setErrorCount(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReconcileResultsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ReconcileResultsResponse): ReconcileResultsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReconcileResultsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReconcileResultsResponse;
+  static deserializeBinaryFromReader(message: ReconcileResultsResponse, reader: jspb.BinaryReader): ReconcileResultsResponse;
+}
+
+export namespace ReconcileResultsResponse {
+  export type AsObject = {
+    checkedCount: number,
+    expiredCount: number,
+    errorCount: number,
+  }
+}
+
+export class ReencryptColumnsRequest extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReencryptColumnsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ReencryptColumnsRequest): ReencryptColumnsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReencryptColumnsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReencryptColumnsRequest;
+  static deserializeBinaryFromReader(message: ReencryptColumnsRequest, reader: jspb.BinaryReader): ReencryptColumnsRequest;
+}
+
+export namespace ReencryptColumnsRequest {
+  export type AsObject = {
+  }
+}
+
+export class ReencryptColumnsResponse extends jspb.Message {
+  getPrimaryKeyId(): string;
+  setPrimaryKeyId(value: string): void;
+
+  getCheckedCount(): number;
+  setCheckedCount(value: number): void;
+
+  getReencryptedCount(): number;
+  setReencryptedCount(value: number): void;
+
+  getFailedCount(): number;
+  setFailedCount(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReencryptColumnsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ReencryptColumnsResponse): ReencryptColumnsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReencryptColumnsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReencryptColumnsResponse;
+  static deserializeBinaryFromReader(message: ReencryptColumnsResponse, reader: jspb.BinaryReader): ReencryptColumnsResponse;
+}
+
+export namespace ReencryptColumnsResponse {
+  export type AsObject = {
+    primaryKeyId: string,
+    checkedCount: number,
+    reencryptedCount: number,
+    failedCount: number,
+  }
+}
+
+export class LogSettings extends jspb.Message {
+  getInfoSampleEvery(): number;
+  setInfoSampleEvery(value: number): void;
+
+  getSlowQueryWaitMs(): number;
+  setSlowQueryWaitMs(value: number): void;
+
+  getSlowQueryTotalMs(): number;
+  setSlowQueryTotalMs(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): LogSettings.AsObject;
+  static toObject(includeInstance: boolean, msg: LogSettings): LogSettings.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: LogSettings, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): LogSettings;
+  static deserializeBinaryFromReader(message: LogSettings, reader: jspb.BinaryReader): LogSettings;
+}
+
+export namespace LogSettings {
+  export type AsObject = {
+    infoSampleEvery: number,
+    slowQueryWaitMs: number,
+    slowQueryTotalMs: number,
+  }
+}
+
+export class GetLogSettingsRequest extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetLogSettingsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetLogSettingsRequest): GetLogSettingsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetLogSettingsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetLogSettingsRequest;
+  static deserializeBinaryFromReader(message: GetLogSettingsRequest, reader: jspb.BinaryReader): GetLogSettingsRequest;
+}
+
+export namespace GetLogSettingsRequest {
+  export type AsObject = {
+  }
+}
+
+export class GetLogSettingsResponse extends jspb.Message {
+  hasSettings(): boolean;
+  clearSettings(): void;
+  getSettings(): LogSettings | undefined;
+  setSettings(value?: LogSettings): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetLogSettingsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetLogSettingsResponse): GetLogSettingsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetLogSettingsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetLogSettingsResponse;
+  static deserializeBinaryFromReader(message: GetLogSettingsResponse, reader: jspb.BinaryReader): GetLogSettingsResponse;
+}
+
+export namespace GetLogSettingsResponse {
+  export type AsObject = {
+    settings?: LogSettings.AsObject,
+  }
+}
+
+export class UpdateLogSettingsRequest extends jspb.Message {
+  hasSettings(): boolean;
+  clearSettings(): void;
+  getSettings(): LogSettings | undefined;
+  setSettings(value?: LogSettings): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateLogSettingsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateLogSettingsRequest): UpdateLogSettingsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateLogSettingsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateLogSettingsRequest;
+  static deserializeBinaryFromReader(message: UpdateLogSettingsRequest, reader: jspb.BinaryReader): UpdateLogSettingsRequest;
+}
+
+export namespace UpdateLogSettingsRequest {
+  export type AsObject = {
+    settings?: LogSettings.AsObject,
+  }
+}
+
+export class UpdateLogSettingsResponse extends jspb.Message {
+  hasSettings(): boolean;
+  clearSettings(): void;
+  getSettings(): LogSettings | undefined;
+  setSettings(value?: LogSettings): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateLogSettingsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateLogSettingsResponse): UpdateLogSettingsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateLogSettingsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateLogSettingsResponse;
+  static deserializeBinaryFromReader(message: UpdateLogSettingsResponse, reader: jspb.BinaryReader): UpdateLogSettingsResponse;
+}
+
+export namespace UpdateLogSettingsResponse {
+  export type AsObject = {
+    settings?: LogSettings.AsObject,
+  }
+}
+
+export class GetPermissionReportRequest extends jspb.Message {
+  getRefresh(): boolean;
+  setRefresh(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetPermissionReportRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetPermissionReportRequest): GetPermissionReportRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetPermissionReportRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetPermissionReportRequest;
+  static deserializeBinaryFromReader(message: GetPermissionReportRequest, reader: jspb.BinaryReader): GetPermissionReportRequest;
+}
+
+export namespace GetPermissionReportRequest {
+  export type AsObject = {
+    refresh: boolean,
+  }
+}
+
+export class PermissionCheck extends jspb.Message {
+  getResourceType(): string;
+  setResourceType(value: string): void;
+
+  getResource(): string;
+  setResource(value: string): void;
+
+  getPermission(): string;
+  setPermission(value: string): void;
+
+  getRole(): string;
+  setRole(value: string): void;
+
+  getGranted(): boolean;
+  setGranted(value: boolean): void;
+
+  getError(): string;
+  setError(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): PermissionCheck.AsObject;
+  static toObject(includeInstance: boolean, msg: PermissionCheck): PermissionCheck.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: PermissionCheck, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): PermissionCheck;
+  static deserializeBinaryFromReader(message: PermissionCheck, reader: jspb.BinaryReader): PermissionCheck;
+}
+
+export namespace PermissionCheck {
+  export type AsObject = {
+    resourceType: string,
+    resource: string,
+    permission: string,
+    role: string,
+    granted: boolean,
+    error: string,
+  }
+}
+
+export class GetPermissionReportResponse extends jspb.Message {
+  getCheckedAt(): number;
+  setCheckedAt(value: number): void;
+
+  clearChecksList(): void;
+  getChecksList(): Array<PermissionCheck>;
+  setChecksList(value: Array<PermissionCheck>): void;
+  addChecks(value?: PermissionCheck, index?: number): PermissionCheck;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetPermissionReportResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetPermissionReportResponse): GetPermissionReportResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetPermissionReportResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetPermissionReportResponse;
+  static deserializeBinaryFromReader(message: GetPermissionReportResponse, reader: jspb.BinaryReader): GetPermissionReportResponse;
+}
+
+export namespace GetPermissionReportResponse {
+  export type AsObject = {
+    checkedAt: number,
+    checksList: Array<PermissionCheck.AsObject>,
+  }
+}
+
+export class GetUsageRequest extends jspb.Message {
+  getFromTime(): number;
+  setFromTime(value: number): void;
+
+  getToTime(): number;
+  setToTime(value: number): void;
+
+  getGroupBy(): GetUsageRequest.GroupByMap[keyof GetUsageRequest.GroupByMap];
+  setGroupBy(value: GetUsageRequest.GroupByMap[keyof GetUsageRequest.GroupByMap]): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetUsageRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetUsageRequest): GetUsageRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetUsageRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetUsageRequest;
+  static deserializeBinaryFromReader(message: GetUsageRequest, reader: jspb.BinaryReader): GetUsageRequest;
+}
+
+export namespace GetUsageRequest {
+  export type AsObject = {
+    fromTime: number,
+    toTime: number,
+    groupBy: GetUsageRequest.GroupByMap[keyof GetUsageRequest.GroupByMap],
+  }
+
+  export interface GroupByMap {
+    GROUP_BY_UNSPECIFIED: 0;
+    GROUP_BY_USER: 1;
+    GROUP_BY_REPORT: 2;
+    GROUP_BY_DAY: 3;
+  }
+
+  export const GroupBy: GroupByMap;
+}
+
+export class Usage extends jspb.Message {
+  getKey(): string;
+  setKey(value: string): void;
+
+  getJobs(): number;
+  setJobs(value: number): void;
+
+  getBytesProcessed(): number;
+  setBytesProcessed(value: number): void;
+
+  getBytesBilled(): number;
+  setBytesBilled(value: number): void;
+
+  getCacheHits(): number;
+  setCacheHits(value: number): void;
+
+  getDurationMs(): number;
+  setDurationMs(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): Usage.AsObject;
+  static toObject(includeInstance: boolean, msg: Usage): Usage.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: Usage, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): Usage;
+  static deserializeBinaryFromReader(message: Usage, reader: jspb.BinaryReader): Usage;
+}
+
+export namespace Usage {
+  export type AsObject = {
+    key: string,
+    jobs: number,
+    bytesProcessed: number,
+    bytesBilled: number,
+    cacheHits: number,
+    durationMs: number,
+  }
+}
+
+export class UsageQuota extends jspb.Message {
+  getMonth(): string;
+  setMonth(value: string): void;
+
+  getBudgetBytes(): number;
+  setBudgetBytes(value: number): void;
+
+  getBytesBilled(): number;
+  setBytesBilled(value: number): void;
+
+  getHeadroomPercent(): number;
+  setHeadroomPercent(value: number): void;
+
+  getResetTime(): number;
+  setResetTime(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UsageQuota.AsObject;
+  static toObject(includeInstance: boolean, msg: UsageQuota): UsageQuota.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UsageQuota, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UsageQuota;
+  static deserializeBinaryFromReader(message: UsageQuota, reader: jspb.BinaryReader): UsageQuota;
+}
+
+export namespace UsageQuota {
+  export type AsObject = {
+    month: string,
+    budgetBytes: number,
+    bytesBilled: number,
+    headroomPercent: number,
+    resetTime: number,
+  }
+}
+
+export class GetUsageResponse extends jspb.Message {
+  clearUsageList(): void;
+  getUsageList(): Array<Usage>;
+  setUsageList(value: Array<Usage>): void;
+  addUsage(value?: Usage, index?: number): Usage;
+
+  hasQuota(): boolean;
+  clearQuota(): void;
+  getQuota(): UsageQuota | undefined;
+  setQuota(value?: UsageQuota): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetUsageResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetUsageResponse): GetUsageResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetUsageResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetUsageResponse;
+  static deserializeBinaryFromReader(message: GetUsageResponse, reader: jspb.BinaryReader): GetUsageResponse;
+}
+
+export namespace GetUsageResponse {
+  export type AsObject = {
+    usageList: Array<Usage.AsObject>,
+    quota?: UsageQuota.AsObject,
+  }
+}
+
+export class ArchiveReportRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getArchive(): boolean;
+  setArchive(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ArchiveReportRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ArchiveReportRequest): ArchiveReportRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ArchiveReportRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ArchiveReportRequest;
+  static deserializeBinaryFromReader(message: ArchiveReportRequest, reader: jspb.BinaryReader): ArchiveReportRequest;
+}
+
+export namespace ArchiveReportRequest {
+  export type AsObject = {
+    reportId: string,
+    archive: boolean,
+  }
+}
+
+export class ArchiveReportResponse extends jspb.Message {
+  getCancelledCount(): number;
+  setCancelledCount(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ArchiveReportResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ArchiveReportResponse): ArchiveReportResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ArchiveReportResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ArchiveReportResponse;
+  static deserializeBinaryFromReader(message: ArchiveReportResponse, reader: jspb.BinaryReader): ArchiveReportResponse;
+}
+
+export namespace ArchiveReportResponse {
+  export type AsObject = {
+    cancelledCount: number,
+  }
+}
+
+export class ShareReportRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getPublic(): boolean;
+  setPublic(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ShareReportRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ShareReportRequest): ShareReportRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ShareReportRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ShareReportRequest;
+  static deserializeBinaryFromReader(message: ShareReportRequest, reader: jspb.BinaryReader): ShareReportRequest;
+}
+
+export namespace ShareReportRequest {
+  export type AsObject = {
+    reportId: string,
+    pb_public: boolean,
+  }
+}
+
+export class ShareReportResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ShareReportResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ShareReportResponse): ShareReportResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ShareReportResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ShareReportResponse;
+  static deserializeBinaryFromReader(message: ShareReportResponse, reader: jspb.BinaryReader): ShareReportResponse;
+}
+
+export namespace ShareReportResponse {
+  export type AsObject = {
+  }
+}
+
+export class ReportListRequest extends jspb.Message {
+  hasStreamOptions(): boolean;
+  clearStreamOptions(): void;
+  getStreamOptions(): StreamOptions | undefined;
+  setStreamOptions(value?: StreamOptions): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReportListRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ReportListRequest): ReportListRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReportListRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReportListRequest;
+  static deserializeBinaryFromReader(message: ReportListRequest, reader: jspb.BinaryReader): ReportListRequest;
+}
+
+export namespace ReportListRequest {
+  export type AsObject = {
+    streamOptions?: StreamOptions.AsObject,
+  }
+}
+
+export class ReportListResponse extends jspb.Message {
+  clearReportsList(): void;
+  getReportsList(): Array<Report>;
+  setReportsList(value: Array<Report>): void;
+  addReports(value?: Report, index?: number): Report;
+
+  hasStreamOptions(): boolean;
+  clearStreamOptions(): void;
+  getStreamOptions(): StreamOptions | undefined;
+  setStreamOptions(value?: StreamOptions): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReportListResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ReportListResponse): ReportListResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReportListResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReportListResponse;
+  static deserializeBinaryFromReader(message: ReportListResponse, reader: jspb.BinaryReader): ReportListResponse;
+}
+
+export namespace ReportListResponse {
+  export type AsObject = {
+    reportsList: Array<Report.AsObject>,
+    streamOptions?: StreamOptions.AsObject,
+  }
+}
+
+export class ListReportsRequest extends jspb.Message {
+  getSearch(): string;
+  setSearch(value: string): void;
+
+  getFilter(): ListReportsRequest.FilterMap[keyof ListReportsRequest.FilterMap];
+  setFilter(value: ListReportsRequest.FilterMap[keyof ListReportsRequest.FilterMap]): void;
+
+  getSort(): ListReportsRequest.SortMap[keyof ListReportsRequest.SortMap];
+  setSort(value: ListReportsRequest.SortMap[keyof ListReportsRequest.SortMap]): void;
+
+  getPageSize(): number;
+  setPageSize(value: number): void;
+
+  getPageToken(): string;
+  setPageToken(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ListReportsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ListReportsRequest): ListReportsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ListReportsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ListReportsRequest;
+  static deserializeBinaryFromReader(message: ListReportsRequest, reader: jspb.BinaryReader): ListReportsRequest;
+}
+
+export namespace ListReportsRequest {
+  export type AsObject = {
+    search: string,
+    filter: ListReportsRequest.FilterMap[keyof ListReportsRequest.FilterMap],
+    sort: ListReportsRequest.SortMap[keyof ListReportsRequest.SortMap],
+    pageSize: number,
+    pageToken: string,
+  }
+
+  export interface FilterMap {
+    FILTER_ALL: 0;
+    FILTER_MINE: 1;
+    FILTER_SHARED: 2;
+    FILTER_ARCHIVED: 3;
+  }
+
+  export const Filter: FilterMap;
+
+  export interface SortMap {
+    SORT_UPDATED_AT: 0;
+    SORT_TITLE: 1;
+  }
+
+  export const Sort: SortMap;
+}
+
+export class ListReportsResponse extends jspb.Message {
+  clearReportsList(): void;
+  getReportsList(): Array<Report>;
+  setReportsList(value: Array<Report>): void;
+  addReports(value?: Report, index?: number): Report;
+
+  getNextPageToken(): string;
+  setNextPageToken(value: string): void;
+
+  getTotalCount(): number;
+  setTotalCount(value: number): void;
+
+  getTotalCountEstimated(): boolean;
+  setTotalCountEstimated(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ListReportsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ListReportsResponse): ListReportsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ListReportsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ListReportsResponse;
+  static deserializeBinaryFromReader(message: ListReportsResponse, reader: jspb.BinaryReader): ListReportsResponse;
+}
+
+export namespace ListReportsResponse {
+  export type AsObject = {
+    reportsList: Array<Report.AsObject>,
+    nextPageToken: string,
+    totalCount: number,
+    totalCountEstimated: boolean,
+  }
+}
+
+export class Report extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getMapConfig(): string;
+  setMapConfig(value: string): void;
+
+  getTitle(): string;
+  setTitle(value: string): void;
+
+  getArchived(): boolean;
+  setArchived(value: boolean): void;
+
+  getCanWrite(): boolean;
+  setCanWrite(value: boolean): void;
+
+  getBillingProject(): string;
+  setBillingProject(value: string): void;
+
+  getDefaultDataset(): string;
+  setDefaultDataset(value: string): void;
+
+  getEffectiveDefaultDataset(): string;
+  setEffectiveDefaultDataset(value: string): void;
+
+  getMapConfigRevision(): number;
+  setMapConfigRevision(value: number): void;
+
+  getAuthorEmail(): string;
+  setAuthorEmail(value: string): void;
+
+  getUpdatedAt(): number;
+  setUpdatedAt(value: number): void;
+
+  clearVariablesList(): void;
+  getVariablesList(): Array<ReportVariable>;
+  setVariablesList(value: Array<ReportVariable>): void;
+  addVariables(value?: ReportVariable, index?: number): ReportVariable;
+
+  getExplorationLimit(): number;
+  setExplorationLimit(value: number): void;
+
+  getPublic(): boolean;
+  setPublic(value: boolean): void;
+
+  hasResultSettings(): boolean;
+  clearResultSettings(): void;
+  getResultSettings(): ResultSettings | undefined;
+  setResultSettings(value?: ResultSettings): void;
+
+  clearParametersList(): void;
+  getParametersList(): Array<ReportParameter>;
+  setParametersList(value: Array<ReportParameter>): void;
+  addParameters(value?: ReportParameter, index?: number): ReportParameter;
+
+  getParameterValuesMap(): jspb.Map<string, string>;
+  clearParameterValuesMap(): void;
+  getSessionParameterValuesMap(): jspb.Map<string, string>;
+  clearSessionParameterValuesMap(): void;
+  hasFreshnessPolicy(): boolean;
+  clearFreshnessPolicy(): void;
+  getFreshnessPolicy(): FreshnessPolicy | undefined;
+  setFreshnessPolicy(value?: FreshnessPolicy): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): Report.AsObject;
+  static toObject(includeInstance: boolean, msg: Report): Report.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: Report, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): Report;
+  static deserializeBinaryFromReader(message: Report, reader: jspb.BinaryReader): Report;
+}
+
+export namespace Report {
+  export type AsObject = {
+    id: string,
+    mapConfig: string,
+    title: string,
+    archived: boolean,
+    canWrite: boolean,
+    billingProject: string,
+    defaultDataset: string,
+    effectiveDefaultDataset: string,
+    mapConfigRevision: number,
+    authorEmail: string,
+    updatedAt: number,
+    variablesList: Array<ReportVariable.AsObject>,
+    explorationLimit: number,
+    pb_public: boolean,
+    resultSettings?: ResultSettings.AsObject,
+    parametersList: Array<ReportParameter.AsObject>,
+    parameterValuesMap: Array<[string, string]>,
+    sessionParameterValuesMap: Array<[string, string]>,
+    freshnessPolicy?: FreshnessPolicy.AsObject,
+  }
+}
+
+export class ReportVariable extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  getType(): ReportVariable.TypeMap[keyof ReportVariable.TypeMap];
+  setType(value: ReportVariable.TypeMap[keyof ReportVariable.TypeMap]): void;
+
+  getDefaultValue(): string;
+  setDefaultValue(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReportVariable.AsObject;
+  static toObject(includeInstance: boolean, msg: ReportVariable): ReportVariable.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReportVariable, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReportVariable;
+  static deserializeBinaryFromReader(message: ReportVariable, reader: jspb.BinaryReader): ReportVariable;
+}
+
+export namespace ReportVariable {
+  export type AsObject = {
+    name: string,
+    type: ReportVariable.TypeMap[keyof ReportVariable.TypeMap],
+    defaultValue: string,
+  }
+
+  export interface TypeMap {
+    TYPE_UNSPECIFIED: 0;
+    TYPE_STRING: 1;
+    TYPE_NUMBER: 2;
+    TYPE_IDENTIFIER: 3;
+  }
+
+  export const Type: TypeMap;
+}
+
+export class UpdateReportVariablesRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  clearVariablesList(): void;
+  getVariablesList(): Array<ReportVariable>;
+  setVariablesList(value: Array<ReportVariable>): void;
+  addVariables(value?: ReportVariable, index?: number): ReportVariable;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportVariablesRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportVariablesRequest): UpdateReportVariablesRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportVariablesRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportVariablesRequest;
+  static deserializeBinaryFromReader(message: UpdateReportVariablesRequest, reader: jspb.BinaryReader): UpdateReportVariablesRequest;
+}
+
+export namespace UpdateReportVariablesRequest {
+  export type AsObject = {
+    reportId: string,
+    variablesList: Array<ReportVariable.AsObject>,
+  }
+}
+
+export class UpdateReportVariablesResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportVariablesResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportVariablesResponse): UpdateReportVariablesResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportVariablesResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportVariablesResponse;
+  static deserializeBinaryFromReader(message: UpdateReportVariablesResponse, reader: jspb.BinaryReader): UpdateReportVariablesResponse;
+}
+
+export namespace UpdateReportVariablesResponse {
+  export type AsObject = {
+  }
+}
+
+export class ReportParameter extends jspb.Message {
+  getName(): string;
+  setName(value: string): void;
+
+  getType(): ReportParameter.TypeMap[keyof ReportParameter.TypeMap];
+  setType(value: ReportParameter.TypeMap[keyof ReportParameter.TypeMap]): void;
+
+  clearAllowedValuesList(): void;
+  getAllowedValuesList(): Array<string>;
+  setAllowedValuesList(value: Array<string>): void;
+  addAllowedValues(value: string, index?: number): string;
+
+  getValuesQueryId(): string;
+  setValuesQueryId(value: string): void;
+
+  getDefaultValue(): string;
+  setDefaultValue(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ReportParameter.AsObject;
+  static toObject(includeInstance: boolean, msg: ReportParameter): ReportParameter.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ReportParameter, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ReportParameter;
+  static deserializeBinaryFromReader(message: ReportParameter, reader: jspb.BinaryReader): ReportParameter;
+}
+
+export namespace ReportParameter {
+  export type AsObject = {
+    name: string,
+    type: ReportParameter.TypeMap[keyof ReportParameter.TypeMap],
+    allowedValuesList: Array<string>,
+    valuesQueryId: string,
+    defaultValue: string,
+  }
+
+  export interface TypeMap {
+    TYPE_UNSPECIFIED: 0;
+    TYPE_STRING: 1;
+    TYPE_INT64: 2;
+    TYPE_FLOAT64: 3;
+    TYPE_BOOL: 4;
+    TYPE_DATE: 5;
+    TYPE_TIMESTAMP: 6;
+  }
+
+  export const Type: TypeMap;
+}
+
+export class UpdateReportParametersRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  clearParametersList(): void;
+  getParametersList(): Array<ReportParameter>;
+  setParametersList(value: Array<ReportParameter>): void;
+  addParameters(value?: ReportParameter, index?: number): ReportParameter;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportParametersRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportParametersRequest): UpdateReportParametersRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportParametersRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportParametersRequest;
+  static deserializeBinaryFromReader(message: UpdateReportParametersRequest, reader: jspb.BinaryReader): UpdateReportParametersRequest;
+}
+
+export namespace UpdateReportParametersRequest {
+  export type AsObject = {
+    reportId: string,
+    parametersList: Array<ReportParameter.AsObject>,
+  }
+}
+
+export class UpdateReportParametersResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportParametersResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportParametersResponse): UpdateReportParametersResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportParametersResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportParametersResponse;
+  static deserializeBinaryFromReader(message: UpdateReportParametersResponse, reader: jspb.BinaryReader): UpdateReportParametersResponse;
+}
+
+export namespace UpdateReportParametersResponse {
+  export type AsObject = {
+  }
+}
+
+export class SetReportParameterValuesRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getValuesMap(): jspb.Map<string, string>;
+  clearValuesMap(): void;
+  getSave(): boolean;
+  setSave(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): SetReportParameterValuesRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: SetReportParameterValuesRequest): SetReportParameterValuesRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: SetReportParameterValuesRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SetReportParameterValuesRequest;
+  static deserializeBinaryFromReader(message: SetReportParameterValuesRequest, reader: jspb.BinaryReader): SetReportParameterValuesRequest;
+}
+
+export namespace SetReportParameterValuesRequest {
+  export type AsObject = {
+    reportId: string,
+    valuesMap: Array<[string, string]>,
+    save: boolean,
+  }
+}
+
+export class SetReportParameterValuesResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): SetReportParameterValuesResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: SetReportParameterValuesResponse): SetReportParameterValuesResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: SetReportParameterValuesResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SetReportParameterValuesResponse;
+  static deserializeBinaryFromReader(message: SetReportParameterValuesResponse, reader: jspb.BinaryReader): SetReportParameterValuesResponse;
+}
+
+export namespace SetReportParameterValuesResponse {
+  export type AsObject = {
+  }
+}
+
+export class GetReportParameterOptionsRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetReportParameterOptionsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetReportParameterOptionsRequest): GetReportParameterOptionsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetReportParameterOptionsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetReportParameterOptionsRequest;
+  static deserializeBinaryFromReader(message: GetReportParameterOptionsRequest, reader: jspb.BinaryReader): GetReportParameterOptionsRequest;
+}
+
+export namespace GetReportParameterOptionsRequest {
+  export type AsObject = {
+    reportId: string,
+    name: string,
+  }
+}
+
+export class GetReportParameterOptionsResponse extends jspb.Message {
+  clearValuesList(): void;
+  getValuesList(): Array<string>;
+  setValuesList(value: Array<string>): void;
+  addValues(value: string, index?: number): string;
+
+  getTruncated(): boolean;
+  setTruncated(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetReportParameterOptionsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetReportParameterOptionsResponse): GetReportParameterOptionsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetReportParameterOptionsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetReportParameterOptionsResponse;
+  static deserializeBinaryFromReader(message: GetReportParameterOptionsResponse, reader: jspb.BinaryReader): GetReportParameterOptionsResponse;
+}
+
+export namespace GetReportParameterOptionsResponse {
+  export type AsObject = {
+    valuesList: Array<string>,
+    truncated: boolean,
+  }
+}
+
+export class ResultSettings extends jspb.Message {
+  getResultFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setResultFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  getResultCompression(): ResultCompressionMap[keyof ResultCompressionMap];
+  setResultCompression(value: ResultCompressionMap[keyof ResultCompressionMap]): void;
+
+  getDelimiter(): string;
+  setDelimiter(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ResultSettings.AsObject;
+  static toObject(includeInstance: boolean, msg: ResultSettings): ResultSettings.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ResultSettings, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ResultSettings;
+  static deserializeBinaryFromReader(message: ResultSettings, reader: jspb.BinaryReader): ResultSettings;
+}
+
+export namespace ResultSettings {
+  export type AsObject = {
+    resultFormat: ResultFormatMap[keyof ResultFormatMap],
+    resultCompression: ResultCompressionMap[keyof ResultCompressionMap],
+    delimiter: string,
+  }
+}
+
+export class UpdateReportSettingsRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  hasResultSettings(): boolean;
+  clearResultSettings(): void;
+  getResultSettings(): ResultSettings | undefined;
+  setResultSettings(value?: ResultSettings): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportSettingsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportSettingsRequest): UpdateReportSettingsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportSettingsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportSettingsRequest;
+  static deserializeBinaryFromReader(message: UpdateReportSettingsRequest, reader: jspb.BinaryReader): UpdateReportSettingsRequest;
+}
+
+export namespace UpdateReportSettingsRequest {
+  export type AsObject = {
+    reportId: string,
+    resultSettings?: ResultSettings.AsObject,
+  }
+}
+
+export class UpdateReportSettingsResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportSettingsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportSettingsResponse): UpdateReportSettingsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportSettingsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportSettingsResponse;
+  static deserializeBinaryFromReader(message: UpdateReportSettingsResponse, reader: jspb.BinaryReader): UpdateReportSettingsResponse;
+}
+
+export namespace UpdateReportSettingsResponse {
+  export type AsObject = {
+  }
+}
+
+export class FreshnessPolicy extends jspb.Message {
+  getMaxAgeSeconds(): number;
+  setMaxAgeSeconds(value: number): void;
+
+  getAutoRerun(): boolean;
+  setAutoRerun(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): FreshnessPolicy.AsObject;
+  static toObject(includeInstance: boolean, msg: FreshnessPolicy): FreshnessPolicy.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: FreshnessPolicy, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): FreshnessPolicy;
+  static deserializeBinaryFromReader(message: FreshnessPolicy, reader: jspb.BinaryReader): FreshnessPolicy;
+}
+
+export namespace FreshnessPolicy {
+  export type AsObject = {
+    maxAgeSeconds: number,
+    autoRerun: boolean,
+  }
+}
+
+export class UpdateReportFreshnessPolicyRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  hasFreshnessPolicy(): boolean;
+  clearFreshnessPolicy(): void;
+  getFreshnessPolicy(): FreshnessPolicy | undefined;
+  setFreshnessPolicy(value?: FreshnessPolicy): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportFreshnessPolicyRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportFreshnessPolicyRequest): UpdateReportFreshnessPolicyRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportFreshnessPolicyRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportFreshnessPolicyRequest;
+  static deserializeBinaryFromReader(message: UpdateReportFreshnessPolicyRequest, reader: jspb.BinaryReader): UpdateReportFreshnessPolicyRequest;
+}
+
+export namespace UpdateReportFreshnessPolicyRequest {
+  export type AsObject = {
+    reportId: string,
+    freshnessPolicy?: FreshnessPolicy.AsObject,
+  }
+}
+
+export class UpdateReportFreshnessPolicyResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportFreshnessPolicyResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportFreshnessPolicyResponse): UpdateReportFreshnessPolicyResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportFreshnessPolicyResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportFreshnessPolicyResponse;
+  static deserializeBinaryFromReader(message: UpdateReportFreshnessPolicyResponse, reader: jspb.BinaryReader): UpdateReportFreshnessPolicyResponse;
+}
+
+export namespace UpdateReportFreshnessPolicyResponse {
+  export type AsObject = {
+  }
+}
+
+export class Dataset extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  getDescription(): string;
+  setDescription(value: string): void;
+
+  getQueryText(): string;
+  setQueryText(value: string): void;
+
+  getAuthorEmail(): string;
+  setAuthorEmail(value: string): void;
+
+  getUpdatedAt(): number;
+  setUpdatedAt(value: number): void;
+
+  getCanWrite(): boolean;
+  setCanWrite(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): Dataset.AsObject;
+  static toObject(includeInstance: boolean, msg: Dataset): Dataset.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: Dataset, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): Dataset;
+  static deserializeBinaryFromReader(message: Dataset, reader: jspb.BinaryReader): Dataset;
+}
+
+export namespace Dataset {
+  export type AsObject = {
+    id: string,
+    name: string,
+    description: string,
+    queryText: string,
+    authorEmail: string,
+    updatedAt: number,
+    canWrite: boolean,
+  }
+}
+
+export class CreateDatasetRequest extends jspb.Message {
+  hasDataset(): boolean;
+  clearDataset(): void;
+  getDataset(): Dataset | undefined;
+  setDataset(value?: Dataset): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): CreateDatasetRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: CreateDatasetRequest): CreateDatasetRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: CreateDatasetRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CreateDatasetRequest;
+  static deserializeBinaryFromReader(message: CreateDatasetRequest, reader: jspb.BinaryReader): CreateDatasetRequest;
+}
+
+export namespace CreateDatasetRequest {
+  export type AsObject = {
+    dataset?: Dataset.AsObject,
+  }
+}
+
+export class CreateDatasetResponse extends jspb.Message {
+  hasDataset(): boolean;
+  clearDataset(): void;
+  getDataset(): Dataset | undefined;
+  setDataset(value?: Dataset): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): CreateDatasetResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: CreateDatasetResponse): CreateDatasetResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: CreateDatasetResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CreateDatasetResponse;
+  static deserializeBinaryFromReader(message: CreateDatasetResponse, reader: jspb.BinaryReader): CreateDatasetResponse;
+}
+
+export namespace CreateDatasetResponse {
+  export type AsObject = {
+    dataset?: Dataset.AsObject,
+  }
+}
+
+export class UpdateDatasetRequest extends jspb.Message {
+  hasDataset(): boolean;
+  clearDataset(): void;
+  getDataset(): Dataset | undefined;
+  setDataset(value?: Dataset): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateDatasetRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateDatasetRequest): UpdateDatasetRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateDatasetRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateDatasetRequest;
+  static deserializeBinaryFromReader(message: UpdateDatasetRequest, reader: jspb.BinaryReader): UpdateDatasetRequest;
+}
+
+export namespace UpdateDatasetRequest {
+  export type AsObject = {
+    dataset?: Dataset.AsObject,
+  }
+}
+
+export class UpdateDatasetResponse extends jspb.Message {
+  hasDataset(): boolean;
+  clearDataset(): void;
+  getDataset(): Dataset | undefined;
+  setDataset(value?: Dataset): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateDatasetResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateDatasetResponse): UpdateDatasetResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateDatasetResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateDatasetResponse;
+  static deserializeBinaryFromReader(message: UpdateDatasetResponse, reader: jspb.BinaryReader): UpdateDatasetResponse;
+}
+
+export namespace UpdateDatasetResponse {
+  export type AsObject = {
+    dataset?: Dataset.AsObject,
+  }
+}
+
+export class DeleteDatasetRequest extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): DeleteDatasetRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: DeleteDatasetRequest): DeleteDatasetRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: DeleteDatasetRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): DeleteDatasetRequest;
+  static deserializeBinaryFromReader(message: DeleteDatasetRequest, reader: jspb.BinaryReader): DeleteDatasetRequest;
+}
+
+export namespace DeleteDatasetRequest {
+  export type AsObject = {
+    id: string,
+  }
+}
+
+export class DeleteDatasetResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): DeleteDatasetResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: DeleteDatasetResponse): DeleteDatasetResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: DeleteDatasetResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): DeleteDatasetResponse;
+  static deserializeBinaryFromReader(message: DeleteDatasetResponse, reader: jspb.BinaryReader): DeleteDatasetResponse;
+}
+
+export namespace DeleteDatasetResponse {
+  export type AsObject = {
+  }
+}
+
+export class ListDatasetsRequest extends jspb.Message {
+  getSearch(): string;
+  setSearch(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ListDatasetsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ListDatasetsRequest): ListDatasetsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ListDatasetsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ListDatasetsRequest;
+  static deserializeBinaryFromReader(message: ListDatasetsRequest, reader: jspb.BinaryReader): ListDatasetsRequest;
+}
+
+export namespace ListDatasetsRequest {
+  export type AsObject = {
+    search: string,
+  }
+}
+
+export class ListDatasetsResponse extends jspb.Message {
+  clearDatasetsList(): void;
+  getDatasetsList(): Array<Dataset>;
+  setDatasetsList(value: Array<Dataset>): void;
+  addDatasets(value?: Dataset, index?: number): Dataset;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ListDatasetsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ListDatasetsResponse): ListDatasetsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ListDatasetsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ListDatasetsResponse;
+  static deserializeBinaryFromReader(message: ListDatasetsResponse, reader: jspb.BinaryReader): ListDatasetsResponse;
+}
+
+export namespace ListDatasetsResponse {
+  export type AsObject = {
+    datasetsList: Array<Dataset.AsObject>,
+  }
+}
+
+export class File extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  getFileType(): FileTypeMap[keyof FileTypeMap];
+  setFileType(value: FileTypeMap[keyof FileTypeMap]): void;
+
+  getSize(): number;
+  setSize(value: number): void;
+
+  getAuthorEmail(): string;
+  setAuthorEmail(value: string): void;
+
+  getCreatedAt(): number;
+  setCreatedAt(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): File.AsObject;
+  static toObject(includeInstance: boolean, msg: File): File.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: File, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): File;
+  static deserializeBinaryFromReader(message: File, reader: jspb.BinaryReader): File;
+}
+
+export namespace File {
+  export type AsObject = {
+    id: string,
+    reportId: string,
+    name: string,
+    fileType: FileTypeMap[keyof FileTypeMap],
+    size: number,
+    authorEmail: string,
+    createdAt: number,
+  }
+}
+
+export class UploadFileRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  getData(): Uint8Array | string;
+  getData_asU8(): Uint8Array;
+  getData_asB64(): string;
+  setData(value: Uint8Array | string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UploadFileRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UploadFileRequest): UploadFileRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UploadFileRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UploadFileRequest;
+  static deserializeBinaryFromReader(message: UploadFileRequest, reader: jspb.BinaryReader): UploadFileRequest;
+}
+
+export namespace UploadFileRequest {
+  export type AsObject = {
+    reportId: string,
+    name: string,
+    data: Uint8Array | string,
+  }
+}
+
+export class UploadFileResponse extends jspb.Message {
+  hasFile(): boolean;
+  clearFile(): void;
+  getFile(): File | undefined;
+  setFile(value?: File): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UploadFileResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UploadFileResponse): UploadFileResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UploadFileResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UploadFileResponse;
+  static deserializeBinaryFromReader(message: UploadFileResponse, reader: jspb.BinaryReader): UploadFileResponse;
+}
+
+export namespace UploadFileResponse {
+  export type AsObject = {
+    file?: File.AsObject,
+  }
+}
+
+export class RemoveFileRequest extends jspb.Message {
+  getFileId(): string;
+  setFileId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RemoveFileRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RemoveFileRequest): RemoveFileRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RemoveFileRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RemoveFileRequest;
+  static deserializeBinaryFromReader(message: RemoveFileRequest, reader: jspb.BinaryReader): RemoveFileRequest;
+}
+
+export namespace RemoveFileRequest {
+  export type AsObject = {
+    fileId: string,
+  }
+}
+
+export class RemoveFileResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RemoveFileResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RemoveFileResponse): RemoveFileResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RemoveFileResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RemoveFileResponse;
+  static deserializeBinaryFromReader(message: RemoveFileResponse, reader: jspb.BinaryReader): RemoveFileResponse;
+}
+
+export namespace RemoveFileResponse {
+  export type AsObject = {
+  }
+}
+
+export class Query extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getQueryText(): string;
+  setQueryText(value: string): void;
+
+  getJobStatus(): Query.JobStatusMap[keyof Query.JobStatusMap];
+  setJobStatus(value: Query.JobStatusMap[keyof Query.JobStatusMap]): void;
+
+  getJobResultId(): string;
+  setJobResultId(value: string): void;
+
+  getJobError(): string;
+  setJobError(value: string): void;
+
+  getJobDuration(): number;
+  setJobDuration(value: number): void;
+
+  getTotalRows(): number;
+  setTotalRows(value: number): void;
+
+  getBytesProcessed(): number;
+  setBytesProcessed(value: number): void;
+
+  getResultSize(): number;
+  setResultSize(value: number): void;
+
+  getRowsWritten(): number;
+  setRowsWritten(value: number): void;
+
+  getSampleRate(): number;
+  setSampleRate(value: number): void;
+
+  getH3ResultId(): string;
+  setH3ResultId(value: string): void;
+
+  getTitle(): string;
+  setTitle(value: string): void;
+
+  getExecutedQueryText(): string;
+  setExecutedQueryText(value: string): void;
+
+  getResultType(): Query.ResultTypeMap[keyof Query.ResultTypeMap];
+  setResultType(value: Query.ResultTypeMap[keyof Query.ResultTypeMap]): void;
+
+  getResultTable(): string;
+  setResultTable(value: string): void;
+
+  getReusedFromQueryId(): string;
+  setReusedFromQueryId(value: string): void;
+
+  getResultCreatedAt(): number;
+  setResultCreatedAt(value: number): void;
+
+  getResultFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setResultFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  getExplorationLimit(): number;
+  setExplorationLimit(value: number): void;
+
+  getExecutedQueryHash(): string;
+  setExecutedQueryHash(value: string): void;
+
+  clearWarningsList(): void;
+  getWarningsList(): Array<JobWarning>;
+  setWarningsList(value: Array<JobWarning>): void;
+  addWarnings(value?: JobWarning, index?: number): JobWarning;
+
+  getBatchId(): string;
+  setBatchId(value: string): void;
+
+  clearDependsOnList(): void;
+  getDependsOnList(): Array<string>;
+  setDependsOnList(value: Array<string>): void;
+  addDependsOn(value: string, index?: number): string;
+
+  getSkippedDependencyId(): string;
+  setSkippedDependencyId(value: string): void;
+
+  getPublicResultPath(): string;
+  setPublicResultPath(value: string): void;
+
+  hasSerializationOptions(): boolean;
+  clearSerializationOptions(): void;
+  getSerializationOptions(): SerializationOptions | undefined;
+  setSerializationOptions(value?: SerializationOptions): void;
+
+  getPlanAvailable(): boolean;
+  setPlanAvailable(value: boolean): void;
+
+  getUseLegacySql(): boolean;
+  setUseLegacySql(value: boolean): void;
+
+  getExactTotalRows(): number;
+  setExactTotalRows(value: number): void;
+
+  getResultCompression(): ResultCompressionMap[keyof ResultCompressionMap];
+  setResultCompression(value: ResultCompressionMap[keyof ResultCompressionMap]): void;
+
+  getParameterBindingsMap(): jspb.Map<string, string>;
+  clearParameterBindingsMap(): void;
+  clearFormatResultsList(): void;
+  getFormatResultsList(): Array<FormatResult>;
+  setFormatResultsList(value: Array<FormatResult>): void;
+  addFormatResults(value?: FormatResult, index?: number): FormatResult;
+
+  getCancelReason(): CancelReasonMap[keyof CancelReasonMap];
+  setCancelReason(value: CancelReasonMap[keyof CancelReasonMap]): void;
+
+  getReservation(): string;
+  setReservation(value: string): void;
+
+  getPreviewResultId(): string;
+  setPreviewResultId(value: string): void;
+
+  getPreviewRows(): number;
+  setPreviewRows(value: number): void;
+
+  hasJobErrorExplanation(): boolean;
+  clearJobErrorExplanation(): void;
+  getJobErrorExplanation(): ErrorExplanation | undefined;
+  setJobErrorExplanation(value?: ErrorExplanation): void;
+
+  hasResultMeta(): boolean;
+  clearResultMeta(): void;
+  getResultMeta(): ResultMeta | undefined;
+  setResultMeta(value?: ResultMeta): void;
+
+  getStale(): boolean;
+  setStale(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): Query.AsObject;
+  static toObject(includeInstance: boolean, msg: Query): Query.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: Query, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): Query;
+  static deserializeBinaryFromReader(message: Query, reader: jspb.BinaryReader): Query;
+}
+
+export namespace Query {
+  export type AsObject = {
+    id: string,
+    reportId: string,
+    queryText: string,
+    jobStatus: Query.JobStatusMap[keyof Query.JobStatusMap],
+    jobResultId: string,
+    jobError: string,
+    jobDuration: number,
+    totalRows: number,
+    bytesProcessed: number,
+    resultSize: number,
+    rowsWritten: number,
+    sampleRate: number,
+    h3ResultId: string,
+    title: string,
+    executedQueryText: string,
+    resultType: Query.ResultTypeMap[keyof Query.ResultTypeMap],
+    resultTable: string,
+    reusedFromQueryId: string,
+    resultCreatedAt: number,
+    resultFormat: ResultFormatMap[keyof ResultFormatMap],
+    explorationLimit: number,
+    executedQueryHash: string,
+    warningsList: Array<JobWarning.AsObject>,
+    batchId: string,
+    dependsOnList: Array<string>,
+    skippedDependencyId: string,
+    publicResultPath: string,
+    serializationOptions?: SerializationOptions.AsObject,
+    planAvailable: boolean,
+    useLegacySql: boolean,
+    exactTotalRows: number,
+    resultCompression: ResultCompressionMap[keyof ResultCompressionMap],
+    parameterBindingsMap: Array<[string, string]>,
+    formatResultsList: Array<FormatResult.AsObject>,
+    cancelReason: CancelReasonMap[keyof CancelReasonMap],
+    reservation: string,
+    previewResultId: string,
+    previewRows: number,
+    jobErrorExplanation?: ErrorExplanation.AsObject,
+    resultMeta?: ResultMeta.AsObject,
+    stale: boolean,
+  }
+
+  export interface JobStatusMap {
+    JOB_STATUS_UNSPECIFIED: 0;
+    JOB_STATUS_PENDING: 1;
+    JOB_STATUS_RUNNING: 2;
+    JOB_STATUS_DONE: 3;
+    JOB_STATUS_SKIPPED: 4;
+    JOB_STATUS_READING_RESULTS: 5;
+    JOB_STATUS_SAVING_RESULT: 6;
+  }
+
+  export const JobStatus: JobStatusMap;
+
+  export interface ResultTypeMap {
+    RESULT_TYPE_UNSPECIFIED: 0;
+    RESULT_TYPE_FILE: 1;
+    RESULT_TYPE_TABLE: 2;
+  }
+
+  export const ResultType: ResultTypeMap;
+}
+
+export class ResultMeta extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  getCompression(): ResultCompressionMap[keyof ResultCompressionMap];
+  setCompression(value: ResultCompressionMap[keyof ResultCompressionMap]): void;
+
+  getSize(): number;
+  setSize(value: number): void;
+
+  getRowCount(): number;
+  setRowCount(value: number): void;
+
+  getChecksum(): string;
+  setChecksum(value: string): void;
+
+  getCreatedAt(): number;
+  setCreatedAt(value: number): void;
+
+  getTruncated(): boolean;
+  setTruncated(value: boolean): void;
+
+  getSampled(): boolean;
+  setSampled(value: boolean): void;
+
+  getStorageBackend(): string;
+  setStorageBackend(value: string): void;
+
+  getBucket(): string;
+  setBucket(value: string): void;
+
+  clearPartsList(): void;
+  getPartsList(): Array<ResultPart>;
+  setPartsList(value: Array<ResultPart>): void;
+  addParts(value?: ResultPart, index?: number): ResultPart;
+
+  getOrdered(): boolean;
+  setOrdered(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ResultMeta.AsObject;
+  static toObject(includeInstance: boolean, msg: ResultMeta): ResultMeta.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ResultMeta, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ResultMeta;
+  static deserializeBinaryFromReader(message: ResultMeta, reader: jspb.BinaryReader): ResultMeta;
+}
+
+export namespace ResultMeta {
+  export type AsObject = {
+    id: string,
+    format: ResultFormatMap[keyof ResultFormatMap],
+    compression: ResultCompressionMap[keyof ResultCompressionMap],
+    size: number,
+    rowCount: number,
+    checksum: string,
+    createdAt: number,
+    truncated: boolean,
+    sampled: boolean,
+    storageBackend: string,
+    bucket: string,
+    partsList: Array<ResultPart.AsObject>,
+    ordered: boolean,
+  }
+}
+
+export class ResultPart extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getKind(): string;
+  setKind(value: string): void;
+
+  getFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ResultPart.AsObject;
+  static toObject(includeInstance: boolean, msg: ResultPart): ResultPart.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ResultPart, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ResultPart;
+  static deserializeBinaryFromReader(message: ResultPart, reader: jspb.BinaryReader): ResultPart;
+}
+
+export namespace ResultPart {
+  export type AsObject = {
+    id: string,
+    kind: string,
+    format: ResultFormatMap[keyof ResultFormatMap],
+  }
+}
+
+export class ErrorExplanation extends jspb.Message {
+  getId(): string;
+  setId(value: string): void;
+
+  getSummary(): string;
+  setSummary(value: string): void;
+
+  getFix(): string;
+  setFix(value: string): void;
+
+  getDocsUrl(): string;
+  setDocsUrl(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ErrorExplanation.AsObject;
+  static toObject(includeInstance: boolean, msg: ErrorExplanation): ErrorExplanation.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ErrorExplanation, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ErrorExplanation;
+  static deserializeBinaryFromReader(message: ErrorExplanation, reader: jspb.BinaryReader): ErrorExplanation;
+}
+
+export namespace ErrorExplanation {
+  export type AsObject = {
+    id: string,
+    summary: string,
+    fix: string,
+    docsUrl: string,
+  }
+}
+
+export class FormatResult extends jspb.Message {
+  getFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  getResultId(): string;
+  setResultId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): FormatResult.AsObject;
+  static toObject(includeInstance: boolean, msg: FormatResult): FormatResult.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: FormatResult, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): FormatResult;
+  static deserializeBinaryFromReader(message: FormatResult, reader: jspb.BinaryReader): FormatResult;
+}
+
+export namespace FormatResult {
+  export type AsObject = {
+    format: ResultFormatMap[keyof ResultFormatMap],
+    resultId: string,
+  }
+}
+
+export class JobWarning extends jspb.Message {
+  getCode(): string;
+  setCode(value: string): void;
+
+  getMessage(): string;
+  setMessage(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): JobWarning.AsObject;
+  static toObject(includeInstance: boolean, msg: JobWarning): JobWarning.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: JobWarning, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): JobWarning;
+  static deserializeBinaryFromReader(message: JobWarning, reader: jspb.BinaryReader): JobWarning;
+}
+
+export namespace JobWarning {
+  export type AsObject = {
+    code: string,
+    message: string,
+  }
+}
+
+export class SerializationOptions extends jspb.Message {
+  getDelimiter(): string;
+  setDelimiter(value: string): void;
+
+  getNullToken(): string;
+  setNullToken(value: string): void;
+
+  getBoolStyle(): SerializationOptions.BoolStyleMap[keyof SerializationOptions.BoolStyleMap];
+  setBoolStyle(value: SerializationOptions.BoolStyleMap[keyof SerializationOptions.BoolStyleMap]): void;
+
+  getFloatPrecision(): number;
+  setFloatPrecision(value: number): void;
+
+  getTimestampFormat(): string;
+  setTimestampFormat(value: string): void;
+
+  getNumericTrailingZeros(): boolean;
+  setNumericTrailingZeros(value: boolean): void;
+
+  getBytesEncoding(): SerializationOptions.BytesEncodingMap[keyof SerializationOptions.BytesEncodingMap];
+  setBytesEncoding(value: SerializationOptions.BytesEncodingMap[keyof SerializationOptions.BytesEncodingMap]): void;
+
+  getHeaderNaming(): SerializationOptions.HeaderNamingMap[keyof SerializationOptions.HeaderNamingMap];
+  setHeaderNaming(value: SerializationOptions.HeaderNamingMap[keyof SerializationOptions.HeaderNamingMap]): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): SerializationOptions.AsObject;
+  static toObject(includeInstance: boolean, msg: SerializationOptions): SerializationOptions.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: SerializationOptions, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SerializationOptions;
+  static deserializeBinaryFromReader(message: SerializationOptions, reader: jspb.BinaryReader): SerializationOptions;
+}
+
+export namespace SerializationOptions {
+  export type AsObject = {
+    delimiter: string,
+    nullToken: string,
+    boolStyle: SerializationOptions.BoolStyleMap[keyof SerializationOptions.BoolStyleMap],
+    floatPrecision: number,
+    timestampFormat: string,
+    numericTrailingZeros: boolean,
+    bytesEncoding: SerializationOptions.BytesEncodingMap[keyof SerializationOptions.BytesEncodingMap],
+    headerNaming: SerializationOptions.HeaderNamingMap[keyof SerializationOptions.HeaderNamingMap],
+  }
+
+  export interface BoolStyleMap {
+    BOOL_STYLE_UNSPECIFIED: 0;
+    BOOL_STYLE_NUMERIC: 1;
+    BOOL_STYLE_UPPER: 2;
+  }
+
+  export const BoolStyle: BoolStyleMap;
+
+  export interface BytesEncodingMap {
+    BYTES_ENCODING_UNSPECIFIED: 0;
+    BYTES_ENCODING_BASE64: 1;
+    BYTES_ENCODING_HEX: 2;
+  }
+
+  export const BytesEncoding: BytesEncodingMap;
+
+  export interface HeaderNamingMap {
+    HEADER_NAMING_UNSPECIFIED: 0;
+    HEADER_NAMING_SNAKE_CASE: 1;
+    HEADER_NAMING_STRICT: 2;
+  }
+
+  export const HeaderNaming: HeaderNamingMap;
+}
+
+export class DestinationTable extends jspb.Message {
+  getTable(): string;
+  setTable(value: string): void;
+
+  getWriteDisposition(): DestinationTable.WriteDispositionMap[keyof DestinationTable.WriteDispositionMap];
+  setWriteDisposition(value: DestinationTable.WriteDispositionMap[keyof DestinationTable.WriteDispositionMap]): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): DestinationTable.AsObject;
+  static toObject(includeInstance: boolean, msg: DestinationTable): DestinationTable.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: DestinationTable, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): DestinationTable;
+  static deserializeBinaryFromReader(message: DestinationTable, reader: jspb.BinaryReader): DestinationTable;
+}
+
+export namespace DestinationTable {
+  export type AsObject = {
+    table: string,
+    writeDisposition: DestinationTable.WriteDispositionMap[keyof DestinationTable.WriteDispositionMap],
+  }
+
+  export interface WriteDispositionMap {
+    WRITE_DISPOSITION_UNSPECIFIED: 0;
+    WRITE_DISPOSITION_TRUNCATE: 1;
+    WRITE_DISPOSITION_APPEND: 2;
+  }
+
+  export const WriteDisposition: WriteDispositionMap;
+}
+
+export class H3Aggregation extends jspb.Message {
+  getLatColumn(): string;
+  setLatColumn(value: string): void;
+
+  getLngColumn(): string;
+  setLngColumn(value: string): void;
+
+  getGeographyColumn(): string;
+  setGeographyColumn(value: string): void;
+
+  getResolution(): number;
+  setResolution(value: number): void;
+
+  clearMetricsList(): void;
+  getMetricsList(): Array<H3Metric>;
+  setMetricsList(value: Array<H3Metric>): void;
+  addMetrics(value?: H3Metric, index?: number): H3Metric;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): H3Aggregation.AsObject;
+  static toObject(includeInstance: boolean, msg: H3Aggregation): H3Aggregation.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: H3Aggregation, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): H3Aggregation;
+  static deserializeBinaryFromReader(message: H3Aggregation, reader: jspb.BinaryReader): H3Aggregation;
+}
+
+export namespace H3Aggregation {
+  export type AsObject = {
+    latColumn: string,
+    lngColumn: string,
+    geographyColumn: string,
+    resolution: number,
+    metricsList: Array<H3Metric.AsObject>,
+  }
+}
+
+export class H3Metric extends jspb.Message {
+  getColumn(): string;
+  setColumn(value: string): void;
+
+  getFunction(): H3Metric.FunctionMap[keyof H3Metric.FunctionMap];
+  setFunction(value: H3Metric.FunctionMap[keyof H3Metric.FunctionMap]): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): H3Metric.AsObject;
+  static toObject(includeInstance: boolean, msg: H3Metric): H3Metric.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: H3Metric, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): H3Metric;
+  static deserializeBinaryFromReader(message: H3Metric, reader: jspb.BinaryReader): H3Metric;
+}
+
+export namespace H3Metric {
+  export type AsObject = {
+    column: string,
+    pb_function: H3Metric.FunctionMap[keyof H3Metric.FunctionMap],
+  }
+
+  export interface FunctionMap {
+    FUNCTION_UNSPECIFIED: 0;
+    FUNCTION_SUM: 1;
+    FUNCTION_AVG: 2;
+  }
+
+  export const Function: FunctionMap;
+}
+
+export class GetReportRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetReportRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetReportRequest): GetReportRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetReportRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetReportRequest;
+  static deserializeBinaryFromReader(message: GetReportRequest, reader: jspb.BinaryReader): GetReportRequest;
+}
+
+export namespace GetReportRequest {
+  export type AsObject = {
+    reportId: string,
+  }
+}
+
+export class GetReportResponse extends jspb.Message {
+  hasReport(): boolean;
+  clearReport(): void;
+  getReport(): Report | undefined;
+  setReport(value?: Report): void;
+
+  clearQueriesList(): void;
+  getQueriesList(): Array<Query>;
+  setQueriesList(value: Array<Query>): void;
+  addQueries(value?: Query, index?: number): Query;
+
+  clearFilesList(): void;
+  getFilesList(): Array<File>;
+  setFilesList(value: Array<File>): void;
+  addFiles(value?: File, index?: number): File;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetReportResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetReportResponse): GetReportResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetReportResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetReportResponse;
+  static deserializeBinaryFromReader(message: GetReportResponse, reader: jspb.BinaryReader): GetReportResponse;
+}
+
+export namespace GetReportResponse {
+  export type AsObject = {
+    report?: Report.AsObject,
+    queriesList: Array<Query.AsObject>,
+    filesList: Array<File.AsObject>,
+  }
+}
+
+export class GetQueryRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetQueryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetQueryRequest): GetQueryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetQueryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetQueryRequest;
+  static deserializeBinaryFromReader(message: GetQueryRequest, reader: jspb.BinaryReader): GetQueryRequest;
+}
+
+export namespace GetQueryRequest {
+  export type AsObject = {
+    queryId: string,
+  }
+}
+
+export class GetQueryResponse extends jspb.Message {
+  hasQuery(): boolean;
+  clearQuery(): void;
+  getQuery(): Query | undefined;
+  setQuery(value?: Query): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetQueryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetQueryResponse): GetQueryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetQueryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetQueryResponse;
+  static deserializeBinaryFromReader(message: GetQueryResponse, reader: jspb.BinaryReader): GetQueryResponse;
+}
+
+export namespace GetQueryResponse {
+  export type AsObject = {
+    query?: Query.AsObject,
+  }
+}
+
+export class GetQuerySchemaContextRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getQueryText(): string;
+  setQueryText(value: string): void;
+
+  getUseLegacySql(): boolean;
+  setUseLegacySql(value: boolean): void;
+
+  getConnection(): string;
+  setConnection(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetQuerySchemaContextRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetQuerySchemaContextRequest): GetQuerySchemaContextRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetQuerySchemaContextRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetQuerySchemaContextRequest;
+  static deserializeBinaryFromReader(message: GetQuerySchemaContextRequest, reader: jspb.BinaryReader): GetQuerySchemaContextRequest;
+}
+
+export namespace GetQuerySchemaContextRequest {
+  export type AsObject = {
+    reportId: string,
+    queryText: string,
+    useLegacySql: boolean,
+    connection: string,
+  }
+}
+
+export class SchemaColumn extends jspb.Message {
+  getTable(): string;
+  setTable(value: string): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  getType(): string;
+  setType(value: string): void;
+
+  getMode(): string;
+  setMode(value: string): void;
+
+  getDescription(): string;
+  setDescription(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): SchemaColumn.AsObject;
+  static toObject(includeInstance: boolean, msg: SchemaColumn): SchemaColumn.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: SchemaColumn, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SchemaColumn;
+  static deserializeBinaryFromReader(message: SchemaColumn, reader: jspb.BinaryReader): SchemaColumn;
+}
+
+export namespace SchemaColumn {
+  export type AsObject = {
+    table: string,
+    name: string,
+    type: string,
+    mode: string,
+    description: string,
+  }
+}
+
+export class GetQuerySchemaContextResponse extends jspb.Message {
+  clearTablesList(): void;
+  getTablesList(): Array<string>;
+  setTablesList(value: Array<string>): void;
+  addTables(value: string, index?: number): string;
+
+  clearColumnsList(): void;
+  getColumnsList(): Array<SchemaColumn>;
+  setColumnsList(value: Array<SchemaColumn>): void;
+  addColumns(value?: SchemaColumn, index?: number): SchemaColumn;
+
+  clearWarningsList(): void;
+  getWarningsList(): Array<string>;
+  setWarningsList(value: Array<string>): void;
+  addWarnings(value: string, index?: number): string;
+
+  getSnapshotAgeSeconds(): number;
+  setSnapshotAgeSeconds(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetQuerySchemaContextResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetQuerySchemaContextResponse): GetQuerySchemaContextResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetQuerySchemaContextResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetQuerySchemaContextResponse;
+  static deserializeBinaryFromReader(message: GetQuerySchemaContextResponse, reader: jspb.BinaryReader): GetQuerySchemaContextResponse;
+}
+
+export namespace GetQuerySchemaContextResponse {
+  export type AsObject = {
+    tablesList: Array<string>,
+    columnsList: Array<SchemaColumn.AsObject>,
+    warningsList: Array<string>,
+    snapshotAgeSeconds: number,
+  }
+}
+
+export class SchemaSnapshotSource extends jspb.Message {
+  getSource(): string;
+  setSource(value: string): void;
+
+  getSnapshotAt(): number;
+  setSnapshotAt(value: number): void;
+
+  getAgeSeconds(): number;
+  setAgeSeconds(value: number): void;
+
+  getStale(): boolean;
+  setStale(value: boolean): void;
+
+  getError(): string;
+  setError(value: string): void;
+
+  getFailedAt(): number;
+  setFailedAt(value: number): void;
+
+  getColumnsCount(): number;
+  setColumnsCount(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): SchemaSnapshotSource.AsObject;
+  static toObject(includeInstance: boolean, msg: SchemaSnapshotSource): SchemaSnapshotSource.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: SchemaSnapshotSource, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): SchemaSnapshotSource;
+  static deserializeBinaryFromReader(message: SchemaSnapshotSource, reader: jspb.BinaryReader): SchemaSnapshotSource;
+}
+
+export namespace SchemaSnapshotSource {
+  export type AsObject = {
+    source: string,
+    snapshotAt: number,
+    ageSeconds: number,
+    stale: boolean,
+    error: string,
+    failedAt: number,
+    columnsCount: number,
+  }
+}
+
+export class GetSchemaSnapshotRequest extends jspb.Message {
+  getConnection(): string;
+  setConnection(value: string): void;
+
+  getTablePrefix(): string;
+  setTablePrefix(value: string): void;
+
+  getTable(): string;
+  setTable(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetSchemaSnapshotRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetSchemaSnapshotRequest): GetSchemaSnapshotRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetSchemaSnapshotRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetSchemaSnapshotRequest;
+  static deserializeBinaryFromReader(message: GetSchemaSnapshotRequest, reader: jspb.BinaryReader): GetSchemaSnapshotRequest;
+}
+
+export namespace GetSchemaSnapshotRequest {
+  export type AsObject = {
+    connection: string,
+    tablePrefix: string,
+    table: string,
+  }
+}
+
+export class GetSchemaSnapshotResponse extends jspb.Message {
+  clearTablesList(): void;
+  getTablesList(): Array<string>;
+  setTablesList(value: Array<string>): void;
+  addTables(value: string, index?: number): string;
+
+  getTruncated(): boolean;
+  setTruncated(value: boolean): void;
+
+  clearColumnsList(): void;
+  getColumnsList(): Array<SchemaColumn>;
+  setColumnsList(value: Array<SchemaColumn>): void;
+  addColumns(value?: SchemaColumn, index?: number): SchemaColumn;
+
+  clearSourcesList(): void;
+  getSourcesList(): Array<SchemaSnapshotSource>;
+  setSourcesList(value: Array<SchemaSnapshotSource>): void;
+  addSources(value?: SchemaSnapshotSource, index?: number): SchemaSnapshotSource;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetSchemaSnapshotResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetSchemaSnapshotResponse): GetSchemaSnapshotResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetSchemaSnapshotResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetSchemaSnapshotResponse;
+  static deserializeBinaryFromReader(message: GetSchemaSnapshotResponse, reader: jspb.BinaryReader): GetSchemaSnapshotResponse;
+}
+
+export namespace GetSchemaSnapshotResponse {
+  export type AsObject = {
+    tablesList: Array<string>,
+    truncated: boolean,
+    columnsList: Array<SchemaColumn.AsObject>,
+    sourcesList: Array<SchemaSnapshotSource.AsObject>,
+  }
+}
+
+export class RefreshSchemaSnapshotRequest extends jspb.Message {
+  getSource(): string;
+  setSource(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RefreshSchemaSnapshotRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RefreshSchemaSnapshotRequest): RefreshSchemaSnapshotRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RefreshSchemaSnapshotRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RefreshSchemaSnapshotRequest;
+  static deserializeBinaryFromReader(message: RefreshSchemaSnapshotRequest, reader: jspb.BinaryReader): RefreshSchemaSnapshotRequest;
+}
+
+export namespace RefreshSchemaSnapshotRequest {
+  export type AsObject = {
+    source: string,
+  }
+}
+
+export class RefreshSchemaSnapshotResponse extends jspb.Message {
+  clearSourcesList(): void;
+  getSourcesList(): Array<SchemaSnapshotSource>;
+  setSourcesList(value: Array<SchemaSnapshotSource>): void;
+  addSources(value?: SchemaSnapshotSource, index?: number): SchemaSnapshotSource;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RefreshSchemaSnapshotResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RefreshSchemaSnapshotResponse): RefreshSchemaSnapshotResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RefreshSchemaSnapshotResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RefreshSchemaSnapshotResponse;
+  static deserializeBinaryFromReader(message: RefreshSchemaSnapshotResponse, reader: jspb.BinaryReader): RefreshSchemaSnapshotResponse;
+}
+
+export namespace RefreshSchemaSnapshotResponse {
+  export type AsObject = {
+    sourcesList: Array<SchemaSnapshotSource.AsObject>,
+  }
+}
+
+export class GetExecutedQueryRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetExecutedQueryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetExecutedQueryRequest): GetExecutedQueryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetExecutedQueryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetExecutedQueryRequest;
+  static deserializeBinaryFromReader(message: GetExecutedQueryRequest, reader: jspb.BinaryReader): GetExecutedQueryRequest;
+}
+
+export namespace GetExecutedQueryRequest {
+  export type AsObject = {
+    queryId: string,
+  }
+}
+
+export class GetExecutedQueryResponse extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getExecutedQueryText(): string;
+  setExecutedQueryText(value: string): void;
+
+  getExecutedQueryHash(): string;
+  setExecutedQueryHash(value: string): void;
+
+  getDefaultDataset(): string;
+  setDefaultDataset(value: string): void;
+
+  getSampleRate(): number;
+  setSampleRate(value: number): void;
+
+  getExplorationLimit(): number;
+  setExplorationLimit(value: number): void;
+
+  getReusedFromQueryId(): string;
+  setReusedFromQueryId(value: string): void;
+
+  getUseLegacySql(): boolean;
+  setUseLegacySql(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetExecutedQueryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetExecutedQueryResponse): GetExecutedQueryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetExecutedQueryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetExecutedQueryResponse;
+  static deserializeBinaryFromReader(message: GetExecutedQueryResponse, reader: jspb.BinaryReader): GetExecutedQueryResponse;
+}
+
+export namespace GetExecutedQueryResponse {
+  export type AsObject = {
+    queryId: string,
+    executedQueryText: string,
+    executedQueryHash: string,
+    defaultDataset: string,
+    sampleRate: number,
+    explorationLimit: number,
+    reusedFromQueryId: string,
+    useLegacySql: boolean,
+  }
+}
+
+export class GetJobPlanRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetJobPlanRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetJobPlanRequest): GetJobPlanRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetJobPlanRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetJobPlanRequest;
+  static deserializeBinaryFromReader(message: GetJobPlanRequest, reader: jspb.BinaryReader): GetJobPlanRequest;
+}
+
+export namespace GetJobPlanRequest {
+  export type AsObject = {
+    queryId: string,
+  }
+}
+
+export class JobPlanStage extends jspb.Message {
+  getId(): number;
+  setId(value: number): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  getStatus(): string;
+  setStatus(value: string): void;
+
+  clearInputStagesList(): void;
+  getInputStagesList(): Array<number>;
+  setInputStagesList(value: Array<number>): void;
+  addInputStages(value: number, index?: number): number;
+
+  getRecordsRead(): number;
+  setRecordsRead(value: number): void;
+
+  getRecordsWritten(): number;
+  setRecordsWritten(value: number): void;
+
+  getWaitRatioAvg(): number;
+  setWaitRatioAvg(value: number): void;
+
+  getWaitRatioMax(): number;
+  setWaitRatioMax(value: number): void;
+
+  getReadRatioAvg(): number;
+  setReadRatioAvg(value: number): void;
+
+  getReadRatioMax(): number;
+  setReadRatioMax(value: number): void;
+
+  getComputeRatioAvg(): number;
+  setComputeRatioAvg(value: number): void;
+
+  getComputeRatioMax(): number;
+  setComputeRatioMax(value: number): void;
+
+  getWriteRatioAvg(): number;
+  setWriteRatioAvg(value: number): void;
+
+  getWriteRatioMax(): number;
+  setWriteRatioMax(value: number): void;
+
+  getShuffleOutputBytes(): number;
+  setShuffleOutputBytes(value: number): void;
+
+  getShuffleOutputBytesSpilled(): number;
+  setShuffleOutputBytesSpilled(value: number): void;
+
+  getDurationMs(): number;
+  setDurationMs(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): JobPlanStage.AsObject;
+  static toObject(includeInstance: boolean, msg: JobPlanStage): JobPlanStage.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: JobPlanStage, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): JobPlanStage;
+  static deserializeBinaryFromReader(message: JobPlanStage, reader: jspb.BinaryReader): JobPlanStage;
+}
+
+export namespace JobPlanStage {
+  export type AsObject = {
+    id: number,
+    name: string,
+    status: string,
+    inputStagesList: Array<number>,
+    recordsRead: number,
+    recordsWritten: number,
+    waitRatioAvg: number,
+    waitRatioMax: number,
+    readRatioAvg: number,
+    readRatioMax: number,
+    computeRatioAvg: number,
+    computeRatioMax: number,
+    writeRatioAvg: number,
+    writeRatioMax: number,
+    shuffleOutputBytes: number,
+    shuffleOutputBytesSpilled: number,
+    durationMs: number,
+  }
+}
+
+export class GetJobPlanResponse extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  clearStagesList(): void;
+  getStagesList(): Array<JobPlanStage>;
+  setStagesList(value: Array<JobPlanStage>): void;
+  addStages(value?: JobPlanStage, index?: number): JobPlanStage;
+
+  getTotalStages(): number;
+  setTotalStages(value: number): void;
+
+  clearReferencedTablesList(): void;
+  getReferencedTablesList(): Array<string>;
+  setReferencedTablesList(value: Array<string>): void;
+  addReferencedTables(value: string, index?: number): string;
+
+  getNote(): string;
+  setNote(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetJobPlanResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetJobPlanResponse): GetJobPlanResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetJobPlanResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetJobPlanResponse;
+  static deserializeBinaryFromReader(message: GetJobPlanResponse, reader: jspb.BinaryReader): GetJobPlanResponse;
+}
+
+export namespace GetJobPlanResponse {
+  export type AsObject = {
+    queryId: string,
+    stagesList: Array<JobPlanStage.AsObject>,
+    totalStages: number,
+    referencedTablesList: Array<string>,
+    note: string,
+  }
+}
+
+export class UpdateReportRequest extends jspb.Message {
+  hasReport(): boolean;
+  clearReport(): void;
+  getReport(): Report | undefined;
+  setReport(value?: Report): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportRequest): UpdateReportRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportRequest;
+  static deserializeBinaryFromReader(message: UpdateReportRequest, reader: jspb.BinaryReader): UpdateReportRequest;
+}
+
+export namespace UpdateReportRequest {
+  export type AsObject = {
+    report?: Report.AsObject,
+  }
+}
+
+export class UpdateMapConfigRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getMapConfig(): string;
+  setMapConfig(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateMapConfigRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateMapConfigRequest): UpdateMapConfigRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateMapConfigRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateMapConfigRequest;
+  static deserializeBinaryFromReader(message: UpdateMapConfigRequest, reader: jspb.BinaryReader): UpdateMapConfigRequest;
+}
+
+export namespace UpdateMapConfigRequest {
+  export type AsObject = {
+    reportId: string,
+    mapConfig: string,
+  }
+}
+
+export class UpdateMapConfigResponse extends jspb.Message {
+  getRevision(): number;
+  setRevision(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateMapConfigResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateMapConfigResponse): UpdateMapConfigResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateMapConfigResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateMapConfigResponse;
+  static deserializeBinaryFromReader(message: UpdateMapConfigResponse, reader: jspb.BinaryReader): UpdateMapConfigResponse;
+}
+
+export namespace UpdateMapConfigResponse {
+  export type AsObject = {
+    revision: number,
+  }
+}
+
+export class GetMapConfigHistoryRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getLimit(): number;
+  setLimit(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetMapConfigHistoryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetMapConfigHistoryRequest): GetMapConfigHistoryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetMapConfigHistoryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetMapConfigHistoryRequest;
+  static deserializeBinaryFromReader(message: GetMapConfigHistoryRequest, reader: jspb.BinaryReader): GetMapConfigHistoryRequest;
+}
+
+export namespace GetMapConfigHistoryRequest {
+  export type AsObject = {
+    reportId: string,
+    limit: number,
+  }
+}
+
+export class MapConfigRevision extends jspb.Message {
+  getRevision(): number;
+  setRevision(value: number): void;
+
+  getMapConfig(): string;
+  setMapConfig(value: string): void;
+
+  getAuthorEmail(): string;
+  setAuthorEmail(value: string): void;
+
+  getCreatedAt(): number;
+  setCreatedAt(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): MapConfigRevision.AsObject;
+  static toObject(includeInstance: boolean, msg: MapConfigRevision): MapConfigRevision.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: MapConfigRevision, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): MapConfigRevision;
+  static deserializeBinaryFromReader(message: MapConfigRevision, reader: jspb.BinaryReader): MapConfigRevision;
+}
+
+export namespace MapConfigRevision {
+  export type AsObject = {
+    revision: number,
+    mapConfig: string,
+    authorEmail: string,
+    createdAt: number,
+  }
+}
+
+export class GetMapConfigHistoryResponse extends jspb.Message {
+  clearRevisionsList(): void;
+  getRevisionsList(): Array<MapConfigRevision>;
+  setRevisionsList(value: Array<MapConfigRevision>): void;
+  addRevisions(value?: MapConfigRevision, index?: number): MapConfigRevision;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetMapConfigHistoryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetMapConfigHistoryResponse): GetMapConfigHistoryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetMapConfigHistoryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetMapConfigHistoryResponse;
+  static deserializeBinaryFromReader(message: GetMapConfigHistoryResponse, reader: jspb.BinaryReader): GetMapConfigHistoryResponse;
+}
+
+export namespace GetMapConfigHistoryResponse {
+  export type AsObject = {
+    revisionsList: Array<MapConfigRevision.AsObject>,
+  }
+}
+
+export class UpdateReportResponse extends jspb.Message {
+  getWarning(): string;
+  setWarning(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateReportResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateReportResponse): UpdateReportResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateReportResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateReportResponse;
+  static deserializeBinaryFromReader(message: UpdateReportResponse, reader: jspb.BinaryReader): UpdateReportResponse;
+}
+
+export namespace UpdateReportResponse {
+  export type AsObject = {
+    warning: string,
+  }
+}
+
+export class RunQueryRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getSampleRate(): number;
+  setSampleRate(value: number): void;
+
+  hasH3Aggregation(): boolean;
+  clearH3Aggregation(): void;
+  getH3Aggregation(): H3Aggregation | undefined;
+  setH3Aggregation(value?: H3Aggregation): void;
+
+  getVariablesMap(): jspb.Map<string, string>;
+  clearVariablesMap(): void;
+  hasDestinationTable(): boolean;
+  clearDestinationTable(): void;
+  getDestinationTable(): DestinationTable | undefined;
+  setDestinationTable(value?: DestinationTable): void;
+
+  getIdempotencyKey(): string;
+  setIdempotencyKey(value: string): void;
+
+  getResultFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setResultFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  hasSerializationOptions(): boolean;
+  clearSerializationOptions(): void;
+  getSerializationOptions(): SerializationOptions | undefined;
+  setSerializationOptions(value?: SerializationOptions): void;
+
+  getResultCompression(): ResultCompressionMap[keyof ResultCompressionMap];
+  setResultCompression(value: ResultCompressionMap[keyof ResultCompressionMap]): void;
+
+  getConnection(): string;
+  setConnection(value: string): void;
+
+  clearAdditionalFormatsList(): void;
+  getAdditionalFormatsList(): Array<ResultFormatMap[keyof ResultFormatMap]>;
+  setAdditionalFormatsList(value: Array<ResultFormatMap[keyof ResultFormatMap]>): void;
+  addAdditionalFormats(value: ResultFormatMap[keyof ResultFormatMap], index?: number): ResultFormatMap[keyof ResultFormatMap];
+
+  getAcknowledgedBytes(): number;
+  setAcknowledgedBytes(value: number): void;
+
+  getAsOf(): number;
+  setAsOf(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RunQueryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RunQueryRequest): RunQueryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RunQueryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RunQueryRequest;
+  static deserializeBinaryFromReader(message: RunQueryRequest, reader: jspb.BinaryReader): RunQueryRequest;
+}
+
+export namespace RunQueryRequest {
+  export type AsObject = {
+    queryId: string,
+    sampleRate: number,
+    h3Aggregation?: H3Aggregation.AsObject,
+    variablesMap: Array<[string, string]>,
+    destinationTable?: DestinationTable.AsObject,
+    idempotencyKey: string,
+    resultFormat: ResultFormatMap[keyof ResultFormatMap],
+    serializationOptions?: SerializationOptions.AsObject,
+    resultCompression: ResultCompressionMap[keyof ResultCompressionMap],
+    connection: string,
+    additionalFormatsList: Array<ResultFormatMap[keyof ResultFormatMap]>,
+    acknowledgedBytes: number,
+    asOf: number,
+  }
+}
+
+export class RunQueryResponse extends jspb.Message {
+  getJobId(): string;
+  setJobId(value: string): void;
+
+  getDuplicate(): boolean;
+  setDuplicate(value: boolean): void;
+
+  getReused(): boolean;
+  setReused(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RunQueryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RunQueryResponse): RunQueryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RunQueryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RunQueryResponse;
+  static deserializeBinaryFromReader(message: RunQueryResponse, reader: jspb.BinaryReader): RunQueryResponse;
+}
+
+export namespace RunQueryResponse {
+  export type AsObject = {
+    jobId: string,
+    duplicate: boolean,
+    reused: boolean,
+  }
+}
+
+export class RunAllQueriesRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getVariablesMap(): jspb.Map<string, string>;
+  clearVariablesMap(): void;
+  getAcknowledgedBytes(): number;
+  setAcknowledgedBytes(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RunAllQueriesRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RunAllQueriesRequest): RunAllQueriesRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RunAllQueriesRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RunAllQueriesRequest;
+  static deserializeBinaryFromReader(message: RunAllQueriesRequest, reader: jspb.BinaryReader): RunAllQueriesRequest;
+}
+
+export namespace RunAllQueriesRequest {
+  export type AsObject = {
+    reportId: string,
+    variablesMap: Array<[string, string]>,
+    acknowledgedBytes: number,
+  }
+}
+
+export class BatchRun extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getJobId(): string;
+  setJobId(value: string): void;
+
+  getReused(): boolean;
+  setReused(value: boolean): void;
+
+  getError(): string;
+  setError(value: string): void;
+
+  getWaiting(): boolean;
+  setWaiting(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): BatchRun.AsObject;
+  static toObject(includeInstance: boolean, msg: BatchRun): BatchRun.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: BatchRun, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): BatchRun;
+  static deserializeBinaryFromReader(message: BatchRun, reader: jspb.BinaryReader): BatchRun;
+}
+
+export namespace BatchRun {
+  export type AsObject = {
+    queryId: string,
+    jobId: string,
+    reused: boolean,
+    error: string,
+    waiting: boolean,
+  }
+}
+
+export class RunAllQueriesResponse extends jspb.Message {
+  getBatchId(): string;
+  setBatchId(value: string): void;
+
+  clearRunsList(): void;
+  getRunsList(): Array<BatchRun>;
+  setRunsList(value: Array<BatchRun>): void;
+  addRuns(value?: BatchRun, index?: number): BatchRun;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RunAllQueriesResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RunAllQueriesResponse): RunAllQueriesResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RunAllQueriesResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RunAllQueriesResponse;
+  static deserializeBinaryFromReader(message: RunAllQueriesResponse, reader: jspb.BinaryReader): RunAllQueriesResponse;
+}
+
+export namespace RunAllQueriesResponse {
+  export type AsObject = {
+    batchId: string,
+    runsList: Array<BatchRun.AsObject>,
+  }
+}
+
+export class CancelBatchRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getBatchId(): string;
+  setBatchId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): CancelBatchRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: CancelBatchRequest): CancelBatchRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: CancelBatchRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CancelBatchRequest;
+  static deserializeBinaryFromReader(message: CancelBatchRequest, reader: jspb.BinaryReader): CancelBatchRequest;
+}
+
+export namespace CancelBatchRequest {
+  export type AsObject = {
+    reportId: string,
+    batchId: string,
+  }
+}
+
+export class CancelBatchResponse extends jspb.Message {
+  getCancelledCount(): number;
+  setCancelledCount(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): CancelBatchResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: CancelBatchResponse): CancelBatchResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: CancelBatchResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CancelBatchResponse;
+  static deserializeBinaryFromReader(message: CancelBatchResponse, reader: jspb.BinaryReader): CancelBatchResponse;
+}
+
+export namespace CancelBatchResponse {
+  export type AsObject = {
+    cancelledCount: number,
+  }
+}
+
+export class RunQueryAndWaitRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getQueryText(): string;
+  setQueryText(value: string): void;
+
+  getTimeoutSeconds(): number;
+  setTimeoutSeconds(value: number): void;
+
+  getSampleRate(): number;
+  setSampleRate(value: number): void;
+
+  hasH3Aggregation(): boolean;
+  clearH3Aggregation(): void;
+  getH3Aggregation(): H3Aggregation | undefined;
+  setH3Aggregation(value?: H3Aggregation): void;
+
+  getVariablesMap(): jspb.Map<string, string>;
+  clearVariablesMap(): void;
+  hasDestinationTable(): boolean;
+  clearDestinationTable(): void;
+  getDestinationTable(): DestinationTable | undefined;
+  setDestinationTable(value?: DestinationTable): void;
+
+  getResultFormat(): ResultFormatMap[keyof ResultFormatMap];
+  setResultFormat(value: ResultFormatMap[keyof ResultFormatMap]): void;
+
+  hasSerializationOptions(): boolean;
+  clearSerializationOptions(): void;
+  getSerializationOptions(): SerializationOptions | undefined;
+  setSerializationOptions(value?: SerializationOptions): void;
+
+  getResultCompression(): ResultCompressionMap[keyof ResultCompressionMap];
+  setResultCompression(value: ResultCompressionMap[keyof ResultCompressionMap]): void;
+
+  getConnection(): string;
+  setConnection(value: string): void;
+
+  clearAdditionalFormatsList(): void;
+  getAdditionalFormatsList(): Array<ResultFormatMap[keyof ResultFormatMap]>;
+  setAdditionalFormatsList(value: Array<ResultFormatMap[keyof ResultFormatMap]>): void;
+  addAdditionalFormats(value: ResultFormatMap[keyof ResultFormatMap], index?: number): ResultFormatMap[keyof ResultFormatMap];
+
+  getIdempotencyKey(): string;
+  setIdempotencyKey(value: string): void;
+
+  getAsOf(): number;
+  setAsOf(value: number): void;
+
+  getAcknowledgedBytes(): number;
+  setAcknowledgedBytes(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RunQueryAndWaitRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RunQueryAndWaitRequest): RunQueryAndWaitRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RunQueryAndWaitRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RunQueryAndWaitRequest;
+  static deserializeBinaryFromReader(message: RunQueryAndWaitRequest, reader: jspb.BinaryReader): RunQueryAndWaitRequest;
+}
+
+export namespace RunQueryAndWaitRequest {
+  export type AsObject = {
+    queryId: string,
+    queryText: string,
+    timeoutSeconds: number,
+    sampleRate: number,
+    h3Aggregation?: H3Aggregation.AsObject,
+    variablesMap: Array<[string, string]>,
+    destinationTable?: DestinationTable.AsObject,
+    resultFormat: ResultFormatMap[keyof ResultFormatMap],
+    serializationOptions?: SerializationOptions.AsObject,
+    resultCompression: ResultCompressionMap[keyof ResultCompressionMap],
+    connection: string,
+    additionalFormatsList: Array<ResultFormatMap[keyof ResultFormatMap]>,
+    idempotencyKey: string,
+    asOf: number,
+    acknowledgedBytes: number,
+  }
+}
+
+export class RunQueryAndWaitResponse extends jspb.Message {
+  getKeepalive(): boolean;
+  setKeepalive(value: boolean): void;
+
+  getJobStatus(): Query.JobStatusMap[keyof Query.JobStatusMap];
+  setJobStatus(value: Query.JobStatusMap[keyof Query.JobStatusMap]): void;
+
+  getJobResultId(): string;
+  setJobResultId(value: string): void;
+
+  getJobError(): string;
+  setJobError(value: string): void;
+
+  getTotalRows(): number;
+  setTotalRows(value: number): void;
+
+  getBytesProcessed(): number;
+  setBytesProcessed(value: number): void;
+
+  getResultSize(): number;
+  setResultSize(value: number): void;
+
+  getDownloadUrl(): string;
+  setDownloadUrl(value: string): void;
+
+  getRowsWritten(): number;
+  setRowsWritten(value: number): void;
+
+  getSampleRate(): number;
+  setSampleRate(value: number): void;
+
+  getH3ResultId(): string;
+  setH3ResultId(value: string): void;
+
+  getH3DownloadUrl(): string;
+  setH3DownloadUrl(value: string): void;
+
+  getExecutedQueryText(): string;
+  setExecutedQueryText(value: string): void;
+
+  getResultType(): Query.ResultTypeMap[keyof Query.ResultTypeMap];
+  setResultType(value: Query.ResultTypeMap[keyof Query.ResultTypeMap]): void;
+
+  getResultTable(): string;
+  setResultTable(value: string): void;
+
+  getExplorationLimit(): number;
+  setExplorationLimit(value: number): void;
+
+  getExecutedQueryHash(): string;
+  setExecutedQueryHash(value: string): void;
+
+  clearWarningsList(): void;
+  getWarningsList(): Array<JobWarning>;
+  setWarningsList(value: Array<JobWarning>): void;
+  addWarnings(value?: JobWarning, index?: number): JobWarning;
+
+  clearFormatResultsList(): void;
+  getFormatResultsList(): Array<FormatResult>;
+  setFormatResultsList(value: Array<FormatResult>): void;
+  addFormatResults(value?: FormatResult, index?: number): FormatResult;
+
+  getCancelReason(): CancelReasonMap[keyof CancelReasonMap];
+  setCancelReason(value: CancelReasonMap[keyof CancelReasonMap]): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RunQueryAndWaitResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RunQueryAndWaitResponse): RunQueryAndWaitResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RunQueryAndWaitResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RunQueryAndWaitResponse;
+  static deserializeBinaryFromReader(message: RunQueryAndWaitResponse, reader: jspb.BinaryReader): RunQueryAndWaitResponse;
+}
+
+export namespace RunQueryAndWaitResponse {
+  export type AsObject = {
+    keepalive: boolean,
+    jobStatus: Query.JobStatusMap[keyof Query.JobStatusMap],
+    jobResultId: string,
+    jobError: string,
+    totalRows: number,
+    bytesProcessed: number,
+    resultSize: number,
+    downloadUrl: string,
+    rowsWritten: number,
+    sampleRate: number,
+    h3ResultId: string,
+    h3DownloadUrl: string,
+    executedQueryText: string,
+    resultType: Query.ResultTypeMap[keyof Query.ResultTypeMap],
+    resultTable: string,
+    explorationLimit: number,
+    executedQueryHash: string,
+    warningsList: Array<JobWarning.AsObject>,
+    formatResultsList: Array<FormatResult.AsObject>,
+    cancelReason: CancelReasonMap[keyof CancelReasonMap],
+  }
+}
+
+export class ExportResultRequest extends jspb.Message {
+  getResultId(): string;
+  setResultId(value: string): void;
+
+  getDestinationUri(): string;
+  setDestinationUri(value: string): void;
+
+  getOverwrite(): boolean;
+  setOverwrite(value: boolean): void;
+
+  getIncludeLineage(): boolean;
+  setIncludeLineage(value: boolean): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ExportResultRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: ExportResultRequest): ExportResultRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ExportResultRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ExportResultRequest;
+  static deserializeBinaryFromReader(message: ExportResultRequest, reader: jspb.BinaryReader): ExportResultRequest;
+}
+
+export namespace ExportResultRequest {
+  export type AsObject = {
+    resultId: string,
+    destinationUri: string,
+    overwrite: boolean,
+    includeLineage: boolean,
+  }
+}
+
+export class ExportResultResponse extends jspb.Message {
+  getDone(): boolean;
+  setDone(value: boolean): void;
+
+  getBytesCopied(): number;
+  setBytesCopied(value: number): void;
+
+  getTotalBytes(): number;
+  setTotalBytes(value: number): void;
+
+  getDestinationUri(): string;
+  setDestinationUri(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ExportResultResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: ExportResultResponse): ExportResultResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ExportResultResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ExportResultResponse;
+  static deserializeBinaryFromReader(message: ExportResultResponse, reader: jspb.BinaryReader): ExportResultResponse;
+}
+
+export namespace ExportResultResponse {
+  export type AsObject = {
+    done: boolean,
+    bytesCopied: number,
+    totalBytes: number,
+    destinationUri: string,
+  }
+}
+
+export class ResultLineage extends jspb.Message {
+  getResultId(): string;
+  setResultId(value: string): void;
+
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getJobId(): string;
+  setJobId(value: string): void;
+
+  getBigqueryJobId(): string;
+  setBigqueryJobId(value: string): void;
+
+  getBigqueryProject(): string;
+  setBigqueryProject(value: string): void;
+
+  getBigqueryLocation(): string;
+  setBigqueryLocation(value: string): void;
+
+  getQueryHash(): string;
+  setQueryHash(value: string): void;
+
+  getQueryText(): string;
+  setQueryText(value: string): void;
+
+  getParameterValuesMap(): jspb.Map<string, string>;
+  clearParameterValuesMap(): void;
+  getUserEmail(): string;
+  setUserEmail(value: string): void;
+
+  getDekartVersion(): string;
+  setDekartVersion(value: string): void;
+
+  getJobStarted(): number;
+  setJobStarted(value: number): void;
+
+  getJobFinished(): number;
+  setJobFinished(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ResultLineage.AsObject;
+  static toObject(includeInstance: boolean, msg: ResultLineage): ResultLineage.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ResultLineage, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ResultLineage;
+  static deserializeBinaryFromReader(message: ResultLineage, reader: jspb.BinaryReader): ResultLineage;
+}
+
+export namespace ResultLineage {
+  export type AsObject = {
+    resultId: string,
+    reportId: string,
+    queryId: string,
+    jobId: string,
+    bigqueryJobId: string,
+    bigqueryProject: string,
+    bigqueryLocation: string,
+    queryHash: string,
+    queryText: string,
+    parameterValuesMap: Array<[string, string]>,
+    userEmail: string,
+    dekartVersion: string,
+    jobStarted: number,
+    jobFinished: number,
+  }
+}
+
+export class GetResultLineageRequest extends jspb.Message {
+  getResultId(): string;
+  setResultId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetResultLineageRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetResultLineageRequest): GetResultLineageRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetResultLineageRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetResultLineageRequest;
+  static deserializeBinaryFromReader(message: GetResultLineageRequest, reader: jspb.BinaryReader): GetResultLineageRequest;
+}
+
+export namespace GetResultLineageRequest {
+  export type AsObject = {
+    resultId: string,
+  }
+}
+
+export class GetResultLineageResponse extends jspb.Message {
+  hasLineage(): boolean;
+  clearLineage(): void;
+  getLineage(): ResultLineage | undefined;
+  setLineage(value?: ResultLineage): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetResultLineageResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetResultLineageResponse): GetResultLineageResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetResultLineageResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetResultLineageResponse;
+  static deserializeBinaryFromReader(message: GetResultLineageResponse, reader: jspb.BinaryReader): GetResultLineageResponse;
+}
+
+export namespace GetResultLineageResponse {
+  export type AsObject = {
+    lineage?: ResultLineage.AsObject,
+  }
+}
+
+export class GetResultFieldTypesRequest extends jspb.Message {
+  getResultId(): string;
+  setResultId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetResultFieldTypesRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetResultFieldTypesRequest): GetResultFieldTypesRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetResultFieldTypesRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetResultFieldTypesRequest;
+  static deserializeBinaryFromReader(message: GetResultFieldTypesRequest, reader: jspb.BinaryReader): GetResultFieldTypesRequest;
+}
+
+export namespace GetResultFieldTypesRequest {
+  export type AsObject = {
+    resultId: string,
+  }
+}
+
+export class ResultFieldType extends jspb.Message {
+  getColumnType(): string;
+  setColumnType(value: string): void;
+
+  getFieldType(): string;
+  setFieldType(value: string): void;
+
+  getName(): string;
+  setName(value: string): void;
+
+  getOriginalName(): string;
+  setOriginalName(value: string): void;
+
+  getDatabaseType(): string;
+  setDatabaseType(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): ResultFieldType.AsObject;
+  static toObject(includeInstance: boolean, msg: ResultFieldType): ResultFieldType.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: ResultFieldType, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ResultFieldType;
+  static deserializeBinaryFromReader(message: ResultFieldType, reader: jspb.BinaryReader): ResultFieldType;
+}
+
+export namespace ResultFieldType {
+  export type AsObject = {
+    columnType: string,
+    fieldType: string,
+    name: string,
+    originalName: string,
+    databaseType: string,
+  }
+}
+
+export class GetResultFieldTypesResponse extends jspb.Message {
+  clearFieldsList(): void;
+  getFieldsList(): Array<ResultFieldType>;
+  setFieldsList(value: Array<ResultFieldType>): void;
+  addFields(value?: ResultFieldType, index?: number): ResultFieldType;
+
+  hasResultMeta(): boolean;
+  clearResultMeta(): void;
+  getResultMeta(): ResultMeta | undefined;
+  setResultMeta(value?: ResultMeta): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetResultFieldTypesResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetResultFieldTypesResponse): GetResultFieldTypesResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetResultFieldTypesResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetResultFieldTypesResponse;
+  static deserializeBinaryFromReader(message: GetResultFieldTypesResponse, reader: jspb.BinaryReader): GetResultFieldTypesResponse;
+}
+
+export namespace GetResultFieldTypesResponse {
+  export type AsObject = {
+    fieldsList: Array<ResultFieldType.AsObject>,
+    resultMeta?: ResultMeta.AsObject,
+  }
+}
+
+export class GetJobEventsRequest extends jspb.Message {
+  getJobId(): string;
+  setJobId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): GetJobEventsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: GetJobEventsRequest): GetJobEventsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: GetJobEventsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetJobEventsRequest;
+  static deserializeBinaryFromReader(message: GetJobEventsRequest, reader: jspb.BinaryReader): GetJobEventsRequest;
+}
 
-  getJobDuration(): number;
-  setJobDuration(value: number): void;
+export namespace GetJobEventsRequest {
+  export type AsObject = {
+    jobId: string,
+  }
+}
 
-  getTotalRows(): number;
-  setTotalRows(value: number): void;
+export class JobEvent extends jspb.Message {
+  getTime(): number;
+  setTime(value: number): void;
 
-  getBytesProcessed(): number;
-  setBytesProcessed(value: number): void;
+  getType(): string;
+  setType(value: string): void;
 
-  getResultSize(): number;
-  setResultSize(value: number): void;
+  getMessage(): string;
+  setMessage(value: string): void;
 
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): Query.AsObject;
-  static toObject(includeInstance: boolean, msg: Query): Query.AsObject;
+  toObject(includeInstance?: boolean): JobEvent.AsObject;
+  static toObject(includeInstance: boolean, msg: JobEvent): JobEvent.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: Query, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): Query;
-  static deserializeBinaryFromReader(message: Query, reader: jspb.BinaryReader): Query;
+  static serializeBinaryToWriter(message: JobEvent, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): JobEvent;
+  static deserializeBinaryFromReader(message: JobEvent, reader: jspb.BinaryReader): JobEvent;
 }
 
-export namespace Query {
+export namespace JobEvent {
   export type AsObject = {
-    id: string,
-    reportId: string,
-    queryText: string,
-    jobStatus: Query.JobStatusMap[keyof Query.JobStatusMap],
-    jobResultId: string,
-    jobError: string,
-    jobDuration: number,
-    totalRows: number,
-    bytesProcessed: number,
-    resultSize: number,
+    time: number,
+    type: string,
+    message: string,
   }
+}
 
-  export interface JobStatusMap {
-    JOB_STATUS_UNSPECIFIED: 0;
-    JOB_STATUS_PENDING: 1;
-    JOB_STATUS_RUNNING: 2;
-    JOB_STATUS_DONE: 3;
-  }
+export class GetJobEventsResponse extends jspb.Message {
+  clearEventsList(): void;
+  getEventsList(): Array<JobEvent>;
+  setEventsList(value: Array<JobEvent>): void;
+  addEvents(value?: JobEvent, index?: number): JobEvent;
 
-  export const JobStatus: JobStatusMap;
-}
+  getEventsDropped(): number;
+  setEventsDropped(value: number): void;
 
-export class UpdateReportRequest extends jspb.Message {
-  hasReport(): boolean;
-  clearReport(): void;
-  getReport(): Report | undefined;
-  setReport(value?: Report): void;
+  getFinished(): boolean;
+  setFinished(value: boolean): void;
 
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): UpdateReportRequest.AsObject;
-  static toObject(includeInstance: boolean, msg: UpdateReportRequest): UpdateReportRequest.AsObject;
+  toObject(includeInstance?: boolean): GetJobEventsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: GetJobEventsResponse): GetJobEventsResponse.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: UpdateReportRequest, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): UpdateReportRequest;
-  static deserializeBinaryFromReader(message: UpdateReportRequest, reader: jspb.BinaryReader): UpdateReportRequest;
+  static serializeBinaryToWriter(message: GetJobEventsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): GetJobEventsResponse;
+  static deserializeBinaryFromReader(message: GetJobEventsResponse, reader: jspb.BinaryReader): GetJobEventsResponse;
 }
 
-export namespace UpdateReportRequest {
+export namespace GetJobEventsResponse {
   export type AsObject = {
-    report?: Report.AsObject,
+    eventsList: Array<JobEvent.AsObject>,
+    eventsDropped: number,
+    finished: boolean,
   }
 }
 
-export class UpdateReportResponse extends jspb.Message {
+export class CompareResultsRequest extends jspb.Message {
+  getBaseResultId(): string;
+  setBaseResultId(value: string): void;
+
+  getResultId(): string;
+  setResultId(value: string): void;
+
+  getKeyColumn(): string;
+  setKeyColumn(value: string): void;
+
+  getCreateDiff(): boolean;
+  setCreateDiff(value: boolean): void;
+
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): UpdateReportResponse.AsObject;
-  static toObject(includeInstance: boolean, msg: UpdateReportResponse): UpdateReportResponse.AsObject;
+  toObject(includeInstance?: boolean): CompareResultsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: CompareResultsRequest): CompareResultsRequest.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: UpdateReportResponse, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): UpdateReportResponse;
-  static deserializeBinaryFromReader(message: UpdateReportResponse, reader: jspb.BinaryReader): UpdateReportResponse;
+  static serializeBinaryToWriter(message: CompareResultsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CompareResultsRequest;
+  static deserializeBinaryFromReader(message: CompareResultsRequest, reader: jspb.BinaryReader): CompareResultsRequest;
 }
 
-export namespace UpdateReportResponse {
+export namespace CompareResultsRequest {
   export type AsObject = {
+    baseResultId: string,
+    resultId: string,
+    keyColumn: string,
+    createDiff: boolean,
   }
 }
 
-export class RunQueryRequest extends jspb.Message {
-  getQueryId(): string;
-  setQueryId(value: string): void;
+export class ColumnChanges extends jspb.Message {
+  getColumn(): string;
+  setColumn(value: string): void;
+
+  getChangedRows(): number;
+  setChangedRows(value: number): void;
 
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): RunQueryRequest.AsObject;
-  static toObject(includeInstance: boolean, msg: RunQueryRequest): RunQueryRequest.AsObject;
+  toObject(includeInstance?: boolean): ColumnChanges.AsObject;
+  static toObject(includeInstance: boolean, msg: ColumnChanges): ColumnChanges.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: RunQueryRequest, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): RunQueryRequest;
-  static deserializeBinaryFromReader(message: RunQueryRequest, reader: jspb.BinaryReader): RunQueryRequest;
+  static serializeBinaryToWriter(message: ColumnChanges, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): ColumnChanges;
+  static deserializeBinaryFromReader(message: ColumnChanges, reader: jspb.BinaryReader): ColumnChanges;
 }
 
-export namespace RunQueryRequest {
+export namespace ColumnChanges {
   export type AsObject = {
-    queryId: string,
+    column: string,
+    changedRows: number,
   }
 }
 
-export class RunQueryResponse extends jspb.Message {
+export class CompareResultsResponse extends jspb.Message {
+  getRowsAdded(): number;
+  setRowsAdded(value: number): void;
+
+  getRowsRemoved(): number;
+  setRowsRemoved(value: number): void;
+
+  getRowsChanged(): number;
+  setRowsChanged(value: number): void;
+
+  getRowsUnchanged(): number;
+  setRowsUnchanged(value: number): void;
+
+  clearColumnChangesList(): void;
+  getColumnChangesList(): Array<ColumnChanges>;
+  setColumnChangesList(value: Array<ColumnChanges>): void;
+  addColumnChanges(value?: ColumnChanges, index?: number): ColumnChanges;
+
+  clearAddedColumnsList(): void;
+  getAddedColumnsList(): Array<string>;
+  setAddedColumnsList(value: Array<string>): void;
+  addAddedColumns(value: string, index?: number): string;
+
+  clearRemovedColumnsList(): void;
+  getRemovedColumnsList(): Array<string>;
+  setRemovedColumnsList(value: Array<string>): void;
+  addRemovedColumns(value: string, index?: number): string;
+
+  getDiffResultId(): string;
+  setDiffResultId(value: string): void;
+
   serializeBinary(): Uint8Array;
-  toObject(includeInstance?: boolean): RunQueryResponse.AsObject;
-  static toObject(includeInstance: boolean, msg: RunQueryResponse): RunQueryResponse.AsObject;
+  toObject(includeInstance?: boolean): CompareResultsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: CompareResultsResponse): CompareResultsResponse.AsObject;
   static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
   static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
-  static serializeBinaryToWriter(message: RunQueryResponse, writer: jspb.BinaryWriter): void;
-  static deserializeBinary(bytes: Uint8Array): RunQueryResponse;
-  static deserializeBinaryFromReader(message: RunQueryResponse, reader: jspb.BinaryReader): RunQueryResponse;
+  static serializeBinaryToWriter(message: CompareResultsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CompareResultsResponse;
+  static deserializeBinaryFromReader(message: CompareResultsResponse, reader: jspb.BinaryReader): CompareResultsResponse;
 }
 
-export namespace RunQueryResponse {
+export namespace CompareResultsResponse {
   export type AsObject = {
+    rowsAdded: number,
+    rowsRemoved: number,
+    rowsChanged: number,
+    rowsUnchanged: number,
+    columnChangesList: Array<ColumnChanges.AsObject>,
+    addedColumnsList: Array<string>,
+    removedColumnsList: Array<string>,
+    diffResultId: string,
   }
 }
 
@@ -380,6 +4018,9 @@ export namespace RemoveQueryRequest {
 }
 
 export class RemoveQueryResponse extends jspb.Message {
+  getRestorableUntil(): number;
+  setRestorableUntil(value: number): void;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): RemoveQueryResponse.AsObject;
   static toObject(includeInstance: boolean, msg: RemoveQueryResponse): RemoveQueryResponse.AsObject;
@@ -392,6 +4033,49 @@ export class RemoveQueryResponse extends jspb.Message {
 
 export namespace RemoveQueryResponse {
   export type AsObject = {
+    restorableUntil: number,
+  }
+}
+
+export class RestoreQueryRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RestoreQueryRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: RestoreQueryRequest): RestoreQueryRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RestoreQueryRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RestoreQueryRequest;
+  static deserializeBinaryFromReader(message: RestoreQueryRequest, reader: jspb.BinaryReader): RestoreQueryRequest;
+}
+
+export namespace RestoreQueryRequest {
+  export type AsObject = {
+    queryId: string,
+  }
+}
+
+export class RestoreQueryResponse extends jspb.Message {
+  hasQuery(): boolean;
+  clearQuery(): void;
+  getQuery(): Query | undefined;
+  setQuery(value?: Query): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): RestoreQueryResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: RestoreQueryResponse): RestoreQueryResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: RestoreQueryResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): RestoreQueryResponse;
+  static deserializeBinaryFromReader(message: RestoreQueryResponse, reader: jspb.BinaryReader): RestoreQueryResponse;
+}
+
+export namespace RestoreQueryResponse {
+  export type AsObject = {
+    query?: Query.AsObject,
   }
 }
 
@@ -431,6 +4115,46 @@ export namespace CancelQueryResponse {
   }
 }
 
+export class CancelReportRequest extends jspb.Message {
+  getReportId(): string;
+  setReportId(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): CancelReportRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: CancelReportRequest): CancelReportRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: CancelReportRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CancelReportRequest;
+  static deserializeBinaryFromReader(message: CancelReportRequest, reader: jspb.BinaryReader): CancelReportRequest;
+}
+
+export namespace CancelReportRequest {
+  export type AsObject = {
+    reportId: string,
+  }
+}
+
+export class CancelReportResponse extends jspb.Message {
+  getCancelledCount(): number;
+  setCancelledCount(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): CancelReportResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: CancelReportResponse): CancelReportResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: CancelReportResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): CancelReportResponse;
+  static deserializeBinaryFromReader(message: CancelReportResponse, reader: jspb.BinaryReader): CancelReportResponse;
+}
+
+export namespace CancelReportResponse {
+  export type AsObject = {
+    cancelledCount: number,
+  }
+}
+
 export class UpdateQueryRequest extends jspb.Message {
   hasQuery(): boolean;
   clearQuery(): void;
@@ -475,6 +4199,139 @@ export namespace UpdateQueryResponse {
   }
 }
 
+export class UpdateQueryDependenciesRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  clearDependsOnList(): void;
+  getDependsOnList(): Array<string>;
+  setDependsOnList(value: Array<string>): void;
+  addDependsOn(value: string, index?: number): string;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateQueryDependenciesRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateQueryDependenciesRequest): UpdateQueryDependenciesRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateQueryDependenciesRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateQueryDependenciesRequest;
+  static deserializeBinaryFromReader(message: UpdateQueryDependenciesRequest, reader: jspb.BinaryReader): UpdateQueryDependenciesRequest;
+}
+
+export namespace UpdateQueryDependenciesRequest {
+  export type AsObject = {
+    queryId: string,
+    dependsOnList: Array<string>,
+  }
+}
+
+export class UpdateQueryDependenciesResponse extends jspb.Message {
+  hasQuery(): boolean;
+  clearQuery(): void;
+  getQuery(): Query | undefined;
+  setQuery(value?: Query): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateQueryDependenciesResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateQueryDependenciesResponse): UpdateQueryDependenciesResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateQueryDependenciesResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateQueryDependenciesResponse;
+  static deserializeBinaryFromReader(message: UpdateQueryDependenciesResponse, reader: jspb.BinaryReader): UpdateQueryDependenciesResponse;
+}
+
+export namespace UpdateQueryDependenciesResponse {
+  export type AsObject = {
+    query?: Query.AsObject,
+  }
+}
+
+export class UpdateQueryParameterBindingsRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getParameterBindingsMap(): jspb.Map<string, string>;
+  clearParameterBindingsMap(): void;
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateQueryParameterBindingsRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateQueryParameterBindingsRequest): UpdateQueryParameterBindingsRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateQueryParameterBindingsRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateQueryParameterBindingsRequest;
+  static deserializeBinaryFromReader(message: UpdateQueryParameterBindingsRequest, reader: jspb.BinaryReader): UpdateQueryParameterBindingsRequest;
+}
+
+export namespace UpdateQueryParameterBindingsRequest {
+  export type AsObject = {
+    queryId: string,
+    parameterBindingsMap: Array<[string, string]>,
+  }
+}
+
+export class UpdateQueryParameterBindingsResponse extends jspb.Message {
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateQueryParameterBindingsResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateQueryParameterBindingsResponse): UpdateQueryParameterBindingsResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateQueryParameterBindingsResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateQueryParameterBindingsResponse;
+  static deserializeBinaryFromReader(message: UpdateQueryParameterBindingsResponse, reader: jspb.BinaryReader): UpdateQueryParameterBindingsResponse;
+}
+
+export namespace UpdateQueryParameterBindingsResponse {
+  export type AsObject = {
+  }
+}
+
+export class UpdateQueryTitleRequest extends jspb.Message {
+  getQueryId(): string;
+  setQueryId(value: string): void;
+
+  getTitle(): string;
+  setTitle(value: string): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateQueryTitleRequest.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateQueryTitleRequest): UpdateQueryTitleRequest.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateQueryTitleRequest, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateQueryTitleRequest;
+  static deserializeBinaryFromReader(message: UpdateQueryTitleRequest, reader: jspb.BinaryReader): UpdateQueryTitleRequest;
+}
+
+export namespace UpdateQueryTitleRequest {
+  export type AsObject = {
+    queryId: string,
+    title: string,
+  }
+}
+
+export class UpdateQueryTitleResponse extends jspb.Message {
+  hasQuery(): boolean;
+  clearQuery(): void;
+  getQuery(): Query | undefined;
+  setQuery(value?: Query): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): UpdateQueryTitleResponse.AsObject;
+  static toObject(includeInstance: boolean, msg: UpdateQueryTitleResponse): UpdateQueryTitleResponse.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: UpdateQueryTitleResponse, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): UpdateQueryTitleResponse;
+  static deserializeBinaryFromReader(message: UpdateQueryTitleResponse, reader: jspb.BinaryReader): UpdateQueryTitleResponse;
+}
+
+export namespace UpdateQueryTitleResponse {
+  export type AsObject = {
+    query?: Query.AsObject,
+  }
+}
+
 export class CreateQueryRequest extends jspb.Message {
   hasQuery(): boolean;
   clearQuery(): void;
@@ -530,6 +4387,9 @@ export class ReportStreamRequest extends jspb.Message {
   getStreamOptions(): StreamOptions | undefined;
   setStreamOptions(value?: StreamOptions): void;
 
+  getFineGrainedJobStatus(): boolean;
+  setFineGrainedJobStatus(value: boolean): void;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): ReportStreamRequest.AsObject;
   static toObject(includeInstance: boolean, msg: ReportStreamRequest): ReportStreamRequest.AsObject;
@@ -544,6 +4404,7 @@ export namespace ReportStreamRequest {
   export type AsObject = {
     report?: Report.AsObject,
     streamOptions?: StreamOptions.AsObject,
+    fineGrainedJobStatus: boolean,
   }
 }
 
@@ -563,6 +4424,16 @@ export class ReportStreamResponse extends jspb.Message {
   getStreamOptions(): StreamOptions | undefined;
   setStreamOptions(value?: StreamOptions): void;
 
+  hasBatchProgress(): boolean;
+  clearBatchProgress(): void;
+  getBatchProgress(): BatchProgress | undefined;
+  setBatchProgress(value?: BatchProgress): void;
+
+  clearFilesList(): void;
+  getFilesList(): Array<File>;
+  setFilesList(value: Array<File>): void;
+  addFiles(value?: File, index?: number): File;
+
   serializeBinary(): Uint8Array;
   toObject(includeInstance?: boolean): ReportStreamResponse.AsObject;
   static toObject(includeInstance: boolean, msg: ReportStreamResponse): ReportStreamResponse.AsObject;
@@ -578,6 +4449,44 @@ export namespace ReportStreamResponse {
     report?: Report.AsObject,
     queriesList: Array<Query.AsObject>,
     streamOptions?: StreamOptions.AsObject,
+    batchProgress?: BatchProgress.AsObject,
+    filesList: Array<File.AsObject>,
+  }
+}
+
+export class BatchProgress extends jspb.Message {
+  getBatchId(): string;
+  setBatchId(value: string): void;
+
+  getComplete(): number;
+  setComplete(value: number): void;
+
+  getFailed(): number;
+  setFailed(value: number): void;
+
+  getTotal(): number;
+  setTotal(value: number): void;
+
+  getSkipped(): number;
+  setSkipped(value: number): void;
+
+  serializeBinary(): Uint8Array;
+  toObject(includeInstance?: boolean): BatchProgress.AsObject;
+  static toObject(includeInstance: boolean, msg: BatchProgress): BatchProgress.AsObject;
+  static extensions: {[key: number]: jspb.ExtensionFieldInfo<jspb.Message>};
+  static extensionsBinary: {[key: number]: jspb.ExtensionFieldBinaryInfo<jspb.Message>};
+  static serializeBinaryToWriter(message: BatchProgress, writer: jspb.BinaryWriter): void;
+  static deserializeBinary(bytes: Uint8Array): BatchProgress;
+  static deserializeBinaryFromReader(message: BatchProgress, reader: jspb.BinaryReader): BatchProgress;
+}
+
+export namespace BatchProgress {
+  export type AsObject = {
+    batchId: string,
+    complete: number,
+    failed: number,
+    total: number,
+    skipped: number,
   }
 }
 
@@ -659,3 +4568,47 @@ export namespace CreateReportResponse {
   }
 }
 
+export interface RoleMap {
+  ROLE_UNSPECIFIED: 0;
+  ROLE_VIEWER: 1;
+  ROLE_EDITOR: 2;
+  ROLE_ADMIN: 3;
+}
+
+export const Role: RoleMap;
+
+export interface FileTypeMap {
+  FILE_TYPE_UNSPECIFIED: 0;
+  FILE_TYPE_CSV: 1;
+  FILE_TYPE_GEOJSON: 2;
+}
+
+export const FileType: FileTypeMap;
+
+export interface ResultFormatMap {
+  RESULT_FORMAT_UNSPECIFIED: 0;
+  RESULT_FORMAT_CSV: 1;
+  RESULT_FORMAT_PARQUET: 2;
+}
+
+export const ResultFormat: ResultFormatMap;
+
+export interface ResultCompressionMap {
+  RESULT_COMPRESSION_UNSPECIFIED: 0;
+  RESULT_COMPRESSION_NONE: 1;
+  RESULT_COMPRESSION_GZIP: 2;
+}
+
+export const ResultCompression: ResultCompressionMap;
+
+export interface CancelReasonMap {
+  CANCEL_REASON_UNSPECIFIED: 0;
+  CANCEL_REASON_USER_REQUEST: 1;
+  CANCEL_REASON_ADMIN: 2;
+  CANCEL_REASON_ORPHAN_TIMEOUT: 3;
+  CANCEL_REASON_SHUTDOWN: 4;
+  CANCEL_REASON_SUPERSEDED: 5;
+}
+
+export const CancelReason: CancelReasonMap;
+
@@ -40,6 +40,105 @@ type DekartArchiveReport = {
   readonly responseType: typeof proto_dekart_pb.ArchiveReportResponse;
 };
 
+type DekartShareReport = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.ShareReportRequest;
+  readonly responseType: typeof proto_dekart_pb.ShareReportResponse;
+};
+
+type DekartUpdateMapConfig = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateMapConfigRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateMapConfigResponse;
+};
+
+type DekartUpdateReportVariables = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateReportVariablesRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateReportVariablesResponse;
+};
+
+type DekartUpdateReportSettings = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateReportSettingsRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateReportSettingsResponse;
+};
+
+type DekartUpdateReportFreshnessPolicy = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateReportFreshnessPolicyRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateReportFreshnessPolicyResponse;
+};
+
+type DekartUpdateReportParameters = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateReportParametersRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateReportParametersResponse;
+};
+
+type DekartSetReportParameterValues = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.SetReportParameterValuesRequest;
+  readonly responseType: typeof proto_dekart_pb.SetReportParameterValuesResponse;
+};
+
+type DekartGetReportParameterOptions = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetReportParameterOptionsRequest;
+  readonly responseType: typeof proto_dekart_pb.GetReportParameterOptionsResponse;
+};
+
+type DekartGetMapConfigHistory = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetMapConfigHistoryRequest;
+  readonly responseType: typeof proto_dekart_pb.GetMapConfigHistoryResponse;
+};
+
+type DekartGetReport = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetReportRequest;
+  readonly responseType: typeof proto_dekart_pb.GetReportResponse;
+};
+
+type DekartListReports = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.ListReportsRequest;
+  readonly responseType: typeof proto_dekart_pb.ListReportsResponse;
+};
+
 type DekartCreateQuery = {
   readonly methodName: string;
   readonly service: typeof Dekart;
@@ -58,6 +157,33 @@ type DekartUpdateQuery = {
   readonly responseType: typeof proto_dekart_pb.UpdateQueryResponse;
 };
 
+type DekartUpdateQueryTitle = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateQueryTitleRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateQueryTitleResponse;
+};
+
+type DekartUpdateQueryDependencies = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateQueryDependenciesRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateQueryDependenciesResponse;
+};
+
+type DekartUpdateQueryParameterBindings = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateQueryParameterBindingsRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateQueryParameterBindingsResponse;
+};
+
 type DekartRunQuery = {
   readonly methodName: string;
   readonly service: typeof Dekart;
@@ -76,6 +202,33 @@ type DekartCancelQuery = {
   readonly responseType: typeof proto_dekart_pb.CancelQueryResponse;
 };
 
+type DekartCancelReport = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.CancelReportRequest;
+  readonly responseType: typeof proto_dekart_pb.CancelReportResponse;
+};
+
+type DekartRunAllQueries = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.RunAllQueriesRequest;
+  readonly responseType: typeof proto_dekart_pb.RunAllQueriesResponse;
+};
+
+type DekartCancelBatch = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.CancelBatchRequest;
+  readonly responseType: typeof proto_dekart_pb.CancelBatchResponse;
+};
+
 type DekartRemoveQuery = {
   readonly methodName: string;
   readonly service: typeof Dekart;
@@ -85,6 +238,177 @@ type DekartRemoveQuery = {
   readonly responseType: typeof proto_dekart_pb.RemoveQueryResponse;
 };
 
+type DekartRestoreQuery = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.RestoreQueryRequest;
+  readonly responseType: typeof proto_dekart_pb.RestoreQueryResponse;
+};
+
+type DekartGetQuery = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetQueryRequest;
+  readonly responseType: typeof proto_dekart_pb.GetQueryResponse;
+};
+
+type DekartGetExecutedQuery = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetExecutedQueryRequest;
+  readonly responseType: typeof proto_dekart_pb.GetExecutedQueryResponse;
+};
+
+type DekartGetJobPlan = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetJobPlanRequest;
+  readonly responseType: typeof proto_dekart_pb.GetJobPlanResponse;
+};
+
+type DekartRunQueryAndWait = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: true;
+  readonly requestType: typeof proto_dekart_pb.RunQueryAndWaitRequest;
+  readonly responseType: typeof proto_dekart_pb.RunQueryAndWaitResponse;
+};
+
+type DekartExportResult = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: true;
+  readonly requestType: typeof proto_dekart_pb.ExportResultRequest;
+  readonly responseType: typeof proto_dekart_pb.ExportResultResponse;
+};
+
+type DekartCompareResults = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.CompareResultsRequest;
+  readonly responseType: typeof proto_dekart_pb.CompareResultsResponse;
+};
+
+type DekartGetResultLineage = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetResultLineageRequest;
+  readonly responseType: typeof proto_dekart_pb.GetResultLineageResponse;
+};
+
+type DekartGetResultFieldTypes = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetResultFieldTypesRequest;
+  readonly responseType: typeof proto_dekart_pb.GetResultFieldTypesResponse;
+};
+
+type DekartGetJobEvents = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetJobEventsRequest;
+  readonly responseType: typeof proto_dekart_pb.GetJobEventsResponse;
+};
+
+type DekartGetQuerySchemaContext = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetQuerySchemaContextRequest;
+  readonly responseType: typeof proto_dekart_pb.GetQuerySchemaContextResponse;
+};
+
+type DekartGetSchemaSnapshot = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetSchemaSnapshotRequest;
+  readonly responseType: typeof proto_dekart_pb.GetSchemaSnapshotResponse;
+};
+
+type DekartRefreshSchemaSnapshot = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.RefreshSchemaSnapshotRequest;
+  readonly responseType: typeof proto_dekart_pb.RefreshSchemaSnapshotResponse;
+};
+
+type DekartCreateDataset = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.CreateDatasetRequest;
+  readonly responseType: typeof proto_dekart_pb.CreateDatasetResponse;
+};
+
+type DekartUpdateDataset = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateDatasetRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateDatasetResponse;
+};
+
+type DekartDeleteDataset = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.DeleteDatasetRequest;
+  readonly responseType: typeof proto_dekart_pb.DeleteDatasetResponse;
+};
+
+type DekartListDatasets = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.ListDatasetsRequest;
+  readonly responseType: typeof proto_dekart_pb.ListDatasetsResponse;
+};
+
+type DekartUploadFile = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: true;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UploadFileRequest;
+  readonly responseType: typeof proto_dekart_pb.UploadFileResponse;
+};
+
+type DekartRemoveFile = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.RemoveFileRequest;
+  readonly responseType: typeof proto_dekart_pb.RemoveFileResponse;
+};
+
 type DekartGetEnv = {
   readonly methodName: string;
   readonly service: typeof Dekart;
@@ -94,6 +418,105 @@ type DekartGetEnv = {
   readonly responseType: typeof proto_dekart_pb.GetEnvResponse;
 };
 
+type DekartGetCurrentUser = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetCurrentUserRequest;
+  readonly responseType: typeof proto_dekart_pb.GetCurrentUserResponse;
+};
+
+type DekartListRoleAssignments = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.ListRoleAssignmentsRequest;
+  readonly responseType: typeof proto_dekart_pb.ListRoleAssignmentsResponse;
+};
+
+type DekartSetRoleAssignment = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.SetRoleAssignmentRequest;
+  readonly responseType: typeof proto_dekart_pb.SetRoleAssignmentResponse;
+};
+
+type DekartRemoveRoleAssignment = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.RemoveRoleAssignmentRequest;
+  readonly responseType: typeof proto_dekart_pb.RemoveRoleAssignmentResponse;
+};
+
+type DekartGetResultLifecycle = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetResultLifecycleRequest;
+  readonly responseType: typeof proto_dekart_pb.GetResultLifecycleResponse;
+};
+
+type DekartReconcileResults = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.ReconcileResultsRequest;
+  readonly responseType: typeof proto_dekart_pb.ReconcileResultsResponse;
+};
+
+type DekartReencryptColumns = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.ReencryptColumnsRequest;
+  readonly responseType: typeof proto_dekart_pb.ReencryptColumnsResponse;
+};
+
+type DekartGetLogSettings = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetLogSettingsRequest;
+  readonly responseType: typeof proto_dekart_pb.GetLogSettingsResponse;
+};
+
+type DekartUpdateLogSettings = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.UpdateLogSettingsRequest;
+  readonly responseType: typeof proto_dekart_pb.UpdateLogSettingsResponse;
+};
+
+type DekartGetPermissionReport = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetPermissionReportRequest;
+  readonly responseType: typeof proto_dekart_pb.GetPermissionReportResponse;
+};
+
+type DekartGetUsage = {
+  readonly methodName: string;
+  readonly service: typeof Dekart;
+  readonly requestStream: false;
+  readonly responseStream: false;
+  readonly requestType: typeof proto_dekart_pb.GetUsageRequest;
+  readonly responseType: typeof proto_dekart_pb.GetUsageResponse;
+};
+
 type DekartGetReportStream = {
   readonly methodName: string;
   readonly service: typeof Dekart;
@@ -118,12 +541,59 @@ export class Dekart {
   static readonly ForkReport: DekartForkReport;
   static readonly UpdateReport: DekartUpdateReport;
   static readonly ArchiveReport: DekartArchiveReport;
+  static readonly ShareReport: DekartShareReport;
+  static readonly UpdateMapConfig: DekartUpdateMapConfig;
+  static readonly UpdateReportVariables: DekartUpdateReportVariables;
+  static readonly UpdateReportSettings: DekartUpdateReportSettings;
+  static readonly UpdateReportFreshnessPolicy: DekartUpdateReportFreshnessPolicy;
+  static readonly UpdateReportParameters: DekartUpdateReportParameters;
+  static readonly SetReportParameterValues: DekartSetReportParameterValues;
+  static readonly GetReportParameterOptions: DekartGetReportParameterOptions;
+  static readonly GetMapConfigHistory: DekartGetMapConfigHistory;
+  static readonly GetReport: DekartGetReport;
+  static readonly ListReports: DekartListReports;
   static readonly CreateQuery: DekartCreateQuery;
   static readonly UpdateQuery: DekartUpdateQuery;
+  static readonly UpdateQueryTitle: DekartUpdateQueryTitle;
+  static readonly UpdateQueryDependencies: DekartUpdateQueryDependencies;
+  static readonly UpdateQueryParameterBindings: DekartUpdateQueryParameterBindings;
   static readonly RunQuery: DekartRunQuery;
   static readonly CancelQuery: DekartCancelQuery;
+  static readonly CancelReport: DekartCancelReport;
+  static readonly RunAllQueries: DekartRunAllQueries;
+  static readonly CancelBatch: DekartCancelBatch;
   static readonly RemoveQuery: DekartRemoveQuery;
+  static readonly RestoreQuery: DekartRestoreQuery;
+  static readonly GetQuery: DekartGetQuery;
+  static readonly GetExecutedQuery: DekartGetExecutedQuery;
+  static readonly GetJobPlan: DekartGetJobPlan;
+  static readonly RunQueryAndWait: DekartRunQueryAndWait;
+  static readonly ExportResult: DekartExportResult;
+  static readonly CompareResults: DekartCompareResults;
+  static readonly GetResultLineage: DekartGetResultLineage;
+  static readonly GetResultFieldTypes: DekartGetResultFieldTypes;
+  static readonly GetJobEvents: DekartGetJobEvents;
+  static readonly GetQuerySchemaContext: DekartGetQuerySchemaContext;
+  static readonly GetSchemaSnapshot: DekartGetSchemaSnapshot;
+  static readonly RefreshSchemaSnapshot: DekartRefreshSchemaSnapshot;
+  static readonly CreateDataset: DekartCreateDataset;
+  static readonly UpdateDataset: DekartUpdateDataset;
+  static readonly DeleteDataset: DekartDeleteDataset;
+  static readonly ListDatasets: DekartListDatasets;
+  static readonly UploadFile: DekartUploadFile;
+  static readonly RemoveFile: DekartRemoveFile;
   static readonly GetEnv: DekartGetEnv;
+  static readonly GetCurrentUser: DekartGetCurrentUser;
+  static readonly ListRoleAssignments: DekartListRoleAssignments;
+  static readonly SetRoleAssignment: DekartSetRoleAssignment;
+  static readonly RemoveRoleAssignment: DekartRemoveRoleAssignment;
+  static readonly GetResultLifecycle: DekartGetResultLifecycle;
+  static readonly ReconcileResults: DekartReconcileResults;
+  static readonly ReencryptColumns: DekartReencryptColumns;
+  static readonly GetLogSettings: DekartGetLogSettings;
+  static readonly UpdateLogSettings: DekartUpdateLogSettings;
+  static readonly GetPermissionReport: DekartGetPermissionReport;
+  static readonly GetUsage: DekartGetUsage;
   static readonly GetReportStream: DekartGetReportStream;
   static readonly GetReportListStream: DekartGetReportListStream;
 }
@@ -196,6 +666,105 @@ export class DekartClient {
     requestMessage: proto_dekart_pb.ArchiveReportRequest,
     callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ArchiveReportResponse|null) => void
   ): UnaryResponse;
+  shareReport(
+    requestMessage: proto_dekart_pb.ShareReportRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ShareReportResponse|null) => void
+  ): UnaryResponse;
+  shareReport(
+    requestMessage: proto_dekart_pb.ShareReportRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ShareReportResponse|null) => void
+  ): UnaryResponse;
+  updateMapConfig(
+    requestMessage: proto_dekart_pb.UpdateMapConfigRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateMapConfigResponse|null) => void
+  ): UnaryResponse;
+  updateMapConfig(
+    requestMessage: proto_dekart_pb.UpdateMapConfigRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateMapConfigResponse|null) => void
+  ): UnaryResponse;
+  updateReportVariables(
+    requestMessage: proto_dekart_pb.UpdateReportVariablesRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportVariablesResponse|null) => void
+  ): UnaryResponse;
+  updateReportVariables(
+    requestMessage: proto_dekart_pb.UpdateReportVariablesRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportVariablesResponse|null) => void
+  ): UnaryResponse;
+  updateReportSettings(
+    requestMessage: proto_dekart_pb.UpdateReportSettingsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportSettingsResponse|null) => void
+  ): UnaryResponse;
+  updateReportSettings(
+    requestMessage: proto_dekart_pb.UpdateReportSettingsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportSettingsResponse|null) => void
+  ): UnaryResponse;
+  updateReportFreshnessPolicy(
+    requestMessage: proto_dekart_pb.UpdateReportFreshnessPolicyRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportFreshnessPolicyResponse|null) => void
+  ): UnaryResponse;
+  updateReportFreshnessPolicy(
+    requestMessage: proto_dekart_pb.UpdateReportFreshnessPolicyRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportFreshnessPolicyResponse|null) => void
+  ): UnaryResponse;
+  updateReportParameters(
+    requestMessage: proto_dekart_pb.UpdateReportParametersRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportParametersResponse|null) => void
+  ): UnaryResponse;
+  updateReportParameters(
+    requestMessage: proto_dekart_pb.UpdateReportParametersRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateReportParametersResponse|null) => void
+  ): UnaryResponse;
+  setReportParameterValues(
+    requestMessage: proto_dekart_pb.SetReportParameterValuesRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.SetReportParameterValuesResponse|null) => void
+  ): UnaryResponse;
+  setReportParameterValues(
+    requestMessage: proto_dekart_pb.SetReportParameterValuesRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.SetReportParameterValuesResponse|null) => void
+  ): UnaryResponse;
+  getReportParameterOptions(
+    requestMessage: proto_dekart_pb.GetReportParameterOptionsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetReportParameterOptionsResponse|null) => void
+  ): UnaryResponse;
+  getReportParameterOptions(
+    requestMessage: proto_dekart_pb.GetReportParameterOptionsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetReportParameterOptionsResponse|null) => void
+  ): UnaryResponse;
+  getMapConfigHistory(
+    requestMessage: proto_dekart_pb.GetMapConfigHistoryRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetMapConfigHistoryResponse|null) => void
+  ): UnaryResponse;
+  getMapConfigHistory(
+    requestMessage: proto_dekart_pb.GetMapConfigHistoryRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetMapConfigHistoryResponse|null) => void
+  ): UnaryResponse;
+  getReport(
+    requestMessage: proto_dekart_pb.GetReportRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetReportResponse|null) => void
+  ): UnaryResponse;
+  getReport(
+    requestMessage: proto_dekart_pb.GetReportRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetReportResponse|null) => void
+  ): UnaryResponse;
+  listReports(
+    requestMessage: proto_dekart_pb.ListReportsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ListReportsResponse|null) => void
+  ): UnaryResponse;
+  listReports(
+    requestMessage: proto_dekart_pb.ListReportsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ListReportsResponse|null) => void
+  ): UnaryResponse;
   createQuery(
     requestMessage: proto_dekart_pb.CreateQueryRequest,
     metadata: grpc.Metadata,
@@ -214,6 +783,33 @@ export class DekartClient {
     requestMessage: proto_dekart_pb.UpdateQueryRequest,
     callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryResponse|null) => void
   ): UnaryResponse;
+  updateQueryTitle(
+    requestMessage: proto_dekart_pb.UpdateQueryTitleRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryTitleResponse|null) => void
+  ): UnaryResponse;
+  updateQueryTitle(
+    requestMessage: proto_dekart_pb.UpdateQueryTitleRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryTitleResponse|null) => void
+  ): UnaryResponse;
+  updateQueryDependencies(
+    requestMessage: proto_dekart_pb.UpdateQueryDependenciesRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryDependenciesResponse|null) => void
+  ): UnaryResponse;
+  updateQueryDependencies(
+    requestMessage: proto_dekart_pb.UpdateQueryDependenciesRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryDependenciesResponse|null) => void
+  ): UnaryResponse;
+  updateQueryParameterBindings(
+    requestMessage: proto_dekart_pb.UpdateQueryParameterBindingsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryParameterBindingsResponse|null) => void
+  ): UnaryResponse;
+  updateQueryParameterBindings(
+    requestMessage: proto_dekart_pb.UpdateQueryParameterBindingsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateQueryParameterBindingsResponse|null) => void
+  ): UnaryResponse;
   runQuery(
     requestMessage: proto_dekart_pb.RunQueryRequest,
     metadata: grpc.Metadata,
@@ -232,6 +828,33 @@ export class DekartClient {
     requestMessage: proto_dekart_pb.CancelQueryRequest,
     callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CancelQueryResponse|null) => void
   ): UnaryResponse;
+  cancelReport(
+    requestMessage: proto_dekart_pb.CancelReportRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CancelReportResponse|null) => void
+  ): UnaryResponse;
+  cancelReport(
+    requestMessage: proto_dekart_pb.CancelReportRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CancelReportResponse|null) => void
+  ): UnaryResponse;
+  runAllQueries(
+    requestMessage: proto_dekart_pb.RunAllQueriesRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RunAllQueriesResponse|null) => void
+  ): UnaryResponse;
+  runAllQueries(
+    requestMessage: proto_dekart_pb.RunAllQueriesRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RunAllQueriesResponse|null) => void
+  ): UnaryResponse;
+  cancelBatch(
+    requestMessage: proto_dekart_pb.CancelBatchRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CancelBatchResponse|null) => void
+  ): UnaryResponse;
+  cancelBatch(
+    requestMessage: proto_dekart_pb.CancelBatchRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CancelBatchResponse|null) => void
+  ): UnaryResponse;
   removeQuery(
     requestMessage: proto_dekart_pb.RemoveQueryRequest,
     metadata: grpc.Metadata,
@@ -241,6 +864,153 @@ export class DekartClient {
     requestMessage: proto_dekart_pb.RemoveQueryRequest,
     callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RemoveQueryResponse|null) => void
   ): UnaryResponse;
+  restoreQuery(
+    requestMessage: proto_dekart_pb.RestoreQueryRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RestoreQueryResponse|null) => void
+  ): UnaryResponse;
+  restoreQuery(
+    requestMessage: proto_dekart_pb.RestoreQueryRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RestoreQueryResponse|null) => void
+  ): UnaryResponse;
+  getQuery(
+    requestMessage: proto_dekart_pb.GetQueryRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetQueryResponse|null) => void
+  ): UnaryResponse;
+  getQuery(
+    requestMessage: proto_dekart_pb.GetQueryRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetQueryResponse|null) => void
+  ): UnaryResponse;
+  getExecutedQuery(
+    requestMessage: proto_dekart_pb.GetExecutedQueryRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetExecutedQueryResponse|null) => void
+  ): UnaryResponse;
+  getExecutedQuery(
+    requestMessage: proto_dekart_pb.GetExecutedQueryRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetExecutedQueryResponse|null) => void
+  ): UnaryResponse;
+  getJobPlan(
+    requestMessage: proto_dekart_pb.GetJobPlanRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetJobPlanResponse|null) => void
+  ): UnaryResponse;
+  getJobPlan(
+    requestMessage: proto_dekart_pb.GetJobPlanRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetJobPlanResponse|null) => void
+  ): UnaryResponse;
+  runQueryAndWait(requestMessage: proto_dekart_pb.RunQueryAndWaitRequest, metadata?: grpc.Metadata): ResponseStream<proto_dekart_pb.RunQueryAndWaitResponse>;
+  exportResult(requestMessage: proto_dekart_pb.ExportResultRequest, metadata?: grpc.Metadata): ResponseStream<proto_dekart_pb.ExportResultResponse>;
+  compareResults(
+    requestMessage: proto_dekart_pb.CompareResultsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CompareResultsResponse|null) => void
+  ): UnaryResponse;
+  compareResults(
+    requestMessage: proto_dekart_pb.CompareResultsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CompareResultsResponse|null) => void
+  ): UnaryResponse;
+  getResultLineage(
+    requestMessage: proto_dekart_pb.GetResultLineageRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetResultLineageResponse|null) => void
+  ): UnaryResponse;
+  getResultLineage(
+    requestMessage: proto_dekart_pb.GetResultLineageRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetResultLineageResponse|null) => void
+  ): UnaryResponse;
+  getResultFieldTypes(
+    requestMessage: proto_dekart_pb.GetResultFieldTypesRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetResultFieldTypesResponse|null) => void
+  ): UnaryResponse;
+  getResultFieldTypes(
+    requestMessage: proto_dekart_pb.GetResultFieldTypesRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetResultFieldTypesResponse|null) => void
+  ): UnaryResponse;
+  getJobEvents(
+    requestMessage: proto_dekart_pb.GetJobEventsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetJobEventsResponse|null) => void
+  ): UnaryResponse;
+  getJobEvents(
+    requestMessage: proto_dekart_pb.GetJobEventsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetJobEventsResponse|null) => void
+  ): UnaryResponse;
+  getQuerySchemaContext(
+    requestMessage: proto_dekart_pb.GetQuerySchemaContextRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetQuerySchemaContextResponse|null) => void
+  ): UnaryResponse;
+  getQuerySchemaContext(
+    requestMessage: proto_dekart_pb.GetQuerySchemaContextRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetQuerySchemaContextResponse|null) => void
+  ): UnaryResponse;
+  getSchemaSnapshot(
+    requestMessage: proto_dekart_pb.GetSchemaSnapshotRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetSchemaSnapshotResponse|null) => void
+  ): UnaryResponse;
+  getSchemaSnapshot(
+    requestMessage: proto_dekart_pb.GetSchemaSnapshotRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetSchemaSnapshotResponse|null) => void
+  ): UnaryResponse;
+  refreshSchemaSnapshot(
+    requestMessage: proto_dekart_pb.RefreshSchemaSnapshotRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RefreshSchemaSnapshotResponse|null) => void
+  ): UnaryResponse;
+  refreshSchemaSnapshot(
+    requestMessage: proto_dekart_pb.RefreshSchemaSnapshotRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RefreshSchemaSnapshotResponse|null) => void
+  ): UnaryResponse;
+  createDataset(
+    requestMessage: proto_dekart_pb.CreateDatasetRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CreateDatasetResponse|null) => void
+  ): UnaryResponse;
+  createDataset(
+    requestMessage: proto_dekart_pb.CreateDatasetRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.CreateDatasetResponse|null) => void
+  ): UnaryResponse;
+  updateDataset(
+    requestMessage: proto_dekart_pb.UpdateDatasetRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateDatasetResponse|null) => void
+  ): UnaryResponse;
+  updateDataset(
+    requestMessage: proto_dekart_pb.UpdateDatasetRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateDatasetResponse|null) => void
+  ): UnaryResponse;
+  deleteDataset(
+    requestMessage: proto_dekart_pb.DeleteDatasetRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.DeleteDatasetResponse|null) => void
+  ): UnaryResponse;
+  deleteDataset(
+    requestMessage: proto_dekart_pb.DeleteDatasetRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.DeleteDatasetResponse|null) => void
+  ): UnaryResponse;
+  listDatasets(
+    requestMessage: proto_dekart_pb.ListDatasetsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ListDatasetsResponse|null) => void
+  ): UnaryResponse;
+  listDatasets(
+    requestMessage: proto_dekart_pb.ListDatasetsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ListDatasetsResponse|null) => void
+  ): UnaryResponse;
+  uploadFile(metadata?: grpc.Metadata): RequestStream<proto_dekart_pb.UploadFileRequest>;
+  removeFile(
+    requestMessage: proto_dekart_pb.RemoveFileRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RemoveFileResponse|null) => void
+  ): UnaryResponse;
+  removeFile(
+    requestMessage: proto_dekart_pb.RemoveFileRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RemoveFileResponse|null) => void
+  ): UnaryResponse;
   getEnv(
     requestMessage: proto_dekart_pb.GetEnvRequest,
     metadata: grpc.Metadata,
@@ -250,6 +1020,105 @@ export class DekartClient {
     requestMessage: proto_dekart_pb.GetEnvRequest,
     callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetEnvResponse|null) => void
   ): UnaryResponse;
+  getCurrentUser(
+    requestMessage: proto_dekart_pb.GetCurrentUserRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetCurrentUserResponse|null) => void
+  ): UnaryResponse;
+  getCurrentUser(
+    requestMessage: proto_dekart_pb.GetCurrentUserRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetCurrentUserResponse|null) => void
+  ): UnaryResponse;
+  listRoleAssignments(
+    requestMessage: proto_dekart_pb.ListRoleAssignmentsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ListRoleAssignmentsResponse|null) => void
+  ): UnaryResponse;
+  listRoleAssignments(
+    requestMessage: proto_dekart_pb.ListRoleAssignmentsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ListRoleAssignmentsResponse|null) => void
+  ): UnaryResponse;
+  setRoleAssignment(
+    requestMessage: proto_dekart_pb.SetRoleAssignmentRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.SetRoleAssignmentResponse|null) => void
+  ): UnaryResponse;
+  setRoleAssignment(
+    requestMessage: proto_dekart_pb.SetRoleAssignmentRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.SetRoleAssignmentResponse|null) => void
+  ): UnaryResponse;
+  removeRoleAssignment(
+    requestMessage: proto_dekart_pb.RemoveRoleAssignmentRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RemoveRoleAssignmentResponse|null) => void
+  ): UnaryResponse;
+  removeRoleAssignment(
+    requestMessage: proto_dekart_pb.RemoveRoleAssignmentRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.RemoveRoleAssignmentResponse|null) => void
+  ): UnaryResponse;
+  getResultLifecycle(
+    requestMessage: proto_dekart_pb.GetResultLifecycleRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetResultLifecycleResponse|null) => void
+  ): UnaryResponse;
+  getResultLifecycle(
+    requestMessage: proto_dekart_pb.GetResultLifecycleRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetResultLifecycleResponse|null) => void
+  ): UnaryResponse;
+  reconcileResults(
+    requestMessage: proto_dekart_pb.ReconcileResultsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ReconcileResultsResponse|null) => void
+  ): UnaryResponse;
+  reconcileResults(
+    requestMessage: proto_dekart_pb.ReconcileResultsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ReconcileResultsResponse|null) => void
+  ): UnaryResponse;
+  reencryptColumns(
+    requestMessage: proto_dekart_pb.ReencryptColumnsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ReencryptColumnsResponse|null) => void
+  ): UnaryResponse;
+  reencryptColumns(
+    requestMessage: proto_dekart_pb.ReencryptColumnsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.ReencryptColumnsResponse|null) => void
+  ): UnaryResponse;
+  getLogSettings(
+    requestMessage: proto_dekart_pb.GetLogSettingsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetLogSettingsResponse|null) => void
+  ): UnaryResponse;
+  getLogSettings(
+    requestMessage: proto_dekart_pb.GetLogSettingsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetLogSettingsResponse|null) => void
+  ): UnaryResponse;
+  updateLogSettings(
+    requestMessage: proto_dekart_pb.UpdateLogSettingsRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateLogSettingsResponse|null) => void
+  ): UnaryResponse;
+  updateLogSettings(
+    requestMessage: proto_dekart_pb.UpdateLogSettingsRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.UpdateLogSettingsResponse|null) => void
+  ): UnaryResponse;
+  getPermissionReport(
+    requestMessage: proto_dekart_pb.GetPermissionReportRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetPermissionReportResponse|null) => void
+  ): UnaryResponse;
+  getPermissionReport(
+    requestMessage: proto_dekart_pb.GetPermissionReportRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetPermissionReportResponse|null) => void
+  ): UnaryResponse;
+  getUsage(
+    requestMessage: proto_dekart_pb.GetUsageRequest,
+    metadata: grpc.Metadata,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetUsageResponse|null) => void
+  ): UnaryResponse;
+  getUsage(
+    requestMessage: proto_dekart_pb.GetUsageRequest,
+    callback: (error: ServiceError|null, responseMessage: proto_dekart_pb.GetUsageResponse|null) => void
+  ): UnaryResponse;
   getReportStream(requestMessage: proto_dekart_pb.ReportStreamRequest, metadata?: grpc.Metadata): ResponseStream<proto_dekart_pb.ReportStreamResponse>;
   getReportListStream(requestMessage: proto_dekart_pb.ReportListRequest, metadata?: grpc.Metadata): ResponseStream<proto_dekart_pb.ReportListResponse>;
 }
@@ -0,0 +1,226 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nullID stored for empty id
+func nullID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+// batchProgress of last batch of report, nil without batch; member is finished once it's skipped,
+// its status is unspecified after failure or cancel, or done with stored result
+func batchProgress(queries []*proto.Query) *proto.BatchProgress {
+	byBatch := map[string]*proto.BatchProgress{}
+	var last *proto.BatchProgress
+	for _, query := range queries {
+		if query.BatchId == "" {
+			continue
+		}
+		progress, ok := byBatch[query.BatchId]
+		if !ok {
+			progress = &proto.BatchProgress{BatchId: query.BatchId}
+			byBatch[query.BatchId] = progress
+		}
+		progress.Total++
+		switch {
+		case query.JobStatus == proto.Query_JOB_STATUS_SKIPPED:
+			progress.Complete++
+			progress.Skipped++
+		case query.JobStatus == proto.Query_JOB_STATUS_UNSPECIFIED:
+			progress.Complete++
+			if query.JobError != "" {
+				progress.Failed++
+			}
+		case query.JobStatus == proto.Query_JOB_STATUS_DONE && (query.JobResultId != "" || query.ResultTable != ""):
+			progress.Complete++
+		}
+		// RunAllQueries clears earlier batch of report, concurrent batches leave members of both
+		if last == nil || progress.Total > last.Total {
+			last = progress
+		}
+	}
+	return last
+}
+
+// reportAuthorized when report exists and user is its author
+func (s Server) reportAuthorized(ctx context.Context, reportID string, email string) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`select count(*) from reports where id=$1 and author_email=$2`,
+		reportID,
+		email,
+	).Scan(&count)
+	return count > 0, err
+}
+
+// batchQueryIDs of report in order of creation
+func (s Server) batchQueryIDs(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var queryIDs []string
+	for rows.Next() {
+		var queryID string
+		if err := rows.Scan(&queryID); err != nil {
+			return nil, err
+		}
+		queryIDs = append(queryIDs, queryID)
+	}
+	return queryIDs, rows.Err()
+}
+
+// batchRunRequest of query of batch; acknowledged bytes apply to estimate of each query, not to sum of them
+func batchRunRequest(req *proto.RunAllQueriesRequest, queryID string) *proto.RunQueryRequest {
+	return &proto.RunQueryRequest{QueryId: queryID, Variables: req.Variables, AcknowledgedBytes: req.AcknowledgedBytes}
+}
+
+// RunAllQueries of report as one batch; every query runs like RunQuery once its dependencies are done,
+// query which fails doesn't stop others except its dependents
+func (s Server) RunAllQueries(ctx context.Context, req *proto.RunAllQueriesRequest) (*proto.RunAllQueriesResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.ReportId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if req.AcknowledgedBytes < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid acknowledged_bytes %d", req.AcknowledgedBytes)
+	}
+	authorized, err := s.reportAuthorized(ctx, req.ReportId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if !authorized {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	queries, err := s.getQueries(ctx, req.ReportId)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	queryIDs := make([]string, len(queries))
+	titles := map[string]string{}
+	dependsOn := map[string][]string{}
+	for i, query := range queries {
+		queryIDs[i] = query.Id
+		titles[query.Id] = query.Title
+		dependsOn[query.Id] = query.DependsOn
+	}
+	// cycles are rejected on save, concurrent saves could still create one
+	if _, err := dependencyOrder(queryIDs, dependsOn); err != nil {
+		if cycle, ok := err.(*dependencyCycleError); ok {
+			return nil, status.Error(codes.FailedPrecondition, cycle.named(titles))
+		}
+		return nil, internalError(err)
+	}
+	// queries of earlier batch leave it, so progress is counted for this batch only
+	if _, err := s.db.ExecContext(ctx, `update queries set batch_id = null where report_id=$1`, req.ReportId); err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	batchID := newUUID()
+	// dependents are started after response is sent
+	batchCtx := batchContext{ctx}
+	start := func(queryID string) batchStart {
+		run := &proto.BatchRun{QueryId: queryID}
+		queryRes, j, err := s.runQuery(batchCtx, batchRunRequest(req, queryID), batchID)
+		if err != nil {
+			// failed query is batch member, so its dependents are skipped and progress counts it
+			run.Error = status.Convert(err).Message()
+			s.storeBatchStatus(batchCtx, req.ReportId, queryID, batchID, proto.Query_JOB_STATUS_UNSPECIFIED, run.Error, "")
+			return batchStart{run: run}
+		}
+		run.JobId = queryRes.JobId
+		run.Reused = queryRes.Reused
+		finished := make(chan bool, 1)
+		if j == nil {
+			finished <- true
+		} else {
+			go func() {
+				<-j.Ctx.Done()
+				finished <- j.GetState() == job.StateDone
+			}()
+		}
+		return batchStart{run: run, finished: finished}
+	}
+	wait := func(queryID string) {
+		s.storeBatchStatus(batchCtx, req.ReportId, queryID, batchID, proto.Query_JOB_STATUS_PENDING, "", "")
+	}
+	skip := func(queryID string, dependencyID string) {
+		dependency := titles[dependencyID]
+		if dependency == "" {
+			dependency = dependencyID
+		}
+		message := fmt.Sprintf("skipped, dependency %s didn't finish", dependency)
+		log.Info().Str("queryID", queryID).Str("dependencyID", dependencyID).Str("batchID", batchID).Msg("Query of batch skipped")
+		s.storeBatchStatus(batchCtx, req.ReportId, queryID, batchID, proto.Query_JOB_STATUS_SKIPPED, message, dependencyID)
+	}
+	runs, err := scheduleBatch(queryIDs, dependsOn, start, wait, skip)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	log.Info().Str("reportID", req.ReportId).Str("batchID", batchID).Int("queries", len(queryIDs)).Msg("Batch started")
+	return &proto.RunAllQueriesResponse{BatchId: batchID, Runs: runs}, nil
+}
+
+// CancelBatch jobs on this and other replicas; queries run again by RunQuery left batch and keep their jobs
+func (s Server) CancelBatch(ctx context.Context, req *proto.CancelBatchRequest) (*proto.CancelBatchResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.ReportId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if _, err := uuid.Parse(req.BatchId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	authorized, err := s.reportAuthorized(ctx, req.ReportId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if !authorized {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	queryIDs, err := s.batchQueryIDs(ctx,
+		fmt.Sprintf(`select id from queries where report_id=$1 and %s=$2`, s.dialect.Text("batch_id")),
+		req.ReportId,
+		req.BatchId,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	reason := proto.CancelReason_CANCEL_REASON_USER_REQUEST
+	cancelled := s.jobs.CancelByBatch(req.BatchId, reason) + s.jobs.CancelRemote(queryIDs, reason)
+	log.Info().Str("reportID", req.ReportId).Str("batchID", req.BatchId).Int("cancelled", cancelled).Msg("Batch cancelled")
+	return &proto.CancelBatchResponse{CancelledCount: int32(cancelled)}, nil
+}
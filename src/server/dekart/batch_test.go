@@ -0,0 +1,103 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchProgress(t *testing.T) {
+	if progress := batchProgress([]*proto.Query{{Id: "single", JobStatus: proto.Query_JOB_STATUS_RUNNING}}); progress != nil {
+		t.Errorf("expected no progress without batch, got %v", progress)
+	}
+	queries := []*proto.Query{
+		{Id: "running", BatchId: "b", JobStatus: proto.Query_JOB_STATUS_RUNNING},
+		{Id: "reading", BatchId: "b", JobStatus: proto.Query_JOB_STATUS_DONE},
+		{Id: "done", BatchId: "b", JobStatus: proto.Query_JOB_STATUS_DONE, JobResultId: "result"},
+		{Id: "table", BatchId: "b", JobStatus: proto.Query_JOB_STATUS_DONE, ResultTable: "p.d.t"},
+		{Id: "failed", BatchId: "b", JobStatus: proto.Query_JOB_STATUS_UNSPECIFIED, JobError: "denied"},
+		{Id: "cancelled", BatchId: "b", JobStatus: proto.Query_JOB_STATUS_UNSPECIFIED},
+		// run again by RunQuery, left batch
+		{Id: "single", JobStatus: proto.Query_JOB_STATUS_RUNNING},
+		{Id: "concurrent", BatchId: "a", JobStatus: proto.Query_JOB_STATUS_RUNNING},
+	}
+	progress := batchProgress(queries)
+	expected := &proto.BatchProgress{BatchId: "b", Total: 6, Complete: 4, Failed: 1}
+	if progress == nil || progress.BatchId != expected.BatchId || progress.Total != expected.Total ||
+		progress.Complete != expected.Complete || progress.Failed != expected.Failed {
+		t.Errorf("expected %v, got %v", expected, progress)
+	}
+}
+
+func TestBatchRunRequest(t *testing.T) {
+	req := &proto.RunAllQueriesRequest{ReportId: testReportID, Variables: map[string]string{"n": "5"}, AcknowledgedBytes: 1 << 40}
+	run := batchRunRequest(req, testQueryID)
+	if run.QueryId != testQueryID || run.Variables["n"] != "5" || run.AcknowledgedBytes != 1<<40 {
+		t.Errorf("unexpected run request %v", run)
+	}
+	s, mock := newTestServer(t)
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	_, err := s.RunAllQueries(testClaimsContext(), &proto.RunAllQueriesRequest{ReportId: testReportID, AcknowledgedBytes: -1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCancelBatch(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	batchID := newUUID()
+	var members []*job.Job
+	for i := 0; i < 3; i++ {
+		j := s.jobs.New(context.Background(), testReportID, newUUID())
+		j.BatchID = batchID
+		members = append(members, j)
+	}
+	single := s.jobs.New(context.Background(), testReportID, testQueryID)
+	for _, j := range append(members, single) {
+		go func(j *job.Job) {
+			for {
+				select {
+				case <-j.Status:
+				case <-j.Ctx.Done():
+					return
+				}
+			}
+		}(j)
+	}
+	defer s.jobs.CancelJob(single.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	// member which failed before cancel is finished already
+	members[2].Abort()
+
+	_, err := s.CancelBatch(testClaimsContext(), &proto.CancelBatchRequest{ReportId: testReportID, BatchId: "batch"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	mock.ExpectQuery("select count").WithArgs(testReportID, user.UnknownEmail).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("select id from queries").WithArgs(testReportID, batchID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	res, err := s.CancelBatch(testClaimsContext(), &proto.CancelBatchRequest{ReportId: testReportID, BatchId: batchID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CancelledCount != 2 || members[0].GetState() != job.StateCancelled || members[1].GetState() != job.StateCancelled {
+		t.Errorf("expected running members cancelled, got %d", res.CancelledCount)
+	}
+	if single.GetState() != job.StatePending {
+		t.Errorf("expected job outside of batch kept, got %s", single.GetState())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,109 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bucketCheckTTL of accessible bucket, bucket is checked again after it
+const bucketCheckTTL = 5 * time.Minute
+
+// storageMisconfiguredError of bucket which can't receive results, job is not started
+type storageMisconfiguredError struct {
+	bucket string
+	err    error
+}
+
+func (e *storageMisconfiguredError) Error() string {
+	var apiErr *googleapi.Error
+	if errors.As(e.err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusNotFound:
+			return fmt.Sprintf("bucket %s not found, check DEKART_CLOUD_STORAGE_BUCKET", e.bucket)
+		case http.StatusForbidden:
+			return fmt.Sprintf("access to bucket %s denied, grant roles/storage.objectAdmin to service account of dekart", e.bucket)
+		}
+	}
+	return fmt.Sprintf("bucket %s is not accessible: %s", e.bucket, e.err)
+}
+
+func (e *storageMisconfiguredError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus of storage error is failed precondition with bucket in details, query runs when bucket is fixed
+func (e *storageMisconfiguredError) GRPCStatus() *status.Status {
+	st := status.New(codes.FailedPrecondition, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "STORAGE_MISCONFIGURED",
+		Domain:   "dekart",
+		Metadata: map[string]string{"bucket": e.bucket},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// bucketAttrs reads metadata of bucket, error when bucket is missing or inaccessible
+type bucketAttrs func(ctx context.Context, bucket *storage.BucketHandle) error
+
+func readBucketAttrs(ctx context.Context, bucket *storage.BucketHandle) error {
+	start := time.Now()
+	_, err := bucket.Attrs(ctx)
+	metrics.Observe(metrics.GCS, "bucket_attrs", start, err)
+	return err
+}
+
+// bucketName of handle, BucketHandle doesn't expose its name in this version of storage client
+func bucketName(bucket *storage.BucketHandle) string {
+	return bucket.Object("").BucketName()
+}
+
+// bucketCheck of buckets before jobs are started, concurrency safe; only accessible buckets are cached,
+// so fixed bucket is used on next run
+type bucketCheck struct {
+	ttl       time.Duration
+	attrs     bucketAttrs
+	mutex     sync.Mutex
+	checkedAt map[string]time.Time
+	now       func() time.Time
+}
+
+func newBucketCheck(ttl time.Duration, attrs bucketAttrs) *bucketCheck {
+	return &bucketCheck{
+		ttl:       ttl,
+		attrs:     attrs,
+		checkedAt: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// check bucket receiving results, storageMisconfiguredError when it's missing or inaccessible
+func (c *bucketCheck) check(ctx context.Context, bucket *storage.BucketHandle) error {
+	name := bucketName(bucket)
+	c.mutex.Lock()
+	checkedAt, ok := c.checkedAt[name]
+	c.mutex.Unlock()
+	if ok && c.now().Sub(checkedAt) < c.ttl {
+		return nil
+	}
+	if err := c.attrs(ctx, bucket); err != nil {
+		return &storageMisconfiguredError{bucket: name, err: err}
+	}
+	c.mutex.Lock()
+	c.checkedAt[name] = c.now()
+	c.mutex.Unlock()
+	return nil
+}
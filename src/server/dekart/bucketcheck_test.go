@@ -0,0 +1,72 @@
+package dekart
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBucketCheck(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	var checked int
+	var failure error
+	c := newBucketCheck(time.Minute, func(ctx context.Context, bucket *storage.BucketHandle) error {
+		checked++
+		return failure
+	})
+	c.now = func() time.Time { return now }
+	bucket := (&storage.Client{}).Bucket("dekart")
+	for i := 0; i < 2; i++ {
+		if err := c.check(context.Background(), bucket); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if checked != 1 {
+		t.Errorf("expected accessible bucket cached, checked %d times", checked)
+	}
+	now = now.Add(time.Minute)
+	failure = &googleapi.Error{Code: 404}
+	for i := 0; i < 2; i++ {
+		err := c.check(context.Background(), bucket)
+		if _, ok := err.(*storageMisconfiguredError); !ok || !strings.Contains(err.Error(), "bucket dekart not found") {
+			t.Fatalf("expected storage misconfigured error, got %v", err)
+		}
+	}
+	// failed check is not cached, fixed bucket is used on next run
+	if checked != 3 {
+		t.Errorf("expected failed check not cached, checked %d times", checked)
+	}
+}
+
+func TestRunQueryJobMisconfiguredBucket(t *testing.T) {
+	s, mock := newTestServer(t)
+	s.bucket = (&storage.Client{}).Bucket("missing")
+	s.buckets = newBucketCheck(bucketCheckTTL, func(ctx context.Context, bucket *storage.BucketHandle) error {
+		return &googleapi.Error{Code: 403}
+	})
+	_, err := s.runQueryJob(testClaimsContext(), testQueryID, queryJobSource{reportID: testReportID, queryText: "select 1"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	// no result is created and no job is started
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if jobs := s.jobs.Stats().Jobs; jobs != 0 {
+		t.Errorf("expected no job started, got %d", jobs)
+	}
+	st := status.Convert(internalError(err))
+	if st.Code() != codes.FailedPrecondition || len(st.Details()) != 1 {
+		t.Fatalf("expected failed precondition with details, got %v", st)
+	}
+	if info, ok := st.Details()[0].(*errdetails.ErrorInfo); !ok || info.Reason != "STORAGE_MISCONFIGURED" || info.Metadata["bucket"] != "missing" {
+		t.Errorf("unexpected details %v", st.Details())
+	}
+}
@@ -0,0 +1,441 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxCompareSize of both results, compared rows are kept in memory
+const defaultMaxCompareSize = 100 << 20
+
+// changeColumn of diff result, first column with added, removed or changed
+const changeColumn = "_change"
+
+// change types of diff rows
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// parseMaxCompareSize of DEKART_COMPARE_MAX_RESULT_SIZE in bytes
+func parseMaxCompareSize(value string) (int64, error) {
+	if value == "" {
+		return defaultMaxCompareSize, nil
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_COMPARE_MAX_RESULT_SIZE %s, expected positive number of bytes", value)
+	}
+	return size, nil
+}
+
+func maxCompareSize() int64 {
+	size, err := parseMaxCompareSize(os.Getenv("DEKART_COMPARE_MAX_RESULT_SIZE"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return size
+}
+
+// compareInputError when results can't be compared as requested, like missing or repeated key
+type compareInputError struct {
+	message string
+}
+
+func (e *compareInputError) Error() string {
+	return e.message
+}
+
+// compareTooLargeError when results are too large to be compared in memory
+type compareTooLargeError struct {
+	Size  int64
+	Limit int64
+}
+
+func (e *compareTooLargeError) Error() string {
+	return fmt.Sprintf("results of %d bytes exceed compare limit of %d bytes, compare smaller results", e.Size, e.Limit)
+}
+
+// errParquetCompare when result is stored as Parquet, only CSV results are read by server
+var errParquetCompare = errors.New("comparing Parquet results is not supported, run query with CSV result format")
+
+// baseRow of earlier result; matched is count of head rows matched by full row hash or one for matched key
+type baseRow struct {
+	values  []string
+	matched int
+}
+
+// csvHeader of result, header is copied as rows reuse record
+func csvHeader(r *csv.Reader) ([]string, error) {
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, &compareInputError{"result is empty, header row is missing"}
+	}
+	return append([]string(nil), header...), err
+}
+
+func headerIndex(header []string) map[string]int {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+	return index
+}
+
+// compareCSV results, diff receives added and changed rows while head is read and removed rows at the end;
+// rows are matched by keyColumn or by values of columns present in both results without key
+func compareCSV(base, head io.Reader, keyColumn string, diff *csv.Writer) (*proto.CompareResultsResponse, error) {
+	baseReader := csv.NewReader(base)
+	headReader := csv.NewReader(head)
+	headReader.ReuseRecord = true
+	baseHeader, err := csvHeader(baseReader)
+	if err != nil {
+		return nil, err
+	}
+	headHeader, err := csvHeader(headReader)
+	if err != nil {
+		return nil, err
+	}
+	baseIndex := headerIndex(baseHeader)
+	headIndex := headerIndex(headHeader)
+	res := &proto.CompareResultsResponse{}
+	// common columns in order of head, base column of each
+	var common, commonBase []int
+	for i, name := range headHeader {
+		if j, ok := baseIndex[name]; ok && headIndex[name] == i {
+			common = append(common, i)
+			commonBase = append(commonBase, j)
+		} else if !ok {
+			res.AddedColumns = append(res.AddedColumns, name)
+		}
+	}
+	var removedColumns []int
+	for j, name := range baseHeader {
+		if _, ok := headIndex[name]; !ok && baseIndex[name] == j {
+			res.RemovedColumns = append(res.RemovedColumns, name)
+			removedColumns = append(removedColumns, j)
+		}
+	}
+	headKey, baseKey := -1, -1
+	if keyColumn != "" {
+		var inBase, inHead bool
+		baseKey, inBase = baseIndex[keyColumn]
+		headKey, inHead = headIndex[keyColumn]
+		if !inBase || !inHead {
+			return nil, &compareInputError{fmt.Sprintf("key column %s is not in both results", keyColumn)}
+		}
+	}
+	rowKey := func(record []string, key int, columns []int) string {
+		if key >= 0 {
+			return record[key]
+		}
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = record[c]
+		}
+		return strings.Join(values, "\x00")
+	}
+	// diff has columns of head followed by removed columns of base
+	diffHeader := append(append([]string{changeColumn}, headHeader...), res.RemovedColumns...)
+	writeHead := func(change string, record []string, base []string) error {
+		if diff == nil {
+			return nil
+		}
+		row := append(append(make([]string, 0, len(diffHeader)), change), record...)
+		for _, j := range removedColumns {
+			if base == nil {
+				row = append(row, "")
+			} else {
+				row = append(row, base[j])
+			}
+		}
+		return diff.Write(row)
+	}
+	if diff != nil {
+		if err := diff.Write(diffHeader); err != nil {
+			return nil, err
+		}
+	}
+
+	var baseRows []*baseRow
+	byKey := map[string][]*baseRow{}
+	for {
+		record, err := baseReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		key := rowKey(record, baseKey, commonBase)
+		if baseKey >= 0 && len(byKey[key]) > 0 {
+			return nil, &compareInputError{fmt.Sprintf("key column %s is not unique, value %q repeats in base result", keyColumn, key)}
+		}
+		row := &baseRow{values: record}
+		baseRows = append(baseRows, row)
+		byKey[key] = append(byKey[key], row)
+	}
+
+	changedColumns := make([]int64, len(common))
+	seenKeys := map[string]bool{}
+	for {
+		record, err := headReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		key := rowKey(record, headKey, common)
+		if headKey >= 0 {
+			if seenKeys[key] {
+				return nil, &compareInputError{fmt.Sprintf("key column %s is not unique, value %q repeats in result", keyColumn, key)}
+			}
+			seenKeys[key] = true
+		}
+		// full rows of same values are matched in turn, so repeated rows are counted
+		var match *baseRow
+		for _, row := range byKey[key] {
+			if row.matched == 0 {
+				match = row
+				break
+			}
+		}
+		if match == nil {
+			res.RowsAdded++
+			if err := writeHead(changeAdded, record, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		match.matched++
+		changed := false
+		for i, c := range common {
+			if record[c] != match.values[commonBase[i]] {
+				changedColumns[i]++
+				changed = true
+			}
+		}
+		if !changed {
+			res.RowsUnchanged++
+			continue
+		}
+		res.RowsChanged++
+		if err := writeHead(changeChanged, record, match.values); err != nil {
+			return nil, err
+		}
+	}
+	for i, c := range common {
+		if changedColumns[i] > 0 {
+			res.ColumnChanges = append(res.ColumnChanges, &proto.ColumnChanges{Column: headHeader[c], ChangedRows: changedColumns[i]})
+		}
+	}
+
+	for _, row := range baseRows {
+		if row.matched > 0 {
+			continue
+		}
+		res.RowsRemoved++
+		if diff == nil {
+			continue
+		}
+		diffRow := make([]string, 0, len(diffHeader))
+		diffRow = append(diffRow, changeRemoved)
+		for _, name := range headHeader {
+			if j, ok := baseIndex[name]; ok {
+				diffRow = append(diffRow, row.values[j])
+			} else {
+				diffRow = append(diffRow, "")
+			}
+		}
+		for _, j := range removedColumns {
+			diffRow = append(diffRow, row.values[j])
+		}
+		if err := diff.Write(diffRow); err != nil {
+			return nil, err
+		}
+	}
+	if diff != nil {
+		diff.Flush()
+		if err := diff.Error(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// resultQuery of result readable by user, empty when result was not run by query of report user can read
+func (s Server) resultQuery(ctx context.Context, resultID string, email string) (string, string, error) {
+	var queryID, reportID string
+	err := s.db.QueryRowContext(ctx,
+		`select results.query_id, queries.report_id from results
+		join queries on queries.id = results.query_id
+		join reports on reports.id = queries.report_id
+		where results.id=$1 and (not reports.archived or reports.author_email=$2) limit 1`,
+		resultID,
+		email,
+	).Scan(&queryID, &reportID)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	return queryID, reportID, err
+}
+
+// openCSVResult of query stored in bucket
+func (s Server) openCSVResult(ctx context.Context, resultID string) (io.ReadCloser, int64, error) {
+	obj, err := s.resultObject(ctx, resultID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if path.Ext(obj.ObjectName()) == ".parquet" {
+		return nil, 0, errParquetCompare
+	}
+	return s.openResult(ctx, resultID)
+}
+
+// createDiffObject of report, diff result is not result of query, so it can't be compared again
+func (s Server) createDiffObject(ctx context.Context, reportID string, resultID string) (io.WriteCloser, error) {
+	obj, err := s.createResultObject(ctx, reportID, "", resultID, ".csv")
+	if err != nil {
+		return nil, err
+	}
+	w := obj.NewWriter(ctx)
+	w.ContentType = "text/csv"
+	return w, nil
+}
+
+// compareError as status of failed comparison
+func compareError(err error, resultID string) error {
+	var inputErr *compareInputError
+	var tooLarge *compareTooLargeError
+	var csvErr *csv.ParseError
+	switch {
+	case errors.Is(err, errResultExpired):
+		return status.Errorf(codes.FailedPrecondition, "result %s expired, run query again", resultID)
+	case err == errParquetCompare:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.As(err, &tooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.As(err, &inputErr), errors.As(err, &csvErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Err(err).Send()
+	return internalError(err)
+}
+
+// compareResults of same query read from source, diff result is created with createDiff
+func (s Server) compareResults(
+	ctx context.Context,
+	req *proto.CompareResultsRequest,
+	source func(ctx context.Context, resultID string) (io.ReadCloser, int64, error),
+	createDiff func(ctx context.Context, reportID string, resultID string) (io.WriteCloser, error),
+) (*proto.CompareResultsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	role := proto.Role_ROLE_VIEWER
+	if req.CreateDiff {
+		// diff is stored in result bucket
+		role = proto.Role_ROLE_EDITOR
+	}
+	if err := s.requireRole(ctx, role); err != nil {
+		return nil, err
+	}
+	for _, id := range []string{req.BaseResultId, req.ResultId} {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+	var queryIDs, reportIDs [2]string
+	for i, id := range []string{req.BaseResultId, req.ResultId} {
+		queryID, reportID, err := s.resultQuery(ctx, id, claims.Email)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		if queryID == "" {
+			err := fmt.Errorf("Result not found id:%s", id)
+			log.Warn().Err(err).Send()
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		queryIDs[i], reportIDs[i] = queryID, reportID
+	}
+	if queryIDs[0] != queryIDs[1] {
+		return nil, status.Errorf(codes.InvalidArgument, "results %s and %s are not results of same query", req.BaseResultId, req.ResultId)
+	}
+
+	var readers [2]io.ReadCloser
+	var size int64
+	for i, id := range []string{req.BaseResultId, req.ResultId} {
+		r, resultSize, err := source(ctx, id)
+		if err != nil {
+			return nil, compareError(err, id)
+		}
+		defer r.Close()
+		readers[i] = r
+		size += resultSize
+	}
+	if size > s.maxCompareSize {
+		return nil, compareError(&compareTooLargeError{Size: size, Limit: s.maxCompareSize}, req.ResultId)
+	}
+
+	var diff *csv.Writer
+	var diffObject io.WriteCloser
+	var diffID string
+	// diff object is not committed when comparison fails
+	diffCtx, cancelDiff := context.WithCancel(ctx)
+	defer cancelDiff()
+	if req.CreateDiff {
+		var err error
+		diffID = newUUID()
+		diffObject, err = createDiff(diffCtx, reportIDs[1], diffID)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		diff = csv.NewWriter(diffObject)
+	}
+	res, err := compareCSV(readers[0], readers[1], req.KeyColumn, diff)
+	if err != nil {
+		if diffObject != nil {
+			cancelDiff()
+			diffObject.Close()
+		}
+		return nil, compareError(err, req.ResultId)
+	}
+	if diffObject != nil {
+		if err := diffObject.Close(); err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		res.DiffResultId = diffID
+	}
+	log.Debug().Str("baseResultID", req.BaseResultId).Str("resultID", req.ResultId).
+		Int64("added", res.RowsAdded).Int64("removed", res.RowsRemoved).Int64("changed", res.RowsChanged).
+		Msg("Results compared")
+	return res, nil
+}
+
+// CompareResults of same query, summary of added, removed and changed rows with optional diff result
+func (s Server) CompareResults(ctx context.Context, req *proto.CompareResultsRequest) (*proto.CompareResultsResponse, error) {
+	return s.compareResults(ctx, req, s.openCSVResult, s.createDiffObject)
+}
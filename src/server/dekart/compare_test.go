@@ -0,0 +1,108 @@
+package dekart
+
+import (
+	"bytes"
+	"dekart/src/proto"
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCompareCSVByKey(t *testing.T) {
+	base := "id,name,value,old\n1,a,10,x\n2,b,20,y\n3,c,30,z\n"
+	head := "id,name,value,new\n1,a,10,p\n2,b,21,q\n4,d,40,r\n"
+	var diff bytes.Buffer
+	w := csv.NewWriter(&diff)
+	res, err := compareCSV(strings.NewReader(base), strings.NewReader(head), "id", w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RowsAdded != 1 || res.RowsRemoved != 1 || res.RowsChanged != 1 || res.RowsUnchanged != 1 {
+		t.Errorf("unexpected summary %v", res)
+	}
+	if len(res.ColumnChanges) != 1 || res.ColumnChanges[0].Column != "value" || res.ColumnChanges[0].ChangedRows != 1 {
+		t.Errorf("unexpected column changes %v", res.ColumnChanges)
+	}
+	if strings.Join(res.AddedColumns, ",") != "new" || strings.Join(res.RemovedColumns, ",") != "old" {
+		t.Errorf("unexpected columns %v %v", res.AddedColumns, res.RemovedColumns)
+	}
+	expected := "_change,id,name,value,new,old\n" +
+		"changed,2,b,21,q,y\n" +
+		"added,4,d,40,r,\n" +
+		"removed,3,c,30,,z\n"
+	if diff.String() != expected {
+		t.Errorf("unexpected diff\n%s", diff.String())
+	}
+}
+
+func TestCompareCSVFullRows(t *testing.T) {
+	base := "a,b\n1,2\n1,2\n3,4\n"
+	// columns are matched by name, repeated rows are counted
+	head := "b,a\n2,1\n4,3\n4,3\n"
+	res, err := compareCSV(strings.NewReader(base), strings.NewReader(head), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RowsAdded != 1 || res.RowsRemoved != 1 || res.RowsChanged != 0 || res.RowsUnchanged != 2 {
+		t.Errorf("unexpected summary %v", res)
+	}
+}
+
+func TestCompareCSVRejected(t *testing.T) {
+	for name, c := range map[string]struct {
+		base, head, key string
+	}{
+		"missing key":       {"id\n1\n", "other\n1\n", "id"},
+		"repeated base key": {"id\n1\n1\n", "id\n1\n", "id"},
+		"repeated key":      {"id\n1\n", "id\n2\n2\n", "id"},
+		"empty result":      {"", "id\n1\n", ""},
+		"inconsistent row":  {"id,a\n1,2\n", "id,a\n1\n", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := compareCSV(strings.NewReader(c.base), strings.NewReader(c.head), c.key, nil)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if code := status.Code(compareError(err, "result")); code != codes.InvalidArgument {
+				t.Errorf("expected invalid argument, got %s %v", code, err)
+			}
+		})
+	}
+}
+
+func TestParseMaxCompareSize(t *testing.T) {
+	if size, err := parseMaxCompareSize(""); err != nil || size != defaultMaxCompareSize {
+		t.Errorf("expected default, got %d %v", size, err)
+	}
+	if size, err := parseMaxCompareSize("1024"); err != nil || size != 1024 {
+		t.Errorf("expected 1024, got %d %v", size, err)
+	}
+	for _, value := range []string{"0", "-1", "100MB"} {
+		if _, err := parseMaxCompareSize(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestCommaSeparated(t *testing.T) {
+	stored := "name\tnote\na\t\"x, y\"\nb\n"
+	r := commaSeparated(ioutil.NopCloser(strings.NewReader(stored)), &proto.SerializationOptions{Delimiter: "\t"})
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "name,note\na,\"x, y\"\nb\n"; string(b) != expected {
+		t.Errorf("expected %q, got %q", expected, string(b))
+	}
+	broken := commaSeparated(ioutil.NopCloser(strings.NewReader("a\t\"b\n")), &proto.SerializationOptions{Delimiter: "\t"})
+	defer broken.Close()
+	if _, err := io.Copy(ioutil.Discard, broken); err == nil {
+		t.Error("expected parse error")
+	}
+}
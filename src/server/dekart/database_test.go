@@ -0,0 +1,2064 @@
+package dekart
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/dialect"
+	"dekart/src/server/encryption"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/schema"
+	"dekart/src/server/user"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/storage"
+	"github.com/gorilla/mux"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openTestDatabase at DEKART_TEST_DATABASE_URL, or SQLite file in temp dir when not set
+func openTestDatabase(t *testing.T) *sql.DB {
+	url := os.Getenv("DEKART_TEST_DATABASE_URL")
+	if url == "" {
+		dir, err := ioutil.TempDir("", "dekart")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(dir) })
+		url = "sqlite://" + filepath.Join(dir, "dekart.db")
+	}
+	if _, err := schema.Migrate(url, "../../../migrations"); err != nil {
+		t.Fatal(err)
+	}
+	db, err := dialect.Open(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestDatabase(t *testing.T) {
+	db := openTestDatabase(t)
+	s := NewServer(db, nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	t.Logf("dialect %s", s.dialect.Name())
+
+	search := newUUID()
+	var reportIDs []string
+	for i := 0; i < 3; i++ {
+		created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		reportIDs = append(reportIDs, created.Report.Id)
+		_, err = s.UpdateReport(ctx, &proto.UpdateReportRequest{Report: &proto.Report{
+			Id:        created.Report.Id,
+			Title:     "Report " + search,
+			MapConfig: `{"version":"v1"}`,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	reportID := reportIDs[0]
+
+	updated, err := s.UpdateMapConfig(ctx, &proto.UpdateMapConfigRequest{ReportId: reportID, MapConfig: `{"version":"v2"}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	history, err := s.GetMapConfigHistory(ctx, &proto.GetMapConfigHistoryRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Revisions) != 2 || history.Revisions[0].Revision != updated.Revision || history.Revisions[0].CreatedAt == 0 {
+		t.Errorf("unexpected history %v", history.Revisions)
+	}
+
+	created, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: reportID, QueryText: "select 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Query.Title != "Query 1" {
+		t.Errorf("expected Query 1, got %s", created.Query.Title)
+	}
+	queryID := created.Query.Id
+	_, err = s.UpdateQuery(ctx, &proto.UpdateQueryRequest{Query: &proto.Query{Id: queryID, QueryText: "select 2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateQueryTitle(ctx, &proto.UpdateQueryTitleRequest{QueryId: queryID, Title: "Points"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Report.MapConfigRevision != updated.Revision || len(got.Queries) != 1 || got.Queries[0].Title != "Points" || got.Queries[0].QueryText != "select 2" {
+		t.Errorf("unexpected report %v %v", got.Report, got.Queries)
+	}
+
+	// job status is persisted by updateJobStatus
+	jobCtx, cancel := context.WithCancel(context.Background())
+	j := &job.Job{QueryID: queryID, ReportID: reportID, Ctx: jobCtx, Status: make(chan int32)}
+	go s.updateJobStatus(j)
+	j.Status <- int32(proto.Query_JOB_STATUS_RUNNING)
+	j.Status <- int32(proto.Query_JOB_STATUS_DONE)
+	// unbuffered channel, so RUNNING is persisted when DONE is received
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		queries, err := s.getQueries(ctx, reportID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if queries[0].JobStatus == proto.Query_JOB_STATUS_DONE {
+			if queries[0].JobDuration < 0 {
+				t.Errorf("unexpected job duration %d", queries[0].JobDuration)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job status not persisted, got %s", queries[0].JobStatus)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forked, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: fork.ReportId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forked.Queries) != 1 || forked.Queries[0].Title != "Points" {
+		t.Errorf("unexpected fork queries %v", forked.Queries)
+	}
+	if _, err := s.ArchiveReport(ctx, &proto.ArchiveReportRequest{ReportId: fork.ReportId, Archive: true}); err != nil {
+		t.Fatal(err)
+	}
+	archived, err := s.ListReports(ctx, &proto.ListReportsRequest{Filter: proto.ListReportsRequest_FILTER_ARCHIVED, Search: search})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived.Reports) != 1 || archived.Reports[0].Id != fork.ReportId {
+		t.Errorf("expected archived fork, got %v", archived.Reports)
+	}
+
+	seen := map[string]bool{}
+	req := &proto.ListReportsRequest{Search: search, PageSize: 2}
+	for page := 0; ; page++ {
+		res, err := s.ListReports(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.TotalCount != 3 {
+			t.Errorf("expected 3 reports, got %d", res.TotalCount)
+		}
+		for _, r := range res.Reports {
+			seen[r.Id] = true
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		if page > 2 {
+			t.Fatal("too many pages")
+		}
+		req.PageToken = res.NextPageToken
+	}
+	for _, id := range reportIDs {
+		if !seen[id] {
+			t.Errorf("report %s not listed", id)
+		}
+	}
+}
+
+func TestDatabaseSessionStore(t *testing.T) {
+	store := NewSessionStore(openTestDatabase(t))
+	ctx := context.Background()
+	session := user.Session{ID: newUUID(), Email: "a@example.com", Groups: []string{"x", "y"}, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Create(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Get(ctx, session.ID)
+	if err != nil || got == nil || len(got.Groups) != 2 || got.ExpiresAt.Unix() != session.ExpiresAt.Unix() {
+		t.Fatalf("unexpected session %v %v", got, err)
+	}
+	if err := store.Revoke(ctx, session.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := store.Get(ctx, session.ID); err != nil || got != nil {
+		t.Errorf("expected revoked session, got %v %v", got, err)
+	}
+}
+
+func TestDatabaseReencryptColumns(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	defer encryption.Use(nil)
+	db := openTestDatabase(t)
+	s := NewServer(db, nil, job.NewStore(), report.NewStreams())
+	store := NewSessionStore(db)
+	ctx := testClaimsContext()
+	if _, err := s.ReencryptColumns(ctx, &proto.ReencryptColumnsRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition without keys, got %v", err)
+	}
+	// session written before encryption was enabled
+	session := user.Session{ID: newUUID(), Email: "a@example.com", Groups: []string{"x"}, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Create(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	rawEmail := func() string {
+		var email string
+		if err := db.QueryRow(`select email from sessions where id=$1`, session.ID).Scan(&email); err != nil {
+			t.Fatal(err)
+		}
+		return email
+	}
+	useKeys := func(keys string) {
+		k, err := encryption.ParseKeys(context.Background(), keys, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encryption.Use(k)
+	}
+	k1 := "k1=" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 32)))
+	k2 := "k2=" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("b", 32)))
+	for _, keys := range []string{k1, k2 + "," + k1} {
+		useKeys(keys)
+		res, err := s.ReencryptColumns(ctx, &proto.ReencryptColumnsRequest{})
+		if err != nil || res.ReencryptedCount < 1 || res.FailedCount != 0 {
+			t.Fatalf("%s: unexpected result %v %v", keys, res, err)
+		}
+		if email := rawEmail(); !strings.HasPrefix(email, "enc:"+res.PrimaryKeyId+":") {
+			t.Errorf("%s: expected email encrypted with primary key, got %s", keys, email)
+		}
+		got, err := store.Get(ctx, session.ID)
+		if err != nil || got == nil || got.Email != session.Email || len(got.Groups) != 1 {
+			t.Errorf("%s: unexpected session %v %v", keys, got, err)
+		}
+	}
+	// key of rows removed from keyring
+	useKeys("k3=" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("c", 32))))
+	if _, err := store.Get(ctx, session.ID); err == nil {
+		t.Error("expected decrypt error")
+	}
+	if res, err := s.ReencryptColumns(ctx, &proto.ReencryptColumnsRequest{}); err != nil || res.FailedCount < 1 {
+		t.Errorf("expected failed rows, got %v %v", res, err)
+	}
+	if err := store.Revoke(ctx, session.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatabaseJobRegistry(t *testing.T) {
+	registry := NewJobRegistry(openTestDatabase(t))
+	ctx := context.Background()
+	record := job.Record{JobID: newUUID(), QueryID: newUUID(), Replica: "a", BigqueryConnection: "ci"}
+	if err := registry.Register(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Heartbeat(ctx, []string{record.JobID}); err != nil {
+		t.Fatal(err)
+	}
+	found, err := registry.Find(ctx, record.QueryID, time.Minute)
+	if err != nil || len(found) != 1 || found[0].JobID != record.JobID || found[0].BigqueryConnection != "ci" {
+		t.Fatalf("unexpected records %v %v", found, err)
+	}
+	// reason of first request is kept
+	for _, reason := range []proto.CancelReason{proto.CancelReason_CANCEL_REASON_ADMIN, proto.CancelReason_CANCEL_REASON_SHUTDOWN} {
+		if err := registry.RequestCancel(ctx, record.JobID, reason); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cancelled, err := registry.CancelRequested(ctx, []string{record.JobID})
+	if err != nil || len(cancelled) != 1 || cancelled[record.JobID] != proto.CancelReason_CANCEL_REASON_ADMIN {
+		t.Errorf("expected cancel requested by admin, got %v %v", cancelled, err)
+	}
+	if err := registry.Remove(ctx, record.JobID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatabaseRunKeys(t *testing.T) {
+	keys := NewRunKeys(openTestDatabase(t))
+	ctx := context.Background()
+	queryID, first, second := newUUID(), newUUID(), newUUID()
+	if holder, err := keys.Claim(ctx, queryID, "key", first, time.Minute); err != nil || holder != first {
+		t.Fatalf("expected key claimed, got %s %v", holder, err)
+	}
+	if holder, err := keys.Claim(ctx, queryID, "key", second, time.Minute); err != nil || holder != first {
+		t.Errorf("expected duplicate of first job, got %s %v", holder, err)
+	}
+	if err := keys.Release(ctx, queryID, "key", first); err != nil {
+		t.Fatal(err)
+	}
+	if holder, err := keys.Claim(ctx, queryID, "key", second, time.Minute); err != nil || holder != second {
+		t.Errorf("expected released key claimed, got %s %v", holder, err)
+	}
+	if err := keys.Purge(ctx, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDatabaseRoleAssignments(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	pattern := newUUID() + "@example.com"
+	for _, role := range []proto.Role{proto.Role_ROLE_VIEWER, proto.Role_ROLE_ADMIN} {
+		_, err := s.SetRoleAssignment(ctx, &proto.SetRoleAssignmentRequest{RoleAssignment: &proto.RoleAssignment{EmailPattern: pattern, Role: role}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	assignments, err := s.getRoleAssignments(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// second assignment replaces first one
+	if role := matchRole(pattern, assignments, proto.Role_ROLE_EDITOR); role != proto.Role_ROLE_ADMIN {
+		t.Errorf("expected admin, got %s", role)
+	}
+}
+
+func TestDatabaseResultObjects(t *testing.T) {
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	s.storagePrefix = "prod/{reportID}/"
+	ctx := context.Background()
+	reportID, resultID := newUUID(), newUUID()
+	obj, err := s.createResultObject(ctx, reportID, "", resultID, ".csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// prefix change doesn't move recorded results
+	s.storagePrefix = ""
+	found, err := s.resultObject(ctx, resultID)
+	if err != nil || found.ObjectName() != obj.ObjectName() || obj.ObjectName() != "prod/"+reportID+"/"+resultID+".csv" {
+		t.Errorf("unexpected object %s %v", found.ObjectName(), err)
+	}
+	legacyID := newUUID()
+	if legacy, err := s.resultObject(ctx, legacyID); err != nil || legacy.ObjectName() != legacyID+".csv" {
+		t.Errorf("expected legacy object, got %s %v", legacy.ObjectName(), err)
+	}
+}
+
+func TestDatabaseFallbackResultObjects(t *testing.T) {
+	client := &storage.Client{}
+	s := NewServer(openTestDatabase(t), client.Bucket("dekart"), job.NewStore(), report.NewStreams())
+	s.UseFallbackBucket("dekart-fallback", client.Bucket("dekart-fallback"))
+	ctx := context.Background()
+	primaryID, fallbackID, unknownID := newUUID(), newUUID(), newUUID()
+	for _, id := range []string{primaryID, fallbackID, unknownID} {
+		if _, err := s.createResultObject(ctx, newUUID(), "", id, ".csv"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for id, bucket := range map[string]string{fallbackID: "dekart-fallback", unknownID: "removed-fallback"} {
+		if _, err := s.db.Exec(`update results set bucket=$1 where id=$2`, bucket, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// result of unknown bucket is read from primary bucket
+	for id, expected := range map[string]string{primaryID: "dekart", fallbackID: "dekart-fallback", unknownID: "dekart"} {
+		obj, err := s.resultObject(ctx, id)
+		if err != nil || obj.BucketName() != expected || obj.ObjectName() != id+".csv" {
+			t.Errorf("expected %s/%s.csv, got %s/%s %v", expected, id, obj.BucketName(), obj.ObjectName(), err)
+		}
+	}
+	checked := map[string]string{}
+	exists := func(ctx context.Context, bucket string, name string) error {
+		checked[name] = bucket
+		return nil
+	}
+	if _, err := s.reconcileResults(ctx, defaultReconcileLimit, exists); err != nil {
+		t.Fatal(err)
+	}
+	if checked[fallbackID+".csv"] != "dekart-fallback" || checked[primaryID+".csv"] != "" {
+		t.Errorf("expected objects checked in recorded bucket, got %v", checked)
+	}
+}
+
+func TestDatabaseResultLifecycle(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	// cutoff has seconds precision, results of previous tests are older than cutoff
+	time.Sleep(1100 * time.Millisecond)
+	before, err := s.GetResultLifecycle(ctx, &proto.GetResultLifecycleRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keptID, removedID := newUUID(), newUUID()
+	for _, id := range []string{keptID, removedID} {
+		if _, err := s.createResultObject(ctx, newUUID(), "", id, ".csv"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(1100 * time.Millisecond)
+	lifecycle, err := s.GetResultLifecycle(ctx, &proto.GetResultLifecycleRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lifecycle.AffectedCount-before.AffectedCount != 2 || lifecycle.DateLayout != "YYYY/MM/DD" {
+		t.Errorf("unexpected lifecycle %v", lifecycle)
+	}
+	exists := func(ctx context.Context, bucket string, name string) error {
+		if name == removedID+".csv" {
+			return storage.ErrObjectNotExist
+		}
+		return nil
+	}
+	res, err := s.reconcileResults(ctx, defaultReconcileLimit, exists)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExpiredCount != 1 || res.ErrorCount != 0 {
+		t.Errorf("unexpected reconcile %v", res)
+	}
+	if _, err := s.resultObject(ctx, removedID); err != errResultExpired {
+		t.Errorf("expected expired result, got %v", err)
+	}
+	if _, err := s.resultObject(ctx, keptID); err != nil {
+		t.Errorf("expected result, got %v", err)
+	}
+}
+
+func TestDatabaseReportVariables(t *testing.T) {
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportID := created.Report.Id
+	variables := []*proto.ReportVariable{
+		{Name: "dataset", Type: proto.ReportVariable_TYPE_IDENTIFIER, DefaultValue: "project.events"},
+		{Name: "day", Type: proto.ReportVariable_TYPE_STRING, DefaultValue: "2021-01-01"},
+	}
+	if _, err := s.UpdateReportVariables(ctx, &proto.UpdateReportVariablesRequest{ReportId: reportID, Variables: variables}); err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: reportID, QueryText: "select * from {{dataset}}.points where day = {{day}}"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forked, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: fork.ReportId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forked.Report.Variables) != 2 || forked.Report.Variables[1].DefaultValue != "2021-01-01" {
+		t.Errorf("unexpected fork variables %v", forked.Report.Variables)
+	}
+	source, err := s.getQuerySource(ctx, query.Query.Id, user.UnknownEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := source.substituteVariables(map[string]string{"day": "2021-02-01"}); err != nil {
+		t.Fatal(err)
+	}
+	if source.queryText != "select * from project.events.points where day = '2021-02-01'" {
+		t.Errorf("unexpected query text %s", source.queryText)
+	}
+	_, err = s.UpdateReportVariables(ctx, &proto.UpdateReportVariablesRequest{ReportId: reportID, Variables: []*proto.ReportVariable{{Name: "a b"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestDatabaseReportParameters(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportID := created.Report.Id
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: reportID, QueryText: "select * from points where region = @region and day >= @since"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: reportID, QueryText: "select distinct city from points"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parameters := []*proto.ReportParameter{
+		{Name: "region", Type: proto.ReportParameter_TYPE_STRING, AllowedValues: []string{"EU", "US"}, DefaultValue: "EU"},
+		{Name: "since", Type: proto.ReportParameter_TYPE_DATE, DefaultValue: "2021-01-01"},
+		{Name: "city", Type: proto.ReportParameter_TYPE_STRING, ValuesQueryId: values.Query.Id},
+	}
+	if _, err := s.UpdateReportParameters(ctx, &proto.UpdateReportParametersRequest{ReportId: reportID, Parameters: parameters}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateReportParameters(ctx, &proto.UpdateReportParametersRequest{ReportId: reportID, Parameters: []*proto.ReportParameter{
+		{Name: "city", Type: proto.ReportParameter_TYPE_STRING, ValuesQueryId: newUUID()},
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected values query of other report rejected, got %v", err)
+	}
+	_, err = s.UpdateQueryParameterBindings(ctx, &proto.UpdateQueryParameterBindingsRequest{QueryId: query.Query.Id, ParameterBindings: map[string]string{"region": "country"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected binding of unknown parameter rejected, got %v", err)
+	}
+	bindings := map[string]string{"region": "region", "since": "since"}
+	if _, err := s.UpdateQueryParameterBindings(ctx, &proto.UpdateQueryParameterBindingsRequest{QueryId: query.Query.Id, ParameterBindings: bindings}); err != nil {
+		t.Fatal(err)
+	}
+
+	// values outside of allowed set are rejected
+	_, err = s.SetReportParameterValues(ctx, &proto.SetReportParameterValuesRequest{ReportId: reportID, Values: map[string]string{"region": "ASIA"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	// values query must be run before its values can be chosen
+	_, err = s.SetReportParameterValues(ctx, &proto.SetReportParameterValuesRequest{ReportId: reportID, Values: map[string]string{"city": "Berlin"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+	if _, err := s.SetReportParameterValues(ctx, &proto.SetReportParameterValuesRequest{ReportId: reportID, Values: map[string]string{"since": "2021-06-01"}, Save: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetReportParameterValues(ctx, &proto.SetReportParameterValuesRequest{ReportId: reportID, Values: map[string]string{"region": "US"}}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Report.Parameters) != 3 || got.Report.Parameters[2].ValuesQueryId != values.Query.Id ||
+		got.Report.ParameterValues["since"] != "2021-06-01" || got.Report.SessionParameterValues["region"] != "US" {
+		t.Errorf("unexpected parameters of report %v", got.Report)
+	}
+	for _, q := range got.Queries {
+		if q.Id == query.Query.Id && q.ParameterBindings["since"] != "since" {
+			t.Errorf("expected bindings of query, got %v", q.ParameterBindings)
+		}
+	}
+
+	// session value of user overrides saved value, saved value overrides default
+	source, err := s.getQuerySource(ctx, query.Query.Id, user.UnknownEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.bindParameters(ctx, &source, user.UnknownEmail); err != nil {
+		t.Fatal(err)
+	}
+	bound := source.options.Parameters
+	if len(bound) != 2 || bound[0].Name != "region" || bound[0].Value != "US" || bound[1].Name != "since" || bound[1].Value.(civil.Date).Month != 6 {
+		t.Errorf("unexpected bound parameters %+v", bound)
+	}
+	// other user viewing report sees saved values
+	other := context.WithValue(context.Background(), user.ContextKey("userDetails"), &user.Claims{Email: "other@example.com"})
+	source, err = s.getQuerySource(ctx, query.Query.Id, user.UnknownEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.bindParameters(other, &source, "other@example.com"); err != nil || source.options.Parameters[0].Value != "EU" {
+		t.Errorf("expected default region for other user, got %+v %v", source.options.Parameters, err)
+	}
+	_, err = s.SetReportParameterValues(other, &proto.SetReportParameterValuesRequest{ReportId: reportID, Values: map[string]string{"region": "US"}, Save: true})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected saving values of report of other author not found, got %v", err)
+	}
+
+	// fork keeps parameters with values query copied into fork
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forked, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: fork.ReportId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	valuesQueryID := forked.Report.Parameters[2].ValuesQueryId
+	if valuesQueryID == values.Query.Id || forked.Report.ParameterValues["since"] != "2021-06-01" || len(forked.Report.SessionParameterValues) != 0 {
+		t.Errorf("unexpected parameters of fork %v", forked.Report)
+	}
+	var copied bool
+	for _, q := range forked.Queries {
+		copied = copied || q.Id == valuesQueryID
+	}
+	if !copied {
+		t.Errorf("expected values query %s in fork", valuesQueryID)
+	}
+}
+
+func TestDatabaseJobStats(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	db := openTestDatabase(t)
+	s := NewServer(db, nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	recorder := NewJobStatsRecorder(db)
+	// emails and reports are unique, so stats of previous runs are not aggregated
+	alice, bob := newUUID()+"@example.com", newUUID()+"@example.com"
+	reportID := newUUID()
+	for _, stats := range []job.FinishedJob{
+		{UserEmail: alice, ReportID: reportID, BytesProcessed: 100, BytesBilled: 1000, Duration: time.Second, Status: int32(proto.Query_JOB_STATUS_DONE)},
+		{UserEmail: alice, ReportID: reportID, CacheHit: true, Duration: time.Second, Status: int32(proto.Query_JOB_STATUS_DONE)},
+		{UserEmail: bob, ReportID: newUUID(), BytesProcessed: 500, BytesBilled: 5000, Duration: 2 * time.Second},
+	} {
+		stats.JobID, stats.QueryID = newUUID(), newUUID()
+		if err := recorder.Record(ctx, stats); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	getUsage := func(groupBy proto.GetUsageRequest_GroupBy) map[string]*proto.Usage {
+		t.Helper()
+		res, err := s.GetUsage(ctx, &proto.GetUsageRequest{
+			FromTime: now.Add(-time.Hour).Unix(),
+			ToTime:   now.Add(time.Hour).Unix(),
+			GroupBy:  groupBy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		usage := map[string]*proto.Usage{}
+		for _, u := range res.Usage {
+			usage[u.Key] = u
+		}
+		return usage
+	}
+	byUser := getUsage(proto.GetUsageRequest_GROUP_BY_USER)
+	if u := byUser[alice]; u == nil || u.Jobs != 2 || u.BytesProcessed != 100 || u.BytesBilled != 1000 || u.CacheHits != 1 || u.DurationMs != 2000 {
+		t.Errorf("unexpected usage of alice %v", u)
+	}
+	if u := byUser[bob]; u == nil || u.Jobs != 1 || u.BytesBilled != 5000 || u.CacheHits != 0 {
+		t.Errorf("unexpected usage of bob %v", u)
+	}
+	if u := getUsage(proto.GetUsageRequest_GROUP_BY_REPORT)[reportID]; u == nil || u.Jobs != 2 {
+		t.Errorf("unexpected usage of report %v", u)
+	}
+	if u := getUsage(proto.GetUsageRequest_GROUP_BY_DAY)[now.UTC().Format("2006-01-02")]; u == nil || u.Jobs < 3 {
+		t.Errorf("unexpected usage of day %v", u)
+	}
+	if u := getUsage(proto.GetUsageRequest_GROUP_BY_UNSPECIFIED)[""]; u == nil || u.Jobs < 3 {
+		t.Errorf("unexpected total usage %v", u)
+	}
+	_, err := s.GetUsage(ctx, &proto.GetUsageRequest{FromTime: now.Unix(), ToTime: now.Add(-time.Hour).Unix()})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for empty range, got %v", err)
+	}
+
+	removed, err := s.pruneJobStats(ctx, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed < 3 {
+		t.Errorf("expected stats pruned, removed %d", removed)
+	}
+	if u := getUsage(proto.GetUsageRequest_GROUP_BY_USER)[alice]; u != nil {
+		t.Errorf("expected no usage after prune, got %v", u)
+	}
+}
+
+func TestDatabaseDatasets(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail+",other@example.com")
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	name := "trips_" + strings.ReplaceAll(newUUID(), "-", "")[:8]
+	created, err := s.CreateDataset(ctx, &proto.CreateDatasetRequest{Dataset: &proto.Dataset{
+		Name: name, Description: "Trips of Berlin", QueryText: "select 1 as trip;",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created.Dataset.CanWrite || created.Dataset.AuthorEmail != user.UnknownEmail {
+		t.Errorf("unexpected dataset %v", created.Dataset)
+	}
+	_, err = s.CreateDataset(ctx, &proto.CreateDatasetRequest{Dataset: &proto.Dataset{Name: name, QueryText: "select 2"}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	list, err := s.ListDatasets(ctx, &proto.ListDatasetsRequest{Search: "of Berlin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, dataset := range list.Datasets {
+		found = found || dataset.Id == created.Dataset.Id
+	}
+	if !found {
+		t.Errorf("expected dataset found by description, got %v", list.Datasets)
+	}
+	source := &queryJobSource{queryText: "select * from {{dataset:" + name + "}} t"}
+	if err := s.expandDatasets(ctx, source); err != nil {
+		t.Fatal(err)
+	}
+	if source.queryText != "select * from (\nselect 1 as trip\n) t" {
+		t.Errorf("unexpected expansion %s", source.queryText)
+	}
+	created.Dataset.Description = "Trips of Berlin and Hamburg"
+	updated, err := s.UpdateDataset(ctx, &proto.UpdateDatasetRequest{Dataset: created.Dataset})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Dataset.Description != created.Dataset.Description {
+		t.Errorf("unexpected dataset %v", updated.Dataset)
+	}
+	other := context.WithValue(context.Background(), user.ContextKey("userDetails"), &user.Claims{Email: "other@example.com"})
+	_, err = s.DeleteDataset(other, &proto.DeleteDatasetRequest{Id: created.Dataset.Id})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if _, err := s.DeleteDataset(ctx, &proto.DeleteDatasetRequest{Id: created.Dataset.Id}); err != nil {
+		t.Fatal(err)
+	}
+	source = &queryJobSource{queryText: "select * from {{dataset:" + name + "}}"}
+	if err := s.expandDatasets(ctx, source); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestDatabaseFiles(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail+",other@example.com")
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	s.storagePrefix = "prod/"
+	s.maxFileSize = 100
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportID := created.Report.Id
+	uploaded := map[string]string{}
+	upload := func(ctx context.Context, name string, contentType string, data []byte) error {
+		uploaded[name] = contentType
+		return nil
+	}
+	var removed []string
+	remove := func(ctx context.Context, bucket string, name string) error {
+		removed = append(removed, name)
+		delete(uploaded, name)
+		return nil
+	}
+	data := []byte(`{"type":"Feature","geometry":{"type":"Point","coordinates":[13.4,52.5]}}`)
+	file, err := s.storeFile(ctx, reportID, "berlin.geojson", data, user.UnknownEmail, upload, remove)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectName := "prod/files/" + reportID + "/" + file.Id + ".geojson"
+	if file.FileType != proto.FileType_FILE_TYPE_GEOJSON || file.Size != int64(len(data)) || uploaded[objectName] != "application/geo+json" {
+		t.Errorf("unexpected file %v uploaded as %v", file, uploaded)
+	}
+	// file is downloaded by id like query result
+	if obj, err := s.resultObject(ctx, file.Id); err != nil || obj.ObjectName() != objectName {
+		t.Errorf("unexpected object of file %v %v", obj, err)
+	}
+	if name := s.fileFilename(ctx, file.Id); name != "berlin.geojson" {
+		t.Errorf("unexpected filename %q", name)
+	}
+	got, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: reportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Files) != 1 || got.Files[0].Id != file.Id || got.Files[0].Name != "berlin.geojson" || got.Files[0].CreatedAt == 0 {
+		t.Errorf("unexpected files of report %v", got.Files)
+	}
+	// files of report are limited to 10 times of max file size
+	if _, err := s.storeFile(ctx, reportID, "large.csv", []byte("a\n"+strings.Repeat("1\n", 490)), user.UnknownEmail, upload, remove); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+	if _, err := s.storeFile(ctx, reportID, "points.csv", []byte("lat,lng\n1,2,3\n"), user.UnknownEmail, upload, remove); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	other := context.WithValue(context.Background(), user.ContextKey("userDetails"), &user.Claims{Email: "other@example.com"})
+	if _, err := s.storeFile(other, reportID, "points.csv", []byte("lat,lng\n"), "other@example.com", upload, remove); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound of report of other author, got %v", err)
+	}
+	// objects of files which were not stored are removed
+	if len(removed) != 2 || len(uploaded) != 1 {
+		t.Errorf("expected objects of rejected files removed, got %v uploaded %v", removed, uploaded)
+	}
+	if _, err := s.RemoveFile(other, &proto.RemoveFileRequest{FileId: file.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound of file of other author, got %v", err)
+	}
+	removed = nil
+	remove = func(ctx context.Context, bucket string, name string) error {
+		removed = append(removed, name)
+		return storage.ErrObjectNotExist
+	}
+	if err := s.removeFile(ctx, file.Id, user.UnknownEmail, remove); err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 || removed[0] != objectName {
+		t.Errorf("unexpected removed objects %v", removed)
+	}
+	files, err := s.getFiles(ctx, reportID)
+	if err != nil || len(files) != 0 {
+		t.Errorf("expected no files, got %v %v", files, err)
+	}
+	if name := s.fileFilename(ctx, file.Id); name != "" {
+		t.Errorf("expected removed file, got %q", name)
+	}
+}
+
+func TestDatabaseArchiveReportRemovesFiles(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	s.maxFileSize = 100
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reportID := created.Report.Id
+	uploaded := map[string]bool{}
+	upload := func(ctx context.Context, name string, contentType string, data []byte) error {
+		uploaded[name] = true
+		return nil
+	}
+	var fileIDs []string
+	for _, name := range []string{"points.csv", "areas.geojson"} {
+		data := []byte("lat,lng\n1,2\n")
+		if name == "areas.geojson" {
+			data = []byte(`{"type":"FeatureCollection","features":[]}`)
+		}
+		file, err := s.storeFile(ctx, reportID, name, data, user.UnknownEmail, upload, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileIDs = append(fileIDs, file.Id)
+	}
+	// object which can't be removed keeps its file, so it's removed when report is archived again
+	fail := true
+	remove := func(ctx context.Context, bucket string, name string) error {
+		if fail && strings.HasSuffix(name, ".geojson") {
+			return fmt.Errorf("storage unavailable")
+		}
+		delete(uploaded, name)
+		return nil
+	}
+	archive := &proto.ArchiveReportRequest{ReportId: reportID, Archive: true}
+	if _, err := s.archiveReport(ctx, archive, user.UnknownEmail, remove); err != nil {
+		t.Fatal(err)
+	}
+	if len(uploaded) != 1 || s.fileFilename(ctx, fileIDs[0]) != "" || s.fileFilename(ctx, fileIDs[1]) != "areas.geojson" {
+		t.Errorf("expected only csv removed, objects %v", uploaded)
+	}
+	fail = false
+	if _, err := s.archiveReport(ctx, archive, user.UnknownEmail, remove); err != nil {
+		t.Fatal(err)
+	}
+	files, err := s.getFiles(ctx, reportID)
+	if err != nil || len(files) != 0 || len(uploaded) != 0 {
+		t.Errorf("expected no files, got %v %v objects %v", files, err, uploaded)
+	}
+	var results int
+	if err := s.db.QueryRowContext(ctx, `select count(*) from results where id in ($1, $2)`, fileIDs[0], fileIDs[1]).Scan(&results); err != nil || results != 0 {
+		t.Errorf("expected results of files removed, got %d %v", results, err)
+	}
+}
+
+func TestDatabaseForkReusesResult(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1 as a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultID := newUUID()
+	if _, err := s.db.Exec(`insert into results (id, object_name) values ($1, $2)`, resultID, resultID+".csv"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.db.Exec(`update queries set job_status=$1, job_result_id=$2, executed_query_text=$3, total_rows=7 where id=$4`,
+		int32(proto.Query_JOB_STATUS_DONE), resultID, "select 1 as a", query.Query.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forkQueryID := func() string {
+		fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: created.Report.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		queries, err := s.getQueries(ctx, fork.ReportId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return queries[0].Id
+	}
+	reused, waited, archived := forkQueryID(), forkQueryID(), forkQueryID()
+	res, err := s.RunQuery(ctx, &proto.RunQueryRequest{QueryId: reused})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Reused {
+		t.Fatalf("expected result reused, got %v", res)
+	}
+	got, err := s.GetQuery(ctx, &proto.GetQueryRequest{QueryId: reused})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Query.JobResultId != resultID || got.Query.ReusedFromQueryId != query.Query.Id ||
+		got.Query.ResultCreatedAt == 0 || got.Query.TotalRows != 7 || got.Query.JobStatus != proto.Query_JOB_STATUS_DONE {
+		t.Errorf("unexpected reused query %v", got.Query)
+	}
+	if source, err := s.getQuerySource(ctx, reused, user.UnknownEmail); err != nil || source.sourceQueryID != "" {
+		t.Errorf("expected result reused only on first run, got %q %v", source.sourceQueryID, err)
+	}
+	// RunQueryAndWait reuses result like RunQuery and sends it without job
+	stream := &fakeRunQueryAndWaitStream{ctx: ctx}
+	if err := s.RunQueryAndWait(&proto.RunQueryAndWaitRequest{QueryId: waited}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.responses) != 1 || stream.responses[0].JobStatus != proto.Query_JOB_STATUS_DONE || stream.responses[0].JobResultId != resultID ||
+		stream.responses[0].TotalRows != 7 || stream.responses[0].DownloadUrl != "/api/v1/job-results/"+resultID+".csv" {
+		t.Errorf("unexpected responses %v", stream.responses)
+	}
+	// results of archived report can't be read by forking user
+	if _, err := s.ArchiveReport(ctx, &proto.ArchiveReportRequest{ReportId: created.Report.Id, Archive: true}); err != nil {
+		t.Fatal(err)
+	}
+	source, err := s.getQuerySource(ctx, archived, user.UnknownEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := s.reuseSourceResult(ctx, archived, source); err != nil || ok {
+		t.Errorf("expected result of archived report not reused, got %v %v", ok, err)
+	}
+}
+
+func TestDatabaseExplorationLimit(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateReport(ctx, &proto.UpdateReportRequest{Report: &proto.Report{Id: created.Report.Id, ExplorationLimit: 500}}); err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// forked report explores like its source
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: created.Report.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{created.Report.Id, fork.ReportId} {
+		got, err := s.getReport(ctx, id)
+		if err != nil || got.ExplorationLimit != 500 {
+			t.Errorf("%s: expected exploration limit 500, got %v %v", id, got, err)
+		}
+	}
+	source, err := s.getQuerySource(ctx, query.Query.Id, user.UnknownEmail)
+	if err != nil || source.options.ExplorationLimit != 500 {
+		t.Errorf("expected exploration limit of run, got %d %v", source.options.ExplorationLimit, err)
+	}
+	queries, err := s.getQueries(ctx, created.Report.Id)
+	if err != nil || len(queries) != 1 || queries[0].ExplorationLimit != 0 {
+		t.Errorf("expected query not run with limit yet, got %v %v", queries, err)
+	}
+}
+
+func TestDatabaseReportResultSettings(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	s.resultSettings = &proto.ResultSettings{ResultFormat: proto.ResultFormat_RESULT_FORMAT_PARQUET}
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := s.getQuerySource(ctx, query.Query.Id, user.UnknownEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.applyResultSettings(runResultSettings(0, 0, nil), nil, s.resultSettings)
+	if source.options.ResultFormat != proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		t.Errorf("expected instance default Parquet, got %v", source.options.ResultFormat)
+	}
+
+	settings := &proto.ResultSettings{
+		ResultFormat:      proto.ResultFormat_RESULT_FORMAT_CSV,
+		ResultCompression: proto.ResultCompression_RESULT_COMPRESSION_GZIP,
+		Delimiter:         ";",
+	}
+	if _, err := s.UpdateReportSettings(ctx, &proto.UpdateReportSettingsRequest{ReportId: created.Report.Id, ResultSettings: settings}); err != nil {
+		t.Fatal(err)
+	}
+	// forked report runs with settings of its source
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: created.Report.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{created.Report.Id, fork.ReportId} {
+		got, err := s.getReport(ctx, id)
+		if err != nil || got.ResultSettings.ResultCompression != settings.ResultCompression || got.ResultSettings.Delimiter != ";" {
+			t.Errorf("%s: expected result settings of report, got %v %v", id, got, err)
+		}
+	}
+	source, err = s.getQuerySource(ctx, query.Query.Id, user.UnknownEmail)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.applyResultSettings(runResultSettings(0, 0, nil), nil, s.resultSettings)
+	if source.options.ResultFormat != proto.ResultFormat_RESULT_FORMAT_CSV || !compressed(source.options.Compression) || job.Delimiter(source.options.Serialization) != ';' {
+		t.Errorf("expected report settings over instance default, got %+v", source.options)
+	}
+
+	// effective settings are recorded with result
+	resultID := newUUID()
+	if _, err := s.db.Exec(`insert into results (id, object_name) values ($1, $2)`, resultID, resultID+".csv"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.storeResultEncoding(ctx, resultID, source.options); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(`update queries set job_result_id=$1 where id=$2`, resultID, query.Query.Id); err != nil {
+		t.Fatal(err)
+	}
+	queries, err := s.getQueries(ctx, created.Report.Id)
+	if err != nil || len(queries) != 1 || queries[0].ResultCompression != proto.ResultCompression_RESULT_COMPRESSION_GZIP {
+		t.Errorf("expected gzip result recorded, got %v %v", queries, err)
+	}
+	serialization, err := s.resultSerialization(ctx, resultID)
+	if err != nil || job.Delimiter(serialization) != ';' {
+		t.Errorf("expected delimiter of report recorded, got %v %v", serialization, err)
+	}
+
+	_, err = s.UpdateReportSettings(ctx, &proto.UpdateReportSettingsRequest{ReportId: created.Report.Id, ResultSettings: &proto.ResultSettings{
+		ResultFormat:      proto.ResultFormat_RESULT_FORMAT_PARQUET,
+		ResultCompression: proto.ResultCompression_RESULT_COMPRESSION_GZIP,
+	}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	other := context.WithValue(context.Background(), user.ContextKey("userDetails"), &user.Claims{Email: "other@example.com"})
+	_, err = s.UpdateReportSettings(other, &proto.UpdateReportSettingsRequest{ReportId: created.Report.Id, ResultSettings: settings})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected report of other author not found, got %v", err)
+	}
+}
+
+func TestDatabaseExecutedQuery(t *testing.T) {
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select * from points"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.GetExecutedQuery(ctx, &proto.GetExecutedQueryRequest{QueryId: query.Query.Id})
+	if err != nil || res.ExecutedQueryText != "" || res.ExecutedQueryHash != "" {
+		t.Errorf("expected query not run, got %v %v", res, err)
+	}
+	executed := "SELECT * FROM (\nSELECT * FROM points TABLESAMPLE SYSTEM (10 PERCENT)\n) LIMIT 100"
+	_, err = s.db.Exec(`update queries set executed_query_text=$1, executed_default_dataset=$2, sample_rate=0.1, exploration_limit=100 where id=$3`,
+		executed, "data-project.team", query.Query.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.GetExecutedQuery(ctx, &proto.GetExecutedQueryRequest{QueryId: query.Query.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ExecutedQueryText != executed || res.ExecutedQueryHash != job.QueryHash(executed) || res.DefaultDataset != "data-project.team" ||
+		res.SampleRate != 0.1 || res.ExplorationLimit != 100 {
+		t.Errorf("unexpected executed query %v", res)
+	}
+	queries, err := s.getQueries(ctx, created.Report.Id)
+	if err != nil || len(queries) != 1 || queries[0].ExecutedQueryHash != res.ExecutedQueryHash {
+		t.Errorf("expected hash in query, got %v %v", queries, err)
+	}
+	if _, err := s.ArchiveReport(ctx, &proto.ArchiveReportRequest{ReportId: created.Report.Id, Archive: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetExecutedQuery(ctx, &proto.GetExecutedQueryRequest{QueryId: query.Query.Id}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected query of archived report not found, got %v", err)
+	}
+}
+
+func TestDatabaseJobWarnings(t *testing.T) {
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings, err := job.MarshalWarnings([]job.Warning{{Code: job.WarningH3RowsSkipped, Message: "1 rows"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(`update queries set job_warnings=$1 where id=$2`, warnings, query.Query.Id); err != nil {
+		t.Fatal(err)
+	}
+	queries, err := s.getQueries(ctx, created.Report.Id)
+	if err != nil || len(queries) != 1 {
+		t.Fatalf("unexpected queries %v %v", queries, err)
+	}
+	if w := queries[0].Warnings; len(w) != 1 || w[0].Code != job.WarningH3RowsSkipped || w[0].Message != "1 rows" {
+		t.Errorf("expected stored warning, got %v", w)
+	}
+}
+
+func TestDatabaseRunAllQueries(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	os.Setenv("DEKART_MAX_QUERY_TEXT_SIZE", "64")
+	defer os.Unsetenv("DEKART_MAX_QUERY_TEXT_SIZE")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	source, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceQuery, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: source.Report.Id, QueryText: "select 1 as a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultID := newUUID()
+	if _, err := s.db.Exec(`insert into results (id, object_name) values ($1, $2)`, resultID, resultID+".csv"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.db.Exec(`update queries set job_status=$1, job_result_id=$2, executed_query_text=$3 where id=$4`,
+		int32(proto.Query_JOB_STATUS_DONE), resultID, "select 1 as a", sourceQuery.Query.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: source.Report.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forked, err := s.getQueries(ctx, fork.ReportId)
+	if err != nil || len(forked) != 1 {
+		t.Fatalf("unexpected queries %v %v", forked, err)
+	}
+	// mixed batch: result of fork is reused, other queries fail to start without stopping batch
+	undefined, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: fork.ReportId, QueryText: "select {{missing}}"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tooLarge, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: fork.ReportId, QueryText: "select 2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(`update queries set query_text=$1 where id=$2`, "select '"+strings.Repeat("x", 64)+"'", tooLarge.Query.Id); err != nil {
+		t.Fatal(err)
+	}
+	// members of earlier batch leave it
+	if _, err := s.db.Exec(`update queries set batch_id=$1 where report_id=$2`, newUUID(), fork.ReportId); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.RunAllQueries(ctx, &proto.RunAllQueriesRequest{ReportId: fork.ReportId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.BatchId == "" || len(res.Runs) != 3 {
+		t.Fatalf("unexpected batch %v", res)
+	}
+	runs := map[string]*proto.BatchRun{}
+	for _, run := range res.Runs {
+		runs[run.QueryId] = run
+	}
+	if run := runs[forked[0].Id]; run == nil || !run.Reused || run.Error != "" {
+		t.Errorf("expected reused result, got %v", run)
+	}
+	if run := runs[undefined.Query.Id]; run == nil || !strings.Contains(run.Error, "missing") {
+		t.Errorf("expected undefined variable error, got %v", run)
+	}
+	if run := runs[tooLarge.Query.Id]; run == nil || !strings.Contains(run.Error, "exceeds limit") {
+		t.Errorf("expected text too large error, got %v", run)
+	}
+	queries, err := s.getQueries(ctx, fork.ReportId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	progress := batchProgress(queries)
+	if progress == nil || progress.BatchId != res.BatchId || progress.Total != 3 || progress.Complete != 3 || progress.Failed != 2 {
+		t.Errorf("expected reused and failed queries complete in batch, got %v", progress)
+	}
+	cancelled, err := s.CancelBatch(ctx, &proto.CancelBatchRequest{ReportId: fork.ReportId, BatchId: res.BatchId})
+	if err != nil || cancelled.CancelledCount != 0 {
+		t.Errorf("expected finished batch not cancelled, got %v %v", cancelled, err)
+	}
+	if _, err := s.RunAllQueries(ctx, &proto.RunAllQueriesRequest{ReportId: newUUID()}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestDatabaseQueryDependencies(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, text := range []string{"create table data.a as select 1", "select * from data.a", "select * from data.b"} {
+		query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: text}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, query.Query.Id)
+	}
+	update := func(queryID string, dependsOn ...string) error {
+		_, err := s.UpdateQueryDependencies(ctx, &proto.UpdateQueryDependenciesRequest{QueryId: queryID, DependsOn: dependsOn})
+		return err
+	}
+	if err := update(ids[1], ids[0], ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := update(ids[2], ids[1]); err != nil {
+		t.Fatal(err)
+	}
+	err = update(ids[0], ids[2])
+	// chain starts at any query of cycle, queries created in same second have no order in MySQL
+	for _, edge := range []string{"Query 1 -> Query 3", "Query 3 -> Query 2", "Query 2 -> Query 1"} {
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), edge) {
+			t.Errorf("expected cycle named with %s, got %v", edge, err)
+		}
+	}
+	if err := update(ids[0], ids[0]); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected self dependency rejected, got %v", err)
+	}
+	if err := update(ids[0], newUUID()); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected dependency outside of report rejected, got %v", err)
+	}
+	queries, err := s.getQueries(ctx, created.Report.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string]*proto.Query{}
+	for _, query := range queries {
+		byID[query.Id] = query
+	}
+	if len(byID[ids[0]].DependsOn) != 0 || !reflect.DeepEqual(byID[ids[1]].DependsOn, []string{ids[0]}) || !reflect.DeepEqual(byID[ids[2]].DependsOn, []string{ids[1]}) {
+		t.Errorf("unexpected dependencies %v %v %v", byID[ids[0]].DependsOn, byID[ids[1]].DependsOn, byID[ids[2]].DependsOn)
+	}
+	// fork depends on its own queries
+	fork, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: created.Report.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forked, err := s.getQueries(ctx, fork.ReportId)
+	if err != nil || len(forked) != 3 {
+		t.Fatalf("unexpected fork %v %v", forked, err)
+	}
+	byTitle := map[string]*proto.Query{}
+	for _, query := range forked {
+		byTitle[query.Title] = query
+	}
+	if deps := byTitle["Query 2"].DependsOn; len(deps) != 1 || deps[0] != byTitle["Query 1"].Id {
+		t.Errorf("expected dependency on forked query, got %v", deps)
+	}
+	// skipped status names dependency, it's cleared when query runs again
+	s.storeBatchStatus(ctx, created.Report.Id, ids[1], newUUID(), proto.Query_JOB_STATUS_SKIPPED, "skipped", ids[0])
+	queries, err = s.getQueries(ctx, created.Report.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range queries {
+		byID[query.Id] = query
+	}
+	if q := byID[ids[1]]; q.JobStatus != proto.Query_JOB_STATUS_SKIPPED || q.SkippedDependencyId != ids[0] || q.JobError != "skipped" {
+		t.Errorf("unexpected skipped query %v", q)
+	}
+	if progress := batchProgress(queries); progress == nil || progress.Skipped != 1 || progress.Complete != 1 {
+		t.Errorf("expected skipped query complete, got %v", progress)
+	}
+}
+
+func TestDatabaseSoftDeletedQuery(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, text := range []string{"select 1", "select 2"} {
+		query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: text}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, query.Query.Id)
+	}
+	// result of deleted query reused by other query is kept
+	resultID, reusedID := newUUID(), newUUID()
+	for _, id := range []string{resultID, reusedID} {
+		if _, err := s.createResultObject(ctx, created.Report.Id, ids[0], id, ".csv"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for queryID, id := range map[string]string{ids[0]: resultID, ids[1]: reusedID} {
+		if _, err := s.db.Exec(`update queries set job_result_id=$1 where id=$2`, id, queryID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	visible := func(queryID string) bool {
+		queries, err := s.getQueries(ctx, created.Report.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, query := range queries {
+			if query.Id == queryID {
+				return true
+			}
+		}
+		return false
+	}
+
+	removed, err := s.RemoveQuery(ctx, &proto.RemoveQueryRequest{QueryId: ids[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if until := time.Unix(removed.RestorableUntil, 0); until.Before(time.Now().AddDate(0, 0, 29)) {
+		t.Errorf("expected query restorable for 30 days, got %v", until)
+	}
+	if visible(ids[0]) || !visible(ids[1]) {
+		t.Error("expected deleted query hidden from report")
+	}
+	if _, err := s.RunQuery(ctx, &proto.RunQueryRequest{QueryId: ids[0]}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected run of deleted query rejected, got %v", err)
+	}
+	if _, err := s.UpdateQuery(ctx, &proto.UpdateQueryRequest{Query: &proto.Query{Id: ids[0], QueryText: "select 3"}}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected update of deleted query rejected, got %v", err)
+	}
+	restored, err := s.RestoreQuery(ctx, &proto.RestoreQueryRequest{QueryId: ids[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Query.QueryText != "select 1" || restored.Query.JobResultId != resultID || !visible(ids[0]) {
+		t.Errorf("unexpected restored query %v", restored.Query)
+	}
+	if _, err := s.RestoreQuery(ctx, &proto.RestoreQueryRequest{QueryId: ids[0]}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected restore of query which is not deleted rejected, got %v", err)
+	}
+
+	if _, err := s.RemoveQuery(ctx, &proto.RemoveQueryRequest{QueryId: ids[0]}); err != nil {
+		t.Fatal(err)
+	}
+	// clock after retention
+	expiredCutoff := time.Now().Add(time.Hour)
+	if _, err := s.restoreQuery(ctx, ids[0], user.UnknownEmail, expiredCutoff); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected restore after retention rejected, got %v", err)
+	}
+	// queries deleted within retention are not swept
+	swept, err := s.sweepDeletedQueries(ctx, time.Now().Add(-time.Hour), nil)
+	if err != nil || swept != 0 {
+		t.Fatalf("expected nothing swept, got %d %v", swept, err)
+	}
+	failing := func(ctx context.Context, bucket string, name string) error { return errors.New("unavailable") }
+	swept, err = s.sweepDeletedQueries(ctx, expiredCutoff, failing)
+	if err != nil || swept != 0 {
+		t.Fatalf("expected query kept when result can't be removed, got %d %v", swept, err)
+	}
+	var removedObjects []string
+	remove := func(ctx context.Context, bucket string, name string) error {
+		removedObjects = append(removedObjects, name)
+		return storage.ErrObjectNotExist
+	}
+	swept, err = s.sweepDeletedQueries(ctx, expiredCutoff, remove)
+	if err != nil || swept != 1 {
+		t.Fatalf("expected deleted query swept, got %d %v", swept, err)
+	}
+	if !reflect.DeepEqual(removedObjects, []string{resultID + ".csv"}) {
+		t.Errorf("expected only result of deleted query removed, got %v", removedObjects)
+	}
+	var queries, results int
+	if err := s.db.QueryRow(`select count(*) from queries where id=$1`, ids[0]).Scan(&queries); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.db.QueryRow(`select count(*) from results where query_id=$1`, ids[0]).Scan(&results); err != nil {
+		t.Fatal(err)
+	}
+	if queries != 0 || results != 1 {
+		t.Errorf("expected query and its result removed, got %d queries %d results", queries, results)
+	}
+	if _, err := s.RestoreQuery(ctx, &proto.RestoreQueryRequest{QueryId: ids[0]}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected swept query not found, got %v", err)
+	}
+}
+
+// diffBuffer of compared results, closed when diff is committed
+type diffBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *diffBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestDatabaseCompareResults(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queryIDs []string
+	for i := 0; i < 2; i++ {
+		query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		queryIDs = append(queryIDs, query.Query.Id)
+	}
+	baseID, headID, otherID := newUUID(), newUUID(), newUUID()
+	for id, queryID := range map[string]string{baseID: queryIDs[0], headID: queryIDs[0], otherID: queryIDs[1]} {
+		if _, err := s.createResultObject(ctx, created.Report.Id, queryID, id, ".csv"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]string{
+		baseID:  "id,value\n1,a\n2,b\n",
+		headID:  "id,value\n1,a\n2,c\n3,d\n",
+		otherID: "id,value\n1,a\n",
+	}
+	source := func(ctx context.Context, resultID string) (io.ReadCloser, int64, error) {
+		return ioutil.NopCloser(strings.NewReader(files[resultID])), int64(len(files[resultID])), nil
+	}
+	diffs := map[string]*diffBuffer{}
+	createDiff := func(ctx context.Context, reportID string, resultID string) (io.WriteCloser, error) {
+		if _, err := s.createResultObject(ctx, reportID, "", resultID, ".csv"); err != nil {
+			return nil, err
+		}
+		diffs[resultID] = &diffBuffer{}
+		return diffs[resultID], nil
+	}
+	compare := func(baseID, resultID string, withDiff bool) (*proto.CompareResultsResponse, error) {
+		return s.compareResults(ctx, &proto.CompareResultsRequest{
+			BaseResultId: baseID,
+			ResultId:     resultID,
+			KeyColumn:    "id",
+			CreateDiff:   withDiff,
+		}, source, createDiff)
+	}
+	res, err := compare(baseID, headID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.RowsAdded != 1 || res.RowsChanged != 1 || res.RowsUnchanged != 1 || res.RowsRemoved != 0 {
+		t.Errorf("unexpected summary %v", res)
+	}
+	diff := diffs[res.DiffResultId]
+	if diff == nil || !diff.closed || diff.String() != "_change,id,value\nchanged,2,c\nadded,3,d\n" {
+		t.Errorf("unexpected diff %v", diff)
+	}
+	// diff is not result of query
+	if _, err := compare(res.DiffResultId, headID, false); status.Code(err) != codes.NotFound {
+		t.Errorf("expected diff result not found, got %v", err)
+	}
+	if _, err := compare(baseID, otherID, false); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected results of other query rejected, got %v", err)
+	}
+	s.maxCompareSize = int64(len(files[baseID]))
+	if _, err := compare(baseID, headID, false); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected results too large, got %v", err)
+	}
+	failing := func(ctx context.Context, resultID string) (io.ReadCloser, int64, error) {
+		return nil, 0, errParquetCompare
+	}
+	req := &proto.CompareResultsRequest{BaseResultId: baseID, ResultId: headID}
+	if _, err := s.compareResults(ctx, req, failing, createDiff); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected parquet result rejected, got %v", err)
+	}
+}
+
+func TestDatabasePublicResults(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), (&storage.Client{}).Bucket("dekart"), job.NewStore(), report.NewStreams())
+	now := time.Unix(1600000000, 0)
+	s.shares = newShareCache(time.Minute)
+	s.shares.now = func() time.Time { return now }
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	csvID, parquetID := newUUID(), newUUID()
+	objects := map[string]string{}
+	for id, extension := range map[string]string{csvID: ".csv", parquetID: ".parquet"} {
+		obj, err := s.createResultObject(ctx, created.Report.Id, query.Query.Id, id, extension)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects[obj.ObjectName()] = "content of " + id
+	}
+	open := func(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader(objects[objectName])), nil
+	}
+	serve := func(resultID string, format string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "/public/results/"+resultID+"."+format, nil)
+		for name, values := range header {
+			r.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		s.servePublicResult(w, mux.SetURLVars(r, map[string]string{"id": resultID, "format": format}), open)
+		return w
+	}
+
+	// private results are never served
+	if w := serve(csvID, "csv", nil); w.Code != http.StatusForbidden || w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected private result forbidden, got %d %v", w.Code, w.Header())
+	}
+	if _, err := s.ShareReport(ctx, &proto.ShareReportRequest{ReportId: created.Report.Id, Public: true}); err != nil {
+		t.Fatal(err)
+	}
+	w := serve(csvID, "csv", nil)
+	if w.Code != http.StatusOK || w.Body.String() != "content of "+csvID {
+		t.Fatalf("expected shared result served, got %d %s", w.Code, w.Body.String())
+	}
+	etag := `"` + csvID + `"`
+	if w.Header().Get("Cache-Control") != "public, max-age=60" || w.Header().Get("ETag") != etag || w.Header().Get("Content-Type") != "text/csv" {
+		t.Errorf("unexpected headers %v", w.Header())
+	}
+	if w := serve(csvID, "csv", http.Header{"If-None-Match": {etag}}); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("expected not modified, got %d", w.Code)
+	}
+	if w := serve(parquetID, "parquet", nil); w.Code != http.StatusOK || w.Header().Get("Content-Type") != job.ParquetContentType {
+		t.Errorf("expected parquet result served, got %d %v", w.Code, w.Header())
+	}
+	// result has one public path
+	if w := serve(csvID, "parquet", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected other extension not found, got %d", w.Code)
+	}
+
+	// share revoked on other replica is served until cached check expires
+	if _, err := s.db.ExecContext(ctx, "update reports set public_share=false where id=$1", created.Report.Id); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Minute - time.Second)
+	if w := serve(csvID, "csv", nil); w.Code != http.StatusOK {
+		t.Errorf("expected cached share served, got %d", w.Code)
+	}
+	now = now.Add(time.Second)
+	if w := serve(csvID, "csv", http.Header{"If-None-Match": {etag}}); w.Code != http.StatusForbidden {
+		t.Errorf("expected revoked share forbidden, got %d", w.Code)
+	}
+
+	// share changed on this replica applies at once
+	if _, err := s.ShareReport(ctx, &proto.ShareReportRequest{ReportId: created.Report.Id, Public: true}); err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(csvID, "csv", nil); w.Code != http.StatusOK {
+		t.Errorf("expected shared result served, got %d", w.Code)
+	}
+	res, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: created.Report.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Report.Public {
+		t.Errorf("expected public report")
+	}
+	if _, err := s.ShareReport(ctx, &proto.ShareReportRequest{ReportId: created.Report.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if w := serve(csvID, "csv", nil); w.Code != http.StatusForbidden {
+		t.Errorf("expected revoked share forbidden, got %d", w.Code)
+	}
+}
+
+func TestDatabaseResultLineage(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	s.lineageQueryText = true
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1 as a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultID := newUUID()
+	if _, err := s.db.Exec(`insert into results (id, object_name, query_id) values ($1, $2, $3)`, resultID, resultID+".csv", query.Query.Id); err != nil {
+		t.Fatal(err)
+	}
+	// result stored before lineage was recorded
+	if _, err := s.GetResultLineage(ctx, &proto.GetResultLineageRequest{ResultId: resultID}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound of result without lineage, got %v", err)
+	}
+
+	j := s.jobs.New(context.Background(), created.Report.Id, query.Query.Id)
+	defer j.Abort()
+	j.UserEmail = user.UnknownEmail
+	tx, err := s.db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := job.StatusSnapshot{ResultID: &resultID, QueryText: "select 1 as a", QueryHash: job.QueryHash("select 1 as a")}
+	if err := s.storeResultLineage(ctx, tx, j, snapshot, time.Unix(1000, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.GetResultLineage(ctx, &proto.GetResultLineageRequest{ResultId: resultID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lineage := res.Lineage
+	if lineage.JobId != j.ID || lineage.QueryId != query.Query.Id || lineage.ReportId != created.Report.Id || lineage.UserEmail != user.UnknownEmail {
+		t.Errorf("unexpected lineage %v", lineage)
+	}
+	if lineage.QueryHash != snapshot.QueryHash || lineage.QueryText != "select 1 as a" || lineage.DekartVersion != Version || lineage.JobFinished != 1000 {
+		t.Errorf("unexpected lineage %v", lineage)
+	}
+	if _, err := s.GetResultLineage(ctx, &proto.GetResultLineageRequest{ResultId: newUUID()}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound of unknown result, got %v", err)
+	}
+}
+
+func TestDatabaseResultFieldTypes(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1 as a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resultID := newUUID()
+	if _, err := s.db.Exec(`insert into results (id, object_name, query_id) values ($1, $2, $3)`, resultID, resultID+".csv", query.Query.Id); err != nil {
+		t.Fatal(err)
+	}
+	// result stored before column types were recorded
+	if _, err := s.GetResultFieldTypes(ctx, &proto.GetResultFieldTypesRequest{ResultId: resultID}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound of result without column types, got %v", err)
+	}
+	if _, err := s.db.Exec(`update results set column_types=$1 where id=$2`, `["INTEGER","NUMERIC(10,2)","GEOGRAPHY","BOOLEAN"]`, resultID); err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.GetResultFieldTypes(ctx, &proto.GetResultFieldTypesRequest{ResultId: resultID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fieldTypes []string
+	for _, field := range res.Fields {
+		fieldTypes = append(fieldTypes, field.FieldType)
+	}
+	if len(res.Fields) != 4 || res.Fields[1].ColumnType != "NUMERIC(10,2)" || strings.Join(fieldTypes, ",") != "integer,real,geojson,boolean" {
+		t.Errorf("unexpected fields %v", res.Fields)
+	}
+	if res.Fields[0].OriginalName != "" {
+		t.Errorf("expected no original names of result with original header, got %v", res.Fields[0])
+	}
+	names := `[{"name":"id","original":"id"},{"name":"total_amount","original":"Total Amount"},{"name":"geom","original":"geom"},{"name":"ok","original":"ok"}]`
+	if _, err := s.db.Exec(`update results set column_names=$1 where id=$2`, names, resultID); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.GetResultFieldTypes(ctx, &proto.GetResultFieldTypesRequest{ResultId: resultID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Fields[1].Name != "total_amount" || res.Fields[1].OriginalName != "Total Amount" {
+		t.Errorf("expected original name of renamed column, got %v", res.Fields[1])
+	}
+	if _, err := s.GetResultFieldTypes(ctx, &proto.GetResultFieldTypesRequest{ResultId: newUUID()}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound of unknown result, got %v", err)
+	}
+}
+
+func TestDatabaseJobEvents(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	db := openTestDatabase(t)
+	jobs := job.NewStore()
+	s := NewServer(db, nil, jobs, report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// running job of report
+	running := jobs.New(ctx, created.Report.Id, newUUID())
+	defer running.Abort()
+	res, err := s.GetJobEvents(ctx, &proto.GetJobEventsRequest{JobId: running.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Finished || len(res.Events) != 0 {
+		t.Errorf("unexpected events of running job %v", res)
+	}
+	stats := job.FinishedJob{
+		JobID:    newUUID(),
+		QueryID:  newUUID(),
+		ReportID: created.Report.Id,
+		Events: []job.Event{
+			{Time: 1600000000000, Type: job.EventState, Message: "RUNNING"},
+			{Time: 1600000003000, Type: job.EventState, Message: "FAILED"},
+		},
+		EventsDropped: 2,
+	}
+	if err := NewJobStatsRecorder(db).Record(ctx, stats); err != nil {
+		t.Fatal(err)
+	}
+	res, err = s.GetJobEvents(ctx, &proto.GetJobEventsRequest{JobId: stats.JobID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Finished || res.EventsDropped != 2 || len(res.Events) != 2 || res.Events[1].Message != "FAILED" || res.Events[1].Time != 1600000003000 {
+		t.Errorf("unexpected events of finished job %v", res)
+	}
+	// job of report user can't read
+	stats.JobID, stats.ReportID = newUUID(), newUUID()
+	if err := NewJobStatsRecorder(db).Record(ctx, stats); err != nil {
+		t.Fatal(err)
+	}
+	for _, jobID := range []string{stats.JobID, newUUID()} {
+		if _, err := s.GetJobEvents(ctx, &proto.GetJobEventsRequest{JobId: jobID}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+	}
+}
+
+func TestDatabaseCancelReason(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: created.Report.Id, QueryText: "select 1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// cancelJob of query with reason and store its cancelled status
+	cancelJob := func(queryID string, reason proto.CancelReason) {
+		j := s.jobs.New(context.Background(), created.Report.Id, queryID)
+		go func() {
+			select {
+			case <-j.Status:
+			case <-j.Ctx.Done():
+			}
+		}()
+		s.jobs.CancelJob(j.ID, reason)
+		s.storeJobStatus(j, j.GetStatus().Status)
+	}
+	storedReason := func() proto.CancelReason {
+		queries, err := s.getQueries(ctx, created.Report.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return queries[0].CancelReason
+	}
+	// status of jobs cancelled at shutdown is stored before Shutdown returns
+	s.watchJobStatus(s.jobs.New(context.Background(), created.Report.Id, query.Query.Id))
+	if cancelled := s.Shutdown(5 * time.Second); cancelled != 1 {
+		t.Errorf("expected 1 job cancelled at shutdown, got %d", cancelled)
+	}
+	if reason := storedReason(); reason != proto.CancelReason_CANCEL_REASON_SHUTDOWN {
+		t.Errorf("expected shutdown reason stored with query, got %s", reason)
+	}
+	// query record has status of job which superseded cancelled one
+	cancelJob(query.Query.Id, proto.CancelReason_CANCEL_REASON_SUPERSEDED)
+	if reason := storedReason(); reason != proto.CancelReason_CANCEL_REASON_SHUTDOWN {
+		t.Errorf("expected status of superseded job not stored, got %s", reason)
+	}
+
+	// admin cancels query of other author
+	otherReportID, otherQueryID := newUUID(), newUUID()
+	if _, err := s.db.Exec(`insert into reports (id, author_email) values ($1, $2)`, otherReportID, "other@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.db.Exec(`insert into queries (id, report_id, query_text) values ($1, $2, $3)`, otherQueryID, otherReportID, "select 2"); err != nil {
+		t.Fatal(err)
+	}
+	other := s.jobs.New(context.Background(), otherReportID, otherQueryID)
+	go func() {
+		select {
+		case <-other.Status:
+		case <-other.Ctx.Done():
+		}
+	}()
+	if _, err := s.CancelQuery(ctx, &proto.CancelQueryRequest{QueryId: otherQueryID}); err != nil {
+		t.Fatal(err)
+	}
+	if reason := other.GetStatus().CancelReason; reason != proto.CancelReason_CANCEL_REASON_ADMIN {
+		t.Errorf("expected admin reason, got %s", reason)
+	}
+}
+
+func TestDatabaseUsageQuota(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	db := openTestDatabase(t)
+	s := NewServer(db, nil, job.NewStore(), report.NewStreams())
+	ctx := testClaimsContext()
+	// month is unique, so usage of previous runs is not counted
+	year := 3000 + time.Now().Nanosecond()%6000
+	now := time.Date(year, time.December, 15, 12, 0, 0, 0, time.UTC)
+	s.quota = &usageQuota{budget: 1000, now: func() time.Time { return now }}
+	recorder := NewJobStatsRecorder(db)
+	alice := newUUID() + "@example.com"
+	record := func(billed int64) {
+		t.Helper()
+		stats := job.FinishedJob{JobID: newUUID(), QueryID: newUUID(), ReportID: newUUID(), UserEmail: alice, BytesBilled: billed, FinishedAt: now}
+		if err := recorder.Record(ctx, stats); err != nil {
+			t.Fatal(err)
+		}
+	}
+	record(600)
+	record(0)
+	if err := s.checkQuota(ctx); err != nil {
+		t.Fatalf("expected budget left, got %v", err)
+	}
+	current, err := s.GetCurrentUser(ctx, &proto.GetCurrentUserRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// users see headroom only
+	if q := current.Quota; q == nil || q.HeadroomPercent != 40 || q.BytesBilled != 0 || q.ResetTime != time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC).Unix() {
+		t.Errorf("unexpected quota of user %v", q)
+	}
+
+	record(400)
+	st := status.Convert(internalError(s.checkQuota(ctx)))
+	if st.Code() != codes.ResourceExhausted || len(st.Details()) != 1 {
+		t.Fatalf("expected exhausted budget with details, got %v", st)
+	}
+	resetDate := fmt.Sprintf("%d-01-01", year+1)
+	if info, ok := st.Details()[0].(*errdetails.ErrorInfo); !ok || info.Reason != "QUOTA_EXCEEDED" || info.Metadata["resetDate"] != resetDate || info.Metadata["bytesBilled"] != "1000" {
+		t.Errorf("unexpected details %v", st.Details())
+	}
+	if !strings.Contains(st.Message(), resetDate) {
+		t.Errorf("expected reset date in message, got %q", st.Message())
+	}
+	if _, err := s.runQueryJob(ctx, newUUID(), queryJobSource{reportID: newUUID()}); status.Code(internalError(err)) != codes.ResourceExhausted {
+		t.Errorf("expected job rejected, got %v", err)
+	}
+	// raw SQL is run with the same budget
+	err = s.RunQueryAndWait(&proto.RunQueryAndWaitRequest{QueryText: "select 1"}, &fakeRunQueryAndWaitStream{ctx: ctx})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected raw SQL rejected, got %v", err)
+	}
+	if jobs := s.JobStats().Jobs; jobs != 0 {
+		t.Errorf("expected no job created, got %d", jobs)
+	}
+	usage, err := s.GetUsage(ctx, &proto.GetUsageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := usage.Quota; q == nil || q.Month != usageMonth(now) || q.BudgetBytes != 1000 || q.BytesBilled != 1000 || q.HeadroomPercent != 0 {
+		t.Errorf("unexpected quota %v", q)
+	}
+	// counter of user allows quotas per user
+	if billed, err := s.monthUsage(ctx, usageMonth(now), alice); err != nil || billed != 1000 {
+		t.Errorf("expected usage of user, got %d %v", billed, err)
+	}
+
+	// budget is available again in next month
+	now = now.AddDate(0, 1, 0)
+	if err := s.checkQuota(ctx); err != nil {
+		t.Errorf("expected budget after reset, got %v", err)
+	}
+}
+
+func TestDatabaseSchemaSnapshot(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	os.Setenv("DEKART_BIGQUERY_DATASETS", "p.data,p.shared*")
+	defer os.Unsetenv("DEKART_BIGQUERY_DATASETS")
+	s := NewServer(openTestDatabase(t), nil, job.NewStore(), report.NewStreams())
+	s.schemaSnapshots, _ = parseSchemaSnapshots("p.region-us", "1h")
+	snapshotAt := time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC)
+	now := snapshotAt
+	s.now = func() time.Time { return now }
+	columns := []job.SchemaSnapshotColumn{
+		{Table: "p.data.points", Name: "id", Type: "INTEGER", Mode: "NULLABLE"},
+		{Table: "p.data.points", Name: "geom", Type: "GEOGRAPHY", Mode: "NULLABLE", Description: "location"},
+		{Table: "p.private.users", Name: "email", Type: "STRING", Mode: "NULLABLE"},
+		{Table: "p.shared_2021.areas", Name: "id", Type: "INTEGER", Mode: "NULLABLE"},
+		{Table: "p.sharedX2021.areas", Name: "id", Type: "INTEGER", Mode: "NULLABLE"},
+	}
+	var readErr error
+	s.readInformationSchema = func(ctx context.Context, source string, visit func(job.SchemaSnapshotColumn) error) error {
+		if source != "p.region-us" {
+			t.Errorf("unexpected source %s", source)
+		}
+		for _, column := range columns {
+			if err := visit(column); err != nil {
+				return err
+			}
+		}
+		return readErr
+	}
+	ctx := testClaimsContext()
+
+	// source without snapshot is stale
+	listed, err := s.GetSchemaSnapshot(ctx, &proto.GetSchemaSnapshotRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed.Sources) != 1 || !listed.Sources[0].Stale || listed.Sources[0].SnapshotAt != 0 || len(listed.Tables) != 0 {
+		t.Errorf("unexpected snapshot before refresh %v", listed)
+	}
+	refreshed, err := s.RefreshSchemaSnapshot(ctx, &proto.RefreshSchemaSnapshotRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source := refreshed.Sources[0]; source.SnapshotAt != snapshotAt.Unix() || source.ColumnsCount != 5 || source.Stale || source.Error != "" {
+		t.Errorf("unexpected refreshed source %v", source)
+	}
+
+	// tables outside of allowlist of connection are not listed, _ of prefix is not wildcard
+	for prefix, expected := range map[string][]string{
+		"":          {"p.data.points", "p.sharedX2021.areas", "p.shared_2021.areas"},
+		"p.shared_": {"p.shared_2021.areas"},
+		"p.private": nil,
+	} {
+		listed, err := s.GetSchemaSnapshot(ctx, &proto.GetSchemaSnapshotRequest{TablePrefix: prefix})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(listed.Tables, expected) || listed.Truncated {
+			t.Errorf("%q: expected tables %v, got %v", prefix, expected, listed.Tables)
+		}
+	}
+	points, err := s.GetSchemaSnapshot(ctx, &proto.GetSchemaSnapshotRequest{Table: "p.data.points"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points.Columns) != 2 || points.Columns[0].Name != "id" || points.Columns[1].Description != "location" {
+		t.Errorf("unexpected columns %v", points.Columns)
+	}
+	if _, err := s.GetSchemaSnapshot(ctx, &proto.GetSchemaSnapshotRequest{Table: "p.private.users"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+
+	// refresh failing after first column keeps previous snapshot, failure is recorded twice in same second
+	now = snapshotAt.Add(time.Hour)
+	readErr = errors.New("connection reset")
+	for i := 0; i < 2; i++ {
+		refreshed, err = s.RefreshSchemaSnapshot(ctx, &proto.RefreshSchemaSnapshotRequest{Source: "p.region-us"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if source := refreshed.Sources[0]; source.SnapshotAt != snapshotAt.Unix() || source.ColumnsCount != 5 || source.AgeSeconds != 3600 ||
+		source.Error != "connection reset" || source.FailedAt != now.Unix() || source.Stale {
+		t.Errorf("unexpected source after failed refresh %v", source)
+	}
+	points, err = s.GetSchemaSnapshot(ctx, &proto.GetSchemaSnapshotRequest{Table: "p.data.points"})
+	if err != nil || len(points.Columns) != 2 {
+		t.Errorf("expected previous columns, got %v %v", points, err)
+	}
+
+	// schema context reads snapshot, table created after snapshot is read from table metadata
+	var fetched []string
+	s.schemas = job.NewSchemaCache(0, func(ctx context.Context, billingProject string, table string) (bigquery.Schema, error) {
+		fetched = append(fetched, table)
+		return bigquery.Schema{{Name: "id", Type: bigquery.IntegerFieldType}}, nil
+	})
+	created, err := s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	queryText := "select * from p.data.points join p.data.created using (id)"
+	res, err := s.GetQuerySchemaContext(ctx, &proto.GetQuerySchemaContextRequest{ReportId: created.Report.Id, QueryText: queryText})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Tables, []string{"p.data.points", "p.data.created"}) || len(res.Columns) != 3 || res.Columns[1].Name != "geom" {
+		t.Errorf("unexpected schema context %v", res)
+	}
+	if res.SnapshotAgeSeconds != 3600 || !reflect.DeepEqual(fetched, []string{"p.data.created"}) {
+		t.Errorf("expected snapshot of hour and metadata of created table, got %d %v", res.SnapshotAgeSeconds, fetched)
+	}
+	if len(res.Warnings) != 1 || !strings.Contains(res.Warnings[0], "failed to refresh") {
+		t.Errorf("expected warning of failed refresh, got %v", res.Warnings)
+	}
+
+	// stale snapshot is listed, schema context reads table metadata
+	now = snapshotAt.Add(3*time.Hour + time.Second)
+	fetched = nil
+	res, err = s.GetQuerySchemaContext(ctx, &proto.GetQuerySchemaContextRequest{ReportId: created.Report.Id, QueryText: queryText})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.SnapshotAgeSeconds != 0 || len(fetched) != 2 || len(res.Warnings) != 0 {
+		t.Errorf("expected metadata of stale snapshot tables, got %v %v", res, fetched)
+	}
+	listed, err = s.GetSchemaSnapshot(ctx, &proto.GetSchemaSnapshotRequest{})
+	if err != nil || !listed.Sources[0].Stale || len(listed.Tables) != 3 {
+		t.Errorf("expected stale snapshot listed, got %v %v", listed, err)
+	}
+
+	// completed refresh replaces columns and clears error
+	readErr = nil
+	columns = columns[:1]
+	refreshed, err = s.RefreshSchemaSnapshot(ctx, &proto.RefreshSchemaSnapshotRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source := refreshed.Sources[0]; source.ColumnsCount != 1 || source.Error != "" || source.FailedAt != 0 || source.Stale {
+		t.Errorf("unexpected source after refresh %v", source)
+	}
+}
@@ -0,0 +1,320 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxDatasetDescriptionLength of catalog dataset
+const maxDatasetDescriptionLength = 4096
+
+// maxDatasets listed at once, catalog is expected to be small
+const maxDatasets = 1000
+
+func (s Server) datasetColumns() string {
+	return fmt.Sprintf(`id, name, description, query_text, author_email, %s`, s.dialect.Int(s.dialect.Epoch("updated_at")))
+}
+
+func scanDataset(rows interface{ Scan(...interface{}) error }, email string) (*proto.Dataset, error) {
+	dataset := &proto.Dataset{}
+	err := rows.Scan(
+		&dataset.Id,
+		&dataset.Name,
+		&dataset.Description,
+		&dataset.QueryText,
+		&dataset.AuthorEmail,
+		&dataset.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	dataset.CanWrite = dataset.AuthorEmail == email
+	return dataset, nil
+}
+
+// getDataset by id, nil when not found
+func (s Server) getDataset(ctx context.Context, id string, email string) (*proto.Dataset, error) {
+	dataset, err := scanDataset(s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select %s from datasets where id=$1`, s.datasetColumns()),
+		id,
+	), email)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return dataset, err
+}
+
+// validateDataset of request, its query text is normalized
+func (s Server) validateDataset(dataset *proto.Dataset) error {
+	if dataset == nil {
+		return status.Error(codes.InvalidArgument, "dataset is required")
+	}
+	queryText, err := s.normalizeQueryText(dataset.QueryText)
+	if err != nil {
+		return err
+	}
+	dataset.QueryText = queryText
+	if err := job.ValidateCatalogDataset(dataset.Name, dataset.QueryText); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(dataset.Description) > maxDatasetDescriptionLength {
+		return status.Errorf(codes.InvalidArgument, "description is longer than %d bytes", maxDatasetDescriptionLength)
+	}
+	return nil
+}
+
+// datasetWriteError when no rows of dataset were changed: not found or other author; nil for author, MySQL
+// doesn't count rows updated with same values
+func (s Server) datasetWriteError(ctx context.Context, id string, email string) error {
+	dataset, err := s.getDataset(ctx, id, email)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if dataset == nil {
+		err := fmt.Errorf("Dataset not found id:%s", id)
+		log.Warn().Err(err).Send()
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if dataset.CanWrite {
+		return nil
+	}
+	err = fmt.Errorf("%s can't edit dataset %s of %s", email, dataset.Name, dataset.AuthorEmail)
+	log.Warn().Err(err).Send()
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+// CreateDataset in catalog
+func (s Server) CreateDataset(ctx context.Context, req *proto.CreateDatasetRequest) (*proto.CreateDatasetResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if err := s.validateDataset(req.Dataset); err != nil {
+		return nil, err
+	}
+	id := newUUID()
+	_, err := s.db.ExecContext(ctx,
+		`insert into datasets (id, name, description, query_text, author_email) values ($1, $2, $3, $4, $5)
+		`+s.dialect.OnConflict([]string{"name"}, ""),
+		id,
+		req.Dataset.Name,
+		req.Dataset.Description,
+		req.Dataset.QueryText,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	// affected rows of kept row differ between drivers, so conflict is detected by id of new row
+	dataset, err := s.getDataset(ctx, id, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if dataset == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "dataset %s exists", req.Dataset.Name)
+	}
+	log.Info().Str("datasetID", id).Str("name", dataset.Name).Msg("Dataset created")
+	return &proto.CreateDatasetResponse{Dataset: dataset}, nil
+}
+
+// UpdateDataset of author
+func (s Server) UpdateDataset(ctx context.Context, req *proto.UpdateDatasetRequest) (*proto.UpdateDatasetResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if err := s.validateDataset(req.Dataset); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.Dataset.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	var taken int
+	err := s.db.QueryRowContext(ctx,
+		`select count(*) from datasets where name=$1 and id<>$2`,
+		req.Dataset.Name,
+		req.Dataset.Id,
+	).Scan(&taken)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if taken > 0 {
+		return nil, status.Errorf(codes.AlreadyExists, "dataset %s exists", req.Dataset.Name)
+	}
+	result, err := s.db.ExecContext(ctx,
+		`update datasets set name=$1, description=$2, query_text=$3, updated_at=CURRENT_TIMESTAMP
+		where id=$4 and author_email=$5`,
+		req.Dataset.Name,
+		req.Dataset.Description,
+		req.Dataset.QueryText,
+		req.Dataset.Id,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		if err := s.datasetWriteError(ctx, req.Dataset.Id, claims.Email); err != nil {
+			return nil, err
+		}
+	}
+	dataset, err := s.getDataset(ctx, req.Dataset.Id, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return &proto.UpdateDatasetResponse{Dataset: dataset}, nil
+}
+
+// DeleteDataset of author; queries referencing it fail on next run
+func (s Server) DeleteDataset(ctx context.Context, req *proto.DeleteDatasetRequest) (*proto.DeleteDatasetResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	result, err := s.db.ExecContext(ctx,
+		`delete from datasets where id=$1 and author_email=$2`,
+		req.Id,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		if err := s.datasetWriteError(ctx, req.Id, claims.Email); err != nil {
+			return nil, err
+		}
+	}
+	log.Info().Str("datasetID", req.Id).Msg("Dataset deleted")
+	return &proto.DeleteDatasetResponse{}, nil
+}
+
+// ListDatasets of catalog, all datasets are readable by every user
+func (s Server) ListDatasets(ctx context.Context, req *proto.ListDatasetsRequest) (*proto.ListDatasetsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_VIEWER); err != nil {
+		return nil, err
+	}
+	var args sqlArgs
+	where := ""
+	if search := strings.TrimSpace(req.Search); search != "" {
+		pattern := args.add(likePattern(search))
+		where = fmt.Sprintf("where %s or %s", s.dialect.ILike("name", pattern), s.dialect.ILike("description", pattern))
+	}
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`select %s from datasets %s order by name limit %d`, s.datasetColumns(), where, maxDatasets),
+		args...,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	defer rows.Close()
+	res := &proto.ListDatasetsResponse{Datasets: []*proto.Dataset{}}
+	for rows.Next() {
+		dataset, err := scanDataset(rows, claims.Email)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		res.Datasets = append(res.Datasets, dataset)
+	}
+	if err := rows.Err(); err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return res, nil
+}
+
+// datasetsError of expansion as FailedPrecondition, missing datasets are listed as violations
+func datasetsError(err error) error {
+	var unknown *job.UnknownDatasetsError
+	if !errors.As(err, &unknown) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	failure := &errdetails.PreconditionFailure{}
+	for _, name := range unknown.Names {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "DATASET_NOT_FOUND",
+			Subject:     name,
+			Description: fmt.Sprintf("dataset {{dataset:%s}} is not in catalog, it was deleted or renamed", name),
+		})
+	}
+	st, detailsErr := status.New(codes.FailedPrecondition, err.Error()).WithDetails(failure)
+	if detailsErr != nil {
+		log.Err(detailsErr).Send()
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return st.Err()
+}
+
+// expandDatasets of catalog referenced in query text of source, before variables are substituted
+func (s Server) expandDatasets(ctx context.Context, source *queryJobSource) error {
+	names := job.DatasetReferences(source.queryText)
+	if len(names) == 0 {
+		return nil
+	}
+	datasets := make(map[string]string, len(names))
+	for _, name := range names {
+		var queryText string
+		err := s.db.QueryRowContext(ctx, `select query_text from datasets where name=$1`, name).Scan(&queryText)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			log.Err(err).Send()
+			return internalError(err)
+		}
+		datasets[name] = queryText
+	}
+	queryText, err := job.ExpandDatasets(source.queryText, datasets)
+	if err != nil {
+		log.Warn().Err(err).Str("reportID", source.reportID).Send()
+		return datasetsError(err)
+	}
+	source.queryText = queryText
+	return nil
+}
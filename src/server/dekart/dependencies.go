@@ -0,0 +1,354 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// marshalDependsOn stored in query record as JSON array of query ids, empty without dependencies
+func marshalDependsOn(dependsOn []string) (string, error) {
+	if len(dependsOn) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(dependsOn)
+	return string(b), err
+}
+
+func unmarshalDependsOn(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var dependsOn []string
+	err := json.Unmarshal([]byte(value), &dependsOn)
+	return dependsOn, err
+}
+
+// dependencyCycleError with chain of query ids, first id is repeated at the end
+type dependencyCycleError struct {
+	chain []string
+}
+
+func (e *dependencyCycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.chain, " -> ")
+}
+
+// named chain of cycle with titles of queries, id is used for query without title
+func (e *dependencyCycleError) named(titles map[string]string) string {
+	names := make([]string, len(e.chain))
+	for i, id := range e.chain {
+		names[i] = id
+		if title := titles[id]; title != "" {
+			names[i] = title
+		}
+	}
+	return "dependency cycle: " + strings.Join(names, " -> ")
+}
+
+// dependencyOrder of queries with dependencies before their dependents, queries keep their order otherwise;
+// dependency on query not in queryIDs is ignored, so removed query doesn't block its dependents
+func dependencyOrder(queryIDs []string, dependsOn map[string][]string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	for _, id := range queryIDs {
+		state[id] = unvisited
+	}
+	order := make([]string, 0, len(queryIDs))
+	var path []string
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			for i, onPath := range path {
+				if onPath == id {
+					chain := append(append([]string{}, path[i:]...), id)
+					return &dependencyCycleError{chain: chain}
+				}
+			}
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, dependency := range dependsOn[id] {
+			if _, ok := state[dependency]; !ok {
+				continue
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		order = append(order, id)
+		return nil
+	}
+	for _, id := range queryIDs {
+		if err := visit(id); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// batchStart of query in batch
+type batchStart struct {
+	run *proto.BatchRun
+	// finished receives true once job is done with result, false when it failed or was cancelled; nil when query failed to start
+	finished <-chan bool
+}
+
+// batchOutcome of query, ok is set before done is closed
+type batchOutcome struct {
+	done chan struct{}
+	ok   bool
+}
+
+// scheduleBatch starts queries without dependencies before it returns and other queries once all their
+// dependencies are done. Query is skipped as soon as one of dependencies is not done, which skips its
+// dependents in turn. Runs are returned in order of queryIDs, dependency cycle fails batch before any query is started
+func scheduleBatch(
+	queryIDs []string,
+	dependsOn map[string][]string,
+	start func(queryID string) batchStart,
+	wait func(queryID string),
+	skip func(queryID string, dependencyID string),
+) ([]*proto.BatchRun, error) {
+	order, err := dependencyOrder(queryIDs, dependsOn)
+	if err != nil {
+		return nil, err
+	}
+	outcomes := make(map[string]*batchOutcome, len(order))
+	for _, id := range order {
+		outcomes[id] = &batchOutcome{done: make(chan struct{})}
+	}
+	finish := func(id string, started batchStart) {
+		outcome := outcomes[id]
+		if started.finished != nil {
+			outcome.ok = <-started.finished
+		}
+		close(outcome.done)
+	}
+	runs := make(map[string]*proto.BatchRun, len(order))
+	for _, id := range order {
+		var dependencies []string
+		for _, dependency := range dependsOn[id] {
+			if _, ok := outcomes[dependency]; ok {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		if len(dependencies) == 0 {
+			started := start(id)
+			runs[id] = started.run
+			go finish(id, started)
+			continue
+		}
+		wait(id)
+		runs[id] = &proto.BatchRun{QueryId: id, Waiting: true}
+		go func(id string, dependencies []string) {
+			// buffered, so dependencies finishing after skip don't block
+			failed := make(chan string, len(dependencies))
+			for _, dependency := range dependencies {
+				go func(dependency string) {
+					outcome := outcomes[dependency]
+					<-outcome.done
+					if outcome.ok {
+						failed <- ""
+					} else {
+						failed <- dependency
+					}
+				}(dependency)
+			}
+			for range dependencies {
+				if dependency := <-failed; dependency != "" {
+					skip(id, dependency)
+					close(outcomes[id].done)
+					return
+				}
+			}
+			finish(id, start(id))
+		}(id, dependencies)
+	}
+	res := make([]*proto.BatchRun, len(queryIDs))
+	for i, id := range queryIDs {
+		res[i] = runs[id]
+	}
+	return res, nil
+}
+
+// batchContext keeps values of request, like claims and request id, without its cancellation,
+// so dependents are started after RunAllQueries returned
+type batchContext struct {
+	context.Context
+}
+
+func (batchContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (batchContext) Done() <-chan struct{}       { return nil }
+func (batchContext) Err() error                  { return nil }
+
+// storeBatchStatus of query not run by job: waiting for dependencies, skipped or failed to start; ctx is batch
+// context, which is not cancelled with request. Waiting query keeps its result until job is started, skipped and
+// failed queries lose it like failed job
+func (s Server) storeBatchStatus(ctx context.Context, reportID, queryID, batchID string, jobStatus proto.Query_JobStatus, jobError, dependencyID string) {
+	var err error
+	if jobStatus == proto.Query_JOB_STATUS_PENDING {
+		_, err = s.db.ExecContext(ctx,
+			`update queries set job_status=$1, job_error='', job_error_explanation=null, batch_id=$2, skipped_dependency_id=null where id=$3`,
+			int32(jobStatus),
+			batchID,
+			queryID,
+		)
+	} else {
+		_, err = s.db.ExecContext(ctx,
+			`update queries set
+				job_status = $1,
+				job_error = $2,
+				job_error_explanation = null,
+				job_result_id = null,
+				h3_result_id = null,
+				format_results = null,
+				result_table = null,
+				total_rows = 0,
+				bytes_processed = 0,
+				result_size = 0,
+				rows_written = 0,
+				job_warnings = null,
+				job_plan = null,
+				job_reservation = null,
+				preview_result_id = null,
+				preview_rows = 0,
+				batch_id = $3,
+				skipped_dependency_id = $4
+			where id = $5`,
+			int32(jobStatus),
+			jobError,
+			batchID,
+			nullID(dependencyID),
+			queryID,
+		)
+	}
+	if err != nil {
+		log.Err(err).Str("queryID", queryID).Msg("Cannot store batch status")
+		return
+	}
+	s.reportStreams.Publish(report.Event{
+		ReportID:  reportID,
+		Kind:      report.JobStatusChanged,
+		QueryID:   queryID,
+		JobStatus: int32(jobStatus),
+	})
+}
+
+// UpdateQueryDependencies replaces queries which RunAllQueries runs before query
+func (s Server) UpdateQueryDependencies(ctx context.Context, req *proto.UpdateQueryDependenciesRequest) (*proto.UpdateQueryDependenciesResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.QueryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	var dependsOn []string
+	seen := map[string]bool{}
+	for _, id := range req.DependsOn {
+		if _, err := uuid.Parse(id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid dependency %s: %s", id, err)
+		}
+		if id == req.QueryId {
+			return nil, status.Error(codes.InvalidArgument, "query can't depend on itself")
+		}
+		if !seen[id] {
+			seen[id] = true
+			dependsOn = append(dependsOn, id)
+		}
+	}
+	reportID, err := s.getReportID(ctx, req.QueryId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if reportID == nil {
+		return nil, s.queryNotFound(ctx, req.QueryId)
+	}
+	queries, err := s.getQueries(ctx, *reportID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	queryIDs := make([]string, len(queries))
+	titles := map[string]string{}
+	graph := map[string][]string{}
+	for i, query := range queries {
+		queryIDs[i] = query.Id
+		titles[query.Id] = query.Title
+		graph[query.Id] = query.DependsOn
+	}
+	for _, id := range dependsOn {
+		if _, ok := titles[id]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "dependency %s is not query of report", id)
+		}
+	}
+	graph[req.QueryId] = dependsOn
+	if _, err := dependencyOrder(queryIDs, graph); err != nil {
+		if cycle, ok := err.(*dependencyCycleError); ok {
+			return nil, status.Error(codes.InvalidArgument, cycle.named(titles))
+		}
+		return nil, internalError(err)
+	}
+	value, err := marshalDependsOn(dependsOn)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`update queries set depends_on=$1, updated_at=CURRENT_TIMESTAMP where id=$2`,
+		value,
+		req.QueryId,
+	)
+	if err == nil {
+		_, err = tx.ExecContext(ctx,
+			`update reports set updated_at=CURRENT_TIMESTAMP where id=$1`,
+			*reportID,
+		)
+	}
+	if err != nil {
+		rollback(tx)
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+
+	s.reportStreams.Publish(report.Event{ReportID: *reportID, Kind: report.QueryChanged, QueryID: req.QueryId})
+
+	return &proto.UpdateQueryDependenciesResponse{
+		Query: &proto.Query{
+			Id:        req.QueryId,
+			ReportId:  *reportID,
+			DependsOn: dependsOn,
+		},
+	}, nil
+}
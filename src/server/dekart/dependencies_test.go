@@ -0,0 +1,185 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDependencyOrder(t *testing.T) {
+	// diamond: b and c depend on a, d depends on b and c
+	diamond := map[string][]string{"b": {"a"}, "c": {"a"}, "d": {"c", "b"}}
+	order, err := dependencyOrder([]string{"d", "c", "b", "a"}, diamond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "c", "b", "d"}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected %v, got %v", expected, order)
+	}
+	order, err = dependencyOrder([]string{"a", "b"}, map[string][]string{"a": {"removed"}})
+	if err != nil || !reflect.DeepEqual(order, []string{"a", "b"}) {
+		t.Errorf("expected removed dependency ignored, got %v %v", order, err)
+	}
+	_, err = dependencyOrder([]string{"a", "b", "c", "d"}, map[string][]string{"a": {"d"}, "b": {"a"}, "c": {"b"}, "d": {"c"}})
+	cycle, ok := err.(*dependencyCycleError)
+	if !ok || !reflect.DeepEqual(cycle.chain, []string{"a", "d", "c", "b", "a"}) {
+		t.Fatalf("expected cycle, got %v", err)
+	}
+	if named := cycle.named(map[string]string{"a": "Query 1", "b": "Query 2", "c": "Query 3"}); named != "dependency cycle: Query 1 -> d -> Query 3 -> Query 2 -> Query 1" {
+		t.Errorf("unexpected cycle %q", named)
+	}
+	if _, err := dependencyOrder([]string{"a"}, map[string][]string{"a": {"a"}}); err == nil {
+		t.Error("expected self dependency rejected")
+	}
+}
+
+// fakeBatch records scheduler calls, jobs finish when test sends outcome
+type fakeBatch struct {
+	mutex    sync.Mutex
+	started  []string
+	waiting  []string
+	skipped  map[string]string
+	finished map[string]chan bool
+	events   chan string
+}
+
+func newFakeBatch(ids ...string) *fakeBatch {
+	b := &fakeBatch{skipped: map[string]string{}, finished: map[string]chan bool{}, events: make(chan string, 10)}
+	for _, id := range ids {
+		b.finished[id] = make(chan bool, 1)
+	}
+	return b
+}
+
+func (b *fakeBatch) start(queryID string) batchStart {
+	b.mutex.Lock()
+	b.started = append(b.started, queryID)
+	b.mutex.Unlock()
+	b.events <- "start " + queryID
+	return batchStart{run: &proto.BatchRun{QueryId: queryID, JobId: "job-" + queryID}, finished: b.finished[queryID]}
+}
+
+func (b *fakeBatch) wait(queryID string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.waiting = append(b.waiting, queryID)
+}
+
+func (b *fakeBatch) skip(queryID string, dependencyID string) {
+	b.mutex.Lock()
+	b.skipped[queryID] = dependencyID
+	b.mutex.Unlock()
+	b.events <- "skip " + queryID
+}
+
+func (b *fakeBatch) next(t *testing.T) string {
+	select {
+	case event := <-b.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler stalled")
+	}
+	return ""
+}
+
+func (b *fakeBatch) idle(t *testing.T) {
+	select {
+	case event := <-b.events:
+		t.Fatalf("unexpected %s", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestScheduleBatchDiamond(t *testing.T) {
+	b := newFakeBatch("a", "b", "c", "d")
+	diamond := map[string][]string{"b": {"a"}, "c": {"a"}, "d": {"b", "c"}}
+	runs, err := scheduleBatch([]string{"d", "c", "b", "a"}, diamond, b.start, b.wait, b.skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// roots are started before return, runs are in order of report
+	if len(runs) != 4 || runs[3].JobId != "job-a" || !runs[0].Waiting || !runs[1].Waiting || !runs[2].Waiting {
+		t.Fatalf("unexpected runs %v", runs)
+	}
+	if event := b.next(t); event != "start a" {
+		t.Fatalf("expected a started, got %s", event)
+	}
+	b.idle(t)
+	b.finished["a"] <- true
+	started := map[string]bool{b.next(t): true, b.next(t): true}
+	if !started["start b"] || !started["start c"] {
+		t.Fatalf("expected b and c started after a, got %v", started)
+	}
+	b.finished["b"] <- true
+	// d waits for both dependencies
+	b.idle(t)
+	b.finished["c"] <- true
+	if event := b.next(t); event != "start d" {
+		t.Fatalf("expected d started, got %s", event)
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if len(b.skipped) != 0 || len(b.waiting) != 3 {
+		t.Errorf("unexpected skipped %v waiting %v", b.skipped, b.waiting)
+	}
+}
+
+func TestScheduleBatchFailedDependency(t *testing.T) {
+	b := newFakeBatch("a", "b", "c", "d", "e")
+	// e depends on d only, it's skipped in turn when d is skipped
+	graph := map[string][]string{"b": {"a"}, "c": {"a"}, "d": {"b", "c"}, "e": {"d"}}
+	if _, err := scheduleBatch([]string{"a", "b", "c", "d", "e"}, graph, b.start, b.wait, b.skip); err != nil {
+		t.Fatal(err)
+	}
+	b.next(t)
+	b.finished["a"] <- true
+	b.next(t)
+	b.next(t)
+	// failed dependency skips d without waiting for c
+	b.finished["b"] <- false
+	if event := b.next(t); event != "skip d" {
+		t.Fatalf("expected d skipped, got %s", event)
+	}
+	if event := b.next(t); event != "skip e" {
+		t.Fatalf("expected e skipped, got %s", event)
+	}
+	b.finished["c"] <- true
+	b.idle(t)
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.skipped["d"] != "b" || b.skipped["e"] != "d" || len(b.started) != 3 {
+		t.Errorf("unexpected skipped %v started %v", b.skipped, b.started)
+	}
+}
+
+func TestScheduleBatchStartFailure(t *testing.T) {
+	b := newFakeBatch("b")
+	start := func(queryID string) batchStart {
+		if queryID == "a" {
+			return batchStart{run: &proto.BatchRun{QueryId: queryID, Error: "invalid"}}
+		}
+		return b.start(queryID)
+	}
+	runs, err := scheduleBatch([]string{"a", "b", "c"}, map[string][]string{"c": {"a"}}, start, b.wait, b.skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs[0].Error != "invalid" || runs[1].JobId != "job-b" || !runs[2].Waiting {
+		t.Errorf("unexpected runs %v", runs)
+	}
+	events := map[string]bool{b.next(t): true, b.next(t): true}
+	if !events["start b"] || !events["skip c"] {
+		t.Errorf("expected b started and c skipped, got %v", events)
+	}
+}
+
+func TestScheduleBatchCycle(t *testing.T) {
+	b := newFakeBatch()
+	_, err := scheduleBatch([]string{"a", "b", "c"}, map[string][]string{"a": {"b"}, "b": {"a"}}, b.start, b.wait, b.skip)
+	if _, ok := err.(*dependencyCycleError); !ok {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	b.idle(t)
+}
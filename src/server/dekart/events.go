@@ -0,0 +1,114 @@
+package dekart
+
+import (
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// eventsHeartbeatInterval keeps proxies from closing idle connection
+var eventsHeartbeatInterval = 15 * time.Second
+
+// same as GetReportStream, shorter than http server WriteTimeout; EventSource reconnects with Last-Event-ID
+const eventsConnectionTimeout = 55 * time.Second
+
+func (s Server) writeReportEvent(w http.ResponseWriter, r *http.Request, reportID string, event report.Event, incremental bool, fineGrained bool) error {
+	res, err := s.reportStreamResponse(r.Context(), reportID, event, incremental)
+	if err != nil {
+		return err
+	}
+	if !fineGrained {
+		compatJobStatuses(res)
+	}
+	b, err := protojson.Marshal(res)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: report\ndata: %s\n\n", event.Sequence, b)
+	return err
+}
+
+// ServeReportEvents streams report changes and job status as Server-Sent Events
+func (s Server) ServeReportEvents(w http.ResponseWriter, r *http.Request) {
+	claims := user.GetClaims(r.Context())
+	if claims == nil {
+		http.Error(w, "UNAUTHENTICATED", http.StatusUnauthorized)
+		return
+	}
+	reportID := mux.Vars(r)["id"]
+	_, err := uuid.Parse(reportID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var sequence int64
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID != "" {
+		sequence, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := fmt.Errorf("Streaming not supported")
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	streamID, err := uuid.NewRandom()
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	incremental := r.URL.Query().Get("incremental") == "1"
+	// like fine_grained_job_status of GetReportStream
+	fineGrained := r.URL.Query().Get("fine_grained_job_status") == "1"
+	events := s.reportStreams.Register(reportID, streamID.String(), sequence)
+	defer s.reportStreams.Deregister(reportID, streamID.String())
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+	timeout := time.NewTimer(eventsConnectionTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			err := s.writeReportEvent(w, r, reportID, event, incremental, fineGrained)
+			if err != nil {
+				log.Err(err).Send()
+				fmt.Fprintf(w, "event: error\ndata: %q\n\n", err.Error())
+				flusher.Flush()
+				return
+			}
+		case <-heartbeat.C:
+			_, err := fmt.Fprint(w, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+		case <-timeout.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
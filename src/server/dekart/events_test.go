@@ -0,0 +1,142 @@
+package dekart
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"dekart/src/proto"
+	"dekart/src/server/user"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+)
+
+func newEventsServer(t *testing.T, s *Server) *httptest.Server {
+	claimsCheck := user.NewClaimsCheck(user.ClaimsCheckConfig{})
+	router := mux.NewRouter()
+	router.HandleFunc("/reports/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		s.ServeReportEvents(w, r.WithContext(claimsCheck.GetContext(r)))
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getEvents(t *testing.T, url string, lastEventID string) (*http.Response, *bufio.Reader) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res, bufio.NewReader(res.Body)
+}
+
+// readEvent returns lines of next event or comment
+func readEvent(t *testing.T, r *bufio.Reader) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event: %v, got %v", err, lines)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestServeReportEventsBadRequest(t *testing.T) {
+	s, _ := newTestServer(t)
+	server := newEventsServer(t, s)
+	for name, c := range map[string]struct {
+		id          string
+		lastEventID string
+	}{
+		"invalid report id":     {"not-uuid", ""},
+		"invalid last event id": {testReportID, "abc"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, _ := getEvents(t, server.URL+"/reports/"+c.id+"/events", c.lastEventID)
+			if res.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServeReportEventsResume(t *testing.T) {
+	// Last-Event-ID issued by other replica or before restart, done job must be replayed with full state
+	s, mock := newTestServer(t)
+	expectTestReport(mock)
+	expectTestFiles(mock)
+	mock.ExpectQuery("from queries where report_id").
+		WillReturnRows(sqlmock.NewRows(queryColumns).
+			AddRow(testQueryID, "select 1", int32(proto.Query_JOB_STATUS_DONE), "result", "", 1000, 10, 100, 1000, 10, 0, "", "Query 1", "", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+	server := newEventsServer(t, s)
+	res, r := getEvents(t, server.URL+"/reports/"+testReportID+"/events", "7")
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.StatusCode)
+	}
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("unexpected Content-Type %s", ct)
+	}
+	lines := readEvent(t, r)
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "id: ") || lines[0] == "id: 7" || lines[1] != "event: report" {
+		t.Fatalf("expected report snapshot, got %v", lines)
+	}
+	if !strings.Contains(lines[2], "JOB_STATUS_DONE") || !strings.Contains(lines[2], "mapConfig") {
+		t.Errorf("expected full report state with done job, got %s", lines[2])
+	}
+}
+
+func TestServeReportEventsHeartbeat(t *testing.T) {
+	interval := eventsHeartbeatInterval
+	eventsHeartbeatInterval = 10 * time.Millisecond
+	t.Cleanup(func() { eventsHeartbeatInterval = interval })
+
+	s, mock := newTestServer(t)
+	expectTestReport(mock)
+	expectTestFiles(mock)
+	expectTestQueries(mock)
+	server := newEventsServer(t, s)
+	_, r := getEvents(t, server.URL+"/reports/"+testReportID+"/events", "")
+	readEvent(t, r) // initial state
+	lines := readEvent(t, r)
+	if len(lines) != 1 || lines[0] != ": heartbeat" {
+		t.Errorf("expected heartbeat, got %v", lines)
+	}
+}
+
+func TestServeReportEventsFineGrainedJobStatus(t *testing.T) {
+	for query, expected := range map[string]string{
+		"":                           "JOB_STATUS_RUNNING",
+		"?fine_grained_job_status=1": "JOB_STATUS_READING_RESULTS",
+	} {
+		s, mock := newTestServer(t)
+		expectTestReport(mock)
+		expectTestFiles(mock)
+		mock.ExpectQuery("from queries where report_id").
+			WillReturnRows(sqlmock.NewRows(queryColumns).
+				AddRow(testQueryID, "select 1", int32(proto.Query_JOB_STATUS_READING_RESULTS), "", "", 1000, 10, 100, 0, 5, 0, "", "Query 1", "", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+		server := newEventsServer(t, s)
+		_, r := getEvents(t, server.URL+"/reports/"+testReportID+"/events"+query, "")
+		lines := readEvent(t, r)
+		if len(lines) != 3 || !strings.Contains(lines[2], expected) {
+			t.Errorf("%q: expected %s, got %v", query, expected, lines)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// parseExactCountMaxBytes of DEKART_EXACT_COUNT_MAX_BYTES, empty disables count of sampled and limited queries
+func parseExactCountMaxBytes(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	maxBytes, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || maxBytes <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_EXACT_COUNT_MAX_BYTES %s, expected positive number of bytes", value)
+	}
+	return maxBytes, nil
+}
+
+func exactCountMaxBytes() int64 {
+	maxBytes, err := parseExactCountMaxBytes(os.Getenv("DEKART_EXACT_COUNT_MAX_BYTES"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return maxBytes
+}
+
+// exactCountWarning of count which failed or was skipped
+func exactCountWarning(err error) string {
+	var skipped *job.CountSkippedError
+	if errors.As(err, &skipped) {
+		return fmt.Sprintf("exact row count skipped, count query would process %d bytes, more than DEKART_EXACT_COUNT_MAX_BYTES", skipped.EstimatedBytes)
+	}
+	return fmt.Sprintf("exact row count failed: %s", err)
+}
+
+// storeExactCount of rows of query whose result was sampled or limited; failed count only adds warning, result is kept
+func (s Server) storeExactCount(j *job.Job, resultID string) {
+	count, err := s.jobs.CountRows(context.Background(), j, s.exactCountMaxBytes)
+	if err == job.ErrCancelled || errors.Is(err, context.Canceled) {
+		// query was cancelled or removed
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err != nil {
+		log.Warn().Err(err).Str("queryID", j.QueryID).Msg("Exact row count unavailable")
+		j.AddWarning(job.WarningExactCountUnavailable, exactCountWarning(err))
+		var warnings string
+		warnings, err = jobWarnings(j.GetStatus().Warnings)
+		if err == nil {
+			// warning is added only to result of job, query could be run again meanwhile
+			_, err = s.db.ExecContext(ctx,
+				`update queries set job_warnings=$1 where id=$2 and job_result_id=$3`,
+				warnings,
+				j.QueryID,
+				resultID,
+			)
+		}
+	} else {
+		_, err = s.db.ExecContext(ctx, `update results set exact_total_rows=$1 where id=$2`, count, resultID)
+	}
+	if err != nil {
+		log.Err(err).Str("queryID", j.QueryID).Msg("Cannot store exact row count")
+		return
+	}
+	s.reportStreams.Publish(report.Event{
+		ReportID:  j.ReportID,
+		Kind:      report.JobStatusChanged,
+		QueryID:   j.QueryID,
+		JobStatus: int32(proto.Query_JOB_STATUS_DONE),
+	})
+}
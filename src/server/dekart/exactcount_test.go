@@ -0,0 +1,32 @@
+package dekart
+
+import (
+	"dekart/src/server/job"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseExactCountMaxBytes(t *testing.T) {
+	for value, expected := range map[string]int64{"": 0, "1000000000": 1000000000} {
+		maxBytes, err := parseExactCountMaxBytes(value)
+		if err != nil || maxBytes != expected {
+			t.Errorf("%q: expected %d, got %d %v", value, expected, maxBytes, err)
+		}
+	}
+	for _, value := range []string{"0", "-1", "1GB"} {
+		if _, err := parseExactCountMaxBytes(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestExactCountWarning(t *testing.T) {
+	skipped := exactCountWarning(fmt.Errorf("count: %w", &job.CountSkippedError{EstimatedBytes: 2000, MaxBytes: 1000}))
+	if !strings.Contains(skipped, "would process 2000 bytes, more than DEKART_EXACT_COUNT_MAX_BYTES") {
+		t.Errorf("unexpected warning %q", skipped)
+	}
+	if failed := exactCountWarning(fmt.Errorf("access denied")); failed != "exact row count failed: access denied" {
+		t.Errorf("unexpected warning %q", failed)
+	}
+}
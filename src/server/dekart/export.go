@@ -0,0 +1,209 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"dekart/src/server/user"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportDestination object in bucket of user
+type exportDestination struct {
+	bucket string
+	name   string
+}
+
+func (d exportDestination) String() string {
+	return fmt.Sprintf("gs://%s/%s", d.bucket, d.name)
+}
+
+// parseExportURI gs://bucket/object; results are stored in GCS, so only GCS destinations are supported
+func parseExportURI(uri string) (exportDestination, error) {
+	if !strings.HasPrefix(uri, "gs://") {
+		return exportDestination{}, fmt.Errorf("unsupported destination %s, only gs:// destinations are supported", uri)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(uri, "gs://"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.HasSuffix(parts[1], "/") {
+		return exportDestination{}, fmt.Errorf("invalid destination %s, expected gs://bucket/object", uri)
+	}
+	return exportDestination{bucket: parts[0], name: parts[1]}, nil
+}
+
+// objectCopy copies result object to destination, progress is called with copied and total bytes;
+// existing destination is replaced only with overwrite, metadata is set on destination when not nil
+type objectCopy func(
+	ctx context.Context,
+	src *storage.ObjectHandle,
+	dst exportDestination,
+	overwrite bool,
+	metadata map[string]string,
+	progress func(copied, total uint64),
+) error
+
+// rewriteObject copies object with GCS rewrite API, data is copied by GCS and not streamed through dekart
+func rewriteObject(
+	ctx context.Context,
+	src *storage.ObjectHandle,
+	dst exportDestination,
+	overwrite bool,
+	metadata map[string]string,
+	progress func(copied, total uint64),
+) error {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	obj := client.Bucket(dst.bucket).Object(dst.name)
+	if !overwrite {
+		// precondition is checked by GCS, so concurrent writes of same object are not replaced either
+		obj = obj.If(storage.Conditions{DoesNotExist: true})
+	}
+	copier := obj.CopierFrom(src)
+	copier.ProgressFunc = progress
+	if metadata != nil {
+		copier.Metadata = metadata
+	}
+	start := time.Now()
+	_, err = copier.Run(ctx)
+	metrics.Observe(metrics.GCS, "result_export", start, err)
+	return err
+}
+
+// exportError as status with hint for destination failures
+func exportError(err error, dst exportDestination) error {
+	var apiErr *googleapi.Error
+	switch {
+	case err == context.Canceled:
+		return status.Error(codes.Canceled, err.Error())
+	case err == storage.ErrBucketNotExist:
+		return status.Errorf(codes.NotFound, "bucket %s not found", dst.bucket)
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed:
+		return status.Errorf(codes.AlreadyExists, "%s exists, set overwrite to replace it", dst)
+	case errors.As(err, &apiErr) && (apiErr.Code == http.StatusForbidden || apiErr.Code == http.StatusUnauthorized):
+		return status.Errorf(codes.PermissionDenied,
+			"cannot write %s, grant storage.objects.create and storage.objects.delete on bucket %s to service account of dekart: %s",
+			dst, dst.bucket, err,
+		)
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		return status.Errorf(codes.NotFound, "cannot write %s: %s", dst, err)
+	}
+	return internalError(err)
+}
+
+// resultReportID of query result in report of user, empty when not found
+func (s Server) resultReportID(ctx context.Context, resultID string, email string) (string, error) {
+	var reportID string
+	err := s.db.QueryRowContext(ctx,
+		`select queries.report_id from queries join reports on reports.id = queries.report_id
+		where (queries.job_result_id=$1 or queries.h3_result_id=$1) and reports.author_email=$2 limit 1`,
+		resultID,
+		email,
+	).Scan(&reportID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return reportID, err
+}
+
+// exportResult copies result to destination and sends progress after each copied chunk
+func (s Server) exportResult(
+	ctx context.Context,
+	req *proto.ExportResultRequest,
+	copyObject objectCopy,
+	send func(*proto.ExportResultResponse) error,
+) error {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(req.ResultId); err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	dst, err := parseExportURI(req.DestinationUri)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if s.isResultBucket(dst.bucket) {
+		// results of other reports would be replaced
+		return status.Errorf(codes.InvalidArgument, "can't export to result bucket %s", dst.bucket)
+	}
+	reportID, err := s.resultReportID(ctx, req.ResultId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if reportID == "" {
+		err := fmt.Errorf("Result not found id:%s", req.ResultId)
+		log.Warn().Err(err).Send()
+		return status.Error(codes.NotFound, err.Error())
+	}
+	var metadata map[string]string
+	if req.IncludeLineage {
+		lineage, err := s.resultLineage(ctx, req.ResultId)
+		if err != nil {
+			log.Err(err).Send()
+			return internalError(err)
+		}
+		if lineage == nil {
+			return status.Errorf(codes.FailedPrecondition, "result %s has no lineage, it was stored before lineage was recorded", req.ResultId)
+		}
+		metadata, err = lineageMetadata(lineage)
+		if err != nil {
+			log.Err(err).Send()
+			return internalError(err)
+		}
+	}
+	src, err := s.resultObject(ctx, req.ResultId)
+	if err == errResultExpired {
+		return status.Errorf(codes.FailedPrecondition, "result %s expired, run query again", req.ResultId)
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	var copied, total int64
+	err = copyObject(ctx, src, dst, req.Overwrite, metadata, func(copiedBytes, totalBytes uint64) {
+		copied, total = int64(copiedBytes), int64(totalBytes)
+		err := send(&proto.ExportResultResponse{
+			BytesCopied:    copied,
+			TotalBytes:     total,
+			DestinationUri: dst.String(),
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("Cannot send export progress")
+		}
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("resultID", req.ResultId).Str("destination", dst.String()).Msg("Result export failed")
+		return exportError(err, dst)
+	}
+	log.Info().Str("resultID", req.ResultId).Str("reportID", reportID).Str("destination", dst.String()).Msg("Result exported")
+	return send(&proto.ExportResultResponse{
+		Done:           true,
+		BytesCopied:    total,
+		TotalBytes:     total,
+		DestinationUri: dst.String(),
+	})
+}
+
+// ExportResult copies query result to bucket of user
+func (s Server) ExportResult(req *proto.ExportResultRequest, srv proto.Dekart_ExportResultServer) error {
+	return s.exportResult(srv.Context(), req, rewriteObject, srv.Send)
+}
@@ -0,0 +1,200 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testResultID = "3b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"
+
+// fakeCopy of result object, reports progress in two chunks and fails with err
+type fakeCopy struct {
+	err       error
+	src       string
+	dst       exportDestination
+	overwrite bool
+	metadata  map[string]string
+}
+
+func (c *fakeCopy) copy(ctx context.Context, src *storage.ObjectHandle, dst exportDestination, overwrite bool, metadata map[string]string, progress func(copied, total uint64)) error {
+	c.src, c.dst, c.overwrite, c.metadata = src.ObjectName(), dst, overwrite, metadata
+	if c.err != nil {
+		return c.err
+	}
+	progress(50, 100)
+	progress(100, 100)
+	return nil
+}
+
+func newExportTestServer(t *testing.T) (*Server, sqlmock.Sqlmock) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	t.Cleanup(func() { os.Unsetenv("DEKART_ADMIN_EMAILS") })
+	os.Setenv("DEKART_CLOUD_STORAGE_BUCKET", "results")
+	t.Cleanup(func() { os.Unsetenv("DEKART_CLOUD_STORAGE_BUCKET") })
+	client, err := storage.NewClient(context.Background(), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	s, mock := newTestServer(t)
+	s.bucket = client.Bucket("results")
+	return s, mock
+}
+
+func expectExportedResult(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("select queries.report_id").
+		WithArgs(testResultID, user.UnknownEmail).
+		WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(testReportID))
+	mock.ExpectQuery("select object_name").
+		WillReturnRows(sqlmock.NewRows([]string{"object_name", "expired", "bucket"}).AddRow("prod/"+testResultID+".csv", false, ""))
+}
+
+func TestExportResult(t *testing.T) {
+	s, mock := newExportTestServer(t)
+	expectExportedResult(mock)
+	fake := &fakeCopy{}
+	var messages []*proto.ExportResultResponse
+	err := s.exportResult(testClaimsContext(), &proto.ExportResultRequest{
+		ResultId:       testResultID,
+		DestinationUri: "gs://pipeline/input/points.csv",
+	}, fake.copy, func(res *proto.ExportResultResponse) error {
+		messages = append(messages, res)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.src != "prod/"+testResultID+".csv" || fake.dst != (exportDestination{"pipeline", "input/points.csv"}) || fake.overwrite || fake.metadata != nil {
+		t.Errorf("unexpected copy %+v", fake)
+	}
+	if len(messages) != 3 || messages[0].BytesCopied != 50 || messages[0].Done || !messages[2].Done || messages[2].BytesCopied != 100 {
+		t.Errorf("unexpected progress %v", messages)
+	}
+	if messages[2].DestinationUri != "gs://pipeline/input/points.csv" {
+		t.Errorf("unexpected destination %s", messages[2].DestinationUri)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExportResultLineage(t *testing.T) {
+	s, mock := newExportTestServer(t)
+	mock.ExpectQuery("select queries.report_id").
+		WithArgs(testResultID, user.UnknownEmail).
+		WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(testReportID))
+	mock.ExpectQuery("from result_lineage").
+		WithArgs(testResultID).
+		WillReturnRows(sqlmock.NewRows([]string{"report_id", "query_id", "job_id", "bigquery_job_id", "bigquery_project", "bigquery_location",
+			"query_hash", "query_text", "parameter_values", "user_email", "dekart_version", "job_started", "job_finished"}).
+			AddRow(testReportID, testQueryID, "job", "bq-job", "project", "EU", "hash", "select * from points", `{"region":"EU"}`, user.UnknownEmail, "dev", 900, 1000))
+	mock.ExpectQuery("select object_name").
+		WillReturnRows(sqlmock.NewRows([]string{"object_name", "expired", "bucket"}).AddRow("prod/"+testResultID+".csv", false, ""))
+	fake := &fakeCopy{}
+	err := s.exportResult(testClaimsContext(), &proto.ExportResultRequest{
+		ResultId:       testResultID,
+		DestinationUri: "gs://pipeline/points.csv",
+		IncludeLineage: true,
+	}, fake.copy, func(res *proto.ExportResultResponse) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := fake.metadata[lineageMetadataKey]
+	// query text is not exported
+	if !strings.Contains(value, `"bigqueryJobId":"bq-job"`) || !strings.Contains(value, `"region":"EU"`) || strings.Contains(value, "select") {
+		t.Errorf("unexpected lineage metadata %q", value)
+	}
+
+	// result without lineage is not exported with lineage
+	mock.ExpectQuery("select queries.report_id").
+		WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(testReportID))
+	mock.ExpectQuery("from result_lineage").
+		WillReturnRows(sqlmock.NewRows([]string{"report_id"}))
+	err = s.exportResult(testClaimsContext(), &proto.ExportResultRequest{
+		ResultId:       testResultID,
+		DestinationUri: "gs://pipeline/points.csv",
+		IncludeLineage: true,
+	}, fake.copy, nil)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExportResultDestinationErrors(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"exists", &googleapi.Error{Code: http.StatusPreconditionFailed}, codes.AlreadyExists},
+		{"denied", &googleapi.Error{Code: http.StatusForbidden, Message: "no storage.objects.create access"}, codes.PermissionDenied},
+		{"no bucket", &googleapi.Error{Code: http.StatusNotFound}, codes.NotFound},
+		{"cancelled", context.Canceled, codes.Canceled},
+		{"other", &googleapi.Error{Code: http.StatusServiceUnavailable}, codes.Internal},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			s, mock := newExportTestServer(t)
+			expectExportedResult(mock)
+			fake := &fakeCopy{err: c.err}
+			var done bool
+			err := s.exportResult(testClaimsContext(), &proto.ExportResultRequest{
+				ResultId:       testResultID,
+				DestinationUri: "gs://pipeline/points.csv",
+				Overwrite:      true,
+			}, fake.copy, func(res *proto.ExportResultResponse) error {
+				done = res.Done
+				return nil
+			})
+			if status.Code(err) != c.code {
+				t.Errorf("expected %s, got %v", c.code, err)
+			}
+			if done || !fake.overwrite {
+				t.Errorf("unexpected copy %+v, done %t", fake, done)
+			}
+		})
+	}
+}
+
+func TestExportResultInvalidRequest(t *testing.T) {
+	s, mock := newExportTestServer(t)
+	s.UseFallbackBucket("results-fallback", s.bucket)
+	for uri, code := range map[string]codes.Code{
+		"s3://pipeline/points.csv": codes.InvalidArgument,
+		"pipeline/points.csv":      codes.InvalidArgument,
+		"gs://pipeline":            codes.InvalidArgument,
+		"gs://pipeline/input/":     codes.InvalidArgument,
+		"gs:///points.csv":         codes.InvalidArgument,
+		"gs://results/points.csv":  codes.InvalidArgument,
+		// results stored when upload to primary bucket failed would be replaced too
+		"gs://results-fallback/points.csv": codes.InvalidArgument,
+	} {
+		err := s.exportResult(testClaimsContext(), &proto.ExportResultRequest{ResultId: testResultID, DestinationUri: uri}, (&fakeCopy{}).copy, nil)
+		if status.Code(err) != code {
+			t.Errorf("%s: expected %s, got %v", uri, code, err)
+		}
+	}
+	// result of other user's report is not found
+	mock.ExpectQuery("select queries.report_id").WillReturnRows(sqlmock.NewRows([]string{"report_id"}))
+	err := s.exportResult(testClaimsContext(), &proto.ExportResultRequest{ResultId: testResultID, DestinationUri: "gs://pipeline/points.csv"}, (&fakeCopy{}).copy, nil)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,112 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// kepler.gl field types of result columns
+const (
+	keplerReal      = "real"
+	keplerInteger   = "integer"
+	keplerBoolean   = "boolean"
+	keplerTimestamp = "timestamp"
+	keplerGeoJSON   = "geojson"
+	keplerString    = "string"
+)
+
+// keplerFieldTypes by BigQuery type of column; types not listed, ARRAY and RECORD are strings
+var keplerFieldTypes = map[string]string{
+	"INTEGER": keplerInteger,
+	"INT64":   keplerInteger,
+	"FLOAT":   keplerReal,
+	"FLOAT64": keplerReal,
+	// NUMERIC and BIGNUMERIC are written as exact decimals, kepler.gl reads them as float64 and loses digits beyond 15-17
+	"NUMERIC":    keplerReal,
+	"BIGNUMERIC": keplerReal,
+	"BOOLEAN":    keplerBoolean,
+	"BOOL":       keplerBoolean,
+	"TIMESTAMP":  keplerTimestamp,
+	"DATETIME":   keplerTimestamp,
+	"DATE":       keplerTimestamp,
+	// GEOGRAPHY is written as WKT, kepler.gl parses WKT and GeoJSON in geojson fields
+	"GEOGRAPHY": keplerGeoJSON,
+}
+
+// keplerFieldType of recorded column type; parameters like NUMERIC(10,2) and BYTES(BASE64) don't change field type
+func keplerFieldType(columnType string) string {
+	if i := strings.IndexAny(columnType, "(<"); i >= 0 {
+		columnType = columnType[:i]
+	}
+	if fieldType, ok := keplerFieldTypes[columnType]; ok {
+		return fieldType
+	}
+	return keplerString
+}
+
+// GetResultFieldTypes of result columns of report user can read
+func (s Server) GetResultFieldTypes(ctx context.Context, req *proto.GetResultFieldTypesRequest) (*proto.GetResultFieldTypesResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_VIEWER); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.ResultId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	queryID, _, err := s.resultQuery(ctx, req.ResultId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if queryID == "" {
+		err := fmt.Errorf("Result not found id:%s", req.ResultId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	types, err := s.resultColumnTypes(ctx, req.ResultId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if types == nil {
+		return nil, status.Errorf(codes.NotFound, "result %s has no column types, it was stored before column types were recorded", req.ResultId)
+	}
+	names, err := s.resultColumnNames(ctx, req.ResultId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	databaseTypes, err := s.resultDatabaseTypes(ctx, req.ResultId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	meta, err := s.resultMeta(ctx, req.ResultId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	fields := make([]*proto.ResultFieldType, len(types))
+	for i, columnType := range types {
+		fields[i] = &proto.ResultFieldType{ColumnType: columnType, FieldType: keplerFieldType(columnType)}
+		if i < len(names) {
+			fields[i].Name = names[i].Name
+			fields[i].OriginalName = names[i].Original
+		}
+		if i < len(databaseTypes) {
+			fields[i].DatabaseType = databaseTypes[i]
+		}
+	}
+	return &proto.GetResultFieldTypesResponse{Fields: fields, ResultMeta: protoResultMeta(meta)}, nil
+}
@@ -0,0 +1,43 @@
+package dekart
+
+import "testing"
+
+func TestKeplerFieldType(t *testing.T) {
+	for columnType, expected := range map[string]string{
+		"INTEGER":           "integer",
+		"INT64":             "integer",
+		"FLOAT":             "real",
+		"FLOAT64":           "real",
+		"NUMERIC":           "real",
+		"NUMERIC(10,2)":     "real",
+		"BIGNUMERIC":        "real",
+		"BIGNUMERIC(76,38)": "real",
+		"BOOLEAN":           "boolean",
+		"BOOL":              "boolean",
+		"TIMESTAMP":         "timestamp",
+		"DATETIME":          "timestamp",
+		"DATE":              "timestamp",
+		"GEOGRAPHY":         "geojson",
+		"STRING":            "string",
+		"BYTES(BASE64)":     "string",
+		"TIME":              "string",
+		"JSON":              "string",
+		"INTERVAL":          "string",
+		"RANGE<DATE>":       "string",
+		"RECORD":            "string",
+		"ARRAY":             "string",
+		"FUTURE_TYPE":       "string",
+		"":                  "string",
+	} {
+		if fieldType := keplerFieldType(columnType); fieldType != expected {
+			t.Errorf("%s: expected %s, got %s", columnType, expected, fieldType)
+		}
+	}
+	// every mapped type has known kepler.gl field type
+	known := map[string]bool{keplerReal: true, keplerInteger: true, keplerBoolean: true, keplerTimestamp: true, keplerGeoJSON: true, keplerString: true}
+	for columnType, fieldType := range keplerFieldTypes {
+		if !known[fieldType] {
+			t.Errorf("%s: unknown field type %s", columnType, fieldType)
+		}
+	}
+}
@@ -0,0 +1,520 @@
+package dekart
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultMaxFileSize of uploaded file, file is validated in memory
+	defaultMaxFileSize = 10 << 20
+	// maxReportFiles size is total of files of report in multiples of max file size
+	maxReportFiles = 10
+	// maxFileNameLength in bytes
+	maxFileNameLength = 255
+)
+
+// parseMaxFileSize of DEKART_MAX_UPLOAD_SIZE in bytes
+func parseMaxFileSize(value string) (int64, error) {
+	if value == "" {
+		return defaultMaxFileSize, nil
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_MAX_UPLOAD_SIZE %s, expected positive number of bytes", value)
+	}
+	return size, nil
+}
+
+func maxFileSize() int64 {
+	size, err := parseMaxFileSize(os.Getenv("DEKART_MAX_UPLOAD_SIZE"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return size
+}
+
+// executableMagic of binaries rejected regardless of extension: ELF, Mach-O and Java class or fat Mach-O;
+// PE and scripts start with text like MZ or #! which CSV can start with too, they are rejected as invalid files
+var executableMagic = [][]byte{
+	[]byte("\x7fELF"),
+	{0xfe, 0xed, 0xfa, 0xce},
+	{0xfe, 0xed, 0xfa, 0xcf},
+	{0xce, 0xfa, 0xed, 0xfe},
+	{0xcf, 0xfa, 0xed, 0xfe},
+	{0xca, 0xfe, 0xba, 0xbe},
+}
+
+// fileType of uploaded file by extension of its name
+func fileType(name string) proto.FileType {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		return proto.FileType_FILE_TYPE_CSV
+	case ".geojson", ".json":
+		return proto.FileType_FILE_TYPE_GEOJSON
+	}
+	return proto.FileType_FILE_TYPE_UNSPECIFIED
+}
+
+// fileExtension of stored object of file type, files are downloaded with extension of their type
+func fileExtension(fileType proto.FileType) string {
+	if fileType == proto.FileType_FILE_TYPE_GEOJSON {
+		return ".geojson"
+	}
+	return ".csv"
+}
+
+func fileContentType(fileType proto.FileType) string {
+	if fileType == proto.FileType_FILE_TYPE_GEOJSON {
+		return "application/geo+json"
+	}
+	return "text/csv"
+}
+
+// validateCSVFile has header of unique named columns and rows of header length
+func validateCSVFile(data []byte) error {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid CSV file: %s", err)
+	}
+	columns := make(map[string]bool, len(header))
+	for _, name := range header {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return fmt.Errorf("CSV header has empty column name")
+		}
+		if columns[name] {
+			return fmt.Errorf("CSV header has column %s twice", name)
+		}
+		columns[name] = true
+	}
+	for {
+		_, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CSV file: %s", err)
+		}
+	}
+}
+
+type geoJSONGeometry struct {
+	Type        string            `json:"type"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+	Geometries  []geoJSONGeometry `json:"geometries"`
+}
+
+type geoJSONFeature struct {
+	Type     string           `json:"type"`
+	Geometry *geoJSONGeometry `json:"geometry"`
+}
+
+type geoJSONObject struct {
+	geoJSONFeature
+	Features []geoJSONFeature `json:"features"`
+}
+
+func validateGeometry(geometry *geoJSONGeometry) error {
+	if geometry == nil {
+		// feature without location is kept, it's not drawn
+		return nil
+	}
+	switch geometry.Type {
+	case "Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon":
+		if len(geometry.Coordinates) == 0 {
+			return fmt.Errorf("%s has no coordinates", geometry.Type)
+		}
+		return nil
+	case "GeometryCollection":
+		for i := range geometry.Geometries {
+			if err := validateGeometry(&geometry.Geometries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown geometry type %q", geometry.Type)
+}
+
+// validateGeoJSONFile is FeatureCollection or Feature with valid geometry types
+func validateGeoJSONFile(data []byte) error {
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("invalid GeoJSON file: %s", err)
+	}
+	features := object.Features
+	switch object.Type {
+	case "FeatureCollection":
+	case "Feature":
+		features = []geoJSONFeature{object.geoJSONFeature}
+	default:
+		return fmt.Errorf("invalid GeoJSON file: expected FeatureCollection or Feature, got %q", object.Type)
+	}
+	for i, feature := range features {
+		if feature.Type != "Feature" {
+			return fmt.Errorf("invalid GeoJSON file: feature %d has type %q", i, feature.Type)
+		}
+		if err := validateGeometry(feature.Geometry); err != nil {
+			return fmt.Errorf("invalid GeoJSON file: feature %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// validateFile of type, executables are rejected by magic bytes whatever name they have
+func validateFile(fileType proto.FileType, data []byte) error {
+	for _, magic := range executableMagic {
+		if bytes.HasPrefix(data, magic) {
+			return fmt.Errorf("executable files can't be uploaded")
+		}
+	}
+	if !utf8.Valid(data) {
+		return fmt.Errorf("file is not UTF-8 text")
+	}
+	if fileType == proto.FileType_FILE_TYPE_GEOJSON {
+		return validateGeoJSONFile(data)
+	}
+	return validateCSVFile(data)
+}
+
+// fileObjectName of file of report under report prefix, so files of report are listed and removed together
+func fileObjectName(prefix string, reportID string, fileID string, t time.Time, extension string) string {
+	return storageObjectName(prefix, reportID, path.Join("files", reportID, fileID), t, extension)
+}
+
+// objectUploader stores data as object in bucket of results
+type objectUploader func(ctx context.Context, name string, contentType string, data []byte) error
+
+func (s Server) uploadObject(ctx context.Context, name string, contentType string, data []byte) error {
+	w := s.bucket.Object(name).NewWriter(ctx)
+	w.ContentType = contentType
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// receiveFile chunks of stream up to max file size, returns first chunk with data of all chunks
+func (s Server) receiveFile(srv proto.Dekart_UploadFileServer) (*proto.UploadFileRequest, []byte, error) {
+	var first *proto.UploadFileRequest
+	var data bytes.Buffer
+	for {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if first == nil {
+			first = req
+			if _, err := uuid.Parse(req.ReportId); err != nil {
+				return nil, nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			if req.Name == "" || len(req.Name) > maxFileNameLength || strings.ContainsAny(req.Name, "/\\") {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "invalid file name %q", req.Name)
+			}
+			if fileType(req.Name) == proto.FileType_FILE_TYPE_UNSPECIFIED {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "unsupported file %s, expected .csv, .geojson or .json", req.Name)
+			}
+		}
+		if int64(data.Len()+len(req.Data)) > s.maxFileSize {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "file is larger than %d bytes", s.maxFileSize)
+		}
+		data.Write(req.Data)
+	}
+	if first == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "no file received")
+	}
+	return first, data.Bytes(), nil
+}
+
+// UploadFile to report of author; file is stored like query result and downloaded by its id
+func (s Server) UploadFile(srv proto.Dekart_UploadFileServer) error {
+	ctx := srv.Context()
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return err
+	}
+	req, data, err := s.receiveFile(srv)
+	if err != nil {
+		return err
+	}
+	file, err := s.storeFile(ctx, req.ReportId, req.Name, data, claims.Email, s.uploadObject, s.deleteObject)
+	if err != nil {
+		return err
+	}
+	return srv.SendAndClose(&proto.UploadFileResponse{File: file})
+}
+
+// storeFile uploaded by author of report after it's validated, object is removed when file can't be recorded
+func (s Server) storeFile(ctx context.Context, reportID string, name string, data []byte, email string, upload objectUploader, remove objectRemover) (*proto.File, error) {
+	file := &proto.File{
+		Id:          newUUID(),
+		ReportId:    reportID,
+		Name:        name,
+		FileType:    fileType(name),
+		Size:        int64(len(data)),
+		AuthorEmail: email,
+	}
+	if err := validateFile(file.FileType, data); err != nil {
+		log.Info().Err(err).Str("reportID", reportID).Str("name", name).Msg("File rejected")
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	objectName := fileObjectName(s.storagePrefix, reportID, file.Id, time.Now(), fileExtension(file.FileType))
+	if err := upload(ctx, objectName, fileContentType(file.FileType), data); err != nil {
+		log.Err(err).Str("reportID", reportID).Msg("Cannot upload file")
+		return nil, internalError(err)
+	}
+	if err := s.insertFile(ctx, file, objectName); err != nil {
+		// file is stored in default bucket of results
+		if err := remove(ctx, "", objectName); err != nil && err != storage.ErrObjectNotExist {
+			log.Warn().Err(err).Str("reportID", reportID).Str("fileID", file.Id).Msg("Cannot delete object of file not stored")
+		}
+		return nil, err
+	}
+	file.CreatedAt = time.Now().Unix()
+	log.Info().Str("reportID", reportID).Str("fileID", file.Id).Int64("size", file.Size).Msg("File uploaded")
+	s.reportStreams.Publish(report.Event{ReportID: reportID, Kind: report.ReportChanged})
+	return file, nil
+}
+
+// insertFile rows of results and files in one transaction, size of files of report is limited; report row is updated
+// first, so concurrent uploads to report wait for each other and size includes files committed before
+func (s Server) insertFile(ctx context.Context, file *proto.File, objectName string) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	defer tx.Rollback()
+	result, err := tx.ExecContext(ctx,
+		`update reports set updated_at=CURRENT_TIMESTAMP where id=$1 and author_email=$2 and not archived`,
+		file.ReportId,
+		file.AuthorEmail,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", file.ReportId)
+		log.Warn().Err(err).Send()
+		return status.Error(codes.NotFound, err.Error())
+	}
+	var filesSize int64
+	err = tx.QueryRowContext(ctx, `select coalesce(sum(size), 0) from files where report_id=$1`, file.ReportId).Scan(&filesSize)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if limit := maxReportFiles * s.maxFileSize; filesSize+file.Size > limit {
+		return status.Errorf(codes.ResourceExhausted, "files of report are larger than %d bytes, remove files before upload", limit)
+	}
+	// file is result without query, so it's served by download path of results
+	_, err = tx.ExecContext(ctx,
+		`insert into results (id, object_name, query_id) values ($1, $2, $3)`,
+		file.Id,
+		objectName,
+		nil,
+	)
+	if err == nil {
+		_, err = tx.ExecContext(ctx,
+			`insert into files (id, report_id, name, file_type, size, author_email) values ($1, $2, $3, $4, $5, $6)`,
+			file.Id,
+			file.ReportId,
+			file.Name,
+			int32(file.FileType),
+			file.Size,
+			file.AuthorEmail,
+		)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	return nil
+}
+
+// getFiles of report ordered by upload
+func (s Server) getFiles(ctx context.Context, reportID string) ([]*proto.File, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`select id, report_id, name, file_type, size, author_email, %s
+		from files where report_id=$1 order by created_at, id`, s.dialect.Int(s.dialect.Epoch("created_at"))),
+		reportID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	files := []*proto.File{}
+	for rows.Next() {
+		file := &proto.File{}
+		var fileType int32
+		if err := rows.Scan(&file.Id, &file.ReportId, &file.Name, &fileType, &file.Size, &file.AuthorEmail, &file.CreatedAt); err != nil {
+			return nil, err
+		}
+		file.FileType = proto.FileType(fileType)
+		files = append(files, file)
+	}
+	return files, rows.Err()
+}
+
+// RemoveFile from report of author with its object
+func (s Server) RemoveFile(ctx context.Context, req *proto.RemoveFileRequest) (*proto.RemoveFileResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.FileId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := s.removeFile(ctx, req.FileId, claims.Email, s.deleteObject); err != nil {
+		return nil, err
+	}
+	return &proto.RemoveFileResponse{}, nil
+}
+
+// removeFile of report of author, file is kept when its object can't be removed so removal is retried
+func (s Server) removeFile(ctx context.Context, fileID string, email string, remove objectRemover) error {
+	var reportID, objectName, bucket string
+	err := s.db.QueryRowContext(ctx,
+		`select files.report_id, results.object_name, case when results.bucket is null then '' else results.bucket end
+		from files
+		join reports on reports.id = files.report_id
+		join results on results.id = files.id
+		where files.id=$1 and reports.author_email=$2 and not reports.archived`,
+		fileID,
+		email,
+	).Scan(&reportID, &objectName, &bucket)
+	if err == sql.ErrNoRows {
+		err := fmt.Errorf("File not found id:%s", fileID)
+		log.Warn().Err(err).Send()
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if err := remove(ctx, bucket, objectName); err != nil && err != storage.ErrObjectNotExist {
+		log.Err(err).Str("fileID", fileID).Msg("Cannot delete file object")
+		return internalError(err)
+	}
+	if err := s.deleteFile(ctx, fileID); err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	s.reportStreams.Publish(report.Event{ReportID: reportID, Kind: report.ReportChanged})
+	return nil
+}
+
+// deleteFile rows of file and its result after object is removed
+func (s Server) deleteFile(ctx context.Context, fileID string) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `delete from files where id=$1`, fileID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `delete from results where id=$1`, fileID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// removeReportFiles objects and rows of files of archived report; file is kept when its object can't be removed,
+// so removal is retried when report is archived again
+func (s Server) removeReportFiles(ctx context.Context, reportID string, remove objectRemover) error {
+	rows, err := s.db.QueryContext(ctx,
+		`select files.id, results.object_name, case when results.bucket is null then '' else results.bucket end
+		from files
+		join results on results.id = files.id
+		where files.report_id=$1`,
+		reportID,
+	)
+	if err != nil {
+		return err
+	}
+	type reportFile struct {
+		id, objectName, bucket string
+	}
+	var files []reportFile
+	for rows.Next() {
+		var f reportFile
+		if err := rows.Scan(&f.id, &f.objectName, &f.bucket); err != nil {
+			rows.Close()
+			return err
+		}
+		files = append(files, f)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := remove(ctx, f.bucket, f.objectName); err != nil && err != storage.ErrObjectNotExist {
+			log.Warn().Err(err).Str("fileID", f.id).Str("reportID", reportID).Msg("Cannot delete file object of archived report")
+			continue
+		}
+		if err := s.deleteFile(ctx, f.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileFilename of uploaded file as it was named, empty when result is not file
+func (s Server) fileFilename(ctx context.Context, resultID string) string {
+	var name string
+	err := s.db.QueryRowContext(ctx, `select name from files where id=$1`, resultID).Scan(&name)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Err(err).Send()
+		}
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,119 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeUploadFileStream of context sends chunks, response is recorded
+type fakeUploadFileStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	chunks   []*proto.UploadFileRequest
+	response *proto.UploadFileResponse
+}
+
+func (f *fakeUploadFileStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeUploadFileStream) Recv() (*proto.UploadFileRequest, error) {
+	if len(f.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return chunk, nil
+}
+
+func (f *fakeUploadFileStream) SendAndClose(res *proto.UploadFileResponse) error {
+	f.response = res
+	return nil
+}
+
+func TestValidateFile(t *testing.T) {
+	for name, c := range map[string]struct {
+		fileType proto.FileType
+		data     string
+		err      string
+	}{
+		"csv":                {proto.FileType_FILE_TYPE_CSV, "lat,lng,name\n52.5,13.4,Berlin\n", ""},
+		"csv header only":    {proto.FileType_FILE_TYPE_CSV, "lat,lng\n", ""},
+		"empty csv":          {proto.FileType_FILE_TYPE_CSV, "", "empty"},
+		"empty column":       {proto.FileType_FILE_TYPE_CSV, "lat,,lng\n", "empty column name"},
+		"repeated column":    {proto.FileType_FILE_TYPE_CSV, "lat,lat\n", "column lat twice"},
+		"ragged rows":        {proto.FileType_FILE_TYPE_CSV, "lat,lng\n1,2,3\n", "wrong number of fields"},
+		"elf":                {proto.FileType_FILE_TYPE_CSV, "\x7fELF\x02\x01\x01", "executable"},
+		"mach-o":             {proto.FileType_FILE_TYPE_GEOJSON, "\xcf\xfa\xed\xfe\x07", "executable"},
+		"pe renamed to csv":  {proto.FileType_FILE_TYPE_CSV, "MZ\x90\x00", "UTF-8"},
+		"script":             {proto.FileType_FILE_TYPE_GEOJSON, "#!/bin/sh\nrm -rf /", "invalid GeoJSON"},
+		"csv starting MZ":    {proto.FileType_FILE_TYPE_CSV, "MZ,count\nA,1\n", ""},
+		"csv starting #!":    {proto.FileType_FILE_TYPE_CSV, "#!id,name\n1,a\n", ""},
+		"binary":             {proto.FileType_FILE_TYPE_CSV, "a,b\n\xff\xfe,1\n", "UTF-8"},
+		"feature collection": {proto.FileType_FILE_TYPE_GEOJSON, `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[13.4,52.5]},"properties":{}},{"type":"Feature","geometry":null}]}`, ""},
+		"feature":            {proto.FileType_FILE_TYPE_GEOJSON, `{"type":"Feature","geometry":{"type":"GeometryCollection","geometries":[{"type":"LineString","coordinates":[[0,0],[1,1]]}]}}`, ""},
+		"geometry":           {proto.FileType_FILE_TYPE_GEOJSON, `{"type":"Point","coordinates":[0,0]}`, "expected FeatureCollection or Feature"},
+		"unknown geometry":   {proto.FileType_FILE_TYPE_GEOJSON, `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Circle","coordinates":[0,0]}}]}`, "unknown geometry type"},
+		"no coordinates":     {proto.FileType_FILE_TYPE_GEOJSON, `{"type":"Feature","geometry":{"type":"Polygon"}}`, "no coordinates"},
+		"not json":           {proto.FileType_FILE_TYPE_GEOJSON, "lat,lng\n", "invalid GeoJSON"},
+	} {
+		err := validateFile(c.fileType, []byte(c.data))
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: expected error with %q, got %v", name, c.err, err)
+		}
+	}
+}
+
+func TestFileType(t *testing.T) {
+	for name, expected := range map[string]proto.FileType{
+		"points.csv":     proto.FileType_FILE_TYPE_CSV,
+		"Areas.GeoJSON":  proto.FileType_FILE_TYPE_GEOJSON,
+		"areas.json":     proto.FileType_FILE_TYPE_GEOJSON,
+		"points.csv.exe": proto.FileType_FILE_TYPE_UNSPECIFIED,
+		"points":         proto.FileType_FILE_TYPE_UNSPECIFIED,
+	} {
+		if got := fileType(name); got != expected {
+			t.Errorf("expected %s of %s, got %s", expected, name, got)
+		}
+	}
+}
+
+func TestUploadFileRejected(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	s.maxFileSize = 10
+	ctx := testClaimsContext()
+	for name, chunks := range map[string][]*proto.UploadFileRequest{
+		"no chunks":  nil,
+		"report":     {{ReportId: "report", Name: "points.csv"}},
+		"name":       {{ReportId: testReportID, Name: "../points.csv"}},
+		"extension":  {{ReportId: testReportID, Name: "points.exe"}},
+		"too large":  {{ReportId: testReportID, Name: "points.csv", Data: []byte("lat,lng\n")}, {Data: []byte("1,2\n")}},
+		"empty name": {{ReportId: testReportID, Data: []byte("lat,lng\n")}},
+	} {
+		stream := &fakeUploadFileStream{ctx: ctx, chunks: chunks}
+		if err := s.UploadFile(stream); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+		if stream.response != nil {
+			t.Errorf("%s: unexpected response %v", name, stream.response)
+		}
+	}
+	// upload is rejected before report is read
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
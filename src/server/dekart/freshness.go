@@ -0,0 +1,177 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxFreshnessAge of freshness policy, results older than year are expired by bucket lifecycle anyway
+const maxFreshnessAge = 366 * 24 * time.Hour
+
+// autoRerun of stale queries when report is opened, DEKART_AUTO_RERUN=0 disables it for instance
+func autoRerun() bool {
+	return os.Getenv("DEKART_AUTO_RERUN") != "0"
+}
+
+// validateFreshnessPolicy of report, nil policy disables staleness
+func validateFreshnessPolicy(policy *proto.FreshnessPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.MaxAgeSeconds < 0 || time.Duration(policy.MaxAgeSeconds)*time.Second > maxFreshnessAge {
+		return fmt.Errorf("invalid max_age_seconds %d, expected 0 to %d", policy.MaxAgeSeconds, int64(maxFreshnessAge/time.Second))
+	}
+	if policy.AutoRerun && policy.MaxAgeSeconds == 0 {
+		return fmt.Errorf("auto_rerun needs max_age_seconds")
+	}
+	return nil
+}
+
+// staleQuery when result of done job is older than max age of policy at now; running query and query without
+// result are not stale, nor is any query without max age
+func staleQuery(query *proto.Query, policy *proto.FreshnessPolicy, now time.Time) bool {
+	if policy.GetMaxAgeSeconds() <= 0 || query.JobStatus != proto.Query_JOB_STATUS_DONE || query.JobResultId == "" || query.ResultCreatedAt == 0 {
+		return false
+	}
+	return now.Sub(time.Unix(query.ResultCreatedAt, 0)) > time.Duration(policy.MaxAgeSeconds)*time.Second
+}
+
+// markStale queries of report at now, returns stale queries
+func markStale(queries []*proto.Query, policy *proto.FreshnessPolicy, now time.Time) []*proto.Query {
+	var stale []*proto.Query
+	for _, query := range queries {
+		query.Stale = staleQuery(query, policy, now)
+		if query.Stale {
+			stale = append(stale, query)
+		}
+	}
+	return stale
+}
+
+// autoRerunKey of run of stale result; viewers opening report at once claim same key, so query runs once
+func autoRerunKey(query *proto.Query) string {
+	return "auto-rerun/" + query.JobResultId
+}
+
+// rerunStale queries of report opened by user when its policy asks for it; only author runs queries, like with
+// RunQuery. Runs are deduplicated by idempotency key of stale result and stop when quota is exhausted,
+// queries which need confirmation are skipped.
+// Queries with dependencies are left to RunAllQueries, they would run before their dependencies
+func (s Server) rerunStale(ctx context.Context, reportID string) {
+	if !s.autoRerun || s.readOnly {
+		return
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return
+	}
+	rep, err := s.getReport(ctx, reportID)
+	if err != nil {
+		log.Err(err).Send()
+		return
+	}
+	if rep == nil || !rep.CanWrite || !rep.FreshnessPolicy.GetAutoRerun() {
+		return
+	}
+	queries, err := s.getQueries(ctx, reportID)
+	if err != nil {
+		log.Err(err).Send()
+		return
+	}
+	stale := markStale(queries, rep.FreshnessPolicy, s.now())
+	if len(stale) == 0 {
+		return
+	}
+	if err := s.checkQuota(ctx); err != nil {
+		log.Info().Err(err).Str("reportID", reportID).Msg("Stale queries are not run")
+		return
+	}
+	// jobs keep running after stream of viewer is closed
+	runCtx := batchContext{ctx}
+	for _, query := range stale {
+		if len(query.DependsOn) > 0 {
+			continue
+		}
+		res, _, err := s.runQuery(runCtx, &proto.RunQueryRequest{QueryId: query.Id, IdempotencyKey: autoRerunKey(query)}, "")
+		if err != nil {
+			if autoRerunFailed(query.Id, err) {
+				return
+			}
+			continue
+		}
+		log.Info().Str("queryID", query.Id).Str("jobID", res.JobId).Bool("duplicate", res.Duplicate).Msg("Stale query rerun")
+	}
+}
+
+// autoRerunFailed logs error of auto-rerun of stale query, returns true when other stale queries can't run either.
+// Auto-rerun acknowledges no bytes, so query estimated above DEKART_CONFIRM_BYTES_THRESHOLD stays stale until user
+// confirms its run
+func autoRerunFailed(queryID string, err error) bool {
+	var confirmationErr *job.ConfirmationError
+	if errors.As(err, &confirmationErr) {
+		log.Info().Err(err).Str("queryID", queryID).Msg("Stale query needs confirmation, it is not rerun")
+		return false
+	}
+	log.Warn().Err(err).Str("queryID", queryID).Msg("Auto-rerun of stale query failed")
+	var quotaErr *quotaExceededError
+	return errors.As(err, &quotaErr)
+}
+
+// UpdateReportFreshnessPolicy replaces freshness policy of report
+func (s Server) UpdateReportFreshnessPolicy(ctx context.Context, req *proto.UpdateReportFreshnessPolicyRequest) (*proto.UpdateReportFreshnessPolicyResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireWritable(); err != nil {
+		return nil, err
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.ReportId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := validateFreshnessPolicy(req.FreshnessPolicy); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	policy := req.FreshnessPolicy
+	if policy == nil {
+		policy = &proto.FreshnessPolicy{}
+	}
+	result, err := s.db.ExecContext(ctx,
+		`update reports set freshness_max_age=$1, freshness_auto_rerun=$2, updated_at=CURRENT_TIMESTAMP
+		where id=$3 and author_email=$4 and not archived`,
+		policy.MaxAgeSeconds,
+		policy.AutoRerun,
+		req.ReportId,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
+	return &proto.UpdateReportFreshnessPolicyResponse{}, nil
+}
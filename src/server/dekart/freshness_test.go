@@ -0,0 +1,212 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const staleResultID = "2b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"
+
+var querySourceColumns = []string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}
+
+func TestStaleQuery(t *testing.T) {
+	created := time.Date(2021, 3, 4, 5, 0, 0, 0, time.UTC)
+	done := func() *proto.Query {
+		return &proto.Query{JobStatus: proto.Query_JOB_STATUS_DONE, JobResultId: staleResultID, ResultCreatedAt: created.Unix()}
+	}
+	hour := &proto.FreshnessPolicy{MaxAgeSeconds: 3600}
+	running := done()
+	running.JobStatus = proto.Query_JOB_STATUS_RUNNING
+	noResult := done()
+	noResult.JobResultId = ""
+	for name, c := range map[string]struct {
+		query    *proto.Query
+		policy   *proto.FreshnessPolicy
+		now      time.Time
+		expected bool
+	}{
+		"older":          {done(), hour, created.Add(time.Hour + time.Second), true},
+		"max age":        {done(), hour, created.Add(time.Hour), false},
+		"fresh":          {done(), hour, created.Add(time.Minute), false},
+		"no max age":     {done(), &proto.FreshnessPolicy{}, created.Add(24 * time.Hour), false},
+		"no policy":      {done(), nil, created.Add(24 * time.Hour), false},
+		"running":        {running, hour, created.Add(24 * time.Hour), false},
+		"without result": {noResult, hour, created.Add(24 * time.Hour), false},
+	} {
+		if got := staleQuery(c.query, c.policy, c.now); got != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, got)
+		}
+	}
+	queries := []*proto.Query{done(), {JobStatus: proto.Query_JOB_STATUS_UNSPECIFIED}}
+	queries[1].Stale = true
+	stale := markStale(queries, hour, created.Add(2*time.Hour))
+	if len(stale) != 1 || !queries[0].Stale || queries[1].Stale {
+		t.Errorf("unexpected stale queries %v", stale)
+	}
+}
+
+func TestValidateFreshnessPolicy(t *testing.T) {
+	for _, policy := range []*proto.FreshnessPolicy{
+		{MaxAgeSeconds: -1},
+		{MaxAgeSeconds: 400 * 24 * 3600},
+		{AutoRerun: true},
+	} {
+		if err := validateFreshnessPolicy(policy); err == nil {
+			t.Errorf("expected error of %v", policy)
+		}
+	}
+	if err := validateFreshnessPolicy(&proto.FreshnessPolicy{MaxAgeSeconds: 3600, AutoRerun: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+// expectStaleReport with auto-rerun policy and query with result of 2021-03-04 05:00
+func expectStaleReport(mock sqlmock.Sqlmock, autoRerun bool) {
+	mock.ExpectQuery("from reports where id").
+		WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testReportID, "{}", "Report", true, "", "", 1, "", 0, false, 0, 0, "", "", "", 3600, autoRerun))
+	mock.ExpectQuery("from queries where report_id").
+		WillReturnRows(sqlmock.NewRows(queryColumns).
+			AddRow(testQueryID, "select 1", int32(proto.Query_JOB_STATUS_DONE), staleResultID, "", 0, 2, 1, 12, 2, 0, "", "Query 1", "select 1", "", "", 1614834000, 1, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+}
+
+func TestRerunStaleDuplicate(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	s.autoRerun = true
+	s.now = func() time.Time { return time.Date(2021, 3, 4, 7, 0, 0, 0, time.UTC) }
+	ctx := testClaimsContext()
+	// other viewer opened report first and claimed rerun of stale result
+	s.jobs.ClaimKey(ctx, testQueryID, autoRerunKey(&proto.Query{JobResultId: staleResultID}), "first")
+	expectStaleReport(mock, true)
+	mock.ExpectQuery("from queries join reports").
+		WillReturnRows(sqlmock.NewRows(querySourceColumns).AddRow("select 1", testReportID, "", "", "", "", 0, false, 0, 0, "", "", "", ""))
+	s.rerunStale(ctx, testReportID)
+	if stats := s.jobs.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected no second job, got %+v", stats)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAutoRerunKeyClaimedOnce(t *testing.T) {
+	s, _ := newTestServer(t)
+	ctx := testClaimsContext()
+	key := autoRerunKey(&proto.Query{JobResultId: staleResultID})
+	var wg sync.WaitGroup
+	holders := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			holders <- s.jobs.ClaimKey(ctx, testQueryID, key, string(rune('a'+i)))
+		}(i)
+	}
+	wg.Wait()
+	close(holders)
+	first := ""
+	for holder := range holders {
+		if first == "" {
+			first = holder
+		}
+		if holder != first {
+			t.Errorf("expected single holder of %s, got %s and %s", key, first, holder)
+		}
+	}
+}
+
+func TestRerunStaleSkipped(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	now := time.Date(2021, 3, 4, 7, 0, 0, 0, time.UTC)
+
+	// policy without auto-rerun only marks queries stale
+	s, mock := newTestServer(t)
+	s.autoRerun = true
+	s.now = func() time.Time { return now }
+	mock.ExpectQuery("from reports where id").
+		WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testReportID, "{}", "Report", true, "", "", 1, "", 0, false, 0, 0, "", "", "", 3600, false))
+	s.rerunStale(testClaimsContext(), testReportID)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	// exhausted quota
+	s, mock = newTestServer(t)
+	s.autoRerun = true
+	s.now = func() time.Time { return now }
+	s.quota = &usageQuota{budget: 1000, now: func() time.Time { return now }}
+	expectStaleReport(mock, true)
+	mock.ExpectQuery("from usage_counters").WillReturnRows(sqlmock.NewRows([]string{"bytes_billed"}).AddRow(1000))
+	s.rerunStale(testClaimsContext(), testReportID)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if stats := s.jobs.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected no job, got %+v", stats)
+	}
+
+	// disabled instance doesn't read report
+	s, mock = newTestServer(t)
+	s.autoRerun = false
+	s.rerunStale(testClaimsContext(), testReportID)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAutoRerunFailed(t *testing.T) {
+	for name, c := range map[string]struct {
+		err  error
+		stop bool
+	}{
+		"needs confirmation": {fmt.Errorf("run: %w", &job.ConfirmationError{EstimatedBytes: 2 << 40, ThresholdBytes: 1 << 40}), false},
+		"quota exhausted":    {&quotaExceededError{budget: 1000, billed: 1000}, true},
+		"other":              {errors.New("backend error"), false},
+	} {
+		if stop := autoRerunFailed(testQueryID, c.err); stop != c.stop {
+			t.Errorf("%s: expected stop %v, got %v", name, c.stop, stop)
+		}
+	}
+}
+
+func TestUpdateReportFreshnessPolicy(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+	if _, err := s.UpdateReportFreshnessPolicy(ctx, &proto.UpdateReportFreshnessPolicyRequest{
+		ReportId:        testReportID,
+		FreshnessPolicy: &proto.FreshnessPolicy{MaxAgeSeconds: -1},
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	mock.ExpectExec("update reports set freshness_max_age").
+		WithArgs(int64(3600), true, testReportID, user.UnknownEmail).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if _, err := s.UpdateReportFreshnessPolicy(ctx, &proto.UpdateReportFreshnessPolicyRequest{
+		ReportId:        testReportID,
+		FreshnessPolicy: &proto.FreshnessPolicy{MaxAgeSeconds: 3600, AutoRerun: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectExec("update reports set freshness_max_age").WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err := s.UpdateReportFreshnessPolicy(ctx, &proto.UpdateReportFreshnessPolicyRequest{ReportId: testReportID}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -3,27 +3,65 @@ package dekart
 import (
 	"context"
 	"dekart/src/proto"
+	"dekart/src/server/job"
+	"fmt"
 
 	"github.com/rs/zerolog/log"
 )
 
 func (s Server) getQueries(ctx context.Context, reportID string) ([]*proto.Query, error) {
 	queryRows, err := s.db.QueryContext(ctx,
-		`select
+		fmt.Sprintf(`select
 			id,
 			query_text,
 			job_status,
-			case when job_result_id is null then '' else cast(job_result_id as VARCHAR) end as job_result_id,
+			case when job_result_id is null then '' else %s end as job_result_id,
 			case when job_error is null then '' else job_error end as job_error,
 			case
 				when job_started is null
 				then 0
-				else CAST((extract('epoch' from CURRENT_TIMESTAMP)  - extract('epoch' from job_started))*1000 as BIGINT)
+				else %s
 			end as job_duration,
 			total_rows,
 			bytes_processed,
-			result_size
-		from queries where report_id=$1 order by created_at asc`,
+			result_size,
+			rows_written,
+			sample_rate,
+			case when h3_result_id is null then '' else %s end as h3_result_id,
+			case when title is null then '' else title end as title,
+			case when executed_query_text is null then '' else executed_query_text end as executed_query_text,
+			case when result_table is null then '' else result_table end as result_table,
+			case when reused_from_query_id is null then '' else %s end as reused_from_query_id,
+			coalesce((select %s from results where results.id = queries.job_result_id), 0) as result_created_at,
+			coalesce(result_format, 0) as result_format,
+			coalesce(exploration_limit, 0) as exploration_limit,
+			case when job_warnings is null then '' else job_warnings end as job_warnings,
+			case when batch_id is null then '' else %s end as batch_id,
+			case when depends_on is null then '' else depends_on end as depends_on,
+			case when skipped_dependency_id is null then '' else %s end as skipped_dependency_id,
+			coalesce((select serialization from results where results.id = queries.job_result_id), '') as serialization,
+			case when job_plan is null then false else true end as plan_available,
+			coalesce(use_legacy_sql, false) as use_legacy_sql,
+			coalesce((select exact_total_rows from results where results.id = queries.job_result_id), -1) as exact_total_rows,
+			coalesce((select compression from results where results.id = queries.job_result_id), 0) as result_compression,
+			case when parameter_bindings is null then '' else parameter_bindings end as parameter_bindings,
+			case when format_results is null then '' else format_results end as format_results,
+			coalesce(cancel_reason, 0) as cancel_reason,
+			coalesce(job_reservation, '') as job_reservation,
+			case when preview_result_id is null then '' else %s end as preview_result_id,
+			coalesce(preview_rows, 0) as preview_rows,
+			case when job_error_explanation is null then '' else job_error_explanation end as job_error_explanation,
+			coalesce((select meta from results where results.id = queries.job_result_id), '') as result_meta
+		from queries where report_id=$1 and deleted_at is null order by created_at asc`,
+			s.dialect.Text("job_result_id"),
+			s.dialect.Int(fmt.Sprintf("(%s - %s)*1000", s.dialect.Epoch("CURRENT_TIMESTAMP"), s.dialect.Epoch("job_started"))),
+			s.dialect.Text("h3_result_id"),
+			s.dialect.Text("reused_from_query_id"),
+			s.dialect.Int(s.dialect.Epoch("results.created_at")),
+			s.dialect.Text("batch_id"),
+			s.dialect.Text("skipped_dependency_id"),
+			s.dialect.Text("preview_result_id"),
+		),
 		reportID,
 	)
 	if err != nil {
@@ -36,6 +74,7 @@ func (s Server) getQueries(ctx context.Context, reportID string) ([]*proto.Query
 		query := proto.Query{
 			ReportId: reportID,
 		}
+		var warnings, dependsOn, serialization, parameterBindings, formatResults, explanation, meta string
 		if err := queryRows.Scan(
 			&query.Id,
 			&query.QueryText,
@@ -46,15 +85,92 @@ func (s Server) getQueries(ctx context.Context, reportID string) ([]*proto.Query
 			&query.TotalRows,
 			&query.BytesProcessed,
 			&query.ResultSize,
+			&query.RowsWritten,
+			&query.SampleRate,
+			&query.H3ResultId,
+			&query.Title,
+			&query.ExecutedQueryText,
+			&query.ResultTable,
+			&query.ReusedFromQueryId,
+			&query.ResultCreatedAt,
+			&query.ResultFormat,
+			&query.ExplorationLimit,
+			&warnings,
+			&query.BatchId,
+			&dependsOn,
+			&query.SkippedDependencyId,
+			&serialization,
+			&query.PlanAvailable,
+			&query.UseLegacySql,
+			&query.ExactTotalRows,
+			&query.ResultCompression,
+			&parameterBindings,
+			&formatResults,
+			&query.CancelReason,
+			&query.Reservation,
+			&query.PreviewResultId,
+			&query.PreviewRows,
+			&explanation,
+			&meta,
 		); err != nil {
 			log.Err(err).Send()
 			return nil, err
 		}
+		jobWarnings, err := job.UnmarshalWarnings(warnings)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		query.Warnings = protoWarnings(jobWarnings)
+		if query.DependsOn, err = unmarshalDependsOn(dependsOn); err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		if query.SerializationOptions, err = job.UnmarshalSerialization(serialization); err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		if query.ParameterBindings, err = unmarshalStringMap(parameterBindings); err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		queryFormatResults, err := job.UnmarshalFormatResults(formatResults)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		query.FormatResults = protoFormatResults(queryFormatResults)
+		jobExplanation, err := job.UnmarshalErrorExplanation(explanation)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		query.JobErrorExplanation = protoErrorExplanation(jobExplanation)
+		resultMeta, err := job.UnmarshalResultMeta(meta)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+		if resultMeta != nil && resultMeta.ID == query.JobResultId {
+			query.ResultMeta = protoResultMeta(resultMeta)
+		} else {
+			// result stored before meta was recorded, deprecated fields of query describe it
+			query.ResultMeta = legacyResultMeta(&query)
+		}
+		if query.ExecutedQueryText != "" {
+			query.ExecutedQueryHash = job.QueryHash(query.ExecutedQueryText)
+		}
+		switch {
+		case query.JobResultId != "":
+			query.ResultType = proto.Query_RESULT_TYPE_FILE
+		case query.ResultTable != "":
+			query.ResultType = proto.Query_RESULT_TYPE_TABLE
+		}
 		switch query.JobStatus {
-		case proto.Query_JOB_STATUS_UNSPECIFIED:
+		case proto.Query_JOB_STATUS_UNSPECIFIED, proto.Query_JOB_STATUS_SKIPPED:
 			query.JobDuration = 0
 		case proto.Query_JOB_STATUS_DONE:
-			if query.JobResultId != "" {
+			if query.ResultType != proto.Query_RESULT_TYPE_UNSPECIFIED {
 				query.JobDuration = 0
 			}
 		}
@@ -0,0 +1,100 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// finishedJobEvents of job recorded in job stats, empty report ID when job stats were not recorded
+func (s Server) finishedJobEvents(ctx context.Context, jobID string) (string, []job.Event, int, error) {
+	var reportID string
+	var events sql.NullString
+	var dropped sql.NullInt64
+	err := s.db.QueryRowContext(ctx,
+		`select report_id, events, events_dropped from job_stats where id=$1`,
+		jobID,
+	).Scan(&reportID, &events, &dropped)
+	if err == sql.ErrNoRows {
+		return "", nil, 0, nil
+	}
+	if err != nil {
+		return "", nil, 0, err
+	}
+	list, err := job.UnmarshalEvents(events.String)
+	return reportID, list, int(dropped.Int64), err
+}
+
+// readableReport by user, same visibility as results of report
+func (s Server) readableReport(ctx context.Context, reportID string, email string) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`select count(*) from reports where id=$1 and (not archived or author_email=$2)`,
+		reportID,
+		email,
+	).Scan(&count)
+	return count > 0, err
+}
+
+// GetJobEvents of job running on this replica or finished job, for users who can read report of job
+func (s Server) GetJobEvents(ctx context.Context, req *proto.GetJobEventsRequest) (*proto.GetJobEventsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_VIEWER); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.JobId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	var reportID string
+	var events []job.Event
+	var dropped int
+	finished := false
+	// job is removed from store after its stats are recorded, so it's found in one of them
+	if j := s.jobs.Get(req.JobId); j != nil {
+		reportID = j.ReportID
+		events, dropped = j.GetEvents()
+	} else {
+		var err error
+		reportID, events, dropped, err = s.finishedJobEvents(ctx, req.JobId)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		finished = true
+	}
+	readable := false
+	if reportID != "" {
+		var err error
+		readable, err = s.readableReport(ctx, reportID, claims.Email)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+	}
+	if !readable {
+		// job of other replica is not found until it's finished
+		err := fmt.Errorf("Job not found id:%s", req.JobId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	res := &proto.GetJobEventsResponse{
+		Events:        make([]*proto.JobEvent, len(events)),
+		EventsDropped: int32(dropped),
+		Finished:      finished,
+	}
+	for i, event := range events {
+		res.Events[i] = &proto.JobEvent{Time: event.Time, Type: event.Type, Message: event.Message}
+	}
+	return res, nil
+}
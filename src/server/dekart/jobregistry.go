@@ -0,0 +1,125 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/dialect"
+	"dekart/src/server/job"
+	"fmt"
+	"time"
+)
+
+// JobRegistry stores job ownership in jobs table
+type JobRegistry struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+}
+
+// NewJobRegistry returns job.Registry backed by database
+func NewJobRegistry(db *sql.DB) *JobRegistry {
+	return &JobRegistry{db: db, dialect: dialect.Of(db)}
+}
+
+// Register job started by replica
+func (r *JobRegistry) Register(ctx context.Context, record job.Record) error {
+	_, err := r.db.ExecContext(ctx,
+		`insert into jobs (id, query_id, replica, bigquery_job_id, bigquery_location, bigquery_project, bigquery_connection)
+		values ($1, $2, $3, $4, $5, $6, $7)`,
+		record.JobID,
+		record.QueryID,
+		record.Replica,
+		record.BigqueryJobID,
+		record.BigqueryLocation,
+		record.BigqueryProject,
+		record.BigqueryConnection,
+	)
+	return err
+}
+
+// Find active jobs for query
+func (r *JobRegistry) Find(ctx context.Context, queryID string, staleAfter time.Duration) ([]job.Record, error) {
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf(`select
+			id,
+			query_id,
+			replica,
+			case when bigquery_job_id is null then '' else bigquery_job_id end as bigquery_job_id,
+			case when bigquery_location is null then '' else bigquery_location end as bigquery_location,
+			case when bigquery_project is null then '' else bigquery_project end as bigquery_project,
+			case when bigquery_connection is null then '' else bigquery_connection end as bigquery_connection
+		from jobs where query_id=$1 and not cancel_requested
+		and heartbeat_at > %s`, r.dialect.SecondsAgo("$2")),
+		queryID,
+		staleAfter.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	records := make([]job.Record, 0)
+	for rows.Next() {
+		record := job.Record{}
+		err := rows.Scan(
+			&record.JobID,
+			&record.QueryID,
+			&record.Replica,
+			&record.BigqueryJobID,
+			&record.BigqueryLocation,
+			&record.BigqueryProject,
+			&record.BigqueryConnection,
+		)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+// Heartbeat of jobs running on replica
+func (r *JobRegistry) Heartbeat(ctx context.Context, jobIDs []string) error {
+	_, err := r.db.ExecContext(ctx,
+		`update jobs set heartbeat_at=CURRENT_TIMESTAMP where `+r.dialect.InList("id", "$1"),
+		r.dialect.ListArg(jobIDs),
+	)
+	return err
+}
+
+// RequestCancel marks job cancelled with reason; reason of first request is kept
+func (r *JobRegistry) RequestCancel(ctx context.Context, jobID string, reason proto.CancelReason) error {
+	_, err := r.db.ExecContext(ctx,
+		`update jobs set cancel_requested=true, cancel_reason=$2 where id=$1 and not cancel_requested`,
+		jobID,
+		int32(reason),
+	)
+	return err
+}
+
+// CancelRequested returns reasons of cancelled jobs from jobIDs
+func (r *JobRegistry) CancelRequested(ctx context.Context, jobIDs []string) (map[string]proto.CancelReason, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`select id, coalesce(cancel_reason, 0) from jobs where `+r.dialect.InList("id", "$1")+` and cancel_requested`,
+		r.dialect.ListArg(jobIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cancelled := make(map[string]proto.CancelReason)
+	for rows.Next() {
+		var jobID string
+		var reason int32
+		if err := rows.Scan(&jobID, &reason); err != nil {
+			return nil, err
+		}
+		cancelled[jobID] = proto.CancelReason(reason)
+	}
+	return cancelled, rows.Err()
+}
+
+// Remove job record
+func (r *JobRegistry) Remove(ctx context.Context, jobID string) error {
+	_, err := r.db.ExecContext(ctx, `delete from jobs where id=$1`, jobID)
+	return err
+}
@@ -0,0 +1,168 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReconcileLimit = 1000
+	maxReconcileLimit     = 10000
+)
+
+// errResultExpired when result object was removed by bucket lifecycle
+var errResultExpired = errors.New("result expired")
+
+// parseReconcileInterval of DEKART_RESULTS_RECONCILE_INTERVAL, zero disables scheduled reconciliation
+func parseReconcileInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < time.Minute {
+		return 0, fmt.Errorf("invalid DEKART_RESULTS_RECONCILE_INTERVAL %s, expected duration of at least 1m", value)
+	}
+	return interval, nil
+}
+
+// objectExists returns storage.ErrObjectNotExist when object is missing; bucket is recorded bucket of result
+type objectExists func(ctx context.Context, bucket string, name string) error
+
+func (s Server) headObject(ctx context.Context, bucket string, name string) error {
+	_, err := s.resultBucket(bucket).Object(name).Attrs(ctx)
+	return err
+}
+
+// GetResultLifecycle reports storage layout and results affected by lifecycle rule with age cutoff
+func (s Server) GetResultLifecycle(ctx context.Context, req *proto.GetResultLifecycleRequest) (*proto.GetResultLifecycleResponse, error) {
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	if req.OlderThanDays < 0 {
+		return nil, status.Error(codes.InvalidArgument, "older_than_days is negative")
+	}
+	cutoff := time.Now().AddDate(0, 0, -int(req.OlderThanDays))
+	res := &proto.GetResultLifecycleResponse{
+		StoragePrefix: s.storagePrefix,
+		DateLayout:    "YYYY/MM/DD",
+	}
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select
+			count(*),
+			coalesce(sum(case when exists (
+				select 1 from queries where queries.job_result_id = results.id or queries.h3_result_id = results.id
+			) then 1 else 0 end), 0)
+		from results where expired_at is null and created_at < %s`, s.dialect.Timestamp("$1")),
+		s.dialect.TimeArg(cutoff),
+	).Scan(&res.AffectedCount, &res.AffectedCurrentCount)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return res, nil
+}
+
+// reconcileResults checks objects of least recently checked results and marks missing ones expired
+func (s Server) reconcileResults(ctx context.Context, limit int, exists objectExists) (*proto.ReconcileResultsResponse, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`select id, object_name, case when bucket is null then '' else bucket end from results where expired_at is null
+		order by case when checked_at is null then 0 else 1 end, checked_at, id limit $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	type result struct{ id, objectName, bucket string }
+	var results []result
+	for rows.Next() {
+		var r result
+		if err := rows.Scan(&r.id, &r.objectName, &r.bucket); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res := &proto.ReconcileResultsResponse{}
+	for _, r := range results {
+		err := exists(ctx, r.bucket, r.objectName)
+		update := `update results set checked_at=CURRENT_TIMESTAMP where id=$1`
+		switch {
+		case err == storage.ErrObjectNotExist:
+			update = `update results set checked_at=CURRENT_TIMESTAMP, expired_at=CURRENT_TIMESTAMP where id=$1`
+			res.ExpiredCount++
+		case err != nil:
+			log.Warn().Err(err).Str("resultID", r.id).Msg("Cannot check result object")
+			res.ErrorCount++
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, update, r.id); err != nil {
+			return nil, err
+		}
+		res.CheckedCount++
+	}
+	return res, nil
+}
+
+// ReconcileResults marks results which objects were removed by bucket lifecycle as expired
+func (s Server) ReconcileResults(ctx context.Context, req *proto.ReconcileResultsRequest) (*proto.ReconcileResultsResponse, error) {
+	if err := s.requireWritable(); err != nil {
+		return nil, err
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultReconcileLimit
+	}
+	if limit > maxReconcileLimit {
+		limit = maxReconcileLimit
+	}
+	res, err := s.reconcileResults(ctx, limit, s.headObject)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	log.Info().Int64("checked", res.CheckedCount).Int64("expired", res.ExpiredCount).Int64("errors", res.ErrorCount).Msg("Results reconciled")
+	return res, nil
+}
+
+// ScheduleResultsReconcile every DEKART_RESULTS_RECONCILE_INTERVAL, not scheduled when interval is not set
+func (s Server) ScheduleResultsReconcile() {
+	interval, err := parseReconcileInterval(os.Getenv("DEKART_RESULTS_RECONCILE_INTERVAL"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	if interval == 0 {
+		return
+	}
+	log.Info().Str("interval", interval.String()).Msg("Results reconcile scheduled")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			res, err := s.reconcileResults(ctx, defaultReconcileLimit, s.headObject)
+			cancel()
+			if err != nil {
+				log.Err(err).Msg("Cannot reconcile results")
+				continue
+			}
+			log.Info().Int64("checked", res.CheckedCount).Int64("expired", res.ExpiredCount).Int64("errors", res.ErrorCount).Msg("Results reconciled")
+		}
+	}()
+}
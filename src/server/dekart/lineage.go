@@ -0,0 +1,181 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/encryption"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Version of dekart recorded in lineage of results, set at build with -ldflags "-X dekart/src/server/dekart.Version=0.5.0"
+var Version = "dev"
+
+// lineageMetadataKey of exported object holding lineage of result
+const lineageMetadataKey = "dekart-lineage"
+
+// lineageQueryText when executed query text is stored in lineage, DEKART_LINEAGE_QUERY_TEXT=1
+func lineageQueryText() bool {
+	return os.Getenv("DEKART_LINEAGE_QUERY_TEXT") == "1"
+}
+
+// storeResultLineage of done job in transaction storing its status, so result is never current without lineage;
+// h3 result and results in additional formats have lineage of job result they were written with
+func (s Server) storeResultLineage(ctx context.Context, tx *sql.Tx, j *job.Job, snapshot job.StatusSnapshot, finished time.Time) error {
+	lineage := j.Lineage()
+	values, err := marshalStringMap(lineage.ParameterValues)
+	if err != nil {
+		return err
+	}
+	var queryText interface{}
+	if s.lineageQueryText {
+		queryText = encryption.String(snapshot.QueryText)
+	}
+	resultIDs := []string{*snapshot.ResultID}
+	if snapshot.H3ResultID != nil {
+		resultIDs = append(resultIDs, *snapshot.H3ResultID)
+	}
+	for _, r := range snapshot.FormatResults {
+		resultIDs = append(resultIDs, r.ResultID)
+	}
+	for _, resultID := range resultIDs {
+		_, err := tx.ExecContext(ctx,
+			`insert into result_lineage (
+				result_id, report_id, query_id, job_id, bigquery_job_id, bigquery_project, bigquery_location,
+				query_hash, query_text, parameter_values, user_email, dekart_version, job_started, job_finished
+			) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+			resultID,
+			j.ReportID,
+			j.QueryID,
+			j.ID,
+			lineage.BigqueryJobID,
+			lineage.BigqueryProject,
+			lineage.BigqueryLocation,
+			snapshot.QueryHash,
+			queryText,
+			values,
+			j.UserEmail,
+			Version,
+			unixSeconds(lineage.StartedAt),
+			finished.Unix(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unixSeconds of t, 0 for zero time
+func unixSeconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// resultLineage of result, nil when lineage was not recorded
+func (s Server) resultLineage(ctx context.Context, resultID string) (*proto.ResultLineage, error) {
+	lineage := &proto.ResultLineage{ResultId: resultID}
+	var queryText encryption.String
+	var values sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		`select report_id, query_id, job_id, bigquery_job_id, bigquery_project, bigquery_location,
+			query_hash, query_text, parameter_values, user_email, dekart_version, job_started, job_finished
+		from result_lineage where result_id=$1`,
+		resultID,
+	).Scan(
+		&lineage.ReportId,
+		&lineage.QueryId,
+		&lineage.JobId,
+		&lineage.BigqueryJobId,
+		&lineage.BigqueryProject,
+		&lineage.BigqueryLocation,
+		&lineage.QueryHash,
+		&queryText,
+		&values,
+		&lineage.UserEmail,
+		&lineage.DekartVersion,
+		&lineage.JobStarted,
+		&lineage.JobFinished,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lineage.QueryText = string(queryText)
+	lineage.ParameterValues, err = unmarshalStringMap(values.String)
+	if err != nil {
+		return nil, err
+	}
+	return lineage, nil
+}
+
+// lineageMetadata of exported object; query text is left out, metadata of object is limited to 8 KiB
+func lineageMetadata(lineage *proto.ResultLineage) (map[string]string, error) {
+	exported := &proto.ResultLineage{
+		ResultId:         lineage.ResultId,
+		ReportId:         lineage.ReportId,
+		QueryId:          lineage.QueryId,
+		JobId:            lineage.JobId,
+		BigqueryJobId:    lineage.BigqueryJobId,
+		BigqueryProject:  lineage.BigqueryProject,
+		BigqueryLocation: lineage.BigqueryLocation,
+		QueryHash:        lineage.QueryHash,
+		ParameterValues:  lineage.ParameterValues,
+		UserEmail:        lineage.UserEmail,
+		DekartVersion:    lineage.DekartVersion,
+		JobStarted:       lineage.JobStarted,
+		JobFinished:      lineage.JobFinished,
+	}
+	b, err := protojson.Marshal(exported)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{lineageMetadataKey: string(b)}, nil
+}
+
+// GetResultLineage of result of report user can read
+func (s Server) GetResultLineage(ctx context.Context, req *proto.GetResultLineageRequest) (*proto.GetResultLineageResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_VIEWER); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.ResultId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	queryID, _, err := s.resultQuery(ctx, req.ResultId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if queryID == "" {
+		err := fmt.Errorf("Result not found id:%s", req.ResultId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	lineage, err := s.resultLineage(ctx, req.ResultId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if lineage == nil {
+		return nil, status.Errorf(codes.NotFound, "result %s has no lineage, it was stored before lineage was recorded", req.ResultId)
+	}
+	return &proto.GetResultLineageResponse{Lineage: lineage}, nil
+}
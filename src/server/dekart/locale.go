@@ -0,0 +1,201 @@
+package dekart
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// maxLocaleFractionDigits of localized FLOAT64 and NUMERIC cells, spreadsheets read numbers as float64
+const maxLocaleFractionDigits = 15
+
+// localeDateLayouts of date cells by language or region, other locales keep ISO dates
+var localeDateLayouts = map[string]string{
+	"de":    "02.01.2006",
+	"fr":    "02/01/2006",
+	"es":    "02/01/2006",
+	"it":    "02/01/2006",
+	"nl":    "02-01-2006",
+	"pl":    "02.01.2006",
+	"ru":    "02.01.2006",
+	"en-US": "01/02/2006",
+	"en-GB": "02/01/2006",
+}
+
+// storedDateLayouts of temporal cells written with default serialization
+var storedDateLayouts = map[string]string{
+	"DATE":      "2006-01-02",
+	"DATETIME":  "2006-01-02T15:04:05.999999999",
+	"TIMESTAMP": "2006-01-02 15:04:05.999999999 -0700 MST",
+}
+
+// downloadLocale formats numeric and date cells of CSV download for spreadsheets of locale
+type downloadLocale struct {
+	printer    *message.Printer
+	decimal    string
+	dateLayout string
+}
+
+// parseDownloadLocale of BCP 47 tag like de-DE, empty tag keeps stored result
+func parseDownloadLocale(value string) (*downloadLocale, error) {
+	if value == "" {
+		return nil, nil
+	}
+	tag, err := language.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid locale %s, expected BCP 47 tag like de-DE", value)
+	}
+	l := &downloadLocale{printer: message.NewPrinter(tag), dateLayout: "2006-01-02"}
+	// decimal separator of locale decides delimiter, so decimal commas are not split into columns
+	l.decimal = strings.Trim(l.printer.Sprint(number.Decimal(0.5, number.NoSeparator())), "05")
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	if layout, ok := localeDateLayouts[base.String()+"-"+region.String()]; ok {
+		l.dateLayout = layout
+	} else if layout, ok := localeDateLayouts[base.String()]; ok {
+		l.dateLayout = layout
+	}
+	return l, nil
+}
+
+// delimiter of localized CSV, semicolon when locale uses decimal comma
+func (l *downloadLocale) delimiter() rune {
+	if l.decimal == "," {
+		return ';'
+	}
+	return ','
+}
+
+// cell localized by column type; cells which are not parsed, like NULL tokens, are kept
+func (l *downloadLocale) cell(value string, columnType string) string {
+	// precision and scale of parameterized types like NUMERIC(10,2)
+	if i := strings.IndexByte(columnType, '('); i >= 0 {
+		columnType = columnType[:i]
+	}
+	switch columnType {
+	case "INTEGER", "INT64":
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return l.printer.Sprint(number.Decimal(i, number.NoSeparator()))
+		}
+	case "FLOAT", "FLOAT64":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return l.float(f)
+		}
+	case "NUMERIC", "BIGNUMERIC":
+		// NUMERIC is stored as decimal, results written before as fraction of big.Rat
+		if r, ok := new(big.Rat).SetString(value); ok {
+			f, _ := r.Float64()
+			return l.float(f)
+		}
+	case "DATE", "DATETIME", "TIMESTAMP":
+		t, err := time.Parse(storedDateLayouts[columnType], value)
+		if err != nil {
+			break
+		}
+		if columnType == "DATE" {
+			return t.Format(l.dateLayout)
+		}
+		return t.Format(l.dateLayout + " 15:04:05")
+	}
+	return value
+}
+
+// float with all digits of shortest representation
+func (l *downloadLocale) float(f float64) string {
+	digits := 0
+	if s := strconv.FormatFloat(f, 'f', -1, 64); strings.Contains(s, ".") {
+		digits = len(s) - strings.Index(s, ".") - 1
+	}
+	if digits > maxLocaleFractionDigits {
+		digits = maxLocaleFractionDigits
+	}
+	return l.printer.Sprint(number.Decimal(f, number.NoSeparator(), number.MaxFractionDigits(digits)))
+}
+
+// localizeCSV of comma separated result with column types; header and cells of untyped columns are copied
+func localizeCSV(r io.Reader, w io.Writer, types []string, l *downloadLocale) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	writer := csv.NewWriter(w)
+	writer.Comma = l.delimiter()
+	header := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !header {
+			for i := range record {
+				if i < len(types) {
+					record[i] = l.cell(record[i], types[i])
+				}
+			}
+		}
+		header = false
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// serveLocalizedResult of CSV result for spreadsheets of locale, stored result stays machine readable
+func (s Server) serveLocalizedResult(w http.ResponseWriter, r *http.Request, resultID string, l *downloadLocale) {
+	ctx := r.Context()
+	obj, err := s.resultObject(ctx, resultID)
+	if err == errResultExpired {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if path.Ext(obj.ObjectName()) != ".csv" {
+		http.Error(w, "locale applies only to CSV result", http.StatusBadRequest)
+		return
+	}
+	types, err := s.resultColumnTypes(ctx, resultID)
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if types == nil {
+		http.Error(w, "column types of result are unknown, run query again to download it with locale", http.StatusConflict)
+		return
+	}
+	reader, _, err := s.openResult(ctx, resultID)
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer reader.Close()
+	if filename := s.resultFilename(ctx, resultID, ".csv"); filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=31536000")
+	if err := localizeCSV(reader, w, types, l); err != nil {
+		// rows were sent already, download is truncated
+		log.Err(err).Str("resultID", resultID).Msg("Cannot localize result")
+	}
+}
@@ -0,0 +1,67 @@
+package dekart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDownloadLocale(t *testing.T) {
+	for tag, cells := range map[string]map[string]string{
+		"de-DE": {
+			"1234567|INTEGER":                         "1234567",
+			"-1234.5|FLOAT":                           "-1234,5",
+			"0.1|FLOAT":                               "0,1",
+			"617/50|NUMERIC":                          "12,34",
+			"2021-03-04|DATE":                         "04.03.2021",
+			"2021-03-04T05:06:07|DATETIME":            "04.03.2021 05:06:07",
+			"2021-03-04 05:06:07 +0000 UTC|TIMESTAMP": "04.03.2021 05:06:07",
+			"<nil>|FLOAT":                             "<nil>",
+			"1.5|STRING":                              "1.5",
+		},
+		"fr-FR": {
+			"1234567|INTEGER":              "1234567",
+			"-1234.5|FLOAT":                "-1234,5",
+			"617/50|NUMERIC":               "12,34",
+			"2021-03-04|DATE":              "04/03/2021",
+			"2021-03-04T05:06:07|DATETIME": "04/03/2021 05:06:07",
+		},
+		"en-US": {
+			"-1234.5|FLOAT":   "-1234.5",
+			"2021-03-04|DATE": "03/04/2021",
+		},
+	} {
+		l, err := parseDownloadLocale(tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for cell, expected := range cells {
+			parts := strings.Split(cell, "|")
+			if actual := l.cell(parts[0], parts[1]); actual != expected {
+				t.Errorf("%s %s: expected %q, got %q", tag, cell, expected, actual)
+			}
+		}
+	}
+	if l, err := parseDownloadLocale(""); err != nil || l != nil {
+		t.Errorf("expected no locale, got %v %v", l, err)
+	}
+	if _, err := parseDownloadLocale("not a locale!"); err == nil {
+		t.Error("expected invalid locale rejected")
+	}
+}
+
+func TestLocalizeCSV(t *testing.T) {
+	l, err := parseDownloadLocale("de-DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	stored := "name,value,day\n\"a, b\",1.5,2021-03-04\nc,<nil>,<nil>\n"
+	if err := localizeCSV(strings.NewReader(stored), &b, []string{"STRING", "FLOAT", "DATE"}, l); err != nil {
+		t.Fatal(err)
+	}
+	// decimal comma is separated by semicolon
+	if expected := "name;value;day\na, b;1,5;04.03.2021\nc;<nil>;<nil>\n"; b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
@@ -0,0 +1,56 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/logging"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s Server) logSettings() *proto.LogSettings {
+	thresholds := s.jobs.SlowQueryThresholds()
+	return &proto.LogSettings{
+		InfoSampleEvery:  int32(logging.Sampler.Every()),
+		SlowQueryWaitMs:  thresholds.Wait.Milliseconds(),
+		SlowQueryTotalMs: thresholds.Total.Milliseconds(),
+	}
+}
+
+// GetLogSettings of replica
+func (s Server) GetLogSettings(ctx context.Context, req *proto.GetLogSettingsRequest) (*proto.GetLogSettingsResponse, error) {
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	return &proto.GetLogSettingsResponse{Settings: s.logSettings()}, nil
+}
+
+// UpdateLogSettings of replica without restart, settings are reset to env variables on restart
+func (s Server) UpdateLogSettings(ctx context.Context, req *proto.UpdateLogSettingsRequest) (*proto.UpdateLogSettingsResponse, error) {
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	settings := req.Settings
+	if settings == nil {
+		return nil, status.Error(codes.InvalidArgument, "req.Settings == nil")
+	}
+	if settings.InfoSampleEvery < 0 || settings.SlowQueryWaitMs < 0 || settings.SlowQueryTotalMs < 0 {
+		return nil, status.Error(codes.InvalidArgument, "log settings can't be negative")
+	}
+	logging.Sampler.SetEvery(uint32(settings.InfoSampleEvery))
+	s.jobs.SetSlowQueryThresholds(job.SlowQueryThresholds{
+		Wait:  time.Duration(settings.SlowQueryWaitMs) * time.Millisecond,
+		Total: time.Duration(settings.SlowQueryTotalMs) * time.Millisecond,
+	})
+	updated := s.logSettings()
+	log.Warn().
+		Int32("infoSampleEvery", updated.InfoSampleEvery).
+		Int64("slowQueryWaitMs", updated.SlowQueryWaitMs).
+		Int64("slowQueryTotalMs", updated.SlowQueryTotalMs).
+		Msg("Log settings updated")
+	return &proto.UpdateLogSettingsResponse{Settings: updated}, nil
+}
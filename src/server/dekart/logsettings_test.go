@@ -0,0 +1,52 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"dekart/src/server/logging"
+	"dekart/src/server/user"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateLogSettings(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	defer logging.Sampler.SetEvery(logging.Sampler.Every())
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+
+	res, err := s.UpdateLogSettings(ctx, &proto.UpdateLogSettingsRequest{Settings: &proto.LogSettings{
+		InfoSampleEvery:  10,
+		SlowQueryWaitMs:  30000,
+		SlowQueryTotalMs: 60000,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Settings.InfoSampleEvery != 10 || logging.Sampler.Every() != 10 || s.jobs.SlowQueryThresholds().Total != time.Minute {
+		t.Errorf("unexpected settings %v", res.Settings)
+	}
+	got, err := s.GetLogSettings(ctx, &proto.GetLogSettingsRequest{})
+	if err != nil || got.Settings.SlowQueryWaitMs != 30000 {
+		t.Errorf("unexpected settings %v %v", got, err)
+	}
+	_, err = s.UpdateLogSettings(ctx, &proto.UpdateLogSettingsRequest{Settings: &proto.LogSettings{SlowQueryWaitMs: -1}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	os.Unsetenv("DEKART_ADMIN_EMAILS")
+	mock.ExpectQuery("from instance_roles").WillReturnRows(sqlmock.NewRows([]string{"email_pattern", "role"}))
+	_, err = s.UpdateLogSettings(ctx, &proto.UpdateLogSettingsRequest{Settings: &proto.LogSettings{}})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,239 @@
+package dekart
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxMapConfigSize is 5MB, map configs are the largest payloads
+const defaultMaxMapConfigSize = 5 << 20
+
+const (
+	defaultHistoryLimit = 10
+	maxHistoryLimit     = 100
+)
+
+// parseMaxMapConfigSize of DEKART_MAX_MAP_CONFIG_SIZE in bytes
+func parseMaxMapConfigSize(value string) (int, error) {
+	if value == "" {
+		return defaultMaxMapConfigSize, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_MAX_MAP_CONFIG_SIZE %s", value)
+	}
+	return size, nil
+}
+
+func maxMapConfigSize() int {
+	size, err := parseMaxMapConfigSize(os.Getenv("DEKART_MAX_MAP_CONFIG_SIZE"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return size
+}
+
+// MaxRequestSize of gRPC message, map config with rest of report or query text with rest of query fits
+func (s Server) MaxRequestSize() int {
+	size := s.maxMapConfigSize
+	if queryTextSize := s.jobs.MaxQueryTextSize(); queryTextSize > size {
+		size = queryTextSize
+	}
+	return size + 1<<20
+}
+
+// normalizeMapConfig with stable key order and indentation, so revisions diff line by line
+func (s Server) normalizeMapConfig(mapConfig string) (string, error) {
+	if len(mapConfig) > s.maxMapConfigSize {
+		return "", status.Errorf(codes.InvalidArgument, "map config of %d bytes exceeds limit of %d bytes", len(mapConfig), s.maxMapConfigSize)
+	}
+	if mapConfig == "" {
+		return "", nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(mapConfig)))
+	// numbers are kept as written
+	decoder.UseNumber()
+	var config interface{}
+	if err := decoder.Decode(&config); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid map config: %s", err)
+	}
+	if decoder.More() {
+		return "", status.Error(codes.InvalidArgument, "invalid map config: unexpected data after JSON value")
+	}
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	// map keys are sorted by encoder
+	if err := encoder.Encode(config); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid map config: %s", err)
+	}
+	return string(bytes.TrimRight(b.Bytes(), "\n")), nil
+}
+
+// mapConfigRevisionSQL increments revision only when map config $1 is changed;
+// MySQL assigns columns left to right, so it goes before map_config assignment
+func (s Server) mapConfigRevisionSQL() string {
+	return fmt.Sprintf(`map_config_revision = map_config_revision + case when %s then 1 else 0 end`, s.dialect.DistinctFrom("map_config", "$1"))
+}
+
+// insertMapConfigRevision to history; unchanged map config keeps revision, so insert is skipped
+func (s Server) insertMapConfigRevision(ctx context.Context, tx *sql.Tx, reportID string, revision int64, mapConfig string, email string) error {
+	_, err := tx.ExecContext(ctx,
+		`insert into map_configs (report_id, revision, map_config, author_email) values ($1, $2, $3, $4)
+		`+s.dialect.OnConflict([]string{"report_id", "revision"}, ""),
+		reportID,
+		revision,
+		mapConfig,
+		email,
+	)
+	return err
+}
+
+// reportWriteError when report was not updated: not found or report of other author
+func (s Server) reportWriteError(ctx context.Context, reportID string, email string) error {
+	var authorEmail string
+	err := s.db.QueryRowContext(ctx, `select author_email from reports where id=$1`, reportID).Scan(&authorEmail)
+	if err == sql.ErrNoRows {
+		err := fmt.Errorf("Report not found id:%s", reportID)
+		log.Warn().Err(err).Send()
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	err = fmt.Errorf("%s can't edit report %s of %s", email, reportID, authorEmail)
+	log.Warn().Err(err).Send()
+	return status.Error(codes.PermissionDenied, err.Error())
+}
+
+// saveMapConfig of report owned by email with extra set clause using args from $4,
+// returns revision of map config
+func (s Server) saveMapConfig(ctx context.Context, reportID string, email string, mapConfig string, set string, args ...interface{}) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		log.Err(err).Send()
+		return 0, internalError(err)
+	}
+	var revision int64
+	err = s.scanReturning(ctx, tx,
+		`update reports set `+s.mapConfigRevisionSQL()+`, map_config=$1, updated_at=CURRENT_TIMESTAMP`+set+`
+		where id=$2 and author_email=$3`,
+		append([]interface{}{mapConfig, reportID, email}, args...),
+		"map_config_revision",
+		`select map_config_revision from reports where id=$1`,
+		[]interface{}{reportID},
+		&revision,
+	)
+	if err == sql.ErrNoRows {
+		rollback(tx)
+		return 0, s.reportWriteError(ctx, reportID, email)
+	}
+	if err == nil {
+		err = s.insertMapConfigRevision(ctx, tx, reportID, revision, mapConfig, email)
+	}
+	if err != nil {
+		rollback(tx)
+		log.Err(err).Send()
+		return 0, internalError(err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Err(err).Send()
+		return 0, internalError(err)
+	}
+	return revision, nil
+}
+
+// UpdateMapConfig of report, stored as new revision when changed
+func (s Server) UpdateMapConfig(ctx context.Context, req *proto.UpdateMapConfigRequest) (*proto.UpdateMapConfigResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	mapConfig, err := s.normalizeMapConfig(req.MapConfig)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.saveMapConfig(ctx, req.ReportId, claims.Email, mapConfig, "")
+	if err != nil {
+		return nil, err
+	}
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
+	return &proto.UpdateMapConfigResponse{Revision: revision}, nil
+}
+
+// GetMapConfigHistory of readable report, newest revision first
+func (s Server) GetMapConfigHistory(ctx context.Context, req *proto.GetMapConfigHistoryRequest) (*proto.GetMapConfigHistoryResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`select
+			map_configs.revision,
+			case when map_configs.map_config is null then '' else map_configs.map_config end as map_config,
+			case when map_configs.author_email is null then '' else map_configs.author_email end as author_email,
+			%s as created_at
+		from map_configs join reports on reports.id = map_configs.report_id
+		where map_configs.report_id=$1 and not reports.archived
+		order by map_configs.revision desc limit $2`,
+			s.dialect.Int(s.dialect.Epoch("map_configs.created_at")),
+		),
+		req.ReportId,
+		limit,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	defer rows.Close()
+	res := &proto.GetMapConfigHistoryResponse{
+		Revisions: make([]*proto.MapConfigRevision, 0),
+	}
+	for rows.Next() {
+		revision := &proto.MapConfigRevision{}
+		err := rows.Scan(&revision.Revision, &revision.MapConfig, &revision.AuthorEmail, &revision.CreatedAt)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		res.Revisions = append(res.Revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,149 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expectSaveMapConfig transaction returning revision
+func expectSaveMapConfig(mock sqlmock.Sqlmock, revision int64) *sqlmock.ExpectedQuery {
+	mock.ExpectBegin()
+	update := mock.ExpectQuery("update reports set map_config").
+		WillReturnRows(sqlmock.NewRows([]string{"map_config_revision"}).AddRow(revision))
+	mock.ExpectExec("insert into map_configs").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	return update
+}
+
+func TestParseMaxMapConfigSize(t *testing.T) {
+	for value, expected := range map[string]int{"": defaultMaxMapConfigSize, "1024": 1024} {
+		size, err := parseMaxMapConfigSize(value)
+		if err != nil || size != expected {
+			t.Errorf("%q: expected %d, got %d %v", value, expected, size, err)
+		}
+	}
+	for _, value := range []string{"0", "-1", "5MB"} {
+		if _, err := parseMaxMapConfigSize(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestNormalizeMapConfig(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.maxMapConfigSize = 128
+	normalized, err := s.normalizeMapConfig(`{"version":"v1","config":{"zoom":1.50,"bearing":0}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "{\n  \"config\": {\n    \"bearing\": 0,\n    \"zoom\": 1.50\n  },\n  \"version\": \"v1\"\n}"
+	if normalized != expected {
+		t.Errorf("expected sorted keys and numbers as written, got %s", normalized)
+	}
+	again, err := s.normalizeMapConfig(normalized)
+	if err != nil || again != normalized {
+		t.Errorf("expected normalization to be stable, got %s %v", again, err)
+	}
+	if normalized, err := s.normalizeMapConfig(""); err != nil || normalized != "" {
+		t.Errorf("expected empty map config kept, got %q %v", normalized, err)
+	}
+	for _, mapConfig := range []string{"{", "{} {}", `{"a":"` + strings.Repeat("a", 128) + `"}`} {
+		if _, err := s.normalizeMapConfig(mapConfig); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%.20s: expected InvalidArgument, got %v", mapConfig, err)
+		}
+	}
+}
+
+func TestUpdateMapConfig(t *testing.T) {
+	// admin role is resolved without database
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	ctx := testClaimsContext()
+
+	t.Run("saved as revision", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		expectSaveMapConfig(mock, 4).WithArgs("{\n  \"a\": 1\n}", testReportID, user.UnknownEmail)
+		res, err := s.UpdateMapConfig(ctx, &proto.UpdateMapConfigRequest{ReportId: testReportID, MapConfig: `{"a":1}`})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Revision != 4 {
+			t.Errorf("expected revision 4, got %d", res.Revision)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("update reports set map_config").WillReturnRows(sqlmock.NewRows([]string{"map_config_revision"}))
+		mock.ExpectRollback()
+		mock.ExpectQuery("select author_email from reports").WithArgs(testReportID).WillReturnRows(sqlmock.NewRows([]string{"author_email"}))
+		_, err := s.UpdateMapConfig(ctx, &proto.UpdateMapConfigRequest{ReportId: testReportID, MapConfig: "{}"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("other author", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery("update reports set map_config").WillReturnRows(sqlmock.NewRows([]string{"map_config_revision"}))
+		mock.ExpectRollback()
+		mock.ExpectQuery("select author_email from reports").WithArgs(testReportID).
+			WillReturnRows(sqlmock.NewRows([]string{"author_email"}).AddRow("author@example.com"))
+		_, err := s.UpdateMapConfig(ctx, &proto.UpdateMapConfigRequest{ReportId: testReportID, MapConfig: "{}"})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected PermissionDenied, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		s, _ := newTestServer(t)
+		s.maxMapConfigSize = 2
+		_, err := s.UpdateMapConfig(ctx, &proto.UpdateMapConfigRequest{ReportId: testReportID, MapConfig: `{"a":1}`})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "exceeds limit of 2 bytes") {
+			t.Errorf("expected size error, got %v", err)
+		}
+	})
+}
+
+func TestGetMapConfigHistory(t *testing.T) {
+	ctx := testClaimsContext()
+	for _, c := range []struct {
+		limit    int32
+		expected int32
+	}{{0, defaultHistoryLimit}, {500, maxHistoryLimit}, {3, 3}} {
+		s, mock := newTestServer(t)
+		mock.ExpectQuery("from map_configs").
+			WithArgs(testReportID, c.expected).
+			WillReturnRows(sqlmock.NewRows([]string{"revision", "map_config", "author_email", "created_at"}).
+				AddRow(2, "{}", "b@example.com", 1600000100).
+				AddRow(1, "", "a@example.com", 1600000000))
+		res, err := s.GetMapConfigHistory(ctx, &proto.GetMapConfigHistoryRequest{ReportId: testReportID, Limit: c.limit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Revisions) != 2 || res.Revisions[0].Revision != 2 || res.Revisions[1].AuthorEmail != "a@example.com" {
+			t.Errorf("unexpected revisions %v", res.Revisions)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("limit %d: %v", c.limit, err)
+		}
+	}
+}
@@ -0,0 +1,508 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storedParameter of reports.parameters, type is stored by name so enum numbers can change
+type storedParameter struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	ValuesQueryID string   `json:"valuesQueryId,omitempty"`
+	DefaultValue  string   `json:"defaultValue"`
+}
+
+func marshalParameters(parameters []*proto.ReportParameter) (string, error) {
+	if len(parameters) == 0 {
+		return "", nil
+	}
+	stored := make([]storedParameter, len(parameters))
+	for i, parameter := range parameters {
+		stored[i] = storedParameter{
+			Name:          parameter.Name,
+			Type:          parameter.Type.String(),
+			AllowedValues: parameter.AllowedValues,
+			ValuesQueryID: parameter.ValuesQueryId,
+			DefaultValue:  parameter.DefaultValue,
+		}
+	}
+	b, err := json.Marshal(stored)
+	return string(b), err
+}
+
+func unmarshalParameters(value string) ([]*proto.ReportParameter, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var stored []storedParameter
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		return nil, err
+	}
+	parameters := make([]*proto.ReportParameter, len(stored))
+	for i, parameter := range stored {
+		parameters[i] = &proto.ReportParameter{
+			Name:          parameter.Name,
+			Type:          proto.ReportParameter_Type(proto.ReportParameter_Type_value[parameter.Type]),
+			AllowedValues: parameter.AllowedValues,
+			ValuesQueryId: parameter.ValuesQueryID,
+			DefaultValue:  parameter.DefaultValue,
+		}
+	}
+	return parameters, nil
+}
+
+// marshalStringMap of parameter values or bindings, empty map is stored as empty string
+func marshalStringMap(values map[string]string) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(values)
+	return string(b), err
+}
+
+func unmarshalStringMap(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var values map[string]string
+	err := json.Unmarshal([]byte(value), &values)
+	return values, err
+}
+
+// findParameter of report by name, nil when report has no such parameter
+func findParameter(parameters []*proto.ReportParameter, name string) *proto.ReportParameter {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return parameter
+		}
+	}
+	return nil
+}
+
+// readParameterOptions of values query result, distinct values of first column in order of rows after header
+func readParameterOptions(r io.Reader) ([]string, bool, error) {
+	reader := csv.NewReader(r)
+	// values query can have more columns, only first one is read
+	reader.FieldsPerRecord = -1
+	if _, err := reader.Read(); err != nil {
+		if err == io.EOF {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	var values []string
+	seen := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return values, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if seen[record[0]] {
+			continue
+		}
+		if len(values) == job.MaxAllowedValues {
+			return values, true, nil
+		}
+		seen[record[0]] = true
+		values = append(values, record[0])
+	}
+}
+
+// parameterOptions of parameter; values query populates them from its current result
+func (s Server) parameterOptions(ctx context.Context, parameter *proto.ReportParameter) ([]string, bool, error) {
+	if parameter.ValuesQueryId == "" {
+		return parameter.AllowedValues, false, nil
+	}
+	var resultID string
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select case when job_result_id is null then '' else %s end from queries where id=$1 and deleted_at is null`,
+			s.dialect.Text("job_result_id"),
+		),
+		parameter.ValuesQueryId,
+	).Scan(&resultID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Err(err).Send()
+		return nil, false, internalError(err)
+	}
+	if resultID == "" {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "values query of parameter %s has no result, run query %s", parameter.Name, parameter.ValuesQueryId)
+	}
+	r, _, err := s.openCSVResult(ctx, resultID)
+	if errors.Is(err, errResultExpired) || err == errParquetCompare {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "values query of parameter %s has no CSV result, run query %s", parameter.Name, parameter.ValuesQueryId)
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, false, internalError(err)
+	}
+	defer r.Close()
+	values, truncated, err := readParameterOptions(r)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, false, internalError(err)
+	}
+	return values, truncated, nil
+}
+
+// withOptions of values queries set as allowed values of parameters, only parameters in names are resolved
+func (s Server) withOptions(ctx context.Context, parameters []*proto.ReportParameter, names map[string]bool) ([]*proto.ReportParameter, error) {
+	resolved := make([]*proto.ReportParameter, len(parameters))
+	for i, parameter := range parameters {
+		resolved[i] = parameter
+		if parameter.ValuesQueryId == "" || !names[parameter.Name] {
+			continue
+		}
+		values, _, err := s.parameterOptions(ctx, parameter)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = &proto.ReportParameter{
+			Name:          parameter.Name,
+			Type:          parameter.Type,
+			AllowedValues: values,
+			ValuesQueryId: parameter.ValuesQueryId,
+			DefaultValue:  parameter.DefaultValue,
+		}
+	}
+	return resolved, nil
+}
+
+// sessionParameterValues of user for report, nil when user set no values
+func (s Server) sessionParameterValues(ctx context.Context, reportID string, email string) (map[string]string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select parameter_values from report_parameter_values where report_id=$1 and user_email=$2`,
+		reportID,
+		email,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalStringMap(value)
+}
+
+// bindParameters of report to named parameters of query text of source; values of viewing session of user
+// override saved values of report
+func (s Server) bindParameters(ctx context.Context, source *queryJobSource, email string) error {
+	if len(source.parameterBindings) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(source.parameterValues))
+	for name, value := range source.parameterValues {
+		values[name] = value
+	}
+	session, err := s.sessionParameterValues(ctx, source.reportID, email)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	for name, value := range session {
+		values[name] = value
+	}
+	bound := make(map[string]bool, len(source.parameterBindings))
+	for _, name := range source.parameterBindings {
+		bound[name] = true
+	}
+	parameters, err := s.withOptions(ctx, source.parameters, bound)
+	if err != nil {
+		return err
+	}
+	source.options.Parameters, err = job.BindParameters(source.queryText, source.parameterBindings, parameters, values)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// UpdateReportParameters replaces parameters of report; values queries must be queries of report
+func (s Server) UpdateReportParameters(ctx context.Context, req *proto.UpdateReportParametersRequest) (*proto.UpdateReportParametersResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := job.ValidateParameters(req.Parameters); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, parameter := range req.Parameters {
+		if parameter.ValuesQueryId == "" {
+			continue
+		}
+		if _, err := uuid.Parse(parameter.ValuesQueryId); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid values query of parameter %s: %s", parameter.Name, err)
+		}
+		var count int
+		err := s.db.QueryRowContext(ctx,
+			`select count(*) from queries where id=$1 and report_id=$2 and deleted_at is null`,
+			parameter.ValuesQueryId,
+			req.ReportId,
+		).Scan(&count)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		if count == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "values query %s of parameter %s is not query of report", parameter.ValuesQueryId, parameter.Name)
+		}
+	}
+	parameters, err := marshalParameters(req.Parameters)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	result, err := s.db.ExecContext(ctx,
+		`update reports set parameters=$1, updated_at=CURRENT_TIMESTAMP where id=$2 and author_email=$3 and not archived`,
+		parameters,
+		req.ReportId,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
+	return &proto.UpdateReportParametersResponse{}, nil
+}
+
+// reportParameters of report readable by user, found is false when report doesn't exist or is archived
+func (s Server) reportParameters(ctx context.Context, reportID string) ([]*proto.ReportParameter, bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select case when parameters is null then '' else parameters end from reports where id=$1 and not archived`,
+		reportID,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	parameters, err := unmarshalParameters(value)
+	return parameters, true, err
+}
+
+// SetReportParameterValues of viewing session of user or saved values of report; values are validated against allowed values
+func (s Server) SetReportParameterValues(ctx context.Context, req *proto.SetReportParameterValuesRequest) (*proto.SetReportParameterValuesResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	role := proto.Role_ROLE_VIEWER
+	if req.Save {
+		// saved values change report for all users
+		role = proto.Role_ROLE_EDITOR
+	}
+	if err := s.requireRole(ctx, role); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	parameters, found, err := s.reportParameters(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if !found {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	names := make(map[string]bool, len(req.Values))
+	for name := range req.Values {
+		if findParameter(parameters, name) == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "report has no parameter %s", name)
+		}
+		names[name] = true
+	}
+	parameters, err = s.withOptions(ctx, parameters, names)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range req.Values {
+		if err := job.ValidateParameterValue(findParameter(parameters, name), value); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	values, err := marshalStringMap(req.Values)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if req.Save {
+		return s.saveParameterValues(ctx, req.ReportId, values, claims.Email)
+	}
+	if values == "" {
+		_, err = s.db.ExecContext(ctx,
+			`delete from report_parameter_values where report_id=$1 and user_email=$2`,
+			req.ReportId,
+			claims.Email,
+		)
+	} else {
+		_, err = s.db.ExecContext(ctx,
+			`insert into report_parameter_values (report_id, user_email, parameter_values) values ($1, $2, $3)
+			`+s.dialect.OnConflict([]string{"report_id", "user_email"}, "parameter_values = "+s.dialect.Excluded("parameter_values")+", updated_at = CURRENT_TIMESTAMP"),
+			req.ReportId,
+			claims.Email,
+			values,
+		)
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return &proto.SetReportParameterValuesResponse{}, nil
+}
+
+// saveParameterValues of report as its saved state, only author can save them
+func (s Server) saveParameterValues(ctx context.Context, reportID string, values string, email string) (*proto.SetReportParameterValuesResponse, error) {
+	result, err := s.db.ExecContext(ctx,
+		`update reports set parameter_values=$1, updated_at=CURRENT_TIMESTAMP where id=$2 and author_email=$3 and not archived`,
+		values,
+		reportID,
+		email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", reportID)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	s.reportStreams.Publish(report.Event{ReportID: reportID, Kind: report.ReportChanged})
+	return &proto.SetReportParameterValuesResponse{}, nil
+}
+
+// GetReportParameterOptions of parameter for dropdown, values query result populates them
+func (s Server) GetReportParameterOptions(ctx context.Context, req *proto.GetReportParameterOptionsRequest) (*proto.GetReportParameterOptionsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_VIEWER); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	parameters, found, err := s.reportParameters(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	parameter := findParameter(parameters, req.Name)
+	if !found || parameter == nil {
+		err := fmt.Errorf("Parameter %s of report %s not found", req.Name, req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	values, truncated, err := s.parameterOptions(ctx, parameter)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetReportParameterOptionsResponse{Values: values, Truncated: truncated}, nil
+}
+
+// UpdateQueryParameterBindings replaces bindings of named parameters of query to report parameters
+func (s Server) UpdateQueryParameterBindings(ctx context.Context, req *proto.UpdateQueryParameterBindingsRequest) (*proto.UpdateQueryParameterBindingsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.QueryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := job.ValidateParameterBindings(req.ParameterBindings); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var reportID, value string
+	err = s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select %s, case when reports.parameters is null then '' else reports.parameters end
+		from queries join reports on reports.id = queries.report_id
+		where queries.id=$1 and queries.deleted_at is null and reports.author_email=$2 and not reports.archived`,
+			s.dialect.Text("queries.report_id"),
+		),
+		req.QueryId,
+		claims.Email,
+	).Scan(&reportID, &value)
+	if err == sql.ErrNoRows {
+		return nil, s.queryNotFound(ctx, req.QueryId)
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	parameters, err := unmarshalParameters(value)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	for name, parameter := range req.ParameterBindings {
+		if findParameter(parameters, parameter) == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "@%s is bound to unknown report parameter %s", name, parameter)
+		}
+	}
+	bindings, err := marshalStringMap(req.ParameterBindings)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	_, err = s.db.ExecContext(ctx, `update queries set parameter_bindings=$1 where id=$2`, bindings, req.QueryId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	s.reportStreams.Publish(report.Event{ReportID: reportID, Kind: report.QueryChanged, QueryID: req.QueryId})
+	return &proto.UpdateQueryParameterBindingsResponse{}, nil
+}
@@ -0,0 +1,29 @@
+package dekart
+
+import (
+	"dekart/src/server/job"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadParameterOptions(t *testing.T) {
+	values, truncated, err := readParameterOptions(strings.NewReader("city,count\nBerlin,1\nParis,2\nBerlin,3\n,4\n"))
+	if err != nil || truncated || !reflect.DeepEqual(values, []string{"Berlin", "Paris", ""}) {
+		t.Errorf("expected distinct values of first column, got %q %v %v", values, truncated, err)
+	}
+	values, truncated, err = readParameterOptions(strings.NewReader(""))
+	if err != nil || truncated || len(values) != 0 {
+		t.Errorf("expected no values of empty result, got %q %v %v", values, truncated, err)
+	}
+	var b strings.Builder
+	b.WriteString("id\n")
+	for i := 0; i <= job.MaxAllowedValues; i++ {
+		fmt.Fprintf(&b, "%d\n", i)
+	}
+	values, truncated, err = readParameterOptions(strings.NewReader(b.String()))
+	if err != nil || !truncated || len(values) != job.MaxAllowedValues {
+		t.Errorf("expected truncated values, got %d %v %v", len(values), truncated, err)
+	}
+}
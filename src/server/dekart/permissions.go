@@ -0,0 +1,64 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+// CheckPermissions of service account on billing project, data project and result bucket; report is logged
+// and kept for errors of denied jobs, missing permissions don't stop startup as queries may not need all of them
+func (s Server) CheckPermissions(ctx context.Context) *job.PermissionReport {
+	checkCtx, cancel := context.WithTimeout(ctx, warmupTimeout)
+	defer cancel()
+	report := job.CheckPermissions(
+		checkCtx,
+		s.permissions,
+		s.jobs.BillingProject(""),
+		s.jobs.Projects().Data,
+		os.Getenv("DEKART_CLOUD_STORAGE_BUCKET"),
+	)
+	job.UsePermissionReport(report)
+	for _, c := range report.Checks {
+		logger := log.With().Str("resourceType", c.ResourceType).Str("resource", c.Resource).Str("permission", c.Permission).Logger()
+		switch {
+		case c.Error != "":
+			logger.Warn().Str("error", c.Error).Msg("Cannot test permission")
+		case !c.Granted:
+			logger.Warn().Str("role", c.Role).Msg("Service account is missing permission, grant " + c.Role)
+		default:
+			logger.Debug().Msg("Permission granted")
+		}
+	}
+	return report
+}
+
+// GetPermissionReport of service account on replica
+func (s Server) GetPermissionReport(ctx context.Context, req *proto.GetPermissionReportRequest) (*proto.GetPermissionReportResponse, error) {
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	report := job.LatestPermissionReport()
+	if req.Refresh {
+		report = s.CheckPermissions(ctx)
+	}
+	res := &proto.GetPermissionReportResponse{}
+	if report == nil {
+		return res, nil
+	}
+	res.CheckedAt = report.CheckedAt.Unix()
+	for _, c := range report.Checks {
+		res.Checks = append(res.Checks, &proto.PermissionCheck{
+			ResourceType: c.ResourceType,
+			Resource:     c.Resource,
+			Permission:   c.Permission,
+			Role:         c.Role,
+			Granted:      c.Granted,
+			Error:        c.Error,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePermissionTester grants permissions on every resource
+type fakePermissionTester struct {
+	granted []string
+	calls   int
+}
+
+func (f *fakePermissionTester) TestProjectPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	f.calls++
+	return f.granted, nil
+}
+
+func (f *fakePermissionTester) TestBucketPermissions(ctx context.Context, bucket string, permissions []string) ([]string, error) {
+	f.calls++
+	return f.granted, nil
+}
+
+func TestGetPermissionReport(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	os.Setenv("DEKART_CLOUD_STORAGE_BUCKET", "results")
+	defer os.Unsetenv("DEKART_CLOUD_STORAGE_BUCKET")
+	defer job.UsePermissionReport(job.LatestPermissionReport())
+	job.UsePermissionReport(nil)
+	s, mock := newTestServer(t)
+	tester := &fakePermissionTester{granted: []string{"bigquery.jobs.create", "storage.objects.create"}}
+	s.permissions = tester
+	ctx := testClaimsContext()
+
+	res, err := s.GetPermissionReport(ctx, &proto.GetPermissionReportRequest{})
+	if err != nil || res.CheckedAt != 0 || len(res.Checks) != 0 {
+		t.Errorf("expected empty report before check, got %v %v", res, err)
+	}
+	s.CheckPermissions(context.Background())
+	res, err = s.GetPermissionReport(ctx, &proto.GetPermissionReportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CheckedAt == 0 || len(res.Checks) != 6 || tester.calls != 2 {
+		t.Errorf("unexpected report %v, %d calls", res, tester.calls)
+	}
+	missing := map[string]string{}
+	for _, c := range res.Checks {
+		if !c.Granted {
+			missing[c.Permission] = c.Role
+		}
+	}
+	if len(missing) != 4 || missing["storage.objects.delete"] != "roles/storage.objectAdmin" || missing["bigquery.tables.getData"] != "roles/bigquery.dataViewer" {
+		t.Errorf("unexpected missing permissions %v", missing)
+	}
+	if _, err := s.GetPermissionReport(ctx, &proto.GetPermissionReportRequest{Refresh: true}); err != nil || tester.calls != 4 {
+		t.Errorf("expected permissions tested again, got %d calls %v", tester.calls, err)
+	}
+
+	os.Unsetenv("DEKART_ADMIN_EMAILS")
+	mock.ExpectQuery("from instance_roles").WillReturnRows(sqlmock.NewRows([]string{"email_pattern", "role"}))
+	_, err = s.GetPermissionReport(ctx, &proto.GetPermissionReportRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
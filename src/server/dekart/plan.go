@@ -0,0 +1,83 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// protoPlan of job for client
+func protoPlan(queryID string, plan *job.Plan) *proto.GetJobPlanResponse {
+	res := &proto.GetJobPlanResponse{
+		QueryId:          queryID,
+		TotalStages:      int32(plan.TotalStages),
+		ReferencedTables: plan.ReferencedTables,
+		Note:             plan.Note,
+	}
+	for _, s := range plan.Stages {
+		res.Stages = append(res.Stages, &proto.JobPlanStage{
+			Id:                        s.ID,
+			Name:                      s.Name,
+			Status:                    s.Status,
+			InputStages:               s.InputStages,
+			RecordsRead:               s.RecordsRead,
+			RecordsWritten:            s.RecordsWritten,
+			WaitRatioAvg:              s.WaitRatioAvg,
+			WaitRatioMax:              s.WaitRatioMax,
+			ReadRatioAvg:              s.ReadRatioAvg,
+			ReadRatioMax:              s.ReadRatioMax,
+			ComputeRatioAvg:           s.ComputeRatioAvg,
+			ComputeRatioMax:           s.ComputeRatioMax,
+			WriteRatioAvg:             s.WriteRatioAvg,
+			WriteRatioMax:             s.WriteRatioMax,
+			ShuffleOutputBytes:        s.ShuffleOutputBytes,
+			ShuffleOutputBytesSpilled: s.ShuffleOutputBytesSpilled,
+			DurationMs:                s.DurationMs,
+		})
+	}
+	return res
+}
+
+// GetJobPlan of last job of query, stored when job finished waiting for BigQuery
+func (s Server) GetJobPlan(ctx context.Context, req *proto.GetJobPlanRequest) (*proto.GetJobPlanResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	_, err := uuid.Parse(req.QueryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	var value sql.NullString
+	err = s.db.QueryRowContext(ctx,
+		`select job_plan from queries
+		where id=$1 and report_id in (select id from reports where not archived)`,
+		req.QueryId,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		err := fmt.Errorf("Query not found id:%s", req.QueryId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	plan, err := job.UnmarshalPlan(value.String)
+	if err != nil {
+		log.Err(err).Str("queryID", req.QueryId).Msg("Cannot read job plan")
+		return nil, internalError(err)
+	}
+	if plan == nil {
+		return nil, status.Errorf(codes.NotFound, "last job of query %s has no plan", req.QueryId)
+	}
+	return protoPlan(req.QueryId, plan), nil
+}
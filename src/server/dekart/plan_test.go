@@ -0,0 +1,38 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetJobPlan(t *testing.T) {
+	s, mock := newTestServer(t)
+	mock.ExpectQuery("select job_plan from queries").WithArgs(testQueryID).
+		WillReturnRows(sqlmock.NewRows([]string{"job_plan"}).
+			AddRow(`{"stages":[{"id":1,"name":"S01: Output","inputStages":[0],"recordsRead":10,"recordsWritten":10,"waitRatioAvg":0.5,"durationMs":20}],"totalStages":60,"referencedTables":["p.d.t"],"note":"truncated"}`))
+	res, err := s.GetJobPlan(testClaimsContext(), &proto.GetJobPlanRequest{QueryId: testQueryID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalStages != 60 || res.Note != "truncated" || len(res.ReferencedTables) != 1 || len(res.Stages) != 1 {
+		t.Fatalf("unexpected plan %v", res)
+	}
+	if stage := res.Stages[0]; stage.Name != "S01: Output" || stage.WaitRatioAvg != 0.5 || stage.DurationMs != 20 || len(stage.InputStages) != 1 {
+		t.Errorf("unexpected stage %v", stage)
+	}
+	mock.ExpectQuery("select job_plan from queries").WithArgs(testQueryID).
+		WillReturnRows(sqlmock.NewRows([]string{"job_plan"}).AddRow(nil))
+	if _, err := s.GetJobPlan(testClaimsContext(), &proto.GetJobPlanRequest{QueryId: testQueryID}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound without plan, got %v", err)
+	}
+	if _, err := s.GetJobPlan(testClaimsContext(), &proto.GetJobPlanRequest{QueryId: "query"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
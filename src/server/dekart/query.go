@@ -2,12 +2,15 @@ package dekart
 
 import (
 	"context"
+	"database/sql"
 	"dekart/src/proto"
 	"dekart/src/server/job"
+	"dekart/src/server/report"
 	"dekart/src/server/user"
 	"fmt"
 	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
@@ -20,59 +23,84 @@ func (s Server) CreateQuery(ctx context.Context, req *proto.CreateQueryRequest)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	if req.Query == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "req.Query == nil")
 	}
+	_, err := uuid.Parse(req.Query.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	title, err := validateTitle(req.Query.Title)
+	if err != nil {
+		return nil, err
+	}
+	queryText, err := s.normalizeQueryText(req.Query.QueryText)
+	if err != nil {
+		return nil, err
+	}
 
 	id := newUUID()
-	result, err := s.db.ExecContext(ctx,
-		`insert into queries (id, report_id, query_text)
+	err = s.scanReturning(ctx, s.db,
+		`insert into queries (id, report_id, query_text, title, use_legacy_sql)
 		select
 			$1 as id,
 			id as report_id,
-			$3 as query_text
+			$3 as query_text,
+			case
+				when $5 = ''
+				then `+s.dialect.Concat("'Query '", "(select count(*) + 1 from queries where report_id=$2)")+`
+				else $5
+			end as title,
+			$6 as use_legacy_sql
 		from reports
-		where id=$2 and not archived and author_email=$4 limit 1
-		`,
-		id,
-		req.Query.ReportId,
-		req.Query.QueryText,
-		claims.Email,
+		where id=$2 and not archived and author_email=$4 limit 1`,
+		[]interface{}{id, req.Query.ReportId, queryText, claims.Email, title, req.Query.UseLegacySql},
+		"title",
+		`select title from queries where id=$1`,
+		[]interface{}{id},
+		&title,
 	)
-	if err != nil {
-		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	affectedRows, err := result.RowsAffected()
-	if err != nil {
-		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
-	if affectedRows == 0 {
+	if err == sql.ErrNoRows {
 		err := fmt.Errorf("Report=%s, author_email=%s not found", req.Query.ReportId, claims.Email)
 		log.Warn().Err(err).Send()
 		return nil, status.Errorf(codes.NotFound, err.Error())
 	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
 
-	s.reportStreams.Ping(req.Query.ReportId)
+	s.reportStreams.Publish(report.Event{ReportID: req.Query.ReportId, Kind: report.QueryChanged, QueryID: id})
 
 	res := &proto.CreateQueryResponse{
 		Query: &proto.Query{
-			Id:        id,
-			ReportId:  req.Query.ReportId,
-			QueryText: req.Query.QueryText,
+			Id:           id,
+			ReportId:     req.Query.ReportId,
+			QueryText:    queryText,
+			Title:        title,
+			UseLegacySql: req.Query.UseLegacySql,
 		},
 	}
 
 	return res, nil
 }
 
+// normalizeQueryText of request or of query with expanded datasets and variables, InvalidArgument when it's rejected
+func (s Server) normalizeQueryText(queryText string) (string, error) {
+	normalized, err := job.NormalizeQueryText(queryText, s.jobs.MaxQueryTextSize())
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return normalized, nil
+}
+
 func (s Server) getReportID(ctx context.Context, queryID string, email string) (*string, error) {
 	queryRows, err := s.db.QueryContext(ctx,
 		`select report_id from queries
-		where id=$1 and report_id in (select report_id from reports where author_email=$2)
+		where id=$1 and deleted_at is null and report_id in (select id from reports where author_email=$2)
 		limit 1`,
 		queryID,
 		email,
@@ -94,40 +122,155 @@ func (s Server) getReportID(ctx context.Context, queryID string, email string) (
 	return &reportID, nil
 }
 
+// getReadableReportID of query in not archived report, same visibility as getReport
+func (s Server) getReadableReportID(ctx context.Context, queryID string) (*string, error) {
+	queryRows, err := s.db.QueryContext(ctx,
+		`select report_id from queries
+		where id=$1 and deleted_at is null and report_id in (select id from reports where not archived)
+		limit 1`,
+		queryID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer queryRows.Close()
+	var reportID string
+	for queryRows.Next() {
+		err := queryRows.Scan(&reportID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if reportID == "" {
+		return nil, nil
+	}
+	return &reportID, nil
+}
+
+// GetQuery by id with job status
+func (s Server) GetQuery(ctx context.Context, req *proto.GetQueryRequest) (*proto.GetQueryResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	_, err := uuid.Parse(req.QueryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	reportID, err := s.getReadableReportID(ctx, req.QueryId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if reportID == nil {
+		return nil, s.queryNotFound(ctx, req.QueryId)
+	}
+	queries, err := s.getQueries(ctx, *reportID)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	for _, query := range queries {
+		if query.Id == req.QueryId {
+			return &proto.GetQueryResponse{Query: query}, nil
+		}
+	}
+	err = fmt.Errorf("Query not found id:%s", req.QueryId)
+	log.Warn().Err(err).Send()
+	return nil, status.Error(codes.NotFound, err.Error())
+}
+
+// GetExecutedQuery returns query text run by last job of query with options applied to it, readable as query itself
+func (s Server) GetExecutedQuery(ctx context.Context, req *proto.GetExecutedQueryRequest) (*proto.GetExecutedQueryResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	_, err := uuid.Parse(req.QueryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	res := &proto.GetExecutedQueryResponse{QueryId: req.QueryId}
+	err = s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select
+			case when executed_query_text is null then '' else executed_query_text end,
+			case when executed_default_dataset is null then '' else executed_default_dataset end,
+			sample_rate,
+			coalesce(exploration_limit, 0),
+			case when reused_from_query_id is null then '' else %s end,
+			coalesce(executed_use_legacy_sql, false)
+		from queries
+		where id=$1 and deleted_at is null and report_id in (select id from reports where not archived)`,
+			s.dialect.Text("reused_from_query_id"),
+		),
+		req.QueryId,
+	).Scan(
+		&res.ExecutedQueryText,
+		&res.DefaultDataset,
+		&res.SampleRate,
+		&res.ExplorationLimit,
+		&res.ReusedFromQueryId,
+		&res.UseLegacySql,
+	)
+	if err == sql.ErrNoRows {
+		err := fmt.Errorf("Query not found id:%s", req.QueryId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if res.ExecutedQueryText != "" {
+		res.ExecutedQueryHash = job.QueryHash(res.ExecutedQueryText)
+	}
+	return res, nil
+}
+
 // UpdateQuery by id implementation
 func (s Server) UpdateQuery(ctx context.Context, req *proto.UpdateQueryRequest) (*proto.UpdateQueryResponse, error) {
 	claims := user.GetClaims(ctx)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	if req.Query == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "req.Query == nil")
 	}
+	_, err := uuid.Parse(req.Query.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	queryText, err := s.normalizeQueryText(req.Query.QueryText)
+	if err != nil {
+		return nil, err
+	}
 
 	reportID, err := s.getReportID(ctx, req.Query.Id, claims.Email)
 
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 
 	if reportID == nil {
-		err := fmt.Errorf("Query not found id:%s", req.Query.Id)
-		log.Warn().Err(err).Send()
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, s.queryNotFound(ctx, req.Query.Id)
 	}
 
 	_, err = s.db.ExecContext(ctx,
-		`update queries set query_text=$1 where id=$2`,
-		req.Query.QueryText,
+		`update queries set query_text=$1, use_legacy_sql=$3 where id=$2`,
+		queryText,
 		req.Query.Id,
+		req.Query.UseLegacySql,
 	)
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 
-	s.reportStreams.Ping(*reportID)
+	s.reportStreams.Publish(report.Event{ReportID: *reportID, Kind: report.QueryChanged, QueryID: req.Query.Id})
 
 	res := &proto.UpdateQueryResponse{
 		Query: &proto.Query{
@@ -138,106 +281,742 @@ func (s Server) UpdateQuery(ctx context.Context, req *proto.UpdateQueryRequest)
 	return res, nil
 }
 
-func (s Server) updateJobStatus(job *job.Job) {
+// updateJobStatus stores and publishes job statuses until job is finished; transitions are published
+// immediately, progress while reading result at most once per status interval
+func (s Server) updateJobStatus(j *job.Job) {
+	throttle := statusThrottle{interval: s.statusInterval}
+	// flush fires when pending progress is due, nil without pending progress
+	var flush <-chan time.Time
 	for {
 		select {
-		case status := <-job.Status:
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			var err error
-			if status == int32(proto.Query_JOB_STATUS_RUNNING) {
-				_, err = s.db.ExecContext(
-					ctx,
-					`update queries set
-						job_status = $1,
-						job_error = $3,
-						job_result_id = $4,
-						job_started = CURRENT_TIMESTAMP,
-						total_rows = 0,
-						bytes_processed = 0,
-						result_size = 0
-					where id  = $2`,
-					status,
-					job.QueryID,
-					job.Err(),
-					job.GetResultID(),
-				)
-
-			} else {
-				_, err = s.db.ExecContext(
-					ctx,
-					`update queries set
-						job_status = $1,
-						job_error = $3,
-						job_result_id = $4,
-						total_rows = $5,
-						bytes_processed = $6,
-						result_size = $7
-					where id  = $2`,
-					status,
-					job.QueryID,
-					job.Err(),
-					job.GetResultID(),
-					job.GetTotalRows(),
-					job.GetProcessedBytes(),
-					job.GetResultSize(),
-				)
+		case status := <-j.Status:
+			throttle.transition(time.Now())
+			flush = nil
+			s.storeJobStatus(j, status)
+		case <-j.Progress:
+			if throttle.progress(time.Now()) {
+				s.storeJobProgress(j)
+			} else if flush == nil {
+				due, _ := throttle.due()
+				flush = time.After(time.Until(due))
 			}
-			cancel()
-			if err != nil {
-				log.Fatal().Err(err).Send()
+		case <-flush:
+			flush = nil
+			if throttle.flush(time.Now()) {
+				s.storeJobProgress(j)
 			}
-			s.reportStreams.Ping(job.ReportID)
-		case <-job.Ctx.Done():
+		case <-j.Ctx.Done():
 			return
 		}
 	}
 }
 
-// RunQuery job against database
-func (s Server) RunQuery(ctx context.Context, req *proto.RunQueryRequest) (*proto.RunQueryResponse, error) {
-	claims := user.GetClaims(ctx)
-	if claims == nil {
-		return nil, Unauthenticated
+// watchJobStatus of job until it's finished, Shutdown waits for it
+func (s Server) watchJobStatus(j *job.Job) {
+	s.statusWriters.Add(1)
+	go func() {
+		defer s.statusWriters.Done()
+		s.updateJobStatus(j)
+	}()
+}
+
+// storeJobProgress of job reading result; progress received after job is finished is already stored with final status
+func (s Server) storeJobProgress(j *job.Job) {
+	if snapshot := j.GetStatus(); snapshot.State == job.StateReading {
+		s.storeJobStatus(j, snapshot.Status)
+	}
+}
+
+// jobWarnings stored in query record
+func jobWarnings(warnings []job.Warning) (string, error) {
+	return job.MarshalWarnings(warnings)
+}
+
+// jobPlan stored in query record, null without plan so plan_available is read without parsing plan
+func jobPlan(plan *job.Plan) (*string, error) {
+	value, err := job.MarshalPlan(plan)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// jobErrorExplanation stored in query record, null for unknown error
+func jobErrorExplanation(explanation *job.ErrorExplanation) (*string, error) {
+	value, err := job.MarshalErrorExplanation(explanation)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// protoErrorExplanation of query record
+func protoErrorExplanation(explanation *job.ErrorExplanation) *proto.ErrorExplanation {
+	if explanation == nil {
+		return nil
 	}
+	return &proto.ErrorExplanation{Id: explanation.ID, Summary: explanation.Summary, Fix: explanation.Fix, DocsUrl: explanation.DocsURL}
+}
+
+// jobFormatResults stored in query record, null without results in additional formats
+func jobFormatResults(results []job.FormatResult) (*string, error) {
+	value, err := job.MarshalFormatResults(results)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// storedColumnTypes in result record
+func storedColumnTypes(types []string) (string, error) {
+	return job.MarshalColumnTypes(types)
+}
+
+// storedColumnNames in result record
+func storedColumnNames(names []job.ColumnName) (string, error) {
+	return job.MarshalColumnNames(names)
+}
+
+// storedDatabaseTypes in result record
+func storedDatabaseTypes(types []string) (string, error) {
+	return job.MarshalDatabaseTypes(types)
+}
+
+// protoWarnings of job for client
+func protoWarnings(warnings []job.Warning) []*proto.JobWarning {
+	var res []*proto.JobWarning
+	for _, w := range warnings {
+		res = append(res, &proto.JobWarning{Code: w.Code, Message: w.Message})
+	}
+	return res
+}
+
+func protoFormatResults(results []job.FormatResult) []*proto.FormatResult {
+	var res []*proto.FormatResult
+	for _, r := range results {
+		res = append(res, &proto.FormatResult{Format: r.Format, ResultId: r.ResultID})
+	}
+	return res
+}
+
+// storeJobStatus in query record and publish it to report subscribers
+func (s Server) storeJobStatus(job *job.Job, status int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// status is stored from one snapshot, so stream subscribers never read result without matching status
+	snapshot := job.GetStatus()
+	if snapshot.CancelReason == proto.CancelReason_CANCEL_REASON_SUPERSEDED {
+		// query record has status of job which superseded this one
+		cancel()
+		return
+	}
+	warnings, err := jobWarnings(snapshot.Warnings)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	plan, err := jobPlan(snapshot.Plan)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	explanation, err := jobErrorExplanation(snapshot.ErrorExplanation)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	formatResults, err := jobFormatResults(snapshot.FormatResults)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	exec := s.db.ExecContext
+	var tx *sql.Tx
+	if status == int32(proto.Query_JOB_STATUS_DONE) && snapshot.ResultID != nil {
+		// result, its lineage and done status are stored atomically
+		tx, err = s.db.BeginTx(ctx, &sql.TxOptions{})
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+		exec = tx.ExecContext
+	}
+	if snapshot.ResultID != nil && snapshot.ResultBucket != "" {
+		// bucket is recorded before done status is stored, so result is never read from primary bucket
+		_, err = exec(ctx, `update results set bucket=$1 where id=$2`, snapshot.ResultBucket, *snapshot.ResultID)
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+	}
+	if snapshot.ResultID != nil && len(snapshot.ColumnTypes) > 0 {
+		// column types let downloads localize numeric and date columns of CSV result
+		types, err := storedColumnTypes(snapshot.ColumnTypes)
+		if err == nil {
+			_, err = exec(ctx, `update results set column_types=$1 where id=$2`, types, *snapshot.ResultID)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+	}
+	if snapshot.ResultID != nil && len(snapshot.ColumnNames) > 0 {
+		// original names of renamed header are shown as labels
+		names, err := storedColumnNames(snapshot.ColumnNames)
+		if err == nil {
+			_, err = exec(ctx, `update results set column_names=$1 where id=$2`, names, *snapshot.ResultID)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+	}
+	if snapshot.ResultID != nil && len(snapshot.DatabaseTypes) > 0 {
+		// types of SQL connection are mapped to BigQuery types, types reported by driver are kept with them
+		types, err := storedDatabaseTypes(snapshot.DatabaseTypes)
+		if err == nil {
+			_, err = exec(ctx, `update results set database_types=$1 where id=$2`, types, *snapshot.ResultID)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+	}
+	if snapshot.ResultID != nil && snapshot.ResultMeta != nil {
+		// meta is stored with done status, clients reading result_meta never see result without it
+		meta, err := storedResultMeta(snapshot.ResultMeta)
+		if err == nil {
+			_, err = exec(ctx, `update results set meta=$1 where id=$2`, meta, *snapshot.ResultID)
+		}
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+	}
+	if status == int32(proto.Query_JOB_STATUS_RUNNING) {
+		_, err = s.db.ExecContext(
+			ctx,
+			`update queries set
+				job_status = $1,
+				job_error = $3,
+				job_result_id = $4,
+				job_started = CURRENT_TIMESTAMP,
+				total_rows = 0,
+				bytes_processed = 0,
+				result_size = 0,
+				rows_written = 0,
+				sample_rate = $5,
+				h3_result_id = null,
+				format_results = null,
+				executed_query_text = $6,
+				result_table = null,
+				reused_from_query_id = null,
+				result_format = $7,
+				exploration_limit = $8,
+				executed_default_dataset = $9,
+				job_warnings = $10,
+				batch_id = $11,
+				skipped_dependency_id = null,
+				job_plan = null,
+				executed_use_legacy_sql = $12,
+				cancel_reason = 0,
+				job_reservation = null,
+				preview_result_id = null,
+				preview_rows = 0,
+				job_error_explanation = null
+			where id  = $2`,
+			status,
+			job.QueryID,
+			snapshot.Err,
+			snapshot.ResultID,
+			snapshot.SampleRate,
+			snapshot.QueryText,
+			int32(snapshot.ResultFormat),
+			snapshot.ExplorationLimit,
+			snapshot.DefaultDataset,
+			warnings,
+			nullID(job.BatchID),
+			snapshot.UseLegacySQL,
+		)
+
+	} else {
+		_, err = exec(
+			ctx,
+			`update queries set
+				job_status = $1,
+				job_error = $3,
+				job_result_id = $4,
+				total_rows = $5,
+				bytes_processed = $6,
+				result_size = $7,
+				rows_written = $8,
+				h3_result_id = $9,
+				result_table = $10,
+				job_warnings = $11,
+				job_plan = $12,
+				format_results = $13,
+				cancel_reason = $14,
+				job_reservation = $15,
+				preview_result_id = $16,
+				preview_rows = $17,
+				job_error_explanation = $18
+			where id  = $2`,
+			status,
+			job.QueryID,
+			snapshot.Err,
+			snapshot.ResultID,
+			snapshot.TotalRows,
+			snapshot.ProcessedBytes,
+			snapshot.ResultSize,
+			snapshot.RowsWritten,
+			snapshot.H3ResultID,
+			snapshot.ResultTable,
+			warnings,
+			plan,
+			formatResults,
+			int32(snapshot.CancelReason),
+			snapshot.Reservation,
+			nullID(snapshot.PreviewResultID),
+			snapshot.PreviewRows,
+			explanation,
+		)
+	}
+	if err == nil && tx != nil {
+		err = s.storeResultLineage(ctx, tx, job, snapshot, time.Now())
+		if err == nil {
+			err = tx.Commit()
+		} else {
+			tx.Rollback()
+		}
+	}
+	cancel()
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	s.reportStreams.Publish(report.Event{
+		ReportID:  job.ReportID,
+		Kind:      report.JobStatusChanged,
+		QueryID:   job.QueryID,
+		JobStatus: status,
+	})
+	if s.exactCountMaxBytes > 0 && snapshot.ResultID != nil && job.Truncated() && job.SQLConnection() == "" {
+		// total rows of sampled or limited result are not rows of query, count query runs in BigQuery
+		go s.storeExactCount(job, *snapshot.ResultID)
+	}
+}
+
+// queryJobSource is query text with report settings needed to run it
+type queryJobSource struct {
+	queryText string
+	reportID  string
+	options   job.RunOptions
+	// variables of report substituted into query text before run
+	variables []*proto.ReportVariable
+	// h3 aggregation requested for run, optional
+	h3 *proto.H3Aggregation
+	// destination table receiving result instead of result file, optional
+	destination *proto.DestinationTable
+	// idempotencyKey of run request, optional
+	idempotencyKey string
+	// sourceQueryID of forked query which was never run, its result can be reused
+	sourceQueryID string
+	// batchID of RunAllQueries, empty for single run
+	batchID string
+	// resultSettings of report, defaults of run request
+	resultSettings *proto.ResultSettings
+	// parameters of report with saved values, bound to named parameters of query text by parameterBindings
+	parameters        []*proto.ReportParameter
+	parameterValues   map[string]string
+	parameterBindings map[string]string
+	// additionalFormats result is written in besides format of run, optional
+	additionalFormats []proto.ResultFormat
+}
+
+// serialization of result as stored in result record, empty for default serialization
+func (source queryJobSource) serialization() string {
+	// stored options are plain strings and numbers, they are always marshaled
+	value, _ := job.MarshalSerialization(source.options.Serialization)
+	return value
+}
+
+// duplicateRunError when run request with same idempotency key was already run
+type duplicateRunError struct {
+	jobID string
+}
+
+func (e *duplicateRunError) Error() string {
+	return fmt.Sprintf("query is already run by job %s", e.jobID)
+}
+
+func (s Server) getQuerySource(ctx context.Context, queryID string, email string) (queryJobSource, error) {
 	queriesRows, err := s.db.QueryContext(ctx,
-		`select
-			query_text,
-			report_id
-		from queries where id=$1 and report_id in (select report_id from reports where author_email=$2) limit 1`,
-		req.QueryId,
-		claims.Email,
+		fmt.Sprintf(`select
+			queries.query_text,
+			queries.report_id,
+			case when reports.billing_project is null then '' else reports.billing_project end as billing_project,
+			case when reports.default_dataset is null then '' else reports.default_dataset end as default_dataset,
+			case when reports.variables is null then '' else reports.variables end as variables,
+			case when queries.source_query_id is null or queries.job_status <> 0 then '' else %s end as source_query_id,
+			coalesce(reports.exploration_limit, 0) as exploration_limit,
+			coalesce(queries.use_legacy_sql, false) as use_legacy_sql,
+			coalesce(reports.result_format, 0) as result_format,
+			coalesce(reports.result_compression, 0) as result_compression,
+			case when reports.result_delimiter is null then '' else reports.result_delimiter end as result_delimiter,
+			case when reports.parameters is null then '' else reports.parameters end as parameters,
+			case when reports.parameter_values is null then '' else reports.parameter_values end as parameter_values,
+			case when queries.parameter_bindings is null then '' else queries.parameter_bindings end as parameter_bindings
+		from queries join reports on reports.id = queries.report_id
+		where queries.id=$1 and queries.deleted_at is null and reports.author_email=$2 limit 1`,
+			s.dialect.Text("queries.source_query_id"),
+		),
+		queryID,
+		email,
 	)
+	var source queryJobSource
 	if err != nil {
-		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return source, err
 	}
 	defer queriesRows.Close()
-	var queryText string
-	var reportID string
+	var variables, parameters, parameterValues, parameterBindings string
+	settings := &proto.ResultSettings{}
 	for queriesRows.Next() {
-		err := queriesRows.Scan(&queryText, &reportID)
+		err := queriesRows.Scan(
+			&source.queryText,
+			&source.reportID,
+			&source.options.BillingProject,
+			&source.options.DefaultDataset,
+			&variables,
+			&source.sourceQueryID,
+			&source.options.ExplorationLimit,
+			&source.options.UseLegacySQL,
+			&settings.ResultFormat,
+			&settings.ResultCompression,
+			&settings.Delimiter,
+			&parameters,
+			&parameterValues,
+			&parameterBindings,
+		)
 		if err != nil {
-			log.Err(err).Send()
-			return nil, status.Error(codes.Internal, err.Error())
+			return source, err
 		}
 	}
+	source.resultSettings = settings
+	if source.variables, err = unmarshalVariables(variables); err != nil {
+		return source, err
+	}
+	if source.parameters, err = unmarshalParameters(parameters); err != nil {
+		return source, err
+	}
+	if source.parameterValues, err = unmarshalStringMap(parameterValues); err != nil {
+		return source, err
+	}
+	source.parameterBindings, err = unmarshalStringMap(parameterBindings)
+	return source, err
+}
 
-	if reportID == "" {
-		err := fmt.Errorf("Query not found id:%s", req.QueryId)
-		log.Warn().Err(err).Send()
-		return nil, status.Error(codes.NotFound, err.Error())
+// runQueryJob creates job for the query and starts it; status updates are written to the query record
+func (s Server) runQueryJob(ctx context.Context, queryID string, source queryJobSource) (*job.Job, error) {
+	// jobs are created by handlers of runs only, checked again so no run path misses it
+	if err := s.requireWritable(); err != nil {
+		return nil, err
+	}
+	if source.destination == nil && s.bucket != nil {
+		// bad bucket would fail upload only after query ran and was billed
+		if err := s.buckets.check(ctx, s.bucket); err != nil {
+			return nil, err
+		}
+	}
+	job := s.jobs.New(ctx, source.reportID, queryID)
+	job.UserEmail = user.GetClaims(ctx).Email
+	job.BatchID = source.batchID
+	if source.idempotencyKey != "" {
+		if holder := s.jobs.ClaimKey(ctx, queryID, source.idempotencyKey, job.ID); holder != job.ID {
+			job.Abort()
+			return nil, &duplicateRunError{jobID: holder}
+		}
+	}
+	// key of job which is not started is released, so retried request runs query
+	release := func() {
+		if source.idempotencyKey != "" {
+			s.jobs.ReleaseKey(ctx, queryID, source.idempotencyKey, job.ID)
+		}
+	}
+	// duplicate of running job is answered when budget is exhausted, new job is not created
+	if err := s.checkQuota(ctx); err != nil {
+		job.Abort()
+		release()
+		return nil, err
+	}
+	options := source.options
+	var obj *storage.ObjectHandle
+	if source.destination != nil {
+		// result is written to table by BigQuery, no result file is stored
+		options = options.WithDestination(source.destination)
+	} else {
+		var err error
+		obj, err = s.createResultObject(ctx, source.reportID, queryID, job.ID, resultExtension(options.ResultFormat))
+		if err == nil {
+			err = s.storeResultEncoding(ctx, job.ID, options)
+		}
+		if err != nil {
+			job.Abort()
+			release()
+			return nil, err
+		}
+	}
+	if obj != nil && s.fallbackBucket != nil {
+		job.UseFallback(s.fallbackBucket.Object(obj.ObjectName()))
+	}
+	if source.h3 != nil {
+		h3ResultID := newUUID()
+		// aggregated cells are always CSV
+		h3Obj, err := s.createResultObject(ctx, source.reportID, queryID, h3ResultID, ".csv")
+		if err != nil {
+			job.Abort()
+			release()
+			return nil, err
+		}
+		job.AggregateH3(source.h3, h3Obj, h3ResultID)
+	}
+	for _, format := range source.additionalFormats {
+		formatResultID := newUUID()
+		formatOptions := options
+		// additional CSV is written with serialization of run, compression applies to result in format of run
+		formatOptions.ResultFormat = format
+		formatOptions.Compression = proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+		formatObj, err := s.createResultObject(ctx, source.reportID, queryID, formatResultID, resultExtension(format))
+		if err == nil && format != proto.ResultFormat_RESULT_FORMAT_PARQUET {
+			err = s.storeResultEncoding(ctx, formatResultID, formatOptions)
+		}
+		if err != nil {
+			job.Abort()
+			release()
+			return nil, err
+		}
+		job.WriteFormat(format, formatObj, formatResultID)
+	}
+	if obj != nil && s.jobs.PreviewRows() > 0 {
+		previewResultID := newUUID()
+		// preview is CSV with serialization of run, uncompressed so it's rewritten cheaply
+		previewOptions := options
+		previewOptions.Compression = proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+		previewObj, err := s.createResultObject(ctx, source.reportID, queryID, previewResultID, ".csv")
+		if err == nil {
+			err = s.storeResultEncoding(ctx, previewResultID, previewOptions)
+		}
+		if err != nil {
+			job.Abort()
+			release()
+			return nil, err
+		}
+		job.WritePreview(previewObj, previewResultID)
+	}
+	s.watchJobStatus(job)
+	if err := job.Run(source.queryText, options, obj); err != nil {
+		release()
+		return nil, err
+	}
+	// jobs run before are superseded only when this one is started, so query record always has running job;
+	// jobs of raw SQL have no query and don't supersede each other
+	if queryID == "" {
+		return job, nil
+	}
+	if superseded := s.jobs.Supersede(queryID, job.ID); superseded > 0 {
+		log.Info().Str("queryID", queryID).Int("superseded", superseded).Msg("Jobs of query superseded")
+	}
+	return job, nil
+}
+
+// validateDestination of run request; result in table can't be aggregated to h3 cells, which are read from result file
+func validateDestination(destination *proto.DestinationTable, h3 *proto.H3Aggregation) error {
+	if destination == nil {
+		return nil
+	}
+	if h3 != nil {
+		return status.Error(codes.InvalidArgument, "destination_table can't be combined with h3_aggregation")
+	}
+	if err := job.ValidateDestinationTable(destination); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// validateConnection of run request is configured, empty runs with credentials of instance
+func (s Server) validateConnection(connection string) error {
+	if connection != "" && !s.jobs.HasConnection(connection) {
+		return status.Errorf(codes.InvalidArgument, "unknown connection %s, connections are configured with DEKART_BIGQUERY_CONNECTIONS or DEKART_SQL_CONNECTIONS", connection)
+	}
+	return nil
+}
+
+// setAdditionalFormats of run request, validated with format of run after result settings are applied
+func (source *queryJobSource) setAdditionalFormats(formats []proto.ResultFormat) error {
+	if len(formats) == 0 {
+		return nil
+	}
+	if source.destination != nil {
+		return status.Error(codes.InvalidArgument, "additional_formats can't be combined with destination_table")
+	}
+	if err := job.ValidateAdditionalFormats(source.options.ResultFormat, formats); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	source.additionalFormats = formats
+	return nil
+}
+
+// validateResultFormat of run request; result in table has no result file
+func validateResultFormat(format proto.ResultFormat, destination *proto.DestinationTable) error {
+	if err := job.ValidateResultFormat(format); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if destination != nil && format != proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+		return status.Error(codes.InvalidArgument, "result_format can't be combined with destination_table")
+	}
+	return nil
+}
+
+// validateSerialization of run request; options apply to cells of CSV result file
+func validateSerialization(options *proto.SerializationOptions, format proto.ResultFormat, destination *proto.DestinationTable) error {
+	if options == nil {
+		return nil
+	}
+	if destination != nil || resultExtension(format) != ".csv" {
+		return status.Error(codes.InvalidArgument, "serialization_options apply only to CSV result")
+	}
+	if err := job.ValidateSerialization(options); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// validateResultCompression of run request; only CSV result file is compressed
+func validateResultCompression(compression proto.ResultCompression, format proto.ResultFormat, destination *proto.DestinationTable) error {
+	if err := job.ValidateResultCompression(compression, format); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if destination != nil && compression != proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED {
+		return status.Error(codes.InvalidArgument, "result_compression can't be combined with destination_table")
+	}
+	return nil
+}
+
+// RunQuery job against database
+func (s Server) RunQuery(ctx context.Context, req *proto.RunQueryRequest) (*proto.RunQueryResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
 	}
+	_, err := uuid.Parse(req.QueryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := s.validateRunQuery(req); err != nil {
+		return nil, err
+	}
+	res, _, err := s.runQuery(ctx, req, "")
+	return res, err
+}
 
-	job := s.jobs.New(reportID, req.QueryId)
-	obj := s.bucket.Object(fmt.Sprintf("%s.csv", job.ID))
-	go s.updateJobStatus(job)
-	err = job.Run(queryText, obj)
+// validateRunQuery options of run request of RunQuery and RunQueryAndWait, query id is validated by caller
+func (s Server) validateRunQuery(req *proto.RunQueryRequest) error {
+	if err := job.ValidateSampleRate(req.SampleRate); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.H3Aggregation != nil {
+		if err := job.ValidateH3Aggregation(req.H3Aggregation); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if err := validateDestination(req.DestinationTable, req.H3Aggregation); err != nil {
+		return err
+	}
+	if err := validateResultFormat(req.ResultFormat, req.DestinationTable); err != nil {
+		return err
+	}
+	if err := validateSerialization(req.SerializationOptions, req.ResultFormat, req.DestinationTable); err != nil {
+		return err
+	}
+	if err := validateResultCompression(req.ResultCompression, req.ResultFormat, req.DestinationTable); err != nil {
+		return err
+	}
+	if err := job.ValidateIdempotencyKey(req.IdempotencyKey); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.validateConnection(req.Connection); err != nil {
+		return err
+	}
+	if req.AcknowledgedBytes < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid acknowledged_bytes %d", req.AcknowledgedBytes)
+	}
+	if req.AsOf < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid as_of %d", req.AsOf)
+	}
+	return nil
+}
+
+// runQuery of validated request, job of batch run is member of batchID; job is nil when result is reused or run is duplicate
+func (s Server) runQuery(ctx context.Context, req *proto.RunQueryRequest, batchID string) (*proto.RunQueryResponse, *job.Job, error) {
+	claims := user.GetClaims(ctx)
+	source, err := s.getQuerySource(ctx, req.QueryId, claims.Email)
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, nil, internalError(err)
 	}
-	res := &proto.RunQueryResponse{}
-	return res, nil
+
+	if source.reportID == "" {
+		return nil, nil, s.queryNotFound(ctx, req.QueryId)
+	}
+	return s.runQuerySource(ctx, req, source, batchID)
+}
+
+// runQuerySource with options of validated request; source is query of report or raw SQL when query id is empty
+func (s Server) runQuerySource(ctx context.Context, req *proto.RunQueryRequest, source queryJobSource, batchID string) (*proto.RunQueryResponse, *job.Job, error) {
+	claims := user.GetClaims(ctx)
+	if err := s.expandDatasets(ctx, &source); err != nil {
+		return nil, nil, err
+	}
+	if err := source.substituteVariables(req.Variables); err != nil {
+		return nil, nil, err
+	}
+	var err error
+	if source.queryText, err = s.normalizeQueryText(source.queryText); err != nil {
+		return nil, nil, err
+	}
+	// legacy SQL can't run what standard SQL can, error is returned before job is created
+	if err := job.ValidateDialect(source.queryText, source.options.UseLegacySQL, req.SampleRate); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.bindParameters(ctx, &source, claims.Email); err != nil {
+		return nil, nil, err
+	}
+	source.options.SampleRate = req.SampleRate
+	source.h3 = req.H3Aggregation
+	source.destination = req.DestinationTable
+	source.applyResultSettings(runResultSettings(req.ResultFormat, req.ResultCompression, req.SerializationOptions), req.SerializationOptions, s.resultSettings)
+	source.options.Connection = req.Connection
+	source.options.AcknowledgedBytes = req.AcknowledgedBytes
+	if req.AsOf > 0 {
+		source.options.AsOf = time.Unix(req.AsOf, 0).UTC()
+	}
+	if err := source.setAdditionalFormats(req.AdditionalFormats); err != nil {
+		return nil, nil, err
+	}
+	source.idempotencyKey = req.IdempotencyKey
+	source.batchID = batchID
+	reused, err := s.reuseSourceResult(ctx, req.QueryId, source)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, nil, internalError(err)
+	}
+	if reused {
+		return &proto.RunQueryResponse{Reused: true}, nil, nil
+	}
+	j, err := s.runQueryJob(ctx, req.QueryId, source)
+	if duplicate, ok := err.(*duplicateRunError); ok {
+		log.Info().Str("queryID", req.QueryId).Str("jobID", duplicate.jobID).Msg("Duplicate run request")
+		return &proto.RunQueryResponse{JobId: duplicate.jobID, Duplicate: true}, nil, nil
+	}
+	if err == job.ErrCancelled {
+		return nil, nil, status.Error(codes.Aborted, err.Error())
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, nil, internalError(err)
+	}
+	res := &proto.RunQueryResponse{JobId: j.ID}
+	return res, j, nil
 }
 
 func (s Server) RemoveQuery(ctx context.Context, req *proto.RemoveQueryRequest) (*proto.RemoveQueryResponse, error) {
@@ -245,6 +1024,9 @@ func (s Server) RemoveQuery(ctx context.Context, req *proto.RemoveQueryRequest)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	_, err := uuid.Parse(req.QueryId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
@@ -254,29 +1036,28 @@ func (s Server) RemoveQuery(ctx context.Context, req *proto.RemoveQueryRequest)
 
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 
 	if reportID == nil {
-		err := fmt.Errorf("Query not found id:%s", req.QueryId)
-		log.Warn().Err(err).Send()
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, s.queryNotFound(ctx, req.QueryId)
 	}
 
-	s.jobs.Cancel(req.QueryId)
+	s.jobs.Cancel(req.QueryId, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
 
+	// query is hidden from report until it's restored or swept with its results
 	_, err = s.db.ExecContext(ctx,
-		`delete from queries where id=$1`,
+		`update queries set deleted_at=CURRENT_TIMESTAMP where id=$1 and deleted_at is null`,
 		req.QueryId,
 	)
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 
-	s.reportStreams.Ping(*reportID)
+	s.reportStreams.Publish(report.Event{ReportID: *reportID, Kind: report.QueryDeleted, QueryID: req.QueryId})
 
-	return &proto.RemoveQueryResponse{}, nil
+	return &proto.RemoveQueryResponse{RestorableUntil: time.Now().Add(s.deletedQueryRetention).Unix()}, nil
 
 }
 
@@ -286,6 +1067,9 @@ func (s Server) CancelQuery(ctx context.Context, req *proto.CancelQueryRequest)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	_, err := uuid.Parse(req.QueryId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
@@ -294,13 +1078,13 @@ func (s Server) CancelQuery(ctx context.Context, req *proto.CancelQueryRequest)
 		`select
 			report_id
 		from queries
-		where id=$1 and report_id in (select report_id from reports where author_email=$2) limit 1`,
+		where id=$1 and report_id in (select id from reports where author_email=$2) limit 1`,
 		req.QueryId,
 		claims.Email,
 	)
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 	defer queriesRows.Close()
 	var reportID string
@@ -308,13 +1092,102 @@ func (s Server) CancelQuery(ctx context.Context, req *proto.CancelQueryRequest)
 		err := queriesRows.Scan(&reportID)
 		if err != nil {
 			log.Err(err).Send()
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, internalError(err)
+		}
+	}
+	reason := proto.CancelReason_CANCEL_REASON_USER_REQUEST
+	if reportID == "" {
+		// admin cancels jobs of queries of other authors
+		reportID, err = s.adminQueryReport(ctx, req.QueryId, claims.Email)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
 		}
+		reason = proto.CancelReason_CANCEL_REASON_ADMIN
 	}
 	if reportID == "" {
-		log.Warn().Str("QueryId", req.QueryId).Msg("Query not found")
+		err := fmt.Errorf("Query not found id:%s", req.QueryId)
+		log.Warn().Err(err).Send()
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	s.jobs.Cancel(req.QueryId)
+	s.jobs.Cancel(req.QueryId, reason)
 	return &proto.CancelQueryResponse{}, nil
 }
+
+// adminQueryReport of query of any report when user is admin, empty when user is not admin or query is not found
+func (s Server) adminQueryReport(ctx context.Context, queryID string, email string) (string, error) {
+	role, err := s.userRole(ctx, email)
+	if err != nil || role != proto.Role_ROLE_ADMIN {
+		return "", err
+	}
+	var reportID string
+	err = s.db.QueryRowContext(ctx, `select report_id from queries where id=$1`, queryID).Scan(&reportID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return reportID, err
+}
+
+// cancelReportJobs of report on this and other replicas, returns number of cancelled jobs
+func (s Server) cancelReportJobs(ctx context.Context, reportID string) (int, error) {
+	rows, err := s.db.QueryContext(ctx, `select id from queries where report_id=$1`, reportID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var queryIDs []string
+	for rows.Next() {
+		var queryID string
+		if err := rows.Scan(&queryID); err != nil {
+			return 0, err
+		}
+		queryIDs = append(queryIDs, queryID)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	// local jobs are cancelled in one snapshot, jobs on other replicas are found by queries;
+	// jobs of report are cancelled only by its author
+	reason := proto.CancelReason_CANCEL_REASON_USER_REQUEST
+	cancelled := s.jobs.CancelByReport(reportID, reason) + s.jobs.CancelRemote(queryIDs, reason)
+	if cancelled > 0 {
+		log.Info().Str("reportID", reportID).Int("cancelled", cancelled).Msg("Jobs of report cancelled")
+	}
+	return cancelled, nil
+}
+
+// CancelReport jobs of all queries of report
+func (s Server) CancelReport(ctx context.Context, req *proto.CancelReportRequest) (*proto.CancelReportResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	var count int
+	err = s.db.QueryRowContext(ctx,
+		`select count(*) from reports where id=$1 and author_email=$2`,
+		req.ReportId,
+		claims.Email,
+	).Scan(&count)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if count == 0 {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	cancelled, err := s.cancelReportJobs(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return &proto.CancelReportResponse{CancelledCount: int32(cancelled)}, nil
+}
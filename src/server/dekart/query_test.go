@@ -0,0 +1,351 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/secrets"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunQueryInvalidSampleRate(t *testing.T) {
+	// admin role is resolved without database
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	for _, rate := range []float64{-0.5, 1, 20} {
+		_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{QueryId: testQueryID, SampleRate: rate})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: expected InvalidArgument, got %v", rate, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunQueryInvalidH3Aggregation(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, _ := newTestServer(t)
+	_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{
+		QueryId:       testQueryID,
+		H3Aggregation: &proto.H3Aggregation{LatColumn: "lat", Resolution: 7},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestRunQueryInvalidDestination(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	for name, req := range map[string]*proto.RunQueryRequest{
+		"table": {QueryId: testQueryID, DestinationTable: &proto.DestinationTable{Table: "points"}},
+		"h3": {
+			QueryId:          testQueryID,
+			DestinationTable: &proto.DestinationTable{Table: "results.points"},
+			H3Aggregation:    &proto.H3Aggregation{LatColumn: "lat", LngColumn: "lng", Resolution: 7},
+		},
+		// result in table has no result file
+		"parquet": {
+			QueryId:          testQueryID,
+			DestinationTable: &proto.DestinationTable{Table: "results.points"},
+			ResultFormat:     proto.ResultFormat_RESULT_FORMAT_PARQUET,
+		},
+		"format":        {QueryId: testQueryID, ResultFormat: proto.ResultFormat(7)},
+		"serialization": {QueryId: testQueryID, SerializationOptions: &proto.SerializationOptions{Delimiter: ";;"}},
+		// serialization options apply to CSV result only
+		"parquet serialization": {
+			QueryId:              testQueryID,
+			ResultFormat:         proto.ResultFormat_RESULT_FORMAT_PARQUET,
+			SerializationOptions: &proto.SerializationOptions{NullToken: "NULL"},
+		},
+	} {
+		if _, err := s.RunQuery(testClaimsContext(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunQueryDuplicate(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+	s.jobs.ClaimKey(ctx, testQueryID, "retry", "first")
+	mock.ExpectQuery("from queries join reports").
+		WillReturnRows(sqlmock.NewRows([]string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}).
+			AddRow("select 1", testReportID, "", "", "", "", 0, false, 0, 0, "", "", "", ""))
+	res, err := s.RunQuery(ctx, &proto.RunQueryRequest{QueryId: testQueryID, IdempotencyKey: "retry"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Duplicate || res.JobId != "first" {
+		t.Errorf("expected duplicate of first job, got %v", res)
+	}
+	if stats := s.jobs.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected duplicate job removed, got %+v", stats)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunQueryLegacySQLParameters(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	mock.ExpectQuery("from queries join reports").
+		WillReturnRows(sqlmock.NewRows([]string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}).
+			AddRow("select * from [data-project:team.points] where id = @id", testReportID, "", "", "", "", 0, true, 0, 0, "", "", "", ""))
+	_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{QueryId: testQueryID})
+	if st := status.Convert(err); st.Code() != codes.InvalidArgument || !strings.Contains(st.Message(), "named parameters @id are not supported in legacy SQL") {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if stats := s.jobs.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected no job, got %+v", stats)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunQueryDeletedDataset(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	mock.ExpectQuery("from queries join reports").
+		WillReturnRows(sqlmock.NewRows([]string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}).
+			AddRow("select * from {{dataset:trips}}", testReportID, "", "", "", "", 0, false, 0, 0, "", "", "", ""))
+	mock.ExpectQuery("from datasets where name").WithArgs("trips").WillReturnError(sql.ErrNoRows)
+	_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{QueryId: testQueryID})
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	failure, ok := st.Details()[0].(*errdetails.PreconditionFailure)
+	if !ok || failure.Violations[0].Subject != "trips" {
+		t.Errorf("expected violation of trips dataset, got %v", st.Details())
+	}
+	if stats := s.jobs.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected no job, got %+v", stats)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunQueryLongIdempotencyKey(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, _ := newTestServer(t)
+	_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{QueryId: testQueryID, IdempotencyKey: strings.Repeat("k", 129)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestRunQueryUnknownConnection(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, _ := newTestServer(t)
+	_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{QueryId: testQueryID, Connection: "github"})
+	if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "DEKART_BIGQUERY_CONNECTIONS") {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestSetAdditionalFormats(t *testing.T) {
+	source := queryJobSource{options: job.RunOptions{ResultFormat: proto.ResultFormat_RESULT_FORMAT_PARQUET}}
+	if err := source.setAdditionalFormats([]proto.ResultFormat{proto.ResultFormat_RESULT_FORMAT_PARQUET}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument of format of run, got %v", err)
+	}
+	if err := source.setAdditionalFormats([]proto.ResultFormat{proto.ResultFormat_RESULT_FORMAT_CSV}); err != nil || len(source.additionalFormats) != 1 {
+		t.Errorf("expected additional CSV, got %v", err)
+	}
+	table := queryJobSource{destination: &proto.DestinationTable{Table: "results.points"}}
+	if err := table.setAdditionalFormats([]proto.ResultFormat{proto.ResultFormat_RESULT_FORMAT_PARQUET}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument of result in table, got %v", err)
+	}
+}
+
+func TestGetQueriesResultType(t *testing.T) {
+	s, mock := newTestServer(t)
+	done := int32(proto.Query_JOB_STATUS_DONE)
+	mock.ExpectQuery("from queries").
+		WillReturnRows(sqlmock.NewRows(queryColumns).
+			AddRow(testQueryID, "select 1", done, "result", "", 0, 1, 1, 1, 1, 0, "", "File", "select 1", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", "").
+			AddRow(testQueryID, "select 2", done, "", "", 0, 0, 1, 0, 0, 0, "", "Table", "select 2", "data-project.results.points", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", "").
+			AddRow(testQueryID, "select 3", 0, "", "", 0, 0, 0, 0, 0, 0, "", "Not run", "", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+	queries, err := s.getQueries(context.Background(), testReportID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []proto.Query_ResultType{
+		proto.Query_RESULT_TYPE_FILE,
+		proto.Query_RESULT_TYPE_TABLE,
+		proto.Query_RESULT_TYPE_UNSPECIFIED,
+	} {
+		if queries[i].ResultType != expected {
+			t.Errorf("%s: expected %s, got %s", queries[i].Title, expected, queries[i].ResultType)
+		}
+	}
+	if queries[1].ResultTable != "data-project.results.points" || queries[1].JobDuration != 0 {
+		t.Errorf("unexpected table result %+v", queries[1])
+	}
+}
+
+func TestQueryTextRejected(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	os.Setenv("DEKART_MAX_QUERY_TEXT_SIZE", "100")
+	defer os.Unsetenv("DEKART_MAX_QUERY_TEXT_SIZE")
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+	for name, queryText := range map[string]string{
+		"too large": "select '" + strings.Repeat("x", 92) + "'",
+		"not UTF-8": "select '\xff'",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.UpdateQuery(ctx, &proto.UpdateQueryRequest{Query: &proto.Query{Id: testQueryID, QueryText: queryText}})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument on update, got %v", err)
+			}
+			_, err = s.CreateQuery(ctx, &proto.CreateQueryRequest{Query: &proto.Query{ReportId: testReportID, QueryText: queryText}})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument on create, got %v", err)
+			}
+			_, err = s.CreateDataset(ctx, &proto.CreateDatasetRequest{Dataset: &proto.Dataset{Name: "trips", QueryText: queryText}})
+			if status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument on dataset, got %v", err)
+			}
+		})
+	}
+	_, err := s.UpdateQuery(ctx, &proto.UpdateQueryRequest{Query: &proto.Query{Id: testQueryID, QueryText: strings.Repeat("x", 101)}})
+	if err == nil || !strings.Contains(err.Error(), "limit of 100 bytes") {
+		t.Errorf("expected limit in error, got %v", err)
+	}
+	// stored query exceeding limit is rejected on run
+	mock.ExpectQuery("from queries join reports").
+		WillReturnRows(sqlmock.NewRows([]string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}).
+			AddRow(strings.Repeat("x", 101), testReportID, "", "", "", "", 0, false, 0, 0, "", "", "", ""))
+	if _, err := s.RunQuery(ctx, &proto.RunQueryRequest{QueryId: testQueryID}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument on run, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateQueryNormalizesText(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	mock.ExpectQuery("select report_id from queries").WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(testReportID))
+	mock.ExpectExec("update queries set query_text").WithArgs("select * from points", testQueryID, false).WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := s.UpdateQuery(testClaimsContext(), &proto.UpdateQueryRequest{Query: &proto.Query{Id: testQueryID, QueryText: "\ufeffselect\u00a0* from\u200b points"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStoreJobStatusWarnings(t *testing.T) {
+	s, mock := newTestServer(t)
+	j := s.jobs.New(context.Background(), testReportID, testQueryID)
+	defer j.Abort()
+	j.AddWarning(job.WarningStatisticsUnavailable, "no statistics")
+	j.AddWarning(job.WarningStatisticsUnavailable, "no statistics")
+	anyArg := sqlmock.AnyArg()
+	mock.ExpectExec("update queries set").
+		WithArgs(int32(proto.Query_JOB_STATUS_PENDING), testQueryID, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg, anyArg,
+			`[{"code":"STATISTICS_UNAVAILABLE","message":"no statistics"}]`, nil, nil, int32(0), "", nil, int64(0), nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.storeJobStatus(j, int32(proto.Query_JOB_STATUS_PENDING))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCancelReport(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	running := s.jobs.New(context.Background(), testReportID, testQueryID)
+	other := s.jobs.New(context.Background(), "1c9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed", testQueryID)
+	for _, j := range []*job.Job{running, other} {
+		go func(j *job.Job) {
+			for {
+				select {
+				case <-j.Status:
+				case <-j.Ctx.Done():
+					return
+				}
+			}
+		}(j)
+	}
+	defer s.jobs.CancelJob(other.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+
+	mock.ExpectQuery("select count").WithArgs(testReportID, user.UnknownEmail).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	if _, err := s.CancelReport(testClaimsContext(), &proto.CancelReportRequest{ReportId: testReportID}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	mock.ExpectQuery("select count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("select id from queries").WithArgs(testReportID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testQueryID))
+	res, err := s.CancelReport(testClaimsContext(), &proto.CancelReportRequest{ReportId: testReportID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.CancelledCount != 1 || running.GetState() != job.StateCancelled || other.GetState() != job.StatePending {
+		t.Errorf("expected only job of report cancelled, got %d", res.CancelledCount)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSecretErrorStatus(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	// connection of database fails to resolve secret of credentials
+	mock.ExpectQuery("from queries join reports").
+		WillReturnError(&secrets.Error{Secret: "gsm://projects/p/secrets/db", Provider: "gsm", Err: fmt.Errorf("permission denied")})
+	_, err := s.RunQuery(testClaimsContext(), &proto.RunQueryRequest{QueryId: testQueryID})
+	if st := status.Convert(err); st.Code() != codes.Unavailable || len(st.Details()) != 1 {
+		t.Errorf("expected unavailable with secret details, got %v", err)
+	}
+}
+
+func TestInternalError(t *testing.T) {
+	for name, c := range map[string]struct {
+		err      error
+		expected codes.Code
+	}{
+		"plain":          {fmt.Errorf("connection reset"), codes.Internal},
+		"read-only":      {errReadOnly, codes.PermissionDenied},
+		"wrapped status": {fmt.Errorf("run: %w", &job.ConfirmationError{EstimatedBytes: 2, ThresholdBytes: 1}), codes.FailedPrecondition},
+		"wrapped secret": {fmt.Errorf("connect: %w", &secrets.Error{Secret: "file:///db", Provider: "file", Err: fmt.Errorf("not found")}), codes.Unavailable},
+	} {
+		if code := status.Code(internalError(c.err)); code != c.expected {
+			t.Errorf("%s: expected %v, got %v", name, c.expected, code)
+		}
+	}
+}
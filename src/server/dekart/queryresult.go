@@ -1,11 +1,24 @@
 package dekart
 
 import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/tiles"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
 	"time"
 
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 )
@@ -13,8 +26,54 @@ import (
 func (s Server) ServeQueryResult(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	ctx := r.Context()
-	obj := s.bucket.Object(fmt.Sprintf("%s.csv", vars["id"]))
+	locale, err := parseDownloadLocale(r.URL.Query().Get("locale"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if locale != nil {
+		s.serveLocalizedResult(w, r, vars["id"], locale)
+		return
+	}
+	obj, err := s.resultObject(ctx, vars["id"])
+	if err == errResultExpired {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	meta, err := s.downloadMeta(ctx, vars["id"])
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if meta != nil && meta.Checksum != "" {
+		// checksum of stored bytes identifies result, so unchanged download is not sent again
+		etag := strconv.Quote(meta.Checksum)
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
 	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if meta != nil {
+		w.Header().Set("X-Dekart-Result-Rows", strconv.FormatInt(meta.Rows, 10))
+	}
+	// compressed result is sent as stored to clients accepting gzip, others receive it decompressed by storage
+	sendCompressed := attrs.ContentEncoding == "gzip" && acceptsGzip(r)
+	if sendCompressed {
+		obj = obj.ReadCompressed(true)
+	}
 	objectReader, err := obj.NewReader(ctx)
 	if err != nil {
 		log.Err(err).Send()
@@ -22,8 +81,26 @@ func (s Server) ServeQueryResult(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer objectReader.Close()
+	filename := s.resultFilename(ctx, vars["id"], path.Ext(obj.ObjectName()))
+	if filename == "" {
+		filename = s.fileFilename(ctx, vars["id"])
+	}
+	if filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
 	w.Header().Set("Content-Type", attrs.ContentType)
-	w.Header().Set("Cache-Control", "public, max-age=31536000")
+	if attrs.ContentEncoding == "gzip" {
+		w.Header().Set("Vary", "Accept-Encoding")
+	}
+	if sendCompressed {
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+	if attrs.Metadata[job.PreviewMetadata] != "" {
+		// preview is rewritten while job reads result
+		w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=31536000")
+	}
 	w.Header().Set("Last-Modified", attrs.Created.Format(time.UnixDate))
 	if _, err := io.Copy(w, objectReader); err != nil {
 		log.Err(err).Send()
@@ -31,3 +108,157 @@ func (s Server) ServeQueryResult(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// downloadMeta of result by id of download path, nil for result stored before meta was recorded
+func (s Server) downloadMeta(ctx context.Context, resultID string) (*job.ResultMeta, error) {
+	if _, err := uuid.Parse(resultID); err != nil {
+		return nil, nil
+	}
+	return s.resultMeta(ctx, resultID)
+}
+
+// acceptsGzip response of request
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		if strings.TrimSpace(strings.Split(encoding, ";")[0]) == "gzip" {
+			return true
+		}
+	}
+	return false
+}
+
+// resultFilename of query result named by report and query titles with extension of result object,
+// empty when query is not found
+func (s Server) resultFilename(ctx context.Context, resultID string, extension string) string {
+	if _, err := uuid.Parse(resultID); err != nil {
+		return ""
+	}
+	var reportTitle, queryTitle string
+	err := s.db.QueryRowContext(ctx,
+		`select
+			case when reports.title is null then '' else reports.title end as report_title,
+			case when queries.title is null then '' else queries.title end as query_title
+		from queries join reports on reports.id = queries.report_id
+		where queries.job_result_id=$1 or queries.h3_result_id=$1 limit 1`,
+		resultID,
+	).Scan(&reportTitle, &queryTitle)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Err(err).Send()
+		}
+		return ""
+	}
+	var parts []string
+	for _, title := range []string{reportTitle, queryTitle} {
+		if title = strings.TrimSpace(title); title != "" {
+			parts = append(parts, title)
+		}
+	}
+	return titleFilename(strings.Join(parts, " - "), extension)
+}
+
+// openResult of job stored in bucket; CSV written with other delimiter is read comma separated,
+// so tiles and comparison parse every result alike
+func (s Server) openResult(ctx context.Context, resultID string) (io.ReadCloser, int64, error) {
+	obj, err := s.resultObject(ctx, resultID)
+	if err != nil {
+		return nil, 0, err
+	}
+	serialization, err := s.resultSerialization(ctx, resultID)
+	if err != nil {
+		return nil, 0, err
+	}
+	attrs, err := obj.Attrs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	r, err := obj.NewReader(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if job.Delimiter(serialization) != ',' {
+		return commaSeparated(r, serialization), attrs.Size, nil
+	}
+	return r, attrs.Size, nil
+}
+
+// transcodedResult closes pipe, source is closed by transcoding goroutine once it stops reading
+type transcodedResult struct {
+	*io.PipeReader
+}
+
+func (t transcodedResult) Close() error {
+	return t.PipeReader.Close()
+}
+
+// commaSeparated CSV of result written with delimiter of options
+func commaSeparated(r io.ReadCloser, options *proto.SerializationOptions) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		defer r.Close()
+		reader := job.NewCSVReader(r, options)
+		// rows are copied as is, readers of result check number of fields
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+		w := csv.NewWriter(pw)
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				w.Flush()
+				pw.CloseWithError(w.Error())
+				return
+			}
+			if err == nil {
+				err = w.Write(record)
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return transcodedResult{pr}
+}
+
+// ServeResultTile of stored result as Mapbox Vector Tile, geometry columns are detected or set with geometry, lat and lng params
+func (s Server) ServeResultTile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var coordinates [3]int
+	for i, name := range []string{"z", "x", "y"} {
+		c, err := strconv.Atoi(vars[name])
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid tile %s", name), http.StatusBadRequest)
+			return
+		}
+		coordinates[i] = c
+	}
+	params := r.URL.Query()
+	columns := tiles.Columns{
+		Geometry: params.Get("geometry"),
+		Lat:      params.Get("lat"),
+		Lng:      params.Get("lng"),
+	}
+	tile, err := s.tiler.Tile(r.Context(), vars["id"], columns, coordinates[0], coordinates[1], coordinates[2])
+	if err != nil {
+		var tooLarge *tiles.ResultTooLargeError
+		switch {
+		case err == storage.ErrObjectNotExist:
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, errResultExpired):
+			http.Error(w, err.Error(), http.StatusGone)
+		case errors.As(err, &tooLarge):
+			log.Warn().Err(err).Str("resultID", vars["id"]).Send()
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		case errors.Is(err, tiles.ErrNoGeometry), errors.Is(err, tiles.ErrInvalidTile):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.Err(err).Send()
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.mapbox-vector-tile")
+	// results are immutable, so are tiles
+	w.Header().Set("Cache-Control", "public, max-age=31536000")
+	w.Write(tile)
+}
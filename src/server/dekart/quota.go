@@ -0,0 +1,156 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/dialect"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// usageQuota of bytes billed by BigQuery jobs in calendar month in UTC
+type usageQuota struct {
+	budget int64
+	// now is clock of quota, replaced in tests
+	now func() time.Time
+}
+
+// parseMonthlyBudget of DEKART_MONTHLY_BYTES_BILLED_BUDGET, zero is unlimited
+func parseMonthlyBudget(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	budget, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || budget < 0 {
+		return 0, fmt.Errorf("invalid DEKART_MONTHLY_BYTES_BILLED_BUDGET %s, expected number of bytes", value)
+	}
+	return budget, nil
+}
+
+// newUsageQuota of DEKART_MONTHLY_BYTES_BILLED_BUDGET, nil when budget is not set
+func newUsageQuota() *usageQuota {
+	budget, err := parseMonthlyBudget(os.Getenv("DEKART_MONTHLY_BYTES_BILLED_BUDGET"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	if budget == 0 {
+		return nil
+	}
+	return &usageQuota{budget: budget, now: time.Now}
+}
+
+// usageMonth of t in UTC, key of usage counters
+func usageMonth(t time.Time) string {
+	return t.UTC().Format("2006-01")
+}
+
+// quotaReset at start of month after t in UTC
+func quotaReset(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// incrementUsage of month by bytes billed of finished job, total of instance and counter of user are incremented
+// in database, so jobs finished on other replicas are counted
+func incrementUsage(ctx context.Context, tx *sql.Tx, d dialect.Dialect, month string, userEmail string, billed int64) error {
+	for _, email := range []string{"", userEmail} {
+		_, err := tx.ExecContext(ctx,
+			`insert into usage_counters (month, user_email, bytes_billed) values ($1, $2, $3)
+			`+d.OnConflict([]string{"month", "user_email"}, "bytes_billed = usage_counters.bytes_billed + "+d.Excluded("bytes_billed")+", updated_at = CURRENT_TIMESTAMP"),
+			month,
+			email,
+			billed,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// monthUsage of user in month, empty email is total of instance
+func (s Server) monthUsage(ctx context.Context, month string, email string) (int64, error) {
+	var billed int64
+	err := s.db.QueryRowContext(ctx,
+		`select bytes_billed from usage_counters where month=$1 and user_email=$2`,
+		month,
+		email,
+	).Scan(&billed)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return billed, err
+}
+
+// quotaExceededError when monthly budget is exhausted, jobs are created again after reset
+type quotaExceededError struct {
+	budget int64
+	billed int64
+	reset  time.Time
+}
+
+func (e *quotaExceededError) Error() string {
+	return fmt.Sprintf("monthly budget of %d bytes billed is exhausted with %d bytes billed, queries can run again from %s", e.budget, e.billed, e.reset.Format("2006-01-02"))
+}
+
+// GRPCStatus of quota error is resource exhausted with reset date in details
+func (e *quotaExceededError) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: "QUOTA_EXCEEDED",
+		Domain: "dekart",
+		Metadata: map[string]string{
+			"budgetBytes": strconv.FormatInt(e.budget, 10),
+			"bytesBilled": strconv.FormatInt(e.billed, 10),
+			"resetDate":   e.reset.Format("2006-01-02"),
+		},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// currentQuota of month, nil when budget is not set
+func (s Server) currentQuota(ctx context.Context) (*proto.UsageQuota, error) {
+	if s.quota == nil {
+		return nil, nil
+	}
+	now := s.quota.now()
+	month := usageMonth(now)
+	billed, err := s.monthUsage(ctx, month, "")
+	if err != nil {
+		return nil, err
+	}
+	headroom := float64(s.quota.budget-billed) / float64(s.quota.budget) * 100
+	if headroom < 0 {
+		headroom = 0
+	}
+	return &proto.UsageQuota{
+		Month:           month,
+		BudgetBytes:     s.quota.budget,
+		BytesBilled:     billed,
+		HeadroomPercent: headroom,
+		ResetTime:       quotaReset(now).Unix(),
+	}, nil
+}
+
+// checkQuota before job is created; reused results and dry runs don't create jobs, so they aren't limited
+func (s Server) checkQuota(ctx context.Context) error {
+	quota, err := s.currentQuota(ctx)
+	if err != nil || quota == nil {
+		return err
+	}
+	if quota.BytesBilled >= quota.BudgetBytes {
+		return &quotaExceededError{budget: quota.BudgetBytes, billed: quota.BytesBilled, reset: time.Unix(quota.ResetTime, 0).UTC()}
+	}
+	return nil
+}
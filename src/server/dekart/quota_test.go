@@ -0,0 +1,37 @@
+package dekart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMonthlyBudget(t *testing.T) {
+	if budget, err := parseMonthlyBudget(""); err != nil || budget != 0 {
+		t.Errorf("expected unlimited budget, got %d %v", budget, err)
+	}
+	if budget, err := parseMonthlyBudget("1099511627776"); err != nil || budget != 1099511627776 {
+		t.Errorf("unexpected budget %d %v", budget, err)
+	}
+	for _, value := range []string{"-1", "1TB"} {
+		if _, err := parseMonthlyBudget(value); err == nil {
+			t.Errorf("%s: expected error", value)
+		}
+	}
+}
+
+func TestQuotaReset(t *testing.T) {
+	// month is in UTC, late evening of last day of year in New York is next year
+	newYork := time.FixedZone("EST", -5*3600)
+	for at, expected := range map[time.Time]string{
+		time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC):   "2026-11-01",
+		time.Date(2026, time.December, 31, 23, 0, 0, 0, time.UTC): "2027-01-01",
+		time.Date(2026, time.December, 31, 20, 0, 0, 0, newYork):  "2027-02-01",
+	} {
+		if reset := quotaReset(at).Format("2006-01-02"); reset != expected {
+			t.Errorf("%s: expected reset %s, got %s", at, expected, reset)
+		}
+	}
+	if month := usageMonth(time.Date(2026, time.December, 31, 20, 0, 0, 0, newYork)); month != "2027-01" {
+		t.Errorf("unexpected month %s", month)
+	}
+}
@@ -0,0 +1,25 @@
+package dekart
+
+import (
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errReadOnly of RPCs changing reports, queries, datasets, roles or results on read-only instance
+var errReadOnly = status.Error(codes.PermissionDenied, "This Dekart instance is read-only: shared reports can be viewed and downloaded, but queries can't be run or edited")
+
+// readOnly instance of public reports, DEKART_READ_ONLY=1
+func readOnly() bool {
+	return os.Getenv("DEKART_READ_ONLY") == "1"
+}
+
+// requireWritable instance, checked by handlers of mutations before role of user,
+// so read-only instance doesn't depend on roles being configured
+func (s Server) requireWritable() error {
+	if s.readOnly {
+		return errReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRunQueryAndWaitStream of context, sent responses are collected
+type fakeRunQueryAndWaitStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*proto.RunQueryAndWaitResponse
+}
+
+func (f *fakeRunQueryAndWaitStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeRunQueryAndWaitStream) Send(res *proto.RunQueryAndWaitResponse) error {
+	f.responses = append(f.responses, res)
+	return nil
+}
+
+func TestReadOnlyRejectsMutations(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", "*")
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	s.readOnly = true
+	ctx := testClaimsContext()
+	for name, call := range map[string]func() error{
+		"CreateReport": func() error { _, err := s.CreateReport(ctx, &proto.CreateReportRequest{}); return err },
+		"ForkReport": func() error {
+			_, err := s.ForkReport(ctx, &proto.ForkReportRequest{ReportId: testReportID})
+			return err
+		},
+		"UpdateReport": func() error { _, err := s.UpdateReport(ctx, &proto.UpdateReportRequest{}); return err },
+		"ArchiveReport": func() error {
+			_, err := s.ArchiveReport(ctx, &proto.ArchiveReportRequest{ReportId: testReportID})
+			return err
+		},
+		"ShareReport": func() error {
+			_, err := s.ShareReport(ctx, &proto.ShareReportRequest{ReportId: testReportID})
+			return err
+		},
+		"UpdateMapConfig": func() error {
+			_, err := s.UpdateMapConfig(ctx, &proto.UpdateMapConfigRequest{ReportId: testReportID})
+			return err
+		},
+		"UpdateReportVariables": func() error {
+			_, err := s.UpdateReportVariables(ctx, &proto.UpdateReportVariablesRequest{ReportId: testReportID})
+			return err
+		},
+		"UpdateReportSettings": func() error {
+			_, err := s.UpdateReportSettings(ctx, &proto.UpdateReportSettingsRequest{ReportId: testReportID})
+			return err
+		},
+		"UpdateReportFreshnessPolicy": func() error {
+			_, err := s.UpdateReportFreshnessPolicy(ctx, &proto.UpdateReportFreshnessPolicyRequest{ReportId: testReportID})
+			return err
+		},
+		"UpdateReportParameters": func() error {
+			_, err := s.UpdateReportParameters(ctx, &proto.UpdateReportParametersRequest{ReportId: testReportID})
+			return err
+		},
+		"SetReportParameterValues saved": func() error {
+			_, err := s.SetReportParameterValues(ctx, &proto.SetReportParameterValuesRequest{ReportId: testReportID, Save: true})
+			return err
+		},
+		"CreateQuery": func() error { _, err := s.CreateQuery(ctx, &proto.CreateQueryRequest{}); return err },
+		"UpdateQuery": func() error { _, err := s.UpdateQuery(ctx, &proto.UpdateQueryRequest{}); return err },
+		"UpdateQueryTitle": func() error {
+			_, err := s.UpdateQueryTitle(ctx, &proto.UpdateQueryTitleRequest{QueryId: testQueryID})
+			return err
+		},
+		"UpdateQueryDependencies": func() error {
+			_, err := s.UpdateQueryDependencies(ctx, &proto.UpdateQueryDependenciesRequest{QueryId: testQueryID})
+			return err
+		},
+		"UpdateQueryParameterBindings": func() error {
+			_, err := s.UpdateQueryParameterBindings(ctx, &proto.UpdateQueryParameterBindingsRequest{QueryId: testQueryID})
+			return err
+		},
+		"RunQuery": func() error { _, err := s.RunQuery(ctx, &proto.RunQueryRequest{QueryId: testQueryID}); return err },
+		"CancelQuery": func() error {
+			_, err := s.CancelQuery(ctx, &proto.CancelQueryRequest{QueryId: testQueryID})
+			return err
+		},
+		"CancelReport": func() error {
+			_, err := s.CancelReport(ctx, &proto.CancelReportRequest{ReportId: testReportID})
+			return err
+		},
+		"RunAllQueries": func() error {
+			_, err := s.RunAllQueries(ctx, &proto.RunAllQueriesRequest{ReportId: testReportID})
+			return err
+		},
+		"CancelBatch": func() error { _, err := s.CancelBatch(ctx, &proto.CancelBatchRequest{}); return err },
+		"RemoveQuery": func() error {
+			_, err := s.RemoveQuery(ctx, &proto.RemoveQueryRequest{QueryId: testQueryID})
+			return err
+		},
+		"RestoreQuery": func() error {
+			_, err := s.RestoreQuery(ctx, &proto.RestoreQueryRequest{QueryId: testQueryID})
+			return err
+		},
+		"RunQueryAndWait": func() error {
+			return s.RunQueryAndWait(&proto.RunQueryAndWaitRequest{QueryId: testQueryID}, &fakeRunQueryAndWaitStream{ctx: ctx})
+		},
+		"GetQuerySchemaContext": func() error {
+			_, err := s.GetQuerySchemaContext(ctx, &proto.GetQuerySchemaContextRequest{ReportId: testReportID})
+			return err
+		},
+		"CreateDataset": func() error { _, err := s.CreateDataset(ctx, &proto.CreateDatasetRequest{}); return err },
+		"UpdateDataset": func() error { _, err := s.UpdateDataset(ctx, &proto.UpdateDatasetRequest{}); return err },
+		"DeleteDataset": func() error { _, err := s.DeleteDataset(ctx, &proto.DeleteDatasetRequest{}); return err },
+		"UploadFile":    func() error { return s.UploadFile(&fakeUploadFileStream{ctx: ctx}) },
+		"RemoveFile": func() error {
+			_, err := s.RemoveFile(ctx, &proto.RemoveFileRequest{FileId: testQueryID})
+			return err
+		},
+		"SetRoleAssignment": func() error {
+			_, err := s.SetRoleAssignment(ctx, &proto.SetRoleAssignmentRequest{})
+			return err
+		},
+		"RemoveRoleAssignment": func() error {
+			_, err := s.RemoveRoleAssignment(ctx, &proto.RemoveRoleAssignmentRequest{})
+			return err
+		},
+		"ReconcileResults": func() error { _, err := s.ReconcileResults(ctx, &proto.ReconcileResultsRequest{}); return err },
+		"ReencryptColumns": func() error { _, err := s.ReencryptColumns(ctx, &proto.ReencryptColumnsRequest{}); return err },
+	} {
+		err := call()
+		if status.Code(err) != codes.PermissionDenied || err != errReadOnly {
+			t.Errorf("%s: expected read-only error, got %v", name, err)
+		}
+	}
+	// mutations are rejected before database is read
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReadOnlyJobCreation(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.readOnly = true
+	if _, err := s.runQueryJob(testClaimsContext(), testQueryID, queryJobSource{reportID: testReportID}); err != errReadOnly {
+		t.Errorf("expected read-only error, got %v", err)
+	}
+	if stats := s.JobStats(); stats.Jobs != 0 {
+		t.Errorf("expected no jobs, got %+v", stats)
+	}
+}
+
+func TestReadOnlyEnv(t *testing.T) {
+	s, _ := newTestServer(t)
+	for _, readOnly := range []bool{false, true} {
+		s.readOnly = readOnly
+		res, err := s.GetEnv(context.Background(), &proto.GetEnvRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var value string
+		for _, v := range res.Variables {
+			if v.Type == proto.GetEnvResponse_Variable_TYPE_READ_ONLY {
+				value = v.Value
+			}
+		}
+		if expected := map[bool]string{false: "0", true: "1"}[readOnly]; value != expected {
+			t.Errorf("read-only %v: expected %q, got %q", readOnly, expected, value)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/encryption"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// reencryptBatch of rows read at once
+const reencryptBatch = 500
+
+// encryptedTable with columns of encryption.String and encryption.Strings values
+type encryptedTable struct {
+	name    string
+	key     string
+	columns []string
+}
+
+var encryptedTables = []encryptedTable{
+	{name: "sessions", key: "id", columns: []string{"email", `"groups"`}},
+	{name: "result_lineage", key: "result_id", columns: []string{"query_text"}},
+}
+
+// reencryptRow values which are plaintext or encrypted with other key than primary; update is conditional
+// on previous values, so row changed concurrently is left for next run
+func (s Server) reencryptRow(ctx context.Context, k *encryption.Keyring, table encryptedTable, key string, values []sql.NullString) (bool, error) {
+	var set, where []string
+	args := []interface{}{}
+	for i, value := range values {
+		if !value.Valid || !k.Stale(value.String) {
+			continue
+		}
+		plaintext, err := k.Decrypt(value.String)
+		if err != nil {
+			return false, err
+		}
+		encrypted, err := k.Encrypt(plaintext)
+		if err != nil {
+			return false, err
+		}
+		args = append(args, encrypted, value.String)
+		set = append(set, fmt.Sprintf("%s=$%d", table.columns[i], len(args)-1))
+		where = append(where, fmt.Sprintf("%s=$%d", table.columns[i], len(args)))
+	}
+	if len(set) == 0 {
+		return false, nil
+	}
+	args = append(args, key)
+	result, err := s.db.ExecContext(ctx,
+		fmt.Sprintf(`update %s set %s where %s=$%d and %s`,
+			table.name, strings.Join(set, ", "), table.key, len(args), strings.Join(where, " and ")),
+		args...,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected == 1, err
+}
+
+// reencryptColumns of encrypted tables in batches ordered by key
+func (s Server) reencryptColumns(ctx context.Context, k *encryption.Keyring) (*proto.ReencryptColumnsResponse, error) {
+	res := &proto.ReencryptColumnsResponse{PrimaryKeyId: k.Primary()}
+	for _, table := range encryptedTables {
+		after := ""
+		for {
+			rows, err := s.db.QueryContext(ctx,
+				fmt.Sprintf(`select %s, %s from %s where %s > $1 order by %s limit %d`,
+					table.key, strings.Join(table.columns, ", "), table.name, table.key, table.key, reencryptBatch),
+				after,
+			)
+			if err != nil {
+				return nil, err
+			}
+			keys := []string{}
+			values := [][]sql.NullString{}
+			for rows.Next() {
+				var key string
+				row := make([]sql.NullString, len(table.columns))
+				dest := []interface{}{&key}
+				for i := range row {
+					dest = append(dest, &row[i])
+				}
+				if err := rows.Scan(dest...); err != nil {
+					rows.Close()
+					return nil, err
+				}
+				keys = append(keys, key)
+				values = append(values, row)
+			}
+			err = rows.Err()
+			rows.Close()
+			if err != nil {
+				return nil, err
+			}
+			for i, key := range keys {
+				res.CheckedCount++
+				updated, err := s.reencryptRow(ctx, k, table, key, values[i])
+				if decryptErr, ok := err.(*encryption.DecryptError); ok {
+					log.Error().Err(err).Str("table", table.name).Str("keyID", decryptErr.KeyID).Msg("Cannot decrypt row")
+					res.FailedCount++
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				if updated {
+					res.ReencryptedCount++
+				}
+			}
+			if len(keys) < reencryptBatch {
+				break
+			}
+			after = keys[len(keys)-1]
+		}
+	}
+	return res, nil
+}
+
+// ReencryptColumns encrypts plaintext values and values of older keys with primary key of DEKART_ENCRYPTION_KEYS;
+// old key can be removed from DEKART_ENCRYPTION_KEYS when no rows failed
+func (s Server) ReencryptColumns(ctx context.Context, req *proto.ReencryptColumnsRequest) (*proto.ReencryptColumnsResponse, error) {
+	if err := s.requireWritable(); err != nil {
+		return nil, err
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	k := encryption.Active()
+	if k == nil {
+		return nil, status.Error(codes.FailedPrecondition, "DEKART_ENCRYPTION_KEYS is not set")
+	}
+	res, err := s.reencryptColumns(ctx, k)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	log.Info().
+		Str("keyID", res.PrimaryKeyId).
+		Int64("checked", res.CheckedCount).
+		Int64("reencrypted", res.ReencryptedCount).
+		Int64("failed", res.FailedCount).
+		Msg("Columns re-encrypted")
+	return res, nil
+}
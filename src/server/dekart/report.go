@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
 	"dekart/src/server/user"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
@@ -31,7 +35,20 @@ func (s Server) getReport(ctx context.Context, reportID string) (*proto.Report,
 			id,
 			case when map_config is null then '' else map_config end as map_config,
 			case when title is null then 'Untitled' else title end as title,
-			author_email = $2 as can_write
+			author_email = $2 as can_write,
+			case when billing_project is null then '' else billing_project end as billing_project,
+			case when default_dataset is null then '' else default_dataset end as default_dataset,
+			map_config_revision,
+			case when variables is null then '' else variables end as variables,
+			coalesce(exploration_limit, 0) as exploration_limit,
+			coalesce(public_share, false) as public_share,
+			coalesce(result_format, 0) as result_format,
+			coalesce(result_compression, 0) as result_compression,
+			case when result_delimiter is null then '' else result_delimiter end as result_delimiter,
+			case when parameters is null then '' else parameters end as parameters,
+			case when parameter_values is null then '' else parameter_values end as parameter_values,
+			coalesce(freshness_max_age, 0) as freshness_max_age,
+			coalesce(freshness_auto_rerun, false) as freshness_auto_rerun
 		from reports where id=$1 and not archived limit 1`,
 		reportID,
 		claims.Email,
@@ -41,7 +58,8 @@ func (s Server) getReport(ctx context.Context, reportID string) (*proto.Report,
 		return nil, err
 	}
 	defer reportRows.Close()
-	report := &proto.Report{}
+	report := &proto.Report{ResultSettings: &proto.ResultSettings{}, FreshnessPolicy: &proto.FreshnessPolicy{}}
+	var variables, parameters, parameterValues string
 
 	for reportRows.Next() {
 		err = reportRows.Scan(
@@ -49,6 +67,19 @@ func (s Server) getReport(ctx context.Context, reportID string) (*proto.Report,
 			&report.MapConfig,
 			&report.Title,
 			&report.CanWrite,
+			&report.BillingProject,
+			&report.DefaultDataset,
+			&report.MapConfigRevision,
+			&variables,
+			&report.ExplorationLimit,
+			&report.Public,
+			&report.ResultSettings.ResultFormat,
+			&report.ResultSettings.ResultCompression,
+			&report.ResultSettings.Delimiter,
+			&parameters,
+			&parameterValues,
+			&report.FreshnessPolicy.MaxAgeSeconds,
+			&report.FreshnessPolicy.AutoRerun,
 		)
 		if err != nil {
 			log.Err(err).Send()
@@ -58,18 +89,80 @@ func (s Server) getReport(ctx context.Context, reportID string) (*proto.Report,
 	if report.Id == "" {
 		return nil, nil // not found
 	}
+	report.Variables, err = unmarshalVariables(variables)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, err
+	}
+	if report.Parameters, err = unmarshalParameters(parameters); err != nil {
+		log.Err(err).Send()
+		return nil, err
+	}
+	if report.ParameterValues, err = unmarshalStringMap(parameterValues); err != nil {
+		log.Err(err).Send()
+		return nil, err
+	}
+	if len(report.Parameters) > 0 {
+		if report.SessionParameterValues, err = s.sessionParameterValues(ctx, reportID, claims.Email); err != nil {
+			log.Err(err).Send()
+			return nil, err
+		}
+	}
+	report.EffectiveDefaultDataset = s.jobs.DefaultDataset(report.DefaultDataset)
 	return report, nil
 }
 
+// GetReport with queries
+func (s Server) GetReport(ctx context.Context, req *proto.GetReportRequest) (*proto.GetReportResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	report, err := s.getReport(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if report == nil {
+		err := fmt.Errorf("Report %s not found", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	}
+	files, err := s.getFiles(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	queries, err := s.getQueries(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	markStale(queries, report.FreshnessPolicy, s.now())
+	setPublicResultPaths(report, queries)
+	return &proto.GetReportResponse{
+		Report:  report,
+		Queries: queries,
+		Files:   files,
+	}, nil
+}
+
 // CreateReport implementation
 func (s Server) CreateReport(ctx context.Context, req *proto.CreateReportRequest) (*proto.CreateReportResponse, error) {
 	claims := user.GetClaims(ctx)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	id := newUUID()
 	_, err := s.db.ExecContext(ctx,
-		"INSERT INTO reports (id, author_email) VALUES ($1, $2)",
+		"INSERT INTO reports (id, author_email, title) VALUES ($1, $2, 'Untitled')",
 		id,
 		claims.Email,
 	)
@@ -79,7 +172,8 @@ func (s Server) CreateReport(ctx context.Context, req *proto.CreateReportRequest
 	}
 	res := &proto.CreateReportResponse{
 		Report: &proto.Report{
-			Id: id,
+			Id:    id,
+			Title: "Untitled",
 		},
 	}
 	return res, nil
@@ -94,26 +188,72 @@ func rollback(tx *sql.Tx) {
 
 func (s Server) commitReportWithQueries(ctx context.Context, report *proto.Report, queries []*proto.Query) error {
 	claims := user.GetClaims(ctx)
+	variables, err := marshalVariables(report.Variables)
+	if err != nil {
+		return err
+	}
+	// dependencies and values queries of parameters are copied as queries of new report
+	queryIDs := make(map[string]string, len(queries))
+	for _, query := range queries {
+		queryIDs[query.Id] = newUUID()
+	}
+	parameters, err := marshalParameters(forkParameters(report.Parameters, queryIDs))
+	if err != nil {
+		return err
+	}
+	parameterValues, err := marshalStringMap(report.ParameterValues)
+	if err != nil {
+		return err
+	}
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
 
 	_, err = tx.ExecContext(ctx,
-		"INSERT INTO reports (id, author_email, map_config, title) VALUES ($1, $2, $3, $4)",
+		"INSERT INTO reports (id, author_email, map_config, title, billing_project, default_dataset, variables, exploration_limit, result_format, result_compression, result_delimiter, parameters, parameter_values) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 		report.Id,
 		claims.Email,
 		report.MapConfig,
 		report.Title,
+		report.BillingProject,
+		report.DefaultDataset,
+		variables,
+		report.ExplorationLimit,
+		int32(report.GetResultSettings().GetResultFormat()),
+		int32(report.GetResultSettings().GetResultCompression()),
+		report.GetResultSettings().GetDelimiter(),
+		parameters,
+		parameterValues,
 	)
 	if err != nil {
 		rollback(tx)
 		return err
 	}
 	for _, query := range queries {
-		queryId := newUUID()
-		_, err := tx.ExecContext(ctx,
-			`INSERT INTO queries (id, report_id, query_text) VALUES($1, $2, $3)`,
-			queryId,
+		var dependsOn []string
+		for _, id := range query.DependsOn {
+			if forked, ok := queryIDs[id]; ok {
+				dependsOn = append(dependsOn, forked)
+			}
+		}
+		value, err := marshalDependsOn(dependsOn)
+		if err != nil {
+			rollback(tx)
+			return err
+		}
+		bindings, err := marshalStringMap(query.ParameterBindings)
+		if err != nil {
+			rollback(tx)
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO queries (id, report_id, query_text, title, source_query_id, depends_on, use_legacy_sql, parameter_bindings) VALUES($1, $2, $3, $4, $5, $6, $7, $8)`,
+			queryIDs[query.Id],
 			report.Id,
 			query.QueryText,
+			query.Title,
+			query.Id,
+			value,
+			query.UseLegacySql,
+			bindings,
 		)
 		if err != nil {
 			rollback(tx)
@@ -124,11 +264,29 @@ func (s Server) commitReportWithQueries(ctx context.Context, report *proto.Repor
 	return err
 }
 
+// forkParameters of report with values queries replaced by their copies in fork
+func forkParameters(parameters []*proto.ReportParameter, queryIDs map[string]string) []*proto.ReportParameter {
+	forked := make([]*proto.ReportParameter, len(parameters))
+	for i, parameter := range parameters {
+		forked[i] = &proto.ReportParameter{
+			Name:          parameter.Name,
+			Type:          parameter.Type,
+			AllowedValues: parameter.AllowedValues,
+			ValuesQueryId: queryIDs[parameter.ValuesQueryId],
+			DefaultValue:  parameter.DefaultValue,
+		}
+	}
+	return forked
+}
+
 func (s Server) ForkReport(ctx context.Context, req *proto.ForkReportRequest) (*proto.ForkReportResponse, error) {
 	claims := user.GetClaims(ctx)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 
 	_, err := uuid.Parse(req.ReportId)
 	if err != nil {
@@ -173,68 +331,100 @@ func (s Server) UpdateReport(ctx context.Context, req *proto.UpdateReportRequest
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	if req.Report == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "req.Report == nil")
 	}
-	result, err := s.db.ExecContext(ctx,
-		`update
-			reports
-		set map_config=$1, title=$2
-		where id=$3 and author_email=$4`,
-		req.Report.MapConfig,
-		req.Report.Title,
-		req.Report.Id,
-		claims.Email,
-	)
+	_, err := uuid.Parse(req.Report.Id)
 	if err != nil {
-		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
-
-	affectedRows, err := result.RowsAffected()
-
+	billingProject := strings.TrimSpace(req.Report.BillingProject)
+	defaultDataset := strings.TrimSpace(req.Report.DefaultDataset)
+	if err := job.ValidateDataset(defaultDataset); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := job.ValidateExplorationLimit(req.Report.ExplorationLimit); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	title, err := validateTitle(req.Report.Title)
 	if err != nil {
-		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
-
-	if affectedRows == 0 {
-		// TODO: distinguish between not found and read only
-		err := fmt.Errorf("Report not found id:%s", req.Report.Id)
-		log.Warn().Err(err).Send()
-		return nil, status.Error(codes.NotFound, err.Error())
+	if title == "" {
+		title = "Untitled"
+	}
+	mapConfig, err := s.normalizeMapConfig(req.Report.MapConfig)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.saveMapConfig(ctx, req.Report.Id, claims.Email, mapConfig,
+		", title=$4, billing_project=$5, default_dataset=$6, exploration_limit=$7",
+		title,
+		billingProject,
+		defaultDataset,
+		req.Report.ExplorationLimit,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	s.reportStreams.Ping(req.Report.Id)
+	s.reportStreams.Publish(report.Event{ReportID: req.Report.Id, Kind: report.ReportChanged})
 
-	return &proto.UpdateReportResponse{}, nil
+	res := &proto.UpdateReportResponse{}
+	if defaultDataset != "" {
+		// report is saved anyway, dataset could be created later
+		checkCtx, cancel := context.WithTimeout(ctx, datasetCheckTimeout)
+		defer cancel()
+		dataset := s.jobs.DefaultDataset(defaultDataset)
+		if err := checkDataset(checkCtx, s.jobs.BillingProject(billingProject), dataset); err != nil {
+			log.Warn().Err(err).Str("dataset", dataset).Msg("Default dataset not available")
+			res.Warning = fmt.Sprintf("Default dataset %s not available: %s", dataset, err)
+		}
+	}
+	return res, nil
 }
 
+// checkDataset exists, stubbed in tests
+var checkDataset = job.CheckDataset
+
+const datasetCheckTimeout = 5 * time.Second
+
 // ArchiveReport implementation
 func (s Server) ArchiveReport(ctx context.Context, req *proto.ArchiveReportRequest) (*proto.ArchiveReportResponse, error) {
 	claims := user.GetClaims(ctx)
 	if claims == nil {
 		return nil, Unauthenticated
 	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
 	_, err := uuid.Parse(req.ReportId)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
+	return s.archiveReport(ctx, req, claims.Email, s.deleteObject)
+}
+
+// archiveReport of author; jobs and uploaded files of archived report are removed
+func (s Server) archiveReport(ctx context.Context, req *proto.ArchiveReportRequest, email string, remove objectRemover) (*proto.ArchiveReportResponse, error) {
 	result, err := s.db.ExecContext(ctx,
-		"update reports set archived=$1 where id=$2 and author_email=$3",
+		"update reports set archived=$1, updated_at=CURRENT_TIMESTAMP where id=$2 and author_email=$3",
 		req.Archive,
 		req.ReportId,
-		claims.Email,
+		email,
 	)
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 	affectedRows, err := result.RowsAffected()
 
 	if err != nil {
 		log.Err(err).Send()
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
 
 	if affectedRows == 0 {
@@ -242,8 +432,22 @@ func (s Server) ArchiveReport(ctx context.Context, req *proto.ArchiveReportReque
 		log.Warn().Err(err).Send()
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
-	s.reportStreams.Ping(req.ReportId)
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
 
-	return &proto.ArchiveReportResponse{}, nil
+	res := &proto.ArchiveReportResponse{}
+	if req.Archive {
+		// archived report is closed by users, its jobs are not needed
+		cancelled, err := s.cancelReportJobs(ctx, req.ReportId)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		res.CancelledCount = int32(cancelled)
+		if err := s.removeReportFiles(ctx, req.ReportId, remove); err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+	}
+	return res, nil
 
 }
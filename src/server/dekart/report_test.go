@@ -0,0 +1,109 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetReportEffectiveDefaultDataset(t *testing.T) {
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	os.Setenv("DEKART_BIGQUERY_DEFAULT_DATASET", "shared")
+	defer os.Unsetenv("DEKART_BIGQUERY_DEFAULT_DATASET")
+	for stored, expected := range map[string]string{
+		"":                   "data-project.shared",
+		"other-project.team": "other-project.team",
+	} {
+		s, mock := newTestServer(t)
+		mock.ExpectQuery("from reports where id").
+			WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testReportID, "{}", "Report", true, "", stored, 1, "", 0, false, 0, 0, "", "", "", 0, false))
+		report, err := s.getReport(testClaimsContext(), testReportID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.DefaultDataset != stored || report.EffectiveDefaultDataset != expected {
+			t.Errorf("expected %s, got %+v", expected, report)
+		}
+	}
+}
+
+func TestUpdateReportDefaultDataset(t *testing.T) {
+	check := checkDataset
+	defer func() { checkDataset = check }()
+	var checked []string
+	checkDataset = func(ctx context.Context, billingProject string, dataset string) error {
+		checked = append(checked, billingProject+":"+dataset)
+		return fmt.Errorf("Not found: Dataset %s", dataset)
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	// admin role is resolved without database
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	ctx := testClaimsContext()
+
+	t.Run("missing dataset is saved with warning", func(t *testing.T) {
+		checked = nil
+		s, mock := newTestServer(t)
+		expectSaveMapConfig(mock, 1).
+			WithArgs("{}", testReportID, user.UnknownEmail, "Report", "team-billing", "team", int64(500))
+		res, err := s.UpdateReport(ctx, &proto.UpdateReportRequest{Report: &proto.Report{
+			Id:               testReportID,
+			MapConfig:        "{}",
+			Title:            "Report",
+			BillingProject:   "team-billing",
+			DefaultDataset:   " team ",
+			ExplorationLimit: 500,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(res.Warning, "data-project.team") {
+			t.Errorf("expected warning naming dataset, got %q", res.Warning)
+		}
+		if len(checked) != 1 || checked[0] != "team-billing:data-project.team" {
+			t.Errorf("unexpected checks %v", checked)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("without dataset", func(t *testing.T) {
+		checked = nil
+		s, mock := newTestServer(t)
+		expectSaveMapConfig(mock, 1)
+		res, err := s.UpdateReport(ctx, &proto.UpdateReportRequest{Report: &proto.Report{Id: testReportID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Warning != "" || len(checked) != 0 {
+			t.Errorf("expected no check, got %q %v", res.Warning, checked)
+		}
+	})
+
+	t.Run("invalid dataset", func(t *testing.T) {
+		s, _ := newTestServer(t)
+		_, err := s.UpdateReport(ctx, &proto.UpdateReportRequest{Report: &proto.Report{Id: testReportID, DefaultDataset: "a.b.c"}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("negative exploration limit", func(t *testing.T) {
+		s, _ := newTestServer(t)
+		_, err := s.UpdateReport(ctx, &proto.UpdateReportRequest{Report: &proto.Report{Id: testReportID, ExplorationLimit: -1}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+}
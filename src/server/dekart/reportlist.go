@@ -0,0 +1,232 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultReportsPageSize = 50
+	maxReportsPageSize     = 200
+	// maxReportsCount is counted exactly, larger total is reported as estimated
+	maxReportsCount = 10000
+)
+
+const reportTitleSQL = `case when title is null then 'Untitled' else title end`
+
+// sqlArgs of query, add returns placeholder of appended argument
+type sqlArgs []interface{}
+
+func (a *sqlArgs) add(value interface{}) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// reportsCursor is position after last report of page
+type reportsCursor struct {
+	Sort      proto.ListReportsRequest_Sort `json:"s"`
+	UpdatedAt time.Time                     `json:"u"`
+	Title     string                        `json:"t"`
+	ID        string                        `json:"i"`
+}
+
+func (c reportsCursor) encode() string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeReportsCursor(token string, sort proto.ListReportsRequest_Sort) (*reportsCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	cursor := &reportsCursor{}
+	if err == nil {
+		err = json.Unmarshal(b, cursor)
+	}
+	if err != nil || cursor.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if cursor.Sort != sort {
+		return nil, status.Error(codes.InvalidArgument, "page_token of different sort")
+	}
+	return cursor, nil
+}
+
+// likePattern matching value as substring
+func likePattern(value string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value) + "%"
+}
+
+// reportsFilter conditions of request; only reports user can read are matched,
+// so query text of other reports is never searched
+func (s Server) reportsFilter(email string, req *proto.ListReportsRequest, args *sqlArgs) (string, error) {
+	var conditions []string
+	switch req.Filter {
+	case proto.ListReportsRequest_FILTER_ALL:
+		conditions = append(conditions, "not archived")
+	case proto.ListReportsRequest_FILTER_MINE:
+		conditions = append(conditions, "author_email="+args.add(email), "not archived")
+	case proto.ListReportsRequest_FILTER_SHARED:
+		conditions = append(conditions, s.dialect.DistinctFrom("author_email", args.add(email)), "not archived")
+	case proto.ListReportsRequest_FILTER_ARCHIVED:
+		conditions = append(conditions, "author_email="+args.add(email), "archived")
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown filter %d", req.Filter)
+	}
+	if search := strings.TrimSpace(req.Search); search != "" {
+		// substring match suits SQL text, where identifiers are not words
+		pattern := args.add(likePattern(search))
+		conditions = append(conditions, fmt.Sprintf(
+			"(%s or exists (select 1 from queries where queries.report_id = reports.id and queries.deleted_at is null and %s))",
+			s.dialect.ILike("title", pattern),
+			s.dialect.ILike("queries.query_text", pattern),
+		))
+	}
+	return strings.Join(conditions, " and "), nil
+}
+
+// countReports matching filter, exact up to maxReportsCount
+func (s Server) countReports(ctx context.Context, filter string, args sqlArgs) (int64, bool, error) {
+	var count int64
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select count(*) from (select 1 from reports where %s limit %d) as matched`, filter, maxReportsCount+1),
+		args...,
+	).Scan(&count)
+	if err != nil {
+		return 0, false, err
+	}
+	if count > maxReportsCount {
+		return maxReportsCount, true, nil
+	}
+	return count, false, nil
+}
+
+// ListReports page matching search and filter
+func (s Server) ListReports(ctx context.Context, req *proto.ListReportsRequest) (*proto.ListReportsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultReportsPageSize
+	}
+	if pageSize > maxReportsPageSize {
+		pageSize = maxReportsPageSize
+	}
+	var order string
+	switch req.Sort {
+	case proto.ListReportsRequest_SORT_UPDATED_AT:
+		order = "updated_at desc, id desc"
+	case proto.ListReportsRequest_SORT_TITLE:
+		order = reportTitleSQL + " asc, id asc"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown sort %d", req.Sort)
+	}
+	var cursor *reportsCursor
+	if req.PageToken != "" {
+		var err error
+		cursor, err = decodeReportsCursor(req.PageToken, req.Sort)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var filterArgs sqlArgs
+	filter, err := s.reportsFilter(claims.Email, req, &filterArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append(sqlArgs{}, filterArgs...)
+	conditions := filter
+	if cursor != nil {
+		if req.Sort == proto.ListReportsRequest_SORT_TITLE {
+			conditions += fmt.Sprintf(" and (%s, id) > (%s, %s)", reportTitleSQL, args.add(cursor.Title), s.dialect.UUID(args.add(cursor.ID)))
+		} else {
+			conditions += fmt.Sprintf(
+				" and (updated_at, id) < (%s, %s)",
+				s.dialect.Timestamp(args.add(s.dialect.TimeArg(cursor.UpdatedAt))),
+				s.dialect.UUID(args.add(cursor.ID)),
+			)
+		}
+	}
+	reportRows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`select
+			id,
+			%s as title,
+			archived,
+			author_email = %s as can_write,
+			case when author_email is null then '' else author_email end as author_email,
+			updated_at
+		from reports
+		where %s
+		order by %s
+		limit %s`,
+			reportTitleSQL,
+			args.add(claims.Email),
+			conditions,
+			order,
+			args.add(pageSize+1),
+		),
+		args...,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	defer reportRows.Close()
+	res := &proto.ListReportsResponse{
+		Reports: make([]*proto.Report, 0),
+	}
+	var last reportsCursor
+	for reportRows.Next() {
+		if len(res.Reports) == pageSize {
+			res.NextPageToken = last.encode()
+			break
+		}
+		report := &proto.Report{}
+		var updatedAt time.Time
+		err := reportRows.Scan(
+			&report.Id,
+			&report.Title,
+			&report.Archived,
+			&report.CanWrite,
+			&report.AuthorEmail,
+			&updatedAt,
+		)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		report.UpdatedAt = updatedAt.Unix()
+		res.Reports = append(res.Reports, report)
+		last = reportsCursor{Sort: req.Sort, UpdatedAt: updatedAt, Title: report.Title, ID: report.Id}
+	}
+	if err := reportRows.Err(); err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+
+	if req.PageToken == "" && res.NextPageToken == "" {
+		// single page, no need to count
+		res.TotalCount = int64(len(res.Reports))
+		return res, nil
+	}
+	res.TotalCount, res.TotalCountEstimated, err = s.countReports(ctx, filter, filterArgs)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return res, nil
+}
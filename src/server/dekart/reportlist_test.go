@@ -0,0 +1,116 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var reportListColumns = []string{"id", "title", "archived", "can_write", "author_email", "updated_at"}
+
+func TestLikePattern(t *testing.T) {
+	if p := likePattern(`50%_a\b`); p != `%50\%\_a\\b%` {
+		t.Errorf("unexpected pattern %s", p)
+	}
+}
+
+func TestListReports(t *testing.T) {
+	ctx := testClaimsContext()
+	updatedAt := time.Date(2020, 10, 1, 12, 0, 0, 123000, time.UTC)
+
+	t.Run("single page", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		mock.ExpectQuery("where not archived and \\(title ilike \\$1 or exists \\(select 1 from queries where queries.report_id = reports.id and queries.deleted_at is null and queries.query_text ilike \\$1\\)\\)\\s+order by updated_at desc, id desc\\s+limit \\$3").
+			WithArgs("%table\\_name%", user.UnknownEmail, defaultReportsPageSize+1).
+			WillReturnRows(sqlmock.NewRows(reportListColumns).AddRow(testReportID, "Report", false, false, "other@example.com", updatedAt))
+		res, err := s.ListReports(ctx, &proto.ListReportsRequest{Search: " table_name "})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Reports) != 1 || res.NextPageToken != "" || res.TotalCount != 1 || res.Reports[0].UpdatedAt != updatedAt.Unix() {
+			t.Errorf("unexpected response %v", res)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		mock.ExpectQuery("where author_email=\\$1 and not archived\\s+order by case when title is null then 'Untitled' else title end asc, id asc").
+			WithArgs(user.UnknownEmail, user.UnknownEmail, 2).
+			WillReturnRows(sqlmock.NewRows(reportListColumns).
+				AddRow(testReportID, "A", false, true, user.UnknownEmail, updatedAt).
+				AddRow(testReportID, "B", false, true, user.UnknownEmail, updatedAt))
+		mock.ExpectQuery("select count").
+			WithArgs(user.UnknownEmail).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(maxReportsCount + 1))
+		req := &proto.ListReportsRequest{Filter: proto.ListReportsRequest_FILTER_MINE, Sort: proto.ListReportsRequest_SORT_TITLE, PageSize: 1}
+		res, err := s.ListReports(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Reports) != 1 || res.NextPageToken == "" || res.TotalCount != maxReportsCount || !res.TotalCountEstimated {
+			t.Errorf("unexpected response %v", res)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+
+		mock.ExpectQuery("and \\(case when title is null then 'Untitled' else title end, id\\) > \\(\\$2, \\$3::uuid\\)").
+			WithArgs(user.UnknownEmail, "A", testReportID, user.UnknownEmail, 2).
+			WillReturnRows(sqlmock.NewRows(reportListColumns))
+		mock.ExpectQuery("select count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		req.PageToken = res.NextPageToken
+		res, err = s.ListReports(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Reports) != 0 || res.NextPageToken != "" || res.TotalCount != 1 || res.TotalCountEstimated {
+			t.Errorf("unexpected response %v", res)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+
+		// token is bound to sort
+		req.Sort = proto.ListReportsRequest_SORT_UPDATED_AT
+		if _, err := s.ListReports(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected InvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("updated at cursor", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		token := reportsCursor{UpdatedAt: updatedAt, ID: testReportID}.encode()
+		mock.ExpectQuery("where author_email is distinct from \\$1 and not archived and \\(updated_at, id\\) < \\(\\$2::timestamptz, \\$3::uuid\\)").
+			WithArgs(user.UnknownEmail, updatedAt, testReportID, user.UnknownEmail, defaultReportsPageSize+1).
+			WillReturnRows(sqlmock.NewRows(reportListColumns))
+		mock.ExpectQuery("select count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		_, err := s.ListReports(ctx, &proto.ListReportsRequest{Filter: proto.ListReportsRequest_FILTER_SHARED, PageToken: token})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		s, _ := newTestServer(t)
+		for _, req := range []*proto.ListReportsRequest{
+			{PageToken: "not a token"},
+			{Filter: 10},
+			{Sort: 10},
+		} {
+			if _, err := s.ListReports(ctx, req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("%v: expected InvalidArgument, got %v", req, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+)
+
+// storedResultMeta in result record, null without meta
+func storedResultMeta(meta *job.ResultMeta) (*string, error) {
+	value, err := job.MarshalResultMeta(meta)
+	if err != nil || value == "" {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// protoResultMeta of result record
+func protoResultMeta(meta *job.ResultMeta) *proto.ResultMeta {
+	if meta == nil {
+		return nil
+	}
+	res := &proto.ResultMeta{
+		Id:             meta.ID,
+		Format:         meta.Format,
+		Compression:    meta.Compression,
+		Size:           meta.Size,
+		RowCount:       meta.Rows,
+		Checksum:       meta.Checksum,
+		CreatedAt:      meta.CreatedAt.Unix(),
+		Truncated:      meta.Truncated,
+		Sampled:        meta.Sampled,
+		Ordered:        meta.Ordered,
+		StorageBackend: meta.Storage,
+		Bucket:         meta.Bucket,
+	}
+	for _, part := range meta.Parts {
+		res.Parts = append(res.Parts, &proto.ResultPart{Id: part.ID, Kind: part.Kind, Format: part.Format})
+	}
+	return res
+}
+
+// legacyResultMeta of result stored before meta was recorded, built from deprecated result fields of query
+func legacyResultMeta(query *proto.Query) *proto.ResultMeta {
+	if query.JobResultId == "" {
+		return nil
+	}
+	meta := &proto.ResultMeta{
+		Id:             query.JobResultId,
+		Format:         query.ResultFormat,
+		Compression:    query.ResultCompression,
+		Size:           query.ResultSize,
+		RowCount:       query.RowsWritten,
+		CreatedAt:      query.ResultCreatedAt,
+		Truncated:      query.ExplorationLimit > 0,
+		Sampled:        query.SampleRate > 0,
+		StorageBackend: job.StorageGCS,
+	}
+	for _, r := range query.FormatResults {
+		meta.Parts = append(meta.Parts, &proto.ResultPart{Id: r.ResultId, Kind: job.ResultPartFormat, Format: r.Format})
+	}
+	if query.H3ResultId != "" {
+		meta.Parts = append(meta.Parts, &proto.ResultPart{Id: query.H3ResultId, Kind: job.ResultPartH3, Format: proto.ResultFormat_RESULT_FORMAT_CSV})
+	}
+	return meta
+}
+
+// resultMeta of result record, nil for result stored before meta was recorded
+func (s Server) resultMeta(ctx context.Context, resultID string) (*job.ResultMeta, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select case when meta is null then '' else meta end from results where id=$1`,
+		resultID,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job.UnmarshalResultMeta(value)
+}
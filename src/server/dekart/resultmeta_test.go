@@ -0,0 +1,41 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestGetQueriesResultMeta(t *testing.T) {
+	s, mock := newTestServer(t)
+	const legacyResultID = "2b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"
+	meta := `{"id":"` + testQueryID + `","format":2,"size":12,"rows":2,"checksum":"AAAAAA==","createdAt":"2021-03-04T05:06:07Z","storage":"gcs","bucket":"results"}`
+	mock.ExpectQuery("from queries where report_id").
+		WillReturnRows(sqlmock.NewRows(queryColumns).
+			AddRow(testQueryID, "select 1", int32(proto.Query_JOB_STATUS_DONE), testQueryID, "", 0, 2, 1, 12, 2, 0, "", "Query 1", "select 1", "", "", 1614834367, 1, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", meta).
+			// result stored before meta was recorded
+			AddRow(legacyResultID, "select 2", int32(proto.Query_JOB_STATUS_DONE), legacyResultID, "", 0, 5, 1, 40, 5, 0.5, "", "Query 2", "select 2", "", "", 1614834367, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+	queries, err := s.getQueries(context.Background(), testReportID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// old clients read deprecated fields, which are filled for both results
+	for i, size := range []int64{12, 40} {
+		if queries[i].JobResultId == "" || queries[i].ResultSize != size {
+			t.Errorf("expected deprecated fields of query %d, got %s %d", i, queries[i].JobResultId, queries[i].ResultSize)
+		}
+	}
+	recorded := queries[0].ResultMeta
+	if recorded.GetId() != testQueryID || recorded.Checksum != "AAAAAA==" || recorded.Bucket != "results" || recorded.RowCount != 2 || recorded.Format != proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		t.Errorf("unexpected recorded meta %+v", recorded)
+	}
+	legacy := queries[1].ResultMeta
+	if legacy.GetId() != legacyResultID || legacy.Size != 40 || legacy.RowCount != 5 || !legacy.Sampled || legacy.Checksum != "" || legacy.CreatedAt != 1614834367 {
+		t.Errorf("unexpected legacy meta %+v", legacy)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,184 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// parseResultSettings of DEKART_RESULT_FORMAT (csv or parquet), DEKART_RESULT_COMPRESSION (none or gzip)
+// and DEKART_RESULT_DELIMITER; empty values keep CSV without compression separated by comma
+func parseResultSettings(format string, compression string, delimiter string) (*proto.ResultSettings, error) {
+	settings := &proto.ResultSettings{Delimiter: delimiter}
+	switch strings.ToLower(format) {
+	case "":
+	case "csv":
+		settings.ResultFormat = proto.ResultFormat_RESULT_FORMAT_CSV
+	case "parquet":
+		settings.ResultFormat = proto.ResultFormat_RESULT_FORMAT_PARQUET
+	default:
+		return nil, fmt.Errorf("invalid DEKART_RESULT_FORMAT %s, expected csv or parquet", format)
+	}
+	switch strings.ToLower(compression) {
+	case "":
+	case "none":
+		settings.ResultCompression = proto.ResultCompression_RESULT_COMPRESSION_NONE
+	case "gzip":
+		settings.ResultCompression = proto.ResultCompression_RESULT_COMPRESSION_GZIP
+	default:
+		return nil, fmt.Errorf("invalid DEKART_RESULT_COMPRESSION %s, expected none or gzip", compression)
+	}
+	if err := validateResultSettings(settings); err != nil {
+		return nil, fmt.Errorf("invalid DEKART_RESULT_* settings: %w", err)
+	}
+	return settings, nil
+}
+
+func defaultResultSettings() *proto.ResultSettings {
+	settings, err := parseResultSettings(
+		os.Getenv("DEKART_RESULT_FORMAT"),
+		os.Getenv("DEKART_RESULT_COMPRESSION"),
+		os.Getenv("DEKART_RESULT_DELIMITER"),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return settings
+}
+
+// validateResultSettings of report or instance, nil settings are valid
+func validateResultSettings(settings *proto.ResultSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if err := job.ValidateResultFormat(settings.ResultFormat); err != nil {
+		return err
+	}
+	if err := job.ValidateResultCompression(settings.ResultCompression, settings.ResultFormat); err != nil {
+		return err
+	}
+	if settings.Delimiter != "" && settings.ResultFormat == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		return fmt.Errorf("delimiter applies only to CSV result")
+	}
+	return job.ValidateSerialization(&proto.SerializationOptions{Delimiter: settings.Delimiter})
+}
+
+// runResultSettings of run request; serialization options and compression are only for CSV, so they set CSV format of run
+func runResultSettings(format proto.ResultFormat, compression proto.ResultCompression, serialization *proto.SerializationOptions) *proto.ResultSettings {
+	settings := &proto.ResultSettings{ResultFormat: format, ResultCompression: compression}
+	if serialization != nil {
+		settings.Delimiter = serialization.Delimiter
+	}
+	csvOnly := serialization != nil || compression == proto.ResultCompression_RESULT_COMPRESSION_GZIP
+	if csvOnly && format == proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+		settings.ResultFormat = proto.ResultFormat_RESULT_FORMAT_CSV
+	}
+	return settings
+}
+
+// effectiveResultSettings of run; each field is taken from run request, then report, then instance, first which is set.
+// Fields which are set nowhere stay unspecified
+func effectiveResultSettings(run, report, instance *proto.ResultSettings) *proto.ResultSettings {
+	effective := &proto.ResultSettings{}
+	for _, settings := range []*proto.ResultSettings{run, report, instance} {
+		if settings == nil {
+			continue
+		}
+		if effective.ResultFormat == proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+			effective.ResultFormat = settings.ResultFormat
+		}
+		if effective.ResultCompression == proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED {
+			effective.ResultCompression = settings.ResultCompression
+		}
+		if effective.Delimiter == "" {
+			effective.Delimiter = settings.Delimiter
+		}
+	}
+	if effective.ResultFormat == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		// compression and delimiter of report or instance apply to its CSV results
+		effective.ResultCompression = proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED
+		effective.Delimiter = ""
+	}
+	return effective
+}
+
+// applyResultSettings of run request over settings of report and instance to run options of source;
+// result in destination table has no result file
+func (source *queryJobSource) applyResultSettings(run *proto.ResultSettings, serialization *proto.SerializationOptions, instance *proto.ResultSettings) {
+	if source.destination != nil {
+		return
+	}
+	effective := effectiveResultSettings(run, source.resultSettings, instance)
+	source.options.ResultFormat = effective.ResultFormat
+	source.options.Compression = effective.ResultCompression
+	source.options.Serialization = serialization
+	if serialization != nil {
+		if serialization.Delimiter == "" && effective.Delimiter != "" {
+			// options of request are not modified, all its other options are kept
+			options := protobuf.Clone(serialization).(*proto.SerializationOptions)
+			options.Delimiter = effective.Delimiter
+			source.options.Serialization = options
+		}
+	} else if effective.Delimiter != "" && effective.Delimiter != "," {
+		// default serialization writes NULL as <nil>, only delimiter differs
+		source.options.Serialization = &proto.SerializationOptions{Delimiter: effective.Delimiter, NullToken: "<nil>"}
+	}
+}
+
+// UpdateReportSettings replaces result settings of report, they apply to next runs of its queries
+func (s Server) UpdateReportSettings(ctx context.Context, req *proto.UpdateReportSettingsRequest) (*proto.UpdateReportSettingsResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := validateResultSettings(req.ResultSettings); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	settings := req.ResultSettings
+	if settings == nil {
+		settings = &proto.ResultSettings{}
+	}
+	result, err := s.db.ExecContext(ctx,
+		`update reports set result_format=$1, result_compression=$2, result_delimiter=$3, updated_at=CURRENT_TIMESTAMP
+		where id=$4 and author_email=$5 and not archived`,
+		int32(settings.ResultFormat),
+		int32(settings.ResultCompression),
+		settings.Delimiter,
+		req.ReportId,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
+	return &proto.UpdateReportSettingsResponse{}, nil
+}
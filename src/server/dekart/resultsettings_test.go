@@ -0,0 +1,76 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"testing"
+)
+
+func TestParseResultSettings(t *testing.T) {
+	settings, err := parseResultSettings("", "", "")
+	if err != nil || settings.ResultFormat != proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED || settings.ResultCompression != proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED {
+		t.Errorf("expected unspecified defaults, got %v %v", settings, err)
+	}
+	settings, err = parseResultSettings("CSV", "gzip", "\t")
+	if err != nil || settings.ResultFormat != proto.ResultFormat_RESULT_FORMAT_CSV || settings.ResultCompression != proto.ResultCompression_RESULT_COMPRESSION_GZIP || settings.Delimiter != "\t" {
+		t.Errorf("expected gzip TSV, got %v %v", settings, err)
+	}
+	for _, c := range [][3]string{{"json", "", ""}, {"", "zstd", ""}, {"parquet", "gzip", ""}, {"", "", "ab"}} {
+		if _, err := parseResultSettings(c[0], c[1], c[2]); err == nil {
+			t.Errorf("expected error of %q", c)
+		}
+	}
+}
+
+func TestEffectiveResultSettings(t *testing.T) {
+	csv := proto.ResultFormat_RESULT_FORMAT_CSV
+	parquet := proto.ResultFormat_RESULT_FORMAT_PARQUET
+	none := proto.ResultCompression_RESULT_COMPRESSION_NONE
+	gzip := proto.ResultCompression_RESULT_COMPRESSION_GZIP
+	instance := &proto.ResultSettings{ResultFormat: csv, ResultCompression: gzip, Delimiter: "|"}
+	for _, c := range []struct {
+		name     string
+		run      *proto.ResultSettings
+		report   *proto.ResultSettings
+		instance *proto.ResultSettings
+		expected *proto.ResultSettings
+	}{
+		{"built-in", &proto.ResultSettings{}, nil, nil, &proto.ResultSettings{}},
+		{"instance", &proto.ResultSettings{}, &proto.ResultSettings{}, instance, instance},
+		{"report over instance", &proto.ResultSettings{}, &proto.ResultSettings{ResultCompression: none, Delimiter: ";"}, instance, &proto.ResultSettings{ResultFormat: csv, ResultCompression: none, Delimiter: ";"}},
+		{"run over report", &proto.ResultSettings{ResultCompression: gzip, Delimiter: "\t"}, &proto.ResultSettings{ResultCompression: none, Delimiter: ";"}, nil, &proto.ResultSettings{ResultCompression: gzip, Delimiter: "\t"}},
+		{"report Parquet", &proto.ResultSettings{}, &proto.ResultSettings{ResultFormat: parquet}, instance, &proto.ResultSettings{ResultFormat: parquet}},
+		{"run CSV over report Parquet", runResultSettings(0, gzip, nil), &proto.ResultSettings{ResultFormat: parquet}, nil, &proto.ResultSettings{ResultFormat: csv, ResultCompression: gzip}},
+		{"run Parquet over report gzip", runResultSettings(parquet, 0, nil), &proto.ResultSettings{ResultCompression: gzip, Delimiter: ";"}, instance, &proto.ResultSettings{ResultFormat: parquet}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			effective := effectiveResultSettings(c.run, c.report, c.instance)
+			if effective.ResultFormat != c.expected.ResultFormat || effective.ResultCompression != c.expected.ResultCompression || effective.Delimiter != c.expected.Delimiter {
+				t.Errorf("expected %v, got %v", c.expected, effective)
+			}
+		})
+	}
+}
+
+func TestApplyResultSettings(t *testing.T) {
+	report := &proto.ResultSettings{Delimiter: ";"}
+	source := queryJobSource{resultSettings: report}
+	source.applyResultSettings(runResultSettings(0, 0, nil), nil, nil)
+	if options := source.options.Serialization; options == nil || options.Delimiter != ";" || options.NullToken != "<nil>" {
+		t.Errorf("expected default serialization with delimiter of report, got %v", options)
+	}
+
+	// other serialization options of request keep delimiter of report
+	requested := &proto.SerializationOptions{NullToken: "NULL", NumericTrailingZeros: true, BytesEncoding: proto.SerializationOptions_BYTES_ENCODING_HEX}
+	source.applyResultSettings(runResultSettings(0, 0, requested), requested, nil)
+	if options := source.options.Serialization; options.Delimiter != ";" || options.NullToken != "NULL" || requested.Delimiter != "" ||
+		!options.NumericTrailingZeros || options.BytesEncoding != proto.SerializationOptions_BYTES_ENCODING_HEX {
+		t.Errorf("expected options of request with delimiter of report, got %v, request %v", options, requested)
+	}
+
+	// result in destination table has no result file
+	source = queryJobSource{resultSettings: &proto.ResultSettings{ResultFormat: proto.ResultFormat_RESULT_FORMAT_PARQUET}, destination: &proto.DestinationTable{Table: "d.t"}}
+	source.applyResultSettings(runResultSettings(0, 0, nil), nil, nil)
+	if source.options.ResultFormat != proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+		t.Errorf("expected no result format of destination run, got %v", source.options.ResultFormat)
+	}
+}
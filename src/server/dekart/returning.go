@@ -0,0 +1,31 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+)
+
+type execQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// scanReturning scans column returned by insert or update statement; when database has no RETURNING
+// statement is executed and column is selected with selectQuery instead, sql.ErrNoRows when no row is changed
+func (s Server) scanReturning(ctx context.Context, q execQueryer, statement string, args []interface{}, column string, selectQuery string, selectArgs []interface{}, dest interface{}) error {
+	if s.dialect.Returning() {
+		return q.QueryRowContext(ctx, statement+"\n\t\treturning "+column, args...).Scan(dest)
+	}
+	result, err := q.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return q.QueryRowContext(ctx, selectQuery, selectArgs...).Scan(dest)
+}
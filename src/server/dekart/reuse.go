@@ -0,0 +1,228 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/report"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultReuseTTL of source results reused by forks, same as BigQuery keeps cached results
+const defaultReuseTTL = 24 * time.Hour
+
+// parseReuseTTL of DEKART_RESULT_REUSE_TTL, zero disables reuse
+func parseReuseTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultReuseTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid DEKART_RESULT_REUSE_TTL %s, expected duration, 0 disables reuse", value)
+	}
+	return ttl, nil
+}
+
+func reuseTTL() time.Duration {
+	ttl, err := parseReuseTTL(os.Getenv("DEKART_RESULT_REUSE_TTL"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return ttl
+}
+
+// sourceResult of query in source report of fork
+type sourceResult struct {
+	queryID           string
+	resultID          string
+	executedQueryText string
+	defaultDataset    string
+	sampleRate        float64
+	totalRows         int64
+	resultSize        int64
+	rowsWritten       int64
+	resultFormat      proto.ResultFormat
+	// serialization of CSV result as stored in result record
+	serialization string
+	compression   proto.ResultCompression
+	// useLegacySQL when executed query text was run in legacy SQL
+	useLegacySQL bool
+	// warnings of source job, they apply to reused result
+	warnings string
+	// createdAt of result in unix seconds
+	createdAt int64
+	// readable is false when source report was archived, its results can't be read by other users
+	readable bool
+	expired  bool
+}
+
+// reusable result for run of source; reason is empty when result is reused
+func (r *sourceResult) reusable(source queryJobSource, now time.Time, ttl time.Duration) string {
+	switch {
+	case !r.readable:
+		return "source report is not readable"
+	case r.expired:
+		return "source result expired"
+	case now.Sub(time.Unix(r.createdAt, 0)) > ttl:
+		return "source result is older than DEKART_RESULT_REUSE_TTL"
+	case r.executedQueryText != source.queryText:
+		return "query text differs from source"
+	case r.defaultDataset != source.options.DefaultDataset:
+		return "default dataset differs from source"
+	case r.sampleRate != source.options.SampleRate:
+		return "sample rate differs from source"
+	case resultExtension(r.resultFormat) != resultExtension(source.options.ResultFormat):
+		return "result format differs from source"
+	case r.serialization != source.serialization():
+		return "serialization options differ from source"
+	case compressed(r.compression) != compressed(source.options.Compression):
+		return "result compression differs from source"
+	case r.useLegacySQL != source.options.UseLegacySQL:
+		return "SQL dialect differs from source"
+	}
+	return ""
+}
+
+// getSourceResult of source query, nil when source was removed or has no result file
+func (s Server) getSourceResult(ctx context.Context, sourceQueryID string) (*sourceResult, error) {
+	r := &sourceResult{}
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select
+			%s,
+			%s,
+			source.executed_query_text,
+			case when reports.default_dataset is null then '' else reports.default_dataset end,
+			source.sample_rate,
+			source.total_rows,
+			source.result_size,
+			source.rows_written,
+			coalesce(source.result_format, 0),
+			case when source.job_warnings is null then '' else source.job_warnings end,
+			%s,
+			not reports.archived,
+			case when results.expired_at is null then false else true end,
+			case when results.serialization is null then '' else results.serialization end,
+			coalesce(source.executed_use_legacy_sql, false),
+			coalesce(results.compression, 0)
+		from queries source
+		join reports on reports.id = source.report_id
+		join results on results.id = source.job_result_id
+		where source.id=$1 and source.job_status=$2
+			and source.h3_result_id is null and source.executed_query_text is not null`,
+			s.dialect.Text("source.id"),
+			s.dialect.Text("source.job_result_id"),
+			s.dialect.Int(s.dialect.Epoch("results.created_at")),
+		),
+		sourceQueryID,
+		int32(proto.Query_JOB_STATUS_DONE),
+	).Scan(
+		&r.queryID,
+		&r.resultID,
+		&r.executedQueryText,
+		&r.defaultDataset,
+		&r.sampleRate,
+		&r.totalRows,
+		&r.resultSize,
+		&r.rowsWritten,
+		&r.resultFormat,
+		&r.warnings,
+		&r.createdAt,
+		&r.readable,
+		&r.expired,
+		&r.serialization,
+		&r.useLegacySQL,
+		&r.compression,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reuseSourceResult on first run of forked query instead of running byte-identical query text again;
+// false when query should be run
+func (s Server) reuseSourceResult(ctx context.Context, queryID string, source queryJobSource) (bool, error) {
+	// values of parameters are not part of executed query text, result of other values can't be told apart;
+	// credentials of connection can read other tables than credentials source query was run with; source result is not snapshot
+	if source.sourceQueryID == "" || s.reuseTTL == 0 || source.h3 != nil || source.destination != nil || len(source.options.Parameters) > 0 ||
+		source.options.Connection != "" || len(source.additionalFormats) > 0 || !source.options.AsOf.IsZero() {
+		return false, nil
+	}
+	r, err := s.getSourceResult(ctx, source.sourceQueryID)
+	if err != nil || r == nil {
+		return false, err
+	}
+	if reason := r.reusable(source, time.Now(), s.reuseTTL); reason != "" {
+		log.Debug().Str("queryID", queryID).Str("sourceQueryID", r.queryID).Str("reason", reason).Msg("Source result not reused")
+		return false, nil
+	}
+	// query which started running concurrently keeps its job;
+	// reused result is never wrapped in exploration LIMIT, executed text of wrapped source differs from query text
+	result, err := s.db.ExecContext(ctx,
+		`update queries set
+			job_status = $1,
+			job_error = '',
+			job_error_explanation = null,
+			job_result_id = $2,
+			total_rows = $3,
+			bytes_processed = 0,
+			result_size = $4,
+			rows_written = $5,
+			sample_rate = $6,
+			h3_result_id = null,
+			format_results = null,
+			executed_query_text = $7,
+			result_table = null,
+			reused_from_query_id = $8,
+			result_format = $9,
+			exploration_limit = 0,
+			executed_default_dataset = $10,
+			job_warnings = $11,
+			batch_id = $12,
+			skipped_dependency_id = null,
+			job_plan = null,
+			executed_use_legacy_sql = $14
+		where id = $13 and job_status = 0`,
+		int32(proto.Query_JOB_STATUS_DONE),
+		r.resultID,
+		r.totalRows,
+		r.resultSize,
+		r.rowsWritten,
+		r.sampleRate,
+		r.executedQueryText,
+		r.queryID,
+		int32(r.resultFormat),
+		s.jobs.DefaultDataset(r.defaultDataset),
+		r.warnings,
+		nullID(source.batchID),
+		queryID,
+		r.useLegacySQL,
+	)
+	if err != nil {
+		return false, err
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil || affectedRows == 0 {
+		return false, err
+	}
+	log.Info().
+		Str("queryID", queryID).
+		Str("sourceQueryID", r.queryID).
+		Str("resultID", r.resultID).
+		Dur("age", time.Since(time.Unix(r.createdAt, 0))).
+		Msg("Source result reused")
+	s.reportStreams.Publish(report.Event{
+		ReportID:  source.reportID,
+		Kind:      report.JobStatusChanged,
+		QueryID:   queryID,
+		JobStatus: int32(proto.Query_JOB_STATUS_DONE),
+	})
+	return true, nil
+}
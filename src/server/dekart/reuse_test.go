@@ -0,0 +1,65 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"testing"
+	"time"
+)
+
+func TestParseReuseTTL(t *testing.T) {
+	for value, expected := range map[string]time.Duration{"": defaultReuseTTL, "0": 0, "90m": 90 * time.Minute} {
+		ttl, err := parseReuseTTL(value)
+		if err != nil || ttl != expected {
+			t.Errorf("%q: expected %s, got %s %v", value, expected, ttl, err)
+		}
+	}
+	for _, value := range []string{"-1h", "day"} {
+		if _, err := parseReuseTTL(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestSourceResultReusable(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	fresh := func() *sourceResult {
+		return &sourceResult{
+			executedQueryText: "select 1",
+			createdAt:         now.Add(-time.Hour).Unix(),
+			readable:          true,
+		}
+	}
+	source := queryJobSource{queryText: "select 1"}
+	if reason := fresh().reusable(source, now, 2*time.Hour); reason != "" {
+		t.Errorf("expected reused, got %s", reason)
+	}
+	// unspecified format is CSV
+	csvSource := source
+	csvSource.options.ResultFormat = proto.ResultFormat_RESULT_FORMAT_CSV
+	if reason := fresh().reusable(csvSource, now, 2*time.Hour); reason != "" {
+		t.Errorf("expected CSV result reused, got %s", reason)
+	}
+	for name, c := range map[string]struct {
+		update func(r *sourceResult, source *queryJobSource)
+		ttl    time.Duration
+	}{
+		"archived source": {func(r *sourceResult, source *queryJobSource) { r.readable = false }, 2 * time.Hour},
+		"expired object":  {func(r *sourceResult, source *queryJobSource) { r.expired = true }, 2 * time.Hour},
+		"older than ttl":  {func(r *sourceResult, source *queryJobSource) {}, 30 * time.Minute},
+		"query drift":     {func(r *sourceResult, source *queryJobSource) { source.queryText = "select 1 " }, 2 * time.Hour},
+		"dataset drift":   {func(r *sourceResult, source *queryJobSource) { source.options.DefaultDataset = "other" }, 2 * time.Hour},
+		"sampled":         {func(r *sourceResult, source *queryJobSource) { source.options.SampleRate = 0.1 }, 2 * time.Hour},
+		"format drift": {func(r *sourceResult, source *queryJobSource) {
+			source.options.ResultFormat = proto.ResultFormat_RESULT_FORMAT_PARQUET
+		}, 2 * time.Hour},
+		"serialization drift": {func(r *sourceResult, source *queryJobSource) {
+			source.options.Serialization = &proto.SerializationOptions{Delimiter: "\t"}
+		}, 2 * time.Hour},
+	} {
+		r, s := fresh(), source
+		c.update(r, &s)
+		if reason := r.reusable(s, now, c.ttl); reason == "" {
+			t.Errorf("%s: expected result not reused", name)
+		}
+	}
+}
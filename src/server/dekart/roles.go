@@ -0,0 +1,236 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parseDefaultRole of authenticated users without role assignment, DEKART_DEFAULT_ROLE is editor or viewer
+func parseDefaultRole(value string) (proto.Role, error) {
+	switch strings.ToLower(value) {
+	case "viewer":
+		return proto.Role_ROLE_VIEWER, nil
+	case "", "editor":
+		return proto.Role_ROLE_EDITOR, nil
+	default:
+		return proto.Role_ROLE_UNSPECIFIED, fmt.Errorf("unknown DEKART_DEFAULT_ROLE %s, expected editor or viewer", value)
+	}
+}
+
+// isAdminEmail listed in DEKART_ADMIN_EMAILS, so instance can't be locked out of role management
+func isAdminEmail(email string) bool {
+	for _, admin := range strings.Split(os.Getenv("DEKART_ADMIN_EMAILS"), ",") {
+		admin = strings.TrimSpace(admin)
+		if admin != "" && strings.EqualFold(admin, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRole of email; exact match wins, otherwise longest matching pattern
+func matchRole(email string, assignments []*proto.RoleAssignment, defaultRole proto.Role) proto.Role {
+	email = strings.ToLower(email)
+	role := defaultRole
+	matched := ""
+	for _, a := range assignments {
+		pattern := strings.ToLower(a.EmailPattern)
+		if pattern == email {
+			return a.Role
+		}
+		ok, err := path.Match(pattern, email)
+		if err != nil || !ok {
+			continue
+		}
+		if len(pattern) > len(matched) {
+			matched = pattern
+			role = a.Role
+		}
+	}
+	return role
+}
+
+func (s Server) getRoleAssignments(ctx context.Context) ([]*proto.RoleAssignment, error) {
+	rows, err := s.db.QueryContext(ctx, `select email_pattern, role from instance_roles order by email_pattern`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	assignments := make([]*proto.RoleAssignment, 0)
+	for rows.Next() {
+		var pattern, name string
+		err := rows.Scan(&pattern, &name)
+		if err != nil {
+			return nil, err
+		}
+		role, ok := proto.Role_value[name]
+		if !ok || role == int32(proto.Role_ROLE_UNSPECIFIED) {
+			// assignment is skipped, so it can't grant or revoke access
+			log.Warn().Str("emailPattern", pattern).Msgf("Unknown role %s in instance_roles", name)
+			continue
+		}
+		assignments = append(assignments, &proto.RoleAssignment{
+			EmailPattern: pattern,
+			Role:         proto.Role(role),
+		})
+	}
+	return assignments, rows.Err()
+}
+
+// userRole resolved on every request so role changes apply without restart
+func (s Server) userRole(ctx context.Context, email string) (proto.Role, error) {
+	if isAdminEmail(email) {
+		return proto.Role_ROLE_ADMIN, nil
+	}
+	assignments, err := s.getRoleAssignments(ctx)
+	if err != nil {
+		return proto.Role_ROLE_UNSPECIFIED, err
+	}
+	return matchRole(email, assignments, s.defaultRole), nil
+}
+
+// requireRole returns grpc error when user has lower role than required
+func (s Server) requireRole(ctx context.Context, required proto.Role) error {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return Unauthenticated
+	}
+	if required == proto.Role_ROLE_EDITOR {
+		// editing requires writable instance, admins can't edit read-only instance either
+		if err := s.requireWritable(); err != nil {
+			return err
+		}
+	}
+	role, err := s.userRole(ctx, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if role < required {
+		err := fmt.Errorf("%s requires %s, has %s", claims.Email, required, role)
+		log.Warn().Err(err).Send()
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// RequireAdmin returns grpc error when user is not admin, used by http endpoints outside of API
+func (s Server) RequireAdmin(ctx context.Context) error {
+	return s.requireRole(ctx, proto.Role_ROLE_ADMIN)
+}
+
+// GetCurrentUser with resolved instance role
+func (s Server) GetCurrentUser(ctx context.Context, req *proto.GetCurrentUserRequest) (*proto.GetCurrentUserResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	role, err := s.userRole(ctx, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	quota, err := s.currentQuota(ctx)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if quota != nil {
+		// users see headroom of budget, usage in bytes is reported to admins by GetUsage
+		quota = &proto.UsageQuota{HeadroomPercent: quota.HeadroomPercent, ResetTime: quota.ResetTime}
+	}
+	return &proto.GetCurrentUserResponse{
+		Email: claims.Email,
+		Role:  role,
+		Quota: quota,
+	}, nil
+}
+
+// ListRoleAssignments for admins
+func (s Server) ListRoleAssignments(ctx context.Context, req *proto.ListRoleAssignmentsRequest) (*proto.ListRoleAssignmentsResponse, error) {
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	assignments, err := s.getRoleAssignments(ctx)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return &proto.ListRoleAssignmentsResponse{
+		RoleAssignments: assignments,
+	}, nil
+}
+
+func validateEmailPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("email_pattern is required")
+	}
+	_, err := path.Match(pattern, "")
+	if err != nil {
+		return fmt.Errorf("invalid email_pattern %s: %w", pattern, err)
+	}
+	return nil
+}
+
+// SetRoleAssignment creates or updates role of email pattern
+func (s Server) SetRoleAssignment(ctx context.Context, req *proto.SetRoleAssignmentRequest) (*proto.SetRoleAssignmentResponse, error) {
+	if err := s.requireWritable(); err != nil {
+		return nil, err
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	if req.RoleAssignment == nil {
+		return nil, status.Error(codes.InvalidArgument, "role_assignment is required")
+	}
+	pattern := strings.ToLower(strings.TrimSpace(req.RoleAssignment.EmailPattern))
+	if err := validateEmailPattern(pattern); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, ok := proto.Role_name[int32(req.RoleAssignment.Role)]; !ok || req.RoleAssignment.Role == proto.Role_ROLE_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role %s", req.RoleAssignment.Role)
+	}
+	_, err := s.db.ExecContext(ctx,
+		`insert into instance_roles (email_pattern, role) values ($1, $2)
+		`+s.dialect.OnConflict([]string{"email_pattern"}, "role = "+s.dialect.Excluded("role")+", updated_at = CURRENT_TIMESTAMP"),
+		pattern,
+		req.RoleAssignment.Role.String(),
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return &proto.SetRoleAssignmentResponse{}, nil
+}
+
+// RemoveRoleAssignment of email pattern, matching users fall back to other patterns or default role
+func (s Server) RemoveRoleAssignment(ctx context.Context, req *proto.RemoveRoleAssignmentRequest) (*proto.RemoveRoleAssignmentResponse, error) {
+	if err := s.requireWritable(); err != nil {
+		return nil, err
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	pattern := strings.ToLower(strings.TrimSpace(req.EmailPattern))
+	result, err := s.db.ExecContext(ctx, `delete from instance_roles where email_pattern=$1`, pattern)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, _ := result.RowsAffected()
+	if affectedRows == 0 {
+		err := fmt.Errorf("Role assignment %s not found", pattern)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &proto.RemoveRoleAssignmentResponse{}, nil
+}
@@ -0,0 +1,101 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMatchRole(t *testing.T) {
+	assignments := []*proto.RoleAssignment{
+		{EmailPattern: "*@example.com", Role: proto.Role_ROLE_VIEWER},
+		{EmailPattern: "*@data.example.com", Role: proto.Role_ROLE_EDITOR},
+		{EmailPattern: "boss@example.com", Role: proto.Role_ROLE_ADMIN},
+		{EmailPattern: "[", Role: proto.Role_ROLE_ADMIN},
+	}
+	for email, expected := range map[string]proto.Role{
+		"someone@example.com":      proto.Role_ROLE_VIEWER,
+		"analyst@data.example.com": proto.Role_ROLE_EDITOR,
+		"Boss@Example.com":         proto.Role_ROLE_ADMIN,
+		"outsider@other.com":       proto.Role_ROLE_EDITOR,
+	} {
+		if role := matchRole(email, assignments, proto.Role_ROLE_EDITOR); role != expected {
+			t.Errorf("%s: expected %s, got %s", email, expected, role)
+		}
+	}
+	if role := matchRole("outsider@other.com", nil, proto.Role_ROLE_VIEWER); role != proto.Role_ROLE_VIEWER {
+		t.Errorf("expected default role, got %s", role)
+	}
+}
+
+func TestParseDefaultRole(t *testing.T) {
+	for value, expected := range map[string]proto.Role{
+		"":       proto.Role_ROLE_EDITOR,
+		"editor": proto.Role_ROLE_EDITOR,
+		"Viewer": proto.Role_ROLE_VIEWER,
+	} {
+		role, err := parseDefaultRole(value)
+		if err != nil || role != expected {
+			t.Errorf("%q: expected %s, got %s %v", value, expected, role, err)
+		}
+	}
+	for _, value := range []string{"admin", "owner"} {
+		if _, err := parseDefaultRole(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestUnknownRoleAssignmentSkipped(t *testing.T) {
+	s, mock := newTestServer(t)
+	mock.ExpectQuery("from instance_roles").WillReturnRows(sqlmock.NewRows([]string{"email_pattern", "role"}).
+		AddRow("*@example.com", "ROLE_OWNER").
+		AddRow("viewer@example.com", "ROLE_VIEWER"))
+	assignments, err := s.getRoleAssignments(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assignments) != 1 || assignments[0].EmailPattern != "viewer@example.com" {
+		t.Errorf("expected unknown role skipped, got %v", assignments)
+	}
+}
+
+func TestViewerPermissions(t *testing.T) {
+	os.Setenv("DEKART_DEFAULT_ROLE", "viewer")
+	defer os.Unsetenv("DEKART_DEFAULT_ROLE")
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+	expectNoAssignments := func() {
+		mock.ExpectQuery("from instance_roles").WillReturnRows(sqlmock.NewRows([]string{"email_pattern", "role"}))
+	}
+
+	expectNoAssignments()
+	_, err := s.RunQuery(ctx, &proto.RunQueryRequest{QueryId: testQueryID})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("RunQuery: expected PermissionDenied, got %v", err)
+	}
+	expectNoAssignments()
+	_, err = s.CreateReport(ctx, &proto.CreateReportRequest{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("CreateReport: expected PermissionDenied, got %v", err)
+	}
+
+	expectTestReport(mock)
+	expectTestFiles(mock)
+	expectTestQueries(mock)
+	res, err := s.GetReport(ctx, &proto.GetReportRequest{ReportId: testReportID})
+	if err != nil {
+		t.Fatalf("GetReport: %v", err)
+	}
+	if res.Report.Id != testReportID || len(res.Queries) != 2 {
+		t.Errorf("unexpected report %v", res)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
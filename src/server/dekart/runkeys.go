@@ -0,0 +1,76 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/server/dialect"
+	"fmt"
+	"time"
+)
+
+// RunKeys stores idempotency keys of run requests in run_keys table
+type RunKeys struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+}
+
+// NewRunKeys returns job.KeyStore backed by database
+func NewRunKeys(db *sql.DB) *RunKeys {
+	return &RunKeys{db: db, dialect: dialect.Of(db)}
+}
+
+// Claim key for job; primary key of query and key makes concurrent claims on replicas fail except first one
+func (k *RunKeys) Claim(ctx context.Context, queryID string, key string, jobID string, window time.Duration) (string, error) {
+	_, err := k.db.ExecContext(ctx,
+		fmt.Sprintf(`delete from run_keys where query_id=$1 and idempotency_key=$2 and created_at < %s`, k.dialect.SecondsAgo("$3")),
+		queryID,
+		key,
+		window.Seconds(),
+	)
+	if err != nil {
+		return "", err
+	}
+	_, insertErr := k.db.ExecContext(ctx,
+		`insert into run_keys (query_id, idempotency_key, job_id) values ($1, $2, $3)`,
+		queryID,
+		key,
+		jobID,
+	)
+	if insertErr == nil {
+		return jobID, nil
+	}
+	var holder string
+	err = k.db.QueryRowContext(ctx,
+		`select job_id from run_keys where query_id=$1 and idempotency_key=$2`,
+		queryID,
+		key,
+	).Scan(&holder)
+	if err == sql.ErrNoRows {
+		// insert failed for other reason than existing key
+		return "", insertErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return holder, nil
+}
+
+// Release key held by job
+func (k *RunKeys) Release(ctx context.Context, queryID string, key string, jobID string) error {
+	_, err := k.db.ExecContext(ctx,
+		`delete from run_keys where query_id=$1 and idempotency_key=$2 and job_id=$3`,
+		queryID,
+		key,
+		jobID,
+	)
+	return err
+}
+
+// Purge keys claimed before window
+func (k *RunKeys) Purge(ctx context.Context, window time.Duration) error {
+	_, err := k.db.ExecContext(ctx,
+		fmt.Sprintf(`delete from run_keys where created_at < %s`, k.dialect.SecondsAgo("$1")),
+		window.Seconds(),
+	)
+	return err
+}
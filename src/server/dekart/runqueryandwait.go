@@ -0,0 +1,225 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const keepaliveInterval = 15 * time.Second
+
+const defaultWaitTimeout = 10 * time.Minute
+
+func jobResultResponse(job *job.Job) *proto.RunQueryAndWaitResponse {
+	snapshot := job.GetStatus()
+	res := &proto.RunQueryAndWaitResponse{
+		JobStatus:         proto.Query_JOB_STATUS_UNSPECIFIED,
+		JobError:          snapshot.Err,
+		TotalRows:         snapshot.TotalRows,
+		BytesProcessed:    snapshot.ProcessedBytes,
+		ResultSize:        snapshot.ResultSize,
+		RowsWritten:       snapshot.RowsWritten,
+		SampleRate:        snapshot.SampleRate,
+		ExecutedQueryText: snapshot.QueryText,
+		ExecutedQueryHash: snapshot.QueryHash,
+		ExplorationLimit:  snapshot.ExplorationLimit,
+		Warnings:          protoWarnings(snapshot.Warnings),
+		CancelReason:      snapshot.CancelReason,
+	}
+	if resultTable := snapshot.ResultTable; resultTable != nil {
+		res.JobStatus = proto.Query_JOB_STATUS_DONE
+		res.ResultType = proto.Query_RESULT_TYPE_TABLE
+		res.ResultTable = *resultTable
+	} else if resultID := snapshot.ResultID; resultID != nil {
+		res.JobStatus = proto.Query_JOB_STATUS_DONE
+		res.ResultType = proto.Query_RESULT_TYPE_FILE
+		res.JobResultId = *resultID
+		res.DownloadUrl = fmt.Sprintf("/api/v1/job-results/%s%s", *resultID, resultExtension(snapshot.ResultFormat))
+		if h3ResultID := snapshot.H3ResultID; h3ResultID != nil {
+			res.H3ResultId = *h3ResultID
+			res.H3DownloadUrl = fmt.Sprintf("/api/v1/job-results/%s.csv", *h3ResultID)
+		}
+		res.FormatResults = protoFormatResults(snapshot.FormatResults)
+	} else if res.JobError == "" {
+		res.JobError = "Job cancelled"
+	}
+	return res
+}
+
+// queryResultResponse of last job of query, sent when no job of request is waited for
+func queryResultResponse(query *proto.Query) *proto.RunQueryAndWaitResponse {
+	res := &proto.RunQueryAndWaitResponse{
+		JobStatus:         query.JobStatus,
+		JobError:          query.JobError,
+		TotalRows:         int64(query.TotalRows),
+		BytesProcessed:    query.BytesProcessed,
+		ResultSize:        query.ResultSize,
+		RowsWritten:       query.RowsWritten,
+		SampleRate:        query.SampleRate,
+		ExecutedQueryText: query.ExecutedQueryText,
+		ExecutedQueryHash: query.ExecutedQueryHash,
+		ExplorationLimit:  query.ExplorationLimit,
+		Warnings:          query.Warnings,
+		CancelReason:      query.CancelReason,
+		ResultType:        query.ResultType,
+		ResultTable:       query.ResultTable,
+		FormatResults:     query.FormatResults,
+	}
+	if query.JobResultId != "" {
+		res.JobResultId = query.JobResultId
+		res.DownloadUrl = fmt.Sprintf("/api/v1/job-results/%s%s", query.JobResultId, resultExtension(query.ResultFormat))
+	}
+	if query.H3ResultId != "" {
+		res.H3ResultId = query.H3ResultId
+		res.H3DownloadUrl = fmt.Sprintf("/api/v1/job-results/%s.csv", query.H3ResultId)
+	}
+	return res
+}
+
+// runQueryRequest of wait request, its options are validated and applied like options of RunQuery
+func runQueryRequest(req *proto.RunQueryAndWaitRequest) *proto.RunQueryRequest {
+	return &proto.RunQueryRequest{
+		QueryId:              req.QueryId,
+		SampleRate:           req.SampleRate,
+		H3Aggregation:        req.H3Aggregation,
+		Variables:            req.Variables,
+		DestinationTable:     req.DestinationTable,
+		IdempotencyKey:       req.IdempotencyKey,
+		ResultFormat:         req.ResultFormat,
+		SerializationOptions: req.SerializationOptions,
+		ResultCompression:    req.ResultCompression,
+		Connection:           req.Connection,
+		AdditionalFormats:    req.AdditionalFormats,
+		AsOf:                 req.AsOf,
+		AcknowledgedBytes:    req.AcknowledgedBytes,
+	}
+}
+
+// RunQueryAndWait runs query by id or raw SQL and streams keepalive messages until job is finished
+func (s Server) RunQueryAndWait(req *proto.RunQueryAndWaitRequest, srv proto.Dekart_RunQueryAndWaitServer) error {
+	ctx := srv.Context()
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return err
+	}
+	if req.TimeoutSeconds < 0 {
+		return status.Errorf(codes.InvalidArgument, "timeout_seconds must be positive")
+	}
+	timeout := defaultWaitTimeout
+	if req.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.TimeoutSeconds) * time.Second
+	}
+	if req.QueryText != "" {
+		if req.QueryId != "" {
+			return status.Error(codes.InvalidArgument, "query_id can't be combined with query_text")
+		}
+		// keys are claimed for query, raw SQL has none
+		if req.IdempotencyKey != "" {
+			return status.Error(codes.InvalidArgument, "idempotency_key can't be combined with query_text")
+		}
+	} else if _, err := uuid.Parse(req.QueryId); err != nil {
+		return status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	runReq := runQueryRequest(req)
+	if err := s.validateRunQuery(runReq); err != nil {
+		return err
+	}
+	var res *proto.RunQueryResponse
+	var j *job.Job
+	var err error
+	if req.QueryText != "" {
+		// raw SQL is not bound to report, it's run with defaults of instance and its job has no query record
+		res, j, err = s.runQuerySource(ctx, runReq, queryJobSource{queryText: req.QueryText}, "")
+	} else {
+		res, j, err = s.runQuery(ctx, runReq, "")
+	}
+	if err != nil {
+		return err
+	}
+	if j == nil && res.Duplicate {
+		j = s.jobs.Get(res.JobId)
+	}
+	if j == nil {
+		// result was reused, or job of first request with same key finished or runs on other replica
+		return s.sendQueryResult(ctx, req.QueryId, res.JobId, srv.Send)
+	}
+	return waitForJob(ctx, j, timeout, keepaliveInterval, srv.Send, s.jobs.CancelJob)
+}
+
+// sendQueryResult of finished last job of query; job of other replica can't be waited for
+func (s Server) sendQueryResult(ctx context.Context, queryID string, jobID string, send func(*proto.RunQueryAndWaitResponse) error) error {
+	reportID, err := s.getReadableReportID(ctx, queryID)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if reportID == nil {
+		return s.queryNotFound(ctx, queryID)
+	}
+	queries, err := s.getQueries(ctx, *reportID)
+	if err != nil {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	for _, query := range queries {
+		if query.Id != queryID {
+			continue
+		}
+		switch query.JobStatus {
+		case proto.Query_JOB_STATUS_PENDING, proto.Query_JOB_STATUS_RUNNING, proto.Query_JOB_STATUS_READING_RESULTS, proto.Query_JOB_STATUS_SAVING_RESULT:
+			return status.Errorf(codes.AlreadyExists, "query is already run by job %s on other replica", jobID)
+		}
+		return send(queryResultResponse(query))
+	}
+	return s.queryNotFound(ctx, queryID)
+}
+
+// waitForJob sends keepalive messages until job is finished, cancels job on timeout
+func waitForJob(
+	ctx context.Context,
+	j *job.Job,
+	timeout time.Duration,
+	keepalive time.Duration,
+	send func(*proto.RunQueryAndWaitResponse) error,
+	cancelJob func(jobID string, reason proto.CancelReason),
+) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(keepalive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.Ctx.Done():
+			return send(jobResultResponse(j))
+		case <-ticker.C:
+			err := send(&proto.RunQueryAndWaitResponse{
+				Keepalive: true,
+				JobStatus: proto.Query_JobStatus(j.GetState().Status()),
+			})
+			if err != nil {
+				log.Err(err).Send()
+				return err
+			}
+		case <-timer.C:
+			// nobody waits for result of job after timeout
+			cancelJob(j.ID, proto.CancelReason_CANCEL_REASON_ORPHAN_TIMEOUT)
+			err := fmt.Errorf("Job %s did not finish in %s", j.ID, timeout)
+			log.Warn().Err(err).Send()
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newWaitTestJob(t *testing.T) (*job.Store, *job.Job) {
+	store := job.NewStore()
+	j := store.New(context.Background(), "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d", "1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed")
+	go func() {
+		for {
+			select {
+			case <-j.Status:
+			case <-j.Ctx.Done():
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		select {
+		case <-j.Ctx.Done():
+		default:
+			store.CancelJob(j.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+		}
+	})
+	return store, j
+}
+
+func TestWaitForJobKeepaliveAndTimeout(t *testing.T) {
+	store, j := newWaitTestJob(t)
+	var messages []*proto.RunQueryAndWaitResponse
+	err := waitForJob(context.Background(), j, 100*time.Millisecond, 5*time.Millisecond, func(res *proto.RunQueryAndWaitResponse) error {
+		messages = append(messages, res)
+		return nil
+	}, store.CancelJob)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	if len(messages) == 0 {
+		t.Fatal("expected keepalive messages")
+	}
+	// job which is not started yet is sent as pending, not running
+	for _, res := range messages {
+		if !res.Keepalive || res.JobStatus != proto.Query_JOB_STATUS_PENDING {
+			t.Errorf("expected keepalive message of pending job, got %v", res)
+		}
+	}
+	select {
+	case <-j.Ctx.Done():
+	default:
+		t.Error("expected job to be cancelled on timeout")
+	}
+	if reason := j.GetStatus().CancelReason; reason != proto.CancelReason_CANCEL_REASON_ORPHAN_TIMEOUT {
+		t.Errorf("expected orphan timeout reason, got %s", reason)
+	}
+}
+
+func TestWaitForJobResult(t *testing.T) {
+	store, j := newWaitTestJob(t)
+	go store.CancelJob(j.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	var messages []*proto.RunQueryAndWaitResponse
+	err := waitForJob(context.Background(), j, time.Minute, time.Minute, func(res *proto.RunQueryAndWaitResponse) error {
+		messages = append(messages, res)
+		return nil
+	}, store.CancelJob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expected single result message, got %d", len(messages))
+	}
+	res := messages[0]
+	if res.Keepalive || res.JobStatus != proto.Query_JOB_STATUS_UNSPECIFIED || res.JobError != "Job cancelled" || res.DownloadUrl != "" ||
+		res.CancelReason != proto.CancelReason_CANCEL_REASON_USER_REQUEST {
+		t.Errorf("expected cancelled result, got %v", res)
+	}
+}
+
+func TestWaitForJobClientGone(t *testing.T) {
+	store, j := newWaitTestJob(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := waitForJob(ctx, j, time.Minute, time.Minute, func(res *proto.RunQueryAndWaitResponse) error {
+		t.Errorf("unexpected message %v", res)
+		return nil
+	}, store.CancelJob)
+	if err != nil {
+		t.Errorf("expected nil error when client is gone, got %v", err)
+	}
+}
+
+func TestRunQueryAndWaitRawSQLRejected(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	os.Setenv("DEKART_MAX_QUERY_TEXT_SIZE", "100")
+	defer os.Unsetenv("DEKART_MAX_QUERY_TEXT_SIZE")
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+	for name, req := range map[string]*proto.RunQueryAndWaitRequest{
+		"query_id and query_text": {QueryId: testQueryID, QueryText: "select 1"},
+		"too large":               {QueryText: "select '" + strings.Repeat("x", 92) + "'"},
+		"sample rate":             {QueryText: "select 1", SampleRate: 2},
+		"idempotency key":         {QueryText: "select 1", IdempotencyKey: "retry-1"},
+		"as of":                   {QueryText: "select 1", AsOf: -1},
+		"acknowledged bytes":      {QueryText: "select 1", AcknowledgedBytes: -1},
+	} {
+		err := s.RunQueryAndWait(req, &fakeRunQueryAndWaitStream{ctx: ctx})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", name, err)
+		}
+	}
+	// raw SQL is not read from database
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRunQueryRequestOfWait(t *testing.T) {
+	req := runQueryRequest(&proto.RunQueryAndWaitRequest{QueryId: testQueryID, AsOf: 1614834000, AcknowledgedBytes: 1 << 40})
+	if req.QueryId != testQueryID || req.AsOf != 1614834000 || req.AcknowledgedBytes != 1<<40 {
+		t.Errorf("unexpected run request %v", req)
+	}
+}
+
+func TestRunQueryAndWaitRawSQLViewer(t *testing.T) {
+	os.Setenv("DEKART_DEFAULT_ROLE", "viewer")
+	defer os.Unsetenv("DEKART_DEFAULT_ROLE")
+	s, mock := newTestServer(t)
+	mock.ExpectQuery("from instance_roles").WillReturnRows(sqlmock.NewRows([]string{"email_pattern", "role"}))
+	err := s.RunQueryAndWait(&proto.RunQueryAndWaitRequest{QueryText: "select 1"}, &fakeRunQueryAndWaitStream{ctx: testClaimsContext()})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultSchemaCacheTTL of table schemas shown in editor, long enough for editing session and short enough for schema changes
+const defaultSchemaCacheTTL = 10 * time.Minute
+
+// maxSchemaTables read for one query, other referenced tables get warning
+const maxSchemaTables = 20
+
+// parseSchemaCacheTTL of DEKART_SCHEMA_CACHE_TTL, zero disables cache
+func parseSchemaCacheTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultSchemaCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid DEKART_SCHEMA_CACHE_TTL %s, expected duration, 0 disables cache", value)
+	}
+	return ttl, nil
+}
+
+func schemaCacheTTL() time.Duration {
+	ttl, err := parseSchemaCacheTTL(os.Getenv("DEKART_SCHEMA_CACHE_TTL"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return ttl
+}
+
+// schemaColumns of table with nested fields flattened as parent.child
+func schemaColumns(table string, prefix string, schema bigquery.Schema) []*proto.SchemaColumn {
+	var columns []*proto.SchemaColumn
+	for _, field := range schema {
+		mode := "NULLABLE"
+		if field.Repeated {
+			mode = "REPEATED"
+		} else if field.Required {
+			mode = "REQUIRED"
+		}
+		name := prefix + field.Name
+		columns = append(columns, &proto.SchemaColumn{
+			Table:       table,
+			Name:        name,
+			Type:        string(field.Type),
+			Mode:        mode,
+			Description: field.Description,
+		})
+		columns = append(columns, schemaColumns(table, name+".", field.Schema)...)
+	}
+	return columns
+}
+
+// GetQuerySchemaContext returns columns of tables referenced by query text for autocomplete in editor
+func (s Server) GetQuerySchemaContext(ctx context.Context, req *proto.GetQuerySchemaContextRequest) (*proto.GetQuerySchemaContextResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.ReportId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	report, err := s.getReport(ctx, req.ReportId)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if report == nil {
+		err := fmt.Errorf("Report %s not found", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	}
+	queryText, err := s.normalizeQueryText(req.QueryText)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateConnection(req.Connection); err != nil {
+		return nil, err
+	}
+	res := &proto.GetQuerySchemaContextResponse{}
+	// tables are read from query as it runs, datasets and default values of variables are expanded
+	source := queryJobSource{queryText: queryText, reportID: req.ReportId, variables: report.Variables}
+	if err := s.expandDatasets(ctx, &source); err != nil {
+		if status.Code(err) != codes.FailedPrecondition && status.Code(err) != codes.InvalidArgument {
+			return nil, err
+		}
+		res.Warnings = append(res.Warnings, status.Convert(err).Message())
+	}
+	if err := source.substituteVariables(nil); err != nil {
+		res.Warnings = append(res.Warnings, status.Convert(err).Message())
+	}
+	// dialect is validated as on run, so editor shows error before query is run
+	if err := job.ValidateDialect(source.queryText, req.UseLegacySql, 0); err != nil {
+		res.Warnings = append(res.Warnings, err.Error())
+	}
+	names := job.TableReferences(source.queryText)
+	if len(names) > maxSchemaTables {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("query references %d tables, schema of first %d is shown", len(names), maxSchemaTables))
+		names = names[:maxSchemaTables]
+	}
+	// fresh snapshots are read before table metadata, snapshot can't be read when it's not configured or database fails
+	var fresh []*proto.SchemaSnapshotSource
+	if len(s.schemaSnapshots.sources) > 0 {
+		sources, err := s.snapshotSources(ctx)
+		if err != nil {
+			log.Err(err).Msg("Cannot read schema snapshots, reading table metadata")
+		}
+		for _, source := range sources {
+			if !source.Stale {
+				fresh = append(fresh, source)
+			}
+		}
+	}
+	warned := map[string]bool{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		table, err := s.jobs.QualifyTable(name, report.DefaultDataset)
+		if err != nil {
+			res.Warnings = append(res.Warnings, err.Error())
+			continue
+		}
+		if seen[table] {
+			continue
+		}
+		seen[table] = true
+		if err := s.jobs.CheckTable(req.Connection, table); err != nil {
+			// schema of table outside of allowed datasets is not shown, query referencing it is rejected on run
+			res.Warnings = append(res.Warnings, err.Error())
+			continue
+		}
+		if len(fresh) > 0 {
+			columns, source, err := s.snapshotColumns(ctx, table, fresh)
+			if err != nil {
+				log.Err(err).Str("table", table).Msg("Cannot read schema snapshot, reading table metadata")
+			}
+			if source != nil {
+				res.Tables = append(res.Tables, table)
+				res.Columns = append(res.Columns, columns...)
+				if source.AgeSeconds > res.SnapshotAgeSeconds {
+					res.SnapshotAgeSeconds = source.AgeSeconds
+				}
+				if source.Error != "" && !warned[source.Source] {
+					warned[source.Source] = true
+					res.Warnings = append(res.Warnings, fmt.Sprintf("schema snapshot of %s failed to refresh, columns are %s old", source.Source, time.Duration(source.AgeSeconds)*time.Second))
+				}
+				continue
+			}
+		}
+		// table created after snapshot or outside of snapshot sources
+		schema, err := s.schemas.Schema(ctx, s.jobs.BillingProject(report.BillingProject), table)
+		if err != nil {
+			log.Debug().Err(err).Str("table", table).Msg("Cannot read table schema")
+			res.Warnings = append(res.Warnings, table+": "+job.SchemaError(err))
+			continue
+		}
+		res.Tables = append(res.Tables, table)
+		res.Columns = append(res.Columns, schemaColumns(table, "", schema)...)
+	}
+	return res, nil
+}
@@ -0,0 +1,107 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/api/googleapi"
+)
+
+func TestGetQuerySchemaContext(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	s.schemas = job.NewSchemaCache(time.Minute, func(ctx context.Context, billingProject string, table string) (bigquery.Schema, error) {
+		if billingProject != "billing" {
+			t.Errorf("expected billing project of report, got %s", billingProject)
+		}
+		if table != "p.data.points" {
+			return nil, &googleapi.Error{Code: 404}
+		}
+		return bigquery.Schema{
+			{Name: "id", Type: bigquery.IntegerFieldType, Required: true},
+			{Name: "tags", Type: bigquery.RecordFieldType, Repeated: true, Schema: bigquery.Schema{
+				{Name: "name", Type: bigquery.StringFieldType, Description: "tag name"},
+			}},
+		}, nil
+	})
+	mock.ExpectQuery("from reports where id").
+		WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testReportID, "{}", "Report", true, "billing", "p.data", 1, "", 0, false, 0, 0, "", "", "", 0, false))
+	res, err := s.GetQuerySchemaContext(testClaimsContext(), &proto.GetQuerySchemaContextRequest{
+		ReportId:  testReportID,
+		QueryText: "with p as (select * from points) select * from p join `p.data.missing` using (id) join p.data.points x on true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Tables, []string{"p.data.points"}) {
+		t.Errorf("unexpected tables %v", res.Tables)
+	}
+	if !reflect.DeepEqual(res.Warnings, []string{"p.data.missing: table not found"}) {
+		t.Errorf("unexpected warnings %v", res.Warnings)
+	}
+	var names []string
+	for _, column := range res.Columns {
+		names = append(names, column.Name+" "+column.Type+" "+column.Mode)
+	}
+	expected := []string{"id INTEGER REQUIRED", "tags RECORD REPEATED", "tags.name STRING NULLABLE"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected columns %v, got %v", expected, names)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseSchemaCacheTTL(t *testing.T) {
+	for value, expected := range map[string]time.Duration{"": defaultSchemaCacheTTL, "0": 0, "1h": time.Hour} {
+		ttl, err := parseSchemaCacheTTL(value)
+		if err != nil || ttl != expected {
+			t.Errorf("%s: expected %v, got %v %v", value, expected, ttl, err)
+		}
+	}
+	for _, value := range []string{"-1m", "hour"} {
+		if _, err := parseSchemaCacheTTL(value); err == nil {
+			t.Errorf("%s: expected error", value)
+		}
+	}
+}
+
+func TestGetQuerySchemaContextAllowlist(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	os.Setenv("DEKART_BIGQUERY_DATASETS", "p.data,p.shared_*")
+	defer os.Unsetenv("DEKART_BIGQUERY_DATASETS")
+	s, mock := newTestServer(t)
+	s.jobs = job.NewStore()
+	s.schemas = job.NewSchemaCache(time.Minute, func(ctx context.Context, billingProject string, table string) (bigquery.Schema, error) {
+		if table == "p.private.users" {
+			t.Errorf("unexpected schema of table outside of allowed datasets")
+		}
+		return bigquery.Schema{{Name: "id", Type: bigquery.IntegerFieldType}}, nil
+	})
+	mock.ExpectQuery("from reports where id").
+		WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testReportID, "{}", "Report", true, "billing", "p.data", 1, "", 0, false, 0, 0, "", "", "", 0, false))
+	res, err := s.GetQuerySchemaContext(testClaimsContext(), &proto.GetQuerySchemaContextRequest{
+		ReportId:  testReportID,
+		QueryText: "select * from points join p.private.users using (id) join p.shared_2021.areas using (id)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Tables, []string{"p.data.points", "p.shared_2021.areas"}) {
+		t.Errorf("unexpected tables %v", res.Tables)
+	}
+	if len(res.Warnings) != 1 || !strings.Contains(res.Warnings[0], "p.private.users") {
+		t.Errorf("expected warning of table outside of allowed datasets, got %v", res.Warnings)
+	}
+}
@@ -0,0 +1,401 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultSchemaSnapshotInterval between refreshes of INFORMATION_SCHEMA snapshots
+const defaultSchemaSnapshotInterval = time.Hour
+
+// maxSnapshotColumns of source, refresh of larger source fails and previous snapshot is kept
+const maxSnapshotColumns = 500000
+
+// maxSnapshotTables listed at once, prefix narrows listing
+const maxSnapshotTables = 1000
+
+// maxSnapshotErrorLength of stored refresh error
+const maxSnapshotErrorLength = 1000
+
+// schemaSnapshots of DEKART_SCHEMA_SNAPSHOT_SOURCES refreshed every interval; snapshot older than maxAge is stale
+type schemaSnapshots struct {
+	sources  []string
+	interval time.Duration
+	maxAge   time.Duration
+	// refresh of sources one at a time on replica
+	refresh *sync.Mutex
+}
+
+// parseSchemaSnapshots of comma separated project.dataset or project.region-xx sources and refresh interval;
+// snapshot is stale after 3 intervals, when refreshes failed or replicas didn't run
+func parseSchemaSnapshots(sources string, interval string) (schemaSnapshots, error) {
+	snapshots := schemaSnapshots{interval: defaultSchemaSnapshotInterval, refresh: &sync.Mutex{}}
+	for _, source := range strings.Split(sources, ",") {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		if err := job.ValidateSchemaSource(source); err != nil {
+			return schemaSnapshots{}, fmt.Errorf("DEKART_SCHEMA_SNAPSHOT_SOURCES: %w", err)
+		}
+		snapshots.sources = append(snapshots.sources, source)
+	}
+	if interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil || d < time.Minute {
+			return schemaSnapshots{}, fmt.Errorf("invalid DEKART_SCHEMA_SNAPSHOT_INTERVAL %s, expected duration of at least 1m", interval)
+		}
+		snapshots.interval = d
+	}
+	snapshots.maxAge = 3 * snapshots.interval
+	return snapshots, nil
+}
+
+func schemaSnapshotsFromEnv() schemaSnapshots {
+	snapshots, err := parseSchemaSnapshots(os.Getenv("DEKART_SCHEMA_SNAPSHOT_SOURCES"), os.Getenv("DEKART_SCHEMA_SNAPSHOT_INTERVAL"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return snapshots
+}
+
+// has source configured
+func (s schemaSnapshots) has(source string) bool {
+	for _, configured := range s.sources {
+		if configured == source {
+			return true
+		}
+	}
+	return false
+}
+
+// informationSchemaReader of columns of source, Store.ReadInformationSchema or fake in tests
+type informationSchemaReader func(ctx context.Context, source string, visit func(job.SchemaSnapshotColumn) error) error
+
+// refreshSchemaSource replaces snapshot of source with columns read from INFORMATION_SCHEMA. Columns are stored
+// only when read completes; rows of failed read are discarded, previous snapshot is kept and error is recorded
+func (s Server) refreshSchemaSource(ctx context.Context, source string) error {
+	s.schemaSnapshots.refresh.Lock()
+	defer s.schemaSnapshots.refresh.Unlock()
+	var columns []job.SchemaSnapshotColumn
+	readErr := s.readInformationSchema(ctx, source, func(column job.SchemaSnapshotColumn) error {
+		if len(columns) == maxSnapshotColumns {
+			return fmt.Errorf("source %s has more than %d columns, use dataset as source", source, maxSnapshotColumns)
+		}
+		columns = append(columns, column)
+		return nil
+	})
+	if readErr != nil {
+		log.Warn().Err(readErr).Str("source", source).Int("columns", len(columns)).Msg("Schema snapshot refresh failed, previous snapshot is kept")
+		return s.recordSnapshotFailure(ctx, source, readErr)
+	}
+	if err := s.storeSnapshot(ctx, source, columns); err != nil {
+		return err
+	}
+	log.Info().Str("source", source).Int("columns", len(columns)).Msg("Schema snapshot refreshed")
+	return nil
+}
+
+// storeSnapshot of source replacing its columns
+func (s Server) storeSnapshot(ctx context.Context, source string, columns []job.SchemaSnapshotColumn) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `delete from schema_snapshot_columns where source=$1`, source); err != nil {
+		return err
+	}
+	for i, column := range columns {
+		_, err := tx.ExecContext(ctx,
+			`insert into schema_snapshot_columns (source, table_name, position, column_name, data_type, mode, description)
+			values ($1, $2, $3, $4, $5, $6, $7)`,
+			source, column.Table, i, column.Name, column.Type, column.Mode, column.Description,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `delete from schema_snapshots where source=$1`, source); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf(`insert into schema_snapshots (source, snapshot_at, columns_count, error) values ($1, %s, $3, '')`, s.dialect.Timestamp("$2")),
+		source, s.dialect.TimeArg(s.now()), len(columns),
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// recordSnapshotFailure of source; source without snapshot gets row with error only
+func (s Server) recordSnapshotFailure(ctx context.Context, source string, readErr error) error {
+	message := readErr.Error()
+	if len(message) > maxSnapshotErrorLength {
+		message = message[:maxSnapshotErrorLength]
+	}
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	var count int
+	if err := tx.QueryRowContext(ctx, `select count(*) from schema_snapshots where source=$1`, source).Scan(&count); err != nil {
+		return err
+	}
+	// MySQL doesn't count rows updated with same values, so row is looked up first
+	update := fmt.Sprintf(`update schema_snapshots set error=$2, failed_at=%s where source=$1`, s.dialect.Timestamp("$3"))
+	if count == 0 {
+		update = fmt.Sprintf(`insert into schema_snapshots (source, error, failed_at) values ($1, $2, %s)`, s.dialect.Timestamp("$3"))
+	}
+	if _, err := tx.ExecContext(ctx, update, source, message, s.dialect.TimeArg(s.now())); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// snapshotSources of configured sources in order, sources without snapshot have zero snapshot_at and are stale
+func (s Server) snapshotSources(ctx context.Context) ([]*proto.SchemaSnapshotSource, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(
+		`select source, coalesce(%s, 0), columns_count, error, coalesce(%s, 0) from schema_snapshots`,
+		s.dialect.Int(s.dialect.Epoch("snapshot_at")),
+		s.dialect.Int(s.dialect.Epoch("failed_at")),
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	stored := map[string]*proto.SchemaSnapshotSource{}
+	for rows.Next() {
+		source := &proto.SchemaSnapshotSource{}
+		if err := rows.Scan(&source.Source, &source.SnapshotAt, &source.ColumnsCount, &source.Error, &source.FailedAt); err != nil {
+			return nil, err
+		}
+		stored[source.Source] = source
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	sources := make([]*proto.SchemaSnapshotSource, 0, len(s.schemaSnapshots.sources))
+	for _, name := range s.schemaSnapshots.sources {
+		source, ok := stored[name]
+		if !ok {
+			source = &proto.SchemaSnapshotSource{Source: name}
+		}
+		if source.SnapshotAt > 0 {
+			age := now.Sub(time.Unix(source.SnapshotAt, 0))
+			source.AgeSeconds = int64(age.Seconds())
+			source.Stale = age > s.schemaSnapshots.maxAge
+		} else {
+			source.Stale = true
+		}
+		if source.FailedAt < source.SnapshotAt {
+			// error of refresh before last snapshot
+			source.Error, source.FailedAt = "", 0
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// snapshotColumns of table from first source in sources which has it, nil when no source has table
+func (s Server) snapshotColumns(ctx context.Context, table string, sources []*proto.SchemaSnapshotSource) ([]*proto.SchemaColumn, *proto.SchemaSnapshotSource, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`select source, column_name, data_type, mode, description from schema_snapshot_columns
+		where table_name=$1 order by source, position`,
+		table,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	bySource := map[string][]*proto.SchemaColumn{}
+	for rows.Next() {
+		var source string
+		column := &proto.SchemaColumn{Table: table}
+		if err := rows.Scan(&source, &column.Name, &column.Type, &column.Mode, &column.Description); err != nil {
+			return nil, nil, err
+		}
+		bySource[source] = append(bySource[source], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	for _, source := range sources {
+		if columns, ok := bySource[source.Source]; ok {
+			return columns, source, nil
+		}
+	}
+	return nil, nil, nil
+}
+
+// escapeLike of prefix with ! as escape character, which has no special meaning in string literals of dialects
+func escapeLike(prefix string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix)
+}
+
+// snapshotTables of sources with prefix allowed for connection, in name order
+func (s Server) snapshotTables(ctx context.Context, connection string, prefix string, sources []*proto.SchemaSnapshotSource) ([]string, bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`select distinct source, table_name from schema_snapshot_columns where table_name like $1 escape '!' order by table_name`,
+		escapeLike(prefix)+"%",
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+	configured := map[string]bool{}
+	for _, source := range sources {
+		configured[source.Source] = true
+	}
+	var tables []string
+	for rows.Next() {
+		var source, table string
+		if err := rows.Scan(&source, &table); err != nil {
+			return nil, false, err
+		}
+		// table of removed source and table listed by other source are skipped
+		if !configured[source] || len(tables) > 0 && tables[len(tables)-1] == table {
+			continue
+		}
+		// permissions of connection are applied when snapshot is read, snapshot is shared by connections
+		if s.jobs.CheckTable(connection, table) != nil {
+			continue
+		}
+		if len(tables) == maxSnapshotTables {
+			return tables, true, nil
+		}
+		tables = append(tables, table)
+	}
+	return tables, false, rows.Err()
+}
+
+// GetSchemaSnapshot lists tables of INFORMATION_SCHEMA snapshots and columns of table for autocomplete in editor;
+// stale snapshot is listed with stale source
+func (s Server) GetSchemaSnapshot(ctx context.Context, req *proto.GetSchemaSnapshotRequest) (*proto.GetSchemaSnapshotResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if err := s.validateConnection(req.Connection); err != nil {
+		return nil, err
+	}
+	sources, err := s.snapshotSources(ctx)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	res := &proto.GetSchemaSnapshotResponse{Sources: sources}
+	if req.Table != "" {
+		if err := s.jobs.CheckTable(req.Connection, req.Table); err != nil {
+			return nil, err
+		}
+		res.Columns, _, err = s.snapshotColumns(ctx, req.Table, sources)
+		if err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+		return res, nil
+	}
+	res.Tables, res.Truncated, err = s.snapshotTables(ctx, req.Connection, req.TablePrefix, sources)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return res, nil
+}
+
+// RefreshSchemaSnapshot of source or all sources now, refresh which failed is reported in its source
+func (s Server) RefreshSchemaSnapshot(ctx context.Context, req *proto.RefreshSchemaSnapshotRequest) (*proto.RefreshSchemaSnapshotResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if len(s.schemaSnapshots.sources) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "schema snapshot sources are not set, set DEKART_SCHEMA_SNAPSHOT_SOURCES")
+	}
+	refreshed := s.schemaSnapshots.sources
+	if req.Source != "" {
+		// only configured sources are queried, INFORMATION_SCHEMA queries are billed
+		if !s.schemaSnapshots.has(req.Source) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown schema snapshot source %s", req.Source)
+		}
+		refreshed = []string{req.Source}
+	}
+	for _, source := range refreshed {
+		if err := s.refreshSchemaSource(ctx, source); err != nil {
+			log.Err(err).Send()
+			return nil, internalError(err)
+		}
+	}
+	sources, err := s.snapshotSources(ctx)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	res := &proto.RefreshSchemaSnapshotResponse{}
+	for _, source := range sources {
+		if req.Source == "" || source.Source == req.Source {
+			res.Sources = append(res.Sources, source)
+		}
+	}
+	return res, nil
+}
+
+// refreshDueSnapshots of sources not refreshed by any replica within half of interval
+func (s Server) refreshDueSnapshots(ctx context.Context) {
+	sources, err := s.snapshotSources(ctx)
+	if err != nil {
+		log.Err(err).Msg("Cannot read schema snapshots")
+		return
+	}
+	due := s.now().Add(-s.schemaSnapshots.interval / 2).Unix()
+	for _, source := range sources {
+		if source.SnapshotAt > due || source.FailedAt > due {
+			continue
+		}
+		if err := s.refreshSchemaSource(ctx, source.Source); err != nil {
+			log.Err(err).Str("source", source.Source).Msg("Cannot store schema snapshot")
+		}
+	}
+}
+
+// ScheduleSchemaSnapshots of DEKART_SCHEMA_SNAPSHOT_SOURCES every DEKART_SCHEMA_SNAPSHOT_INTERVAL, first refresh
+// runs at start; not scheduled without sources and on read-only instance
+func (s Server) ScheduleSchemaSnapshots() {
+	if len(s.schemaSnapshots.sources) == 0 || s.readOnly {
+		return
+	}
+	interval := s.schemaSnapshots.interval
+	log.Info().Strs("sources", s.schemaSnapshots.sources).Str("interval", interval.String()).Msg("Schema snapshots scheduled")
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			s.refreshDueSnapshots(ctx)
+			cancel()
+			<-ticker.C
+		}
+	}()
+}
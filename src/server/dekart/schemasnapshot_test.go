@@ -0,0 +1,59 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"dekart/src/server/user"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSchemaSnapshots(t *testing.T) {
+	snapshots, err := parseSchemaSnapshots(" p.data, p.region-us ,", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots.sources) != 2 || snapshots.sources[1] != "p.region-us" || snapshots.interval != time.Hour || snapshots.maxAge != 3*time.Hour {
+		t.Errorf("unexpected snapshots %+v", snapshots)
+	}
+	if snapshots, err := parseSchemaSnapshots("", "10m"); err != nil || len(snapshots.sources) != 0 || snapshots.maxAge != 30*time.Minute {
+		t.Errorf("unexpected snapshots %+v %v", snapshots, err)
+	}
+	for sources, interval := range map[string]string{
+		"p.data.table": "",
+		"data":         "",
+		"p.data":       "30s",
+		"p.other":      "hour",
+	} {
+		if _, err := parseSchemaSnapshots(sources, interval); err == nil {
+			t.Errorf("%s %s: expected error", sources, interval)
+		}
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got := escapeLike("p.shared_2021!%"); got != "p.shared!_2021!!!%" {
+		t.Errorf("unexpected escaped prefix %s", got)
+	}
+}
+
+func TestRefreshSchemaSnapshotRejected(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	s, mock := newTestServer(t)
+	ctx := testClaimsContext()
+	if _, err := s.RefreshSchemaSnapshot(ctx, &proto.RefreshSchemaSnapshotRequest{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition without sources, got %v", err)
+	}
+	s.schemaSnapshots, _ = parseSchemaSnapshots("p.data", "")
+	// other sources are not queried on request
+	if _, err := s.RefreshSchemaSnapshot(ctx, &proto.RefreshSchemaSnapshotRequest{Source: "p.private"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument of unknown source, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -4,11 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"dekart/src/proto"
+	"dekart/src/server/dialect"
 	"dekart/src/server/job"
 	"dekart/src/server/report"
+	"dekart/src/server/tiles"
+	"errors"
+	"io"
 	"os"
+	"sync"
+	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -16,33 +23,138 @@ import (
 // Server is Dekart Endpoints implementation (HTTP and GRPC)
 type Server struct {
 	db            *sql.DB
-	reportStreams *report.Streams
+	reportStreams report.Bus
 	bucket        *storage.BucketHandle
 	proto.UnimplementedDekartServer
-	jobs *job.Store
+	jobs        *job.Store
+	defaultRole proto.Role
+	tiler       *tiles.Tiler
+	dialect     dialect.Dialect
+	// maxMapConfigSize in bytes
+	maxMapConfigSize int
+	// storagePrefix template of result object names
+	storagePrefix string
+	// statusInterval is minimum interval between progress updates of job
+	statusInterval time.Duration
+	// reuseTTL of source results reused on first run of forked queries, zero disables reuse
+	reuseTTL time.Duration
+	// schemas of tables referenced by queries in editor
+	schemas *job.SchemaCache
+	// permissions of service account are tested with testIamPermissions
+	permissions job.PermissionTester
+	// maxCompareSize of both compared results in bytes
+	maxCompareSize int64
+	// shares of public results checked by public requests
+	shares *shareCache
+	// fallbackBucket receives results when upload to bucket fails, nil when not configured
+	fallbackBucket     *storage.BucketHandle
+	fallbackBucketName string
+	// deletedQueryRetention of removed queries, they are restorable until swept
+	deletedQueryRetention time.Duration
+	// buckets checked before jobs are started, so misconfigured bucket fails run before query is billed
+	buckets *bucketCheck
+	// exactCountMaxBytes estimated by dry run of count of sampled or limited query, zero disables count
+	exactCountMaxBytes int64
+	// resultSettings of instance, defaults of reports without own settings
+	resultSettings *proto.ResultSettings
+	// lineageQueryText when executed query text is stored in lineage of results
+	lineageQueryText bool
+	// statusWriters of running jobs, waited for by Shutdown
+	statusWriters *sync.WaitGroup
+	// readOnly instance rejects mutations and runs, reports are viewed and downloaded only
+	readOnly bool
+	// quota of monthly bytes billed, nil when budget is not set
+	quota *usageQuota
+	// autoRerun of stale queries with auto-rerun policy when report is opened
+	autoRerun bool
+	// now is clock of result freshness
+	now func() time.Time
+	// maxFileSize of file uploaded to report in bytes
+	maxFileSize int64
+	// schemaSnapshots of INFORMATION_SCHEMA served to editor, readInformationSchema reads their columns
+	schemaSnapshots       schemaSnapshots
+	readInformationSchema informationSchemaReader
 }
 
 //Unauthenticated error returned when no user claims in context
 var Unauthenticated error = status.Error(codes.Unauthenticated, "UNAUTHENTICATED")
 
+// internalError status of err; error with status of its own anywhere in its chain, like read-only instance,
+// secret of database credentials, misconfigured bucket, exhausted quota or job rejected before it ran, keeps it
+func internalError(err error) error {
+	var withStatus interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &withStatus) {
+		return withStatus.GRPCStatus().Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
 // NewServer returns new Dekart Server
-func NewServer(db *sql.DB, bucket *storage.BucketHandle, jobs *job.Store) *Server {
+func NewServer(db *sql.DB, bucket *storage.BucketHandle, jobs *job.Store, reportStreams report.Bus) *Server {
+	defaultRole, err := parseDefaultRole(os.Getenv("DEKART_DEFAULT_ROLE"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
 	server := Server{
 		db:            db,
-		reportStreams: report.NewStreams(),
+		reportStreams: reportStreams,
 		bucket:        bucket,
 		jobs:          jobs,
+		defaultRole:   defaultRole,
+		dialect:       dialect.Of(db),
+
+		maxMapConfigSize: maxMapConfigSize(),
+		storagePrefix:    storagePrefix(),
+		statusInterval:   statusInterval(),
+		reuseTTL:         reuseTTL(),
+		schemas:          job.NewSchemaCache(schemaCacheTTL(), job.FetchTableSchema),
+		permissions:      job.GooglePermissionTester{},
+		maxCompareSize:   maxCompareSize(),
+		shares:           newShareCache(shareCacheTTL()),
+
+		deletedQueryRetention: deletedQueryRetention(),
+		buckets:               newBucketCheck(bucketCheckTTL, readBucketAttrs),
+		exactCountMaxBytes:    exactCountMaxBytes(),
+		resultSettings:        defaultResultSettings(),
+		lineageQueryText:      lineageQueryText(),
+		statusWriters:         &sync.WaitGroup{},
+		readOnly:              readOnly(),
+		quota:                 newUsageQuota(),
+		autoRerun:             autoRerun(),
+		now:                   time.Now,
+		maxFileSize:           maxFileSize(),
+		schemaSnapshots:       schemaSnapshotsFromEnv(),
+		readInformationSchema: jobs.ReadInformationSchema,
 	}
-	return &server
+	// tiler reads results through returned server, so fallback bucket set later is used
+	s := &server
+	server.tiler = tiles.NewTiler(func(ctx context.Context, resultID string) (io.ReadCloser, int64, error) {
+		return s.openResult(ctx, resultID)
+	})
+	return s
 
 }
 
+// JobStats of jobs running on this replica
+func (s Server) JobStats() job.Stats {
+	return s.jobs.Stats()
+}
+
+// JobSnapshot of phases of jobs running on this replica
+func (s Server) JobSnapshot() []job.Diagnostics {
+	return s.jobs.Snapshot()
+}
+
 // GetEnv variables to the client
 func (s Server) GetEnv(ctx context.Context, req *proto.GetEnvRequest) (*proto.GetEnvResponse, error) {
 	homePageUrl := os.Getenv("DEKART_UX_HOMEPAGE")
 	if homePageUrl == "" {
 		homePageUrl = "/"
 	}
+	readOnlyValue := "0"
+	if s.readOnly {
+		readOnlyValue = "1"
+	}
 	variables := []*proto.GetEnvResponse_Variable{
 		{
 			Type:  proto.GetEnvResponse_Variable_TYPE_MAPBOX_TOKEN,
@@ -56,6 +168,11 @@ func (s Server) GetEnv(ctx context.Context, req *proto.GetEnvRequest) (*proto.Ge
 			Type:  proto.GetEnvResponse_Variable_TYPE_UX_HOMEPAGE,
 			Value: homePageUrl,
 		},
+		{
+			// frontend hides editing of read-only instance, handlers reject it anyway
+			Type:  proto.GetEnvResponse_Variable_TYPE_READ_ONLY,
+			Value: readOnlyValue,
+		},
 	}
 	return &proto.GetEnvResponse{
 		Variables: variables,
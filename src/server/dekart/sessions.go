@@ -0,0 +1,74 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/server/dialect"
+	"dekart/src/server/encryption"
+	"dekart/src/server/user"
+	"time"
+)
+
+// SessionStore keeps user sessions in sessions table
+type SessionStore struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+}
+
+// NewSessionStore returns user.SessionStore backed by database
+func NewSessionStore(db *sql.DB) *SessionStore {
+	return &SessionStore{db: db, dialect: dialect.Of(db)}
+}
+
+// Create session; email and groups are encrypted when DEKART_ENCRYPTION_KEYS is set
+func (s *SessionStore) Create(ctx context.Context, session user.Session) error {
+	_, err := s.db.ExecContext(ctx,
+		`insert into sessions (id, email, "groups", expires_at) values ($1, $2, $3, $4)`,
+		session.ID,
+		encryption.String(session.Email),
+		encryption.Strings(session.Groups),
+		s.dialect.TimeArg(session.ExpiresAt),
+	)
+	return err
+}
+
+// Get active session
+func (s *SessionStore) Get(ctx context.Context, id string) (*user.Session, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`select id, email, "groups", expires_at from sessions
+		where id=$1 and not revoked and expires_at > CURRENT_TIMESTAMP limit 1`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		session := user.Session{}
+		var email encryption.String
+		var groups encryption.Strings
+		err := rows.Scan(&session.ID, &email, &groups, &session.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		session.Email, session.Groups = string(email), groups
+		return &session, nil
+	}
+	return nil, rows.Err()
+}
+
+// Extend session expiry
+func (s *SessionStore) Extend(ctx context.Context, id string, expiresAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`update sessions set expires_at=$1 where id=$2 and not revoked`,
+		s.dialect.TimeArg(expiresAt),
+		id,
+	)
+	return err
+}
+
+// Revoke session
+func (s *SessionStore) Revoke(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `update sessions set revoked=true where id=$1`, id)
+	return err
+}
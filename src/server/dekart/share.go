@@ -0,0 +1,279 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultShareCacheTTL of public share checks, share revoked on other replica is served at most this long
+const defaultShareCacheTTL = time.Minute
+
+// maxShareCacheEntries of shareCache, expired and then all checks are removed when it's full
+const maxShareCacheEntries = 10000
+
+// publicResultMaxAge of public result in caches; result never changes, but revoked share is served
+// by CDN at most this long, and then it's revalidated with ETag
+const publicResultMaxAge = time.Minute
+
+// publicResultsPath of results of public reports, served without login
+const publicResultsPath = "/public/results/"
+
+// parseShareCacheTTL of DEKART_PUBLIC_SHARE_CACHE_TTL, zero disables cache
+func parseShareCacheTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultShareCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid DEKART_PUBLIC_SHARE_CACHE_TTL %s, expected duration, 0 disables cache", value)
+	}
+	return ttl, nil
+}
+
+func shareCacheTTL() time.Duration {
+	ttl, err := parseShareCacheTTL(os.Getenv("DEKART_PUBLIC_SHARE_CACHE_TTL"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return ttl
+}
+
+// publicResultPath of result, path never changes as result is never overwritten
+func publicResultPath(resultID string, format proto.ResultFormat) string {
+	return publicResultsPath + resultID + resultExtension(format)
+}
+
+// setPublicResultPaths of file results when report is public, private results have no public path
+func setPublicResultPaths(rep *proto.Report, queries []*proto.Query) {
+	for _, query := range queries {
+		if rep.Public && query.ResultType == proto.Query_RESULT_TYPE_FILE {
+			query.PublicResultPath = publicResultPath(query.JobResultId, query.ResultFormat)
+		} else {
+			query.PublicResultPath = ""
+		}
+	}
+}
+
+// shareCheck of result; result is served when public, reportID is empty when result was not run by query
+type shareCheck struct {
+	reportID   string
+	objectName string
+	// bucket recorded for result, empty for primary bucket
+	bucket    string
+	public    bool
+	expired   bool
+	checkedAt time.Time
+}
+
+// shareCache of share checks by resultID, concurrency safe; both shared and private checks are cached,
+// so public requests don't reach database on every CDN miss
+type shareCache struct {
+	// ttl of checks, zero disables cache
+	ttl    time.Duration
+	mutex  sync.Mutex
+	checks map[string]shareCheck
+	now    func() time.Time
+}
+
+func newShareCache(ttl time.Duration) *shareCache {
+	return &shareCache{
+		ttl:    ttl,
+		checks: make(map[string]shareCheck),
+		now:    time.Now,
+	}
+}
+
+func (c *shareCache) cached(resultID string) (shareCheck, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	check, ok := c.checks[resultID]
+	if !ok || c.now().Sub(check.checkedAt) >= c.ttl {
+		return shareCheck{}, false
+	}
+	return check, true
+}
+
+func (c *shareCache) store(resultID string, check shareCheck) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	if _, ok := c.checks[resultID]; !ok && len(c.checks) >= maxShareCacheEntries {
+		for id, cached := range c.checks {
+			if now.Sub(cached.checkedAt) >= c.ttl {
+				delete(c.checks, id)
+			}
+		}
+		if len(c.checks) >= maxShareCacheEntries {
+			c.checks = make(map[string]shareCheck)
+		}
+	}
+	check.checkedAt = now
+	c.checks[resultID] = check
+}
+
+// invalidate checks of report shared or revoked on this replica, so change applies here at once
+func (c *shareCache) invalidate(reportID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for id, check := range c.checks {
+		if check.reportID == reportID {
+			delete(c.checks, id)
+		}
+	}
+}
+
+// checkShare of result, result is public when report of its query is shared and not archived
+func (s Server) checkShare(ctx context.Context, resultID string) (shareCheck, error) {
+	if check, ok := s.shares.cached(resultID); ok {
+		return check, nil
+	}
+	var check shareCheck
+	var public, archived bool
+	err := s.db.QueryRowContext(ctx,
+		`select
+			queries.report_id,
+			results.object_name,
+			case when results.bucket is null then '' else results.bucket end,
+			case when results.expired_at is null then false else true end as expired,
+			coalesce(reports.public_share, false) as public_share,
+			coalesce(reports.archived, false) as archived
+		from results
+		join queries on queries.id = results.query_id
+		join reports on reports.id = queries.report_id
+		where results.id=$1 limit 1`,
+		resultID,
+	).Scan(&check.reportID, &check.objectName, &check.bucket, &check.expired, &public, &archived)
+	if err != nil && err != sql.ErrNoRows {
+		return shareCheck{}, err
+	}
+	check.public = public && !archived
+	s.shares.store(resultID, check)
+	return check, nil
+}
+
+// publicContentType of result object by extension
+func publicContentType(extension string) string {
+	if extension == ".parquet" {
+		return job.ParquetContentType
+	}
+	return "text/csv"
+}
+
+// ServePublicResult of public report without user claims; response is cached by CDN for publicResultMaxAge
+func (s Server) ServePublicResult(w http.ResponseWriter, r *http.Request) {
+	s.servePublicResult(w, r, func(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error) {
+		return s.resultBucket(bucket).Object(objectName).NewReader(ctx)
+	})
+}
+
+func (s Server) servePublicResult(w http.ResponseWriter, r *http.Request, open func(ctx context.Context, bucket string, objectName string) (io.ReadCloser, error)) {
+	vars := mux.Vars(r)
+	ctx := r.Context()
+	resultID := vars["id"]
+	if _, err := uuid.Parse(resultID); err != nil {
+		http.Error(w, "result not found", http.StatusNotFound)
+		return
+	}
+	check, err := s.checkShare(ctx, resultID)
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !check.public {
+		// CDN checks again once report is shared
+		w.Header().Set("Cache-Control", "no-store")
+		http.Error(w, "result is not shared publicly", http.StatusForbidden)
+		return
+	}
+	if check.expired {
+		http.Error(w, errResultExpired.Error(), http.StatusGone)
+		return
+	}
+	extension := path.Ext(check.objectName)
+	// one path per result, so CDN doesn't cache same result under other format
+	if extension != "."+vars["format"] {
+		http.Error(w, "result not found", http.StatusNotFound)
+		return
+	}
+	etag := strconv.Quote(resultID)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(publicResultMaxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	objectReader, err := open(ctx, check.bucket, check.objectName)
+	if err == storage.ErrObjectNotExist {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer objectReader.Close()
+	w.Header().Set("Content-Type", publicContentType(extension))
+	if _, err := io.Copy(w, objectReader); err != nil {
+		log.Err(err).Str("resultID", resultID).Msg("Public result not served")
+	}
+}
+
+// ShareReport publicly or revoke share; only author can share report
+func (s Server) ShareReport(ctx context.Context, req *proto.ShareReportRequest) (*proto.ShareReportResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	result, err := s.db.ExecContext(ctx,
+		"update reports set public_share=$1, updated_at=CURRENT_TIMESTAMP where id=$2 and author_email=$3 and not archived",
+		req.Public,
+		req.ReportId,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	s.shares.invalidate(req.ReportId)
+	log.Info().Str("reportID", req.ReportId).Bool("public", req.Public).Msg("Report share changed")
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
+	return &proto.ShareReportResponse{}, nil
+}
@@ -0,0 +1,67 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"testing"
+	"time"
+)
+
+func TestParseShareCacheTTL(t *testing.T) {
+	for value, expected := range map[string]time.Duration{"": defaultShareCacheTTL, "0": 0, "10s": 10 * time.Second} {
+		ttl, err := parseShareCacheTTL(value)
+		if err != nil || ttl != expected {
+			t.Errorf("%q: expected %s, got %s %v", value, expected, ttl, err)
+		}
+	}
+	for _, value := range []string{"-1m", "minute"} {
+		if _, err := parseShareCacheTTL(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestSetPublicResultPaths(t *testing.T) {
+	queries := []*proto.Query{
+		{JobResultId: "csv", ResultType: proto.Query_RESULT_TYPE_FILE},
+		{JobResultId: "parquet", ResultType: proto.Query_RESULT_TYPE_FILE, ResultFormat: proto.ResultFormat_RESULT_FORMAT_PARQUET},
+		{ResultTable: "p.d.t", ResultType: proto.Query_RESULT_TYPE_TABLE},
+	}
+	setPublicResultPaths(&proto.Report{Public: true}, queries)
+	for i, expected := range []string{"/public/results/csv.csv", "/public/results/parquet.parquet", ""} {
+		if queries[i].PublicResultPath != expected {
+			t.Errorf("expected %q, got %q", expected, queries[i].PublicResultPath)
+		}
+	}
+	setPublicResultPaths(&proto.Report{}, queries)
+	for _, query := range queries {
+		if query.PublicResultPath != "" {
+			t.Errorf("expected no public path of private result, got %q", query.PublicResultPath)
+		}
+	}
+}
+
+func TestShareCacheTTL(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	c := newShareCache(time.Minute)
+	c.now = func() time.Time { return now }
+	c.store("result", shareCheck{reportID: "report", public: true})
+	now = now.Add(59 * time.Second)
+	if check, ok := c.cached("result"); !ok || !check.public {
+		t.Errorf("expected cached check, got %v %v", check, ok)
+	}
+	now = now.Add(time.Second)
+	if _, ok := c.cached("result"); ok {
+		t.Errorf("expected expired check")
+	}
+	c.store("result", shareCheck{reportID: "report", public: true})
+	c.invalidate("report")
+	if _, ok := c.cached("result"); ok {
+		t.Errorf("expected invalidated check")
+	}
+	// zero ttl disables cache
+	c = newShareCache(0)
+	c.store("result", shareCheck{public: true})
+	if _, ok := c.cached("result"); ok {
+		t.Errorf("expected disabled cache")
+	}
+}
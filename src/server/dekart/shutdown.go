@@ -0,0 +1,29 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Shutdown cancels jobs of this replica and waits at most timeout until their cancelled status is stored
+// and hooks of finished jobs are run, returns number of cancelled jobs
+func (s Server) Shutdown(timeout time.Duration) int {
+	deadline := time.Now().Add(timeout)
+	cancelled := s.jobs.CancelAll(proto.CancelReason_CANCEL_REASON_SHUTDOWN)
+	stored := make(chan struct{})
+	go func() {
+		s.statusWriters.Wait()
+		close(stored)
+	}()
+	select {
+	case <-stored:
+	case <-time.After(timeout):
+		log.Warn().Str("timeout", timeout.String()).Msg("Status of cancelled jobs was not stored before shutdown")
+	}
+	if !s.jobs.WaitHooks(time.Until(deadline)) {
+		log.Warn().Str("timeout", timeout.String()).Msg("Job hooks didn't finish before shutdown")
+	}
+	return cancelled
+}
@@ -0,0 +1,258 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultDeletedQueryRetentionDays = 30
+	deletedQuerySweepInterval        = time.Hour
+	// maxSweptQueries in one sweep, rest is swept next time
+	maxSweptQueries = 100
+)
+
+// parseDeletedQueryRetention of DEKART_DELETED_QUERY_RETENTION_DAYS, removed queries are restorable during it
+func parseDeletedQueryRetention(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultDeletedQueryRetentionDays * 24 * time.Hour, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_DELETED_QUERY_RETENTION_DAYS %s, expected positive number of days", value)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+func deletedQueryRetention() time.Duration {
+	retention, err := parseDeletedQueryRetention(os.Getenv("DEKART_DELETED_QUERY_RETENTION_DAYS"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return retention
+}
+
+// queryNotFound error of query which report is not found; deleted query is reported as such, so it can be restored
+func (s Server) queryNotFound(ctx context.Context, queryID string) error {
+	var deleted bool
+	err := s.db.QueryRowContext(ctx,
+		`select case when deleted_at is null then false else true end from queries where id=$1`,
+		queryID,
+	).Scan(&deleted)
+	if err != nil && err != sql.ErrNoRows {
+		log.Err(err).Send()
+		return internalError(err)
+	}
+	if deleted {
+		return status.Errorf(codes.FailedPrecondition, "query %s is deleted, restore it with RestoreQuery", queryID)
+	}
+	err = fmt.Errorf("Query not found id:%s", queryID)
+	log.Warn().Err(err).Send()
+	return status.Error(codes.NotFound, err.Error())
+}
+
+// RestoreQuery removed by RemoveQuery, until it's swept after DEKART_DELETED_QUERY_RETENTION_DAYS
+func (s Server) RestoreQuery(ctx context.Context, req *proto.RestoreQueryRequest) (*proto.RestoreQueryResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.QueryId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	query, err := s.restoreQuery(ctx, req.QueryId, claims.Email, time.Now().Add(-s.deletedQueryRetention))
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RestoreQueryResponse{Query: query}, nil
+}
+
+// restoreQuery deleted after cutoff in report of author
+func (s Server) restoreQuery(ctx context.Context, queryID string, email string, cutoff time.Time) (*proto.Query, error) {
+	var reportID string
+	var expired bool
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`select
+			queries.report_id,
+			case when queries.deleted_at < %s then true else false end
+		from queries join reports on queries.report_id = reports.id
+		where queries.id=$2 and queries.deleted_at is not null and reports.author_email=$3 and not reports.archived`,
+			s.dialect.Timestamp("$1"),
+		),
+		s.dialect.TimeArg(cutoff),
+		queryID,
+		email,
+	).Scan(&reportID, &expired)
+	if err == sql.ErrNoRows {
+		err := fmt.Errorf("Deleted query not found id:%s", queryID)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if expired {
+		return nil, status.Errorf(codes.FailedPrecondition, "query %s was deleted more than %d days ago and can't be restored", queryID, int(s.deletedQueryRetention.Hours()/24))
+	}
+	_, err = s.db.ExecContext(ctx, `update queries set deleted_at=null where id=$1`, queryID)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	s.reportStreams.Publish(report.Event{ReportID: reportID, Kind: report.QueryRestored, QueryID: queryID})
+	queries, err := s.getQueries(ctx, reportID)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	for _, query := range queries {
+		if query.Id == queryID {
+			return query, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Query not found id:%s", queryID)
+}
+
+// objectRemover deletes result object; bucket is recorded bucket of result
+type objectRemover func(ctx context.Context, bucket string, name string) error
+
+func (s Server) deleteObject(ctx context.Context, bucket string, name string) error {
+	return s.resultBucket(bucket).Object(name).Delete(ctx)
+}
+
+// sweepDeletedQueries deleted before cutoff with their results, returns number of swept queries;
+// results reused by other queries are kept
+func (s Server) sweepDeletedQueries(ctx context.Context, cutoff time.Time, remove objectRemover) (int64, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`select id from queries where deleted_at < %s order by deleted_at limit $2`, s.dialect.Timestamp("$1")),
+		s.dialect.TimeArg(cutoff),
+		maxSweptQueries,
+	)
+	if err != nil {
+		return 0, err
+	}
+	var queryIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		queryIDs = append(queryIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	var swept int64
+	for _, queryID := range queryIDs {
+		removed, err := s.sweepQueryResults(ctx, queryID, remove)
+		if err != nil {
+			return swept, err
+		}
+		if !removed {
+			// objects are removed again on next sweep
+			continue
+		}
+		if _, err := s.db.ExecContext(ctx, `delete from run_keys where query_id=$1`, queryID); err != nil {
+			return swept, err
+		}
+		if _, err := s.db.ExecContext(ctx, `delete from queries where id=$1`, queryID); err != nil {
+			return swept, err
+		}
+		swept++
+	}
+	return swept, nil
+}
+
+// sweepQueryResults removes results of query not referenced by other queries, false when some object can't be removed
+func (s Server) sweepQueryResults(ctx context.Context, queryID string, remove objectRemover) (bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`select
+			id,
+			object_name,
+			case when bucket is null then '' else bucket end,
+			case when expired_at is null then false else true end
+		from results
+		where query_id=$1 and not exists (
+			select 1 from queries
+			where queries.id <> $1 and (queries.job_result_id = results.id or queries.h3_result_id = results.id)
+		)`,
+		queryID,
+	)
+	if err != nil {
+		return false, err
+	}
+	type result struct {
+		id, objectName, bucket string
+		expired                bool
+	}
+	var results []result
+	for rows.Next() {
+		var r result
+		if err := rows.Scan(&r.id, &r.objectName, &r.bucket, &r.expired); err != nil {
+			rows.Close()
+			return false, err
+		}
+		results = append(results, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	removed := true
+	for _, r := range results {
+		if !r.expired {
+			err := remove(ctx, r.bucket, r.objectName)
+			if err != nil && err != storage.ErrObjectNotExist {
+				log.Warn().Err(err).Str("resultID", r.id).Str("queryID", queryID).Msg("Cannot delete result of deleted query")
+				removed = false
+				continue
+			}
+		}
+		if _, err := s.db.ExecContext(ctx, `delete from result_lineage where result_id=$1`, r.id); err != nil {
+			return false, err
+		}
+		if _, err := s.db.ExecContext(ctx, `delete from results where id=$1`, r.id); err != nil {
+			return false, err
+		}
+	}
+	return removed, nil
+}
+
+// ScheduleDeletedQueriesSweep removes queries deleted more than DEKART_DELETED_QUERY_RETENTION_DAYS ago at start and every hour
+func (s Server) ScheduleDeletedQueriesSweep() {
+	log.Info().Str("retention", s.deletedQueryRetention.String()).Msg("Deleted queries sweep scheduled")
+	go func() {
+		ticker := time.NewTicker(deletedQuerySweepInterval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			swept, err := s.sweepDeletedQueries(ctx, time.Now().Add(-s.deletedQueryRetention), s.deleteObject)
+			cancel()
+			if err != nil {
+				log.Err(err).Msg("Cannot sweep deleted queries")
+			} else if swept > 0 {
+				log.Info().Int64("swept", swept).Msg("Deleted queries swept")
+			}
+			<-ticker.C
+		}
+	}()
+}
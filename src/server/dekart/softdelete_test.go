@@ -0,0 +1,20 @@
+package dekart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDeletedQueryRetention(t *testing.T) {
+	if retention, err := parseDeletedQueryRetention(""); err != nil || retention != 30*24*time.Hour {
+		t.Errorf("expected 30 days, got %v %v", retention, err)
+	}
+	if retention, err := parseDeletedQueryRetention("7"); err != nil || retention != 7*24*time.Hour {
+		t.Errorf("expected 7 days, got %v %v", retention, err)
+	}
+	for _, value := range []string{"0", "-1", "7d"} {
+		if _, err := parseDeletedQueryRetention(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// storageDateFormat of {date} in DEKART_STORAGE_PREFIX, partitions results by day
+const storageDateFormat = "2006/01/02"
+
+var storagePlaceholderRe = regexp.MustCompile(`{[^{}]*}`)
+
+// parseStoragePrefix of DEKART_STORAGE_PREFIX template with {reportID} and {date} placeholders;
+// datePartition appends {date} when template has none
+func parseStoragePrefix(template string, datePartition bool) (string, error) {
+	if datePartition && !strings.Contains(template, "{date}") {
+		if template != "" && !strings.HasSuffix(template, "/") {
+			template += "/"
+		}
+		template += "{date}/"
+	}
+	for _, placeholder := range storagePlaceholderRe.FindAllString(template, -1) {
+		if placeholder != "{reportID}" && placeholder != "{date}" {
+			return "", fmt.Errorf("invalid DEKART_STORAGE_PREFIX %s: unknown placeholder %s", template, placeholder)
+		}
+	}
+	name := storageObjectName(template, "00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000000", time.Time{}, ".csv")
+	if strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("invalid DEKART_STORAGE_PREFIX %s: object names can't start with slash", template)
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if strings.TrimSpace(segment) == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("invalid DEKART_STORAGE_PREFIX %s: object names can't have empty, . or .. path segments", template)
+		}
+	}
+	return template, nil
+}
+
+func storagePrefix() string {
+	prefix, err := parseStoragePrefix(os.Getenv("DEKART_STORAGE_PREFIX"), os.Getenv("DEKART_STORAGE_DATE_PARTITION") == "1")
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return prefix
+}
+
+// storageObjectName of result created at t, prefix is rendered template of DEKART_STORAGE_PREFIX,
+// extension is of result format
+func storageObjectName(prefix string, reportID string, resultID string, t time.Time, extension string) string {
+	prefix = strings.NewReplacer(
+		"{reportID}", reportID,
+		"{date}", t.UTC().Format(storageDateFormat),
+	).Replace(prefix)
+	return prefix + resultID + extension
+}
+
+// resultExtension of result object in format, unspecified format is CSV
+func resultExtension(format proto.ResultFormat) string {
+	if format == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		return ".parquet"
+	}
+	return ".csv"
+}
+
+// compressed result file is stored with Content-Encoding gzip
+func compressed(compression proto.ResultCompression) bool {
+	return compression == proto.ResultCompression_RESULT_COMPRESSION_GZIP
+}
+
+// UseFallbackBucket receiving results when upload to bucket fails with retryable error;
+// bucket holding result is recorded, so results are read from fallback bucket
+func (s *Server) UseFallbackBucket(name string, bucket *storage.BucketHandle) {
+	s.fallbackBucketName = name
+	s.fallbackBucket = bucket
+}
+
+// isResultBucket when results of instance are stored in bucket, primary or fallback
+func (s Server) isResultBucket(name string) bool {
+	if name == os.Getenv("DEKART_CLOUD_STORAGE_BUCKET") {
+		return true
+	}
+	return s.fallbackBucket != nil && name == s.fallbackBucketName
+}
+
+// resultBucket of result recorded in bucket, empty name is primary bucket
+func (s Server) resultBucket(name string) *storage.BucketHandle {
+	if name == "" {
+		return s.bucket
+	}
+	if s.fallbackBucket == nil || name != s.fallbackBucketName {
+		// fallback bucket was changed, result is read from primary bucket in case it was copied back
+		log.Warn().Str("bucket", name).Msg("Result is stored in bucket which is not configured as fallback bucket")
+		return s.bucket
+	}
+	return s.fallbackBucket
+}
+
+// createResultObject for result of report; object name is recorded by resultID,
+// so result is found when DEKART_STORAGE_PREFIX is changed; queryID is empty for results not run by query
+func (s Server) createResultObject(ctx context.Context, reportID string, queryID string, resultID string, extension string) (*storage.ObjectHandle, error) {
+	name := storageObjectName(s.storagePrefix, reportID, resultID, time.Now(), extension)
+	_, err := s.db.ExecContext(ctx,
+		`insert into results (id, object_name, query_id) values ($1, $2, $3)`,
+		resultID,
+		name,
+		nullID(queryID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return s.bucket.Object(name), nil
+}
+
+// storeResultEncoding of CSV result run with options, so result is read with delimiter it was written with;
+// compression is recorded as effective setting of run
+func (s Server) storeResultEncoding(ctx context.Context, resultID string, options job.RunOptions) error {
+	value, err := job.MarshalSerialization(options.Serialization)
+	if err != nil {
+		return err
+	}
+	if value == "" && options.Compression == proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx,
+		`update results set serialization=$1, compression=$2 where id=$3`,
+		value,
+		int32(options.Compression),
+		resultID,
+	)
+	return err
+}
+
+// resultSerialization of CSV result, nil for result written with default serialization
+func (s Server) resultSerialization(ctx context.Context, resultID string) (*proto.SerializationOptions, error) {
+	if _, err := uuid.Parse(resultID); err != nil {
+		return nil, nil
+	}
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select case when serialization is null then '' else serialization end from results where id=$1`,
+		resultID,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job.UnmarshalSerialization(value)
+}
+
+// resultColumnTypes of result, nil for result stored before column types were recorded
+func (s Server) resultColumnTypes(ctx context.Context, resultID string) ([]string, error) {
+	if _, err := uuid.Parse(resultID); err != nil {
+		return nil, nil
+	}
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select case when column_types is null then '' else column_types end from results where id=$1`,
+		resultID,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job.UnmarshalColumnTypes(value)
+}
+
+// resultColumnNames of result, nil when header has original names
+func (s Server) resultColumnNames(ctx context.Context, resultID string) ([]job.ColumnName, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select case when column_names is null then '' else column_names end from results where id=$1`,
+		resultID,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job.UnmarshalColumnNames(value)
+}
+
+// resultDatabaseTypes of result of SQL connection, nil for BigQuery result
+func (s Server) resultDatabaseTypes(ctx context.Context, resultID string) ([]string, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`select case when database_types is null then '' else database_types end from results where id=$1`,
+		resultID,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job.UnmarshalDatabaseTypes(value)
+}
+
+// resultObject by resultID; results without recorded name were stored at bucket root,
+// errResultExpired when object was removed by bucket lifecycle
+func (s Server) resultObject(ctx context.Context, resultID string) (*storage.ObjectHandle, error) {
+	if _, err := uuid.Parse(resultID); err != nil {
+		return s.bucket.Object(fmt.Sprintf("%s.csv", resultID)), nil
+	}
+	var name, bucket string
+	var expired bool
+	err := s.db.QueryRowContext(ctx,
+		`select object_name,
+			case when expired_at is null then false else true end,
+			case when bucket is null then '' else bucket end
+		from results where id=$1`,
+		resultID,
+	).Scan(&name, &expired, &bucket)
+	if err == sql.ErrNoRows {
+		return s.bucket.Object(fmt.Sprintf("%s.csv", resultID)), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, errResultExpired
+	}
+	return s.resultBucket(bucket).Object(name), nil
+}
@@ -0,0 +1,48 @@
+package dekart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStoragePrefix(t *testing.T) {
+	valid := []struct {
+		template      string
+		datePartition bool
+		expect        string
+	}{
+		{"", false, ""},
+		{"", true, "{date}/"},
+		{"prod/results/", false, "prod/results/"},
+		{"prod/results", true, "prod/results/{date}/"},
+		{"prod/{date}/", true, "prod/{date}/"},
+		{"prod/{reportID}/", false, "prod/{reportID}/"},
+		{"prod-", false, "prod-"},
+	}
+	for _, test := range valid {
+		prefix, err := parseStoragePrefix(test.template, test.datePartition)
+		if err != nil || prefix != test.expect {
+			t.Errorf("parseStoragePrefix(%q, %t) = %q %v, expected %q", test.template, test.datePartition, prefix, err, test.expect)
+		}
+	}
+	for _, template := range []string{"/results/", "prod//", "prod/{jobID}/", "{date}//", "./", "prod/../", " /"} {
+		if _, err := parseStoragePrefix(template, false); err == nil {
+			t.Errorf("expected error for %q", template)
+		}
+	}
+}
+
+func TestStorageObjectName(t *testing.T) {
+	created := time.Date(2024, 5, 3, 23, 0, 0, 0, time.FixedZone("", -3600*5))
+	name := storageObjectName("prod/{reportID}/{date}/", "r", "id", created, ".csv")
+	// date is UTC
+	if name != "prod/r/2024/05/04/id.csv" {
+		t.Errorf("unexpected name %s", name)
+	}
+	if name := storageObjectName("", "r", "id", created, ".csv"); name != "id.csv" {
+		t.Errorf("expected legacy name, got %s", name)
+	}
+	if name := storageObjectName("", "r", "id", created, ".parquet"); name != "id.parquet" {
+		t.Errorf("expected parquet name, got %s", name)
+	}
+}
@@ -3,6 +3,7 @@ package dekart
 import (
 	"context"
 	"dekart/src/proto"
+	"dekart/src/server/job"
 	"dekart/src/server/report"
 	"dekart/src/server/user"
 	"fmt"
@@ -14,45 +15,91 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func (s Server) sendReportMessage(reportID string, srv proto.Dekart_GetReportStreamServer, sequence int64) error {
-	ctx := srv.Context()
-
-	report, err := s.getReport(ctx, reportID)
+// reportStreamResponse for event; incremental response contains only changed report or query
+func (s Server) reportStreamResponse(ctx context.Context, reportID string, event report.Event, incremental bool) (*proto.ReportStreamResponse, error) {
+	rep, err := s.getReport(ctx, reportID)
 	if err != nil {
 		log.Err(err).Msg("Cannot retrieve report")
-		return status.Errorf(codes.Internal, err.Error())
+		return nil, internalError(err)
 	}
-	if report == nil {
+	if rep == nil {
 		err := fmt.Errorf("Report %s not found", reportID)
 		log.Warn().Err(err).Send()
-		return status.Errorf(codes.NotFound, err.Error())
+		return nil, status.Errorf(codes.NotFound, err.Error())
+	}
+	res := &proto.ReportStreamResponse{
+		StreamOptions: &proto.StreamOptions{
+			Sequence: event.Sequence,
+		},
+	}
+	if !incremental || event.Kind != report.JobStatusChanged {
+		// files are changed with report
+		res.Files, err = s.getFiles(ctx, reportID)
+		if err != nil {
+			log.Err(err).Msg("Cannot retrieve files")
+			return nil, internalError(err)
+		}
+	}
+	if incremental && event.Kind == report.ReportChanged {
+		res.Report = rep
+		res.StreamOptions.Incremental = true
+		return res, nil
 	}
 
 	queries, err := s.getQueries(ctx, reportID)
-
 	if err != nil {
 		log.Err(err).Msg("Cannot retrieve queries")
-		return status.Errorf(codes.Internal, err.Error())
+		return nil, internalError(err)
+	}
+	markStale(queries, rep.FreshnessPolicy, s.now())
+	setPublicResultPaths(rep, queries)
+	if incremental && event.Kind == report.JobStatusChanged {
+		for _, query := range queries {
+			if query.Id == event.QueryID {
+				res.Queries = []*proto.Query{query}
+				res.BatchProgress = batchProgress(queries)
+				res.StreamOptions.Incremental = true
+				return res, nil
+			}
+		}
 	}
+	switch event.Kind {
+	case report.QueryDeleted:
+		res.StreamOptions.DeletedQueryId = event.QueryID
+	case report.QueryRestored:
+		res.StreamOptions.RestoredQueryId = event.QueryID
+	}
+	// query added, removed or restored, resync or unknown query: full state
+	res.Report = rep
+	res.Queries = queries
+	res.BatchProgress = batchProgress(queries)
+	return res, nil
+}
 
-	res := proto.ReportStreamResponse{
-		Report:  report,
-		Queries: queries,
-		StreamOptions: &proto.StreamOptions{
-			Sequence: sequence,
-		},
+// compatJobStatuses of queries in response for clients which don't know statuses of reading and saving result
+func compatJobStatuses(res *proto.ReportStreamResponse) {
+	for _, query := range res.Queries {
+		query.JobStatus = job.CompatStatus(query.JobStatus)
 	}
+}
 
-	err = srv.Send(&res)
+func (s Server) sendReportMessage(reportID string, srv proto.Dekart_GetReportStreamServer, event report.Event, incremental bool, fineGrained bool) error {
+	res, err := s.reportStreamResponse(srv.Context(), reportID, event, incremental)
+	if err != nil {
+		return err
+	}
+	if !fineGrained {
+		compatJobStatuses(res)
+	}
+	err = srv.Send(res)
 	if err != nil {
 		log.Err(err).Send()
 		return err
 	}
 	return nil
-
 }
 
-// GetReportStream which sends report and queries on every update
+// GetReportStream which sends report and queries on every change event until stream timeout
 func (s Server) GetReportStream(req *proto.ReportStreamRequest, srv proto.Dekart_GetReportStreamServer) error {
 	claims := user.GetClaims(srv.Context())
 	if claims == nil {
@@ -75,18 +122,25 @@ func (s Server) GetReportStream(req *proto.ReportStreamRequest, srv proto.Dekart
 	streamID, err := uuid.NewRandom()
 	if err != nil {
 		log.Err(err).Send()
-		return status.Error(codes.Internal, err.Error())
+		return internalError(err)
 	}
-	ping := s.reportStreams.Register(req.Report.Id, streamID.String(), req.StreamOptions.Sequence)
+	events := s.reportStreams.Register(req.Report.Id, streamID.String(), req.StreamOptions.Sequence)
 	defer s.reportStreams.Deregister(req.Report.Id, streamID.String())
+	if req.StreamOptions.Sequence == 0 {
+		// report is opened, stream is registered so reruns are streamed to viewer
+		s.rerunStale(srv.Context(), req.Report.Id)
+	}
 
 	ctx, cancel := context.WithTimeout(srv.Context(), 55*time.Second)
 	defer cancel()
 
 	for {
 		select {
-		case sequence := <-ping:
-			return s.sendReportMessage(req.Report.Id, srv, sequence)
+		case event := <-events:
+			err := s.sendReportMessage(req.Report.Id, srv, event, req.StreamOptions.Incremental, req.FineGrainedJobStatus)
+			if err != nil {
+				return err
+			}
 		case <-ctx.Done():
 			return nil
 		}
@@ -107,7 +161,7 @@ func (s Server) sendReportList(ctx context.Context, srv proto.Dekart_GetReportLi
 	)
 	if err != nil {
 		log.Err(err).Send()
-		return status.Errorf(codes.Internal, err.Error())
+		return internalError(err)
 	}
 	defer reportRows.Close()
 	res := proto.ReportListResponse{
@@ -128,7 +182,7 @@ func (s Server) sendReportList(ctx context.Context, srv proto.Dekart_GetReportLi
 	err = srv.Send(&res)
 	if err != nil {
 		log.Err(err).Send()
-		return status.Errorf(codes.Internal, err.Error())
+		return internalError(err)
 	}
 	return nil
 }
@@ -147,10 +201,10 @@ func (s Server) GetReportListStream(req *proto.ReportListRequest, srv proto.Deka
 	streamID, err := uuid.NewRandom()
 	if err != nil {
 		log.Err(err).Send()
-		return status.Error(codes.Internal, err.Error())
+		return internalError(err)
 	}
 
-	ping := s.reportStreams.Register(report.All, streamID.String(), req.StreamOptions.Sequence)
+	events := s.reportStreams.Register(report.All, streamID.String(), req.StreamOptions.Sequence)
 	defer s.reportStreams.Deregister(report.All, streamID.String())
 
 	ctx, cancel := context.WithTimeout(srv.Context(), 55*time.Second)
@@ -158,8 +212,11 @@ func (s Server) GetReportListStream(req *proto.ReportListRequest, srv proto.Deka
 
 	for {
 		select {
-		case sequence := <-ping:
-			return s.sendReportList(ctx, srv, sequence)
+		case event := <-events:
+			err := s.sendReportList(ctx, srv, event.Sequence)
+			if err != nil {
+				return err
+			}
 		case <-ctx.Done():
 			return nil
 		}
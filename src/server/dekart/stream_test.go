@@ -0,0 +1,179 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc"
+)
+
+const (
+	testReportID = "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+	testQueryID  = "1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"
+)
+
+var reportColumns = []string{"id", "map_config", "title", "can_write", "billing_project", "default_dataset", "map_config_revision", "variables", "exploration_limit", "public_share", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "freshness_max_age", "freshness_auto_rerun"}
+
+var fileColumns = []string{"id", "report_id", "name", "file_type", "size", "author_email", "created_at"}
+
+var queryColumns = []string{
+	"id", "query_text", "job_status", "job_result_id", "job_error",
+	"job_duration", "total_rows", "bytes_processed", "result_size", "rows_written", "sample_rate", "h3_result_id", "title",
+	"executed_query_text",
+	"result_table",
+	"reused_from_query_id", "result_created_at", "result_format", "exploration_limit", "job_warnings", "batch_id", "depends_on", "skipped_dependency_id", "serialization", "plan_available", "use_legacy_sql", "exact_total_rows", "result_compression", "parameter_bindings", "format_results", "cancel_reason", "job_reservation", "preview_result_id", "preview_rows", "job_error_explanation", "result_meta",
+}
+
+func newTestServer(t *testing.T) (*Server, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewServer(db, nil, job.NewStore(), report.NewStreams()), mock
+}
+
+func testClaimsContext() context.Context {
+	return user.NewClaimsCheck(user.ClaimsCheckConfig{}).GetContext(httptest.NewRequest("GET", "/", nil))
+}
+
+func expectTestReport(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("from reports where id").
+		WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testReportID, "{}", "Report", true, "", "", 1, "", 0, false, 0, 0, "", "", "", 0, false))
+}
+
+func expectTestFiles(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("from files where report_id").
+		WillReturnRows(sqlmock.NewRows(fileColumns))
+}
+
+func expectTestQueries(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("from queries where report_id").
+		WillReturnRows(sqlmock.NewRows(queryColumns).
+			AddRow(testQueryID, "select 1", 2, "", "", 0, 0, 0, 0, 0, 0, "", "Query 1", "", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", "").
+			AddRow("2b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed", "select 2", 0, "", "", 0, 0, 0, 0, 0, 0, "", "Query 2", "", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+}
+
+func TestReportStreamResponse(t *testing.T) {
+	ctx := testClaimsContext()
+
+	t.Run("report changed incremental", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		expectTestReport(mock)
+		expectTestFiles(mock)
+		res, err := s.reportStreamResponse(ctx, testReportID, report.Event{Sequence: 3, Kind: report.ReportChanged}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.StreamOptions.Incremental || res.Report == nil || len(res.Queries) != 0 || res.StreamOptions.Sequence != 3 {
+			t.Errorf("expected report only, got %v", res)
+		}
+	})
+
+	t.Run("job status incremental", func(t *testing.T) {
+		s, mock := newTestServer(t)
+		expectTestReport(mock)
+		expectTestQueries(mock)
+		res, err := s.reportStreamResponse(ctx, testReportID, report.Event{Sequence: 4, Kind: report.JobStatusChanged, QueryID: testQueryID}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.StreamOptions.Incremental || res.Report != nil || len(res.Queries) != 1 || res.Queries[0].Id != testQueryID {
+			t.Errorf("expected changed query only, got %v", res)
+		}
+	})
+
+	for name, c := range map[string]struct {
+		event       report.Event
+		incremental bool
+	}{
+		"resync":          {report.Event{Sequence: 5, Kind: report.Resync}, true},
+		"query changed":   {report.Event{Sequence: 5, Kind: report.QueryChanged, QueryID: testQueryID}, true},
+		"query deleted":   {report.Event{Sequence: 5, Kind: report.QueryDeleted, QueryID: testQueryID}, true},
+		"query restored":  {report.Event{Sequence: 5, Kind: report.QueryRestored, QueryID: testQueryID}, true},
+		"not incremental": {report.Event{Sequence: 5, Kind: report.JobStatusChanged, QueryID: testQueryID}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, mock := newTestServer(t)
+			expectTestReport(mock)
+			expectTestFiles(mock)
+			expectTestQueries(mock)
+			res, err := s.reportStreamResponse(ctx, testReportID, c.event, c.incremental)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StreamOptions.Incremental || res.Report == nil || len(res.Queries) != 2 {
+				t.Errorf("expected full state, got %v", res)
+			}
+			if deleted := res.StreamOptions.DeletedQueryId; (c.event.Kind == report.QueryDeleted) != (deleted == testQueryID) {
+				t.Errorf("unexpected deleted query %q", deleted)
+			}
+			if restored := res.StreamOptions.RestoredQueryId; (c.event.Kind == report.QueryRestored) != (restored == testQueryID) {
+				t.Errorf("unexpected restored query %q", restored)
+			}
+		})
+	}
+}
+
+// fakeReportStream records responses sent to subscriber
+type fakeReportStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*proto.ReportStreamResponse
+}
+
+func (f *fakeReportStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeReportStream) Send(res *proto.ReportStreamResponse) error {
+	f.sent = append(f.sent, res)
+	return nil
+}
+
+func TestReportStreamAfterJobDone(t *testing.T) {
+	s, mock := newTestServer(t)
+	// job finished while subscriber was reconnecting, subscriber already has sequence of DONE event
+	sequence := s.reportStreams.Publish(report.Event{
+		ReportID:  testReportID,
+		Kind:      report.JobStatusChanged,
+		QueryID:   testQueryID,
+		JobStatus: int32(proto.Query_JOB_STATUS_DONE),
+	})
+	expectTestReport(mock)
+	expectTestFiles(mock)
+	mock.ExpectQuery("from queries where report_id").
+		WillReturnRows(sqlmock.NewRows(queryColumns).
+			AddRow(testQueryID, "select 1", int32(proto.Query_JOB_STATUS_DONE), testQueryID, "", 0, 1, 1, 1, 1, 0, "", "Query 1", "select 1", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+
+	ctx, cancel := context.WithTimeout(testClaimsContext(), 100*time.Millisecond)
+	defer cancel()
+	srv := &fakeReportStream{ctx: ctx}
+	err := s.GetReportStream(&proto.ReportStreamRequest{
+		Report:        &proto.Report{Id: testReportID},
+		StreamOptions: &proto.StreamOptions{Sequence: sequence, Incremental: true},
+	}, srv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.sent) != 1 {
+		t.Fatalf("expected current state sent once, got %d responses", len(srv.sent))
+	}
+	res := srv.sent[0]
+	if res.StreamOptions.Incremental || res.StreamOptions.Sequence != sequence || len(res.Queries) != 1 {
+		t.Fatalf("expected full state with sequence %d, got %v", sequence, res)
+	}
+	if query := res.Queries[0]; query.JobStatus != proto.Query_JOB_STATUS_DONE || query.JobResultId != testQueryID {
+		t.Errorf("expected terminal state, got %v", query)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
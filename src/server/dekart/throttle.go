@@ -0,0 +1,73 @@
+package dekart
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultStatusInterval between progress updates of job published to clients
+const defaultStatusInterval = 500 * time.Millisecond
+
+// parseStatusInterval of DEKART_STATUS_UPDATE_INTERVAL
+func parseStatusInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultStatusInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_STATUS_UPDATE_INTERVAL %s, expected positive duration", value)
+	}
+	return interval, nil
+}
+
+func statusInterval() time.Duration {
+	interval, err := parseStatusInterval(os.Getenv("DEKART_STATUS_UPDATE_INTERVAL"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return interval
+}
+
+// statusThrottle of job updates published to clients. Status transitions are published immediately,
+// so terminal status and change between states are never coalesced away. Progress within status is
+// published at most once per interval; progress received in between is coalesced into one update at end of interval.
+type statusThrottle struct {
+	interval  time.Duration
+	published time.Time
+	pending   bool
+}
+
+// transition published at now; pending progress is superseded, transition update has latest progress too
+func (t *statusThrottle) transition(now time.Time) {
+	t.published = now
+	t.pending = false
+}
+
+// progress received at now, returns true when it's published now; otherwise it's pending until due
+func (t *statusThrottle) progress(now time.Time) bool {
+	if now.Sub(t.published) >= t.interval {
+		t.published = now
+		t.pending = false
+		return true
+	}
+	t.pending = true
+	return false
+}
+
+// due time of pending progress, false without pending progress
+func (t *statusThrottle) due() (time.Time, bool) {
+	return t.published.Add(t.interval), t.pending
+}
+
+// flush pending progress at now, returns true when it's published
+func (t *statusThrottle) flush(now time.Time) bool {
+	if due, pending := t.due(); !pending || now.Before(due) {
+		return false
+	}
+	t.published = now
+	t.pending = false
+	return true
+}
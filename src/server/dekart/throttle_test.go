@@ -0,0 +1,121 @@
+package dekart
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStatusInterval(t *testing.T) {
+	for value, expected := range map[string]time.Duration{"": defaultStatusInterval, "1s": time.Second} {
+		if interval, err := parseStatusInterval(value); err != nil || interval != expected {
+			t.Errorf("%q: expected %s, got %s %v", value, expected, interval, err)
+		}
+	}
+	for _, invalid := range []string{"0", "-1s", "fast"} {
+		if _, err := parseStatusInterval(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+// throttleUpdate published by statusThrottle at fake time
+type throttleUpdate struct {
+	at         time.Duration
+	transition bool
+}
+
+// runThrottle feeds progress every millisecond and transitions at given times,
+// pending progress is flushed when due like timer of updateJobStatus
+func runThrottle(interval time.Duration, duration time.Duration, transitions map[time.Duration]bool) []throttleUpdate {
+	start := time.Unix(1600000000, 0)
+	throttle := statusThrottle{interval: interval}
+	var updates []throttleUpdate
+	for at := time.Duration(0); at < duration; at += time.Millisecond {
+		now := start.Add(at)
+		if due, pending := throttle.due(); pending && !now.Before(due) && throttle.flush(now) {
+			updates = append(updates, throttleUpdate{at: at})
+		}
+		if transitions[at] {
+			throttle.transition(now)
+			updates = append(updates, throttleUpdate{at: at, transition: true})
+			continue
+		}
+		if throttle.progress(now) {
+			updates = append(updates, throttleUpdate{at: at})
+		}
+	}
+	return updates
+}
+
+func TestStatusThrottleRateCap(t *testing.T) {
+	updates := runThrottle(500*time.Millisecond, 2*time.Second, nil)
+	// 2000 progress events are published at 0, 500, 1000 and 1500ms
+	if len(updates) != 4 {
+		t.Fatalf("expected 4 updates, got %v", updates)
+	}
+	for i := 1; i < len(updates); i++ {
+		if gap := updates[i].at - updates[i-1].at; gap < 500*time.Millisecond {
+			t.Errorf("updates %v and %v are %s apart", updates[i-1], updates[i], gap)
+		}
+	}
+}
+
+func TestStatusThrottleKeepsTransitions(t *testing.T) {
+	// running, reading and done close to each other and to progress
+	transitions := map[time.Duration]bool{
+		10 * time.Millisecond:  true,
+		11 * time.Millisecond:  true,
+		700 * time.Millisecond: true,
+		701 * time.Millisecond: true,
+	}
+	updates := runThrottle(500*time.Millisecond, time.Second, transitions)
+	published := map[time.Duration]bool{}
+	var progress []time.Duration
+	for _, u := range updates {
+		if u.transition {
+			published[u.at] = true
+		} else {
+			progress = append(progress, u.at)
+		}
+	}
+	// every transition is published immediately
+	for at := range transitions {
+		if !published[at] {
+			t.Errorf("transition at %s was coalesced, updates %v", at, updates)
+		}
+	}
+	// progress after transition waits for interval since transition, progress pending at transition is superseded
+	expected := []time.Duration{0, 511 * time.Millisecond}
+	if len(progress) != len(expected) {
+		t.Fatalf("expected progress at %v, got %v", expected, progress)
+	}
+	for i := range expected {
+		if progress[i] != expected[i] {
+			t.Errorf("expected progress at %v, got %v", expected, progress)
+		}
+	}
+}
+
+func TestStatusThrottleFlush(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	throttle := statusThrottle{interval: time.Second}
+	throttle.transition(now)
+	if throttle.flush(now.Add(2 * time.Second)) {
+		t.Error("nothing pending, expected no update")
+	}
+	if throttle.progress(now.Add(100 * time.Millisecond)) {
+		t.Error("expected progress pending")
+	}
+	if due, pending := throttle.due(); !pending || !due.Equal(now.Add(time.Second)) {
+		t.Errorf("expected progress due in 1s, got %s %t", due, pending)
+	}
+	if throttle.flush(now.Add(900 * time.Millisecond)) {
+		t.Error("expected no update before due")
+	}
+	if !throttle.flush(now.Add(time.Second)) {
+		t.Error("expected pending progress published when due")
+	}
+	if _, pending := throttle.due(); pending {
+		t.Error("expected nothing pending after flush")
+	}
+}
@@ -0,0 +1,114 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxTitleLength in characters of report and query titles
+const maxTitleLength = 200
+
+// validateTitle of report or query, returns trimmed title
+func validateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if !utf8.ValidString(title) {
+		return "", status.Error(codes.InvalidArgument, "title is not valid UTF-8")
+	}
+	if n := utf8.RuneCountInString(title); n > maxTitleLength {
+		return "", status.Errorf(codes.InvalidArgument, "title of %d characters exceeds limit of %d characters", n, maxTitleLength)
+	}
+	for _, r := range title {
+		if unicode.IsControl(r) {
+			return "", status.Errorf(codes.InvalidArgument, "title contains control character %U", r)
+		}
+	}
+	return title, nil
+}
+
+var unsafeFilenameRe = regexp.MustCompile(`[^\p{L}\p{N}._ -]+`)
+
+// titleFilename is title usable as download file name
+func titleFilename(title string, ext string) string {
+	name := strings.TrimSpace(unsafeFilenameRe.ReplaceAllString(title, "_"))
+	if name == "" {
+		name = "result"
+	}
+	return name + ext
+}
+
+// UpdateQueryTitle of query in report owned by user
+func (s Server) UpdateQueryTitle(ctx context.Context, req *proto.UpdateQueryTitleRequest) (*proto.UpdateQueryTitleResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.QueryId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	title, err := validateTitle(req.Title)
+	if err != nil {
+		return nil, err
+	}
+	if title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is empty")
+	}
+	reportID, err := s.getReportID(ctx, req.QueryId, claims.Email)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if reportID == nil {
+		return nil, s.queryNotFound(ctx, req.QueryId)
+	}
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`update queries set title=$1, updated_at=CURRENT_TIMESTAMP where id=$2`,
+		title,
+		req.QueryId,
+	)
+	if err == nil {
+		_, err = tx.ExecContext(ctx,
+			`update reports set updated_at=CURRENT_TIMESTAMP where id=$1`,
+			*reportID,
+		)
+	}
+	if err != nil {
+		rollback(tx)
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+
+	s.reportStreams.Publish(report.Event{ReportID: *reportID, Kind: report.QueryChanged, QueryID: req.QueryId})
+
+	return &proto.UpdateQueryTitleResponse{
+		Query: &proto.Query{
+			Id:       req.QueryId,
+			ReportId: *reportID,
+			Title:    title,
+		},
+	}, nil
+}
@@ -0,0 +1,43 @@
+package dekart
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateTitle(t *testing.T) {
+	for title, expected := range map[string]string{
+		"  Roads  ":                         "Roads",
+		"":                                  "",
+		"Straßen und Plätze":                "Straßen und Plätze",
+		strings.Repeat("ü", maxTitleLength): strings.Repeat("ü", maxTitleLength),
+	} {
+		valid, err := validateTitle(title)
+		if err != nil || valid != expected {
+			t.Errorf("%q: expected %q, got %q %v", title, expected, valid, err)
+		}
+	}
+	for _, title := range []string{"a\nb", "a\x00b", "\xff", strings.Repeat("a", maxTitleLength+1)} {
+		if _, err := validateTitle(title); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%q: expected InvalidArgument, got %v", title, err)
+		}
+	}
+}
+
+func TestTitleFilename(t *testing.T) {
+	for title, expected := range map[string]string{
+		"Roads - Query 1": "Roads - Query 1.csv",
+		`a/b\c"d`:         "a_b_c_d.csv",
+		"Straßen":         "Straßen.csv",
+		"../":             ".._.csv",
+		"":                "result.csv",
+		"    ":            "result.csv",
+	} {
+		if filename := titleFilename(title, ".csv"); filename != expected {
+			t.Errorf("%q: expected %q, got %q", title, expected, filename)
+		}
+	}
+}
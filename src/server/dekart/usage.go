@@ -0,0 +1,267 @@
+package dekart
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/proto"
+	"dekart/src/server/dialect"
+	"dekart/src/server/job"
+	"dekart/src/server/metrics"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultUsagePeriod of GetUsage without time range and of usage metrics
+const defaultUsagePeriod = 30 * 24 * time.Hour
+
+// jobStatsPruneInterval of scheduled removal of job stats older than retention
+const jobStatsPruneInterval = time.Hour
+
+// JobStatsRecorder stores statistics of finished jobs in job_stats table and bytes billed in usage counters
+type JobStatsRecorder struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+}
+
+// NewJobStatsRecorder returns job.StatsRecorder backed by database
+func NewJobStatsRecorder(db *sql.DB) *JobStatsRecorder {
+	return &JobStatsRecorder{db: db, dialect: dialect.Of(db)}
+}
+
+// Record stats of finished job, bytes billed are counted in month job finished
+func (r *JobStatsRecorder) Record(ctx context.Context, stats job.FinishedJob) error {
+	events, err := job.MarshalEvents(stats.Events)
+	if err != nil {
+		return err
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx,
+		`insert into job_stats (id, query_id, report_id, user_email, job_status, bytes_processed, bytes_billed, cache_hit, duration_ms, cancel_reason, events, events_dropped)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		stats.JobID,
+		stats.QueryID,
+		stats.ReportID,
+		stats.UserEmail,
+		stats.Status,
+		stats.BytesProcessed,
+		stats.BytesBilled,
+		stats.CacheHit,
+		stats.Duration.Milliseconds(),
+		int32(stats.CancelReason),
+		events,
+		stats.EventsDropped,
+	)
+	if err != nil {
+		return err
+	}
+	if stats.BytesBilled > 0 {
+		finishedAt := stats.FinishedAt
+		if finishedAt.IsZero() {
+			finishedAt = time.Now()
+		}
+		if err := incrementUsage(ctx, tx, r.dialect, usageMonth(finishedAt), stats.UserEmail, stats.BytesBilled); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// usageKey expression of job_stats grouped by, empty for total
+func usageKey(d dialect.Dialect, groupBy proto.GetUsageRequest_GroupBy) (string, error) {
+	switch groupBy {
+	case proto.GetUsageRequest_GROUP_BY_UNSPECIFIED:
+		return "", nil
+	case proto.GetUsageRequest_GROUP_BY_USER:
+		return "user_email", nil
+	case proto.GetUsageRequest_GROUP_BY_REPORT:
+		return d.Text("report_id"), nil
+	case proto.GetUsageRequest_GROUP_BY_DAY:
+		return d.Day("created_at"), nil
+	}
+	return "", fmt.Errorf("unknown group_by %d", groupBy)
+}
+
+// usage of jobs finished from inclusive to exclusive time, aggregated by key
+func (s Server) usage(ctx context.Context, from time.Time, to time.Time, key string) ([]*proto.Usage, error) {
+	selectKey, groupBy := "''", ""
+	if key != "" {
+		selectKey = key
+		groupBy = "group by " + key + " order by sum(bytes_billed) desc, " + key
+	}
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`select
+			%s,
+			count(*),
+			coalesce(sum(bytes_processed), 0),
+			coalesce(sum(bytes_billed), 0),
+			coalesce(sum(case when cache_hit then 1 else 0 end), 0),
+			coalesce(sum(duration_ms), 0)
+		from job_stats where created_at >= %s and created_at < %s
+		%s`, selectKey, s.dialect.Timestamp("$1"), s.dialect.Timestamp("$2"), groupBy),
+		s.dialect.TimeArg(from),
+		s.dialect.TimeArg(to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	usage := make([]*proto.Usage, 0)
+	for rows.Next() {
+		u := &proto.Usage{}
+		if err := rows.Scan(&u.Key, &u.Jobs, &u.BytesProcessed, &u.BytesBilled, &u.CacheHits, &u.DurationMs); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+// GetUsage of BigQuery by finished jobs in time range
+func (s Server) GetUsage(ctx context.Context, req *proto.GetUsageRequest) (*proto.GetUsageResponse, error) {
+	if err := s.requireRole(ctx, proto.Role_ROLE_ADMIN); err != nil {
+		return nil, err
+	}
+	to := time.Now()
+	if req.ToTime > 0 {
+		to = time.Unix(req.ToTime, 0)
+	}
+	from := to.Add(-defaultUsagePeriod)
+	if req.FromTime > 0 {
+		from = time.Unix(req.FromTime, 0)
+	}
+	if req.FromTime < 0 || req.ToTime < 0 || !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from_time must be before to_time")
+	}
+	key, err := usageKey(s.dialect, req.GroupBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	usage, err := s.usage(ctx, from, to, key)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	quota, err := s.currentQuota(ctx)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	return &proto.GetUsageResponse{Usage: usage, Quota: quota}, nil
+}
+
+// parseRetentionDays of DEKART_JOB_STATS_RETENTION_DAYS, zero keeps job stats forever
+func parseRetentionDays(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid DEKART_JOB_STATS_RETENTION_DAYS %s, expected number of days", value)
+	}
+	return days, nil
+}
+
+// pruneJobStats created before cutoff, returns number of removed stats
+func (s Server) pruneJobStats(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx,
+		fmt.Sprintf(`delete from job_stats where created_at < %s`, s.dialect.Timestamp("$1")),
+		s.dialect.TimeArg(cutoff),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ScheduleJobStatsPrune removes job stats older than DEKART_JOB_STATS_RETENTION_DAYS at start and every hour
+func (s Server) ScheduleJobStatsPrune() {
+	days, err := parseRetentionDays(os.Getenv("DEKART_JOB_STATS_RETENTION_DAYS"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	if days == 0 {
+		return
+	}
+	log.Info().Int("retentionDays", days).Msg("Job stats prune scheduled")
+	go func() {
+		ticker := time.NewTicker(jobStatsPruneInterval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			removed, err := s.pruneJobStats(ctx, time.Now().AddDate(0, 0, -days))
+			cancel()
+			if err != nil {
+				log.Err(err).Msg("Cannot prune job stats")
+			} else if removed > 0 {
+				log.Info().Int64("removed", removed).Msg("Job stats pruned")
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// usageCollector exports usage of last 30 days by user, queried on every scrape
+type usageCollector struct {
+	server Server
+	jobs   *prometheus.Desc
+	billed *prometheus.Desc
+	bytes  *prometheus.Desc
+	cached *prometheus.Desc
+}
+
+func newUsageCollector(server Server) *usageCollector {
+	labels := []string{"user"}
+	return &usageCollector{
+		server: server,
+		jobs:   prometheus.NewDesc("dekart_usage_jobs", "Jobs finished in last 30 days by user.", labels, nil),
+		billed: prometheus.NewDesc("dekart_usage_bytes_billed", "Bytes billed by BigQuery for jobs finished in last 30 days by user.", labels, nil),
+		bytes:  prometheus.NewDesc("dekart_usage_bytes_processed", "Bytes processed by BigQuery for jobs finished in last 30 days by user.", labels, nil),
+		cached: prometheus.NewDesc("dekart_usage_cache_hits", "Jobs answered from BigQuery cache in last 30 days by user.", labels, nil),
+	}
+}
+
+func (c *usageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.jobs
+	ch <- c.billed
+	ch <- c.bytes
+	ch <- c.cached
+}
+
+// Collect usage; database errors are logged, so runtime metrics are still served
+func (c *usageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	now := time.Now()
+	usage, err := c.server.usage(ctx, now.Add(-defaultUsagePeriod), now, "user_email")
+	if err != nil {
+		log.Err(err).Msg("Cannot collect usage metrics")
+		return
+	}
+	for _, u := range usage {
+		ch <- prometheus.MustNewConstMetric(c.jobs, prometheus.GaugeValue, float64(u.Jobs), u.Key)
+		ch <- prometheus.MustNewConstMetric(c.billed, prometheus.GaugeValue, float64(u.BytesBilled), u.Key)
+		ch <- prometheus.MustNewConstMetric(c.bytes, prometheus.GaugeValue, float64(u.BytesProcessed), u.Key)
+		ch <- prometheus.MustNewConstMetric(c.cached, prometheus.GaugeValue, float64(u.CacheHits), u.Key)
+	}
+}
+
+// RegisterUsageMetrics exports usage by user as Prometheus gauges when DEKART_USAGE_METRICS=1
+func (s Server) RegisterUsageMetrics() {
+	if os.Getenv("DEKART_USAGE_METRICS") != "1" {
+		return
+	}
+	if err := metrics.Register(newUsageCollector(s)); err != nil {
+		log.Fatal().Err(err).Send()
+	}
+}
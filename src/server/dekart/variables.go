@@ -0,0 +1,136 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// storedVariable of reports.variables, type is stored by name so enum numbers can change
+type storedVariable struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	DefaultValue string `json:"defaultValue"`
+}
+
+func marshalVariables(variables []*proto.ReportVariable) (string, error) {
+	if len(variables) == 0 {
+		return "", nil
+	}
+	stored := make([]storedVariable, len(variables))
+	for i, variable := range variables {
+		stored[i] = storedVariable{
+			Name:         variable.Name,
+			Type:         variable.Type.String(),
+			DefaultValue: variable.DefaultValue,
+		}
+	}
+	b, err := json.Marshal(stored)
+	return string(b), err
+}
+
+func unmarshalVariables(value string) ([]*proto.ReportVariable, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var stored []storedVariable
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		return nil, err
+	}
+	variables := make([]*proto.ReportVariable, len(stored))
+	for i, variable := range stored {
+		variables[i] = &proto.ReportVariable{
+			Name:         variable.Name,
+			Type:         proto.ReportVariable_Type(proto.ReportVariable_Type_value[variable.Type]),
+			DefaultValue: variable.DefaultValue,
+		}
+	}
+	return variables, nil
+}
+
+// variablesError of substitution as InvalidArgument, unknown placeholders are listed as field violations
+func variablesError(err error) error {
+	var unknown *job.UnknownVariablesError
+	if !errors.As(err, &unknown) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, name := range unknown.Names {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "query_text",
+			Description: fmt.Sprintf("unknown variable {{%s}}", name),
+		})
+	}
+	st, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(badRequest)
+	if detailsErr != nil {
+		log.Err(detailsErr).Send()
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
+
+// substituteVariables of report into query text of source; values are set on run
+func (source *queryJobSource) substituteVariables(values map[string]string) error {
+	queryText, err := job.SubstituteVariables(source.queryText, source.variables, values)
+	if err != nil {
+		return variablesError(err)
+	}
+	source.queryText = queryText
+	return nil
+}
+
+// UpdateReportVariables replaces variables of report
+func (s Server) UpdateReportVariables(ctx context.Context, req *proto.UpdateReportVariablesRequest) (*proto.UpdateReportVariablesResponse, error) {
+	claims := user.GetClaims(ctx)
+	if claims == nil {
+		return nil, Unauthenticated
+	}
+	if err := s.requireRole(ctx, proto.Role_ROLE_EDITOR); err != nil {
+		return nil, err
+	}
+	_, err := uuid.Parse(req.ReportId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if err := job.ValidateVariables(req.Variables); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	variables, err := marshalVariables(req.Variables)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	result, err := s.db.ExecContext(ctx,
+		`update reports set variables=$1, updated_at=CURRENT_TIMESTAMP where id=$2 and author_email=$3 and not archived`,
+		variables,
+		req.ReportId,
+		claims.Email,
+	)
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	affectedRows, err := result.RowsAffected()
+	if err != nil {
+		log.Err(err).Send()
+		return nil, internalError(err)
+	}
+	if affectedRows == 0 {
+		err := fmt.Errorf("Report not found id:%s", req.ReportId)
+		log.Warn().Err(err).Send()
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	s.reportStreams.Publish(report.Event{ReportID: req.ReportId, Kind: report.ReportChanged})
+	return &proto.UpdateReportVariablesResponse{}, nil
+}
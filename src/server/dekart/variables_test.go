@@ -0,0 +1,47 @@
+package dekart
+
+import (
+	"dekart/src/proto"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubstituteVariablesUnknown(t *testing.T) {
+	source := queryJobSource{
+		queryText: "select * from {{table}} where day = {{day}} and {{other}}",
+		variables: []*proto.ReportVariable{{Name: "day", Type: proto.ReportVariable_TYPE_STRING}},
+	}
+	err := source.substituteVariables(nil)
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("expected InvalidArgument with details, got %v", err)
+	}
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || len(badRequest.FieldViolations) != 2 || badRequest.FieldViolations[0].Description != "unknown variable {{table}}" {
+		t.Errorf("unexpected details %v", st.Details())
+	}
+}
+
+func TestMarshalVariables(t *testing.T) {
+	variables := []*proto.ReportVariable{
+		{Name: "day", Type: proto.ReportVariable_TYPE_STRING, DefaultValue: "2021-01-01"},
+		{Name: "dataset", Type: proto.ReportVariable_TYPE_IDENTIFIER},
+	}
+	value, err := marshalVariables(variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unmarshalled, err := unmarshalVariables(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unmarshalled) != 2 || unmarshalled[0].DefaultValue != "2021-01-01" || unmarshalled[1].Type != proto.ReportVariable_TYPE_IDENTIFIER {
+		t.Errorf("unexpected variables %v", unmarshalled)
+	}
+	if value, err := marshalVariables(nil); err != nil || value != "" {
+		t.Errorf("expected empty value, got %q %v", value, err)
+	}
+}
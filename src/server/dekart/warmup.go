@@ -0,0 +1,119 @@
+package dekart
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// warmupTimeout of each warmup check
+const warmupTimeout = 30 * time.Second
+
+// warmupProbePrefix of probe object name, so leftover probes are easy to find in bucket
+const warmupProbePrefix = "dekart-warmup-probe-"
+
+// warmupCheck of dependency at startup, remediation is logged when check fails
+type warmupCheck struct {
+	name        string
+	run         func(ctx context.Context) error
+	remediation string
+}
+
+// runWarmup checks and logs latency of each; all checks are run, so every broken dependency is reported
+func runWarmup(ctx context.Context, checks []warmupCheck) error {
+	var failed []string
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, warmupTimeout)
+		start := time.Now()
+		err := check.run(checkCtx)
+		cancel()
+		latency := time.Since(start)
+		if err != nil {
+			log.Error().Err(err).Str("check", check.name).Dur("latency", latency).Msg("Warmup check failed: " + check.remediation)
+			failed = append(failed, check.name)
+			continue
+		}
+		log.Info().Str("check", check.name).Dur("latency", latency).Msg("Warmup check passed")
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("warmup checks failed: %s; set DEKART_SKIP_WARMUP=1 to start without checks", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// probeStorage writes and deletes probe object; delete is attempted even when write fails,
+// because failed upload can still leave object in bucket
+func probeStorage(
+	ctx context.Context,
+	name string,
+	write func(ctx context.Context, name string) error,
+	remove func(ctx context.Context, name string) error,
+) (err error) {
+	defer func() {
+		// cleanup is not limited by deadline of failed write
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		removeErr := remove(cleanupCtx, name)
+		if removeErr == nil || removeErr == storage.ErrObjectNotExist {
+			return
+		}
+		log.Warn().Err(removeErr).Str("object", name).Msg("Cannot delete warmup probe")
+		if err == nil {
+			err = fmt.Errorf("cannot delete probe %s: %w", name, removeErr)
+		}
+	}()
+	if err := write(ctx, name); err != nil {
+		return fmt.Errorf("cannot write probe %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s Server) writeProbe(ctx context.Context, name string) error {
+	w := s.bucket.Object(name).NewWriter(ctx)
+	w.ContentType = "text/plain"
+	if _, err := w.Write([]byte("dekart warmup probe, safe to delete\n")); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (s Server) removeProbe(ctx context.Context, name string) error {
+	return s.bucket.Object(name).Delete(ctx)
+}
+
+// warmupChecks of database, result bucket and BigQuery access
+func (s Server) warmupChecks() []warmupCheck {
+	bucket := os.Getenv("DEKART_CLOUD_STORAGE_BUCKET")
+	// probe is stored like results, permissions limited to prefix are checked too
+	probe := storageObjectName(s.storagePrefix, "warmup", warmupProbePrefix+newUUID(), time.Now(), ".csv")
+	return []warmupCheck{
+		{
+			name:        "database",
+			run:         s.db.PingContext,
+			remediation: "check DEKART_DATABASE_URL or DEKART_POSTGRES_* variables, access to secret referenced by them and that database accepts connections",
+		},
+		{
+			name: "storage",
+			run: func(ctx context.Context) error {
+				return probeStorage(ctx, probe, s.writeProbe, s.removeProbe)
+			},
+			remediation: fmt.Sprintf("grant storage.objects.create and storage.objects.delete on bucket %s to service account of dekart, bucket is set with DEKART_CLOUD_STORAGE_BUCKET", bucket),
+		},
+		{
+			name:        "bigquery",
+			run:         s.jobs.ListDatasets,
+			remediation: fmt.Sprintf("grant roles/bigquery.user on billing project %s and roles/bigquery.dataViewer on project %s to service account of dekart, check DEKART_BIGQUERY_PROJECT_ID and GOOGLE_APPLICATION_CREDENTIALS", s.jobs.BillingProject(""), s.jobs.Projects().Data),
+		},
+	}
+}
+
+// Warmup checks credentials and access to database, result bucket and BigQuery before serving requests
+func (s Server) Warmup(ctx context.Context) error {
+	return runWarmup(ctx, s.warmupChecks())
+}
@@ -0,0 +1,76 @@
+package dekart
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/storage"
+)
+
+func TestRunWarmup(t *testing.T) {
+	var run []string
+	check := func(name string, err error) warmupCheck {
+		return warmupCheck{
+			name: name,
+			run: func(ctx context.Context) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Errorf("%s: expected deadline", name)
+				}
+				run = append(run, name)
+				return err
+			},
+			remediation: "fix " + name,
+		}
+	}
+	if err := runWarmup(context.Background(), []warmupCheck{check("database", nil), check("storage", nil)}); err != nil {
+		t.Errorf("expected checks passed, got %v", err)
+	}
+	run = nil
+	err := runWarmup(context.Background(), []warmupCheck{
+		check("database", fmt.Errorf("refused")),
+		check("storage", nil),
+		check("bigquery", fmt.Errorf("denied")),
+	})
+	if err == nil || !strings.Contains(err.Error(), "database, bigquery") {
+		t.Errorf("expected failed checks in error, got %v", err)
+	}
+	if len(run) != 3 {
+		t.Errorf("expected all checks run after failure, got %v", run)
+	}
+}
+
+func TestProbeStorage(t *testing.T) {
+	var removed []string
+	remove := func(err error) func(ctx context.Context, name string) error {
+		return func(ctx context.Context, name string) error {
+			removed = append(removed, name)
+			return err
+		}
+	}
+	written := func(ctx context.Context, name string) error { return nil }
+	failed := func(ctx context.Context, name string) error {
+		return fmt.Errorf("permission denied")
+	}
+	for _, c := range []struct {
+		name      string
+		write     func(ctx context.Context, name string) error
+		removeErr error
+		fails     bool
+	}{
+		{"written and deleted", written, nil, false},
+		{"write failed, nothing to delete", failed, storage.ErrObjectNotExist, true},
+		{"write failed, partial object deleted", failed, nil, true},
+		{"delete failed", written, fmt.Errorf("permission denied"), true},
+	} {
+		removed = nil
+		err := probeStorage(context.Background(), warmupProbePrefix+"1.csv", c.write, remove(c.removeErr))
+		if (err != nil) != c.fails {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+		if len(removed) != 1 || removed[0] != warmupProbePrefix+"1.csv" {
+			t.Errorf("%s: expected probe deleted, got %v", c.name, removed)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package dekart
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"dekart/src/server/job"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+)
+
+// JobWebhook posts summary of finished jobs to DEKART_JOB_WEBHOOK_URL, it's registered as job hook
+type JobWebhook struct {
+	url string
+	// secret signs body in X-Dekart-Signature header, optional
+	secret string
+	client *http.Client
+}
+
+// NewJobWebhook of DEKART_JOB_WEBHOOK_URL and DEKART_JOB_WEBHOOK_SECRET, nil when url is not set
+func NewJobWebhook() *JobWebhook {
+	url := os.Getenv("DEKART_JOB_WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return &JobWebhook{url: url, secret: os.Getenv("DEKART_JOB_WEBHOOK_SECRET"), client: &http.Client{}}
+}
+
+// jobWebhookPayload of finished job
+type jobWebhookPayload struct {
+	JobID          string `json:"jobId"`
+	QueryID        string `json:"queryId"`
+	ReportID       string `json:"reportId"`
+	UserEmail      string `json:"userEmail"`
+	BatchID        string `json:"batchId,omitempty"`
+	State          string `json:"state"`
+	Error          string `json:"error,omitempty"`
+	CancelReason   string `json:"cancelReason,omitempty"`
+	TotalRows      int64  `json:"totalRows"`
+	BytesProcessed int64  `json:"bytesProcessed"`
+	BytesBilled    int64  `json:"bytesBilled"`
+	CacheHit       bool   `json:"cacheHit"`
+	ResultID       string `json:"resultId,omitempty"`
+	ResultTable    string `json:"resultTable,omitempty"`
+	// FinishedAt in unix seconds
+	FinishedAt int64 `json:"finishedAt"`
+}
+
+func newJobWebhookPayload(summary job.JobSummary) jobWebhookPayload {
+	payload := jobWebhookPayload{
+		JobID:          summary.JobID,
+		QueryID:        summary.QueryID,
+		ReportID:       summary.ReportID,
+		UserEmail:      summary.UserEmail,
+		BatchID:        summary.BatchID,
+		State:          summary.State.String(),
+		Error:          summary.Err,
+		TotalRows:      summary.TotalRows,
+		BytesProcessed: summary.BytesProcessed,
+		BytesBilled:    summary.BytesBilled,
+		CacheHit:       summary.CacheHit,
+		ResultID:       summary.ResultID,
+		ResultTable:    summary.ResultTable,
+		FinishedAt:     summary.FinishedAt.Unix(),
+	}
+	if summary.State == job.StateCancelled {
+		payload.CancelReason = summary.CancelReason.String()
+	}
+	return payload
+}
+
+// sign body with secret, hex of HMAC-SHA256
+func (w *JobWebhook) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(w.secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify webhook about finished job, response other than 2xx is error
+func (w *JobWebhook) Notify(ctx context.Context, summary job.JobSummary) error {
+	body, err := json.Marshal(newJobWebhookPayload(summary))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		req.Header.Set("X-Dekart-Signature", w.sign(body))
+	}
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		message, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("webhook responded %d: %s", res.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
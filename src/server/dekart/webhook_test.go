@@ -0,0 +1,64 @@
+package dekart
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestJobWebhookNotify(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		signature = r.Header.Get("X-Dekart-Signature")
+	}))
+	defer server.Close()
+	os.Setenv("DEKART_JOB_WEBHOOK_URL", server.URL)
+	os.Setenv("DEKART_JOB_WEBHOOK_SECRET", "secret")
+	defer os.Unsetenv("DEKART_JOB_WEBHOOK_URL")
+	defer os.Unsetenv("DEKART_JOB_WEBHOOK_SECRET")
+	webhook := NewJobWebhook()
+	err := webhook.Notify(context.Background(), job.JobSummary{
+		JobID:        "job",
+		QueryID:      testQueryID,
+		ReportID:     testReportID,
+		State:        job.StateCancelled,
+		CancelReason: proto.CancelReason_CANCEL_REASON_SHUTDOWN,
+		FinishedAt:   time.Unix(1600000000, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload jobWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.JobID != "job" || payload.State != "CANCELLED" || payload.CancelReason != "CANCEL_REASON_SHUTDOWN" || payload.FinishedAt != 1600000000 {
+		t.Errorf("unexpected payload %s", body)
+	}
+	if signature != webhook.sign(body) || len(signature) != len("sha256=")+64 {
+		t.Errorf("unexpected signature %s", signature)
+	}
+}
+
+func TestJobWebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	webhook := &JobWebhook{url: server.URL, client: &http.Client{}}
+	if err := webhook.Notify(context.Background(), job.JobSummary{State: job.StateDone}); err == nil {
+		t.Error("expected error of failed response")
+	}
+	if NewJobWebhook() != nil {
+		t.Error("expected no webhook without url")
+	}
+}
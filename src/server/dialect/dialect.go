@@ -0,0 +1,310 @@
+// Package dialect hides differences of SQL supported by Postgres, MySQL and SQLite.
+// SQLite is used for single user local deployments, Postgres is default.
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+)
+
+// Dialect of SQL database; methods return SQL expressions of placeholders and columns
+type Dialect interface {
+	Name() string
+	// Epoch seconds of timestamp
+	Epoch(timestamp string) string
+	// Timestamp param comparable with timestamp columns, passed as TimeArg
+	Timestamp(param string) string
+	TimeArg(t time.Time) interface{}
+	// UUID param comparable with uuid columns
+	UUID(param string) string
+	// Text cast of value, used to read uuid columns
+	Text(value string) string
+	// Int cast of numeric value to 64 bit integer
+	Int(value string) string
+	// Concat of string values
+	Concat(values ...string) string
+	// SecondsAgo timestamp of seconds param before current timestamp
+	SecondsAgo(param string) string
+	// DistinctFrom is null safe inequality
+	DistinctFrom(a, b string) string
+	// ILike is case insensitive LIKE operator, backslash escapes wildcards
+	ILike(value, pattern string) string
+	// InList matches column with list param passed as ListArg
+	InList(column, param string) string
+	ListArg(values []string) interface{}
+	// StringArray param and scan destination of text array column
+	StringArray(values []string) interface{}
+	ScanStringArray(values *[]string) sql.Scanner
+	// OnConflict clause of insert with unique columns; set of empty string keeps existing row
+	OnConflict(columns []string, set string) string
+	// Excluded value of column proposed for insertion, used in OnConflict set
+	Excluded(column string) string
+	// Returning clause is supported by insert and update statements
+	Returning() bool
+	// Day of timestamp in UTC as YYYY-MM-DD text
+	Day(timestamp string) string
+}
+
+// Postgres dialect
+var Postgres Dialect = postgres{}
+
+// SQLite dialect
+var SQLite Dialect = sqliteDialect{}
+
+// MySQL dialect
+var MySQL Dialect = mysqlDialect{}
+
+// Of database driver, Postgres for unknown drivers and nil database
+func Of(db *sql.DB) Dialect {
+	if db == nil {
+		return Postgres
+	}
+	switch db.Driver().(type) {
+	case *sqlite.Driver:
+		return SQLite
+	case mysqlDriver:
+		return MySQL
+	}
+	return Postgres
+}
+
+// ForURL of database; sqlite://path is SQLite, mysql://dsn is MySQL, other URLs are Postgres
+func ForURL(url string) Dialect {
+	switch {
+	case strings.HasPrefix(url, "sqlite://"):
+		return SQLite
+	case strings.HasPrefix(url, "mysql://"):
+		return MySQL
+	}
+	return Postgres
+}
+
+// Open database at URL; sqlite://path opens SQLite file at path,
+// mysql://dsn opens MySQL with DSN of github.com/go-sql-driver/mysql,
+// other URLs are opened with Postgres driver
+func Open(url string) (*sql.DB, error) {
+	switch ForURL(url) {
+	case SQLite:
+		return sql.OpenDB(sqliteConnector{path: strings.TrimPrefix(url, "sqlite://")}), nil
+	case MySQL:
+		connector, err := newMySQLConnector(strings.TrimPrefix(url, "mysql://"))
+		if err != nil {
+			return nil, err
+		}
+		return sql.OpenDB(connector), nil
+	}
+	return sql.Open("postgres", url)
+}
+
+// OpenResolved database of dialect with URL resolved for every new connection,
+// so rotated credentials are used without restart
+func OpenResolved(d Dialect, resolve func(ctx context.Context) (string, error)) *sql.DB {
+	return sql.OpenDB(resolvedConnector{dialect: d, resolve: resolve})
+}
+
+// resolvedConnector connects with URL resolved by connection
+type resolvedConnector struct {
+	dialect Dialect
+	resolve func(ctx context.Context) (string, error)
+}
+
+func (c resolvedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	url, err := c.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var connector driver.Connector
+	switch c.dialect {
+	case SQLite:
+		connector = sqliteConnector{path: strings.TrimPrefix(url, "sqlite://")}
+	case MySQL:
+		connector, err = newMySQLConnector(strings.TrimPrefix(url, "mysql://"))
+	default:
+		connector, err = pq.NewConnector(url)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// Driver of dialect, so Of returns dialect of database
+func (c resolvedConnector) Driver() driver.Driver {
+	switch c.dialect {
+	case SQLite:
+		return &sqlite.Driver{}
+	case MySQL:
+		return mysqlDriver{}
+	}
+	return &pq.Driver{}
+}
+
+// sqliteConnector sets pragmas of every connection
+type sqliteConnector struct {
+	path string
+}
+
+func (c sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.path)
+	if err != nil {
+		return nil, err
+	}
+	// foreign keys are off by default; writers wait for lock instead of failing with SQLITE_BUSY,
+	// WAL lets readers proceed while other connection writes
+	_, err = conn.(driver.ExecerContext).ExecContext(ctx, "PRAGMA foreign_keys = ON; PRAGMA busy_timeout = 5000; PRAGMA journal_mode = WAL", nil)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (sqliteConnector) Driver() driver.Driver {
+	return &sqlite.Driver{}
+}
+
+type postgres struct{}
+
+func (postgres) Name() string { return "postgres" }
+
+func (postgres) Epoch(timestamp string) string {
+	return fmt.Sprintf("extract('epoch' from %s)", timestamp)
+}
+
+func (postgres) Timestamp(param string) string { return param + "::timestamptz" }
+
+func (postgres) TimeArg(t time.Time) interface{} { return t }
+
+func (postgres) UUID(param string) string { return param + "::uuid" }
+
+func (postgres) Text(value string) string { return "cast(" + value + " as VARCHAR)" }
+
+func (postgres) Int(value string) string { return "CAST(" + value + " as BIGINT)" }
+
+func (postgres) Concat(values ...string) string { return strings.Join(values, " || ") }
+
+func (postgres) SecondsAgo(param string) string {
+	return fmt.Sprintf("CURRENT_TIMESTAMP - make_interval(secs => %s)", param)
+}
+
+func (postgres) DistinctFrom(a, b string) string { return a + " is distinct from " + b }
+
+func (postgres) ILike(value, pattern string) string { return value + " ilike " + pattern }
+
+func (postgres) InList(column, param string) string {
+	return fmt.Sprintf("%s = any(%s::uuid[])", column, param)
+}
+
+func (postgres) ListArg(values []string) interface{} { return pq.Array(values) }
+
+func (postgres) StringArray(values []string) interface{} { return pq.Array(values) }
+
+func (postgres) ScanStringArray(values *[]string) sql.Scanner {
+	return pq.Array(values).(sql.Scanner)
+}
+
+func (postgres) OnConflict(columns []string, set string) string { return onConflict(columns, set) }
+
+func (postgres) Excluded(column string) string { return "excluded." + column }
+
+func (postgres) Returning() bool { return true }
+
+func (postgres) Day(timestamp string) string {
+	return fmt.Sprintf("to_char(%s at time zone 'UTC', 'YYYY-MM-DD')", timestamp)
+}
+
+// onConflict clause of Postgres and SQLite
+func onConflict(columns []string, set string) string {
+	if set == "" {
+		return fmt.Sprintf("on conflict (%s) do nothing", strings.Join(columns, ", "))
+	}
+	return fmt.Sprintf("on conflict (%s) do update set %s", strings.Join(columns, ", "), set)
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) Epoch(timestamp string) string {
+	return fmt.Sprintf("((julianday(%s) - 2440587.5) * 86400.0)", timestamp)
+}
+
+func (sqliteDialect) Timestamp(param string) string { return param }
+
+// TimeArg in UTC text format of CURRENT_TIMESTAMP, so timestamps compare as text
+func (sqliteDialect) TimeArg(t time.Time) interface{} { return t.UTC().Format("2006-01-02 15:04:05") }
+
+func (sqliteDialect) UUID(param string) string { return param }
+
+func (sqliteDialect) Text(value string) string { return "cast(" + value + " as VARCHAR)" }
+
+func (sqliteDialect) Int(value string) string { return "CAST(" + value + " as BIGINT)" }
+
+func (sqliteDialect) Concat(values ...string) string { return strings.Join(values, " || ") }
+
+func (sqliteDialect) SecondsAgo(param string) string {
+	return fmt.Sprintf("datetime('now', '-' || %s || ' seconds')", param)
+}
+
+func (sqliteDialect) DistinctFrom(a, b string) string { return a + " is not " + b }
+
+// ILike of SQLite, LIKE is case insensitive for ASCII
+func (sqliteDialect) ILike(value, pattern string) string {
+	return value + " like " + pattern + ` escape '\'`
+}
+
+func (sqliteDialect) InList(column, param string) string {
+	return fmt.Sprintf("%s in (select value from json_each(%s))", column, param)
+}
+
+func (sqliteDialect) ListArg(values []string) interface{} { return jsonArray(values) }
+
+func (sqliteDialect) StringArray(values []string) interface{} { return jsonArray(values) }
+
+func (sqliteDialect) ScanStringArray(values *[]string) sql.Scanner { return &jsonArrayScanner{values} }
+
+func (sqliteDialect) OnConflict(columns []string, set string) string { return onConflict(columns, set) }
+
+func (sqliteDialect) Excluded(column string) string { return "excluded." + column }
+
+func (sqliteDialect) Returning() bool { return true }
+
+// Day of SQLite, timestamps are stored in UTC
+func (sqliteDialect) Day(timestamp string) string {
+	return fmt.Sprintf("strftime('%%Y-%%m-%%d', %s)", timestamp)
+}
+
+// jsonArray of text values, stored in text column by SQLite and MySQL
+func jsonArray(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	// strings are always marshalled
+	b, _ := json.Marshal(values)
+	return string(b)
+}
+
+type jsonArrayScanner struct {
+	values *[]string
+}
+
+func (s *jsonArrayScanner) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*s.values = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), s.values)
+	case []byte:
+		return json.Unmarshal(src, s.values)
+	}
+	return fmt.Errorf("cannot scan %T into string array", src)
+}
@@ -0,0 +1,40 @@
+package dialect
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenResolved(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dekart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var resolveErr error
+	resolved := 0
+	db := OpenResolved(SQLite, func(ctx context.Context) (string, error) {
+		resolved++
+		return "sqlite://" + filepath.Join(dir, "dekart.db"), resolveErr
+	})
+	defer db.Close()
+	if d := Of(db); d != SQLite {
+		t.Errorf("expected SQLite dialect, got %s", d.Name())
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	// URL is resolved for new connections only
+	db.SetMaxIdleConns(0)
+	resolveErr = fmt.Errorf("secret unavailable")
+	if err := db.Ping(); err != resolveErr {
+		t.Errorf("expected resolve error, got %v", err)
+	}
+	if resolved != 2 {
+		t.Errorf("expected URL resolved for each connection, got %d", resolved)
+	}
+}
@@ -0,0 +1,243 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlSQLMode of connections; ANSI_QUOTES makes double quotes quote identifiers as in Postgres
+const mysqlSQLMode = `'TRADITIONAL,ONLY_FULL_GROUP_BY,ANSI_QUOTES'`
+
+// mysqlConnector opens connections which accept $n placeholders, so statements are shared with Postgres
+type mysqlConnector struct {
+	dsn string
+}
+
+func newMySQLConnector(dsn string) (mysqlConnector, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return mysqlConnector{}, err
+	}
+	// timestamps are stored and scanned in UTC
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	// rows affected counts matched rows, as in Postgres, not only changed rows
+	cfg.ClientFoundRows = true
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["time_zone"] = `'+00:00'`
+	cfg.Params["sql_mode"] = mysqlSQLMode
+	return mysqlConnector{dsn: cfg.FormatDSN()}, nil
+}
+
+func (c mysqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.Driver().Open(c.dsn)
+}
+
+func (mysqlConnector) Driver() driver.Driver {
+	return mysqlDriver{}
+}
+
+// mysqlDriver wraps connections of MySQL driver
+type mysqlDriver struct{}
+
+func (mysqlDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := mysql.MySQLDriver{}.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlConn{conn}, nil
+}
+
+// rebind $n placeholders to ? placeholders, returns order of arguments;
+// quoted strings and identifiers are not changed
+func rebind(query string) (string, []int) {
+	var b strings.Builder
+	var order []int
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch c {
+		case '\'', '"', '`':
+			end := i + 1
+			for end < len(query) && query[end] != c {
+				if query[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(query) {
+				end = len(query) - 1
+			}
+			b.WriteString(query[i : end+1])
+			i = end
+		case '$':
+			n, end := 0, i+1
+			for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+				n = n*10 + int(query[end]-'0')
+				end++
+			}
+			if end == i+1 {
+				b.WriteByte(c)
+				continue
+			}
+			b.WriteByte('?')
+			order = append(order, n)
+			i = end - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), order
+}
+
+// reorder arguments of $n placeholders by order of ? placeholders
+func reorder(args []driver.NamedValue, order []int) ([]driver.NamedValue, error) {
+	reordered := make([]driver.NamedValue, len(order))
+	for i, n := range order {
+		if n < 1 || n > len(args) {
+			return nil, fmt.Errorf("no argument for placeholder $%d", n)
+		}
+		reordered[i] = driver.NamedValue{Ordinal: i + 1, Value: args[n-1].Value}
+	}
+	return reordered, nil
+}
+
+// mysqlConn rebinds placeholders of statements
+type mysqlConn struct {
+	driver.Conn
+}
+
+func (c *mysqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query, order := rebind(query)
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlStmt{Stmt: stmt, order: order}, nil
+}
+
+func (c *mysqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query, order := rebind(query)
+	args, err := reorder(args, order)
+	if err != nil {
+		return nil, err
+	}
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c *mysqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, order := rebind(query)
+	args, err := reorder(args, order)
+	if err != nil {
+		return nil, err
+	}
+	return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+}
+
+func (c *mysqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *mysqlConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *mysqlConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *mysqlConn) CheckNamedValue(nv *driver.NamedValue) error {
+	return c.Conn.(driver.NamedValueChecker).CheckNamedValue(nv)
+}
+
+// mysqlStmt reorders arguments of prepared statement
+type mysqlStmt struct {
+	driver.Stmt
+	order []int
+}
+
+// NumInput is unknown, placeholders may repeat
+func (s *mysqlStmt) NumInput() int { return -1 }
+
+func (s *mysqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	args, err := reorder(args, s.order)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+
+func (s *mysqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	args, err := reorder(args, s.order)
+	if err != nil {
+		return nil, err
+	}
+	return s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) Epoch(timestamp string) string {
+	return fmt.Sprintf("UNIX_TIMESTAMP(%s)", timestamp)
+}
+
+func (mysqlDialect) Timestamp(param string) string { return param }
+
+// TimeArg truncated to seconds of datetime columns, MySQL would round fractional seconds
+func (mysqlDialect) TimeArg(t time.Time) interface{} { return t.UTC().Truncate(time.Second) }
+
+func (mysqlDialect) UUID(param string) string { return param }
+
+func (mysqlDialect) Text(value string) string { return "cast(" + value + " as CHAR)" }
+
+func (mysqlDialect) Int(value string) string { return "CAST(" + value + " as SIGNED)" }
+
+func (mysqlDialect) Concat(values ...string) string {
+	return "concat(" + strings.Join(values, ", ") + ")"
+}
+
+func (mysqlDialect) SecondsAgo(param string) string {
+	return fmt.Sprintf("CURRENT_TIMESTAMP - INTERVAL %s SECOND", param)
+}
+
+func (mysqlDialect) DistinctFrom(a, b string) string { return fmt.Sprintf("not (%s <=> %s)", a, b) }
+
+// ILike of MySQL, LIKE is case insensitive with default collation and escapes with backslash
+func (mysqlDialect) ILike(value, pattern string) string { return value + " like " + pattern }
+
+// InList of MySQL compares JSON strings, so collation of column doesn't matter
+func (mysqlDialect) InList(column, param string) string {
+	return fmt.Sprintf("json_contains(%s, json_quote(%s))", param, column)
+}
+
+func (mysqlDialect) ListArg(values []string) interface{} { return jsonArray(values) }
+
+func (mysqlDialect) StringArray(values []string) interface{} { return jsonArray(values) }
+
+func (mysqlDialect) ScanStringArray(values *[]string) sql.Scanner { return &jsonArrayScanner{values} }
+
+func (mysqlDialect) OnConflict(columns []string, set string) string {
+	if set == "" {
+		// assignment of unique column keeps existing row, unlike insert ignore it doesn't hide other errors
+		set = columns[0] + " = " + columns[0]
+	}
+	return "on duplicate key update " + set
+}
+
+func (mysqlDialect) Excluded(column string) string { return "values(" + column + ")" }
+
+func (mysqlDialect) Returning() bool { return false }
+
+// Day of MySQL, connection time zone is UTC
+func (mysqlDialect) Day(timestamp string) string {
+	return fmt.Sprintf("date_format(%s, '%%Y-%%m-%%d')", timestamp)
+}
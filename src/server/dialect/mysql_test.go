@@ -0,0 +1,58 @@
+package dialect
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		query  string
+		expect string
+		order  []int
+	}{
+		{"select 1", "select 1", nil},
+		{"update t set a=$1 where id=$2", "update t set a=? where id=?", []int{1, 2}},
+		{"select $2, $1, $2", "select ?, ?, ?", []int{2, 1, 2}},
+		{"select $10 from t", "select ? from t", []int{10}},
+		{`select '$1', "$2", json_extract(x, '$[*]'), $3`, `select '$1', "$2", json_extract(x, '$[*]'), ?`, []int{3}},
+		{`select 'it''s $1', $1`, `select 'it''s $1', ?`, []int{1}},
+		{`select 'a\'$1', $1`, `select 'a\'$1', ?`, []int{1}},
+		{"select $ from t", "select $ from t", nil},
+	}
+	for _, test := range tests {
+		query, order := rebind(test.query)
+		if query != test.expect || !reflect.DeepEqual(order, test.order) {
+			t.Errorf("rebind(%q) = %q %v, expected %q %v", test.query, query, order, test.expect, test.order)
+		}
+	}
+}
+
+func TestReorder(t *testing.T) {
+	args := []driver.NamedValue{{Ordinal: 1, Value: "a"}, {Ordinal: 2, Value: int64(2)}}
+	reordered, err := reorder(args, []int{2, 1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []driver.NamedValue{{Ordinal: 1, Value: int64(2)}, {Ordinal: 2, Value: "a"}, {Ordinal: 3, Value: int64(2)}}
+	if !reflect.DeepEqual(reordered, expect) {
+		t.Errorf("expected %v, got %v", expect, reordered)
+	}
+	if _, err := reorder(args, []int{3}); err == nil {
+		t.Error("expected error of missing argument")
+	}
+}
+
+func TestForURL(t *testing.T) {
+	tests := map[string]Dialect{
+		"postgres://u:p@localhost:5432/dekart":   Postgres,
+		"sqlite:///tmp/dekart.db":                SQLite,
+		"mysql://u:p@tcp(localhost:3306)/dekart": MySQL,
+	}
+	for url, expect := range tests {
+		if d := ForURL(url); d != expect {
+			t.Errorf("ForURL(%s) = %s, expected %s", url, d.Name(), expect.Name())
+		}
+	}
+}
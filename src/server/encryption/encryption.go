@@ -0,0 +1,289 @@
+// Package encryption encrypts sensitive columns of application database with AES-GCM.
+// Ciphertext is stored as enc:keyID:base64, so values encrypted with older keys are decrypted
+// after rotation and re-encrypted with primary key by ReencryptColumns.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"dekart/src/server/secrets"
+
+	"github.com/rs/zerolog/log"
+)
+
+// prefix of encrypted values, values without prefix are plaintext rows written before encryption was enabled
+const prefix = "enc:"
+
+var validKeyID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Keyring of column keys by ID; values are encrypted with primary key
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// DecryptError of column value; key ID is included, so failures after rotation are traced to missing key
+type DecryptError struct {
+	KeyID string
+	Err   error
+}
+
+func (e *DecryptError) Error() string {
+	return fmt.Sprintf("cannot decrypt value of key %s: %s", e.KeyID, e.Err)
+}
+
+func (e *DecryptError) Unwrap() error {
+	return e.Err
+}
+
+// keyUnwrap decrypts key material wrapped by KMS key name
+type keyUnwrap func(ctx context.Context, keyName string, wrapped string) ([]byte, error)
+
+// ParseKeys of DEKART_ENCRYPTION_KEYS, comma separated id=material with primary key first. Material is
+// base64 of 32 bytes, secret reference of base64 key, or kms:KEY_NAME:WRAPPED with key wrapped by Cloud KMS key
+// projects/p/locations/l/keyRings/r/cryptoKeys/k. Empty value disables encryption and returns nil keyring.
+func ParseKeys(ctx context.Context, value string, unwrap keyUnwrap) (*Keyring, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	k := &Keyring{keys: make(map[string]cipher.AEAD)}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 || !validKeyID.MatchString(parts[0]) {
+			return nil, fmt.Errorf("invalid DEKART_ENCRYPTION_KEYS entry, expected id=key with id of letters, digits, _ and -")
+		}
+		id, material := parts[0], parts[1]
+		if _, ok := k.keys[id]; ok {
+			return nil, fmt.Errorf("duplicate encryption key %s", id)
+		}
+		raw, err := keyMaterial(ctx, material, unwrap)
+		if err != nil {
+			// error of material is not included, it can contain key
+			return nil, fmt.Errorf("invalid encryption key %s: %s", id, err)
+		}
+		aead, err := newAEAD(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %s: %s", id, err)
+		}
+		k.keys[id] = aead
+		if k.primary == "" {
+			k.primary = id
+		}
+	}
+	return k, nil
+}
+
+func keyMaterial(ctx context.Context, material string, unwrap keyUnwrap) ([]byte, error) {
+	if strings.HasPrefix(material, "kms:") {
+		parts := strings.SplitN(strings.TrimPrefix(material, "kms:"), ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected kms:KEY_NAME:WRAPPED")
+		}
+		return unwrap(ctx, parts[0], parts[1])
+	}
+	if secrets.IsReference(material) {
+		resolved, err := secrets.Resolve(ctx, material)
+		if err != nil {
+			return nil, err
+		}
+		material = strings.TrimSpace(resolved)
+	}
+	raw, err := base64.StdEncoding.DecodeString(material)
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64")
+	}
+	return raw, nil
+}
+
+func newAEAD(raw []byte) (cipher.AEAD, error) {
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Primary key ID of keyring
+func (k *Keyring) Primary() string {
+	return k.primary
+}
+
+// Encrypt plaintext with primary key; key ID is authenticated, so value can't be moved to other key
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.primary))
+	return prefix + k.primary + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt value encrypted with any key of keyring, value without prefix is returned as is
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, prefix), ":", 2)
+	if len(parts) != 2 {
+		return "", &DecryptError{Err: fmt.Errorf("invalid encrypted value")}
+	}
+	id := parts[0]
+	if k == nil {
+		return "", &DecryptError{KeyID: id, Err: fmt.Errorf("DEKART_ENCRYPTION_KEYS is not set")}
+	}
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", &DecryptError{KeyID: id, Err: fmt.Errorf("key is not in DEKART_ENCRYPTION_KEYS")}
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", &DecryptError{KeyID: id, Err: fmt.Errorf("invalid encrypted value")}
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", &DecryptError{KeyID: id, Err: fmt.Errorf("authentication failed, value was encrypted with other key or changed")}
+	}
+	return string(plaintext), nil
+}
+
+// Stale value is plaintext or encrypted with key other than primary
+func (k *Keyring) Stale(value string) bool {
+	return !strings.HasPrefix(value, prefix+k.primary+":")
+}
+
+var (
+	active      *Keyring
+	activeMutex sync.RWMutex
+)
+
+// Use keyring for columns, nil disables encryption of written values
+func Use(k *Keyring) {
+	activeMutex.Lock()
+	active = k
+	activeMutex.Unlock()
+}
+
+// Active keyring, nil when encryption is disabled
+func Active() *Keyring {
+	activeMutex.RLock()
+	defer activeMutex.RUnlock()
+	return active
+}
+
+// Configure keyring of DEKART_ENCRYPTION_KEYS
+func Configure(ctx context.Context) {
+	k, err := ParseKeys(ctx, os.Getenv("DEKART_ENCRYPTION_KEYS"), unwrapKMS)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	if k == nil {
+		log.Warn().Msg("DEKART_ENCRYPTION_KEYS is not set, sensitive columns are stored as plaintext")
+	} else {
+		log.Info().Str("keyID", k.Primary()).Int("keys", len(k.keys)).Msg("Column encryption enabled")
+	}
+	Use(k)
+}
+
+func encrypt(plaintext string) (string, error) {
+	k := Active()
+	if k == nil {
+		return plaintext, nil
+	}
+	return k.Encrypt(plaintext)
+}
+
+func decrypt(value string) (string, error) {
+	plaintext, err := Active().Decrypt(value)
+	if err != nil {
+		// value is never logged
+		decryptErr, _ := err.(*DecryptError)
+		log.Error().Err(err).Str("keyID", decryptErr.KeyID).Msg("Cannot decrypt column value")
+		return "", err
+	}
+	return plaintext, nil
+}
+
+func scanText(src interface{}) (string, bool, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	}
+	return "", false, fmt.Errorf("cannot scan %T into encrypted column", src)
+}
+
+// String column encrypted with active keyring
+type String string
+
+// Value encrypted with primary key
+func (s String) Value() (driver.Value, error) {
+	return encrypt(string(s))
+}
+
+// Scan decrypts value, null is scanned as empty string
+func (s *String) Scan(src interface{}) error {
+	value, ok, err := scanText(src)
+	if err != nil || !ok {
+		*s = ""
+		return err
+	}
+	plaintext, err := decrypt(value)
+	if err != nil {
+		return err
+	}
+	*s = String(plaintext)
+	return nil
+}
+
+// Strings column stored as encrypted JSON array
+type Strings []string
+
+// Value encrypted with primary key, nil is stored as null
+func (s Strings) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal([]string(s))
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(string(b))
+}
+
+// Scan decrypts value, null is scanned as nil
+func (s *Strings) Scan(src interface{}) error {
+	value, ok, err := scanText(src)
+	if err != nil || !ok {
+		*s = nil
+		return err
+	}
+	plaintext, err := decrypt(value)
+	if err != nil {
+		return err
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(plaintext), &values); err != nil {
+		return err
+	}
+	*s = values
+	return nil
+}
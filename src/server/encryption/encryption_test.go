@@ -0,0 +1,137 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func testKey(c string) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(c, 32)))
+}
+
+func noUnwrap(ctx context.Context, keyName string, wrapped string) ([]byte, error) {
+	return nil, fmt.Errorf("unexpected unwrap of %s", keyName)
+}
+
+func TestParseKeys(t *testing.T) {
+	ctx := context.Background()
+	if k, err := ParseKeys(ctx, "", noUnwrap); k != nil || err != nil {
+		t.Errorf("expected disabled encryption, got %v %v", k, err)
+	}
+	k, err := ParseKeys(ctx, "k2="+testKey("b")+", k1="+testKey("a"), noUnwrap)
+	if err != nil || k.Primary() != "k2" || len(k.keys) != 2 {
+		t.Fatalf("unexpected keyring %v %v", k, err)
+	}
+	for _, value := range []string{
+		"k1",
+		"k 1=" + testKey("a"),
+		"k1=short",
+		"k1=" + base64.StdEncoding.EncodeToString([]byte("short")),
+		"k1=" + testKey("a") + ",k1=" + testKey("b"),
+		"k1=kms:missing-wrapped",
+	} {
+		if _, err := ParseKeys(ctx, value, noUnwrap); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+	// key wrapped by KMS key
+	unwrap := func(ctx context.Context, keyName string, wrapped string) ([]byte, error) {
+		if keyName != "projects/p/locations/global/keyRings/r/cryptoKeys/k" || wrapped != "d3JhcHBlZA==" {
+			return nil, fmt.Errorf("unexpected key %s %s", keyName, wrapped)
+		}
+		return []byte(strings.Repeat("c", 32)), nil
+	}
+	if _, err := ParseKeys(ctx, "k3=kms:projects/p/locations/global/keyRings/r/cryptoKeys/k:d3JhcHBlZA==", unwrap); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	ctx := context.Background()
+	old, err := ParseKeys(ctx, "k1="+testKey("a"), noUnwrap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := old.Encrypt("a@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(encrypted, "enc:k1:") || strings.Contains(encrypted, "example") {
+		t.Fatalf("unexpected encrypted value %s", encrypted)
+	}
+	rotated, err := ParseKeys(ctx, "k2="+testKey("b")+",k1="+testKey("a"), noUnwrap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plaintext, err := rotated.Decrypt(encrypted); err != nil || plaintext != "a@example.com" {
+		t.Errorf("expected value of old key, got %q %v", plaintext, err)
+	}
+	if !rotated.Stale(encrypted) || !rotated.Stale("a@example.com") {
+		t.Error("expected value of old key and plaintext stale")
+	}
+	reencrypted, _ := rotated.Encrypt("a@example.com")
+	if rotated.Stale(reencrypted) {
+		t.Error("expected value of primary key not stale")
+	}
+	// old key removed from keyring
+	removed, _ := ParseKeys(ctx, "k2="+testKey("b"), noUnwrap)
+	_, err = removed.Decrypt(encrypted)
+	if decryptErr, ok := err.(*DecryptError); !ok || decryptErr.KeyID != "k1" {
+		t.Errorf("expected decrypt error of k1, got %v", err)
+	}
+	// key ID is authenticated
+	moved := strings.Replace(reencrypted, "enc:k2:", "enc:k1:", 1)
+	if _, err := rotated.Decrypt(moved); err == nil {
+		t.Error("expected error of value moved to other key")
+	}
+	if plaintext, err := removed.Decrypt("plaintext"); err != nil || plaintext != "plaintext" {
+		t.Errorf("expected plaintext of row written before encryption, got %q %v", plaintext, err)
+	}
+}
+
+func TestColumnTypes(t *testing.T) {
+	defer Use(nil)
+	// without keyring values are written as plaintext
+	if value, err := String("a@example.com").Value(); err != nil || value != "a@example.com" {
+		t.Errorf("expected plaintext, got %v %v", value, err)
+	}
+	k, err := ParseKeys(context.Background(), "k1="+testKey("a"), noUnwrap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Use(k)
+	value, err := String("a@example.com").Value()
+	if err != nil || !strings.HasPrefix(value.(string), "enc:k1:") {
+		t.Fatalf("expected encrypted value, got %v %v", value, err)
+	}
+	var s String
+	if err := s.Scan([]byte(value.(string))); err != nil || s != "a@example.com" {
+		t.Errorf("unexpected scanned value %q %v", s, err)
+	}
+	groups, err := Strings([]string{"x", "y"}).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scanned Strings
+	if err := scanned.Scan(groups); err != nil || len(scanned) != 2 || scanned[1] != "y" {
+		t.Errorf("unexpected scanned groups %v %v", scanned, err)
+	}
+	// legacy rows are JSON arrays
+	if err := scanned.Scan(`["z"]`); err != nil || len(scanned) != 1 {
+		t.Errorf("unexpected plaintext groups %v %v", scanned, err)
+	}
+	if value, err := Strings(nil).Value(); err != nil || value != nil {
+		t.Errorf("expected null, got %v %v", value, err)
+	}
+	if err := scanned.Scan(nil); err != nil || scanned != nil {
+		t.Errorf("expected nil groups, got %v %v", scanned, err)
+	}
+	// decryption failure is not silent empty value
+	Use(nil)
+	if err := s.Scan(value); err == nil {
+		t.Error("expected error without keyring")
+	}
+}
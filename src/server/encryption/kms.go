@@ -0,0 +1,24 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+)
+
+// unwrapKMS decrypts wrapped key with Cloud KMS key, wrapped key is base64 of ciphertext of
+// gcloud kms encrypt --plaintext-file=key --ciphertext-file=- | base64
+func unwrapKMS(ctx context.Context, keyName string, wrapped string) ([]byte, error) {
+	service, err := cloudkms.NewService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := service.Projects.Locations.KeyRings.CryptoKeys.Decrypt(keyName, &cloudkms.DecryptRequest{
+		Ciphertext: wrapped,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(res.Plaintext)
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// waitStreams of grpc-web which are served longer than WriteTimeout of server, they end with timeout of their own
+var waitStreams = map[string]bool{
+	"/Dekart/RunQueryAndWait": true,
+}
+
+type connKey struct{}
+
+// withConn in context of requests of connection, so write deadline of HTTP/1 connection can be cleared
+func withConn(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+// clearWriteDeadline of response set by WriteTimeout of server; writers of Go 1.20 clear it for HTTP/1 connection
+// and HTTP/2 stream, on older Go only deadline of HTTP/1 connection can be cleared
+func clearWriteDeadline(w http.ResponseWriter, r *http.Request) error {
+	if d, ok := w.(interface{ SetWriteDeadline(time.Time) error }); ok {
+		return d.SetWriteDeadline(time.Time{})
+	}
+	if c, ok := r.Context().Value(connKey{}).(net.Conn); ok && r.ProtoMajor == 1 {
+		return c.SetWriteDeadline(time.Time{})
+	}
+	return fmt.Errorf("write deadline of %s response can't be cleared", r.Proto)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// slowDekartServer sends first message of streams after delay
+type slowDekartServer struct {
+	proto.UnimplementedDekartServer
+	delay time.Duration
+}
+
+func (s slowDekartServer) RunQueryAndWait(req *proto.RunQueryAndWaitRequest, srv proto.Dekart_RunQueryAndWaitServer) error {
+	time.Sleep(s.delay)
+	return srv.Send(&proto.RunQueryAndWaitResponse{JobStatus: proto.Query_JOB_STATUS_DONE, JobResultId: "result"})
+}
+
+func (s slowDekartServer) GetReportStream(req *proto.ReportStreamRequest, srv proto.Dekart_GetReportStreamServer) error {
+	time.Sleep(s.delay)
+	return srv.Send(&proto.ReportStreamResponse{Report: &proto.Report{Id: "report"}})
+}
+
+// postGrpcWeb request of method, returns body of response or error when connection is cut
+func postGrpcWeb(url string, method string, req protobuf.Message) ([]byte, error) {
+	message, err := protobuf.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	frame = append(frame, message...)
+	r, err := http.NewRequest(http.MethodPost, url+method, bytes.NewReader(frame))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/grpc-web+proto")
+	r.Header.Set("X-Grpc-Web", "1")
+	res, err := http.DefaultClient.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return ioutil.ReadAll(res.Body)
+}
+
+func TestWaitStreamLongerThanWriteTimeout(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	proto.RegisterDekartServer(grpcServer, slowDekartServer{delay: 300 * time.Millisecond})
+	handler := newHandler(grpcweb.WrapServer(grpcServer), http.NotFoundHandler(), func(r *http.Request) context.Context {
+		return r.Context()
+	})
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.WriteTimeout = 100 * time.Millisecond
+	server.Config.ConnContext = withConn
+	server.Start()
+	defer server.Close()
+
+	body, err := postGrpcWeb(server.URL, "/Dekart/RunQueryAndWait", &proto.RunQueryAndWaitRequest{QueryId: "query"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := protobuf.Marshal(&proto.RunQueryAndWaitResponse{JobStatus: proto.Query_JOB_STATUS_DONE, JobResultId: "result"})
+	if !bytes.Contains(body, expected) {
+		t.Errorf("expected response of job after write timeout, got %q", body)
+	}
+	// other streams keep WriteTimeout of server
+	body, err = postGrpcWeb(server.URL, "/Dekart/GetReportStream", &proto.ReportStreamRequest{})
+	if err == nil && len(body) > 0 {
+		t.Errorf("expected stream cut by write timeout, got %q", body)
+	}
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"crypto/subtle"
+	"dekart/src/server/dekart"
+	"dekart/src/server/metrics"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+)
+
+// debugPrefix of pprof and runtime stats endpoints
+const debugPrefix = "/debug/"
+
+// debugStats of replica
+type debugStats struct {
+	Jobs                int            `json:"jobs"`
+	JobsByStatus        map[string]int `json:"jobsByStatus"`
+	OldestJobAgeSeconds float64        `json:"oldestJobAgeSeconds"`
+	Goroutines          int            `json:"goroutines"`
+	// MemoryReservedBytes of buffered rows, MemoryBudgetBytes is 0 when unlimited
+	MemoryReservedBytes int64 `json:"memoryReservedBytes"`
+	MemoryBudgetBytes   int64 `json:"memoryBudgetBytes"`
+}
+
+// debugJob of replica in its current phase
+type debugJob struct {
+	ID             string  `json:"id"`
+	QueryID        string  `json:"queryId"`
+	ReportID       string  `json:"reportId"`
+	Internal       bool    `json:"internal,omitempty"`
+	Phase          string  `json:"phase"`
+	InPhaseSeconds float64 `json:"inPhaseSeconds"`
+	RowsWritten    int64   `json:"rowsWritten"`
+	BytesUploaded  int64   `json:"bytesUploaded"`
+	MemoryReserved int64   `json:"memoryReserved"`
+}
+
+// requireDebugAccess allows requests with debug token or from admin
+func requireDebugAccess(dekartServer *dekart.Server, token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token != "" {
+				bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+				if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			err := dekartServer.RequireAdmin(r.Context())
+			if err != nil {
+				log.Warn().Err(err).Str("path", r.URL.Path).Msg("Debug endpoint access denied")
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func serveDebugStats(dekartServer *dekart.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats := dekartServer.JobStats()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(debugStats{
+			Jobs:                stats.Jobs,
+			JobsByStatus:        stats.JobsByStatus,
+			OldestJobAgeSeconds: stats.OldestJobAge.Seconds(),
+			Goroutines:          runtime.NumGoroutine(),
+			MemoryReservedBytes: stats.MemoryReserved,
+			MemoryBudgetBytes:   stats.MemoryBudget,
+		})
+	}
+}
+
+func serveDebugJobs(dekartServer *dekart.Server) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobs := make([]debugJob, 0)
+		for _, j := range dekartServer.JobSnapshot() {
+			jobs = append(jobs, debugJob{
+				ID:             j.ID,
+				QueryID:        j.QueryID,
+				ReportID:       j.ReportID,
+				Internal:       j.Internal,
+				Phase:          string(j.Phase),
+				InPhaseSeconds: j.InPhase.Seconds(),
+				RowsWritten:    j.RowsWritten,
+				BytesUploaded:  j.BytesUploaded,
+				MemoryReserved: j.MemoryReserved,
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(jobs)
+	}
+}
+
+// configureDebug mounts pprof, job stats, job phases and Prometheus metrics, token grants access without admin role
+func configureDebug(router *mux.Router, dekartServer *dekart.Server, token string) {
+	log.Warn().Bool("token", token != "").Msg("Debug endpoints enabled")
+	debug := router.PathPrefix(debugPrefix).Subrouter()
+	debug.Use(requireDebugAccess(dekartServer, token))
+	debug.HandleFunc("/stats", serveDebugStats(dekartServer)).Methods("GET")
+	debug.HandleFunc("/jobs", serveDebugJobs(dekartServer)).Methods("GET")
+	debug.Handle("/metrics", metrics.Handler()).Methods("GET")
+	debug.HandleFunc("/pprof/cmdline", pprof.Cmdline)
+	debug.HandleFunc("/pprof/profile", pprof.Profile)
+	debug.HandleFunc("/pprof/symbol", pprof.Symbol)
+	debug.HandleFunc("/pprof/trace", pprof.Trace)
+	debug.PathPrefix("/pprof/").HandlerFunc(pprof.Index)
+}
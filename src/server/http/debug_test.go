@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"dekart/src/server/dekart"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+func TestDebugEndpoints(t *testing.T) {
+	os.Setenv("DEKART_ADMIN_EMAILS", "admin@example.com")
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	jobs := job.NewStore()
+	jobs.New(context.Background(), testID, testID)
+	router := mux.NewRouter()
+	configureDebug(router, dekart.NewServer(db, nil, jobs, report.NewStreams()), "debug-token")
+	proxies, _ := user.ParseCIDRs("192.0.2.0/24")
+	claimsCheck := user.NewClaimsCheck(user.ClaimsCheckConfig{
+		TrustedHeader:  "X-Forwarded-Email",
+		TrustedProxies: proxies,
+	})
+	handler := newHandler(grpcweb.WrapServer(grpc.NewServer()), router, claimsCheck.GetContext)
+
+	request := func(path string, email string, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if email != "" {
+			r.Header.Set("X-Forwarded-Email", email)
+		}
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	for _, path := range []string{"/debug/stats", "/debug/jobs", "/debug/pprof/", "/debug/pprof/goroutine", "/debug/metrics"} {
+		if w := request(path, "", ""); w.Code != http.StatusForbidden {
+			t.Errorf("%s without credentials: expected 403, got %d", path, w.Code)
+		}
+		if w := request(path, "", "wrong"); w.Code != http.StatusForbidden {
+			t.Errorf("%s with wrong token: expected 403, got %d", path, w.Code)
+		}
+	}
+	if w := request("/debug/metrics", "", "debug-token"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "go_goroutines") {
+		t.Errorf("metrics: expected Prometheus metrics, got %d %s", w.Code, w.Body.String())
+	}
+	mock.ExpectQuery("from instance_roles").WillReturnRows(sqlmock.NewRows([]string{"email_pattern", "role"}))
+	if w := request("/debug/stats", "editor@example.com", ""); w.Code != http.StatusForbidden {
+		t.Errorf("editor: expected 403, got %d", w.Code)
+	}
+
+	for name, w := range map[string]*httptest.ResponseRecorder{
+		"token": request("/debug/stats", "", "debug-token"),
+		"admin": request("/debug/stats", "admin@example.com", ""),
+	} {
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", name, w.Code)
+		}
+		var stats debugStats
+		if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+			t.Fatal(err)
+		}
+		if stats.Jobs != 1 || stats.JobsByStatus["JOB_STATUS_PENDING"] != 1 || stats.OldestJobAgeSeconds <= 0 || stats.Goroutines == 0 {
+			t.Errorf("%s: unexpected stats %+v", name, stats)
+		}
+	}
+	w := request("/debug/jobs", "", "debug-token")
+	var phases []debugJob
+	if err := json.NewDecoder(w.Body).Decode(&phases); err != nil {
+		t.Fatal(err)
+	}
+	if len(phases) != 1 || phases[0].QueryID != testID || phases[0].Phase != "created" {
+		t.Errorf("unexpected jobs %+v", phases)
+	}
+	if w := request("/debug/pprof/goroutine?debug=1", "", "debug-token"); w.Code != http.StatusOK {
+		t.Errorf("pprof: expected 200, got %d", w.Code)
+	}
+}
@@ -1,11 +1,16 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
 	"dekart/src/proto"
 	"dekart/src/server/dekart"
+	"dekart/src/server/requestid"
 	"dekart/src/server/user"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -41,7 +46,11 @@ func (m ResponseWriter) WriteHeader(statusCode int) {
 }
 
 func configureGRPC(dekartServer *dekart.Server) *grpcweb.WrappedGrpcServer {
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(requestid.UnaryServerInterceptor),
+		grpc.StreamInterceptor(requestid.StreamServerInterceptor),
+		grpc.MaxRecvMsgSize(dekartServer.MaxRequestSize()),
+	)
 	proto.RegisterDekartServer(server, dekartServer)
 	return grpcweb.WrapServer(
 		server,
@@ -52,17 +61,53 @@ func configureGRPC(dekartServer *dekart.Server) *grpcweb.WrappedGrpcServer {
 	)
 }
 
-func configureHTTP(dekartServer *dekart.Server) *mux.Router {
+// apiPrefix of routes which require user claims
+const apiPrefix = "/api/"
+
+func configureHTTP(dekartServer *dekart.Server, oidc *user.OIDC) *mux.Router {
 	router := mux.NewRouter()
-	api := router.PathPrefix("/api/v1/").Subrouter()
+	if oidc != nil {
+		router.HandleFunc("/auth/login", oidc.Login).Methods("GET")
+		router.HandleFunc("/auth/callback", oidc.Callback).Methods("GET")
+		router.HandleFunc("/auth/logout", oidc.Logout).Methods("POST")
+	}
+	api := router.PathPrefix(apiPrefix + "v1/").Subrouter()
 	api.Use(mux.CORSMethodMiddleware(router))
-	api.HandleFunc("/job-results/{id}.csv", func(w http.ResponseWriter, r *http.Request) {
+	// result is found by id, extension only names format of downloaded file
+	api.HandleFunc("/job-results/{id}.{format:csv|parquet|geojson}", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		if r.Method == http.MethodOptions {
 			return
 		}
 		dekartServer.ServeQueryResult(w, r)
 	}).Methods("GET", "OPTIONS")
+	// tiles are built from result, so access is same as for result download
+	api.HandleFunc("/results/{id}/tiles/{z:[0-9]+}/{x:[0-9]+}/{y:[0-9]+}.mvt", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			return
+		}
+		dekartServer.ServeResultTile(w, r)
+	}).Methods("GET", "OPTIONS")
+	api.HandleFunc("/reports/{id}/events", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			return
+		}
+		dekartServer.ServeReportEvents(w, r)
+	}).Methods("GET", "OPTIONS")
+	configureREST(api, dekartServer)
+	// results of public reports are served without claims, so CDN can cache them
+	router.HandleFunc("/public/results/{id}.{format:csv|parquet}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			return
+		}
+		dekartServer.ServePublicResult(w, r)
+	}).Methods("GET", "OPTIONS")
+	if os.Getenv("DEKART_DEBUG_ENDPOINTS") == "1" {
+		configureDebug(router, dekartServer, os.Getenv("DEKART_DEBUG_TOKEN"))
+	}
 
 	staticFilesHandler := NewStaticFilesHandler()
 
@@ -78,29 +123,121 @@ func configureHTTP(dekartServer *dekart.Server) *mux.Router {
 	return router
 }
 
+// ListenAndServe with TLS when server TLSConfig is set
+func ListenAndServe(server *http.Server) error {
+	if server.TLSConfig != nil {
+		// certificate is provided by TLSConfig.GetCertificate
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
+
+// splitList of comma or space separated values
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+func configureOIDC(sessions user.SessionStore) *user.OIDC {
+	issuer := os.Getenv("DEKART_OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+	sessionTTL := time.Duration(0)
+	if ttl := os.Getenv("DEKART_SESSION_TTL"); ttl != "" {
+		var err error
+		sessionTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid DEKART_SESSION_TTL")
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	oidc, err := user.NewOIDC(ctx, user.OIDCConfig{
+		Issuer:        issuer,
+		ClientID:      os.Getenv("DEKART_OIDC_CLIENT_ID"),
+		ClientSecret:  os.Getenv("DEKART_OIDC_CLIENT_SECRET"),
+		RedirectURL:   os.Getenv("DEKART_OIDC_REDIRECT_URL"),
+		Scopes:        splitList(os.Getenv("DEKART_OIDC_SCOPES")),
+		GroupsClaim:   os.Getenv("DEKART_OIDC_GROUPS_CLAIM"),
+		SessionSecret: os.Getenv("DEKART_SESSION_SECRET"),
+		SessionTTL:    sessionTTL,
+	}, sessions)
+	if err != nil {
+		log.Fatal().Err(err).Msg("OIDC configuration failed")
+	}
+	return oidc
+}
+
+// newHandler routes grpc-web and http requests; claims are resolved only for API and grpc requests
+func newHandler(grpcServer *grpcweb.WrappedGrpcServer, httpServer http.Handler, getContext func(r *http.Request) context.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		grpcRequest := grpcServer.IsAcceptableGrpcCorsRequest(r) || grpcServer.IsGrpcWebRequest(r)
+		if !grpcRequest && !strings.HasPrefix(r.URL.Path, apiPrefix) && !strings.HasPrefix(r.URL.Path, debugPrefix) {
+			// static files and auth routes don't need claims, no session lookup
+			httpServer.ServeHTTP(w, r)
+			return
+		}
+		ctx := getContext(r)
+		if err := user.ClaimsError(ctx); err != nil {
+			// request ID correlates response with logged error
+			http.Error(w, fmt.Sprintf("Internal error, request id %s", requestid.FromContext(ctx)), http.StatusInternalServerError)
+			return
+		}
+		if grpcRequest {
+			if waitStreams[r.URL.Path] {
+				// stream sends keepalives until job is finished, WriteTimeout would cut it
+				if err := clearWriteDeadline(w, r); err != nil {
+					log.Warn().Err(err).Str("path", r.URL.Path).Msg("Stream is limited by WriteTimeout")
+				}
+			}
+			grpcServer.ServeHTTP(w, r.WithContext(ctx))
+		} else {
+			httpServer.ServeHTTP(w, r.WithContext(ctx))
+		}
+	})
+}
+
 // Configure HTTP server with http and grpc
-func Configure(dekartServer *dekart.Server) *http.Server {
+func Configure(dekartServer *dekart.Server, sessions user.SessionStore) *http.Server {
+	oidc := configureOIDC(sessions)
 	grpcServer := configureGRPC(dekartServer)
-	httpServer := configureHTTP(dekartServer)
-	claimsCheck := user.NewClaimsCheck(
-		os.Getenv("DEKART_IAP_JWT_AUD"),
-		os.Getenv("DEKART_REQUIRE_IAP") == "1",
-		os.Getenv("DEKART_DEV_CLAIMS_EMAIL"),
+	httpServer := configureHTTP(dekartServer, oidc)
+	trustedProxies, err := user.ParseCIDRs(os.Getenv("DEKART_TRUSTED_PROXY_CIDRS"))
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid DEKART_TRUSTED_PROXY_CIDRS")
+	}
+	claimsCheck := user.NewClaimsCheck(user.ClaimsCheckConfig{
+		Audience:       os.Getenv("DEKART_IAP_JWT_AUD"),
+		RequireIAP:     os.Getenv("DEKART_REQUIRE_IAP") == "1",
+		DevClaimsEmail: os.Getenv("DEKART_DEV_CLAIMS_EMAIL"),
+		TrustedHeader:  os.Getenv("DEKART_TRUSTED_HEADER"),
+		TrustedProxies: trustedProxies,
+		OIDC:           oidc,
+	})
+
+	tlsConfig, reloader, err := configureTLS(
+		os.Getenv("DEKART_TLS_CERT_FILE"),
+		os.Getenv("DEKART_TLS_KEY_FILE"),
+		os.Getenv("DEKART_TLS_CLIENT_CA_FILE"),
 	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("TLS configuration failed")
+	}
+	if reloader != nil {
+		go reloader.reloadOnSIGHUP()
+		log.Info().Bool("mTLS", tlsConfig.ClientAuth == tls.RequireAndVerifyClientCert).Msg("TLS enabled")
+	}
 
 	port := os.Getenv("DEKART_PORT")
 	log.Info().Msgf("Starting dekart at :%s", port)
 	return &http.Server{
-		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			reqWithClaims := r.WithContext(claimsCheck.GetContext(r))
-			if grpcServer.IsAcceptableGrpcCorsRequest(r) || grpcServer.IsGrpcWebRequest(r) {
-				grpcServer.ServeHTTP(w, reqWithClaims)
-			} else {
-				httpServer.ServeHTTP(w, reqWithClaims)
-			}
-		}),
+		Handler:      requestid.Middleware(newHandler(grpcServer, httpServer, claimsCheck.GetContext)),
 		Addr:         ":" + port,
+		TLSConfig:    tlsConfig,
 		WriteTimeout: 60 * time.Second,
 		ReadTimeout:  60 * time.Second,
+		ConnContext:  withConn,
 	}
 }
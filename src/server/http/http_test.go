@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+)
+
+func TestClaimsResolvedOnlyForAPI(t *testing.T) {
+	lookups := 0
+	handler := newHandler(
+		grpcweb.WrapServer(grpc.NewServer()),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		func(r *http.Request) context.Context {
+			lookups++
+			return r.Context()
+		},
+	)
+	for path, expected := range map[string]int{
+		"/":                         0,
+		"/reports/1":                0,
+		"/static/js/main.js":        0,
+		"/auth/login":               0,
+		"/api/v1/reports":           1,
+		"/api/v1/job-results/1.csv": 1,
+	} {
+		lookups = 0
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		if lookups != expected {
+			t.Errorf("%s: expected %d claims lookups, got %d", path, expected, lookups)
+		}
+	}
+	lookups = 0
+	r := httptest.NewRequest(http.MethodPost, "/dekart.Dekart/GetReport", nil)
+	r.Header.Set("Content-Type", "application/grpc-web+proto")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if lookups != 1 {
+		t.Errorf("expected claims for grpc-web request, got %d lookups", lookups)
+	}
+}
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"dekart/src/server/job"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var pathParamRe = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// openAPISchemas collects component schemas of proto messages using proto JSON mapping
+type openAPISchemas map[string]interface{}
+
+func schemaRef(name protoreflect.FullName) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + string(name)}
+}
+
+func (schemas openAPISchemas) fieldSchema(field protoreflect.FieldDescriptor) map[string]interface{} {
+	var schema map[string]interface{}
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		schema = map[string]interface{}{"type": "boolean"}
+	case protoreflect.StringKind:
+		schema = map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		schema = map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// proto JSON mapping encodes 64 bit integers as strings
+		schema = map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		schema = map[string]interface{}{"type": "number"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]string, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names[i] = string(values.Get(i).Name())
+		}
+		schema = map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if field.IsMap() {
+			return map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": schemas.fieldSchema(field.MapValue()),
+			}
+		}
+		schemas.add(field.Message())
+		schema = schemaRef(field.Message().FullName())
+	}
+	if field.IsList() {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+func (schemas openAPISchemas) add(message protoreflect.MessageDescriptor) {
+	name := string(message.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+	properties := map[string]interface{}{}
+	schemas[name] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		properties[field.JSONName()] = schemas.fieldSchema(field)
+	}
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+// openAPISpec generates OpenAPI 3 spec from REST routes and proto descriptors of their messages
+func openAPISpec(routes []restRoute) ([]byte, error) {
+	schemas := openAPISchemas{}
+	paths := map[string]map[string]interface{}{}
+	errorResponse := map[string]interface{}{
+		"description": "Error status using proto JSON mapping of google.rpc.Status",
+	}
+	for _, route := range routes {
+		path := "/api/v1" + route.path
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		schemas.add(route.response.ProtoReflect().Descriptor())
+		operation := map[string]interface{}{
+			"summary": route.summary,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"content":     jsonContent(schemaRef(route.response.ProtoReflect().Descriptor().FullName())),
+				},
+				"default": errorResponse,
+			},
+		}
+		if route.body != nil {
+			schemas.add(route.body.ProtoReflect().Descriptor())
+			operation["requestBody"] = map[string]interface{}{
+				"content": jsonContent(schemaRef(route.body.ProtoReflect().Descriptor().FullName())),
+			}
+		}
+		parameters := []interface{}{}
+		for _, match := range pathParamRe.FindAllStringSubmatch(route.path, -1) {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string", "format": "uuid"},
+			})
+		}
+		for _, name := range route.query {
+			parameters = append(parameters, map[string]interface{}{
+				"name":   name,
+				"in":     "query",
+				"schema": map[string]interface{}{"type": "string"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+		paths[path][strings.ToLower(route.method)] = operation
+	}
+	// result run with result_format PARQUET is GeoParquet when it has GEOGRAPHY columns, GeoJSON is file uploaded to report
+	for extension, contentType := range map[string]string{"csv": "text/csv", "parquet": job.ParquetContentType, "geojson": "application/geo+json"} {
+		resultParameters := []interface{}{map[string]interface{}{
+			"name":     "id",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		}}
+		if extension == "csv" {
+			resultParameters = append(resultParameters, map[string]interface{}{
+				"name":        "locale",
+				"in":          "query",
+				"description": "BCP 47 tag like de-DE, numbers and dates are formatted for spreadsheets of locale",
+				"schema":      map[string]interface{}{"type": "string"},
+			})
+		}
+		paths["/api/v1/job-results/{id}."+extension] = map[string]interface{}{
+			strings.ToLower(http.MethodGet): map[string]interface{}{
+				"summary":    "Download query result",
+				"parameters": resultParameters,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "Query result",
+						"content": map[string]interface{}{
+							contentType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+						},
+					},
+				},
+			},
+		}
+	}
+	tileParameters := []interface{}{}
+	for _, name := range []string{"id", "z", "x", "y"} {
+		tileParameters = append(tileParameters, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	for _, name := range []string{"geometry", "lat", "lng"} {
+		tileParameters = append(tileParameters, map[string]interface{}{
+			"name":        name,
+			"in":          "query",
+			"description": "Geometry column, detected by name when not set",
+			"schema":      map[string]interface{}{"type": "string"},
+		})
+	}
+	paths["/api/v1/results/{id}/tiles/{z}/{x}/{y}.mvt"] = map[string]interface{}{
+		strings.ToLower(http.MethodGet): map[string]interface{}{
+			"summary":    "Vector tile of query result points",
+			"parameters": tileParameters,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "Mapbox Vector Tile",
+					"content": map[string]interface{}{
+						"application/vnd.mapbox-vector-tile": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+					},
+				},
+			},
+		},
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Dekart REST API",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}, "", "  ")
+}
@@ -0,0 +1,605 @@
+package http
+
+import (
+	"dekart/src/proto"
+	"dekart/src/server/dekart"
+	"dekart/src/server/requestid"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// httpStatusFromCode maps gRPC codes to HTTP status, same as grpc-gateway does
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func writeRESTError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	b, marshalErr := protojson.Marshal(st.Proto())
+	if marshalErr != nil {
+		log.Err(marshalErr).Send()
+		http.Error(w, st.Message(), httpStatusFromCode(st.Code()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	w.Write(b)
+}
+
+// maxRESTBodySize limits request body, map configs are the largest payloads
+const maxRESTBodySize = 10 << 20
+
+// readRESTBody unmarshals JSON request body into proto message; empty body is allowed
+func readRESTBody(r *http.Request, m protoreflect.ProtoMessage) error {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, m)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// readRESTQuery sets proto message fields from query parameters, enums are set by value name
+func readRESTQuery(r *http.Request, m protoreflect.ProtoMessage, names []string) error {
+	fields := make(map[string]string)
+	for _, name := range names {
+		if value := r.URL.Query().Get(name); value != "" {
+			fields[name] = value
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = protojson.Unmarshal(b, m)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+// restHandler calls handler and writes response using proto JSON mapping
+func restHandler(handler func(r *http.Request) (protoreflect.ProtoMessage, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxRESTBodySize)
+		res, err := handler(r)
+		if err != nil {
+			writeRESTError(w, requestid.WithDetails(r.Context(), err))
+			return
+		}
+		b, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(res)
+		if err != nil {
+			log.Err(err).Send()
+			writeRESTError(w, status.Error(codes.Internal, err.Error()))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}
+}
+
+// restRoute maps HTTP method and path to Dekart RPC, also used to generate OpenAPI spec
+type restRoute struct {
+	method  string
+	path    string
+	summary string
+	// body schema, nil when route takes no body
+	body protoreflect.ProtoMessage
+	// query parameters, names of body fields
+	query    []string
+	response protoreflect.ProtoMessage
+	handler  func(r *http.Request) (protoreflect.ProtoMessage, error)
+}
+
+var listReportsQuery = []string{"search", "filter", "sort", "page_size", "page_token"}
+
+func newRESTRoutes(dekartServer *dekart.Server) []restRoute {
+	return []restRoute{
+		{
+			method:   http.MethodGet,
+			path:     "/user",
+			summary:  "Current user with instance role",
+			response: &proto.GetCurrentUserResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetCurrentUser(r.Context(), &proto.GetCurrentUserRequest{})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/reports",
+			summary:  "List reports, next page is requested with page_token",
+			query:    listReportsQuery,
+			response: &proto.ListReportsResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.ListReportsRequest{}
+				if err := readRESTQuery(r, req, listReportsQuery); err != nil {
+					return nil, err
+				}
+				return dekartServer.ListReports(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/reports",
+			summary:  "Create report",
+			body:     &proto.CreateReportRequest{},
+			response: &proto.CreateReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.CreateReportRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				return dekartServer.CreateReport(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/reports/{id}",
+			summary:  "Get report with queries",
+			response: &proto.GetReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetReport(r.Context(), &proto.GetReportRequest{
+					ReportId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}",
+			summary:  "Replace report title and map config",
+			body:     &proto.Report{},
+			response: &proto.UpdateReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				report := &proto.Report{}
+				if err := readRESTBody(r, report); err != nil {
+					return nil, err
+				}
+				report.Id = mux.Vars(r)["id"]
+				return dekartServer.UpdateReport(r.Context(), &proto.UpdateReportRequest{
+					Report: report,
+				})
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/reports/{id}",
+			summary:  "Archive report",
+			response: &proto.ArchiveReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.ArchiveReport(r.Context(), &proto.ArchiveReportRequest{
+					ReportId: mux.Vars(r)["id"],
+					Archive:  true,
+				})
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}/share",
+			summary:  "Share results of report publicly under /public/results/ or revoke share",
+			body:     &proto.ShareReportRequest{},
+			response: &proto.ShareReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.ShareReportRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.ShareReport(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/reports/{id}/fork",
+			summary:  "Fork report",
+			response: &proto.ForkReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.ForkReport(r.Context(), &proto.ForkReportRequest{
+					ReportId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/reports/{id}/cancel",
+			summary:  "Cancel jobs of all queries of report",
+			response: &proto.CancelReportResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.CancelReport(r.Context(), &proto.CancelReportRequest{
+					ReportId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/reports/{id}/run",
+			summary:  "Run all queries of report as one batch, progress is sent with report stream",
+			body:     &proto.RunAllQueriesRequest{},
+			response: &proto.RunAllQueriesResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.RunAllQueriesRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.RunAllQueries(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/reports/{id}/batches/{batchId}/cancel",
+			summary:  "Cancel jobs of batch",
+			response: &proto.CancelBatchResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.CancelBatch(r.Context(), &proto.CancelBatchRequest{
+					ReportId: mux.Vars(r)["id"],
+					BatchId:  mux.Vars(r)["batchId"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}/map-config",
+			summary:  "Save map config of report as new revision",
+			body:     &proto.UpdateMapConfigRequest{},
+			response: &proto.UpdateMapConfigResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateMapConfigRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.UpdateMapConfig(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}/variables",
+			summary:  "Replace variables substituted as {{name}} into queries of report",
+			body:     &proto.UpdateReportVariablesRequest{},
+			response: &proto.UpdateReportVariablesResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateReportVariablesRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.UpdateReportVariables(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}/settings",
+			summary:  "Replace result format, compression and delimiter used by runs of queries of report",
+			body:     &proto.UpdateReportSettingsRequest{},
+			response: &proto.UpdateReportSettingsResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateReportSettingsRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.UpdateReportSettings(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}/parameters",
+			summary:  "Replace parameter controls of report",
+			body:     &proto.UpdateReportParametersRequest{},
+			response: &proto.UpdateReportParametersResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateReportParametersRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.UpdateReportParameters(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/reports/{id}/parameters/values",
+			summary:  "Set parameter values of viewing session, or saved values of report with save",
+			body:     &proto.SetReportParameterValuesRequest{},
+			response: &proto.SetReportParameterValuesResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.SetReportParameterValuesRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.SetReportParameterValues(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/reports/{id}/parameters/{name}/options",
+			summary:  "Allowed values of report parameter, read from result of values query when it's set",
+			response: &proto.GetReportParameterOptionsResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				vars := mux.Vars(r)
+				return dekartServer.GetReportParameterOptions(r.Context(), &proto.GetReportParameterOptionsRequest{ReportId: vars["id"], Name: vars["name"]})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/reports/{id}/map-config/history",
+			summary:  "Map config revisions of report, newest first",
+			query:    []string{"limit"},
+			response: &proto.GetMapConfigHistoryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.GetMapConfigHistoryRequest{}
+				if err := readRESTQuery(r, req, []string{"limit"}); err != nil {
+					return nil, err
+				}
+				req.ReportId = mux.Vars(r)["id"]
+				return dekartServer.GetMapConfigHistory(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/reports/{id}/queries",
+			summary:  "Create query in report",
+			body:     &proto.Query{},
+			response: &proto.CreateQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				query := &proto.Query{}
+				if err := readRESTBody(r, query); err != nil {
+					return nil, err
+				}
+				query.ReportId = mux.Vars(r)["id"]
+				return dekartServer.CreateQuery(r.Context(), &proto.CreateQueryRequest{
+					Query: query,
+				})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/queries/{id}",
+			summary:  "Get query with job status",
+			response: &proto.GetQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetQuery(r.Context(), &proto.GetQueryRequest{
+					QueryId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/queries/{id}/executed",
+			summary:  "Get query text run by last job",
+			response: &proto.GetExecutedQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetExecutedQuery(r.Context(), &proto.GetExecutedQueryRequest{
+					QueryId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/queries/{id}/plan",
+			summary:  "Get query plan of last job",
+			response: &proto.GetJobPlanResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetJobPlan(r.Context(), &proto.GetJobPlanRequest{
+					QueryId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/queries/{id}",
+			summary:  "Replace query text",
+			body:     &proto.Query{},
+			response: &proto.UpdateQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				query := &proto.Query{}
+				if err := readRESTBody(r, query); err != nil {
+					return nil, err
+				}
+				query.Id = mux.Vars(r)["id"]
+				return dekartServer.UpdateQuery(r.Context(), &proto.UpdateQueryRequest{
+					Query: query,
+				})
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/queries/{id}/title",
+			summary:  "Rename query",
+			body:     &proto.UpdateQueryTitleRequest{},
+			response: &proto.UpdateQueryTitleResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateQueryTitleRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.QueryId = mux.Vars(r)["id"]
+				return dekartServer.UpdateQueryTitle(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/queries/{id}/dependencies",
+			summary:  "Replace queries run before query by run of all queries of report",
+			body:     &proto.UpdateQueryDependenciesRequest{},
+			response: &proto.UpdateQueryDependenciesResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateQueryDependenciesRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.QueryId = mux.Vars(r)["id"]
+				return dekartServer.UpdateQueryDependencies(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPut,
+			path:     "/queries/{id}/parameter-bindings",
+			summary:  "Replace bindings of named parameters of query to report parameters",
+			body:     &proto.UpdateQueryParameterBindingsRequest{},
+			response: &proto.UpdateQueryParameterBindingsResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.UpdateQueryParameterBindingsRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.QueryId = mux.Vars(r)["id"]
+				return dekartServer.UpdateQueryParameterBindings(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/queries/{id}",
+			summary:  "Remove query, restorable until restorable_until",
+			response: &proto.RemoveQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.RemoveQuery(r.Context(), &proto.RemoveQueryRequest{
+					QueryId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/queries/{id}/restore",
+			summary:  "Restore removed query",
+			response: &proto.RestoreQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.RestoreQuery(r.Context(), &proto.RestoreQueryRequest{
+					QueryId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/queries/{id}/run",
+			summary:  "Run query, poll GET /queries/{id} for job status",
+			body:     &proto.RunQueryRequest{},
+			response: &proto.RunQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.RunQueryRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.QueryId = mux.Vars(r)["id"]
+				return dekartServer.RunQuery(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/queries/{id}/cancel",
+			summary:  "Cancel query job",
+			response: &proto.CancelQueryResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.CancelQuery(r.Context(), &proto.CancelQueryRequest{
+					QueryId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/results/{id}/compare",
+			summary:  "Compare result with earlier result of same query",
+			body:     &proto.CompareResultsRequest{},
+			response: &proto.CompareResultsResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				req := &proto.CompareResultsRequest{}
+				if err := readRESTBody(r, req); err != nil {
+					return nil, err
+				}
+				req.ResultId = mux.Vars(r)["id"]
+				return dekartServer.CompareResults(r.Context(), req)
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/results/{id}/lineage",
+			summary:  "Get job, query hash, parameter values and user which produced result",
+			response: &proto.GetResultLineageResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetResultLineage(r.Context(), &proto.GetResultLineageRequest{
+					ResultId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/results/{id}/field-types",
+			summary:  "Get kepler.gl field types of result columns mapped from BigQuery types",
+			response: &proto.GetResultFieldTypesResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetResultFieldTypes(r.Context(), &proto.GetResultFieldTypesRequest{
+					ResultId: mux.Vars(r)["id"],
+				})
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/jobs/{id}/events",
+			summary:  "Get timeline of job state changes, retries, warnings, progress and upload",
+			response: &proto.GetJobEventsResponse{},
+			handler: func(r *http.Request) (protoreflect.ProtoMessage, error) {
+				return dekartServer.GetJobEvents(r.Context(), &proto.GetJobEventsRequest{
+					JobId: mux.Vars(r)["id"],
+				})
+			},
+		},
+	}
+}
+
+// configureREST adds REST routes sharing business logic with gRPC handlers
+func configureREST(api *mux.Router, dekartServer *dekart.Server) {
+	routes := newRESTRoutes(dekartServer)
+	for _, route := range routes {
+		api.HandleFunc(route.path, restHandler(route.handler)).Methods(route.method)
+	}
+	spec, err := openAPISpec(routes)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot generate OpenAPI spec")
+	}
+	api.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	}).Methods(http.MethodGet)
+}
@@ -0,0 +1,388 @@
+package http
+
+import (
+	"database/sql"
+	"dekart/src/server/dekart"
+	"dekart/src/server/job"
+	"dekart/src/server/report"
+	"dekart/src/server/user"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+)
+
+func newRESTRouter(claimsCheck user.ClaimsCheck) http.Handler {
+	return newRESTRouterWithDB(claimsCheck, nil)
+}
+
+func newRESTRouterWithDB(claimsCheck user.ClaimsCheck, db *sql.DB) http.Handler {
+	router := mux.NewRouter()
+	api := router.PathPrefix("/api/v1/").Subrouter()
+	configureREST(api, dekart.NewServer(db, nil, job.NewStore(), report.NewStreams()))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		router.ServeHTTP(w, r.WithContext(claimsCheck.GetContext(r)))
+	})
+}
+
+const testID = "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d"
+
+var restRoutes = []struct {
+	method string
+	path   string
+}{
+	{http.MethodPost, "/api/v1/reports"},
+	{http.MethodGet, "/api/v1/reports/" + testID},
+	{http.MethodPut, "/api/v1/reports/" + testID},
+	{http.MethodDelete, "/api/v1/reports/" + testID},
+	{http.MethodPost, "/api/v1/reports/" + testID + "/fork"},
+	{http.MethodPost, "/api/v1/reports/" + testID + "/cancel"},
+	{http.MethodPost, "/api/v1/reports/" + testID + "/queries"},
+	{http.MethodGet, "/api/v1/queries/" + testID},
+	{http.MethodPut, "/api/v1/queries/" + testID},
+	{http.MethodDelete, "/api/v1/queries/" + testID},
+	{http.MethodPost, "/api/v1/queries/" + testID + "/run"},
+	{http.MethodPost, "/api/v1/queries/" + testID + "/cancel"},
+}
+
+func TestRESTUnauthenticated(t *testing.T) {
+	handler := newRESTRouter(user.NewClaimsCheck(user.ClaimsCheckConfig{RequireIAP: true}))
+	for _, route := range restRoutes {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(route.method, route.path, nil))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s: expected %d, got %d", route.method, route.path, http.StatusUnauthorized, w.Code)
+		}
+	}
+}
+
+func TestRESTInvalidArgument(t *testing.T) {
+	// admin role is resolved without database
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+	handler := newRESTRouter(user.NewClaimsCheck(user.ClaimsCheckConfig{}))
+	for _, route := range restRoutes {
+		if !strings.Contains(route.path, testID) {
+			continue
+		}
+		path := strings.Replace(route.path, testID, "not-uuid", 1)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(route.method, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s %s: expected %d, got %d", route.method, path, http.StatusBadRequest, w.Code)
+		}
+		if w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("%s %s: expected json error", route.method, path)
+		}
+	}
+}
+
+func TestRESTInvalidBody(t *testing.T) {
+	handler := newRESTRouter(user.NewClaimsCheck(user.ClaimsCheckConfig{}))
+	for _, path := range []string{
+		"/api/v1/reports/" + testID,
+		"/api/v1/queries/" + testID,
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, strings.NewReader("{")))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("PUT %s: expected %d, got %d", path, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestRESTMethodNotAllowed(t *testing.T) {
+	handler := newRESTRouter(user.NewClaimsCheck(user.ClaimsCheckConfig{}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/queries/"+testID+"/run", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d, got %d", http.StatusMethodNotAllowed, w.Code)
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	for code, httpStatus := range map[codes.Code]int{
+		codes.OK:               http.StatusOK,
+		codes.InvalidArgument:  http.StatusBadRequest,
+		codes.NotFound:         http.StatusNotFound,
+		codes.Unauthenticated:  http.StatusUnauthorized,
+		codes.PermissionDenied: http.StatusForbidden,
+		codes.Internal:         http.StatusInternalServerError,
+		codes.Unknown:          http.StatusInternalServerError,
+	} {
+		if httpStatusFromCode(code) != httpStatus {
+			t.Errorf("%s: expected %d, got %d", code, httpStatus, httpStatusFromCode(code))
+		}
+	}
+}
+
+const testQueryID = "1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed"
+
+var reportColumns = []string{"id", "map_config", "title", "can_write", "billing_project", "default_dataset", "map_config_revision", "variables", "exploration_limit", "public_share", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "freshness_max_age", "freshness_auto_rerun"}
+
+var queryColumns = []string{
+	"id", "query_text", "job_status", "job_result_id", "job_error",
+	"job_duration", "total_rows", "bytes_processed", "result_size", "rows_written", "sample_rate", "h3_result_id", "title",
+	"executed_query_text",
+	"result_table",
+	"reused_from_query_id", "result_created_at", "result_format", "exploration_limit", "job_warnings", "batch_id", "depends_on", "skipped_dependency_id", "serialization", "plan_available", "use_legacy_sql", "exact_total_rows", "result_compression", "parameter_bindings", "format_results", "cancel_reason", "job_reservation", "preview_result_id", "preview_rows", "job_error_explanation", "result_meta",
+}
+
+func expectReport(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("from reports where id").
+		WithArgs(testID, user.UnknownEmail).
+		WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(testID, "{}", "Report", true, "", "", 1, "", 0, false, 0, 0, "", "", "", 0, false))
+}
+
+func expectFiles(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("from files where report_id").
+		WithArgs(testID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "report_id", "name", "file_type", "size", "author_email", "created_at"}))
+}
+
+func expectQueries(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("from queries where report_id").
+		WithArgs(testID).
+		WillReturnRows(sqlmock.NewRows(queryColumns).AddRow(testQueryID, "select 1", 0, "", "", 0, 0, 0, 0, 0, 0, "", "Query 1", "", "", "", 0, 0, 0, "", "", "", "", "", false, false, -1, 0, "", "", 0, "", "", 0, "", ""))
+}
+
+func expectQueryReport(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("select report_id from queries").
+		WithArgs(testQueryID, user.UnknownEmail).
+		WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(testID))
+}
+
+// expectQueryNotDeleted when query is not found, deleted query is reported as such
+func expectQueryNotDeleted(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("select case when deleted_at is null").
+		WithArgs(testQueryID).
+		WillReturnRows(sqlmock.NewRows([]string{"deleted"}))
+}
+
+func TestRESTRoutes(t *testing.T) {
+	// admin role is resolved without database
+	os.Setenv("DEKART_ADMIN_EMAILS", user.UnknownEmail)
+	defer os.Unsetenv("DEKART_ADMIN_EMAILS")
+
+	for _, c := range []struct {
+		method string
+		path   string
+		body   string
+		expect func(mock sqlmock.Sqlmock)
+		status int
+		field  string
+	}{
+		{http.MethodGet, "/api/v1/user", "", func(mock sqlmock.Sqlmock) {}, http.StatusOK, `"role":"ROLE_ADMIN"`},
+		{http.MethodGet, "/api/v1/reports?filter=FILTER_MINE&page_size=5&search=roads", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("from reports").
+				WithArgs(user.UnknownEmail, "%roads%", user.UnknownEmail, 6).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "title", "archived", "can_write", "author_email", "updated_at"}).
+					AddRow(testID, "Roads", false, true, user.UnknownEmail, time.Unix(1600000000, 0)))
+		}, http.StatusOK, `"totalCount":"1"`},
+		{http.MethodGet, "/api/v1/reports?filter=unknown", "", func(mock sqlmock.Sqlmock) {}, http.StatusBadRequest, `"code":3`},
+		{http.MethodPost, "/api/v1/reports", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("INSERT INTO reports").WillReturnResult(sqlmock.NewResult(0, 1))
+		}, http.StatusOK, `"report":`},
+		{http.MethodGet, "/api/v1/reports/" + testID, "", func(mock sqlmock.Sqlmock) {
+			expectReport(mock)
+			expectFiles(mock)
+			expectQueries(mock)
+		}, http.StatusOK, `"mapConfig":"{}"`},
+		{http.MethodGet, "/api/v1/reports/" + testID, "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("from reports where id").WillReturnRows(sqlmock.NewRows(reportColumns))
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPut, "/api/v1/reports/" + testID, `{"title":"New","mapConfig":"{}","billingProject":"team-billing"}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("update reports set map_config").
+				WithArgs("{}", testID, user.UnknownEmail, "New", "team-billing", "", int64(0)).
+				WillReturnRows(sqlmock.NewRows([]string{"map_config_revision"}).AddRow(2))
+			mock.ExpectExec("insert into map_configs").
+				WithArgs(testID, 2, "{}", user.UnknownEmail).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}, http.StatusOK, `"warning":""`},
+		{http.MethodPut, "/api/v1/reports/" + testID, `{"title":"New"}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("update reports set map_config").WillReturnRows(sqlmock.NewRows([]string{"map_config_revision"}))
+			mock.ExpectRollback()
+			mock.ExpectQuery("select author_email from reports").WillReturnRows(sqlmock.NewRows([]string{"author_email"}))
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPut, "/api/v1/reports/" + testID + "/map-config", `{"mapConfig":"{\"b\":1,\"a\":2}"}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("update reports set map_config").
+				WithArgs("{\n  \"a\": 2,\n  \"b\": 1\n}", testID, user.UnknownEmail).
+				WillReturnRows(sqlmock.NewRows([]string{"map_config_revision"}).AddRow(3))
+			mock.ExpectExec("insert into map_configs").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}, http.StatusOK, `"revision":"3"`},
+		{http.MethodGet, "/api/v1/reports/" + testID + "/map-config/history?limit=5", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("from map_configs").
+				WithArgs(testID, 5).
+				WillReturnRows(sqlmock.NewRows([]string{"revision", "map_config", "author_email", "created_at"}).AddRow(3, "{}", user.UnknownEmail, 1600000000))
+		}, http.StatusOK, `"revision":"3"`},
+		{http.MethodGet, "/api/v1/reports/" + testID + "/map-config/history?limit=x", "", func(mock sqlmock.Sqlmock) {}, http.StatusBadRequest, `"code":3`},
+		{http.MethodDelete, "/api/v1/reports/" + testID, "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("update reports set archived").
+				WithArgs(true, testID, user.UnknownEmail).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			// jobs of archived report are cancelled
+			mock.ExpectQuery("select id from queries").WithArgs(testID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			// and its uploaded files removed
+			mock.ExpectQuery("from files").WithArgs(testID).WillReturnRows(sqlmock.NewRows([]string{"id", "object_name", "bucket"}))
+		}, http.StatusOK, `"cancelledCount":0`},
+		{http.MethodPost, "/api/v1/reports/" + testID + "/cancel", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("select count").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPost, "/api/v1/reports/" + testID + "/fork", "", func(mock sqlmock.Sqlmock) {
+			expectReport(mock)
+			expectQueries(mock)
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO reports").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("INSERT INTO queries").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}, http.StatusOK, `"reportId":`},
+		{http.MethodPost, "/api/v1/reports/" + testID + "/queries", `{"queryText":"select 1"}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("insert into queries").
+				WithArgs(sqlmock.AnyArg(), testID, "select 1", user.UnknownEmail, "", false).
+				WillReturnRows(sqlmock.NewRows([]string{"title"}).AddRow("Query 2"))
+		}, http.StatusOK, `"title":"Query 2"`},
+		{http.MethodPost, "/api/v1/reports/" + testID + "/queries", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("insert into queries").WillReturnRows(sqlmock.NewRows([]string{"title"}))
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPost, "/api/v1/reports/" + testID + "/queries", `{"title":"a\u0007b"}`, func(mock sqlmock.Sqlmock) {}, http.StatusBadRequest, `"code":3`},
+		{http.MethodGet, "/api/v1/queries/" + testQueryID, "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("select report_id from queries").
+				WithArgs(testQueryID).
+				WillReturnRows(sqlmock.NewRows([]string{"report_id"}).AddRow(testID))
+			expectQueries(mock)
+		}, http.StatusOK, `"queryText":"select 1"`},
+		{http.MethodGet, "/api/v1/queries/" + testQueryID, "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("select report_id from queries").WillReturnRows(sqlmock.NewRows([]string{"report_id"}))
+			expectQueryNotDeleted(mock)
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPut, "/api/v1/queries/" + testQueryID, `{"queryText":"select 2"}`, func(mock sqlmock.Sqlmock) {
+			expectQueryReport(mock)
+			mock.ExpectExec("update queries set query_text").
+				WithArgs("select 2", testQueryID, false).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}, http.StatusOK, `"id":"` + testQueryID},
+		{http.MethodPut, "/api/v1/queries/" + testQueryID + "/title", `{"title":" Roads "}`, func(mock sqlmock.Sqlmock) {
+			expectQueryReport(mock)
+			mock.ExpectBegin()
+			mock.ExpectExec("update queries set title").
+				WithArgs("Roads", testQueryID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectExec("update reports set updated_at").
+				WithArgs(testID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+		}, http.StatusOK, `"title":"Roads"`},
+		{http.MethodDelete, "/api/v1/queries/" + testQueryID, "", func(mock sqlmock.Sqlmock) {
+			expectQueryReport(mock)
+			mock.ExpectExec("update queries set deleted_at").WithArgs(testQueryID).WillReturnResult(sqlmock.NewResult(0, 1))
+		}, http.StatusOK, `"restorableUntil":`},
+		{http.MethodPost, "/api/v1/queries/" + testQueryID + "/restore", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("from queries join reports").WillReturnRows(sqlmock.NewRows([]string{"report_id", "expired"}))
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPost, "/api/v1/queries/" + testQueryID + "/run", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("select\\s+queries.query_text").WillReturnRows(sqlmock.NewRows([]string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}))
+			expectQueryNotDeleted(mock)
+		}, http.StatusNotFound, `"code":5`},
+		{http.MethodPost, "/api/v1/queries/" + testQueryID + "/run", `{"variables":{"day":"2021-01-01"}}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("select\\s+queries.query_text").WillReturnRows(sqlmock.NewRows([]string{"query_text", "report_id", "billing_project", "default_dataset", "variables", "source_query_id", "exploration_limit", "use_legacy_sql", "result_format", "result_compression", "result_delimiter", "parameters", "parameter_values", "parameter_bindings"}).
+				AddRow("select {{day}}, {{region}}", testID, "", "", `[{"name":"day","type":"TYPE_STRING"}]`, "", 0, false, 0, 0, "", "", "", ""))
+		}, http.StatusBadRequest, `"description":"unknown variable {{region}}"`},
+		{http.MethodPut, "/api/v1/reports/" + testID + "/variables", `{"variables":[{"name":"day","type":"TYPE_STRING","defaultValue":"2021-01-01"}]}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("update reports set variables").
+				WithArgs(`[{"name":"day","type":"TYPE_STRING","defaultValue":"2021-01-01"}]`, testID, user.UnknownEmail).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}, http.StatusOK, "{}"},
+		{http.MethodPut, "/api/v1/reports/" + testID + "/variables", `{"variables":[{"name":"day"}]}`, func(mock sqlmock.Sqlmock) {}, http.StatusBadRequest, `"code":3`},
+		{http.MethodPut, "/api/v1/reports/" + testID + "/settings", `{"resultSettings":{"resultCompression":"RESULT_COMPRESSION_GZIP","delimiter":";"}}`, func(mock sqlmock.Sqlmock) {
+			mock.ExpectExec("update reports set result_format").
+				WithArgs(0, 2, ";", testID, user.UnknownEmail).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}, http.StatusOK, "{}"},
+		{http.MethodPut, "/api/v1/reports/" + testID + "/settings", `{"resultSettings":{"resultFormat":"RESULT_FORMAT_PARQUET","resultCompression":"RESULT_COMPRESSION_GZIP"}}`, func(mock sqlmock.Sqlmock) {}, http.StatusBadRequest, `"code":3`},
+		{http.MethodPost, "/api/v1/queries/" + testQueryID + "/cancel", "", func(mock sqlmock.Sqlmock) {
+			expectQueryReport(mock)
+		}, http.StatusOK, "{}"},
+		{http.MethodPost, "/api/v1/queries/" + testQueryID + "/cancel", "", func(mock sqlmock.Sqlmock) {
+			mock.ExpectQuery("select\\s+report_id").WillReturnRows(sqlmock.NewRows([]string{"report_id"}))
+			// admin looks up query of any report
+			mock.ExpectQuery("select report_id from queries where id").WillReturnRows(sqlmock.NewRows([]string{"report_id"}))
+		}, http.StatusNotFound, `"code":5`},
+	} {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.expect(mock)
+		handler := newRESTRouterWithDB(user.NewClaimsCheck(user.ClaimsCheckConfig{}), db)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(c.method, c.path, strings.NewReader(c.body)))
+		if w.Code != c.status {
+			t.Errorf("%s %s: expected %d, got %d %s", c.method, c.path, c.status, w.Code, w.Body.String())
+		}
+		if !strings.Contains(w.Body.String(), c.field) {
+			t.Errorf("%s %s: expected %s in %s", c.method, c.path, c.field, w.Body.String())
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%s %s: %v", c.method, c.path, err)
+		}
+		db.Close()
+	}
+}
+
+func TestRESTBodyTooLarge(t *testing.T) {
+	handler := newRESTRouter(user.NewClaimsCheck(user.ClaimsCheckConfig{}))
+	w := httptest.NewRecorder()
+	body := strings.NewReader(`{"title":"` + strings.Repeat("a", maxRESTBodySize) + `"}`)
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/reports/"+testID, body))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	handler := newRESTRouter(user.NewClaimsCheck(user.ClaimsCheckConfig{}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, w.Code)
+	}
+	var spec struct {
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	for _, route := range restRoutes {
+		path := strings.Replace(route.path, testID, "{id}", 1)
+		if _, ok := spec.Paths[path][strings.ToLower(route.method)]; !ok {
+			t.Errorf("%s %s missing in spec", route.method, path)
+		}
+	}
+	query, ok := spec.Components.Schemas["Query"]
+	if !ok {
+		t.Fatal("Query schema missing")
+	}
+	if query.Properties["queryText"]["type"] != "string" || query.Properties["resultSize"]["format"] != "int64" {
+		t.Errorf("expected proto JSON names and types, got %v", query.Properties)
+	}
+	if _, ok := query.Properties["jobStatus"]["enum"]; !ok {
+		t.Error("expected enum values for jobStatus")
+	}
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+)
+
+// certificateReloader serves certificate and client CA which can be reloaded from files without restart
+type certificateReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	cert         *tls.Certificate
+	clientCAs    *x509.CertPool
+	mutex        sync.RWMutex
+}
+
+func newCertificateReloader(certFile string, keyFile string, clientCAFile string) (*certificateReloader, error) {
+	r := &certificateReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	err := r.reload()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func loadClientCAs(clientCAFile string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read client CA %s: %w", clientCAFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA %s", clientCAFile)
+	}
+	return pool, nil
+}
+
+// reload certificate and client CA together, nothing is replaced when any of files is invalid
+func (r *certificateReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot load TLS certificate %s and key %s: %w", r.certFile, r.keyFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		clientCAs, err = loadClientCAs(r.clientCAFile)
+		if err != nil {
+			return err
+		}
+	}
+	r.mutex.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.mutex.Unlock()
+	return nil
+}
+
+func (r *certificateReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.cert, nil
+}
+
+// getConfigForClient verifies client certificates with current client CA
+func (r *certificateReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.getCertificate,
+		ClientCAs:      r.clientCAs,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+// reloadOnSIGHUP so cert-manager rotation works without restarts
+func (r *certificateReloader) reloadOnSIGHUP() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	r.reloadOnSignal(signals)
+}
+
+func (r *certificateReloader) reloadOnSignal(signals <-chan os.Signal) {
+	for range signals {
+		err := r.reload()
+		if err != nil {
+			// keep serving previous certificate and client CA
+			log.Err(err).Msg("TLS certificate reload failed")
+			continue
+		}
+		log.Info().Msg("TLS certificate reloaded")
+	}
+}
+
+// configureTLS returns nil config when certFile is not set; with clientCAFile clients must present certificate signed by CA
+func configureTLS(certFile string, keyFile string, clientCAFile string) (*tls.Config, *certificateReloader, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, nil, fmt.Errorf("DEKART_TLS_CLIENT_CA_FILE requires DEKART_TLS_CERT_FILE and DEKART_TLS_KEY_FILE")
+		}
+		return nil, nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, nil, fmt.Errorf("both DEKART_TLS_CERT_FILE and DEKART_TLS_KEY_FILE are required")
+	}
+	reloader, err := newCertificateReloader(certFile, keyFile, clientCAFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.getCertificate,
+	}
+	if clientCAFile != "" {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.GetConfigForClient = reloader.getConfigForClient
+	}
+	return config, reloader, nil
+}
@@ -0,0 +1,268 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestFile(t *testing.T, dir string, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+var testSerial int64
+
+func newTestCA(t *testing.T) *testCert {
+	testSerial++
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(testSerial),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+}
+
+func newTestServerCert(t *testing.T, ca *testCert) *testCert {
+	testSerial++
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(testSerial),
+		Subject:      pkix.Name{CommonName: "server"},
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+}
+
+func newTestClientCert(t *testing.T, ca *testCert) *testCert {
+	testSerial++
+	return newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(testSerial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+}
+
+// serveTLS returns url of server responding ok
+func serveTLS(t *testing.T, tlsConfig *tls.Config) string {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	return "https://" + listener.Addr().String()
+}
+
+// getWithClientCert returns error of request made with client certificate signed by client CA
+func getWithClientCert(url string, serverCA *testCert, client *testCert) error {
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCA.cert)
+	clientCert, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	if err != nil {
+		return err
+	}
+	c := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: []tls.Certificate{clientCert},
+		},
+		DisableKeepAlives: true,
+	}}
+	res, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected 200, got %d", res.StatusCode)
+	}
+	return nil
+}
+
+func TestReloadClientCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	server := newTestServerCert(t, ca)
+	rotatedCA := newTestCA(t)
+	caFile := writeTestFile(t, dir, "ca.crt", ca.certPEM)
+	tlsConfig, reloader, err := configureTLS(
+		writeTestFile(t, dir, "server.crt", server.certPEM),
+		writeTestFile(t, dir, "server.key", server.keyPEM),
+		caFile,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := serveTLS(t, tlsConfig)
+	client := newTestClientCert(t, ca)
+	rotatedClient := newTestClientCert(t, rotatedCA)
+	if err := getWithClientCert(url, ca, client); err != nil {
+		t.Fatal(err)
+	}
+	if err := getWithClientCert(url, ca, rotatedClient); err == nil {
+		t.Fatal("client of rotated CA should be rejected before reload")
+	}
+
+	signals := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		reloader.reloadOnSignal(signals)
+		close(done)
+	}()
+	defer func() {
+		close(signals)
+		<-done
+	}()
+
+	t.Run("invalid client CA keeps previous", func(t *testing.T) {
+		writeTestFile(t, dir, "ca.crt", []byte("bad"))
+		signals <- syscall.SIGHUP
+		signals <- syscall.SIGHUP // second send returns after first reload is done
+		if err := getWithClientCert(url, ca, client); err != nil {
+			t.Errorf("expected previous client CA, got %v", err)
+		}
+	})
+
+	t.Run("rotated client CA", func(t *testing.T) {
+		writeTestFile(t, dir, "ca.crt", rotatedCA.certPEM)
+		signals <- syscall.SIGHUP
+		signals <- syscall.SIGHUP
+		if err := getWithClientCert(url, ca, rotatedClient); err != nil {
+			t.Errorf("expected client of rotated CA accepted, got %v", err)
+		}
+		if err := getWithClientCert(url, ca, client); err == nil {
+			t.Errorf("expected client of previous CA rejected")
+		}
+	})
+}
+
+func TestConfigureTLSWithClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	server := newTestServerCert(t, ca)
+	client := newTestClientCert(t, ca)
+
+	tlsConfig, _, err := configureTLS(
+		writeTestFile(t, dir, "server.crt", server.certPEM),
+		writeTestFile(t, dir, "server.key", server.keyPEM),
+		writeTestFile(t, dir, "ca.crt", ca.certPEM),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := serveTLS(t, tlsConfig)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	t.Run("without client certificate", func(t *testing.T) {
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}}
+		_, err := c.Get(url)
+		if err == nil {
+			t.Fatal("expected handshake error without client certificate")
+		}
+	})
+
+	t.Run("with client certificate", func(t *testing.T) {
+		clientCert, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: []tls.Certificate{clientCert},
+		}}}
+		res, err := c.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("expected 200, got %d", res.StatusCode)
+		}
+	})
+}
+
+func TestConfigureTLSMisconfigured(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	server := newTestServerCert(t, ca)
+	serverCert := writeTestFile(t, dir, "server.crt", server.certPEM)
+	serverKey := writeTestFile(t, dir, "server.key", server.keyPEM)
+	for name, files := range map[string][3]string{
+		"key without cert":    {"", filepath.Join(dir, "server.key"), ""},
+		"missing client CA":   {serverCert, serverKey, filepath.Join(dir, "missing-ca.crt")},
+		"invalid client CA":   {serverCert, serverKey, writeTestFile(t, dir, "bad-ca.crt", []byte("bad"))},
+		"missing files":       {filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"), ""},
+		"client CA alone":     {"", "", filepath.Join(dir, "ca.crt")},
+		"invalid certificate": {writeTestFile(t, dir, "bad.crt", []byte("bad")), writeTestFile(t, dir, "bad.key", []byte("bad")), ""},
+	} {
+		_, _, err := configureTLS(files[0], files[1], files[2])
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	config, _, err := configureTLS("", "", "")
+	if err != nil || config != nil {
+		t.Errorf("expected plaintext when TLS is not configured")
+	}
+}
@@ -0,0 +1,137 @@
+package job
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DatasetNotAllowed is reason of error when query references table outside of datasets allowed for connection
+const DatasetNotAllowed = "DATASET_NOT_ALLOWED"
+
+// DatasetAllowlist of glob patterns of project.dataset, like my-project.public_* or *.shared;
+// empty allowlist allows all datasets credentials can read
+type DatasetAllowlist []string
+
+// DatasetNotAllowedError when table referenced by query is in dataset outside of allowlist of connection
+type DatasetNotAllowedError struct {
+	Table string
+	// Connection of allowlist, empty for credentials of instance
+	Connection string
+}
+
+func (e *DatasetNotAllowedError) Error() string {
+	if e.Connection == "" {
+		return fmt.Sprintf("%s: table %s is not in datasets allowed by DEKART_BIGQUERY_DATASETS", DatasetNotAllowed, e.Table)
+	}
+	return fmt.Sprintf("%s: table %s is not in datasets allowed for connection %s by DEKART_BIGQUERY_CONNECTION_DATASETS", DatasetNotAllowed, e.Table, e.Connection)
+}
+
+// GRPCStatus of allowlist error is permission denied with table in details
+func (e *DatasetNotAllowedError) GRPCStatus() *status.Status {
+	st := status.New(codes.PermissionDenied, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   DatasetNotAllowed,
+		Domain:   "dekart",
+		Metadata: map[string]string{"table": e.Table, "connection": e.Connection},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// parseDatasetAllowlist of patterns separated by sep
+func parseDatasetAllowlist(value string, sep string) (DatasetAllowlist, error) {
+	var allowlist DatasetAllowlist
+	for _, pattern := range strings.Split(value, sep) {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil || !strings.Contains(pattern, ".") {
+			return nil, fmt.Errorf("invalid dataset pattern %q, expected glob of project.dataset", pattern)
+		}
+		allowlist = append(allowlist, pattern)
+	}
+	return allowlist, nil
+}
+
+// allows table as project.dataset.table; dataset of table is matched, so each table of allowed dataset is allowed
+func (a DatasetAllowlist) allows(table string) bool {
+	if len(a) == 0 {
+		return true
+	}
+	i := strings.LastIndex(table, ".")
+	if i < 0 {
+		return false
+	}
+	dataset := table[:i]
+	for _, pattern := range a {
+		if ok, _ := path.Match(pattern, dataset); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setAllowlists of DEKART_BIGQUERY_DATASETS for jobs with credentials of instance, comma separated patterns, and of
+// DEKART_BIGQUERY_CONNECTION_DATASETS, comma separated connection=patterns where patterns are separated by |
+func (c *Connections) setAllowlists(instance string, connections string) error {
+	c.allowlists = map[string]DatasetAllowlist{}
+	allowlist, err := parseDatasetAllowlist(instance, ",")
+	if err != nil {
+		return fmt.Errorf("DEKART_BIGQUERY_DATASETS: %w", err)
+	}
+	if len(allowlist) > 0 {
+		c.allowlists[""] = allowlist
+	}
+	if strings.TrimSpace(connections) == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(connections, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid DEKART_BIGQUERY_CONNECTION_DATASETS entry %q, expected connection=patterns", entry)
+		}
+		if !c.Has(parts[0]) {
+			return fmt.Errorf("DEKART_BIGQUERY_CONNECTION_DATASETS: unknown connection %s", parts[0])
+		}
+		allowlist, err := parseDatasetAllowlist(parts[1], "|")
+		if err != nil {
+			return fmt.Errorf("DEKART_BIGQUERY_CONNECTION_DATASETS: %w", err)
+		}
+		if len(allowlist) == 0 {
+			return fmt.Errorf("DEKART_BIGQUERY_CONNECTION_DATASETS: connection %s has no dataset patterns", parts[0])
+		}
+		c.allowlists[parts[0]] = allowlist
+	}
+	return nil
+}
+
+// Allowlist of datasets jobs of connection can reference, empty connection is credentials of instance
+func (c *Connections) Allowlist(connection string) DatasetAllowlist {
+	return c.allowlists[connection]
+}
+
+// checkTables referenced by query of connection, first table outside of allowlist is reported
+func (c *Connections) checkTables(connection string, tables []string) error {
+	allowlist := c.Allowlist(connection)
+	for _, table := range tables {
+		if !allowlist.allows(table) {
+			return &DatasetNotAllowedError{Table: table, Connection: connection}
+		}
+	}
+	return nil
+}
+
+// CheckTable of schema browser is in datasets allowed for connection
+func (s *Store) CheckTable(connection string, table string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.connections.checkTables(connection, []string{table})
+}
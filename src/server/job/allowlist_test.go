@@ -0,0 +1,98 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/grpc/codes"
+)
+
+func TestDatasetAllowlistPatterns(t *testing.T) {
+	allowlist, err := parseDatasetAllowlist("my-project.public_*| *.shared |example.com:p.geo", "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for table, expected := range map[string]bool{
+		"my-project.public_2021.trips": true,
+		"my-project.public.trips":      false,
+		"my-project.private.users":     false,
+		"other-project.shared.areas":   true,
+		"other-project.shared_v2.x":    false,
+		"example.com:p.geo.points":     true,
+		"points":                       false,
+	} {
+		if allowlist.allows(table) != expected {
+			t.Errorf("expected %s allowed %v", table, expected)
+		}
+	}
+	if !DatasetAllowlist(nil).allows("any.dataset.table") {
+		t.Error("expected empty allowlist to allow all tables")
+	}
+	for _, value := range []string{"[", "project"} {
+		if _, err := parseDatasetAllowlist(value, ","); err == nil {
+			t.Errorf("expected error of %q", value)
+		}
+	}
+}
+
+func TestSetAllowlists(t *testing.T) {
+	c, err := parseConnections("ci=/etc/dekart/ci.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setAllowlists("p.data", "ci=p.ci_*|q.shared"); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Allowlist("")) != 1 || len(c.Allowlist("ci")) != 2 {
+		t.Errorf("unexpected allowlists %v", c.allowlists)
+	}
+	for _, value := range []string{"other=p.data", "ci", "ci="} {
+		if err := c.setAllowlists("", value); err == nil {
+			t.Errorf("expected error of %q", value)
+		}
+	}
+}
+
+// allowlistJob of connection ci with dry run referencing tables
+func allowlistJob(t *testing.T, tables []string) *Job {
+	c, err := parseConnections("ci=/etc/dekart/ci.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setAllowlists("", "ci=p.reports"); err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore()
+	store.SetConnections(c)
+	job := store.New(context.Background(), "report", "query")
+	job.dryRun = func(ctx context.Context, query *bigquery.Query) (dryRunStats, error) {
+		return dryRunStats{bytes: 100, tables: tables}, nil
+	}
+	return job
+}
+
+func TestCheckDryRunAllowlist(t *testing.T) {
+	if err := allowlistJob(t, []string{"p.reports.daily"}).checkDryRun(nil, RunOptions{Connection: "ci"}); err != nil {
+		t.Errorf("expected table of allowed dataset, got %v", err)
+	}
+	// query text references only view p.reports.users_view, dry run reports table it reads
+	job := allowlistJob(t, []string{"p.reports.users_view", "p.private.users"})
+	err := job.checkDryRun(nil, RunOptions{Connection: "ci"})
+	var datasetErr *DatasetNotAllowedError
+	if !errors.As(err, &datasetErr) || datasetErr.Table != "p.private.users" || datasetErr.Connection != "ci" {
+		t.Fatalf("expected error of table read by view, got %v", err)
+	}
+	if code := datasetErr.GRPCStatus().Code(); code != codes.PermissionDenied {
+		t.Errorf("expected permission denied, got %s", code)
+	}
+	// instance credentials have no allowlist, dry run is not needed
+	job = allowlistJob(t, nil)
+	job.dryRun = func(ctx context.Context, query *bigquery.Query) (dryRunStats, error) {
+		return dryRunStats{}, errors.New("unexpected dry run")
+	}
+	if err := job.checkDryRun(nil, RunOptions{}); err != nil {
+		t.Errorf("expected no dry run without allowlist, got %v", err)
+	}
+}
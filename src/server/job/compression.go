@@ -0,0 +1,41 @@
+package job
+
+import (
+	"compress/gzip"
+	"dekart/src/proto"
+	"fmt"
+)
+
+// ValidateResultCompression of result file in format; only CSV is compressed, Parquet is compressed by its encoder
+func ValidateResultCompression(compression proto.ResultCompression, format proto.ResultFormat) error {
+	if _, ok := proto.ResultCompression_name[int32(compression)]; !ok {
+		return fmt.Errorf("unknown result_compression %d", compression)
+	}
+	if compression == proto.ResultCompression_RESULT_COMPRESSION_GZIP && format == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		return fmt.Errorf("result_compression applies only to CSV result")
+	}
+	return nil
+}
+
+// gzipWriter compresses result written to w; Close writes end of compressed stream before w is closed
+type gzipWriter struct {
+	*gzip.Writer
+	w resultWriter
+}
+
+func newGzipWriter(w resultWriter) *gzipWriter {
+	return &gzipWriter{Writer: gzip.NewWriter(w), w: w}
+}
+
+func (g *gzipWriter) Close() error {
+	if err := g.Writer.Close(); err != nil {
+		g.w.Close()
+		return err
+	}
+	return g.w.Close()
+}
+
+// compressed result file, object is stored with Content-Encoding gzip
+func (job *Job) compressed() bool {
+	return job.compression == proto.ResultCompression_RESULT_COMPRESSION_GZIP
+}
@@ -0,0 +1,48 @@
+package job
+
+import (
+	"compress/gzip"
+	"dekart/src/proto"
+	"io/ioutil"
+	"testing"
+)
+
+func TestValidateResultCompression(t *testing.T) {
+	if err := ValidateResultCompression(proto.ResultCompression_RESULT_COMPRESSION_GZIP, proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED); err != nil {
+		t.Errorf("expected gzip CSV valid, got %v", err)
+	}
+	if err := ValidateResultCompression(proto.ResultCompression_RESULT_COMPRESSION_GZIP, proto.ResultFormat_RESULT_FORMAT_PARQUET); err == nil {
+		t.Error("expected error of compressed Parquet")
+	}
+	if err := ValidateResultCompression(proto.ResultCompression(7), proto.ResultFormat_RESULT_FORMAT_CSV); err == nil {
+		t.Error("expected error of unknown compression")
+	}
+}
+
+func TestWriteResultGzip(t *testing.T) {
+	store := NewStore()
+	job := readingJob(store)
+	job.compression = proto.ResultCompression_RESULT_COMPRESSION_GZIP
+	job.totalRows = 2
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	job.writeResult(newFakeIterator(2), fakeSchema, w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	if !w.closed {
+		t.Error("expected result writer closed")
+	}
+	stored := int64(w.Len())
+	r, err := gzip.NewReader(&w.Buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if expected := "id,text\n0,some text value\n1,some text value\n"; err != nil || string(b) != expected {
+		t.Errorf("expected %q, got %q %v", expected, b, err)
+	}
+	snapshot := job.GetStatus()
+	// size is of stored object, like size of object in bucket
+	if snapshot.ResultCompression != proto.ResultCompression_RESULT_COMPRESSION_GZIP || snapshot.ResultSize != stored || snapshot.ResultMeta.Size != stored {
+		t.Errorf("expected gzip result of %d stored bytes, got %+v", stored, snapshot)
+	}
+}
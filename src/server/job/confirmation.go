@@ -0,0 +1,110 @@
+package job
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"fmt"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NeedsConfirmation is reason of error when estimate of query is above DEKART_CONFIRM_BYTES_THRESHOLD
+const NeedsConfirmation = "NEEDS_CONFIRMATION"
+
+// ConfirmationError when dry run estimates more bytes than confirmation threshold and run request
+// acknowledged fewer bytes; run is confirmed by running again with acknowledged bytes of estimate
+type ConfirmationError struct {
+	EstimatedBytes    int64
+	ThresholdBytes    int64
+	AcknowledgedBytes int64
+}
+
+func (e *ConfirmationError) Error() string {
+	return fmt.Sprintf(
+		"%s: query would process %d bytes, more than %d bytes which need confirmation; check filters of partitions and _TABLE_SUFFIX of wildcard tables or run again with acknowledged_bytes of at least %d",
+		NeedsConfirmation, e.EstimatedBytes, e.ThresholdBytes, e.EstimatedBytes,
+	)
+}
+
+// GRPCStatus of confirmation error is failed precondition with estimate in details
+func (e *ConfirmationError) GRPCStatus() *status.Status {
+	st := status.New(codes.FailedPrecondition, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: NeedsConfirmation,
+		Domain: "dekart",
+		Metadata: map[string]string{
+			"estimatedBytes":    strconv.FormatInt(e.EstimatedBytes, 10),
+			"thresholdBytes":    strconv.FormatInt(e.ThresholdBytes, 10),
+			"acknowledgedBytes": strconv.FormatInt(e.AcknowledgedBytes, 10),
+		},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// dryRunStats of query estimated by BigQuery
+type dryRunStats struct {
+	bytes int64
+	// tables referenced by query as project.dataset.table, tables read by views are included
+	tables []string
+}
+
+// dryRunQuery estimated by BigQuery, nothing is billed
+func dryRunQuery(ctx context.Context, query *bigquery.Query) (dryRunStats, error) {
+	query.DryRun = true
+	defer func() { query.DryRun = false }()
+	start := time.Now()
+	dryRun, err := query.Run(ctx)
+	metrics.Observe(metrics.BigQuery, "confirm_dry_run", start, err)
+	if err != nil {
+		return dryRunStats{}, err
+	}
+	stats := dryRun.LastStatus().Statistics
+	if stats == nil {
+		return dryRunStats{}, nil
+	}
+	result := dryRunStats{bytes: stats.TotalBytesProcessed}
+	if details, ok := stats.Details.(*bigquery.QueryStatistics); ok {
+		for _, t := range details.ReferencedTables {
+			if t != nil {
+				result.tables = append(result.tables, fmt.Sprintf("%s.%s.%s", t.ProjectID, t.DatasetID, t.TableID))
+			}
+		}
+	}
+	return result, nil
+}
+
+// checkDryRun of query before job is created, dry run is made when confirmation or allowlist of connection needs it.
+// Referenced tables of dry run are checked, so tables read by views are not hidden behind them
+func (job *Job) checkDryRun(query *bigquery.Query, options RunOptions) error {
+	allowlist := job.connections.Allowlist(options.Connection)
+	if job.confirmThreshold == 0 && len(allowlist) == 0 {
+		return nil
+	}
+	stats, err := job.dryRun(job.Ctx, query)
+	if err != nil {
+		return err
+	}
+	if err := job.connections.checkTables(options.Connection, stats.tables); err != nil {
+		job.logger.Info().Err(err).Msg("Job references table outside of allowed datasets")
+		return err
+	}
+	return job.confirmEstimate(stats.bytes, options.AcknowledgedBytes)
+}
+
+// confirmEstimate of query before job is created; estimate is checked on every run, so run acknowledged
+// with earlier estimate needs confirmation again when data grew above acknowledged bytes
+func (job *Job) confirmEstimate(estimated int64, acknowledged int64) error {
+	if job.confirmThreshold == 0 || estimated <= job.confirmThreshold || acknowledged >= estimated {
+		return nil
+	}
+	job.logger.Info().Int64("estimatedBytes", estimated).Int64("acknowledgedBytes", acknowledged).Msg("Job needs confirmation")
+	return &ConfirmationError{EstimatedBytes: estimated, ThresholdBytes: job.confirmThreshold, AcknowledgedBytes: acknowledged}
+}
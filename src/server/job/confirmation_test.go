@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// confirmingJob with dry run estimating bytes
+func confirmingJob(threshold int64, estimate *int64) *Job {
+	job := NewStore().New(context.Background(), "report", "query")
+	job.confirmThreshold = threshold
+	job.dryRun = func(ctx context.Context, query *bigquery.Query) (dryRunStats, error) {
+		return dryRunStats{bytes: *estimate}, nil
+	}
+	return job
+}
+
+func TestConfirmEstimateUnderThreshold(t *testing.T) {
+	estimate := int64(1000)
+	if err := confirmingJob(1000, &estimate).checkDryRun(nil, RunOptions{}); err != nil {
+		t.Errorf("expected run under threshold without confirmation, got %v", err)
+	}
+	job := confirmingJob(0, &estimate)
+	job.dryRun = func(ctx context.Context, query *bigquery.Query) (dryRunStats, error) {
+		return dryRunStats{}, errors.New("unexpected dry run")
+	}
+	if err := job.checkDryRun(nil, RunOptions{}); err != nil {
+		t.Errorf("expected no dry run when confirmation is disabled, got %v", err)
+	}
+}
+
+func TestConfirmEstimateRoundTrip(t *testing.T) {
+	estimate := int64(5000)
+	job := confirmingJob(1000, &estimate)
+	err := job.checkDryRun(nil, RunOptions{})
+	var confirmErr *ConfirmationError
+	if !errors.As(err, &confirmErr) || confirmErr.EstimatedBytes != 5000 || confirmErr.ThresholdBytes != 1000 {
+		t.Fatalf("expected confirmation error, got %v", err)
+	}
+	st := confirmErr.GRPCStatus()
+	if st.Code() != codes.FailedPrecondition || len(st.Details()) != 1 {
+		t.Fatalf("unexpected status %v", st)
+	}
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	if !ok || info.Reason != NeedsConfirmation || info.Metadata["estimatedBytes"] != "5000" {
+		t.Errorf("unexpected details %v", st.Details())
+	}
+	// run again with acknowledged estimate
+	if err := job.checkDryRun(nil, RunOptions{AcknowledgedBytes: confirmErr.EstimatedBytes}); err != nil {
+		t.Errorf("expected acknowledged run, got %v", err)
+	}
+}
+
+func TestConfirmEstimateGrew(t *testing.T) {
+	estimate := int64(5000)
+	job := confirmingJob(1000, &estimate)
+	// data grew between dry run shown to user and confirmed run
+	estimate = 8000
+	err := job.checkDryRun(nil, RunOptions{AcknowledgedBytes: 5000})
+	var confirmErr *ConfirmationError
+	if !errors.As(err, &confirmErr) || confirmErr.EstimatedBytes != 8000 || confirmErr.AcknowledgedBytes != 5000 {
+		t.Fatalf("expected confirmation of new estimate, got %v", err)
+	}
+	if err := job.checkDryRun(nil, RunOptions{AcknowledgedBytes: 8000}); err != nil {
+		t.Errorf("expected acknowledged run, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package job
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/rs/zerolog/log"
+)
+
+// ControlCharacters mode of C0 control characters in string cells, they break CSV readers like Excel and pandas
+type ControlCharacters string
+
+const (
+	// ControlCharactersReplace with U+FFFD, so position of removed character is visible
+	ControlCharactersReplace ControlCharacters = "replace"
+	// ControlCharactersStrip removes characters from cell
+	ControlCharactersStrip ControlCharacters = "strip"
+)
+
+// controlReplacement of replaced control character
+const controlReplacement = "\ufffd"
+
+// parseControlCharacters of DEKART_CONTROL_CHARACTERS, replace by default
+func parseControlCharacters(value string) (ControlCharacters, error) {
+	switch ControlCharacters(value) {
+	case "":
+		return ControlCharactersReplace, nil
+	case ControlCharactersReplace, ControlCharactersStrip:
+		return ControlCharacters(value), nil
+	}
+	return "", fmt.Errorf("invalid DEKART_CONTROL_CHARACTERS %s, expected replace or strip", value)
+}
+
+func controlCharacters() ControlCharacters {
+	mode, err := parseControlCharacters(os.Getenv("DEKART_CONTROL_CHARACTERS"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return mode
+}
+
+// controlByte is C0 control character other than tab and line breaks; bytes of multi-byte UTF-8
+// sequences are 0x80 and above, so string is scanned by bytes
+func controlByte(b byte) bool {
+	return b < 0x20 && b != '\t' && b != '\n' && b != '\r'
+}
+
+// sanitizeString without control characters, false when string is clean and returned as is
+func sanitizeString(s string, mode ControlCharacters) (string, bool) {
+	i := 0
+	for i < len(s) && !controlByte(s[i]) {
+		i++
+	}
+	if i == len(s) {
+		return s, false
+	}
+	var b strings.Builder
+	b.Grow(len(s) + len(controlReplacement))
+	b.WriteString(s[:i])
+	for ; i < len(s); i++ {
+		if !controlByte(s[i]) {
+			b.WriteByte(s[i])
+			continue
+		}
+		if mode != ControlCharactersStrip {
+			b.WriteString(controlReplacement)
+		}
+	}
+	return b.String(), true
+}
+
+// sanitizeRow replaces string cells of row in place, values of repeated and record columns included;
+// returns number of changed cells
+func sanitizeRow(row []bigquery.Value, mode ControlCharacters) int {
+	changed := 0
+	for i, v := range row {
+		switch value := v.(type) {
+		case string:
+			if s, ok := sanitizeString(value, mode); ok {
+				row[i] = s
+				changed++
+			}
+		case []bigquery.Value:
+			if sanitizeRow(value, mode) > 0 {
+				changed++
+			}
+		}
+	}
+	return changed
+}
+
+// warnControlCharacters of cells sanitized while result was written
+func (job *Job) warnControlCharacters(cells int64) {
+	if cells == 0 {
+		return
+	}
+	action := "replaced with U+FFFD"
+	if job.controlCharacters == ControlCharactersStrip {
+		action = "removed"
+	}
+	job.AddWarning(WarningControlCharacters, fmt.Sprintf("control characters in %d string cells were %s", cells, action))
+}
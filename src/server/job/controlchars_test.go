@@ -0,0 +1,90 @@
+package job
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestParseControlCharacters(t *testing.T) {
+	for value, expected := range map[string]ControlCharacters{
+		"":        ControlCharactersReplace,
+		"replace": ControlCharactersReplace,
+		"strip":   ControlCharactersStrip,
+	} {
+		mode, err := parseControlCharacters(value)
+		if err != nil || mode != expected {
+			t.Errorf("%q: expected %s, got %s %v", value, expected, mode, err)
+		}
+	}
+	if _, err := parseControlCharacters("drop"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestSanitizeString(t *testing.T) {
+	for _, c := range []struct {
+		value, replaced, stripped string
+	}{
+		{"clean", "clean", "clean"},
+		{"tab\tand\r\nlines", "tab\tand\r\nlines", "tab\tand\r\nlines"},
+		{"a\x00b", "a�b", "ab"},
+		{"\x00\x1f", "��", ""},
+		// control characters next to multi-byte characters keep them intact
+		{"ü\x00日本\x07😀", "ü�日本�😀", "ü日本😀"},
+		{"\x1bé", "�é", "é"},
+		// DEL and C1 characters are not C0
+		{"a\x7fb\u0085", "a\x7fb\u0085", "a\x7fb\u0085"},
+	} {
+		clean := c.value == c.replaced
+		for mode, expected := range map[ControlCharacters]string{
+			ControlCharactersReplace: c.replaced,
+			ControlCharactersStrip:   c.stripped,
+		} {
+			s, changed := sanitizeString(c.value, mode)
+			if s != expected || changed == clean {
+				t.Errorf("%q %s: expected %q, got %q changed=%v", c.value, mode, expected, s, changed)
+			}
+		}
+	}
+}
+
+func TestWriteResultControlCharacters(t *testing.T) {
+	store := NewStore()
+	job := readingJob(store)
+	job.totalRows = 3
+	statuses := collectStatus(job)
+	it := &fakeIterator{rows: [][]bigquery.Value{
+		{int64(1), "a\x00b"},
+		{int64(2), "clean"},
+		{int64(3), []bigquery.Value{"x", "y\x01"}},
+	}}
+	w := &fakeResultWriter{}
+	job.writeResult(it, fakeSchema, w, func() {})
+	<-statuses
+	if expected := "id,text\n1,a�b\n2,clean\n3,[x y�]\n"; w.String() != expected {
+		t.Errorf("expected %q, got %q", expected, w.String())
+	}
+	warnings := job.GetStatus().Warnings
+	if len(warnings) != 1 || warnings[0].Code != WarningControlCharacters || !strings.Contains(warnings[0].Message, "in 2 string cells") {
+		t.Errorf("unexpected warnings %v", warnings)
+	}
+}
+
+func BenchmarkSanitizeRow(b *testing.B) {
+	clean := []bigquery.Value{int64(1), "Brandenburger Tor, Pariser Platz, 10117 Berlin", 52.516, "Straße des 17. Juni"}
+	dirty := []bigquery.Value{int64(1), "Brandenburger Tor,\x00Pariser Platz, 10117 Berlin", 52.516, "Straße des 17. Juni"}
+	b.Run("clean", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sanitizeRow(clean, ControlCharactersReplace)
+		}
+	})
+	b.Run("dirty", func(b *testing.B) {
+		row := make([]bigquery.Value, len(dirty))
+		for i := 0; i < b.N; i++ {
+			copy(row, dirty)
+			sanitizeRow(row, ControlCharactersReplace)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+// CountSkippedError when dry run of count query estimates more bytes than limit, count is not run
+type CountSkippedError struct {
+	EstimatedBytes int64
+	MaxBytes       int64
+}
+
+func (e *CountSkippedError) Error() string {
+	return fmt.Sprintf("count query would process %d bytes, more than limit of %d bytes", e.EstimatedBytes, e.MaxBytes)
+}
+
+// countQuery of all rows of query text, query is kept on own lines like sampled query
+func countQuery(queryText string) (string, error) {
+	trimmed, code, err := trimStatement(queryText)
+	if err != nil {
+		return "", fmt.Errorf("cannot count rows of query: %w", err)
+	}
+	if strings.Contains(code, ";") || !queryStartRe.MatchString(code) {
+		return "", fmt.Errorf("cannot count rows of query which is not single query statement")
+	}
+	return fmt.Sprintf("SELECT COUNT(*) FROM (\n%s\n)", trimmed), nil
+}
+
+// Truncated when result of done job is sampled or wrapped in exploration LIMIT, total rows of result are not rows of query
+func (job *Job) Truncated() bool {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.state == StateDone && job.destinationTable == "" && (job.sampleRate > 0 || job.explorationLimit > 0)
+}
+
+// CountRows of query run by parent job, without sampling and exploration LIMIT. Count runs as internal job of store,
+// it's cancelled with jobs of report, but not as job of query, and its statuses are not sent to clients.
+// When maxBytes is set, count is skipped with CountSkippedError if dry run estimates more bytes
+func (s *Store) CountRows(reqCtx context.Context, parent *Job, maxBytes int64) (int64, error) {
+	parent.mutex.Lock()
+	queryText, options := parent.sourceQueryText, parent.runOptions
+	parent.mutex.Unlock()
+	job := s.newInternal(reqCtx, parent.ReportID, parent.QueryID)
+	job.UserEmail = parent.UserEmail
+	// cancellation of query sends status of internal job, nobody else receives it
+	go func() {
+		for {
+			select {
+			case <-job.Status:
+			case <-job.Ctx.Done():
+				return
+			}
+		}
+	}()
+	count, err := job.count(queryText, options, maxBytes)
+	if err != nil {
+		job.transition(StateFailed, false, func() {
+			job.err = err.Error()
+		})
+	}
+	job.finish()
+	return count, err
+}
+
+// count rows of query text run with options
+func (job *Job) count(queryText string, options RunOptions, maxBytes int64) (int64, error) {
+	countText, err := countQuery(queryText)
+	if err != nil {
+		return 0, err
+	}
+	client, _, err := job.bigqueryClient(options)
+	if err != nil {
+		return 0, err
+	}
+	defaultDataset := job.projects.defaultDataset(options.DefaultDataset)
+	query := client.Query(countText)
+	query.Labels = map[string]string{queryHashLabel: QueryHash(countText)}
+	applyDialect(&query.QueryConfig, options.UseLegacySQL)
+	query.Parameters = options.Parameters
+	if err := applyDefaultDataset(&query.QueryConfig, defaultDataset); err != nil {
+		return 0, err
+	}
+	if maxBytes > 0 {
+		query.DryRun = true
+		start := time.Now()
+		dryRun, err := query.Run(job.Ctx)
+		metrics.Observe(metrics.BigQuery, "count_dry_run", start, err)
+		if err != nil {
+			return 0, err
+		}
+		if stats := dryRun.LastStatus().Statistics; stats != nil && stats.TotalBytesProcessed > maxBytes {
+			return 0, &CountSkippedError{EstimatedBytes: stats.TotalBytesProcessed, MaxBytes: maxBytes}
+		}
+		query.DryRun = false
+	}
+	start := time.Now()
+	bigqueryJob, err := query.Run(job.Ctx)
+	metrics.Observe(metrics.BigQuery, "job_create", start, err)
+	if err != nil {
+		return 0, err
+	}
+	if !job.transition(StateRunning, false, func() {
+		job.bigqueryJob = bigqueryJob
+		job.queryText = countText
+		job.queryHash = QueryHash(countText)
+		job.defaultDataset = defaultDataset
+		job.startedAt = job.now()
+	}) {
+		return 0, ErrCancelled
+	}
+	job.setPhase(PhaseWaitingBigQuery)
+	waitCtx, cancelWait := job.startQueryPhase()
+	queryStatus, err := bigqueryJob.Wait(waitCtx)
+	cancelWait()
+	if err == nil {
+		err = queryStatus.Err()
+	} else if timeout, ok := job.timeoutError(err).(*TimeoutError); ok {
+		stopBigqueryJob(bigqueryJob, job.logger)
+		err = timeout
+	}
+	if err != nil {
+		return 0, err
+	}
+	job.markWaited()
+	job.setPhase(PhaseReading)
+	it, err := bigqueryJob.Read(job.Ctx)
+	if err != nil {
+		return 0, err
+	}
+	job.setJobStats(queryStatus, it.TotalRows)
+	var row []bigquery.Value
+	if err := it.Next(&row); err != nil {
+		if err == iterator.Done {
+			return 0, fmt.Errorf("count query returned no rows")
+		}
+		return 0, err
+	}
+	if len(row) != 1 {
+		return 0, fmt.Errorf("unexpected count %v", row)
+	}
+	count, ok := row[0].(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count %v", row)
+	}
+	job.transition(StateDone, false, func() {
+		job.rowsWritten = 1
+	})
+	job.logger.Info().Int64("count", count).Msg("Count job done")
+	return count, nil
+}
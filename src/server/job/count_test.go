@@ -0,0 +1,156 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+// fakeCountEndpoint of BigQuery runs count jobs which are done at once and return count;
+// dry run processes estimated bytes. Returns executed query texts, dry runs excluded
+func fakeCountEndpoint(t *testing.T, estimated int64, count string) *[]string {
+	var executed []string
+	var mutex sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reference := map[string]string{"projectId": "data-project", "jobId": "count", "location": "US"}
+		done := map[string]string{"state": "DONE"}
+		var res interface{}
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/jobs"):
+			job := map[string]interface{}{}
+			if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+				t.Error(err)
+			}
+			configuration := job["configuration"].(map[string]interface{})
+			query := configuration["query"].(map[string]interface{})
+			if configuration["dryRun"] != true {
+				mutex.Lock()
+				executed = append(executed, query["query"].(string))
+				mutex.Unlock()
+			}
+			query["destinationTable"] = map[string]string{"projectId": "data-project", "datasetId": "anon", "tableId": "count"}
+			job["jobReference"] = reference
+			job["status"] = done
+			job["statistics"] = map[string]interface{}{"totalBytesProcessed": strconv.FormatInt(estimated, 10), "query": map[string]interface{}{}}
+			res = job
+		case strings.Contains(r.URL.Path, "/jobs/"):
+			res = map[string]interface{}{"jobReference": reference, "status": done}
+		case strings.Contains(r.URL.Path, "/queries/"):
+			res = map[string]interface{}{
+				"jobComplete": true,
+				"totalRows":   "1",
+				"schema":      map[string]interface{}{"fields": []map[string]string{{"name": "f0_", "type": "INTEGER"}}},
+			}
+		case strings.HasSuffix(r.URL.Path, "/data"):
+			res = map[string]interface{}{"totalRows": "1", "rows": []interface{}{map[string]interface{}{"f": []map[string]string{{"v": count}}}}}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+	newClient := newBigqueryClient
+	t.Cleanup(func() { newBigqueryClient = newClient })
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	t.Cleanup(func() { os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID") })
+	return &executed
+}
+
+func TestCountQuery(t *testing.T) {
+	countText, err := countQuery("select * from points;\n")
+	if expected := "SELECT COUNT(*) FROM (\nselect * from points\n)"; err != nil || countText != expected {
+		t.Errorf("expected %q, got %q %v", expected, countText, err)
+	}
+	if _, err := countQuery("insert into points select 1"); err == nil {
+		t.Error("expected error of statement which is not query")
+	}
+	if _, err := countQuery("select 'a"); err == nil || err.Error() != "cannot count rows of query: unterminated '" {
+		t.Errorf("expected error of counting, got %v", err)
+	}
+}
+
+func TestCountRows(t *testing.T) {
+	executed := fakeCountEndpoint(t, 1000, "12345")
+	store := NewStore()
+	parent := store.New(context.Background(), "report", "query")
+	parent.sourceQueryText = "select * from points"
+	parent.runOptions = RunOptions{SampleRate: 0.1, ExplorationLimit: 10}
+	count, err := store.CountRows(context.Background(), parent, 1000)
+	if err != nil || count != 12345 {
+		t.Fatalf("expected 12345 rows, got %d %v", count, err)
+	}
+	if len(*executed) != 1 || (*executed)[0] != "SELECT COUNT(*) FROM (\nselect * from points\n)" {
+		t.Errorf("expected count of query without sampling and LIMIT, got %q", *executed)
+	}
+	// count job is removed from store, parent is not finished by it
+	if jobs := store.Snapshot(); len(jobs) != 1 || jobs[0].ID != parent.ID {
+		t.Errorf("expected only parent job in store, got %+v", jobs)
+	}
+}
+
+func TestCountJobNotJobOfQuery(t *testing.T) {
+	store := NewStore()
+	parent := store.New(context.Background(), "report", "query")
+	go drainStatus(parent)
+	count := store.newInternal(context.Background(), "report", "query")
+	go drainStatus(count)
+	// count job shares query of parent, but it is not job of query
+	if superseded := store.Supersede("query", "next"); superseded != 1 || count.GetState() != StatePending {
+		t.Errorf("expected only parent superseded, got %d %s", superseded, count.GetState())
+	}
+	store.Cancel("query", proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	if count.GetState() != StatePending {
+		t.Errorf("expected count job not cancelled, got %s", count.GetState())
+	}
+	// count is cancelled with report
+	if cancelled := store.CancelByReport("report", proto.CancelReason_CANCEL_REASON_USER_REQUEST); cancelled != 1 || count.GetState() != StateCancelled {
+		t.Errorf("expected count job cancelled with report, got %d %s", cancelled, count.GetState())
+	}
+}
+
+func TestCountRowsSkipped(t *testing.T) {
+	executed := fakeCountEndpoint(t, 1001, "1")
+	store := NewStore()
+	parent := store.New(context.Background(), "report", "query")
+	parent.sourceQueryText = "select * from points"
+	_, err := store.CountRows(context.Background(), parent, 1000)
+	if skipped, ok := err.(*CountSkippedError); !ok || skipped.EstimatedBytes != 1001 {
+		t.Fatalf("expected count skipped, got %v", err)
+	}
+	if len(*executed) != 0 {
+		t.Errorf("expected only dry run, got %q", *executed)
+	}
+	if jobs := store.Snapshot(); len(jobs) != 1 {
+		t.Errorf("expected only parent job in store, got %+v", jobs)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	job := NewStore().New(context.Background(), "report", "query")
+	job.state = StateDone
+	if job.Truncated() {
+		t.Error("expected result of all rows")
+	}
+	job.explorationLimit = 10
+	if !job.Truncated() {
+		t.Error("expected limited result truncated")
+	}
+	job.state = StateReading
+	if job.Truncated() {
+		t.Error("expected result which is being read not truncated")
+	}
+}
@@ -0,0 +1,115 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+)
+
+// ResultConflict is prefix of error when result object exists with other content
+const ResultConflict = "RESULT_CONFLICT"
+
+// ResultConflictError when result object already exists and its checksum doesn't match result written by job;
+// existing object is kept
+type ResultConflictError struct {
+	Object string
+}
+
+func (e *ResultConflictError) Error() string {
+	return fmt.Sprintf("%s: result object %s already exists with other content, it was not replaced", ResultConflict, e.Object)
+}
+
+// preconditionFailed when object of create-only writer or compose already exists
+func preconditionFailed(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed
+}
+
+// createOnlyWriter of object which is created only when it doesn't exist, so object is never overwritten;
+// existing object is kept as written when its CRC32C and size match bytes written
+type createOnlyWriter struct {
+	ctx  context.Context
+	obj  *storage.ObjectHandle
+	w    *storage.Writer
+	crc  hash.Hash32
+	size int64
+	// created with generation of object, called only when this writer created object; matching existing object
+	// is kept without it, so it's not removed as partial result of writer which didn't create it
+	created func(generation int64)
+}
+
+func newCreateOnlyWriter(ctx context.Context, obj *storage.ObjectHandle, created func(generation int64)) *createOnlyWriter {
+	return &createOnlyWriter{
+		ctx: ctx,
+		obj: obj,
+		// GCS checks precondition, so concurrent writers of same object don't replace each other either
+		w:       obj.If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx),
+		crc:     crc32.New(crc32.MakeTable(crc32.Castagnoli)),
+		created: created,
+	}
+}
+
+func (w *createOnlyWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.crc.Write(p[:n])
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *createOnlyWriter) Close() error {
+	err := w.w.Close()
+	if err == nil {
+		w.setCreated(w.w.Attrs().Generation)
+		return nil
+	}
+	if !preconditionFailed(err) {
+		return err
+	}
+	attrs, attrsErr := w.obj.Attrs(w.ctx)
+	if attrsErr != nil {
+		return fmt.Errorf("%s; cannot read existing object: %s", err, attrsErr)
+	}
+	if attrs.Size != w.size || attrs.CRC32C != w.crc.Sum32() {
+		return &ResultConflictError{Object: w.obj.ObjectName()}
+	}
+	// same bytes were written before, for example by retried close
+	return nil
+}
+
+func (w *createOnlyWriter) setCreated(generation int64) {
+	if w.created != nil {
+		w.created(generation)
+	}
+}
+
+// setResultGeneration of result object created by job, deleteResult removes only this generation
+func (job *Job) setResultGeneration(generation int64) {
+	job.mutex.Lock()
+	job.resultGeneration = generation
+	job.mutex.Unlock()
+}
+
+// deleteResult removes partial result which could be already uploaded by job;
+// result object of other writer is not removed
+func (job *Job) deleteResult() {
+	job.mutex.Lock()
+	generation := job.resultGeneration
+	job.mutex.Unlock()
+	if generation == 0 {
+		// object is created when upload is closed, job didn't create it
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := job.storageObj.If(storage.Conditions{GenerationMatch: generation}).Delete(ctx)
+	if err != nil && err != storage.ErrObjectNotExist && !preconditionFailed(err) {
+		job.logger.Warn().Err(err).Msg("Cannot delete partial result")
+	}
+}
@@ -0,0 +1,174 @@
+package job
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// fakeGCS emulates JSON API of bucket with objects in memory; preconditions of generation are checked like by GCS
+type fakeGCS struct {
+	mutex      sync.Mutex
+	objects    map[string][]byte
+	generation map[string]int64
+	next       int64
+}
+
+func (f *fakeGCS) resource(w http.ResponseWriter, name string) {
+	data := f.objects[name]
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli)))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"bucket":     "results",
+		"name":       name,
+		"generation": strconv.FormatInt(f.generation[name], 10),
+		"size":       strconv.Itoa(len(data)),
+		"crc32c":     base64.StdEncoding.EncodeToString(crc),
+	})
+}
+
+func (f *fakeGCS) error(w http.ResponseWriter, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"code": code, "message": http.StatusText(code)}})
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/b/results/o"), "/")
+	if r.Method == http.MethodPost {
+		name = r.URL.Query().Get("name")
+	}
+	generation, exists := f.generation[name]
+	if match := r.URL.Query().Get("ifGenerationMatch"); match != "" && match != strconv.FormatInt(generation, 10) {
+		f.error(w, http.StatusPreconditionFailed)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			f.error(w, http.StatusBadRequest)
+			return
+		}
+		parts := multipart.NewReader(r.Body, params["boundary"])
+		// metadata is followed by media
+		if _, err := parts.NextPart(); err != nil {
+			f.error(w, http.StatusBadRequest)
+			return
+		}
+		media, err := parts.NextPart()
+		if err != nil {
+			f.error(w, http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(media)
+		f.next++
+		f.objects[name] = data
+		f.generation[name] = f.next
+		f.resource(w, name)
+	case http.MethodGet:
+		if !exists {
+			f.error(w, http.StatusNotFound)
+			return
+		}
+		f.resource(w, name)
+	case http.MethodDelete:
+		if !exists {
+			f.error(w, http.StatusNotFound)
+			return
+		}
+		delete(f.objects, name)
+		delete(f.generation, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// newFakeGCS bucket results of client
+func newFakeGCS(t *testing.T) (*fakeGCS, *storage.BucketHandle) {
+	fake := &fakeGCS{objects: map[string][]byte{}, generation: map[string]int64{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	client, err := storage.NewClient(context.Background(), option.WithoutAuthentication(), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return fake, client.Bucket("results")
+}
+
+func writeObject(job *Job, data string) error {
+	w := job.newStorageWriter(context.Background(), job.storageObj, job.setResultGeneration)
+	if _, err := w.Write([]byte(data)); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func TestCreateOnlyResult(t *testing.T) {
+	fake, bucket := newFakeGCS(t)
+	job := NewStore().New(context.Background(), "report", "query")
+	job.storageObj = bucket.Object("job.csv")
+	if err := writeObject(job, "id\n1\n"); err != nil {
+		t.Fatal(err)
+	}
+	generation := fake.generation["job.csv"]
+	if string(fake.objects["job.csv"]) != "id\n1\n" || job.resultGeneration != generation {
+		t.Fatalf("expected object created with generation %d, got %q %d", generation, fake.objects["job.csv"], job.resultGeneration)
+	}
+	// retried close of same result keeps existing object as result
+	if err := writeObject(job, "id\n1\n"); err != nil {
+		t.Errorf("expected existing object with same checksum kept, got %v", err)
+	}
+	// other result doesn't replace existing object
+	err := writeObject(job, "id\n2\n")
+	var conflictErr *ResultConflictError
+	if !errors.As(err, &conflictErr) || !strings.HasPrefix(err.Error(), ResultConflict) {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+	if string(fake.objects["job.csv"]) != "id\n1\n" || fake.generation["job.csv"] != generation {
+		t.Errorf("expected existing object kept, got %q", fake.objects["job.csv"])
+	}
+}
+
+func TestDeleteResultOfJobOnly(t *testing.T) {
+	fake, bucket := newFakeGCS(t)
+	other := NewStore().New(context.Background(), "report", "query")
+	other.storageObj = bucket.Object("job.csv")
+	if err := writeObject(other, "id\n1\n"); err != nil {
+		t.Fatal(err)
+	}
+	// job which didn't create object doesn't remove it, even when it wrote same bytes
+	job := NewStore().New(context.Background(), "report", "query")
+	job.storageObj = bucket.Object("job.csv")
+	if err := writeObject(job, "id\n1\n"); err != nil || job.resultGeneration != 0 {
+		t.Fatalf("expected matching object kept without generation of job, got %d %v", job.resultGeneration, err)
+	}
+	job.deleteResult()
+	job.setResultGeneration(fake.generation["job.csv"] + 1)
+	job.deleteResult()
+	if _, ok := fake.objects["job.csv"]; !ok {
+		t.Fatal("expected object of other job kept")
+	}
+	other.deleteResult()
+	if _, ok := fake.objects["job.csv"]; ok {
+		t.Error("expected object removed by job which created it")
+	}
+}
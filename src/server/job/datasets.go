@@ -0,0 +1,74 @@
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxDatasetNameLength fits name column of MySQL
+const maxDatasetNameLength = 64
+
+// datasetRe matches {{dataset:name}} reference of catalog dataset, variables use {{name}} without prefix
+var datasetRe = regexp.MustCompile(`{{\s*dataset:\s*([^{}]*?)\s*}}`)
+
+// UnknownDatasetsError when query text references datasets missing in catalog
+type UnknownDatasetsError struct {
+	// Names of datasets in order of first reference
+	Names []string
+}
+
+func (e *UnknownDatasetsError) Error() string {
+	references := make([]string, len(e.Names))
+	for i, name := range e.Names {
+		references[i] = "{{dataset:" + name + "}}"
+	}
+	return fmt.Sprintf("unknown datasets %s, datasets were deleted or renamed", strings.Join(references, ", "))
+}
+
+// DatasetReferences of query text, names in order of first reference
+func DatasetReferences(queryText string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, match := range datasetRe.FindAllStringSubmatch(queryText, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// ValidateCatalogDataset name and query text; datasets can't reference other datasets, so expansion is not recursive
+func ValidateCatalogDataset(name string, queryText string) error {
+	if len(name) > maxDatasetNameLength || !variableNameRe.MatchString(name) {
+		return fmt.Errorf("invalid dataset name %q, expected at most %d letters, digits and _", name, maxDatasetNameLength)
+	}
+	if strings.TrimSpace(queryText) == "" {
+		return fmt.Errorf("dataset %s has no query text", name)
+	}
+	if references := DatasetReferences(queryText); len(references) > 0 {
+		return fmt.Errorf("dataset %s references dataset %s, datasets can't reference other datasets", name, references[0])
+	}
+	return nil
+}
+
+// ExpandDatasets references in query text into subqueries of dataset query text by name, so reference is used
+// as table: select * from {{dataset:trips}} where ... Missing datasets are reported with UnknownDatasetsError
+func ExpandDatasets(queryText string, datasets map[string]string) (string, error) {
+	unknown := &UnknownDatasetsError{}
+	for _, name := range DatasetReferences(queryText) {
+		if _, ok := datasets[name]; !ok {
+			unknown.Names = append(unknown.Names, name)
+		}
+	}
+	if len(unknown.Names) > 0 {
+		return "", unknown
+	}
+	return datasetRe.ReplaceAllStringFunc(queryText, func(reference string) string {
+		name := datasetRe.FindStringSubmatch(reference)[1]
+		// trailing semicolon ends statement, it's not valid inside subquery
+		text := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(datasets[name]), ";"))
+		return "(\n" + text + "\n)"
+	}), nil
+}
@@ -0,0 +1,48 @@
+package job
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExpandDatasets(t *testing.T) {
+	datasets := map[string]string{
+		"trips":  "select * from trips where city = 'Berlin';\n",
+		"cities": "select * from cities -- all cities",
+	}
+	expanded, err := ExpandDatasets("select * from {{dataset:trips}} t join {{ dataset: cities }} c using (city) where t.day = {{day}}", datasets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "select * from (\nselect * from trips where city = 'Berlin'\n) t join (\nselect * from cities -- all cities\n) c using (city) where t.day = {{day}}"
+	if expanded != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, expanded)
+	}
+}
+
+func TestExpandDatasetsUnknown(t *testing.T) {
+	_, err := ExpandDatasets("select * from {{dataset:gone}} join {{dataset:trips}} join {{dataset:gone}}", map[string]string{"trips": "select 1"})
+	var unknown *UnknownDatasetsError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownDatasetsError, got %v", err)
+	}
+	if !reflect.DeepEqual(unknown.Names, []string{"gone"}) {
+		t.Errorf("unexpected names %v", unknown.Names)
+	}
+}
+
+func TestValidateCatalogDataset(t *testing.T) {
+	if err := ValidateCatalogDataset("trips_2021", "select 1"); err != nil {
+		t.Error(err)
+	}
+	for name, c := range map[string][2]string{
+		"invalid name": {"trips-2021", "select 1"},
+		"empty query":  {"trips", " \n"},
+		"nested":       {"trips", "select * from {{dataset:cities}}"},
+	} {
+		if err := ValidateCatalogDataset(c[0], c[1]); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// namedParameterRe matches @name of named query parameter, @@name of system variable is not parameter
+var namedParameterRe = regexp.MustCompile(`(^|[^@\w])@([A-Za-z_]\w*)`)
+
+// ValidateDialect of query text run in legacy SQL; legacy SQL has no named parameters and no TABLESAMPLE used for sampling.
+// Query text of standard SQL is validated by BigQuery
+func ValidateDialect(queryText string, useLegacySQL bool, sampleRate float64) error {
+	if !useLegacySQL {
+		return nil
+	}
+	if sampleRate > 0 {
+		return fmt.Errorf("sampling is supported only in standard SQL, turn off legacy SQL or run query on all rows")
+	}
+	code, err := sqlCode(queryText)
+	if err != nil {
+		return fmt.Errorf("cannot check named parameters of legacy SQL: %w", err)
+	}
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range namedParameterRe.FindAllStringSubmatch(code, -1) {
+		if !seen[m[2]] {
+			seen[m[2]] = true
+			names = append(names, "@"+m[2])
+		}
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("named parameters %s are not supported in legacy SQL, turn off legacy SQL to use them", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// applyDialect to query config, standard SQL is default of client
+func applyDialect(config *bigquery.QueryConfig, useLegacySQL bool) {
+	config.UseLegacySQL = useLegacySQL
+	config.UseStandardSQL = false
+}
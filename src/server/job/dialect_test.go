@@ -0,0 +1,81 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"os"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+func TestValidateDialect(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		queryText  string
+		legacy     bool
+		sampleRate float64
+		err        string
+	}{
+		{"standard parameters", "select * from points where id = @id", false, 0, ""},
+		{"legacy", "select * from [data-project:team.points@-3600000]", true, 0, ""},
+		{"legacy parameters", "select * from points where id = @id and name = @name or id = @id", true, 0, "named parameters @id, @name are not supported"},
+		{"parameter in literal and comment", "select '@id', x from points -- @id\n", true, 0, ""},
+		{"system variable", "select @@project_id", true, 0, ""},
+		{"legacy sampled", "select * from points", true, 0.5, "sampling is supported only in standard SQL"},
+		{"legacy unterminated", "select * from points /* @id", true, 0, "cannot check named parameters of legacy SQL: unterminated comment"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateDialect(c.queryText, c.legacy, c.sampleRate)
+			if c.err == "" && err != nil {
+				t.Errorf("expected valid query, got %v", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Errorf("expected %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestRunDialect(t *testing.T) {
+	server, inserted := fakeJobsEndpoint(t)
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	for _, legacy := range []bool{false, true} {
+		*inserted = nil
+		job := NewStore().New(context.Background(), "report", "query")
+		go drainStatus(job)
+		if err := job.Run("select * from [data-project:team.points]", RunOptions{UseLegacySQL: legacy}, nil); err != nil {
+			t.Fatal(err)
+		}
+		if snapshot := job.GetStatus(); snapshot.UseLegacySQL != legacy {
+			t.Errorf("expected legacy SQL %v recorded, got %v", legacy, snapshot.UseLegacySQL)
+		}
+		job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+		if len(*inserted) != 1 {
+			t.Fatalf("expected one job inserted, got %d", len(*inserted))
+		}
+		query := (*inserted)[0]["configuration"].(map[string]interface{})["query"].(map[string]interface{})
+		if query["useLegacySql"] != legacy {
+			t.Errorf("expected useLegacySql %v, got %v", legacy, query["useLegacySql"])
+		}
+	}
+
+	// named parameters fail before BigQuery job is created
+	*inserted = nil
+	job := NewStore().New(context.Background(), "report", "query")
+	go drainStatus(job)
+	if err := job.Run("select * from points where id = @id", RunOptions{UseLegacySQL: true}, nil); err == nil {
+		t.Fatal("expected dialect error")
+	}
+	if len(*inserted) != 0 {
+		t.Errorf("expected no job inserted, got %d", len(*inserted))
+	}
+}
@@ -0,0 +1,33 @@
+// Package job runs BigQuery queries, or queries of SQL connections of database/sql drivers, and writes their results
+// to Cloud Storage objects or destination tables.
+//
+// Store is created with NewStore; DEKART_* environment is read once there and StoreOption values
+// override it, so jobs and methods of store don't read environment after it. Callers resolve billing project,
+// default dataset and dataset.table names with BillingProject, DefaultDataset and QualifyTable of store instead
+// of DEKART_BIGQUERY_* variables. Dekart server is one consumer of the package, other programs create own store:
+//
+//	store := job.NewStore(job.WithRunner(newClient), job.WithStorage(bucket), job.WithTimeout(timeouts))
+//	j := store.New(ctx, reportID, queryID)
+//	done := make(chan job.JobSummary)
+//	go func() { done <- j.Wait() }()
+//	if err := j.Run(queryText, job.RunOptions{}, bucket.Object(name)); err != nil {
+//		return err
+//	}
+//	summary := <-done
+//
+// # Concurrency
+//
+// Store and Job methods are safe for concurrent use. Jobs copy settings of store when they are created,
+// so SetTimeouts and Use* methods of store change only jobs created after them. Status channel of job is
+// unbuffered and job waits until each status is received: exactly one goroutine receives from Status until
+// Ctx of job is done, Wait does it for callers which need only JobSummary. Run sends status when BigQuery job
+// is created, so receiver is started before Run, otherwise Run blocks. Progress never blocks job.
+// Hooks registered with OnJobComplete run in own goroutines after job finished.
+//
+// # Compatibility
+//
+// Exported API of package follows semantic versioning of dekart releases: fields, options and methods are
+// added in minor releases and removed or changed only in major releases. NewStore without options keeps
+// configuration by environment, Job.UseParallelUpload and Job.UseExtract are kept for callers which set bucket
+// for each job.
+package job
@@ -0,0 +1,247 @@
+package job
+
+// errorMappingsJSON of job errors to explanations, first matching entry explains error, so specific entries
+// precede general entries of same reason. Entry matches when its code, reason and pattern match error, fields
+// which are not set match any error; pattern is case insensitive regular expression of error message.
+const errorMappingsJSON = `[
+	{
+		"id": "location-mismatch",
+		"reason": "notFound",
+		"pattern": "was not found in location",
+		"summary": "Table or dataset exists, but not in location where query ran.",
+		"fix": "Query only tables of one location; set location of billing project or move datasets so they are in same region.",
+		"docs": "https://cloud.google.com/bigquery/docs/locations"
+	},
+	{
+		"id": "table-not-found",
+		"reason": "notFound",
+		"pattern": "not found: table",
+		"summary": "Query references table which doesn't exist.",
+		"fix": "Check project.dataset.table name and default dataset of report; tables are case sensitive.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "dataset-not-found",
+		"reason": "notFound",
+		"pattern": "not found: dataset",
+		"summary": "Query references dataset which doesn't exist or isn't visible to credentials.",
+		"fix": "Check dataset name and project; unqualified dataset is read from billing project.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "job-not-found",
+		"reason": "notFound",
+		"pattern": "not found: job",
+		"summary": "BigQuery job expired or was created in other location.",
+		"fix": "Run query again.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "not-found",
+		"reason": "notFound",
+		"summary": "Resource referenced by query doesn't exist.",
+		"fix": "Check names of tables, views and functions in query.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "billing-disabled",
+		"pattern": "billing (has not been|is not) enabled|billing account",
+		"summary": "Billing project has no active billing account.",
+		"fix": "Enable billing of project or set other billing project for report.",
+		"docs": "https://cloud.google.com/billing/docs/how-to/modify-project"
+	},
+	{
+		"id": "missing-jobs-create",
+		"pattern": "bigquery\\.jobs\\.create",
+		"summary": "Credentials can't create query jobs in billing project.",
+		"fix": "Grant BigQuery Job User role on billing project or set other billing project for report.",
+		"docs": "https://cloud.google.com/bigquery/docs/access-control"
+	},
+	{
+		"id": "missing-get-data",
+		"pattern": "bigquery\\.tables\\.getData",
+		"summary": "Credentials can't read data of table.",
+		"fix": "Grant BigQuery Data Viewer role on dataset or table.",
+		"docs": "https://cloud.google.com/bigquery/docs/access-control"
+	},
+	{
+		"id": "dataset-not-allowed",
+		"pattern": "^DATASET_NOT_ALLOWED",
+		"summary": "Query references table outside of datasets approved for dekart.",
+		"fix": "Query approved datasets only or ask administrator to add dataset to allowlist.",
+		"docs": ""
+	},
+	{
+		"id": "access-denied",
+		"reason": "accessDenied",
+		"summary": "Credentials of query are not allowed to access resource.",
+		"fix": "Ask owner of dataset to grant access to service account of dekart or connection.",
+		"docs": "https://cloud.google.com/bigquery/docs/access-control"
+	},
+	{
+		"id": "access-denied",
+		"code": 403,
+		"summary": "Credentials of query are not allowed to access resource.",
+		"fix": "Ask owner of dataset to grant access to service account of dekart or connection.",
+		"docs": "https://cloud.google.com/bigquery/docs/access-control"
+	},
+	{
+		"id": "credentials-expired",
+		"pattern": "invalid_grant|token (has been )?expired|cannot get credentials of connection",
+		"summary": "Credentials of dekart or connection are invalid or expired.",
+		"fix": "Renew credentials of service account or workload identity configuration of connection.",
+		"docs": "https://cloud.google.com/iam/docs/workload-identity-federation"
+	},
+	{
+		"id": "concurrent-queries",
+		"reason": "quotaExceeded",
+		"pattern": "concurrent",
+		"summary": "Project runs too many interactive queries at once.",
+		"fix": "Run query again when other queries finished or cancel unused queries of report.",
+		"docs": "https://cloud.google.com/bigquery/quotas"
+	},
+	{
+		"id": "custom-quota",
+		"reason": "quotaExceeded",
+		"pattern": "custom quota",
+		"summary": "Daily custom quota of bytes processed is used up.",
+		"fix": "Wait until quota resets at midnight Pacific Time or ask administrator to raise custom quota.",
+		"docs": "https://cloud.google.com/bigquery/docs/custom-quotas"
+	},
+	{
+		"id": "quota-exceeded",
+		"reason": "quotaExceeded",
+		"summary": "BigQuery quota of project is exceeded.",
+		"fix": "Wait and run query again, or ask administrator to raise quota.",
+		"docs": "https://cloud.google.com/bigquery/quotas"
+	},
+	{
+		"id": "rate-limit",
+		"reason": "rateLimitExceeded",
+		"summary": "Too many requests were sent to BigQuery in short time.",
+		"fix": "Run query again in a minute.",
+		"docs": "https://cloud.google.com/bigquery/quotas"
+	},
+	{
+		"id": "bytes-billed-limit",
+		"reason": "bytesBilledLimitExceeded",
+		"summary": "Query would bill more bytes than maximum bytes billed allows.",
+		"fix": "Filter partitions and select fewer columns, or raise maximum bytes billed.",
+		"docs": "https://cloud.google.com/bigquery/docs/best-practices-costs"
+	},
+	{
+		"id": "partition-filter-required",
+		"pattern": "without a filter over column|partition elimination",
+		"summary": "Table requires filter on partitioning column.",
+		"fix": "Add WHERE condition on partitioning column, like date range of _PARTITIONDATE.",
+		"docs": "https://cloud.google.com/bigquery/docs/querying-partitioned-tables"
+	},
+	{
+		"id": "unrecognized-name",
+		"reason": "invalidQuery",
+		"pattern": "unrecognized name",
+		"summary": "Query references column which doesn't exist in tables of query.",
+		"fix": "Check column name and alias of table; use schema of editor to find columns.",
+		"docs": "https://cloud.google.com/bigquery/docs/reference/standard-sql/query-syntax"
+	},
+	{
+		"id": "no-matching-signature",
+		"reason": "invalidQuery",
+		"pattern": "no matching signature",
+		"summary": "Function is called with arguments of wrong types.",
+		"fix": "CAST arguments to types function accepts.",
+		"docs": "https://cloud.google.com/bigquery/docs/reference/standard-sql/functions-and-operators"
+	},
+	{
+		"id": "division-by-zero",
+		"pattern": "division by zero",
+		"summary": "Query divides by zero in some row.",
+		"fix": "Use SAFE_DIVIDE or NULLIF of divisor.",
+		"docs": "https://cloud.google.com/bigquery/docs/reference/standard-sql/mathematical_functions#safe_divide"
+	},
+	{
+		"id": "bad-cast",
+		"pattern": "bad (int64|double|bool|numeric) value|could not cast",
+		"summary": "Value in some row can't be converted to type of CAST.",
+		"fix": "Use SAFE_CAST, which returns NULL for invalid values.",
+		"docs": "https://cloud.google.com/bigquery/docs/reference/standard-sql/conversion_functions#safe_casting"
+	},
+	{
+		"id": "syntax-error",
+		"reason": "invalidQuery",
+		"pattern": "syntax error",
+		"summary": "Query text is not valid SQL.",
+		"fix": "Fix SQL at position of error; check dialect of query, legacy SQL uses [project:dataset.table].",
+		"docs": "https://cloud.google.com/bigquery/docs/reference/standard-sql/query-syntax"
+	},
+	{
+		"id": "resources-exceeded",
+		"reason": "resourcesExceeded",
+		"summary": "Query needs more memory than one BigQuery worker has.",
+		"fix": "Remove ORDER BY without LIMIT, aggregate before joins and avoid large ARRAY_AGG or STRING_AGG.",
+		"docs": "https://cloud.google.com/bigquery/docs/best-practices-performance-compute"
+	},
+	{
+		"id": "response-too-large",
+		"reason": "responseTooLarge",
+		"summary": "Result is larger than BigQuery returns without destination table.",
+		"fix": "Aggregate or filter rows, or write result to destination table.",
+		"docs": "https://cloud.google.com/bigquery/docs/writing-results"
+	},
+	{
+		"id": "billing-tier",
+		"reason": "billingTierLimitExceeded",
+		"summary": "Query uses too much compute for bytes it processes.",
+		"fix": "Simplify joins and user defined functions of query.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "destination-exists",
+		"reason": "duplicate",
+		"summary": "Destination table already exists.",
+		"fix": "Choose other destination table or write disposition which replaces or appends rows.",
+		"docs": "https://cloud.google.com/bigquery/docs/writing-results"
+	},
+	{
+		"id": "invalid-query",
+		"reason": "invalidQuery",
+		"summary": "BigQuery rejected query.",
+		"fix": "Check message of error for position and name in query it refers to.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "backend-error",
+		"pattern": "backendError|internalError|Error 50[0-9]",
+		"summary": "BigQuery failed with temporary error.",
+		"fix": "Run query again.",
+		"docs": "https://cloud.google.com/bigquery/docs/error-messages"
+	},
+	{
+		"id": "time-travel-unsupported",
+		"pattern": "^TIME_TRAVEL_UNSUPPORTED",
+		"summary": "Query can't be run as of snapshot timestamp.",
+		"fix": "Query base tables instead of views and wildcard tables, or run query on current data.",
+		"docs": "https://cloud.google.com/bigquery/docs/access-historical-data"
+	},
+	{
+		"id": "time-travel-window",
+		"pattern": "time travel|FOR SYSTEM_TIME AS OF .*(before|beyond|outside)",
+		"summary": "Snapshot timestamp is outside of time travel window of table.",
+		"fix": "Choose timestamp within time travel window of dataset, 7 days by default, and after table was created.",
+		"docs": "https://cloud.google.com/bigquery/docs/time-travel"
+	},
+	{
+		"id": "result-too-large",
+		"pattern": "^RESULT_TOO_LARGE",
+		"summary": "Result is larger than dekart stores for maps.",
+		"fix": "Aggregate rows, select fewer columns or add LIMIT.",
+		"docs": ""
+	},
+	{
+		"id": "timeout",
+		"pattern": "timed out after .*, see DEKART_",
+		"summary": "Query or export of result ran longer than timeout of dekart.",
+		"fix": "Filter partitions to make query faster or ask administrator to raise timeout.",
+		"docs": ""
+	}
+]`
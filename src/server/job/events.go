@@ -0,0 +1,96 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event types of job event log
+const (
+	// EventState when job transitioned to state in message
+	EventState = "STATE"
+	// EventRetry when result is written again, message is reason
+	EventRetry = "RETRY"
+	// EventWarning when warning with code in message was added
+	EventWarning = "WARNING"
+	// EventFirstRow when first row of result was read
+	EventFirstRow = "FIRST_ROW"
+	// EventProgress every 10% of rows written, message is percent
+	EventProgress = "PROGRESS"
+	// EventUploadStarted and EventUploadFinished of result object
+	EventUploadStarted  = "UPLOAD_STARTED"
+	EventUploadFinished = "UPLOAD_FINISHED"
+	// EventPreview when preview of first rows was stored, message is rows of preview
+	EventPreview = "PREVIEW"
+)
+
+// maxEvents of job; when log is full, last event is replaced, so terminal state is always last
+const maxEvents = 200
+
+// Event of job event log, timeline of job for post-mortem analysis
+type Event struct {
+	Time    int64  `json:"time"` // unix milliseconds
+	Type    string `json:"type"`
+	Message string `json:"message,omitempty"`
+}
+
+// addEvent to event log of job
+func (job *Job) addEvent(eventType string, message string) {
+	job.mutex.Lock()
+	job.appendEvent(eventType, message)
+	job.mutex.Unlock()
+}
+
+// appendEvent under job mutex
+func (job *Job) appendEvent(eventType string, message string) {
+	event := Event{Time: job.now().UnixNano() / 1e6, Type: eventType, Message: message}
+	if len(job.events) >= maxEvents {
+		job.events[len(job.events)-1] = event
+		job.eventsDropped++
+		return
+	}
+	job.events = append(job.events, event)
+}
+
+// addProgressEvent when rows crossed next 10% of total rows since last, returns percent of rows
+func (job *Job) addProgressEvent(rows int64, last int64) int64 {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.totalRows <= 0 {
+		return last
+	}
+	percent := rows * 100 / job.totalRows / 10 * 10
+	if percent > last {
+		job.appendEvent(EventProgress, fmt.Sprintf("%d%%", percent))
+		return percent
+	}
+	return last
+}
+
+// GetEvents of job in order they happened and number of events dropped from full log
+func (job *Job) GetEvents() ([]Event, int) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	events := make([]Event, len(job.events))
+	copy(events, job.events)
+	return events, job.eventsDropped
+}
+
+// MarshalEvents stored in job stats, empty when there are no events
+func MarshalEvents(events []Event) (string, error) {
+	if len(events) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(events)
+	return string(b), err
+}
+
+// UnmarshalEvents of job stats
+func UnmarshalEvents(value string) ([]Event, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var events []Event
+	err := json.Unmarshal([]byte(value), &events)
+	return events, err
+}
@@ -0,0 +1,97 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func eventList(events []Event) string {
+	list := make([]string, len(events))
+	for i, event := range events {
+		list[i] = event.Type
+		if event.Message != "" {
+			list[i] += " " + event.Message
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+func TestEventsOrder(t *testing.T) {
+	job := readingJob(NewStore())
+	job.totalRows = 20
+	statuses := collectStatus(job)
+	job.AddWarning(WarningControlCharacters, "replaced")
+	job.writeResult(newFakeIterator(20), fakeSchema, &fakeResultWriter{}, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	events, dropped := job.GetEvents()
+	expected := "WARNING CONTROL_CHARACTERS,UPLOAD_STARTED,FIRST_ROW," +
+		"PROGRESS 10%,PROGRESS 20%,PROGRESS 30%,PROGRESS 40%,PROGRESS 50%," +
+		"PROGRESS 60%,PROGRESS 70%,PROGRESS 80%,PROGRESS 90%,PROGRESS 100%," +
+		"STATE SAVING,UPLOAD_FINISHED,STATE DONE"
+	if list := eventList(events); list != expected || dropped != 0 {
+		t.Errorf("expected %s, got %s, %d dropped", expected, list, dropped)
+	}
+}
+
+func TestEventsCapped(t *testing.T) {
+	job := readingJob(NewStore())
+	for i := 0; i < maxEvents+10; i++ {
+		job.addEvent(EventRetry, "retry")
+	}
+	job.transition(StateFailed, false, nil)
+	events, dropped := job.GetEvents()
+	if len(events) != maxEvents || dropped != 11 {
+		t.Fatalf("expected %d events and 11 dropped, got %d and %d", maxEvents, len(events), dropped)
+	}
+	if last := events[len(events)-1]; last.Type != EventState || last.Message != "FAILED" {
+		t.Errorf("expected terminal state last, got %v", last)
+	}
+}
+
+func TestEventsFlushedOnce(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		store := NewStore()
+		recorder := &fakeRecorder{}
+		store.UseStatsRecorder(recorder)
+		job := store.New(context.Background(), "report", "query")
+		go drainStatus(job)
+		job.transition(StateRunning, true, func() {
+			job.startedAt = job.now()
+		})
+		job.transition(StateReading, true, nil)
+		job.transition(StateSaving, true, nil)
+		var wg sync.WaitGroup
+		wg.Add(3)
+		// terminal paths race, first transition wins
+		go func() {
+			defer wg.Done()
+			job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+		}()
+		go func() {
+			defer wg.Done()
+			job.cancelWithError(context.DeadlineExceeded)
+		}()
+		go func() {
+			defer wg.Done()
+			job.transition(StateDone, true, nil)
+			job.finish()
+		}()
+		wg.Wait()
+		if len(recorder.stats) != 1 {
+			t.Fatalf("expected events flushed once, got %d", len(recorder.stats))
+		}
+		events := recorder.stats[0].Events
+		terminal := 0
+		for _, event := range events {
+			if event.Type == EventState && (event.Message == "DONE" || event.Message == "FAILED" || event.Message == "CANCELLED") {
+				terminal++
+			}
+		}
+		if terminal != 1 || events[len(events)-1].Type != EventState {
+			t.Fatalf("expected one terminal state last, got %s", eventList(events))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package job_test
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+func ExampleStore_New() {
+	ctx := context.Background()
+	gcs, err := storage.NewClient(ctx, option.WithoutAuthentication())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	bucket := gcs.Bucket("results")
+	store := job.NewStore(
+		job.WithProjects(job.Projects{Data: "data-project", Billing: "billing-project"}),
+		job.WithTimeout(job.Timeouts{Query: 10 * time.Minute, Export: 5 * time.Minute}),
+		job.WithStorage(bucket),
+		job.WithFormat(proto.ResultFormat_RESULT_FORMAT_CSV, proto.ResultCompression_RESULT_COMPRESSION_GZIP),
+		job.WithRunner(func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+			// programs with BigQuery access return bigquery.NewClient(ctx, projectID, opts...)
+			return nil, fmt.Errorf("no BigQuery access in %s", projectID)
+		}),
+	)
+	j := store.New(ctx, "report", "query")
+	// Status is unbuffered and Run sends to it, so Wait receives statuses before Run is called
+	done := make(chan job.JobSummary)
+	go func() { done <- j.Wait() }()
+	if err := j.Run("SELECT 1 AS x", job.RunOptions{}, bucket.Object(j.ID+".csv")); err != nil {
+		fmt.Println(err)
+	}
+	summary := <-done
+	fmt.Println(summary.State, summary.TotalRows)
+	// Output:
+	// no BigQuery access in billing-project
+	// FAILED 0
+}
@@ -0,0 +1,129 @@
+package job
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+// ErrorExplanation of job error for users; raw error is kept as error of job
+type ErrorExplanation struct {
+	// ID of mapping which explained error
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+	Fix     string `json:"fix"`
+	DocsURL string `json:"docs,omitempty"`
+}
+
+// errorMapping of errors matching code, reason and pattern to explanation
+type errorMapping struct {
+	ErrorExplanation
+	Code    int    `json:"code,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	re      *regexp.Regexp
+}
+
+// errorMappings of errorMappingsJSON, parsed once
+var errorMappings = mustParseErrorMappings(errorMappingsJSON)
+
+// parseErrorMappings of JSON array in order of precedence
+func parseErrorMappings(value string) ([]errorMapping, error) {
+	var mappings []errorMapping
+	if err := json.Unmarshal([]byte(value), &mappings); err != nil {
+		return nil, err
+	}
+	for i := range mappings {
+		m := &mappings[i]
+		if m.ID == "" || m.Summary == "" || m.Fix == "" {
+			return nil, fmt.Errorf("error mapping %d needs id, summary and fix", i)
+		}
+		if m.Code == 0 && m.Reason == "" && m.Pattern == "" {
+			return nil, fmt.Errorf("error mapping %s matches every error", m.ID)
+		}
+		if m.Pattern != "" {
+			re, err := regexp.Compile("(?i)" + m.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("error mapping %s: %s", m.ID, err)
+			}
+			m.re = re
+		}
+	}
+	return mappings, nil
+}
+
+func mustParseErrorMappings(value string) []errorMapping {
+	mappings, err := parseErrorMappings(value)
+	if err != nil {
+		panic(err)
+	}
+	return mappings
+}
+
+// errorFields of error matched by mappings; code of API error, reason of API or job error
+func errorFields(err error) (code int, reason string) {
+	var apiErr *googleapi.Error
+	var bqErr *bigquery.Error
+	switch {
+	case errors.As(err, &apiErr):
+		code = apiErr.Code
+		if len(apiErr.Errors) > 0 {
+			reason = apiErr.Errors[0].Reason
+		}
+	case errors.As(err, &bqErr):
+		reason = bqErr.Reason
+	}
+	return code, reason
+}
+
+// explainError with first matching mapping, nil for unknown error; pattern is matched on message of whole error,
+// so message added by dekart to error is matched too
+func explainError(mappings []errorMapping, err error) *ErrorExplanation {
+	if err == nil {
+		return nil
+	}
+	code, reason := errorFields(err)
+	message := err.Error()
+	for _, m := range mappings {
+		if m.Code != 0 && m.Code != code {
+			continue
+		}
+		if m.Reason != "" && m.Reason != reason {
+			continue
+		}
+		if m.re != nil && !m.re.MatchString(message) {
+			continue
+		}
+		explanation := m.ErrorExplanation
+		return &explanation
+	}
+	return nil
+}
+
+// ExplainError of job for users, nil when error is unknown
+func ExplainError(err error) *ErrorExplanation {
+	return explainError(errorMappings, err)
+}
+
+// MarshalErrorExplanation stored in job record, empty without explanation
+func MarshalErrorExplanation(explanation *ErrorExplanation) (string, error) {
+	if explanation == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(explanation)
+	return string(b), err
+}
+
+// UnmarshalErrorExplanation of job record, nil without explanation
+func UnmarshalErrorExplanation(value string) (*ErrorExplanation, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var explanation ErrorExplanation
+	err := json.Unmarshal([]byte(value), &explanation)
+	return &explanation, err
+}
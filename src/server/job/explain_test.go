@@ -0,0 +1,93 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+func TestErrorMappings(t *testing.T) {
+	if len(errorMappings) < 20 {
+		t.Errorf("expected at least 20 error mappings, got %d", len(errorMappings))
+	}
+	for _, value := range []string{
+		`[{"id": "any", "summary": "s", "fix": "f"}]`,
+		`[{"id": "bad", "pattern": "(", "summary": "s", "fix": "f"}]`,
+		`[{"reason": "notFound", "summary": "s", "fix": "f"}]`,
+	} {
+		if _, err := parseErrorMappings(value); err == nil {
+			t.Errorf("expected error of %s", value)
+		}
+	}
+}
+
+func TestExplainErrorPrecedence(t *testing.T) {
+	for _, c := range []struct {
+		err      error
+		expected string
+	}{
+		// location mismatch is notFound of table, it precedes table-not-found
+		{&bigquery.Error{Reason: "notFound", Message: "Not found: Table p:d.t was not found in location EU"}, "location-mismatch"},
+		{&bigquery.Error{Reason: "notFound", Message: "Not found: Table p:d.t"}, "table-not-found"},
+		{&bigquery.Error{Reason: "notFound", Message: "Not found: Dataset p:d"}, "dataset-not-found"},
+		{&bigquery.Error{Reason: "notFound", Message: "Not found: Function p:d.f"}, "not-found"},
+		// permission named by error precedes reason of error
+		{&googleapi.Error{Code: 403, Message: "Access Denied: User does not have bigquery.jobs.create permission", Errors: []googleapi.ErrorItem{{Reason: "accessDenied"}}}, "missing-jobs-create"},
+		{&googleapi.Error{Code: 403, Message: "Access Denied: Table p:d.t", Errors: []googleapi.ErrorItem{{Reason: "accessDenied"}}}, "access-denied"},
+		{&googleapi.Error{Code: 403, Message: "Forbidden"}, "access-denied"},
+		{&bigquery.Error{Reason: "quotaExceeded", Message: "Exceeded rate limits: too many concurrent queries for this project_and_region"}, "concurrent-queries"},
+		{&bigquery.Error{Reason: "quotaExceeded", Message: "Custom quota exceeded: Your usage exceeded the custom quota for QueryUsagePerDay"}, "custom-quota"},
+		{&bigquery.Error{Reason: "quotaExceeded", Message: "Quota exceeded"}, "quota-exceeded"},
+		{&bigquery.Error{Reason: "invalidQuery", Message: "Cannot query over table 'p.d.t' without a filter over column(s) 'day' that can be used for partition elimination"}, "partition-filter-required"},
+		{&bigquery.Error{Reason: "invalidQuery", Message: "Syntax error: Unexpected keyword FROM at [1:8]"}, "syntax-error"},
+		{&bigquery.Error{Reason: "invalidQuery", Message: "Unrecognized name: lat at [1:8]"}, "unrecognized-name"},
+		{&bigquery.Error{Reason: "invalidQuery", Message: "Something new"}, "invalid-query"},
+		// error wrapped by dekart keeps reason of BigQuery error
+		{fmt.Errorf("%w; grant bigquery.jobs.create permission on billing project p", &googleapi.Error{Code: 400, Message: "Billing has not been enabled for this project"}), "billing-disabled"},
+		{&TimeoutError{Phase: "query execution", Timeout: 1, Variable: "DEKART_QUERY_TIMEOUT"}, "timeout"},
+		{&ResultTooLargeError{Limit: 10, Unit: "rows"}, "result-too-large"},
+		{&DatasetNotAllowedError{Table: "p.private.users"}, "dataset-not-allowed"},
+	} {
+		explanation := ExplainError(c.err)
+		if explanation == nil || explanation.ID != c.expected {
+			t.Errorf("expected %s of %q, got %+v", c.expected, c.err, explanation)
+		}
+	}
+}
+
+func TestExplainUnknownError(t *testing.T) {
+	if explanation := ExplainError(errors.New("disk on fire")); explanation != nil {
+		t.Errorf("expected unknown error without explanation, got %+v", explanation)
+	}
+	if explanation := ExplainError(&bigquery.Error{Reason: "somethingNew", Message: "new"}); explanation != nil {
+		t.Errorf("expected unknown reason without explanation, got %+v", explanation)
+	}
+	if ExplainError(nil) != nil {
+		t.Error("expected no explanation without error")
+	}
+}
+
+func TestFailedJobExplanation(t *testing.T) {
+	job := readingJob(NewStore())
+	go drainStatus(job)
+	err := &bigquery.Error{Reason: "notFound", Message: "Not found: Table p:d.t"}
+	job.cancelWithError(err)
+	snapshot := job.GetStatus()
+	if snapshot.Err != err.Error() {
+		t.Errorf("expected raw error kept, got %s", snapshot.Err)
+	}
+	if snapshot.ErrorExplanation == nil || snapshot.ErrorExplanation.ID != "table-not-found" {
+		t.Fatalf("unexpected explanation %+v", snapshot.ErrorExplanation)
+	}
+	value, err2 := MarshalErrorExplanation(snapshot.ErrorExplanation)
+	if err2 != nil {
+		t.Fatal(err2)
+	}
+	stored, err2 := UnmarshalErrorExplanation(value)
+	if err2 != nil || *stored != *snapshot.ErrorExplanation {
+		t.Errorf("expected stored explanation, got %+v %v", stored, err2)
+	}
+}
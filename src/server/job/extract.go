@@ -0,0 +1,283 @@
+package job
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+)
+
+// extractFormatting of cells written by BigQuery EXTRACT, they differ from rows written by dekart;
+// stored in metadata of extracted result object
+const extractFormatting = "NULL as empty cell, TIMESTAMP as 2006-01-02 15:04:05.999999 UTC, BYTES as base64, " +
+	"NUMERIC and BIGNUMERIC with trailing zeros trimmed, FLOAT64 with shortest round-trip digits, " +
+	"JSON, INTERVAL and RANGE as BigQuery text, control characters in strings are kept"
+
+// extractShard of result written by EXTRACT to bucket
+type extractShard struct {
+	name string
+	size int64
+}
+
+// resultExtract of result table above DEKART_EXTRACT_THRESHOLD; BigQuery writes result as CSV shards
+// to bucket of result, which are composed into result object after header, so rows are not read by dekart
+type resultExtract struct {
+	bucket string
+	store  componentStore
+	// run EXTRACT of table to uri and wait for it, replaced in tests
+	run func(ctx context.Context, table *bigquery.Table, uri string) error
+	// list shards with name prefix in name order, replaced in tests
+	list func(ctx context.Context, prefix string) ([]extractShard, error)
+}
+
+// UseExtract of result table above DEKART_EXTRACT_THRESHOLD bytes, shards are written to bucket of result.
+// Jobs of store created WithStorage use its bucket
+func (job *Job) UseExtract(bucket *storage.BucketHandle) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.extractThreshold == 0 {
+		return
+	}
+	job.extract = &resultExtract{
+		bucket: bucket.Object("").BucketName(),
+		store:  &bucketComponents{job: job, bucket: bucket},
+		run:    job.runExtract,
+		list: func(ctx context.Context, prefix string) ([]extractShard, error) {
+			return listShards(ctx, bucket, prefix)
+		},
+	}
+}
+
+// extractable schema of result, EXTRACT to CSV fails on nested and repeated fields
+func extractable(schema bigquery.Schema) bool {
+	for _, field := range schema {
+		if field.Repeated || field.Type == bigquery.RecordFieldType {
+			return false
+		}
+	}
+	return len(schema) > 0
+}
+
+// canExtract result of job; rows with serialization options, aggregation and additional formats are written by dekart.
+// Rows of ordered query are read in order from single stream, EXTRACT writes shards in parallel without order
+func (job *Job) canExtract() bool {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.extract != nil &&
+		!job.ordered &&
+		job.destinationTable == "" &&
+		job.resultFormat != proto.ResultFormat_RESULT_FORMAT_PARQUET &&
+		job.serialization == nil &&
+		job.h3 == nil &&
+		len(job.outputs) == 0
+}
+
+// extractResult of job with EXTRACT when result table is above threshold and can be extracted;
+// false when rows of result are to be written by dekart, otherwise job is finished
+func (job *Job) extractResult(ctx context.Context) bool {
+	if !job.canExtract() {
+		return false
+	}
+	config, err := job.bigqueryJob.Config()
+	if err != nil {
+		return false
+	}
+	queryConfig, ok := config.(*bigquery.QueryConfig)
+	if !ok || queryConfig.Dst == nil {
+		return false
+	}
+	start := time.Now()
+	meta, err := queryConfig.Dst.Metadata(ctx)
+	metrics.Observe(metrics.BigQuery, "table_metadata", start, err)
+	if err != nil {
+		job.logger.Warn().Err(err).Msg("Cannot read result table, result is written from rows")
+		return false
+	}
+	if int64(meta.NumBytes) < job.extractThreshold || !extractable(meta.Schema) {
+		return false
+	}
+	return job.writeExtract(ctx, queryConfig.Dst, job.transport.restoreSchema(meta.Schema), int64(meta.NumRows))
+}
+
+// runExtract of table to uri as CSV without header, compressed like result
+func (job *Job) runExtract(ctx context.Context, table *bigquery.Table, uri string) error {
+	ref := bigquery.NewGCSReference(uri)
+	ref.DestinationFormat = bigquery.CSV
+	if job.compressed() {
+		ref.Compression = bigquery.Gzip
+	}
+	extractor := table.ExtractorTo(ref)
+	extractor.DisableHeader = true
+	extractor.Location = job.bigqueryJob.Location()
+	start := time.Now()
+	extractJob, err := extractor.Run(ctx)
+	metrics.Observe(metrics.BigQuery, "extract", start, err)
+	if err != nil {
+		return err
+	}
+	status, err := extractJob.Wait(ctx)
+	if err != nil {
+		stopBigqueryJob(extractJob, job.logger)
+		return err
+	}
+	return status.Err()
+}
+
+// listShards of bucket with name prefix in name order
+func listShards(ctx context.Context, bucket *storage.BucketHandle, prefix string) ([]extractShard, error) {
+	var shards []extractShard
+	it := bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return shards, err
+		}
+		shards = append(shards, extractShard{name: attrs.Name, size: attrs.Size})
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].name < shards[j].name })
+	return shards, nil
+}
+
+func shardNames(shards []extractShard) []string {
+	names := make([]string, len(shards))
+	for i, shard := range shards {
+		names[i] = shard.name
+	}
+	return names
+}
+
+// extractHeader of CSV result with names of schema, compressed like result
+func (job *Job) extractHeader(schema bigquery.Schema) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if job.compressed() {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+	names := make([]string, len(schema))
+	for i, field := range schema {
+		names[i] = field.Name
+	}
+	writer := csv.NewWriter(w)
+	writer.Write(names)
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	if gz != nil {
+		// gzip members of header and shards are read as one stream
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// composeExtract of header and shards into result object, returns size of result
+func (job *Job) composeExtract(ctx context.Context, schema bigquery.Schema, shards []extractShard) (int64, error) {
+	if len(shards) > maxComponents {
+		return 0, fmt.Errorf("result has %d extracted shards, at most %d can be composed", len(shards), maxComponents)
+	}
+	header, err := job.extractHeader(schema)
+	if err != nil {
+		return 0, err
+	}
+	w := job.extract.store.newWriter(ctx)
+	if _, err := w.Write(header); err != nil {
+		w.Close()
+		return 0, err
+	}
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+	intermediates, err := composeInto(ctx, job.extract.store, job.extract.store.object(), shardNames(shards))
+	removeObjects(job.extract.store, intermediates, job.upload.Parallelism, job.logger)
+	if err != nil {
+		return 0, err
+	}
+	size := int64(len(header))
+	for _, shard := range shards {
+		size += shard.size
+	}
+	return size, nil
+}
+
+// writeExtract of table with rows into result object; false when extract failed and rows are to be written by dekart,
+// otherwise job is finished
+func (job *Job) writeExtract(ctx context.Context, table *bigquery.Table, schema bigquery.Schema, rows int64) bool {
+	job.setPhase(PhaseUploading)
+	job.mutex.Lock()
+	// metadata of result object documents formatting of EXTRACT
+	job.extracted = true
+	job.mutex.Unlock()
+	job.addEvent(EventUploadStarted, "extract")
+	prefix := job.extract.store.object() + ".extract-"
+	err := job.extract.run(ctx, table, fmt.Sprintf("gs://%s/%s*", job.extract.bucket, prefix))
+	shards, listErr := job.extract.list(ctx, prefix)
+	if err == nil {
+		err = listErr
+	}
+	var size int64
+	if err == nil {
+		size, err = job.composeExtract(ctx, schema, shards)
+	}
+	// shards are removed once composed, or when extract failed
+	removeObjects(job.extract.store, shardNames(shards), job.upload.Parallelism, job.logger)
+	if err == nil {
+		err = job.checkLimits(size, rows)
+	}
+	if err != nil {
+		job.deleteResult()
+		job.mutex.Lock()
+		job.extracted = false
+		job.mutex.Unlock()
+		if job.cancelled(err) {
+			job.finish()
+			return true
+		}
+		if _, tooLarge := err.(*ResultTooLargeError); tooLarge {
+			job.cancelWithError(err)
+			return true
+		}
+		job.logger.Warn().Err(err).Msg("Result extract failed, writing result from rows")
+		job.setPhase(PhaseReading)
+		return false
+	}
+	job.addEvent(EventUploadFinished, "extract")
+	job.mutex.Lock()
+	job.rowsWritten = rows
+	job.bytesUploaded = size
+	job.columnTypes = job.transport.columnTypes(schema)
+	job.mutex.Unlock()
+	if !job.transition(StateSaving, true, nil) {
+		// cancelled after extract
+		job.deleteResult()
+		job.finish()
+		return true
+	}
+	done := job.transition(StateDone, true, func() {
+		job.resultID = &job.ID
+		job.resultSize = size
+		// extracted object is not read by job, its checksum is in object attributes
+		job.resultMeta = job.newResultMeta(size, "")
+	})
+	if done {
+		job.logger.Info().Int("shards", len(shards)).Msg("Job done, result extracted")
+	}
+	job.finish()
+	return true
+}
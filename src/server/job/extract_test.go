@@ -0,0 +1,104 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+)
+
+func TestExtractable(t *testing.T) {
+	if !extractable(bigquery.Schema{{Name: "id", Type: bigquery.IntegerFieldType}, {Name: "geom", Type: bigquery.GeographyFieldType}}) {
+		t.Error("expected flat schema extractable")
+	}
+	if extractable(bigquery.Schema{{Name: "ids", Type: bigquery.IntegerFieldType, Repeated: true}}) {
+		t.Error("expected repeated field not extractable")
+	}
+	if extractable(bigquery.Schema{{Name: "point", Type: bigquery.RecordFieldType}}) {
+		t.Error("expected record not extractable")
+	}
+}
+
+// extractingJob with shards written by fake EXTRACT into store
+func extractingJob(store *fakeComponents, shards map[string]string, runErr error) *Job {
+	job := readingJob(NewStore())
+	job.extract = &resultExtract{
+		bucket: "results",
+		store:  store,
+		run: func(ctx context.Context, table *bigquery.Table, uri string) error {
+			if uri != "gs://results/result.csv.extract-*" {
+				return errors.New("unexpected uri " + uri)
+			}
+			for name, data := range shards {
+				store.objects[name] = []byte(data)
+			}
+			return runErr
+		},
+		list: func(ctx context.Context, prefix string) ([]extractShard, error) {
+			var list []extractShard
+			for _, name := range store.names() {
+				if strings.HasPrefix(name, prefix) {
+					list = append(list, extractShard{name: name, size: int64(len(store.objects[name]))})
+				}
+			}
+			return list, nil
+		},
+	}
+	return job
+}
+
+func TestWriteExtract(t *testing.T) {
+	store := newFakeComponents()
+	job := extractingJob(store, map[string]string{
+		"result.csv.extract-000000000001": "2,b\n",
+		"result.csv.extract-000000000000": "1,a\n",
+	}, nil)
+	statuses := collectStatus(job)
+	schema := bigquery.Schema{{Name: "id", Type: bigquery.IntegerFieldType}, {Name: "name", Type: bigquery.StringFieldType}}
+	if !job.writeExtract(context.Background(), nil, schema, 2) {
+		t.Fatal("expected job finished by extract")
+	}
+	<-statuses
+	if err := job.Err(); err != "" {
+		t.Fatal(err)
+	}
+	if names := strings.Join(store.names(), ","); names != "result.csv" {
+		t.Fatalf("expected shards removed, got %s", names)
+	}
+	expected := "id,name\n1,a\n2,b\n"
+	if data := string(store.objects["result.csv"]); data != expected {
+		t.Errorf("expected %q, got %q", expected, data)
+	}
+	status := job.GetStatus()
+	if status.State != StateDone || status.RowsWritten != 2 || status.ResultSize != int64(len(expected)) {
+		t.Errorf("unexpected status %+v", status)
+	}
+	attrs := &storage.ObjectAttrs{}
+	job.setResultAttrs(attrs)
+	if attrs.Metadata["export"] != "extract" || attrs.Metadata["extractFormatting"] != extractFormatting {
+		t.Errorf("expected extract metadata, got %v", attrs.Metadata)
+	}
+}
+
+func TestWriteExtractFallback(t *testing.T) {
+	store := newFakeComponents()
+	job := extractingJob(store, map[string]string{"result.csv.extract-000000000000": "1,a\n"}, errors.New("extract failed"))
+	schema := bigquery.Schema{{Name: "id", Type: bigquery.IntegerFieldType}, {Name: "name", Type: bigquery.StringFieldType}}
+	if job.writeExtract(context.Background(), nil, schema, 1) {
+		t.Fatal("expected rows written by dekart after failed extract")
+	}
+	if names := store.names(); len(names) != 0 {
+		t.Errorf("expected shards removed, got %v", names)
+	}
+	attrs := &storage.ObjectAttrs{}
+	job.setResultAttrs(attrs)
+	if _, ok := attrs.Metadata["export"]; ok {
+		t.Error("expected no extract metadata after fallback")
+	}
+	if job.GetStatus().State != StateReading {
+		t.Errorf("expected job reading, got %v", job.GetStatus().State)
+	}
+}
@@ -0,0 +1,111 @@
+package job
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+)
+
+// anonymousTableTTL of BigQuery temporary table with query result, result can't be read again after it
+const anonymousTableTTL = 24 * time.Hour
+
+// resultFallback receives result when upload to primary bucket fails
+type resultFallback struct {
+	bucket string
+	writer func(ctx context.Context) resultWriter
+	// remove partial result from fallback bucket
+	remove func()
+}
+
+// UseFallback object receiving result when upload to primary bucket fails with retryable error;
+// jobs with h3 aggregation don't fall back, aggregated cells are stored in primary bucket
+func (job *Job) UseFallback(obj *storage.ObjectHandle) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	job.fallback = &resultFallback{
+		bucket: obj.BucketName(),
+		writer: func(ctx context.Context) resultWriter {
+			return instrumentWriter(job.newStorageWriter(ctx, obj, nil), metrics.GCS, "fallback_upload")
+		},
+		remove: func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := obj.Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+				job.logger.Warn().Err(err).Msg("Cannot delete partial fallback result")
+			}
+		},
+	}
+}
+
+// retryableUpload error of storage service or network, upload to other bucket can succeed
+func retryableUpload(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == 429 || apiErr.Code >= 500
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// rereadResult of finished BigQuery job from its temporary table
+func (job *Job) rereadResult(ctx context.Context) (rowIterator, func() bigquery.Schema, error) {
+	it, err := job.bigqueryJob.Read(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &instrumentedIterator{it: it, operation: "rows_fetch"}, job.resultSchema(func() bigquery.Schema { return it.Schema }), nil
+}
+
+// writeFallback of result which upload to primary bucket failed with err; false when fallback is not attempted,
+// otherwise job is finished with result in fallback bucket or with error
+func (job *Job) writeFallback(err error) bool {
+	job.mutex.Lock()
+	fallback := job.fallback
+	attempt := fallback != nil && job.resultBucket == "" && job.h3 == nil
+	waitedAt := job.waitedAt
+	job.mutex.Unlock()
+	if !attempt || !retryableUpload(err) {
+		return false
+	}
+	if job.now().Sub(waitedAt) >= anonymousTableTTL {
+		job.logger.Warn().Err(err).Msg("Result expired from BigQuery temporary table, fallback upload is not attempted")
+		return false
+	}
+	job.logger.Warn().Err(err).Str("bucket", fallback.bucket).Msg("Result upload failed, writing result to fallback bucket")
+	job.addEvent(EventRetry, "result upload failed, writing result to fallback bucket")
+	job.setPhase(PhaseReading)
+	// fallback upload is part of export, it's within export timeout
+	exportCtx := job.phaseContext()
+	it, schema, readErr := job.reread(exportCtx)
+	if readErr != nil {
+		if job.cancelled(readErr) {
+			job.finish()
+			return true
+		}
+		job.cancelWithError(fmt.Errorf("result upload failed: %s; reading result again for fallback bucket failed: %s", err, readErr))
+		return true
+	}
+	job.mutex.Lock()
+	job.resultBucket = fallback.bucket
+	job.rowsWritten = 0
+	job.bytesUploaded = 0
+	job.mutex.Unlock()
+	ctx, abortUpload := context.WithCancel(exportCtx)
+	defer abortUpload()
+	job.writeResult(it, schema, fallback.writer(ctx), func() {
+		abortUpload()
+		fallback.remove()
+	})
+	return true
+}
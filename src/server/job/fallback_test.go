@@ -0,0 +1,110 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+// closeFailingWriter accepts rows and fails to finish upload
+type closeFailingWriter struct {
+	fakeResultWriter
+	err error
+}
+
+func (w *closeFailingWriter) Close() error {
+	w.closed = true
+	return w.err
+}
+
+// fallbackJob of reading job with fallback writing to w; rereads counts reads of result for fallback
+func fallbackJob(w resultWriter, rereads *int) *Job {
+	job := readingJob(NewStore())
+	job.totalRows = 3
+	job.waitedAt = job.now()
+	job.fallback = &resultFallback{
+		bucket: "fallback-bucket",
+		writer: func(ctx context.Context) resultWriter { return w },
+		remove: func() {},
+	}
+	job.reread = func(ctx context.Context) (rowIterator, func() bigquery.Schema, error) {
+		*rereads++
+		return newFakeIterator(3), fakeSchema, nil
+	}
+	return job
+}
+
+func TestRetryableUpload(t *testing.T) {
+	for _, err := range []error{&googleapi.Error{Code: 503}, &googleapi.Error{Code: 429}, io.ErrUnexpectedEOF} {
+		if !retryableUpload(err) {
+			t.Errorf("%v: expected retryable", err)
+		}
+	}
+	for _, err := range []error{&googleapi.Error{Code: 403}, errors.New("invalid object")} {
+		if retryableUpload(err) {
+			t.Errorf("%v: expected not retryable", err)
+		}
+	}
+}
+
+func TestResultFallback(t *testing.T) {
+	fallback := &fakeResultWriter{}
+	var rereads int
+	job := fallbackJob(fallback, &rereads)
+	statuses := collectStatus(job)
+	primary := &closeFailingWriter{err: &googleapi.Error{Code: 503, Message: "backend error"}}
+	job.writeResult(newFakeIterator(3), fakeSchema, primary, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v: %s", s, job.Err())
+	}
+	if rereads != 1 || !fallback.closed || fallback.String() != primary.String() {
+		t.Errorf("expected result written to fallback once, got %d reads, fallback %q, primary %q", rereads, fallback.String(), primary.String())
+	}
+	snapshot := job.GetStatus()
+	if snapshot.ResultBucket != "fallback-bucket" || snapshot.ResultID == nil || snapshot.RowsWritten != 3 || snapshot.ResultSize != int64(fallback.Len()) {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+}
+
+func TestResultFallbackNotAttempted(t *testing.T) {
+	for name, c := range map[string]struct {
+		err      error
+		waitedAt time.Duration
+	}{
+		"not retryable":  {&googleapi.Error{Code: 403, Message: "forbidden"}, 0},
+		"result expired": {&googleapi.Error{Code: 503, Message: "backend error"}, -25 * time.Hour},
+	} {
+		var rereads int
+		job := fallbackJob(&fakeResultWriter{}, &rereads)
+		job.waitedAt = job.now().Add(c.waitedAt)
+		statuses := collectStatus(job)
+		job.writeResult(newFakeIterator(3), fakeSchema, &closeFailingWriter{err: c.err}, func() {})
+		if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != 0 {
+			t.Errorf("%s: expected failed status, got %v", name, s)
+		}
+		if rereads != 0 || job.GetStatus().ResultBucket != "" {
+			t.Errorf("%s: expected no fallback, got %d reads", name, rereads)
+		}
+	}
+}
+
+func TestResultFallbackFails(t *testing.T) {
+	var rereads int
+	job := fallbackJob(&closeFailingWriter{err: &googleapi.Error{Code: 503, Message: "fallback unavailable"}}, &rereads)
+	statuses := collectStatus(job)
+	job.writeResult(newFakeIterator(3), fakeSchema, &closeFailingWriter{err: &googleapi.Error{Code: 503, Message: "primary unavailable"}}, func() {})
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != 0 {
+		t.Fatalf("expected failed status, got %v", s)
+	}
+	// fallback is attempted once
+	if rereads != 1 || !strings.Contains(job.Err(), "fallback unavailable") || job.GetResultID() != nil {
+		t.Errorf("expected error of fallback upload, got %d reads, %q", rereads, job.Err())
+	}
+}
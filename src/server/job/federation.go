@@ -0,0 +1,367 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/server/metrics"
+	"dekart/src/server/secrets"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	tokenExchangeGrant = "urn:ietf:params:oauth:grant-type:token-exchange"
+	accessTokenType    = "urn:ietf:params:oauth:token-type:access_token"
+	federationTimeout  = 30 * time.Second
+	// maxFederationResponse in bytes read of token responses
+	maxFederationResponse = 1 << 20
+)
+
+var connectionNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// CredentialsError of connection, token exchange failed before query was sent to BigQuery;
+// unlike permission errors of queries it's fixed in configuration of workload identity pool
+type CredentialsError struct {
+	Connection string
+	// Stage which failed: credentials config, subject token, token exchange or impersonation
+	Stage string
+	Err   error
+}
+
+func (e *CredentialsError) Error() string {
+	return fmt.Sprintf("cannot get credentials of connection %s, %s failed: %s", e.Connection, e.Stage, e.Err)
+}
+
+func (e *CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// externalAccount credentials JSON of workload identity federation
+type externalAccount struct {
+	Type                           string           `json:"type"`
+	Audience                       string           `json:"audience"`
+	SubjectTokenType               string           `json:"subject_token_type"`
+	TokenURL                       string           `json:"token_url"`
+	ServiceAccountImpersonationURL string           `json:"service_account_impersonation_url"`
+	CredentialSource               credentialSource `json:"credential_source"`
+}
+
+// credentialSource of subject token, file or URL; JSON format reads token from field of response
+type credentialSource struct {
+	File    string            `json:"file"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Format  struct {
+		Type                  string `json:"type"`
+		SubjectTokenFieldName string `json:"subject_token_field_name"`
+	} `json:"format"`
+}
+
+// parseExternalAccount credentials JSON; type can be omitted in token exchange configuration
+func parseExternalAccount(value []byte) (*externalAccount, error) {
+	var a externalAccount
+	if err := json.Unmarshal(value, &a); err != nil {
+		return nil, fmt.Errorf("invalid credentials JSON: %s", err)
+	}
+	if a.Type != "" && a.Type != "external_account" {
+		return nil, fmt.Errorf("unsupported credentials type %s, expected external_account", a.Type)
+	}
+	if a.Audience == "" || a.SubjectTokenType == "" || a.TokenURL == "" {
+		return nil, fmt.Errorf("audience, subject_token_type and token_url are required")
+	}
+	source := a.CredentialSource
+	if (source.File == "") == (source.URL == "") {
+		return nil, fmt.Errorf("credential_source requires either file or url")
+	}
+	switch source.Format.Type {
+	case "", "text":
+	case "json":
+		if source.Format.SubjectTokenFieldName == "" {
+			return nil, fmt.Errorf("credential_source of json format requires subject_token_field_name")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported credential_source format %s, expected text or json", source.Format.Type)
+	}
+	return &a, nil
+}
+
+// federatedTokenSource exchanges subject token of external identity for Google access token
+type federatedTokenSource struct {
+	connection string
+	account    *externalAccount
+	client     *http.Client
+}
+
+func (s *federatedTokenSource) Token() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), federationTimeout)
+	defer cancel()
+	subjectToken, err := s.subjectToken(ctx)
+	if err != nil {
+		return nil, &CredentialsError{Connection: s.connection, Stage: "subject token", Err: err}
+	}
+	start := time.Now()
+	token, err := s.exchange(ctx, subjectToken)
+	metrics.Observe(metrics.BigQuery, "token_exchange", start, err)
+	if err != nil {
+		return nil, &CredentialsError{Connection: s.connection, Stage: "token exchange", Err: err}
+	}
+	if s.account.ServiceAccountImpersonationURL == "" {
+		return token, nil
+	}
+	token, err = s.impersonate(ctx, token)
+	if err != nil {
+		return nil, &CredentialsError{Connection: s.connection, Stage: "impersonation", Err: err}
+	}
+	return token, nil
+}
+
+// subjectToken of external identity, e.g. OIDC token of GitHub Actions
+func (s *federatedTokenSource) subjectToken(ctx context.Context) (string, error) {
+	source := s.account.CredentialSource
+	var content []byte
+	var err error
+	if source.File != "" {
+		content, err = ioutil.ReadFile(source.File)
+	} else {
+		content, err = s.fetchSubjectToken(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+	if source.Format.Type != "json" {
+		return strings.TrimSpace(string(content)), nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return "", fmt.Errorf("invalid subject token JSON: %s", err)
+	}
+	token, ok := fields[source.Format.SubjectTokenFieldName].(string)
+	if !ok || token == "" {
+		return "", fmt.Errorf("subject token JSON has no %s field", source.Format.SubjectTokenFieldName)
+	}
+	return token, nil
+}
+
+func (s *federatedTokenSource) fetchSubjectToken(ctx context.Context) ([]byte, error) {
+	source := s.account.CredentialSource
+	req, err := http.NewRequest(http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range source.Headers {
+		req.Header.Set(name, value)
+	}
+	return s.do(req.WithContext(ctx))
+}
+
+// stsResponse of token exchange
+type stsResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+func (s *federatedTokenSource) exchange(ctx context.Context, subjectToken string) (*oauth2.Token, error) {
+	form := url.Values{
+		"grant_type":           {tokenExchangeGrant},
+		"audience":             {s.account.Audience},
+		"scope":                {cloudPlatformScope},
+		"requested_token_type": {accessTokenType},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {s.account.SubjectTokenType},
+	}
+	req, err := http.NewRequest(http.MethodPost, s.account.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, err := s.do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var res stsResponse
+	if err := json.Unmarshal(body, &res); err != nil || res.AccessToken == "" {
+		return nil, fmt.Errorf("invalid token exchange response")
+	}
+	return &oauth2.Token{
+		AccessToken: res.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Duration(res.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// impersonationResponse of generateAccessToken of IAM credentials API
+type impersonationResponse struct {
+	AccessToken string    `json:"accessToken"`
+	ExpireTime  time.Time `json:"expireTime"`
+}
+
+// impersonate service account with federated token, so roles of service account apply to queries
+func (s *federatedTokenSource) impersonate(ctx context.Context, federated *oauth2.Token) (*oauth2.Token, error) {
+	b, err := json.Marshal(map[string]interface{}{"scope": []string{cloudPlatformScope}})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.account.ServiceAccountImpersonationURL, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	federated.SetAuthHeader(req)
+	body, err := s.do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	var res impersonationResponse
+	if err := json.Unmarshal(body, &res); err != nil || res.AccessToken == "" {
+		return nil, fmt.Errorf("invalid impersonation response")
+	}
+	return &oauth2.Token{AccessToken: res.AccessToken, TokenType: "Bearer", Expiry: res.ExpireTime}, nil
+}
+
+// do request, response other than 200 is error with body of response, which has no token
+func (s *federatedTokenSource) do(req *http.Request) ([]byte, error) {
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, maxFederationResponse))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded %d: %s", req.URL.Host, res.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// Connections of BigQuery jobs with federated credentials by name; token source of connection is created
+// on first use and cached, token is exchanged again when it expires
+type Connections struct {
+	refs    map[string]string
+	mutex   sync.Mutex
+	sources map[string]oauth2.TokenSource
+	// client of token exchange, replaced in tests
+	client *http.Client
+	// load credentials JSON of reference, file path or secret reference
+	load func(ctx context.Context, ref string) ([]byte, error)
+	// reservations and allowlists of datasets of jobs by connection, empty connection is credentials of instance
+	reservations map[string]string
+	allowlists   map[string]DatasetAllowlist
+}
+
+// loadCredentials of file path or secret reference
+func loadCredentials(ctx context.Context, ref string) ([]byte, error) {
+	if secrets.IsReference(ref) {
+		value, err := secrets.Resolve(ctx, ref)
+		return []byte(value), err
+	}
+	return ioutil.ReadFile(ref)
+}
+
+// parseConnections of DEKART_BIGQUERY_CONNECTIONS, comma separated name=credentials where credentials is
+// path of external account credentials JSON or secret reference of it
+func parseConnections(value string) (*Connections, error) {
+	c := &Connections{
+		refs:    map[string]string{},
+		sources: map[string]oauth2.TokenSource{},
+		client:  &http.Client{},
+		load:    loadCredentials,
+	}
+	if strings.TrimSpace(value) == "" {
+		return c, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 || !connectionNameRe.MatchString(parts[0]) || parts[1] == "" {
+			return nil, fmt.Errorf("invalid DEKART_BIGQUERY_CONNECTIONS entry %q, expected name=credentials with name of letters, digits, _ and -", entry)
+		}
+		if _, ok := c.refs[parts[0]]; ok {
+			return nil, fmt.Errorf("duplicate connection %s", parts[0])
+		}
+		c.refs[parts[0]] = parts[1]
+	}
+	return c, nil
+}
+
+func connections() *Connections {
+	c, err := parseConnections(os.Getenv("DEKART_BIGQUERY_CONNECTIONS"))
+	if err == nil {
+		err = c.setReservations(os.Getenv("DEKART_BIGQUERY_RESERVATION"), os.Getenv("DEKART_BIGQUERY_CONNECTION_RESERVATIONS"))
+	}
+	if err == nil {
+		err = c.setAllowlists(os.Getenv("DEKART_BIGQUERY_DATASETS"), os.Getenv("DEKART_BIGQUERY_CONNECTION_DATASETS"))
+	}
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return c
+}
+
+// Has connection of name
+func (c *Connections) Has(name string) bool {
+	_, ok := c.refs[name]
+	return ok
+}
+
+// TokenSource of connection; credentials which can't be loaded are loaded again on next use
+func (c *Connections) TokenSource(ctx context.Context, name string) (oauth2.TokenSource, error) {
+	ref, ok := c.refs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown connection %s, connections are configured with DEKART_BIGQUERY_CONNECTIONS", name)
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if source, ok := c.sources[name]; ok {
+		return source, nil
+	}
+	content, err := c.load(ctx, ref)
+	if err != nil {
+		return nil, &CredentialsError{Connection: name, Stage: "credentials config", Err: err}
+	}
+	account, err := parseExternalAccount(content)
+	if err != nil {
+		return nil, &CredentialsError{Connection: name, Stage: "credentials config", Err: err}
+	}
+	source := oauth2.ReuseTokenSource(nil, &federatedTokenSource{connection: name, account: account, client: c.client})
+	c.sources[name] = source
+	return source, nil
+}
+
+// SetConnections of jobs created after call
+func (s *Store) SetConnections(c *Connections) {
+	s.mutex.Lock()
+	s.connections = c
+	s.mutex.Unlock()
+}
+
+// HasConnection configured in DEKART_BIGQUERY_CONNECTIONS or DEKART_SQL_CONNECTIONS
+func (s *Store) HasConnection(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.connections.Has(name) || s.sqlConnections.Has(name)
+}
+
+// credentialsError of connection which failed token exchange replaces error of request it failed
+func credentialsError(err error) error {
+	var credentialsErr *CredentialsError
+	if errors.As(err, &credentialsErr) {
+		return credentialsErr
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+// fakeSTS exchanges subject token for access token, fails exchanges with status when it's set
+type fakeSTS struct {
+	mutex     sync.Mutex
+	exchanges int
+	status    int
+	forms     []map[string]string
+}
+
+func (f *fakeSTS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if r.URL.Path == "/impersonate" {
+		if r.Header.Get("Authorization") != "Bearer federated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"accessToken":"impersonated","expireTime":"2100-01-01T00:00:00Z"}`)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	form := map[string]string{}
+	for name := range r.PostForm {
+		form[name] = r.PostForm.Get(name)
+	}
+	f.forms = append(f.forms, form)
+	f.exchanges++
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		fmt.Fprint(w, `{"error":"invalid_grant","error_description":"audience does not match"}`)
+		return
+	}
+	fmt.Fprint(w, `{"access_token":"federated","expires_in":3600,"token_type":"Bearer"}`)
+}
+
+// fixtureConnections of connection github with credentials JSON exchanging subject token file with sts
+func fixtureConnections(t *testing.T, sts *httptest.Server, impersonate bool) *Connections {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenFile, []byte("github-oidc-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	impersonationURL := ""
+	if impersonate {
+		impersonationURL = sts.URL + "/impersonate"
+	}
+	credentials := fmt.Sprintf(`{
+		"type": "external_account",
+		"audience": "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/github/providers/actions",
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url": %q,
+		"service_account_impersonation_url": %q,
+		"credential_source": {"file": %q}
+	}`, sts.URL+"/token", impersonationURL, tokenFile)
+	credentialsFile := filepath.Join(dir, "credentials.json")
+	if err := ioutil.WriteFile(credentialsFile, []byte(credentials), 0600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := parseConnections("github=" + credentialsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestParseConnections(t *testing.T) {
+	c, err := parseConnections(" ci=/etc/dekart/ci.json, nightly=gsm://projects/p/secrets/nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Has("ci") || !c.Has("nightly") || c.Has("other") {
+		t.Errorf("unexpected connections %v", c.refs)
+	}
+	for _, value := range []string{"ci", "ci=", "c i=/a.json", "ci=/a.json,ci=/b.json"} {
+		if _, err := parseConnections(value); err == nil {
+			t.Errorf("expected error of %q", value)
+		}
+	}
+	for name, credentials := range map[string]string{
+		"service account": `{"type":"service_account"}`,
+		"no audience":     `{"subject_token_type":"jwt","token_url":"https://sts","credential_source":{"file":"token"}}`,
+		"no source":       `{"audience":"a","subject_token_type":"jwt","token_url":"https://sts"}`,
+		"json no field":   `{"audience":"a","subject_token_type":"jwt","token_url":"https://sts","credential_source":{"url":"https://token","format":{"type":"json"}}}`,
+	} {
+		if _, err := parseExternalAccount([]byte(credentials)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestConnectionTokenSource(t *testing.T) {
+	sts := &fakeSTS{}
+	server := httptest.NewServer(sts)
+	defer server.Close()
+	c := fixtureConnections(t, server, false)
+	source, err := c.TokenSource(context.Background(), "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "federated" {
+			t.Errorf("unexpected token %v", token)
+		}
+	}
+	// token is cached until it expires, token source is cached per connection
+	if cached, _ := c.TokenSource(context.Background(), "github"); cached != source || sts.exchanges != 1 {
+		t.Errorf("expected cached token, got %d exchanges", sts.exchanges)
+	}
+	form := sts.forms[0]
+	if form["subject_token"] != "github-oidc-token" || form["grant_type"] != tokenExchangeGrant || !strings.HasSuffix(form["audience"], "/providers/actions") {
+		t.Errorf("unexpected token exchange %v", form)
+	}
+	if _, err := c.TokenSource(context.Background(), "other"); err == nil || !strings.Contains(err.Error(), "unknown connection other") {
+		t.Errorf("expected unknown connection, got %v", err)
+	}
+
+	impersonated, err := fixtureConnections(t, server, true).TokenSource(context.Background(), "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, err := impersonated.Token(); err != nil || token.AccessToken != "impersonated" {
+		t.Errorf("expected impersonated token, got %v %v", token, err)
+	}
+}
+
+func TestConnectionCredentialsError(t *testing.T) {
+	sts := &fakeSTS{status: http.StatusBadRequest}
+	server := httptest.NewServer(sts)
+	defer server.Close()
+	var authorized bool
+	bigqueryServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorized = true
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer bigqueryServer.Close()
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, append(opts, option.WithEndpoint(bigqueryServer.URL))...)
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	store := NewStore()
+	store.SetConnections(fixtureConnections(t, server, false))
+	job := store.New(context.Background(), "report", "query")
+	statuses := collectStatus(job)
+	err := job.Run("select 1", RunOptions{Connection: "github"}, nil)
+	var credentialsErr *CredentialsError
+	if !errors.As(err, &credentialsErr) || credentialsErr.Stage != "token exchange" {
+		t.Fatalf("expected token exchange error, got %v", err)
+	}
+	<-statuses
+	// error of token exchange is not reported as error of query request
+	if !strings.HasPrefix(job.Err(), "cannot get credentials of connection github, token exchange failed") || !strings.Contains(job.Err(), "audience does not match") {
+		t.Errorf("unexpected job error %q", job.Err())
+	}
+	if authorized {
+		t.Error("expected no BigQuery request without token")
+	}
+}
@@ -0,0 +1,206 @@
+package job
+
+import (
+	"dekart/src/server/parquet"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ParquetContentType of result stored in Parquet format
+const ParquetContentType = "application/vnd.apache.parquet"
+
+// geoParquetVersion of geo metadata written to Parquet results
+const geoParquetVersion = "1.0.0"
+
+// crsEPSG4326 is PROJJSON of WGS 84, CRS of BigQuery GEOGRAPHY values.
+// GeoParquet stores coordinates as lon/lat regardless of axis order of CRS
+var crsEPSG4326 = json.RawMessage(`{
+"$schema":"https://proj.org/schemas/v0.7/projjson.schema.json",
+"type":"GeographicCRS",
+"name":"WGS 84",
+"datum":{"type":"GeodeticReferenceFrame","name":"World Geodetic System 1984","ellipsoid":{"name":"WGS 84","semi_major_axis":6378137,"inverse_flattening":298.257223563}},
+"coordinate_system":{"subtype":"ellipsoidal","axis":[
+{"name":"Geodetic latitude","abbreviation":"Lat","direction":"north","unit":"degree"},
+{"name":"Geodetic longitude","abbreviation":"Lon","direction":"east","unit":"degree"}]},
+"id":{"authority":"EPSG","code":4326}}`)
+
+// geoMetadata of GeoParquet file
+type geoMetadata struct {
+	Version       string                       `json:"version"`
+	PrimaryColumn string                       `json:"primary_column"`
+	Columns       map[string]geoColumnMetadata `json:"columns"`
+}
+
+type geoColumnMetadata struct {
+	Encoding      string          `json:"encoding"`
+	GeometryTypes []string        `json:"geometry_types"`
+	CRS           json.RawMessage `json:"crs"`
+	// Edges of BigQuery GEOGRAPHY are geodesic
+	Edges string `json:"edges"`
+	// BBox is omitted when column has no coordinates
+	BBox []float64 `json:"bbox,omitempty"`
+}
+
+// geoColumn of result, bbox and types are collected while rows are written
+type geoColumn struct {
+	name  string
+	bbox  bbox
+	types map[string]bool
+}
+
+// parquetEncoder writes result as Parquet; result with GEOGRAPHY columns is GeoParquet with WKB geometries.
+// Schema is read on first row or on close of empty result, as RowIterator has schema after first Next
+type parquetEncoder struct {
+	w       io.Writer
+	schema  func() bigquery.Schema
+	writer  *parquet.Writer
+	columns []parquet.Column
+	// geo columns by field index, in schema order
+	geo     map[int]*geoColumn
+	geoList []*geoColumn
+	row     []interface{}
+	// decimalScales of columns, NUMERIC and BIGNUMERIC are written with all digits of scale
+	decimalScales []int
+	transport     *jobTransport
+}
+
+func newParquetEncoder(w io.Writer, schema func() bigquery.Schema, transport *jobTransport) *parquetEncoder {
+	return &parquetEncoder{w: w, schema: schema, transport: transport}
+}
+
+// parquetColumn of BigQuery field; repeated fields, records and time types are written as strings like in CSV
+func parquetColumn(field *bigquery.FieldSchema) parquet.Column {
+	column := parquet.Column{Name: field.Name, Type: parquet.ByteArray}
+	if field.Repeated {
+		column.UTF8 = true
+		return column
+	}
+	switch field.Type {
+	case bigquery.IntegerFieldType:
+		column.Type = parquet.Int64
+	case bigquery.FloatFieldType:
+		column.Type = parquet.Double
+	case bigquery.BooleanFieldType:
+		column.Type = parquet.Boolean
+	case bigquery.BytesFieldType, bigquery.GeographyFieldType:
+	default:
+		column.UTF8 = true
+	}
+	return column
+}
+
+func (e *parquetEncoder) init() {
+	fields := e.schema()
+	e.geo = map[int]*geoColumn{}
+	e.columns = make([]parquet.Column, len(fields))
+	for i, field := range fields {
+		e.columns[i] = parquetColumn(field)
+		if field.Type == bigquery.GeographyFieldType && !field.Repeated {
+			column := &geoColumn{name: field.Name, bbox: newBBox(), types: map[string]bool{}}
+			e.geo[i] = column
+			e.geoList = append(e.geoList, column)
+		}
+	}
+	e.decimalScales = e.transport.decimalScales(fields)
+	e.writer = parquet.NewWriter(e.w, e.columns)
+	e.row = make([]interface{}, len(e.columns))
+}
+
+// value of BigQuery field for Parquet column
+func (e *parquetEncoder) value(i int, v bigquery.Value) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if geo, ok := e.geo[i]; ok {
+		wkt, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %T value of GEOGRAPHY column %s", v, geo.name)
+		}
+		wkb, typeName, bbox, err := wktToWKB(wkt)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %s", geo.name, err)
+		}
+		geo.types[typeName] = true
+		geo.bbox.extend(bbox)
+		return wkb, nil
+	}
+	if column := e.columns[i]; column.Type != parquet.ByteArray || !column.UTF8 {
+		// type of value is checked by writer
+		return v, nil
+	}
+	if scale := e.decimalScales[i]; scale >= 0 {
+		if s, ok := formatDecimalCell(v, scale, true); ok {
+			return s, nil
+		}
+	}
+	if r, ok := v.(*big.Rat); ok {
+		return bigquery.NumericString(r), nil
+	}
+	return fmt.Sprintf("%v", v), nil
+}
+
+func (e *parquetEncoder) write(row []bigquery.Value) error {
+	if e.writer == nil {
+		e.init()
+	}
+	if len(row) != len(e.row) {
+		return fmt.Errorf("row has %d values, schema has %d fields", len(row), len(e.row))
+	}
+	for i, v := range row {
+		value, err := e.value(i, v)
+		if err != nil {
+			return err
+		}
+		e.row[i] = value
+	}
+	return e.writer.Write(e.row)
+}
+
+// geoMetadata of geo columns, first one is primary; nil without geo columns
+func (e *parquetEncoder) geoMetadata() *geoMetadata {
+	if len(e.geoList) == 0 {
+		return nil
+	}
+	metadata := &geoMetadata{
+		Version:       geoParquetVersion,
+		PrimaryColumn: e.geoList[0].name,
+		Columns:       map[string]geoColumnMetadata{},
+	}
+	for _, column := range e.geoList {
+		types := make([]string, 0, len(column.types))
+		for typeName := range column.types {
+			types = append(types, typeName)
+		}
+		sort.Strings(types)
+		columnMetadata := geoColumnMetadata{
+			Encoding:      "WKB",
+			GeometryTypes: types,
+			CRS:           crsEPSG4326,
+			Edges:         "spherical",
+		}
+		if !column.bbox.empty {
+			columnMetadata.BBox = []float64{column.bbox.min[0], column.bbox.min[1], column.bbox.max[0], column.bbox.max[1]}
+		}
+		metadata.Columns[column.name] = columnMetadata
+	}
+	return metadata
+}
+
+func (e *parquetEncoder) close() error {
+	if e.writer == nil {
+		e.init()
+	}
+	if metadata := e.geoMetadata(); metadata != nil {
+		b, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		e.writer.SetMetadata("geo", string(b))
+	}
+	return e.writer.Close()
+}
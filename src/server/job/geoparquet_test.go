@@ -0,0 +1,143 @@
+package job
+
+import (
+	"bytes"
+	"dekart/src/proto"
+	"dekart/src/server/parquet"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// writeParquet result of rows with schema, returns file metadata
+func writeParquet(t *testing.T, schema bigquery.Schema, rows [][]bigquery.Value) *parquet.FileMetadata {
+	job := readingJob(NewStore())
+	job.resultFormat = proto.ResultFormat_RESULT_FORMAT_PARQUET
+	job.totalRows = int64(len(rows))
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	job.writeResult(&fakeIterator{rows: rows}, func() bigquery.Schema { return schema }, w, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v: %s", s, job.Err())
+	}
+	if job.GetResultSize() != int64(w.Len()) {
+		t.Errorf("expected result size %d, got %d", w.Len(), job.GetResultSize())
+	}
+	metadata, err := parquet.ReadMetadata(bytes.NewReader(w.Bytes()), int64(w.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata
+}
+
+// geoColumnSpec of fields checked by test, crs is checked by id
+type geoColumnSpec struct {
+	Encoding      string    `json:"encoding"`
+	GeometryTypes []string  `json:"geometry_types"`
+	Edges         string    `json:"edges"`
+	BBox          []float64 `json:"bbox"`
+	CRS           struct {
+		Type string `json:"type"`
+		ID   struct {
+			Authority string `json:"authority"`
+			Code      int    `json:"code"`
+		} `json:"id"`
+	} `json:"crs"`
+}
+
+func TestGeoParquetResult(t *testing.T) {
+	schema := bigquery.Schema{
+		{Name: "name", Type: bigquery.StringFieldType},
+		{Name: "location", Type: bigquery.GeographyFieldType},
+		{Name: "count", Type: bigquery.IntegerFieldType},
+		{Name: "area", Type: bigquery.GeographyFieldType},
+		{Name: "price", Type: bigquery.NumericFieldType},
+	}
+	metadata := writeParquet(t, schema, [][]bigquery.Value{
+		{"Berlin", "POINT(13.4 52.5)", int64(1), "POLYGON((13 52, 14 52, 14 53, 13 52))", big.NewRat(3, 2)},
+		{"Paris", "POINT(2.35 48.85)", nil, nil, nil},
+		{nil, "MULTIPOINT(-3.7 40.4, 2 3)", int64(3), nil, nil},
+	})
+	if metadata.NumRows != 3 {
+		t.Errorf("expected 3 rows, got %d", metadata.NumRows)
+	}
+	expectedColumns := []parquet.Column{
+		{Name: "name", Type: parquet.ByteArray, UTF8: true},
+		{Name: "location", Type: parquet.ByteArray},
+		{Name: "count", Type: parquet.Int64},
+		{Name: "area", Type: parquet.ByteArray},
+		{Name: "price", Type: parquet.ByteArray, UTF8: true},
+	}
+	if !reflect.DeepEqual(metadata.Columns, expectedColumns) {
+		t.Errorf("expected columns %v, got %v", expectedColumns, metadata.Columns)
+	}
+	var geo struct {
+		Version       string                   `json:"version"`
+		PrimaryColumn string                   `json:"primary_column"`
+		Columns       map[string]geoColumnSpec `json:"columns"`
+	}
+	if err := json.Unmarshal([]byte(metadata.KeyValue["geo"]), &geo); err != nil {
+		t.Fatalf("invalid geo metadata %q: %s", metadata.KeyValue["geo"], err)
+	}
+	// first geography column is primary, all are listed
+	if geo.Version != "1.0.0" || geo.PrimaryColumn != "location" || len(geo.Columns) != 2 {
+		t.Fatalf("unexpected geo metadata %+v", geo)
+	}
+	location, area := geo.Columns["location"], geo.Columns["area"]
+	if !reflect.DeepEqual(location.GeometryTypes, []string{"MultiPoint", "Point"}) ||
+		!reflect.DeepEqual(area.GeometryTypes, []string{"Polygon"}) {
+		t.Errorf("unexpected geometry types %v, %v", location.GeometryTypes, area.GeometryTypes)
+	}
+	if !reflect.DeepEqual(location.BBox, []float64{-3.7, 3, 13.4, 52.5}) || !reflect.DeepEqual(area.BBox, []float64{13, 52, 14, 53}) {
+		t.Errorf("unexpected bbox %v, %v", location.BBox, area.BBox)
+	}
+	for name, column := range geo.Columns {
+		if column.Encoding != "WKB" || column.Edges != "spherical" || column.CRS.Type != "GeographicCRS" ||
+			column.CRS.ID.Authority != "EPSG" || column.CRS.ID.Code != 4326 {
+			t.Errorf("%s: unexpected column metadata %+v", name, column)
+		}
+	}
+}
+
+func TestGeoParquetEmptyResult(t *testing.T) {
+	metadata := writeParquet(t, bigquery.Schema{{Name: "geometry", Type: bigquery.GeographyFieldType}}, nil)
+	var geo struct {
+		Columns map[string]map[string]interface{} `json:"columns"`
+	}
+	if err := json.Unmarshal([]byte(metadata.KeyValue["geo"]), &geo); err != nil {
+		t.Fatal(err)
+	}
+	// empty column has no bbox and unknown geometry types
+	column := geo.Columns["geometry"]
+	if _, ok := column["bbox"]; ok || len(column["geometry_types"].([]interface{})) != 0 {
+		t.Errorf("unexpected column metadata %v", column)
+	}
+}
+
+func TestParquetResultWithoutGeography(t *testing.T) {
+	metadata := writeParquet(t, fakeSchema(), [][]bigquery.Value{{int64(1), "a"}})
+	if _, ok := metadata.KeyValue["geo"]; ok {
+		t.Error("expected no geo metadata without GEOGRAPHY columns")
+	}
+	if metadata.NumRows != 1 || len(metadata.Columns) != 2 {
+		t.Errorf("unexpected metadata %+v", metadata)
+	}
+}
+
+func TestGeoParquetInvalidGeography(t *testing.T) {
+	job := readingJob(NewStore())
+	job.resultFormat = proto.ResultFormat_RESULT_FORMAT_PARQUET
+	statuses := collectStatus(job)
+	cleaned := false
+	schema := func() bigquery.Schema { return bigquery.Schema{{Name: "geometry", Type: bigquery.GeographyFieldType}} }
+	job.writeResult(&fakeIterator{rows: [][]bigquery.Value{{"POINT Z (1 2 3)"}}}, schema, &fakeResultWriter{}, func() { cleaned = true })
+	if s := <-statuses; len(s) != 1 || s[0] != 0 || !cleaned {
+		t.Errorf("expected failed status and cleanup, got %v", s)
+	}
+	if job.GetStatus().ResultFormat != proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		t.Error("expected result format in snapshot")
+	}
+}
@@ -0,0 +1,289 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"github.com/uber/h3-go/v3"
+)
+
+// maxH3Cells keeps memory of aggregation bounded, finer resolution should be used on smaller area
+const maxH3Cells = 1000000
+
+// ValidateH3Aggregation options before job is started
+func ValidateH3Aggregation(options *proto.H3Aggregation) error {
+	if options.Resolution < 0 || options.Resolution > 15 {
+		return fmt.Errorf("invalid H3 resolution %d, expected 0-15", options.Resolution)
+	}
+	if options.GeographyColumn == "" && (options.LatColumn == "" || options.LngColumn == "") {
+		return fmt.Errorf("H3 aggregation requires geography_column or lat_column and lng_column")
+	}
+	for _, metric := range options.Metrics {
+		if metric.Column == "" {
+			return fmt.Errorf("H3 metric requires column")
+		}
+		if metric.Function != proto.H3Metric_FUNCTION_SUM && metric.Function != proto.H3Metric_FUNCTION_AVG {
+			return fmt.Errorf("invalid H3 metric function %s of %s", metric.Function, metric.Column)
+		}
+	}
+	return nil
+}
+
+// h3Cell totals of rows in cell
+type h3Cell struct {
+	count int64
+	sums  []float64
+	// counts of not null metric values, for averages
+	counts []int64
+}
+
+// h3Aggregator aggregates rows into H3 cells while result is streamed
+type h3Aggregator struct {
+	options      *proto.H3Aggregation
+	latIndex     int
+	lngIndex     int
+	geoIndex     int
+	metricIndex  []int
+	cells        map[h3.H3Index]*h3Cell
+	skipped      int64
+	schemaLoaded bool
+	// reserve memory of new cell, nil without budget; reserved bytes of cells
+	reserve  func(n int64) bool
+	reserved int64
+}
+
+// errH3MemoryBudget when memory budget has no room for new cell, aggregation is skipped
+var errH3MemoryBudget = errors.New("memory budget exhausted")
+
+func newH3Aggregator(options *proto.H3Aggregation) *h3Aggregator {
+	return &h3Aggregator{
+		options: options,
+		cells:   make(map[h3.H3Index]*h3Cell),
+	}
+}
+
+// AggregateH3 of result into obj, stored as resultID in addition to raw result; call before Run
+func (job *Job) AggregateH3(options *proto.H3Aggregation, obj *storage.ObjectHandle, resultID string) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	job.h3 = newH3Aggregator(options)
+	job.h3.reserve = func(n int64) bool {
+		return job.reserveMemory(memoryH3, n)
+	}
+	job.h3ResultID = resultID
+	job.h3Writer = func(ctx context.Context) resultWriter {
+		return instrumentWriter(obj.NewWriter(ctx), metrics.GCS, "h3_upload")
+	}
+}
+
+// GetH3ResultID of aggregated result, nil when aggregation was not requested or job is not done
+func (job *Job) GetH3ResultID() *string {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.resultID == nil || job.h3 == nil {
+		return nil
+	}
+	return &job.h3ResultID
+}
+
+func columnIndex(schema bigquery.Schema, name string) (int, error) {
+	for i, field := range schema {
+		if field.Name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("H3 aggregation column %s not found in result", name)
+}
+
+func (a *h3Aggregator) loadSchema(schema bigquery.Schema) error {
+	var err error
+	a.schemaLoaded = true
+	if a.options.GeographyColumn != "" {
+		a.geoIndex, err = columnIndex(schema, a.options.GeographyColumn)
+		if err != nil {
+			return err
+		}
+	} else {
+		a.latIndex, err = columnIndex(schema, a.options.LatColumn)
+		if err != nil {
+			return err
+		}
+		a.lngIndex, err = columnIndex(schema, a.options.LngColumn)
+		if err != nil {
+			return err
+		}
+	}
+	a.metricIndex = make([]int, len(a.options.Metrics))
+	for i, metric := range a.options.Metrics {
+		a.metricIndex[i], err = columnIndex(schema, metric.Column)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pointRe is WKT of GEOGRAPHY point as returned by BigQuery
+var pointRe = regexp.MustCompile(`(?i)^\s*POINT\s*\(\s*(\S+)\s+(\S+)\s*\)\s*$`)
+
+// location of row, false when row has no point
+func (a *h3Aggregator) location(row []bigquery.Value) (h3.GeoCoord, bool) {
+	if a.options.GeographyColumn != "" {
+		wkt, ok := row[a.geoIndex].(string)
+		if !ok {
+			return h3.GeoCoord{}, false
+		}
+		m := pointRe.FindStringSubmatch(wkt)
+		if m == nil {
+			return h3.GeoCoord{}, false
+		}
+		lng, lngErr := strconv.ParseFloat(m[1], 64)
+		lat, latErr := strconv.ParseFloat(m[2], 64)
+		return h3.GeoCoord{Latitude: lat, Longitude: lng}, lngErr == nil && latErr == nil
+	}
+	lat, latOk := toFloat(row[a.latIndex])
+	lng, lngOk := toFloat(row[a.lngIndex])
+	return h3.GeoCoord{Latitude: lat, Longitude: lng}, latOk && lngOk
+}
+
+// toFloat of numeric BigQuery value, false for null and not numeric values
+func toFloat(v bigquery.Value) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// add row to its cell; rows without location are skipped
+func (a *h3Aggregator) add(row []bigquery.Value, schema func() bigquery.Schema) error {
+	if !a.schemaLoaded {
+		if err := a.loadSchema(schema()); err != nil {
+			return err
+		}
+	}
+	location, ok := a.location(row)
+	if !ok || location.Latitude < -90 || location.Latitude > 90 || location.Longitude < -180 || location.Longitude > 180 {
+		a.skipped++
+		return nil
+	}
+	index := h3.FromGeo(location, int(a.options.Resolution))
+	cell, ok := a.cells[index]
+	if !ok {
+		if len(a.cells) >= maxH3Cells {
+			return fmt.Errorf("H3 aggregation exceeds %d cells, use lower resolution", maxH3Cells)
+		}
+		if a.reserve != nil {
+			size := int64(h3CellBytes + h3MetricBytes*len(a.metricIndex))
+			if !a.reserve(size) {
+				return errH3MemoryBudget
+			}
+			a.reserved += size
+		}
+		cell = &h3Cell{
+			sums:   make([]float64, len(a.metricIndex)),
+			counts: make([]int64, len(a.metricIndex)),
+		}
+		a.cells[index] = cell
+	}
+	cell.count++
+	for i, column := range a.metricIndex {
+		if v, ok := toFloat(row[column]); ok {
+			cell.sums[i] += v
+			cell.counts[i]++
+		}
+	}
+	return nil
+}
+
+// write aggregated cells as CSV ordered by cell
+func (a *h3Aggregator) write(w io.Writer) error {
+	csvWriter := csv.NewWriter(w)
+	header := []string{"h3", "count"}
+	for _, metric := range a.options.Metrics {
+		function := strings.ToLower(strings.TrimPrefix(metric.Function.String(), "FUNCTION_"))
+		header = append(header, fmt.Sprintf("%s_%s", function, metric.Column))
+	}
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	indexes := make([]h3.H3Index, 0, len(a.cells))
+	for index := range a.cells {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	for _, index := range indexes {
+		cell := a.cells[index]
+		csvRow := []string{h3.ToString(index), strconv.FormatInt(cell.count, 10)}
+		for i, metric := range a.options.Metrics {
+			value := cell.sums[i]
+			if metric.Function == proto.H3Metric_FUNCTION_AVG {
+				if cell.counts[i] == 0 {
+					csvRow = append(csvRow, "")
+					continue
+				}
+				value = value / float64(cell.counts[i])
+			}
+			csvRow = append(csvRow, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+		if err := csvWriter.Write(csvRow); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// skipH3 aggregation which exceeded memory budget, cells are released and raw result is stored without it
+func (job *Job) skipH3() {
+	job.mutex.Lock()
+	a := job.h3
+	job.h3 = nil
+	job.mutex.Unlock()
+	job.releaseMemory(a.reserved)
+	job.AddWarning(WarningH3Skipped, "H3 aggregation was skipped, server has no memory for its cells; result is stored without it")
+}
+
+// writeH3Result after raw result is stored
+func (job *Job) writeH3Result() error {
+	if job.h3 == nil {
+		return nil
+	}
+	ctx, abortUpload := context.WithCancel(job.Ctx)
+	defer abortUpload()
+	w := job.h3Writer(ctx)
+	if err := job.h3.write(w); err != nil {
+		abortUpload()
+		w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if job.h3.skipped > 0 {
+		job.AddWarning(WarningH3RowsSkipped, fmt.Sprintf("%d rows without valid location were not aggregated into H3 cells", job.h3.skipped))
+	}
+	job.logger.Info().Int("cells", len(job.h3.cells)).Int64("skipped", job.h3.skipped).Msg("H3 aggregation done")
+	return nil
+}
@@ -0,0 +1,131 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/uber/h3-go/v3"
+)
+
+func pointsSchema() bigquery.Schema {
+	return bigquery.Schema{{Name: "lat"}, {Name: "lng"}, {Name: "value"}, {Name: "geo"}}
+}
+
+func pointsIterator() *fakeIterator {
+	return &fakeIterator{rows: [][]bigquery.Value{
+		{52.52, 13.405, int64(1), "POINT(13.405 52.52)"},
+		{52.5201, 13.4051, 3.0, "POINT(13.4051 52.5201)"},
+		{52.52, 13.405, nil, "POINT(13.405 52.52)"},
+		{40.7128, -74.006, int64(10), "POINT(-74.006 40.7128)"},
+		{nil, nil, int64(100), nil},
+	}}
+}
+
+func TestH3Aggregation(t *testing.T) {
+	berlin := h3.ToString(h3.FromGeo(h3.GeoCoord{Latitude: 52.52, Longitude: 13.405}, 7))
+	newYork := h3.ToString(h3.FromGeo(h3.GeoCoord{Latitude: 40.7128, Longitude: -74.006}, 7))
+	metrics := []*proto.H3Metric{
+		{Column: "value", Function: proto.H3Metric_FUNCTION_SUM},
+		{Column: "value", Function: proto.H3Metric_FUNCTION_AVG},
+	}
+	for name, options := range map[string]*proto.H3Aggregation{
+		"lat lng":   {LatColumn: "lat", LngColumn: "lng", Resolution: 7, Metrics: metrics},
+		"geography": {GeographyColumn: "geo", Resolution: 7, Metrics: metrics},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := newH3Aggregator(options)
+			it := pointsIterator()
+			for _, row := range it.rows {
+				if err := a.add(row, pointsSchema); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var out bytes.Buffer
+			if err := a.write(&out); err != nil {
+				t.Fatal(err)
+			}
+			expected := map[string]string{
+				berlin:  "3,4,2",
+				newYork: "1,10,10",
+			}
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(lines) != 3 || lines[0] != "h3,count,sum_value,avg_value" {
+				t.Fatalf("unexpected result %q", out.String())
+			}
+			for _, line := range lines[1:] {
+				cell := strings.SplitN(line, ",", 2)
+				if expected[cell[0]] != cell[1] {
+					t.Errorf("cell %s: expected %s, got %s", cell[0], expected[cell[0]], cell[1])
+				}
+			}
+			if a.skipped != 1 {
+				t.Errorf("expected row without location skipped, got %d", a.skipped)
+			}
+		})
+	}
+}
+
+func TestH3AggregationStoredWithResult(t *testing.T) {
+	job := readingJob(NewStore())
+	job.totalRows = 5
+	statuses := collectStatus(job)
+	aggregated := &fakeResultWriter{}
+	job.h3 = newH3Aggregator(&proto.H3Aggregation{LatColumn: "lat", LngColumn: "lng", Resolution: 5})
+	job.h3ResultID = "h3-result"
+	job.h3Writer = func(ctx context.Context) resultWriter { return aggregated }
+	w := &fakeResultWriter{}
+	job.writeResult(pointsIterator(), pointsSchema, w, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v %s", s, job.Err())
+	}
+	if !w.closed || !aggregated.closed || !strings.HasPrefix(aggregated.String(), "h3,count\n") {
+		t.Errorf("expected raw and aggregated results, got %q", aggregated.String())
+	}
+	if id := job.GetH3ResultID(); id == nil || *id != "h3-result" || *job.GetResultID() != job.ID {
+		t.Errorf("expected both result ids, got %v", id)
+	}
+	if warnings := job.GetStatus().Warnings; len(warnings) != 1 || warnings[0].Code != WarningH3RowsSkipped {
+		t.Errorf("expected warning of skipped row, got %v", warnings)
+	}
+}
+
+func TestH3AggregationUnknownColumn(t *testing.T) {
+	job := readingJob(NewStore())
+	statuses := collectStatus(job)
+	job.h3 = newH3Aggregator(&proto.H3Aggregation{GeographyColumn: "location", Resolution: 5})
+	job.h3Writer = func(ctx context.Context) resultWriter {
+		t.Error("unexpected aggregated result")
+		return &fakeResultWriter{}
+	}
+	cleaned := false
+	job.writeResult(pointsIterator(), pointsSchema, &fakeResultWriter{}, func() { cleaned = true })
+	if s := <-statuses; len(s) != 1 || s[0] != 0 {
+		t.Errorf("expected failed status, got %v", s)
+	}
+	if !cleaned || !strings.Contains(job.Err(), "location not found") {
+		t.Errorf("unexpected error %q", job.Err())
+	}
+	if job.GetH3ResultID() != nil {
+		t.Error("expected no aggregated result")
+	}
+}
+
+func TestValidateH3Aggregation(t *testing.T) {
+	for name, options := range map[string]*proto.H3Aggregation{
+		"resolution":    {GeographyColumn: "geo", Resolution: 16},
+		"no location":   {LatColumn: "lat", Resolution: 5},
+		"metric column": {GeographyColumn: "geo", Metrics: []*proto.H3Metric{{Function: proto.H3Metric_FUNCTION_SUM}}},
+		"function":      {GeographyColumn: "geo", Metrics: []*proto.H3Metric{{Column: "value"}}},
+	} {
+		if err := ValidateH3Aggregation(options); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if err := ValidateH3Aggregation(&proto.H3Aggregation{LatColumn: "lat", LngColumn: "lng", Resolution: 15}); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,164 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// emptyColumnName of column without letters or digits after renaming
+const emptyColumnName = "column"
+
+// ColumnName of result column renamed by header naming
+type ColumnName struct {
+	Name     string `json:"name"`
+	Original string `json:"original"`
+}
+
+// headerNaming of CSV result written with options
+func headerNaming(options *proto.SerializationOptions) proto.SerializationOptions_HeaderNaming {
+	if options == nil {
+		return proto.SerializationOptions_HEADER_NAMING_UNSPECIFIED
+	}
+	return options.HeaderNaming
+}
+
+// snakeCaseName in lowercase with words separated by single underscore, unicode letters are kept
+func snakeCaseName(name string) string {
+	var b strings.Builder
+	separate := false
+	var previous rune
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = b.Len() > 0
+			previous = r
+			continue
+		}
+		// camelCase starts word at upper case letter
+		if unicode.IsUpper(r) && (unicode.IsLower(previous) || unicode.IsDigit(previous)) && b.Len() > 0 {
+			separate = true
+		}
+		if separate {
+			b.WriteRune('_')
+			separate = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+		previous = r
+	}
+	if b.Len() == 0 {
+		return emptyColumnName
+	}
+	return b.String()
+}
+
+// strictName of ASCII letters, digits and underscores; other characters are replaced with underscore
+func strictName(name string) string {
+	var b strings.Builder
+	replaced := false
+	for _, r := range name {
+		if r == '_' || r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			replaced = false
+			continue
+		}
+		// run of other characters is one underscore
+		if !replaced {
+			b.WriteRune('_')
+			replaced = true
+		}
+	}
+	s := b.String()
+	if s == "" {
+		return emptyColumnName
+	}
+	if s[0] >= '0' && s[0] <= '9' {
+		return "_" + s
+	}
+	return s
+}
+
+// uniqueNames with suffixes _2, _3 of names equal ignoring case to earlier name; suffixed names don't take
+// name of other column, so renaming depends only on names and their order
+func uniqueNames(names []string) []string {
+	taken := make(map[string]bool, len(names))
+	for _, name := range names {
+		taken[strings.ToLower(name)] = true
+	}
+	used := make(map[string]bool, len(names))
+	unique := make([]string, len(names))
+	for i, name := range names {
+		if !used[strings.ToLower(name)] {
+			used[strings.ToLower(name)] = true
+			unique[i] = name
+			continue
+		}
+		for n := 2; ; n++ {
+			candidate := fmt.Sprintf("%s_%d", name, n)
+			if !used[strings.ToLower(candidate)] && !taken[strings.ToLower(candidate)] {
+				used[strings.ToLower(candidate)] = true
+				unique[i] = candidate
+				break
+			}
+		}
+	}
+	return unique
+}
+
+// HeaderNames of CSV result columns with header naming of options
+func HeaderNames(schema bigquery.Schema, options *proto.SerializationOptions) []string {
+	names := make([]string, len(schema))
+	naming := headerNaming(options)
+	for i, field := range schema {
+		switch naming {
+		case proto.SerializationOptions_HEADER_NAMING_SNAKE_CASE:
+			names[i] = snakeCaseName(field.Name)
+		case proto.SerializationOptions_HEADER_NAMING_STRICT:
+			names[i] = strictName(field.Name)
+		default:
+			names[i] = field.Name
+		}
+	}
+	if naming == proto.SerializationOptions_HEADER_NAMING_UNSPECIFIED {
+		// BigQuery column names are unique
+		return names
+	}
+	return uniqueNames(names)
+}
+
+// renamedColumns of CSV result, nil when header has original names
+func renamedColumns(schema bigquery.Schema, options *proto.SerializationOptions) []ColumnName {
+	names := HeaderNames(schema, options)
+	var columns []ColumnName
+	renamed := false
+	for i, field := range schema {
+		columns = append(columns, ColumnName{Name: names[i], Original: field.Name})
+		renamed = renamed || names[i] != field.Name
+	}
+	if !renamed {
+		return nil
+	}
+	return columns
+}
+
+// MarshalColumnNames of result record, empty when columns were not renamed
+func MarshalColumnNames(columns []ColumnName) (string, error) {
+	if len(columns) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(columns)
+	return string(b), err
+}
+
+// UnmarshalColumnNames of result record, nil when columns were not renamed
+func UnmarshalColumnNames(value string) ([]ColumnName, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var columns []ColumnName
+	err := json.Unmarshal([]byte(value), &columns)
+	return columns, err
+}
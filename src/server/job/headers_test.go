@@ -0,0 +1,83 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func schemaOf(names ...string) bigquery.Schema {
+	schema := make(bigquery.Schema, len(names))
+	for i, name := range names {
+		schema[i] = &bigquery.FieldSchema{Name: name, Type: bigquery.StringFieldType}
+	}
+	return schema
+}
+
+func TestHeaderNames(t *testing.T) {
+	snakeCase := &proto.SerializationOptions{HeaderNaming: proto.SerializationOptions_HEADER_NAMING_SNAKE_CASE}
+	strict := &proto.SerializationOptions{HeaderNaming: proto.SerializationOptions_HEADER_NAMING_STRICT}
+	for _, c := range []struct {
+		names    []string
+		options  *proto.SerializationOptions
+		expected string
+	}{
+		{[]string{"Total Amount", "a.b"}, nil, "Total Amount|a.b"},
+		{[]string{"Total Amount", "userID", "a.b", "  Größe  ", "__x__", "2nd"}, snakeCase, "total_amount|user_id|a_b|größe|x|2nd"},
+		{[]string{"Total Amount", "a.b", "Größe", "__x__", "2nd", "名前"}, strict, "Total_Amount|a_b|Gr_e|__x__|_2nd|_"},
+		// names equal after renaming get suffixes in column order
+		{[]string{"a b", "a.b", "a-b"}, snakeCase, "a_b|a_b_2|a_b_3"},
+		// suffix doesn't take name of later column
+		{[]string{"a b", "a.b", "a_b_2"}, snakeCase, "a_b|a_b_3|a_b_2"},
+		// names are equal ignoring case
+		{[]string{"A B", "a.b"}, strict, "A_B|a_b_2"},
+		{[]string{"...", "!!!", ""}, snakeCase, "column|column_2|column_3"},
+		{[]string{"é", "ü", "_"}, strict, "_|__2|__3"},
+	} {
+		names := strings.Join(HeaderNames(schemaOf(c.names...), c.options), "|")
+		if names != c.expected {
+			t.Errorf("%q: expected %s, got %s", c.names, c.expected, names)
+		}
+	}
+}
+
+func TestRenamedColumns(t *testing.T) {
+	strict := &proto.SerializationOptions{HeaderNaming: proto.SerializationOptions_HEADER_NAMING_STRICT}
+	if columns := renamedColumns(schemaOf("id", "name"), strict); columns != nil {
+		t.Errorf("expected no renamed columns, got %v", columns)
+	}
+	columns := renamedColumns(schemaOf("id", "full name"), strict)
+	value, err := MarshalColumnNames(columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := UnmarshalColumnNames(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 || stored[0] != (ColumnName{Name: "id", Original: "id"}) || stored[1] != (ColumnName{Name: "full_name", Original: "full name"}) {
+		t.Errorf("unexpected columns %v", stored)
+	}
+}
+
+func TestCSVResultHeaderNaming(t *testing.T) {
+	job := readingJob(NewStore())
+	job.serialization = &proto.SerializationOptions{NullToken: "", HeaderNaming: proto.SerializationOptions_HEADER_NAMING_SNAKE_CASE}
+	job.totalRows = 1
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	schema := schemaOf("Full Name", "full.name")
+	job.writeResult(&fakeIterator{rows: [][]bigquery.Value{{"a", "b"}}}, func() bigquery.Schema { return schema }, w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	if err := job.Err(); err != "" {
+		t.Fatal(err)
+	}
+	if expected := "full_name,full_name_2\na,b\n"; w.String() != expected {
+		t.Errorf("expected %q, got %q", expected, w.String())
+	}
+	if names := job.GetStatus().ColumnNames; len(names) != 2 || names[1].Original != "full.name" || names[1].Name != "full_name_2" {
+		t.Errorf("unexpected column names %v", names)
+	}
+}
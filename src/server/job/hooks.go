@@ -0,0 +1,127 @@
+package job
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultHookConcurrency = 4
+	maxHookConcurrency     = 64
+	// hookTimeout of single hook run, hook which needs longer is cancelled
+	hookTimeout = 30 * time.Second
+)
+
+// Hook runs when job finishes, returned error is logged and counted
+type Hook func(ctx context.Context, summary JobSummary) error
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+// hooks of job completion; each hook runs in own goroutine, at most slots hooks at once
+type hooks struct {
+	mutex   sync.Mutex
+	hooks   []namedHook
+	slots   chan struct{}
+	running sync.WaitGroup
+	timeout time.Duration
+}
+
+// parseHookConcurrency of DEKART_JOB_HOOK_CONCURRENCY, number of hooks running at once
+func parseHookConcurrency(value string) (int, error) {
+	if value == "" {
+		return defaultHookConcurrency, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > maxHookConcurrency {
+		return 0, fmt.Errorf("invalid DEKART_JOB_HOOK_CONCURRENCY %s, expected number from 1 to %d", value, maxHookConcurrency)
+	}
+	return n, nil
+}
+
+func newHooks() *hooks {
+	concurrency, err := parseHookConcurrency(os.Getenv("DEKART_JOB_HOOK_CONCURRENCY"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return &hooks{slots: make(chan struct{}, concurrency), timeout: hookTimeout}
+}
+
+// OnJobComplete registers hook of jobs finished after call, name labels its logs and metrics;
+// hooks are registered at startup
+func (s *Store) OnJobComplete(name string, hook Hook) {
+	s.hooks.mutex.Lock()
+	s.hooks.hooks = append(s.hooks.hooks, namedHook{name: name, hook: hook})
+	s.hooks.mutex.Unlock()
+}
+
+// WaitHooks running or waiting for slot, returns false when they didn't finish before timeout
+func (s *Store) WaitHooks(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.hooks.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// dispatch summary to all hooks asynchronously, finished job doesn't wait for them
+func (h *hooks) dispatch(summary JobSummary) {
+	h.mutex.Lock()
+	registered := append([]namedHook(nil), h.hooks...)
+	h.mutex.Unlock()
+	for _, hook := range registered {
+		h.running.Add(1)
+		go h.run(hook, summary)
+	}
+}
+
+// run hook in slot; panic of hook is recovered, so it doesn't affect other hooks or jobs
+func (h *hooks) run(hook namedHook, summary JobSummary) {
+	defer h.running.Done()
+	h.slots <- struct{}{}
+	defer func() { <-h.slots }()
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	logger := log.With().Str("hook", hook.name).Str("jobID", summary.JobID).Logger()
+	start := time.Now()
+	outcome := "ok"
+	defer func() {
+		if r := recover(); r != nil {
+			outcome = "panic"
+			logger.Error().Interface("panic", r).Msg("Job hook panicked")
+		}
+		metrics.HookRuns.WithLabelValues(hook.name, outcome).Inc()
+		metrics.HookDuration.WithLabelValues(hook.name).Observe(time.Since(start).Seconds())
+	}()
+	if err := hook.hook(ctx, summary); err != nil {
+		outcome = "error"
+		logger.Warn().Err(err).Msg("Job hook failed")
+	}
+}
+
+// runHooks of finished job; internal jobs and jobs aborted before start have no hooks
+func (job *Job) runHooks() {
+	if job.store == nil || job.internal {
+		return
+	}
+	summary := job.summary(job.now())
+	if summary.State == StateCancelled && summary.StartedAt.IsZero() {
+		return
+	}
+	job.store.hooks.dispatch(summary)
+}
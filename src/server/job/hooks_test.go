@@ -0,0 +1,148 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// finishStartedJob of store with result
+func finishStartedJob(store *Store) *Job {
+	job := store.New(context.Background(), "report", "query")
+	job.UserEmail = "a@example.com"
+	go drainStatus(job)
+	job.transition(StateRunning, true, func() {
+		job.startedAt = job.now()
+	})
+	job.transition(StateDone, true, func() {
+		job.resultID = &job.ID
+		job.totalRows = 10
+	})
+	job.finish()
+	return job
+}
+
+func TestParseHookConcurrency(t *testing.T) {
+	if n, err := parseHookConcurrency(""); err != nil || n != defaultHookConcurrency {
+		t.Errorf("expected default concurrency, got %d %v", n, err)
+	}
+	if n, err := parseHookConcurrency("16"); err != nil || n != 16 {
+		t.Errorf("expected 16, got %d %v", n, err)
+	}
+	for _, value := range []string{"0", "65", "many"} {
+		if _, err := parseHookConcurrency(value); err == nil {
+			t.Errorf("%s: expected error", value)
+		}
+	}
+}
+
+func TestHookPanicIsolated(t *testing.T) {
+	store := NewStore()
+	var mutex sync.Mutex
+	var summaries []JobSummary
+	store.OnJobComplete("panic", func(ctx context.Context, summary JobSummary) error {
+		panic("hook bug")
+	})
+	store.OnJobComplete("error", func(ctx context.Context, summary JobSummary) error {
+		return errors.New("unreachable")
+	})
+	store.OnJobComplete("record", func(ctx context.Context, summary JobSummary) error {
+		mutex.Lock()
+		summaries = append(summaries, summary)
+		mutex.Unlock()
+		return nil
+	})
+	first := finishStartedJob(store)
+	second := finishStartedJob(store)
+	if !store.WaitHooks(time.Second) {
+		t.Fatal("expected hooks to finish")
+	}
+	// hooks of both jobs ran after first hook panicked
+	if len(summaries) != 2 {
+		t.Fatalf("expected summaries of 2 jobs, got %d", len(summaries))
+	}
+	for _, summary := range summaries {
+		if summary.JobID != first.ID && summary.JobID != second.ID {
+			t.Errorf("unexpected job %s", summary.JobID)
+		}
+		if summary.State != StateDone || summary.ResultID != summary.JobID || summary.TotalRows != 10 || summary.UserEmail != "a@example.com" {
+			t.Errorf("unexpected summary %+v", summary)
+		}
+	}
+}
+
+func TestHookConcurrency(t *testing.T) {
+	store := NewStore()
+	store.hooks.slots = make(chan struct{}, 2)
+	release := make(chan struct{})
+	var mutex sync.Mutex
+	var running, maxRunning, runs int
+	store.OnJobComplete("blocked", func(ctx context.Context, summary JobSummary) error {
+		mutex.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+		<-release
+		time.Sleep(time.Millisecond)
+		mutex.Lock()
+		running--
+		runs++
+		mutex.Unlock()
+		return nil
+	})
+	// jobs finish while hooks are blocked
+	for i := 0; i < 6; i++ {
+		finishStartedJob(store)
+	}
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		mutex.Lock()
+		full := running == 2
+		mutex.Unlock()
+		if full {
+			break
+		}
+	}
+	close(release)
+	if !store.WaitHooks(time.Second) {
+		t.Fatal("expected hooks to finish")
+	}
+	if runs != 6 || maxRunning != 2 {
+		t.Errorf("expected 6 runs, 2 at once, got %d runs, %d at once", runs, maxRunning)
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	store := NewStore()
+	store.hooks.timeout = 10 * time.Millisecond
+	done := make(chan error, 1)
+	store.OnJobComplete("stuck", func(ctx context.Context, summary JobSummary) error {
+		<-ctx.Done()
+		done <- ctx.Err()
+		return ctx.Err()
+	})
+	finishStartedJob(store)
+	if err := <-done; err != context.DeadlineExceeded {
+		t.Errorf("expected hook cancelled after timeout, got %v", err)
+	}
+}
+
+func TestHookSkipsAbortedJob(t *testing.T) {
+	store := NewStore()
+	var calls int
+	store.OnJobComplete("count", func(ctx context.Context, summary JobSummary) error {
+		calls++
+		return nil
+	})
+	store.New(context.Background(), "report", "query").Abort()
+	failed := store.New(context.Background(), "report", "query")
+	failed.failStart(errors.New("invalid query"))
+	store.WaitHooks(time.Second)
+	// job which failed to start is finished for user, job aborted before start is not
+	if calls != 1 {
+		t.Errorf("expected hook of failed job only, got %d calls", calls)
+	}
+}
@@ -0,0 +1,114 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// IdempotencyWindow in which run request with repeated idempotency key returns job of first request
+var IdempotencyWindow = 5 * time.Minute
+
+// maxIdempotencyKeyLength fits key column of MySQL
+const maxIdempotencyKeyLength = 128
+
+// KeyStore persists idempotency keys of run requests, so retried request is found on any replica
+type KeyStore interface {
+	// Claim key of query for jobID unless it was claimed within window; returns ID of job holding key
+	Claim(ctx context.Context, queryID string, key string, jobID string, window time.Duration) (string, error)
+	// Release key held by jobID, so request can be retried when job was not started
+	Release(ctx context.Context, queryID string, key string, jobID string) error
+	// Purge keys claimed before window
+	Purge(ctx context.Context, window time.Duration) error
+}
+
+// claimedKey of run request
+type claimedKey struct {
+	jobID     string
+	claimedAt time.Time
+}
+
+// ValidateIdempotencyKey length, empty key disables idempotency
+func ValidateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency_key is longer than %d bytes", maxIdempotencyKeyLength)
+	}
+	return nil
+}
+
+// UseKeyStore to find idempotency keys claimed on other replicas, expired keys are purged every window
+func (s *Store) UseKeyStore(keyStore KeyStore) {
+	s.mutex.Lock()
+	s.keyStore = keyStore
+	s.mutex.Unlock()
+	go s.purgeKeys(IdempotencyWindow)
+}
+
+func (s *Store) purgeKeys(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := s.keyStore.Purge(ctx, IdempotencyWindow)
+		cancel()
+		if err != nil {
+			log.Err(err).Msg("Cannot purge idempotency keys")
+		}
+	}
+}
+
+// ClaimKey of run request of query for job; returns ID of job which claimed key within IdempotencyWindow,
+// which is jobID when key is new. Keys are scoped by query, so same key of other query is not a duplicate.
+// Key store errors are logged and key is claimed locally, so runs are not blocked by database.
+func (s *Store) ClaimKey(ctx context.Context, queryID string, key string, jobID string) string {
+	local := queryID + "/" + key
+	s.mutex.Lock()
+	now := s.now()
+	for k, claimed := range s.keys {
+		if now.Sub(claimed.claimedAt) >= IdempotencyWindow {
+			delete(s.keys, k)
+		}
+	}
+	if claimed, ok := s.keys[local]; ok {
+		s.mutex.Unlock()
+		return claimed.jobID
+	}
+	// claimed locally before key store, so concurrent duplicates on this replica don't race in database
+	s.keys[local] = claimedKey{jobID: jobID, claimedAt: now}
+	keyStore := s.keyStore
+	s.mutex.Unlock()
+	if keyStore == nil {
+		return jobID
+	}
+	holder, err := keyStore.Claim(ctx, queryID, key, jobID, IdempotencyWindow)
+	if err != nil {
+		log.Err(err).Str("queryID", queryID).Msg("Cannot claim idempotency key")
+		return jobID
+	}
+	if holder != jobID {
+		// claimed on other replica
+		s.mutex.Lock()
+		s.keys[local] = claimedKey{jobID: holder, claimedAt: now}
+		s.mutex.Unlock()
+	}
+	return holder
+}
+
+// ReleaseKey held by job which could not be started, so retried request runs query again
+func (s *Store) ReleaseKey(ctx context.Context, queryID string, key string, jobID string) {
+	local := queryID + "/" + key
+	s.mutex.Lock()
+	if s.keys[local].jobID == jobID {
+		delete(s.keys, local)
+	}
+	keyStore := s.keyStore
+	s.mutex.Unlock()
+	if keyStore == nil {
+		return
+	}
+	if err := keyStore.Release(ctx, queryID, key, jobID); err != nil {
+		log.Err(err).Str("queryID", queryID).Msg("Cannot release idempotency key")
+	}
+}
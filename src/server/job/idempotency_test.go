@@ -0,0 +1,110 @@
+package job
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeKeyStore is shared by stores to simulate replicas using same database
+type fakeKeyStore struct {
+	keys  map[string]string
+	mutex sync.Mutex
+}
+
+func (k *fakeKeyStore) Claim(ctx context.Context, queryID string, key string, jobID string, window time.Duration) (string, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if holder, ok := k.keys[queryID+"/"+key]; ok {
+		return holder, nil
+	}
+	k.keys[queryID+"/"+key] = jobID
+	return jobID, nil
+}
+
+func (k *fakeKeyStore) Release(ctx context.Context, queryID string, key string, jobID string) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if k.keys[queryID+"/"+key] == jobID {
+		delete(k.keys, queryID+"/"+key)
+	}
+	return nil
+}
+
+func (k *fakeKeyStore) Purge(ctx context.Context, window time.Duration) error {
+	return nil
+}
+
+func TestClaimKey(t *testing.T) {
+	store := NewStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	if holder := store.ClaimKey(ctx, "query", "key", "a"); holder != "a" {
+		t.Fatalf("expected new key claimed by a, got %s", holder)
+	}
+	if holder := store.ClaimKey(ctx, "query", "key", "b"); holder != "a" {
+		t.Errorf("expected duplicate of a, got %s", holder)
+	}
+	// keys are scoped by query
+	if holder := store.ClaimKey(ctx, "other", "key", "c"); holder != "c" {
+		t.Errorf("expected key of other query claimed by c, got %s", holder)
+	}
+	now = now.Add(IdempotencyWindow)
+	if holder := store.ClaimKey(ctx, "query", "key", "d"); holder != "d" {
+		t.Errorf("expected expired key claimed by d, got %s", holder)
+	}
+	store.ReleaseKey(ctx, "query", "key", "d")
+	if holder := store.ClaimKey(ctx, "query", "key", "e"); holder != "e" {
+		t.Errorf("expected released key claimed by e, got %s", holder)
+	}
+}
+
+func TestClaimKeyReplicas(t *testing.T) {
+	keyStore := &fakeKeyStore{keys: make(map[string]string)}
+	a, b := NewStore(), NewStore()
+	a.keyStore, b.keyStore = keyStore, keyStore
+	ctx := context.Background()
+	if holder := a.ClaimKey(ctx, "query", "key", "a"); holder != "a" {
+		t.Fatalf("expected key claimed by a, got %s", holder)
+	}
+	if holder := b.ClaimKey(ctx, "query", "key", "b"); holder != "a" {
+		t.Errorf("expected key of other replica, got %s", holder)
+	}
+	// releasing key not held by job keeps it
+	b.ReleaseKey(ctx, "query", "key", "b")
+	if holder := b.ClaimKey(ctx, "query", "key", "c"); holder != "a" {
+		t.Errorf("expected key still held by a, got %s", holder)
+	}
+}
+
+func TestClaimKeyConcurrent(t *testing.T) {
+	store := NewStore()
+	ctx := context.Background()
+	holders := make([]string, 20)
+	var wg sync.WaitGroup
+	for i := range holders {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			holders[i] = store.ClaimKey(ctx, "query", "key", string(rune('a'+i)))
+		}(i)
+	}
+	wg.Wait()
+	for _, holder := range holders {
+		if holder != holders[0] {
+			t.Fatalf("expected single holder, got %v", holders)
+		}
+	}
+}
+
+func TestValidateIdempotencyKey(t *testing.T) {
+	if err := ValidateIdempotencyKey(strings.Repeat("k", maxIdempotencyKeyLength)); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateIdempotencyKey(strings.Repeat("k", maxIdempotencyKeyLength+1)); err == nil {
+		t.Error("expected error of long key")
+	}
+}
@@ -0,0 +1,194 @@
+package job
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+// schemaSourceRe is project.dataset or project.region-xx of INFORMATION_SCHEMA
+var schemaSourceRe = regexp.MustCompile(`^[A-Za-z0-9-]+\.[A-Za-z0-9_-]+$`)
+
+// SchemaSnapshotColumn of table in INFORMATION_SCHEMA, nested fields are parent.child like in table schema
+type SchemaSnapshotColumn struct {
+	// Table is project.dataset.table
+	Table       string
+	Name        string
+	Type        string
+	Mode        string
+	Description string
+}
+
+// ValidateSchemaSource of snapshot
+func ValidateSchemaSource(source string) error {
+	if !schemaSourceRe.MatchString(source) {
+		return fmt.Errorf("invalid schema snapshot source %q, expected project.dataset or project.region-xx", source)
+	}
+	return nil
+}
+
+// informationSchemaQuery of columns and nested fields of tables in source, ordered by table and column position
+func informationSchemaQuery(source string) (string, error) {
+	if err := ValidateSchemaSource(source); err != nil {
+		return "", err
+	}
+	parts := strings.SplitN(source, ".", 2)
+	schema := fmt.Sprintf("`%s`.`%s`.INFORMATION_SCHEMA", parts[0], parts[1])
+	return fmt.Sprintf(`SELECT f.table_catalog, f.table_schema, f.table_name, f.field_path, f.data_type, f.description
+FROM %s.COLUMN_FIELD_PATHS f
+JOIN %s.COLUMNS c USING (table_catalog, table_schema, table_name, column_name)
+ORDER BY f.table_catalog, f.table_schema, f.table_name, c.ordinal_position, f.field_path`, schema, schema), nil
+}
+
+// legacyTypes of standard SQL types as they are named in table schema
+var legacyTypes = map[string]string{
+	"INT64":   "INTEGER",
+	"FLOAT64": "FLOAT",
+	"BOOL":    "BOOLEAN",
+}
+
+// informationSchemaType of data_type as type and mode of table schema; ARRAY is repeated, STRUCT is RECORD
+// and parameters like STRING(10) are dropped
+func informationSchemaType(dataType string) (string, string) {
+	mode := "NULLABLE"
+	if strings.HasPrefix(dataType, "ARRAY<") && strings.HasSuffix(dataType, ">") {
+		mode = "REPEATED"
+		dataType = dataType[len("ARRAY<") : len(dataType)-1]
+	}
+	if strings.HasPrefix(dataType, "STRUCT<") {
+		return "RECORD", mode
+	}
+	if i := strings.IndexAny(dataType, "(<"); i > 0 {
+		dataType = dataType[:i]
+	}
+	if legacy, ok := legacyTypes[dataType]; ok {
+		dataType = legacy
+	}
+	return dataType, mode
+}
+
+// readSchemaColumns of INFORMATION_SCHEMA rows; error of iterator is returned after columns visited before it
+func readSchemaColumns(it rowIterator, visit func(SchemaSnapshotColumn) error) error {
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(row) != 6 {
+			return fmt.Errorf("unexpected INFORMATION_SCHEMA row %v", row)
+		}
+		text := make([]string, len(row))
+		for i, v := range row {
+			if v != nil {
+				text[i] = fmt.Sprint(v)
+			}
+		}
+		typ, mode := informationSchemaType(text[4])
+		column := SchemaSnapshotColumn{
+			Table:       strings.Join(text[:3], "."),
+			Name:        text[3],
+			Type:        typ,
+			Mode:        mode,
+			Description: text[5],
+		}
+		if err := visit(column); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadInformationSchema of source with credentials of instance, each column is visited in order; read runs
+// as internal job of store, so it's listed and cancelled with other jobs. Error after some columns were visited
+// means snapshot is partial
+func (s *Store) ReadInformationSchema(reqCtx context.Context, source string, visit func(SchemaSnapshotColumn) error) error {
+	queryText, err := informationSchemaQuery(source)
+	if err != nil {
+		return err
+	}
+	job := s.newInternal(reqCtx, "", "schema-snapshot/"+source)
+	// cancellation sends status of internal job, nobody else receives it
+	go func() {
+		for {
+			select {
+			case <-job.Status:
+			case <-job.Ctx.Done():
+				return
+			}
+		}
+	}()
+	err = job.readInformationSchema(queryText, visit)
+	if err != nil {
+		job.transition(StateFailed, false, func() {
+			job.err = err.Error()
+		})
+	}
+	job.finish()
+	return err
+}
+
+// readInformationSchema rows of query text
+func (job *Job) readInformationSchema(queryText string, visit func(SchemaSnapshotColumn) error) error {
+	client, _, err := job.bigqueryClient(RunOptions{})
+	if err != nil {
+		return err
+	}
+	query := client.Query(queryText)
+	query.Labels = map[string]string{queryHashLabel: QueryHash(queryText)}
+	start := time.Now()
+	bigqueryJob, err := query.Run(job.Ctx)
+	metrics.Observe(metrics.BigQuery, "job_create", start, err)
+	if err != nil {
+		return err
+	}
+	if !job.transition(StateRunning, false, func() {
+		job.bigqueryJob = bigqueryJob
+		job.queryText = queryText
+		job.queryHash = QueryHash(queryText)
+		job.startedAt = job.now()
+	}) {
+		return ErrCancelled
+	}
+	job.setPhase(PhaseWaitingBigQuery)
+	waitCtx, cancelWait := job.startQueryPhase()
+	queryStatus, err := bigqueryJob.Wait(waitCtx)
+	cancelWait()
+	if err == nil {
+		err = queryStatus.Err()
+	} else if timeout, ok := job.timeoutError(err).(*TimeoutError); ok {
+		stopBigqueryJob(bigqueryJob, job.logger)
+		err = timeout
+	}
+	if err != nil {
+		return err
+	}
+	job.markWaited()
+	job.setPhase(PhaseReading)
+	it, err := bigqueryJob.Read(job.Ctx)
+	if err != nil {
+		return err
+	}
+	job.setJobStats(queryStatus, it.TotalRows)
+	var rows int64
+	err = readSchemaColumns(it, func(column SchemaSnapshotColumn) error {
+		rows++
+		return visit(column)
+	})
+	if err != nil {
+		return err
+	}
+	job.transition(StateDone, false, func() {
+		job.rowsWritten = rows
+	})
+	job.logger.Info().Int64("columns", rows).Msg("Schema snapshot job done")
+	return nil
+}
@@ -0,0 +1,79 @@
+package job
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestInformationSchemaQuery(t *testing.T) {
+	query, err := informationSchemaQuery("my-project.region-us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(query, "FROM `my-project`.`region-us`.INFORMATION_SCHEMA.COLUMN_FIELD_PATHS f") {
+		t.Errorf("unexpected query %s", query)
+	}
+	for _, source := range []string{"dataset", "p.d.t", "p.d`; drop table x; --", "p.", ""} {
+		if _, err := informationSchemaQuery(source); err == nil {
+			t.Errorf("%q: expected error", source)
+		}
+	}
+}
+
+func TestInformationSchemaType(t *testing.T) {
+	for dataType, expected := range map[string]string{
+		"INT64":                           "INTEGER NULLABLE",
+		"STRING(10)":                      "STRING NULLABLE",
+		"NUMERIC(10, 2)":                  "NUMERIC NULLABLE",
+		"GEOGRAPHY":                       "GEOGRAPHY NULLABLE",
+		"ARRAY<FLOAT64>":                  "FLOAT REPEATED",
+		"ARRAY<STRUCT<name STRING>>":      "RECORD REPEATED",
+		"STRUCT<a INT64, b ARRAY<INT64>>": "RECORD NULLABLE",
+	} {
+		typ, mode := informationSchemaType(dataType)
+		if got := typ + " " + mode; got != expected {
+			t.Errorf("%s: expected %s, got %s", dataType, expected, got)
+		}
+	}
+}
+
+// failingIterator returns err after rows
+type failingIterator struct {
+	*fakeIterator
+	err error
+}
+
+func (it *failingIterator) Next(dst interface{}) error {
+	if it.next == len(it.rows) {
+		return it.err
+	}
+	return it.fakeIterator.Next(dst)
+}
+
+func TestReadSchemaColumns(t *testing.T) {
+	rows := [][]bigquery.Value{
+		{"p", "data", "points", "id", "INT64", nil},
+		{"p", "data", "points", "tags.name", "STRING", "tag name"},
+	}
+	var columns []SchemaSnapshotColumn
+	visit := func(column SchemaSnapshotColumn) error {
+		columns = append(columns, column)
+		return nil
+	}
+	if err := readSchemaColumns(&fakeIterator{rows: rows}, visit); err != nil {
+		t.Fatal(err)
+	}
+	expected := SchemaSnapshotColumn{Table: "p.data.points", Name: "tags.name", Type: "STRING", Mode: "NULLABLE", Description: "tag name"}
+	if len(columns) != 2 || columns[1] != expected || columns[0].Description != "" {
+		t.Errorf("unexpected columns %+v", columns)
+	}
+	// error of read after first rows is returned, caller discards partial snapshot
+	columns = nil
+	failed := errors.New("connection reset")
+	if err := readSchemaColumns(&failingIterator{&fakeIterator{rows: rows}, failed}, visit); err != failed || len(columns) != 2 {
+		t.Errorf("expected read error after 2 columns, got %v after %d", err, len(columns))
+	}
+}
@@ -0,0 +1,62 @@
+package job
+
+import (
+	"dekart/src/server/metrics"
+	"time"
+
+	"google.golang.org/api/iterator"
+)
+
+// instrumentedWriter observes upload of result from first write to close with bytes written
+type instrumentedWriter struct {
+	w         resultWriter
+	backend   string
+	operation string
+	start     time.Time
+	err       error
+}
+
+func instrumentWriter(w resultWriter, backend string, operation string) *instrumentedWriter {
+	return &instrumentedWriter{w: w, backend: backend, operation: operation, start: time.Now()}
+}
+
+func (w *instrumentedWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	metrics.BytesWritten.WithLabelValues(w.backend).Add(float64(n))
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+	return n, err
+}
+
+func (w *instrumentedWriter) Close() error {
+	err := w.w.Close()
+	if w.err == nil {
+		w.err = err
+	}
+	metrics.Observe(w.backend, w.operation, w.start, w.err)
+	return err
+}
+
+// instrumentedIterator observes time spent fetching rows, without time spent writing them
+type instrumentedIterator struct {
+	it        rowIterator
+	operation string
+	spent     time.Duration
+	observed  bool
+}
+
+func (i *instrumentedIterator) Next(dst interface{}) error {
+	start := time.Now()
+	err := i.it.Next(dst)
+	i.spent += time.Since(start)
+	if err != nil && !i.observed {
+		i.observed = true
+		var observedErr error
+		if err != iterator.Done {
+			observedErr = err
+		}
+		metrics.ObserveDuration(metrics.BigQuery, i.operation, i.spent, observedErr)
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/server/metrics"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/api/iterator"
+)
+
+type bufferWriter struct {
+	bytes.Buffer
+}
+
+func (w *bufferWriter) Close() error {
+	return nil
+}
+
+// fakeRows returns n rows then err
+type fakeRows struct {
+	n   int
+	err error
+}
+
+func (r *fakeRows) Next(dst interface{}) error {
+	if r.n == 0 {
+		return r.err
+	}
+	r.n--
+	return nil
+}
+
+func TestInstrumentWriter(t *testing.T) {
+	written := testutil.ToFloat64(metrics.BytesWritten.WithLabelValues("test"))
+	w := instrumentWriter(&bufferWriter{}, "test", "writer_upload")
+	w.Write([]byte("hello"))
+	w.Write([]byte(" world"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := testutil.ToFloat64(metrics.BytesWritten.WithLabelValues("test")) - written; got != 11 {
+		t.Errorf("expected 11 bytes written, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.OperationErrors.WithLabelValues("test", "writer_upload")); got != 0 {
+		t.Errorf("expected no errors, got %v", got)
+	}
+}
+
+func TestInstrumentedIterator(t *testing.T) {
+	errors := func(operation string) float64 {
+		return testutil.ToFloat64(metrics.OperationErrors.WithLabelValues(metrics.BigQuery, operation))
+	}
+	for _, c := range []struct {
+		operation string
+		err       error
+		errors    float64
+	}{
+		{"test_done", iterator.Done, 0},
+		{"test_failed", fmt.Errorf("backend error"), 1},
+		{"test_cancelled", context.Canceled, 0},
+	} {
+		before := errors(c.operation)
+		it := &instrumentedIterator{it: &fakeRows{n: 3, err: c.err}, operation: c.operation}
+		for it.Next(nil) == nil {
+		}
+		// repeated Next after end of rows is not observed again
+		it.Next(nil)
+		if got := errors(c.operation) - before; got != c.errors {
+			t.Errorf("%s: expected %v errors, got %v", c.operation, c.errors, got)
+		}
+	}
+}
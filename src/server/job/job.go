@@ -2,11 +2,16 @@ package job
 
 import (
 	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"dekart/src/server/requestid"
 	"dekart/src/server/uuid"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -14,26 +19,169 @@ import (
 
 	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/api/iterator"
 )
 
 // Job of quering db, concurency safe
 type Job struct {
-	ID             string
-	QueryID        string
-	ReportID       string
+	ID        string
+	QueryID   string
+	ReportID  string
+	UserEmail string
+	// BatchID of RunAllQueries which created job, empty for single run
+	BatchID        string
 	Ctx            context.Context
 	cancel         context.CancelFunc
 	bigqueryJob    *bigquery.Job
 	Status         chan int32
+	Progress       chan struct{}
 	err            string
 	totalRows      int64
 	processedBytes int64
+	bytesBilled    int64
+	cacheHit       bool
 	resultSize     int64
+	rowsWritten    int64
+	sampleRate     float64
+	queryText      string
+	queryHash      string
+	h3             *h3Aggregator
+	h3ResultID     string
+	h3Writer       func(ctx context.Context) resultWriter
 	resultID       *string
+	resultMeta     *ResultMeta
 	storageObj     *storage.ObjectHandle
 	mutex          sync.Mutex
+	registry       Registry
+	replica        string
+	logger         zerolog.Logger
+	createdAt      time.Time
+	// startedAt when BigQuery job was created, waitedAt when it finished
+	startedAt time.Time
+	waitedAt  time.Time
+	now       func() time.Time
+	// state of job, changed only by transition
+	state           JobState
+	transitionMutex sync.Mutex
+	store           *Store
+	// maxResultSize in bytes and maxResultRows, zero is unlimited
+	maxResultSize int64
+	maxResultRows int64
+	// maxQueryTextSize in bytes of text run by job
+	maxQueryTextSize int
+	// finished once on any terminal transition
+	finished sync.Once
+	// destinationTable receives result instead of storageObj, resultTable is set when it's written
+	destinationTable string
+	resultTable      *string
+	// resultFormat of storageObj
+	resultFormat proto.ResultFormat
+	// serialization of CSV cells, nil for default serialization
+	serialization *proto.SerializationOptions
+	// compression of storageObj
+	compression proto.ResultCompression
+	// explorationLimit query was wrapped in, 0 when it wasn't
+	explorationLimit int64
+	// defaultDataset of unqualified tables in queryText
+	defaultDataset string
+	// warnings of conditions which didn't fail job, added with AddWarning
+	warnings []Warning
+	// plan of BigQuery job, set when job finished waiting
+	plan *Plan
+	// fallback receives result when upload to storageObj fails, optional
+	fallback *resultFallback
+	// upload settings of result; parallel stores components of result above threshold, nil for single writer
+	upload   UploadSettings
+	parallel componentStore
+	// resultBucket holding result when it was written to fallback bucket, empty for storageObj
+	resultBucket string
+	// resultGeneration of storageObj created by job, 0 until result object is created
+	resultGeneration int64
+	// extract of result table above extractThreshold bytes, nil when rows are always written by dekart
+	extract          *resultExtract
+	extractThreshold int64
+	// extracted when result object is written by EXTRACT
+	extracted bool
+	// confirmThreshold of estimated bytes above which run needs acknowledged bytes, 0 disables confirmation;
+	// dryRun estimates bytes and referenced tables of query, replaced in tests
+	confirmThreshold int64
+	dryRun           func(ctx context.Context, query *bigquery.Query) (dryRunStats, error)
+	// reread result of finished BigQuery job, replaced in tests
+	reread func(ctx context.Context) (rowIterator, func() bigquery.Schema, error)
+	// phase of job work since phaseAt, changed with setPhase
+	phase   Phase
+	phaseAt time.Time
+	// bytesUploaded to result object so far
+	bytesUploaded int64
+	// columnTypes of result file, set when all rows are written; columnNames when header naming renamed columns
+	columnTypes []string
+	columnNames []ColumnName
+	// controlCharacters of string cells are replaced or removed before rows are written
+	controlCharacters ControlCharacters
+	// useLegacySQL when queryText was run in legacy SQL
+	useLegacySQL bool
+	// sourceQueryText before sampling and exploration LIMIT, run with runOptions; rows of it are counted by CountRows
+	sourceQueryText string
+	runOptions      RunOptions
+	// ordered rows of query with top level ORDER BY are read from single stream in its order
+	ordered bool
+	// internal job of store runs for other job, its statuses are not sent to clients
+	internal bool
+	// timeouts of query and export phases, budget of phase which is running
+	timeouts Timeouts
+	budget   *phaseBudget
+	// connections of federated credentials, RunOptions.Connection is one of them
+	connections *Connections
+	// outputs of additional formats written from rows of result, formatResults of them which were stored
+	outputs       []*formatOutput
+	formatResults []FormatResult
+	// cancelReason of first cancellation which finished job, unspecified when job was not cancelled
+	cancelReason proto.CancelReason
+	// transport of BigQuery client of job, nil until job is started;
+	// effectiveReservation which ran job is set when job finished waiting
+	transport            *jobTransport
+	effectiveReservation string
+	// events of job in order, at most maxEvents; eventsDropped from full log
+	events        []Event
+	eventsDropped int
+	// projects, runner, bucket and defaults of result format are copied from store options
+	projects           Projects
+	runner             Runner
+	bucket             *storage.BucketHandle
+	defaultFormat      proto.ResultFormat
+	defaultCompression proto.ResultCompression
+	// preview of first rows while result is read, nil without preview; previewStored rows are in preview object
+	preview         *resultPreview
+	previewLimit    int64
+	previewInterval time.Duration
+	previewStored   int64
+	// sqlConnections of DEKART_SQL_CONNECTIONS; sqlConnection job was run on, empty for BigQuery job,
+	// databaseTypes of result columns reported by its driver
+	sqlConnections *SQLConnections
+	sqlConnection  string
+	databaseTypes  []string
+	// errExplanation of err for users, nil when error is unknown
+	errExplanation *ErrorExplanation
+	// memory budget of store, memoryReserved by job in it
+	memory         *memoryBudget
+	memoryReserved int64
+}
+
+// finish job: cancels context and removes job from store exactly once
+func (job *Job) finish() {
+	job.cancel()
+	job.finished.Do(func() {
+		job.removePreview()
+		job.releaseAllMemory()
+		job.logSlowQuery()
+		job.recordStats()
+		job.runHooks()
+		if job.store != nil {
+			job.store.remove(job)
+		}
+	})
 }
 
 // Err of job
@@ -64,6 +212,27 @@ func (job *Job) GetTotalRows() int64 {
 	return job.totalRows
 }
 
+// GetRowsWritten to result, equals total rows when job is done
+func (job *Job) GetRowsWritten() int64 {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.rowsWritten
+}
+
+// GetSampleRate of result, 0 when result is not sampled
+func (job *Job) GetSampleRate() float64 {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.sampleRate
+}
+
+// GetQueryText run by job with variables substituted and sampling applied, empty until job is started
+func (job *Job) GetQueryText() string {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.queryText
+}
+
 // GetProcessedBytes in result
 func (job *Job) GetProcessedBytes() int64 {
 	job.mutex.Lock()
@@ -71,215 +240,992 @@ func (job *Job) GetProcessedBytes() int64 {
 	return job.processedBytes
 }
 
+// StatusSnapshot of job; fields are read together, so result and stats always match status
+type StatusSnapshot struct {
+	State JobState
+	// Status of state sent to Status channel
+	Status         int32
+	Err            string
+	TotalRows      int64
+	ProcessedBytes int64
+	ResultSize     int64
+	RowsWritten    int64
+	SampleRate     float64
+	QueryText      string
+	QueryHash      string
+	// ResultID is nil until result is saved, H3ResultID is nil without h3 aggregation
+	ResultID   *string
+	H3ResultID *string
+	// ResultTable is nil until result is written to destination table
+	ResultTable *string
+	// ResultFormat of result file
+	ResultFormat proto.ResultFormat
+	// ResultCompression of result file
+	ResultCompression proto.ResultCompression
+	// ExplorationLimit query text was wrapped in, 0 when it wasn't
+	ExplorationLimit int64
+	// DefaultDataset applied to QueryText, empty when not set
+	DefaultDataset string
+	// Warnings of job in order of first occurrence
+	Warnings []Warning
+	// Plan of BigQuery job, nil until job finished waiting or when job has no query plan
+	Plan *Plan
+	// ResultBucket of fallback bucket holding result, empty when result is in primary bucket
+	ResultBucket string
+	// ColumnTypes of result file columns, nil until result is written
+	ColumnTypes []string
+	// ColumnNames of CSV result header renamed from names of query, nil when header has original names
+	ColumnNames []ColumnName
+	// UseLegacySQL when QueryText was run in legacy SQL
+	UseLegacySQL bool
+	// FormatResults in additional formats, nil until result is stored
+	FormatResults []FormatResult
+	// CancelReason of cancelled job, unspecified for other states
+	CancelReason proto.CancelReason
+	// Reservation which ran job as reported by BigQuery, empty until job finished waiting or without reservation
+	Reservation string
+	// PreviewResultID of first PreviewRows rows stored while job reads result, empty without preview and once job finished
+	PreviewResultID string
+	PreviewRows     int64
+	// DatabaseTypes of result columns reported by driver of SQL connection, nil for BigQuery job
+	DatabaseTypes []string
+	// ErrorExplanation of Err for users, nil when Err is unknown error
+	ErrorExplanation *ErrorExplanation
+	// ResultMeta of result file, nil until job is done or when result is written to destination table;
+	// ResultID, ResultSize, ResultFormat, ResultCompression, ResultBucket and FormatResults are filled with it
+	ResultMeta *ResultMeta
+}
+
+// GetStatus snapshot of job
+func (job *Job) GetStatus() StatusSnapshot {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	snapshot := StatusSnapshot{
+		State:             job.state,
+		Status:            job.state.Status(),
+		Err:               job.err,
+		TotalRows:         job.totalRows,
+		ProcessedBytes:    job.processedBytes,
+		ResultSize:        job.resultSize,
+		RowsWritten:       job.rowsWritten,
+		SampleRate:        job.sampleRate,
+		QueryText:         job.queryText,
+		QueryHash:         job.queryHash,
+		ResultID:          job.resultID,
+		ResultTable:       job.resultTable,
+		ResultFormat:      job.resultFormat,
+		ResultCompression: job.compression,
+		ExplorationLimit:  job.explorationLimit,
+		DefaultDataset:    job.defaultDataset,
+		Warnings:          append([]Warning(nil), job.warnings...),
+		Plan:              job.plan,
+		ResultBucket:      job.resultBucket,
+		ColumnTypes:       job.columnTypes,
+		ColumnNames:       job.columnNames,
+		UseLegacySQL:      job.useLegacySQL,
+		FormatResults:     append([]FormatResult(nil), job.formatResults...),
+		CancelReason:      job.cancelReason,
+		Reservation:       job.effectiveReservation,
+		DatabaseTypes:     job.databaseTypes,
+		ErrorExplanation:  job.errExplanation,
+		ResultMeta:        job.resultMeta,
+	}
+	if job.resultID != nil && job.h3 != nil {
+		h3ResultID := job.h3ResultID
+		snapshot.H3ResultID = &h3ResultID
+	}
+	if job.preview != nil && job.previewStored > 0 && !job.state.Terminal() {
+		// result of done job replaces preview in same transition
+		snapshot.PreviewResultID = job.preview.resultID
+		snapshot.PreviewRows = job.previewStored
+	}
+	return snapshot
+}
+
 var contextCancelledRe = regexp.MustCompile(`context canceled`)
 
-func (job *Job) close(storageWriter *storage.Writer, csvWriter *csv.Writer) {
-	csvWriter.Flush()
-	err := storageWriter.Close()
+// ResultTooLarge prefixes job error when result exceeds configured limits
+const ResultTooLarge = "RESULT_TOO_LARGE"
+
+// ResultTooLargeError when query result exceeds DEKART_MAX_RESULT_SIZE or DEKART_MAX_RESULT_ROWS
+type ResultTooLargeError struct {
+	Limit int64
+	// Unit of limit, bytes or rows
+	Unit string
+}
+
+func (e *ResultTooLargeError) Error() string {
+	return fmt.Sprintf("%s: query result exceeds limit of %d %s, add LIMIT to query", ResultTooLarge, e.Limit, e.Unit)
+}
+
+// resultWriter is storage.Writer, interface allows testing without bucket
+type resultWriter interface {
+	io.Writer
+	Close() error
+}
+
+// rowIterator is bigquery.RowIterator
+type rowIterator interface {
+	Next(dst interface{}) error
+}
+
+// countingWriter counts bytes written to result
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// cancelled job context, errors after cancel are expected
+func (job *Job) cancelled(err error) bool {
+	return job.Ctx.Err() == context.Canceled && (err == context.Canceled || contextCancelledRe.MatchString(err.Error()))
+}
+
+// close result writer and finish job; size and checksum of stored bytes are recorded in result meta,
+// they are known once compressed result is flushed by close
+func (job *Job) close(w resultWriter, checksum *checksumWriter, cleanup func()) {
+	job.setPhase(PhaseClosing)
+	err := w.Close()
 	if err != nil {
-		if err == context.Canceled {
+		// result rewritten to fallback bucket or with single writer has no additional formats
+		job.discardOutputs()
+		if job.cancelled(err) {
+			job.finish()
 			return
 		}
-		if contextCancelledRe.MatchString(err.Error()) {
+		if job.writeSimple(err) || job.writeFallback(err) {
 			return
 		}
-		log.Err(err).Send()
 		job.cancelWithError(err)
 		return
 	}
-	attrs := storageWriter.Attrs()
+	job.addEvent(EventUploadFinished, "")
 	job.mutex.Lock()
-	// TODO: use bool done
-	job.resultID = &job.ID
-	if attrs != nil {
-		job.resultSize = attrs.Size
-	}
+	rowsWritten, totalRows := job.rowsWritten, job.totalRows
 	job.mutex.Unlock()
-	job.Status <- int32(proto.Query_JOB_STATUS_DONE)
-	job.cancel()
+	if rowsWritten != totalRows && job.Ctx.Err() == nil {
+		// result is truncated, rows were lost without error
+		cleanup()
+		job.cancelWithError(fmt.Errorf("result has %d rows, query returned %d rows", rowsWritten, totalRows))
+		return
+	}
+	job.closeOutputs()
+	if err := job.writeH3Result(); err != nil {
+		cleanup()
+		if job.cancelled(err) {
+			job.finish()
+			return
+		}
+		job.cancelWithError(err)
+		return
+	}
+	done := job.transition(StateDone, true, func() {
+		job.resultID = &job.ID
+		job.resultSize = checksum.size
+		job.resultMeta = job.newResultMeta(checksum.size, checksum.sum())
+	})
+	if done {
+		job.logger.Info().Msg("Job done")
+	}
+	job.finish()
 }
 
 func (job *Job) setJobStats(queryStatus *bigquery.JobStatus, totalRows uint64) {
+	if queryStatus.Statistics == nil {
+		job.AddWarning(WarningStatisticsUnavailable, "BigQuery returned no job statistics, processed bytes are unknown")
+	}
 	job.mutex.Lock()
 	defer job.mutex.Unlock()
 	if queryStatus.Statistics != nil {
 		job.processedBytes = queryStatus.Statistics.TotalBytesProcessed
+		job.plan = newPlan(queryStatus.Statistics)
+		if details, ok := queryStatus.Statistics.Details.(*bigquery.QueryStatistics); ok {
+			job.bytesBilled = details.TotalBytesBilled
+			job.cacheHit = details.CacheHit
+		}
 	}
 	job.totalRows = int64(totalRows)
+	if job.transport != nil {
+		job.effectiveReservation = job.transport.effectiveReservation()
+	}
 }
 
 func (job *Job) read(queryStatus *bigquery.JobStatus) {
-	ctx := job.Ctx
+	// export has own budget, query which finished late still has whole export timeout
+	ctx, cancel := job.startExportPhase()
+	defer cancel()
+	job.setPhase(PhaseReading)
 
+	start := time.Now()
 	it, err := job.bigqueryJob.Read(ctx)
+	metrics.Observe(metrics.BigQuery, "job_read", start, err)
 	if err != nil {
-		log.Err(err).Send()
 		job.cancelWithError(err)
 		return
 	}
 
 	job.setJobStats(queryStatus, it.TotalRows)
-	job.Status <- int32(queryStatus.State)
+	if !job.transition(StateReading, true, nil) {
+		// cancelled while waiting
+		return
+	}
+	if job.extractResult(ctx) {
+		return
+	}
 
-	storageWriter := job.storageObj.NewWriter(ctx)
-	csvWriter := csv.NewWriter(storageWriter)
-	defer job.close(storageWriter, csvWriter)
+	// canceling writer context aborts upload, so partial result is not saved
+	writerCtx, abortUpload := context.WithCancel(ctx)
+	defer abortUpload()
+	rows := &instrumentedIterator{it: it, operation: "rows_fetch"}
+	schema := job.resultSchema(func() bigquery.Schema { return it.Schema })
+	w := job.newResultWriter(writerCtx)
+	// additional formats are written from same rows, so memory doesn't grow with number of formats
+	job.startOutputs(writerCtx, schema)
+	job.writeResult(rows, schema, w, func() {
+		abortUpload()
+		job.deleteResult()
+		job.discardOutputs()
+	})
+}
+
+// newStorageWriter of result object with content type and metadata of result, existing object is not replaced;
+// created is called with generation of created object, optional
+func (job *Job) newStorageWriter(ctx context.Context, obj *storage.ObjectHandle, created func(generation int64)) resultWriter {
+	w := newCreateOnlyWriter(ctx, obj, created)
+	if job.upload.ChunkSize > 0 {
+		w.w.ChunkSize = job.upload.ChunkSize
+	}
+	job.setResultAttrs(&w.w.ObjectAttrs)
+	return w
+}
+
+// setResultAttrs of content type and metadata of result
+func (job *Job) setResultAttrs(attrs *storage.ObjectAttrs) {
+	if job.resultFormat == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		attrs.ContentType = ParquetContentType
+	}
+	if job.compressed() {
+		// content type is not detected from compressed bytes
+		attrs.ContentType = "text/csv"
+		attrs.ContentEncoding = "gzip"
+	}
+	if sampleRate := job.GetSampleRate(); sampleRate > 0 {
+		attrs.Metadata = map[string]string{
+			"sampleRate": strconv.FormatFloat(sampleRate, 'f', -1, 64),
+		}
+	}
+	job.mutex.Lock()
+	extracted := job.extracted
+	job.mutex.Unlock()
+	if extracted {
+		if attrs.Metadata == nil {
+			attrs.Metadata = map[string]string{}
+		}
+		attrs.Metadata["export"] = "extract"
+		attrs.Metadata["extractFormatting"] = extractFormatting
+	}
+}
+
+// checkLimits of result written so far
+func (job *Job) checkLimits(size int64, rows int64) error {
+	if job.maxResultSize > 0 && size > job.maxResultSize {
+		return &ResultTooLargeError{Limit: job.maxResultSize, Unit: "bytes"}
+	}
+	if job.maxResultRows > 0 && rows > job.maxResultRows {
+		return &ResultTooLargeError{Limit: job.maxResultRows, Unit: "rows"}
+	}
+	return nil
+}
+
+// resultEncoder of rows in format of result file
+type resultEncoder interface {
+	write(row []bigquery.Value) error
+	// close writes buffered rows, it's called once after last row
+	close() error
+}
+
+// csvEncoder writes header of schema before first row, empty result has no header
+type csvEncoder struct {
+	w             *csv.Writer
+	schema        func() bigquery.Schema
+	serialization *proto.SerializationOptions
+	firstLine     bool
+	transport     *jobTransport
+	// decimalScales of columns, set with header
+	decimalScales []int
+}
+
+func (e *csvEncoder) write(row []bigquery.Value) error {
+	if e.firstLine {
+		e.firstLine = false
+		csvRow := make([]string, len(row), len(row))
+		schema := e.schema()
+		copy(csvRow, HeaderNames(schema, e.serialization))
+		e.decimalScales = e.transport.decimalScales(schema)
+		if err := e.w.Write(csvRow); err != nil {
+			return err
+		}
+	}
+	csvRow := make([]string, len(row), len(row))
+	for i, v := range row {
+		if i < len(e.decimalScales) && e.decimalScales[i] >= 0 {
+			if s, ok := formatDecimalCell(v, e.decimalScales[i], e.serialization != nil && e.serialization.NumericTrailingZeros); ok {
+				csvRow[i] = s
+				continue
+			}
+		}
+		csvRow[i] = FormatValue(v, e.serialization)
+	}
+	return e.w.Write(csvRow)
+}
+
+func (e *csvEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// newEncoder of job result format
+func (job *Job) newEncoder(w io.Writer, schema func() bigquery.Schema) resultEncoder {
+	return job.newFormatEncoder(job.resultFormat, w, schema)
+}
 
-	firstLine := true
+// newFormatEncoder of format, CSV is written with serialization of job
+func (job *Job) newFormatEncoder(format proto.ResultFormat, w io.Writer, schema func() bigquery.Schema) resultEncoder {
+	if format == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		return newParquetEncoder(w, schema, job.transport)
+	}
+	writer := csv.NewWriter(w)
+	writer.Comma = Delimiter(job.serialization)
+	return &csvEncoder{w: writer, schema: schema, serialization: job.serialization, firstLine: true, transport: job.transport}
+}
+
+// writeResult in job result format and finish job; cleanup removes partial result when writing fails
+func (job *Job) writeResult(it rowIterator, schema func() bigquery.Schema, w resultWriter, cleanup func()) {
+	checksum := newChecksumWriter(w)
+	w = checksum
+	if job.compressed() {
+		// limits apply to rows as they are read, before compression; stored size is counted by checksum
+		w = newGzipWriter(w)
+	}
+	counter := &countingWriter{w: w}
+	job.addEvent(EventUploadStarted, "")
+	err := job.writeRows(it, schema, job.newEncoder(counter, schema), counter)
+	if err != nil {
+		cleanup()
+		w.Close()
+		if job.cancelled(err) {
+			job.finish()
+			return
+		}
+		if job.writeSimple(err) {
+			return
+		}
+		job.cancelWithError(err)
+		return
+	}
+	job.mutex.Lock()
+	job.columnTypes = job.transport.columnTypes(schema())
+	if job.resultFormat != proto.ResultFormat_RESULT_FORMAT_PARQUET {
+		job.columnTypes = bytesColumnTypes(job.columnTypes, schema(), job.serialization)
+		job.columnNames = renamedColumns(schema(), job.serialization)
+	}
+	reading := job.state == StateReading
+	job.mutex.Unlock()
+	// rows written again to fallback bucket are saved in same state
+	if reading && !job.transition(StateSaving, true, nil) {
+		// cancelled after last row
+		cleanup()
+		w.Close()
+		job.finish()
+		return
+	}
+	job.close(w, checksum, cleanup)
+}
 
+func (job *Job) writeRows(it rowIterator, schema func() bigquery.Schema, encoder resultEncoder, counter *countingWriter) error {
+	var rows, sanitized, percent int64
+	var converters []cellConverter
 	for {
 		var row []bigquery.Value
 		err := it.Next(&row)
 		if err == iterator.Done {
-			break
-		}
-		if err == context.Canceled {
-			break
+			job.warnControlCharacters(sanitized)
+			// encoder is closed after last row
+			if err := encoder.close(); err != nil {
+				return err
+			}
+			job.mutex.Lock()
+			job.bytesUploaded = counter.n
+			job.mutex.Unlock()
+			return job.checkLimits(counter.n, rows)
 		}
 		if err != nil {
-			log.Err(err).Send()
-			job.cancelWithError(err)
-			return
+			return err
 		}
-		if firstLine {
-			firstLine = false
-			csvRow := make([]string, len(row), len(row))
-			for i, fieldSchema := range it.Schema {
-				csvRow[i] = fieldSchema.Name
-				// fmt.Println(fieldSchema.Name, fieldSchema.Type)
-			}
-			err = csvWriter.Write(csvRow)
-			if err == context.Canceled {
-				break
-			}
-			if err != nil {
-				log.Err(err).Send()
-				job.cancelWithError(err)
-				return
+		if converters == nil {
+			// schema is read with first row
+			converters = job.cellConverters(schema())
+		}
+		convertRow(row, converters)
+		sanitized += int64(sanitizeRow(row, job.controlCharacters))
+		if err := encoder.write(row); err != nil {
+			return err
+		}
+		job.writeOutputs(row)
+		job.previewRow(row, rows, schema)
+		if job.h3 != nil {
+			if err := job.h3.add(row, schema); err == errH3MemoryBudget {
+				job.skipH3()
+			} else if err != nil {
+				return err
 			}
 		}
-		csvRow := make([]string, len(row), len(row))
-		for i, v := range row {
-			csvRow[i] = fmt.Sprintf("%v", v)
+		rows++
+		if rows == 1 {
+			job.addEvent(EventFirstRow, "")
+			job.setPhase(PhaseUploading)
 		}
-		err = csvWriter.Write(csvRow)
-		if err == context.Canceled {
-			break
+		job.mutex.Lock()
+		job.rowsWritten = rows
+		job.bytesUploaded = counter.n
+		job.mutex.Unlock()
+		percent = job.addProgressEvent(rows, percent)
+		select {
+		case job.Progress <- struct{}{}:
+		default:
 		}
-		if err != nil {
-			log.Err(err).Send()
-			job.cancelWithError(err)
-			return
+		// counter is behind by encoder buffer, result is aborted at most one buffer after limit
+		if err := job.checkLimits(counter.n, rows); err != nil {
+			return err
 		}
 	}
 }
 
 func (job *Job) cancelWithError(err error) {
-	job.mutex.Lock()
-	job.err = err.Error()
-	job.mutex.Unlock()
-	job.Status <- 0
-	job.cancel()
+	err = credentialsError(permissionError(job.timeoutError(err)))
+	job.logger.Warn().Err(err).Msg("Job failed")
+	explanation := ExplainError(err)
+	job.transition(StateFailed, true, func() {
+		job.err = err.Error()
+		job.errExplanation = explanation
+	})
+	job.finish()
 }
 
 func (job *Job) wait() {
-	queryStatus, err := job.bigqueryJob.Wait(job.Ctx)
+	job.setPhase(PhaseWaitingBigQuery)
+	start := time.Now()
+	waitCtx, cancelWait := job.startQueryPhase()
+	queryStatus, err := job.bigqueryJob.Wait(waitCtx)
+	cancelWait()
+	metrics.Observe(metrics.BigQuery, "job_wait", start, err)
 	if err == context.Canceled {
+		// cancelled by Store.Cancel, which finished job
 		return
 	}
 	if err != nil {
+		if _, timeout := job.timeoutError(err).(*TimeoutError); timeout {
+			// BigQuery job would keep running and billing after job failed
+			stopBigqueryJob(job.bigqueryJob, job.logger)
+		}
 		job.cancelWithError(err)
 		return
 	}
 	if queryStatus == nil {
-		log.Fatal().Msgf("queryStatus == nil")
+		job.logger.Fatal().Msgf("queryStatus == nil")
 	}
+	job.markWaited()
 	if err := queryStatus.Err(); err != nil {
-		job.cancelWithError(err)
+		job.cancelWithError(destinationError(err, job.destinationTable))
+		return
+	}
+	if job.destinationTable != "" {
+		job.doneInTable(queryStatus)
 		return
 	}
 	job.read(queryStatus)
 }
 
-// Run implementation
-func (job *Job) Run(queryText string, obj *storage.ObjectHandle) error {
-	client, err := bigquery.NewClient(job.Ctx, os.Getenv("DEKART_BIGQUERY_PROJECT_ID"))
+// stopBigqueryJob which result is not needed anymore
+func stopBigqueryJob(bigqueryJob *bigquery.Job, logger zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := bigqueryJob.Cancel(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Cannot cancel BigQuery job")
+	}
+}
+
+// doneInTable finishes job which wrote result to destination table, result is not read from BigQuery
+func (job *Job) doneInTable(queryStatus *bigquery.JobStatus) {
+	job.setJobStats(queryStatus, 0)
+	done := job.transition(StateDone, true, func() {
+		resultTable := job.destinationTable
+		job.resultTable = &resultTable
+	})
+	if done {
+		job.logger.Info().Str("resultTable", job.destinationTable).Msg("Job done")
+	}
+	job.finish()
+}
+
+// Abort job which is not started, removes it from store
+func (job *Job) Abort() {
+	job.transition(StateCancelled, false, nil)
+	job.finish()
+}
+
+// failStart of job which can't be started, error is returned to caller instead of sent as status
+func (job *Job) failStart(err error) error {
+	err = credentialsError(err)
+	explanation := ExplainError(err)
+	job.transition(StateFailed, false, func() {
+		job.err = err.Error()
+		job.errExplanation = explanation
+	})
+	job.finish()
+	return err
+}
+
+// ErrCancelled when job is cancelled before it is started
+var ErrCancelled = errors.New("job cancelled before start")
+
+// Run query with report options, result is written to obj; without obj result is written to
+// destination table of options. Run returns once BigQuery job is created, statuses follow on Status
+func (job *Job) Run(queryText string, options RunOptions, obj *storage.ObjectHandle) error {
+	if job.GetState() != StatePending {
+		// cancelled with report, finished already
+		return ErrCancelled
+	}
+	options = job.applyDefaults(options)
+	if obj != nil && job.bucket != nil {
+		job.UseParallelUpload(job.bucket)
+		job.UseExtract(job.bucket)
+	}
+	// RPCs normalize query text, text is checked again as it may come from other callers
+	queryText, err := NormalizeQueryText(queryText, job.maxQueryTextSize)
 	if err != nil {
-		job.cancel()
-		return err
+		return job.failStart(err)
+	}
+	if job.sqlConnections.Has(options.Connection) {
+		return job.runSQL(queryText, options, obj)
 	}
-	bigqueryJob, err := client.Query(queryText).Run(job.Ctx)
+	if err := ValidateDialect(queryText, options.UseLegacySQL, options.SampleRate); err != nil {
+		return job.failStart(err)
+	}
+	project := job.projects.billingProject(options.BillingProject)
+	client, transport, err := job.bigqueryClient(options)
 	if err != nil {
-		job.cancel()
-		return err
+		return job.failStart(err)
 	}
-	job.mutex.Lock()
-	job.bigqueryJob = bigqueryJob
-	job.storageObj = obj
-	job.mutex.Unlock()
-	job.Status <- int32(proto.Query_JOB_STATUS_RUNNING)
+	defaultDataset := job.projects.defaultDataset(options.DefaultDataset)
+	if !options.AsOf.IsZero() {
+		// tables of query as written are dry run, snapshot is injected before sampling and exploration LIMIT
+		query, err := newQuery(client, queryText, options, defaultDataset)
+		if err == nil {
+			queryText, options, err = job.timeTravel(query, queryText, options)
+		}
+		if err != nil {
+			return job.failStart(billingError(err, project))
+		}
+	}
+	sourceQueryText := queryText
+	queryText, err = SampleQuery(queryText, options.SampleRate)
+	if err != nil {
+		return job.failStart(err)
+	}
+	var explorationLimit int64
+	if options.DestinationTable == "" {
+		// table is written for use outside of report, its rows are not limited
+		var limited bool
+		if queryText, limited = LimitQuery(queryText, options.ExplorationLimit); limited {
+			explorationLimit = options.ExplorationLimit
+		}
+	}
+	hash := QueryHash(queryText)
+	ordered := job.orderedRun(sourceQueryText, queryText)
+	query, err := newQuery(client, queryText, options, defaultDataset)
+	if err != nil {
+		return job.failStart(err)
+	}
+	if err := applyDestination(&query.QueryConfig, client, options); err != nil {
+		return job.failStart(err)
+	}
+	if err := job.checkDryRun(query, options); err != nil {
+		return job.failStart(billingError(err, project))
+	}
+	start := time.Now()
+	bigqueryJob, err := query.Run(job.Ctx)
+	metrics.Observe(metrics.BigQuery, "job_create", start, err)
+	if err != nil {
+		return job.failStart(reservationError(destinationError(billingError(err, project), options.DestinationTable), job.connections.Reservation(options.Connection)))
+	}
+	running := job.transition(StateRunning, true, func() {
+		job.bigqueryJob = bigqueryJob
+		job.storageObj = obj
+		job.destinationTable = options.DestinationTable
+		job.resultFormat = options.ResultFormat
+		job.serialization = options.Serialization
+		job.compression = options.Compression
+		job.explorationLimit = explorationLimit
+		job.sampleRate = options.SampleRate
+		job.ordered = ordered
+		job.queryText = queryText
+		job.queryHash = hash
+		job.defaultDataset = defaultDataset
+		job.useLegacySQL = options.UseLegacySQL
+		job.sourceQueryText = sourceQueryText
+		job.runOptions = options
+		job.transport = transport
+		job.startedAt = job.now()
+	})
+	if !running {
+		// cancelled while BigQuery job was created
+		stopBigqueryJob(bigqueryJob, job.logger)
+		return nil
+	}
+	job.register(project, options.Connection, bigqueryJob.ID(), bigqueryJob.Location())
+	job.logger.Info().
+		Str("bigqueryJobID", bigqueryJob.ID()).
+		Str("queryHash", hash).
+		Float64("sampleRate", options.SampleRate).
+		Int64("explorationLimit", explorationLimit).
+		Bool("useLegacySQL", options.UseLegacySQL).
+		Msg("Job started")
 	go job.wait()
 	return nil
 }
 
+// newQuery of client with dialect, parameters and default dataset of options, labeled with hash of query text
+func newQuery(client *bigquery.Client, queryText string, options RunOptions, defaultDataset string) (*bigquery.Query, error) {
+	query := client.Query(queryText)
+	query.Labels = map[string]string{queryHashLabel: QueryHash(queryText)}
+	applyDialect(&query.QueryConfig, options.UseLegacySQL)
+	query.Parameters = options.Parameters
+	if err := applyDefaultDataset(&query.QueryConfig, defaultDataset); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
 // Store of jobs
 type Store struct {
-	jobs  []*Job
-	mutex sync.Mutex
+	jobs          []*Job
+	mutex         sync.Mutex
+	registry      Registry
+	replica       string
+	maxResultSize int64
+	maxResultRows int64
+	// maxQueryTextSize in bytes, checked by RPCs and again by jobs
+	maxQueryTextSize int
+	slowQuery        SlowQueryThresholds
+	recorder         StatsRecorder
+	// controlCharacters mode of DEKART_CONTROL_CHARACTERS
+	controlCharacters ControlCharacters
+	// timeouts of DEKART_QUERY_TIMEOUT and DEKART_EXPORT_TIMEOUT
+	timeouts Timeouts
+	// connections of DEKART_BIGQUERY_CONNECTIONS
+	connections *Connections
+	// upload of DEKART_UPLOAD_CHUNK_SIZE, DEKART_PARALLEL_UPLOAD_THRESHOLD and DEKART_PARALLEL_UPLOADS
+	upload UploadSettings
+	// extractThreshold of DEKART_EXTRACT_THRESHOLD, 0 disables extract
+	extractThreshold int64
+	// confirmThreshold of DEKART_CONFIRM_BYTES_THRESHOLD, 0 disables confirmation
+	confirmThreshold int64
+	// hooks of finished jobs registered with OnJobComplete
+	hooks *hooks
+	// now is clock of jobs, replaced in tests
+	now func() time.Time
+	// keys of run requests by query, keyStore shares them with other replicas
+	keys     map[string]claimedKey
+	keyStore KeyStore
+	// projects, runner, bucket and result format of StoreOption, copied to jobs
+	projects    Projects
+	runner      Runner
+	bucket      *storage.BucketHandle
+	format      proto.ResultFormat
+	compression proto.ResultCompression
+	// previewRows of DEKART_PREVIEW_ROWS, 0 disables preview; previewInterval of DEKART_PREVIEW_INTERVAL
+	previewRows     int64
+	previewInterval time.Duration
+	// sqlConnections of DEKART_SQL_CONNECTIONS
+	sqlConnections *SQLConnections
+	// memory budget of DEKART_MEMORY_BUDGET shared by jobs
+	memory *memoryBudget
 }
 
-// NewStore instance
-func NewStore() *Store {
-	store := &Store{}
+// parseLimit of env variable, empty is unlimited
+func parseLimit(name string) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || limit < 0 {
+		log.Fatal().Str("value", value).Msgf("Invalid %s", name)
+	}
+	return limit
+}
+
+// NewStore instance configured by DEKART_* environment, options override it;
+// environment is read only here, jobs don't read it when they run
+func NewStore(options ...StoreOption) *Store {
+	store := &Store{
+		maxResultSize:    parseLimit("DEKART_MAX_RESULT_SIZE"),
+		maxResultRows:    parseLimit("DEKART_MAX_RESULT_ROWS"),
+		maxQueryTextSize: maxQueryTextSize(),
+		slowQuery: SlowQueryThresholds{
+			Wait:  parseThreshold("DEKART_SLOW_QUERY_WAIT"),
+			Total: parseThreshold("DEKART_SLOW_QUERY_TOTAL"),
+		},
+		controlCharacters: controlCharacters(),
+		timeouts:          timeouts(),
+		connections:       connections(),
+		upload:            uploadSettings(),
+		extractThreshold:  parseLimit("DEKART_EXTRACT_THRESHOLD"),
+		confirmThreshold:  parseLimit("DEKART_CONFIRM_BYTES_THRESHOLD"),
+		previewRows:       parseLimit("DEKART_PREVIEW_ROWS"),
+		previewInterval:   previewInterval(),
+		memory:            &memoryBudget{limit: parseLimit("DEKART_MEMORY_BUDGET")},
+		hooks:             newHooks(),
+		now:               time.Now,
+		projects:          projectsFromEnv(),
+	}
+	store.sqlConnections = sqlConnections(store.connections)
 	store.jobs = make([]*Job, 0)
+	store.keys = make(map[string]claimedKey)
+	for _, option := range options {
+		option(store)
+	}
 	return store
 }
 
-func (s *Store) removeJobWhenDone(job *Job) {
-	select {
-	case <-job.Ctx.Done():
-		s.mutex.Lock()
-		for i, j := range s.jobs {
-			if job.ID == j.ID {
-				// removing job from slice
-				last := len(s.jobs) - 1
-				s.jobs[i] = s.jobs[last]
-				s.jobs = s.jobs[:last]
-				break
-			}
+// MaxQueryTextSize in bytes of DEKART_MAX_QUERY_TEXT_SIZE
+func (s *Store) MaxQueryTextSize() int {
+	return s.maxQueryTextSize
+}
+
+// remove job from store, called once by Job.finish
+func (s *Store) remove(job *Job) {
+	s.mutex.Lock()
+	for i, j := range s.jobs {
+		if job.ID == j.ID {
+			// removing job from slice
+			last := len(s.jobs) - 1
+			s.jobs[i] = s.jobs[last]
+			s.jobs = s.jobs[:last]
+			break
 		}
-		s.mutex.Unlock()
-		return
 	}
+	s.mutex.Unlock()
+	s.unregister(job)
+}
+
+// New job on store; reqCtx is context of request which created job, used for logging only
+func (s *Store) New(reqCtx context.Context, reportID string, queryID string) *Job {
+	return s.newJob(reqCtx, reportID, queryID, false)
 }
 
-// New job on store
-func (s *Store) New(reportID string, queryID string) *Job {
+// newInternal job of store running for other job; it's internal before it's listed in store,
+// so it's never matched as job of query
+func (s *Store) newInternal(reqCtx context.Context, reportID string, queryID string) *Job {
+	return s.newJob(reqCtx, reportID, queryID, true)
+}
+
+func (s *Store) newJob(reqCtx context.Context, reportID string, queryID string, internal bool) *Job {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	// job context is cancelled when job finishes, phases of job have own deadlines
+	ctx, cancel := context.WithCancel(context.Background())
+	jobID := uuid.GetUUID()
+	logger := requestid.Logger(reqCtx)
 	job := &Job{
-		ID:       uuid.GetUUID(),
+		ID:       jobID,
 		ReportID: reportID,
 		QueryID:  queryID,
 		Ctx:      ctx,
 		cancel:   cancel,
 		Status:   make(chan int32),
+		// progress while reading result is coalesced, reader never waits for subscriber
+		Progress: make(chan struct{}, 1),
+		registry: s.registry,
+		replica:  s.replica,
+		logger:   logger.With().Str("jobID", jobID).Str("queryID", queryID).Logger(),
+		// job is pending until it is started
+		state:              StatePending,
+		phase:              PhaseCreated,
+		createdAt:          s.now(),
+		phaseAt:            s.now(),
+		now:                s.now,
+		store:              s,
+		maxResultSize:      s.maxResultSize,
+		maxResultRows:      s.maxResultRows,
+		controlCharacters:  s.controlCharacters,
+		maxQueryTextSize:   s.maxQueryTextSize,
+		timeouts:           s.timeouts,
+		connections:        s.connections,
+		upload:             s.upload,
+		extractThreshold:   s.extractThreshold,
+		confirmThreshold:   s.confirmThreshold,
+		dryRun:             dryRunQuery,
+		projects:           s.projects,
+		runner:             s.runner,
+		bucket:             s.bucket,
+		defaultFormat:      s.format,
+		defaultCompression: s.compression,
+		previewLimit:       s.previewRows,
+		previewInterval:    s.previewInterval,
+		sqlConnections:     s.sqlConnections,
+		memory:             s.memory,
+		internal:           internal,
 	}
+	job.reread = job.rereadResult
+	job.logger.Info().Msg("Job created")
 	s.jobs = append(s.jobs, job)
-	go s.removeJobWhenDone(job)
 	return job
 }
 
-// Cancel job for queryID
-func (s *Store) Cancel(queryID string) {
+// cancelWithReason waits for status to be received, returns false when job was finished or cancelled before;
+// reason of first cancellation is kept when cancellations race
+func (job *Job) cancelWithReason(reason proto.CancelReason) bool {
+	cancelled := job.transition(StateCancelled, true, func() {
+		job.cancelReason = reason
+	})
+	if cancelled {
+		job.logger.Info().Str("reason", reason.String()).Msg("Canceling Job Context")
+	}
+	job.finish()
+	return cancelled
+}
+
+// find jobs matching filter; jobs are cancelled without holding store mutex, because finish removes them from store
+func (s *Store) find(match func(job *Job) bool) []*Job {
 	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	jobs := make([]*Job, 0)
 	for _, job := range s.jobs {
-		if job.QueryID == queryID {
-			job.Status <- int32(proto.Query_JOB_STATUS_UNSPECIFIED)
-			log.Info().Msg("Canceling Job Context")
-			job.cancel()
+		if match(job) {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// Cancel job for queryID on this or other replicas; internal jobs sharing queryID of their parent are not jobs of query
+func (s *Store) Cancel(queryID string, reason proto.CancelReason) {
+	for _, job := range s.find(func(job *Job) bool { return !job.internal && job.QueryID == queryID }) {
+		job.cancelWithReason(reason)
+	}
+	s.mutex.Lock()
+	registry := s.registry
+	s.mutex.Unlock()
+	if registry != nil {
+		s.cancelRemote(queryID, reason, "")
+	}
+}
+
+// Supersede jobs of query run before job on this and other replicas, returns number of cancelled jobs
+func (s *Store) Supersede(queryID string, jobID string) int {
+	var cancelled int
+	for _, job := range s.find(func(job *Job) bool { return !job.internal && job.QueryID == queryID && job.ID != jobID }) {
+		if job.cancelWithReason(proto.CancelReason_CANCEL_REASON_SUPERSEDED) {
+			cancelled++
 		}
 	}
+	s.mutex.Lock()
+	registry := s.registry
 	s.mutex.Unlock()
+	if registry != nil {
+		cancelled += s.cancelRemote(queryID, proto.CancelReason_CANCEL_REASON_SUPERSEDED, jobID)
+	}
+	return cancelled
+}
+
+// CancelByReport cancels jobs of report on this replica, returns number of cancelled jobs.
+// Jobs are matched in one snapshot under store mutex, which orders it with New: job created before
+// snapshot is cancelled, and when it's not started yet its Run is rejected with ErrCancelled;
+// job created after snapshot is new run and is not affected.
+func (s *Store) CancelByReport(reportID string, reason proto.CancelReason) int {
+	var cancelled int
+	for _, job := range s.find(func(job *Job) bool { return job.ReportID == reportID }) {
+		if job.cancelWithReason(reason) {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// CancelByBatch cancels jobs of batch on this replica, returns number of cancelled jobs
+func (s *Store) CancelByBatch(batchID string, reason proto.CancelReason) int {
+	var cancelled int
+	for _, job := range s.find(func(job *Job) bool { return job.BatchID == batchID }) {
+		if job.cancelWithReason(reason) {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// CancelJob by job ID
+func (s *Store) CancelJob(jobID string, reason proto.CancelReason) {
+	for _, job := range s.find(func(job *Job) bool { return job.ID == jobID }) {
+		job.cancelWithReason(reason)
+	}
+}
+
+// Get job running on this replica by ID, nil when job is not running on it
+func (s *Store) Get(jobID string) *Job {
+	jobs := s.find(func(job *Job) bool { return job.ID == jobID })
+	if len(jobs) == 0 {
+		return nil
+	}
+	return jobs[0]
+}
+
+// CancelAll jobs on this replica, returns number of cancelled jobs
+func (s *Store) CancelAll(reason proto.CancelReason) int {
+	var cancelled int
+	for _, job := range s.find(func(job *Job) bool { return true }) {
+		if job.cancelWithReason(reason) {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+// Stats of jobs on this replica
+type Stats struct {
+	Jobs int
+	// JobsByStatus is number of jobs by last status name
+	JobsByStatus map[string]int
+	// OldestJobAge is zero when there are no jobs
+	OldestJobAge time.Duration
+	// MemoryReserved by jobs for buffered rows, MemoryBudget is 0 when unlimited
+	MemoryReserved int64
+	MemoryBudget   int64
+}
+
+// Stats of jobs in store
+func (s *Store) Stats() Stats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := Stats{
+		Jobs:         len(s.jobs),
+		JobsByStatus: make(map[string]int),
+	}
+	now := time.Now()
+	for _, job := range s.jobs {
+		job.mutex.Lock()
+		stats.JobsByStatus[proto.Query_JobStatus(job.state.Status()).String()]++
+		if age := now.Sub(job.createdAt); age > stats.OldestJobAge {
+			stats.OldestJobAge = age
+		}
+		job.mutex.Unlock()
+	}
+	stats.MemoryReserved, stats.MemoryBudget = s.memory.usage()
+	return stats
 }
@@ -0,0 +1,441 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"go.uber.org/goleak"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+func TestCompletedJobsDoNotLeakGoroutines(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+	store := NewStore()
+	for i := 0; i < 1000; i++ {
+		job := store.New(context.Background(), "report", fmt.Sprintf("query-%d", i))
+		done := make(chan struct{})
+		go func() {
+			drainStatus(job)
+			close(done)
+		}()
+		if i%2 == 0 {
+			job.cancelWithError(fmt.Errorf("failed"))
+		} else {
+			store.CancelJob(job.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+		}
+		<-done
+	}
+	if stats := store.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected all jobs removed, got %d", stats.Jobs)
+	}
+}
+
+// removeCounter counts Remove calls of registry
+type removeCounter struct {
+	*fakeRegistry
+	removed map[string]int
+	mutex   sync.Mutex
+}
+
+func (r *removeCounter) Remove(ctx context.Context, jobID string) error {
+	r.mutex.Lock()
+	r.removed[jobID]++
+	r.mutex.Unlock()
+	return r.fakeRegistry.Remove(ctx, jobID)
+}
+
+func TestJobRemovedOnceWhenTerminalPathsRace(t *testing.T) {
+	registry := &removeCounter{fakeRegistry: newFakeRegistry(), removed: make(map[string]int)}
+	store := NewStore()
+	store.registry = registry
+	var jobs []*Job
+	for i := 0; i < 100; i++ {
+		job := store.New(context.Background(), "report", "query")
+		// every terminal path sends status, so status is read until end of test
+		go func() {
+			for range job.Status {
+			}
+		}()
+		jobs = append(jobs, job)
+	}
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		job := job
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			job.cancelWithError(fmt.Errorf("failed"))
+		}()
+		go func() {
+			defer wg.Done()
+			store.CancelJob(job.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+		}()
+		go func() {
+			defer wg.Done()
+			job.finish()
+		}()
+	}
+	wg.Wait()
+	if stats := store.Stats(); stats.Jobs != 0 {
+		t.Errorf("expected all jobs removed, got %d", stats.Jobs)
+	}
+	for _, job := range jobs {
+		if n := registry.removed[job.ID]; n != 1 {
+			t.Errorf("job %s removed %d times", job.ID, n)
+		}
+	}
+}
+
+// fakeIterator returns rows like bigquery.RowIterator
+type fakeIterator struct {
+	rows [][]bigquery.Value
+	next int
+}
+
+func (it *fakeIterator) Next(dst interface{}) error {
+	if it.next == len(it.rows) {
+		return iterator.Done
+	}
+	*dst.(*[]bigquery.Value) = it.rows[it.next]
+	it.next++
+	return nil
+}
+
+func newFakeIterator(rows int) *fakeIterator {
+	it := &fakeIterator{}
+	for i := 0; i < rows; i++ {
+		it.rows = append(it.rows, []bigquery.Value{i, "some text value"})
+	}
+	return it
+}
+
+func fakeSchema() bigquery.Schema {
+	return bigquery.Schema{{Name: "id"}, {Name: "text"}}
+}
+
+type fakeResultWriter struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (w *fakeResultWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+// readingJob of store as if BigQuery job is done and result is being stored
+func readingJob(store *Store) *Job {
+	job := store.New(context.Background(), "report", "query")
+	job.state = StateReading
+	return job
+}
+
+// collectStatus until job is done
+func collectStatus(job *Job) chan []int32 {
+	result := make(chan []int32, 1)
+	go func() {
+		var statuses []int32
+		for {
+			select {
+			case status := <-job.Status:
+				statuses = append(statuses, status)
+			case <-job.Ctx.Done():
+				result <- statuses
+				return
+			}
+		}
+	}()
+	return result
+}
+
+func TestResultLimits(t *testing.T) {
+	for name, limits := range map[string][2]int64{
+		"size": {1000, 0},
+		"rows": {0, 10},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := NewStore()
+			store.maxResultSize, store.maxResultRows = limits[0], limits[1]
+			job := readingJob(store)
+			statuses := collectStatus(job)
+			it := newFakeIterator(10000)
+			w := &fakeResultWriter{}
+			cleaned := false
+			job.writeResult(it, fakeSchema, w, func() { cleaned = true })
+
+			if it.next == len(it.rows) {
+				t.Error("expected result to be aborted before iterator is done")
+			}
+			if !cleaned || !w.closed {
+				t.Error("expected partial result to be cleaned up")
+			}
+			if s := <-statuses; len(s) != 1 || s[0] != 0 {
+				t.Errorf("expected failed status, got %v", s)
+			}
+			if !strings.HasPrefix(job.Err(), ResultTooLarge) || !strings.Contains(job.Err(), "LIMIT") {
+				t.Errorf("unexpected error %q", job.Err())
+			}
+			limit := limits[0] + limits[1]
+			if !strings.Contains(job.Err(), fmt.Sprintf("limit of %d", limit)) {
+				t.Errorf("expected limit %d in error %q", limit, job.Err())
+			}
+			if job.GetResultID() != nil {
+				t.Error("expected no result")
+			}
+			if store.Stats().Jobs != 0 {
+				t.Error("expected job removed from store")
+			}
+		})
+	}
+}
+
+func TestResultWithinLimits(t *testing.T) {
+	store := NewStore()
+	store.maxResultSize, store.maxResultRows = 1<<20, 100
+	job := readingJob(store)
+	job.totalRows = 100
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	job.writeResult(newFakeIterator(100), fakeSchema, w, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Errorf("expected done status, got %v", s)
+	}
+	if job.GetResultSize() != int64(w.Len()) || !strings.HasPrefix(w.String(), "id,text\n0,some text value\n") {
+		t.Errorf("unexpected result %d bytes: %.40q", job.GetResultSize(), w.String())
+	}
+	if job.GetRowsWritten() != 100 {
+		t.Errorf("expected 100 rows written, got %d", job.GetRowsWritten())
+	}
+	snapshot := job.GetStatus()
+	if snapshot.Status != int32(proto.Query_JOB_STATUS_DONE) || snapshot.ResultID == nil || *snapshot.ResultID != job.ID ||
+		snapshot.RowsWritten != 100 || snapshot.ResultSize != int64(w.Len()) || snapshot.Err != "" || snapshot.H3ResultID != nil {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+	// progress of 100 rows is coalesced, reading is not blocked without subscriber
+	if len(job.Progress) != 1 {
+		t.Errorf("expected coalesced progress, got %d", len(job.Progress))
+	}
+}
+
+// failingResultWriter fails on failAt write to storage
+type failingResultWriter struct {
+	fakeResultWriter
+	writes int
+	failAt int
+}
+
+func (w *failingResultWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes >= w.failAt {
+		return 0, fmt.Errorf("storage unavailable")
+	}
+	return w.fakeResultWriter.Write(p)
+}
+
+func TestResultWriteFails(t *testing.T) {
+	store := NewStore()
+	job := readingJob(store)
+	job.totalRows = 10000
+	statuses := collectStatus(job)
+	w := &failingResultWriter{failAt: 3}
+	cleaned := false
+	job.writeResult(newFakeIterator(10000), fakeSchema, w, func() { cleaned = true })
+	if s := <-statuses; len(s) != 1 || s[0] != 0 {
+		t.Errorf("expected failed status, got %v", s)
+	}
+	if !strings.Contains(job.Err(), "storage unavailable") {
+		t.Errorf("unexpected error %q", job.Err())
+	}
+	if !cleaned || job.GetResultID() != nil {
+		t.Error("expected partial result to be cleaned up")
+	}
+	if snapshot := job.GetStatus(); snapshot.Status != 0 || snapshot.ResultID != nil || snapshot.Err != job.Err() {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+}
+
+func TestResultRowsMismatch(t *testing.T) {
+	store := NewStore()
+	job := readingJob(store)
+	// iterator ended early without error
+	job.totalRows = 100
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	cleaned := false
+	job.writeResult(newFakeIterator(90), fakeSchema, w, func() { cleaned = true })
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != 0 {
+		t.Errorf("expected failed status, got %v", s)
+	}
+	if job.Err() != "result has 90 rows, query returned 100 rows" {
+		t.Errorf("unexpected error %q", job.Err())
+	}
+	if !cleaned || job.GetResultID() != nil {
+		t.Error("expected incomplete result to be cleaned up")
+	}
+}
+
+func TestResultInDestinationTable(t *testing.T) {
+	job := NewStore().New(context.Background(), "report", "query")
+	statuses := collectStatus(job)
+	job.transition(StateRunning, true, func() { job.destinationTable = "data-project.results.points" })
+	job.doneInTable(&bigquery.JobStatus{Statistics: &bigquery.JobStatistics{TotalBytesProcessed: 100}})
+	if s := <-statuses; len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Errorf("unexpected statuses %v", s)
+	}
+	snapshot := job.GetStatus()
+	if snapshot.State != StateDone || snapshot.ResultID != nil || snapshot.ResultTable == nil || *snapshot.ResultTable != "data-project.results.points" {
+		t.Errorf("expected table result, got %+v", snapshot)
+	}
+	if snapshot.ProcessedBytes != 100 {
+		t.Errorf("expected stats of job, got %d", snapshot.ProcessedBytes)
+	}
+}
+
+func TestCancelByReport(t *testing.T) {
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		t.Error("cancelled job must not create BigQuery job")
+		return nil, fmt.Errorf("no client in test")
+	}
+	store := NewStore()
+	running := store.New(context.Background(), "report", "query-1")
+	pending := store.New(context.Background(), "report", "query-2")
+	other := store.New(context.Background(), "other-report", "query-3")
+	for _, job := range []*Job{running, pending, other} {
+		go drainStatus(job)
+	}
+	eventRun(running)
+	if cancelled := store.CancelByReport("report", proto.CancelReason_CANCEL_REASON_USER_REQUEST); cancelled != 2 {
+		t.Errorf("expected 2 cancelled jobs, got %d", cancelled)
+	}
+	if running.GetState() != StateCancelled || pending.GetState() != StateCancelled || other.GetState() != StatePending {
+		t.Errorf("unexpected states %s %s %s", running.GetState(), pending.GetState(), other.GetState())
+	}
+	if cancelled := store.CancelByReport("report", proto.CancelReason_CANCEL_REASON_USER_REQUEST); cancelled != 0 {
+		t.Errorf("expected finished jobs not counted again, got %d", cancelled)
+	}
+	// job created before cancel is rejected when started
+	if err := pending.Run("select 1", RunOptions{}, nil); err != ErrCancelled {
+		t.Errorf("expected ErrCancelled, got %v", err)
+	}
+	other.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+}
+
+func TestCancelReason(t *testing.T) {
+	userRequest := proto.CancelReason_CANCEL_REASON_USER_REQUEST
+	for name, c := range map[string]struct {
+		cancel func(store *Store, job *Job)
+		reason proto.CancelReason
+	}{
+		"query":      {func(store *Store, job *Job) { store.Cancel(job.QueryID, userRequest) }, userRequest},
+		"job":        {func(store *Store, job *Job) { store.CancelJob(job.ID, proto.CancelReason_CANCEL_REASON_ORPHAN_TIMEOUT) }, proto.CancelReason_CANCEL_REASON_ORPHAN_TIMEOUT},
+		"report":     {func(store *Store, job *Job) { store.CancelByReport(job.ReportID, userRequest) }, userRequest},
+		"batch":      {func(store *Store, job *Job) { store.CancelByBatch("batch", proto.CancelReason_CANCEL_REASON_ADMIN) }, proto.CancelReason_CANCEL_REASON_ADMIN},
+		"shutdown":   {func(store *Store, job *Job) { store.CancelAll(proto.CancelReason_CANCEL_REASON_SHUTDOWN) }, proto.CancelReason_CANCEL_REASON_SHUTDOWN},
+		"superseded": {func(store *Store, job *Job) { store.Supersede(job.QueryID, "next") }, proto.CancelReason_CANCEL_REASON_SUPERSEDED},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := NewStore()
+			job := store.New(context.Background(), "report", "query")
+			job.BatchID = "batch"
+			go drainStatus(job)
+			c.cancel(store, job)
+			if snapshot := job.GetStatus(); snapshot.State != StateCancelled || snapshot.CancelReason != c.reason {
+				t.Errorf("expected %s, got %s %s", c.reason, snapshot.State, snapshot.CancelReason)
+			}
+		})
+	}
+
+	store := NewStore()
+	next := store.New(context.Background(), "report", "query")
+	go drainStatus(next)
+	if superseded := store.Supersede("query", next.ID); superseded != 0 || next.GetState() != StatePending {
+		t.Errorf("expected job superseding others not cancelled, got %d %s", superseded, next.GetState())
+	}
+	// reason of job which is not cancelled is unspecified
+	if reason := next.GetStatus().CancelReason; reason != proto.CancelReason_CANCEL_REASON_UNSPECIFIED {
+		t.Errorf("expected unspecified reason, got %s", reason)
+	}
+	next.Abort()
+}
+
+func TestCancelReasonFirstWins(t *testing.T) {
+	reasons := []proto.CancelReason{
+		proto.CancelReason_CANCEL_REASON_USER_REQUEST,
+		proto.CancelReason_CANCEL_REASON_ADMIN,
+		proto.CancelReason_CANCEL_REASON_ORPHAN_TIMEOUT,
+		proto.CancelReason_CANCEL_REASON_SHUTDOWN,
+		proto.CancelReason_CANCEL_REASON_SUPERSEDED,
+	}
+	for i := 0; i < 20; i++ {
+		store := NewStore()
+		job := store.New(context.Background(), "report", "query")
+		go drainStatus(job)
+		won := make(chan proto.CancelReason, len(reasons))
+		var wg sync.WaitGroup
+		for _, reason := range reasons {
+			wg.Add(1)
+			go func(reason proto.CancelReason) {
+				defer wg.Done()
+				if job.cancelWithReason(reason) {
+					won <- reason
+				}
+			}(reason)
+		}
+		wg.Wait()
+		close(won)
+		first, ok := <-won
+		if _, again := <-won; !ok || again {
+			t.Fatal("expected exactly one cancellation to finish job")
+		}
+		// later cancellation doesn't overwrite reason of terminal status
+		store.CancelJob(job.ID, proto.CancelReason_CANCEL_REASON_ADMIN)
+		if reason := job.GetStatus().CancelReason; reason != first {
+			t.Fatalf("expected reason %s of first cancellation, got %s", first, reason)
+		}
+	}
+}
+
+func TestCancelByReportConcurrentNew(t *testing.T) {
+	store := NewStore()
+	jobs := make(chan *Job, 100)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job := store.New(context.Background(), "report", "query")
+			go drainStatus(job)
+			jobs <- job
+		}()
+	}
+	cancelled := store.CancelByReport("report", proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	wg.Wait()
+	close(jobs)
+	// every job is either cancelled by the call and counted, or created after it and not affected
+	var cancelledStates, pendingStates int
+	for job := range jobs {
+		switch job.GetState() {
+		case StateCancelled:
+			cancelledStates++
+		case StatePending:
+			pendingStates++
+		default:
+			t.Errorf("unexpected state %s", job.GetState())
+		}
+	}
+	if cancelledStates != cancelled || cancelledStates+pendingStates != 100 {
+		t.Errorf("cancelled %d, got %d cancelled and %d pending jobs", cancelled, cancelledStates, pendingStates)
+	}
+	if again := store.CancelByReport("report", proto.CancelReason_CANCEL_REASON_USER_REQUEST); again != pendingStates || store.Stats().Jobs != 0 {
+		t.Errorf("expected %d jobs created after first cancel, got %d, %d left", pendingStates, again, store.Stats().Jobs)
+	}
+}
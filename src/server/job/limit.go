@@ -0,0 +1,51 @@
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ValidateExplorationLimit of report is 0 when exploration mode is off or maximum rows of result
+func ValidateExplorationLimit(limit int64) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid exploration limit %d, expected number of rows, 0 disables exploration mode", limit)
+	}
+	return nil
+}
+
+// queryStartRe matches start of query statement, other statements are scripts, DDL or DML
+var queryStartRe = regexp.MustCompile(`(?is)^[\s(]*(select|with)\b`)
+
+// trailingLimitRe matches LIMIT with optional OFFSET ending statement
+var trailingLimitRe = regexp.MustCompile(`(?is)\blimit\s+(\d+)(?:\s+offset\s+\d+)?\s*$`)
+
+// LimitQuery wraps query in outer LIMIT, so exploration of clustered and partitioned tables reads less bytes;
+// query is not wrapped when limit is 0, it already ends in LIMIT not larger than limit, or it's not single query statement.
+// Query ending in top level ORDER BY gets LIMIT appended instead, so its rows stay ordered. Returns query and whether it was limited
+func LimitQuery(queryText string, limit int64) (string, bool) {
+	if limit <= 0 {
+		return queryText, false
+	}
+	trimmed, code, err := trimStatement(queryText)
+	if err != nil {
+		// invalid query fails in BigQuery with its own error
+		return queryText, false
+	}
+	if strings.Contains(code, ";") || !queryStartRe.MatchString(code) {
+		return queryText, false
+	}
+	m := trailingLimitRe.FindStringSubmatch(code)
+	if m != nil {
+		if existing, err := strconv.ParseInt(m[1], 10, 64); err == nil && existing <= limit {
+			return queryText, false
+		}
+	}
+	if m == nil && OrderedQuery(trimmed) {
+		// subquery is not kept ordered, so LIMIT follows ORDER BY of query
+		return fmt.Sprintf("%s\nLIMIT %d", trimmed, limit), true
+	}
+	// query is kept on own lines like sampled query, so comment on its last line doesn't hide LIMIT
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) LIMIT %d", trimmed, limit), true
+}
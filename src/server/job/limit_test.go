@@ -0,0 +1,139 @@
+package job
+
+import (
+	"testing"
+)
+
+func TestLimitQuery(t *testing.T) {
+	for name, c := range map[string]struct {
+		query    string
+		limit    int64
+		expected string
+	}{
+		"disabled": {
+			"select * from points", 0, "",
+		},
+		"select": {
+			"select * from points", 1000,
+			"SELECT * FROM (\nselect * from points\n) LIMIT 1000",
+		},
+		"trailing semicolons": {
+			"select * from points;; \n", 100,
+			"SELECT * FROM (\nselect * from points\n) LIMIT 100",
+		},
+		"trailing comments": {
+			"select * from points -- all points\n/* done */ # end", 100,
+			"SELECT * FROM (\nselect * from points\n) LIMIT 100",
+		},
+		"comment on last line in middle": {
+			"select *\nfrom points -- all\nwhere id > 1", 100,
+			"SELECT * FROM (\nselect *\nfrom points -- all\nwhere id > 1\n) LIMIT 100",
+		},
+		"leading comment": {
+			"-- explore\n/* points */ SELECT lat, lng FROM points", 10,
+			"SELECT * FROM (\n-- explore\n/* points */ SELECT lat, lng FROM points\n) LIMIT 10",
+		},
+		"cte": {
+			"WITH p AS (SELECT * FROM points LIMIT 5)\nSELECT * FROM p", 10,
+			"SELECT * FROM (\nWITH p AS (SELECT * FROM points LIMIT 5)\nSELECT * FROM p\n) LIMIT 10",
+		},
+		"parenthesized union": {
+			"(select 1) union all (select 2)", 10,
+			"SELECT * FROM (\n(select 1) union all (select 2)\n) LIMIT 10",
+		},
+		"smaller limit": {
+			"select * from points limit 10", 100, "",
+		},
+		"equal limit": {
+			"select * from points LIMIT 100;", 100, "",
+		},
+		"smaller limit with offset": {
+			"select * from points order by id limit 10 offset 20 -- second page", 100, "",
+		},
+		"larger limit": {
+			"select * from points limit 100000", 100,
+			"SELECT * FROM (\nselect * from points limit 100000\n) LIMIT 100",
+		},
+		"limit in subquery": {
+			"select * from (select * from points limit 10)", 100,
+			"SELECT * FROM (\nselect * from (select * from points limit 10)\n) LIMIT 100",
+		},
+		"limit in literal": {
+			"select * from points where name = 'limit 10'", 100,
+			"SELECT * FROM (\nselect * from points where name = 'limit 10'\n) LIMIT 100",
+		},
+		"limit in comment": {
+			"select * from points /* limit 10 */ where id > 1", 100,
+			"SELECT * FROM (\nselect * from points /* limit 10 */ where id > 1\n) LIMIT 100",
+		},
+		"limit parameter": {
+			"select * from points limit @rows", 100,
+			"SELECT * FROM (\nselect * from points limit @rows\n) LIMIT 100",
+		},
+		"column named limit_rows": {
+			"select limit_rows from points", 100,
+			"SELECT * FROM (\nselect limit_rows from points\n) LIMIT 100",
+		},
+		"semicolon in literal": {
+			"select ';' as a from points;", 100,
+			"SELECT * FROM (\nselect ';' as a from points\n) LIMIT 100",
+		},
+		"script": {
+			"DECLARE n INT64 DEFAULT 1;\nSELECT * FROM points LIMIT n", 100, "",
+		},
+		"multiple statements": {
+			"select 1; select 2", 100, "",
+		},
+		"begin block": {
+			"BEGIN\n  SELECT 1;\nEND", 100, "",
+		},
+		"dml": {
+			"INSERT INTO points SELECT * FROM other", 100, "",
+		},
+		"ddl": {
+			"CREATE TABLE p AS SELECT * FROM points", 100, "",
+		},
+		"identifier starting with select": {
+			"selected_points", 100, "",
+		},
+		"unterminated comment": {
+			"select * from points /* ", 100, "",
+		},
+		"empty": {
+			" ; ", 100, "",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			limited, wrapped := LimitQuery(c.query, c.limit)
+			if c.expected == "" {
+				if wrapped || limited != c.query {
+					t.Errorf("expected query not wrapped, got\n%s", limited)
+				}
+				return
+			}
+			if !wrapped || limited != c.expected {
+				t.Errorf("expected\n%s\ngot\n%s", c.expected, limited)
+			}
+		})
+	}
+}
+
+func TestLimitSampledQuery(t *testing.T) {
+	sampled, err := SampleQuery("select * from points", 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limited, wrapped := LimitQuery(sampled, 50)
+	expected := "SELECT * FROM (\nSELECT * FROM points TABLESAMPLE SYSTEM (10 PERCENT)\n) LIMIT 50"
+	if !wrapped || limited != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, limited)
+	}
+}
+
+func TestValidateExplorationLimit(t *testing.T) {
+	for limit, valid := range map[int64]bool{0: true, 1: true, 1000: true, -1: false} {
+		if err := ValidateExplorationLimit(limit); (err == nil) != valid {
+			t.Errorf("%d: expected valid %v, got %v", limit, valid, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// Lineage of job result: BigQuery job which produced it and values it was run with
+type Lineage struct {
+	BigqueryJobID    string
+	BigqueryProject  string
+	BigqueryLocation string
+	// ParameterValues of named parameters passed to BigQuery, by name of named parameter
+	ParameterValues map[string]string
+	// StartedAt when BigQuery job was created, zero when job didn't start
+	StartedAt time.Time
+}
+
+// Lineage of started job; BigQuery fields are empty when BigQuery job wasn't created
+func (job *Job) Lineage() Lineage {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	lineage := Lineage{StartedAt: job.startedAt}
+	if job.bigqueryJob != nil {
+		lineage.BigqueryJobID = job.bigqueryJob.ID()
+		lineage.BigqueryLocation = job.bigqueryJob.Location()
+		lineage.BigqueryProject = job.projects.billingProject(job.runOptions.BillingProject)
+	}
+	if len(job.runOptions.Parameters) > 0 {
+		lineage.ParameterValues = make(map[string]string, len(job.runOptions.Parameters))
+		for _, p := range job.runOptions.Parameters {
+			lineage.ParameterValues[p.Name] = formatParameterValue(p.Value)
+		}
+	}
+	return lineage
+}
+
+// formatParameterValue of ParameterValue in format it's parsed from
+func formatParameterValue(value interface{}) string {
+	switch v := value.(type) {
+	case civil.Date:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,33 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+)
+
+func TestLineageParameterValues(t *testing.T) {
+	store := NewStore()
+	job := store.New(context.Background(), "report", "query")
+	defer job.Abort()
+	job.runOptions = RunOptions{Parameters: []bigquery.QueryParameter{
+		{Name: "n", Value: int64(10)},
+		{Name: "day", Value: civil.Date{Year: 2021, Month: 2, Day: 3}},
+		{Name: "at", Value: time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)},
+		{Name: "ok", Value: true},
+	}}
+	lineage := job.Lineage()
+	// values are formatted as they are parsed by ParameterValue
+	expected := map[string]string{"n": "10", "day": "2021-02-03", "at": "2021-02-03T04:05:06Z", "ok": "true"}
+	for name, value := range expected {
+		if lineage.ParameterValues[name] != value {
+			t.Errorf("expected %s=%s, got %v", name, value, lineage.ParameterValues)
+		}
+	}
+	if lineage.BigqueryJobID != "" || !lineage.StartedAt.IsZero() {
+		t.Errorf("expected no BigQuery job of job which didn't start, got %+v", lineage)
+	}
+}
@@ -0,0 +1,98 @@
+package job
+
+import (
+	"dekart/src/server/metrics"
+	"sync/atomic"
+)
+
+// Components of job holding buffered rows in memory budget
+const (
+	memoryUpload  = "upload"
+	memoryPreview = "preview"
+	memoryH3      = "h3"
+)
+
+// h3CellBytes of cell in aggregator map, metrics add h3MetricBytes each
+const (
+	h3CellBytes   = 112
+	h3MetricBytes = 16
+)
+
+// memoryBudget of rows buffered by jobs of store; reservation above limit fails, components of job
+// degrade instead of growing, 0 limit only counts usage
+type memoryBudget struct {
+	limit int64
+	used  int64
+}
+
+// reserve n bytes, false when budget is exhausted; nil budget is unlimited
+func (b *memoryBudget) reserve(n int64) bool {
+	if b == nil {
+		return true
+	}
+	used := atomic.AddInt64(&b.used, n)
+	if b.limit > 0 && used > b.limit {
+		atomic.AddInt64(&b.used, -n)
+		return false
+	}
+	metrics.MemoryReserved.Add(float64(n))
+	return true
+}
+
+// release n reserved bytes
+func (b *memoryBudget) release(n int64) {
+	if b == nil || n == 0 {
+		return
+	}
+	atomic.AddInt64(&b.used, -n)
+	metrics.MemoryReserved.Sub(float64(n))
+}
+
+// usage of budget and its limit
+func (b *memoryBudget) usage() (used int64, limit int64) {
+	if b == nil {
+		return 0, 0
+	}
+	return atomic.LoadInt64(&b.used), b.limit
+}
+
+// WithMemoryBudget of rows buffered by jobs instead of DEKART_MEMORY_BUDGET, 0 is unlimited
+func WithMemoryBudget(limit int64) StoreOption {
+	return func(s *Store) {
+		s.memory = &memoryBudget{limit: limit}
+	}
+}
+
+// reserveMemory of n bytes for component of job, false when component must degrade; reserved memory
+// is released when job finishes
+func (job *Job) reserveMemory(component string, n int64) bool {
+	if !job.memory.reserve(n) {
+		metrics.MemoryDegraded.WithLabelValues(component).Inc()
+		job.logger.Warn().Str("component", component).Int64("bytes", n).Msg("Memory budget exhausted")
+		return false
+	}
+	job.mutex.Lock()
+	job.memoryReserved += n
+	job.mutex.Unlock()
+	return true
+}
+
+// releaseMemory of n bytes reserved by job before it finishes
+func (job *Job) releaseMemory(n int64) {
+	job.mutex.Lock()
+	if n > job.memoryReserved {
+		n = job.memoryReserved
+	}
+	job.memoryReserved -= n
+	job.mutex.Unlock()
+	job.memory.release(n)
+}
+
+// releaseAllMemory of finished job
+func (job *Job) releaseAllMemory() {
+	job.mutex.Lock()
+	n := job.memoryReserved
+	job.memoryReserved = 0
+	job.mutex.Unlock()
+	job.memory.release(n)
+}
@@ -0,0 +1,128 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	b := &memoryBudget{limit: 100}
+	if !b.reserve(60) || !b.reserve(40) {
+		t.Fatal("expected reservations within budget")
+	}
+	if b.reserve(1) {
+		t.Error("expected reservation above budget to fail")
+	}
+	b.release(50)
+	if used, limit := b.usage(); used != 50 || limit != 100 {
+		t.Errorf("unexpected usage %d of %d", used, limit)
+	}
+	// usage is counted without limit
+	unlimited := &memoryBudget{}
+	if !unlimited.reserve(1 << 40) {
+		t.Error("expected unlimited budget")
+	}
+	var none *memoryBudget
+	if !none.reserve(1) {
+		t.Error("expected job without budget to reserve")
+	}
+}
+
+func TestMemoryBudgetSkipsH3(t *testing.T) {
+	store := NewStore(WithMemoryBudget(h3CellBytes))
+	job := readingJob(store)
+	job.totalRows = 5
+	statuses := collectStatus(job)
+	job.AggregateH3(&proto.H3Aggregation{LatColumn: "lat", LngColumn: "lng", Resolution: 7}, nil, "h3-result")
+	job.h3Writer = func(ctx context.Context) resultWriter {
+		t.Error("unexpected aggregated result")
+		return &fakeResultWriter{}
+	}
+	w := &fakeResultWriter{}
+	job.writeResult(pointsIterator(), pointsSchema, w, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v %s", s, job.Err())
+	}
+	// Berlin cell fits budget, New York cell doesn't
+	if job.GetH3ResultID() != nil || job.GetResultID() == nil || strings.Count(w.String(), "\n") != 6 {
+		t.Errorf("expected raw result without aggregation, got %q", w.String())
+	}
+	if warnings := job.GetStatus().Warnings; len(warnings) != 1 || warnings[0].Code != WarningH3Skipped {
+		t.Errorf("expected warning of skipped aggregation, got %v", warnings)
+	}
+	if stats := store.Stats(); stats.MemoryReserved != 0 || stats.MemoryBudget != h3CellBytes {
+		t.Errorf("expected memory released, got %+v", stats)
+	}
+}
+
+func TestMemoryBudgetStopsPreview(t *testing.T) {
+	// header and first two rows fit budget
+	store := NewStore(WithMemoryBudget(int64(len("id,text\n0,some text value\n1,some text value\n"))))
+	job := readingJob(store)
+	job.totalRows = 5
+	preview := &fakePreview{}
+	preview.use(job, 5, 0)
+	statuses := collectStatus(job)
+	job.writeResult(newFakeIterator(5), fakeSchema, &fakeResultWriter{}, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v %s", s, job.Err())
+	}
+	last := preview.stored[len(preview.stored)-1]
+	if strings.Count(last, "\n") != 3 || job.preview.maxRows != 2 {
+		t.Errorf("expected preview of 2 rows, got %q", last)
+	}
+	if stats := store.Stats(); stats.MemoryReserved != 0 {
+		t.Errorf("expected memory released, got %+v", stats)
+	}
+}
+
+func TestMemoryBudgetSingleWriter(t *testing.T) {
+	settings := UploadSettings{ChunkSize: 8, ParallelThreshold: 16, Parallelism: 2}
+	for budget, parallel := range map[int64]bool{
+		0:  true,
+		24: true,
+		23: false,
+	} {
+		store := NewStore(WithMemoryBudget(budget))
+		store.SetUploadSettings(settings)
+		job := readingJob(store)
+		job.storageObj = (&storage.Client{}).Bucket("dekart").Object("result.csv")
+		job.parallel = newFakeComponents()
+		ctx, abort := context.WithCancel(context.Background())
+		w := job.newResultWriter(ctx).(*instrumentedWriter)
+		if _, ok := w.w.(*parallelWriter); ok != parallel {
+			t.Errorf("budget %d: expected parallel %v", budget, parallel)
+		}
+		if diagnostics := store.Snapshot(); parallel && diagnostics[0].MemoryReserved != 24 {
+			t.Errorf("budget %d: expected components reserved, got %+v", budget, diagnostics)
+		}
+		abort()
+		job.finish()
+		if stats := store.Stats(); stats.MemoryReserved != 0 {
+			t.Errorf("budget %d: expected memory released, got %+v", budget, stats)
+		}
+	}
+}
+
+func TestMemoryReleasedOnCancel(t *testing.T) {
+	store := NewStore(WithMemoryBudget(1000))
+	job := readingJob(store)
+	if !job.reserveMemory(memoryPreview, 600) || job.reserveMemory(memoryPreview, 600) {
+		t.Fatal("expected second reservation above budget to fail")
+	}
+	statuses := collectStatus(job)
+	store.Cancel(job.QueryID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	select {
+	case <-statuses:
+	case <-time.After(time.Second):
+		t.Fatal("job was not cancelled")
+	}
+	if stats := store.Stats(); stats.MemoryReserved != 0 || stats.Jobs != 0 {
+		t.Errorf("expected memory of cancelled job released, got %+v", stats)
+	}
+}
@@ -0,0 +1,341 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// RunOptions of query set on report
+type RunOptions struct {
+	// BillingProject billed for query, empty for instance default
+	BillingProject string
+	// DefaultDataset for unqualified table names, empty for instance default
+	DefaultDataset string
+	// SampleRate is fraction of rows in result, 0 for all rows
+	SampleRate float64
+	// DestinationTable receives result instead of result file as project.dataset.table or dataset.table of data project,
+	// empty for file result
+	DestinationTable string
+	// WriteDisposition of destination table
+	WriteDisposition bigquery.TableWriteDisposition
+	// ResultFormat of result file, CSV when unspecified
+	ResultFormat proto.ResultFormat
+	// Serialization of CSV result cells, nil for default serialization
+	Serialization *proto.SerializationOptions
+	// Compression of CSV result file, uncompressed when unspecified
+	Compression proto.ResultCompression
+	// ExplorationLimit of report wraps query in LIMIT, 0 when exploration mode is off
+	ExplorationLimit int64
+	// UseLegacySQL of query, standard SQL when false
+	UseLegacySQL bool
+	// Parameters bound to named parameters of query text, values of report parameters
+	Parameters []bigquery.QueryParameter
+	// Connection of federated credentials job is run with, empty for credentials of instance
+	Connection string
+	// AcknowledgedBytes of estimate user confirmed, needed when estimate is above DEKART_CONFIRM_BYTES_THRESHOLD
+	AcknowledgedBytes int64
+	// AsOf snapshot timestamp tables of query are read at with FOR SYSTEM_TIME AS OF, zero reads current data
+	AsOf time.Time
+}
+
+// Runner creates BigQuery client billing queries to projectID
+type Runner func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error)
+
+// newBigqueryClient billing queries to projectID
+var newBigqueryClient Runner = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+	return bigquery.NewClient(ctx, projectID, opts...)
+}
+
+// bigqueryClient of job billing project of options; job of connection is authorized with its federated credentials.
+// Requests of client go through transport of job, which sets reservation of connection
+func (job *Job) bigqueryClient(options RunOptions) (*bigquery.Client, *jobTransport, error) {
+	project := job.projects.billingProject(options.BillingProject)
+	var source oauth2.TokenSource = &instanceTokenSource{ctx: job.Ctx}
+	if options.Connection != "" {
+		var err error
+		source, err = job.connections.TokenSource(job.Ctx, options.Connection)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	transport := &jobTransport{base: http.DefaultTransport, reservation: job.connections.Reservation(options.Connection)}
+	newClient := newBigqueryClient
+	if job.runner != nil {
+		newClient = job.runner
+	}
+	client, err := newClient(job.Ctx, project, option.WithHTTPClient(&http.Client{
+		Transport: &oauth2.Transport{Source: source, Base: transport},
+	}))
+	return client, transport, err
+}
+
+// Projects of BigQuery jobs of store; dataset and table names without project are in Data project
+type Projects struct {
+	// Data project holding tables of reports
+	Data string
+	// Billing project of queries, Data project when empty
+	Billing string
+	// DefaultDataset for unqualified table names as project.dataset or dataset, empty when not configured
+	DefaultDataset string
+}
+
+// projectsFromEnv of DEKART_BIGQUERY_PROJECT_ID, DEKART_BIGQUERY_BILLING_PROJECT_ID and DEKART_BIGQUERY_DEFAULT_DATASET
+func projectsFromEnv() Projects {
+	return Projects{
+		Data:           os.Getenv("DEKART_BIGQUERY_PROJECT_ID"),
+		Billing:        os.Getenv("DEKART_BIGQUERY_BILLING_PROJECT_ID"),
+		DefaultDataset: os.Getenv("DEKART_BIGQUERY_DEFAULT_DATASET"),
+	}
+}
+
+// billingProject for queries; override is billing project of report
+func (p Projects) billingProject(override string) string {
+	if override != "" {
+		return override
+	}
+	if p.Billing != "" {
+		return p.Billing
+	}
+	return p.Data
+}
+
+// defaultDataset as project.dataset; override is default dataset of report
+func (p Projects) defaultDataset(override string) string {
+	dataset := override
+	if dataset == "" {
+		dataset = p.DefaultDataset
+	}
+	if dataset == "" || strings.Contains(dataset, ".") {
+		return dataset
+	}
+	// dataset without project is in data project
+	return fmt.Sprintf("%s.%s", p.Data, dataset)
+}
+
+// qualifyTable as project.dataset.table; table without project is in data project
+func (p Projects) qualifyTable(table string) string {
+	if strings.Count(table, ".") != 1 {
+		return table
+	}
+	return fmt.Sprintf("%s.%s", p.Data, table)
+}
+
+// Projects of store, DEKART_BIGQUERY_* environment unless set with WithProjects
+func (s *Store) Projects() Projects {
+	return s.projects
+}
+
+// BillingProject of store for queries; override is billing project of report.
+// Tables in query text are qualified with data project, so billing project only decides who pays
+func (s *Store) BillingProject(override string) string {
+	return s.projects.billingProject(override)
+}
+
+// DefaultDataset of store as project.dataset; override is default dataset of report, empty when not configured
+func (s *Store) DefaultDataset(override string) string {
+	return s.projects.defaultDataset(override)
+}
+
+// parseDataset project.dataset
+func parseDataset(dataset string) (string, string, error) {
+	parts := strings.Split(dataset, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid default dataset %s, expected project.dataset or dataset", dataset)
+	}
+	return parts[0], parts[1], nil
+}
+
+// ValidateDataset format, dataset is project.dataset or dataset, empty is not configured
+func ValidateDataset(dataset string) error {
+	if dataset == "" {
+		return nil
+	}
+	if !strings.Contains(dataset, ".") {
+		// dataset without project is in data project of store
+		dataset = "project." + dataset
+	}
+	_, _, err := parseDataset(dataset)
+	return err
+}
+
+// ValidateResultFormat is known format of result file
+func ValidateResultFormat(format proto.ResultFormat) error {
+	if _, ok := proto.ResultFormat_name[int32(format)]; !ok {
+		return fmt.Errorf("unknown result_format %d", format)
+	}
+	return nil
+}
+
+// applyDefaultDataset to query config; qualified table names in query text are resolved by BigQuery as is
+func applyDefaultDataset(config *bigquery.QueryConfig, dataset string) error {
+	if dataset == "" {
+		return nil
+	}
+	projectID, datasetID, err := parseDataset(dataset)
+	if err != nil {
+		return err
+	}
+	config.DefaultProjectID = projectID
+	config.DefaultDatasetID = datasetID
+	return nil
+}
+
+// CheckDataset exists, dataset is project.dataset; metadata read is billed to billingProject resolved by store
+func CheckDataset(ctx context.Context, billingProject string, dataset string) error {
+	projectID, datasetID, err := parseDataset(dataset)
+	if err != nil {
+		return err
+	}
+	client, err := newBigqueryClient(ctx, billingProject)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	start := time.Now()
+	_, err = client.DatasetInProject(projectID, datasetID).Metadata(ctx)
+	metrics.Observe(metrics.BigQuery, "dataset_metadata", start, err)
+	return err
+}
+
+// ListDatasets of data project of store, first page of one dataset is read to check access
+func (s *Store) ListDatasets(ctx context.Context) error {
+	client, err := newBigqueryClient(ctx, s.projects.billingProject(""))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	start := time.Now()
+	it := client.DatasetsInProject(ctx, s.projects.Data)
+	it.PageInfo().MaxSize = 1
+	_, err = it.Next()
+	if err == iterator.Done {
+		err = nil
+	}
+	metrics.Observe(metrics.BigQuery, "datasets_list", start, err)
+	return err
+}
+
+// billingError adds hint when user can't create jobs in billing project
+func billingError(err error, project string) error {
+	var apiErr *googleapi.Error
+	if hinted := permissionError(err); hinted != err {
+		// latest permission report names missing permission and role
+		return hinted
+	}
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden && strings.Contains(apiErr.Message, "bigquery.jobs.create") {
+		return fmt.Errorf("%w; grant bigquery.jobs.create permission on billing project %s or set other billing project for report", err, project)
+	}
+	return err
+}
+
+// writeDispositions of destination table; unspecified fails job when table has rows
+var writeDispositions = map[proto.DestinationTable_WriteDisposition]bigquery.TableWriteDisposition{
+	proto.DestinationTable_WRITE_DISPOSITION_UNSPECIFIED: bigquery.WriteEmpty,
+	proto.DestinationTable_WRITE_DISPOSITION_TRUNCATE:    bigquery.WriteTruncate,
+	proto.DestinationTable_WRITE_DISPOSITION_APPEND:      bigquery.WriteAppend,
+}
+
+// parseTable project.dataset.table
+func parseTable(table string) (string, string, string, error) {
+	parts := strings.Split(table, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid destination table %s, expected project.dataset.table or dataset.table", table)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// ValidateDestinationTable format and write disposition; table without project is in data project of store
+func ValidateDestinationTable(destination *proto.DestinationTable) error {
+	table := destination.Table
+	if strings.Count(table, ".") == 1 {
+		table = "project." + table
+	}
+	if _, _, _, err := parseTable(table); err != nil {
+		return fmt.Errorf("invalid destination table %s, expected project.dataset.table or dataset.table", destination.Table)
+	}
+	if _, ok := writeDispositions[destination.WriteDisposition]; !ok {
+		return fmt.Errorf("unknown write_disposition %d", destination.WriteDisposition)
+	}
+	return nil
+}
+
+// WithDestination returns options writing result to destination table instead of result file;
+// table without project is qualified with data project of store when job is run
+func (o RunOptions) WithDestination(destination *proto.DestinationTable) RunOptions {
+	o.DestinationTable = destination.Table
+	o.WriteDisposition = writeDispositions[destination.WriteDisposition]
+	return o
+}
+
+// applyDestination table to query config, table is created when it doesn't exist
+func applyDestination(config *bigquery.QueryConfig, client *bigquery.Client, options RunOptions) error {
+	if options.DestinationTable == "" {
+		return nil
+	}
+	projectID, datasetID, tableID, err := parseTable(options.DestinationTable)
+	if err != nil {
+		return err
+	}
+	config.Dst = client.DatasetInProject(projectID, datasetID).Table(tableID)
+	config.CreateDisposition = bigquery.CreateIfNeeded
+	config.WriteDisposition = options.WriteDisposition
+	return nil
+}
+
+// DestinationAccessDenied prefixes job error when result can't be written to destination table
+const DestinationAccessDenied = "DESTINATION_ACCESS_DENIED"
+
+// destinationPermissions needed to write query result to table
+var destinationPermissions = []string{"bigquery.tables.create", "bigquery.tables.updateData"}
+
+// destinationDenied when error is about writing destination table; access to source tables and billing project are not
+func destinationDenied(err error, table string) bool {
+	var message string
+	var apiErr *googleapi.Error
+	var bqErr *bigquery.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden:
+		message = apiErr.Message
+	case errors.As(err, &bqErr) && bqErr.Reason == "accessDenied":
+		message = bqErr.Message
+	default:
+		return false
+	}
+	if strings.Contains(message, "bigquery.jobs.create") {
+		return false
+	}
+	for _, permission := range destinationPermissions {
+		if strings.Contains(message, permission) {
+			return true
+		}
+	}
+	// BigQuery names resources as project:dataset.table in errors
+	projectID, datasetID, tableID, _ := parseTable(table)
+	return strings.Contains(message, "Dataset "+projectID+":"+datasetID+":") ||
+		strings.Contains(message, "Table "+projectID+":"+datasetID+"."+tableID+":")
+}
+
+// destinationError classifies permission errors on destination table, other errors are returned as is
+func destinationError(err error, table string) error {
+	if table == "" || !destinationDenied(err, table) {
+		return err
+	}
+	projectID, datasetID, _, _ := parseTable(table)
+	return fmt.Errorf(
+		"%s: cannot write result to %s, grant roles/bigquery.dataEditor on dataset %s.%s to service account of dekart: %w",
+		DestinationAccessDenied, table, projectID, datasetID, err,
+	)
+}
@@ -0,0 +1,259 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+func TestRunUsesBillingProject(t *testing.T) {
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	var project string
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		project = projectID
+		return nil, fmt.Errorf("no client in test")
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	for _, c := range []struct {
+		name, env, override, expected string
+	}{
+		{"data project", "", "", "data-project"},
+		{"billing project", "instance-billing", "", "instance-billing"},
+		{"report override", "instance-billing", "team-billing", "team-billing"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			os.Setenv("DEKART_BIGQUERY_BILLING_PROJECT_ID", c.env)
+			defer os.Unsetenv("DEKART_BIGQUERY_BILLING_PROJECT_ID")
+			job := NewStore().New(context.Background(), "report", "query")
+			if err := job.Run("select 1", RunOptions{BillingProject: c.override}, nil); err == nil {
+				t.Fatal("expected client error")
+			}
+			if project != c.expected {
+				t.Errorf("expected client for %s, got %s", c.expected, project)
+			}
+		})
+	}
+}
+
+// fakeJobsEndpoint of BigQuery keeps configuration of inserted jobs, other jobs requests get running job
+func fakeJobsEndpoint(t *testing.T) (*httptest.Server, *[]map[string]interface{}) {
+	var inserted []map[string]interface{}
+	var mutex sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		job := map[string]interface{}{}
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/jobs") {
+			if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+				t.Error(err)
+			}
+			mutex.Lock()
+			inserted = append(inserted, job)
+			mutex.Unlock()
+		}
+		job["jobReference"] = map[string]string{"projectId": "billing", "jobId": "job", "location": "US"}
+		job["status"] = map[string]string{"state": "RUNNING"}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"job": job, "jobReference": job["jobReference"], "status": job["status"]})
+	}))
+	t.Cleanup(server.Close)
+	return server, &inserted
+}
+
+func TestRunExecutedQuery(t *testing.T) {
+	server, inserted := fakeJobsEndpoint(t)
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	for _, c := range []struct {
+		name           string
+		options        RunOptions
+		expected       string
+		defaultDataset string
+	}{
+		{"as written", RunOptions{}, "select * from points", ""},
+		{"default dataset", RunOptions{DefaultDataset: "team"}, "select * from points", "data-project.team"},
+		{"sampled", RunOptions{SampleRate: 0.5}, "SELECT * FROM points TABLESAMPLE SYSTEM (50 PERCENT)", ""},
+		{"exploration limit", RunOptions{ExplorationLimit: 10}, "SELECT * FROM (\nselect * from points\n) LIMIT 10", ""},
+		{
+			"sampled and limited", RunOptions{SampleRate: 0.5, ExplorationLimit: 10},
+			"SELECT * FROM (\nSELECT * FROM points TABLESAMPLE SYSTEM (50 PERCENT)\n) LIMIT 10", "",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			*inserted = nil
+			job := NewStore().New(context.Background(), "report", "query")
+			go drainStatus(job)
+			if err := job.Run("select * from points", c.options, nil); err != nil {
+				t.Fatal(err)
+			}
+			defer job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+			snapshot := job.GetStatus()
+			if snapshot.QueryText != c.expected || snapshot.QueryHash != QueryHash(c.expected) || snapshot.DefaultDataset != c.defaultDataset {
+				t.Errorf("expected %q in %q, got %q in %q", c.expected, c.defaultDataset, snapshot.QueryText, snapshot.DefaultDataset)
+			}
+			if len(*inserted) != 1 {
+				t.Fatalf("expected one job inserted, got %d", len(*inserted))
+			}
+			query := (*inserted)[0]["configuration"].(map[string]interface{})["query"].(map[string]interface{})
+			if query["query"] != c.expected {
+				t.Errorf("expected executed query %q, got %q", c.expected, query["query"])
+			}
+			labels := (*inserted)[0]["configuration"].(map[string]interface{})["labels"].(map[string]interface{})
+			if labels[queryHashLabel] != QueryHash(c.expected) {
+				t.Errorf("expected hash label %s, got %v", QueryHash(c.expected), labels)
+			}
+		})
+	}
+}
+
+func TestBillingError(t *testing.T) {
+	denied := &googleapi.Error{
+		Code:    http.StatusForbidden,
+		Message: "Access Denied: Project team-billing: User does not have bigquery.jobs.create permission in project team-billing.",
+	}
+	err := billingError(denied, "team-billing")
+	if !strings.Contains(err.Error(), "billing project team-billing") {
+		t.Errorf("expected hint naming project, got %q", err)
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		t.Error("expected original error to be wrapped")
+	}
+	other := &googleapi.Error{Code: http.StatusForbidden, Message: "Access Denied: Table data:shared.table"}
+	if err := billingError(other, "team-billing"); err != other {
+		t.Errorf("expected error unchanged, got %q", err)
+	}
+}
+
+func TestDefaultDataset(t *testing.T) {
+	store := NewStore(WithProjects(Projects{Data: "data-project", DefaultDataset: "shared"}))
+	for override, expected := range map[string]string{
+		"":                   "data-project.shared",
+		"team":               "data-project.team",
+		"other-project.team": "other-project.team",
+	} {
+		if dataset := store.DefaultDataset(override); dataset != expected {
+			t.Errorf("%q: expected %s, got %s", override, expected, dataset)
+		}
+	}
+	for _, invalid := range []string{"a.b.c", ".team", "project."} {
+		if err := ValidateDataset(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestApplyDefaultDataset(t *testing.T) {
+	queryText := "select * from other-project.other_dataset.table join points using (id)"
+	config := bigquery.QueryConfig{Q: queryText}
+	if err := applyDefaultDataset(&config, "data-project.shared"); err != nil {
+		t.Fatal(err)
+	}
+	if config.DefaultProjectID != "data-project" || config.DefaultDatasetID != "shared" {
+		t.Errorf("unexpected defaults %s.%s", config.DefaultProjectID, config.DefaultDatasetID)
+	}
+	// qualified names are passed to BigQuery as is, defaults apply only to unqualified
+	if config.Q != queryText {
+		t.Errorf("query text changed: %s", config.Q)
+	}
+	empty := bigquery.QueryConfig{}
+	if err := applyDefaultDataset(&empty, ""); err != nil || empty.DefaultProjectID != "" || empty.DefaultDatasetID != "" {
+		t.Errorf("expected no defaults, got %+v %v", empty, err)
+	}
+}
+
+func TestDestinationTable(t *testing.T) {
+	options := RunOptions{}.WithDestination(&proto.DestinationTable{
+		Table:            "results.points",
+		WriteDisposition: proto.DestinationTable_WRITE_DISPOSITION_TRUNCATE,
+	})
+	if options.DestinationTable != "results.points" || options.WriteDisposition != bigquery.WriteTruncate {
+		t.Errorf("unexpected options %+v", options)
+	}
+	// dataset.table is in data project of store, environment is not read
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "env-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	job := NewStore(WithProjects(Projects{Data: "data-project"})).New(context.Background(), "report", "query")
+	options = job.applyDefaults(options)
+	if options.DestinationTable != "data-project.results.points" {
+		t.Errorf("expected table in data project, got %s", options.DestinationTable)
+	}
+	if qualified := job.applyDefaults(RunOptions{DestinationTable: "other.results.points"}); qualified.DestinationTable != "other.results.points" {
+		t.Errorf("expected qualified table as is, got %s", qualified.DestinationTable)
+	}
+	if options := (RunOptions{}).WithDestination(&proto.DestinationTable{Table: "other.results.points"}); options.WriteDisposition != bigquery.WriteEmpty {
+		t.Errorf("expected table with rows to fail job by default, got %s", options.WriteDisposition)
+	}
+	for _, invalid := range []*proto.DestinationTable{
+		{Table: ""},
+		{Table: "points"},
+		{Table: "a.b.c.d"},
+		{Table: "project..points"},
+		{Table: "results.points", WriteDisposition: 10},
+	} {
+		if err := ValidateDestinationTable(invalid); err == nil {
+			t.Errorf("expected error for %+v", invalid)
+		}
+	}
+
+	client, err := bigquery.NewClient(context.Background(), "billing", option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	config := bigquery.QueryConfig{}
+	if err := applyDestination(&config, client, options); err != nil {
+		t.Fatal(err)
+	}
+	if config.Dst == nil || config.Dst.FullyQualifiedName() != "data-project:results.points" || config.WriteDisposition != bigquery.WriteTruncate {
+		t.Errorf("unexpected destination %+v", config)
+	}
+	empty := bigquery.QueryConfig{}
+	if err := applyDestination(&empty, client, RunOptions{}); err != nil || empty.Dst != nil {
+		t.Errorf("expected no destination, got %+v %v", empty.Dst, err)
+	}
+}
+
+func TestDestinationError(t *testing.T) {
+	table := "data-project.results.points"
+	for _, c := range []struct {
+		name       string
+		err        error
+		classified bool
+	}{
+		{"create denied", &bigquery.Error{Reason: "accessDenied", Message: "Access Denied: Dataset data-project:results: Permission bigquery.tables.create denied on dataset data-project:results (or it may not exist)."}, true},
+		{"update denied", &googleapi.Error{Code: http.StatusForbidden, Message: "Access Denied: Table data-project:results.points: Permission bigquery.tables.updateData denied on table data-project:results.points"}, true},
+		{"source denied", &bigquery.Error{Reason: "accessDenied", Message: "Access Denied: Table data-project:results.source: User does not have permission to query table data-project:results.source."}, false},
+		{"billing denied", billingError(&googleapi.Error{Code: http.StatusForbidden, Message: "User does not have bigquery.jobs.create permission in project billing."}, "billing"), false},
+		{"not denied", &bigquery.Error{Reason: "invalidQuery", Message: "Syntax error"}, false},
+	} {
+		err := destinationError(c.err, table)
+		if classified := strings.HasPrefix(err.Error(), DestinationAccessDenied); classified != c.classified {
+			t.Errorf("%s: expected classified %t, got %q", c.name, c.classified, err)
+		}
+		if c.classified && (!errors.Is(err, c.err) || !strings.Contains(err.Error(), "roles/bigquery.dataEditor on dataset data-project.results")) {
+			t.Errorf("%s: expected hint wrapping original error, got %q", c.name, err)
+		}
+	}
+	denied := &bigquery.Error{Reason: "accessDenied", Message: "Permission bigquery.tables.create denied"}
+	if err := destinationError(denied, ""); err != denied {
+		t.Errorf("expected error unchanged without destination, got %q", err)
+	}
+}
@@ -0,0 +1,36 @@
+package job
+
+import "strings"
+
+// OrderedQuery when query text is single statement with ORDER BY at top level; ORDER BY of subqueries,
+// aggregate and window functions doesn't order result. Query wrapped by sampling or exploration LIMIT is not ordered,
+// BigQuery doesn't keep order of subquery
+func OrderedQuery(queryText string) bool {
+	_, code, err := trimStatement(queryText)
+	if err != nil || strings.Contains(code, ";") {
+		return false
+	}
+	tokens := tokenize(code)
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case !t.quoted && t.text == "(":
+			depth++
+		case !t.quoted && t.text == ")":
+			depth--
+		case depth == 0 && t.word("ORDER") && i+1 < len(tokens) && tokens[i+1].word("BY"):
+			return true
+		}
+	}
+	return false
+}
+
+// orderedRun of query as written, run as query text; ordering is lost when query is wrapped, warning tells
+// user that rows are in other order than ORDER BY of query
+func (job *Job) orderedRun(sourceQueryText string, queryText string) bool {
+	ordered := OrderedQuery(queryText)
+	if !ordered && OrderedQuery(sourceQueryText) {
+		job.AddWarning(WarningOrderNotPreserved, "Rows are not in ORDER BY of query, sampled or limited query is run as subquery which BigQuery doesn't keep ordered")
+	}
+	return ordered
+}
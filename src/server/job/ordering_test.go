@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOrderedQuery(t *testing.T) {
+	for query, expected := range map[string]bool{
+		"select * from points order by ts":                                        true,
+		"SELECT * FROM points ORDER BY ts DESC LIMIT 10;":                         true,
+		"with p as (select * from points order by ts) select * from p":            false,
+		"with p as (select * from points) select * from p order by ts":            true,
+		"select * from (select * from points order by ts)":                        false,
+		"select id, row_number() over (order by ts) from points":                  false,
+		"select array_agg(id order by ts) from points":                            false,
+		"(select 1 as a) union all (select 2) order by a":                         true,
+		"select * from points -- order by ts":                                     false,
+		"select 'order by' as s from points":                                      false,
+		"select `order` from points":                                              false,
+		"select * from points order by ts; select 1":                              false,
+		"SELECT * FROM (\nselect * from points order by ts\n) LIMIT 100":          false,
+		"SELECT * FROM (\nselect * from points order by ts\n) WHERE RAND() < 0.1": false,
+	} {
+		if got := OrderedQuery(query); got != expected {
+			t.Errorf("expected %v of %q, got %v", expected, query, got)
+		}
+	}
+}
+
+func TestLimitOrderedQuery(t *testing.T) {
+	// LIMIT follows ORDER BY, wrapped query would lose order
+	got, limited := LimitQuery("select * from points order by ts -- by time\n;", 100)
+	if !limited || got != "select * from points order by ts\nLIMIT 100" || !OrderedQuery(got) {
+		t.Errorf("expected appended LIMIT, got %q", got)
+	}
+	got, limited = limitSQLQuery("select * from points order by ts", 100)
+	if !limited || got != "select * from points order by ts\nLIMIT 100" {
+		t.Errorf("expected appended LIMIT of SQL connection, got %q", got)
+	}
+	// larger LIMIT of query is wrapped, rows are not kept ordered
+	got, limited = LimitQuery("select * from points order by ts limit 1000", 100)
+	if !limited || OrderedQuery(got) {
+		t.Errorf("expected wrapped query, got %q", got)
+	}
+}
+
+func TestOrderedRun(t *testing.T) {
+	job := NewStore().New(context.Background(), "report", "query")
+	if !job.orderedRun("select * from points order by ts", "select * from points order by ts\nLIMIT 100") {
+		t.Error("expected ordered run")
+	}
+	if len(job.GetStatus().Warnings) != 0 {
+		t.Errorf("unexpected warnings %v", job.GetStatus().Warnings)
+	}
+	sampled, err := SampleQuery("select * from points order by ts", 0.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.orderedRun("select * from points order by ts", sampled) {
+		t.Error("expected sampled run not ordered")
+	}
+	if warnings := job.GetStatus().Warnings; len(warnings) != 1 || warnings[0].Code != WarningOrderNotPreserved {
+		t.Errorf("expected order warning, got %v", warnings)
+	}
+	other := NewStore().New(context.Background(), "report", "query")
+	if other.orderedRun("select * from points", "SELECT * FROM (\nselect * from points\n) LIMIT 100") || len(other.GetStatus().Warnings) != 0 {
+		t.Errorf("expected unordered run without warning, got %v", other.GetStatus().Warnings)
+	}
+}
+
+func TestOrderedResultNotExtracted(t *testing.T) {
+	store := newFakeComponents()
+	job := extractingJob(store, nil, nil)
+	if !job.canExtract() {
+		t.Fatal("expected unordered result extracted")
+	}
+	// ordered rows are written by dekart from single stream of query results
+	job.ordered = true
+	if job.canExtract() {
+		t.Error("expected ordered result written from rows")
+	}
+	job.totalRows = 3
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	job.writeResult(newFakeIterator(3), fakeSchema, w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	if meta := job.GetStatus().ResultMeta; meta == nil || !meta.Ordered {
+		t.Errorf("expected ordered meta, got %+v", meta)
+	}
+}
@@ -0,0 +1,175 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+)
+
+// FormatResult of job in additional format, written from same rows as result in format of run
+type FormatResult struct {
+	Format   proto.ResultFormat `json:"format"`
+	ResultID string             `json:"resultId"`
+}
+
+// formatOutput of additional format; failed output stops receiving rows, result of run is stored without it
+type formatOutput struct {
+	format    proto.ResultFormat
+	resultID  string
+	newWriter func(ctx context.Context) resultWriter
+	remove    func()
+	// set while rows are written
+	w       resultWriter
+	abort   context.CancelFunc
+	encoder resultEncoder
+	closed  bool
+	err     error
+}
+
+// errOutputDiscarded when result in format of run is not stored
+var errOutputDiscarded = errors.New("result was not stored")
+
+// ValidateAdditionalFormats of run with result in format; each format is stored once
+func ValidateAdditionalFormats(format proto.ResultFormat, additional []proto.ResultFormat) error {
+	if format == proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+		format = proto.ResultFormat_RESULT_FORMAT_CSV
+	}
+	seen := map[proto.ResultFormat]bool{format: true}
+	for _, f := range additional {
+		if err := ValidateResultFormat(f); err != nil {
+			return err
+		}
+		if f == proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+			return fmt.Errorf("additional format is unspecified")
+		}
+		if seen[f] {
+			return fmt.Errorf("result is already written as %s", f)
+		}
+		seen[f] = true
+	}
+	return nil
+}
+
+// WriteFormat of result into obj, stored as resultID in addition to result in format of run; call before Run
+func (job *Job) WriteFormat(format proto.ResultFormat, obj *storage.ObjectHandle, resultID string) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	job.outputs = append(job.outputs, &formatOutput{
+		format:   format,
+		resultID: resultID,
+		newWriter: func(ctx context.Context) resultWriter {
+			w := newCreateOnlyWriter(ctx, obj, nil)
+			if format == proto.ResultFormat_RESULT_FORMAT_PARQUET {
+				w.w.ContentType = ParquetContentType
+			}
+			return instrumentWriter(w, metrics.GCS, "format_upload")
+		},
+		remove: func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := obj.Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+				job.logger.Warn().Err(err).Str("resultID", resultID).Msg("Cannot delete partial format result")
+			}
+		},
+	})
+}
+
+// startOutputs of additional formats; uploads are aborted when ctx is done
+func (job *Job) startOutputs(ctx context.Context, schema func() bigquery.Schema) {
+	for _, o := range job.outputs {
+		outputCtx, abort := context.WithCancel(ctx)
+		o.w, o.abort = o.newWriter(outputCtx), abort
+		o.encoder = job.newFormatEncoder(o.format, o.w, schema)
+	}
+}
+
+// writeOutputs of row in additional formats
+func (job *Job) writeOutputs(row []bigquery.Value) {
+	for _, o := range job.outputs {
+		if o.encoder == nil || o.err != nil {
+			continue
+		}
+		if err := o.encoder.write(row); err != nil {
+			job.failOutput(o, err)
+		}
+	}
+}
+
+// failOutput aborts upload of additional format and removes partial object, failure is warning of job
+func (job *Job) failOutput(o *formatOutput, err error) {
+	o.err = err
+	o.abort()
+	o.w.Close()
+	o.remove()
+	job.logger.Warn().Err(err).Str("format", o.format.String()).Msg("Format result failed")
+	job.AddWarning(WarningFormatFailed, fmt.Sprintf("%s result was not stored: %s", o.format, err))
+}
+
+// closeOutputs after result in format of run is stored, stored outputs are format results of job
+func (job *Job) closeOutputs() {
+	var results []FormatResult
+	for _, o := range job.outputs {
+		if o.encoder == nil || o.err != nil {
+			continue
+		}
+		// encoder flushes buffered rows, so writer is closed after it
+		if err := o.encoder.close(); err != nil {
+			job.failOutput(o, err)
+			continue
+		}
+		if err := o.w.Close(); err != nil {
+			o.closed = true
+			job.failOutput(o, err)
+			continue
+		}
+		o.closed = true
+		results = append(results, FormatResult{Format: o.format, ResultID: o.resultID})
+	}
+	job.mutex.Lock()
+	job.formatResults = results
+	job.mutex.Unlock()
+}
+
+// discardOutputs when result in format of run is not stored; stored outputs are removed too
+func (job *Job) discardOutputs() {
+	for _, o := range job.outputs {
+		if o.encoder == nil || o.err != nil {
+			continue
+		}
+		o.err = errOutputDiscarded
+		if !o.closed {
+			o.abort()
+			o.w.Close()
+		}
+		o.remove()
+	}
+	job.mutex.Lock()
+	job.formatResults = nil
+	job.mutex.Unlock()
+}
+
+// MarshalFormatResults stored in job record, empty when job has no format results
+func MarshalFormatResults(results []FormatResult) (string, error) {
+	if len(results) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(results)
+	return string(b), err
+}
+
+// UnmarshalFormatResults of job record
+func UnmarshalFormatResults(value string) ([]FormatResult, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var results []FormatResult
+	err := json.Unmarshal([]byte(value), &results)
+	return results, err
+}
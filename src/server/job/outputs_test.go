@@ -0,0 +1,139 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/parquet"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// closeOrder records order in which writers of job are closed
+type closeOrder struct {
+	closed []string
+}
+
+// orderedWriter of result closed in closeOrder
+type orderedWriter struct {
+	fakeResultWriter
+	name  string
+	order *closeOrder
+	err   error
+}
+
+func (w *orderedWriter) Close() error {
+	w.closed = true
+	w.order.closed = append(w.order.closed, w.name)
+	return w.err
+}
+
+// fakeOutput of additional format writing to w, removed is set when partial object is deleted
+type fakeOutput struct {
+	w       resultWriter
+	ctx     context.Context
+	removed bool
+}
+
+func (f *fakeOutput) add(job *Job, format proto.ResultFormat, resultID string) {
+	job.outputs = append(job.outputs, &formatOutput{
+		format:   format,
+		resultID: resultID,
+		newWriter: func(ctx context.Context) resultWriter {
+			f.ctx = ctx
+			return f.w
+		},
+		remove: func() { f.removed = true },
+	})
+}
+
+// writeFormats of 3 rows with primary writer and outputs started, returns statuses of job
+func writeFormats(job *Job, primary resultWriter) []int32 {
+	job.totalRows = 3
+	statuses := collectStatus(job)
+	ctx, abort := context.WithCancel(context.Background())
+	defer abort()
+	job.startOutputs(ctx, fakeSchema)
+	job.writeResult(newFakeIterator(3), fakeSchema, primary, func() { job.discardOutputs() })
+	return <-statuses
+}
+
+func TestFormatResults(t *testing.T) {
+	job := readingJob(NewStore())
+	order := &closeOrder{}
+	parquetOutput := &fakeOutput{w: &orderedWriter{name: "parquet", order: order}}
+	parquetOutput.add(job, proto.ResultFormat_RESULT_FORMAT_PARQUET, "parquet-result")
+	s := writeFormats(job, &orderedWriter{name: "csv", order: order})
+	if len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v %s", s, job.Err())
+	}
+	// result in format of run is stored before additional formats are closed
+	if strings.Join(order.closed, ",") != "csv,parquet" {
+		t.Errorf("unexpected close order %v", order.closed)
+	}
+	w := parquetOutput.w.(*orderedWriter)
+	if _, err := parquet.ReadMetadata(bytes.NewReader(w.Bytes()), int64(w.Len())); err != nil {
+		t.Errorf("expected parquet result, got %v", err)
+	}
+	results := job.GetStatus().FormatResults
+	if len(results) != 1 || results[0].Format != proto.ResultFormat_RESULT_FORMAT_PARQUET || results[0].ResultID != "parquet-result" {
+		t.Errorf("unexpected format results %v", results)
+	}
+	if parquetOutput.removed || len(job.GetStatus().Warnings) != 0 {
+		t.Errorf("expected stored format result, got warnings %v", job.GetStatus().Warnings)
+	}
+}
+
+func TestFormatResultFailureIsWarning(t *testing.T) {
+	job := readingJob(NewStore())
+	job.resultFormat = proto.ResultFormat_RESULT_FORMAT_PARQUET
+	order := &closeOrder{}
+	failed := &fakeOutput{w: &orderedWriter{name: "csv", order: order, err: errors.New("upload failed")}}
+	failed.add(job, proto.ResultFormat_RESULT_FORMAT_CSV, "csv-result")
+	s := writeFormats(job, &orderedWriter{name: "parquet", order: order})
+	if len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v %s", s, job.Err())
+	}
+	if !failed.removed {
+		t.Error("expected partial format result removed")
+	}
+	snapshot := job.GetStatus()
+	if len(snapshot.FormatResults) != 0 {
+		t.Errorf("expected no format results, got %v", snapshot.FormatResults)
+	}
+	if len(snapshot.Warnings) != 1 || snapshot.Warnings[0].Code != WarningFormatFailed || !strings.Contains(snapshot.Warnings[0].Message, "upload failed") {
+		t.Errorf("expected warning of failed format, got %v", snapshot.Warnings)
+	}
+}
+
+func TestFormatResultsDiscardedWithResult(t *testing.T) {
+	job := readingJob(NewStore())
+	order := &closeOrder{}
+	output := &fakeOutput{w: &orderedWriter{name: "parquet", order: order}}
+	output.add(job, proto.ResultFormat_RESULT_FORMAT_PARQUET, "parquet-result")
+	s := writeFormats(job, &orderedWriter{name: "csv", order: order, err: errors.New("permission denied")})
+	if len(s) == 0 || s[len(s)-1] != 0 || !strings.Contains(job.Err(), "permission denied") {
+		t.Fatalf("expected failed job, got %v %s", s, job.Err())
+	}
+	// upload of additional format is aborted, so partial object is not stored
+	if output.ctx.Err() == nil || !output.removed || job.GetStatus().FormatResults != nil {
+		t.Errorf("expected discarded format result, aborted %v removed %v", output.ctx.Err(), output.removed)
+	}
+}
+
+func TestValidateAdditionalFormats(t *testing.T) {
+	if err := ValidateAdditionalFormats(proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED, []proto.ResultFormat{proto.ResultFormat_RESULT_FORMAT_PARQUET}); err != nil {
+		t.Error(err)
+	}
+	for name, formats := range map[string][]proto.ResultFormat{
+		"format of run": {proto.ResultFormat_RESULT_FORMAT_CSV},
+		"duplicate":     {proto.ResultFormat_RESULT_FORMAT_PARQUET, proto.ResultFormat_RESULT_FORMAT_PARQUET},
+		"unspecified":   {proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED},
+		"unknown":       {proto.ResultFormat(10)},
+	} {
+		if err := ValidateAdditionalFormats(proto.ResultFormat_RESULT_FORMAT_CSV, formats); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+)
+
+// maxParameters of report
+const maxParameters = 100
+
+// MaxAllowedValues of parameter, values query result is read up to this many distinct values
+const MaxAllowedValues = 1000
+
+// ValidateParameters of report: names are unique, types are set, allowed values and default values are valid for type.
+// Values query is checked by caller, it must be query of report
+func ValidateParameters(parameters []*proto.ReportParameter) error {
+	if len(parameters) > maxParameters {
+		return fmt.Errorf("too many parameters, at most %d allowed", maxParameters)
+	}
+	names := make(map[string]bool, len(parameters))
+	for _, parameter := range parameters {
+		if !variableNameRe.MatchString(parameter.Name) {
+			return fmt.Errorf("invalid parameter name %q, expected letters, digits and _", parameter.Name)
+		}
+		if names[parameter.Name] {
+			return fmt.Errorf("duplicate parameter %s", parameter.Name)
+		}
+		names[parameter.Name] = true
+		if _, ok := proto.ReportParameter_Type_name[int32(parameter.Type)]; !ok || parameter.Type == proto.ReportParameter_TYPE_UNSPECIFIED {
+			return fmt.Errorf("parameter %s has no type", parameter.Name)
+		}
+		if len(parameter.AllowedValues) > 0 && parameter.ValuesQueryId != "" {
+			return fmt.Errorf("parameter %s can't have both allowed values and values query", parameter.Name)
+		}
+		if len(parameter.AllowedValues) > MaxAllowedValues {
+			return fmt.Errorf("too many allowed values of parameter %s, at most %d allowed", parameter.Name, MaxAllowedValues)
+		}
+		allowed := make(map[string]bool, len(parameter.AllowedValues))
+		for _, value := range parameter.AllowedValues {
+			if allowed[value] {
+				return fmt.Errorf("duplicate allowed value %q of parameter %s", value, parameter.Name)
+			}
+			allowed[value] = true
+			if _, err := ParameterValue(parameter, value); err != nil {
+				return err
+			}
+		}
+		if parameter.DefaultValue == "" {
+			continue
+		}
+		// default value of values query is checked when query result is known
+		if err := ValidateParameterValue(parameter, parameter.DefaultValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParameterValue of report parameter as BigQuery value of its type
+func ParameterValue(parameter *proto.ReportParameter, value string) (interface{}, error) {
+	var parsed interface{}
+	var err error
+	switch parameter.Type {
+	case proto.ReportParameter_TYPE_STRING:
+		return value, nil
+	case proto.ReportParameter_TYPE_INT64:
+		parsed, err = strconv.ParseInt(value, 10, 64)
+	case proto.ReportParameter_TYPE_FLOAT64:
+		parsed, err = strconv.ParseFloat(value, 64)
+	case proto.ReportParameter_TYPE_BOOL:
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("invalid value of parameter %s: %q is not true or false", parameter.Name, value)
+		}
+		return value == "true", nil
+	case proto.ReportParameter_TYPE_DATE:
+		parsed, err = civil.ParseDate(value)
+	case proto.ReportParameter_TYPE_TIMESTAMP:
+		parsed, err = time.Parse(time.RFC3339Nano, value)
+	default:
+		return nil, fmt.Errorf("parameter %s has no type", parameter.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of parameter %s: %q is not %s", parameter.Name, value, parameterTypeName(parameter.Type))
+	}
+	return parsed, nil
+}
+
+// parameterTypeName of type in errors
+func parameterTypeName(t proto.ReportParameter_Type) string {
+	switch t {
+	case proto.ReportParameter_TYPE_INT64:
+		return "integer"
+	case proto.ReportParameter_TYPE_FLOAT64:
+		return "number"
+	case proto.ReportParameter_TYPE_DATE:
+		return "date YYYY-MM-DD"
+	case proto.ReportParameter_TYPE_TIMESTAMP:
+		return "RFC 3339 timestamp"
+	}
+	return t.String()
+}
+
+// ValidateParameterValue of type of parameter; value must be one of allowed values when they are set
+func ValidateParameterValue(parameter *proto.ReportParameter, value string) error {
+	if _, err := ParameterValue(parameter, value); err != nil {
+		return err
+	}
+	if len(parameter.AllowedValues) == 0 {
+		return nil
+	}
+	for _, allowed := range parameter.AllowedValues {
+		if value == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("value %q of parameter %s is not one of allowed values", value, parameter.Name)
+}
+
+// ValidateParameterBindings of query, keys are named parameters of query text without @, values are report parameters
+func ValidateParameterBindings(bindings map[string]string) error {
+	if len(bindings) > maxParameters {
+		return fmt.Errorf("too many parameter bindings, at most %d allowed", maxParameters)
+	}
+	for name, parameter := range bindings {
+		if !variableNameRe.MatchString(name) {
+			return fmt.Errorf("invalid named parameter %q, expected letters, digits and _", name)
+		}
+		if !variableNameRe.MatchString(parameter) {
+			return fmt.Errorf("invalid report parameter %q bound to @%s", parameter, name)
+		}
+	}
+	return nil
+}
+
+// BindParameters of report to named parameters of query text; values override default values.
+// Bound named parameters which query text doesn't use are not passed to BigQuery.
+// Allowed values of values queries must be set on parameters
+func BindParameters(queryText string, bindings map[string]string, parameters []*proto.ReportParameter, values map[string]string) ([]bigquery.QueryParameter, error) {
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+	code, err := sqlCode(queryText)
+	if err != nil {
+		return nil, fmt.Errorf("cannot bind parameters of query: %w", err)
+	}
+	used := map[string]bool{}
+	for _, m := range namedParameterRe.FindAllStringSubmatch(code, -1) {
+		used[m[2]] = true
+	}
+	byName := make(map[string]*proto.ReportParameter, len(parameters))
+	for _, parameter := range parameters {
+		byName[parameter.Name] = parameter
+	}
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		if used[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	bound := make([]bigquery.QueryParameter, 0, len(names))
+	for _, name := range names {
+		parameter := byName[bindings[name]]
+		if parameter == nil {
+			return nil, fmt.Errorf("@%s is bound to unknown report parameter %s", name, bindings[name])
+		}
+		value, ok := values[parameter.Name]
+		if !ok {
+			value = parameter.DefaultValue
+		}
+		if value == "" && parameter.Type != proto.ReportParameter_TYPE_STRING {
+			return nil, fmt.Errorf("parameter %s has no value", parameter.Name)
+		}
+		if err := ValidateParameterValue(parameter, value); err != nil {
+			return nil, err
+		}
+		parsed, _ := ParameterValue(parameter, value)
+		bound = append(bound, bigquery.QueryParameter{Name: name, Value: parsed})
+	}
+	return bound, nil
+}
@@ -0,0 +1,95 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestValidateParameters(t *testing.T) {
+	valid := []*proto.ReportParameter{
+		{Name: "region", Type: proto.ReportParameter_TYPE_STRING, AllowedValues: []string{"EU", "US"}, DefaultValue: "EU"},
+		{Name: "day", Type: proto.ReportParameter_TYPE_DATE, DefaultValue: "2021-01-01"},
+		{Name: "city", Type: proto.ReportParameter_TYPE_STRING, ValuesQueryId: "query"},
+	}
+	if err := ValidateParameters(valid); err != nil {
+		t.Errorf("expected valid parameters, got %v", err)
+	}
+	for name, parameters := range map[string][]*proto.ReportParameter{
+		"name":              {{Name: "1st", Type: proto.ReportParameter_TYPE_STRING}},
+		"duplicate":         {{Name: "a", Type: proto.ReportParameter_TYPE_STRING}, {Name: "a", Type: proto.ReportParameter_TYPE_INT64}},
+		"no type":           {{Name: "a"}},
+		"allowed and query": {{Name: "a", Type: proto.ReportParameter_TYPE_STRING, AllowedValues: []string{"x"}, ValuesQueryId: "query"}},
+		"allowed of type":   {{Name: "a", Type: proto.ReportParameter_TYPE_INT64, AllowedValues: []string{"1", "x"}}},
+		"default allowed":   {{Name: "a", Type: proto.ReportParameter_TYPE_STRING, AllowedValues: []string{"x"}, DefaultValue: "y"}},
+		"default of type":   {{Name: "a", Type: proto.ReportParameter_TYPE_TIMESTAMP, DefaultValue: "2021-01-01"}},
+	} {
+		if err := ValidateParameters(parameters); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParameterValue(t *testing.T) {
+	for _, c := range []struct {
+		t        proto.ReportParameter_Type
+		value    string
+		expected interface{}
+	}{
+		{proto.ReportParameter_TYPE_STRING, "it's", "it's"},
+		{proto.ReportParameter_TYPE_INT64, "-42", int64(-42)},
+		{proto.ReportParameter_TYPE_FLOAT64, "1.5", 1.5},
+		{proto.ReportParameter_TYPE_BOOL, "true", true},
+		{proto.ReportParameter_TYPE_DATE, "2021-02-03", civil.Date{Year: 2021, Month: 2, Day: 3}},
+		{proto.ReportParameter_TYPE_TIMESTAMP, "2021-02-03T04:05:06Z", time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)},
+	} {
+		parsed, err := ParameterValue(&proto.ReportParameter{Name: "p", Type: c.t}, c.value)
+		if err != nil || parsed != c.expected {
+			t.Errorf("%v %q: expected %v, got %v %v", c.t, c.value, c.expected, parsed, err)
+		}
+	}
+	if _, err := ParameterValue(&proto.ReportParameter{Name: "p", Type: proto.ReportParameter_TYPE_BOOL}, "1"); err == nil {
+		t.Error("expected error of bool which is not true or false")
+	}
+}
+
+func TestBindParameters(t *testing.T) {
+	parameters := []*proto.ReportParameter{
+		{Name: "region", Type: proto.ReportParameter_TYPE_STRING, AllowedValues: []string{"EU", "US"}, DefaultValue: "EU"},
+		{Name: "limit", Type: proto.ReportParameter_TYPE_INT64},
+	}
+	queryText := "select * from points where region = @r -- @unused\nlimit @n"
+	bindings := map[string]string{"r": "region", "n": "limit", "unused": "region"}
+	bound, err := BindParameters(queryText, bindings, parameters, map[string]string{"limit": "10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// parameters in comments are not used by query
+	if len(bound) != 2 || bound[0].Name != "n" || bound[0].Value != int64(10) || bound[1].Name != "r" || bound[1].Value != "EU" {
+		t.Errorf("expected default region and limit of values, got %+v", bound)
+	}
+	for _, c := range []struct {
+		values   map[string]string
+		bindings map[string]string
+		expected string
+	}{
+		{map[string]string{"limit": "10", "region": "ASIA"}, bindings, "not one of allowed values"},
+		{map[string]string{}, bindings, "parameter limit has no value"},
+		{map[string]string{"limit": "ten"}, bindings, "is not integer"},
+		{map[string]string{"limit": "10"}, map[string]string{"r": "country", "n": "limit"}, "unknown report parameter country"},
+	} {
+		_, err := BindParameters(queryText, c.bindings, parameters, c.values)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("%v: expected error %q, got %v", c.values, c.expected, err)
+		}
+	}
+	if _, err := BindParameters("select @r /* unused", bindings, parameters, nil); err == nil || err.Error() != "cannot bind parameters of query: unterminated comment" {
+		t.Errorf("expected error of binding, got %v", err)
+	}
+	if bound, err := BindParameters("select 1", nil, parameters, nil); err != nil || bound != nil {
+		t.Errorf("expected no parameters of query without bindings, got %v %v", bound, err)
+	}
+}
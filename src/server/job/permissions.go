@@ -0,0 +1,233 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// Resource types of permission checks
+const (
+	ResourceProject = "project"
+	ResourceBucket  = "bucket"
+)
+
+// requiredPermission of service account and predefined role granting it
+type requiredPermission struct {
+	permission string
+	role       string
+}
+
+var billingPermissions = []requiredPermission{
+	{"bigquery.jobs.create", "roles/bigquery.jobUser"},
+}
+
+var dataPermissions = []requiredPermission{
+	{"bigquery.tables.get", "roles/bigquery.dataViewer"},
+	{"bigquery.tables.getData", "roles/bigquery.dataViewer"},
+}
+
+var bucketPermissions = []requiredPermission{
+	{"storage.objects.create", "roles/storage.objectAdmin"},
+	{"storage.objects.get", "roles/storage.objectAdmin"},
+	{"storage.objects.delete", "roles/storage.objectAdmin"},
+}
+
+// PermissionTester returns subset of permissions granted to caller on resource, like testIamPermissions
+type PermissionTester interface {
+	TestProjectPermissions(ctx context.Context, project string, permissions []string) ([]string, error)
+	TestBucketPermissions(ctx context.Context, bucket string, permissions []string) ([]string, error)
+}
+
+// GooglePermissionTester calls testIamPermissions of Cloud Resource Manager and Cloud Storage
+type GooglePermissionTester struct{}
+
+// TestProjectPermissions granted on project
+func (GooglePermissionTester) TestProjectPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	service, err := cloudresourcemanager.NewService(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := service.Projects.TestIamPermissions(project, &cloudresourcemanager.TestIamPermissionsRequest{
+		Permissions: permissions,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return res.Permissions, nil
+}
+
+// TestBucketPermissions granted on bucket
+func (GooglePermissionTester) TestBucketPermissions(ctx context.Context, bucket string, permissions []string) ([]string, error) {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.Bucket(bucket).IAM().TestPermissions(ctx, permissions)
+}
+
+// PermissionCheck of one permission on resource
+type PermissionCheck struct {
+	ResourceType string
+	Resource     string
+	Permission   string
+	Role         string
+	Granted      bool
+	// Error when permissions of resource cannot be tested, permission is neither granted nor missing
+	Error string
+}
+
+// Missing when permission was tested and is not granted
+func (c PermissionCheck) Missing() bool {
+	return !c.Granted && c.Error == ""
+}
+
+// grantHint with role to grant
+func (c PermissionCheck) grantHint() string {
+	return fmt.Sprintf("%s on %s %s, grant %s", c.Permission, c.ResourceType, c.Resource, c.Role)
+}
+
+// PermissionReport of service account
+type PermissionReport struct {
+	CheckedAt time.Time
+	Checks    []PermissionCheck
+}
+
+// Missing permissions of report
+func (r *PermissionReport) Missing() []PermissionCheck {
+	var missing []PermissionCheck
+	for _, c := range r.Checks {
+		if c.Missing() {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// testResource permissions with one call, error is recorded in each check of resource
+func testResource(
+	ctx context.Context,
+	resourceType, resource string,
+	required []requiredPermission,
+	test func(ctx context.Context, resource string, permissions []string) ([]string, error),
+) []PermissionCheck {
+	permissions := make([]string, len(required))
+	for i, r := range required {
+		permissions[i] = r.permission
+	}
+	granted, err := test(ctx, resource, permissions)
+	grantedSet := map[string]bool{}
+	for _, p := range granted {
+		grantedSet[p] = true
+	}
+	checks := make([]PermissionCheck, len(required))
+	for i, r := range required {
+		checks[i] = PermissionCheck{
+			ResourceType: resourceType,
+			Resource:     resource,
+			Permission:   r.permission,
+			Role:         r.role,
+			Granted:      err == nil && grantedSet[r.permission],
+		}
+		if err != nil {
+			checks[i].Error = err.Error()
+		}
+	}
+	return checks
+}
+
+// CheckPermissions of service account on billing project, data project and result bucket; empty resources are not checked
+func CheckPermissions(ctx context.Context, tester PermissionTester, billingProject, dataProject, bucket string) *PermissionReport {
+	report := &PermissionReport{CheckedAt: time.Now()}
+	// same project is tested with one call
+	projects := map[string][]requiredPermission{}
+	var order []string
+	for _, p := range []struct {
+		project  string
+		required []requiredPermission
+	}{{billingProject, billingPermissions}, {dataProject, dataPermissions}} {
+		if p.project == "" {
+			continue
+		}
+		if _, ok := projects[p.project]; !ok {
+			order = append(order, p.project)
+		}
+		projects[p.project] = append(projects[p.project], p.required...)
+	}
+	for _, project := range order {
+		report.Checks = append(report.Checks, testResource(ctx, ResourceProject, project, projects[project], tester.TestProjectPermissions)...)
+	}
+	if bucket != "" {
+		report.Checks = append(report.Checks, testResource(ctx, ResourceBucket, bucket, bucketPermissions, tester.TestBucketPermissions)...)
+	}
+	return report
+}
+
+// latestPermissions report of replica, checked at startup or by admin
+var latestPermissions struct {
+	sync.Mutex
+	report *PermissionReport
+}
+
+// UsePermissionReport for errors of denied jobs
+func UsePermissionReport(report *PermissionReport) {
+	latestPermissions.Lock()
+	defer latestPermissions.Unlock()
+	latestPermissions.report = report
+}
+
+// LatestPermissionReport of replica, nil when permissions were not checked
+func LatestPermissionReport() *PermissionReport {
+	latestPermissions.Lock()
+	defer latestPermissions.Unlock()
+	return latestPermissions.report
+}
+
+// permissionError of denied request names permissions missing in latest report, if any
+func permissionError(err error) error {
+	var message string
+	var apiErr *googleapi.Error
+	var bqErr *bigquery.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden:
+		message = apiErr.Message
+	case errors.As(err, &bqErr) && bqErr.Reason == "accessDenied":
+		message = bqErr.Message
+	default:
+		return err
+	}
+	report := LatestPermissionReport()
+	if report == nil {
+		return err
+	}
+	missing := report.Missing()
+	if len(missing) == 0 {
+		return err
+	}
+	// permission named by error is shown alone, other denials list every missing permission;
+	// longest match wins, as bigquery.tables.get is prefix of bigquery.tables.getData
+	var named *PermissionCheck
+	for i, c := range missing {
+		if strings.Contains(message, c.Permission) && (named == nil || len(c.Permission) > len(named.Permission)) {
+			named = &missing[i]
+		}
+	}
+	if named != nil {
+		missing = []PermissionCheck{*named}
+	}
+	hints := make([]string, len(missing))
+	for i, c := range missing {
+		hints[i] = c.grantHint()
+	}
+	return fmt.Errorf("%w; the service account is missing %s", err, strings.Join(hints, "; "))
+}
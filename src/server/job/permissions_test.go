@@ -0,0 +1,140 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+// fakePermissionTester grants permissions by resource, resource in errs fails test call
+type fakePermissionTester struct {
+	granted map[string][]string
+	errs    map[string]error
+	calls   []string
+}
+
+func (f *fakePermissionTester) test(resource string, permissions []string) ([]string, error) {
+	f.calls = append(f.calls, resource+":"+strings.Join(permissions, ","))
+	if err := f.errs[resource]; err != nil {
+		return nil, err
+	}
+	return f.granted[resource], nil
+}
+
+func (f *fakePermissionTester) TestProjectPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	return f.test(project, permissions)
+}
+
+func (f *fakePermissionTester) TestBucketPermissions(ctx context.Context, bucket string, permissions []string) ([]string, error) {
+	return f.test(bucket, permissions)
+}
+
+func missingPermissions(report *PermissionReport) []string {
+	var missing []string
+	for _, c := range report.Missing() {
+		missing = append(missing, c.Resource+" "+c.Permission)
+	}
+	return missing
+}
+
+func TestCheckPermissions(t *testing.T) {
+	tester := &fakePermissionTester{
+		granted: map[string][]string{
+			"billing": {"bigquery.jobs.create"},
+			"data":    {"bigquery.tables.get"},
+			"results": {"storage.objects.create", "storage.objects.get"},
+		},
+	}
+	report := CheckPermissions(context.Background(), tester, "billing", "data", "results")
+	expected := []string{"data bigquery.tables.getData", "results storage.objects.delete"}
+	if missing := missingPermissions(report); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing %v, got %v", expected, missing)
+	}
+	if len(report.Checks) != 6 || report.CheckedAt.IsZero() {
+		t.Errorf("unexpected report %+v", report)
+	}
+	for _, c := range report.Missing() {
+		if c.Permission == "storage.objects.delete" && (c.ResourceType != ResourceBucket || c.Role != "roles/storage.objectAdmin") {
+			t.Errorf("unexpected check %+v", c)
+		}
+	}
+}
+
+func TestCheckPermissionsSameProject(t *testing.T) {
+	tester := &fakePermissionTester{granted: map[string][]string{"data": {"bigquery.jobs.create", "bigquery.tables.get", "bigquery.tables.getData"}}}
+	report := CheckPermissions(context.Background(), tester, "data", "data", "")
+	expected := []string{"data:bigquery.jobs.create,bigquery.tables.get,bigquery.tables.getData"}
+	if !reflect.DeepEqual(tester.calls, expected) {
+		t.Errorf("expected one call for project, got %v", tester.calls)
+	}
+	if len(report.Checks) != 3 || len(report.Missing()) != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestCheckPermissionsError(t *testing.T) {
+	tester := &fakePermissionTester{errs: map[string]error{"billing": errors.New("cloudresourcemanager API disabled")}}
+	report := CheckPermissions(context.Background(), tester, "billing", "", "")
+	if len(report.Checks) != 1 || report.Checks[0].Granted || report.Checks[0].Error == "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+	// untested permission is not reported missing
+	if len(report.Missing()) != 0 {
+		t.Errorf("expected no missing, got %v", report.Missing())
+	}
+}
+
+func TestPermissionError(t *testing.T) {
+	defer UsePermissionReport(LatestPermissionReport())
+	denied := &googleapi.Error{Code: http.StatusForbidden, Message: "Access Denied: Table data:shared.points: User does not have permission bigquery.tables.getData"}
+	UsePermissionReport(nil)
+	if err := permissionError(denied); err != denied {
+		t.Errorf("expected error unchanged without report, got %q", err)
+	}
+	tester := &fakePermissionTester{granted: map[string][]string{"billing": {"bigquery.jobs.create"}}}
+	UsePermissionReport(CheckPermissions(context.Background(), tester, "billing", "data", "results"))
+
+	err := permissionError(denied)
+	if !strings.Contains(err.Error(), "the service account is missing bigquery.tables.getData on project data, grant roles/bigquery.dataViewer") {
+		t.Errorf("expected named permission, got %q", err)
+	}
+	if strings.Contains(err.Error(), "storage.objects") || strings.Contains(err.Error(), "bigquery.tables.get ") {
+		t.Errorf("expected only named permission, got %q", err)
+	}
+	if !errors.Is(err, denied) {
+		t.Error("expected original error to be wrapped")
+	}
+	// denial without permission name lists every missing permission
+	err = permissionError(fmt.Errorf("write result: %w", &googleapi.Error{Code: http.StatusForbidden, Message: "Forbidden"}))
+	if !strings.Contains(err.Error(), "bigquery.tables.get on project data") || !strings.Contains(err.Error(), "storage.objects.delete on bucket results") {
+		t.Errorf("expected all missing permissions, got %q", err)
+	}
+	err = permissionError(&bigquery.Error{Reason: "accessDenied", Message: "Access Denied: bigquery.tables.get"})
+	if !strings.Contains(err.Error(), "missing bigquery.tables.get on project data") {
+		t.Errorf("expected job error classified, got %q", err)
+	}
+	notFound := &googleapi.Error{Code: http.StatusNotFound}
+	if err := permissionError(notFound); err != notFound {
+		t.Errorf("expected other errors unchanged, got %q", err)
+	}
+}
+
+func TestBillingErrorWithPermissionReport(t *testing.T) {
+	defer UsePermissionReport(LatestPermissionReport())
+	UsePermissionReport(CheckPermissions(context.Background(), &fakePermissionTester{}, "team-billing", "", ""))
+	denied := &googleapi.Error{
+		Code:    http.StatusForbidden,
+		Message: "Access Denied: Project team-billing: User does not have bigquery.jobs.create permission in project team-billing.",
+	}
+	err := billingError(denied, "team-billing")
+	if !strings.Contains(err.Error(), "the service account is missing bigquery.jobs.create on project team-billing, grant roles/bigquery.jobUser") {
+		t.Errorf("expected hint from report, got %q", err)
+	}
+}
@@ -0,0 +1,73 @@
+package job
+
+import (
+	"sort"
+	"time"
+)
+
+// Phase of job work, shows where stuck job is
+type Phase string
+
+const (
+	// PhaseCreated until BigQuery job is created
+	PhaseCreated Phase = "created"
+	// PhaseWaitingBigQuery until BigQuery job is done
+	PhaseWaitingBigQuery Phase = "waiting_bq"
+	// PhaseReading until first row of result is fetched
+	PhaseReading Phase = "reading"
+	// PhaseUploading while rows are written to result object
+	PhaseUploading Phase = "uploading"
+	// PhaseClosing while upload is finished and aggregated result is written
+	PhaseClosing Phase = "closing"
+)
+
+// setPhase of job, job.mutex must not be held
+func (job *Job) setPhase(phase Phase) {
+	job.mutex.Lock()
+	previous, inPhase := job.phase, job.now().Sub(job.phaseAt)
+	job.phase = phase
+	job.phaseAt = job.now()
+	job.mutex.Unlock()
+	job.logger.Debug().Str("from", string(previous)).Str("to", string(phase)).Dur("inPhase", inPhase).Msg("Job phase changed")
+}
+
+// Diagnostics of job in store, see Store.Snapshot
+type Diagnostics struct {
+	ID       string
+	QueryID  string
+	ReportID string
+	// Internal job runs for other job of query, like count of rows
+	Internal bool
+	Phase    Phase
+	// InPhase since job entered phase
+	InPhase     time.Duration
+	RowsWritten int64
+	// BytesUploaded to result object so far, including bytes buffered by storage writer
+	BytesUploaded int64
+	// MemoryReserved by job for buffered rows
+	MemoryReserved int64
+}
+
+// Snapshot of jobs running on this replica, oldest first
+func (s *Store) Snapshot() []Diagnostics {
+	jobs := s.find(func(job *Job) bool { return true })
+	now := s.now()
+	sort.SliceStable(jobs, func(i, j int) bool { return jobs[i].createdAt.Before(jobs[j].createdAt) })
+	snapshot := make([]Diagnostics, 0, len(jobs))
+	for _, job := range jobs {
+		job.mutex.Lock()
+		snapshot = append(snapshot, Diagnostics{
+			ID:             job.ID,
+			QueryID:        job.QueryID,
+			ReportID:       job.ReportID,
+			Internal:       job.internal,
+			Phase:          job.phase,
+			InPhase:        now.Sub(job.phaseAt),
+			RowsWritten:    job.rowsWritten,
+			BytesUploaded:  job.bytesUploaded,
+			MemoryReserved: job.memoryReserved,
+		})
+		job.mutex.Unlock()
+	}
+	return snapshot
+}
@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// hookedIterator calls next before each row is fetched
+type hookedIterator struct {
+	rowIterator
+	next func()
+}
+
+func (it *hookedIterator) Next(dst interface{}) error {
+	it.next()
+	return it.rowIterator.Next(dst)
+}
+
+// hookedWriter calls close before upload is finished
+type hookedWriter struct {
+	fakeResultWriter
+	close func()
+}
+
+func (w *hookedWriter) Close() error {
+	w.close()
+	return w.fakeResultWriter.Close()
+}
+
+func TestStoreSnapshot(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	store := NewStore()
+	store.now = func() time.Time { return now }
+	job := store.New(context.Background(), "report", "query")
+	snapshot := func() Diagnostics {
+		t.Helper()
+		jobs := store.Snapshot()
+		if len(jobs) != 1 || jobs[0].ID != job.ID || jobs[0].QueryID != "query" || jobs[0].ReportID != "report" {
+			t.Fatalf("unexpected snapshot %+v", jobs)
+		}
+		return jobs[0]
+	}
+	now = now.Add(time.Second)
+	if d := snapshot(); d.Phase != PhaseCreated || d.InPhase != time.Second {
+		t.Errorf("expected created job, got %+v", d)
+	}
+	job.setPhase(PhaseWaitingBigQuery)
+	now = now.Add(2 * time.Second)
+	if d := snapshot(); d.Phase != PhaseWaitingBigQuery || d.InPhase != 2*time.Second {
+		t.Errorf("expected job waiting for BigQuery, got %+v", d)
+	}
+
+	job.state = StateReading
+	job.totalRows = 3
+	job.setPhase(PhaseReading)
+	statuses := collectStatus(job)
+	var phases []Diagnostics
+	it := &hookedIterator{rowIterator: newFakeIterator(3), next: func() { phases = append(phases, snapshot()) }}
+	w := &hookedWriter{close: func() { phases = append(phases, snapshot()) }}
+	job.writeResult(it, fakeSchema, w, func() {})
+	<-statuses
+	if len(phases) != 5 {
+		t.Fatalf("expected snapshot before each row and close, got %+v", phases)
+	}
+	if phases[0].Phase != PhaseReading || phases[0].RowsWritten != 0 {
+		t.Errorf("expected job reading before first row, got %+v", phases[0])
+	}
+	if phases[2].Phase != PhaseUploading || phases[2].RowsWritten != 2 {
+		t.Errorf("expected job uploading rows, got %+v", phases[2])
+	}
+	if phases[4].Phase != PhaseClosing || phases[4].RowsWritten != 3 || phases[4].BytesUploaded != int64(w.Len()) {
+		t.Errorf("expected job closing, got %+v", phases[4])
+	}
+	// finished job is removed from store
+	if jobs := store.Snapshot(); len(jobs) != 0 {
+		t.Errorf("expected empty snapshot, got %+v", jobs)
+	}
+}
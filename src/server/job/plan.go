@@ -0,0 +1,133 @@
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// maxPlanStages stored with job, plans of complex queries have hundreds of stages
+const maxPlanStages = 50
+
+// PlanStage of BigQuery query plan
+type PlanStage struct {
+	ID                        int64   `json:"id"`
+	Name                      string  `json:"name"`
+	Status                    string  `json:"status,omitempty"`
+	InputStages               []int64 `json:"inputStages,omitempty"`
+	RecordsRead               int64   `json:"recordsRead"`
+	RecordsWritten            int64   `json:"recordsWritten"`
+	WaitRatioAvg              float64 `json:"waitRatioAvg"`
+	WaitRatioMax              float64 `json:"waitRatioMax"`
+	ReadRatioAvg              float64 `json:"readRatioAvg"`
+	ReadRatioMax              float64 `json:"readRatioMax"`
+	ComputeRatioAvg           float64 `json:"computeRatioAvg"`
+	ComputeRatioMax           float64 `json:"computeRatioMax"`
+	WriteRatioAvg             float64 `json:"writeRatioAvg"`
+	WriteRatioMax             float64 `json:"writeRatioMax"`
+	ShuffleOutputBytes        int64   `json:"shuffleOutputBytes,omitempty"`
+	ShuffleOutputBytesSpilled int64   `json:"shuffleOutputBytesSpilled,omitempty"`
+	// DurationMs from stage start to end, 0 when stage has not ended
+	DurationMs int64 `json:"durationMs"`
+}
+
+// Plan summary of finished BigQuery job
+type Plan struct {
+	// Stages in plan order, truncated to slowest maxPlanStages
+	Stages []PlanStage `json:"stages"`
+	// TotalStages of full plan
+	TotalStages int `json:"totalStages"`
+	// ReferencedTables as project.dataset.table
+	ReferencedTables []string `json:"referencedTables,omitempty"`
+	// Note when plan was truncated
+	Note string `json:"note,omitempty"`
+}
+
+func newPlanStage(s *bigquery.ExplainQueryStage) PlanStage {
+	stage := PlanStage{
+		ID:                        s.ID,
+		Name:                      s.Name,
+		Status:                    s.Status,
+		InputStages:               s.InputStages,
+		RecordsRead:               s.RecordsRead,
+		RecordsWritten:            s.RecordsWritten,
+		WaitRatioAvg:              s.WaitRatioAvg,
+		WaitRatioMax:              s.WaitRatioMax,
+		ReadRatioAvg:              s.ReadRatioAvg,
+		ReadRatioMax:              s.ReadRatioMax,
+		ComputeRatioAvg:           s.ComputeRatioAvg,
+		ComputeRatioMax:           s.ComputeRatioMax,
+		WriteRatioAvg:             s.WriteRatioAvg,
+		WriteRatioMax:             s.WriteRatioMax,
+		ShuffleOutputBytes:        s.ShuffleOutputBytes,
+		ShuffleOutputBytesSpilled: s.ShuffleOutputBytesSpilled,
+	}
+	if !s.StartTime.IsZero() && s.EndTime.After(s.StartTime) {
+		stage.DurationMs = int64(s.EndTime.Sub(s.StartTime) / time.Millisecond)
+	}
+	return stage
+}
+
+// newPlan of job statistics, nil when job has no query plan
+func newPlan(stats *bigquery.JobStatistics) *Plan {
+	if stats == nil {
+		return nil
+	}
+	details, ok := stats.Details.(*bigquery.QueryStatistics)
+	if !ok || len(details.QueryPlan) == 0 {
+		return nil
+	}
+	plan := &Plan{TotalStages: len(details.QueryPlan)}
+	for _, s := range details.QueryPlan {
+		if s != nil {
+			plan.Stages = append(plan.Stages, newPlanStage(s))
+		}
+	}
+	if len(plan.Stages) > maxPlanStages {
+		// slowest stages are kept in plan order
+		slowest := append([]PlanStage(nil), plan.Stages...)
+		sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].DurationMs > slowest[j].DurationMs })
+		keep := make(map[int64]bool, maxPlanStages)
+		for _, s := range slowest[:maxPlanStages] {
+			keep[s.ID] = true
+		}
+		stages := plan.Stages[:0]
+		for _, s := range plan.Stages {
+			if keep[s.ID] && len(stages) < maxPlanStages {
+				stages = append(stages, s)
+			}
+		}
+		plan.Stages = stages
+		plan.Note = fmt.Sprintf("plan has %d stages, %d slowest are shown", plan.TotalStages, maxPlanStages)
+	}
+	for _, t := range details.ReferencedTables {
+		if t != nil {
+			plan.ReferencedTables = append(plan.ReferencedTables, fmt.Sprintf("%s.%s.%s", t.ProjectID, t.DatasetID, t.TableID))
+		}
+	}
+	return plan
+}
+
+// MarshalPlan stored in job record, empty without plan
+func MarshalPlan(plan *Plan) (string, error) {
+	if plan == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(plan)
+	return string(b), err
+}
+
+// UnmarshalPlan of job record, nil when job has no plan
+func UnmarshalPlan(value string) (*Plan, error) {
+	if value == "" {
+		return nil, nil
+	}
+	plan := &Plan{}
+	if err := json.Unmarshal([]byte(value), plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
@@ -0,0 +1,93 @@
+package job
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestNewPlan(t *testing.T) {
+	if plan := newPlan(nil); plan != nil {
+		t.Errorf("expected no plan without statistics, got %v", plan)
+	}
+	if plan := newPlan(&bigquery.JobStatistics{Details: &bigquery.QueryStatistics{}}); plan != nil {
+		t.Errorf("expected no plan without stages, got %v", plan)
+	}
+	start := time.Unix(1600000000, 0)
+	stats := &bigquery.JobStatistics{
+		TotalBytesProcessed: 2 << 40,
+		Details: &bigquery.QueryStatistics{
+			QueryPlan: []*bigquery.ExplainQueryStage{
+				{
+					ID:              0,
+					Name:            "S00: Input",
+					Status:          "COMPLETE",
+					RecordsRead:     1000,
+					RecordsWritten:  10,
+					WaitRatioAvg:    0.1,
+					ComputeRatioMax: 1,
+					StartTime:       start,
+					EndTime:         start.Add(1500 * time.Millisecond),
+				},
+				{ID: 1, Name: "S01: Output", InputStages: []int64{0}, RecordsRead: 10, RecordsWritten: 10, StartTime: start},
+			},
+			ReferencedTables: []*bigquery.Table{{ProjectID: "p", DatasetID: "d", TableID: "t"}},
+		},
+	}
+	plan := newPlan(stats)
+	if plan == nil || len(plan.Stages) != 2 || plan.TotalStages != 2 || plan.Note != "" {
+		t.Fatalf("expected plan of 2 stages, got %v", plan)
+	}
+	input := plan.Stages[0]
+	if input.Name != "S00: Input" || input.RecordsRead != 1000 || input.WaitRatioAvg != 0.1 || input.ComputeRatioMax != 1 || input.DurationMs != 1500 {
+		t.Errorf("unexpected input stage %+v", input)
+	}
+	if output := plan.Stages[1]; output.DurationMs != 0 || len(output.InputStages) != 1 {
+		t.Errorf("expected output stage without duration, got %+v", output)
+	}
+	if len(plan.ReferencedTables) != 1 || plan.ReferencedTables[0] != "p.d.t" {
+		t.Errorf("unexpected referenced tables %v", plan.ReferencedTables)
+	}
+}
+
+func TestNewPlanTruncated(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	details := &bigquery.QueryStatistics{}
+	for i := 0; i < maxPlanStages+10; i++ {
+		// stages 0 to 9 are fastest
+		details.QueryPlan = append(details.QueryPlan, &bigquery.ExplainQueryStage{
+			ID:        int64(i),
+			Name:      fmt.Sprintf("S%02d", i),
+			StartTime: start,
+			EndTime:   start.Add(time.Duration(i+1) * time.Second),
+		})
+	}
+	plan := newPlan(&bigquery.JobStatistics{Details: details})
+	if len(plan.Stages) != maxPlanStages || plan.TotalStages != maxPlanStages+10 || plan.Note == "" {
+		t.Fatalf("expected truncated plan, got %d of %d stages, note %q", len(plan.Stages), plan.TotalStages, plan.Note)
+	}
+	for i, s := range plan.Stages {
+		if s.ID != int64(i+10) {
+			t.Fatalf("expected slowest stages in plan order, got stage %d at %d", s.ID, i)
+		}
+	}
+}
+
+func TestMarshalPlan(t *testing.T) {
+	if value, err := MarshalPlan(nil); err != nil || value != "" {
+		t.Errorf("expected empty value, got %q %v", value, err)
+	}
+	if plan, err := UnmarshalPlan(""); err != nil || plan != nil {
+		t.Errorf("expected no plan, got %v %v", plan, err)
+	}
+	value, err := MarshalPlan(&Plan{Stages: []PlanStage{{ID: 3, Name: "S03", DurationMs: 20}}, TotalStages: 1, ReferencedTables: []string{"p.d.t"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := UnmarshalPlan(value)
+	if err != nil || len(plan.Stages) != 1 || plan.Stages[0].ID != 3 || plan.Stages[0].DurationMs != 20 || plan.ReferencedTables[0] != "p.d.t" {
+		t.Errorf("unexpected plan %+v %v", plan, err)
+	}
+}
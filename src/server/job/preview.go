@@ -0,0 +1,162 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog/log"
+)
+
+// PreviewMetadata of preview object; preview is rewritten while job reads result, so it's served without caching
+const PreviewMetadata = "dekart-preview"
+
+// defaultPreviewInterval between checkpoints of preview
+const defaultPreviewInterval = 10 * time.Second
+
+// resultPreview of first rows of result, rewritten as CSV header and rows at most once per interval
+// while job reads result; preview is removed when job finishes, result of done job replaces it
+type resultPreview struct {
+	resultID string
+	// write replaces preview object with data, remove deletes it
+	write  func(ctx context.Context, data []byte) error
+	remove func()
+	// maxRows of preview and interval between checkpoints
+	maxRows  int64
+	interval time.Duration
+	mutex    sync.Mutex
+	buf      bytes.Buffer
+	encoder  *csvEncoder
+	// rows in buf, stored rows in preview object
+	rows         int64
+	stored       int64
+	checkpointAt time.Time
+	removed      bool
+}
+
+// previewInterval of DEKART_PREVIEW_INTERVAL
+func previewInterval() time.Duration {
+	value := os.Getenv("DEKART_PREVIEW_INTERVAL")
+	if value == "" {
+		return defaultPreviewInterval
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Fatal().Str("value", value).Msg("Invalid DEKART_PREVIEW_INTERVAL")
+	}
+	return interval
+}
+
+// PreviewRows of DEKART_PREVIEW_ROWS, 0 when jobs don't write preview
+func (s *Store) PreviewRows() int64 {
+	return s.previewRows
+}
+
+// WritePreview of first rows of result into obj, stored as resultID while job reads result; call before Run
+func (job *Job) WritePreview(obj *storage.ObjectHandle, resultID string) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.previewLimit == 0 {
+		return
+	}
+	job.preview = &resultPreview{
+		resultID: resultID,
+		maxRows:  job.previewLimit,
+		interval: job.previewInterval,
+		write: func(ctx context.Context, data []byte) error {
+			start := time.Now()
+			// preview is replaced, so writer has no precondition
+			w := obj.NewWriter(ctx)
+			w.ContentType = "text/csv"
+			w.CacheControl = "no-cache"
+			w.Metadata = map[string]string{PreviewMetadata: "true"}
+			_, err := w.Write(data)
+			if closeErr := w.Close(); err == nil {
+				err = closeErr
+			}
+			metrics.Observe(metrics.GCS, "preview_upload", start, err)
+			return err
+		},
+		remove: func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := obj.Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+				job.logger.Warn().Err(err).Str("resultID", resultID).Msg("Cannot delete result preview")
+			}
+		},
+	}
+}
+
+// previewRow n of result; row is added when preview has n rows, so rows written again are not repeated.
+// Preview is stored when interval passed since last checkpoint and preview has new rows
+func (job *Job) previewRow(row []bigquery.Value, n int64, schema func() bigquery.Schema) {
+	p := job.preview
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.removed || p.stored == p.maxRows {
+		return
+	}
+	if p.encoder == nil {
+		// preview is CSV with serialization of job, also for Parquet result
+		p.encoder = job.newFormatEncoder(proto.ResultFormat_RESULT_FORMAT_CSV, &p.buf, schema).(*csvEncoder)
+		p.checkpointAt = job.now()
+	}
+	if n == p.rows && p.rows < p.maxRows {
+		size := p.buf.Len()
+		if err := p.encoder.write(row); err != nil {
+			job.logger.Warn().Err(err).Msg("Cannot write result preview")
+			return
+		}
+		// row is flushed into buf, so its bytes are reserved before it's kept
+		p.encoder.w.Flush()
+		if !job.reserveMemory(memoryPreview, int64(p.buf.Len()-size)) {
+			// preview keeps rows it has
+			p.buf.Truncate(size)
+			p.maxRows = p.rows
+		} else {
+			p.rows++
+		}
+	}
+	if p.rows == p.stored || job.now().Sub(p.checkpointAt) < p.interval {
+		return
+	}
+	p.checkpointAt = job.now()
+	p.encoder.w.Flush()
+	ctx, cancel := context.WithTimeout(job.Ctx, 10*time.Second)
+	defer cancel()
+	if err := p.write(ctx, p.buf.Bytes()); err != nil {
+		if !job.cancelled(err) {
+			job.logger.Warn().Err(err).Msg("Cannot store result preview")
+		}
+		return
+	}
+	p.stored = p.rows
+	job.mutex.Lock()
+	job.previewStored = p.stored
+	job.mutex.Unlock()
+	job.addEvent(EventPreview, fmt.Sprintf("%d rows", p.stored))
+}
+
+// removePreview of finished job; preview stored later is not written
+func (job *Job) removePreview() {
+	p := job.preview
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.removed = true
+	if p.stored > 0 {
+		p.remove()
+	}
+}
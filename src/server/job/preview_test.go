@@ -0,0 +1,132 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// fakePreview of job records stored previews and removals
+type fakePreview struct {
+	stored  []string
+	removed int
+}
+
+func (f *fakePreview) use(job *Job, maxRows int64, interval time.Duration) {
+	job.preview = &resultPreview{
+		resultID: "preview",
+		maxRows:  maxRows,
+		interval: interval,
+		write: func(ctx context.Context, data []byte) error {
+			f.stored = append(f.stored, string(data))
+			return nil
+		},
+		remove: func() { f.removed++ },
+	}
+}
+
+// clockIterator advances clock by step before each row
+type clockIterator struct {
+	*fakeIterator
+	clock *fakeClock
+	step  time.Duration
+}
+
+func (it *clockIterator) Next(dst interface{}) error {
+	it.clock.advance(it.step)
+	return it.fakeIterator.Next(dst)
+}
+
+func TestPreviewCheckpointsByTime(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1600000000, 0)}
+	store := NewStore()
+	store.now = clock.now
+	job := readingJob(store)
+	job.totalRows = 20
+	preview := &fakePreview{}
+	preview.use(job, 5, 3*time.Second)
+	statuses := collectStatus(job)
+	it := &clockIterator{fakeIterator: newFakeIterator(20), clock: clock, step: time.Second}
+	job.writeResult(it, fakeSchema, &fakeResultWriter{}, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	// 4 rows are stored 3 seconds after first row, 5 rows 3 seconds later, full preview isn't stored again
+	if len(preview.stored) != 2 {
+		t.Fatalf("expected 2 checkpoints, got %d", len(preview.stored))
+	}
+	if lines := strings.Count(preview.stored[0], "\n"); lines != 5 {
+		t.Errorf("expected header and 4 rows in first checkpoint, got %q", preview.stored[0])
+	}
+	if lines := strings.Count(preview.stored[1], "\n"); lines != 6 || !strings.HasPrefix(preview.stored[1], "id,text\n0,") {
+		t.Errorf("expected header and 5 rows in second checkpoint, got %q", preview.stored[1])
+	}
+	if preview.removed != 1 {
+		t.Errorf("expected preview removed once, got %d", preview.removed)
+	}
+	if snapshot := job.GetStatus(); snapshot.PreviewResultID != "" || snapshot.ResultID == nil {
+		t.Errorf("expected result replacing preview, got %+v", snapshot)
+	}
+}
+
+func TestPreviewReplacedAtDone(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1600000000, 0)}
+	store := NewStore()
+	store.now = clock.now
+	job := readingJob(store)
+	preview := &fakePreview{}
+	preview.use(job, 10, time.Second)
+	// row written again after retry is not repeated in preview
+	for _, n := range []int64{0, 1, 0, 1, 2} {
+		clock.advance(time.Second)
+		job.previewRow([]bigquery.Value{n, "text"}, n, fakeSchema)
+	}
+	snapshot := job.GetStatus()
+	if snapshot.PreviewResultID != "preview" || snapshot.PreviewRows != 3 {
+		t.Fatalf("expected preview of 3 rows while reading, got %s %d", snapshot.PreviewResultID, snapshot.PreviewRows)
+	}
+	if last := preview.stored[len(preview.stored)-1]; last != "id,text\n0,text\n1,text\n2,text\n" {
+		t.Errorf("unexpected preview %q", last)
+	}
+	go drainStatus(job)
+	job.transition(StateSaving, true, nil)
+	job.transition(StateDone, true, func() {
+		job.resultID = &job.ID
+	})
+	if snapshot := job.GetStatus(); snapshot.PreviewResultID != "" || snapshot.PreviewRows != 0 || snapshot.ResultID == nil {
+		t.Errorf("expected result and no preview at done, got %+v", snapshot)
+	}
+	job.finish()
+	if preview.removed != 1 {
+		t.Errorf("expected preview removed, got %d", preview.removed)
+	}
+}
+
+func TestPreviewRemovedOnCancel(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1600000000, 0)}
+	store := NewStore()
+	store.now = clock.now
+	job := readingJob(store)
+	preview := &fakePreview{}
+	preview.use(job, 10, time.Second)
+	clock.advance(time.Second)
+	job.previewRow([]bigquery.Value{0, "text"}, 0, fakeSchema)
+	clock.advance(time.Second)
+	job.previewRow([]bigquery.Value{1, "text"}, 1, fakeSchema)
+	go drainStatus(job)
+	job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	if preview.removed != 1 {
+		t.Fatalf("expected preview removed on cancel, got %d", preview.removed)
+	}
+	// row read after cancel doesn't store preview again
+	clock.advance(time.Second)
+	job.previewRow([]bigquery.Value{2, "text"}, 2, fakeSchema)
+	if len(preview.stored) != 1 {
+		t.Errorf("expected no preview after cancel, got %d", len(preview.stored))
+	}
+	if snapshot := job.GetStatus(); snapshot.PreviewResultID != "" {
+		t.Errorf("expected no preview of cancelled job, got %s", snapshot.PreviewResultID)
+	}
+}
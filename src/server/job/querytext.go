@@ -0,0 +1,90 @@
+package job
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultMaxQueryTextSize is 1MB, BigQuery limits unresolved query text to same size
+const defaultMaxQueryTextSize = 1 << 20
+
+// parseMaxQueryTextSize of DEKART_MAX_QUERY_TEXT_SIZE in bytes
+func parseMaxQueryTextSize(value string) (int, error) {
+	if value == "" {
+		return defaultMaxQueryTextSize, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid DEKART_MAX_QUERY_TEXT_SIZE %s", value)
+	}
+	return size, nil
+}
+
+func maxQueryTextSize() int {
+	size, err := parseMaxQueryTextSize(os.Getenv("DEKART_MAX_QUERY_TEXT_SIZE"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return size
+}
+
+// invisibleRune copied from documents and chats, BigQuery fails on it with illegal input character;
+// zero width characters and BOM are removed, no-break space is replaced with space
+func invisibleRune(r rune) (string, bool) {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff':
+		return "", true
+	case '\u00a0':
+		return " ", true
+	}
+	return "", false
+}
+
+// QueryTextTooLargeError when query text exceeds DEKART_MAX_QUERY_TEXT_SIZE
+type QueryTextTooLargeError struct {
+	Size  int
+	Limit int
+}
+
+func (e *QueryTextTooLargeError) Error() string {
+	return fmt.Sprintf("query text of %d bytes exceeds limit of %d bytes", e.Size, e.Limit)
+}
+
+// NormalizeQueryText rejects text larger than maxSize bytes or not valid UTF-8, maxSize 0 is unlimited. Invisible characters
+// are normalized outside of string literals, so literals keep their value; text which can't be parsed only loses leading BOM
+func NormalizeQueryText(queryText string, maxSize int) (string, error) {
+	if maxSize > 0 && len(queryText) > maxSize {
+		return "", &QueryTextTooLargeError{Size: len(queryText), Limit: maxSize}
+	}
+	if !utf8.ValidString(queryText) {
+		return "", fmt.Errorf("query text is not valid UTF-8")
+	}
+	queryText = strings.TrimPrefix(queryText, "\ufeff")
+	code, err := sqlCode(queryText)
+	if err != nil {
+		// BigQuery reports unterminated literal or comment
+		return queryText, nil
+	}
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(code); {
+		// masked literal is ASCII, so invisible rune in code is outside of literal
+		r, size := utf8.DecodeRuneInString(code[i:])
+		if replaced, ok := invisibleRune(r); ok {
+			b.WriteString(queryText[start:i])
+			b.WriteString(replaced)
+			start = i + size
+		}
+		i += size
+	}
+	if start == 0 {
+		return queryText, nil
+	}
+	b.WriteString(queryText[start:])
+	return b.String(), nil
+}
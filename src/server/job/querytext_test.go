@@ -0,0 +1,97 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+func TestNormalizeQueryText(t *testing.T) {
+	for name, c := range map[string]struct {
+		query    string
+		expected string
+	}{
+		"unchanged":              {"select 'ä' from points", "select 'ä' from points"},
+		"leading bom":            {"\ufeffselect 1", "select 1"},
+		"zero width space":       {"select\u200b * from\u200c points\u200d\u2060", "select * from points"},
+		"bom inside":             {"select 1\ufeff", "select 1"},
+		"no-break space":         {"select\u00a0*\u00a0from points", "select * from points"},
+		"literal keeps value":    {"select '\u200b', \"\u00a0\" from points\u200b", "select '\u200b', \"\u00a0\" from points"},
+		"triple quoted literal":  {"select '''a\u200bb''' x\u200b", "select '''a\u200bb''' x"},
+		"unterminated literal":   {"\ufeffselect 'a\u200b", "select 'a\u200b"},
+		"unterminated comment":   {"\ufeffselect 1 /*\u200b", "select 1 /*\u200b"},
+		"multibyte before rune":  {"select 'ü' ,\u200b 'é'", "select 'ü' , 'é'"},
+		"empty":                  {"", ""},
+		"only invisible":         {"\ufeff\u200b", ""},
+		"backtick identifier ok": {"select * from `data.points`\u200b", "select * from `data.points`"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			normalized, err := NormalizeQueryText(c.query, 1000)
+			if err != nil || normalized != c.expected {
+				t.Errorf("expected %q, got %q %v", c.expected, normalized, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeQueryTextRejected(t *testing.T) {
+	if _, err := NormalizeQueryText("select '\xff'", 1000); err == nil || !strings.Contains(err.Error(), "UTF-8") {
+		t.Errorf("expected invalid UTF-8 rejected, got %v", err)
+	}
+	limit := 100
+	boundary := "select '" + strings.Repeat("x", limit-9) + "'"
+	if _, err := NormalizeQueryText(boundary, limit); err != nil || len(boundary) != limit {
+		t.Errorf("expected text of %d bytes accepted, got %v", len(boundary), err)
+	}
+	_, err := NormalizeQueryText(boundary+" ", limit)
+	if tooLarge, ok := err.(*QueryTextTooLargeError); !ok || tooLarge.Size != limit+1 || tooLarge.Limit != limit {
+		t.Errorf("expected text too large, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), fmt.Sprintf("limit of %d bytes", limit)) {
+		t.Errorf("expected limit in error, got %q", err)
+	}
+	// size is checked before invisible characters are removed
+	if _, err := NormalizeQueryText(boundary[1:]+"\u200b", limit); err == nil {
+		t.Error("expected size of text as sent")
+	}
+	if _, err := NormalizeQueryText(strings.Repeat("x", 1<<21), 0); err != nil {
+		t.Errorf("expected zero limit unlimited, got %v", err)
+	}
+}
+
+func TestParseMaxQueryTextSize(t *testing.T) {
+	for value, expected := range map[string]int{"": defaultMaxQueryTextSize, "10": 10} {
+		size, err := parseMaxQueryTextSize(value)
+		if err != nil || size != expected {
+			t.Errorf("%q: expected %d, got %d %v", value, expected, size, err)
+		}
+	}
+	for _, value := range []string{"0", "-1", "1MB"} {
+		if _, err := parseMaxQueryTextSize(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestRunRejectsLargeQueryText(t *testing.T) {
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		t.Error("rejected query must not create BigQuery job")
+		return nil, fmt.Errorf("no client in test")
+	}
+	store := NewStore()
+	store.maxQueryTextSize = 10
+	job := store.New(context.Background(), "report", "query")
+	err := job.Run("select 1234567", RunOptions{}, nil)
+	if _, ok := err.(*QueryTextTooLargeError); !ok {
+		t.Errorf("expected text too large, got %v", err)
+	}
+	if job.GetState() != StateFailed {
+		t.Errorf("expected failed job, got %s", job.GetState())
+	}
+}
@@ -0,0 +1,83 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"time"
+)
+
+// FinishedJob is statistics of BigQuery job recorded for usage reporting
+type FinishedJob struct {
+	JobID     string
+	QueryID   string
+	ReportID  string
+	UserEmail string
+	// Status is JOB_STATUS_DONE when result is stored, unspecified when job failed or was cancelled
+	Status         int32
+	BytesProcessed int64
+	BytesBilled    int64
+	CacheHit       bool
+	// CancelReason of cancelled job, unspecified when job was not cancelled
+	CancelReason proto.CancelReason
+	// Duration from job created to finished
+	Duration   time.Duration
+	FinishedAt time.Time
+	// Events of job until terminal transition, EventsDropped from full log
+	Events        []Event
+	EventsDropped int
+}
+
+// StatsRecorder persists statistics of finished jobs
+type StatsRecorder interface {
+	Record(ctx context.Context, stats FinishedJob) error
+}
+
+// UseStatsRecorder to record statistics of jobs finished after BigQuery job was created
+func (s *Store) UseStatsRecorder(recorder StatsRecorder) {
+	s.mutex.Lock()
+	s.recorder = recorder
+	s.mutex.Unlock()
+}
+
+// recordStats of job on terminal transition, jobs which were not started are not recorded
+func (job *Job) recordStats() {
+	if job.store == nil {
+		return
+	}
+	job.store.mutex.Lock()
+	recorder := job.store.recorder
+	job.store.mutex.Unlock()
+	if recorder == nil {
+		return
+	}
+	finishedAt := job.now()
+	job.mutex.Lock()
+	stats := FinishedJob{
+		JobID:          job.ID,
+		QueryID:        job.QueryID,
+		ReportID:       job.ReportID,
+		UserEmail:      job.UserEmail,
+		BytesProcessed: job.processedBytes,
+		BytesBilled:    job.bytesBilled,
+		CacheHit:       job.cacheHit,
+		CancelReason:   job.cancelReason,
+		Duration:       finishedAt.Sub(job.createdAt),
+		FinishedAt:     finishedAt,
+		EventsDropped:  job.eventsDropped,
+	}
+	stats.Events = make([]Event, len(job.events))
+	copy(stats.Events, job.events)
+	if job.state == StateDone {
+		stats.Status = int32(proto.Query_JOB_STATUS_DONE)
+	}
+	started := !job.startedAt.IsZero()
+	job.mutex.Unlock()
+	if !started {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := recorder.Record(ctx, stats); err != nil {
+		job.logger.Err(err).Msg("Cannot record job stats")
+	}
+}
@@ -0,0 +1,84 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeRecorder struct {
+	stats []FinishedJob
+	mutex sync.Mutex
+}
+
+func (r *fakeRecorder) Record(ctx context.Context, stats FinishedJob) error {
+	r.mutex.Lock()
+	r.stats = append(r.stats, stats)
+	r.mutex.Unlock()
+	return nil
+}
+
+func TestRecordStats(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1600000000, 0)}
+	store := NewStore()
+	store.now = clock.now
+	recorder := &fakeRecorder{}
+	store.UseStatsRecorder(recorder)
+
+	// job aborted before BigQuery job was created is not recorded
+	store.New(context.Background(), "report", "query").Abort()
+	if len(recorder.stats) != 0 {
+		t.Fatalf("expected job which was not started not recorded, got %v", recorder.stats)
+	}
+
+	job := store.New(context.Background(), "report", "query")
+	job.UserEmail = "a@example.com"
+	done := make(chan struct{})
+	go func() {
+		drainStatus(job)
+		close(done)
+	}()
+	job.transition(StateRunning, true, func() {
+		job.startedAt = job.now()
+	})
+	job.mutex.Lock()
+	job.processedBytes = 100
+	job.bytesBilled = 1000
+	job.cacheHit = true
+	job.mutex.Unlock()
+	job.transition(StateReading, true, nil)
+	job.transition(StateSaving, true, nil)
+	clock.advance(3 * time.Second)
+	job.transition(StateDone, true, func() {
+		job.resultID = &job.ID
+	})
+	job.finish()
+	// finished twice is recorded once
+	job.finish()
+	<-done
+
+	expected := FinishedJob{
+		JobID:          job.ID,
+		QueryID:        "query",
+		ReportID:       "report",
+		UserEmail:      "a@example.com",
+		Status:         int32(proto.Query_JOB_STATUS_DONE),
+		BytesProcessed: 100,
+		BytesBilled:    1000,
+		CacheHit:       true,
+		Duration:       3 * time.Second,
+		FinishedAt:     time.Unix(1600000003, 0),
+		Events: []Event{
+			{Time: 1600000000000, Type: EventState, Message: "RUNNING"},
+			{Time: 1600000000000, Type: EventState, Message: "READING"},
+			{Time: 1600000000000, Type: EventState, Message: "SAVING"},
+			{Time: 1600000003000, Type: EventState, Message: "DONE"},
+		},
+	}
+	if len(recorder.stats) != 1 || !reflect.DeepEqual(recorder.stats[0], expected) {
+		t.Errorf("expected %v, got %v", expected, recorder.stats)
+	}
+}
@@ -0,0 +1,203 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+// Record of job ownership shared between replicas
+type Record struct {
+	JobID            string
+	QueryID          string
+	Replica          string
+	BigqueryProject  string
+	BigqueryJobID    string
+	BigqueryLocation string
+	// BigqueryConnection of DEKART_BIGQUERY_CONNECTIONS job was created with, empty is credentials of instance
+	BigqueryConnection string
+}
+
+// Registry persists job ownership so any replica can cancel any job
+type Registry interface {
+	// Register job started by replica
+	Register(ctx context.Context, record Record) error
+	// Find active jobs for query on all replicas, skipping records without heartbeat for staleAfter
+	Find(ctx context.Context, queryID string, staleAfter time.Duration) ([]Record, error)
+	// Heartbeat marks jobs of replica alive
+	Heartbeat(ctx context.Context, jobIDs []string) error
+	// RequestCancel marks job as cancelled with reason for owner replica
+	RequestCancel(ctx context.Context, jobID string, reason proto.CancelReason) error
+	// CancelRequested returns reasons of jobs from jobIDs which were cancelled by other replicas
+	CancelRequested(ctx context.Context, jobIDs []string) (map[string]proto.CancelReason, error)
+	// Remove record when job is finished
+	Remove(ctx context.Context, jobID string) error
+}
+
+// CancelPollInterval is how often replica checks if its jobs were cancelled by other replicas
+var CancelPollInterval = 5 * time.Second
+
+// staleAfter missed heartbeats record belongs to crashed replica
+func staleAfter() time.Duration {
+	return 3 * CancelPollInterval
+}
+
+// cancelBigqueryJob cancels BigQuery job running on other replica with credentials of source; job can be looked up
+// only in project it was billed to
+var cancelBigqueryJob = func(ctx context.Context, record Record, source oauth2.TokenSource) error {
+	client, err := newBigqueryClient(ctx, record.BigqueryProject, option.WithHTTPClient(&http.Client{
+		Transport: &oauth2.Transport{Source: source, Base: http.DefaultTransport},
+	}))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	start := time.Now()
+	bigqueryJob, err := client.JobFromIDLocation(ctx, record.BigqueryJobID, record.BigqueryLocation)
+	if err == nil {
+		err = bigqueryJob.Cancel(ctx)
+	}
+	metrics.Observe(metrics.BigQuery, "job_cancel", start, err)
+	return err
+}
+
+// UseRegistry to share jobs with other replicas; replica is name of current instance
+func (s *Store) UseRegistry(registry Registry, replica string) {
+	s.mutex.Lock()
+	s.registry = registry
+	s.replica = replica
+	s.mutex.Unlock()
+	go s.watchCancelRequests(CancelPollInterval)
+}
+
+func (job *Job) register(bigqueryProject string, bigqueryConnection string, bigqueryJobID string, bigqueryLocation string) {
+	if job.registry == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := job.registry.Register(ctx, Record{
+		JobID:              job.ID,
+		QueryID:            job.QueryID,
+		Replica:            job.replica,
+		BigqueryProject:    bigqueryProject,
+		BigqueryJobID:      bigqueryJobID,
+		BigqueryLocation:   bigqueryLocation,
+		BigqueryConnection: bigqueryConnection,
+	})
+	if err != nil {
+		job.logger.Err(err).Msg("Cannot register job")
+	}
+}
+
+func (s *Store) unregister(job *Job) {
+	if job.registry == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := job.registry.Remove(ctx, job.ID)
+	if err != nil {
+		job.logger.Err(err).Msg("Cannot remove job record")
+	}
+}
+
+func (s *Store) localJobIDs() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	jobIDs := make([]string, len(s.jobs))
+	for i, job := range s.jobs {
+		jobIDs[i] = job.ID
+	}
+	return jobIDs
+}
+
+// watchCancelRequests keeps local jobs alive in registry and cancels jobs cancelled by other replicas
+func (s *Store) watchCancelRequests(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		jobIDs := s.localJobIDs()
+		if len(jobIDs) == 0 {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := s.registry.Heartbeat(ctx, jobIDs)
+		if err != nil {
+			log.Err(err).Msg("Cannot update jobs heartbeat")
+		}
+		cancelled, err := s.registry.CancelRequested(ctx, jobIDs)
+		cancel()
+		if err != nil {
+			log.Err(err).Msg("Cannot check cancelled jobs")
+			continue
+		}
+		for jobID, reason := range cancelled {
+			log.Info().Str("jobID", jobID).Str("reason", reason.String()).Msg("Job cancelled by other replica")
+			s.CancelJob(jobID, reason)
+		}
+	}
+}
+
+// cancelRemote requests cancel of query jobs running on other replicas except job exceptID,
+// returns number of cancel requests
+func (s *Store) cancelRemote(queryID string, reason proto.CancelReason, exceptID string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	records, err := s.registry.Find(ctx, queryID, staleAfter())
+	if err != nil {
+		log.Err(err).Str("queryID", queryID).Msg("Cannot find job records")
+		return 0
+	}
+	var requested int
+	for _, record := range records {
+		if record.Replica == s.replica || record.JobID == exceptID {
+			continue
+		}
+		err := s.registry.RequestCancel(ctx, record.JobID, reason)
+		if err != nil {
+			log.Err(err).Str("jobID", record.JobID).Msg("Cannot request job cancel")
+			continue
+		}
+		requested++
+		if record.BigqueryJobID == "" {
+			continue
+		}
+		if record.BigqueryProject == "" {
+			// record of job billed to default project
+			record.BigqueryProject = s.projects.billingProject("")
+		}
+		var source oauth2.TokenSource = &instanceTokenSource{ctx: ctx}
+		if record.BigqueryConnection != "" {
+			source, err = s.connections.TokenSource(ctx, record.BigqueryConnection)
+		}
+		if err == nil {
+			err = cancelBigqueryJob(ctx, record, source)
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("bigqueryJobID", record.BigqueryJobID).Msg("Cannot cancel BigQuery job")
+		}
+	}
+	return requested
+}
+
+// CancelRemote jobs of queries running on other replicas, returns number of cancelled jobs
+func (s *Store) CancelRemote(queryIDs []string, reason proto.CancelReason) int {
+	s.mutex.Lock()
+	registry := s.registry
+	s.mutex.Unlock()
+	if registry == nil {
+		return 0
+	}
+	var cancelled int
+	for _, queryID := range queryIDs {
+		cancelled += s.cancelRemote(queryID, reason, "")
+	}
+	return cancelled
+}
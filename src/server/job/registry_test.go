@@ -0,0 +1,287 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+// fakeRegistry is shared by stores to simulate replicas using same database
+type fakeRegistry struct {
+	records   map[string]Record
+	cancelled map[string]proto.CancelReason
+	heartbeat map[string]time.Time
+	mutex     sync.Mutex
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		records:   make(map[string]Record),
+		cancelled: make(map[string]proto.CancelReason),
+		heartbeat: make(map[string]time.Time),
+	}
+}
+
+func (r *fakeRegistry) Register(ctx context.Context, record Record) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records[record.JobID] = record
+	r.heartbeat[record.JobID] = time.Now()
+	return nil
+}
+
+func (r *fakeRegistry) Find(ctx context.Context, queryID string, staleAfter time.Duration) ([]Record, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	records := []Record{}
+	for _, record := range r.records {
+		if time.Since(r.heartbeat[record.JobID]) > staleAfter {
+			continue
+		}
+		if _, cancelled := r.cancelled[record.JobID]; record.QueryID == queryID && !cancelled {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *fakeRegistry) Heartbeat(ctx context.Context, jobIDs []string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, jobID := range jobIDs {
+		if _, ok := r.records[jobID]; ok {
+			r.heartbeat[jobID] = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *fakeRegistry) RequestCancel(ctx context.Context, jobID string, reason proto.CancelReason) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.cancelled[jobID]; !ok {
+		r.cancelled[jobID] = reason
+	}
+	return nil
+}
+
+func (r *fakeRegistry) CancelRequested(ctx context.Context, jobIDs []string) (map[string]proto.CancelReason, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	cancelled := map[string]proto.CancelReason{}
+	for _, jobID := range jobIDs {
+		if reason, ok := r.cancelled[jobID]; ok {
+			cancelled[jobID] = reason
+		}
+	}
+	return cancelled, nil
+}
+
+func (r *fakeRegistry) Remove(ctx context.Context, jobID string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.records, jobID)
+	return nil
+}
+
+func drainStatus(job *Job) {
+	for {
+		select {
+		case <-job.Status:
+		case <-job.Ctx.Done():
+			return
+		}
+	}
+}
+
+// stubRegistryGlobals until end of test
+func stubRegistryGlobals(t *testing.T, pollInterval time.Duration, cancel func(ctx context.Context, record Record, source oauth2.TokenSource) error) {
+	interval := CancelPollInterval
+	cancelJob := cancelBigqueryJob
+	t.Cleanup(func() {
+		CancelPollInterval = interval
+		cancelBigqueryJob = cancelJob
+	})
+	CancelPollInterval = pollInterval
+	cancelBigqueryJob = cancel
+}
+
+func TestCancelJobOnOtherReplica(t *testing.T) {
+	var cancelledBigqueryJobs []string
+	var cancelledMutex sync.Mutex
+	stubRegistryGlobals(t, 10*time.Millisecond, func(ctx context.Context, record Record, source oauth2.TokenSource) error {
+		cancelledMutex.Lock()
+		defer cancelledMutex.Unlock()
+		cancelledBigqueryJobs = append(cancelledBigqueryJobs, record.BigqueryJobID)
+		return nil
+	})
+
+	registry := newFakeRegistry()
+	storeA := NewStore()
+	storeA.UseRegistry(registry, "replica-a")
+	storeB := NewStore()
+	storeB.UseRegistry(registry, "replica-b")
+
+	job := storeA.New(context.Background(), "report", "query")
+	go drainStatus(job)
+	job.register("", "", "bigquery-job", "US")
+
+	storeB.Cancel("query", proto.CancelReason_CANCEL_REASON_ADMIN)
+
+	select {
+	case <-job.Ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("job was not cancelled by other replica")
+	}
+	if job.Ctx.Err() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", job.Ctx.Err())
+	}
+	// reason of request is stamped by replica running job
+	if reason := job.GetStatus().CancelReason; reason != proto.CancelReason_CANCEL_REASON_ADMIN {
+		t.Errorf("expected admin reason, got %s", reason)
+	}
+	cancelledMutex.Lock()
+	defer cancelledMutex.Unlock()
+	if len(cancelledBigqueryJobs) != 1 || cancelledBigqueryJobs[0] != "bigquery-job" {
+		t.Errorf("expected BigQuery job cancelled by id, got %v", cancelledBigqueryJobs)
+	}
+}
+
+func TestCancelRemoteWithConnectionCredentials(t *testing.T) {
+	stubRegistryGlobals(t, 10*time.Millisecond, cancelBigqueryJob)
+	sts := httptest.NewServer(&fakeSTS{})
+	defer sts.Close()
+	var requests []string
+	var mutex sync.Mutex
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.URL.Path+" "+r.Header.Get("Authorization"))
+		mutex.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		job := `{"jobReference":{"projectId":"billing","jobId":"bigquery-job","location":"US"},"status":{"state":"RUNNING"}}`
+		// get of job answers job, cancel answers job in envelope
+		fmt.Fprintf(w, `{"job":%s,%s`, job, job[1:])
+	}))
+	defer endpoint.Close()
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, append(opts, option.WithEndpoint(endpoint.URL))...)
+	}
+
+	registry := newFakeRegistry()
+	storeA := NewStore()
+	storeA.UseRegistry(registry, "replica-a")
+	storeB := NewStore()
+	storeB.connections = fixtureConnections(t, sts, false)
+	storeB.UseRegistry(registry, "replica-b")
+	job := storeA.New(context.Background(), "report", "query")
+	go drainStatus(job)
+	job.register("billing", "github", "bigquery-job", "US")
+
+	storeB.Cancel("query", proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	<-job.Ctx.Done()
+	mutex.Lock()
+	defer mutex.Unlock()
+	var cancelled bool
+	for _, request := range requests {
+		if strings.HasSuffix(request, "/jobs/bigquery-job/cancel Bearer federated") {
+			cancelled = true
+		}
+	}
+	if !cancelled {
+		t.Errorf("expected BigQuery job cancelled with federated credentials of connection, got %v", requests)
+	}
+}
+
+func TestCancelSkipsOwnRecords(t *testing.T) {
+	stubRegistryGlobals(t, CancelPollInterval, func(ctx context.Context, record Record, source oauth2.TokenSource) error {
+		t.Errorf("local job should not be cancelled remotely")
+		return nil
+	})
+	registry := newFakeRegistry()
+	store := NewStore()
+	store.UseRegistry(registry, "replica-a")
+	job := store.New(context.Background(), "report", "query")
+	go drainStatus(job)
+	job.register("", "", "bigquery-job", "US")
+	store.Cancel("query", proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	<-job.Ctx.Done()
+	cancelled, _ := registry.CancelRequested(context.Background(), []string{job.ID})
+	if len(cancelled) > 0 {
+		t.Errorf("local job should not be marked as cancelled remotely")
+	}
+}
+
+func TestFindSkipsRecordsOfCrashedReplica(t *testing.T) {
+	stubRegistryGlobals(t, 10*time.Millisecond, func(ctx context.Context, record Record, source oauth2.TokenSource) error {
+		t.Errorf("job of crashed replica should not be cancelled")
+		return nil
+	})
+	registry := newFakeRegistry()
+	// replica-a crashed: record is left in registry without heartbeat
+	registry.Register(context.Background(), Record{JobID: "crashed", QueryID: "query", Replica: "replica-a", BigqueryJobID: "bigquery-job"})
+	registry.heartbeat["crashed"] = time.Now().Add(-time.Minute)
+
+	storeB := NewStore()
+	storeB.UseRegistry(registry, "replica-b")
+	storeB.Cancel("query", proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	cancelled, _ := registry.CancelRequested(context.Background(), []string{"crashed"})
+	if len(cancelled) > 0 {
+		t.Errorf("stale record should not be returned by Find")
+	}
+}
+
+func TestHeartbeatKeepsRecordAlive(t *testing.T) {
+	stubRegistryGlobals(t, 10*time.Millisecond, func(ctx context.Context, record Record, source oauth2.TokenSource) error {
+		return nil
+	})
+	registry := newFakeRegistry()
+	store := NewStore()
+	store.UseRegistry(registry, "replica-a")
+	job := store.New(context.Background(), "report", "query")
+	go drainStatus(job)
+	job.register("", "", "", "")
+	defer store.CancelJob(job.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+
+	// job lives longer than staleAfter, heartbeat keeps it visible for other replicas
+	time.Sleep(staleAfter() * 2)
+	records, _ := registry.Find(context.Background(), "query", staleAfter())
+	if len(records) != 1 {
+		t.Errorf("expected running job record, got %v", records)
+	}
+}
+
+func TestCancelRemoteByQueries(t *testing.T) {
+	stubRegistryGlobals(t, 10*time.Millisecond, func(ctx context.Context, record Record, source oauth2.TokenSource) error { return nil })
+	registry := newFakeRegistry()
+	storeA := NewStore()
+	storeA.UseRegistry(registry, "replica-a")
+	storeB := NewStore()
+	storeB.UseRegistry(registry, "replica-b")
+	job := storeA.New(context.Background(), "report", "query")
+	go drainStatus(job)
+	job.register("", "", "bigquery-job", "US")
+
+	if cancelled := storeB.CancelRemote([]string{"query", "other-query"}, proto.CancelReason_CANCEL_REASON_USER_REQUEST); cancelled != 1 {
+		t.Errorf("expected 1 remote job cancelled, got %d", cancelled)
+	}
+	select {
+	case <-job.Ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("job was not cancelled by other replica")
+	}
+	if cancelled := NewStore().CancelRemote([]string{"query"}, proto.CancelReason_CANCEL_REASON_USER_REQUEST); cancelled != 0 {
+		t.Errorf("expected nothing cancelled without registry, got %d", cancelled)
+	}
+}
@@ -0,0 +1,108 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var reservationRe = regexp.MustCompile(`^projects/[a-z0-9.:-]+/locations/[A-Za-z0-9-]+/reservations/[A-Za-z0-9_-]+$`)
+
+// ValidateReservation name, projects/{project}/locations/{location}/reservations/{reservation}
+func ValidateReservation(name string) error {
+	if !reservationRe.MatchString(name) {
+		return fmt.Errorf("invalid reservation %s, expected projects/{project}/locations/{location}/reservations/{reservation}", name)
+	}
+	return nil
+}
+
+// setReservations of DEKART_BIGQUERY_RESERVATION for jobs with credentials of instance and of
+// DEKART_BIGQUERY_CONNECTION_RESERVATIONS, comma separated connection=reservation
+func (c *Connections) setReservations(instance string, connections string) error {
+	c.reservations = map[string]string{}
+	if instance != "" {
+		if err := ValidateReservation(instance); err != nil {
+			return fmt.Errorf("DEKART_BIGQUERY_RESERVATION: %w", err)
+		}
+		c.reservations[""] = instance
+	}
+	if strings.TrimSpace(connections) == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(connections, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid DEKART_BIGQUERY_CONNECTION_RESERVATIONS entry %q, expected connection=reservation", entry)
+		}
+		if !c.Has(parts[0]) {
+			return fmt.Errorf("DEKART_BIGQUERY_CONNECTION_RESERVATIONS: unknown connection %s", parts[0])
+		}
+		if err := ValidateReservation(parts[1]); err != nil {
+			return fmt.Errorf("DEKART_BIGQUERY_CONNECTION_RESERVATIONS: %w", err)
+		}
+		c.reservations[parts[0]] = parts[1]
+	}
+	return nil
+}
+
+// Reservation jobs of connection are run in, empty connection is credentials of instance;
+// empty when reservation is decided by reservation assignments of billing project
+func (c *Connections) Reservation(connection string) string {
+	return c.reservations[connection]
+}
+
+// InvalidReservation prefixes job error when BigQuery rejects reservation of job
+const InvalidReservation = "INVALID_RESERVATION"
+
+// ReservationError when BigQuery can't create job in configured reservation
+type ReservationError struct {
+	Reservation string
+	Err         error
+}
+
+func (e *ReservationError) Error() string {
+	return fmt.Sprintf(
+		"%s: job can't run in reservation %s, check DEKART_BIGQUERY_RESERVATION and DEKART_BIGQUERY_CONNECTION_RESERVATIONS: %s",
+		InvalidReservation, e.Reservation, e.Err,
+	)
+}
+
+func (e *ReservationError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus of reservation error is failed precondition, fixed in configuration of instance
+func (e *ReservationError) GRPCStatus() *status.Status {
+	st := status.New(codes.FailedPrecondition, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   InvalidReservation,
+		Domain:   "dekart",
+		Metadata: map[string]string{"reservation": e.Reservation},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// reservationError classifies errors of job creation about reservation, other errors are returned as is
+func reservationError(err error, reservation string) error {
+	var apiErr *googleapi.Error
+	if reservation == "" || !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.Code != http.StatusBadRequest && apiErr.Code != http.StatusNotFound && apiErr.Code != http.StatusForbidden {
+		return err
+	}
+	if !strings.Contains(strings.ToLower(apiErr.Message), "reservation") {
+		return err
+	}
+	return &ReservationError{Reservation: reservation, Err: err}
+}
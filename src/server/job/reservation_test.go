@@ -0,0 +1,143 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testReservation = "projects/admin-project/locations/US/reservations/dashboards"
+
+func TestSetReservations(t *testing.T) {
+	c, err := parseConnections("partner=/etc/partner.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setReservations(testReservation, "partner=projects/partner-admin/locations/EU/reservations/batch"); err != nil {
+		t.Fatal(err)
+	}
+	if c.Reservation("") != testReservation || c.Reservation("partner") != "projects/partner-admin/locations/EU/reservations/batch" {
+		t.Errorf("unexpected reservations %v", c.reservations)
+	}
+	for _, values := range [][2]string{
+		{"dashboards", ""},
+		{"projects/admin-project/reservations/dashboards", ""},
+		{"", "partner"},
+		{"", "other=" + testReservation},
+		{"", "partner=projects/admin-project/locations/US/reservations/"},
+	} {
+		if err := c.setReservations(values[0], values[1]); err == nil {
+			t.Errorf("%v: expected error", values)
+		}
+	}
+}
+
+// useReservationEndpoint of fake BigQuery with instance reservation, client is authorized with static token
+func useReservationEndpoint(t *testing.T, store *Store, reservation string) *[]map[string]interface{} {
+	server, inserted := fakeJobsEndpoint(t)
+	newClient := newBigqueryClient
+	tokenSource := defaultTokenSource
+	t.Cleanup(func() {
+		newBigqueryClient = newClient
+		defaultTokenSource = tokenSource
+	})
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, append(opts, option.WithEndpoint(server.URL))...)
+	}
+	defaultTokenSource = func(ctx context.Context) (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), nil
+	}
+	c, err := parseConnections("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setReservations(reservation, ""); err != nil {
+		t.Fatal(err)
+	}
+	store.SetConnections(c)
+	return inserted
+}
+
+func TestRunSetsReservation(t *testing.T) {
+	for _, reservation := range []string{"", testReservation} {
+		store := NewStore()
+		inserted := useReservationEndpoint(t, store, reservation)
+		job := store.New(context.Background(), "report", "query")
+		go drainStatus(job)
+		if err := job.Run("select 1", RunOptions{BillingProject: "billing"}, nil); err != nil {
+			t.Fatal(err)
+		}
+		job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+		if len(*inserted) != 1 {
+			t.Fatalf("expected one job inserted, got %d", len(*inserted))
+		}
+		configuration := (*inserted)[0]["configuration"].(map[string]interface{})
+		if value, _ := configuration["reservation"].(string); value != reservation {
+			t.Errorf("expected reservation %q, got %q", reservation, value)
+		}
+		if query, ok := configuration["query"].(map[string]interface{}); !ok || query["query"] != "select 1" {
+			t.Errorf("expected query kept in configuration, got %v", configuration)
+		}
+	}
+}
+
+func TestEffectiveReservation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":     map[string]string{"state": "DONE"},
+			"statistics": map[string]string{"reservation_id": "admin-project:US.dashboards"},
+		})
+	}))
+	defer server.Close()
+	transport := &jobTransport{base: http.DefaultTransport, reservation: testReservation}
+	res, err := (&http.Client{Transport: transport}).Get(server.URL + "/projects/billing/jobs/job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// response is read again by client
+	var body map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil || body["status"] == nil {
+		t.Fatalf("expected job status, got %v %v", body, err)
+	}
+	job := NewStore().New(context.Background(), "report", "query")
+	job.transport = transport
+	job.setJobStats(&bigquery.JobStatus{Statistics: &bigquery.JobStatistics{}}, 0)
+	if reservation := job.GetStatus().Reservation; reservation != "admin-project:US.dashboards" {
+		t.Errorf("expected effective reservation, got %q", reservation)
+	}
+}
+
+func TestReservationError(t *testing.T) {
+	invalid := &googleapi.Error{Code: http.StatusBadRequest, Message: "Reservation projects/admin-project/locations/US/reservations/dashboards not found"}
+	err := reservationError(invalid, testReservation)
+	var reservationErr *ReservationError
+	if !errors.As(err, &reservationErr) || !strings.HasPrefix(err.Error(), InvalidReservation) {
+		t.Fatalf("expected classified error, got %v", err)
+	}
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition || len(st.Details()) != 1 {
+		t.Errorf("expected failed precondition with details, got %v", st)
+	}
+	if !errors.Is(err, invalid) {
+		t.Error("expected original error to be wrapped")
+	}
+	if err := reservationError(invalid, ""); err != invalid {
+		t.Errorf("expected error of job without reservation as is, got %v", err)
+	}
+	other := &googleapi.Error{Code: http.StatusBadRequest, Message: "Syntax error: Unexpected end of script"}
+	if err := reservationError(other, testReservation); err != other {
+		t.Errorf("expected other error as is, got %v", err)
+	}
+}
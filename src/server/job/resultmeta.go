@@ -0,0 +1,125 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"encoding/base64"
+	"encoding/json"
+	"hash"
+	"hash/crc32"
+	"time"
+)
+
+// StorageGCS is storage backend of results written to Cloud Storage bucket
+const StorageGCS = "gcs"
+
+// ResultPartKind of result stored with result of run
+const (
+	ResultPartFormat = "format"
+	ResultPartH3     = "h3"
+)
+
+// ResultPart of result stored with result file of job, like additional format or h3 aggregation
+type ResultPart struct {
+	ID     string             `json:"id"`
+	Kind   string             `json:"kind"`
+	Format proto.ResultFormat `json:"format"`
+}
+
+// ResultMeta of result file of done job; result ID, size and format fields of snapshot are filled with it
+// until clients read ResultMeta only
+type ResultMeta struct {
+	ID          string                  `json:"id"`
+	Format      proto.ResultFormat      `json:"format"`
+	Compression proto.ResultCompression `json:"compression,omitempty"`
+	// Size of stored result, compressed when result is compressed, Rows written to it
+	Size int64 `json:"size"`
+	Rows int64 `json:"rows"`
+	// Checksum of stored bytes, base64 CRC32C as in object attributes; empty when result was written by EXTRACT
+	Checksum  string    `json:"checksum,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Truncated by exploration LIMIT, Sampled with sample rate of run
+	Truncated bool `json:"truncated,omitempty"`
+	Sampled   bool `json:"sampled,omitempty"`
+	// Ordered rows are stored in ORDER BY of query
+	Ordered bool `json:"ordered,omitempty"`
+	// Storage backend and Bucket holding result, Bucket is empty when it's not known to job
+	Storage string       `json:"storage"`
+	Bucket  string       `json:"bucket,omitempty"`
+	Parts   []ResultPart `json:"parts,omitempty"`
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// checksumWriter of bytes passed to result object
+type checksumWriter struct {
+	resultWriter
+	hash hash.Hash32
+	// size of stored object, compressed when result is compressed
+	size int64
+}
+
+func newChecksumWriter(w resultWriter) *checksumWriter {
+	return &checksumWriter{resultWriter: w, hash: crc32.New(crc32cTable)}
+}
+
+func (c *checksumWriter) Write(p []byte) (int, error) {
+	n, err := c.resultWriter.Write(p)
+	c.hash.Write(p[:n])
+	c.size += int64(n)
+	return n, err
+}
+
+// sum of bytes written so far, encoded like CRC32C of storage object
+func (c *checksumWriter) sum() string {
+	if c == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(c.hash.Sum(nil))
+}
+
+// newResultMeta of result written by job, called with job mutex held by transition to done
+func (job *Job) newResultMeta(size int64, checksum string) *ResultMeta {
+	meta := &ResultMeta{
+		ID:          job.ID,
+		Format:      job.resultFormat,
+		Compression: job.compression,
+		Size:        size,
+		Rows:        job.rowsWritten,
+		Checksum:    checksum,
+		CreatedAt:   job.now().UTC(),
+		Truncated:   job.explorationLimit > 0,
+		Sampled:     job.sampleRate > 0,
+		Ordered:     job.ordered,
+		Storage:     StorageGCS,
+		Bucket:      job.resultBucket,
+	}
+	if meta.Bucket == "" && job.storageObj != nil {
+		meta.Bucket = job.storageObj.BucketName()
+	}
+	for _, r := range job.formatResults {
+		meta.Parts = append(meta.Parts, ResultPart{ID: r.ResultID, Kind: ResultPartFormat, Format: r.Format})
+	}
+	if job.h3 != nil {
+		meta.Parts = append(meta.Parts, ResultPart{ID: job.h3ResultID, Kind: ResultPartH3, Format: proto.ResultFormat_RESULT_FORMAT_CSV})
+	}
+	return meta
+}
+
+// MarshalResultMeta stored in result record, empty without meta
+func MarshalResultMeta(meta *ResultMeta) (string, error) {
+	if meta == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(meta)
+	return string(b), err
+}
+
+// UnmarshalResultMeta of result record, nil for result stored before meta was recorded
+func UnmarshalResultMeta(value string) (*ResultMeta, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var meta ResultMeta
+	err := json.Unmarshal([]byte(value), &meta)
+	return &meta, err
+}
@@ -0,0 +1,58 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/crc32"
+	"testing"
+)
+
+func TestResultMetaOfClose(t *testing.T) {
+	job := readingJob(NewStore())
+	job.compression = proto.ResultCompression_RESULT_COMPRESSION_GZIP
+	job.explorationLimit = 10
+	job.totalRows = 3
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	job.writeResult(newFakeIterator(3), fakeSchema, w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	snapshot := job.GetStatus()
+	meta := snapshot.ResultMeta
+	if snapshot.State != StateDone || meta == nil {
+		t.Fatalf("expected done job with meta, got %s %+v", snapshot.State, meta)
+	}
+	// deprecated fields of snapshot are filled with meta
+	if meta.ID != *snapshot.ResultID || meta.Size != snapshot.ResultSize || meta.Compression != snapshot.ResultCompression {
+		t.Errorf("meta %+v doesn't match snapshot %+v", meta, snapshot)
+	}
+	if meta.Rows != 3 || !meta.Truncated || meta.Sampled || meta.Storage != StorageGCS || meta.CreatedAt.IsZero() {
+		t.Errorf("unexpected meta %+v", meta)
+	}
+	// checksum is of compressed bytes as stored
+	sum := make([]byte, 4)
+	binary.BigEndian.PutUint32(sum, crc32.Checksum(w.Bytes(), crc32.MakeTable(crc32.Castagnoli)))
+	if expected := base64.StdEncoding.EncodeToString(sum); meta.Checksum != expected {
+		t.Errorf("expected checksum %s, got %s", expected, meta.Checksum)
+	}
+	value, err := MarshalResultMeta(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := UnmarshalResultMeta(value)
+	if err != nil || stored.Checksum != meta.Checksum || !stored.CreatedAt.Equal(meta.CreatedAt) {
+		t.Errorf("expected stored meta, got %+v %v", stored, err)
+	}
+}
+
+func TestResultMetaOfFailedJob(t *testing.T) {
+	job := readingJob(NewStore())
+	go drainStatus(job)
+	job.cancelWithError(&ResultTooLargeError{Limit: 1, Unit: "rows"})
+	if meta := job.GetStatus().ResultMeta; meta != nil {
+		t.Errorf("expected no meta of failed job, got %+v", meta)
+	}
+	if meta, err := UnmarshalResultMeta(""); meta != nil || err != nil {
+		t.Errorf("expected no meta of old result, got %+v %v", meta, err)
+	}
+}
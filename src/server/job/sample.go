@@ -0,0 +1,122 @@
+package job
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ValidateSampleRate is 0 for all rows or fraction of rows between 0 and 1
+func ValidateSampleRate(rate float64) error {
+	if math.IsNaN(rate) || rate < 0 || rate >= 1 {
+		return fmt.Errorf("invalid sample rate %v, expected fraction between 0 and 1", rate)
+	}
+	return nil
+}
+
+// simpleScanRe matches query reading all columns of single table
+var simpleScanRe = regexp.MustCompile("(?is)^\\s*select\\s+\\*\\s+from\\s+(`[^`]+`|[a-z0-9_-]+(?:\\.[a-z0-9_-]+){0,2})\\s*$")
+
+// SampleQuery rewrites query to read fraction of rows; simple table scan uses TABLESAMPLE,
+// other queries are wrapped in subquery filtered with RAND()
+func SampleQuery(queryText string, rate float64) (string, error) {
+	if err := ValidateSampleRate(rate); err != nil {
+		return "", err
+	}
+	if rate == 0 {
+		return queryText, nil
+	}
+	queryText, code, err := trimStatement(queryText)
+	if err != nil {
+		return "", fmt.Errorf("cannot sample query: %w", err)
+	}
+	if strings.TrimSpace(code) == "" {
+		return "", fmt.Errorf("cannot sample empty query")
+	}
+	if strings.Contains(code, ";") {
+		return "", fmt.Errorf("cannot sample query with multiple statements")
+	}
+	if m := simpleScanRe.FindStringSubmatchIndex(code); m != nil {
+		percent := strconv.FormatFloat(math.Round(rate*1e8)/1e6, 'f', -1, 64)
+		return fmt.Sprintf("SELECT * FROM %s TABLESAMPLE SYSTEM (%s PERCENT)", queryText[m[2]:m[3]], percent), nil
+	}
+	// query is kept on own lines, so it reads as written in BigQuery job history
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) WHERE RAND() < %s", queryText, strconv.FormatFloat(rate, 'f', -1, 64)), nil
+}
+
+// trimStatement removes trailing semicolons, returns query and its code
+func trimStatement(queryText string) (string, string, error) {
+	for {
+		code, err := sqlCode(queryText)
+		if err != nil {
+			return "", "", err
+		}
+		end := len(strings.TrimRight(code, " \t\r\n"))
+		if end == 0 || code[end-1] != ';' {
+			return queryText[:end], code[:end], nil
+		}
+		queryText = queryText[:end-1]
+	}
+}
+
+// sqlCode of query with same length, comments are replaced with spaces and string literals with x,
+// so statement structure can be matched without being confused by comments and literals. Error of unterminated
+// comment or literal has no context, callers add what they were doing
+func sqlCode(queryText string) (string, error) {
+	code := []byte(queryText)
+	mask := func(from int, to int, c byte) {
+		for i := from; i < to; i++ {
+			if code[i] != '\n' {
+				code[i] = c
+			}
+		}
+	}
+	for i := 0; i < len(queryText); {
+		switch c := queryText[i]; {
+		case c == '#' || strings.HasPrefix(queryText[i:], "--"):
+			end := strings.IndexByte(queryText[i:], '\n')
+			if end < 0 {
+				end = len(queryText) - i
+			}
+			mask(i, i+end, ' ')
+			i += end
+		case strings.HasPrefix(queryText[i:], "/*"):
+			end := strings.Index(queryText[i+2:], "*/")
+			if end < 0 {
+				return "", fmt.Errorf("unterminated comment")
+			}
+			mask(i, i+end+4, ' ')
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			quote := queryText[i : i+1]
+			if c != '`' && strings.HasPrefix(queryText[i:], strings.Repeat(quote, 3)) {
+				quote = strings.Repeat(quote, 3)
+			}
+			// raw strings can't escape quote
+			raw := i > 0 && (queryText[i-1] == 'r' || queryText[i-1] == 'R')
+			end := -1
+			for j := i + len(quote); j < len(queryText); j++ {
+				if queryText[j] == '\\' && !raw {
+					j++
+					continue
+				}
+				if strings.HasPrefix(queryText[j:], quote) {
+					end = j + len(quote)
+					break
+				}
+			}
+			if end < 0 {
+				return "", fmt.Errorf("unterminated %s", quote)
+			}
+			if c != '`' {
+				mask(i+len(quote), end-len(quote), 'x')
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return string(code), nil
+}
@@ -0,0 +1,83 @@
+package job
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSampleQuery(t *testing.T) {
+	for name, c := range map[string]struct {
+		query    string
+		rate     float64
+		expected string
+	}{
+		"not sampled": {
+			"select 1;", 0, "select 1;",
+		},
+		"table scan": {
+			"SELECT * FROM `bigquery-public-data.new_york.tlc_yellow_trips_2015`;\n", 0.01,
+			"SELECT * FROM `bigquery-public-data.new_york.tlc_yellow_trips_2015` TABLESAMPLE SYSTEM (1 PERCENT)",
+		},
+		"table scan without backticks": {
+			"select *\nfrom project.dataset.points -- all points", 0.07,
+			"SELECT * FROM project.dataset.points TABLESAMPLE SYSTEM (7 PERCENT)",
+		},
+		"filtered table": {
+			"select * from points where id > 1", 0.5,
+			"SELECT * FROM (\nselect * from points where id > 1\n) WHERE RAND() < 0.5",
+		},
+		"trailing comment is trimmed": {
+			"select lat, lng from points -- ; not end", 0.1,
+			"SELECT * FROM (\nselect lat, lng from points\n) WHERE RAND() < 0.1",
+		},
+		"semicolons and comments after statement": {
+			"select a from t; ; /* done; */\n# end\n", 0.1,
+			"SELECT * FROM (\nselect a from t\n) WHERE RAND() < 0.1",
+		},
+		"cte": {
+			"WITH p AS (SELECT * FROM points)\nSELECT * FROM p", 0.2,
+			"SELECT * FROM (\nWITH p AS (SELECT * FROM points)\nSELECT * FROM p\n) WHERE RAND() < 0.2",
+		},
+		"semicolon in literals": {
+			`select ';' as a, "x\";" as b, '''multi; line''' as c, r'\' as d from t;`, 0.3,
+			"SELECT * FROM (\nselect ';' as a, \"x\\\";\" as b, '''multi; line''' as c, r'\\' as d from t\n) WHERE RAND() < 0.3",
+		},
+		"table scan in comment is not simple": {
+			"/* select * from points */ select * from points where a = 1", 0.1,
+			"SELECT * FROM (\n/* select * from points */ select * from points where a = 1\n) WHERE RAND() < 0.1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sampled, err := SampleQuery(c.query, c.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sampled != c.expected {
+				t.Errorf("expected\n%s\ngot\n%s", c.expected, sampled)
+			}
+		})
+	}
+}
+
+func TestSampleQueryErrors(t *testing.T) {
+	for name, c := range map[string]struct {
+		query string
+		rate  float64
+	}{
+		"multiple statements":  {"select 1; select 2", 0.1},
+		"empty":                {" ; -- nothing", 0.1},
+		"unterminated string":  {"select 'a", 0.1},
+		"unterminated comment": {"select 1 /* a", 0.1},
+		"negative rate":        {"select 1", -0.1},
+		"full rate":            {"select 1", 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sampled, err := SampleQuery(c.query, c.rate)
+			if err == nil {
+				t.Errorf("expected error, got %s", sampled)
+			} else if strings.HasPrefix(name, "unterminated") && !strings.HasPrefix(err.Error(), "cannot sample query: unterminated") {
+				t.Errorf("expected error of sampling, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package job
+
+import (
+	"context"
+	"dekart/src/server/metrics"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+)
+
+// maxSchemaCacheEntries of SchemaCache, expired and then oldest schemas are removed when it's full
+const maxSchemaCacheEntries = 1000
+
+// SchemaFetcher reads schema of project.dataset.table billing metadata reads to billingProject
+type SchemaFetcher func(ctx context.Context, billingProject string, table string) (bigquery.Schema, error)
+
+// FetchTableSchema from BigQuery table metadata, metadata read is billed to billingProject resolved by store
+func FetchTableSchema(ctx context.Context, billingProject string, table string) (bigquery.Schema, error) {
+	projectID, datasetID, tableID, err := parseTable(table)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newBigqueryClient(ctx, billingProject)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	start := time.Now()
+	metadata, err := client.DatasetInProject(projectID, datasetID).Table(tableID).Metadata(ctx)
+	metrics.Observe(metrics.BigQuery, "table_metadata", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.Schema, nil
+}
+
+// SchemaError of table which can't be read, message is shown to user as warning
+func SchemaError(err error) string {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusNotFound:
+			return "table not found"
+		case http.StatusForbidden:
+			return "access denied, grant roles/bigquery.metadataViewer to service account of dekart"
+		}
+	}
+	return err.Error()
+}
+
+type schemaEntry struct {
+	schema    bigquery.Schema
+	fetchedAt time.Time
+}
+
+// SchemaCache of table schemas by project.dataset.table, concurrency safe. Tables are read with credentials of
+// dekart, so schemas are shared by users; errors are not cached, so created tables and granted access show up
+type SchemaCache struct {
+	// ttl of cached schemas, zero disables cache
+	ttl     time.Duration
+	fetch   SchemaFetcher
+	mutex   sync.Mutex
+	entries map[string]schemaEntry
+	now     func() time.Time
+}
+
+// NewSchemaCache of schemas read by fetch
+func NewSchemaCache(ttl time.Duration, fetch SchemaFetcher) *SchemaCache {
+	return &SchemaCache{
+		ttl:     ttl,
+		fetch:   fetch,
+		entries: make(map[string]schemaEntry),
+		now:     time.Now,
+	}
+}
+
+func (c *SchemaCache) cached(table string) (bigquery.Schema, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[table]
+	if !ok || c.now().Sub(entry.fetchedAt) >= c.ttl {
+		return nil, false
+	}
+	return entry.schema, true
+}
+
+func (c *SchemaCache) store(table string, schema bigquery.Schema) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	if _, ok := c.entries[table]; !ok && len(c.entries) >= maxSchemaCacheEntries {
+		var oldest string
+		for name, entry := range c.entries {
+			if now.Sub(entry.fetchedAt) >= c.ttl {
+				delete(c.entries, name)
+				continue
+			}
+			if oldest == "" || entry.fetchedAt.Before(c.entries[oldest].fetchedAt) {
+				oldest = name
+			}
+		}
+		if len(c.entries) >= maxSchemaCacheEntries {
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[table] = schemaEntry{schema: schema, fetchedAt: now}
+}
+
+// Schema of project.dataset.table, read from BigQuery when it's not cached
+func (c *SchemaCache) Schema(ctx context.Context, billingProject string, table string) (bigquery.Schema, error) {
+	if schema, ok := c.cached(table); ok {
+		return schema, nil
+	}
+	schema, err := c.fetch(ctx, billingProject, table)
+	if err != nil {
+		return nil, err
+	}
+	if c.ttl > 0 {
+		c.store(table, schema)
+	}
+	return schema, nil
+}
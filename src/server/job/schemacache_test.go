@@ -0,0 +1,105 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type fakeSchemas struct {
+	fetched map[string]int
+	err     error
+}
+
+func (f *fakeSchemas) fetch(ctx context.Context, billingProject string, table string) (bigquery.Schema, error) {
+	f.fetched[table]++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return bigquery.Schema{{Name: table}}, nil
+}
+
+func newFakeSchemaCache(ttl time.Duration) (*SchemaCache, *fakeSchemas, *time.Time) {
+	fake := &fakeSchemas{fetched: map[string]int{}}
+	cache := NewSchemaCache(ttl, fake.fetch)
+	now := time.Unix(1600000000, 0)
+	cache.now = func() time.Time { return now }
+	return cache, fake, &now
+}
+
+func TestSchemaCacheTTL(t *testing.T) {
+	cache, fake, now := newFakeSchemaCache(time.Minute)
+	for i := 0; i < 2; i++ {
+		schema, err := cache.Schema(context.Background(), "", "p.d.t")
+		if err != nil || schema[0].Name != "p.d.t" {
+			t.Fatalf("unexpected schema %v %v", schema, err)
+		}
+	}
+	if fake.fetched["p.d.t"] != 1 {
+		t.Errorf("expected schema fetched once, got %d", fake.fetched["p.d.t"])
+	}
+	*now = now.Add(time.Minute)
+	if _, err := cache.Schema(context.Background(), "", "p.d.t"); err != nil {
+		t.Fatal(err)
+	}
+	if fake.fetched["p.d.t"] != 2 {
+		t.Errorf("expected expired schema fetched again, got %d", fake.fetched["p.d.t"])
+	}
+}
+
+func TestSchemaCacheErrors(t *testing.T) {
+	cache, fake, _ := newFakeSchemaCache(time.Minute)
+	fake.err = errors.New("not found")
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Schema(context.Background(), "", "p.d.t"); err != fake.err {
+			t.Fatalf("expected error, got %v", err)
+		}
+	}
+	if fake.fetched["p.d.t"] != 2 {
+		t.Errorf("expected error not cached, got %d fetches", fake.fetched["p.d.t"])
+	}
+}
+
+func TestSchemaCacheDisabled(t *testing.T) {
+	cache, fake, _ := newFakeSchemaCache(0)
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Schema(context.Background(), "", "p.d.t"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fake.fetched["p.d.t"] != 2 || len(cache.entries) != 0 {
+		t.Errorf("expected no caching, got %d fetches and %d entries", fake.fetched["p.d.t"], len(cache.entries))
+	}
+}
+
+func TestSchemaCacheEviction(t *testing.T) {
+	cache, _, now := newFakeSchemaCache(time.Hour)
+	for i := 0; i < maxSchemaCacheEntries; i++ {
+		*now = now.Add(time.Second)
+		if _, err := cache.Schema(context.Background(), "", fmt.Sprintf("p.d.t%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	*now = now.Add(time.Second)
+	if _, err := cache.Schema(context.Background(), "", "p.d.new"); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.entries) != maxSchemaCacheEntries {
+		t.Errorf("expected %d entries, got %d", maxSchemaCacheEntries, len(cache.entries))
+	}
+	if _, ok := cache.entries["p.d.t0"]; ok {
+		t.Error("expected oldest entry evicted")
+	}
+	// expired entries are removed before oldest
+	*now = now.Add(time.Hour - time.Duration(maxSchemaCacheEntries/2)*time.Second)
+	if _, err := cache.Schema(context.Background(), "", "p.d.newer"); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.entries) >= maxSchemaCacheEntries {
+		t.Errorf("expected expired entries removed, got %d entries", len(cache.entries))
+	}
+}
@@ -0,0 +1,290 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// maxFloatPrecision of serialization options, float64 has no more significant decimal digits
+const maxFloatPrecision = 15
+
+// maxSerializationToken length of null token and timestamp format
+const maxSerializationToken = 64
+
+// numericScale and bigNumericScale of NUMERIC and BIGNUMERIC columns without declared scale
+const (
+	numericScale    = 9
+	bigNumericScale = 38
+)
+
+// timestampLayoutProbe is formatted with timestamp format, layout without elements formats as itself
+var timestampLayoutProbe = time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+
+// validDelimiter of CSV writer and reader
+func validDelimiter(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// ValidateSerialization options of CSV result, nil options are valid
+func ValidateSerialization(options *proto.SerializationOptions) error {
+	if options == nil {
+		return nil
+	}
+	if options.Delimiter != "" {
+		r, size := utf8.DecodeRuneInString(options.Delimiter)
+		if size != len(options.Delimiter) || !validDelimiter(r) {
+			return fmt.Errorf("invalid delimiter %q, expected one character other than quote and line break", options.Delimiter)
+		}
+	}
+	if len(options.NullToken) > maxSerializationToken || strings.ContainsAny(options.NullToken, "\r\n") {
+		return fmt.Errorf("invalid null_token, expected at most %d characters without line breaks", maxSerializationToken)
+	}
+	if _, ok := proto.SerializationOptions_BoolStyle_name[int32(options.BoolStyle)]; !ok {
+		return fmt.Errorf("unknown bool_style %d", options.BoolStyle)
+	}
+	if _, ok := proto.SerializationOptions_BytesEncoding_name[int32(options.BytesEncoding)]; !ok {
+		return fmt.Errorf("unknown bytes_encoding %d", options.BytesEncoding)
+	}
+	if _, ok := proto.SerializationOptions_HeaderNaming_name[int32(options.HeaderNaming)]; !ok {
+		return fmt.Errorf("unknown header_naming %d", options.HeaderNaming)
+	}
+	if options.FloatPrecision < 0 || options.FloatPrecision > maxFloatPrecision {
+		return fmt.Errorf("invalid float_precision %d, expected 0 to %d", options.FloatPrecision, maxFloatPrecision)
+	}
+	if format := options.TimestampFormat; format != "" {
+		if len(format) > maxSerializationToken || timestampLayoutProbe.Format(format) == format {
+			return fmt.Errorf("invalid timestamp_format %q, expected Go layout like 2006-01-02T15:04:05Z07:00", format)
+		}
+	}
+	return nil
+}
+
+// Delimiter of CSV result written with options
+func Delimiter(options *proto.SerializationOptions) rune {
+	if options == nil || options.Delimiter == "" {
+		return ','
+	}
+	r, _ := utf8.DecodeRuneInString(options.Delimiter)
+	return r
+}
+
+// NewCSVReader of result written with options
+func NewCSVReader(r io.Reader, options *proto.SerializationOptions) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = Delimiter(options)
+	return reader
+}
+
+// formatFloat rounded to precision with trailing zeros trimmed
+func formatFloat(value float64, precision int32) string {
+	s := strconv.FormatFloat(value, 'f', int(precision), 64)
+	if !strings.Contains(s, ".") {
+		// NaN and Inf
+		return s
+	}
+	s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// FormatDecimal of NUMERIC or BIGNUMERIC value with scale digits, never in scientific notation;
+// trailing zeros are trimmed unless trailingZeros is set
+func FormatDecimal(r *big.Rat, scale int, trailingZeros bool) string {
+	s := r.FloatString(scale)
+	if trailingZeros || !strings.Contains(s, ".") {
+		return s
+	}
+	s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// formatDecimalCell of column with scale; NUMERIC is read as big.Rat and BIGNUMERIC as decimal string,
+// false for NULL and values which are not decimals
+func formatDecimalCell(v bigquery.Value, scale int, trailingZeros bool) (string, bool) {
+	var r *big.Rat
+	switch value := v.(type) {
+	case *big.Rat:
+		r = value
+	case string:
+		var ok bool
+		if r, ok = new(big.Rat).SetString(value); !ok {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return FormatDecimal(r, scale, trailingZeros), true
+}
+
+// bytesEncoding of options, base64 when unspecified
+func bytesEncoding(options *proto.SerializationOptions) proto.SerializationOptions_BytesEncoding {
+	if options == nil || options.BytesEncoding == proto.SerializationOptions_BYTES_ENCODING_UNSPECIFIED {
+		return proto.SerializationOptions_BYTES_ENCODING_BASE64
+	}
+	return options.BytesEncoding
+}
+
+// FormatBytes of BYTES value in encoding of options, empty value is empty string
+func FormatBytes(b []byte, options *proto.SerializationOptions) string {
+	if bytesEncoding(options) == proto.SerializationOptions_BYTES_ENCODING_HEX {
+		return hex.EncodeToString(b)
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// FormatValue of result cell; value is formatted with %v without options, so stored results read as before,
+// except NUMERIC which is written as decimal rather than fraction and BYTES which are written encoded
+func FormatValue(v bigquery.Value, options *proto.SerializationOptions) string {
+	switch value := v.(type) {
+	case *big.Rat:
+		return FormatDecimal(value, numericScale, options != nil && options.NumericTrailingZeros)
+	case []byte:
+		return FormatBytes(value, options)
+	}
+	if options == nil {
+		return fmt.Sprintf("%v", v)
+	}
+	switch value := v.(type) {
+	case nil:
+		return options.NullToken
+	case bool:
+		switch options.BoolStyle {
+		case proto.SerializationOptions_BOOL_STYLE_NUMERIC:
+			if value {
+				return "1"
+			}
+			return "0"
+		case proto.SerializationOptions_BOOL_STYLE_UPPER:
+			return strings.ToUpper(strconv.FormatBool(value))
+		}
+		return strconv.FormatBool(value)
+	case float64:
+		if options.FloatPrecision > 0 {
+			return formatFloat(value, options.FloatPrecision)
+		}
+	case time.Time:
+		if options.TimestampFormat != "" {
+			return value.Format(options.TimestampFormat)
+		}
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// storedSerialization in result record, bool style is stored by name
+type storedSerialization struct {
+	Delimiter       string `json:"delimiter,omitempty"`
+	NullToken       string `json:"nullToken"`
+	BoolStyle       string `json:"boolStyle,omitempty"`
+	FloatPrecision  int32  `json:"floatPrecision,omitempty"`
+	TimestampFormat string `json:"timestampFormat,omitempty"`
+	// NumericTrailingZeros keeps zeros up to scale of NUMERIC and BIGNUMERIC columns
+	NumericTrailingZeros bool `json:"numericTrailingZeros,omitempty"`
+	// BytesEncoding is stored by name like bool style
+	BytesEncoding string `json:"bytesEncoding,omitempty"`
+	HeaderNaming  string `json:"headerNaming,omitempty"`
+}
+
+// MarshalSerialization of result record, empty for nil options
+func MarshalSerialization(options *proto.SerializationOptions) (string, error) {
+	if options == nil {
+		return "", nil
+	}
+	stored := storedSerialization{
+		Delimiter:            options.Delimiter,
+		NullToken:            options.NullToken,
+		FloatPrecision:       options.FloatPrecision,
+		TimestampFormat:      options.TimestampFormat,
+		NumericTrailingZeros: options.NumericTrailingZeros,
+	}
+	if options.BoolStyle != proto.SerializationOptions_BOOL_STYLE_UNSPECIFIED {
+		stored.BoolStyle = options.BoolStyle.String()
+	}
+	if options.BytesEncoding != proto.SerializationOptions_BYTES_ENCODING_UNSPECIFIED {
+		stored.BytesEncoding = options.BytesEncoding.String()
+	}
+	if options.HeaderNaming != proto.SerializationOptions_HEADER_NAMING_UNSPECIFIED {
+		stored.HeaderNaming = options.HeaderNaming.String()
+	}
+	b, err := json.Marshal(stored)
+	return string(b), err
+}
+
+// UnmarshalSerialization of result record, nil for result written without options
+func UnmarshalSerialization(value string) (*proto.SerializationOptions, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var stored storedSerialization
+	if err := json.Unmarshal([]byte(value), &stored); err != nil {
+		return nil, err
+	}
+	return &proto.SerializationOptions{
+		Delimiter:            stored.Delimiter,
+		NullToken:            stored.NullToken,
+		BoolStyle:            proto.SerializationOptions_BoolStyle(proto.SerializationOptions_BoolStyle_value[stored.BoolStyle]),
+		FloatPrecision:       stored.FloatPrecision,
+		TimestampFormat:      stored.TimestampFormat,
+		NumericTrailingZeros: stored.NumericTrailingZeros,
+		BytesEncoding:        proto.SerializationOptions_BytesEncoding(proto.SerializationOptions_BytesEncoding_value[stored.BytesEncoding]),
+		HeaderNaming:         proto.SerializationOptions_HeaderNaming(proto.SerializationOptions_HeaderNaming_value[stored.HeaderNaming]),
+	}, nil
+}
+
+// ColumnTypes of result columns by BigQuery type name, repeated columns are ARRAY
+func ColumnTypes(schema bigquery.Schema) []string {
+	types := make([]string, len(schema))
+	for i, field := range schema {
+		types[i] = string(field.Type)
+		if field.Repeated {
+			types[i] = "ARRAY"
+		}
+	}
+	return types
+}
+
+// bytesColumnTypes of CSV result with encoding of BYTES columns, like BYTES(BASE64)
+func bytesColumnTypes(types []string, schema bigquery.Schema, options *proto.SerializationOptions) []string {
+	encoding := strings.TrimPrefix(bytesEncoding(options).String(), "BYTES_ENCODING_")
+	for i, field := range schema {
+		if field.Type == bigquery.BytesFieldType && !field.Repeated && i < len(types) {
+			types[i] = fmt.Sprintf("%s(%s)", field.Type, encoding)
+		}
+	}
+	return types
+}
+
+// MarshalColumnTypes of result record, empty for result without columns
+func MarshalColumnTypes(types []string) (string, error) {
+	if len(types) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(types)
+	return string(b), err
+}
+
+// UnmarshalColumnTypes of result record, nil for result written before types were recorded
+func UnmarshalColumnTypes(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var types []string
+	err := json.Unmarshal([]byte(value), &types)
+	return types, err
+}
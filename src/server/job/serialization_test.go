@@ -0,0 +1,260 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestFormatValue(t *testing.T) {
+	timestamp := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	values := map[string]bigquery.Value{
+		"string":    "a",
+		"integer":   int64(42),
+		"float":     1.2500,
+		"negative":  -0.0001,
+		"nan":       math.NaN(),
+		"true":      true,
+		"false":     false,
+		"timestamp": timestamp,
+		"null":      nil,
+	}
+	defaults := map[string]string{
+		"string":    "a",
+		"integer":   "42",
+		"float":     "1.25",
+		"negative":  "-0.0001",
+		"nan":       "NaN",
+		"true":      "true",
+		"false":     "false",
+		"timestamp": "2021-03-04 05:06:07 +0000 UTC",
+		"null":      "",
+	}
+	for _, c := range []struct {
+		name     string
+		options  *proto.SerializationOptions
+		expected map[string]string
+	}{
+		{"no options", nil, map[string]string{"null": "<nil>"}},
+		{"empty options", &proto.SerializationOptions{}, nil},
+		{"null token", &proto.SerializationOptions{NullToken: "NULL"}, map[string]string{"null": "NULL"}},
+		{"numeric bool", &proto.SerializationOptions{BoolStyle: proto.SerializationOptions_BOOL_STYLE_NUMERIC}, map[string]string{"true": "1", "false": "0"}},
+		{"upper bool", &proto.SerializationOptions{BoolStyle: proto.SerializationOptions_BOOL_STYLE_UPPER}, map[string]string{"true": "TRUE", "false": "FALSE"}},
+		{"float precision", &proto.SerializationOptions{FloatPrecision: 1}, map[string]string{"float": "1.2", "negative": "0"}},
+		{"float precision trims zeros", &proto.SerializationOptions{FloatPrecision: 6}, map[string]string{"float": "1.25", "negative": "-0.0001"}},
+		{"timestamp format", &proto.SerializationOptions{TimestampFormat: time.RFC3339}, map[string]string{"timestamp": "2021-03-04T05:06:07Z"}},
+		{"delimiter", &proto.SerializationOptions{Delimiter: "\t"}, nil},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			for name, value := range values {
+				expected, ok := c.expected[name]
+				if !ok {
+					expected = defaults[name]
+				}
+				if got := FormatValue(value, c.options); got != expected {
+					t.Errorf("%s: expected %q, got %q", name, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSerialization(t *testing.T) {
+	for _, options := range []*proto.SerializationOptions{
+		nil,
+		{},
+		{Delimiter: "\t", NullToken: "\\N", BoolStyle: proto.SerializationOptions_BOOL_STYLE_NUMERIC, FloatPrecision: 15, TimestampFormat: "2006-01-02"},
+		{Delimiter: "¦"},
+	} {
+		if err := ValidateSerialization(options); err != nil {
+			t.Errorf("%v: unexpected error %v", options, err)
+		}
+	}
+	for name, options := range map[string]*proto.SerializationOptions{
+		"long delimiter":    {Delimiter: ";;"},
+		"quote delimiter":   {Delimiter: `"`},
+		"newline delimiter": {Delimiter: "\n"},
+		"newline null":      {NullToken: "a\nb"},
+		"long null":         {NullToken: strings.Repeat("n", maxSerializationToken+1)},
+		"unknown bool":      {BoolStyle: 10},
+		"unknown bytes":     {BytesEncoding: 10},
+		"unknown header":    {HeaderNaming: 10},
+		"negative float":    {FloatPrecision: -1},
+		"large float":       {FloatPrecision: maxFloatPrecision + 1},
+		"constant layout":   {TimestampFormat: "timestamp"},
+	} {
+		if err := ValidateSerialization(options); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestMarshalSerialization(t *testing.T) {
+	if value, err := MarshalSerialization(nil); err != nil || value != "" {
+		t.Errorf("expected empty value, got %q %v", value, err)
+	}
+	if options, err := UnmarshalSerialization(""); err != nil || options != nil {
+		t.Errorf("expected nil options, got %v %v", options, err)
+	}
+	for _, options := range []*proto.SerializationOptions{
+		{},
+		{Delimiter: "\t", NullToken: "NULL", BoolStyle: proto.SerializationOptions_BOOL_STYLE_UPPER, FloatPrecision: 3, TimestampFormat: time.RFC3339, NumericTrailingZeros: true, BytesEncoding: proto.SerializationOptions_BYTES_ENCODING_HEX,
+			HeaderNaming: proto.SerializationOptions_HEADER_NAMING_STRICT},
+	} {
+		value, err := MarshalSerialization(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored, err := UnmarshalSerialization(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.String() != options.String() {
+			t.Errorf("expected %v, got %v", options, stored)
+		}
+	}
+}
+
+func TestCSVResultSerialization(t *testing.T) {
+	job := readingJob(NewStore())
+	job.serialization = &proto.SerializationOptions{Delimiter: "\t", NullToken: "NULL", BoolStyle: proto.SerializationOptions_BOOL_STYLE_NUMERIC}
+	job.totalRows = 2
+	statuses := collectStatus(job)
+	schema := bigquery.Schema{{Name: "name", Type: bigquery.StringFieldType}, {Name: "active", Type: bigquery.BooleanFieldType}}
+	w := &fakeResultWriter{}
+	rows := [][]bigquery.Value{{"a b", true}, {nil, false}}
+	job.writeResult(&fakeIterator{rows: rows}, func() bigquery.Schema { return schema }, w, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_SAVING_RESULT) || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v: %s", s, job.Err())
+	}
+	if expected := "name\tactive\na b\t1\nNULL\t0\n"; w.String() != expected {
+		t.Errorf("expected %q, got %q", expected, w.String())
+	}
+	records, err := NewCSVReader(strings.NewReader(w.String()), job.serialization).ReadAll()
+	if err != nil || len(records) != 3 || records[1][0] != "a b" {
+		t.Errorf("expected result read with delimiter, got %v %v", records, err)
+	}
+}
+
+func decimal(t *testing.T, value string) *big.Rat {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		t.Fatalf("invalid decimal %s", value)
+	}
+	return r
+}
+
+func TestFormatDecimal(t *testing.T) {
+	for _, c := range []struct {
+		value         string
+		scale         int
+		trailingZeros bool
+		expected      string
+	}{
+		// NUMERIC limits
+		{"99999999999999999999999999999.999999999", numericScale, false, "99999999999999999999999999999.999999999"},
+		{"-99999999999999999999999999999.999999999", numericScale, false, "-99999999999999999999999999999.999999999"},
+		{"0.000000001", numericScale, false, "0.000000001"},
+		{"-0.000000001", numericScale, false, "-0.000000001"},
+		// BIGNUMERIC limits
+		{"578960446186580977117854925043439539266.34992332820282019728792003956564819967", bigNumericScale, false, "578960446186580977117854925043439539266.34992332820282019728792003956564819967"},
+		{"-578960446186580977117854925043439539266.34992332820282019728792003956564819968", bigNumericScale, false, "-578960446186580977117854925043439539266.34992332820282019728792003956564819968"},
+		{"1e-38", bigNumericScale, false, "0.00000000000000000000000000000000000001"},
+		{"123.45", numericScale, false, "123.45"},
+		{"123.45", numericScale, true, "123.450000000"},
+		{"-7", 2, true, "-7.00"},
+		{"-7", 2, false, "-7"},
+		{"0", numericScale, false, "0"},
+		{"1e20", 0, false, "100000000000000000000"},
+	} {
+		if s := FormatDecimal(decimal(t, c.value), c.scale, c.trailingZeros); s != c.expected {
+			t.Errorf("%s with scale %d: expected %s, got %s", c.value, c.scale, c.expected, s)
+		}
+	}
+	// NUMERIC cell without column scale is not written as fraction
+	if s := FormatValue(decimal(t, "-123.45"), nil); s != "-123.45" {
+		t.Errorf("expected decimal, got %s", s)
+	}
+}
+
+func TestCSVResultDecimals(t *testing.T) {
+	job := readingJob(NewStore())
+	job.transport = &jobTransport{columns: map[string]resultColumn{
+		"price": {fieldType: bigquery.NumericFieldType, precision: "10", scale: "2"},
+		"big":   {fieldType: bigNumericFieldType},
+	}}
+	job.totalRows = 2
+	statuses := collectStatus(job)
+	schema := bigquery.Schema{
+		{Name: "amount", Type: bigquery.NumericFieldType},
+		{Name: "price", Type: bigquery.NumericFieldType},
+		{Name: "big", Type: bigNumericFieldType},
+	}
+	w := &fakeResultWriter{}
+	rows := [][]bigquery.Value{
+		{decimal(t, "-0.5"), decimal(t, "12345678.9"), "-578960446186580977117854925043439539266.34992332820282019728792003956564819968"},
+		{nil, decimal(t, "-1"), "0.00000000000000000000000000000000000001"},
+	}
+	job.writeResult(&fakeIterator{rows: rows}, job.resultSchema(func() bigquery.Schema { return schema }), w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	if err := job.Err(); err != "" {
+		t.Fatal(err)
+	}
+	expected := "amount,price,big\n" +
+		"-0.5,12345678.9,-578960446186580977117854925043439539266.34992332820282019728792003956564819968\n" +
+		"<nil>,-1,0.00000000000000000000000000000000000001\n"
+	if w.String() != expected {
+		t.Errorf("expected %q, got %q", expected, w.String())
+	}
+	// precision and scale of parameterized column are recorded with column types
+	if types := job.GetStatus().ColumnTypes; strings.Join(types, ",") != "NUMERIC,NUMERIC(10,2),BIGNUMERIC" {
+		t.Errorf("unexpected column types %v", types)
+	}
+}
+
+func TestCSVResultBytes(t *testing.T) {
+	binary := []byte{',', '"', '\n', 0, 0xff, '\t', '"'}
+	for _, c := range []struct {
+		options  *proto.SerializationOptions
+		encoding string
+		decode   func(string) ([]byte, error)
+		null     string
+	}{
+		{nil, "BASE64", base64.StdEncoding.DecodeString, "<nil>"},
+		{&proto.SerializationOptions{Delimiter: "\t", NullToken: "NULL", BytesEncoding: proto.SerializationOptions_BYTES_ENCODING_HEX}, "HEX", hex.DecodeString, "NULL"},
+	} {
+		job := readingJob(NewStore())
+		job.serialization = c.options
+		job.totalRows = 3
+		statuses := collectStatus(job)
+		schema := bigquery.Schema{{Name: "payload", Type: bigquery.BytesFieldType}, {Name: "text", Type: bigquery.StringFieldType}}
+		w := &fakeResultWriter{}
+		rows := [][]bigquery.Value{{binary, "a,\"b\""}, {[]byte{}, ""}, {nil, nil}}
+		job.writeResult(&fakeIterator{rows: rows}, func() bigquery.Schema { return schema }, w, func() { t.Error("unexpected cleanup") })
+		<-statuses
+		if err := job.Err(); err != "" {
+			t.Fatal(err)
+		}
+		records, err := NewCSVReader(strings.NewReader(w.String()), c.options).ReadAll()
+		if err != nil || len(records) != 4 {
+			t.Fatalf("%s: expected 4 records, got %q %v", c.encoding, records, err)
+		}
+		if b, err := c.decode(records[1][0]); err != nil || string(b) != string(binary) || records[1][1] != "a,\"b\"" {
+			t.Errorf("%s: expected binary value, got %q %v", c.encoding, records[1], err)
+		}
+		// empty value and NULL are distinguishable
+		if records[2][0] != "" || records[3][0] != c.null {
+			t.Errorf("%s: expected empty value and NULL, got %q %q", c.encoding, records[2][0], records[3][0])
+		}
+		if types := job.GetStatus().ColumnTypes; types[0] != "BYTES("+c.encoding+")" || types[1] != "STRING" {
+			t.Errorf("unexpected column types %v", types)
+		}
+	}
+}
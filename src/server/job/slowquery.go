@@ -0,0 +1,105 @@
+package job
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SlowQueryThresholds of job durations logged as slow query, zero disables threshold
+type SlowQueryThresholds struct {
+	// Wait for BigQuery job to finish
+	Wait time.Duration
+	// Total from job created to result stored
+	Total time.Duration
+}
+
+// parseThreshold of env variable as duration, empty disables threshold
+func parseThreshold(name string) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold < 0 {
+		log.Fatal().Str("value", value).Msgf("Invalid %s", name)
+	}
+	return threshold
+}
+
+// SlowQueryThresholds of jobs created by store
+func (s *Store) SlowQueryThresholds() SlowQueryThresholds {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.slowQuery
+}
+
+// SetSlowQueryThresholds applied to running and new jobs
+func (s *Store) SetSlowQueryThresholds(thresholds SlowQueryThresholds) {
+	s.mutex.Lock()
+	s.slowQuery = thresholds
+	s.mutex.Unlock()
+}
+
+// queryHashLabel of BigQuery job, finds job of executed query text in BigQuery audit logs
+const queryHashLabel = "dekart-query-hash"
+
+// QueryHash identifies query text in logs and job labels without logging the query, it may contain sensitive literals
+func QueryHash(queryText string) string {
+	sum := sha256.Sum256([]byte(queryText))
+	return hex.EncodeToString(sum[:8])
+}
+
+// markWaited when BigQuery job is finished
+func (job *Job) markWaited() {
+	job.mutex.Lock()
+	job.waitedAt = job.now()
+	job.mutex.Unlock()
+}
+
+// logSlowQuery when job durations exceed thresholds of store, jobs which were not started are not logged
+func (job *Job) logSlowQuery() {
+	if job.store == nil {
+		return
+	}
+	thresholds := job.store.SlowQueryThresholds()
+	if thresholds.Wait == 0 && thresholds.Total == 0 {
+		return
+	}
+	finishedAt := job.now()
+	job.mutex.Lock()
+	startedAt, waitedAt := job.startedAt, job.waitedAt
+	queryText, processedBytes, jobErr := job.queryText, job.processedBytes, job.err
+	job.mutex.Unlock()
+	if startedAt.IsZero() {
+		return
+	}
+	// wait lasts until job is finished when BigQuery job did not finish
+	waitEnd := waitedAt
+	if waitEnd.IsZero() {
+		waitEnd = finishedAt
+	}
+	wait := waitEnd.Sub(startedAt)
+	total := finishedAt.Sub(job.createdAt)
+	if (thresholds.Wait == 0 || wait < thresholds.Wait) && (thresholds.Total == 0 || total < thresholds.Total) {
+		return
+	}
+	event := job.logger.Warn().
+		Str("queryHash", QueryHash(queryText)).
+		Str("reportID", job.ReportID).
+		Str("user", job.UserEmail).
+		Dur("start", startedAt.Sub(job.createdAt)).
+		Dur("wait", wait).
+		Dur("total", total).
+		Int64("processedBytes", processedBytes)
+	if !waitedAt.IsZero() {
+		event = event.Dur("read", finishedAt.Sub(waitedAt))
+	}
+	if jobErr != "" {
+		event = event.Str("jobError", jobErr)
+	}
+	event.Msg("Slow query")
+}
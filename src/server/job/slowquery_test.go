@@ -0,0 +1,102 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// fakeClock advanced by tests
+type fakeClock struct {
+	t     time.Time
+	mutex sync.Mutex
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mutex.Lock()
+	c.t = c.t.Add(d)
+	c.mutex.Unlock()
+}
+
+// runFakeJob through phases of BigQuery job with durations, returns captured log
+func runFakeJob(t *testing.T, store *Store, clock *fakeClock, wait time.Duration, read time.Duration) []map[string]interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	job := store.New(context.Background(), "report", "query")
+	job.logger = zerolog.New(&buf)
+	job.UserEmail = "a@example.com"
+	done := make(chan struct{})
+	go func() {
+		drainStatus(job)
+		close(done)
+	}()
+	clock.advance(time.Second)
+	job.mutex.Lock()
+	job.queryText = "select 1"
+	job.startedAt = job.now()
+	job.processedBytes = 100
+	job.mutex.Unlock()
+	clock.advance(wait)
+	job.markWaited()
+	clock.advance(read)
+	job.cancelWithError(fmt.Errorf("failed"))
+	<-done
+	var events []map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		event := map[string]interface{}{}
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatal(err)
+		}
+		if event["message"] == "Slow query" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestSlowQueryLogged(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1600000000, 0)}
+	store := NewStore()
+	store.now = clock.now
+	store.SetSlowQueryThresholds(SlowQueryThresholds{Wait: 10 * time.Second})
+
+	if events := runFakeJob(t, store, clock, 5*time.Second, time.Minute); len(events) != 0 {
+		t.Errorf("expected fast wait not logged, got %v", events)
+	}
+	events := runFakeJob(t, store, clock, 20*time.Second, 2*time.Second)
+	if len(events) != 1 {
+		t.Fatalf("expected slow query event, got %v", events)
+	}
+	event := events[0]
+	if event["level"] != "warn" || event["queryHash"] != QueryHash("select 1") || event["processedBytes"] != 100.0 || event["user"] != "a@example.com" {
+		t.Errorf("unexpected event %v", event)
+	}
+	// durations are logged in milliseconds
+	for field, expected := range map[string]float64{"start": 1000, "wait": 20000, "read": 2000, "total": 23000} {
+		if event[field] != expected {
+			t.Errorf("expected %s %v, got %v", field, expected, event[field])
+		}
+	}
+
+	store.SetSlowQueryThresholds(SlowQueryThresholds{Total: time.Minute})
+	if events := runFakeJob(t, store, clock, time.Second, time.Minute); len(events) != 1 {
+		t.Errorf("expected slow total logged, got %v", events)
+	}
+	store.SetSlowQueryThresholds(SlowQueryThresholds{})
+	if events := runFakeJob(t, store, clock, time.Hour, time.Hour); len(events) != 0 {
+		t.Errorf("expected disabled thresholds, got %v", events)
+	}
+}
@@ -0,0 +1,467 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/server/secrets"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/api/iterator"
+
+	// drivers of SQL connections included in dekart, other drivers are registered by building dekart
+	// with file importing them, e.g. ODBC or ADBC driver which needs cgo
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// sqlConnection of database/sql driver, dsn is secret reference because DSN contains credentials
+type sqlConnection struct {
+	driver string
+	dsn    string
+}
+
+// openedDB of connection with DSN it was opened with, DSN changes when secret is rotated
+type openedDB struct {
+	dsn string
+	db  *sql.DB
+}
+
+// SQLConnections of generic database/sql datasources by name; query of run with one of them as
+// RunOptions.Connection is run on the database instead of BigQuery. Type mapping of database/sql
+// is lossier than BigQuery: columns are typed by database type names reported by driver.
+type SQLConnections struct {
+	conns map[string]sqlConnection
+	mutex sync.Mutex
+	dbs   map[string]openedDB
+	// resolve DSN of secret reference, replaced in tests
+	resolve func(ctx context.Context, ref string) (string, error)
+}
+
+// parseSQLConnections of DEKART_SQL_CONNECTIONS, comma separated name=driver:dsn where dsn is secret reference
+// of DSN; names of BigQuery connections can't be reused
+func parseSQLConnections(value string, bigqueryConnections *Connections) (*SQLConnections, error) {
+	c := &SQLConnections{
+		conns:   map[string]sqlConnection{},
+		dbs:     map[string]openedDB{},
+		resolve: secrets.Resolve,
+	}
+	if strings.TrimSpace(value) == "" {
+		return c, nil
+	}
+	drivers := sql.Drivers()
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(entry), "=", 2)
+		if len(parts) != 2 || !connectionNameRe.MatchString(parts[0]) {
+			return nil, fmt.Errorf("invalid DEKART_SQL_CONNECTIONS entry %q, expected name=driver:dsn with name of letters, digits, _ and -", entry)
+		}
+		name := parts[0]
+		i := strings.Index(parts[1], ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid DEKART_SQL_CONNECTIONS entry of %s, expected name=driver:dsn", name)
+		}
+		driver, dsn := parts[1][:i], parts[1][i+1:]
+		if j := sort.SearchStrings(drivers, driver); j == len(drivers) || drivers[j] != driver {
+			return nil, fmt.Errorf("connection %s uses driver %s which is not registered, registered drivers are %s", name, driver, strings.Join(drivers, ", "))
+		}
+		if !secrets.IsReference(dsn) {
+			// DSN has credentials, it's never kept in env variables
+			return nil, fmt.Errorf("DSN of connection %s must be secret reference", name)
+		}
+		if _, ok := c.conns[name]; ok || bigqueryConnections.Has(name) {
+			return nil, fmt.Errorf("duplicate connection %s", name)
+		}
+		c.conns[name] = sqlConnection{driver: driver, dsn: dsn}
+	}
+	return c, nil
+}
+
+func sqlConnections(bigqueryConnections *Connections) *SQLConnections {
+	c, err := parseSQLConnections(os.Getenv("DEKART_SQL_CONNECTIONS"), bigqueryConnections)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return c
+}
+
+// Has SQL connection of name
+func (c *SQLConnections) Has(name string) bool {
+	if c == nil {
+		return false
+	}
+	_, ok := c.conns[name]
+	return ok
+}
+
+// open database of connection; database is opened once for each DSN, pool of rotated DSN is kept
+// for jobs which use it, but it keeps no idle connections
+func (c *SQLConnections) open(ctx context.Context, name string) (*sql.DB, string, error) {
+	conn, ok := c.conns[name]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown SQL connection %s, connections are configured with DEKART_SQL_CONNECTIONS", name)
+	}
+	dsn, err := c.resolve(ctx, conn.dsn)
+	if err != nil {
+		return nil, "", err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	opened, ok := c.dbs[name]
+	if ok && opened.dsn == dsn {
+		return opened.db, conn.driver, nil
+	}
+	db, err := sql.Open(conn.driver, dsn)
+	if err != nil {
+		// driver errors may quote DSN, so only driver is reported
+		return nil, "", fmt.Errorf("cannot open connection %s with driver %s", name, conn.driver)
+	}
+	if ok {
+		opened.db.SetMaxIdleConns(0)
+	}
+	c.dbs[name] = openedDB{dsn: dsn, db: db}
+	return db, conn.driver, nil
+}
+
+// SetSQLConnections of jobs created after call
+func (s *Store) SetSQLConnections(c *SQLConnections) {
+	s.mutex.Lock()
+	s.sqlConnections = c
+	s.mutex.Unlock()
+}
+
+// SQLConnection job was run on, empty for BigQuery job
+func (job *Job) SQLConnection() string {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.sqlConnection
+}
+
+// limitSQLQuery in exploration LIMIT; derived table has alias, which PostgreSQL and MySQL require
+func limitSQLQuery(queryText string, limit int64) (string, bool) {
+	limitedText, limited := LimitQuery(queryText, limit)
+	if !limited {
+		return queryText, false
+	}
+	if OrderedQuery(limitedText) {
+		// LIMIT follows ORDER BY of query, it's not wrapped
+		return limitedText, true
+	}
+	trimmed, _, err := trimStatement(queryText)
+	if err != nil {
+		return queryText, false
+	}
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) AS dekart_result LIMIT %d", trimmed, limit), true
+}
+
+// validateSQLOptions of run on SQL connection; sampling, destination tables, legacy SQL and parameters are BigQuery features
+func validateSQLOptions(options RunOptions, obj *storage.ObjectHandle) error {
+	switch {
+	case options.SampleRate > 0:
+		return fmt.Errorf("sampling is not supported by SQL connection %s", options.Connection)
+	case options.DestinationTable != "":
+		return fmt.Errorf("destination table is not supported by SQL connection %s", options.Connection)
+	case options.UseLegacySQL:
+		return fmt.Errorf("legacy SQL is not supported by SQL connection %s", options.Connection)
+	case len(options.Parameters) > 0:
+		return fmt.Errorf("query parameters are not supported by SQL connection %s", options.Connection)
+	case !options.AsOf.IsZero():
+		return fmt.Errorf("as_of is not supported by SQL connection %s", options.Connection)
+	case obj == nil:
+		return fmt.Errorf("result of SQL connection %s is written only to result file", options.Connection)
+	}
+	return nil
+}
+
+// runSQL of query text on SQL connection of options, rows are written to obj like rows of BigQuery result;
+// billing project and default dataset don't apply to databases
+func (job *Job) runSQL(queryText string, options RunOptions, obj *storage.ObjectHandle) error {
+	if err := validateSQLOptions(options, obj); err != nil {
+		return job.failStart(err)
+	}
+	sourceQueryText := queryText
+	var explorationLimit int64
+	queryText, limited := limitSQLQuery(queryText, options.ExplorationLimit)
+	if limited {
+		explorationLimit = options.ExplorationLimit
+	}
+	db, driver, err := job.sqlConnections.open(job.Ctx, options.Connection)
+	if err != nil {
+		return job.failStart(err)
+	}
+	hash := QueryHash(queryText)
+	ordered := job.orderedRun(sourceQueryText, queryText)
+	job.reread = func(ctx context.Context) (rowIterator, func() bigquery.Schema, error) {
+		return job.querySQL(ctx, db, queryText)
+	}
+	running := job.transition(StateRunning, true, func() {
+		job.storageObj = obj
+		job.resultFormat = options.ResultFormat
+		job.serialization = options.Serialization
+		job.compression = options.Compression
+		job.explorationLimit = explorationLimit
+		job.ordered = ordered
+		job.queryText = queryText
+		job.queryHash = hash
+		job.sourceQueryText = sourceQueryText
+		job.runOptions = options
+		job.sqlConnection = options.Connection
+		job.startedAt = job.now()
+	})
+	if !running {
+		return nil
+	}
+	job.logger.Info().
+		Str("connection", options.Connection).
+		Str("driver", driver).
+		Str("queryHash", hash).
+		Int64("explorationLimit", explorationLimit).
+		Msg("Job started")
+	go job.readSQL()
+	return nil
+}
+
+// readSQL result of query while database runs it; rows of database/sql are read with context of query,
+// so query and export share one budget and cancelling job cancels query in database
+func (job *Job) readSQL() {
+	ctx, cancel := job.startPhase(&TimeoutError{
+		Phase:    "SQL query",
+		Timeout:  job.timeouts.Query + job.timeouts.Export,
+		Variable: "DEKART_QUERY_TIMEOUT and DEKART_EXPORT_TIMEOUT",
+	})
+	defer cancel()
+	job.setPhase(PhaseReading)
+	it, schema, err := job.reread(ctx)
+	if err != nil {
+		if job.cancelled(err) {
+			job.finish()
+			return
+		}
+		job.cancelWithError(err)
+		return
+	}
+	job.markWaited()
+	if !job.transition(StateReading, true, nil) {
+		// cancelled while query was sent
+		return
+	}
+	writerCtx, abortUpload := context.WithCancel(ctx)
+	defer abortUpload()
+	w := job.newResultWriter(writerCtx)
+	job.startOutputs(writerCtx, schema)
+	job.writeResult(it, schema, w, func() {
+		abortUpload()
+		job.deleteResult()
+		job.discardOutputs()
+	})
+}
+
+// querySQL on db, iterator closes rows after last row; total rows of job are rows read, database doesn't report them
+func (job *Job) querySQL(ctx context.Context, db *sql.DB, queryText string) (rowIterator, func() bigquery.Schema, error) {
+	rows, err := db.QueryContext(ctx, queryText)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		rows.Close()
+		return nil, nil, err
+	}
+	schema, databaseTypes := sqlSchema(columns)
+	job.mutex.Lock()
+	job.databaseTypes = databaseTypes
+	job.mutex.Unlock()
+	it := &sqlIterator{rows: rows, schema: schema, done: func(read int64) {
+		job.mutex.Lock()
+		job.totalRows = read
+		job.mutex.Unlock()
+	}}
+	return it, func() bigquery.Schema { return schema }, nil
+}
+
+// sqlFieldTypes by database type name; types of PostgreSQL, MySQL and SQLite, other types are strings
+var sqlFieldTypes = map[string]bigquery.FieldType{
+	"INT":              bigquery.IntegerFieldType,
+	"INTEGER":          bigquery.IntegerFieldType,
+	"BIGINT":           bigquery.IntegerFieldType,
+	"SMALLINT":         bigquery.IntegerFieldType,
+	"MEDIUMINT":        bigquery.IntegerFieldType,
+	"TINYINT":          bigquery.IntegerFieldType,
+	"INT2":             bigquery.IntegerFieldType,
+	"INT4":             bigquery.IntegerFieldType,
+	"INT8":             bigquery.IntegerFieldType,
+	"SERIAL":           bigquery.IntegerFieldType,
+	"BIGSERIAL":        bigquery.IntegerFieldType,
+	"FLOAT":            bigquery.FloatFieldType,
+	"FLOAT4":           bigquery.FloatFieldType,
+	"FLOAT8":           bigquery.FloatFieldType,
+	"DOUBLE":           bigquery.FloatFieldType,
+	"DOUBLE PRECISION": bigquery.FloatFieldType,
+	"REAL":             bigquery.FloatFieldType,
+	// exact decimals are read as float64 and lose digits beyond 15-17
+	"NUMERIC":     bigquery.FloatFieldType,
+	"DECIMAL":     bigquery.FloatFieldType,
+	"BOOL":        bigquery.BooleanFieldType,
+	"BOOLEAN":     bigquery.BooleanFieldType,
+	"TIMESTAMPTZ": bigquery.TimestampFieldType,
+	// TIMESTAMP of MySQL is in UTC, TIMESTAMP of PostgreSQL has no time zone like DATETIME
+	"TIMESTAMP": bigquery.TimestampFieldType,
+	"DATETIME":  bigquery.DateTimeFieldType,
+	"DATE":      bigquery.DateFieldType,
+	"BYTEA":     bigquery.BytesFieldType,
+	"BLOB":      bigquery.BytesFieldType,
+	"BINARY":    bigquery.BytesFieldType,
+	"VARBINARY": bigquery.BytesFieldType,
+}
+
+// sqlFieldType of database type name reported by driver, e.g. UNSIGNED BIGINT or DECIMAL(10,2)
+func sqlFieldType(databaseType string) bigquery.FieldType {
+	name := strings.ToUpper(strings.TrimSpace(databaseType))
+	if i := strings.Index(name, "("); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	name = strings.TrimPrefix(name, "UNSIGNED ")
+	if fieldType, ok := sqlFieldTypes[name]; ok {
+		return fieldType
+	}
+	return bigquery.StringFieldType
+}
+
+// sqlSchema of columns and database type names reported by driver, which are recorded with result
+func sqlSchema(columns []*sql.ColumnType) (bigquery.Schema, []string) {
+	schema := make(bigquery.Schema, len(columns))
+	databaseTypes := make([]string, len(columns))
+	for i, column := range columns {
+		databaseTypes[i] = column.DatabaseTypeName()
+		schema[i] = &bigquery.FieldSchema{Name: column.Name(), Type: sqlFieldType(databaseTypes[i])}
+	}
+	return schema, databaseTypes
+}
+
+// sqlIterator of rows converted to values of BigQuery types of schema
+type sqlIterator struct {
+	rows   *sql.Rows
+	schema bigquery.Schema
+	read   int64
+	// done with number of rows read
+	done func(read int64)
+}
+
+func (it *sqlIterator) Next(dst interface{}) error {
+	if !it.rows.Next() {
+		err := it.rows.Err()
+		it.rows.Close()
+		if err != nil {
+			return err
+		}
+		it.done(it.read)
+		return iterator.Done
+	}
+	values := make([]interface{}, len(it.schema))
+	pointers := make([]interface{}, len(values))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	if err := it.rows.Scan(pointers...); err != nil {
+		it.rows.Close()
+		return err
+	}
+	row := make([]bigquery.Value, len(values))
+	for i, v := range values {
+		row[i] = sqlValue(v, it.schema[i].Type)
+	}
+	*(dst.(*[]bigquery.Value)) = row
+	it.read++
+	return nil
+}
+
+// sqlValue of driver value as value of field type; text which isn't valid value of type is kept as string
+func sqlValue(v interface{}, fieldType bigquery.FieldType) bigquery.Value {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if fieldType == bigquery.BytesFieldType {
+			return append([]byte(nil), value...)
+		}
+		// drivers return text of numbers, decimals and strings as bytes
+		return sqlText(string(value), fieldType)
+	case string:
+		return sqlText(value, fieldType)
+	case time.Time:
+		switch fieldType {
+		case bigquery.DateFieldType:
+			return civil.DateOf(value)
+		case bigquery.DateTimeFieldType:
+			return civil.DateTimeOf(value)
+		}
+		return value.UTC()
+	case int64:
+		switch fieldType {
+		case bigquery.FloatFieldType:
+			return float64(value)
+		case bigquery.BooleanFieldType:
+			// SQLite and MySQL store booleans as integers
+			return value != 0
+		}
+	case float32:
+		return float64(value)
+	}
+	if fieldType == bigquery.StringFieldType {
+		// column of unknown database type has values of any type
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+// sqlText of column parsed as value of field type
+func sqlText(text string, fieldType bigquery.FieldType) bigquery.Value {
+	switch fieldType {
+	case bigquery.IntegerFieldType:
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n
+		}
+	case bigquery.FloatFieldType:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	case bigquery.BooleanFieldType:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case bigquery.DateFieldType:
+		if d, err := civil.ParseDate(text); err == nil {
+			return d
+		}
+	case bigquery.DateTimeFieldType:
+		if dt, err := civil.ParseDateTime(strings.Replace(text, " ", "T", 1)); err == nil {
+			return dt
+		}
+	case bigquery.TimestampFieldType:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999"} {
+			if t, err := time.Parse(layout, text); err == nil {
+				return t.UTC()
+			}
+		}
+	}
+	return text
+}
+
+// MarshalDatabaseTypes of result record, empty for BigQuery result
+func MarshalDatabaseTypes(types []string) (string, error) {
+	return MarshalColumnTypes(types)
+}
+
+// UnmarshalDatabaseTypes of result record, nil for BigQuery result
+func UnmarshalDatabaseTypes(value string) ([]string, error) {
+	return UnmarshalColumnTypes(value)
+}
@@ -0,0 +1,137 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"fmt"
+)
+
+// JobState of job lifecycle; every job ends in exactly one of Done, Failed or Cancelled
+type JobState int32
+
+const (
+	// StatePending until BigQuery job is created
+	StatePending JobState = iota
+	// StateRunning while BigQuery job is running
+	StateRunning
+	// StateReading when BigQuery job is done and rows are written to result
+	StateReading
+	// StateDone when result is stored
+	StateDone
+	// StateFailed with error of job
+	StateFailed
+	// StateCancelled by user, timeout or other replica
+	StateCancelled
+	// StateSaving after last row is written until result is closed and stored
+	StateSaving
+)
+
+var stateNames = map[JobState]string{
+	StatePending:   "PENDING",
+	StateRunning:   "RUNNING",
+	StateReading:   "READING",
+	StateDone:      "DONE",
+	StateFailed:    "FAILED",
+	StateCancelled: "CANCELLED",
+	StateSaving:    "SAVING",
+}
+
+func (s JobState) String() string {
+	if name, ok := stateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("JobState(%d)", int32(s))
+}
+
+// transitions allowed from non terminal states
+var transitions = map[JobState][]JobState{
+	StatePending: {StateRunning, StateFailed, StateCancelled},
+	// running job with destination table is done without reading result
+	StateRunning: {StateReading, StateDone, StateFailed, StateCancelled},
+	StateReading: {StateSaving, StateFailed, StateCancelled},
+	StateSaving:  {StateDone, StateFailed, StateCancelled},
+}
+
+// Terminal state is final, job is finished
+func (s JobState) Terminal() bool {
+	return s == StateDone || s == StateFailed || s == StateCancelled
+}
+
+func (s JobState) canTransition(next JobState) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Status of state sent to clients, see CompatStatus for clients which only know running and done statuses
+func (s JobState) Status() int32 {
+	switch s {
+	case StatePending:
+		return int32(proto.Query_JOB_STATUS_PENDING)
+	case StateRunning:
+		return int32(proto.Query_JOB_STATUS_RUNNING)
+	case StateReading:
+		return int32(proto.Query_JOB_STATUS_READING_RESULTS)
+	case StateSaving:
+		return int32(proto.Query_JOB_STATUS_SAVING_RESULT)
+	case StateDone:
+		return int32(proto.Query_JOB_STATUS_DONE)
+	}
+	return int32(proto.Query_JOB_STATUS_UNSPECIFIED)
+}
+
+// CompatStatus of clients which don't know statuses of reading and saving result, job is running for them until done
+func CompatStatus(status proto.Query_JobStatus) proto.Query_JobStatus {
+	switch status {
+	case proto.Query_JOB_STATUS_READING_RESULTS, proto.Query_JOB_STATUS_SAVING_RESULT:
+		return proto.Query_JOB_STATUS_RUNNING
+	}
+	return status
+}
+
+// strictTransitions panics on invalid transitions from non terminal states, set in tests
+var strictTransitions = false
+
+// transition job to next state; apply is called under job mutex with the transition, so state,
+// error and result change together. Status of new state is sent to Status channel when notify is set.
+// Transitions and sends are serialized, so statuses are received in order of transitions.
+// Transitions from terminal state are expected when terminal paths race and are ignored.
+func (job *Job) transition(next JobState, notify bool, apply func()) bool {
+	job.transitionMutex.Lock()
+	defer job.transitionMutex.Unlock()
+	job.mutex.Lock()
+	current := job.state
+	allowed := current.canTransition(next)
+	if allowed {
+		job.state = next
+		if apply != nil {
+			apply()
+		}
+		job.appendEvent(EventState, next.String())
+	}
+	job.mutex.Unlock()
+	if !allowed {
+		if current.Terminal() {
+			job.logger.Debug().Str("state", current.String()).Str("next", next.String()).Msg("Job is finished, transition ignored")
+			return false
+		}
+		if strictTransitions {
+			panic(fmt.Sprintf("invalid job transition from %s to %s", current, next))
+		}
+		job.logger.Error().Str("state", current.String()).Str("next", next.String()).Msg("Invalid job transition ignored")
+		return false
+	}
+	if notify {
+		job.Status <- next.Status()
+	}
+	return true
+}
+
+// GetState of job
+func (job *Job) GetState() JobState {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	return job.state
+}
@@ -0,0 +1,227 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"fmt"
+	"math/rand"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// invalid transitions are bugs, fail tests instead of logging
+	strictTransitions = true
+	os.Exit(m.Run())
+}
+
+func TestStateTransitions(t *testing.T) {
+	for _, c := range []struct {
+		from, to JobState
+		allowed  bool
+	}{
+		{StatePending, StateRunning, true},
+		{StatePending, StateCancelled, true},
+		{StatePending, StateReading, false},
+		{StatePending, StateDone, false},
+		{StateRunning, StateReading, true},
+		{StateRunning, StateDone, true},
+		{StateReading, StateSaving, true},
+		{StateReading, StateDone, false},
+		{StateReading, StateRunning, false},
+		{StateSaving, StateDone, true},
+		{StateSaving, StateReading, false},
+		{StateDone, StateCancelled, false},
+		{StateCancelled, StateDone, false},
+		{StateFailed, StateRunning, false},
+	} {
+		if allowed := c.from.canTransition(c.to); allowed != c.allowed {
+			t.Errorf("%s to %s: expected allowed %t", c.from, c.to, c.allowed)
+		}
+	}
+	// finished job ignores transitions, terminal paths race by design
+	job := NewStore().New(context.Background(), "report", "query")
+	go drainStatus(job)
+	job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	if job.transition(StateRunning, true, nil) || job.GetState() != StateCancelled {
+		t.Errorf("expected transition of cancelled job ignored, got %s", job.GetState())
+	}
+}
+
+// statusSequenceRe of statuses received by subscriber: running, reading, saving, then single terminal status
+var statusSequenceRe = regexp.MustCompile(`^(2(5(6(3|0)?|0)?|0)?|0)?$`)
+
+// runEvent of job lifecycle; job path runs in order like job goroutine, others race with it
+type runEvent func(job *Job)
+
+var (
+	eventRun = func(job *Job) {
+		job.transition(StateRunning, true, func() { job.startedAt = job.now() })
+	}
+	eventRead = func(job *Job) {
+		job.transition(StateReading, true, nil)
+	}
+	eventSave = func(job *Job) {
+		job.transition(StateSaving, true, nil)
+	}
+	eventDone = func(job *Job) {
+		job.transition(StateDone, true, func() { job.resultID = &job.ID })
+		job.finish()
+	}
+	eventFail = func(job *Job) {
+		job.cancelWithError(fmt.Errorf("failed"))
+	}
+	eventCancel = func(job *Job) {
+		job.store.CancelJob(job.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+	}
+)
+
+// checkInvariants of finished job and statuses received by subscriber
+func checkInvariants(t *testing.T, name string, job *Job, statuses []int32, removed int) {
+	t.Helper()
+	snapshot := job.GetStatus()
+	if !snapshot.State.Terminal() {
+		t.Errorf("%s: expected terminal state, got %s", name, snapshot.State)
+	}
+	if (snapshot.ResultID != nil) != (snapshot.State == StateDone) {
+		t.Errorf("%s: result %v in state %s", name, snapshot.ResultID, snapshot.State)
+	}
+	if snapshot.Err != "" && snapshot.State != StateFailed {
+		t.Errorf("%s: error %q in state %s", name, snapshot.Err, snapshot.State)
+	}
+	sequence := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(statuses)), ""), "[]")
+	if !statusSequenceRe.MatchString(sequence) {
+		t.Errorf("%s: invalid status sequence %v", name, statuses)
+	}
+	if last := len(statuses) - 1; last >= 0 && snapshot.Status != statuses[last] {
+		t.Errorf("%s: final status %d, last sent %d", name, snapshot.Status, statuses[last])
+	}
+	if removed != 1 {
+		t.Errorf("%s: job removed %d times", name, removed)
+	}
+}
+
+func TestStateRandomOrderings(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		registry := &removeCounter{fakeRegistry: newFakeRegistry(), removed: make(map[string]int)}
+		store := NewStore()
+		store.registry = registry
+		job := store.New(context.Background(), "report", "query")
+		statuses := collectStatus(job)
+
+		// job path stops at random step with error or done, external events are merged at random positions
+		path := []runEvent{eventRun, eventRead, eventSave, eventDone}[:rnd.Intn(5)]
+		if len(path) < 4 && rnd.Intn(2) == 0 {
+			path = append(path, eventFail)
+		}
+		external := []runEvent{eventCancel}
+		if rnd.Intn(2) == 0 {
+			external = append(external, eventFail)
+		}
+		var events []runEvent
+		for len(path) > 0 || len(external) > 0 {
+			if len(external) == 0 || (len(path) > 0 && rnd.Intn(2) == 0) {
+				events, path = append(events, path[0]), path[1:]
+			} else {
+				events, external = append(events, external[0]), external[1:]
+			}
+		}
+		for _, event := range events {
+			event(job)
+		}
+		checkInvariants(t, fmt.Sprintf("ordering %d", i), job, <-statuses, registry.removed[job.ID])
+	}
+}
+
+func TestStateConcurrentEvents(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		registry := &removeCounter{fakeRegistry: newFakeRegistry(), removed: make(map[string]int)}
+		store := NewStore()
+		store.registry = registry
+		job := store.New(context.Background(), "report", "query")
+		statuses := collectStatus(job)
+
+		// result is only visible in done state while transitions race
+		stop := make(chan struct{})
+		var violations int
+		var watcher sync.WaitGroup
+		watcher.Add(1)
+		go func() {
+			defer watcher.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if snapshot := job.GetStatus(); snapshot.ResultID != nil && snapshot.State != StateDone {
+					violations++
+				}
+				runtime.Gosched()
+			}
+		}()
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for _, event := range []runEvent{eventRun, eventRead, eventSave, eventDone} {
+				event(job)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			eventCancel(job)
+		}()
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				eventFail(job)
+			}
+		}()
+		wg.Wait()
+		close(stop)
+		watcher.Wait()
+		if violations > 0 {
+			t.Errorf("run %d: result visible outside done state %d times", i, violations)
+		}
+		registry.mutex.Lock()
+		removed := registry.removed[job.ID]
+		registry.mutex.Unlock()
+		checkInvariants(t, fmt.Sprintf("run %d", i), job, <-statuses, removed)
+		if t.Failed() {
+			return
+		}
+	}
+}
+
+func TestStoreStatsByState(t *testing.T) {
+	store := NewStore()
+	job := store.New(context.Background(), "report", "query")
+	go drainStatus(job)
+	eventRun(job)
+	eventRead(job)
+	if stats := store.Stats(); stats.JobsByStatus[proto.Query_JOB_STATUS_READING_RESULTS.String()] != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	job.cancelWithReason(proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+}
+
+func TestCompatStatus(t *testing.T) {
+	for status, expected := range map[proto.Query_JobStatus]proto.Query_JobStatus{
+		proto.Query_JOB_STATUS_PENDING:         proto.Query_JOB_STATUS_PENDING,
+		proto.Query_JOB_STATUS_RUNNING:         proto.Query_JOB_STATUS_RUNNING,
+		proto.Query_JOB_STATUS_READING_RESULTS: proto.Query_JOB_STATUS_RUNNING,
+		proto.Query_JOB_STATUS_SAVING_RESULT:   proto.Query_JOB_STATUS_RUNNING,
+		proto.Query_JOB_STATUS_DONE:            proto.Query_JOB_STATUS_DONE,
+	} {
+		if compat := CompatStatus(status); compat != expected {
+			t.Errorf("%s: expected %s, got %s", status, expected, compat)
+		}
+	}
+}
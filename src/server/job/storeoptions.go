@@ -0,0 +1,60 @@
+package job
+
+import (
+	"dekart/src/proto"
+
+	"cloud.google.com/go/storage"
+)
+
+// StoreOption configures store created with NewStore; options override settings read from environment
+type StoreOption func(s *Store)
+
+// WithTimeout of query and export phases of jobs, zero timeout is unlimited
+func WithTimeout(t Timeouts) StoreOption {
+	return func(s *Store) {
+		s.timeouts = t
+	}
+}
+
+// WithStorage bucket of result objects; large results are uploaded in parallel components and extracted
+// to this bucket when their thresholds are set
+func WithStorage(bucket *storage.BucketHandle) StoreOption {
+	return func(s *Store) {
+		s.bucket = bucket
+	}
+}
+
+// WithRunner creating BigQuery clients of jobs, bigquery.NewClient by default
+func WithRunner(runner Runner) StoreOption {
+	return func(s *Store) {
+		s.runner = runner
+	}
+}
+
+// WithFormat of result file and its compression, used by runs which don't set them in RunOptions
+func WithFormat(format proto.ResultFormat, compression proto.ResultCompression) StoreOption {
+	return func(s *Store) {
+		s.format = format
+		s.compression = compression
+	}
+}
+
+// WithProjects of BigQuery jobs instead of DEKART_BIGQUERY_* environment
+func WithProjects(projects Projects) StoreOption {
+	return func(s *Store) {
+		s.projects = projects
+	}
+}
+
+// applyDefaults of store to options of run
+func (job *Job) applyDefaults(options RunOptions) RunOptions {
+	if options.ResultFormat == proto.ResultFormat_RESULT_FORMAT_UNSPECIFIED {
+		options.ResultFormat = job.defaultFormat
+	}
+	if options.Compression == proto.ResultCompression_RESULT_COMPRESSION_UNSPECIFIED {
+		options.Compression = job.defaultCompression
+	}
+	// destination table without project is in data project of store
+	options.DestinationTable = job.projects.qualifyTable(options.DestinationTable)
+	return options
+}
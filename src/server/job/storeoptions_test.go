@@ -0,0 +1,86 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+func TestStoreOptionsOverrideEnv(t *testing.T) {
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "env-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	var project string
+	store := NewStore(
+		WithProjects(Projects{Data: "data-project", Billing: "billing-project", DefaultDataset: "dataset"}),
+		WithTimeout(Timeouts{Query: time.Minute}),
+		WithFormat(proto.ResultFormat_RESULT_FORMAT_PARQUET, proto.ResultCompression_RESULT_COMPRESSION_GZIP),
+		WithRunner(func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+			project = projectID
+			return nil, fmt.Errorf("no client in test")
+		}),
+	)
+	// environment changed after store was created is not read by jobs
+	os.Setenv("DEKART_BIGQUERY_BILLING_PROJECT_ID", "env-billing")
+	defer os.Unsetenv("DEKART_BIGQUERY_BILLING_PROJECT_ID")
+	job := store.New(context.Background(), "report", "query")
+	if err := job.Run("select 1", RunOptions{}, nil); err == nil {
+		t.Fatal("expected client error")
+	}
+	if project != "billing-project" {
+		t.Errorf("expected client for billing-project, got %s", project)
+	}
+	if job.timeouts.Query != time.Minute {
+		t.Errorf("expected query timeout of option, got %s", job.timeouts.Query)
+	}
+	if dataset := job.projects.defaultDataset(""); dataset != "data-project.dataset" {
+		t.Errorf("expected dataset in data project, got %s", dataset)
+	}
+	options := job.applyDefaults(RunOptions{Compression: proto.ResultCompression_RESULT_COMPRESSION_NONE})
+	if options.ResultFormat != proto.ResultFormat_RESULT_FORMAT_PARQUET || options.Compression != proto.ResultCompression_RESULT_COMPRESSION_NONE {
+		t.Errorf("expected default format and compression of run, got %s %s", options.ResultFormat, options.Compression)
+	}
+}
+
+func TestWaitSummary(t *testing.T) {
+	job := readingJob(NewStore())
+	job.totalRows = 3
+	done := make(chan JobSummary)
+	go func() { done <- job.Wait() }()
+	job.writeResult(newFakeIterator(3), fakeSchema, &fakeResultWriter{}, func() { t.Error("unexpected cleanup") })
+	summary := <-done
+	if summary.State != StateDone || summary.RowsWritten != 3 || summary.ResultID == "" || !summary.FinishedAt.IsZero() {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+}
+
+func TestWaitBeforeRun(t *testing.T) {
+	// BigQuery job keeps running, so job sends running status from Run and fails on query timeout
+	server, _ := fakeJobsEndpoint(t)
+	store := NewStore(
+		WithProjects(Projects{Data: "data-project"}),
+		WithTimeout(Timeouts{Query: 50 * time.Millisecond, Export: time.Minute}),
+		WithRunner(func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+			return bigquery.NewClient(ctx, projectID, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+		}),
+	)
+	job := store.New(context.Background(), "report", "query")
+	done := make(chan JobSummary)
+	go func() { done <- job.Wait() }()
+	if err := job.Run("select * from points", RunOptions{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case summary := <-done:
+		if summary.State != StateFailed {
+			t.Errorf("expected failed job, got %+v", summary)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("job didn't finish")
+	}
+}
@@ -0,0 +1,96 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"time"
+)
+
+// JobSummary of job passed to hooks and returned by Wait; it's copy of job, its readers don't lock job
+type JobSummary struct {
+	JobID     string
+	QueryID   string
+	ReportID  string
+	UserEmail string
+	// BatchID of RunAllQueries, empty for single run
+	BatchID string
+	// State is Done, Failed or Cancelled
+	State JobState
+	Err   string
+	// CancelReason of cancelled job, unspecified for other states
+	CancelReason   proto.CancelReason
+	TotalRows      int64
+	RowsWritten    int64
+	BytesProcessed int64
+	BytesBilled    int64
+	CacheHit       bool
+	ResultSize     int64
+	// ResultID of result file, H3ResultID of aggregated result and ResultTable of destination table, empty without them
+	ResultID    string
+	H3ResultID  string
+	ResultTable string
+	// ResultFormat of ResultID
+	ResultFormat  proto.ResultFormat
+	FormatResults []FormatResult
+	// Warnings of job in order of first occurrence
+	Warnings []Warning
+	// StartedAt when BigQuery job was created, zero when job failed before; FinishedAt when hooks of job ran
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// summary of job finished at finishedAt
+func (job *Job) summary(finishedAt time.Time) JobSummary {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	summary := JobSummary{
+		JobID:          job.ID,
+		QueryID:        job.QueryID,
+		ReportID:       job.ReportID,
+		UserEmail:      job.UserEmail,
+		BatchID:        job.BatchID,
+		State:          job.state,
+		Err:            job.err,
+		CancelReason:   job.cancelReason,
+		TotalRows:      job.totalRows,
+		RowsWritten:    job.rowsWritten,
+		BytesProcessed: job.processedBytes,
+		BytesBilled:    job.bytesBilled,
+		CacheHit:       job.cacheHit,
+		ResultSize:     job.resultSize,
+		ResultFormat:   job.resultFormat,
+		FormatResults:  append([]FormatResult(nil), job.formatResults...),
+		Warnings:       append([]Warning(nil), job.warnings...),
+		CreatedAt:      job.createdAt,
+		StartedAt:      job.startedAt,
+		FinishedAt:     finishedAt,
+	}
+	if job.resultID != nil {
+		summary.ResultID = *job.resultID
+		if job.h3 != nil {
+			summary.H3ResultID = job.h3ResultID
+		}
+	}
+	if job.resultTable != nil {
+		summary.ResultTable = *job.resultTable
+	}
+	return summary
+}
+
+// Summary of job, fields are read together like GetStatus; FinishedAt is zero, it's set for hooks
+func (job *Job) Summary() JobSummary {
+	return job.summary(time.Time{})
+}
+
+// Wait until job started by Run is finished and return its summary. Wait receives statuses of job,
+// so it must not be called when other goroutine receives from Status; Run blocks until its first status
+// is received, so Wait is started in own goroutine before Run
+func (job *Job) Wait() JobSummary {
+	for {
+		select {
+		case <-job.Status:
+		case <-job.Ctx.Done():
+			return job.Summary()
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package job
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlToken of query code; identifiers are words or backtick quoted names, other tokens are single characters.
+// end is offset after token in code, so text can be inserted after it
+type sqlToken struct {
+	text   string
+	quoted bool
+	end    int
+}
+
+// word is case insensitive keyword or identifier which is not quoted
+func (t sqlToken) word(keyword string) bool {
+	return !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+func (t sqlToken) identifier() bool {
+	if t.quoted {
+		return true
+	}
+	c := t.text[0]
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// tokenize code of sqlCode; literals are skipped, dashes between word characters are kept in word as in project names
+func tokenize(code string) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(code); {
+		c := code[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case c == '`':
+			end := strings.IndexByte(code[i+1:], '`')
+			closed := i + end + 2
+			if end < 0 {
+				end = len(code) - i - 1
+				closed = len(code)
+			}
+			tokens = append(tokens, sqlToken{text: code[i+1 : i+1+end], quoted: true, end: closed})
+			i += end + 2
+		case c == '\'' || c == '"':
+			// literal is masked, so it has no quotes inside
+			end := strings.IndexByte(code[i+1:], c)
+			if end < 0 {
+				end = len(code) - i - 1
+			}
+			i += end + 2
+		case isWordByte(c):
+			j := i + 1
+			for j < len(code) && (isWordByte(code[j]) || code[j] == '-' && j+1 < len(code) && isWordByte(code[j+1])) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{text: code[i:j], end: j})
+			i = j
+		default:
+			tokens = append(tokens, sqlToken{text: code[i : i+1], end: i + 1})
+			i++
+		}
+	}
+	return tokens
+}
+
+// aliasStop are keywords which can follow table name, they are not alias
+var aliasStop = map[string]bool{
+	"WHERE": true, "JOIN": true, "LEFT": true, "RIGHT": true, "INNER": true, "FULL": true, "CROSS": true,
+	"OUTER": true, "ON": true, "USING": true, "GROUP": true, "ORDER": true, "LIMIT": true, "UNION": true,
+	"WINDOW": true, "HAVING": true, "QUALIFY": true, "FOR": true, "TABLESAMPLE": true, "EXCEPT": true,
+	"INTERSECT": true, "WITH": true, "PIVOT": true, "UNPIVOT": true, "SELECT": true, "FROM": true, "SET": true,
+}
+
+// closing index of parenthesis opened at i, or last token when it's not closed
+func closing(tokens []sqlToken, i int) int {
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch {
+		case tokens[j].text == "(" && !tokens[j].quoted:
+			depth++
+		case tokens[j].text == ")" && !tokens[j].quoted:
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// cteNames of WITH clauses, names are upper case
+func cteNames(tokens []sqlToken) map[string]bool {
+	names := map[string]bool{}
+	for i := range tokens {
+		if !tokens[i].word("WITH") {
+			continue
+		}
+		j := i + 1
+		if j < len(tokens) && tokens[j].word("RECURSIVE") {
+			j++
+		}
+		// name AS ( ... ), name AS ( ... )
+		for j+2 < len(tokens) && tokens[j].identifier() && tokens[j+1].word("AS") && tokens[j+2].text == "(" {
+			names[strings.ToUpper(tokens[j].text)] = true
+			end := closing(tokens, j+2)
+			if end+1 >= len(tokens) || tokens[end+1].text != "," {
+				break
+			}
+			j = end + 2
+		}
+	}
+	return names
+}
+
+// tablePath of dotted identifiers at i; returns name and index after it, name is empty when there is no table
+func tablePath(tokens []sqlToken, i int) (string, int) {
+	var parts []string
+	for i < len(tokens) && tokens[i].identifier() {
+		parts = append(parts, tokens[i].text)
+		i++
+		// wildcard table ends with *
+		if i < len(tokens) && tokens[i].text == "*" {
+			parts[len(parts)-1] += "*"
+			i++
+			break
+		}
+		if i+1 >= len(tokens) || tokens[i].text != "." {
+			break
+		}
+		i++
+	}
+	return strings.Join(parts, "."), i
+}
+
+// tableReference in FROM or JOIN clause; end is offset in query text after name and alias of table
+type tableReference struct {
+	name string
+	end  int
+}
+
+// TableReferences in FROM and JOIN clauses of query text, names as written without backticks in order of first reference.
+// Lexer is tolerant: CTE names, subqueries, UNNEST and paths of aliases are excluded, invalid query has no references
+func TableReferences(queryText string) []string {
+	references, err := tableReferences(queryText)
+	if err != nil {
+		return nil
+	}
+	var tables []string
+	seen := map[string]bool{}
+	for _, r := range references {
+		if !seen[r.name] {
+			seen[r.name] = true
+			tables = append(tables, r.name)
+		}
+	}
+	return tables
+}
+
+// tableReferences of query text in order they are written, table referenced twice is listed twice
+func tableReferences(queryText string) ([]tableReference, error) {
+	code, err := sqlCode(queryText)
+	if err != nil {
+		return nil, err
+	}
+	tokens := tokenize(code)
+	ctes := cteNames(tokens)
+	aliases := map[string]bool{}
+	var references []tableReference
+	// opened keeps token before each open parenthesis, FROM inside EXTRACT( is not clause
+	var opened []sqlToken
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if !t.quoted && t.text == "(" {
+			before := sqlToken{text: "("}
+			if i > 0 {
+				before = tokens[i-1]
+			}
+			opened = append(opened, before)
+			continue
+		}
+		if !t.quoted && t.text == ")" && len(opened) > 0 {
+			opened = opened[:len(opened)-1]
+			continue
+		}
+		if !t.word("FROM") && !t.word("JOIN") {
+			continue
+		}
+		if t.word("FROM") && (i > 0 && tokens[i-1].word("DISTINCT") || len(opened) > 0 && opened[len(opened)-1].word("EXTRACT")) {
+			continue
+		}
+		// comma join lists tables after FROM
+		for j := i + 1; j < len(tokens); {
+			if tokens[j].word("UNNEST") || !tokens[j].identifier() {
+				break
+			}
+			name, next := tablePath(tokens, j)
+			// CTE is referenced by name, alias by path to array field
+			parts := strings.SplitN(strings.ToUpper(name), ".", 2)
+			table := !(len(parts) == 1 && ctes[parts[0]]) && !(len(parts) == 2 && aliases[parts[0]])
+			j = next
+			// alias of table, path starting with it is array of table row
+			if j < len(tokens) && tokens[j].word("AS") {
+				j++
+			}
+			if j < len(tokens) && tokens[j].identifier() && !aliasStop[strings.ToUpper(tokens[j].text)] {
+				aliases[strings.ToUpper(tokens[j].text)] = true
+				j++
+			}
+			if table {
+				references = append(references, tableReference{name: name, end: tokens[j-1].end})
+			}
+			if j >= len(tokens) || tokens[j].text != "," {
+				break
+			}
+			j++
+		}
+	}
+	return references, nil
+}
+
+// QualifyTable reference as project.dataset.table; dataset.table is in data project of store,
+// table is in default dataset of report or store
+func (s *Store) QualifyTable(name string, defaultDataset string) (string, error) {
+	if strings.Contains(name, "*") {
+		return "", fmt.Errorf("wildcard table %s is not supported", name)
+	}
+	switch strings.Count(name, ".") {
+	case 0:
+		dataset := s.projects.defaultDataset(defaultDataset)
+		if dataset == "" {
+			return "", fmt.Errorf("table %s has no dataset and default dataset is not set", name)
+		}
+		name = dataset + "." + name
+	case 1:
+		name = s.projects.qualifyTable(name)
+	}
+	if _, _, _, err := parseTable(name); err != nil {
+		return "", fmt.Errorf("invalid table %s, expected project.dataset.table, dataset.table or table", name)
+	}
+	return name, nil
+}
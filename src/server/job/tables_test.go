@@ -0,0 +1,108 @@
+package job
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTableReferences(t *testing.T) {
+	for name, c := range map[string]struct {
+		query    string
+		expected []string
+	}{
+		"qualified": {
+			"select * from project.dataset.points", []string{"project.dataset.points"},
+		},
+		"backticks": {
+			"SELECT * FROM `bigquery-public-data.new_york.tlc_yellow_trips_2015` t", []string{"bigquery-public-data.new_york.tlc_yellow_trips_2015"},
+		},
+		"partial backticks": {
+			"select * from `my-project`.dataset.`points`", []string{"my-project.dataset.points"},
+		},
+		"project with dashes": {
+			"select * from my-project.dataset.points", []string{"my-project.dataset.points"},
+		},
+		"unqualified and dataset": {
+			"select * from points join zones.berlin on true", []string{"points", "zones.berlin"},
+		},
+		"joins in order of first reference": {
+			"select * from a.t1 left outer join a.t2 using (id) inner join a.t1 x on x.id = t2.id cross join b.t3",
+			[]string{"a.t1", "a.t2", "b.t3"},
+		},
+		"comma join": {
+			"select * from a.t1 as x, a.t2 y, a.t3 where x.id = y.id", []string{"a.t1", "a.t2", "a.t3"},
+		},
+		"cte names are excluded": {
+			"WITH p AS (SELECT * FROM data.points), recent AS (SELECT * FROM p WHERE day > '2021')\nSELECT * FROM recent JOIN data.zones ON true",
+			[]string{"data.points", "data.zones"},
+		},
+		"dataset named as cte": {
+			"with data as (select 1) select * from data join data.points on true", []string{"data.points"},
+		},
+		"recursive cte": {
+			"with recursive tree as (select * from data.nodes union all select * from tree) select * from tree",
+			[]string{"data.nodes"},
+		},
+		"subquery": {
+			"select * from (select * from data.points) join (select 1) on true", []string{"data.points"},
+		},
+		"unnest and alias paths": {
+			"select * from data.trips t, unnest(t.stops) s, t.tags left join unnest([1]) on true", []string{"data.trips"},
+		},
+		"comments": {
+			"-- from data.old\nselect * /* from data.hidden */ from data.points # join data.other",
+			[]string{"data.points"},
+		},
+		"literals": {
+			"select 'from data.fake' from data.points where name = \"join data.fake2\"", []string{"data.points"},
+		},
+		"extract": {
+			"select extract(day from created_at) from data.trips", []string{"data.trips"},
+		},
+		"is distinct from": {
+			"select * from data.a where x is distinct from y", []string{"data.a"},
+		},
+		"wildcard": {
+			"select * from data.events_* where _table_suffix > '1'", []string{"data.events_*"},
+		},
+		"case insensitive": {
+			"SeLeCt * FrOm Data.Points", []string{"Data.Points"},
+		},
+		"sampled": {
+			"select * from data.points tablesample system (1 percent)", []string{"data.points"},
+		},
+		"no tables": {
+			"select 1", nil,
+		},
+		"unterminated comment": {
+			"select * from data.points /*", nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tables := TableReferences(c.query); !reflect.DeepEqual(tables, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, tables)
+			}
+		})
+	}
+}
+
+func TestQualifyTable(t *testing.T) {
+	store := NewStore(WithProjects(Projects{Data: "data-project"}))
+	for name, expected := range map[[2]string]string{
+		{"p.d.t", ""}:           "p.d.t",
+		{"d.t", ""}:             "data-project.d.t",
+		{"t", "d"}:              "data-project.d.t",
+		{"t", "other.d"}:        "other.d.t",
+		{"my-project.d.t", "e"}: "my-project.d.t",
+	} {
+		table, err := store.QualifyTable(name[0], name[1])
+		if err != nil || table != expected {
+			t.Errorf("%v: expected %s, got %s %v", name, expected, table, err)
+		}
+	}
+	for _, name := range []string{"t", "d.events_*", "a.b.c.d", "d..t"} {
+		if table, err := store.QualifyTable(name, ""); err == nil {
+			t.Errorf("%s: expected error, got %s", name, table)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultTimeout of each phase of job
+const defaultTimeout = 10 * time.Minute
+
+// Timeouts of job phases, each phase has own budget
+type Timeouts struct {
+	// Query execution, while BigQuery job runs
+	Query time.Duration
+	// Export of result, from start of reading result until result is stored
+	Export time.Duration
+}
+
+// TimeoutError when phase of job ran out of time
+type TimeoutError struct {
+	Phase   string
+	Timeout time.Duration
+	// Variable configuring timeout of phase
+	Variable string
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s, see %s", e.Phase, e.Timeout, e.Variable)
+}
+
+// parseTimeout of env variable as positive duration, empty is default timeout
+func parseTimeout(name string, value string) (time.Duration, error) {
+	if value == "" {
+		return defaultTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s %s, expected positive duration, e.g. 30m", name, value)
+	}
+	return timeout, nil
+}
+
+// parseTimeouts of DEKART_QUERY_TIMEOUT and DEKART_EXPORT_TIMEOUT
+func parseTimeouts(query string, export string) (Timeouts, error) {
+	queryTimeout, err := parseTimeout("DEKART_QUERY_TIMEOUT", query)
+	if err != nil {
+		return Timeouts{}, err
+	}
+	exportTimeout, err := parseTimeout("DEKART_EXPORT_TIMEOUT", export)
+	if err != nil {
+		return Timeouts{}, err
+	}
+	return Timeouts{Query: queryTimeout, Export: exportTimeout}, nil
+}
+
+func timeouts() Timeouts {
+	t, err := parseTimeouts(os.Getenv("DEKART_QUERY_TIMEOUT"), os.Getenv("DEKART_EXPORT_TIMEOUT"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return t
+}
+
+// SetTimeouts of jobs created after call
+func (s *Store) SetTimeouts(t Timeouts) {
+	s.mutex.Lock()
+	s.timeouts = t
+	s.mutex.Unlock()
+}
+
+// phaseBudget of job, context derived from job context with deadline of phase
+type phaseBudget struct {
+	ctx     context.Context
+	timeout *TimeoutError
+}
+
+// startPhase with own deadline; cancelling job context cancels phase, deadline of phase doesn't cancel job
+func (job *Job) startPhase(timeout *TimeoutError) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(job.Ctx, timeout.Timeout)
+	job.mutex.Lock()
+	job.budget = &phaseBudget{ctx: ctx, timeout: timeout}
+	job.mutex.Unlock()
+	return ctx, cancel
+}
+
+func (job *Job) startQueryPhase() (context.Context, context.CancelFunc) {
+	return job.startPhase(&TimeoutError{Phase: "query execution", Timeout: job.timeouts.Query, Variable: "DEKART_QUERY_TIMEOUT"})
+}
+
+func (job *Job) startExportPhase() (context.Context, context.CancelFunc) {
+	return job.startPhase(&TimeoutError{Phase: "result export", Timeout: job.timeouts.Export, Variable: "DEKART_EXPORT_TIMEOUT"})
+}
+
+// phaseContext of running phase, job context when no phase is started
+func (job *Job) phaseContext() context.Context {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.budget == nil {
+		return job.Ctx
+	}
+	return job.budget.ctx
+}
+
+// timeoutError of phase which ran out of time replaces err caused by its deadline
+func (job *Job) timeoutError(err error) error {
+	job.mutex.Lock()
+	budget := job.budget
+	job.mutex.Unlock()
+	if budget == nil || budget.ctx.Err() != context.DeadlineExceeded || job.Ctx.Err() != nil {
+		return err
+	}
+	return budget.timeout
+}
@@ -0,0 +1,105 @@
+package job
+
+import (
+	"context"
+	"dekart/src/proto"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	timeouts, err := parseTimeouts("", "")
+	if err != nil || timeouts.Query != defaultTimeout || timeouts.Export != defaultTimeout {
+		t.Errorf("expected default timeouts, got %+v %v", timeouts, err)
+	}
+	timeouts, err = parseTimeouts("30m", "1h")
+	if err != nil || timeouts.Query != 30*time.Minute || timeouts.Export != time.Hour {
+		t.Errorf("expected configured timeouts, got %+v %v", timeouts, err)
+	}
+	for _, c := range [][2]string{{"10", ""}, {"", "-1m"}, {"0s", ""}} {
+		if _, err := parseTimeouts(c[0], c[1]); err == nil {
+			t.Errorf("expected error of %q", c)
+		}
+	}
+}
+
+// slowResultWriter takes delay for each write, write is aborted when context of upload is done
+type slowResultWriter struct {
+	fakeResultWriter
+	ctx   context.Context
+	delay time.Duration
+}
+
+func (w *slowResultWriter) Write(p []byte) (int, error) {
+	select {
+	case <-time.After(w.delay):
+		return w.fakeResultWriter.Write(p)
+	case <-w.ctx.Done():
+		return 0, w.ctx.Err()
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	// BigQuery job keeps running
+	server, _ := fakeJobsEndpoint(t)
+	newClient := newBigqueryClient
+	defer func() { newBigqueryClient = newClient }()
+	newBigqueryClient = func(ctx context.Context, projectID string, opts ...option.ClientOption) (*bigquery.Client, error) {
+		return bigquery.NewClient(ctx, projectID, option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	}
+	os.Setenv("DEKART_BIGQUERY_PROJECT_ID", "data-project")
+	defer os.Unsetenv("DEKART_BIGQUERY_PROJECT_ID")
+	store := NewStore()
+	store.SetTimeouts(Timeouts{Query: 50 * time.Millisecond, Export: time.Minute})
+	job := store.New(context.Background(), "report", "query")
+	statuses := collectStatus(job)
+	if err := job.Run("select * from points", RunOptions{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if s := <-statuses; len(s) != 2 || s[0] != int32(proto.Query_JOB_STATUS_RUNNING) || s[1] != 0 {
+		t.Errorf("expected failed status, got %v", s)
+	}
+	if !strings.HasPrefix(job.Err(), "query execution timed out after 50ms") || !strings.Contains(job.Err(), "DEKART_QUERY_TIMEOUT") {
+		t.Errorf("expected query timeout, got %q", job.Err())
+	}
+}
+
+func TestExportTimeout(t *testing.T) {
+	store := NewStore()
+	// export takes longer than query timeout
+	store.SetTimeouts(Timeouts{Query: 10 * time.Millisecond, Export: time.Minute})
+	job := readingJob(store)
+	job.totalRows = 10
+	statuses := collectStatus(job)
+	ctx, cancel := job.startExportPhase()
+	defer cancel()
+	w := &slowResultWriter{ctx: ctx, delay: 50 * time.Millisecond}
+	job.writeResult(newFakeIterator(10), fakeSchema, w, func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Errorf("expected export within its own timeout, got %v %q", s, job.Err())
+	}
+
+	store.SetTimeouts(Timeouts{Query: time.Minute, Export: 20 * time.Millisecond})
+	job = readingJob(store)
+	job.totalRows = 10000
+	statuses = collectStatus(job)
+	ctx, cancel = job.startExportPhase()
+	defer cancel()
+	w = &slowResultWriter{ctx: ctx, delay: 10 * time.Millisecond}
+	cleaned := false
+	job.writeResult(newFakeIterator(10000), fakeSchema, w, func() { cleaned = true })
+	if s := <-statuses; len(s) != 1 || s[0] != 0 {
+		t.Errorf("expected failed status, got %v", s)
+	}
+	if !strings.HasPrefix(job.Err(), "result export timed out after 20ms") || !strings.Contains(job.Err(), "DEKART_EXPORT_TIMEOUT") {
+		t.Errorf("expected export timeout, got %q", job.Err())
+	}
+	if !cleaned {
+		t.Error("expected partial result to be cleaned up")
+	}
+}
@@ -0,0 +1,141 @@
+package job
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TimeTravelUnsupported is reason of error when query can't be run as of snapshot timestamp
+const TimeTravelUnsupported = "TIME_TRAVEL_UNSUPPORTED"
+
+// AsOfParameter is named parameter of snapshot timestamp tables of query are read at
+const AsOfParameter = "dekart_as_of"
+
+// TimeTravelError when as_of of run can't be applied to query soundly; query is not run
+type TimeTravelError struct {
+	Reason string
+}
+
+func (e *TimeTravelError) Error() string {
+	return fmt.Sprintf("%s: cannot run query as of snapshot timestamp, %s", TimeTravelUnsupported, e.Reason)
+}
+
+// GRPCStatus of time travel error is invalid argument with reason in details
+func (e *TimeTravelError) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   TimeTravelUnsupported,
+		Domain:   "dekart",
+		Metadata: map[string]string{"reason": e.Reason},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// systemTimeRe matches FOR SYSTEM_TIME AS OF in code of query
+var systemTimeRe = regexp.MustCompile(`(?i)\bFOR\s+SYSTEM_TIME\b`)
+
+// asOfParameterRe matches named parameter of snapshot timestamp in code of query
+var asOfParameterRe = regexp.MustCompile(`(?i)@` + AsOfParameter + `\b`)
+
+// matchesReference when table as project.dataset.table is named by reference as written, reference may omit
+// project and dataset resolved by BigQuery
+func matchesReference(table string, reference string) bool {
+	return table == reference || strings.HasSuffix(table, "."+reference)
+}
+
+// RewriteAsOf inserts FOR SYSTEM_TIME AS OF @dekart_as_of after each table reference of query text. Referenced are
+// tables of dry run of query as project.dataset.table; query is rewritten only when they are exactly tables named
+// in query text, otherwise query reads views, wildcard or INFORMATION_SCHEMA tables where snapshot can't be injected.
+// Comments and literals are kept as written
+func RewriteAsOf(queryText string, referenced []string) (string, error) {
+	queryText, code, err := trimStatement(queryText)
+	if err != nil {
+		return "", &TimeTravelError{Reason: err.Error()}
+	}
+	if strings.Contains(code, ";") {
+		return "", &TimeTravelError{Reason: "query has multiple statements"}
+	}
+	if systemTimeRe.MatchString(code) {
+		return "", &TimeTravelError{Reason: "query already uses FOR SYSTEM_TIME AS OF"}
+	}
+	if asOfParameterRe.MatchString(code) {
+		return "", &TimeTravelError{Reason: fmt.Sprintf("query already uses named parameter @%s", AsOfParameter)}
+	}
+	references, err := tableReferences(queryText)
+	if err != nil {
+		return "", &TimeTravelError{Reason: err.Error()}
+	}
+	if len(references) == 0 {
+		return "", &TimeTravelError{Reason: "query references no tables"}
+	}
+	for _, r := range references {
+		if strings.Contains(r.name, "*") {
+			return "", &TimeTravelError{Reason: fmt.Sprintf("wildcard table %s can't be read as of snapshot", r.name)}
+		}
+		if strings.Contains(strings.ToUpper(r.name), "INFORMATION_SCHEMA") {
+			return "", &TimeTravelError{Reason: fmt.Sprintf("%s has no snapshots", r.name)}
+		}
+		matched := false
+		for _, table := range referenced {
+			matched = matched || matchesReference(table, r.name)
+		}
+		if !matched {
+			// view is expanded to tables it reads, so its name is not referenced by dry run
+			return "", &TimeTravelError{Reason: fmt.Sprintf("%s is a view or is not readable, time travel of views is not supported", r.name)}
+		}
+	}
+	for _, table := range referenced {
+		matched := false
+		for _, r := range references {
+			matched = matched || matchesReference(table, r.name)
+		}
+		if !matched {
+			return "", &TimeTravelError{Reason: fmt.Sprintf("query reads %s through a view, time travel of views is not supported", table)}
+		}
+	}
+	var b strings.Builder
+	last := 0
+	for _, r := range references {
+		b.WriteString(queryText[last:r.end])
+		b.WriteString(" FOR SYSTEM_TIME AS OF @" + AsOfParameter)
+		last = r.end
+	}
+	b.WriteString(queryText[last:])
+	return b.String(), nil
+}
+
+// timeTravel of query text run as of options.AsOf; query of text is dry run for its referenced tables.
+// Returns rewritten text and options with snapshot timestamp parameter, query is unchanged without AsOf
+func (job *Job) timeTravel(query *bigquery.Query, queryText string, options RunOptions) (string, RunOptions, error) {
+	if options.AsOf.IsZero() {
+		return queryText, options, nil
+	}
+	if options.UseLegacySQL {
+		return "", options, &TimeTravelError{Reason: "legacy SQL has no named parameters, turn off legacy SQL"}
+	}
+	if options.AsOf.After(job.now()) {
+		return "", options, &TimeTravelError{Reason: fmt.Sprintf("as_of %s is in the future", options.AsOf.UTC().Format(time.RFC3339))}
+	}
+	stats, err := job.dryRun(job.Ctx, query)
+	if err != nil {
+		return "", options, err
+	}
+	queryText, err = RewriteAsOf(queryText, stats.tables)
+	if err != nil {
+		job.logger.Info().Err(err).Msg("Job can't be run as of snapshot")
+		return "", options, err
+	}
+	parameters := append([]bigquery.QueryParameter(nil), options.Parameters...)
+	options.Parameters = append(parameters, bigquery.QueryParameter{Name: AsOfParameter, Value: options.AsOf.UTC()})
+	return queryText, options, nil
+}
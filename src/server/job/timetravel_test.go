@@ -0,0 +1,133 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+const asOf = " FOR SYSTEM_TIME AS OF @dekart_as_of"
+
+func TestRewriteAsOf(t *testing.T) {
+	for _, c := range []struct {
+		query      string
+		referenced []string
+		expected   string
+	}{
+		{"select * from d.t", []string{"p.d.t"}, "select * from d.t" + asOf},
+		{"SELECT * FROM `p.d.t`;", []string{"p.d.t"}, "SELECT * FROM `p.d.t`" + asOf},
+		{"select * from `p`.d.`t` where x > 1", []string{"p.d.t"}, "select * from `p`.d.`t`" + asOf + " where x > 1"},
+		// snapshot follows alias
+		{"select a.x from p.d.t as a join p.d.u b on a.id = b.id", []string{"p.d.t", "p.d.u"},
+			"select a.x from p.d.t as a" + asOf + " join p.d.u b" + asOf + " on a.id = b.id"},
+		// comma join, table referenced twice
+		{"select * from d.t x, d.t y", []string{"p.d.t"}, "select * from d.t x" + asOf + ", d.t y" + asOf},
+		// table without dataset is in default dataset
+		{"select * from t\nwhere day = '2021-03-04'", []string{"p.d.t"}, "select * from t" + asOf + "\nwhere day = '2021-03-04'"},
+		// CTE, subquery and UNNEST are not tables
+		{"with c as (select * from d.t) select * from c, unnest(c.items) i where id in (select id from d.u)", []string{"p.d.t", "p.d.u"},
+			"with c as (select * from d.t" + asOf + ") select * from c, unnest(c.items) i where id in (select id from d.u" + asOf + ")"},
+		// comments and literals are kept, FROM in them is not clause
+		{"select 'from d.x' as s -- from d.y\n/* join d.z */ from d.t", []string{"p.d.t"},
+			"select 'from d.x' as s -- from d.y\n/* join d.z */ from d.t" + asOf},
+		{"select extract(year from day) from d.t group by 1", []string{"p.d.t"}, "select extract(year from day) from d.t" + asOf + " group by 1"},
+		{"select * from project-1.d.t t where true", []string{"project-1.d.t"}, "select * from project-1.d.t t" + asOf + " where true"},
+	} {
+		got, err := RewriteAsOf(c.query, c.referenced)
+		if err != nil {
+			t.Errorf("unexpected error of %q: %v", c.query, err)
+			continue
+		}
+		if got != c.expected {
+			t.Errorf("expected\n%q of\n%q, got\n%q", c.expected, c.query, got)
+		}
+	}
+}
+
+func TestRewriteAsOfRefused(t *testing.T) {
+	for _, c := range []struct {
+		query      string
+		referenced []string
+		reason     string
+	}{
+		{"select * from d.t for system_time as of current_timestamp()", []string{"p.d.t"}, "already uses FOR SYSTEM_TIME"},
+		{"select * from d.t where ts < @dekart_as_of", []string{"p.d.t"}, "@dekart_as_of"},
+		{"select 1; select * from d.t", []string{"p.d.t"}, "multiple statements"},
+		{"select 1", nil, "no tables"},
+		{"select * from d.events_*", []string{"p.d.events_1"}, "wildcard"},
+		{"select * from `region-us`.INFORMATION_SCHEMA.JOBS", nil, "no snapshots"},
+		// view is expanded, dry run references tables it reads
+		{"select * from d.v", []string{"p.d.t"}, "d.v is a view"},
+		// view joined with table it reads
+		{"select * from d.t join d.v using (id)", []string{"p.d.t", "p.d.u"}, "is a view"},
+		{"select * from d.t", []string{"p.d.t", "p.other.u"}, "reads p.other.u through a view"},
+		{"select * from d.t where s = 'x", []string{"p.d.t"}, "unterminated"},
+	} {
+		_, err := RewriteAsOf(c.query, c.referenced)
+		var timeTravelErr *TimeTravelError
+		if !errors.As(err, &timeTravelErr) || !strings.Contains(err.Error(), c.reason) {
+			t.Errorf("expected error with %q of %q, got %v", c.reason, c.query, err)
+		}
+	}
+	// text of query which already uses time travel in literal is not time travel
+	if _, err := RewriteAsOf("select 'for system_time as of' from d.t", []string{"p.d.t"}); err != nil {
+		t.Errorf("unexpected error of literal, got %v", err)
+	}
+}
+
+func TestTimeTravelErrorStatus(t *testing.T) {
+	st := (&TimeTravelError{Reason: "d.v is a view"}).GRPCStatus()
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("unexpected status %v", st)
+	}
+	if info, ok := st.Details()[0].(*errdetails.ErrorInfo); !ok || info.Reason != TimeTravelUnsupported {
+		t.Errorf("unexpected details %v", st.Details())
+	}
+	if explanation := ExplainError(&TimeTravelError{Reason: "x"}); explanation == nil || explanation.ID != "time-travel-unsupported" {
+		t.Errorf("unexpected explanation %+v", explanation)
+	}
+}
+
+func TestTimeTravelParameter(t *testing.T) {
+	job := NewStore().New(context.Background(), "report", "query")
+	job.dryRun = func(ctx context.Context, query *bigquery.Query) (dryRunStats, error) {
+		return dryRunStats{tables: []string{"p.d.t"}}, nil
+	}
+	at := time.Date(2021, 3, 4, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	options := RunOptions{AsOf: at, Parameters: []bigquery.QueryParameter{{Name: "country", Value: "NL"}}}
+	queryText, got, err := job.timeTravel(nil, "select * from d.t where country = @country", options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queryText != "select * from d.t"+asOf+" where country = @country" {
+		t.Errorf("unexpected query text %q", queryText)
+	}
+	if len(got.Parameters) != 2 || got.Parameters[1].Name != AsOfParameter || got.Parameters[1].Value != at.UTC() {
+		t.Errorf("unexpected parameters %v", got.Parameters)
+	}
+	// parameters of options passed in are not changed
+	if len(options.Parameters) != 1 {
+		t.Errorf("options of caller changed %v", options.Parameters)
+	}
+	// lineage records snapshot timestamp with other parameter values
+	job.runOptions = got
+	if value := job.Lineage().ParameterValues[AsOfParameter]; value != "2021-03-04T08:00:00Z" {
+		t.Errorf("unexpected lineage value %q", value)
+	}
+	if _, _, err := job.timeTravel(nil, "select * from d.t", RunOptions{AsOf: time.Now().Add(time.Hour)}); err == nil {
+		t.Error("expected error of future snapshot")
+	}
+	if _, _, err := job.timeTravel(nil, "select * from [p:d.t]", RunOptions{AsOf: at, UseLegacySQL: true}); err == nil {
+		t.Error("expected error of legacy SQL")
+	}
+	queryText, _, err = job.timeTravel(nil, "select 1", RunOptions{})
+	if err != nil || queryText != "select 1" {
+		t.Errorf("expected query without snapshot unchanged, got %q %v", queryText, err)
+	}
+}
@@ -0,0 +1,344 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cloud.google.com/go/bigquery"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// jsonFieldType of BigQuery JSON columns, client library doesn't know it and can't convert its values
+const jsonFieldType bigquery.FieldType = "JSON"
+
+// bigNumericFieldType of BigQuery BIGNUMERIC columns, client library doesn't know it either;
+// values are read as decimal strings
+const bigNumericFieldType bigquery.FieldType = "BIGNUMERIC"
+
+// defaultTokenSource of instance credentials, replaced in tests
+var defaultTokenSource = func(ctx context.Context) (oauth2.TokenSource, error) {
+	return google.DefaultTokenSource(ctx, bigquery.Scope)
+}
+
+// instanceTokenSource finds credentials of instance on first token, so client is created before they are needed
+type instanceTokenSource struct {
+	ctx    context.Context
+	once   sync.Once
+	source oauth2.TokenSource
+	err    error
+}
+
+func (s *instanceTokenSource) Token() (*oauth2.Token, error) {
+	s.once.Do(func() {
+		s.source, s.err = defaultTokenSource(s.ctx)
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.source.Token()
+}
+
+// jobTransport of BigQuery client of job, it does what client library has no fields for:
+// sets reservation of inserted jobs, reads reservation which ran job from statistics of job status,
+// reads columns of types client doesn't know, like JSON and BIGNUMERIC, as strings keeping their types,
+// so raw JSON text and exact decimals are written to result, and reads precision and scale of NUMERIC and BIGNUMERIC columns
+type jobTransport struct {
+	base http.RoundTripper
+	// reservation of inserted jobs, empty leaves it to reservation assignments
+	reservation string
+	mutex       sync.Mutex
+	effective   string
+	columns     map[string]resultColumn
+}
+
+// clientFieldTypes which client library converts, columns of other types are read as strings
+var clientFieldTypes = map[bigquery.FieldType]bool{
+	bigquery.StringFieldType:    true,
+	bigquery.BytesFieldType:     true,
+	bigquery.IntegerFieldType:   true,
+	bigquery.FloatFieldType:     true,
+	bigquery.BooleanFieldType:   true,
+	bigquery.TimestampFieldType: true,
+	bigquery.RecordFieldType:    true,
+	bigquery.DateFieldType:      true,
+	bigquery.TimeFieldType:      true,
+	bigquery.DateTimeFieldType:  true,
+	bigquery.NumericFieldType:   true,
+	bigquery.GeographyFieldType: true,
+}
+
+// resultColumn of query results schema as sent by BigQuery, before types client library doesn't know are read as strings
+type resultColumn struct {
+	fieldType bigquery.FieldType
+	// elementType of RANGE column, like DATE
+	elementType string
+	// precision and scale of parameterized NUMERIC and BIGNUMERIC columns, empty for default ones
+	precision string
+	scale     string
+}
+
+func (t *jobTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.reservation != "" && req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/jobs") && req.Body != nil {
+		body, err := t.withReservation(req)
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+	res, err := t.base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+	switch {
+	case strings.Contains(req.URL.Path, "/queries/"):
+		return t.readResponse(res, t.readSchema)
+	case strings.Contains(req.URL.Path, "/tables/") && !strings.HasSuffix(req.URL.Path, "/data"):
+		// result table is read before it's extracted
+		return t.readResponse(res, t.readSchema)
+	case strings.Contains(req.URL.Path, "/jobs/"):
+		return t.readResponse(res, t.readReservation)
+	}
+	return res, nil
+}
+
+// readResponse body with read, body returned by read replaces body of response
+func (t *jobTransport) readResponse(res *http.Response, read func(body []byte) []byte) (*http.Response, error) {
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = read(body)
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	return res, nil
+}
+
+// withReservation body of job insert request
+func (t *jobTransport) withReservation(req *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var job map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// int64 fields of job are kept as written
+	decoder.UseNumber()
+	if err := decoder.Decode(&job); err != nil {
+		return nil, err
+	}
+	configuration, ok := job["configuration"].(map[string]interface{})
+	if !ok {
+		return body, nil
+	}
+	configuration["reservation"] = t.reservation
+	return json.Marshal(job)
+}
+
+// readReservation of job status, body is not changed
+func (t *jobTransport) readReservation(body []byte) []byte {
+	var job struct {
+		Statistics struct {
+			ReservationID string `json:"reservation_id"`
+		} `json:"statistics"`
+	}
+	if json.Unmarshal(body, &job) == nil && job.Statistics.ReservationID != "" {
+		t.mutex.Lock()
+		t.effective = job.Statistics.ReservationID
+		t.mutex.Unlock()
+	}
+	return body
+}
+
+// effectiveReservation reported by BigQuery, empty until job status has it
+func (t *jobTransport) effectiveReservation() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.effective
+}
+
+// readSchema of query results; values of JSON columns are JSON text, values of BIGNUMERIC columns are decimal text
+// and values of other types client doesn't know are text too, which client reads as strings
+func (t *jobTransport) readSchema(body []byte) []byte {
+	if !bytes.Contains(body, []byte(`"schema"`)) {
+		return body
+	}
+	var results map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&results); err != nil {
+		return body
+	}
+	schema, ok := results["schema"].(map[string]interface{})
+	if !ok {
+		return body
+	}
+	columns := map[string]resultColumn{}
+	stringified := readSchemaFields(schema["fields"], columns)
+	t.mutex.Lock()
+	if t.columns == nil {
+		t.columns = map[string]resultColumn{}
+	}
+	for name, column := range columns {
+		t.columns[name] = column
+	}
+	t.mutex.Unlock()
+	if !stringified {
+		return body
+	}
+	rewritten, err := json.Marshal(results)
+	if err != nil {
+		return body
+	}
+	return rewritten
+}
+
+// readSchemaFields of schema and their nested fields as strings when client can't convert them,
+// top level columns are recorded when columns is not nil; true when any field is changed
+func readSchemaFields(value interface{}, columns map[string]resultColumn) bool {
+	fields, _ := value.([]interface{})
+	stringified := false
+	for _, f := range fields {
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fieldType, _ := field["type"].(string)
+		column := resultColumn{fieldType: bigquery.FieldType(fieldType)}
+		switch {
+		case !clientFieldTypes[column.fieldType]:
+			field["type"] = string(bigquery.StringFieldType)
+			stringified = true
+			if element, ok := field["rangeElementType"].(map[string]interface{}); ok {
+				column.elementType, _ = element["type"].(string)
+			}
+		case column.fieldType != bigquery.NumericFieldType:
+			if readSchemaFields(field["fields"], nil) {
+				stringified = true
+			}
+			continue
+		}
+		if name, ok := field["name"].(string); ok && columns != nil && field["mode"] != "REPEATED" {
+			if precision, ok := field["precision"]; ok {
+				column.precision = fmt.Sprint(precision)
+			}
+			if scale, ok := field["scale"]; ok {
+				column.scale = fmt.Sprint(scale)
+			}
+			columns[name] = column
+		}
+	}
+	return stringified
+}
+
+// column of result as sent by BigQuery, false when its type and parameters are known to client
+func (t *jobTransport) column(name string) (resultColumn, bool) {
+	if t == nil {
+		return resultColumn{}, false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	column, ok := t.columns[name]
+	return column, ok
+}
+
+// restoreSchema of result with types of columns read as strings, like JSON, BIGNUMERIC, INTERVAL and RANGE
+func (t *jobTransport) restoreSchema(schema bigquery.Schema) bigquery.Schema {
+	if t == nil {
+		return schema
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.columns) == 0 {
+		return schema
+	}
+	restored := make(bigquery.Schema, len(schema))
+	for i, field := range schema {
+		restored[i] = field
+		fieldType := t.columns[field.Name].fieldType
+		if fieldType != "" && !clientFieldTypes[fieldType] && field.Type == bigquery.StringFieldType {
+			column := *field
+			column.Type = fieldType
+			restored[i] = &column
+		}
+	}
+	return restored
+}
+
+// decimalScales of result columns, NUMERIC and BIGNUMERIC values are written with declared scale
+// or with scale of type by default; -1 for other columns
+func (t *jobTransport) decimalScales(schema bigquery.Schema) []int {
+	scales := make([]int, len(schema))
+	for i, field := range schema {
+		scales[i] = -1
+		if field.Repeated {
+			continue
+		}
+		switch field.Type {
+		case bigquery.NumericFieldType:
+			scales[i] = numericScale
+		case bigNumericFieldType:
+			scales[i] = bigNumericScale
+		default:
+			continue
+		}
+		if column, ok := t.column(field.Name); ok && column.precision != "" {
+			// NUMERIC(P) has scale 0
+			scale, _ := strconv.Atoi(column.scale)
+			scales[i] = scale
+		}
+	}
+	return scales
+}
+
+// columnTypes of result with precision and scale of parameterized NUMERIC and BIGNUMERIC columns, like NUMERIC(10,2),
+// and element type of RANGE columns, like RANGE<DATE>
+func (t *jobTransport) columnTypes(schema bigquery.Schema) []string {
+	types := ColumnTypes(schema)
+	for i, field := range schema {
+		if field.Type == rangeFieldType && !field.Repeated {
+			if column, ok := t.column(field.Name); ok && column.elementType != "" {
+				types[i] = fmt.Sprintf("%s<%s>", field.Type, column.elementType)
+			}
+			continue
+		}
+		if field.Repeated || (field.Type != bigquery.NumericFieldType && field.Type != bigNumericFieldType) {
+			continue
+		}
+		if column, ok := t.column(field.Name); ok && column.precision != "" {
+			scale := column.scale
+			if scale == "" {
+				scale = "0"
+			}
+			types[i] = fmt.Sprintf("%s(%s,%s)", field.Type, column.precision, scale)
+		}
+	}
+	return types
+}
+
+// resultSchema of iterator with types of columns read as strings restored, it's restored once iterator has schema
+func (job *Job) resultSchema(schema func() bigquery.Schema) func() bigquery.Schema {
+	var mutex sync.Mutex
+	var restored bigquery.Schema
+	return func() bigquery.Schema {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if restored == nil {
+			if s := schema(); s != nil {
+				restored = job.transport.restoreSchema(s)
+			}
+		}
+		return restored
+	}
+}
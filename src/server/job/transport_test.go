@@ -0,0 +1,148 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+)
+
+// fakeResultsEndpoint of BigQuery job with JSON column in result
+func fakeResultsEndpoint(t *testing.T, rows [][]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var res interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/jobs/job"):
+			res = map[string]interface{}{
+				"jobReference": map[string]string{"projectId": "billing", "jobId": "job", "location": "US"},
+				"configuration": map[string]interface{}{"query": map[string]interface{}{
+					"query":            "select id, payload from events",
+					"destinationTable": map[string]string{"projectId": "billing", "datasetId": "_anon", "tableId": "result"},
+				}},
+				"status": map[string]string{"state": "DONE"},
+			}
+		case strings.HasSuffix(r.URL.Path, "/queries/job"):
+			res = map[string]interface{}{
+				"jobComplete": true,
+				"totalRows":   "3",
+				"schema": map[string]interface{}{"fields": []map[string]interface{}{
+					{"name": "id", "type": "INTEGER"},
+					{"name": "payload", "type": "JSON"},
+					{"name": "nested", "type": "RECORD", "fields": []map[string]string{{"name": "attributes", "type": "JSON"}}},
+				}},
+			}
+		case strings.HasSuffix(r.URL.Path, "/tables/result/data"):
+			var tableRows []map[string]interface{}
+			for _, row := range rows {
+				var cells []map[string]interface{}
+				for _, v := range row {
+					cells = append(cells, map[string]interface{}{"v": v})
+				}
+				tableRows = append(tableRows, map[string]interface{}{"f": cells})
+			}
+			res = map[string]interface{}{"totalRows": "3", "rows": tableRows}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReadJSONColumns(t *testing.T) {
+	large := `{"values":[` + strings.Repeat(`"line\nwith \"quotes\", commas",`, 100000) + `1]}`
+	nested := map[string]interface{}{"f": []map[string]interface{}{{"v": `{"k":"v"}`}}}
+	server := fakeResultsEndpoint(t, [][]interface{}{
+		{"1", `{"a":[1,2],"b":{"c":null}}`, nested},
+		{"2", nil, nil},
+		{"3", large, nested},
+	})
+	transport := &jobTransport{base: http.DefaultTransport}
+	ctx := context.Background()
+	client, err := bigquery.NewClient(ctx, "billing", option.WithHTTPClient(&http.Client{Transport: transport}), option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bigqueryJob, err := client.JobFromIDLocation(ctx, "job", "US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it, err := bigqueryJob.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := readingJob(NewStore())
+	job.transport = transport
+	job.totalRows = 3
+	statuses := collectStatus(job)
+	w := &fakeResultWriter{}
+	job.writeResult(it, job.resultSchema(func() bigquery.Schema { return it.Schema }), w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	if err := job.Err(); err != "" {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(w.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// JSON text is written as is, NULL is written like other NULL values
+	if len(records) != 4 || records[1][1] != `{"a":[1,2],"b":{"c":null}}` || records[2][1] != "<nil>" || records[3][1] != large {
+		t.Errorf("unexpected records %.200q", records)
+	}
+	if !json.Valid([]byte(records[3][1])) {
+		t.Error("expected large cell to be valid JSON")
+	}
+	// column types of result mark JSON column, so client can parse its cells
+	if types := job.GetStatus().ColumnTypes; strings.Join(types, ",") != "INTEGER,JSON,RECORD" {
+		t.Errorf("unexpected column types %v", types)
+	}
+}
+
+func TestRestoreSchemaWithoutJSON(t *testing.T) {
+	schema := bigquery.Schema{{Name: "id", Type: bigquery.StringFieldType}}
+	transport := &jobTransport{}
+	if restored := transport.restoreSchema(schema); len(restored) != 1 || restored[0] != schema[0] {
+		t.Errorf("expected schema as is, got %v", restored)
+	}
+	var none *jobTransport
+	if restored := none.restoreSchema(schema); restored[0] != schema[0] {
+		t.Errorf("expected schema as is, got %v", restored)
+	}
+}
+
+func TestReadDecimalSchema(t *testing.T) {
+	transport := &jobTransport{}
+	body := transport.readSchema([]byte(`{"schema":{"fields":[` +
+		`{"name":"amount","type":"NUMERIC"},` +
+		`{"name":"price","type":"NUMERIC","precision":"10","scale":"2"},` +
+		`{"name":"big","type":"BIGNUMERIC","precision":"50"},` +
+		`{"name":"nested","type":"RECORD","fields":[{"name":"total","type":"BIGNUMERIC"}]}` +
+		`]}}`))
+	// BIGNUMERIC values are read as strings, other types are kept
+	if types := strings.Count(string(body), `"type":"STRING"`); types != 2 || !strings.Contains(string(body), `"type":"NUMERIC"`) {
+		t.Errorf("unexpected schema %s", body)
+	}
+	schema := transport.restoreSchema(bigquery.Schema{
+		{Name: "amount", Type: bigquery.NumericFieldType},
+		{Name: "price", Type: bigquery.NumericFieldType},
+		{Name: "big", Type: bigquery.StringFieldType},
+	})
+	if schema[2].Type != bigNumericFieldType {
+		t.Errorf("expected BIGNUMERIC restored, got %s", schema[2].Type)
+	}
+	if scales := transport.decimalScales(schema); scales[0] != numericScale || scales[1] != 2 || scales[2] != 0 {
+		t.Errorf("unexpected scales %v", scales)
+	}
+	if types := transport.columnTypes(schema); strings.Join(types, ",") != "NUMERIC,NUMERIC(10,2),BIGNUMERIC(50,0)" {
+		t.Errorf("unexpected column types %v", types)
+	}
+}
@@ -0,0 +1,177 @@
+package job
+
+import (
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// intervalFieldType and rangeFieldType of BigQuery columns client library doesn't know, values are read as text
+const (
+	intervalFieldType bigquery.FieldType = "INTERVAL"
+	rangeFieldType    bigquery.FieldType = "RANGE"
+)
+
+// unboundedRangeEnd of RANGE value without start or end
+const unboundedRangeEnd = "UNBOUNDED"
+
+// cellConverter of text value of column client library doesn't know
+type cellConverter func(value string) string
+
+// cellConverters of result columns by index, nil for columns written as read;
+// columns of types without converter are written as text with warning
+func (job *Job) cellConverters(schema bigquery.Schema) []cellConverter {
+	converters := make([]cellConverter, len(schema))
+	var unknown []string
+	for i, field := range schema {
+		if field.Type == "" || clientFieldTypes[field.Type] {
+			continue
+		}
+		switch field.Type {
+		case jsonFieldType, bigNumericFieldType:
+		case intervalFieldType:
+			if !field.Repeated {
+				converters[i] = formatInterval
+			}
+		case rangeFieldType:
+			if !field.Repeated {
+				converters[i] = formatRange
+			}
+		default:
+			unknown = append(unknown, fmt.Sprintf("%s %s", field.Name, field.Type))
+		}
+	}
+	if len(unknown) > 0 {
+		job.AddWarning(WarningUnknownTypes, fmt.Sprintf("columns of unknown types were written as text: %s", strings.Join(unknown, ", ")))
+	}
+	return converters
+}
+
+// convertRow of result in place, NULL cells are kept
+func convertRow(row []bigquery.Value, converters []cellConverter) {
+	for i, convert := range converters {
+		if convert == nil || i >= len(row) {
+			continue
+		}
+		if s, ok := row[i].(string); ok {
+			row[i] = convert(s)
+		}
+	}
+}
+
+// formatInterval of canonical BigQuery INTERVAL text Y-M D H:M:S[.F] as ISO 8601 duration like P1Y2M3DT4H5M6.5S;
+// signs of parts apply to their components, text which is not canonical is kept
+func formatInterval(value string) string {
+	parts := strings.Fields(value)
+	if len(parts) != 3 {
+		return value
+	}
+	yearMonth, yearMonthSign := cutSign(parts[0])
+	years, months, ok := cut(yearMonth, "-")
+	if !ok || !digits(years) || !digits(months) {
+		return value
+	}
+	days, daysSign := cutSign(parts[1])
+	if !digits(days) {
+		return value
+	}
+	hms, timeSign := cutSign(parts[2])
+	clock := strings.Split(hms, ":")
+	if len(clock) != 3 || !digits(clock[0]) || !digits(clock[1]) {
+		return value
+	}
+	seconds, fraction, _ := cut(clock[2], ".")
+	if !digits(seconds) || (fraction != "" && !digits(fraction)) {
+		return value
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if fraction != "" {
+		seconds = strings.TrimLeft(seconds, "0") + "." + fraction
+		if strings.HasPrefix(seconds, ".") {
+			seconds = "0" + seconds
+		}
+	}
+	var b strings.Builder
+	b.WriteString("P")
+	writeComponent(&b, yearMonthSign, years, "Y")
+	writeComponent(&b, yearMonthSign, months, "M")
+	writeComponent(&b, daysSign, days, "D")
+	var t strings.Builder
+	writeComponent(&t, timeSign, clock[0], "H")
+	writeComponent(&t, timeSign, clock[1], "M")
+	writeComponent(&t, timeSign, seconds, "S")
+	if t.Len() > 0 {
+		b.WriteString("T")
+		b.WriteString(t.String())
+	}
+	if b.Len() == 1 {
+		return "PT0S"
+	}
+	return b.String()
+}
+
+// writeComponent of ISO 8601 duration, zero components are omitted
+func writeComponent(b *strings.Builder, sign string, value string, designator string) {
+	if strings.Trim(value, "0.") == "" {
+		return
+	}
+	if !strings.Contains(value, ".") {
+		value = strings.TrimLeft(value, "0")
+	}
+	b.WriteString(sign)
+	b.WriteString(value)
+	b.WriteString(designator)
+}
+
+// cutSign of interval part, sign is - or empty
+func cutSign(s string) (string, string) {
+	if strings.HasPrefix(s, "-") {
+		return s[1:], "-"
+	}
+	return strings.TrimPrefix(s, "+"), ""
+}
+
+// cut s around first separator
+func cut(s string, separator string) (string, string, bool) {
+	if i := strings.Index(s, separator); i >= 0 {
+		return s[:i], s[i+len(separator):], true
+	}
+	return s, "", false
+}
+
+// digits of unsigned number, at least one
+func digits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatRange of BigQuery RANGE text as [start, end), unbounded start or end is UNBOUNDED;
+// text which is not range is kept
+func formatRange(value string) string {
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, ")") {
+		return value
+	}
+	start, end, ok := cut(value[1:len(value)-1], ",")
+	if !ok {
+		return value
+	}
+	return fmt.Sprintf("[%s, %s)", rangeEnd(start), rangeEnd(end))
+}
+
+// rangeEnd of RANGE, NULL and empty ends are unbounded
+func rangeEnd(s string) string {
+	s = strings.TrimSpace(s)
+	switch strings.ToUpper(s) {
+	case "", "NULL", unboundedRangeEnd:
+		return unboundedRangeEnd
+	}
+	return s
+}
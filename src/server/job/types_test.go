@@ -0,0 +1,84 @@
+package job
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestFormatInterval(t *testing.T) {
+	for value, expected := range map[string]string{
+		"1-2 3 4:5:6.789":     "P1Y2M3DT4H5M6.789S",
+		"0-0 0 0:0:0":         "PT0S",
+		"-1-6 0 0:0:0":        "P-1Y-6M",
+		"0-0 -10 -0:30:0":     "P-10DT-30M",
+		"0-0 0 0:0:0.000001":  "PT0.000001S",
+		"10000-0 0 0:0:0":     "P10000Y",
+		"0-0 3660000 0:0:0":   "P3660000D",
+		"0-0 0 87840000:0:00": "PT87840000H",
+		"0-11 0 0:0:59.500":   "P11MT59.5S",
+		"P1Y":                 "P1Y",
+		"1-x 0 0:0:0":         "1-x 0 0:0:0",
+	} {
+		if s := formatInterval(value); s != expected {
+			t.Errorf("%s: expected %s, got %s", value, expected, s)
+		}
+	}
+}
+
+func TestFormatRange(t *testing.T) {
+	for value, expected := range map[string]string{
+		"[2020-01-01, 2020-12-31)": "[2020-01-01, 2020-12-31)",
+		"[UNBOUNDED, 2020-12-31)":  "[UNBOUNDED, 2020-12-31)",
+		"[2020-01-01, NULL)":       "[2020-01-01, UNBOUNDED)",
+		"[,)":                      "[UNBOUNDED, UNBOUNDED)",
+		"[2020-01-01 12:00:00, 2020-01-02 00:00:00)": "[2020-01-01 12:00:00, 2020-01-02 00:00:00)",
+		"2020-01-01": "2020-01-01",
+	} {
+		if s := formatRange(value); s != expected {
+			t.Errorf("%s: expected %s, got %s", value, expected, s)
+		}
+	}
+}
+
+func TestCSVResultIntervalRange(t *testing.T) {
+	transport := &jobTransport{}
+	transport.readSchema([]byte(`{"schema":{"fields":[` +
+		`{"name":"duration","type":"INTERVAL"},` +
+		`{"name":"period","type":"RANGE","rangeElementType":{"type":"DATE"}},` +
+		`{"name":"shape","type":"FUTURE_TYPE"}` +
+		`]}}`))
+	job := readingJob(NewStore())
+	job.transport = transport
+	job.totalRows = 2
+	statuses := collectStatus(job)
+	// client reads columns as strings
+	schema := bigquery.Schema{
+		{Name: "duration", Type: bigquery.StringFieldType},
+		{Name: "period", Type: bigquery.StringFieldType},
+		{Name: "shape", Type: bigquery.StringFieldType},
+	}
+	w := &fakeResultWriter{}
+	rows := [][]bigquery.Value{
+		{"1-2 3 4:5:6.5", "[2020-01-01, UNBOUNDED)", "opaque"},
+		{nil, nil, nil},
+	}
+	job.writeResult(&fakeIterator{rows: rows}, job.resultSchema(func() bigquery.Schema { return schema }), w, func() { t.Error("unexpected cleanup") })
+	<-statuses
+	if err := job.Err(); err != "" {
+		t.Fatal(err)
+	}
+	expected := "duration,period,shape\nP1Y2M3DT4H5M6.5S,\"[2020-01-01, UNBOUNDED)\",opaque\n<nil>,<nil>,<nil>\n"
+	if w.String() != expected {
+		t.Errorf("expected %q, got %q", expected, w.String())
+	}
+	status := job.GetStatus()
+	if types := strings.Join(status.ColumnTypes, ","); types != "INTERVAL,RANGE<DATE>,FUTURE_TYPE" {
+		t.Errorf("unexpected column types %s", types)
+	}
+	// unknown type doesn't fail job
+	if len(status.Warnings) != 1 || status.Warnings[0].Code != WarningUnknownTypes || !strings.Contains(status.Warnings[0].Message, "shape FUTURE_TYPE") {
+		t.Errorf("expected unknown types warning, got %v", status.Warnings)
+	}
+}
@@ -0,0 +1,527 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/metrics"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/api/googleapi"
+)
+
+// maxComposeSources of one compose request of storage
+const maxComposeSources = 32
+
+// maxComponents of result composed in two levels: components are composed into at most 31 intermediates,
+// which are composed with first part of result
+const maxComponents = (maxComposeSources - 1) * maxComposeSources
+
+// defaultParallelUploads of components uploaded at once
+const defaultParallelUploads = 4
+
+// UploadSettings of result upload
+type UploadSettings struct {
+	// ChunkSize of storage writer, 0 is default of storage client; components of parallel upload have same size
+	ChunkSize int
+	// ParallelThreshold in bytes after which rest of result is uploaded in components, 0 disables parallel upload
+	ParallelThreshold int64
+	// Parallelism of component uploads, each uploaded component is buffered in memory
+	Parallelism int
+}
+
+// componentSize of parallel upload
+func (s UploadSettings) componentSize() int64 {
+	if s.ChunkSize > 0 {
+		return int64(s.ChunkSize)
+	}
+	return googleapi.DefaultUploadChunkSize
+}
+
+// parseSize of env variable as non-negative number of bytes, empty is 0
+func parseSize(name string, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid %s %s, expected number of bytes", name, value)
+	}
+	return size, nil
+}
+
+// parseUploadSettings of DEKART_UPLOAD_CHUNK_SIZE, DEKART_PARALLEL_UPLOAD_THRESHOLD and DEKART_PARALLEL_UPLOADS
+func parseUploadSettings(chunkSize string, threshold string, parallelism string) (UploadSettings, error) {
+	chunk, err := parseSize("DEKART_UPLOAD_CHUNK_SIZE", chunkSize)
+	if err != nil {
+		return UploadSettings{}, err
+	}
+	if chunk > 0 && chunk < googleapi.MinUploadChunkSize {
+		return UploadSettings{}, fmt.Errorf("invalid DEKART_UPLOAD_CHUNK_SIZE %s, minimum is %d", chunkSize, googleapi.MinUploadChunkSize)
+	}
+	parallelThreshold, err := parseSize("DEKART_PARALLEL_UPLOAD_THRESHOLD", threshold)
+	if err != nil {
+		return UploadSettings{}, err
+	}
+	settings := UploadSettings{ChunkSize: int(chunk), ParallelThreshold: parallelThreshold, Parallelism: defaultParallelUploads}
+	if parallelism != "" {
+		settings.Parallelism, err = strconv.Atoi(parallelism)
+		if err != nil || settings.Parallelism < 1 || settings.Parallelism > maxComposeSources {
+			return UploadSettings{}, fmt.Errorf("invalid DEKART_PARALLEL_UPLOADS %s, expected 1 to %d", parallelism, maxComposeSources)
+		}
+	}
+	return settings, nil
+}
+
+func uploadSettings() UploadSettings {
+	s, err := parseUploadSettings(
+		os.Getenv("DEKART_UPLOAD_CHUNK_SIZE"),
+		os.Getenv("DEKART_PARALLEL_UPLOAD_THRESHOLD"),
+		os.Getenv("DEKART_PARALLEL_UPLOADS"),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	return s
+}
+
+// SetUploadSettings of jobs created after call
+func (s *Store) SetUploadSettings(settings UploadSettings) {
+	s.mutex.Lock()
+	s.upload = settings
+	s.mutex.Unlock()
+}
+
+// componentStore of result object and its temporary components, bucket of result or fake in tests
+type componentStore interface {
+	// object name of result
+	object() string
+	// newWriter of result object
+	newWriter(ctx context.Context) resultWriter
+	upload(ctx context.Context, name string, data []byte) error
+	// compose sources into object dst in order, dst can be one of sources
+	compose(ctx context.Context, dst string, sources []string) error
+	delete(ctx context.Context, name string) error
+}
+
+// bucketComponents of job result stored in bucket
+type bucketComponents struct {
+	job    *Job
+	bucket *storage.BucketHandle
+}
+
+// UseParallelUpload of result above DEKART_PARALLEL_UPLOAD_THRESHOLD, components are stored in bucket of result;
+// jobs with h3 aggregation upload result with single writer. Jobs of store created WithStorage use its bucket
+func (job *Job) UseParallelUpload(bucket *storage.BucketHandle) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	if job.upload.ParallelThreshold > 0 {
+		job.parallel = &bucketComponents{job: job, bucket: bucket}
+	}
+}
+
+func (b *bucketComponents) object() string {
+	return b.job.storageObj.ObjectName()
+}
+
+func (b *bucketComponents) newWriter(ctx context.Context) resultWriter {
+	return b.job.newStorageWriter(ctx, b.job.storageObj, b.job.setResultGeneration)
+}
+
+func (b *bucketComponents) upload(ctx context.Context, name string, data []byte) error {
+	w := b.bucket.Object(name).NewWriter(ctx)
+	// buffered component is sent in one chunk, chunk is retried by storage client
+	w.ChunkSize = len(data)
+	instrumented := instrumentWriter(w, metrics.GCS, "component_upload")
+	if _, err := instrumented.Write(data); err != nil {
+		instrumented.Close()
+		return err
+	}
+	return instrumented.Close()
+}
+
+func (b *bucketComponents) compose(ctx context.Context, dst string, sources []string) error {
+	handles := make([]*storage.ObjectHandle, len(sources))
+	for i, name := range sources {
+		handles[i] = b.bucket.Object(name)
+	}
+	composer := b.bucket.Object(dst).ComposerFrom(handles...)
+	if dst == b.object() {
+		// composed object has attributes of composer only
+		b.job.setResultAttrs(&composer.ObjectAttrs)
+	}
+	start := time.Now()
+	attrs, err := composer.Run(ctx)
+	metrics.Observe(metrics.GCS, "compose", start, err)
+	if err == nil && dst == b.object() {
+		b.job.setResultGeneration(attrs.Generation)
+	}
+	return err
+}
+
+func (b *bucketComponents) delete(ctx context.Context, name string) error {
+	err := b.bucket.Object(name).Delete(ctx)
+	if err == storage.ErrObjectNotExist {
+		return nil
+	}
+	return err
+}
+
+// composeError when components of result can't be composed, result is written again with single writer
+type composeError struct {
+	err error
+}
+
+func (e *composeError) Error() string {
+	return fmt.Sprintf("cannot compose result from components: %s", e.err)
+}
+
+func (e *composeError) Unwrap() error {
+	return e.err
+}
+
+// splitter of result into parts, part ends at boundary where it can be read on its own
+type splitter interface {
+	// cut returns bytes of p in part of size with limit, end when part ends after them
+	cut(p []byte, size int64, limit int64) (n int, end bool)
+}
+
+// byteSplitter ends part at limit, parquet and compressed bytes are concatenated as they are
+type byteSplitter struct{}
+
+func (byteSplitter) cut(p []byte, size int64, limit int64) (int, bool) {
+	if rest := limit - size; rest <= int64(len(p)) {
+		return int(rest), true
+	}
+	return len(p), false
+}
+
+// rowSplitter ends part after CSV row which reached limit; quoted cells can contain line breaks,
+// quote state is kept between writes
+type rowSplitter struct {
+	quoted bool
+}
+
+func (s *rowSplitter) cut(p []byte, size int64, limit int64) (int, bool) {
+	for i, b := range p {
+		switch b {
+		case '"':
+			// escaped quote is two quotes, so state is unchanged after it
+			s.quoted = !s.quoted
+		case '\n':
+			if !s.quoted && size+int64(i)+1 >= limit {
+				return i + 1, true
+			}
+		}
+	}
+	return len(p), false
+}
+
+// splitter of job result format
+func (job *Job) splitter() splitter {
+	if job.resultFormat == proto.ResultFormat_RESULT_FORMAT_PARQUET || job.compressed() {
+		return byteSplitter{}
+	}
+	return &rowSplitter{}
+}
+
+// newResultWriter of job result object, result above threshold is uploaded in parallel when job uses it
+// and memory budget has room for its components
+func (job *Job) newResultWriter(ctx context.Context) resultWriter {
+	job.mutex.Lock()
+	parallel := job.parallel
+	job.mutex.Unlock()
+	// components being uploaded and part being written are buffered, upload streams with single writer without budget
+	if parallel == nil || job.h3 != nil || !job.reserveMemory(memoryUpload, int64(job.upload.Parallelism+1)*job.upload.componentSize()) {
+		return instrumentWriter(job.newStorageWriter(ctx, job.storageObj, job.setResultGeneration), metrics.GCS, "result_upload")
+	}
+	return instrumentWriter(newParallelWriter(ctx, parallel, job.upload, job.splitter(), job.logger), metrics.GCS, "result_upload")
+}
+
+// parallelWriter streams first part of result to result object, rest of result is buffered into components
+// uploaded concurrently and composed into result object on Close; components are deleted in any case
+type parallelWriter struct {
+	ctx      context.Context
+	abort    context.CancelFunc
+	store    componentStore
+	name     string
+	settings UploadSettings
+	split    splitter
+	logger   zerolog.Logger
+	first    resultWriter
+	// part being written, nil while first part is written
+	part       *bytes.Buffer
+	size       int64
+	components []string
+	slots      chan struct{}
+	wg         sync.WaitGroup
+	mutex      sync.Mutex
+	err        error
+}
+
+// newParallelWriter of result object in store; canceling ctx aborts upload and components are deleted on Close
+func newParallelWriter(ctx context.Context, store componentStore, settings UploadSettings, split splitter, logger zerolog.Logger) *parallelWriter {
+	ctx, abort := context.WithCancel(ctx)
+	return &parallelWriter{
+		ctx:      ctx,
+		abort:    abort,
+		store:    store,
+		name:     store.object(),
+		settings: settings,
+		split:    split,
+		logger:   logger,
+		first:    store.newWriter(ctx),
+		slots:    make(chan struct{}, settings.Parallelism),
+	}
+}
+
+// fail upload with first error, other uploads are aborted
+func (w *parallelWriter) fail(err error) {
+	w.mutex.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.mutex.Unlock()
+	w.abort()
+}
+
+func (w *parallelWriter) failed() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	return w.ctx.Err()
+}
+
+func (w *parallelWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		if err := w.failed(); err != nil {
+			return written, err
+		}
+		limit := w.settings.ParallelThreshold
+		if w.part != nil {
+			limit = w.settings.componentSize()
+		}
+		n, end := w.split.cut(p, w.size, limit)
+		var err error
+		if w.part == nil {
+			_, err = w.first.Write(p[:n])
+		} else {
+			w.part.Write(p[:n])
+		}
+		if err != nil {
+			return written, err
+		}
+		written += n
+		w.size += int64(n)
+		p = p[n:]
+		if end {
+			if err := w.nextPart(); err != nil {
+				w.fail(err)
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// nextPart of result, written part is uploaded
+func (w *parallelWriter) nextPart() error {
+	if w.part != nil {
+		w.uploadPart()
+	}
+	if len(w.components) == maxComponents {
+		return &composeError{fmt.Errorf("result has more than %d components of %d bytes", maxComponents, w.settings.componentSize())}
+	}
+	w.part = bytes.NewBuffer(make([]byte, 0, w.settings.componentSize()))
+	w.size = 0
+	return nil
+}
+
+// uploadPart as next component, waits while other components are uploaded with all slots
+func (w *parallelWriter) uploadPart() {
+	name := fmt.Sprintf("%s.component-%d", w.name, len(w.components))
+	w.components = append(w.components, name)
+	data := w.part.Bytes()
+	select {
+	case w.slots <- struct{}{}:
+	case <-w.ctx.Done():
+		w.fail(w.ctx.Err())
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() { <-w.slots }()
+		if err := w.store.upload(w.ctx, name, data); err != nil {
+			w.fail(err)
+		}
+	}()
+}
+
+// Close uploads last component and composes result; result object is not left with part of result
+func (w *parallelWriter) Close() error {
+	defer w.abort()
+	if w.part != nil && w.part.Len() > 0 && w.failed() == nil {
+		w.uploadPart()
+	}
+	w.wg.Wait()
+	if err := w.failed(); err != nil {
+		// aborted writer doesn't create result object
+		w.abort()
+		w.first.Close()
+		w.remove(w.components)
+		return err
+	}
+	if err := w.first.Close(); err != nil {
+		w.remove(w.components)
+		return err
+	}
+	if len(w.components) == 0 {
+		return nil
+	}
+	intermediates, err := w.compose()
+	w.remove(append(w.components, intermediates...))
+	if err != nil {
+		// result object has first part only
+		w.remove([]string{w.name})
+		return err
+	}
+	return nil
+}
+
+// compose components into result object after first part
+func (w *parallelWriter) compose() ([]string, error) {
+	intermediates, err := composeInto(w.ctx, w.store, w.name, w.components)
+	if err != nil {
+		return intermediates, w.composeErr(err)
+	}
+	return intermediates, nil
+}
+
+// composeInto object name after its first part, sources above 31 are composed into intermediates first;
+// returns intermediates, which are removed by caller
+func composeInto(ctx context.Context, store componentStore, name string, sources []string) ([]string, error) {
+	var intermediates []string
+	if len(sources)+1 > maxComposeSources {
+		for i := 0; i < len(sources); i += maxComposeSources {
+			intermediates = append(intermediates, fmt.Sprintf("%s.composed-%d", name, len(intermediates)))
+		}
+		errs := make([]error, len(intermediates))
+		var wg sync.WaitGroup
+		for i, intermediate := range intermediates {
+			end := (i + 1) * maxComposeSources
+			if end > len(sources) {
+				end = len(sources)
+			}
+			wg.Add(1)
+			go func(i int, intermediate string, group []string) {
+				defer wg.Done()
+				errs[i] = store.compose(ctx, intermediate, group)
+			}(i, intermediate, sources[i*maxComposeSources:end])
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return intermediates, err
+			}
+		}
+		sources = intermediates
+	}
+	return intermediates, store.compose(ctx, name, append([]string{name}, sources...))
+}
+
+// composeErr of failed compose, error of aborted upload is returned as it is
+func (w *parallelWriter) composeErr(err error) error {
+	if w.ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+	return &composeError{err}
+}
+
+// remove objects of upload, objects are deleted when upload was aborted too
+func (w *parallelWriter) remove(names []string) {
+	removeObjects(w.store, names, w.settings.Parallelism, w.logger)
+}
+
+// removeObjects of store, parallelism objects at once
+func removeObjects(store componentStore, names []string, parallelism int, logger zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if parallelism < 1 {
+		parallelism = defaultParallelUploads
+	}
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for _, name := range names {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := store.delete(ctx, name); err != nil {
+				logger.Warn().Err(err).Str("object", name).Msg("Cannot delete component of result")
+			}
+		}(name)
+	}
+	wg.Wait()
+}
+
+// writeSimple result again with single writer when components of result can't be composed;
+// false when err is not compose error, otherwise job is finished with result or with error
+func (job *Job) writeSimple(err error) bool {
+	var composeErr *composeError
+	if !errors.As(err, &composeErr) {
+		return false
+	}
+	job.mutex.Lock()
+	parallel := job.parallel
+	// result is written once more at most
+	job.parallel = nil
+	waitedAt := job.waitedAt
+	job.mutex.Unlock()
+	if parallel == nil {
+		return false
+	}
+	if job.now().Sub(waitedAt) >= anonymousTableTTL {
+		job.logger.Warn().Err(err).Msg("Result expired from BigQuery temporary table, result is not written again")
+		return false
+	}
+	job.logger.Warn().Err(err).Msg("Parallel upload failed, writing result with single writer")
+	job.addEvent(EventRetry, "parallel upload failed, writing result with single writer")
+	job.setPhase(PhaseReading)
+	exportCtx := job.phaseContext()
+	it, schema, readErr := job.reread(exportCtx)
+	if readErr != nil {
+		if job.cancelled(readErr) {
+			job.finish()
+			return true
+		}
+		job.cancelWithError(fmt.Errorf("%s; reading result again failed: %s", err, readErr))
+		return true
+	}
+	job.mutex.Lock()
+	job.rowsWritten = 0
+	job.bytesUploaded = 0
+	job.mutex.Unlock()
+	ctx, abortUpload := context.WithCancel(exportCtx)
+	defer abortUpload()
+	w := instrumentWriter(parallel.newWriter(ctx), metrics.GCS, "result_upload")
+	job.writeResult(it, schema, w, func() {
+		abortUpload()
+		if err := parallel.delete(context.Background(), parallel.object()); err != nil {
+			job.logger.Warn().Err(err).Msg("Cannot delete partial result")
+		}
+	})
+	return true
+}
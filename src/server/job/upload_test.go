@@ -0,0 +1,336 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// fakeComponents stores objects in memory; writes of bandwidth bytes per second are delayed by size,
+// blocked component uploads wait until aborted
+type fakeComponents struct {
+	mutex        sync.Mutex
+	objects      map[string][]byte
+	uploaded     map[string][]byte
+	composed     [][]string
+	deleted      []string
+	composeErr   error
+	bandwidth    int
+	block        bool
+	uploading    int
+	maxUploading int
+}
+
+func newFakeComponents() *fakeComponents {
+	return &fakeComponents{objects: map[string][]byte{}, uploaded: map[string][]byte{}}
+}
+
+func (f *fakeComponents) object() string {
+	return "result.csv"
+}
+
+// transfer of n bytes with bandwidth of stream
+func (f *fakeComponents) transfer(ctx context.Context, n int) error {
+	var delay time.Duration
+	if f.bandwidth > 0 {
+		delay = time.Duration(n) * time.Second / time.Duration(f.bandwidth)
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *fakeComponents) newWriter(ctx context.Context) resultWriter {
+	return &fakeObjectWriter{store: f, ctx: ctx}
+}
+
+func (f *fakeComponents) upload(ctx context.Context, name string, data []byte) error {
+	f.mutex.Lock()
+	f.uploading++
+	if f.uploading > f.maxUploading {
+		f.maxUploading = f.uploading
+	}
+	f.mutex.Unlock()
+	defer func() {
+		f.mutex.Lock()
+		f.uploading--
+		f.mutex.Unlock()
+	}()
+	if f.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if err := f.transfer(ctx, len(data)); err != nil {
+		return err
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.objects[name] = append([]byte{}, data...)
+	f.uploaded[name] = f.objects[name]
+	return nil
+}
+
+func (f *fakeComponents) compose(ctx context.Context, dst string, sources []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.composeErr != nil {
+		return f.composeErr
+	}
+	if len(sources) > maxComposeSources {
+		return fmt.Errorf("%d sources", len(sources))
+	}
+	var composed []byte
+	for _, name := range sources {
+		data, ok := f.objects[name]
+		if !ok {
+			return fmt.Errorf("no source %s", name)
+		}
+		composed = append(composed, data...)
+	}
+	f.objects[dst] = composed
+	f.composed = append(f.composed, sources)
+	return nil
+}
+
+func (f *fakeComponents) delete(ctx context.Context, name string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	delete(f.objects, name)
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+// names of stored objects
+func (f *fakeComponents) names() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var names []string
+	for name := range f.objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fakeObjectWriter stores object on Close unless it was aborted
+type fakeObjectWriter struct {
+	bytes.Buffer
+	store *fakeComponents
+	ctx   context.Context
+}
+
+func (w *fakeObjectWriter) Write(p []byte) (int, error) {
+	if err := w.store.transfer(w.ctx, len(p)); err != nil {
+		return 0, err
+	}
+	return w.Buffer.Write(p)
+}
+
+func (w *fakeObjectWriter) Close() error {
+	if err := w.ctx.Err(); err != nil {
+		return err
+	}
+	w.store.mutex.Lock()
+	defer w.store.mutex.Unlock()
+	w.store.objects[w.store.object()] = w.Bytes()
+	return nil
+}
+
+// writeChunks of data to w in chunks of size
+func writeChunks(w resultWriter, data []byte, size int) error {
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		if _, err := w.Write(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// fixtureCSV of rows with quoted line breaks
+func fixtureCSV(rows int) []byte {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"id", "text"})
+	for i := 0; i < rows; i++ {
+		w.Write([]string{fmt.Sprint(i), fmt.Sprintf("line\n\"quoted\" %d", i)})
+	}
+	w.Flush()
+	return b.Bytes()
+}
+
+func TestParseUploadSettings(t *testing.T) {
+	s, err := parseUploadSettings("", "", "")
+	if err != nil || s.ChunkSize != 0 || s.ParallelThreshold != 0 || s.Parallelism != defaultParallelUploads {
+		t.Errorf("unexpected default settings %+v %v", s, err)
+	}
+	s, err = parseUploadSettings("8388608", "1073741824", "8")
+	if err != nil || s.ChunkSize != 8388608 || s.ParallelThreshold != 1073741824 || s.Parallelism != 8 || s.componentSize() != 8388608 {
+		t.Errorf("unexpected settings %+v %v", s, err)
+	}
+	for _, values := range [][3]string{{"1024", "", ""}, {"", "-1", ""}, {"", "1GB", ""}, {"", "", "0"}, {"", "", "33"}} {
+		if _, err := parseUploadSettings(values[0], values[1], values[2]); err == nil {
+			t.Errorf("%v: expected error", values)
+		}
+	}
+}
+
+func TestParallelUploadCSVRows(t *testing.T) {
+	store := newFakeComponents()
+	data := fixtureCSV(200)
+	w := newParallelWriter(context.Background(), store, UploadSettings{ChunkSize: 64, ParallelThreshold: 100, Parallelism: 3}, &rowSplitter{}, zerolog.Nop())
+	if err := writeChunks(w, data, 7); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if names := store.names(); len(names) != 1 || !bytes.Equal(store.objects["result.csv"], data) {
+		t.Fatalf("expected result composed from components, got %v", names)
+	}
+	if len(store.uploaded) < 2 || store.maxUploading > 3 {
+		t.Errorf("expected components uploaded 3 at most at once, got %d components, %d at once", len(store.uploaded), store.maxUploading)
+	}
+	var rows, size int
+	for name, component := range store.uploaded {
+		// component ends with row, so it's read as CSV on its own
+		records, err := csv.NewReader(bytes.NewReader(component)).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		rows += len(records)
+		size += len(component)
+	}
+	first, err := csv.NewReader(bytes.NewReader(data[:len(data)-size])).ReadAll()
+	if err != nil || rows+len(first) != 201 {
+		t.Errorf("expected header and 200 rows, got %d %v", rows+len(first), err)
+	}
+}
+
+func TestParallelUploadTwoLevelCompose(t *testing.T) {
+	store := newFakeComponents()
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	w := newParallelWriter(context.Background(), store, UploadSettings{ChunkSize: 10, ParallelThreshold: 10, Parallelism: 4}, byteSplitter{}, zerolog.Nop())
+	if err := writeChunks(w, data, 33); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(store.objects["result.csv"], data) || len(store.names()) != 1 {
+		t.Fatalf("expected result composed from components, got %v", store.names())
+	}
+	// 99 components are composed into 4 intermediates, which are composed with first part
+	last := store.composed[len(store.composed)-1]
+	if len(store.uploaded) != 99 || len(store.composed) != 5 || len(last) != 5 || last[0] != "result.csv" {
+		t.Errorf("unexpected compose of %d components %v", len(store.uploaded), store.composed)
+	}
+}
+
+func TestParallelUploadCancel(t *testing.T) {
+	store := newFakeComponents()
+	store.block = true
+	ctx, cancel := context.WithCancel(context.Background())
+	w := newParallelWriter(ctx, store, UploadSettings{ChunkSize: 10, ParallelThreshold: 1, Parallelism: 2}, byteSplitter{}, zerolog.Nop())
+	done := make(chan error, 1)
+	go func() {
+		// first byte is written to result object, writer waits for free slot after two components
+		done <- writeChunks(w, bytes.Repeat([]byte("x"), 100), 100)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected cancelled write, got %v", err)
+	}
+	if err := w.Close(); err != context.Canceled {
+		t.Errorf("expected cancelled upload, got %v", err)
+	}
+	if names := store.names(); len(names) != 0 || len(store.deleted) != 3 {
+		t.Errorf("expected components deleted, got objects %v, deleted %v", names, store.deleted)
+	}
+}
+
+func TestParallelUploadComponentLimit(t *testing.T) {
+	store := newFakeComponents()
+	w := newParallelWriter(context.Background(), store, UploadSettings{ChunkSize: 1, ParallelThreshold: 1, Parallelism: 8}, byteSplitter{}, zerolog.Nop())
+	err := writeChunks(w, bytes.Repeat([]byte("x"), maxComponents+10), 100)
+	var composeErr *composeError
+	if !errors.As(err, &composeErr) {
+		t.Fatalf("expected compose error, got %v", err)
+	}
+	if err := w.Close(); !errors.As(err, &composeErr) {
+		t.Errorf("expected compose error, got %v", err)
+	}
+	if names := store.names(); len(names) != 0 {
+		t.Errorf("expected no objects, got %d", len(names))
+	}
+}
+
+func TestWriteSimpleOnComposeError(t *testing.T) {
+	var rereads int
+	job := fallbackJob(&fakeResultWriter{}, &rereads)
+	job.fallback = nil
+	store := newFakeComponents()
+	store.composeErr = errors.New("source objects are in different storage classes")
+	job.upload = UploadSettings{ChunkSize: 8, ParallelThreshold: 16, Parallelism: 2}
+	job.parallel = store
+	statuses := collectStatus(job)
+	ctx, abort := context.WithCancel(context.Background())
+	defer abort()
+	job.writeResult(newFakeIterator(3), fakeSchema, job.newResultWriter(ctx), func() { t.Error("unexpected cleanup") })
+	if s := <-statuses; len(s) != 2 || s[1] != int32(proto.Query_JOB_STATUS_DONE) {
+		t.Fatalf("expected done status, got %v: %s", s, job.Err())
+	}
+	// result is written again with single writer, components are deleted
+	result := string(store.objects["result.csv"])
+	if rereads != 1 || len(store.names()) != 1 || !strings.HasPrefix(result, "id,text\n0,some text value\n") || strings.Count(result, "\n") != 4 {
+		t.Errorf("expected result written again, got %d reads, objects %v, result %q", rereads, store.names(), result)
+	}
+	if snapshot := job.GetStatus(); snapshot.RowsWritten != 3 || snapshot.ResultSize != int64(len(result)) {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+}
+
+// BenchmarkUpload of result with single writer and in parallel components, streams have same bandwidth
+func BenchmarkUpload(b *testing.B) {
+	data := fixtureCSV(20000)
+	for name, settings := range map[string]UploadSettings{
+		"single":   {},
+		"parallel": {ChunkSize: 64 << 10, ParallelThreshold: 64 << 10, Parallelism: 8},
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				store := newFakeComponents()
+				store.bandwidth = 64 << 20
+				var w resultWriter = store.newWriter(context.Background())
+				if settings.ParallelThreshold > 0 {
+					w = newParallelWriter(context.Background(), store, settings, &rowSplitter{}, zerolog.Nop())
+				}
+				if err := writeChunks(w, data, 64<<10); err != nil {
+					b.Fatal(err)
+				}
+				if err := w.Close(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
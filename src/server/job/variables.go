@@ -0,0 +1,151 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxVariables of report
+const maxVariables = 100
+
+var variableNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// placeholderRe matches {{name}}, anything between braces is placeholder so typos are reported
+var placeholderRe = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+
+var identifierRe = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+var numberRe = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// UnknownVariablesError when query text has placeholders of variables not declared on report
+type UnknownVariablesError struct {
+	// Names of placeholders in order of first appearance
+	Names []string
+}
+
+func (e *UnknownVariablesError) Error() string {
+	placeholders := make([]string, len(e.Names))
+	for i, name := range e.Names {
+		placeholders[i] = "{{" + name + "}}"
+	}
+	return fmt.Sprintf("unknown variables %s, declare them in report variables", strings.Join(placeholders, ", "))
+}
+
+// ValidateVariables of report: names are unique, types are set and default values are valid for type
+func ValidateVariables(variables []*proto.ReportVariable) error {
+	if len(variables) > maxVariables {
+		return fmt.Errorf("too many variables, at most %d allowed", maxVariables)
+	}
+	names := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		if !variableNameRe.MatchString(variable.Name) {
+			return fmt.Errorf("invalid variable name %q, expected letters, digits and _", variable.Name)
+		}
+		if names[variable.Name] {
+			return fmt.Errorf("duplicate variable %s", variable.Name)
+		}
+		names[variable.Name] = true
+		if _, ok := proto.ReportVariable_Type_name[int32(variable.Type)]; !ok || variable.Type == proto.ReportVariable_TYPE_UNSPECIFIED {
+			return fmt.Errorf("variable %s has no type", variable.Name)
+		}
+		if variable.DefaultValue == "" {
+			continue
+		}
+		if _, err := renderVariable(variable, variable.DefaultValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderVariable value as SQL of variable type; negative number is parenthesized, so 5-{{n}} with n=-1 is not
+// rendered as 5--1 comment, unsigned number stays bare for LIMIT
+func renderVariable(variable *proto.ReportVariable, value string) (string, error) {
+	switch variable.Type {
+	case proto.ReportVariable_TYPE_STRING:
+		return quoteString(value), nil
+	case proto.ReportVariable_TYPE_NUMBER:
+		if !numberRe.MatchString(value) {
+			return "", fmt.Errorf("invalid value of variable %s: %q is not a number", variable.Name, value)
+		}
+		if strings.HasPrefix(value, "-") {
+			return "(" + value + ")", nil
+		}
+		return value, nil
+	case proto.ReportVariable_TYPE_IDENTIFIER:
+		if !identifierRe.MatchString(value) {
+			return "", fmt.Errorf("invalid value of variable %s: identifier %q can have only A-Z, a-z, 0-9, _ and .", variable.Name, value)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("variable %s has no type", variable.Name)
+}
+
+// quoteString as BigQuery string literal
+func quoteString(value string) string {
+	return "'" + strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		"\n", `\n`,
+		"\r", `\r`,
+	).Replace(value) + "'"
+}
+
+// SubstituteVariables of report into {{name}} placeholders of query text; values override default values.
+// Placeholders of undeclared variables are reported with UnknownVariablesError
+func SubstituteVariables(queryText string, variables []*proto.ReportVariable, values map[string]string) (string, error) {
+	byName := make(map[string]*proto.ReportVariable, len(variables))
+	for _, variable := range variables {
+		byName[variable.Name] = variable
+	}
+	var undeclared []string
+	for name := range values {
+		if byName[name] == nil {
+			undeclared = append(undeclared, name)
+		}
+	}
+	if len(undeclared) > 0 {
+		sort.Strings(undeclared)
+		return "", fmt.Errorf("values set for undeclared variables %s", strings.Join(undeclared, ", "))
+	}
+
+	unknown := &UnknownVariablesError{}
+	seen := map[string]bool{}
+	var renderErr error
+	substituted := placeholderRe.ReplaceAllStringFunc(queryText, func(placeholder string) string {
+		name := placeholderRe.FindStringSubmatch(placeholder)[1]
+		variable := byName[name]
+		if variable == nil {
+			if !seen[name] {
+				seen[name] = true
+				unknown.Names = append(unknown.Names, name)
+			}
+			return placeholder
+		}
+		value, ok := values[name]
+		if !ok {
+			value = variable.DefaultValue
+		}
+		if value == "" && variable.Type != proto.ReportVariable_TYPE_STRING {
+			if renderErr == nil {
+				renderErr = fmt.Errorf("variable %s has no value", name)
+			}
+			return placeholder
+		}
+		rendered, err := renderVariable(variable, value)
+		if err != nil && renderErr == nil {
+			renderErr = err
+		}
+		return rendered
+	})
+	if len(unknown.Names) > 0 {
+		return "", unknown
+	}
+	if renderErr != nil {
+		return "", renderErr
+	}
+	return substituted, nil
+}
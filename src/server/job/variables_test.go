@@ -0,0 +1,100 @@
+package job
+
+import (
+	"dekart/src/proto"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var testVariables = []*proto.ReportVariable{
+	{Name: "start_date", Type: proto.ReportVariable_TYPE_STRING, DefaultValue: "2021-01-01"},
+	{Name: "limit", Type: proto.ReportVariable_TYPE_NUMBER, DefaultValue: "100"},
+	{Name: "dataset", Type: proto.ReportVariable_TYPE_IDENTIFIER, DefaultValue: "project.events"},
+	{Name: "filter", Type: proto.ReportVariable_TYPE_STRING},
+}
+
+func TestSubstituteVariables(t *testing.T) {
+	for name, c := range map[string]struct {
+		query    string
+		values   map[string]string
+		expected string
+	}{
+		"no placeholders": {
+			"select 1", nil, "select 1",
+		},
+		"default values": {
+			"select * from `{{dataset}}.points` where day >= {{ start_date }} limit {{limit}}", nil,
+			"select * from `project.events.points` where day >= '2021-01-01' limit 100",
+		},
+		"values override defaults": {
+			"select * from {{dataset}}.t where day = {{start_date}} limit {{limit}}",
+			map[string]string{"dataset": "other", "limit": "-1.5e3"},
+			"select * from other.t where day = '2021-01-01' limit (-1.5e3)",
+		},
+		"negative number is not comment": {
+			"select 5-{{limit}}", map[string]string{"limit": "-1"}, "select 5-(-1)",
+		},
+		"string is escaped": {
+			"select {{filter}}", map[string]string{"filter": "it's \\ ' or 1=1\n--"},
+			`select 'it\'s \\ \' or 1=1\n--'`,
+		},
+		"empty string": {
+			"select {{filter}}", nil, "select ''",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			substituted, err := SubstituteVariables(c.query, testVariables, c.values)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if substituted != c.expected {
+				t.Errorf("expected\n%s\ngot\n%s", c.expected, substituted)
+			}
+		})
+	}
+}
+
+func TestSubstituteVariablesErrors(t *testing.T) {
+	for name, c := range map[string]struct {
+		query  string
+		values map[string]string
+	}{
+		"identifier injection":  {"select * from {{dataset}}", map[string]string{"dataset": "t; drop table x"}},
+		"identifier with quote": {"select * from `{{dataset}}`", map[string]string{"dataset": "a`b"}},
+		"number injection":      {"select {{limit}}", map[string]string{"limit": "1 or 1=1"}},
+		"number without value":  {"select {{limit}}", map[string]string{"limit": ""}},
+		"undeclared value":      {"select 1", map[string]string{"end_date": "2021-01-01"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := SubstituteVariables(c.query, testVariables, c.values); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	_, err := SubstituteVariables("select {{a}}, {{ start_date }}, {{b c}}, {{a}}", testVariables, nil)
+	var unknown *UnknownVariablesError
+	if !errors.As(err, &unknown) || !reflect.DeepEqual(unknown.Names, []string{"a", "b c"}) {
+		t.Errorf("expected unknown variables a and b c, got %v", err)
+	}
+}
+
+func TestValidateVariables(t *testing.T) {
+	if err := ValidateVariables(testVariables); err != nil {
+		t.Error(err)
+	}
+	for name, variables := range map[string][]*proto.ReportVariable{
+		"invalid name":          {{Name: "1day", Type: proto.ReportVariable_TYPE_STRING}},
+		"duplicate name":        {{Name: "a", Type: proto.ReportVariable_TYPE_STRING}, {Name: "a", Type: proto.ReportVariable_TYPE_NUMBER}},
+		"no type":               {{Name: "a"}},
+		"unknown type":          {{Name: "a", Type: 42}},
+		"invalid default value": {{Name: "a", Type: proto.ReportVariable_TYPE_IDENTIFIER, DefaultValue: "a-b"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateVariables(variables); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
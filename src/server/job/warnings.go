@@ -0,0 +1,65 @@
+package job
+
+import (
+	"encoding/json"
+)
+
+// Warning codes of conditions which don't fail job
+const (
+	// WarningStatisticsUnavailable when BigQuery job has no statistics, processed bytes are unknown
+	WarningStatisticsUnavailable = "STATISTICS_UNAVAILABLE"
+	// WarningH3RowsSkipped when rows without valid location are not aggregated into cells
+	WarningH3RowsSkipped = "H3_ROWS_SKIPPED"
+	// WarningH3Skipped when H3 aggregation exceeded memory budget, result is stored without aggregated result
+	WarningH3Skipped = "H3_AGGREGATION_SKIPPED"
+	// WarningControlCharacters when control characters in string cells were replaced or removed
+	WarningControlCharacters = "CONTROL_CHARACTERS"
+	// WarningExactCountUnavailable when rows of sampled or limited query were not counted, result is kept
+	WarningExactCountUnavailable = "EXACT_COUNT_UNAVAILABLE"
+	// WarningFormatFailed when result in additional format was not stored, result in format of run is kept
+	WarningFormatFailed = "FORMAT_RESULT_FAILED"
+	// WarningUnknownTypes when columns of types without conversion were written as text BigQuery sent
+	WarningUnknownTypes = "UNKNOWN_COLUMN_TYPES"
+	// WarningOrderNotPreserved when query has ORDER BY but rows of result are not stored in its order
+	WarningOrderNotPreserved = "ORDER_NOT_PRESERVED"
+)
+
+// Warning of job shown to user, job still has result
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// AddWarning to job; repeated condition with same code replaces message of first warning, so warnings are not duplicated
+func (job *Job) AddWarning(code string, message string) {
+	job.mutex.Lock()
+	defer job.mutex.Unlock()
+	for i := range job.warnings {
+		if job.warnings[i].Code == code {
+			job.warnings[i].Message = message
+			return
+		}
+	}
+	job.warnings = append(job.warnings, Warning{Code: code, Message: message})
+	job.appendEvent(EventWarning, code)
+	job.logger.Debug().Str("code", code).Msg("Job warning")
+}
+
+// MarshalWarnings stored in job record, empty when there are no warnings
+func MarshalWarnings(warnings []Warning) (string, error) {
+	if len(warnings) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(warnings)
+	return string(b), err
+}
+
+// UnmarshalWarnings of job record
+func UnmarshalWarnings(value string) ([]Warning, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var warnings []Warning
+	err := json.Unmarshal([]byte(value), &warnings)
+	return warnings, err
+}
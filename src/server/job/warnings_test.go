@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+)
+
+func TestAddWarning(t *testing.T) {
+	job := NewStore().New(context.Background(), "report", "query")
+	if warnings := job.GetStatus().Warnings; warnings != nil {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+	job.AddWarning(WarningH3RowsSkipped, "1 rows")
+	job.AddWarning(WarningStatisticsUnavailable, "no statistics")
+	snapshot := job.GetStatus()
+	// repeated condition updates its warning
+	job.AddWarning(WarningH3RowsSkipped, "2 rows")
+	expected := []Warning{{WarningH3RowsSkipped, "2 rows"}, {WarningStatisticsUnavailable, "no statistics"}}
+	if warnings := job.GetStatus().Warnings; !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("expected %v, got %v", expected, warnings)
+	}
+	if snapshot.Warnings[0].Message != "1 rows" {
+		t.Errorf("expected snapshot not changed by later warnings, got %v", snapshot.Warnings)
+	}
+}
+
+func TestStatisticsUnavailableWarning(t *testing.T) {
+	job := NewStore().New(context.Background(), "report", "query")
+	for i := 0; i < 2; i++ {
+		job.setJobStats(&bigquery.JobStatus{}, 10)
+	}
+	if warnings := job.GetStatus().Warnings; len(warnings) != 1 || warnings[0].Code != WarningStatisticsUnavailable {
+		t.Errorf("expected one warning, got %v", warnings)
+	}
+	job = NewStore().New(context.Background(), "report", "query")
+	job.setJobStats(&bigquery.JobStatus{Statistics: &bigquery.JobStatistics{TotalBytesProcessed: 1}}, 10)
+	if warnings := job.GetStatus().Warnings; warnings != nil {
+		t.Errorf("expected no warnings with statistics, got %v", warnings)
+	}
+}
+
+func TestMarshalWarnings(t *testing.T) {
+	for _, warnings := range [][]Warning{nil, {{WarningH3RowsSkipped, "1 rows"}, {WarningStatisticsUnavailable, "no statistics"}}} {
+		value, err := MarshalWarnings(warnings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unmarshaled, err := UnmarshalWarnings(value)
+		if err != nil || !reflect.DeepEqual(unmarshaled, warnings) {
+			t.Errorf("expected %v, got %v %v", warnings, unmarshaled, err)
+		}
+	}
+	if value, _ := MarshalWarnings(nil); value != "" {
+		t.Errorf("expected empty value without warnings, got %q", value)
+	}
+	if _, err := UnmarshalWarnings("{"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,243 @@
+package job
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// wkbTypes of WKT geometry names, names are GeoParquet geometry_types
+var wkbTypes = map[string]struct {
+	code uint32
+	name string
+}{
+	"POINT":              {1, "Point"},
+	"LINESTRING":         {2, "LineString"},
+	"POLYGON":            {3, "Polygon"},
+	"MULTIPOINT":         {4, "MultiPoint"},
+	"MULTILINESTRING":    {5, "MultiLineString"},
+	"MULTIPOLYGON":       {6, "MultiPolygon"},
+	"GEOMETRYCOLLECTION": {7, "GeometryCollection"},
+}
+
+// bbox of coordinates as minx, miny, maxx, maxy; empty until first point is added
+type bbox struct {
+	min, max [2]float64
+	empty    bool
+}
+
+func newBBox() bbox {
+	return bbox{empty: true}
+}
+
+func (b *bbox) add(x, y float64) {
+	if b.empty {
+		b.min, b.max, b.empty = [2]float64{x, y}, [2]float64{x, y}, false
+		return
+	}
+	b.min = [2]float64{math.Min(b.min[0], x), math.Min(b.min[1], y)}
+	b.max = [2]float64{math.Max(b.max[0], x), math.Max(b.max[1], y)}
+}
+
+func (b *bbox) extend(o bbox) {
+	if !o.empty {
+		b.add(o.min[0], o.min[1])
+		b.add(o.max[0], o.max[1])
+	}
+}
+
+// wktParser of 2D WKT as BigQuery returns GEOGRAPHY values
+type wktParser struct {
+	s    string
+	i    int
+	wkb  bytes.Buffer
+	bbox bbox
+	// typeName of top level geometry
+	typeName string
+}
+
+// wktToWKB encodes WKT geometry as little endian WKB and returns GeoParquet type name and bbox of coordinates
+func wktToWKB(wkt string) ([]byte, string, bbox, error) {
+	p := &wktParser{s: wkt, bbox: newBBox()}
+	if err := p.geometry(true); err != nil {
+		return nil, "", p.bbox, fmt.Errorf("invalid WKT %.40q: %s", wkt, err)
+	}
+	p.skipSpace()
+	if p.i != len(p.s) {
+		return nil, "", p.bbox, fmt.Errorf("invalid WKT %.40q: unexpected %q", wkt, p.s[p.i:])
+	}
+	return p.wkb.Bytes(), p.typeName, p.bbox, nil
+}
+
+func (p *wktParser) skipSpace() {
+	for p.i < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.s) && (p.s[p.i] >= 'A' && p.s[p.i] <= 'Z' || p.s[p.i] >= 'a' && p.s[p.i] <= 'z') {
+		p.i++
+	}
+	return strings.ToUpper(p.s[start:p.i])
+}
+
+// consume byte c after spaces, false when next byte is other
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) expect(c byte) error {
+	if !p.consume(c) {
+		return fmt.Errorf("expected %q at %d", c, p.i)
+	}
+	return nil
+}
+
+func (p *wktParser) uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	p.wkb.Write(b[:])
+}
+
+func (p *wktParser) float(v float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+	p.wkb.Write(b[:])
+}
+
+func (p *wktParser) header(code uint32) {
+	// byte order 1 is little endian
+	p.wkb.WriteByte(1)
+	p.uint32(code)
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.i]) >= 0 {
+		p.i++
+	}
+	return strconv.ParseFloat(p.s[start:p.i], 64)
+}
+
+// point coordinates x y, added to bbox
+func (p *wktParser) point() error {
+	x, err := p.number()
+	if err != nil {
+		return err
+	}
+	y, err := p.number()
+	if err != nil {
+		return err
+	}
+	p.float(x)
+	p.float(y)
+	p.bbox.add(x, y)
+	return nil
+}
+
+// list of items in parentheses, count is written before items; EMPTY is list without items
+func (p *wktParser) list(item func() error) error {
+	if p.word() == "EMPTY" {
+		p.uint32(0)
+		return nil
+	}
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	countAt := p.wkb.Len()
+	p.uint32(0)
+	var count uint32
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		count++
+		if !p.consume(',') {
+			break
+		}
+	}
+	binary.LittleEndian.PutUint32(p.wkb.Bytes()[countAt:], count)
+	return p.expect(')')
+}
+
+func (p *wktParser) points() error {
+	return p.list(p.point)
+}
+
+func (p *wktParser) rings() error {
+	return p.list(p.points)
+}
+
+func (p *wktParser) geometry(top bool) error {
+	name := p.word()
+	t, ok := wkbTypes[name]
+	if !ok {
+		return fmt.Errorf("unsupported geometry type %q", name)
+	}
+	if top {
+		p.typeName = t.name
+	}
+	start := p.i
+	if dimension := p.word(); dimension != "" && dimension != "EMPTY" {
+		return fmt.Errorf("unsupported dimension %s, only 2D geometries are supported", dimension)
+	}
+	p.i = start
+	p.header(t.code)
+	switch name {
+	case "POINT":
+		if p.word() == "EMPTY" {
+			// empty point has NaN coordinates in WKB, quiet NaN without payload as GEOS writes it
+			p.float(math.Float64frombits(0x7ff8000000000000))
+			p.float(math.Float64frombits(0x7ff8000000000000))
+			return nil
+		}
+		if err := p.expect('('); err != nil {
+			return err
+		}
+		if err := p.point(); err != nil {
+			return err
+		}
+		return p.expect(')')
+	case "LINESTRING":
+		return p.points()
+	case "POLYGON":
+		return p.rings()
+	case "MULTIPOINT":
+		return p.list(func() error {
+			p.header(wkbTypes["POINT"].code)
+			// points of multipoint are written with or without parentheses
+			if p.consume('(') {
+				if err := p.point(); err != nil {
+					return err
+				}
+				return p.expect(')')
+			}
+			return p.point()
+		})
+	case "MULTILINESTRING":
+		return p.list(func() error {
+			p.header(wkbTypes["LINESTRING"].code)
+			return p.points()
+		})
+	case "MULTIPOLYGON":
+		return p.list(func() error {
+			p.header(wkbTypes["POLYGON"].code)
+			return p.rings()
+		})
+	}
+	return p.list(func() error {
+		return p.geometry(false)
+	})
+}
@@ -0,0 +1,78 @@
+package job
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestWKTToWKB(t *testing.T) {
+	for wkt, expected := range map[string]struct {
+		wkb      string
+		typeName string
+		bbox     [4]float64
+	}{
+		"POINT(1 2)": {
+			"0101000000000000000000f03f0000000000000040", "Point", [4]float64{1, 2, 1, 2},
+		},
+		"LINESTRING(-1 -2, 3.5 4)": {
+			"010200000002000000000000000000f0bf00000000000000c00000000000000c400000000000001040",
+			"LineString", [4]float64{-1, -2, 3.5, 4},
+		},
+		"MULTIPOINT(0 0, (1 1))": {
+			"0104000000020000000101000000000000000000000000000000000000000101000000000000000000f03f000000000000f03f",
+			"MultiPoint", [4]float64{0, 0, 1, 1},
+		},
+		"GEOMETRYCOLLECTION(POINT(5 6), LINESTRING EMPTY)": {
+			"010700000002000000010100000000000000000014400000000000001840010200000000000000",
+			"GeometryCollection", [4]float64{5, 6, 5, 6},
+		},
+	} {
+		wkb, typeName, bbox, err := wktToWKB(wkt)
+		if err != nil {
+			t.Errorf("%s: %s", wkt, err)
+			continue
+		}
+		if hex.EncodeToString(wkb) != expected.wkb {
+			t.Errorf("%s: expected %s, got %s", wkt, expected.wkb, hex.EncodeToString(wkb))
+		}
+		if typeName != expected.typeName {
+			t.Errorf("%s: expected %s, got %s", wkt, expected.typeName, typeName)
+		}
+		if got := [4]float64{bbox.min[0], bbox.min[1], bbox.max[0], bbox.max[1]}; bbox.empty || got != expected.bbox {
+			t.Errorf("%s: expected bbox %v, got %v", wkt, expected.bbox, got)
+		}
+	}
+}
+
+func TestWKTToWKBPolygon(t *testing.T) {
+	wkb, typeName, bbox, err := wktToWKB("MULTIPOLYGON(((0 0, 2 0, 2 2, 0 0)), ((10 10, 11 10, 11 12, 10 10)))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header, 2 polygons of header, 1 ring, 4 points
+	if typeName != "MultiPolygon" || len(wkb) != 9+2*(9+4+4*16) {
+		t.Errorf("unexpected %s of %d bytes", typeName, len(wkb))
+	}
+	if bbox.min != [2]float64{0, 0} || bbox.max != [2]float64{11, 12} {
+		t.Errorf("unexpected bbox %+v", bbox)
+	}
+}
+
+func TestWKTToWKBEmpty(t *testing.T) {
+	wkb, typeName, bbox, err := wktToWKB("POINT EMPTY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// empty point has NaN coordinates
+	if typeName != "Point" || hex.EncodeToString(wkb) != "0101000000000000000000f87f000000000000f87f" || !bbox.empty {
+		t.Errorf("unexpected %s %x %+v", typeName, wkb, bbox)
+	}
+}
+
+func TestWKTToWKBInvalid(t *testing.T) {
+	for _, wkt := range []string{"", "POINT(1)", "POINT Z (1 2 3)", "CIRCLE(1 2)", "POINT(1 2) x", "LINESTRING(1 2, 3 4"} {
+		if _, _, _, err := wktToWKB(wkt); err == nil {
+			t.Errorf("%q: expected error", wkt)
+		}
+	}
+}
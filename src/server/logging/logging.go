@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// InfoSampler logs every Nth info and debug message, warnings and errors are always logged; N is changed at runtime
+type InfoSampler struct {
+	every   uint32
+	counter uint32
+}
+
+// Sample implements zerolog.Sampler
+func (s *InfoSampler) Sample(level zerolog.Level) bool {
+	if level > zerolog.InfoLevel {
+		return true
+	}
+	every := atomic.LoadUint32(&s.every)
+	if every <= 1 {
+		return true
+	}
+	return atomic.AddUint32(&s.counter, 1)%every == 1
+}
+
+// Every N info message is logged, 0 and 1 log all messages
+func (s *InfoSampler) Every() uint32 {
+	return atomic.LoadUint32(&s.every)
+}
+
+// SetEvery N info message logged
+func (s *InfoSampler) SetEvery(every uint32) {
+	atomic.StoreUint32(&s.every, every)
+}
+
+// Sampler of global logger, installed by Configure
+var Sampler = &InfoSampler{}
+
+// parseSampleEvery of DEKART_LOG_SAMPLE_INFO, empty logs all messages
+func parseSampleEvery(value string) (uint32, error) {
+	if value == "" {
+		return 0, nil
+	}
+	every, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid DEKART_LOG_SAMPLE_INFO %s, expected number of messages per logged message", value)
+	}
+	return uint32(every), nil
+}
+
+// Configure sampling of global logger, loggers derived from global logger later are sampled too
+func Configure() {
+	every, err := parseSampleEvery(os.Getenv("DEKART_LOG_SAMPLE_INFO"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	Sampler.SetEvery(every)
+	log.Logger = log.Logger.Sample(Sampler)
+}
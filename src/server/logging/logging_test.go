@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestInfoSampler(t *testing.T) {
+	var buf bytes.Buffer
+	sampler := &InfoSampler{}
+	logger := zerolog.New(&buf).Sample(sampler)
+	count := func() int {
+		defer buf.Reset()
+		return strings.Count(buf.String(), "\n")
+	}
+	for i := 0; i < 10; i++ {
+		logger.Info().Msg("routine")
+	}
+	if n := count(); n != 10 {
+		t.Errorf("expected all messages without sampling, got %d", n)
+	}
+	sampler.SetEvery(5)
+	for i := 0; i < 10; i++ {
+		logger.Info().Msg("routine")
+		logger.Warn().Msg("warning")
+	}
+	if n := count(); n != 12 {
+		t.Errorf("expected 2 info and 10 warning messages, got %d", n)
+	}
+}
+
+func TestParseSampleEvery(t *testing.T) {
+	for value, expected := range map[string]uint32{"": 0, "1": 1, "100": 100} {
+		if every, err := parseSampleEvery(value); err != nil || every != expected {
+			t.Errorf("%q: expected %d, got %d %v", value, expected, every, err)
+		}
+	}
+	for _, value := range []string{"-1", "x", "0.5"} {
+		if _, err := parseSampleEvery(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
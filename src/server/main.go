@@ -4,18 +4,25 @@ import (
 	"context"
 	"database/sql"
 	"dekart/src/server/dekart"
+	"dekart/src/server/dialect"
+	"dekart/src/server/encryption"
 	"dekart/src/server/http"
 	"dekart/src/server/job"
+	"dekart/src/server/logging"
+	"dekart/src/server/report"
+	"dekart/src/server/schema"
+	"dekart/src/server/secrets"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"net/url"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"cloud.google.com/go/storage"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/lib/pq"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -35,71 +42,241 @@ func configureLogger() {
 	} else {
 		zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	}
+	logging.Configure()
 	log.Info().Msgf("Log level: %s", zerolog.GlobalLevel().String())
 
 }
 
-func configureDb() *sql.DB {
-	db, err := sql.Open("postgres", fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		os.Getenv("DEKART_POSTGRES_USER"),
-		os.Getenv("DEKART_POSTGRES_PASSWORD"),
+func postgresDSN(userinfo string) string {
+	return fmt.Sprintf(
+		"postgres://%s@%s:%s/%s?sslmode=disable",
+		userinfo,
 		os.Getenv("DEKART_POSTGRES_HOST"),
 		os.Getenv("DEKART_POSTGRES_PORT"),
 		os.Getenv("DEKART_POSTGRES_DB"),
-	))
+	)
+}
+
+// databaseSecret reference of DEKART_DATABASE_URL or DEKART_POSTGRES_PASSWORD, empty when credentials are set as values
+func databaseSecret() string {
+	if value := os.Getenv("DEKART_DATABASE_URL"); value != "" {
+		if secrets.IsReference(value) {
+			return value
+		}
+		return ""
+	}
+	if password := os.Getenv("DEKART_POSTGRES_PASSWORD"); secrets.IsReference(password) {
+		return password
+	}
+	return ""
+}
+
+// resolveDatabaseURL is DEKART_DATABASE_URL, sqlite://path for SQLite file, mysql://dsn for MySQL,
+// or Postgres DSN of DEKART_POSTGRES_* variables; DEKART_DATABASE_URL or DEKART_POSTGRES_PASSWORD
+// can be secret reference resolved by secrets package
+func resolveDatabaseURL(ctx context.Context) (string, error) {
+	value := os.Getenv("DEKART_DATABASE_URL")
+	if value != "" && secrets.IsReference(value) {
+		return secrets.Resolve(ctx, value)
+	}
+	if value != "" {
+		return value, nil
+	}
+	user, password := os.Getenv("DEKART_POSTGRES_USER"), os.Getenv("DEKART_POSTGRES_PASSWORD")
+	if !secrets.IsReference(password) {
+		return postgresDSN(user + ":" + password), nil
+	}
+	resolved, err := secrets.Resolve(ctx, password)
+	if err != nil {
+		return "", err
+	}
+	// resolved password can contain any characters, so it's escaped
+	return postgresDSN(url.UserPassword(user, resolved).String()), nil
+}
+
+func databaseURL() string {
+	value, err := resolveDatabaseURL(context.Background())
 	if err != nil {
 		log.Fatal().Err(err).Send()
 	}
+	return value
+}
+
+func configureDb() *sql.DB {
+	var db *sql.DB
+	if secret := databaseSecret(); secret != "" {
+		// credentials are resolved by every new connection, so rotated secret is used without restart
+		log.Info().Str("secret", secret).Msg("Database credentials are resolved from secret")
+		db = dialect.OpenResolved(dialect.ForURL(databaseURL()), resolveDatabaseURL)
+	} else {
+		var err error
+		db, err = dialect.Open(databaseURL())
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+	}
 	db.SetConnMaxLifetime(0)
 	db.SetMaxIdleConns(3)
 	db.SetMaxOpenConns(3)
 	return db
 }
 
-func applyMigrations(db *sql.DB) {
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		log.Fatal().Err(err).Msg("WithInstance")
+// applyMigrations at startup, with DEKART_AUTO_MIGRATE=0 schema is only checked
+// and migrations are applied with migrate command
+func applyMigrations() {
+	if os.Getenv("DEKART_AUTO_MIGRATE") == "0" {
+		status, err := schema.Check(databaseURL(), schema.Dir())
+		if err != nil {
+			log.Fatal().Err(err).Msg("Database schema check failed, run server migrate")
+		}
+		log.Info().Uint("version", status.Version).Msg("Database schema checked")
+		return
 	}
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"postgres", driver)
+	status, err := schema.Migrate(databaseURL(), schema.Dir())
 	if err != nil {
-		log.Fatal().Err(err).Msg("NewWithDatabaseInstance")
+		log.Fatal().Err(err).Msg("Migrations Up")
 	}
-	err = m.Up()
-	if err != nil {
-		if err == migrate.ErrNoChange {
-			return
+	log.Info().Uint("version", status.Version).Msg("Database schema migrated")
+}
+
+// runCommand of server CLI, returns false when no command is given
+func runCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if args[0] == "seal-secret" && len(args) == 2 {
+		sealSecret(args[1])
+		return true
+	}
+	if args[0] != "migrate" || len(args) > 2 || (len(args) == 2 && args[1] != "version") {
+		log.Fatal().Strs("args", args).Msg("Unknown command, usage: server [migrate [version] | seal-secret name]")
+	}
+	if len(args) == 2 {
+		status, err := schema.Check(databaseURL(), schema.Dir())
+		fmt.Printf("version %d, latest %d, dirty %t\n", status.Version, status.Latest, status.Dirty)
+		if err != nil {
+			log.Fatal().Err(err).Send()
 		}
+		return true
+	}
+	status, err := schema.Migrate(databaseURL(), schema.Dir())
+	if err != nil {
 		log.Fatal().Err(err).Msg("Migrations Up")
 	}
+	fmt.Printf("version %d\n", status.Version)
+	return true
 }
 
-func configureBucket() *storage.BucketHandle {
+// sealSecret value read from stdin with DEKART_SECRETS_KEY, sealed value is printed for DEKART_SECRETS_FILE
+func sealSecret(name string) {
+	value, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	sealed, err := secrets.Seal(os.Getenv("DEKART_SECRETS_KEY"), name, strings.TrimRight(string(value), "\r\n"))
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	fmt.Println(sealed)
+}
+
+// configureBuckets of results; fallback is nil when DEKART_CLOUD_STORAGE_FALLBACK_BUCKET is not set
+func configureBuckets() (bucket *storage.BucketHandle, fallback *storage.BucketHandle) {
 	ctx := context.Background()
 	client, err := storage.NewClient(ctx)
 	if err != nil {
 		log.Fatal().Err(err).Send()
 	}
-	return client.Bucket(os.Getenv("DEKART_CLOUD_STORAGE_BUCKET"))
+	name, fallbackName := os.Getenv("DEKART_CLOUD_STORAGE_BUCKET"), os.Getenv("DEKART_CLOUD_STORAGE_FALLBACK_BUCKET")
+	if fallbackName == "" {
+		return client.Bucket(name), nil
+	}
+	if fallbackName == name {
+		log.Fatal().Msgf("DEKART_CLOUD_STORAGE_FALLBACK_BUCKET %s is same as DEKART_CLOUD_STORAGE_BUCKET", fallbackName)
+	}
+	return client.Bucket(name), client.Bucket(fallbackName)
+}
+
+func configureReportStreams(db *sql.DB) report.Bus {
+	switch os.Getenv("DEKART_STREAM_BUS") {
+	case "postgres":
+		if d := dialect.ForURL(databaseURL()); d != dialect.Postgres {
+			log.Warn().Msgf("DEKART_STREAM_BUS=postgres is not supported with %s, report updates delivered in process", d.Name())
+			return report.NewStreams()
+		}
+		log.Info().Msg("Report updates delivered with Postgres LISTEN/NOTIFY")
+		return report.NewPostgresBus(db, databaseURL())
+	case "":
+		return report.NewStreams()
+	default:
+		log.Fatal().Msgf("Unknown DEKART_STREAM_BUS %s", os.Getenv("DEKART_STREAM_BUS"))
+		return nil
+	}
+}
+
+// shutdownTimeout for storing status of jobs cancelled at shutdown
+const shutdownTimeout = 10 * time.Second
+
+// shutdownOnSignal of deploy cancels jobs of replica, so their queries are not left running
+func shutdownOnSignal(dekartServer *dekart.Server, reportStreams report.Bus) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	sig := <-signals
+	cancelled := dekartServer.Shutdown(shutdownTimeout)
+	log.Info().Str("signal", sig.String()).Int("cancelled", cancelled).Msg("Shutting down")
+	reportStreams.Close()
+	os.Exit(0)
 }
 
 func main() {
 	configureLogger()
 
+	if runCommand(os.Args[1:]) {
+		return
+	}
+
+	encryption.Configure(context.Background())
 	db := configureDb()
 	defer db.Close()
 
-	applyMigrations(db)
+	applyMigrations()
+
+	bucket, fallbackBucket := configureBuckets()
+	// large results are uploaded in parallel and extracted to bucket of results
+	jobs := job.NewStore(job.WithStorage(bucket))
+	replica, err := os.Hostname()
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+	jobs.UseRegistry(dekart.NewJobRegistry(db), replica)
+	jobs.UseStatsRecorder(dekart.NewJobStatsRecorder(db))
+	jobs.UseKeyStore(dekart.NewRunKeys(db))
+	if webhook := dekart.NewJobWebhook(); webhook != nil {
+		jobs.OnJobComplete("webhook", webhook.Notify)
+	}
 
-	bucket := configureBucket()
-	jobs := job.NewStore()
+	reportStreams := configureReportStreams(db)
 
-	dekartServer := dekart.NewServer(db, bucket, jobs)
+	dekartServer := dekart.NewServer(db, bucket, jobs, reportStreams)
+	if fallbackBucket != nil {
+		dekartServer.UseFallbackBucket(os.Getenv("DEKART_CLOUD_STORAGE_FALLBACK_BUCKET"), fallbackBucket)
+	}
+	if os.Getenv("DEKART_SKIP_WARMUP") != "1" {
+		if err := dekartServer.Warmup(context.Background()); err != nil {
+			log.Fatal().Err(err).Send()
+		}
+		dekartServer.CheckPermissions(context.Background())
+	}
+	dekartServer.ScheduleResultsReconcile()
+	dekartServer.ScheduleSchemaSnapshots()
+	dekartServer.ScheduleJobStatsPrune()
+	dekartServer.ScheduleDeletedQueriesSweep()
+	dekartServer.RegisterUsageMetrics()
 
-	httpServer := http.Configure(dekartServer)
-	log.Fatal().Err(httpServer.ListenAndServe()).Send()
+	httpServer := http.Configure(dekartServer, dekart.NewSessionStore(db))
+	go shutdownOnSignal(dekartServer, reportStreams)
+	err = http.ListenAndServe(httpServer)
+	reportStreams.Close()
+	log.Fatal().Err(err).Send()
 
 }
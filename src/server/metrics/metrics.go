@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Backends of external operations
+const (
+	BigQuery = "bigquery"
+	GCS      = "gcs"
+)
+
+var (
+	// OperationDuration of BigQuery API calls and storage operations, failed operations are observed too
+	OperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "dekart",
+		Name:      "backend_operation_duration_seconds",
+		Help:      "Duration of BigQuery API calls and storage operations by backend and operation.",
+		// from single API call to longest query wait
+		Buckets: prometheus.ExponentialBuckets(0.01, 4, 9),
+	}, []string{"backend", "operation"})
+
+	// OperationErrors of BigQuery API calls and storage operations
+	OperationErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dekart",
+		Name:      "backend_operation_errors_total",
+		Help:      "Failed BigQuery API calls and storage operations by backend and operation.",
+	}, []string{"backend", "operation"})
+
+	// BytesWritten to result objects, rate is upload throughput
+	BytesWritten = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dekart",
+		Name:      "storage_written_bytes_total",
+		Help:      "Bytes written to result objects by backend.",
+	}, []string{"backend"})
+
+	// HookRuns of job completion hooks by outcome: ok, error or panic
+	HookRuns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dekart",
+		Name:      "job_hook_runs_total",
+		Help:      "Runs of job completion hooks by hook and outcome.",
+	}, []string{"hook", "outcome"})
+
+	// HookDuration of job completion hooks
+	HookDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "dekart",
+		Name:      "job_hook_duration_seconds",
+		Help:      "Duration of job completion hooks by hook.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 7),
+	}, []string{"hook"})
+
+	// MemoryReserved by jobs for buffered rows in memory budget
+	MemoryReserved = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dekart",
+		Name:      "job_memory_reserved_bytes",
+		Help:      "Bytes of buffered rows reserved by jobs in memory budget.",
+	})
+
+	// MemoryDegraded when component of job was degraded because memory budget was exhausted
+	MemoryDegraded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dekart",
+		Name:      "job_memory_degraded_total",
+		Help:      "Components of jobs degraded because memory budget was exhausted, by component.",
+	}, []string{"component"})
+)
+
+// registry of dekart metrics with Go runtime and process metrics
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		OperationDuration,
+		OperationErrors,
+		BytesWritten,
+		HookRuns,
+		HookDuration,
+		MemoryReserved,
+		MemoryDegraded,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+}
+
+// Observe operation started at start, err is returned error of operation
+func Observe(backend string, operation string, start time.Time, err error) {
+	ObserveDuration(backend, operation, time.Since(start), err)
+}
+
+// ObserveDuration of operation; cancelled operations are not observed, cancellation is not backend error
+func ObserveDuration(backend string, operation string, duration time.Duration, err error) {
+	if err != nil && (errors.Is(err, context.Canceled) || strings.Contains(err.Error(), context.Canceled.Error())) {
+		return
+	}
+	OperationDuration.WithLabelValues(backend, operation).Observe(duration.Seconds())
+	if err != nil {
+		OperationErrors.WithLabelValues(backend, operation).Inc()
+	}
+}
+
+// Register collector of dekart metrics served by Handler
+func Register(collector prometheus.Collector) error {
+	return registry.Register(collector)
+}
+
+// Handler serves metrics in Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
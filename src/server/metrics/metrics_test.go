@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveDuration(t *testing.T) {
+	ObserveDuration("test", "ok", time.Second, nil)
+	ObserveDuration("test", "failed", time.Second, fmt.Errorf("backend error"))
+	ObserveDuration("test", "cancelled", time.Second, fmt.Errorf("read: %w", context.Canceled))
+	if got := testutil.CollectAndCount(OperationDuration); got != 2 {
+		t.Errorf("expected cancelled operation not observed, got %d series", got)
+	}
+	if got := testutil.ToFloat64(OperationErrors.WithLabelValues("test", "failed")); got != 1 {
+		t.Errorf("expected 1 error, got %v", got)
+	}
+	if got := testutil.ToFloat64(OperationErrors.WithLabelValues("test", "ok")); got != 0 {
+		t.Errorf("expected no errors, got %v", got)
+	}
+}
@@ -0,0 +1,197 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// boolean types of Thrift compact protocol, struct fields carry value in type
+const (
+	compactTrue   = 1
+	compactFalse  = 2
+	compactByte   = 3
+	compactI16    = 4
+	compactDouble = 7
+	compactSet    = 10
+)
+
+// thriftDecoder of compact protocol, structs are decoded into map of field id to value;
+// first error stops decoding
+type thriftDecoder struct {
+	b   []byte
+	i   int
+	err error
+}
+
+func (d *thriftDecoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = fmt.Errorf(format, args...)
+	}
+}
+
+func (d *thriftDecoder) next(n int) []byte {
+	if d.err != nil || n < 0 || d.i+n > len(d.b) {
+		d.fail("unexpected end of thrift data at %d", d.i)
+		return nil
+	}
+	d.i += n
+	return d.b[d.i-n : d.i]
+}
+
+func (d *thriftDecoder) varint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.b[d.i:])
+	if n <= 0 {
+		d.fail("invalid varint at %d", d.i)
+		return 0
+	}
+	d.i += n
+	return v
+}
+
+func (d *thriftDecoder) zigzag() int64 {
+	v := d.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// value of type; integers are int64, binary is string, lists are []interface{}
+func (d *thriftDecoder) value(typ byte) interface{} {
+	switch typ {
+	case compactTrue, compactFalse:
+		// boolean list elements are one byte
+		b := d.next(1)
+		return len(b) == 1 && b[0] == compactTrue
+	case compactByte:
+		b := d.next(1)
+		if len(b) == 0 {
+			return int64(0)
+		}
+		return int64(int8(b[0]))
+	case compactI16, compactI32, compactI64:
+		return d.zigzag()
+	case compactDouble:
+		b := d.next(8)
+		if len(b) == 0 {
+			return float64(0)
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	case compactBinary:
+		return string(d.next(int(d.varint())))
+	case compactList, compactSet:
+		header := d.next(1)
+		if len(header) == 0 {
+			return nil
+		}
+		size := int(header[0] >> 4)
+		if size == 15 {
+			size = int(d.varint())
+		}
+		if size > len(d.b) {
+			d.fail("invalid list size %d at %d", size, d.i)
+			return nil
+		}
+		items := make([]interface{}, 0, size)
+		for n := 0; n < size && d.err == nil; n++ {
+			items = append(items, d.value(header[0]&0x0f))
+		}
+		return items
+	case compactStruct:
+		s := map[int16]interface{}{}
+		var last int16
+		for d.err == nil {
+			header := d.next(1)
+			if len(header) == 0 || header[0] == 0 {
+				return s
+			}
+			id := last + int16(header[0]>>4)
+			if header[0]>>4 == 0 {
+				id = int16(d.zigzag())
+			}
+			switch fieldType := header[0] & 0x0f; fieldType {
+			case compactTrue, compactFalse:
+				s[id] = fieldType == compactTrue
+			default:
+				s[id] = d.value(fieldType)
+			}
+			last = id
+		}
+		return s
+	}
+	d.fail("unsupported thrift type %d at %d", typ, d.i)
+	return nil
+}
+
+// FileMetadata of Parquet file footer
+type FileMetadata struct {
+	NumRows   int64
+	RowGroups int
+	// Columns of flat schema, nested schemas are not supported
+	Columns []Column
+	// KeyValue metadata of file, as geo of GeoParquet
+	KeyValue map[string]string
+	// CreatedBy application which wrote file
+	CreatedBy string
+}
+
+// ReadMetadata from footer of Parquet file of size
+func ReadMetadata(r io.ReaderAt, size int64) (*FileMetadata, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, fmt.Errorf("parquet file of %d bytes is too small", size)
+	}
+	tail := make([]byte, 4+len(magic))
+	if _, err := r.ReadAt(tail, size-int64(len(tail))); err != nil {
+		return nil, err
+	}
+	if string(tail[4:]) != magic {
+		return nil, fmt.Errorf("missing parquet magic at end of file")
+	}
+	footerLength := int64(binary.LittleEndian.Uint32(tail))
+	if footerLength > size-int64(len(tail)+len(magic)) {
+		return nil, fmt.Errorf("invalid parquet footer length %d", footerLength)
+	}
+	footer := make([]byte, footerLength)
+	if _, err := r.ReadAt(footer, size-int64(len(tail))-footerLength); err != nil {
+		return nil, err
+	}
+	d := &thriftDecoder{b: footer}
+	s, _ := d.value(compactStruct).(map[int16]interface{})
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid parquet footer: %s", d.err)
+	}
+	metadata := &FileMetadata{KeyValue: map[string]string{}}
+	metadata.NumRows, _ = s[3].(int64)
+	rowGroups, _ := s[4].([]interface{})
+	metadata.RowGroups = len(rowGroups)
+	metadata.CreatedBy, _ = s[6].(string)
+	schema, _ := s[2].([]interface{})
+	for i, item := range schema {
+		element, _ := item.(map[int16]interface{})
+		if i == 0 {
+			// root of schema
+			continue
+		}
+		if _, nested := element[5]; nested {
+			return nil, fmt.Errorf("nested parquet schema is not supported")
+		}
+		typ, _ := element[1].(int64)
+		name, _ := element[4].(string)
+		converted, hasConverted := element[6].(int64)
+		metadata.Columns = append(metadata.Columns, Column{
+			Name: name,
+			Type: Type(typ),
+			UTF8: hasConverted && int32(converted) == convertedUTF8,
+		})
+	}
+	keyValues, _ := s[5].([]interface{})
+	for _, item := range keyValues {
+		keyValue, _ := item.(map[int16]interface{})
+		key, _ := keyValue[1].(string)
+		value, _ := keyValue[2].(string)
+		metadata.KeyValue[key] = value
+	}
+	return metadata, nil
+}
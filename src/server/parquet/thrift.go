@@ -0,0 +1,106 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// types of Thrift compact protocol
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// field of Thrift struct, value is int32, int64, string, []byte, thriftStruct or thriftList
+type field struct {
+	id    int16
+	value interface{}
+}
+
+// thriftStruct with fields in order of id; optional fields are omitted
+type thriftStruct []field
+
+// thriftList of values of one compact type
+type thriftList struct {
+	elem  byte
+	items []interface{}
+}
+
+func compactType(value interface{}) byte {
+	switch value.(type) {
+	case int32:
+		return compactI32
+	case int64:
+		return compactI64
+	case string, []byte:
+		return compactBinary
+	case thriftList:
+		return compactList
+	case thriftStruct:
+		return compactStruct
+	}
+	panic("unsupported thrift value")
+}
+
+func writeVarint(b *bytes.Buffer, v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	b.Write(buf[:n])
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
+
+// writeValue in compact protocol
+func writeValue(b *bytes.Buffer, value interface{}) {
+	switch v := value.(type) {
+	case int32:
+		writeVarint(b, zigzag(int64(v)))
+	case int64:
+		writeVarint(b, zigzag(v))
+	case string:
+		writeVarint(b, uint64(len(v)))
+		b.WriteString(v)
+	case []byte:
+		writeVarint(b, uint64(len(v)))
+		b.Write(v)
+	case thriftList:
+		if len(v.items) < 15 {
+			b.WriteByte(byte(len(v.items))<<4 | v.elem)
+		} else {
+			b.WriteByte(0xf0 | v.elem)
+			writeVarint(b, uint64(len(v.items)))
+		}
+		for _, item := range v.items {
+			writeValue(b, item)
+		}
+	case thriftStruct:
+		var last int16
+		for _, f := range v {
+			typ := compactType(f.value)
+			if delta := f.id - last; delta > 0 && delta <= 15 {
+				b.WriteByte(byte(delta)<<4 | typ)
+			} else {
+				b.WriteByte(typ)
+				writeVarint(b, zigzag(int64(f.id)))
+			}
+			writeValue(b, f.value)
+			last = f.id
+		}
+		// stop field
+		b.WriteByte(0)
+	default:
+		panic("unsupported thrift value")
+	}
+}
+
+// encodeStruct in compact protocol, as Parquet stores page headers and file metadata
+func encodeStruct(s thriftStruct) []byte {
+	var b bytes.Buffer
+	writeValue(&b, s)
+	return b.Bytes()
+}
@@ -0,0 +1,296 @@
+// Package parquet writes flat Parquet files of optional columns: PLAIN encoded, uncompressed,
+// one data page per column chunk, and reads their footer metadata. It covers query results,
+// which are written once and read by other tools.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// magic at start and end of Parquet file
+const magic = "PAR1"
+
+// DefaultRowGroupSize in bytes of buffered values
+const DefaultRowGroupSize = 32 << 20
+
+// Type of column values
+type Type int32
+
+// physical types of Parquet used by Writer
+const (
+	Boolean   Type = 0
+	Int64     Type = 2
+	Double    Type = 5
+	ByteArray Type = 6
+)
+
+// encodings and codec of Parquet
+const (
+	encodingPlain      int32 = 0
+	encodingRLE        int32 = 3
+	codecUncompressed  int32 = 0
+	pageTypeData       int32 = 0
+	repetitionOptional int32 = 1
+	convertedUTF8      int32 = 0
+)
+
+// Column of file schema, all columns are optional
+type Column struct {
+	Name string
+	Type Type
+	// UTF8 annotates ByteArray column, so readers decode values as strings
+	UTF8 bool
+}
+
+// columnBuffer of row group; values are PLAIN encoded, null values only have definition level
+type columnBuffer struct {
+	defined []bool
+	values  bytes.Buffer
+	// bools are bit packed when row group is written
+	bools []bool
+}
+
+// Writer of Parquet file, not concurrency safe
+type Writer struct {
+	w       io.Writer
+	columns []Column
+	buffers []columnBuffer
+	// RowGroupSize in bytes of buffered values, row group is written when it's exceeded
+	RowGroupSize int
+	buffered     int
+	rows         int64
+	totalRows    int64
+	offset       int64
+	rowGroups    []interface{}
+	metadata     []interface{}
+}
+
+// NewWriter of Parquet file with columns; nothing is written until first row group is full or Close
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	return &Writer{
+		w:            w,
+		columns:      columns,
+		buffers:      make([]columnBuffer, len(columns)),
+		RowGroupSize: DefaultRowGroupSize,
+	}
+}
+
+// Write row with value of each column; values are nil, bool, int64, float64, string or []byte by column type
+func (w *Writer) Write(row []interface{}) error {
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, schema has %d columns", len(row), len(w.columns))
+	}
+	// row is checked before it's buffered, so columns of rejected row stay aligned
+	for i, value := range row {
+		if !valid(w.columns[i].Type, value) {
+			return fmt.Errorf("cannot write %T to column %s of type %d", value, w.columns[i].Name, w.columns[i].Type)
+		}
+	}
+	for i, value := range row {
+		w.add(&w.buffers[i], value)
+	}
+	w.rows++
+	if w.buffered >= w.RowGroupSize {
+		return w.writeRowGroup()
+	}
+	return nil
+}
+
+func valid(typ Type, value interface{}) bool {
+	switch value.(type) {
+	case nil:
+		return true
+	case bool:
+		return typ == Boolean
+	case int64:
+		return typ == Int64
+	case float64:
+		return typ == Double
+	case string, []byte:
+		return typ == ByteArray
+	}
+	return false
+}
+
+func (w *Writer) add(b *columnBuffer, value interface{}) {
+	b.defined = append(b.defined, value != nil)
+	var buf [8]byte
+	switch v := value.(type) {
+	case bool:
+		b.bools = append(b.bools, v)
+		w.buffered++
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		b.values.Write(buf[:])
+		w.buffered += 8
+	case float64:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v))
+		b.values.Write(buf[:])
+		w.buffered += 8
+	case string:
+		binary.LittleEndian.PutUint32(buf[:4], uint32(len(v)))
+		b.values.Write(buf[:4])
+		b.values.WriteString(v)
+		w.buffered += 4 + len(v)
+	case []byte:
+		binary.LittleEndian.PutUint32(buf[:4], uint32(len(v)))
+		b.values.Write(buf[:4])
+		b.values.Write(v)
+		w.buffered += 4 + len(v)
+	}
+}
+
+// SetMetadata key of file metadata, written by Close
+func (w *Writer) SetMetadata(key string, value string) {
+	w.metadata = append(w.metadata, thriftStruct{{1, key}, {2, value}})
+}
+
+func (w *Writer) write(b []byte) error {
+	if w.offset == 0 {
+		n, err := io.WriteString(w.w, magic)
+		w.offset += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+// definitionLevels of optional column in RLE hybrid encoding with length prefix, levels are 0 for null and 1 for value
+func definitionLevels(defined []bool) []byte {
+	var runs bytes.Buffer
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		// run header is count shifted left, low bit 0 is RLE run; value has one byte for bit width 1
+		writeVarint(&runs, uint64(j-i)<<1)
+		if defined[i] {
+			runs.WriteByte(1)
+		} else {
+			runs.WriteByte(0)
+		}
+		i = j
+	}
+	b := make([]byte, 4, 4+runs.Len())
+	binary.LittleEndian.PutUint32(b, uint32(runs.Len()))
+	return append(b, runs.Bytes()...)
+}
+
+// packBools LSB first, as PLAIN encoding of boolean values
+func packBools(values []bool) []byte {
+	b := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			b[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return b
+}
+
+// writeRowGroup of buffered rows, one data page per column
+func (w *Writer) writeRowGroup() error {
+	if w.rows == 0 {
+		return nil
+	}
+	var chunks []interface{}
+	var groupSize int64
+	for i, column := range w.columns {
+		b := &w.buffers[i]
+		page := definitionLevels(b.defined)
+		if column.Type == Boolean {
+			page = append(page, packBools(b.bools)...)
+		} else {
+			page = append(page, b.values.Bytes()...)
+		}
+		header := encodeStruct(thriftStruct{
+			{1, pageTypeData},
+			{2, int32(len(page))},
+			{3, int32(len(page))},
+			{5, thriftStruct{
+				{1, int32(len(b.defined))},
+				{2, encodingPlain},
+				{3, encodingRLE},
+				{4, encodingRLE},
+			}},
+		})
+		pageOffset := w.offset
+		if pageOffset == 0 {
+			pageOffset = int64(len(magic))
+		}
+		if err := w.write(header); err != nil {
+			return err
+		}
+		if err := w.write(page); err != nil {
+			return err
+		}
+		size := int64(len(header) + len(page))
+		groupSize += size
+		chunks = append(chunks, thriftStruct{
+			{2, pageOffset},
+			{3, thriftStruct{
+				{1, int32(column.Type)},
+				{2, thriftList{compactI32, []interface{}{encodingPlain, encodingRLE}}},
+				{3, thriftList{compactBinary, []interface{}{column.Name}}},
+				{4, codecUncompressed},
+				{5, int64(len(b.defined))},
+				{6, size},
+				{7, size},
+				{9, pageOffset},
+			}},
+		})
+		w.buffers[i] = columnBuffer{}
+	}
+	w.rowGroups = append(w.rowGroups, thriftStruct{
+		{1, thriftList{compactStruct, chunks}},
+		{2, groupSize},
+		{3, w.rows},
+	})
+	w.totalRows += w.rows
+	w.rows = 0
+	w.buffered = 0
+	return nil
+}
+
+// Close writes buffered rows and file metadata; underlying writer is not closed
+func (w *Writer) Close() error {
+	if err := w.writeRowGroup(); err != nil {
+		return err
+	}
+	schema := []interface{}{thriftStruct{
+		{4, "schema"},
+		{5, int32(len(w.columns))},
+	}}
+	for _, column := range w.columns {
+		element := thriftStruct{
+			{1, int32(column.Type)},
+			{3, repetitionOptional},
+			{4, column.Name},
+		}
+		if column.UTF8 {
+			element = append(element, field{6, convertedUTF8})
+		}
+		schema = append(schema, element)
+	}
+	metadata := thriftStruct{
+		{1, int32(1)},
+		{2, thriftList{compactStruct, schema}},
+		{3, w.totalRows},
+		{4, thriftList{compactStruct, w.rowGroups}},
+	}
+	if len(w.metadata) > 0 {
+		metadata = append(metadata, field{5, thriftList{compactStruct, w.metadata}})
+	}
+	metadata = append(metadata, field{6, "dekart"})
+	footer := encodeStruct(metadata)
+	footer = append(footer, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(footer[len(footer)-4:], uint32(len(footer)-4))
+	return w.write(append(footer, magic...))
+}
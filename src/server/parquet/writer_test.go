@@ -0,0 +1,225 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type parsedFile struct {
+	metadata map[int16]interface{}
+	// columns of first row group, values and nulls in row order
+	columns [][]interface{}
+}
+
+// parseFile checks layout and decodes metadata and values of file written by Writer
+func parseFile(t *testing.T, b []byte) parsedFile {
+	if string(b[:4]) != magic || string(b[len(b)-4:]) != magic {
+		t.Fatal("missing magic")
+	}
+	footerLength := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	r := &thriftDecoder{b: b[len(b)-8-footerLength : len(b)-8]}
+	parsed := parsedFile{metadata: r.value(compactStruct).(map[int16]interface{})}
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	if r.i != footerLength {
+		t.Fatalf("footer has %d bytes, decoded %d", footerLength, r.i)
+	}
+	schema := parsed.metadata[2].([]interface{})
+	rowGroups := parsed.metadata[4].([]interface{})
+	if len(rowGroups) == 0 {
+		return parsed
+	}
+	for i, chunk := range rowGroups[0].(map[int16]interface{})[1].([]interface{}) {
+		meta := chunk.(map[int16]interface{})[3].(map[int16]interface{})
+		offset := int(meta[9].(int64))
+		page := &thriftDecoder{b: b[offset:]}
+		header := page.value(compactStruct).(map[int16]interface{})
+		if page.err != nil {
+			t.Fatal(page.err)
+		}
+		data := page.b[page.i : page.i+int(header[3].(int64))]
+		if int64(page.i+len(data)) != meta[6].(int64) {
+			t.Errorf("column %d: chunk size %d, page is %d bytes", i, meta[6], page.i+len(data))
+		}
+		numValues := int(header[5].(map[int16]interface{})[1].(int64))
+		typ := Type(schema[i+1].(map[int16]interface{})[1].(int64))
+		parsed.columns = append(parsed.columns, decodePage(t, data, typ, numValues))
+	}
+	return parsed
+}
+
+func decodePage(t *testing.T, data []byte, typ Type, numValues int) []interface{} {
+	levelsLength := int(binary.LittleEndian.Uint32(data))
+	levels := &thriftDecoder{b: data[4 : 4+levelsLength]}
+	var defined []bool
+	for levels.i < levelsLength {
+		header := levels.varint()
+		if header&1 != 0 {
+			t.Fatal("unexpected bit packed run")
+		}
+		value := levels.b[levels.i] == 1
+		levels.i++
+		for n := 0; n < int(header>>1); n++ {
+			defined = append(defined, value)
+		}
+	}
+	if len(defined) != numValues {
+		t.Fatalf("expected %d levels, got %d", numValues, len(defined))
+	}
+	values := data[4+levelsLength:]
+	var result []interface{}
+	bit := 0
+	for _, ok := range defined {
+		if !ok {
+			result = append(result, nil)
+			continue
+		}
+		switch typ {
+		case Boolean:
+			result = append(result, values[bit/8]&(1<<uint(bit%8)) != 0)
+			bit++
+		case Int64:
+			result = append(result, int64(binary.LittleEndian.Uint64(values)))
+			values = values[8:]
+		case Double:
+			result = append(result, math.Float64frombits(binary.LittleEndian.Uint64(values)))
+			values = values[8:]
+		case ByteArray:
+			n := int(binary.LittleEndian.Uint32(values))
+			result = append(result, string(values[4:4+n]))
+			values = values[4+n:]
+		}
+	}
+	return result
+}
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, []Column{
+		{Name: "name", Type: ByteArray, UTF8: true},
+		{Name: "count", Type: Int64},
+		{Name: "share", Type: Double},
+		{Name: "active", Type: Boolean},
+		{Name: "geometry", Type: ByteArray},
+	})
+	rows := [][]interface{}{
+		{"a", int64(1), 0.5, true, []byte{1, 2}},
+		{nil, nil, nil, nil, nil},
+		{"c", int64(-3), math.Inf(1), false, []byte{}},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.SetMetadata("geo", `{"version":"1.0.0"}`)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	parsed := parseFile(t, b.Bytes())
+	if parsed.metadata[3].(int64) != 3 {
+		t.Errorf("expected 3 rows, got %v", parsed.metadata[3])
+	}
+	schema := parsed.metadata[2].([]interface{})
+	if root := schema[0].(map[int16]interface{}); root[5].(int64) != 5 {
+		t.Errorf("unexpected root %v", root)
+	}
+	if name := schema[1].(map[int16]interface{}); name[4] != "name" || name[6] != int64(convertedUTF8) || name[3] != int64(repetitionOptional) {
+		t.Errorf("unexpected name column %v", name)
+	}
+	if _, ok := schema[5].(map[int16]interface{})[6]; ok {
+		t.Error("binary column is annotated as UTF8")
+	}
+	keyValue := parsed.metadata[5].([]interface{})[0].(map[int16]interface{})
+	if keyValue[1] != "geo" || keyValue[2] != `{"version":"1.0.0"}` {
+		t.Errorf("unexpected metadata %v", keyValue)
+	}
+	expected := [][]interface{}{
+		{"a", nil, "c"},
+		{int64(1), nil, int64(-3)},
+		{0.5, nil, math.Inf(1)},
+		{true, nil, false},
+		{"\x01\x02", nil, ""},
+	}
+	if !reflect.DeepEqual(parsed.columns, expected) {
+		t.Errorf("expected %v, got %v", expected, parsed.columns)
+	}
+}
+
+func TestWriterRowGroups(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, []Column{{Name: "id", Type: Int64}})
+	w.RowGroupSize = 80
+	for i := 0; i < 25; i++ {
+		if err := w.Write([]interface{}{int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	parsed := parseFile(t, b.Bytes())
+	rowGroups := parsed.metadata[4].([]interface{})
+	if len(rowGroups) != 3 || parsed.metadata[3].(int64) != 25 {
+		t.Errorf("expected 25 rows in 3 row groups, got %d rows in %d", parsed.metadata[3], len(rowGroups))
+	}
+	if len(parsed.columns[0]) != 10 || parsed.columns[0][9] != int64(9) {
+		t.Errorf("unexpected first row group %v", parsed.columns[0])
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	var b bytes.Buffer
+	w := NewWriter(&b, []Column{{Name: "id", Type: Int64}})
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if parsed := parseFile(t, b.Bytes()); parsed.metadata[3].(int64) != 0 {
+		t.Errorf("expected no rows, got %v", parsed.metadata[3])
+	}
+}
+
+func TestWriterTypeMismatch(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, []Column{{Name: "id", Type: Int64}})
+	if err := w.Write([]interface{}{"1"}); err == nil {
+		t.Error("expected error of string in int64 column")
+	}
+	if err := w.Write([]interface{}{int64(1), int64(2)}); err == nil {
+		t.Error("expected error of row length")
+	}
+}
+
+func TestReadMetadata(t *testing.T) {
+	var b bytes.Buffer
+	columns := []Column{{Name: "name", Type: ByteArray, UTF8: true}, {Name: "geometry", Type: ByteArray}}
+	w := NewWriter(&b, columns)
+	for i := 0; i < 3; i++ {
+		if err := w.Write([]interface{}{"a", []byte{1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.SetMetadata("geo", `{"version":"1.0.0"}`)
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := ReadMetadata(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.NumRows != 3 || metadata.RowGroups != 1 || metadata.CreatedBy != "dekart" {
+		t.Errorf("unexpected metadata %+v", metadata)
+	}
+	if !reflect.DeepEqual(metadata.Columns, columns) {
+		t.Errorf("expected columns %v, got %v", columns, metadata.Columns)
+	}
+	if metadata.KeyValue["geo"] != `{"version":"1.0.0"}` {
+		t.Errorf("unexpected key value metadata %v", metadata.KeyValue)
+	}
+	if _, err := ReadMetadata(bytes.NewReader(b.Bytes()[:b.Len()-1]), int64(b.Len()-1)); err == nil {
+		t.Error("expected error of truncated file")
+	}
+}
@@ -0,0 +1,132 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+const notifyChannel = "dekart_report_updates"
+
+// PollInterval is how often streams are refreshed from database while LISTEN connection is down
+var PollInterval = 5 * time.Second
+
+// PostgresBus delivers report updates between replicas with Postgres LISTEN/NOTIFY; use NewPostgresBus to init
+type PostgresBus struct {
+	*Streams
+	db       *sql.DB
+	listener *pq.Listener
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewPostgresBus creates bus listening for notifications on connection configured with dsn
+func NewPostgresBus(db *sql.DB, dsn string) *PostgresBus {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warn().Err(err).Msg("Postgres report updates listener")
+		}
+	})
+	err := listener.Listen(notifyChannel)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Cannot listen for report updates")
+	}
+	return newPostgresBus(db, listener)
+}
+
+func newPostgresBus(db *sql.DB, listener *pq.Listener) *PostgresBus {
+	b := &PostgresBus{
+		Streams:  NewStreams(),
+		db:       db,
+		listener: listener,
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go b.listen()
+	return b
+}
+
+func encodeNotification(event Event) (string, error) {
+	b, err := json.Marshal(event)
+	return string(b), err
+}
+
+func decodeNotification(payload string) (Event, error) {
+	var event Event
+	err := json.Unmarshal([]byte(payload), &event)
+	if err != nil {
+		return event, fmt.Errorf("invalid notification payload %q: %w", payload, err)
+	}
+	if event.ReportID == "" || event.Sequence == 0 {
+		return event, fmt.Errorf("invalid notification payload %q", payload)
+	}
+	return event, nil
+}
+
+func (b *PostgresBus) handleNotification(n *pq.Notification) {
+	if n == nil {
+		// connection was re-established, notifications could be lost
+		log.Info().Msg("Report updates listener reconnected")
+		b.ResyncAll()
+		return
+	}
+	event, err := decodeNotification(n.Extra)
+	if err != nil {
+		log.Err(err).Send()
+		return
+	}
+	b.PublishWithSequence(event)
+}
+
+func (b *PostgresBus) listen() {
+	defer close(b.stopped)
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.done:
+			return
+		case n := <-b.listener.Notify:
+			b.handleNotification(n)
+		case <-ticker.C:
+			err := b.listener.Ping()
+			if err != nil {
+				// degrade to polling: streams re-read state from database
+				log.Warn().Err(err).Msg("Report updates listener is down, polling")
+				b.ResyncAll()
+			}
+		}
+	}
+}
+
+// Publish event to all replicas
+func (b *PostgresBus) Publish(event Event) int64 {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := b.db.QueryRowContext(ctx, "select nextval('report_updates_sequence')").Scan(&event.Sequence)
+	var payload string
+	if err == nil {
+		payload, err = encodeNotification(event)
+	}
+	if err == nil {
+		_, err = b.db.ExecContext(ctx, "select pg_notify($1, $2)", notifyChannel, payload)
+	}
+	if err != nil {
+		log.Err(err).Msg("Cannot notify replicas about report update")
+		return b.Streams.Publish(event)
+	}
+	// own notification is delivered back with listener
+	return event.Sequence
+}
+
+// Close stops listening for notifications and closes listener connection
+func (b *PostgresBus) Close() error {
+	close(b.done)
+	<-b.stopped
+	return b.listener.Close()
+}
@@ -2,15 +2,59 @@ package report
 
 import (
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
-// Streams of report changes; use NewStreams to init
+// EventKind tells subscribers what changed in report
+type EventKind int32
+
+const (
+	// ReportChanged title, map config or archived flag
+	ReportChanged EventKind = iota + 1
+	// QueryChanged when query is added or its text is updated
+	QueryChanged
+	// JobStatusChanged when job of query moves to next status
+	JobStatusChanged
+	// Resync means subscriber missed events and has to reload full report state
+	Resync
+	// QueryDeleted when query is removed from report, it can be restored until it's swept
+	QueryDeleted
+	// QueryRestored when removed query is restored
+	QueryRestored
+)
+
+// Event of report change; Sequence is report revision assigned by bus
+type Event struct {
+	ReportID  string    `json:"reportId"`
+	Sequence  int64     `json:"sequence"`
+	Kind      EventKind `json:"kind"`
+	QueryID   string    `json:"queryId,omitempty"`
+	JobStatus int32     `json:"jobStatus,omitempty"`
+}
+
+// Bus delivers report change events to subscribed streams
+type Bus interface {
+	// Register stream; sequence is last received by subscriber, current state is always sent first
+	Register(reportID string, streamID string, sequence int64) chan Event
+	Deregister(reportID string, streamID string)
+	// Publish event to subscribers of the report and All, returns assigned sequence
+	Publish(event Event) int64
+	// Close stops delivering events
+	Close() error
+}
+
+// subscriberBuffer is how many events slow subscriber can lag before it is resynced
+const subscriberBuffer = 16
+
+// Streams of report changes within one process; use NewStreams to init
 type Streams struct {
-	channels map[string]map[string]chan int64
+	channels map[string]map[string]chan Event
 	sequence map[string]int64
-	mutex    sync.Mutex
+	// base of sequences of reports first seen by this process, so sequences are not reused after restart
+	base  int64
+	mutex sync.Mutex
 }
 
 // NewStreams creates new Streams struct
@@ -24,38 +68,44 @@ func NewStreams() *Streams {
 func (s *Streams) Init() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.channels = make(map[string]map[string]chan int64)
+	s.channels = make(map[string]map[string]chan Event)
 	s.sequence = make(map[string]int64)
+	s.base = time.Now().UnixNano() / int64(time.Millisecond)
+}
+
+// Close Streams; events are delivered in process, nothing to release
+func (s *Streams) Close() error {
+	return nil
 }
 
 // All means subscribing for all reports changes
 const All string = "AllReports"
 
-// Register to listen report updates
-func (s *Streams) Register(reportID string, streamID string, sequence int64) chan int64 {
+// Register to listen report updates; first event is always Resync with current sequence, so subscriber
+// loads current state after it is registered and changes published while it was reconnecting are not missed
+func (s *Streams) Register(reportID string, streamID string, sequence int64) chan Event {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	currentSequence, ok := s.sequence[reportID]
-	if !ok {
-		s.sequence[reportID] = 1
-		currentSequence = 1
+	currentSequence, known := s.sequence[reportID]
+	if !known {
+		currentSequence = s.base
+		s.sequence[reportID] = currentSequence
 	}
 	streamMap, ok := s.channels[reportID]
 	if !ok {
-		streamMap = make(map[string]chan int64)
+		streamMap = make(map[string]chan Event)
 		s.channels[reportID] = streamMap
 	}
 	_, ok = streamMap[streamID]
 	if ok {
 		log.Fatal().Msgf("streamID %s exists", streamID)
 	}
-	ch := make(chan int64)
+	// buffered so Publish never waits for slow subscribers
+	ch := make(chan Event, subscriberBuffer)
 	streamMap[streamID] = ch
-	if currentSequence > sequence {
-		go func() {
-			ch <- currentSequence
-		}()
-	}
+	// state is sent even when sequence is current: sequence could come from other replica or previous process,
+	// and event delivered by other replica can be late, so sequence alone doesn't prove subscriber has latest state
+	ch <- Event{ReportID: reportID, Sequence: currentSequence, Kind: Resync}
 	return ch
 }
 
@@ -70,19 +120,61 @@ func (s *Streams) Deregister(reportID string, streamID string) {
 	delete(streamMap, streamID)
 }
 
-// Ping about report update
-func (s *Streams) Ping(reportID string) {
+// deliver event to subscribers of rid; subscriber with full buffer gets single Resync event instead
+func (s *Streams) deliver(rid string, event Event) {
+	for _, ch := range s.channels[rid] {
+		select {
+		case ch <- event:
+		default:
+			for len(ch) > 0 {
+				<-ch
+			}
+			ch <- Event{ReportID: event.ReportID, Sequence: event.Sequence, Kind: Resync}
+		}
+	}
+}
+
+// publish assigns sequence for report and All; sequence from other replica is kept when ahead, sequence never goes back
+func (s *Streams) publish(event Event, sequence int64) int64 {
+	for _, rid := range []string{event.ReportID, All} {
+		if _, ok := s.sequence[rid]; !ok {
+			s.sequence[rid] = s.base
+		}
+		if sequence > s.sequence[rid] {
+			s.sequence[rid] = sequence
+		} else {
+			s.sequence[rid] = s.sequence[rid] + 1
+		}
+		e := event
+		e.Sequence = s.sequence[rid]
+		s.deliver(rid, e)
+	}
+	return s.sequence[event.ReportID]
+}
+
+// Publish report change event; returns new report sequence
+func (s *Streams) Publish(event Event) int64 {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	for _, rid := range []string{reportID, All} {
-		sequence, ok := s.sequence[rid]
-		s.sequence[rid] = sequence + 1
-		streamMap, ok := s.channels[rid]
-		if !ok {
+	return s.publish(event, 0)
+}
+
+// PublishWithSequence delivers event with externally assigned sequence
+func (s *Streams) PublishWithSequence(event Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.publish(event, event.Sequence)
+}
+
+// ResyncAll registered streams, used when events could be missed
+func (s *Streams) ResyncAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for rid, streamMap := range s.channels {
+		if len(streamMap) == 0 {
 			continue
 		}
-		for _, ch := range streamMap {
-			ch <- s.sequence[rid]
-		}
+		s.sequence[rid] = s.sequence[rid] + 1
+		s.deliver(rid, Event{ReportID: rid, Sequence: s.sequence[rid], Kind: Resync})
 	}
 }
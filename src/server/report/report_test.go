@@ -0,0 +1,220 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func receive(t *testing.T, ch chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-ch:
+		return event
+	default:
+		t.Fatal("expected pending event")
+		return Event{}
+	}
+}
+
+func expectNoEvent(t *testing.T, ch chan Event) {
+	t.Helper()
+	select {
+	case event := <-ch:
+		t.Fatalf("unexpected event %+v", event)
+	default:
+	}
+}
+
+func TestPublishDoesNotBlockOnIdleSubscriber(t *testing.T) {
+	s := NewStreams()
+	ch := s.Register("report", "stream", 0)
+	receive(t, ch)
+	var last int64
+	for i := 0; i < subscriberBuffer*3; i++ {
+		last = s.Publish(Event{ReportID: "report", Kind: QueryChanged})
+	}
+	if len(ch) > subscriberBuffer {
+		t.Errorf("expected at most %d pending events, got %d", subscriberBuffer, len(ch))
+	}
+	// dropped events are replaced with resync, newer events follow it
+	if event := receive(t, ch); event.Kind != Resync {
+		t.Errorf("expected resync, got %+v", event)
+	}
+	var event Event
+	for len(ch) > 0 {
+		event = receive(t, ch)
+	}
+	if event.Sequence != last {
+		t.Errorf("expected latest sequence %d, got %+v", last, event)
+	}
+	s.Deregister("report", "stream")
+}
+
+func TestRegisterWithOutdatedSequence(t *testing.T) {
+	s := NewStreams()
+	sequence := s.Publish(Event{ReportID: "report", Kind: ReportChanged})
+	ch := s.Register("report", "stream", 0)
+	event := receive(t, ch)
+	if event.Kind != Resync || event.Sequence != sequence {
+		t.Errorf("expected resync with sequence %d, got %+v", sequence, event)
+	}
+}
+
+func TestRegisterWithCurrentSequence(t *testing.T) {
+	s := NewStreams()
+	sequence := s.Publish(Event{ReportID: "report", Kind: ReportChanged})
+	ch := s.Register("report", "stream", sequence)
+	// current state is sent once, status could change while subscriber was reconnecting
+	if event := receive(t, ch); event.Kind != Resync || event.Sequence != sequence {
+		t.Errorf("expected resync with sequence %d, got %+v", sequence, event)
+	}
+	expectNoEvent(t, ch)
+}
+
+func TestRegisterWithUnknownSequence(t *testing.T) {
+	// client resumes stream started on other replica or before restart
+	for name, sequence := range map[string]int64{"behind": 1, "ahead": 1 << 62} {
+		t.Run(name, func(t *testing.T) {
+			s := NewStreams()
+			ch := s.Register("report", "stream", sequence)
+			event := receive(t, ch)
+			if event.Kind != Resync {
+				t.Errorf("expected resync, got %+v", event)
+			}
+		})
+	}
+	s := NewStreams()
+	sequence := s.Publish(Event{ReportID: "report", Kind: ReportChanged})
+	ch := s.Register("report", "stream", sequence+1)
+	if event := receive(t, ch); event.Kind != Resync || event.Sequence != sequence {
+		t.Errorf("expected resync with sequence %d, got %+v", sequence, event)
+	}
+}
+
+func TestSequenceNotReusedAfterRestart(t *testing.T) {
+	s := NewStreams()
+	sequence := s.Publish(Event{ReportID: "report", Kind: ReportChanged})
+	time.Sleep(2 * time.Millisecond)
+	restarted := NewStreams()
+	if next := restarted.Publish(Event{ReportID: "report", Kind: ReportChanged}); next <= sequence {
+		t.Errorf("expected sequence after %d, got %d", sequence, next)
+	}
+}
+
+func TestThreeSubscribersInterleavedMutations(t *testing.T) {
+	s := NewStreams()
+	a := s.Register("report1", "a", 0)
+	b := s.Register("report1", "b", 0)
+	c := s.Register("report2", "c", 0)
+	all := s.Register(All, "list", 0)
+	// initial state
+	for _, ch := range []chan Event{a, b, c, all} {
+		receive(t, ch)
+	}
+
+	mutations := []Event{
+		{ReportID: "report1", Kind: ReportChanged},
+		{ReportID: "report2", Kind: QueryChanged, QueryID: "q2"},
+		{ReportID: "report1", Kind: JobStatusChanged, QueryID: "q1", JobStatus: 2},
+		{ReportID: "report2", Kind: JobStatusChanged, QueryID: "q2", JobStatus: 3},
+		{ReportID: "report1", Kind: QueryChanged, QueryID: "q1"},
+	}
+	var lastAll int64
+	for _, mutation := range mutations {
+		sequence := s.Publish(mutation)
+		// b and c read every event, a never reads until the end
+		sub := b
+		if mutation.ReportID == "report2" {
+			sub = c
+		}
+		event := receive(t, sub)
+		if event.Kind != mutation.Kind || event.QueryID != mutation.QueryID || event.JobStatus != mutation.JobStatus {
+			t.Errorf("expected %+v, got %+v", mutation, event)
+		}
+		if event.Sequence != sequence {
+			t.Errorf("expected sequence %d, got %d", sequence, event.Sequence)
+		}
+		listEvent := receive(t, all)
+		if listEvent.ReportID != mutation.ReportID || listEvent.Sequence <= lastAll {
+			t.Errorf("expected increasing list sequence for %s, got %+v", mutation.ReportID, listEvent)
+		}
+		lastAll = listEvent.Sequence
+	}
+	expectNoEvent(t, b)
+	expectNoEvent(t, c)
+
+	// a lagged behind but within buffer: receives report1 events in order
+	var kinds []EventKind
+	var last int64
+	for len(a) > 0 {
+		event := receive(t, a)
+		if event.Sequence <= last {
+			t.Errorf("a: sequence went back from %d to %d", last, event.Sequence)
+		}
+		last = event.Sequence
+		kinds = append(kinds, event.Kind)
+	}
+	if len(kinds) != 3 || kinds[0] != ReportChanged || kinds[1] != JobStatusChanged || kinds[2] != QueryChanged {
+		t.Errorf("a: unexpected events %v", kinds)
+	}
+}
+
+func TestPublishWithSequenceNeverGoesBack(t *testing.T) {
+	s := NewStreams()
+	ch := s.Register("report", "stream", 0)
+	base := receive(t, ch).Sequence
+	s.PublishWithSequence(Event{ReportID: "report", Sequence: base + 10, Kind: ReportChanged})
+	if event := receive(t, ch); event.Sequence != base+10 {
+		t.Errorf("expected %d, got %d", base+10, event.Sequence)
+	}
+	s.PublishWithSequence(Event{ReportID: "report", Sequence: base + 5, Kind: ReportChanged})
+	if event := receive(t, ch); event.Sequence != base+11 {
+		t.Errorf("expected %d, got %d", base+11, event.Sequence)
+	}
+	s.ResyncAll()
+	if event := receive(t, ch); event.Sequence != base+12 || event.Kind != Resync {
+		t.Errorf("expected resync %d, got %+v", base+12, event)
+	}
+}
+
+func TestDecodeNotification(t *testing.T) {
+	expected := Event{
+		ReportID:  "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d",
+		Sequence:  42,
+		Kind:      JobStatusChanged,
+		QueryID:   "1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed",
+		JobStatus: 3,
+	}
+	payload, err := encodeNotification(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := decodeNotification(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event != expected {
+		t.Errorf("expected %+v, got %+v", expected, event)
+	}
+	for _, payload := range []string{"", "report:1", `{"reportId":"r"}`, `{"sequence":1}`} {
+		_, err := decodeNotification(payload)
+		if err == nil {
+			t.Errorf("expected error for %q", payload)
+		}
+	}
+}
+
+func TestPostgresBusClose(t *testing.T) {
+	// listener keeps reconnecting to unavailable database until closed
+	listener := pq.NewListener("postgres://127.0.0.1:1/dekart?sslmode=disable&connect_timeout=1", time.Millisecond, time.Millisecond, nil)
+	b := newPostgresBus(nil, listener)
+	closed := make(chan error)
+	go func() { closed <- b.Close() }()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not stop listener")
+	}
+}
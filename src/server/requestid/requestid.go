@@ -0,0 +1,115 @@
+package requestid
+
+import (
+	"context"
+	"dekart/src/server/uuid"
+	"net/http"
+	"regexp"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Header with request ID, inbound value is kept when valid
+const Header = "X-Request-Id"
+
+type contextKey string
+
+const requestIDContextKey contextKey = "requestID"
+
+// validID limits inbound IDs so they are safe to log and return
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewContext with request ID
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// FromContext returns request ID or empty string
+func FromContext(ctx context.Context) string {
+	requestID, ok := ctx.Value(requestIDContextKey).(string)
+	if !ok {
+		return ""
+	}
+	return requestID
+}
+
+// Logger with requestID field when request ID is in context
+func Logger(ctx context.Context) zerolog.Logger {
+	requestID := FromContext(ctx)
+	if requestID == "" {
+		return log.Logger
+	}
+	return log.With().Str("requestID", requestID).Logger()
+}
+
+// Middleware takes inbound request ID or generates new one, returns it in response header
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(Header)
+		if !validID.MatchString(requestID) {
+			requestID = uuid.GetUUID()
+		}
+		w.Header().Set(Header, requestID)
+		ctx := NewContext(r.Context(), requestID)
+		logger := Logger(ctx)
+		next.ServeHTTP(w, r.WithContext(logger.WithContext(ctx)))
+	})
+}
+
+// WithDetails adds request ID to status error details so client can show error id
+func WithDetails(ctx context.Context, err error) error {
+	requestID := FromContext(ctx)
+	if err == nil || requestID == "" {
+		return err
+	}
+	st := status.Convert(err)
+	if st.Code() == codes.OK {
+		return err
+	}
+	for _, detail := range st.Details() {
+		if _, ok := detail.(*errdetails.RequestInfo); ok {
+			return err
+		}
+	}
+	withDetails, detailsErr := st.WithDetails(&errdetails.RequestInfo{RequestId: requestID})
+	if detailsErr != nil {
+		log.Err(detailsErr).Send()
+		return err
+	}
+	return withDetails.Err()
+}
+
+// logRPCError with request ID so error id shown to user can be found in logs
+func logRPCError(ctx context.Context, method string, err error) {
+	logger := Logger(ctx)
+	code := status.Code(err)
+	event := logger.Debug()
+	if code == codes.Internal || code == codes.Unknown {
+		event = logger.Error()
+	}
+	event.Err(err).Str("method", method).Str("code", code.String()).Msg("RPC failed")
+}
+
+// UnaryServerInterceptor adds request ID to errors and logs them
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		logRPCError(ctx, info.FullMethod, err)
+	}
+	return res, WithDetails(ctx, err)
+}
+
+// StreamServerInterceptor adds request ID to stream errors and logs them
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	ctx := ss.Context()
+	if err != nil {
+		logRPCError(ctx, info.FullMethod, err)
+	}
+	return WithDetails(ctx, err)
+}
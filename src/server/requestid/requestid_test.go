@@ -0,0 +1,115 @@
+package requestid_test
+
+import (
+	"bytes"
+	"context"
+	"dekart/src/proto"
+	"dekart/src/server/job"
+	"dekart/src/server/requestid"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	logger := log.Logger
+	log.Logger = zerolog.New(buf)
+	t.Cleanup(func() { log.Logger = logger })
+	return buf
+}
+
+func TestMiddlewareRequestID(t *testing.T) {
+	var got string
+	handler := requestid.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestid.FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(requestid.Header, "abc123")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if got != "abc123" || w.Header().Get(requestid.Header) != "abc123" {
+		t.Errorf("expected inbound request ID to be kept, got %q", got)
+	}
+
+	for _, inbound := range []string{"", "bad id\n", strings.Repeat("a", 129)} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(requestid.Header, inbound)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if got == "" || got == inbound || w.Header().Get(requestid.Header) != got {
+			t.Errorf("%q: expected generated request ID, got %q", inbound, got)
+		}
+	}
+}
+
+func TestRequestIDPropagatedToJob(t *testing.T) {
+	logs := captureLogs(t)
+	store := job.NewStore()
+	var j *job.Job
+	handler := requestid.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		j = store.New(r.Context(), "report", "query")
+	}))
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set(requestid.Header, "req-1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	go func() {
+		for {
+			select {
+			case <-j.Status:
+			case <-j.Ctx.Done():
+				return
+			}
+		}
+	}()
+	store.CancelJob(j.ID, proto.CancelReason_CANCEL_REASON_USER_REQUEST)
+
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["message"] != "Job created" {
+			continue
+		}
+		found = true
+		if entry["requestID"] != "req-1" || entry["jobID"] != j.ID {
+			t.Errorf("expected job log with request and job ID, got %s", line)
+		}
+	}
+	if !found {
+		t.Errorf("expected job created log, got %s", logs.String())
+	}
+}
+
+func TestWithDetails(t *testing.T) {
+	ctx := requestid.NewContext(context.Background(), "abc123")
+	err := requestid.WithDetails(ctx, status.Error(codes.Internal, "boom"))
+	st := status.Convert(err)
+	if st.Code() != codes.Internal || st.Message() != "boom" {
+		t.Errorf("expected original status, got %v", st)
+	}
+	if len(st.Details()) != 1 || st.Details()[0].(*errdetails.RequestInfo).RequestId != "abc123" {
+		t.Errorf("expected request info in details, got %v", st.Details())
+	}
+	if len(status.Convert(requestid.WithDetails(ctx, err)).Details()) != 1 {
+		t.Error("expected request info to be added once")
+	}
+	if requestid.WithDetails(ctx, nil) != nil {
+		t.Error("expected nil error to stay nil")
+	}
+	plain := status.Error(codes.NotFound, "missing")
+	if requestid.WithDetails(context.Background(), plain) != plain {
+		t.Error("expected error without request ID to be unchanged")
+	}
+}
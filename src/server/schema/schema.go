@@ -0,0 +1,164 @@
+// Package schema applies versioned SQL migrations to Dekart database.
+// Migrations are applied under Postgres advisory lock, so replicas starting at the same time don't race.
+// MySQL and SQLite databases are migrated with migrations from mysql and sqlite subdirectories.
+package schema
+
+import (
+	"dekart/src/server/dialect"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang-migrate/migrate/v4"
+	// mysql and postgres drivers of migrate
+	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// Status of database schema
+type Status struct {
+	// Version applied to database, 0 when no migrations are applied
+	Version uint
+	Dirty   bool
+	// Latest migration version known to this binary
+	Latest uint
+}
+
+// Pending migrations not applied to database
+func (s Status) Pending() bool {
+	return s.Version < s.Latest
+}
+
+// ErrNewerSchema when database is migrated by newer version of Dekart
+var ErrNewerSchema = errors.New("database schema is newer than this version of Dekart")
+
+// ErrPending when migrations are not applied and auto migration is disabled
+var ErrPending = errors.New("database schema has pending migrations")
+
+// Dir of migration files, DEKART_MIGRATIONS_DIR or ./migrations
+func Dir() string {
+	dir := os.Getenv("DEKART_MIGRATIONS_DIR")
+	if dir == "" {
+		return "migrations"
+	}
+	return dir
+}
+
+// latestVersion of migrations in source
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	for err == nil {
+		var next uint
+		next, err = src.Next(version)
+		if err == nil {
+			version = next
+		}
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	return version, nil
+}
+
+func status(m *migrate.Migrate, latest uint) (Status, error) {
+	version, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return Status{Latest: latest}, err
+	}
+	return validate(Status{Version: version, Dirty: dirty, Latest: latest})
+}
+
+// validate status, dirty and newer schema are errors
+func validate(s Status) (Status, error) {
+	if s.Dirty {
+		return s, fmt.Errorf("database schema version %d is dirty, fix failed migration manually and force version", s.Version)
+	}
+	if s.Version > s.Latest {
+		return s, fmt.Errorf("%w: database version %d, latest migration %d", ErrNewerSchema, s.Version, s.Latest)
+	}
+	return s, nil
+}
+
+// up applies pending migrations; database newer than source is never migrated
+func up(m *migrate.Migrate, latest uint) (Status, error) {
+	s, err := status(m, latest)
+	if err != nil || !s.Pending() {
+		return s, err
+	}
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		return s, err
+	}
+	// other replica could migrate database while waiting for lock
+	return status(m, latest)
+}
+
+// check schema without migrating, pending migrations are error
+func check(m *migrate.Migrate, latest uint) (Status, error) {
+	return requireMigrated(status(m, latest))
+}
+
+func requireMigrated(s Status, err error) (Status, error) {
+	if err == nil && s.Pending() {
+		err = fmt.Errorf("%w: database version %d, latest migration %d", ErrPending, s.Version, s.Latest)
+	}
+	return s, err
+}
+
+// sourceDir of migrations for database dialect
+func sourceDir(dsn string, dir string) string {
+	if d := dialect.ForURL(dsn); d != dialect.Postgres {
+		return filepath.Join(dir, d.Name())
+	}
+	return dir
+}
+
+func open(dsn string, dir string) (*migrate.Migrate, uint, error) {
+	src, err := (&file.File{}).Open("file://" + sourceDir(dsn, dir))
+	if err != nil {
+		return nil, 0, err
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		src.Close()
+		return nil, 0, err
+	}
+	m, err := migrate.NewWithSourceInstance("file", src, dsn)
+	if err != nil {
+		src.Close()
+		return nil, 0, err
+	}
+	return m, latest, nil
+}
+
+// Migrate database at dsn with migrations from dir
+func Migrate(dsn string, dir string) (Status, error) {
+	if dialect.ForURL(dsn) == dialect.SQLite {
+		return migrateSQLite(dsn, dir)
+	}
+	m, latest, err := open(dsn, dir)
+	if err != nil {
+		return Status{}, err
+	}
+	defer m.Close()
+	return up(m, latest)
+}
+
+// Check database at dsn is migrated with migrations from dir
+func Check(dsn string, dir string) (Status, error) {
+	if dialect.ForURL(dsn) == dialect.SQLite {
+		return checkSQLite(dsn, dir)
+	}
+	m, latest, err := open(dsn, dir)
+	if err != nil {
+		return Status{}, err
+	}
+	defer m.Close()
+	return check(m, latest)
+}
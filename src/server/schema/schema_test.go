@@ -0,0 +1,202 @@
+package schema
+
+import (
+	"dekart/src/server/dialect"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/stub"
+	"github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// repoMigrations dir relative to package
+const repoMigrations = "../../../migrations"
+
+func migrationsDir(t *testing.T, names ...string) string {
+	dir, err := ioutil.TempDir("", "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for _, name := range names {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func newStubMigrate(t *testing.T, dir string, version int, dirty bool) (*migrate.Migrate, *stub.Stub, uint) {
+	src, err := (&file.File{}).Open("file://" + dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, _ := stub.WithInstance(nil, &stub.Config{})
+	db.(*stub.Stub).CurrentVersion = version
+	db.(*stub.Stub).IsDirty = dirty
+	m, err := migrate.NewWithInstance("file", src, "stub", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, db.(*stub.Stub), latest
+}
+
+func TestLatestVersion(t *testing.T) {
+	m, _, latest := newStubMigrate(t, migrationsDir(t, "000001_a.up.sql", "000002_b.up.sql", "000010_c.up.sql"), database.NilVersion, false)
+	defer m.Close()
+	if latest != 10 {
+		t.Errorf("expected 10, got %d", latest)
+	}
+	_, _, latest = newStubMigrate(t, migrationsDir(t), database.NilVersion, false)
+	if latest != 0 {
+		t.Errorf("expected 0 for empty dir, got %d", latest)
+	}
+}
+
+func TestRepoMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(repoMigrations, "*.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _, latest := newStubMigrate(t, repoMigrations, database.NilVersion, false)
+	defer m.Close()
+	// versions are sequential, so no migration is shadowed by duplicate version
+	if int(latest) != len(files) {
+		t.Errorf("expected latest version %d, got %d", len(files), latest)
+	}
+}
+
+func TestUp(t *testing.T) {
+	dir := migrationsDir(t, "000001_a.up.sql", "000002_b.up.sql")
+
+	m, db, latest := newStubMigrate(t, dir, database.NilVersion, false)
+	s, err := up(m, latest)
+	if err != nil || s.Version != 2 || s.Pending() {
+		t.Errorf("expected version 2, got %+v %v", s, err)
+	}
+	if !db.EqualSequence([]string{"000001_a.up.sql", "000002_b.up.sql"}) {
+		t.Errorf("unexpected migrations %v", db.MigrationSequence)
+	}
+
+	m, db, latest = newStubMigrate(t, dir, 2, false)
+	if s, err := up(m, latest); err != nil || s.Version != 2 || len(db.MigrationSequence) != 0 {
+		t.Errorf("expected no change, got %+v %v %v", s, err, db.MigrationSequence)
+	}
+
+	m, db, latest = newStubMigrate(t, dir, 3, false)
+	if _, err := up(m, latest); !errors.Is(err, ErrNewerSchema) || len(db.MigrationSequence) != 0 {
+		t.Errorf("expected ErrNewerSchema, got %v", err)
+	}
+
+	m, _, latest = newStubMigrate(t, dir, 1, true)
+	if _, err := up(m, latest); err == nil {
+		t.Error("expected dirty error")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := migrationsDir(t, "000001_a.up.sql", "000002_b.up.sql")
+	m, db, latest := newStubMigrate(t, dir, 1, false)
+	if s, err := check(m, latest); !errors.Is(err, ErrPending) || !s.Pending() || len(db.MigrationSequence) != 0 {
+		t.Errorf("expected ErrPending without migrating, got %+v %v", s, err)
+	}
+	m, _, latest = newStubMigrate(t, dir, 2, false)
+	if _, err := check(m, latest); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestDatabaseURL migrates disposable Postgres or MySQL database at DEKART_TEST_DATABASE_URL
+func TestDatabaseURL(t *testing.T) {
+	dsn := os.Getenv("DEKART_TEST_DATABASE_URL")
+	if dsn == "" || dialect.ForURL(dsn) == dialect.SQLite {
+		t.Skip("DEKART_TEST_DATABASE_URL of Postgres or MySQL is not set")
+	}
+	for i := 0; i < 2; i++ {
+		s, err := Migrate(dsn, repoMigrations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Pending() || s.Version == 0 {
+			t.Fatalf("expected migrated database, got %+v", s)
+		}
+	}
+	s, err := Check(dsn, repoMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := migrate.New("file://"+sourceDir(dsn, repoMigrations), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if err := m.Force(int(s.Latest + 1)); err != nil {
+		t.Fatal(err)
+	}
+	defer m.Force(int(s.Latest))
+	if _, err := Migrate(dsn, repoMigrations); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("expected ErrNewerSchema, got %v", err)
+	}
+}
+
+func TestDialectMigrations(t *testing.T) {
+	postgresFiles, _ := filepath.Glob(filepath.Join(repoMigrations, "*.up.sql"))
+	for _, d := range []dialect.Dialect{dialect.MySQL, dialect.SQLite} {
+		files, err := filepath.Glob(filepath.Join(repoMigrations, d.Name(), "*.up.sql"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// every Postgres migration has counterpart of the same version
+		if len(files) != len(postgresFiles) {
+			t.Errorf("expected %d %s migrations, got %d", len(postgresFiles), d.Name(), len(files))
+			continue
+		}
+		for i := range files {
+			if filepath.Base(files[i]) != filepath.Base(postgresFiles[i]) {
+				t.Errorf("expected %s, got %s", filepath.Base(postgresFiles[i]), filepath.Base(files[i]))
+			}
+		}
+	}
+}
+
+func TestSQLite(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(migrationsDir(t), "dekart.db")
+	if _, err := Check(dsn, repoMigrations); !errors.Is(err, ErrPending) {
+		t.Errorf("expected ErrPending, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		s, err := Migrate(dsn, repoMigrations)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Pending() || s.Version == 0 {
+			t.Fatalf("expected migrated database, got %+v", s)
+		}
+	}
+	s, err := Check(dsn, repoMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := dialect.Open(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`update schema_migrations set version=$1`, s.Latest+1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Migrate(dsn, repoMigrations); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("expected ErrNewerSchema, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"dekart/src/server/dialect"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// migrate sqlite driver requires cgo, so SQLite migrations are applied here
+// to schema_migrations table of the same layout
+
+const createSQLiteMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`
+
+type sqliteMigrator struct {
+	db     *sql.DB
+	src    source.Driver
+	latest uint
+}
+
+func openSQLite(dsn string, dir string) (*sqliteMigrator, error) {
+	src, err := (&file.File{}).Open("file://" + sourceDir(dsn, dir))
+	if err != nil {
+		return nil, err
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	db, err := dialect.Open(dsn)
+	if err != nil {
+		src.Close()
+		return nil, err
+	}
+	if _, err := db.Exec(createSQLiteMigrationsTable); err != nil {
+		src.Close()
+		db.Close()
+		return nil, err
+	}
+	return &sqliteMigrator{db: db, src: src, latest: latest}, nil
+}
+
+func (m *sqliteMigrator) Close() {
+	m.src.Close()
+	m.db.Close()
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (m *sqliteMigrator) status(ctx context.Context, q queryer) (Status, error) {
+	s := Status{Latest: m.latest}
+	err := q.QueryRowContext(ctx, `select version, dirty from schema_migrations limit 1`).Scan(&s.Version, &s.Dirty)
+	if err != nil && err != sql.ErrNoRows {
+		return s, err
+	}
+	return validate(s)
+}
+
+// up applies pending migrations under write lock, each migration in own transaction
+func (m *sqliteMigrator) up(ctx context.Context) (Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return Status{Latest: m.latest}, err
+	}
+	defer conn.Close()
+	for {
+		// BEGIN IMMEDIATE takes write lock, so other process waits until migration is applied
+		if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+			return Status{Latest: m.latest}, err
+		}
+		s, err := m.status(ctx, conn)
+		if err != nil || !s.Pending() {
+			conn.ExecContext(ctx, "ROLLBACK")
+			return s, err
+		}
+		version, err := m.next(s.Version)
+		if err == nil {
+			err = m.apply(ctx, conn, version)
+		}
+		if err != nil {
+			conn.ExecContext(ctx, "ROLLBACK")
+			return s, err
+		}
+		if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+			return s, err
+		}
+	}
+}
+
+// next migration version after version
+func (m *sqliteMigrator) next(version uint) (uint, error) {
+	if version == 0 {
+		return m.src.First()
+	}
+	return m.src.Next(version)
+}
+
+func (m *sqliteMigrator) apply(ctx context.Context, conn *sql.Conn, version uint) error {
+	r, _, err := m.src.ReadUp(version)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	migration, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, string(migration)); err != nil {
+		return fmt.Errorf("migration %d failed: %w", version, err)
+	}
+	if _, err := conn.ExecContext(ctx, `delete from schema_migrations`); err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, `insert into schema_migrations (version, dirty) values ($1, false)`, version)
+	return err
+}
+
+func migrateSQLite(dsn string, dir string) (Status, error) {
+	m, err := openSQLite(dsn, dir)
+	if err != nil {
+		return Status{}, err
+	}
+	defer m.Close()
+	return m.up(context.Background())
+}
+
+func checkSQLite(dsn string, dir string) (Status, error) {
+	m, err := openSQLite(dsn, dir)
+	if err != nil {
+		return Status{}, err
+	}
+	defer m.Close()
+	return requireMigrated(m.status(context.Background(), m.db))
+}
@@ -0,0 +1,69 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignRequest(t *testing.T) {
+	// get-vanilla of AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signRequest(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if auth := req.Header.Get("Authorization"); auth != expected {
+		t.Errorf("unexpected authorization %s", auth)
+	}
+}
+
+func TestAWSSecretsManager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/secretsmanager/aws4_request") ||
+			!strings.Contains(r.Header.Get("Authorization"), "x-amz-security-token") {
+			t.Errorf("unexpected request headers %v", r.Header)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch body["SecretId"] {
+		case "dekart/db":
+			w.Write([]byte(`{"Name":"dekart/db","SecretString":"password"}`))
+		case "dekart/binary":
+			w.Write([]byte(`{"Name":"dekart/binary","SecretBinary":"YmluYXJ5"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazonaws#ResourceNotFoundException","Message":"Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+	defer server.Close()
+	a := &awsSecretsManager{
+		region:       "eu-west-1",
+		accessKey:    "key",
+		secretKey:    "secret",
+		sessionToken: "token",
+		endpoint:     server.URL,
+		client:       server.Client(),
+		now:          time.Now,
+	}
+	ctx := context.Background()
+	for name, expected := range map[string]string{"dekart/db": "password", "dekart/binary": "binary"} {
+		if value, err := a.Get(ctx, name); err != nil || value != expected {
+			t.Errorf("%s: expected %s, got %q %v", name, expected, value, err)
+		}
+	}
+	if _, err := a.Get(ctx, "missing"); err == nil || !strings.HasPrefix(err.Error(), "ResourceNotFoundException") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := (&awsSecretsManager{}).Get(ctx, "dekart/db"); err == nil {
+		t.Error("expected error without credentials")
+	}
+}
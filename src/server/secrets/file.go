@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// sealedFile is JSON object of secret names and values sealed with AES-256-GCM by DEKART_SECRETS_KEY,
+// base64 of 32 bytes. File is read on every get, so rotation is picked up when file is replaced.
+type sealedFile struct {
+	path string
+	key  string
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf("DEKART_SECRETS_KEY must be base64 of 32 bytes")
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal value of secret name for sealed file; name is authenticated,
+// so sealed value can't be moved to other name
+func Seal(key string, name string, value string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (f *sealedFile) Get(ctx context.Context, name string) (string, error) {
+	if f.path == "" {
+		return "", fmt.Errorf("DEKART_SECRETS_FILE is not set")
+	}
+	aead, err := newAEAD(f.key)
+	if err != nil {
+		return "", err
+	}
+	data, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	var sealedValues map[string]string
+	if err := json.Unmarshal(data, &sealedValues); err != nil {
+		return "", fmt.Errorf("invalid secrets file %s: %w", f.path, err)
+	}
+	sealedValue, ok := sealedValues[name]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found in %s", name, f.path)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(sealedValue)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("invalid sealed value of %s", name)
+	}
+	value, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(name))
+	if err != nil {
+		// error of GCM doesn't tell whether key or value is wrong
+		return "", fmt.Errorf("cannot open sealed value of %s, check DEKART_SECRETS_KEY", name)
+	}
+	return string(value), nil
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSealedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dekart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	sealed, err := Seal(key, "db", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "secrets.json")
+	// value sealed for other name is rejected
+	data, _ := json.Marshal(map[string]string{"db": sealed, "moved": sealed})
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	f := &sealedFile{path: path, key: key}
+	ctx := context.Background()
+	if value, err := f.Get(ctx, "db"); err != nil || value != "password" {
+		t.Errorf("expected password, got %q %v", value, err)
+	}
+	for _, name := range []string{"moved", "missing"} {
+		if _, err := f.Get(ctx, name); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	other := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("o", 32)))
+	if _, err := (&sealedFile{path: path, key: other}).Get(ctx, "db"); err == nil {
+		t.Error("expected error of other key")
+	}
+	if _, err := Seal("short", "db", "password"); err == nil {
+		t.Error("expected error of invalid key")
+	}
+}
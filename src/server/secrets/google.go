@@ -0,0 +1,53 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+// googleSecretManager reads secret versions with application default credentials,
+// name is projects/p/secrets/s with optional /versions/v, latest version by default
+type googleSecretManager struct {
+	options []option.ClientOption
+	mutex   sync.Mutex
+	service *secretmanager.Service
+}
+
+// client is created on first use, so deployments without Secret Manager don't need credentials of it
+func (g *googleSecretManager) client(ctx context.Context) (*secretmanager.Service, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.service != nil {
+		return g.service, nil
+	}
+	service, err := secretmanager.NewService(ctx, g.options...)
+	if err != nil {
+		return nil, err
+	}
+	g.service = service
+	return service, nil
+}
+
+func (g *googleSecretManager) Get(ctx context.Context, name string) (string, error) {
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	service, err := g.client(ctx)
+	if err != nil {
+		return "", err
+	}
+	res, err := service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	value, err := base64.StdEncoding.DecodeString(res.Payload.Data)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/api/option"
+)
+
+func TestGoogleSecretManager(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// latest version is accessed by default
+		if r.URL.Path != "/v1/projects/p/secrets/db/versions/latest:access" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name":"projects/p/secrets/db/versions/2","payload":{"data":"cGFzc3dvcmQ="}}`))
+	}))
+	defer server.Close()
+	g := &googleSecretManager{options: []option.ClientOption{option.WithEndpoint(server.URL), option.WithoutAuthentication()}}
+	ctx := context.Background()
+	if value, err := g.Get(ctx, "projects/p/secrets/db"); err != nil || value != "password" {
+		t.Errorf("expected password, got %q %v", value, err)
+	}
+	if _, err := g.Get(ctx, "projects/p/secrets/db/versions/1"); err == nil {
+		t.Error("expected error of missing version")
+	}
+}
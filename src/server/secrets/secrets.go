@@ -0,0 +1,159 @@
+// Package secrets resolves credentials referenced by secret name, so values are not kept in env variables
+// or database. References are gsm://projects/p/secrets/s[/versions/v] for Google Secret Manager,
+// awssm://name for AWS Secrets Manager and file://name for sealed local file of DEKART_SECRETS_FILE.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Provider of secret values by name
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// Error resolving secret; name of secret is included, value never is
+type Error struct {
+	Secret   string
+	Provider string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("cannot resolve secret %s: %s", e.Secret, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus of secret error is unavailable with secret in details, connection succeeds when secret is fixed
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(codes.Unavailable, e.Error())
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   "SECRET_UNAVAILABLE",
+		Domain:   "dekart",
+		Metadata: map[string]string{"secret": e.Secret, "provider": e.Provider},
+	})
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// defaultTTL of resolved secret, rotated secret is used after TTL without restart
+const defaultTTL = 5 * time.Minute
+
+type cachedSecret struct {
+	value      string
+	resolvedAt time.Time
+}
+
+// Resolver of secret references with cache
+type Resolver struct {
+	providers map[string]Provider
+	ttl       time.Duration
+	mutex     sync.Mutex
+	cache     map[string]cachedSecret
+	// now is clock of cache, replaced in tests
+	now func() time.Time
+}
+
+// NewResolver with providers by reference scheme
+func NewResolver(providers map[string]Provider, ttl time.Duration) *Resolver {
+	return &Resolver{
+		providers: providers,
+		ttl:       ttl,
+		cache:     make(map[string]cachedSecret),
+		now:       time.Now,
+	}
+}
+
+// IsReference of secret instead of value
+func IsReference(value string) bool {
+	scheme, _ := splitReference(value)
+	return scheme != ""
+}
+
+func splitReference(ref string) (scheme string, name string) {
+	for _, s := range []string{"gsm", "awssm", "file"} {
+		if strings.HasPrefix(ref, s+"://") {
+			return s, strings.TrimPrefix(ref, s+"://")
+		}
+	}
+	return "", ""
+}
+
+// Resolve value of secret reference; value is cached for TTL, so each resolve doesn't call provider.
+// When provider fails after TTL, cached value is used until provider recovers.
+func (r *Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	scheme, name := splitReference(ref)
+	if scheme == "" {
+		return "", &Error{Secret: ref, Err: fmt.Errorf("expected gsm://, awssm:// or file:// reference")}
+	}
+	// lock is held while provider is called, so concurrent connections don't fetch same secret
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	cached, ok := r.cache[ref]
+	if ok && r.now().Sub(cached.resolvedAt) < r.ttl {
+		return cached.value, nil
+	}
+	provider, found := r.providers[scheme]
+	if !found {
+		return "", &Error{Secret: ref, Provider: scheme, Err: fmt.Errorf("provider %s is not configured", scheme)}
+	}
+	value, err := provider.Get(ctx, name)
+	if err != nil {
+		if ok {
+			log.Warn().Err(err).Str("secret", ref).Msg("Cannot refresh secret, cached value is used")
+			return cached.value, nil
+		}
+		log.Error().Err(err).Str("secret", ref).Str("provider", scheme).Msg("Cannot resolve secret")
+		return "", &Error{Secret: ref, Provider: scheme, Err: err}
+	}
+	// audit of secret use, value is never logged
+	log.Info().Str("secret", ref).Str("provider", scheme).Bool("rotated", ok && cached.value != value).Msg("Secret resolved")
+	r.cache[ref] = cachedSecret{value: value, resolvedAt: r.now()}
+	return value, nil
+}
+
+// parseTTL of DEKART_SECRETS_TTL
+func parseTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid DEKART_SECRETS_TTL %s, expected duration", value)
+	}
+	return ttl, nil
+}
+
+var defaultResolver *Resolver
+var defaultOnce sync.Once
+
+// Resolve secret reference with providers configured by env variables
+func Resolve(ctx context.Context, ref string) (string, error) {
+	defaultOnce.Do(func() {
+		ttl, err := parseTTL(os.Getenv("DEKART_SECRETS_TTL"))
+		if err != nil {
+			log.Fatal().Err(err).Send()
+		}
+		defaultResolver = NewResolver(map[string]Provider{
+			"gsm":   &googleSecretManager{},
+			"awssm": newAWSSecretsManager(),
+			"file":  &sealedFile{path: os.Getenv("DEKART_SECRETS_FILE"), key: os.Getenv("DEKART_SECRETS_KEY")},
+		}, ttl)
+	})
+	return defaultResolver.Resolve(ctx, ref)
+}
@@ -0,0 +1,120 @@
+package tiles
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// extent of tile in MVT coordinates
+const extent = 4096
+
+// geometry types of MVT feature
+const (
+	pointType   uint32 = 1
+	lineType    uint32 = 2
+	polygonType uint32 = 3
+)
+
+// bbox in world coordinates, 0,0 is top left corner of web mercator world, 1,1 is bottom right
+type bbox struct {
+	minX, minY, maxX, maxY float64
+}
+
+func (b bbox) intersects(o bbox) bool {
+	return b.minX <= o.maxX && o.minX <= b.maxX && b.minY <= o.maxY && o.minY <= b.maxY
+}
+
+// tile coordinates
+type tile struct {
+	z, x, y int
+}
+
+// bounds of tile in world coordinates
+func (t tile) bounds() bbox {
+	size := 1 / float64(uint64(1)<<uint(t.z))
+	return bbox{
+		minX: float64(t.x) * size,
+		minY: float64(t.y) * size,
+		maxX: float64(t.x+1) * size,
+		maxY: float64(t.y+1) * size,
+	}
+}
+
+// pixel of world coordinates in tile extent
+func (t tile) pixel(x, y float64) (int64, int64) {
+	scale := float64(uint64(1)<<uint(t.z)) * extent
+	return int64(math.Floor(x*scale - float64(t.x)*extent)), int64(math.Floor(y*scale - float64(t.y)*extent))
+}
+
+// geometry of feature; lines and polygons implement it with clipping and simplification in tile
+type geometry interface {
+	bounds() bbox
+	// encode geometry in tile; false when geometry is outside of tile or simplified away
+	encode(t tile, seen map[[2]int64]bool) (uint32, []uint32, bool)
+}
+
+// point in world coordinates
+type point struct {
+	x, y float64
+}
+
+// newPoint projects longitude and latitude to web mercator world
+func newPoint(lng, lat float64) (point, error) {
+	if lng < -180 || lng > 180 || lat < -85.0511 || lat > 85.0511 || math.IsNaN(lng) || math.IsNaN(lat) {
+		return point{}, fmt.Errorf("point %v %v is outside of web mercator", lng, lat)
+	}
+	sin := math.Sin(lat * math.Pi / 180)
+	return point{
+		x: lng/360 + 0.5,
+		y: 0.5 - math.Log((1+sin)/(1-sin))/(4*math.Pi),
+	}, nil
+}
+
+func (p point) bounds() bbox {
+	return bbox{p.x, p.y, p.x, p.y}
+}
+
+// encode point; points in same pixel are simplified to first one, so low zoom tiles stay small
+func (p point) encode(t tile, seen map[[2]int64]bool) (uint32, []uint32, bool) {
+	x, y := t.pixel(p.x, p.y)
+	if x < 0 || y < 0 || x >= extent || y >= extent {
+		return 0, nil, false
+	}
+	key := [2]int64{x, y}
+	if seen[key] {
+		return 0, nil, false
+	}
+	seen[key] = true
+	return pointType, []uint32{command(1, 1), zigzag(x), zigzag(y)}, true
+}
+
+// command of MVT geometry, id 1 is MoveTo
+func command(id uint32, count uint32) uint32 {
+	return id&0x7 | count<<3
+}
+
+func zigzag(v int64) uint32 {
+	return uint32((v << 1) ^ (v >> 63))
+}
+
+// pointRe is WKT point as stored in results of GEOGRAPHY columns
+var pointRe = regexp.MustCompile(`(?i)^\s*POINT\s*\(\s*(\S+)\s+(\S+)\s*\)\s*$`)
+
+// parseWKT geometry; only points are supported
+func parseWKT(wkt string) (geometry, error) {
+	m := pointRe.FindStringSubmatch(wkt)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported geometry %.20s, only points are supported", wkt)
+	}
+	lng, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil, err
+	}
+	lat, err := strconv.ParseFloat(m[2], 64)
+	if err != nil {
+		return nil, err
+	}
+	return newPoint(lng, lat)
+}
@@ -0,0 +1,210 @@
+package tiles
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// indexZoom of grid used to find features of tile
+const indexZoom = 10
+
+// ErrNoGeometry when geometry columns are not found in result
+var ErrNoGeometry = errors.New("result has no geometry column")
+
+// Columns with geometry of feature; Geometry is WKT column, or Lat and Lng are coordinate columns.
+// Empty columns are detected by name
+type Columns struct {
+	Geometry string
+	Lat      string
+	Lng      string
+}
+
+func (c Columns) String() string {
+	return strings.Join([]string{c.Geometry, c.Lat, c.Lng}, ",")
+}
+
+// detectColumns of geometry by common names
+func detectColumns(header []string, columns Columns) (Columns, error) {
+	find := func(names ...string) string {
+		for _, name := range names {
+			for _, column := range header {
+				if strings.EqualFold(column, name) {
+					return column
+				}
+			}
+		}
+		return ""
+	}
+	if columns.Geometry != "" || (columns.Lat != "" && columns.Lng != "") {
+		return columns, nil
+	}
+	if lat, lng := find("lat", "latitude"), find("lng", "lon", "long", "longitude"); lat != "" && lng != "" {
+		return Columns{Lat: lat, Lng: lng}, nil
+	}
+	if geometry := find("geometry", "geom", "geography", "geo", "wkt", "location"); geometry != "" {
+		return Columns{Geometry: geometry}, nil
+	}
+	return columns, ErrNoGeometry
+}
+
+type feature struct {
+	geometry   geometry
+	properties []string
+}
+
+// index of result features on grid of indexZoom tiles
+type index struct {
+	keys     []string
+	features []feature
+	grid     map[tile][]int32
+}
+
+func columnIndex(header []string, name string) (int, error) {
+	for i, column := range header {
+		if column == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("column %s not found: %w", name, ErrNoGeometry)
+}
+
+// buildIndex of CSV result; rows without valid geometry are skipped
+func buildIndex(r io.Reader, columns Columns) (*index, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.ReuseRecord = true
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	header = append([]string(nil), header...)
+	columns, err = detectColumns(header, columns)
+	if err != nil {
+		return nil, err
+	}
+	var geometryColumns []int
+	for _, name := range []string{columns.Geometry, columns.Lng, columns.Lat} {
+		if name == "" {
+			continue
+		}
+		i, err := columnIndex(header, name)
+		if err != nil {
+			return nil, err
+		}
+		geometryColumns = append(geometryColumns, i)
+	}
+	idx := &index{grid: make(map[tile][]int32)}
+	isGeometry := make([]bool, len(header))
+	for _, i := range geometryColumns {
+		isGeometry[i] = true
+	}
+	for i, name := range header {
+		if !isGeometry[i] {
+			idx.keys = append(idx.keys, name)
+		}
+	}
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return idx, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var g geometry
+		if columns.Geometry != "" {
+			g, err = parseWKT(record[geometryColumns[0]])
+		} else {
+			g, err = parseLngLat(record[geometryColumns[0]], record[geometryColumns[1]])
+		}
+		if err != nil {
+			continue
+		}
+		properties := make([]string, 0, len(idx.keys))
+		for i, value := range record {
+			if !isGeometry[i] {
+				properties = append(properties, value)
+			}
+		}
+		idx.add(feature{geometry: g, properties: properties})
+	}
+}
+
+func parseLngLat(lngValue, latValue string) (geometry, error) {
+	lng, err := strconv.ParseFloat(lngValue, 64)
+	if err != nil {
+		return nil, err
+	}
+	lat, err := strconv.ParseFloat(latValue, 64)
+	if err != nil {
+		return nil, err
+	}
+	return newPoint(lng, lat)
+}
+
+// gridRange of index tiles covering bounds
+func gridRange(b bbox) (tile, tile) {
+	n := 1 << indexZoom
+	clamp := func(v float64) int {
+		i := int(v * float64(n))
+		if i < 0 {
+			return 0
+		}
+		if i >= n {
+			return n - 1
+		}
+		return i
+	}
+	return tile{indexZoom, clamp(b.minX), clamp(b.minY)}, tile{indexZoom, clamp(b.maxX), clamp(b.maxY)}
+}
+
+// add feature to every grid tile it intersects
+func (idx *index) add(f feature) {
+	i := int32(len(idx.features))
+	idx.features = append(idx.features, f)
+	min, max := gridRange(f.geometry.bounds())
+	for x := min.x; x <= max.x; x++ {
+		for y := min.y; y <= max.y; y++ {
+			key := tile{indexZoom, x, y}
+			idx.grid[key] = append(idx.grid[key], i)
+		}
+	}
+}
+
+// find features intersecting tile, each feature once in order of result
+func (idx *index) find(t tile) []int32 {
+	b := t.bounds()
+	min, max := gridRange(b)
+	found := make(map[int32]bool)
+	collect := func(key tile) {
+		for _, i := range idx.grid[key] {
+			if !found[i] && idx.features[i].geometry.bounds().intersects(b) {
+				found[i] = true
+			}
+		}
+	}
+	if (max.x-min.x+1)*(max.y-min.y+1) > len(idx.grid) {
+		// low zoom tile covers more grid tiles than there are in index
+		for key := range idx.grid {
+			if key.x >= min.x && key.x <= max.x && key.y >= min.y && key.y <= max.y {
+				collect(key)
+			}
+		}
+	} else {
+		for x := min.x; x <= max.x; x++ {
+			for y := min.y; y <= max.y; y++ {
+				collect(tile{indexZoom, x, y})
+			}
+		}
+	}
+	result := make([]int32, 0, len(found))
+	for i := range found {
+		result = append(result, i)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,83 @@
+package tiles
+
+import (
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// layerName of result features in tile
+const layerName = "result"
+
+// encodeValue of property, numbers are encoded as double so they can be used in map styles
+func encodeValue(value string) []byte {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return protowire.AppendFixed64(protowire.AppendTag(nil, 3, protowire.Fixed64Type), math.Float64bits(f))
+	}
+	return protowire.AppendString(protowire.AppendTag(nil, 1, protowire.BytesType), value)
+}
+
+// encodeTile of features in Mapbox Vector Tile format
+func (idx *index) encodeTile(t tile) []byte {
+	var layer []byte
+	layer = protowire.AppendTag(layer, 15, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, 2)
+	layer = protowire.AppendTag(layer, 1, protowire.BytesType)
+	layer = protowire.AppendString(layer, layerName)
+	for _, key := range idx.keys {
+		layer = protowire.AppendTag(layer, 3, protowire.BytesType)
+		layer = protowire.AppendString(layer, key)
+	}
+	values := make(map[string]uint64)
+	var encodedValues [][]byte
+	seen := make(map[[2]int64]bool)
+	for _, i := range idx.find(t) {
+		f := idx.features[i]
+		geomType, commands, ok := f.geometry.encode(t, seen)
+		if !ok {
+			continue
+		}
+		var tags []byte
+		for k, value := range f.properties {
+			if value == "" {
+				// null in result
+				continue
+			}
+			v, ok := values[value]
+			if !ok {
+				v = uint64(len(encodedValues))
+				values[value] = v
+				encodedValues = append(encodedValues, encodeValue(value))
+			}
+			tags = protowire.AppendVarint(tags, uint64(k))
+			tags = protowire.AppendVarint(tags, v)
+		}
+		var geom []byte
+		for _, c := range commands {
+			geom = protowire.AppendVarint(geom, uint64(c))
+		}
+		var featureBytes []byte
+		featureBytes = protowire.AppendTag(featureBytes, 1, protowire.VarintType)
+		featureBytes = protowire.AppendVarint(featureBytes, uint64(i)+1)
+		if len(tags) > 0 {
+			featureBytes = protowire.AppendTag(featureBytes, 2, protowire.BytesType)
+			featureBytes = protowire.AppendBytes(featureBytes, tags)
+		}
+		featureBytes = protowire.AppendTag(featureBytes, 3, protowire.VarintType)
+		featureBytes = protowire.AppendVarint(featureBytes, uint64(geomType))
+		featureBytes = protowire.AppendTag(featureBytes, 4, protowire.BytesType)
+		featureBytes = protowire.AppendBytes(featureBytes, geom)
+		layer = protowire.AppendTag(layer, 2, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, featureBytes)
+	}
+	for _, value := range encodedValues {
+		layer = protowire.AppendTag(layer, 4, protowire.BytesType)
+		layer = protowire.AppendBytes(layer, value)
+	}
+	layer = protowire.AppendTag(layer, 5, protowire.VarintType)
+	layer = protowire.AppendVarint(layer, extent)
+	var tileBytes []byte
+	tileBytes = protowire.AppendTag(tileBytes, 3, protowire.BytesType)
+	return protowire.AppendBytes(tileBytes, layer)
+}
@@ -0,0 +1,141 @@
+package tiles
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ResultTooLargeError when result is too large to be indexed in memory
+type ResultTooLargeError struct {
+	Size  int64
+	Limit int64
+}
+
+func (e *ResultTooLargeError) Error() string {
+	return fmt.Sprintf("result of %d bytes exceeds tiles limit of %d bytes, use H3 aggregation or smaller result", e.Size, e.Limit)
+}
+
+// ErrInvalidTile when tile coordinates are out of range
+var ErrInvalidTile = errors.New("invalid tile")
+
+// Source opens stored CSV result and returns its size
+type Source func(ctx context.Context, resultID string) (io.ReadCloser, int64, error)
+
+// cacheEntry is index of result built once and shared by concurrent tile requests
+type cacheEntry struct {
+	key   string
+	index *index
+	err   error
+	ready chan struct{}
+}
+
+// Tiler builds vector tiles of stored results; index of result is built on first tile request
+// and kept in LRU cache; use NewTiler to init
+type Tiler struct {
+	source        Source
+	maxResultSize int64
+	capacity      int
+	entries       map[string]*list.Element
+	lru           *list.List
+	mutex         sync.Mutex
+}
+
+// parseInt of env variable or default
+func parseInt(name string, defaultValue int64) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || i <= 0 {
+		log.Fatal().Str("value", value).Msgf("Invalid %s", name)
+	}
+	return i
+}
+
+// NewTiler of results from source; DEKART_TILES_MAX_RESULT_SIZE limits indexed result size,
+// DEKART_TILES_CACHE_SIZE is number of result indexes kept in memory
+func NewTiler(source Source) *Tiler {
+	return &Tiler{
+		source:        source,
+		maxResultSize: parseInt("DEKART_TILES_MAX_RESULT_SIZE", 100<<20),
+		capacity:      int(parseInt("DEKART_TILES_CACHE_SIZE", 8)),
+		entries:       make(map[string]*list.Element),
+		lru:           list.New(),
+	}
+}
+
+// get index from cache or build it; failed builds are not cached
+func (t *Tiler) get(ctx context.Context, resultID string, columns Columns) (*index, error) {
+	key := resultID + ":" + columns.String()
+	t.mutex.Lock()
+	if element, ok := t.entries[key]; ok {
+		t.lru.MoveToFront(element)
+		entry := element.Value.(*cacheEntry)
+		t.mutex.Unlock()
+		select {
+		case <-entry.ready:
+			return entry.index, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	entry := &cacheEntry{key: key, ready: make(chan struct{})}
+	t.entries[key] = t.lru.PushFront(entry)
+	for t.lru.Len() > t.capacity {
+		oldest := t.lru.Back()
+		t.lru.Remove(oldest)
+		delete(t.entries, oldest.Value.(*cacheEntry).key)
+	}
+	t.mutex.Unlock()
+
+	// index is shared, so it is built without request context
+	entry.index, entry.err = t.build(context.Background(), resultID, columns)
+	close(entry.ready)
+	if entry.err != nil {
+		t.mutex.Lock()
+		if element, ok := t.entries[key]; ok && element.Value == entry {
+			t.lru.Remove(element)
+			delete(t.entries, key)
+		}
+		t.mutex.Unlock()
+	}
+	return entry.index, entry.err
+}
+
+func (t *Tiler) build(ctx context.Context, resultID string, columns Columns) (*index, error) {
+	r, size, err := t.source(ctx, resultID)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	if size > t.maxResultSize {
+		return nil, &ResultTooLargeError{Size: size, Limit: t.maxResultSize}
+	}
+	idx, err := buildIndex(r, columns)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("resultID", resultID).Int("features", len(idx.features)).Msg("Result indexed for tiles")
+	return idx, nil
+}
+
+// Tile of result in Mapbox Vector Tile format
+func (t *Tiler) Tile(ctx context.Context, resultID string, columns Columns, z, x, y int) ([]byte, error) {
+	if z < 0 || z > 24 || x < 0 || y < 0 || x >= 1<<uint(z) || y >= 1<<uint(z) {
+		return nil, fmt.Errorf("%w %d/%d/%d", ErrInvalidTile, z, x, y)
+	}
+	idx, err := t.get(ctx, resultID, columns)
+	if err != nil {
+		return nil, err
+	}
+	return idx.encodeTile(tile{z, x, y}), nil
+}
@@ -0,0 +1,275 @@
+package tiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type decodedFeature struct {
+	id       uint64
+	geomType uint64
+	geometry []uint64
+	tags     []uint64
+}
+
+type decodedLayer struct {
+	name     string
+	keys     []string
+	features []decodedFeature
+	extent   uint64
+}
+
+// fields of protobuf message, calls fn for each field
+func fields(t *testing.T, b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) int) {
+	t.Helper()
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		b = b[n:]
+		n = fn(num, typ, b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		b = b[n:]
+	}
+}
+
+func packed(t *testing.T, b []byte) []uint64 {
+	var values []uint64
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		values = append(values, v)
+		b = b[n:]
+	}
+	return values
+}
+
+func decodeTile(t *testing.T, tileBytes []byte) decodedLayer {
+	t.Helper()
+	var layer decodedLayer
+	fields(t, tileBytes, func(num protowire.Number, typ protowire.Type, b []byte) int {
+		layerBytes, n := protowire.ConsumeBytes(b)
+		fields(t, layerBytes, func(num protowire.Number, typ protowire.Type, b []byte) int {
+			switch num {
+			case 1, 3:
+				s, n := protowire.ConsumeString(b)
+				if num == 1 {
+					layer.name = s
+				} else {
+					layer.keys = append(layer.keys, s)
+				}
+				return n
+			case 2:
+				featureBytes, n := protowire.ConsumeBytes(b)
+				var f decodedFeature
+				fields(t, featureBytes, func(num protowire.Number, typ protowire.Type, b []byte) int {
+					switch num {
+					case 2, 4:
+						v, n := protowire.ConsumeBytes(b)
+						if num == 2 {
+							f.tags = packed(t, v)
+						} else {
+							f.geometry = packed(t, v)
+						}
+						return n
+					default:
+						v, n := protowire.ConsumeVarint(b)
+						if num == 1 {
+							f.id = v
+						} else {
+							f.geomType = v
+						}
+						return n
+					}
+				})
+				layer.features = append(layer.features, f)
+				return n
+			case 5:
+				v, n := protowire.ConsumeVarint(b)
+				layer.extent = v
+				return n
+			default:
+				return protowire.ConsumeFieldValue(num, typ, b)
+			}
+		})
+		return n
+	})
+	return layer
+}
+
+// countingSource of CSV results
+type countingSource struct {
+	results map[string]string
+	opened  map[string]int
+	mutex   sync.Mutex
+}
+
+func (s *countingSource) open(ctx context.Context, resultID string) (io.ReadCloser, int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.opened[resultID]++
+	result, ok := s.results[resultID]
+	if !ok {
+		return nil, 0, fmt.Errorf("result %s not found", resultID)
+	}
+	return ioutil.NopCloser(strings.NewReader(result)), int64(len(result)), nil
+}
+
+func newTestTiler(results map[string]string) (*Tiler, *countingSource) {
+	source := &countingSource{results: results, opened: make(map[string]int)}
+	return NewTiler(source.open), source
+}
+
+const points = `name,lat,lng,value
+berlin,52.52,13.405,1
+berlin nearby,52.52001,13.40501,2
+new york,40.7128,-74.006,3
+sydney,-33.8688,151.2093,
+no location,,,4
+`
+
+func TestTilePoints(t *testing.T) {
+	tiler, _ := newTestTiler(map[string]string{"points": points})
+	world, err := tiler.Tile(context.Background(), "points", Columns{}, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer := decodeTile(t, world)
+	if layer.name != layerName || layer.extent != extent || strings.Join(layer.keys, ",") != "name,value" {
+		t.Errorf("unexpected layer %+v", layer)
+	}
+	// berlin points are in same pixel at zoom 0
+	if len(layer.features) != 3 {
+		t.Fatalf("expected 3 features, got %+v", layer.features)
+	}
+	for _, f := range layer.features {
+		if f.geomType != uint64(pointType) || len(f.geometry) != 3 || f.geometry[0] != uint64(command(1, 1)) {
+			t.Errorf("unexpected point %+v", f)
+		}
+	}
+	// sydney has no value, only name tag
+	if sydney := layer.features[2]; len(sydney.tags) != 2 || sydney.tags[0] != 0 {
+		t.Errorf("expected null value skipped, got %+v", sydney)
+	}
+
+	// north east quadrant has berlin points only
+	northEast, err := tiler.Tile(context.Background(), "points", Columns{}, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layer := decodeTile(t, northEast); len(layer.features) != 1 || layer.features[0].id != 1 {
+		t.Errorf("expected berlin in north east, got %+v", layer.features)
+	}
+	// berlin points are in different pixels at high zoom, so none is simplified away
+	p, _ := newPoint(13.405, 52.52)
+	z := 20
+	tx, ty := int(p.x*float64(int(1)<<uint(z))), int(p.y*float64(int(1)<<uint(z)))
+	berlin, err := tiler.Tile(context.Background(), "points", Columns{}, z, tx, ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if layer := decodeTile(t, berlin); len(layer.features) != 2 || layer.features[0].geometry[1] == layer.features[1].geometry[1] {
+		t.Errorf("expected both berlin points, got %+v", layer.features)
+	}
+}
+
+func TestTileGeometryColumn(t *testing.T) {
+	tiler, _ := newTestTiler(map[string]string{
+		"wkt":     "id,geography\n1,POINT(13.405 52.52)\n2,\"POLYGON((0 0, 1 0, 1 1, 0 0))\"\n",
+		"columns": "id,y,x\n1,52.52,13.405\n",
+		"none":    "id,value\n1,2\n",
+	})
+	layer := decodeTile(t, mustTile(t, tiler, "wkt", Columns{}))
+	if len(layer.features) != 1 || strings.Join(layer.keys, ",") != "id" {
+		t.Errorf("expected point and unsupported polygon skipped, got %+v", layer)
+	}
+	layer = decodeTile(t, mustTile(t, tiler, "columns", Columns{Lat: "y", Lng: "x"}))
+	if len(layer.features) != 1 {
+		t.Errorf("expected point from configured columns, got %+v", layer)
+	}
+	_, err := tiler.Tile(context.Background(), "none", Columns{}, 0, 0, 0)
+	if !errors.Is(err, ErrNoGeometry) {
+		t.Errorf("expected ErrNoGeometry, got %v", err)
+	}
+	_, err = tiler.Tile(context.Background(), "wkt", Columns{Geometry: "missing"}, 0, 0, 0)
+	if !errors.Is(err, ErrNoGeometry) {
+		t.Errorf("expected ErrNoGeometry for missing column, got %v", err)
+	}
+}
+
+func mustTile(t *testing.T, tiler *Tiler, resultID string, columns Columns) []byte {
+	t.Helper()
+	b, err := tiler.Tile(context.Background(), resultID, columns, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestTilerCache(t *testing.T) {
+	tiler, source := newTestTiler(map[string]string{"a": points, "b": points})
+	tiler.capacity = 1
+	mustTile(t, tiler, "a", Columns{})
+	mustTile(t, tiler, "a", Columns{})
+	if source.opened["a"] != 1 {
+		t.Errorf("expected index reused, opened %d times", source.opened["a"])
+	}
+	mustTile(t, tiler, "b", Columns{})
+	mustTile(t, tiler, "a", Columns{})
+	if source.opened["a"] != 2 {
+		t.Errorf("expected index evicted, opened %d times", source.opened["a"])
+	}
+
+	// failed builds are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := tiler.Tile(context.Background(), "missing", Columns{}, 0, 0, 0); err == nil {
+			t.Error("expected error")
+		}
+	}
+	if source.opened["missing"] != 2 {
+		t.Errorf("expected failed index rebuilt, opened %d times", source.opened["missing"])
+	}
+}
+
+func TestTilerConcurrentRequestsShareIndex(t *testing.T) {
+	tiler, source := newTestTiler(map[string]string{"a": points})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mustTile(t, tiler, "a", Columns{})
+		}()
+	}
+	wg.Wait()
+	if source.opened["a"] != 1 {
+		t.Errorf("expected single index build, opened %d times", source.opened["a"])
+	}
+}
+
+func TestTilerLimits(t *testing.T) {
+	tiler, _ := newTestTiler(map[string]string{"a": points})
+	tiler.maxResultSize = 10
+	var tooLarge *ResultTooLargeError
+	if _, err := tiler.Tile(context.Background(), "a", Columns{}, 0, 0, 0); !errors.As(err, &tooLarge) {
+		t.Errorf("expected ResultTooLargeError, got %v", err)
+	}
+	for _, c := range [][3]int{{0, 1, 0}, {1, 0, 2}, {-1, 0, 0}, {25, 0, 0}} {
+		if _, err := tiler.Tile(context.Background(), "a", Columns{}, c[0], c[1], c[2]); !errors.Is(err, ErrInvalidTile) {
+			t.Errorf("%v: expected ErrInvalidTile, got %v", c, err)
+		}
+	}
+}
@@ -2,15 +2,19 @@ package user
 
 import (
 	"context"
+	"net"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
-	"google.golang.org/api/idtoken"
 )
 
 // Claims stores user detail received from request
 type Claims struct {
 	Email string
+	// Groups from OIDC token, empty for other authentication methods
+	Groups []string
 }
 
 // ContextKey type
@@ -18,30 +22,62 @@ type ContextKey string
 
 const contextKey ContextKey = "userDetails"
 
+const claimsErrorKey ContextKey = "claimsError"
+
+// ClaimsCheckConfig of user authentication
+type ClaimsCheckConfig struct {
+	Audience       string
+	RequireIAP     bool
+	DevClaimsEmail string
+	// TrustedHeader with user email set by proxy, e.g. X-Forwarded-Email
+	TrustedHeader string
+	// TrustedProxies allowed to set TrustedHeader
+	TrustedProxies []*net.IPNet
+	// OIDC login flow, when set users are resolved from session cookie
+	OIDC *OIDC
+}
+
 // ClaimsCheck factory to add user claims to context
 type ClaimsCheck struct {
-	audience       string
-	requireIAP     bool
-	devClaimsEmail string
+	ClaimsCheckConfig
 }
 
 // NewClaimsCheck creates Context
-func NewClaimsCheck(audience string, requireIAP bool, devClaimsEmail string) ClaimsCheck {
-	if !requireIAP {
-		log.Info().Msgf("All users can read/write all entities")
-	} else {
+func NewClaimsCheck(config ClaimsCheckConfig) ClaimsCheck {
+	if config.RequireIAP {
 		log.Info().Msgf("Dekart configured to require IAP")
-		if devClaimsEmail != "" {
+		if config.DevClaimsEmail != "" {
 			log.Warn().Msgf("Use DEKART_DEV_CLAIMS_EMAIL only in development environment")
 		}
+	} else if config.OIDC != nil {
+		log.Info().Msgf("Dekart configured to require OIDC login with %s", config.OIDC.config.Issuer)
+	} else if config.TrustedHeader != "" {
+		log.Info().Msgf("Dekart configured to trust %s header from %d proxy networks", config.TrustedHeader, len(config.TrustedProxies))
+	} else {
+		log.Info().Msgf("All users can read/write all entities")
 	}
 	return ClaimsCheck{
-		audience,
-		requireIAP,
-		devClaimsEmail,
+		ClaimsCheckConfig: config,
 	}
 }
 
+// ParseCIDRs from comma separated list
+func ParseCIDRs(s string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0)
+	for _, cidr := range strings.Split(s, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
 // UnknownEmail is set as claims email when auth is not required
 const UnknownEmail = "UNKNOWN_EMAIL"
 
@@ -49,14 +85,22 @@ const UnknownEmail = "UNKNOWN_EMAIL"
 func (c ClaimsCheck) GetContext(r *http.Request) context.Context {
 	ctx := r.Context()
 	var claims *Claims
-	if c.requireIAP {
-		if c.devClaimsEmail != "" {
+	if c.RequireIAP {
+		if c.DevClaimsEmail != "" {
 			claims = &Claims{
-				Email: c.devClaimsEmail,
+				Email: c.DevClaimsEmail,
 			}
 		} else {
 			claims = c.validateJWTFromAppEngine(ctx, r.Header.Get("X-Goog-IAP-JWT-Assertion"))
 		}
+	} else if c.OIDC != nil {
+		var err error
+		claims, err = c.OIDC.claimsFromSession(r)
+		if err != nil {
+			ctx = context.WithValue(ctx, claimsErrorKey, err)
+		}
+	} else if c.TrustedHeader != "" {
+		claims = c.claimsFromTrustedHeader(r)
 	} else {
 		claims = &Claims{
 			Email: UnknownEmail,
@@ -69,6 +113,12 @@ func (c ClaimsCheck) GetContext(r *http.Request) context.Context {
 	return userCtx
 }
 
+// ClaimsError when claims could not be resolved because of server error, e.g. session can't be read
+func ClaimsError(ctx context.Context) error {
+	err, _ := ctx.Value(claimsErrorKey).(error)
+	return err
+}
+
 //GetClaims from the context
 func GetClaims(ctx context.Context) *Claims {
 	return ctx.Value(contextKey).(*Claims)
@@ -77,16 +127,36 @@ func GetClaims(ctx context.Context) *Claims {
 // validateJWTFromAppEngine validates a JWT found in the
 // "x-goog-iap-jwt-assertion" header.
 func (c ClaimsCheck) validateJWTFromAppEngine(ctx context.Context, iapJWT string) *Claims {
-	payload, err := idtoken.Validate(ctx, iapJWT, c.audience)
+	if iapJWT == "" {
+		return nil
+	}
+	claims, err := validateIAPJWT(ctx, iapJWT, c.Audience, time.Now())
 	if err != nil {
 		log.Warn().Err(err).Msg("Error validating IAP JWT")
 		return nil
 	}
-	if email, ok := payload.Claims["email"]; ok {
-		return &Claims{
-			Email: email.(string),
+	return claims
+}
+
+// claimsFromTrustedHeader when request comes directly from trusted proxy
+func (c ClaimsCheck) claimsFromTrustedHeader(r *http.Request) *Claims {
+	email := r.Header.Get(c.TrustedHeader)
+	if email == "" {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		log.Warn().Err(err).Msg("Cannot parse remote address")
+		return nil
+	}
+	ip := net.ParseIP(host)
+	for _, network := range c.TrustedProxies {
+		if ip != nil && network.Contains(ip) {
+			return &Claims{
+				Email: email,
+			}
 		}
 	}
-	log.Warn().Msg("No email in Claims")
+	log.Warn().Str("remoteAddr", r.RemoteAddr).Msgf("%s header from untrusted address", c.TrustedHeader)
 	return nil
 }
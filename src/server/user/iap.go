@@ -0,0 +1,35 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/idtoken"
+)
+
+const iapIssuer = "https://cloud.google.com/iap"
+
+// validateIDToken checks signature with Google public keys, audience and expiration
+var validateIDToken = idtoken.Validate
+
+// validateIAPJWT validates IAP JWT, idtoken does not check issuer and issued at
+func validateIAPJWT(ctx context.Context, token string, audience string, now time.Time) (*Claims, error) {
+	payload, err := validateIDToken(ctx, token, audience)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Issuer != iapIssuer {
+		return nil, fmt.Errorf("unexpected JWT issuer %s", payload.Issuer)
+	}
+	if now.Add(clockSkew).Unix() < payload.IssuedAt {
+		return nil, fmt.Errorf("JWT issued in the future")
+	}
+	email, ok := payload.Claims["email"].(string)
+	if !ok || email == "" {
+		return nil, fmt.Errorf("no email in JWT claims")
+	}
+	return &Claims{
+		Email: email,
+	}, nil
+}
@@ -0,0 +1,247 @@
+package user
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clockSkew tolerated when validating exp and iat
+const clockSkew = 30 * time.Second
+
+// minKeysRefreshInterval prevents fetching keys on every token with unknown kid
+const minKeysRefreshInterval = time.Minute
+
+// keysFetchErrorBackoff before next fetch when keys endpoint fails
+const keysFetchErrorBackoff = 5 * time.Second
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// keySet caches JWT public keys, refreshes them when token signed with unknown key
+type keySet struct {
+	url    string
+	client *http.Client
+	keys   map[string]crypto.PublicKey
+	// nextFetch is earliest time keys can be fetched again
+	nextFetch time.Time
+	// fetching is closed when fetch in progress is done
+	fetching chan struct{}
+	fetchErr error
+	mutex    sync.Mutex
+}
+
+func newKeySet(url string) *keySet {
+	return &keySet{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		keys:   make(map[string]crypto.PublicKey),
+	}
+}
+
+func decodeCoordinate(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k *keySet) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := k.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching keys %s: %s", k.url, res.Status)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&set)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, key := range set.Keys {
+		switch {
+		case key.Kty == "EC" && key.Crv == "P-256":
+			x, err := decodeCoordinate(key.X)
+			if err != nil {
+				return nil, err
+			}
+			y, err := decodeCoordinate(key.Y)
+			if err != nil {
+				return nil, err
+			}
+			keys[key.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+		case key.Kty == "RSA":
+			n, err := decodeCoordinate(key.N)
+			if err != nil {
+				return nil, err
+			}
+			e, err := decodeCoordinate(key.E)
+			if err != nil {
+				return nil, err
+			}
+			keys[key.Kid] = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		}
+	}
+	return keys, nil
+}
+
+// refresh keys without holding mutex, concurrent callers wait for single fetch
+func (k *keySet) refresh(ctx context.Context) error {
+	k.mutex.Lock()
+	fetching := k.fetching
+	if fetching == nil {
+		if time.Now().Before(k.nextFetch) {
+			err := k.fetchErr
+			k.mutex.Unlock()
+			return err
+		}
+		fetching = make(chan struct{})
+		k.fetching = fetching
+		k.mutex.Unlock()
+
+		// fetch is shared by waiting requests, so it is not bound to ctx of one of them
+		keys, err := k.fetch(context.Background())
+
+		k.mutex.Lock()
+		k.fetchErr = err
+		if err != nil {
+			k.nextFetch = time.Now().Add(keysFetchErrorBackoff)
+		} else {
+			k.keys = keys
+			k.nextFetch = time.Now().Add(minKeysRefreshInterval)
+		}
+		k.fetching = nil
+		k.mutex.Unlock()
+		close(fetching)
+		return err
+	}
+	k.mutex.Unlock()
+	select {
+	case <-fetching:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	return k.fetchErr
+}
+
+func (k *keySet) lookup(kid string) (crypto.PublicKey, bool) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+func (k *keySet) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+	// keys are rotated, refresh
+	err := k.refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key, ok := k.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key id %s", kid)
+}
+
+// verifyJWT signature with key from keys, returns JWT payload
+func verifyJWT(ctx context.Context, keys *keySet, token string, algs ...string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed JWT")
+	}
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("malformed JWT header: %w", err)
+	}
+	alg := ""
+	for _, a := range algs {
+		if header.Alg == a {
+			alg = a
+		}
+	}
+	if alg == "" {
+		return nil, fmt.Errorf("unexpected JWT alg %s", header.Alg)
+	}
+	key, err := keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature")
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if alg != "ES256" || len(signature) != 64 {
+			return nil, fmt.Errorf("invalid JWT signature")
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(key, hash[:], r, s) {
+			return nil, fmt.Errorf("invalid JWT signature")
+		}
+	case *rsa.PublicKey:
+		if alg != "RS256" || rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) != nil {
+			return nil, fmt.Errorf("invalid JWT signature")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type")
+	}
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT payload: %w", err)
+	}
+	return payloadJSON, nil
+}
+
+// validateTime of JWT allowing clockSkew
+func validateTime(now time.Time, issuedAt int64, expires int64) error {
+	if now.Add(-clockSkew).Unix() > expires {
+		return fmt.Errorf("JWT expired")
+	}
+	if now.Add(clockSkew).Unix() < issuedAt {
+		return fmt.Errorf("JWT issued in the future")
+	}
+	return nil
+}
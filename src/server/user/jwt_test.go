@@ -0,0 +1,270 @@
+package user
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testKeyServer struct {
+	keys map[string]*ecdsa.PrivateKey
+	hits int
+	// block responses until closed
+	block chan struct{}
+	fail  bool
+	mutex sync.Mutex
+}
+
+func (s *testKeyServer) addKey(t *testing.T, kid string) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.mutex.Lock()
+	s.keys[kid] = key
+	s.mutex.Unlock()
+	return key
+}
+
+func (s *testKeyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.hits++
+	if s.fail {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	for kid, key := range s.keys {
+		set.Keys = append(set.Keys, jwk{
+			Kid: kid,
+			Kty: "EC",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+			Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+		})
+	}
+	json.NewEncoder(w).Encode(set)
+}
+
+func signTestJWT(t *testing.T, key *ecdsa.PrivateKey, kid string, claims interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *testKeyServer) getHits() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.hits
+}
+
+func newTestKeySet(t *testing.T) (*testKeyServer, *keySet) {
+	server := &testKeyServer{keys: make(map[string]*ecdsa.PrivateKey)}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	return server, newKeySet(ts.URL)
+}
+
+type testClaims struct {
+	Email string `json:"email"`
+}
+
+func TestVerifyJWT(t *testing.T) {
+	server, keys := newTestKeySet(t)
+	key := server.addKey(t, "key1")
+	ctx := context.Background()
+
+	payload, err := verifyJWT(ctx, keys, signTestJWT(t, key, "key1", testClaims{"user@example.com"}), "ES256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims testClaims
+	json.Unmarshal(payload, &claims)
+	if claims.Email != "user@example.com" {
+		t.Errorf("unexpected payload %s", payload)
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	for name, token := range map[string]string{
+		"invalid signature": signTestJWT(t, other, "key1", testClaims{}),
+		"empty":             "",
+		"two parts":         "a.b",
+		"malformed":         "a.b.c",
+	} {
+		_, err := verifyJWT(ctx, keys, token, "ES256")
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	_, err = verifyJWT(ctx, keys, signTestJWT(t, key, "key1", testClaims{}), "RS256")
+	if err == nil {
+		t.Error("expected unexpected alg error")
+	}
+}
+
+func TestValidateTime(t *testing.T) {
+	now := time.Now()
+	for name, c := range map[string]struct {
+		issuedAt time.Time
+		expires  time.Time
+		valid    bool
+	}{
+		"valid":            {now, now.Add(time.Minute), true},
+		"small skew":       {now.Add(10 * time.Second), now.Add(-10 * time.Second), true},
+		"expired":          {now.Add(-time.Hour), now.Add(-2 * clockSkew), false},
+		"issued in future": {now.Add(2 * clockSkew), now.Add(time.Hour), false},
+	} {
+		err := validateTime(now, c.issuedAt.Unix(), c.expires.Unix())
+		if (err == nil) != c.valid {
+			t.Errorf("%s: unexpected result %v", name, err)
+		}
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	server, keys := newTestKeySet(t)
+	key1 := server.addKey(t, "key1")
+	ctx := context.Background()
+
+	_, err := verifyJWT(ctx, keys, signTestJWT(t, key1, "key1", testClaims{}), "ES256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2 := server.addKey(t, "key2")
+	token := signTestJWT(t, key2, "key2", testClaims{})
+
+	// keys were just fetched, unknown key is rejected without hammering endpoint
+	_, err = verifyJWT(ctx, keys, token, "ES256")
+	if err == nil {
+		t.Fatal("expected unknown key error before refresh interval")
+	}
+	if hits := server.getHits(); hits != 1 {
+		t.Errorf("expected 1 keys fetch, got %d", hits)
+	}
+
+	keys.mutex.Lock()
+	keys.nextFetch = time.Now()
+	keys.mutex.Unlock()
+	_, err = verifyJWT(ctx, keys, token, "ES256")
+	if err != nil {
+		t.Fatalf("expected rotated key to be fetched: %v", err)
+	}
+	if hits := server.getHits(); hits != 2 {
+		t.Errorf("expected 2 keys fetches, got %d", hits)
+	}
+}
+
+func TestKeyFetchErrorBackoff(t *testing.T) {
+	server, keys := newTestKeySet(t)
+	key := server.addKey(t, "key1")
+	server.fail = true
+	token := signTestJWT(t, key, "key1", testClaims{})
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := verifyJWT(ctx, keys, token, "ES256")
+		if err == nil {
+			t.Fatal("expected fetch error")
+		}
+	}
+	if hits := server.getHits(); hits != 1 {
+		t.Errorf("expected single fetch during backoff, got %d", hits)
+	}
+
+	server.mutex.Lock()
+	server.fail = false
+	server.mutex.Unlock()
+	keys.mutex.Lock()
+	keys.nextFetch = time.Now()
+	keys.mutex.Unlock()
+	_, err := verifyJWT(ctx, keys, token, "ES256")
+	if err != nil {
+		t.Fatalf("expected keys fetched after backoff: %v", err)
+	}
+}
+
+func TestKeyFetchDoesNotBlockKnownKeys(t *testing.T) {
+	server, keys := newTestKeySet(t)
+	key1 := server.addKey(t, "key1")
+	ctx := context.Background()
+	known := signTestJWT(t, key1, "key1", testClaims{})
+	_, err := verifyJWT(ctx, keys, known, "ES256")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.block = make(chan struct{})
+	keys.mutex.Lock()
+	keys.nextFetch = time.Now()
+	keys.mutex.Unlock()
+	key2 := server.addKey(t, "key2")
+	rotated := signTestJWT(t, key2, "key2", testClaims{})
+
+	const waiting = 3
+	errs := make(chan error, waiting)
+	for i := 0; i < waiting; i++ {
+		go func() {
+			_, err := verifyJWT(ctx, keys, rotated, "ES256")
+			errs <- err
+		}()
+	}
+	for inFlight := false; !inFlight; {
+		keys.mutex.Lock()
+		inFlight = keys.fetching != nil
+		keys.mutex.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	// keys endpoint hangs, tokens signed with cached key are still validated
+	done := make(chan error)
+	go func() {
+		_, err := verifyJWT(ctx, keys, known, "ES256")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("validation of known key blocked by fetch")
+	}
+
+	// waiting requests give up with their context
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = verifyJWT(short, keys, rotated, "ES256")
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	close(server.block)
+	for i := 0; i < waiting; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("expected rotated key after fetch: %v", err)
+		}
+	}
+	if hits := server.getHits(); hits != 2 {
+		t.Errorf("expected concurrent requests to share fetch, got %d fetches", hits)
+	}
+}
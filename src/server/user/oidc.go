@@ -0,0 +1,410 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/oauth2"
+)
+
+const stateCookieName = "dekart_oidc_state"
+
+const sessionCookieName = "dekart_session"
+
+// sessionCookieMaxAge is absolute session lifetime; server side session expiry slides within it
+const sessionCookieMaxAge = 30 * 24 * time.Hour
+
+// OIDCConfig of OpenID Connect provider
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes requested from provider, openid is always requested
+	Scopes []string
+	// GroupsClaim of ID token with user groups, groups are not read when empty
+	GroupsClaim   string
+	SessionSecret string
+	// SessionTTL is how long session is valid since last request
+	SessionTTL time.Duration
+}
+
+// OIDC login flow with server side sessions; use NewOIDC to init
+type OIDC struct {
+	config   OIDCConfig
+	oauth2   oauth2.Config
+	keys     *keySet
+	sealer   *sealer
+	sessions SessionStore
+	now      func() time.Time
+}
+
+type oidcState struct {
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	ReturnTo string `json:"return_to"`
+}
+
+type idTokenClaims struct {
+	Issuer        string          `json:"iss"`
+	Audience      json.RawMessage `json:"aud"`
+	Expires       int64           `json:"exp"`
+	IssuedAt      int64           `json:"iat"`
+	Nonce         string          `json:"nonce"`
+	Email         string          `json:"email"`
+	EmailVerified *bool           `json:"email_verified"`
+	Groups        []string        `json:"-"`
+}
+
+// defaultScopes when DEKART_OIDC_SCOPES is not set
+var defaultScopes = []string{"openid", "email", "profile"}
+
+func oidcScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		return defaultScopes
+	}
+	for _, scope := range scopes {
+		if scope == "openid" {
+			return scopes
+		}
+	}
+	return append([]string{"openid"}, scopes...)
+}
+
+// parseGroups from claim which is either list or single string
+func parseGroups(payloadJSON []byte, claim string) ([]string, error) {
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(payloadJSON, &payload); err != nil {
+		return nil, err
+	}
+	raw, ok := payload[claim]
+	if !ok {
+		return nil, nil
+	}
+	var groups []string
+	if json.Unmarshal(raw, &groups) == nil {
+		return groups, nil
+	}
+	var group string
+	if err := json.Unmarshal(raw, &group); err != nil {
+		return nil, fmt.Errorf("invalid %s claim: %w", claim, err)
+	}
+	return []string{group}, nil
+}
+
+// NewOIDC discovers provider endpoints from issuer
+func NewOIDC(ctx context.Context, config OIDCConfig, sessions SessionStore) (*OIDC, error) {
+	sealer, err := newSealer(config.SessionSecret)
+	if err != nil {
+		return nil, err
+	}
+	if config.SessionTTL == 0 {
+		config.SessionTTL = 7 * 24 * time.Hour
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(config.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery failed: %s", res.Status)
+	}
+	var discovery struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&discovery)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if discovery.Issuer != config.Issuer {
+		return nil, fmt.Errorf("OIDC issuer mismatch: expected %s, got %s", config.Issuer, discovery.Issuer)
+	}
+	return &OIDC{
+		config: config,
+		oauth2: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthorizationEndpoint,
+				TokenURL: discovery.TokenEndpoint,
+			},
+			Scopes: oidcScopes(config.Scopes),
+		},
+		keys:     newKeySet(discovery.JWKSURI),
+		sealer:   sealer,
+		sessions: sessions,
+		now:      time.Now,
+	}, nil
+}
+
+func codeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func isSecure(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
+
+// safeReturnTo allows only local paths to prevent open redirects
+func safeReturnTo(returnTo string) string {
+	if !strings.HasPrefix(returnTo, "/") || strings.HasPrefix(returnTo, "//") || strings.HasPrefix(returnTo, "/\\") {
+		return "/"
+	}
+	return returnTo
+}
+
+// Login redirects to provider with state, nonce and PKCE challenge
+func (o *OIDC) Login(w http.ResponseWriter, r *http.Request) {
+	state := oidcState{ReturnTo: safeReturnTo(r.URL.Query().Get("return_to"))}
+	var err error
+	for _, v := range []*string{&state.State, &state.Verifier, &state.Nonce} {
+		*v, err = randomString(32)
+		if err != nil {
+			log.Err(err).Send()
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	b, _ := json.Marshal(state)
+	sealed, err := o.sealer.seal(b)
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookieName,
+		Value:    sealed,
+		Path:     "/auth/",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   isSecure(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, o.oauth2.AuthCodeURL(
+		state.State,
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge(state.Verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oauth2.SetAuthURLParam("nonce", state.Nonce),
+	), http.StatusFound)
+}
+
+func audienceContains(raw json.RawMessage, clientID string) bool {
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return single == clientID
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		for _, aud := range list {
+			if aud == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (o *OIDC) validateIDToken(ctx context.Context, token string, nonce string) (*idTokenClaims, error) {
+	payloadJSON, err := verifyJWT(ctx, o.keys, token, "RS256", "ES256")
+	if err != nil {
+		return nil, err
+	}
+	var claims idTokenClaims
+	if err := json.Unmarshal(payloadJSON, &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token: %w", err)
+	}
+	if claims.Issuer != o.config.Issuer {
+		return nil, fmt.Errorf("unexpected ID token issuer %s", claims.Issuer)
+	}
+	if !audienceContains(claims.Audience, o.config.ClientID) {
+		return nil, fmt.Errorf("unexpected ID token audience")
+	}
+	if err := validateTime(o.now(), claims.IssuedAt, claims.Expires); err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("ID token nonce mismatch")
+	}
+	if claims.Email == "" {
+		return nil, fmt.Errorf("no email in ID token")
+	}
+	if claims.EmailVerified != nil && !*claims.EmailVerified {
+		return nil, fmt.Errorf("email %s is not verified", claims.Email)
+	}
+	if o.config.GroupsClaim != "" {
+		claims.Groups, err = parseGroups(payloadJSON, o.config.GroupsClaim)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &claims, nil
+}
+
+// Callback validates state, exchanges code and starts session
+func (o *OIDC) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	cookie, err := r.Cookie(stateCookieName)
+	if err != nil {
+		http.Error(w, "Missing login state", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: stateCookieName, Path: "/auth/", MaxAge: -1})
+	b, err := o.sealer.open(cookie.Value)
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid login state cookie")
+		http.Error(w, "Invalid login state", http.StatusBadRequest)
+		return
+	}
+	var state oidcState
+	if err := json.Unmarshal(b, &state); err != nil {
+		http.Error(w, "Invalid login state", http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		log.Warn().Str("error", providerErr).Str("description", query.Get("error_description")).Msg("OIDC provider error")
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state.State)) != 1 {
+		log.Warn().Msg("OIDC state mismatch")
+		http.Error(w, "Invalid login state", http.StatusBadRequest)
+		return
+	}
+	token, err := o.oauth2.Exchange(ctx, query.Get("code"), oauth2.SetAuthURLParam("code_verifier", state.Verifier))
+	if err != nil {
+		log.Warn().Err(err).Msg("OIDC code exchange failed")
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		log.Warn().Msg("No id_token in token response")
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+	claims, err := o.validateIDToken(ctx, idToken, state.Nonce)
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid ID token")
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+	sessionID, err := randomString(32)
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = o.sessions.Create(ctx, Session{
+		ID:        sessionID,
+		Email:     claims.Email,
+		Groups:    claims.Groups,
+		ExpiresAt: o.now().Add(o.config.SessionTTL),
+	})
+	if err != nil {
+		log.Err(err).Msg("Cannot create session")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sealed, err := o.sealer.seal([]byte(sessionID))
+	if err != nil {
+		log.Err(err).Send()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sealed,
+		Path:     "/",
+		MaxAge:   int(sessionCookieMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   isSecure(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	log.Info().Str("email", claims.Email).Msg("User logged in")
+	http.Redirect(w, r, state.ReturnTo, http.StatusFound)
+}
+
+func (o *OIDC) sessionID(r *http.Request) string {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return ""
+	}
+	b, err := o.sealer.open(cookie.Value)
+	if err != nil {
+		log.Warn().Err(err).Msg("Invalid session cookie")
+		return ""
+	}
+	return string(b)
+}
+
+// Logout revokes session; POST only, so logout can't be triggered by cross-site links and images
+func (o *OIDC) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sessionID := o.sessionID(r)
+	if sessionID != "" {
+		err := o.sessions.Revoke(r.Context(), sessionID)
+		if err != nil {
+			log.Err(err).Msg("Cannot revoke session")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Path: "/", MaxAge: -1})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// claimsFromSession resolves session cookie, extends session expiry; error when session can't be read,
+// so request fails instead of being unauthenticated
+func (o *OIDC) claimsFromSession(r *http.Request) (*Claims, error) {
+	sessionID := o.sessionID(r)
+	if sessionID == "" {
+		return nil, nil
+	}
+	ctx := r.Context()
+	session, err := o.sessions.Get(ctx, sessionID)
+	if err != nil {
+		log.Err(err).Msg("Cannot get session")
+		return nil, err
+	}
+	if session == nil {
+		return nil, nil
+	}
+	now := o.now()
+	if session.ExpiresAt.Before(now) {
+		return nil, nil
+	}
+	if session.ExpiresAt.Sub(now) < o.config.SessionTTL/2 {
+		err := o.sessions.Extend(ctx, sessionID, now.Add(o.config.SessionTTL))
+		if err != nil {
+			log.Err(err).Msg("Cannot extend session")
+		}
+	}
+	return &Claims{
+		Email:  session.Email,
+		Groups: session.Groups,
+	}, nil
+}
@@ -0,0 +1,409 @@
+package user
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testClientID = "dekart-client"
+
+type memorySessionStore struct {
+	sessions map[string]*Session
+	revoked  map[string]bool
+	mutex    sync.Mutex
+	// err of Get, e.g. session which can't be decrypted
+	err error
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{
+		sessions: make(map[string]*Session),
+		revoked:  make(map[string]bool),
+	}
+}
+
+func (m *memorySessionStore) Create(ctx context.Context, session Session) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sessions[session.ID] = &session
+	return nil
+}
+
+func (m *memorySessionStore) Get(ctx context.Context, id string) (*Session, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	session, ok := m.sessions[id]
+	if !ok || m.revoked[id] {
+		return nil, nil
+	}
+	s := *session
+	return &s, nil
+}
+
+func (m *memorySessionStore) Extend(ctx context.Context, id string, expiresAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if session, ok := m.sessions[id]; ok {
+		session.ExpiresAt = expiresAt
+	}
+	return nil
+}
+
+func (m *memorySessionStore) Revoke(ctx context.Context, id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.revoked[id] = true
+	return nil
+}
+
+// testProvider is minimal OIDC provider issuing RS256 ID tokens
+type testProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	mutex    sync.Mutex
+	codes    map[string]url.Values
+	exchange int
+	scope    string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &testProvider{key: key, codes: make(map[string]url.Values)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string][]jwk{"keys": {{
+			Kid: "rsa1",
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		code, _ := randomString(16)
+		p.mutex.Lock()
+		p.codes[code] = query
+		p.scope = query.Get("scope")
+		p.mutex.Unlock()
+		redirect, _ := url.Parse(query.Get("redirect_uri"))
+		q := redirect.Query()
+		q.Set("code", code)
+		q.Set("state", query.Get("state"))
+		redirect.RawQuery = q.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		p.mutex.Lock()
+		p.exchange++
+		authorize, ok := p.codes[r.Form.Get("code")]
+		delete(p.codes, r.Form.Get("code"))
+		p.mutex.Unlock()
+		if !ok || codeChallenge(r.Form.Get("code_verifier")) != authorize.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		now := time.Now()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token": p.sign(t, map[string]interface{}{
+				"iss":            p.server.URL,
+				"aud":            []string{testClientID},
+				"iat":            now.Unix(),
+				"exp":            now.Add(time.Hour).Unix(),
+				"nonce":          authorize.Get("nonce"),
+				"email":          "user@example.com",
+				"email_verified": true,
+				"groups":         []string{"ignored"},
+				"dekart_groups":  []string{"analysts"},
+			}),
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *testProvider) sign(t *testing.T, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "rsa1", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestOIDC(t *testing.T) (*testProvider, *OIDC, *memorySessionStore, *httptest.Server) {
+	provider := newTestProvider(t)
+	sessions := newMemorySessionStore()
+	mux := http.NewServeMux()
+	app := httptest.NewServer(mux)
+	oidc, err := NewOIDC(context.Background(), OIDCConfig{
+		Issuer:        provider.server.URL,
+		ClientID:      testClientID,
+		ClientSecret:  "secret",
+		RedirectURL:   app.URL + "/auth/callback",
+		Scopes:        []string{"email", "groups"},
+		GroupsClaim:   "dekart_groups",
+		SessionSecret: "session secret",
+		SessionTTL:    time.Hour,
+	}, sessions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimsCheck := NewClaimsCheck(ClaimsCheckConfig{OIDC: oidc})
+	mux.HandleFunc("/auth/login", oidc.Login)
+	mux.HandleFunc("/auth/callback", oidc.Callback)
+	mux.HandleFunc("/auth/logout", oidc.Logout)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		ctx := claimsCheck.GetContext(r)
+		if ClaimsError(ctx) != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		claims := GetClaims(ctx)
+		if claims == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(claims)
+	})
+	return provider, oidc, sessions, app
+}
+
+func getClaims(t *testing.T, client *http.Client, url string) *Claims {
+	res, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusUnauthorized {
+		return nil
+	}
+	var claims Claims
+	if err := json.NewDecoder(res.Body).Decode(&claims); err != nil {
+		t.Fatal(err)
+	}
+	return &claims
+}
+
+func TestOIDCLoginFlow(t *testing.T) {
+	provider, oidc, sessions, app := newTestOIDC(t)
+	defer provider.server.Close()
+	defer app.Close()
+
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{Jar: jar}
+	if getClaims(t, client, app.URL+"/") != nil {
+		t.Fatal("expected no claims before login")
+	}
+
+	res, err := client.Get(app.URL + "/auth/login?return_to=/reports/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.Request.URL.Path != "/reports/1" {
+		t.Errorf("expected redirect to return_to, got %s", res.Request.URL.Path)
+	}
+	claims := getClaims(t, client, app.URL+"/")
+	if claims == nil || claims.Email != "user@example.com" {
+		t.Fatalf("expected claims after login, got %+v", claims)
+	}
+	if len(claims.Groups) != 1 || claims.Groups[0] != "analysts" {
+		t.Errorf("expected groups from ID token, got %v", claims.Groups)
+	}
+
+	t.Run("sliding expiry", func(t *testing.T) {
+		var session *Session
+		for _, s := range sessions.sessions {
+			session = s
+		}
+		expiresAt := time.Now().Add(oidc.config.SessionTTL / 4)
+		sessions.Extend(context.Background(), session.ID, expiresAt)
+		getClaims(t, client, app.URL+"/")
+		s, _ := sessions.Get(context.Background(), session.ID)
+		if !s.ExpiresAt.After(expiresAt) {
+			t.Error("expected session expiry to be extended")
+		}
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		provider.mutex.Lock()
+		defer provider.mutex.Unlock()
+		if provider.scope != "openid email groups" {
+			t.Errorf("expected configured scopes with openid, got %q", provider.scope)
+		}
+	})
+
+	t.Run("session store error", func(t *testing.T) {
+		sessions.mutex.Lock()
+		sessions.err = fmt.Errorf("cannot decrypt value of key k1")
+		sessions.mutex.Unlock()
+		defer func() {
+			sessions.mutex.Lock()
+			sessions.err = nil
+			sessions.mutex.Unlock()
+		}()
+		// request fails instead of being unauthenticated
+		res, err := client.Get(app.URL + "/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", res.StatusCode)
+		}
+	})
+
+	t.Run("logout requires POST", func(t *testing.T) {
+		res, err := client.Get(app.URL + "/auth/logout")
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("expected 405, got %d", res.StatusCode)
+		}
+		if getClaims(t, client, app.URL+"/") == nil {
+			t.Error("expected session to stay after GET logout")
+		}
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		cookies := jar.Cookies(res.Request.URL)
+		res, err := client.Post(app.URL+"/auth/logout", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if getClaims(t, client, app.URL+"/") != nil {
+			t.Error("expected no claims after logout")
+		}
+		// replayed cookie is rejected because session is revoked server side
+		replay, _ := cookiejar.New(nil)
+		u, _ := url.Parse(app.URL)
+		replay.SetCookies(u, cookies)
+		if getClaims(t, &http.Client{Jar: replay}, app.URL+"/") != nil {
+			t.Error("expected revoked session cookie to be rejected")
+		}
+	})
+}
+
+func TestOIDCCallbackStateMismatch(t *testing.T) {
+	provider, _, sessions, app := newTestOIDC(t)
+	defer provider.server.Close()
+	defer app.Close()
+
+	jar, _ := cookiejar.New(nil)
+	client := &http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	res, err := client.Get(app.URL + "/auth/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	authorize, _ := url.Parse(res.Header.Get("Location"))
+	if authorize.Query().Get("code_challenge_method") != "S256" || authorize.Query().Get("nonce") == "" {
+		t.Errorf("expected PKCE and nonce in authorization request: %s", authorize)
+	}
+
+	res, err = client.Get(app.URL + "/auth/callback?code=any&state=forged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 on state mismatch, got %d", res.StatusCode)
+	}
+	if provider.exchange != 0 {
+		t.Error("expected no code exchange on state mismatch")
+	}
+	if len(sessions.sessions) != 0 {
+		t.Error("expected no session on state mismatch")
+	}
+
+	res, err = (&http.Client{}).Get(app.URL + "/auth/callback?code=any&state=any")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 without state cookie, got %d", res.StatusCode)
+	}
+}
+
+func TestParseGroups(t *testing.T) {
+	for payload, expected := range map[string][]string{
+		`{"roles":["a","b"]}`: {"a", "b"},
+		`{"roles":"a"}`:       {"a"},
+		`{"groups":["a"]}`:    nil,
+	} {
+		groups, err := parseGroups([]byte(payload), "roles")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(groups) != fmt.Sprint(expected) {
+			t.Errorf("%s: expected %v, got %v", payload, expected, groups)
+		}
+	}
+	_, err := parseGroups([]byte(`{"roles":1}`), "roles")
+	if err == nil {
+		t.Error("expected invalid claim error")
+	}
+	if scopes := oidcScopes(nil); len(scopes) != 3 || scopes[0] != "openid" {
+		t.Errorf("unexpected default scopes %v", scopes)
+	}
+}
+
+func TestSafeReturnTo(t *testing.T) {
+	for returnTo, expected := range map[string]string{
+		"/reports/1":       "/reports/1",
+		"":                 "/",
+		"https://evil.com": "/",
+		"//evil.com":       "/",
+		"/\\evil.com":      "/",
+		"reports/relative": "/",
+	} {
+		if got := safeReturnTo(returnTo); got != expected {
+			t.Errorf("%q: expected %q, got %q", returnTo, expected, got)
+		}
+	}
+}
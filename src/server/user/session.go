@@ -0,0 +1,82 @@
+package user
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Session of user logged in with OIDC
+type Session struct {
+	ID        string
+	Email     string
+	Groups    []string
+	ExpiresAt time.Time
+}
+
+// SessionStore keeps sessions server side so they can be revoked
+type SessionStore interface {
+	// Create new session
+	Create(ctx context.Context, session Session) error
+	// Get session which is not revoked and not expired; nil when not found
+	Get(ctx context.Context, id string) (*Session, error)
+	// Extend session expiry
+	Extend(ctx context.Context, id string, expiresAt time.Time) error
+	// Revoke session
+	Revoke(ctx context.Context, id string) error
+}
+
+// sealer encrypts cookie values with AES-GCM
+type sealer struct {
+	aead cipher.AEAD
+}
+
+// newSealer with key derived from secret
+func newSealer(secret string) (*sealer, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("session secret is required")
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &sealer{aead: aead}, nil
+}
+
+func (s *sealer) seal(plaintext []byte) (string, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func (s *sealer) open(value string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("sealed value too short")
+	}
+	return s.aead.Open(nil, b[:s.aead.NonceSize()], b[s.aead.NonceSize():], nil)
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}